@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/techie2000/axiom/internal/app"
+	"github.com/techie2000/axiom/internal/breaker"
+	"github.com/techie2000/axiom/internal/dbmigrate"
+	"github.com/techie2000/axiom/internal/events"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"github.com/techie2000/axiom/pkg/logger"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// newDatabaseComponent verifies connectivity on start, reports readiness via ping and closes the pool last
+func newDatabaseComponent(db *gorm.DB) app.Component {
+	ping := func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+
+	c := app.NewComponent("database", ping, func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	})
+	return app.WithReadiness(c, ping)
+}
+
+// newReadReplicasComponent pings the read replicas every interval, keeping those that do not
+// answer out of rotation; stop closes their pools. Unreachable replicas do not fail readiness, as
+// reads fall back to the primary.
+func newReadReplicasComponent(replicas *repository.ReadReplicas, interval time.Duration) app.Component {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	return app.NewComponent("read-replicas",
+		func(ctx context.Context) error {
+			total, _ := replicas.Count()
+			if total == 0 {
+				close(done)
+				return nil
+			}
+			replicas.Check(ctx)
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-stop:
+						return
+					case <-ticker.C:
+						checkCtx, cancel := context.WithTimeout(context.Background(), interval)
+						replicas.Check(checkCtx)
+						cancel()
+					}
+				}
+			}()
+			return nil
+		},
+		func(ctx context.Context) error {
+			close(stop)
+			select {
+			case <-done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return replicas.Close()
+		},
+	)
+}
+
+// newMigrationsCheck reports not ready while the database lags the migrations, or a migration
+// failed halfway. golang-migrate records the applied version in schema_migrations.
+func newMigrationsCheck(db *gorm.DB, dir string) app.Component {
+	c := app.NewComponent("migrations", nil, nil)
+	migrations, err := dbmigrate.List(dbmigrate.Source(dir))
+	if err != nil {
+		logger.Warn().Err(err).Str("dir", dir).Msg("Pending migrations are not checked")
+		return c
+	}
+	latest := migrations[len(migrations)-1].Version
+
+	return app.WithReadiness(c, func(ctx context.Context) error {
+		var state struct {
+			Version uint
+			Dirty   bool
+		}
+		if err := db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&state).Error; err != nil {
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		return dbmigrate.Check(state.Version, state.Dirty, latest)
+	})
+}
+
+// newDataDirCheck reports not ready when files cannot be written to the LEI data directory, where
+// downloads land before they are stored
+func newDataDirCheck(dir string) app.Component {
+	return app.WithReadiness(app.NewComponent("lei-data-dir", nil, nil), func(ctx context.Context) error {
+		file, err := os.CreateTemp(dir, ".ready-*")
+		if err != nil {
+			return fmt.Errorf("%s is not writable: %w", dir, err)
+		}
+		file.Close()
+		return os.Remove(file.Name())
+	})
+}
+
+// newGLEIFCheck reports GLEIF as degraded while its circuit breaker is open: syncs, refreshes and
+// on-demand fetches fail fast, but the API serves everything stored
+func newGLEIFCheck(gleif *breaker.Breaker) app.Component {
+	return app.WithReadiness(app.NewComponent("gleif", nil, nil), func(ctx context.Context) error {
+		return app.Degraded(gleif.Err())
+	})
+}
+
+// newEventRelayComponent relays change events from the outbox; stop finishes the batch in flight
+// and closes the broker connection
+func newEventRelayComponent(relay service.EventRelay, publisher events.Publisher) app.Component {
+	return app.NewComponent("event-relay",
+		func(ctx context.Context) error {
+			relay.Start()
+			return nil
+		},
+		func(ctx context.Context) error {
+			err := relay.Stop(ctx)
+			if closeErr := publisher.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		},
+	)
+}
+
+// newSchedulerComponent runs the LEI scheduler. Stop starts no more runs and interrupts the source
+// files being processed, which checkpoint and resume on the next start, then waits for the running
+// job to wind up, both up to the shutdown deadline.
+func newSchedulerComponent(scheduler service.SchedulerService, leiService service.LEIService) app.Component {
+	return app.NewComponent("scheduler",
+		func(ctx context.Context) error {
+			return scheduler.Start()
+		},
+		func(ctx context.Context) error {
+			scheduler.Stop()
+			err := leiService.StopProcessing(ctx)
+			if waitErr := scheduler.Wait(ctx); err == nil {
+				err = waitErr
+			}
+			return err
+		},
+	)
+}
+
+// newDataImportWorkerComponent runs the workers that process queued imports; stop waits for the
+// row in progress up to the shutdown deadline
+func newDataImportWorkerComponent(imports service.DataImportService) app.Component {
+	return app.NewComponent("data-import-workers",
+		func(ctx context.Context) error {
+			imports.StartWorkers()
+			return nil
+		},
+		imports.StopWorkers,
+	)
+}
+
+// newLEIRefreshWorkerComponent stops the on-demand GLEIF refresh worker (it starts lazily on first use)
+func newLEIRefreshWorkerComponent(leiService service.LEIService) app.Component {
+	return app.NewComponent("lei-refresh-worker", nil, leiService.StopRefreshWorker)
+}
+
+// newHTTPServerComponent binds the listener synchronously (so port conflicts fail startup) and serves in the background
+func newHTTPServerComponent(srv *http.Server) app.Component {
+	return app.NewComponent("http-server",
+		func(ctx context.Context) error {
+			ln, err := net.Listen("tcp", srv.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", srv.Addr, err)
+			}
+			go func() {
+				serve := srv.Serve
+				if srv.TLSConfig != nil {
+					// The certificates come from TLSConfig.GetCertificate, not files
+					serve = func(ln net.Listener) error { return srv.ServeTLS(ln, "", "") }
+					logger.Info().Msgf("Starting Axiom API server on %s (HTTPS)", srv.Addr)
+				} else {
+					logger.Info().Msgf("Starting Axiom API server on %s", srv.Addr)
+				}
+				if err := serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error().Err(err).Msg("HTTP server stopped unexpectedly")
+				}
+			}()
+			return nil
+		},
+		srv.Shutdown,
+	)
+}
+
+// newGRPCServerComponent binds the gRPC listener synchronously, like the HTTP server, and serves in
+// the background. Stop lets calls in flight finish up to the shutdown deadline, then cuts them off.
+func newGRPCServerComponent(server *grpc.Server, addr string) app.Component {
+	return app.NewComponent("grpc-server",
+		func(ctx context.Context) error {
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", addr, err)
+			}
+			go func() {
+				logger.Info().Msgf("Starting Axiom gRPC server on %s", addr)
+				if err := server.Serve(ln); err != nil {
+					logger.Error().Err(err).Msg("gRPC server stopped unexpectedly")
+				}
+			}()
+			return nil
+		},
+		func(ctx context.Context) error {
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/fieldcrypt"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+const encryptionUsage = `usage: encryption <command>
+
+  newkey ID     print a new key encryption key, to add to encryption.keys
+  rotate        encrypt SSI beneficiary accounts still in the clear and re-wrap the data keys of
+                the others under the primary (first) key, so the keys after it can be retired`
+
+// runEncryption runs "encryption <command>" with the configured keys
+func runEncryption(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(encryptionUsage)
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "newkey":
+		if len(args) == 0 {
+			return errors.New("newkey takes the ID of the key, e.g. the month it is introduced")
+		}
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return err
+		}
+		id := args[0] + ":" + base64.StdEncoding.EncodeToString(key)
+		if _, _, err := fieldcrypt.ParseKey(id); err != nil {
+			return err
+		}
+		fmt.Println(id)
+		return nil
+
+	case "rotate":
+		cipher, err := fieldcrypt.FromConfig(cfg.Encryption)
+		if err != nil {
+			return err
+		}
+		if cipher == nil {
+			return errors.New("encryption.provider is none; there is no key to rotate to")
+		}
+		fieldcrypt.Use(cipher)
+
+		db, err := connectDatabase(cfg)
+		if err != nil {
+			return err
+		}
+		result, err := repository.NewSSIRepository(db, false).ReencryptAccounts(cipher)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rewrote %d SSI(s) and %d SSI version(s) under key %s\n", result.SSIs, result.Versions, cipher.Primary())
+		return nil
+	}
+	return fmt.Errorf("unknown encryption command %q\n%s", command, encryptionUsage)
+}
@@ -5,18 +5,33 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/techie2000/axiom/internal/app"
+	"github.com/techie2000/axiom/internal/cache"
 	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/errreport"
+	"github.com/techie2000/axiom/internal/events"
+	"github.com/techie2000/axiom/internal/fieldcrypt"
+	"github.com/techie2000/axiom/internal/grpcserver"
 	"github.com/techie2000/axiom/internal/handler"
+	handlerv2 "github.com/techie2000/axiom/internal/handler/v2"
+	"github.com/techie2000/axiom/internal/jsonfmt"
 	"github.com/techie2000/axiom/internal/middleware"
+	"github.com/techie2000/axiom/internal/ratelimit"
 	"github.com/techie2000/axiom/internal/repository"
 	"github.com/techie2000/axiom/internal/service"
+	"github.com/techie2000/axiom/internal/storage"
+	"github.com/techie2000/axiom/internal/tlscert"
 	"github.com/techie2000/axiom/internal/version"
 	"github.com/techie2000/axiom/pkg/logger"
 	"gorm.io/driver/postgres"
@@ -57,6 +72,41 @@ func main() {
 
 	// Initialize logger
 	logger.Init(cfg.Log.Level)
+	logger.Info().Str("env", cfg.Env).Strs("files", config.Files()).Msg("Configuration loaded")
+
+	// "migrate <command>" manages the schema and "encryption <command>" the keys of encrypted
+	// fields, instead of serving
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			if err := runMigrate(cfg, os.Args[2:]); err != nil {
+				log.Fatalf("Migrate: %v", err)
+			}
+		case "encryption":
+			if err := runEncryption(cfg, os.Args[2:]); err != nil {
+				log.Fatalf("Encryption: %v", err)
+			}
+		default:
+			log.Fatalf("Unknown command %q; the commands are migrate and encryption", os.Args[1])
+		}
+		return
+	}
+
+	// Report panics, 5xx responses and failed jobs to the error tracker, if one is configured;
+	// reports are filed under the APP_ENV profile, or the server mode without one
+	environment := cfg.Server.Mode
+	if cfg.Env != "" {
+		environment = cfg.Env
+	}
+	if err := errreport.Init(cfg.Sentry, environment); err != nil {
+		log.Fatalf("Failed to initialize error reporting: %v", err)
+	}
+	defer errreport.Flush(5 * time.Second)
+
+	// Apply pending migrations if configured, and refuse to start on a half-applied one
+	if err := checkMigrations(cfg.Database); err != nil {
+		log.Fatalf("Database migrations: %v", err)
+	}
 
 	// Connect to database
 	db, err := connectDatabase(cfg)
@@ -64,8 +114,39 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	// Initialize repositories
-	repos := repository.NewRepositories(db)
+	// Read replicas for the heavy LEI reads, if configured
+	readReplicas, err := connectReadReplicas(cfg, db)
+	if err != nil {
+		log.Fatalf("Failed to connect to read replicas: %v", err)
+	}
+
+	// Record-change events for downstream consumers (settlement, risk); connects on first publish
+	publisher, err := events.NewPublisher(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize event publisher: %v", err)
+	}
+
+	// Encryption of sensitive SSI fields at rest, if configured; applied as records are read and written
+	fieldCipher, err := fieldcrypt.FromConfig(cfg.Encryption)
+	if err != nil {
+		log.Fatalf("Failed to initialize field encryption: %v", err)
+	}
+	fieldcrypt.Use(fieldCipher)
+
+	// Cache for LEI lookups and reference lists, if configured
+	lookupCache, err := cache.New(cfg.Cache, cfg.Redis)
+	if err != nil {
+		log.Fatalf("Failed to initialize cache: %v", err)
+	}
+
+	// Initialize repositories; change events go to the outbox only when something will publish them
+	repos := repository.NewRepositories(db, repository.Options{
+		LEIAuditMode: cfg.LEI.AuditMode,
+		ChangeEvents: publisher != events.Discard,
+		ReadReplicas: readReplicas,
+		Cache:        lookupCache,
+		CacheTTL:     repository.CacheTTL{LEI: cfg.Cache.LEITTL, Reference: cfg.Cache.ReferenceTTL},
+	})
 
 	// LEI data directory from config
 	leiDataDir := cfg.LEI.DataDir
@@ -73,25 +154,64 @@ func main() {
 		log.Fatalf("Failed to create LEI data directory: %v", err)
 	}
 
+	// LEI file storage: the data directory itself, or an object store with the directory as scratch space
+	leiStorage, err := storage.New(cfg.Storage, leiDataDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize LEI file storage: %v", err)
+	}
+	log.Printf("LEI files stored in %s storage", leiStorage.Backend())
+
+	// Archive bucket for S3 exports and archived LEI files, if configured
+	archive, err := storage.NewArchive(cfg.Archive)
+	if err != nil {
+		log.Fatalf("Failed to initialize archive: %v", err)
+	}
+
+	// Rate limit buckets, per replica or shared through Redis; the rules can be reloaded
+	rateLimitRules := ratelimit.NewRules(cfg.RateLimit)
+	var rateLimits ratelimit.Store
+	if cfg.RateLimit.Enabled {
+		rateLimits, err = ratelimit.NewStore(cfg.RateLimit, cfg.Redis)
+		if err != nil {
+			log.Fatalf("Failed to initialize rate limiting: %v", err)
+		}
+	}
+
 	// Initialize services
-	services := service.NewServices(repos, leiDataDir)
+	services := service.NewServices(repos, cfg, leiStorage, archive)
+	eventRelay := service.NewEventRelay(repos.Outbox, repository.NewLeaderLock(db, repository.OutboxRelayLockKey), publisher, services.DeadLetter, cfg.Events)
 
-	// Initialize scheduler service for LEI data acquisition (with config for schedules)
-	schedulerService := service.NewSchedulerService(services.LEI, cfg)
+	// Flag model/schema drift before it surfaces as failed imports
+	if cfg.Database.SchemaDriftCheck {
+		logSchemaDrift(services.SchemaDrift)
+	}
+
+	// Add the ISO 3166 countries and ISO 4217 currencies missing from the bundled datasets
+	if cfg.ReferenceData.SeedCountries {
+		seedCountries(services.Country)
+	}
+	if cfg.ReferenceData.SeedCurrencies {
+		seedCurrencies(services.Currency)
+	}
 
-	// Start scheduler
-	if err := schedulerService.Start(); err != nil {
-		log.Fatalf("Failed to start scheduler: %v", err)
+	// Initialize scheduler service for LEI data acquisition (with config for schedules); with leader
+	// election, only the replica holding the advisory lock runs scheduled jobs
+	var leaderLock repository.LeaderLock
+	if cfg.Scheduler.LeaderElection {
+		leaderLock = repository.NewLeaderLock(db, cfg.Scheduler.LeaderLockKey)
 	}
-	defer schedulerService.Stop()
+	schedulerService := service.NewSchedulerService(services.LEI, services.Export, services.Retention, services.Renewals, services.Exchange, services.Country, services.Currency, services.ELF, services.RA, services.LOU, services.FX, services.Jobs, leaderLock, cfg)
 
 	// Initialize handlers
 	handlers := handler.NewHandlers(services, schedulerService)
+	handlersV2 := handlerv2.NewHandlers(services)
+
+	// Application container: components start in registration order and stop in reverse
+	application := app.New()
 
 	// Setup Gin router
-	router := setupRouter(cfg, handlers)
+	router := setupRouter(cfg, handlers, handlersV2, application, rateLimits, rateLimitRules)
 
-	// Start server
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
 		Handler:      router,
@@ -99,14 +219,35 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
-
-	// Start server in goroutine
-	go func() {
-		logger.Info().Msgf("Starting Axiom API server on port %d", cfg.Server.Port)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+	if cfg.Server.TLS.Enabled {
+		srv.TLSConfig, err = tlscert.NewConfig(cfg.Server.TLS)
+		if err != nil {
+			log.Fatalf("Failed to configure TLS: %v", err)
 		}
-	}()
+	}
+
+	application.Register(
+		newDatabaseComponent(db),
+		newReadReplicasComponent(readReplicas, cfg.Database.ReplicaCheckInterval),
+		newMigrationsCheck(db, cfg.Database.MigrationsDir),
+		newDataDirCheck(cfg.LEI.DataDir),
+		newGLEIFCheck(services.GLEIF),
+		newEventRelayComponent(eventRelay, publisher),
+		newSchedulerComponent(schedulerService, services.LEI),
+		newLEIRefreshWorkerComponent(services.LEI),
+		newDataImportWorkerComponent(services.DataImport),
+		newHTTPServerComponent(srv),
+	)
+	if cfg.GRPC.Enabled {
+		application.Register(newGRPCServerComponent(grpcserver.New(cfg, services, srv.TLSConfig), fmt.Sprintf(":%d", cfg.GRPC.Port)))
+	}
+
+	if err := application.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start application: %v", err)
+	}
+
+	// Apply changes to the config file that can take effect without a restart
+	watchConfig(schedulerService, rateLimitRules)
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
@@ -115,32 +256,146 @@ func main() {
 
 	logger.Info().Msg("Shutting down server...")
 
-	// Graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Graceful shutdown: stop accepting requests, then workers and scheduler (interrupting file
+	// processing at its next checkpoint), then the database
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	if err := application.Stop(ctx); err != nil {
+		logger.Error().Err(err).Msg("Shutdown completed with errors")
+		errreport.Flush(5 * time.Second)
+		os.Exit(1)
 	}
 
 	logger.Info().Msg("Server exited")
 }
 
-func connectDatabase(cfg *config.Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Name,
-		cfg.Database.SSLMode,
+// watchConfig applies saved changes of the config file to the log level, the scheduler's job
+// times, intervals and file retention counts, and the rate limit rules. Other settings (connections,
+// credentials, URLs, enabling rate limiting) still need a restart.
+func watchConfig(scheduler service.SchedulerService, rateLimitRules *ratelimit.Rules) {
+	watching := config.Watch(func(cfg *config.Config) {
+		logger.SetLevel(cfg.Log.Level)
+		scheduler.Reload(cfg)
+		rateLimitRules.Set(cfg.RateLimit)
+		logger.Info().Msg("Configuration reloaded")
+	}, func(err error) {
+		logger.Error().Err(err).Msg("Config file change not applied, keeping the running configuration")
+	})
+	if watching {
+		logger.Info().Strs("files", config.Files()).Msg("Watching the config files for changes")
+	}
+}
+
+// logSchemaDrift logs the schema drift report; drift never blocks startup
+func logSchemaDrift(drift service.SchemaDriftService) {
+	report, err := drift.Report()
+	if err != nil {
+		logger.Warn().Err(err).Msg("Schema drift check failed")
+		return
+	}
+
+	for _, issue := range report.Issues {
+		switch issue.Severity {
+		case domain.SchemaDriftSeverityError:
+			logger.Error().Str("table", issue.Table).Str("column", issue.Column).Str("kind", issue.Kind).Msg(issue.Message)
+		case domain.SchemaDriftSeverityWarning:
+			logger.Warn().Str("table", issue.Table).Str("column", issue.Column).Str("kind", issue.Kind).Msg(issue.Message)
+		}
+	}
+	logger.Info().
+		Int("tables", report.TablesChecked).
+		Int("errors", report.Errors).
+		Int("warnings", report.Warnings).
+		Msg("Schema drift check complete (details: GET /api/v1/admin/schema-drift)")
+}
+
+func seedCountries(countries service.CountryService) {
+	result, err := countries.Seed(context.Background())
+	if err != nil {
+		logger.Warn().Err(err).Msg("Country seeding failed")
+		return
+	}
+	logger.Info().
+		Int("created", result.Created).
+		Int("rejected", result.RejectedCount).
+		Msg("Countries seeded from the bundled ISO 3166 dataset")
+}
+
+func seedCurrencies(currencies service.CurrencyService) {
+	result, err := currencies.Seed(context.Background())
+	if err != nil {
+		logger.Warn().Err(err).Msg("Currency seeding failed")
+		return
+	}
+	logger.Info().
+		Int("created", result.Created).
+		Int("rejected", result.RejectedCount).
+		Msg("Currencies seeded from the bundled ISO 4217 dataset")
+}
+
+// databaseDSN returns the connection string for the configured database
+func databaseDSN(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s default_query_exec_mode=%s statement_cache_capacity=%d",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+		cfg.SSLMode,
+		strings.ToLower(cfg.QueryExecMode),
+		cfg.StatementCacheCapacity,
 	)
+}
+
+// replicaDSN adds the configured query mode to a replica's connection string, a postgres:// URL or
+// key=value pairs, unless it sets its own
+func replicaDSN(dsn string, cfg config.DatabaseConfig) (string, error) {
+	params := map[string]string{
+		"default_query_exec_mode":  strings.ToLower(cfg.QueryExecMode),
+		"statement_cache_capacity": strconv.Itoa(cfg.StatementCacheCapacity),
+	}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", err
+		}
+		query := u.Query()
+		for key, value := range params {
+			if !query.Has(key) {
+				query.Set(key, value)
+			}
+		}
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+	for key, value := range params {
+		if !strings.Contains(dsn, key+"=") {
+			dsn += " " + key + "=" + value
+		}
+	}
+	return dsn, nil
+}
+
+// configurePool applies the connection pool settings to a database's pool
+func configurePool(db *gorm.DB, cfg config.DatabaseConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return nil
+}
+
+func connectDatabase(cfg *config.Config) (*gorm.DB, error) {
+	dsn := databaseDSN(cfg.Database)
 
 	// Configure GORM logger based on DATABASE_LOGLEVEL
-	logLevel := parseGORMLogLevel(cfg.Database.LogLevel)
-	customLogger := newCustomGORMLogger(logLevel)
 	gormConfig := &gorm.Config{
-		Logger: customLogger,
+		Logger: logger.NewGORM(parseGORMLogLevel(cfg.Database.LogLevel)),
 	}
 
 	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
@@ -148,20 +403,47 @@ func connectDatabase(cfg *config.Config) (*gorm.DB, error) {
 		return nil, err
 	}
 
-	sqlDB, err := db.DB()
-	if err != nil {
+	if err := configurePool(db, cfg.Database); err != nil {
 		return nil, err
 	}
 
-	// Connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
-
-	logger.Info().Msgf("Database connection established (log level: %s)", cfg.Database.LogLevel)
+	logger.Info().Msgf("Database connection established (log level: %s, query mode: %s)", cfg.Database.LogLevel, cfg.Database.QueryExecMode)
 	return db, nil
 }
 
+// connectReadReplicas opens a pool for each of database.replicas, configured like the primary's.
+// Replicas are not pinged here: one that is down is skipped until it answers, rather than keeping
+// the server from starting.
+func connectReadReplicas(cfg *config.Config, primary *gorm.DB) (*repository.ReadReplicas, error) {
+	replicas := map[string]*gorm.DB{}
+	for i, dsn := range cfg.Database.Replicas {
+		pgConfig, err := pgconn.ParseConfig(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("database.replicas[%d]: %w", i, err)
+		}
+		name := fmt.Sprintf("%s:%d/%s", pgConfig.Host, pgConfig.Port, pgConfig.Database)
+		if dsn, err = replicaDSN(dsn, cfg.Database); err != nil {
+			return nil, fmt.Errorf("database.replicas[%d]: %w", i, err)
+		}
+
+		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+			Logger:               logger.NewGORM(parseGORMLogLevel(cfg.Database.LogLevel)),
+			DisableAutomaticPing: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("replica %s: %w", name, err)
+		}
+		if err := configurePool(db, cfg.Database); err != nil {
+			return nil, err
+		}
+		replicas[name] = db
+	}
+	if len(replicas) > 0 {
+		logger.Info().Int("replicas", len(replicas)).Msg("LEI lists, searches and exports read from replicas")
+	}
+	return repository.NewReadReplicas(primary, replicas), nil
+}
+
 func parseGORMLogLevel(level string) gormLogger.LogLevel {
 	switch strings.ToLower(level) {
 	case "silent":
@@ -177,59 +459,55 @@ func parseGORMLogLevel(level string) gormLogger.LogLevel {
 	}
 }
 
-// customGORMLogger wraps the default GORM logger to suppress "record not found" errors
-type customGORMLogger struct {
-	gormLogger.Interface
-	logLevel gormLogger.LogLevel
-}
-
-func newCustomGORMLogger(level gormLogger.LogLevel) *customGORMLogger {
-	return &customGORMLogger{
-		Interface: gormLogger.Default.LogMode(level),
-		logLevel:  level,
-	}
-}
-
-// Error overrides the Error method to suppress "record not found" logs
-func (l *customGORMLogger) Error(ctx context.Context, msg string, data ...interface{}) {
-	// Suppress "record not found" errors as they're expected during upsert operations
-	if l.logLevel >= gormLogger.Error {
-		if !strings.Contains(msg, "record not found") {
-			l.Interface.Error(ctx, msg, data...)
-		}
-	}
-}
-
-// Trace overrides the Trace method to suppress "record not found" query logs
-func (l *customGORMLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
-	// Suppress trace logs for "record not found" errors
-	if err != nil && err.Error() == "record not found" {
-		return
-	}
-	l.Interface.Trace(ctx, begin, fc, err)
-}
-
-func setupRouter(cfg *config.Config, h *handler.Handlers) *gin.Engine {
+func setupRouter(cfg *config.Config, h *handler.Handlers, h2 *handlerv2.Handlers, application *app.App, rateLimits ratelimit.Store, rateLimitRules *ratelimit.Rules) *gin.Engine {
 	if cfg.Server.Mode == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
+	// Client IPs, which anonymous requests are rate limited by, come from X-Forwarded-For only
+	// when set by a trusted proxy
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid server.trustedproxies: %v", err)
+	}
 
 	// Global middleware
-	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.APIVersion())
 	router.Use(middleware.Logger())
+	router.Use(gin.Recovery())
+	router.Use(middleware.ErrorReporting())
 	router.Use(middleware.CORS(cfg))
-	router.Use(middleware.RateLimit())
+	router.Use(middleware.TimestampFormat(cfg))
 
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
-	})
+	// Liveness: the process is up and serving. Nothing external is checked, so an outage of the
+	// database makes replicas unready rather than restarting them.
+	live := func(c *gin.Context) {
+		jsonfmt.JSON(c, http.StatusOK, gin.H{"status": "alive"})
+	}
+	router.GET("/health/live", live)
+	router.GET("/health", live)
+
+	// Readiness aggregated across all application components: database connectivity, pending
+	// migrations, a writable data directory and every started subsystem. A degraded component
+	// (GLEIF failing) is reported without failing readiness.
+	ready := func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		report := application.Readiness(ctx)
+		if !report.Ready {
+			jsonfmt.JSON(c, http.StatusServiceUnavailable, report)
+			return
+		}
+		jsonfmt.JSON(c, http.StatusOK, report)
+	}
+	router.GET("/health/ready", ready)
+	router.GET("/ready", ready)
 
 	// Version endpoint
 	router.GET("/version", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
+		jsonfmt.JSON(c, http.StatusOK, gin.H{
 			"version":     version.Version,
 			"gitCommit":   version.GitCommit,
 			"buildDate":   version.BuildDate,
@@ -237,14 +515,18 @@ func setupRouter(cfg *config.Config, h *handler.Handlers) *gin.Engine {
 		})
 	})
 
-	// Debug CORS config (remove in production)
-	router.GET("/debug/cors", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"allowed_origins": cfg.CORS.AllowedOrigins,
-			"allowed_methods": cfg.CORS.AllowedMethods,
-			"allowed_headers": cfg.CORS.AllowedHeaders,
+	// CORS config, to debug a frontend's requests being refused; not served in release mode
+	if cfg.Server.Mode != "release" {
+		router.GET("/debug/cors", func(c *gin.Context) {
+			jsonfmt.JSON(c, http.StatusOK, gin.H{
+				"allowed_origins": cfg.CORS.AllowedOrigins,
+				"allowed_methods": cfg.CORS.AllowedMethods,
+				"allowed_headers": cfg.CORS.AllowedHeaders,
+				"exposed_headers": cfg.CORS.ExposedHeaders,
+				"max_age":         cfg.CORS.MaxAge.String(),
+			})
 		})
-	})
+	}
 
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -254,38 +536,65 @@ func setupRouter(cfg *config.Config, h *handler.Handlers) *gin.Engine {
 	{
 		// Public routes
 		auth := v1.Group("/auth")
+		auth.Use(middleware.RateLimit(rateLimits, rateLimitRules, ratelimit.GroupAuth), middleware.MaxBodySize(cfg.BodyLimit.Auth))
 		{
 			auth.POST("/login", h.Auth.Login)
 			auth.POST("/register", h.Auth.Register)
 		}
 
+		// Routes open without a token
+		public := v1.Group("")
+		public.Use(middleware.RateLimit(rateLimits, rateLimitRules, ratelimit.GroupPublic), middleware.MaxBodySize(cfg.BodyLimit.Public))
+
 		// Public monitoring routes (no auth required)
-		v1.GET("/lei/status/:jobType", h.LEI.GetProcessingStatus)
+		public.GET("/lei/status/:jobType", h.LEI.GetProcessingStatus)
+		public.GET("/lei/source-file/:id/progress", h.LEI.StreamProcessingProgress)
+		public.GET("/ws", h.LEI.StatusStream)
 
 		// Public reference data routes (read-only, no auth required)
-		v1.GET("/countries", h.Country.List)
-		v1.GET("/countries/:id", h.Country.Get)
-		v1.GET("/currencies", h.Currency.List)
-		v1.GET("/currencies/:id", h.Currency.Get)
+		public.GET("/countries", h.Country.List)
+		public.GET("/countries/:id", h.Country.Get)
+		public.GET("/countries/as-of", h.Version.CountriesAsOf)
+		public.GET("/countries/:id/versions", h.Version.CountryVersions)
+		public.GET("/currencies", h.Currency.List)
+		public.GET("/currencies/:id", h.Currency.Get)
+		public.GET("/currencies/as-of", h.Version.CurrenciesAsOf)
+		public.GET("/currencies/:id/versions", h.Version.CurrencyVersions)
+		public.GET("/fx/rates", h.FX.Rates)
+		public.GET("/fx/convert", h.FX.Convert)
+		public.GET("/fx/history", h.FX.History)
 
 		// Public LEI data routes (read-only, no auth required)
-		v1.GET("/lei", h.LEI.ListLEI)
-		v1.GET("/lei-countries", h.LEI.GetDistinctCountries)
-		v1.GET("/lei/record/:id", h.LEI.GetLEIByID)
-		v1.GET("/lei/:lei/audit", h.LEI.GetAuditHistory)
-		v1.GET("/lei/:lei", h.LEI.GetLEIByCode)
+		public.GET("/lei", h.LEI.ListLEI)
+		public.GET("/lei-countries", h.LEI.GetDistinctCountries)
+		public.GET("/lei/search", h.LEI.FuzzySearchLEI)
+		public.GET("/lei/match", h.LEI.MatchEntity)
+		public.GET("/lei/changes", h.LEI.GetChanges)
+		public.GET("/lei/registration-authorities", h.RA.List)
+		public.GET("/lei/registration-authorities/:code", h.RA.Get)
+		public.GET("/lei/lous", h.LOU.List)
+		public.GET("/lei/lous/:lei", h.LOU.Get)
+		public.GET("/lei/record/:id", h.LEI.GetLEIByID)
+		public.GET("/lei/:lei/audit", h.LEI.GetAuditHistory)
+		public.GET("/lei/:lei/audit/diff", h.LEI.GetAuditDiff)
+		public.GET("/lei/:lei/audit/:auditId/version", h.LEI.GetAuditVersion)
+		public.GET("/lei/:lei/exceptions", h.LEI.GetReportingExceptions)
+		// refresh=true falls back to GLEIF, so it needs a token
+		public.GET("/lei/:lei", middleware.JWTAuthFor(cfg, handler.LEIRefreshRequested), h.LEI.GetLEIByCode)
 
 		// Protected routes (require JWT)
 		protected := v1.Group("")
-		protected.Use(middleware.JWTAuth(cfg))
+		protected.Use(middleware.JWTAuth(cfg), middleware.RateLimit(rateLimits, rateLimitRules, ratelimit.GroupProtected), middleware.MaxBodySize(cfg.BodyLimit.Protected), middleware.MaskFields(cfg.Masking))
 		{
 			// Protected write operations for countries and currencies
 			protected.POST("/countries", h.Country.Create)
 			protected.PUT("/countries/:id", h.Country.Update)
 			protected.DELETE("/countries/:id", h.Country.Delete)
 			protected.POST("/currencies", h.Currency.Create)
+			protected.POST("/currencies/sync", h.Currency.Sync)
 			protected.PUT("/currencies/:id", h.Currency.Update)
 			protected.DELETE("/currencies/:id", h.Currency.Delete)
+			protected.POST("/fx/sync", h.FX.Sync)
 
 			// Domain data routes
 
@@ -296,6 +605,15 @@ func setupRouter(cfg *config.Config, h *handler.Handlers) *gin.Engine {
 				entities.POST("", h.Entity.Create)
 				entities.PUT("/:id", h.Entity.Update)
 				entities.DELETE("/:id", h.Entity.Delete)
+				entities.POST("/enrich", h.Enrichment.EnrichAll)
+				entities.POST("/:id/enrich", h.Enrichment.Enrich)
+				entities.GET("/duplicates", h.Dedup.ListDuplicates)
+				entities.POST("/duplicates/scan", h.Dedup.ScanDuplicates)
+				entities.POST("/duplicates/:id/dismiss", h.Dedup.DismissDuplicate)
+				entities.POST("/merge", h.Dedup.Merge)
+				entities.GET("/merges", h.Dedup.ListMerges)
+				entities.GET("/as-of", h.Version.EntitiesAsOf)
+				entities.GET("/:id/versions", h.Version.EntityVersions)
 			}
 
 			instruments := protected.Group("/instruments")
@@ -305,6 +623,14 @@ func setupRouter(cfg *config.Config, h *handler.Handlers) *gin.Engine {
 				instruments.POST("", h.Instrument.Create)
 				instruments.PUT("/:id", h.Instrument.Update)
 				instruments.DELETE("/:id", h.Instrument.Delete)
+				instruments.GET("/as-of", h.Version.InstrumentsAsOf)
+				instruments.GET("/:id/versions", h.Version.InstrumentVersions)
+
+				// Reference prices
+				instruments.POST("/prices/import", h.Price.ImportPrices)
+				instruments.POST("/prices/sync", h.Price.SyncPrices)
+				instruments.GET("/prices/providers", h.Price.ListProviders)
+				instruments.GET("/:id/prices/latest", h.Price.GetLatestPrice)
 			}
 
 			accounts := protected.Group("/accounts")
@@ -319,28 +645,189 @@ func setupRouter(cfg *config.Config, h *handler.Handlers) *gin.Engine {
 			ssis := protected.Group("/ssis")
 			{
 				ssis.GET("", h.SSI.List)
+				ssis.GET("/effective", h.SSI.Effective)
+				ssis.GET("/conflicts", h.SSI.Conflicts)
+				ssis.GET("/defaults", h.SSIDefaults.List)
+				ssis.POST("/defaults", h.SSIDefaults.Create)
+				ssis.POST("/defaults/preview", h.SSIDefaults.Preview)
+				ssis.GET("/defaults/:id", h.SSIDefaults.Get)
+				ssis.PUT("/defaults/:id", h.SSIDefaults.Update)
+				ssis.DELETE("/defaults/:id", h.SSIDefaults.Delete)
+				ssis.GET("/:id/versions", h.Version.SSIVersions)
 				ssis.GET("/:id", h.SSI.Get)
+				ssis.POST("/:id/export", h.SSI.Export)
 				ssis.POST("", h.SSI.Create)
 				ssis.PUT("/:id", h.SSI.Update)
 				ssis.DELETE("/:id", h.SSI.Delete)
 			}
 
+			// ISO 9362 BIC directory, checked by SSI writes once imported
+			bics := protected.Group("/bics")
+			{
+				bics.GET("", h.BIC.List)
+				bics.GET("/:bic", h.BIC.Get)
+			}
+
+			// ISO 10383 MIC list, checked by instrument writes once imported
+			exchanges := protected.Group("/exchanges")
+			{
+				exchanges.GET("", h.Exchange.List)
+				exchanges.POST("/refresh", h.Exchange.Refresh)
+				exchanges.GET("/:mic", h.Exchange.Get)
+			}
+
+			// Holiday calendars by market or currency, and the settlement dates they give
+			calendars := protected.Group("/calendars")
+			{
+				calendars.GET("", h.Calendar.List)
+				calendars.GET("/settlement-date", h.Calendar.SettlementDate)
+				calendars.GET("/:code", h.Calendar.Get)
+				calendars.PUT("/:code", h.Calendar.Save)
+				calendars.POST("/:code/holidays", h.Calendar.AddHolidays)
+				calendars.DELETE("/:code/holidays/:date", h.Calendar.DeleteHoliday)
+			}
+
+			// ISO 20275 entity legal forms, naming the legal forms of LEI records once imported
+			elfCodes := protected.Group("/elf-codes")
+			{
+				elfCodes.GET("", h.ELF.List)
+				elfCodes.POST("/refresh", h.ELF.Refresh)
+				elfCodes.GET("/:code", h.ELF.Get)
+			}
+
+			// Identifier checks for the frontend
+			validate := protected.Group("/validate")
+			{
+				validate.GET("/iban/:iban", h.Validation.IBAN)
+			}
+
+			// Counterparty onboarding: draft -> enrich -> validate SSIs -> approve (or reject)
+			onboarding := protected.Group("/onboarding")
+			{
+				onboarding.POST("", h.Onboarding.Create)
+				onboarding.GET("", h.Onboarding.List)
+				onboarding.GET("/:id", h.Onboarding.Get)
+				onboarding.POST("/:id/enrich", h.Onboarding.Enrich)
+				onboarding.POST("/:id/validate-ssis", h.Onboarding.ValidateSSIs)
+				onboarding.POST("/:id/approve", h.Onboarding.Approve)
+				onboarding.POST("/:id/reject", h.Onboarding.Reject)
+			}
+
 			// LEI management routes (write operations only)
 			lei := protected.Group("/lei")
 			{
 				lei.POST("/sync/full", h.LEI.TriggerFullSync)
 				lei.POST("/sync/delta", h.LEI.TriggerDeltaSync)
+				lei.POST("/sync/repex", h.LEI.TriggerRepexSync)
+				lei.POST("/refresh", h.LEI.RefreshLEIs)
+				lei.GET("/export.ndjson", h.LEI.ExportNDJSON)
+				lei.POST("/registration-authorities/refresh", h.RA.Refresh)
+				lei.POST("/lous/sync", h.LOU.Sync)
 				lei.POST("/source-file/:id/resume", h.LEI.ResumeProcessing)
+				lei.GET("/source-file/:id/quality", h.LEI.GetSourceFileQuality)
+				lei.GET("/quality", h.LEI.GetQualityTrend)
+				lei.GET("/quarantine", h.LEI.ListQuarantinedRecords)
+				lei.POST("/quarantine/:id/dismiss", h.LEI.DismissQuarantinedRecord)
+				lei.GET("/renewals", h.Renewal.List)
+				lei.POST("/reconcile", h.LEI.TriggerReconciliation)
+				lei.GET("/reconciliation", h.LEI.GetReconciliation)
+				lei.GET("/reconciliation/discrepancies", h.LEI.ListReconciliationDiscrepancies)
 			}
 
 			// Data acquisition routes
 			dataAcq := protected.Group("/data")
 			{
-				dataAcq.POST("/import", h.DataAcquisition.Import)
 				dataAcq.POST("/export", h.DataAcquisition.Export)
 				dataAcq.GET("/jobs", h.DataAcquisition.ListJobs)
 				dataAcq.GET("/jobs/:id", h.DataAcquisition.GetJob)
+				dataAcq.GET("/freshness", h.DataAcquisition.Freshness)
 			}
+
+			// Saved LEI views and scheduled exports
+			views := protected.Group("/lei/views")
+			{
+				views.GET("", h.Export.ListViews)
+				views.POST("", h.Export.CreateView)
+				views.GET("/:id", h.Export.GetView)
+				views.DELETE("/:id", h.Export.DeleteView)
+			}
+			schedules := protected.Group("/exports/schedules")
+			{
+				schedules.GET("", h.Export.ListSchedules)
+				schedules.POST("", h.Export.CreateSchedule)
+				schedules.GET("/:id", h.Export.GetSchedule)
+				schedules.PUT("/:id", h.Export.UpdateSchedule)
+				schedules.DELETE("/:id", h.Export.DeleteSchedule)
+				schedules.POST("/:id/run", h.Export.RunSchedule)
+			}
+
+			// Data quality findings
+			dq := protected.Group("/dq")
+			{
+				dq.GET("/findings", h.DataQuality.ListFindings)
+				dq.POST("/findings/:id/resolve", h.DataQuality.ResolveFinding)
+			}
+
+			// Operator/admin routes
+			admin := protected.Group("/admin", middleware.RequireRole(cfg.JWT.AdminRole))
+			{
+				admin.GET("/dead-letters", h.DeadLetter.List)
+				admin.POST("/dead-letters/purge", h.DeadLetter.Purge)
+				admin.GET("/dead-letters/:id", h.DeadLetter.Get)
+				admin.PUT("/dead-letters/:id", h.DeadLetter.Update)
+				admin.POST("/dead-letters/:id/retry", h.DeadLetter.Retry)
+				admin.DELETE("/dead-letters/:id", h.DeadLetter.Delete)
+
+				admin.POST("/countries/sync", h.Country.Sync)
+				admin.POST("/lei/quarantine/:id/requeue", h.LEI.RequeueQuarantinedRecord)
+
+				admin.GET("/schema-drift", h.Admin.SchemaDrift)
+				admin.POST("/audit/prune", h.Admin.PruneAudit)
+				admin.POST("/reports/renewals/run", h.Renewal.Run)
+				admin.POST("/integrity-check", h.DataQuality.IntegrityCheck)
+
+				admin.POST("/maintenance/cleanup", h.Admin.CleanupFiles)
+				admin.POST("/maintenance/reset-stuck", h.Admin.ResetStuck)
+				admin.POST("/maintenance/source-files/:id/rerun", h.Admin.RerunSourceFile)
+				admin.POST("/maintenance/stats/recompute", h.Admin.RecomputeStats)
+
+				admin.GET("/tasks", h.Job.List)
+				admin.GET("/tasks/stream", h.Job.Stream)
+				admin.GET("/tasks/:id", h.Job.Get)
+
+				admin.GET("/id-sequences", h.IDSequence.List)
+				admin.POST("/id-sequences", h.IDSequence.Create)
+				admin.GET("/id-sequences/:id", h.IDSequence.Get)
+				admin.PUT("/id-sequences/:id", h.IDSequence.Update)
+				admin.DELETE("/id-sequences/:id", h.IDSequence.Delete)
+			}
+		}
+
+		// File imports (require JWT): protected routes too, but with the larger upload body limit
+		uploads := v1.Group("")
+		uploads.Use(middleware.JWTAuth(cfg), middleware.RateLimit(rateLimits, rateLimitRules, ratelimit.GroupProtected), middleware.MaxBodySize(cfg.BodyLimit.Upload), middleware.MaskFields(cfg.Masking))
+		{
+			uploads.POST("/bics/import", h.BIC.Import)
+			uploads.POST("/exchanges/import", h.Exchange.Import)
+			uploads.POST("/elf-codes/import", h.ELF.Import)
+			uploads.POST("/lei/registration-authorities/import", h.RA.Import)
+			uploads.POST("/data/import", h.DataAcquisition.Import)
+		}
+	}
+
+	// API v2 routes: the routes whose response shapes changed (pagination envelope, error
+	// objects); everything else is served by v1 only
+	v2 := router.Group("/api/v2")
+	{
+		public := v2.Group("")
+		public.Use(middleware.RateLimit(rateLimits, rateLimitRules, ratelimit.GroupPublic), middleware.MaxBodySize(cfg.BodyLimit.Public))
+		{
+			public.GET("/countries", h2.Country.List)
+			public.GET("/countries/:code", h2.Country.Get)
+			public.GET("/currencies", h2.Currency.List)
+			public.GET("/currencies/:code", h2.Currency.Get)
+			public.GET("/lei", h2.LEI.List)
+			public.GET("/lei/:lei", h2.LEI.Get)
 		}
 	}
 
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/dbmigrate"
+	"github.com/techie2000/axiom/pkg/logger"
+)
+
+const migrateUsage = `usage: migrate <command>
+
+  up            apply every pending migration
+  down [N]      roll back the last N migrations (default 1)
+  status        show the applied version and pending migrations (--json for JSON)
+  force V       record version V as applied and clear a failed migration's dirty flag,
+                after repairing the schema by hand; runs no migration`
+
+// runMigrate runs "migrate <command>" against the configured database
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := dbmigrate.Open(databaseDSN(cfg.Database), dbmigrate.Source(cfg.Database.MigrationsDir))
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "up":
+		applied, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migration(s)\n", applied)
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("down takes a number of migrations, got %q", args[0])
+			}
+		}
+		if err := migrator.Down(steps); err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back %d migration(s)\n", steps)
+		return nil
+
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		if len(args) > 0 && args[0] == "--json" {
+			return json.NewEncoder(os.Stdout).Encode(status)
+		}
+		printMigrationStatus(status)
+		return nil
+
+	case "force":
+		if len(args) == 0 {
+			return errors.New("force takes the version to record")
+		}
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		if err := migrator.Force(version); err != nil {
+			return err
+		}
+		fmt.Printf("Recorded version %d\n", version)
+		return nil
+	}
+	return fmt.Errorf("unknown migrate command %q\n%s", command, migrateUsage)
+}
+
+func printMigrationStatus(status *dbmigrate.Status) {
+	fmt.Printf("Version: %d (latest %d)\n", status.Version, status.Latest)
+	switch {
+	case status.Dirty:
+		fmt.Printf("Migration %d failed halfway: repair the schema, then force the version\n", status.Version)
+	case status.Unknown:
+		fmt.Println("The database is at a version this binary does not have; it was migrated by a newer release")
+	case len(status.Pending) == 0:
+		fmt.Println("Up to date")
+	}
+	for _, migration := range status.Pending {
+		fmt.Printf("Pending: %06d_%s\n", migration.Version, migration.Name)
+	}
+}
+
+// checkMigrations applies pending migrations at startup if database.migrateonstart is set, then
+// checks where the schema stands: a migration that failed halfway stops the server, while pending
+// migrations only keep /health/ready failing until they are applied
+func checkMigrations(cfg config.DatabaseConfig) error {
+	migrator, err := dbmigrate.Open(databaseDSN(cfg), dbmigrate.Source(cfg.MigrationsDir))
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if cfg.MigrateOnStart {
+		applied, err := migrator.Up()
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+		if applied > 0 {
+			logger.Info().Int("applied", applied).Msg("Applied database migrations")
+		}
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	switch {
+	case status.Dirty:
+		return fmt.Errorf("migration %d failed halfway; repair the schema and run \"migrate force %d\"", status.Version, status.Version)
+	case status.Unknown:
+		logger.Warn().Uint("version", status.Version).Uint("latest", status.Latest).
+			Msg("Database was migrated by a newer release than this one")
+	case len(status.Pending) > 0:
+		logger.Warn().Uint("version", status.Version).Uint("latest", status.Latest).Int("pending", len(status.Pending)).
+			Msg("Database migrations are pending; run \"migrate up\" or set database.migrateonstart")
+	default:
+		logger.Info().Uint("version", status.Version).Msg("Database schema is up to date")
+	}
+	return nil
+}
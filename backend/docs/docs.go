@@ -1,4 +1,11604 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
-// This file will be auto-generated by swag init
-// Run: make swagger
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "email": "support@axiom.example.com"
+        },
+        "license": {
+            "name": "MIT",
+            "url": "https://opensource.org/licenses/MIT"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/admin/audit/prune": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes lei_records_audit and audit_logs rows older than audit.retentionmonths, archiving them first when audit.archive is on. Each LEI keeps its last version from before the cutoff. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Prune audit history",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/countries/sync": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds the missing countries of the list and updates the names, alpha-3 and numeric codes and regions of existing ones; active flags and unlisted countries are left alone. source=bundled (default) uses the dataset built into Axiom and returns the outcome; source=remote downloads referencedata.countrylisturl in the background (202 with a job ID; follow it at /admin/tasks).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Sync countries with ISO 3166",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "bundled",
+                        "description": "bundled or remote",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.CountrySyncResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List dead letters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source subsystem: lei-refresh, event-publish, notification or data-import",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING or RESOLVED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/dead-letters/purge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Purge dead letters",
+                "parameters": [
+                    {
+                        "description": "Filter (empty purges everything)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.PurgeDeadLettersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/dead-letters/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get dead letter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DeadLetter"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Edit dead letter payload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Replacement payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateDeadLetterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DeadLetter"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete dead letter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/admin/dead-letters/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Re-runs the work item through its source's retry handler. On failure the item stays PENDING with the new error.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Retry dead letter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DeadLetter"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/id-sequences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List ID sequences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.IDSequenceResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Defines how business IDs are generated for a target (ENTITY business_id or ACCOUNT account_number): prefix, zero padding, check digits (NONE, LUHN, MOD97) and the range next_value..max_value. Only one sequence may be active per target.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create ID sequence",
+                "parameters": [
+                    {
+                        "description": "ID sequence",
+                        "name": "sequence",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.IDSequence"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IDSequenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/id-sequences/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get ID sequence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID sequence ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IDSequenceResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the sequence definition. Use next_value/max_value to assign a new range; values already in use are skipped when generating.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update ID sequence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID sequence ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID sequence",
+                        "name": "sequence",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.IDSequence"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IDSequenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete ID sequence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID sequence ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/integrity-check": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Scans for live records referencing deleted or missing parents (e.g. accounts of deleted entities, SSIs in deleted currencies, instrument codes without an instrument) and raises an ERROR DQ finding for each. With repair=true, orphaned instrument codes, instrument prices and entity address links are soft-deleted and their findings recorded as resolved.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Run referential integrity check",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Soft-delete trivially repairable orphans",
+                        "name": "repair",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.IntegrityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/maintenance/cleanup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes downloaded LEI files beyond the newest lei.keepfullfiles full and lei.keepdeltafiles delta files, as the daily cleanup does. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Clean up LEI files",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/maintenance/reset-stuck": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks IN_PROGRESS source files that have not checkpointed for olderThan as FAILED (STALLED), and returns RUNNING processing statuses unchanged for as long to IDLE unless their file is still in progress. Files being processed by this instance are left alone. Failed files keep their checkpoint and can be re-run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reset stuck statuses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Age without progress after which work counts as stuck (default twice lei.stalltimeout)",
+                        "name": "olderThan",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.StuckStatusReset"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/maintenance/source-files/{id}/rerun": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Processes a FAILED source file again, resuming after its last checkpointed LEI unless fromStart is set. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Re-run a failed source file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source file ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Process the whole file instead of resuming from the checkpoint",
+                        "name": "fromStart",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/maintenance/stats/recompute": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Analyzes the tables reported at /data/freshness so their row estimates are current (e.g. after a bulk load), and drops the cached freshness report. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Recompute summary statistics",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/renewals/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Builds the report configured under renewalreport and emails it to renewalreport.emailto. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Send the renewal report",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/schema-drift": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists missing tables/columns and text size mismatches between the Go models and the database. ERROR issues (e.g. a column narrower in the database than on the model) cause \"value too long\" failures on import.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Schema drift report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SchemaDriftReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Syncs, file processing, exports and cleanups running in this process, newest first. Finished tasks stay listed for an hour.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List background tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RUNNING, COMPLETED or FAILED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task kind (e.g. LEI_FULL_SYNC, LEI_FILE_PROCESSING, EXPORT)",
+                        "name": "kind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrades to a WebSocket. Sends a \"snapshot\" message with the current task list, then one message per started, progress or finished event ({\"type\": ..., \"job\": {...}}). Browsers may pass the JWT as the access_token query parameter.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Stream background task events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT, for clients that cannot set the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    }
+                }
+            }
+        },
+        "/admin/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get background task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.JobInfo"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/lei/quarantine/{id}/requeue": {
+            "post": {
+                "description": "Loads a PENDING quarantined record (or a corrected version of it) into lei_records. Data quality rules are evaluated again unless override_rules is set; if the record still fails, or the database rejects it, it stays PENDING.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Requeue quarantined LEI record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quarantined record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Corrected record and review note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RequeueQuarantinedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LEIQuarantinedRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei": {
+            "get": {
+                "description": "Get a paginated list of LEI records with optional search and filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "List LEI records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term (LEI code or legal name)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search on legal name (prefix match on every term, ranked by relevance unless sortBy is set)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity status filter (e.g., ACTIVE, INACTIVE)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity category filter (e.g., GENERAL, FUND)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code filter (e.g., US, GB)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Managing LOU filter: the LOU's LEI, or part of its name or marketing name",
+                        "name": "lou",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Legal form filter: ELF code (e.g., 8Z6G), or part of the legal form's name or one of its abbreviations (e.g., GmbH)",
+                        "name": "legalForm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field (lei, legal_name, entity_status, entity_category, legal_address_country, last_update_date)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LEIRecord"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei-countries": {
+            "get": {
+                "description": "Get sorted list of unique countries from LEI records",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get distinct countries",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/changes": {
+            "get": {
+                "description": "Incremental feed for downstream caches. Start with an RFC 3339 timestamp or date, then pass next_cursor back as since; has_more means another page is ready now. Changes appear a few seconds after they commit so a feed never skips one that committed late.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get LEI changes since a timestamp or cursor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 timestamp, date (YYYY-MM-DD) or next_cursor from a previous page",
+                        "name": "since",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1000,
+                        "description": "Maximum changes (max 10000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.LEIChangeFeed"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/export.ndjson": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams every LEI record matching the filters, or the whole dataset without any, as JSON Lines: one record per line, as stored (without the names resolved by GET /lei). Records are read from the database as the client reads the response, so a slow consumer slows the dump instead of buffering it. Takes the filters of GET /lei; limit and offset do not apply. X-Total-Count gives the records matching when the dump started; a response that ends short of it was cut off by an error.",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Dump LEI records as NDJSON",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search term (LEI code or legal name)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search on legal name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity status filter (e.g., ACTIVE, INACTIVE)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity category filter (e.g., GENERAL, FUND)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code filter (e.g., US, GB)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Managing LOU filter: the LOU's LEI, or part of its name or marketing name",
+                        "name": "lou",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Legal form filter: ELF code, or part of the legal form's name or one of its abbreviations",
+                        "name": "legalForm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field (lei, legal_name, entity_status, entity_category, legal_address_country, last_update_date)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/lous": {
+            "get": {
+                "description": "LEI issuers (Local Operating Units) in name order, with their accreditation date and whether GLEIF still lists them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "List LOUs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LOU LEI, or part of its name or marketing name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only LOUs GLEIF still lists",
+                        "name": "accredited",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/lous/sync": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reads the LEI issuer list from the GLEIF API and upserts the LOUs on it; LOUs no longer listed are marked as not accredited. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Sync the LOUs",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/lous/{lei}": {
+            "get": {
+                "description": "Returns the LEI issuer with the given LEI, as found in LEI records' managing_lou.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Look up an LOU",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LOU LEI",
+                        "name": "lei",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LOU"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/match": {
+            "get": {
+                "description": "Ranks LEI records by how well their legal (or transliterated) name matches the entity name, to speed up counterparty onboarding. Names are compared after normalization: case, punctuation and trailing legal forms (Ltd, GmbH, S.A., ...) are ignored. Each candidate's score is the mean of its trigram and whole-word similarity; among equal scores ISSUED LEIs rank first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Match an entity to candidate LEIs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity name (min 3 characters)",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2 country of the entity's legal address (e.g., DE, GB)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum candidates (max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.LEIMatchCandidate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/quality": {
+            "get": {
+                "description": "Completeness (populated as % of records) and validity (valid as % of populated) per field for the most recently published source files, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "LEI data quality trend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "FULL or DELTA",
+                        "name": "file_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of files",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/quarantine": {
+            "get": {
+                "description": "Records rejected by the database (source DATABASE, with the SQLSTATE and error message) or failing data quality rules (source RULES, with the rule codes and reasons), each with a snapshot of the record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "List quarantined LEI records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only records from this source file",
+                        "name": "source_file_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DATABASE or RULES",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, REQUEUED or DISMISSED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/quarantine/{id}/dismiss": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Dismiss quarantined LEI record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quarantined record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DismissQuarantinedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LEIQuarantinedRecord"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/reconcile": {
+            "post": {
+                "description": "Compare local LEI record counts with GLEIF's now instead of waiting for lei.reconciletime",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Trigger LEI reconciliation",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/reconciliation": {
+            "get": {
+                "description": "Local LEI record counts compared with the golden copy record_count (TOTAL) and, if enabled, GLEIF's count per legal address country",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get the latest LEI reconciliation",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.LEIReconciliationRun"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/reconciliation/discrepancies": {
+            "get": {
+                "description": "Reconciliation results, across runs and newest first, whose drift from GLEIF exceeded lei.reconciledriftthreshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "List LEI reconciliation discrepancies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/record/{id}": {
+            "get": {
+                "description": "Get a specific LEI record by its database ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get LEI record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LEIRecord"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch the given LEIs from the GLEIF single-record API (rate-limited, queued) and upsert them without waiting for the next delta",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Refresh specific LEI records",
+                "parameters": [
+                    {
+                        "description": "LEI codes to refresh (max 500)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RefreshLEIsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/service.LEIRefreshResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/registration-authorities": {
+            "get": {
+                "description": "Business registers of the GLEIF RA list in RA code order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "List registration authorities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2 country code",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the jurisdiction (country or region the register covers)",
+                        "name": "jurisdiction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RA code, or part of the register or organization name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/registration-authorities/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Loads the GLEIF Registration Authorities list as published in CSV (comma, semicolon or tab separated), sent as the multipart field \"file\" or as the request body. The header row must name the RA code column and the international or local register name column. Registers are upserted by RA code and invalid rows reported. LEI records are then returned with the name and jurisdiction of their registration authority.",
+                "consumes": [
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Import the RA list",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "RA list file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.RAImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/registration-authorities/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Downloads the RA list from lei.ralisturl and imports it. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Refresh the RA list",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/registration-authorities/{code}": {
+            "get": {
+                "description": "Returns the business register of an RA code.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Look up a registration authority",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "RA code (e.g., RA000665)",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegistrationAuthority"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/renewals": {
+            "get": {
+                "description": "LEIs with a next renewal date from today up to within_days ahead, soonest first. format=csv downloads every match as CSV (ignoring limit and offset).",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "List LEIs due for renewal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Days ahead (1-366)",
+                        "name": "within_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated legal address countries (e.g., DE,GB)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated managing LOU LEIs",
+                        "name": "lou",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.RenewalsDue"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/search": {
+            "get": {
+                "description": "Trigram similarity search on legal name (and transliterated name), tolerant of typos and abbreviations. Results are ranked by similarity score.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Fuzzy search LEI records by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity name to match (min 3 characters)",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.3,
+                        "description": "Minimum similarity score between 0.1 and 1.0",
+                        "name": "threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code filter (e.g., DE, GB)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum results (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LEIFuzzyMatch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/source-file/{id}/progress": {
+            "get": {
+                "description": "Server-sent events with the processing heartbeat of a source file (percent complete, rate, ETA, last LEI). A \"progress\" event is sent straight away and on every heartbeat; a final \"done\" event is sent when the file completes or fails, and the stream then ends.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Stream file processing progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source file ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.SourceFileProgress"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/source-file/{id}/quality": {
+            "get": {
+                "description": "Completeness and validity per field across the records of a source file, measured while it was loaded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "LEI source file data quality",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source file ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.LEIQualityReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/source-file/{id}/resume": {
+            "post": {
+                "description": "Resume processing of a source file from where it left off",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Resume file processing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source file ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/status/{jobType}": {
+            "get": {
+                "description": "Get the current processing status for LEI sync jobs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get processing status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job type (DAILY_FULL or DAILY_DELTA)",
+                        "name": "jobType",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.FileProcessingStatus"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/sync/delta": {
+            "post": {
+                "description": "Manually trigger a delta LEI data synchronization. Without type the scheduled delta type (lei.deltatype) is used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Trigger delta LEI sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delta file type (IntraDay, LastDay, LastWeek, LastMonth)",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/sync/full": {
+            "post": {
+                "description": "Manually trigger a full LEI data synchronization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Trigger full LEI sync",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/sync/repex": {
+            "post": {
+                "description": "Manually download and load the GLEIF reporting exceptions (repex) file. It also runs after every full sync.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Trigger reporting exceptions sync",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/{lei}": {
+            "get": {
+                "description": "Get a specific LEI record by its LEI code. With asOf, the record is reconstructed from its audit history as it stood at that time and returned with the audit entry it reflects.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get LEI record by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LEI code",
+                        "name": "lei",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "If the LEI is not stored locally, fetch it from the GLEIF API, store it and return it; requires a token",
+                        "name": "refresh",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "When asOf is given",
+                        "schema": {
+                            "$ref": "#/definitions/service.LEIRecordVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "refresh without a valid token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "GLEIF API rate limit reached; see Retry-After",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "GLEIF unavailable after repeated failures; see Retry-After",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/{lei}/audit": {
+            "get": {
+                "description": "Get audit trail for a specific LEI record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get LEI audit history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LEI code",
+                        "name": "lei",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LEIRecordAudit"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/{lei}/audit/diff": {
+            "get": {
+                "description": "Field-by-field comparison of the record after the from and to audit entries, computed server-side from the audit trail. Bookkeeping fields (IDs, timestamps, source file) are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Diff two LEI record versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LEI code",
+                        "name": "lei",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Audit entry ID of the earlier version",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Audit entry ID of the later version",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.LEIRecordDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/{lei}/audit/{auditId}/version": {
+            "get": {
+                "description": "Rebuild the record as of an audit entry. Works in both audit modes: with lei.auditmode=diff the version is replayed from the last full snapshot and the changed fields recorded since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get a historical LEI record version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LEI code",
+                        "name": "lei",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Audit entry ID",
+                        "name": "auditId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.LEIRecordVersion"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lei/{lei}/exceptions": {
+            "get": {
+                "description": "Explains why an entity has no direct or ultimate accounting consolidation parent reported (GLEIF repex), e.g. NATURAL_PERSONS or NON_CONSOLIDATING. An empty list means no exception was filed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get LEI reporting exceptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LEI code",
+                        "name": "lei",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ws": {
+            "get": {
+                "description": "WebSocket. On connect the server sends {\"type\":\"snapshot\",\"statuses\":[...]} with the processing status of every sync job, then {\"type\":\"processing_status\",\"processing_status\":{...}} whenever a job's status or current file changes and {\"type\":\"batch_flushed\",\"batch\":{...}} each time a batch of a file being processed is checkpointed. Batch events for a slow client may be dropped.",
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Stream LEI sync status",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/countries": {
+            "get": {
+                "description": "Get a page of countries, in a pagination envelope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "List countries (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Page-v2_Country"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/countries/{code}": {
+            "get": {
+                "description": "Get a country by its ISO 3166-1 alpha-2 code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Get country by code (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code, e.g. GB",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Country"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/currencies": {
+            "get": {
+                "description": "Get a page of currencies, in a pagination envelope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "List currencies (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Page-v2_Currency"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/currencies/{code}": {
+            "get": {
+                "description": "Get a currency by its ISO 4217 code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "Get currency by code (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency code, e.g. EUR",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Currency"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/lei": {
+            "get": {
+                "description": "Get a page of LEI records matching the filters of GET /api/v1/lei, in a pagination envelope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "List LEI records (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term (LEI code or legal name)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search on legal name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity status filter (e.g., ACTIVE, INACTIVE)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity category filter (e.g., GENERAL, FUND)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code filter (e.g., US, GB)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Legal form filter: ELF code, or part of the legal form's name or one of its abbreviations",
+                        "name": "legalForm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Managing LOU filter: the LOU's LEI, or part of its name or marketing name",
+                        "name": "lou",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field (lei, legal_name, entity_status, entity_category, legal_address_country, last_update_date)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order (asc, desc)",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Page-v2_LEIRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/lei/{lei}": {
+            "get": {
+                "description": "Get a stored LEI record by its LEI code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LEI"
+                ],
+                "summary": "Get LEI record by code (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "LEI code",
+                        "name": "lei",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.LEIRecord"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user and return JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "User registration",
+                "parameters": [
+                    {
+                        "description": "User details",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/bics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "BIC directory entries in BIC order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bics"
+                ],
+                "summary": "List BICs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2 country code",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "BIC prefix or part of the institution name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only BICs still listed by the directory",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/bics/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Loads a comma, semicolon or tab separated BIC directory file, sent as the multipart field \"file\" or as the request body. The header row must name the BIC (or BIC8 and branch code) and institution name columns; branch name, city and country code are optional. Entries are upserted by BIC and invalid rows reported. With full, BICs the file no longer lists are deactivated. Once the directory has entries, SSI BICs must be listed in it.",
+                "consumes": [
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bics"
+                ],
+                "summary": "Import the BIC directory",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "BIC directory file",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "The file is the complete directory: deactivate BICs it does not list",
+                        "name": "full",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.BICImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/bics/{bic}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks the BIC against the ISO 9362 structure (400 with the reason if invalid) and returns its directory entry. A BIC8 is looked up as its head office, branch XXX.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bics"
+                ],
+                "summary": "Look up a BIC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "BIC8 or BIC11",
+                        "name": "bic",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BICDirectoryEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calendars": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Business day calendars by market (MIC) or currency, in code order, without their holidays",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendars"
+                ],
+                "summary": "List holiday calendars",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calendars/settlement-date": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Counts the cycle's business days on from the trade date, skipping weekends and the holidays of the market's calendar. Several markets (comma separated, e.g. XNYS,USD) settle on a day that is a business day in all of them. T+0 settles on the trade date, or the next business day if it is not one. Lists the days passed over and warns about calendars with no holidays loaded for a year involved.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendars"
+                ],
+                "summary": "Compute settlement date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Calendar code(s), comma separated",
+                        "name": "market",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trade date (YYYY-MM-DD)",
+                        "name": "tradeDate",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "T+2",
+                        "description": "Settlement cycle, T+0 to T+30",
+                        "name": "cycle",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.SettlementDateResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calendars/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendars"
+                ],
+                "summary": "Get holiday calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Calendar code, e.g. XLON or USD",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the holidays of this year",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HolidayCalendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the name and weekend days (comma separated MON..SUN, default SAT,SUN) of a calendar; its holidays are left as they are",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendars"
+                ],
+                "summary": "Create or update holiday calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Calendar code, e.g. XLON or USD",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Calendar",
+                        "name": "calendar",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.HolidayCalendar"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.HolidayCalendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calendars/{code}/holidays": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds holidays to a calendar; a date the calendar already has is renamed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendars"
+                ],
+                "summary": "Add holidays",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Calendar code, e.g. XLON or USD",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Holidays",
+                        "name": "holidays",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.holidayRequest"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/calendars/{code}/holidays/{date}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "calendars"
+                ],
+                "summary": "Delete holiday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Calendar code, e.g. XLON or USD",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Holiday date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/countries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of all countries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "List countries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Country"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new country",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Create country",
+                "parameters": [
+                    {
+                        "description": "Country object",
+                        "name": "country",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Country"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Country"
+                        }
+                    }
+                }
+            }
+        },
+        "/countries/as-of": {
+            "get": {
+                "description": "The version of each country in effect at asOf, in ID order; countries created later or deleted by then are left out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "List countries as of a point in time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/countries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single country by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Get country by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Country"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing country",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Update country",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Country object",
+                        "name": "country",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Country"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Country"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a country",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Delete country",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/countries/{id}/versions": {
+            "get": {
+                "description": "Every version of a country, oldest first, each valid from valid_from until valid_to (null while current). With asOf, only the version in effect then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "countries"
+                ],
+                "summary": "Get country versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "When asOf is given",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MasterDataVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/currencies/as-of": {
+            "get": {
+                "description": "The version of each currency in effect at asOf, in ID order; currencies created later or deleted by then are left out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "List currencies as of a point in time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/currencies/sync": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds the missing currencies of list one and updates the names, numeric codes, minor units (decimal_places) and fund and precious metal flags of existing ones; symbols, active flags and unlisted currencies are left alone. source=bundled (default) uses the dataset built into Axiom and returns the outcome; source=remote downloads referencedata.currencylisturl in the background (202 with a job ID; follow it at /admin/tasks).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "Sync currencies with ISO 4217",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "bundled",
+                        "description": "bundled or remote",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.CurrencySyncResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/currencies/{id}/versions": {
+            "get": {
+                "description": "Every version of a currency, oldest first, each valid from valid_from until valid_to (null while current). With asOf, only the version in effect then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "Get currency versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "When asOf is given",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MasterDataVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/data/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams every countries, currencies, entities, instruments, accounts, ssis or lei record matching the filters as CSV (default), Excel (xlsx), JSON (an array) or ndjson (JSON Lines). Filters are those of the type's list endpoint, e.g. {\"type[in]\": \"CORPORATE,FUND\", \"created_at[gte]\": \"2024-01-01\", \"sortBy\": \"name\"} for entities, or {\"country\": \"DE\", \"status\": \"ACTIVE\"} for lei; limit and offset do not apply. CSV and JSON are sent in chunks as the records are read, so exports of any size stream; an Excel file (at most 1,048,575 rows) is sent once complete. Fields masked for the caller (masking.rules) are masked as in JSON responses; in CSV and Excel, masked values that are not text are left empty. X-Total-Count gives the rows matching when the export started; a response that ends short of it was cut off by an error.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "data"
+                ],
+                "summary": "Export master data",
+                "parameters": [
+                    {
+                        "description": "Export type, format and filters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.exportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/data/freshness": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Per-table row counts (estimated), last update timestamps, last import runs and a change sequence. Cheap to poll: results are cached for 60 seconds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "data"
+                ],
+                "summary": "Data freshness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DataFreshness"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/data/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a CSV (comma, semicolon or tab separated, with a header row) or JSON (array of objects) file of countries, currencies, entities, instruments or SSIs, sent as the multipart field \"file\" (type and format, if sent as form fields rather than in the query, must come before it). Files over bodylimit.upload are refused with 413. Columns are the JSON field names of the records (e.g. registration_number); currencies may be given by code (issue_currency, settlement_currency). Countries and currencies matching an existing code, and rows with an id, update the record; other rows create one. Empty values leave fields as they are; rows that would change nothing are reported as unchanged and not written. Every row goes through the same validation as the API; rows that fail are reported and the rest are written. With dryRun=true nothing is written: the report says which rows would create, update (with the fields that change) or fail, for sign-off before the real load. The file is checked and the job returned QUEUED; a background worker then processes the rows. Follow its progress and the outcome of each row at GET /data/jobs/{id}.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "data"
+                ],
+                "summary": "Import master data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "countries, currencies, entities, instruments or ssis",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or json; defaults to the file extension, else csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validate and compare only; write nothing",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Import file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/data/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Imports, newest first, with their status, progress and row counts but without the row report",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "data"
+                ],
+                "summary": "List import jobs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/data/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "An import's status (QUEUED, RUNNING, COMPLETED, COMPLETED_WITH_ERRORS or FAILED), progress (processed_rows of total_rows) and, once finished, the outcome of each row",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "data"
+                ],
+                "summary": "Get import job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/dq/findings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "data-quality"
+                ],
+                "summary": "List data quality findings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule code (e.g. INACTIVE_CURRENCY_REFERENCE)",
+                        "name": "rule_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "OPEN or RESOLVED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Table of the affected record (accounts, ssis, instruments, addresses)",
+                        "name": "target_table",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the affected record",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/dq/findings/{id}/resolve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes the finding. When the affected record has no other open findings its review_required flag is cleared.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "data-quality"
+                ],
+                "summary": "Resolve data quality finding",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Finding ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DQFinding"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/elf-codes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Legal forms of the ELF code list by country, then code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "elf-codes"
+                ],
+                "summary": "List entity legal forms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2 country code",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ELF code, or part of a name or abbreviation in any language",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only codes with status ACTV",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/elf-codes/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Loads the ISO 20275 entity legal form code list as GLEIF publishes it in CSV (comma, semicolon or tab separated), sent as the multipart field \"file\" or as the request body. The header row must name the ELF code, country code, local name and ELF status columns. The list has a row per code and language; codes are upserted with their names in every language and invalid rows reported. LEI records are then returned with the name of their legal form.",
+                "consumes": [
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "elf-codes"
+                ],
+                "summary": "Import the ELF code list",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "ELF code list file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ELFImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/elf-codes/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Downloads the ELF code list from lei.elflisturl and imports it. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "elf-codes"
+                ],
+                "summary": "Refresh the ELF code list",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/elf-codes/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the legal form of an ELF code with its names in every listed language, active or not.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "elf-codes"
+                ],
+                "summary": "Look up an ELF code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ELF code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ELFCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/as-of": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The version of each entity in effect at asOf, in ID order; entities created later or deleted (or merged away) by then are left out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "List entities as of a point in time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pairs flagged by the duplicate scan (reasons SAME_LEI, SAME_REGISTRATION_NUMBER, SIMILAR_NAME), most similar names first, with both entities",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "List probable duplicate entities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "OPEN, MERGED or DISMISSED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only pairs this entity is part of",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/duplicates/scan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Flags pairs of entities sharing an LEI or registration number (ignoring case, spaces and punctuation) or with similar names, as a job; follow it at /admin/tasks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "Scan entities for duplicates",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/duplicates/{id}/dismiss": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks an OPEN pair DISMISSED; later scans do not flag it again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "Dismiss a duplicate pair",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Duplicate pair ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EntityDuplicate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/enrich": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Runs the enrichment for every entity with an LEI as a job; follow it at /admin/tasks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "Enrich all entities from LEI",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also replace fields maintained by hand",
+                        "name": "overwrite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves the merged entity's accounts, SSIs, addresses and (if the survivor has none) onboarding to the survivor, fills the survivor's empty LEI, legal form and type from it, and soft-deletes it. The merge is recorded with a snapshot of the merged entity; a flagged pair of the two becomes MERGED. Entities with an onboarding in progress cannot be merged (409).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "Merge entities",
+                "parameters": [
+                    {
+                        "description": "Survivor and merged entity",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MergeEntitiesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.EntityMerge"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/merges": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Audit of merges, newest first, each with a snapshot of the merged entity and the counts of accounts, SSIs, addresses and onboardings moved",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "List entity merges",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only merges this entity survived or was merged in",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/{id}/enrich": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fills name, legal_form and the REGISTERED address from the LEI record of the entity's LEI, recording each filled field in auto_filled. Fields edited by hand since they were filled (or never filled) are left alone unless overwrite=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "Enrich entity from LEI",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also replace fields maintained by hand",
+                        "name": "overwrite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.EntityEnrichment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/entities/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Every version of an entity's own fields (not its addresses), oldest first, each valid from valid_from until valid_to (null while current). With asOf, only the version in effect then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "entities"
+                ],
+                "summary": "Get entity versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "When asOf is given",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MasterDataVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exchanges and market segments of the MIC list in MIC order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchanges"
+                ],
+                "summary": "List exchanges",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 3166-1 alpha-2 country code",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "MIC, acronym or part of the market name",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only MICs that have not expired",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Loads the ISO 10383 MIC list as published in CSV (comma, semicolon or tab separated), sent as the multipart field \"file\" or as the request body. The header row must name the MIC, operating MIC, market name, country code and status columns. Exchanges are upserted by MIC and invalid rows reported. Once the list has entries, instrument MICs must be listed in it and not expired.",
+                "consumes": [
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchanges"
+                ],
+                "summary": "Import the MIC list",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "MIC list file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.MICImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Downloads the MIC list from referencedata.miclisturl and imports it. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchanges"
+                ],
+                "summary": "Refresh the MIC list",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{mic}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks the MIC against the ISO 10383 structure (400 with the reason if invalid) and returns its exchange, expired or not.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchanges"
+                ],
+                "summary": "Look up a MIC",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC",
+                        "name": "mic",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Exchange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "List scheduled exports",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ScheduledExport"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exports the records of kind (countries, currencies, entities, instruments, accounts, ssis or lei) matching filters, the filters of the kind's list endpoint (as for POST /data/export), or the saved LEI view saved_view_id. Runs DAILY, WEEKLY or MONTHLY, writing CSV, XLSX or JSON to the destination: FILE (under export.dir), SFTP (uploaded to sftp_directory on sftp_host:sftp_port as sftp_username, with the configured key or password) or S3 (uploaded to the archive bucket under s3_prefix). Without next_run_at the first run happens within a minute. Callers who get any field the export writes masked (masking.rules) are refused, as the file is not masked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Create scheduled export",
+                "parameters": [
+                    {
+                        "description": "Scheduled export",
+                        "name": "export",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledExport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Get scheduled export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledExport"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Update scheduled export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scheduled export",
+                        "name": "export",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledExport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Delete scheduled export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/schedules/{id}/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Run scheduled export now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scheduled export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduledExport"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/fx/convert": {
+            "get": {
+                "description": "Converts with the rates of the latest date on or before the date asked for (today by default), as for GET /fx/rates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fx"
+                ],
+                "summary": "Convert an amount",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Amount in the from currency",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From currency (ISO 4217)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To currency (ISO 4217)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD); defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.FXConversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/fx/history": {
+            "get": {
+                "description": "Rate of quote per 1 base on each date the source has rates for, in date order; at most a year at a time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fx"
+                ],
+                "summary": "Get FX rate history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base currency (ISO 4217); defaults to the source's",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote currency (ISO 4217)",
+                        "name": "quote",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD); defaults to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD); defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/fx/rates": {
+            "get": {
+                "description": "Rates of the latest date on or before the date asked for (today by default), looking back up to a week over weekends and holidays, as units of each currency per 1 base. A base other than the source's (EUR for the ECB) is derived as a cross rate.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fx"
+                ],
+                "summary": "Get FX rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base currency (ISO 4217); defaults to the source's",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD); defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.FXRateSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/fx/sync": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Downloads referencedata.fxrateurl (the latest rates) or, with history=true, referencedata.fxhistoryurl (past rates, e.g. to fill a gap) and stores the rates, replacing those already stored for the same dates. Runs in the background; follow it at /admin/tasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fx"
+                ],
+                "summary": "Sync FX rates",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Load the history file instead of the latest rates",
+                        "name": "history",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/instruments/as-of": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The version of each instrument in effect at asOf, in ID order; instruments created later or deleted by then are left out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "instruments"
+                ],
+                "summary": "List instruments as of a point in time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/instruments/prices/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upserts prices by (instrument, date, source). Invalid rows are reported and skipped; valid rows are stored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Bulk import instrument prices",
+                "parameters": [
+                    {
+                        "description": "Prices to import",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ImportPricesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.PriceImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/instruments/prices/providers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "List price providers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/instruments/prices/sync": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Sync prices from a provider",
+                "parameters": [
+                    {
+                        "description": "Provider and date",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SyncPricesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/instruments/{id}/prices/latest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Most recent price on or before asOf (default today), optionally from a single source",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prices"
+                ],
+                "summary": "Latest instrument price",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instrument ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price source (e.g. MANUAL or a provider name)",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.InstrumentPrice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/instruments/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Every version of an instrument, oldest first, each valid from valid_from until valid_to (null while current). With asOf, only the version in effect then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "instruments"
+                ],
+                "summary": "Get instrument versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instrument ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "When asOf is given",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MasterDataVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lei/views": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "List saved LEI views",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LEISavedView"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stores the filters accepted by GET /lei (search, q, status, category, country, sort_by, sort_order) under a unique name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Create saved LEI view",
+                "parameters": [
+                    {
+                        "description": "Saved view",
+                        "name": "view",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LEISavedView"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LEISavedView"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lei/views/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Get saved LEI view",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved view ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.LEISavedView"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Delete saved LEI view",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved view ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/onboarding": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Onboardings newest first, with their entity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "List counterparty onboardings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DRAFT, ENRICHED, SSIS_VALIDATED, APPROVED or REJECTED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates the entity with its accounts and SSIs, all inactive until the onboarding is approved. If any of them cannot be created, none are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Draft counterparty onboarding",
+                "parameters": [
+                    {
+                        "description": "Entity, accounts and SSIs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.OnboardingDraft"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Onboarding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/onboarding/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The onboarding with its entity and every action taken on it, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Get counterparty onboarding",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Onboarding ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Onboarding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/onboarding/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "SSIS_VALIDATED -\u003e APPROVED. Activates the entity, its accounts and SSIs. The approver must not be the user who drafted the onboarding.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Approve counterparty onboarding",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Onboarding ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Approval note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OnboardingNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Onboarding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/onboarding/{id}/enrich": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "DRAFT (or ENRICHED) -\u003e ENRICHED. Fills name, legal form and registered address from the entity's LEI record. An entity without an LEI can only move on with skip set, with a note saying why.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Enrich onboarding from LEI",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Onboarding ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Skip enrichment for an entity without an LEI",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.EnrichOnboardingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Onboarding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/onboarding/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Any open status -\u003e REJECTED. Deletes the entity, its accounts and SSIs; the onboarding and its audit trail are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Reject counterparty onboarding",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Onboarding ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the rejection",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OnboardingNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Onboarding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/onboarding/{id}/validate-ssis": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ENRICHED (or SSIS_VALIDATED) -\u003e SSIS_VALIDATED. The entity needs at least one SSI, and each needs its beneficiary, a valid IBAN (if the account is one), valid BICs, an active settlement currency and a validity window that has not ended. On failure the onboarding stays where it is and the failures are returned per SSI.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "Validate onboarding SSIs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Onboarding ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Onboarding"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ssis/conflicts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pairs of active SSIs for the same entity, settlement currency, instrument and settlement type whose validity windows overlap, with the overlap. Such pairs make the SSI for a settlement ambiguous; they predate the overlap check, were saved under the flag policy or were activated together by an onboarding approval.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "List conflicting SSIs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ssis/defaults": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Default place of settlement, settlement type and cutoff by market (instrument primary exchange) and/or settlement currency, by market then currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "List SSI default rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A rule is scoped to a market, a settlement currency or both, one rule per scope, and sets at least one of place_of_settlement (a BIC), settlement_type and cutoff_time (HH:MM, in cutoff_timezone, UTC if unset)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "Create SSI default rule",
+                "parameters": [
+                    {
+                        "description": "Rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SSIDefaultRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SSIDefaultRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ssis/defaults/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Finds the most specific active rule for the SSI's instrument market and settlement currency (market and currency, then market, then currency) and returns the fields it would fill, those the SSI leaves empty, and the SSI as it would be created. Nothing is saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "Preview SSI defaults",
+                "parameters": [
+                    {
+                        "description": "SSI to create",
+                        "name": "ssi",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SSI"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.SSIDefaultsPreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ssis/defaults/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "Get SSI default rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SSIDefaultRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Existing SSIs keep the defaults they were created with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "Update SSI default rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SSIDefaultRule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SSIDefaultRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Existing SSIs keep the defaults they were created with",
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "Delete SSI default rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ssis/effective": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The active SSI of the entity in the settlement currency whose validity window (from valid_from up to but excluding valid_to) contains asOf. With instrument, an SSI for that instrument wins over one for any instrument; without it, only SSIs for any instrument are considered. When several SSIs apply equally, 409 lists them. With knownAt, the SSIs are taken as they were recorded at that time, reproducing what the lookup returned then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "Resolve the SSI in effect for a settlement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "entity",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Settlement currency ISO 4217 code",
+                        "name": "currency",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Settlement date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp; defaults to now",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resolve from the SSIs as recorded at this time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "knownAt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Instrument ID",
+                        "name": "instrument",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DVP, FOP, RVP or DAP",
+                        "name": "settlement_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SSI"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ssis/{id}/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renders the SSI as an ISO 20022 message fragment to paste into a settlement message: pacs.008 gives the creditor agent, creditor (with the entity's address and LEI), creditor account and any intermediary agent of a CdtTrfTxInf; sese.023 gives the RcvgSttlmPties chain from the intermediary or beneficiary bank down to the beneficiary and its safekeeping account. Without message, an SSI with a settlement type is exported as sese.023 and any other as pacs.008. XML is not masked, so callers who get any field of the SSI, its entity or the entity's addresses masked (masking.rules) are refused.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "Export an SSI as ISO 20022",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SSI ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pacs.008 or sese.023",
+                        "name": "message",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "XML fragment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ssis/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Every recorded version of an SSI, oldest first, each valid from valid_from until valid_to (null while current). These are when the SSI was known in each state, not its own validity window, which is part of each snapshot. With asOf, only the version recorded then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ssis"
+                ],
+                "summary": "Get SSI versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SSI ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "When asOf is given",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MasterDataVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/validate/iban/{iban}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks an IBAN, with or without spaces, against its country's IBAN length and the ISO 7064 mod-97 check digits. An invalid IBAN is still a 200, with valid false and the reason in error; a valid one is broken into country code, check digits and BBAN and formatted in groups of four.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validation"
+                ],
+                "summary": "Validate an IBAN",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IBAN",
+                        "name": "iban",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.IBANCheck"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Account": {
+            "type": "object",
+            "required": [
+                "account_number"
+            ],
+            "properties": {
+                "account_currency": {
+                    "$ref": "#/definitions/domain.Currency"
+                },
+                "account_currency_id": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "active": {
+                    "type": "boolean"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "$ref": "#/definitions/domain.Entity"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "review_reason": {
+                    "type": "string"
+                },
+                "review_required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.AccountType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AccountType": {
+            "type": "string",
+            "enum": [
+                "TRADING",
+                "SETTLEMENT",
+                "CUSTODY",
+                "MARGIN"
+            ],
+            "x-enum-varnames": [
+                "AccountTypeTrading",
+                "AccountTypeSettlement",
+                "AccountTypeCustody",
+                "AccountTypeMargin"
+            ]
+        },
+        "domain.Address": {
+            "type": "object",
+            "properties": {
+                "address_line_1": {
+                    "description": "Unstructured address lines (ISO20022 allows up to 7 lines)",
+                    "type": "string"
+                },
+                "address_line_2": {
+                    "type": "string"
+                },
+                "address_line_3": {
+                    "type": "string"
+                },
+                "address_line_4": {
+                    "type": "string"
+                },
+                "address_line_5": {
+                    "type": "string"
+                },
+                "address_line_6": {
+                    "type": "string"
+                },
+                "address_line_7": {
+                    "type": "string"
+                },
+                "address_type": {
+                    "description": "Structured address fields (ISO20022)",
+                    "type": "string"
+                },
+                "building_name": {
+                    "description": "Building name",
+                    "type": "string"
+                },
+                "building_number": {
+                    "description": "Building number",
+                    "type": "string"
+                },
+                "country": {
+                    "$ref": "#/definitions/domain.Country"
+                },
+                "country_id": {
+                    "type": "string"
+                },
+                "country_sub_division": {
+                    "description": "State/province/region",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "department": {
+                    "description": "Department",
+                    "type": "string"
+                },
+                "district_name": {
+                    "description": "District name",
+                    "type": "string"
+                },
+                "floor": {
+                    "description": "Floor",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "post_box": {
+                    "description": "Post office box number",
+                    "type": "string"
+                },
+                "postal_code": {
+                    "description": "Postal code/ZIP code",
+                    "type": "string"
+                },
+                "review_reason": {
+                    "type": "string"
+                },
+                "review_required": {
+                    "description": "set by reference data cascades; see dq_findings",
+                    "type": "boolean"
+                },
+                "room": {
+                    "description": "Room",
+                    "type": "string"
+                },
+                "street_name": {
+                    "description": "Street name",
+                    "type": "string"
+                },
+                "sub_department": {
+                    "description": "Sub-department",
+                    "type": "string"
+                },
+                "town_location_name": {
+                    "description": "Town location name",
+                    "type": "string"
+                },
+                "town_name": {
+                    "description": "Town/city name",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BICDirectoryEntry": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "false once a full import no longer lists it",
+                    "type": "boolean"
+                },
+                "bic": {
+                    "description": "BIC11; a head office has branch code XXX",
+                    "type": "string"
+                },
+                "branch_name": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "imported_at": {
+                    "description": "last import that listed it",
+                    "type": "string"
+                },
+                "institution_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CalendarHoliday": {
+            "type": "object",
+            "properties": {
+                "calendar_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CodeType": {
+            "type": "string",
+            "enum": [
+                "ISIN",
+                "FIGI",
+                "CUSIP",
+                "WKN",
+                "SEDOL",
+                "RIC",
+                "TICKER",
+                "BLOOMBERG"
+            ],
+            "x-enum-varnames": [
+                "CodeTypeISIN",
+                "CodeTypeFIGI",
+                "CodeTypeCUSIP",
+                "CodeTypeWKN",
+                "CodeTypeSEDOL",
+                "CodeTypeRIC",
+                "CodeTypeTicker",
+                "CodeTypeBloomberg"
+            ]
+        },
+        "domain.Country": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "alpha3_code": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "numeric_code": {
+                    "description": "ISO 3166-1 numeric, e.g. 040",
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "sub_region": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Currency": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "decimal_places": {
+                    "description": "ISO 4217 minor units; 0 where not applicable",
+                    "type": "integer"
+                },
+                "fund": {
+                    "description": "ISO 4217 fund code, e.g. CLF",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "numeric_code": {
+                    "description": "ISO 4217 numeric, e.g. 978",
+                    "type": "string"
+                },
+                "precious_metal": {
+                    "description": "XAU, XAG, XPT, XPD",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DQFinding": {
+            "type": "object",
+            "properties": {
+                "cause": {
+                    "description": "what raised the finding, e.g. \"currency:HRK deactivated\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "rule_code": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_table": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DataFreshness": {
+            "type": "object",
+            "properties": {
+                "cache_ttl": {
+                    "type": "string"
+                },
+                "change_sequence": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "last_imports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportFreshness"
+                    }
+                },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TableFreshness"
+                    }
+                }
+            }
+        },
+        "domain.DeadLetter": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "type of work within the source, e.g. event type",
+                    "type": "string"
+                },
+                "last_failed_at": {
+                    "type": "string"
+                },
+                "last_retried_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "subsystem that produced the work, e.g. lei-refresh",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ELFCode": {
+            "type": "object",
+            "properties": {
+                "abbreviations": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "imported_at": {
+                    "description": "last import that listed it",
+                    "type": "string"
+                },
+                "jurisdiction": {
+                    "type": "string"
+                },
+                "modification_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ELFName"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subdivision_code": {
+                    "description": "ISO 3166-2, for sub-national legal forms",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ELFName": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "language_code": {
+                    "description": "ISO 639-1",
+                    "type": "string"
+                },
+                "local_abbreviations": {
+                    "description": "semicolon separated, as listed",
+                    "type": "string"
+                },
+                "local_name": {
+                    "type": "string"
+                },
+                "transliterated_abbreviations": {
+                    "type": "string"
+                },
+                "transliterated_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Entity": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EntityAddress"
+                    }
+                },
+                "auto_filled": {
+                    "description": "Fields filled in automatically (e.g. from the LEI store); maintained by the enrichment, never by writes",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EntityFieldProvenance"
+                    }
+                },
+                "business_id": {
+                    "description": "firm-specific identifier, generated on create when an ENTITY ID sequence is active",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "legal_form": {
+                    "description": "ISO 20275 ELF code",
+                    "type": "string"
+                },
+                "lei": {
+                    "description": "the entity's Legal Entity Identifier, if known",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "registration_number": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.EntityType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EntityAddress": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/domain.Address"
+                },
+                "address_id": {
+                    "type": "string"
+                },
+                "address_type": {
+                    "description": "e.g., 'REGISTERED', 'TRADING', 'BILLING', 'CORRESPONDENCE'",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "$ref": "#/definitions/domain.Entity"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EntityDuplicate": {
+            "type": "object",
+            "properties": {
+                "detected_at": {
+                    "type": "string"
+                },
+                "duplicate": {
+                    "$ref": "#/definitions/domain.Entity"
+                },
+                "duplicate_id": {
+                    "type": "string"
+                },
+                "entity": {
+                    "$ref": "#/definitions/domain.Entity"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name_similarity": {
+                    "description": "0-1, on normalized names",
+                    "type": "number"
+                },
+                "reasons": {
+                    "description": "comma-separated, e.g. SAME_LEI,SIMILAR_NAME",
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EntityFieldProvenance": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "filled_at": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "e.g. LEI",
+                    "type": "string"
+                },
+                "source_ref": {
+                    "description": "e.g. the LEI the value came from",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "value filled in; for an address, its fields joined by |",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EntityMerge": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "accounts moved to the survivor",
+                    "type": "integer"
+                },
+                "addresses": {
+                    "description": "addresses moved to the survivor",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filled_fields": {
+                    "description": "comma-separated survivor fields taken from the merged entity",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "merged_by": {
+                    "type": "string"
+                },
+                "merged_id": {
+                    "type": "string"
+                },
+                "merged_snapshot": {
+                    "type": "string"
+                },
+                "onboardings": {
+                    "description": "onboardings moved to the survivor (0 or 1)",
+                    "type": "integer"
+                },
+                "ssis": {
+                    "description": "SSIs moved to the survivor",
+                    "type": "integer"
+                },
+                "survivor_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EntityType": {
+            "type": "string",
+            "enum": [
+                "COMPANY",
+                "BUSINESS",
+                "CORPORATION",
+                "PARTNERSHIP",
+                "INDIVIDUAL"
+            ],
+            "x-enum-varnames": [
+                "EntityTypeCompany",
+                "EntityTypeBusiness",
+                "EntityTypeCorporation",
+                "EntityTypePartnership",
+                "EntityTypeIndividual"
+            ]
+        },
+        "domain.Exchange": {
+            "type": "object",
+            "properties": {
+                "acronym": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "creation_date": {
+                    "type": "string"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "imported_at": {
+                    "description": "last import that listed it",
+                    "type": "string"
+                },
+                "last_update_date": {
+                    "type": "string"
+                },
+                "legal_entity_name": {
+                    "type": "string"
+                },
+                "lei": {
+                    "type": "string"
+                },
+                "market_category_code": {
+                    "description": "e.g. RMKT (regulated market), MLTF",
+                    "type": "string"
+                },
+                "market_type": {
+                    "description": "OPRT or SGMT",
+                    "type": "string"
+                },
+                "mic": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operating_mic": {
+                    "description": "the MIC itself for an operating MIC",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FileProcessingStatus": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current_source_file": {
+                    "$ref": "#/definitions/domain.SourceFile"
+                },
+                "current_source_file_id": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_type": {
+                    "description": "DAILY_FULL, DAILY_DELTA, MANUAL",
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_success_at": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "IDLE, RUNNING, COMPLETED, FAILED",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HolidayCalendar": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CalendarHoliday"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weekend_days": {
+                    "description": "comma separated, e.g. SAT,SUN or FRI,SAT",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.IDSequence": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "check_digit": {
+                    "description": "NONE, LUHN or MOD97",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_value": {
+                    "description": "last value of the range; 0 means unbounded",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_value": {
+                    "type": "integer"
+                },
+                "padding": {
+                    "type": "integer"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "target": {
+                    "description": "ENTITY or ACCOUNT",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.IdentifierLevel": {
+            "type": "string",
+            "enum": [
+                "INTERNATIONAL",
+                "REGIONAL",
+                "LOCAL"
+            ],
+            "x-enum-comments": {
+                "IdentifierLevelInternational": "ISIN, FIGI",
+                "IdentifierLevelLocal": "Exchange-specific codes",
+                "IdentifierLevelRegional": "CUSIP, WKN"
+            },
+            "x-enum-varnames": [
+                "IdentifierLevelInternational",
+                "IdentifierLevelRegional",
+                "IdentifierLevelLocal"
+            ]
+        },
+        "domain.ImportFreshness": {
+            "type": "object",
+            "properties": {
+                "job_type": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_success_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ImportJob": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_rows": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "description": "validated and compared only; the counts say what the import would do",
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "why the file could not be read or the import stopped",
+                    "type": "string"
+                },
+                "failed_rows": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ignored_columns": {
+                    "description": "columns the kind does not have",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "report": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportRowResult"
+                    }
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "unchanged_rows": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "created, updated, unchanged or failed",
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "fields an update changes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "description": "why the row failed",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "the record created or updated (none for a dry-run create)",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "the row's natural key or ID, to find it in the file",
+                    "type": "string"
+                },
+                "row": {
+                    "description": "line in a CSV file (the header is line 1), position in a JSON array (from 1)",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Instrument": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "cfi": {
+                    "description": "ISO 10962 classification; sets Type when it maps to one",
+                    "type": "string"
+                },
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.InstrumentCode"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issue_currency": {
+                    "$ref": "#/definitions/domain.Currency"
+                },
+                "issue_currency_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "primary_exchange": {
+                    "type": "string"
+                },
+                "review_reason": {
+                    "type": "string"
+                },
+                "review_required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.InstrumentType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InstrumentCode": {
+            "type": "object",
+            "properties": {
+                "code_type": {
+                    "$ref": "#/definitions/domain.CodeType"
+                },
+                "code_value": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "identifier_level": {
+                    "$ref": "#/definitions/domain.IdentifierLevel"
+                },
+                "instrument": {
+                    "$ref": "#/definitions/domain.Instrument"
+                },
+                "instrument_id": {
+                    "type": "string"
+                },
+                "market_identifier_code": {
+                    "description": "MIC code (e.g., XNAS, XFRA)",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "For regional codes (e.g., US, DE)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InstrumentPrice": {
+            "type": "object",
+            "required": [
+                "instrument_id",
+                "price_date"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "$ref": "#/definitions/domain.Currency"
+                },
+                "currency_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instrument": {
+                    "$ref": "#/definitions/domain.Instrument"
+                },
+                "instrument_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_date": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InstrumentType": {
+            "type": "string",
+            "enum": [
+                "EQUITY",
+                "BOND",
+                "DERIVATIVE",
+                "COMMODITY",
+                "FUND",
+                "FOREX"
+            ],
+            "x-enum-varnames": [
+                "InstrumentTypeEquity",
+                "InstrumentTypeBond",
+                "InstrumentTypeDerivative",
+                "InstrumentTypeCommodity",
+                "InstrumentTypeFund",
+                "InstrumentTypeForex"
+            ]
+        },
+        "domain.IntegrityCheckResult": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "findings_raised": {
+                    "description": "new OPEN findings; orphans already under an open finding are not raised again",
+                    "type": "integer"
+                },
+                "orphans": {
+                    "type": "integer"
+                },
+                "referenced_table": {
+                    "type": "string"
+                },
+                "repairable": {
+                    "description": "orphans are soft-deleted when the check runs with repair",
+                    "type": "boolean"
+                },
+                "repaired": {
+                    "type": "integer"
+                },
+                "rule_code": {
+                    "type": "string"
+                },
+                "table": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.IntegrityReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.IntegrityCheckResult"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "findings_raised": {
+                    "type": "integer"
+                },
+                "repair": {
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "total_orphans": {
+                    "type": "integer"
+                },
+                "total_repaired": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LEIChangeDetection": {
+            "type": "object",
+            "properties": {
+                "field_name": {
+                    "type": "string"
+                },
+                "new_value": {},
+                "old_value": {}
+            }
+        },
+        "domain.LEIFuzzyMatch": {
+            "type": "object",
+            "required": [
+                "lei"
+            ],
+            "properties": {
+                "changed_fields": {
+                    "description": "Last change details",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Standard fields",
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "entity_category": {
+                    "type": "string"
+                },
+                "entity_legal_form": {
+                    "description": "ISO 20275 ELF code",
+                    "type": "string"
+                },
+                "entity_legal_form_name": {
+                    "description": "resolved from the ELF code list in API responses",
+                    "type": "string"
+                },
+                "entity_status": {
+                    "type": "string"
+                },
+                "entity_sub_category": {
+                    "type": "string"
+                },
+                "hq_address_city": {
+                    "type": "string"
+                },
+                "hq_address_country": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string"
+                },
+                "hq_address_line_1": {
+                    "description": "Headquarters address",
+                    "type": "string"
+                },
+                "hq_address_line_2": {
+                    "type": "string"
+                },
+                "hq_address_line_3": {
+                    "type": "string"
+                },
+                "hq_address_line_4": {
+                    "type": "string"
+                },
+                "hq_address_postal_code": {
+                    "type": "string"
+                },
+                "hq_address_region": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "initial_registration_date": {
+                    "description": "Dates",
+                    "type": "string"
+                },
+                "last_update_date": {
+                    "type": "string"
+                },
+                "legal_address_city": {
+                    "type": "string"
+                },
+                "legal_address_country": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string"
+                },
+                "legal_address_line_1": {
+                    "description": "Legal address",
+                    "type": "string"
+                },
+                "legal_address_line_2": {
+                    "type": "string"
+                },
+                "legal_address_line_3": {
+                    "type": "string"
+                },
+                "legal_address_line_4": {
+                    "type": "string"
+                },
+                "legal_address_postal_code": {
+                    "type": "string"
+                },
+                "legal_address_region": {
+                    "type": "string"
+                },
+                "legal_name": {
+                    "description": "Entity information",
+                    "type": "string"
+                },
+                "lei": {
+                    "description": "Legal Entity Identifier (unique)",
+                    "type": "string"
+                },
+                "managing_lou": {
+                    "description": "Associated entities",
+                    "type": "string"
+                },
+                "managing_lou_name": {
+                    "description": "resolved from the LEI issuer list in API responses",
+                    "type": "string"
+                },
+                "next_renewal_date": {
+                    "type": "string"
+                },
+                "other_names": {
+                    "description": "Array of alternative names",
+                    "type": "string"
+                },
+                "registration_authority": {
+                    "description": "Registration",
+                    "type": "string"
+                },
+                "registration_authority_id": {
+                    "type": "string"
+                },
+                "registration_authority_jurisdiction": {
+                    "type": "string"
+                },
+                "registration_authority_name": {
+                    "description": "Resolved from the GLEIF RA list in API responses",
+                    "type": "string"
+                },
+                "registration_number": {
+                    "type": "string"
+                },
+                "registration_status": {
+                    "description": "LEI registration: ISSUED, LAPSED, RETIRED, ...",
+                    "type": "string"
+                },
+                "similarity": {
+                    "type": "number"
+                },
+                "source_file": {
+                    "$ref": "#/definitions/domain.SourceFile"
+                },
+                "source_file_id": {
+                    "description": "Audit and provenance",
+                    "type": "string"
+                },
+                "successor_lei": {
+                    "type": "string"
+                },
+                "transliterated_legal_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "validation_authority": {
+                    "type": "string"
+                },
+                "validation_sources": {
+                    "description": "Validation",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LEIQualityMetric": {
+            "type": "object",
+            "properties": {
+                "completeness": {
+                    "description": "populated as a percentage of records",
+                    "type": "number"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "populated": {
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "description": "nil for fields only measured for completeness",
+                    "type": "integer"
+                },
+                "validity": {
+                    "description": "valid as a percentage of populated",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.LEIQuarantinedRecord": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "description": "PostgreSQL SQLSTATE, e.g. 22001 (DATABASE only)",
+                    "type": "string"
+                },
+                "error_message": {
+                    "description": "the rejection, or one reason per failed rule",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lei": {
+                    "type": "string"
+                },
+                "record_snapshot": {
+                    "description": "JSON; text so malformed content can still be stored",
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "rule_codes": {
+                    "description": "comma-separated failed DQ rules (RULES only)",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "DATABASE or RULES",
+                    "type": "string"
+                },
+                "source_file_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LEIReconciliationResult": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "difference": {
+                    "description": "local minus GLEIF",
+                    "type": "integer"
+                },
+                "drift_percent": {
+                    "description": "|difference| as a percentage of the GLEIF count",
+                    "type": "number"
+                },
+                "exceeded": {
+                    "description": "drift above the configured threshold",
+                    "type": "boolean"
+                },
+                "gleif_count": {
+                    "type": "integer"
+                },
+                "gleif_publish_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "local_count": {
+                    "type": "integer"
+                },
+                "run_id": {
+                    "type": "string"
+                },
+                "scope": {
+                    "description": "TOTAL or an ISO 3166-1 alpha-2 country code",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LEIRecord": {
+            "type": "object",
+            "required": [
+                "lei"
+            ],
+            "properties": {
+                "changed_fields": {
+                    "description": "Last change details",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Standard fields",
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "entity_category": {
+                    "type": "string"
+                },
+                "entity_legal_form": {
+                    "description": "ISO 20275 ELF code",
+                    "type": "string"
+                },
+                "entity_legal_form_name": {
+                    "description": "resolved from the ELF code list in API responses",
+                    "type": "string"
+                },
+                "entity_status": {
+                    "type": "string"
+                },
+                "entity_sub_category": {
+                    "type": "string"
+                },
+                "hq_address_city": {
+                    "type": "string"
+                },
+                "hq_address_country": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string"
+                },
+                "hq_address_line_1": {
+                    "description": "Headquarters address",
+                    "type": "string"
+                },
+                "hq_address_line_2": {
+                    "type": "string"
+                },
+                "hq_address_line_3": {
+                    "type": "string"
+                },
+                "hq_address_line_4": {
+                    "type": "string"
+                },
+                "hq_address_postal_code": {
+                    "type": "string"
+                },
+                "hq_address_region": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "initial_registration_date": {
+                    "description": "Dates",
+                    "type": "string"
+                },
+                "last_update_date": {
+                    "type": "string"
+                },
+                "legal_address_city": {
+                    "type": "string"
+                },
+                "legal_address_country": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string"
+                },
+                "legal_address_line_1": {
+                    "description": "Legal address",
+                    "type": "string"
+                },
+                "legal_address_line_2": {
+                    "type": "string"
+                },
+                "legal_address_line_3": {
+                    "type": "string"
+                },
+                "legal_address_line_4": {
+                    "type": "string"
+                },
+                "legal_address_postal_code": {
+                    "type": "string"
+                },
+                "legal_address_region": {
+                    "type": "string"
+                },
+                "legal_name": {
+                    "description": "Entity information",
+                    "type": "string"
+                },
+                "lei": {
+                    "description": "Legal Entity Identifier (unique)",
+                    "type": "string"
+                },
+                "managing_lou": {
+                    "description": "Associated entities",
+                    "type": "string"
+                },
+                "managing_lou_name": {
+                    "description": "resolved from the LEI issuer list in API responses",
+                    "type": "string"
+                },
+                "next_renewal_date": {
+                    "type": "string"
+                },
+                "other_names": {
+                    "description": "Array of alternative names",
+                    "type": "string"
+                },
+                "registration_authority": {
+                    "description": "Registration",
+                    "type": "string"
+                },
+                "registration_authority_id": {
+                    "type": "string"
+                },
+                "registration_authority_jurisdiction": {
+                    "type": "string"
+                },
+                "registration_authority_name": {
+                    "description": "Resolved from the GLEIF RA list in API responses",
+                    "type": "string"
+                },
+                "registration_number": {
+                    "type": "string"
+                },
+                "registration_status": {
+                    "description": "LEI registration: ISSUED, LAPSED, RETIRED, ...",
+                    "type": "string"
+                },
+                "source_file": {
+                    "$ref": "#/definitions/domain.SourceFile"
+                },
+                "source_file_id": {
+                    "description": "Audit and provenance",
+                    "type": "string"
+                },
+                "successor_lei": {
+                    "type": "string"
+                },
+                "transliterated_legal_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "validation_authority": {
+                    "type": "string"
+                },
+                "validation_sources": {
+                    "description": "Validation",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LEIRecordAudit": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "CREATE, UPDATE, DELETE",
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "changed_fields": {
+                    "description": "Change details",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lei": {
+                    "type": "string"
+                },
+                "lei_record_id": {
+                    "type": "string"
+                },
+                "record_snapshot": {
+                    "description": "Complete record snapshot ({} on UPDATE rows written in diff audit mode)",
+                    "type": "string"
+                },
+                "source_file_id": {
+                    "description": "Source information",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LEISavedView": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "entity category, e.g. FUND",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "legal address country",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "email of the creating user",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "q": {
+                    "description": "full-text query on legal name",
+                    "type": "string"
+                },
+                "search": {
+                    "description": "LEI code or legal name substring",
+                    "type": "string"
+                },
+                "sort_by": {
+                    "description": "see GET /lei sortBy",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "asc or desc",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "entity status, e.g. ACTIVE",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LOU": {
+            "type": "object",
+            "properties": {
+                "accreditation_date": {
+                    "type": "string"
+                },
+                "accredited": {
+                    "description": "listed by GLEIF at the last sync",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "lei": {
+                    "description": "the LOU's own LEI, as in LEIRecord.ManagingLOU",
+                    "type": "string"
+                },
+                "marketing_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "synced_at": {
+                    "description": "last sync that listed it",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MasterDataVersion": {
+            "type": "object",
+            "properties": {
+                "domain": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "record_id": {
+                    "type": "string"
+                },
+                "snapshot": {
+                    "description": "the row, keyed by column name",
+                    "type": "object"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "description": "nil while current",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Onboarding": {
+            "type": "object",
+            "properties": {
+                "approved_at": {
+                    "type": "string"
+                },
+                "approved_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "$ref": "#/definitions/domain.Entity"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OnboardingEvent"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OnboardingEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "description": "e.g. fields filled, SSI validation failures",
+                    "type": "string"
+                },
+                "from_status": {
+                    "description": "empty for CREATE",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "onboarding_id": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "description": "equals FromStatus when the action failed",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RegistrationAuthority": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "RA followed by 6 digits, e.g. RA000665",
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "description": "ISO 3166-1 alpha-2",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "imported_at": {
+                    "description": "last import that listed it",
+                    "type": "string"
+                },
+                "jurisdiction": {
+                    "description": "country or region the register covers",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_local_name": {
+                    "type": "string"
+                },
+                "organization_name": {
+                    "description": "organization responsible for the register",
+                    "type": "string"
+                },
+                "register_local_name": {
+                    "type": "string"
+                },
+                "register_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SSI": {
+            "type": "object",
+            "required": [
+                "beneficiary_account",
+                "beneficiary_bank",
+                "beneficiary_name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "beneficiary_account": {
+                    "description": "encrypted at rest by the services with encryption.provider set",
+                    "type": "string"
+                },
+                "beneficiary_bank": {
+                    "type": "string"
+                },
+                "beneficiary_bank_bic": {
+                    "type": "string"
+                },
+                "beneficiary_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cutoff_time": {
+                    "description": "HH:MM in CutoffTimezone",
+                    "type": "string"
+                },
+                "cutoff_timezone": {
+                    "type": "string"
+                },
+                "default_rule_id": {
+                    "description": "the SSIDefaultRule that filled fields at creation",
+                    "type": "string"
+                },
+                "entity": {
+                    "$ref": "#/definitions/domain.Entity"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instrument": {
+                    "$ref": "#/definitions/domain.Instrument"
+                },
+                "instrument_id": {
+                    "type": "string"
+                },
+                "intermediary_bank": {
+                    "type": "string"
+                },
+                "intermediary_bank_bic": {
+                    "type": "string"
+                },
+                "place_of_settlement": {
+                    "description": "PSET BIC, usually a CSD",
+                    "type": "string"
+                },
+                "review_reason": {
+                    "type": "string"
+                },
+                "review_required": {
+                    "type": "boolean"
+                },
+                "settlement_currency": {
+                    "$ref": "#/definitions/domain.Currency"
+                },
+                "settlement_currency_id": {
+                    "type": "string"
+                },
+                "settlement_type": {
+                    "$ref": "#/definitions/domain.SettlementType"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SSIDefaultRule": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "$ref": "#/definitions/domain.Currency"
+                },
+                "currency_id": {
+                    "description": "nil matches any",
+                    "type": "string"
+                },
+                "cutoff_time": {
+                    "description": "HH:MM in CutoffTimezone",
+                    "type": "string"
+                },
+                "cutoff_timezone": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "market": {
+                    "description": "instrument primary exchange, e.g. XLON; nil matches any",
+                    "type": "string"
+                },
+                "place_of_settlement": {
+                    "description": "PSET BIC",
+                    "type": "string"
+                },
+                "settlement_type": {
+                    "$ref": "#/definitions/domain.SettlementType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ScheduledExport": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "destination": {
+                    "description": "FILE, SFTP or S3",
+                    "type": "string"
+                },
+                "filters": {
+                    "description": "filters of the kind's list endpoint",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "format": {
+                    "description": "CSV, XLSX, JSON or NDJSON",
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "DAILY, WEEKLY or MONTHLY",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "see ExportKind*; lei with a saved view",
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "description": "Outcome of the most recent run",
+                    "type": "string"
+                },
+                "last_run_error": {
+                    "type": "string"
+                },
+                "last_run_file": {
+                    "type": "string"
+                },
+                "last_run_rows": {
+                    "type": "integer"
+                },
+                "last_run_status": {
+                    "description": "SUCCEEDED or FAILED",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "recipients": {
+                    "description": "comma-separated email addresses told about each run",
+                    "type": "string"
+                },
+                "s3_prefix": {
+                    "description": "S3 destination: the archive bucket (archive.*)",
+                    "type": "string"
+                },
+                "saved_view": {
+                    "$ref": "#/definitions/domain.LEISavedView"
+                },
+                "saved_view_id": {
+                    "description": "a saved LEI view, instead of filters",
+                    "type": "string"
+                },
+                "sftp_directory": {
+                    "description": "must exist; default the login directory",
+                    "type": "string"
+                },
+                "sftp_host": {
+                    "description": "SFTP destination; credentials and host keys are configured (export.sftp)",
+                    "type": "string"
+                },
+                "sftp_port": {
+                    "description": "default 22",
+                    "type": "integer"
+                },
+                "sftp_username": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SchemaDriftIssue": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "db_size": {
+                    "type": "integer"
+                },
+                "db_type": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "model_size": {
+                    "type": "integer"
+                },
+                "model_type": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "table": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SchemaDriftReport": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SchemaDriftIssue"
+                    }
+                },
+                "tables_checked": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SettlementType": {
+            "type": "string",
+            "enum": [
+                "DVP",
+                "FOP",
+                "RVP",
+                "DAP"
+            ],
+            "x-enum-comments": {
+                "SettlementTypeDAP": "Delivery Against Payment",
+                "SettlementTypeDVP": "Delivery Versus Payment",
+                "SettlementTypeFOP": "Free Of Payment",
+                "SettlementTypeRVP": "Receive Versus Payment"
+            },
+            "x-enum-varnames": [
+                "SettlementTypeDVP",
+                "SettlementTypeFOP",
+                "SettlementTypeRVP",
+                "SettlementTypeDAP"
+            ]
+        },
+        "domain.SourceFile": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delta_type": {
+                    "description": "IntraDay, LastDay, LastWeek or LastMonth (DELTA files only)",
+                    "type": "string"
+                },
+                "downloaded_at": {
+                    "type": "string"
+                },
+                "failed_records": {
+                    "type": "integer"
+                },
+                "failure_category": {
+                    "description": "SCHEMA_ERROR, NETWORK_ERROR, FILE_CORRUPTION, STALLED, UNKNOWN",
+                    "type": "string"
+                },
+                "file_format": {
+                    "description": "JSON or XML (LEI-CDF)",
+                    "type": "string"
+                },
+                "file_hash": {
+                    "description": "SHA-256 hash",
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "type": "integer"
+                },
+                "file_type": {
+                    "description": "FULL, DELTA, REPEX",
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_processed_lei": {
+                    "description": "For resumption",
+                    "type": "string"
+                },
+                "max_retries": {
+                    "type": "integer"
+                },
+                "processed_records": {
+                    "type": "integer"
+                },
+                "processing_completed_at": {
+                    "type": "string"
+                },
+                "processing_error": {
+                    "type": "string"
+                },
+                "processing_started_at": {
+                    "type": "string"
+                },
+                "processing_status": {
+                    "description": "Processing status",
+                    "type": "string"
+                },
+                "publication_date": {
+                    "description": "GLEIF publish the file belongs to; delta catch-up resumes after the latest applied one",
+                    "type": "string"
+                },
+                "retry_count": {
+                    "description": "Retry tracking",
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TableFreshness": {
+            "type": "object",
+            "properties": {
+                "change_counter": {
+                    "description": "inserts + updates + deletes; never decreases, statistics resets included",
+                    "type": "integer"
+                },
+                "last_updated_at": {
+                    "type": "string"
+                },
+                "row_count": {
+                    "type": "integer"
+                },
+                "table": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.DismissQuarantinedRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.EnrichOnboardingRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "skip": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.IDSequenceResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "check_digit": {
+                    "description": "NONE, LUHN or MOD97",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_value": {
+                    "description": "last value of the range; 0 means unbounded",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_id": {
+                    "type": "string"
+                },
+                "next_value": {
+                    "type": "integer"
+                },
+                "padding": {
+                    "type": "integer"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "target": {
+                    "description": "ENTITY or ACCOUNT",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ImportPricesRequest": {
+            "type": "object",
+            "required": [
+                "prices"
+            ],
+            "properties": {
+                "prices": {
+                    "type": "array",
+                    "maxItems": 10000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/service.PriceImportRow"
+                    }
+                }
+            }
+        },
+        "handler.MergeEntitiesRequest": {
+            "type": "object",
+            "required": [
+                "merged_id",
+                "survivor_id"
+            ],
+            "properties": {
+                "merged_id": {
+                    "type": "string"
+                },
+                "survivor_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.OnboardingNoteRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PurgeDeadLettersRequest": {
+            "type": "object",
+            "properties": {
+                "older_than": {
+                    "description": "RFC3339 timestamp",
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RefreshLEIsRequest": {
+            "type": "object",
+            "required": [
+                "leis"
+            ],
+            "properties": {
+                "leis": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.RequeueQuarantinedRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "override_rules": {
+                    "description": "load even if the record fails data quality rules",
+                    "type": "boolean"
+                },
+                "record": {
+                    "description": "corrected record; omitted loads the snapshot as is",
+                    "type": "object"
+                }
+            }
+        },
+        "handler.SyncPricesRequest": {
+            "type": "object",
+            "required": [
+                "provider"
+            ],
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD, defaults to today",
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UpdateDeadLetterRequest": {
+            "type": "object",
+            "required": [
+                "payload"
+            ],
+            "properties": {
+                "payload": {
+                    "type": "object"
+                }
+            }
+        },
+        "handler.exportRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "filters": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "format": {
+                    "type": "string",
+                    "example": "csv"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "entities"
+                }
+            }
+        },
+        "handler.holidayRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "name"
+            ],
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.BICImportResult": {
+            "type": "object",
+            "properties": {
+                "deactivated": {
+                    "description": "BICs a full import no longer lists",
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "read": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "the first rejections",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRejection"
+                    }
+                },
+                "rejected_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.CountrySyncResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "read": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "the first rejections",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRejection"
+                    }
+                },
+                "rejected_count": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "soft-deleted countries, left deleted",
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.CurrencySyncResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "published": {
+                    "description": "publication date of the list, if it says",
+                    "type": "string"
+                },
+                "read": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "the first rejections",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRejection"
+                    }
+                },
+                "rejected_count": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "soft-deleted currencies, left deleted",
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.ELFImportResult": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "description": "codes",
+                    "type": "integer"
+                },
+                "inactive": {
+                    "description": "imported codes with status INAC",
+                    "type": "integer"
+                },
+                "read": {
+                    "description": "rows, one per code and language",
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "the first rejections",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRejection"
+                    }
+                },
+                "rejected_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.EntityEnrichment": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "$ref": "#/definitions/domain.Entity"
+                },
+                "filled": {
+                    "description": "fields set from the LEI record",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lei": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "description": "field -\u003e why it was left alone",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.FXConversion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "converted": {
+                    "type": "number"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "units of To per 1 From",
+                    "type": "number"
+                },
+                "rate_date": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.FXRateSet": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "description": "date asked for",
+                    "type": "string"
+                },
+                "base": {
+                    "type": "string"
+                },
+                "cross": {
+                    "description": "derived through the source's base currency",
+                    "type": "boolean"
+                },
+                "date": {
+                    "description": "date of the rates, on or before the date asked for",
+                    "type": "string"
+                },
+                "rates": {
+                    "description": "quote currency -\u003e units per 1 base",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "source": {
+                    "description": "publisher of the rates",
+                    "type": "string"
+                }
+            }
+        },
+        "service.IBANCheck": {
+            "type": "object",
+            "properties": {
+                "bban": {
+                    "description": "the domestic account number",
+                    "type": "string"
+                },
+                "check_digits": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "formatted": {
+                    "description": "in groups of four, as printed",
+                    "type": "string"
+                },
+                "iban": {
+                    "description": "normalized: upper case, no spaces",
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "service.ImportRejection": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "description": "1-based line in the file, the header being line 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.JobInfo": {
+            "type": "object",
+            "properties": {
+                "context": {
+                    "description": "IDs of what the job works on, e.g. source_file_id",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "email of the requesting user, or scheduler/system",
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "stage": {
+                    "description": "current step, e.g. \"downloading\"",
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "0 when unknown",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.LEIChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "created, updated or deleted",
+                    "type": "string"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "changed_fields": {
+                    "description": "updated fields",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cursor": {
+                    "description": "pass as since to continue after this change",
+                    "type": "string"
+                },
+                "lei": {
+                    "type": "string"
+                },
+                "source_file_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.LEIChangeFeed": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.LEIChange"
+                    }
+                },
+                "has_more": {
+                    "description": "another page is ready now",
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "description": "since for the next request; unchanged when there was nothing new",
+                    "type": "string"
+                }
+            }
+        },
+        "service.LEIMatchCandidate": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "entity_status": {
+                    "type": "string"
+                },
+                "legal_form": {
+                    "type": "string"
+                },
+                "legal_name": {
+                    "type": "string"
+                },
+                "lei": {
+                    "type": "string"
+                },
+                "matched_name": {
+                    "description": "the legal or transliterated name that scored best",
+                    "type": "string"
+                },
+                "registration_status": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "mean of trigram and token score; 1 for an exact normalized match",
+                    "type": "number"
+                },
+                "token_score": {
+                    "description": "Dice coefficient of the normalized name words",
+                    "type": "number"
+                },
+                "trigram_score": {
+                    "description": "trigram similarity of the normalized names",
+                    "type": "number"
+                }
+            }
+        },
+        "service.LEIQualityReport": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LEIQualityMetric"
+                    }
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "publication_date": {
+                    "type": "string"
+                },
+                "records": {
+                    "description": "records measured",
+                    "type": "integer"
+                },
+                "source_file_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.LEIReconciliationRun": {
+            "type": "object",
+            "properties": {
+                "drift_threshold": {
+                    "type": "number"
+                },
+                "exceeded": {
+                    "description": "scopes over the threshold",
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LEIReconciliationResult"
+                    }
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "run_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.LEIRecordDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "fields that differ, by field name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LEIChangeDetection"
+                    }
+                },
+                "from": {
+                    "description": "versions are returned without their records",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/service.LEIRecordVersion"
+                        }
+                    ]
+                },
+                "lei": {
+                    "type": "string"
+                },
+                "to": {
+                    "$ref": "#/definitions/service.LEIRecordVersion"
+                }
+            }
+        },
+        "service.LEIRecordVersion": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "audit_id": {
+                    "type": "string"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "record": {
+                    "$ref": "#/definitions/domain.LEIRecord"
+                }
+            }
+        },
+        "service.LEIRefreshResult": {
+            "type": "object",
+            "properties": {
+                "queue_depth": {
+                    "type": "integer"
+                },
+                "queued": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rejected": {
+                    "description": "LEI -\u003e reason",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.MICImportResult": {
+            "type": "object",
+            "properties": {
+                "expired": {
+                    "description": "imported MICs with status EXPIRED",
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "read": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "the first rejections",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRejection"
+                    }
+                },
+                "rejected_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.NonSettlement": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "\"weekend\", or the calendar and holiday name, e.g. \"XLON: Christmas Day\"",
+                    "type": "string"
+                }
+            }
+        },
+        "service.OnboardingDraft": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Account"
+                    }
+                },
+                "entity": {
+                    "$ref": "#/definitions/domain.Entity"
+                },
+                "ssis": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SSI"
+                    }
+                }
+            }
+        },
+        "service.PriceImportRejection": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "description": "zero-based index in the request",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.PriceImportResult": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.PriceImportRejection"
+                    }
+                }
+            }
+        },
+        "service.PriceImportRow": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "ISO 4217 code",
+                    "type": "string"
+                },
+                "instrument_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "price_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "defaults to MANUAL",
+                    "type": "string"
+                }
+            }
+        },
+        "service.RAImportResult": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer"
+                },
+                "read": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "the first rejections",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.ImportRejection"
+                    }
+                },
+                "rejected_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.RenewalsDue": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LEIRecord"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.SSIDefaultsPreview": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "field -\u003e value filled",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "rule": {
+                    "description": "nil if no rule matches",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SSIDefaultRule"
+                        }
+                    ]
+                },
+                "ssi": {
+                    "description": "the SSI as it would be created",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SSI"
+                        }
+                    ]
+                }
+            }
+        },
+        "service.SettlementDateResult": {
+            "type": "object",
+            "properties": {
+                "cycle": {
+                    "type": "string"
+                },
+                "markets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "settlement_date": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "description": "non-business days passed over, in date order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.NonSettlement"
+                    }
+                },
+                "trade_date": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "e.g. a calendar with no holidays loaded for a year",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "service.SourceFileProgress": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "eta_seconds": {
+                    "type": "number"
+                },
+                "failed_records": {
+                    "type": "integer"
+                },
+                "last_lei": {
+                    "type": "string"
+                },
+                "percent_complete": {
+                    "type": "number"
+                },
+                "processed_records": {
+                    "type": "integer"
+                },
+                "records_per_sec": {
+                    "type": "number"
+                },
+                "source_file_id": {
+                    "type": "string"
+                },
+                "stage": {
+                    "description": "processing or merging while IN_PROGRESS",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "PENDING, IN_PROGRESS, COMPLETED or FAILED",
+                    "type": "string"
+                },
+                "total_records": {
+                    "description": "records scanned so far",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.StuckStatusReset": {
+            "type": "object",
+            "properties": {
+                "older_than": {
+                    "type": "string"
+                },
+                "processing_statuses": {
+                    "description": "RUNNING statuses returned to IDLE",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FileProcessingStatus"
+                    }
+                },
+                "source_files": {
+                    "description": "IN_PROGRESS files marked FAILED (STALLED)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SourceFile"
+                    }
+                }
+            }
+        },
+        "v2.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.Coded": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.Country": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "alpha3_code": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "numeric_code": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "sub_region": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.Currency": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "decimal_places": {
+                    "type": "integer"
+                },
+                "fund": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "numeric_code": {
+                    "type": "string"
+                },
+                "precious_metal": {
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.LEIRecord": {
+            "type": "object",
+            "properties": {
+                "entity_category": {
+                    "type": "string"
+                },
+                "entity_status": {
+                    "type": "string"
+                },
+                "entity_sub_category": {
+                    "type": "string"
+                },
+                "headquarters_address": {
+                    "$ref": "#/definitions/v2.Address"
+                },
+                "initial_registration_date": {
+                    "type": "string"
+                },
+                "last_update_date": {
+                    "type": "string"
+                },
+                "legal_address": {
+                    "$ref": "#/definitions/v2.Address"
+                },
+                "legal_form": {
+                    "$ref": "#/definitions/v2.Coded"
+                },
+                "legal_name": {
+                    "type": "string"
+                },
+                "lei": {
+                    "type": "string"
+                },
+                "managing_lou": {
+                    "$ref": "#/definitions/v2.Coded"
+                },
+                "next_renewal_date": {
+                    "type": "string"
+                },
+                "other_names": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.OtherName"
+                    }
+                },
+                "registration_authority": {
+                    "$ref": "#/definitions/v2.Coded"
+                },
+                "registration_authority_entity_id": {
+                    "type": "string"
+                },
+                "registration_number": {
+                    "type": "string"
+                },
+                "registration_status": {
+                    "type": "string"
+                },
+                "successor_lei": {
+                    "type": "string"
+                },
+                "transliterated_legal_name": {
+                    "type": "string"
+                },
+                "validation_authority": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.OtherName": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.Page-v2_Country": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.Country"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v2.Pagination"
+                }
+            }
+        },
+        "v2.Page-v2_Currency": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.Currency"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v2.Pagination"
+                }
+            }
+        },
+        "v2.Page-v2_LEIRecord": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.LEIRecord"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/v2.Pagination"
+                }
+            }
+        },
+        "v2.Pagination": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_offset": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Type \"Bearer\" followed by a space and JWT token.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8080",
+	BasePath:         "/api/v1",
+	Schemes:          []string{},
+	Title:            "Axiom API",
+	Description:      "Financial Services Static Data Management System",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
@@ -0,0 +1,223 @@
+// Package app provides the application container that owns the lifecycle of
+// long-running subsystems (database, scheduler, workers, HTTP server, ...).
+//
+// Components are started in registration order and stopped in reverse order,
+// so a component may rely on everything registered before it.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Component is a subsystem with a managed lifecycle
+type Component interface {
+	Name() string
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// ReadinessChecker is implemented by components that can report whether they are able to serve traffic
+type ReadinessChecker interface {
+	Ready(ctx context.Context) error
+}
+
+// DegradedError is returned by a readiness check whose component still serves traffic, with part
+// of its function unavailable (e.g. an upstream service it calls being down). It is reported
+// without failing readiness, as restarting or unrouting the replica would not help.
+type DegradedError struct {
+	Err error
+}
+
+func (e *DegradedError) Error() string { return e.Err.Error() }
+func (e *DegradedError) Unwrap() error { return e.Err }
+
+// Degraded marks a readiness error as degraded rather than not ready; nil stays nil
+func Degraded(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &DegradedError{Err: err}
+}
+
+// ComponentStatus is the readiness of a single component
+type ComponentStatus struct {
+	Name   string `json:"name"`
+	Ready  bool   `json:"ready"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ReadinessReport aggregates the readiness of all registered components
+type ReadinessReport struct {
+	Ready      bool              `json:"ready"`
+	Degraded   bool              `json:"degraded"` // ready, but with a component degraded
+	Components []ComponentStatus `json:"components"`
+}
+
+// App is the application container
+type App struct {
+	mu         sync.Mutex
+	components []Component
+	started    []Component
+	stopped    bool
+}
+
+// New creates an empty application container
+func New() *App {
+	return &App{}
+}
+
+// Register adds a component; components start in the order they are registered
+func (a *App) Register(components ...Component) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.components = append(a.components, components...)
+}
+
+// Start starts every registered component in order
+// If a component fails to start, the components already started are stopped in reverse order
+func (a *App) Start(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, c := range a.components {
+		begin := time.Now()
+		if err := c.Start(ctx); err != nil {
+			log.Error().Err(err).Str("component", c.Name()).Msg("Component failed to start, rolling back")
+			if stopErr := a.stopStarted(ctx); stopErr != nil {
+				log.Error().Err(stopErr).Msg("Errors while rolling back startup")
+			}
+			return fmt.Errorf("failed to start %s: %w", c.Name(), err)
+		}
+		a.started = append(a.started, c)
+		log.Info().
+			Str("component", c.Name()).
+			Dur("duration", time.Since(begin)).
+			Msg("Component started")
+	}
+	return nil
+}
+
+// Stop stops all started components in reverse order
+// Every component is given the chance to stop even if an earlier one fails; errors are joined
+func (a *App) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.stopped {
+		return nil
+	}
+	a.stopped = true
+	return a.stopStarted(ctx)
+}
+
+func (a *App) stopStarted(ctx context.Context) error {
+	var errs []error
+	for i := len(a.started) - 1; i >= 0; i-- {
+		c := a.started[i]
+		begin := time.Now()
+		if err := c.Stop(ctx); err != nil {
+			log.Error().Err(err).Str("component", c.Name()).Msg("Component failed to stop cleanly")
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name(), err))
+			continue
+		}
+		log.Info().
+			Str("component", c.Name()).
+			Dur("duration", time.Since(begin)).
+			Msg("Component stopped")
+	}
+	a.started = nil
+	return errors.Join(errs...)
+}
+
+// Readiness checks every component; the app is ready only if all started components are ready,
+// degraded ones included
+func (a *App) Readiness(ctx context.Context) ReadinessReport {
+	a.mu.Lock()
+	components := append([]Component(nil), a.components...)
+	started := make(map[Component]bool, len(a.started))
+	for _, c := range a.started {
+		started[c] = true
+	}
+	a.mu.Unlock()
+
+	report := ReadinessReport{Ready: true, Components: make([]ComponentStatus, 0, len(components))}
+	for _, c := range components {
+		status := ComponentStatus{Name: c.Name(), Ready: true, Status: "ready"}
+		if !started[c] {
+			status.Ready = false
+			status.Status = "not_started"
+		} else if checker, ok := c.(ReadinessChecker); ok {
+			var degraded *DegradedError
+			err := checker.Ready(ctx)
+			switch {
+			case errors.As(err, &degraded):
+				status.Status = "degraded"
+				status.Error = err.Error()
+				report.Degraded = true
+			case err != nil:
+				status.Ready = false
+				status.Status = "not_ready"
+				status.Error = err.Error()
+			}
+		}
+		if !status.Ready {
+			report.Ready = false
+		}
+		report.Components = append(report.Components, status)
+	}
+	return report
+}
+
+// funcComponent adapts plain functions to the Component interface
+type funcComponent struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+	ready func(ctx context.Context) error
+}
+
+// NewComponent builds a Component from start/stop functions; either may be nil
+func NewComponent(name string, start, stop func(ctx context.Context) error) Component {
+	return &funcComponent{name: name, start: start, stop: stop}
+}
+
+// WithReadiness returns a copy of a component built by NewComponent with a readiness check attached
+func WithReadiness(c Component, ready func(ctx context.Context) error) Component {
+	fc, ok := c.(*funcComponent)
+	if !ok {
+		return c
+	}
+	copied := *fc
+	copied.ready = ready
+	return &copied
+}
+
+func (c *funcComponent) Name() string { return c.name }
+
+func (c *funcComponent) Start(ctx context.Context) error {
+	if c.start == nil {
+		return nil
+	}
+	return c.start(ctx)
+}
+
+func (c *funcComponent) Stop(ctx context.Context) error {
+	if c.stop == nil {
+		return nil
+	}
+	return c.stop(ctx)
+}
+
+func (c *funcComponent) Ready(ctx context.Context) error {
+	if c.ready == nil {
+		return nil
+	}
+	return c.ready(ctx)
+}
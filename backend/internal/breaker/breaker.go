@@ -0,0 +1,146 @@
+// Package breaker stops calls to an upstream service after it has failed repeatedly, so that
+// callers fail fast instead of each retrying against a service that is down. After a cooldown a
+// single trial call is let through: its success closes the breaker, its failure opens it again.
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// States of a breaker
+const (
+	StateClosed   = "closed"    // calls go through
+	StateOpen     = "open"      // calls fail fast until the cooldown has passed
+	StateHalfOpen = "half_open" // one trial call is in flight; others fail fast
+)
+
+// ErrOpen is matched by the errors of calls refused while the breaker is open
+var ErrOpen = errors.New("circuit breaker open")
+
+// OpenError is returned instead of making a call while the breaker is open
+type OpenError struct {
+	Name  string    // The upstream service
+	Until time.Time // When a trial call will be let through
+	Last  error     // The failure that opened the breaker
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s unavailable, not called until %s after repeated failures: %v", e.Name, e.Until.Format(time.RFC3339), e.Last)
+}
+
+func (e *OpenError) Is(target error) bool { return target == ErrOpen }
+
+// Status is a snapshot of a breaker, for health reporting
+type Status struct {
+	State     string    `json:"state"`
+	Failures  int       `json:"failures"` // consecutive failed calls
+	LastError string    `json:"last_error,omitempty"`
+	OpenedAt  time.Time `json:"opened_at,omitempty"`
+}
+
+// Breaker counts consecutive failures of an upstream service. A nil Breaker lets every call
+// through.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	lastErr  error
+	openedAt time.Time
+}
+
+// New creates a breaker that opens after threshold consecutive failures and lets a trial call
+// through cooldown later; nil, never opening, when threshold is 0
+func New(name string, threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown, state: StateClosed}
+}
+
+// Allow returns an *OpenError if a call must not be made now. A nil error obliges the caller to
+// Record the call's outcome.
+func (b *Breaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateOpen:
+		until := b.openedAt.Add(b.cooldown)
+		if time.Now().Before(until) {
+			return &OpenError{Name: b.name, Until: until, Last: b.lastErr}
+		}
+		b.state = StateHalfOpen
+		log.Info().Str("upstream", b.name).Msg("Circuit breaker letting a trial call through")
+		return nil
+	case StateHalfOpen:
+		return &OpenError{Name: b.name, Until: time.Now().Add(b.cooldown), Last: b.lastErr}
+	default:
+		return nil
+	}
+}
+
+// Record reports the outcome of an allowed call: nil for success, or the upstream failure
+func (b *Breaker) Record(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		if b.state != StateClosed {
+			log.Info().Str("upstream", b.name).Msg("Circuit breaker closed, upstream answering again")
+		}
+		b.state, b.failures, b.lastErr = StateClosed, 0, nil
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+	if b.state == StateHalfOpen || (b.state == StateClosed && b.failures >= b.threshold) {
+		b.state, b.openedAt = StateOpen, time.Now()
+		log.Warn().
+			Err(err).
+			Str("upstream", b.name).
+			Int("failures", b.failures).
+			Dur("cooldown", b.cooldown).
+			Msg("Circuit breaker opened, calls fail fast until the cooldown has passed")
+	}
+}
+
+// Status returns the breaker's state; closed for a nil breaker
+func (b *Breaker) Status() Status {
+	if b == nil {
+		return Status{State: StateClosed}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status := Status{State: b.state, Failures: b.failures}
+	if b.lastErr != nil {
+		status.LastError = b.lastErr.Error()
+	}
+	if b.state != StateClosed {
+		status.OpenedAt = b.openedAt
+	}
+	return status
+}
+
+// Err returns why calls are failing while the breaker is not closed, else nil
+func (b *Breaker) Err() error {
+	status := b.Status()
+	if status.State == StateClosed {
+		return nil
+	}
+	return fmt.Errorf("circuit breaker %s since %s after %d consecutive failures: %s",
+		status.State, status.OpenedAt.Format(time.RFC3339), status.Failures, status.LastError)
+}
@@ -0,0 +1,138 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// breakerStep is one event: a call that fails or succeeds (after asking Allow, which must let it
+// through), a call that must be refused, or waiting out the cooldown. wantState is the state
+// after the step.
+type breakerStep struct {
+	op        string // fail, succeed, refused or cool
+	wantState string
+}
+
+func TestBreaker(t *testing.T) {
+	const cooldown = 20 * time.Millisecond
+	tests := []struct {
+		name  string
+		steps []breakerStep
+	}{
+		{
+			name: "failures below the threshold keep it closed",
+			steps: []breakerStep{
+				{op: "fail", wantState: StateClosed},
+				{op: "fail", wantState: StateClosed},
+				{op: "succeed", wantState: StateClosed},
+			},
+		},
+		{
+			name: "a success resets the count",
+			steps: []breakerStep{
+				{op: "fail", wantState: StateClosed},
+				{op: "fail", wantState: StateClosed},
+				{op: "succeed", wantState: StateClosed},
+				{op: "fail", wantState: StateClosed},
+				{op: "fail", wantState: StateClosed},
+				{op: "fail", wantState: StateOpen},
+			},
+		},
+		{
+			name: "opens at the threshold and refuses calls",
+			steps: []breakerStep{
+				{op: "fail", wantState: StateClosed},
+				{op: "fail", wantState: StateClosed},
+				{op: "fail", wantState: StateOpen},
+				{op: "refused", wantState: StateOpen},
+			},
+		},
+		{
+			name: "a successful trial call closes it",
+			steps: []breakerStep{
+				{op: "fail"}, {op: "fail"}, {op: "fail", wantState: StateOpen},
+				{op: "cool", wantState: StateOpen},
+				{op: "succeed", wantState: StateClosed},
+				{op: "succeed", wantState: StateClosed},
+			},
+		},
+		{
+			name: "a failed trial call opens it again",
+			steps: []breakerStep{
+				{op: "fail"}, {op: "fail"}, {op: "fail", wantState: StateOpen},
+				{op: "cool", wantState: StateOpen},
+				{op: "fail", wantState: StateOpen},
+				{op: "refused", wantState: StateOpen},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New("GLEIF", 3, cooldown)
+			for i, step := range tt.steps {
+				switch step.op {
+				case "fail", "succeed":
+					require.NoError(t, b.Allow(), "step %d", i)
+					if step.op == "fail" {
+						b.Record(errors.New("HTTP 503"))
+					} else {
+						b.Record(nil)
+					}
+				case "refused":
+					err := b.Allow()
+					require.ErrorIs(t, err, ErrOpen, "step %d", i)
+					var open *OpenError
+					require.ErrorAs(t, err, &open)
+					assert.Equal(t, "GLEIF", open.Name)
+					assert.EqualError(t, open.Last, "HTTP 503")
+				case "cool":
+					time.Sleep(cooldown + 10*time.Millisecond)
+				}
+				if step.wantState != "" {
+					assert.Equal(t, step.wantState, b.Status().State, "step %d", i)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakerHalfOpenLetsOneTrialThrough(t *testing.T) {
+	b := New("GLEIF", 1, time.Millisecond)
+	require.NoError(t, b.Allow())
+	b.Record(errors.New("connection refused"))
+	time.Sleep(5 * time.Millisecond)
+
+	require.NoError(t, b.Allow(), "the trial call")
+	assert.Equal(t, StateHalfOpen, b.Status().State)
+	assert.ErrorIs(t, b.Allow(), ErrOpen, "other calls while the trial is in flight")
+}
+
+func TestBreakerStatusAndErr(t *testing.T) {
+	b := New("GLEIF", 2, time.Minute)
+	assert.Equal(t, Status{State: StateClosed}, b.Status())
+	assert.NoError(t, b.Err())
+
+	b.Record(errors.New("HTTP 502"))
+	b.Record(errors.New("HTTP 503"))
+	status := b.Status()
+	assert.Equal(t, StateOpen, status.State)
+	assert.Equal(t, 2, status.Failures)
+	assert.Equal(t, "HTTP 503", status.LastError)
+	assert.False(t, status.OpenedAt.IsZero())
+	assert.ErrorContains(t, b.Err(), "after 2 consecutive failures: HTTP 503")
+}
+
+func TestNilBreakerNeverOpens(t *testing.T) {
+	b := New("GLEIF", 0, time.Minute)
+	require.Nil(t, b)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, b.Allow())
+		b.Record(errors.New("HTTP 503"))
+	}
+	assert.Equal(t, StateClosed, b.Status().State)
+	assert.NoError(t, b.Err())
+}
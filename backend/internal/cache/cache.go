@@ -0,0 +1,87 @@
+// Package cache keeps the results of hot lookups (LEI records by code, reference data lists) so the
+// UI's frequent reference queries do not each reach the database. Entries expire after a TTL and
+// are dropped when the data behind them is written.
+//
+// A cache is a performance aid only: when it cannot be reached, lookups go to the database.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// Cache backends
+const (
+	BackendNone  = "none"
+	BackendRedis = "redis"
+)
+
+// Groups of entries, invalidated together
+const (
+	GroupLEI        = "lei"        // LEI records by code
+	GroupLEIFacets  = "lei-facets" // distinct values of LEI fields
+	GroupCountries  = "countries"
+	GroupCurrencies = "currencies"
+)
+
+// Cache stores encoded values by group and key
+type Cache interface {
+	// Get returns the value under key, if present
+	Get(ctx context.Context, group, key string) ([]byte, bool)
+	// Set stores value under key for ttl
+	Set(ctx context.Context, group, key string, value []byte, ttl time.Duration)
+	// Delete drops keys of a group
+	Delete(ctx context.Context, group string, keys ...string)
+	// Invalidate drops every key of a group
+	Invalidate(ctx context.Context, group string)
+}
+
+// New creates the configured cache; with no backend nothing is cached
+func New(cfg config.CacheConfig, redisCfg config.RedisConfig) (Cache, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case "", BackendNone:
+		return None{}, nil
+	case BackendRedis:
+		return newRedisCache(redisCfg)
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q (expected none or redis)", cfg.Backend)
+	}
+}
+
+// None caches nothing
+type None struct{}
+
+func (None) Get(context.Context, string, string) ([]byte, bool)         { return nil, false }
+func (None) Set(context.Context, string, string, []byte, time.Duration) {}
+func (None) Delete(context.Context, string, ...string)                  {}
+func (None) Invalidate(context.Context, string)                         {}
+
+// Fetch returns the value cached under key, else loads it and caches it for ttl. Errors are not
+// cached, nor is anything when ttl is 0.
+func Fetch[T any](c Cache, group, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	ctx := context.Background()
+	if ttl > 0 {
+		if data, ok := c.Get(ctx, group, key); ok {
+			var value T
+			if err := json.Unmarshal(data, &value); err == nil {
+				return value, nil
+			}
+			log.Warn().Str("group", group).Str("key", key).Msg("Discarding undecodable cache entry")
+		}
+	}
+
+	value, err := load()
+	if err != nil || ttl <= 0 {
+		return value, err
+	}
+	if data, err := json.Marshal(value); err == nil {
+		c.Set(ctx, group, key, data, ttl)
+	}
+	return value, nil
+}
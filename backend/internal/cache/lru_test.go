@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lruStep is one call on the cache: add stores value under key, get expects want (or a miss when
+// want is empty), expire waits out the TTL and purge empties the cache
+type lruStep struct {
+	op    string
+	key   string
+	value string
+	want  string
+}
+
+func TestLRU(t *testing.T) {
+	const ttl = 20 * time.Millisecond
+	tests := []struct {
+		name  string
+		size  int
+		steps []lruStep
+	}{
+		{
+			name: "hit and miss",
+			size: 2,
+			steps: []lruStep{
+				{op: "add", key: "GB", value: "United Kingdom"},
+				{op: "get", key: "GB", want: "United Kingdom"},
+				{op: "get", key: "FR"},
+			},
+		},
+		{
+			name: "adding past the size evicts the least recently added",
+			size: 2,
+			steps: []lruStep{
+				{op: "add", key: "GB", value: "United Kingdom"},
+				{op: "add", key: "FR", value: "France"},
+				{op: "add", key: "DE", value: "Germany"},
+				{op: "get", key: "GB"},
+				{op: "get", key: "FR", want: "France"},
+				{op: "get", key: "DE", want: "Germany"},
+			},
+		},
+		{
+			name: "a get keeps an entry from eviction",
+			size: 2,
+			steps: []lruStep{
+				{op: "add", key: "GB", value: "United Kingdom"},
+				{op: "add", key: "FR", value: "France"},
+				{op: "get", key: "GB", want: "United Kingdom"},
+				{op: "add", key: "DE", value: "Germany"},
+				{op: "get", key: "FR"},
+				{op: "get", key: "GB", want: "United Kingdom"},
+			},
+		},
+		{
+			name: "adding an existing key replaces it without evicting",
+			size: 2,
+			steps: []lruStep{
+				{op: "add", key: "GB", value: "United Kingdom"},
+				{op: "add", key: "FR", value: "France"},
+				{op: "add", key: "GB", value: "Great Britain"},
+				{op: "get", key: "GB", want: "Great Britain"},
+				{op: "get", key: "FR", want: "France"},
+			},
+		},
+		{
+			name: "entries expire after the TTL",
+			size: 2,
+			steps: []lruStep{
+				{op: "add", key: "GB", value: "United Kingdom"},
+				{op: "expire"},
+				{op: "get", key: "GB"},
+				{op: "add", key: "GB", value: "United Kingdom"},
+				{op: "get", key: "GB", want: "United Kingdom"},
+			},
+		},
+		{
+			name: "purge empties the cache",
+			size: 2,
+			steps: []lruStep{
+				{op: "add", key: "GB", value: "United Kingdom"},
+				{op: "add", key: "FR", value: "France"},
+				{op: "purge"},
+				{op: "get", key: "GB"},
+				{op: "get", key: "FR"},
+				{op: "add", key: "DE", value: "Germany"},
+				{op: "get", key: "DE", want: "Germany"},
+			},
+		},
+		{
+			name: "size 0 caches nothing",
+			steps: []lruStep{
+				{op: "add", key: "GB", value: "United Kingdom"},
+				{op: "get", key: "GB"},
+				{op: "purge"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRU[string, string](tt.size, ttl)
+			for i, step := range tt.steps {
+				switch step.op {
+				case "add":
+					c.Add(step.key, step.value)
+				case "get":
+					got, ok := c.Get(step.key)
+					assert.Equal(t, step.want, got, "step %d", i)
+					assert.Equal(t, step.want != "", ok, "step %d", i)
+				case "expire":
+					time.Sleep(ttl + 10*time.Millisecond)
+				case "purge":
+					c.Purge()
+				}
+			}
+		})
+	}
+}
+
+func TestNewLRUDisabled(t *testing.T) {
+	assert.Nil(t, NewLRU[string, string](0, time.Minute))
+	assert.Nil(t, NewLRU[string, string](100, 0))
+	assert.NotNil(t, NewLRU[string, string](100, time.Minute))
+}
+
+func TestLRUConcurrentUse(t *testing.T) {
+	c := NewLRU[int, int](16, time.Minute)
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Add(i%32, i)
+				if value, ok := c.Get(i % 32); ok {
+					assert.Equal(t, i%32, value%32)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, len(c.entries), 16)
+	assert.Equal(t, len(c.entries), c.order.Len())
+}
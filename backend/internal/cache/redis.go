@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// redisKeyPrefix namespaces the entries in a Redis database shared with other data
+const redisKeyPrefix = "axiom:cache:"
+
+// redisTimeout bounds each call, so a slow Redis costs a lookup little more than the database would
+const redisTimeout = 250 * time.Millisecond
+
+// Entries are stored under axiom:cache:<group>:<generation>:<key>, the generation being a counter
+// at axiom:cache:<group>. Invalidating a group increments it, which orphans every entry at once;
+// the orphans expire with their TTL. Each script reads the generation and uses the entry in one
+// round trip.
+var (
+	getScript = redis.NewScript(`
+local gen = redis.call('GET', KEYS[1]) or '0'
+return redis.call('GET', KEYS[1] .. ':' .. gen .. ':' .. ARGV[1])
+`)
+	setScript = redis.NewScript(`
+local gen = redis.call('GET', KEYS[1]) or '0'
+return redis.call('SET', KEYS[1] .. ':' .. gen .. ':' .. ARGV[1], ARGV[2], 'PX', ARGV[3])
+`)
+	deleteScript = redis.NewScript(`
+local gen = redis.call('GET', KEYS[1]) or '0'
+for i = 1, #ARGV do
+	redis.call('UNLINK', KEYS[1] .. ':' .. gen .. ':' .. ARGV[i])
+end
+return #ARGV
+`)
+)
+
+type redisCache struct {
+	client  *redis.Client
+	failing atomic.Bool // the last call failed; logged once until a call succeeds again
+}
+
+// newRedisCache connects to the Redis server at redis.url
+func newRedisCache(cfg config.RedisConfig) (*redisCache, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("the redis cache backend needs redis.url")
+	}
+	options, err := redis.ParseURL(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis.url: %w", err)
+	}
+	return &redisCache{client: redis.NewClient(options)}, nil
+}
+
+func generationKey(group string) []string {
+	return []string{redisKeyPrefix + group}
+}
+
+func (c *redisCache) Get(ctx context.Context, group, key string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+	data, err := getScript.Run(ctx, c.client, generationKey(group), key).Text()
+	if err == redis.Nil {
+		c.result(nil)
+		return nil, false
+	}
+	c.result(err)
+	return []byte(data), err == nil
+}
+
+func (c *redisCache) Set(ctx context.Context, group, key string, value []byte, ttl time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+	c.result(setScript.Run(ctx, c.client, generationKey(group), key, value, ttl.Milliseconds()).Err())
+}
+
+func (c *redisCache) Delete(ctx context.Context, group string, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+	c.result(deleteScript.Run(ctx, c.client, generationKey(group), args...).Err())
+}
+
+func (c *redisCache) Invalidate(ctx context.Context, group string) {
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+	c.result(c.client.Incr(ctx, redisKeyPrefix+group).Err())
+}
+
+// result logs when calls start failing and when they succeed again, rather than every failure
+func (c *redisCache) result(err error) {
+	if err != nil {
+		if !c.failing.Swap(true) {
+			log.Warn().Err(err).Msg("Redis cache unavailable, reading from the database")
+		}
+		return
+	}
+	if c.failing.Swap(false) {
+		log.Info().Msg("Redis cache available again")
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// EnvVar names the environment profile, e.g. dev, test or prod. Its config.<env>.yaml is layered
+// over config.yaml: the settings it has replace those of the base file, the rest are kept.
+const EnvVar = "APP_ENV"
+
+// configPaths are searched, in order, for config.yaml and the profile's file
+var configPaths = []string{"./config", "."}
+
+var envName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// profile is the environment profile Load layered over the base file, if any
+var profile struct {
+	env  string
+	file string
+}
+
+// Files returns the config files in use, the base file first; empty when there are none
+func Files() []string {
+	var files []string
+	if base := viper.ConfigFileUsed(); base != "" {
+		files = append(files, base)
+	}
+	if profile.file != "" {
+		files = append(files, profile.file)
+	}
+	return files
+}
+
+// loadProfile finds the config file of the profile named by APP_ENV and merges it over what viper
+// has read. A profile without a file is an error rather than silently running on the base settings.
+func loadProfile() error {
+	profile.env, profile.file = "", ""
+	env := os.Getenv(EnvVar)
+	if env == "" {
+		return nil
+	}
+	if !envName.MatchString(env) {
+		return fmt.Errorf("%s=%q is not a valid profile name (lowercase letters, digits, - and _)", EnvVar, env)
+	}
+
+	name := "config." + env + ".yaml"
+	for _, dir := range configPaths {
+		file, err := filepath.Abs(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(file); err == nil {
+			profile.env, profile.file = env, file
+			return mergeProfile()
+		}
+	}
+	return fmt.Errorf("%s=%s but no %s was found in %v", EnvVar, env, name, configPaths)
+}
+
+// mergeProfile layers the profile's file over the settings viper holds
+func mergeProfile() error {
+	if profile.file == "" {
+		return nil
+	}
+	f, err := os.Open(profile.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := viper.MergeConfig(f); err != nil {
+		return fmt.Errorf("failed to read %s: %w", profile.file, err)
+	}
+	return nil
+}
+
+// reread reads the base file and the profile's file again
+func reread() error {
+	if viper.ConfigFileUsed() != "" {
+		if err := viper.ReadInConfig(); err != nil {
+			return err
+		}
+	}
+	return mergeProfile()
+}
+
+// watchFiles calls onChange when any of files is written or replaced, including by swapping the
+// symlink it resolves through, as Kubernetes does when it updates a mounted ConfigMap. Watcher
+// failures go to onError.
+func watchFiles(files []string, onChange func(), onError func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	realPaths := map[string]string{}
+	dirs := map[string]bool{}
+	for _, file := range files {
+		file = filepath.Clean(file)
+		realPaths[file], _ = filepath.EvalSymlinks(file)
+		dir := filepath.Dir(file)
+		if !dirs[dir] {
+			if err := watcher.Add(dir); err != nil {
+				watcher.Close()
+				return err
+			}
+			dirs[dir] = true
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				changed := false
+				for file, realPath := range realPaths {
+					current, _ := filepath.EvalSymlinks(file)
+					written := filepath.Clean(event.Name) == file && (event.Has(fsnotify.Write) || event.Has(fsnotify.Create))
+					if written || (current != "" && current != realPath) {
+						realPaths[file] = current
+						changed = true
+					}
+				}
+				if changed {
+					onChange()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				onError(fmt.Errorf("config file watcher: %w", err))
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,378 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultJWTSecret is the placeholder jwt.secret; release mode refuses to start with it
+const DefaultJWTSecret = "change-this-secret-in-production"
+
+// ValidationError lists every problem found in a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	count := fmt.Sprintf("%d problems", len(e.Problems))
+	if len(e.Problems) == 1 {
+		count = "1 problem"
+	}
+	return fmt.Sprintf("invalid configuration (%s):\n  - %s", count, strings.Join(e.Problems, "\n  - "))
+}
+
+// validator collects problems so they are reported together rather than one per restart
+type validator struct {
+	problems []string
+}
+
+func (v *validator) fail(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+// oneOf checks value (case-insensitively) against the allowed values
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	for _, a := range allowed {
+		if strings.EqualFold(strings.TrimSpace(value), a) {
+			return
+		}
+	}
+	v.fail("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+}
+
+// duration checks a positive duration setting of at least min; zeroOK also accepts 0, for
+// settings it disables
+func (v *validator) duration(key, value string, min time.Duration, zeroOK bool) {
+	d, err := time.ParseDuration(value)
+	switch {
+	case err != nil:
+		v.fail("%s must be a duration such as 30s or 1h, got %q", key, value)
+	case d == 0 && zeroOK:
+	case d <= 0 || d < min:
+		v.fail("%s must be at least %s, got %s", key, max(min, time.Nanosecond), value)
+	}
+}
+
+// timeOfDay checks an HH:MM setting; empty is accepted where it disables the job
+func (v *validator) timeOfDay(key, value string, emptyDisables bool) {
+	value = strings.TrimSpace(value)
+	if value == "" && emptyDisables {
+		return
+	}
+	hour, minute, found := strings.Cut(value, ":")
+	h, herr := strconv.Atoi(hour)
+	m, merr := strconv.Atoi(minute)
+	if !found || herr != nil || merr != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		v.fail("%s must be a time of day as HH:MM, got %q", key, value)
+	}
+}
+
+// weekday checks a day-of-week setting; empty is accepted where it means every day
+func (v *validator) weekday(key, value string, emptyDaily bool) {
+	day := strings.ToLower(strings.TrimSpace(value))
+	if day == "" && emptyDaily {
+		return
+	}
+	for _, name := range []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"} {
+		if day == name || day == name[:3] || (name == "thursday" && day == "thurs") {
+			return
+		}
+	}
+	v.fail("%s must be a day of the week such as Monday, got %q", key, value)
+}
+
+// Validate checks the configuration as a whole, returning a *ValidationError that lists every
+// problem. Settings the services would otherwise replace with a default (with a warning, once the
+// job using them runs) are rejected here, so a typo stops the deploy instead.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// Server
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		v.fail("server.port must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	v.oneOf("server.mode", c.Server.Mode, "debug", "release", "test")
+	v.oneOf("server.timestampformat", c.Server.TimestampFormat, "rfc3339", "epoch_millis")
+	if _, err := time.LoadLocation(c.Server.Timezone); err != nil {
+		v.fail("server.timezone must be an IANA timezone such as Europe/London, got %q", c.Server.Timezone)
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				v.fail("server.trustedproxies must hold IPs or CIDRs, got %q", proxy)
+			}
+		}
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		v.fail("server.shutdowntimeout must be positive, got %s", c.Server.ShutdownTimeout)
+	}
+	if tls := c.Server.TLS; tls.Enabled {
+		switch {
+		case len(tls.ACME.Domains) > 0 && (tls.CertFile != "" || tls.KeyFile != ""):
+			v.fail("server.tls takes either certfile and keyfile or acme.domains, not both")
+		case len(tls.ACME.Domains) > 0 && tls.ACME.CacheDir == "":
+			v.fail("server.tls.acme.cachedir is required with acme.domains")
+		case len(tls.ACME.Domains) == 0 && (tls.CertFile == "" || tls.KeyFile == ""):
+			v.fail("server.tls.certfile and server.tls.keyfile (or server.tls.acme.domains) are required when TLS is enabled")
+		}
+	}
+
+	if c.GRPC.Enabled {
+		if c.GRPC.Port < 1 || c.GRPC.Port > 65535 {
+			v.fail("grpc.port must be between 1 and 65535, got %d", c.GRPC.Port)
+		} else if c.GRPC.Port == c.Server.Port {
+			v.fail("grpc.port must differ from server.port (%d)", c.Server.Port)
+		}
+	}
+
+	// Database
+	if c.Database.Host == "" {
+		v.fail("database.host is required")
+	}
+	if c.Database.Port < 1 || c.Database.Port > 65535 {
+		v.fail("database.port must be between 1 and 65535, got %d", c.Database.Port)
+	}
+	if c.Database.Name == "" {
+		v.fail("database.name is required")
+	}
+	if c.Database.User == "" || c.Database.Password == "" {
+		v.fail("database.user and database.password are required")
+	}
+	v.oneOf("database.sslmode", c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	v.oneOf("database.loglevel", c.Database.LogLevel, "silent", "error", "warn", "info")
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		v.fail("database.maxopenconns and database.maxidleconns must not be negative")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		v.fail("database.maxidleconns (%d) must not exceed database.maxopenconns (%d)", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	}
+	if c.Database.ConnMaxLifetime < 0 || c.Database.ConnMaxIdleTime < 0 {
+		v.fail("database.connmaxlifetime and database.connmaxidletime must not be negative")
+	}
+	v.oneOf("database.queryexecmode", c.Database.QueryExecMode, "cache_statement", "cache_describe", "describe_exec", "exec", "simple_protocol")
+	if c.Database.StatementCacheCapacity < 0 {
+		v.fail("database.statementcachecapacity must not be negative, got %d", c.Database.StatementCacheCapacity)
+	}
+	for i, replica := range c.Database.Replicas {
+		if strings.TrimSpace(replica) == "" {
+			v.fail("database.replicas[%d] is empty", i)
+		}
+	}
+	if len(c.Database.Replicas) > 0 && c.Database.ReplicaCheckInterval < time.Second {
+		v.fail("database.replicacheckinterval must be at least 1s, got %s", c.Database.ReplicaCheckInterval)
+	}
+
+	// Authentication
+	if c.JWT.Secret == "" {
+		v.fail("jwt.secret is required")
+	} else if c.JWT.Secret == DefaultJWTSecret && strings.EqualFold(c.Server.Mode, "release") {
+		v.fail("jwt.secret is the built-in placeholder; set JWT_SECRET before running in release mode")
+	}
+	if c.JWT.Expiry <= 0 {
+		v.fail("jwt.expiry must be positive, got %s", c.JWT.Expiry)
+	}
+	if strings.TrimSpace(c.JWT.AdminRole) == "" {
+		v.fail("jwt.adminrole is required: without it every authenticated user could use the admin routes")
+	}
+
+	// CORS
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin != "*" && !validOrigin(origin) {
+			v.fail("cors.allowed_origins must hold origins such as https://app.example.com (scheme and host, no path), patterns such as https://*.example.com, or *, got %q", origin)
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		v.fail("cors.max_age must not be negative, got %s", c.CORS.MaxAge)
+	}
+
+	v.oneOf("log.level", c.Log.Level, "debug", "info", "warn", "error")
+
+	// LEI acquisition
+	v.duration("lei.deltasyncinterval", c.LEI.DeltaSyncInterval, time.Minute, false)
+	v.oneOf("lei.deltatype", c.LEI.DeltaType, "IntraDay", "LastDay", "LastWeek", "LastMonth")
+	if c.LEI.MaxCatchUpDeltas < 0 {
+		v.fail("lei.maxcatchupdeltas must not be negative, got %d", c.LEI.MaxCatchUpDeltas)
+	}
+	v.weekday("lei.fullsyncday", c.LEI.FullSyncDay, false)
+	v.timeOfDay("lei.fullsynctime", c.LEI.FullSyncTime, false)
+	v.timeOfDay("lei.cleanuptime", c.LEI.CleanupTime, false)
+	if c.LEI.KeepFullFiles < 1 || c.LEI.KeepDeltaFiles < 1 {
+		v.fail("lei.keepfullfiles and lei.keepdeltafiles must be at least 1")
+	}
+	v.duration("lei.processingtimeout", c.LEI.ProcessingTimeout, time.Minute, true)
+	v.duration("lei.stalltimeout", c.LEI.StallTimeout, time.Minute, true)
+	if c.LEI.ProcessingWorkers < 1 || c.LEI.ProcessingWorkers > 32 {
+		v.fail("lei.processingworkers must be between 1 and 32, got %d", c.LEI.ProcessingWorkers)
+	}
+	v.oneOf("lei.auditmode", c.LEI.AuditMode, "snapshot", "diff")
+	v.oneOf("lei.fileformat", c.LEI.FileFormat, "json", "xml")
+	v.timeOfDay("lei.reconciletime", c.LEI.ReconcileTime, true)
+	v.duration("lei.httptimeout", c.LEI.HTTPTimeout, 0, false)
+	v.duration("lei.httpretrybasedelay", c.LEI.HTTPRetryBaseDelay, 0, false)
+	v.duration("lei.httpretrymaxdelay", c.LEI.HTTPRetryMaxDelay, 0, false)
+	v.duration("lei.httpbreakercooldown", c.LEI.HTTPBreakerCooldown, time.Second, false)
+	if c.LEI.HTTPBreakerThreshold < 0 {
+		v.fail("lei.httpbreakerthreshold must not be negative, got %d", c.LEI.HTTPBreakerThreshold)
+	}
+	if c.LEI.HTTPMaxAttempts < 1 {
+		v.fail("lei.httpmaxattempts must be at least 1, got %d", c.LEI.HTTPMaxAttempts)
+	}
+	v.weekday("lei.elfrefreshday", c.LEI.ELFRefreshDay, true)
+	v.timeOfDay("lei.elfrefreshtime", c.LEI.ELFRefreshTime, true)
+	v.weekday("lei.rarefreshday", c.LEI.RARefreshDay, true)
+	v.timeOfDay("lei.rarefreshtime", c.LEI.RARefreshTime, true)
+	v.weekday("lei.lousyncday", c.LEI.LOUSyncDay, true)
+	v.timeOfDay("lei.lousynctime", c.LEI.LOUSyncTime, true)
+
+	// Storage and archive
+	if c.Storage.Backend != "" {
+		v.oneOf("storage.backend", c.Storage.Backend, "local", "s3", "gcs")
+	}
+	if (strings.EqualFold(c.Storage.Backend, "s3") || strings.EqualFold(c.Storage.Backend, "gcs")) && c.Storage.Bucket == "" {
+		v.fail("storage.bucket is required with the %s storage backend", c.Storage.Backend)
+	}
+	v.duration("export.sftp.timeout", c.Export.SFTP.Timeout, 0, false)
+
+	// Scheduled jobs
+	if c.Audit.RetentionMonths < 0 {
+		v.fail("audit.retentionmonths must not be negative, got %d", c.Audit.RetentionMonths)
+	}
+	v.timeOfDay("audit.prunetime", c.Audit.PruneTime, false)
+	if c.Scheduler.LeaderElection && c.Scheduler.LeaderLockKey <= 0 {
+		v.fail("scheduler.leaderlockkey must be positive, got %d", c.Scheduler.LeaderLockKey)
+	}
+	v.duration("scheduler.leadercheckinterval", c.Scheduler.LeaderCheckInterval, time.Second, false)
+	v.duration("scheduler.jitter", c.Scheduler.Jitter, 0, true)
+	if c.RenewalReport.Enabled {
+		v.weekday("renewalreport.day", c.RenewalReport.Day, true)
+		v.timeOfDay("renewalreport.time", c.RenewalReport.Time, false)
+	}
+
+	// Reference data
+	v.oneOf("referencedata.deactivationpolicy", c.ReferenceData.DeactivationPolicy, "flag", "deactivate", "restrict")
+	v.oneOf("referencedata.ssioverlappolicy", c.ReferenceData.SSIOverlapPolicy, "reject", "flag")
+	v.weekday("referencedata.micrefreshday", c.ReferenceData.MICRefreshDay, true)
+	v.timeOfDay("referencedata.micrefreshtime", c.ReferenceData.MICRefreshTime, true)
+	v.weekday("referencedata.countrysyncday", c.ReferenceData.CountrySyncDay, true)
+	v.timeOfDay("referencedata.countrysynctime", c.ReferenceData.CountrySyncTime, true)
+	v.weekday("referencedata.currencysyncday", c.ReferenceData.CurrencySyncDay, true)
+	v.timeOfDay("referencedata.currencysynctime", c.ReferenceData.CurrencySyncTime, true)
+	v.weekday("referencedata.fxsyncday", c.ReferenceData.FXSyncDay, true)
+	v.timeOfDay("referencedata.fxsynctime", c.ReferenceData.FXSyncTime, true)
+
+	// Background workers and change events
+	if c.DataImport.Workers < 0 {
+		v.fail("dataimport.workers must not be negative, got %d", c.DataImport.Workers)
+	}
+	v.duration("dataimport.pollinterval", c.DataImport.PollInterval, 0, false)
+	if c.Events.Publisher != "" {
+		v.oneOf("events.publisher", c.Events.Publisher, "none", "rabbitmq", "kafka")
+	}
+	v.duration("events.relayinterval", c.Events.RelayInterval, 0, false)
+	v.duration("events.outboxretention", c.Events.OutboxRetention, 0, false)
+	if c.Events.RelayBatchSize < 1 {
+		v.fail("events.relaybatchsize must be at least 1, got %d", c.Events.RelayBatchSize)
+	}
+	if c.Events.RelayMaxAttempts < 1 {
+		v.fail("events.relaymaxattempts must be at least 1, got %d", c.Events.RelayMaxAttempts)
+	}
+	if strings.EqualFold(c.Events.Publisher, "kafka") && len(c.Kafka.Brokers) == 0 {
+		v.fail("kafka.brokers is required when events.publisher is kafka")
+	}
+
+	// Notifications
+	if c.Notifications.Email.Enabled && (c.Notifications.Email.SMTPHost == "" || c.Notifications.Email.From == "") {
+		v.fail("notifications.email.smtphost and notifications.email.from are required when email notifications are enabled")
+	}
+	if c.Notifications.Slack.Enabled && c.Notifications.Slack.WebhookURL == "" {
+		v.fail("notifications.slack.webhookurl is required when Slack notifications are enabled")
+	}
+	if c.Notifications.Webhook.Enabled && c.Notifications.Webhook.URL == "" {
+		v.fail("notifications.webhook.url is required when webhook notifications are enabled")
+	}
+
+	// Rate and body limits, caching, error reporting
+	if c.RateLimit.Enabled {
+		v.oneOf("ratelimit.backend", c.RateLimit.Backend, "memory", "redis")
+		if strings.EqualFold(c.RateLimit.Backend, "redis") && c.Redis.URL == "" {
+			v.fail("redis.url is required by the redis rate limit backend")
+		}
+		for _, group := range []struct {
+			key  string
+			rule RateLimitRule
+		}{{"public", c.RateLimit.Public}, {"auth", c.RateLimit.Auth}, {"protected", c.RateLimit.Protected}} {
+			if group.rule.Rate < 0 || (group.rule.Rate > 0 && group.rule.Burst < 1) {
+				v.fail("ratelimit.%s needs a rate of 0 or more and, with a rate, a burst of at least 1", group.key)
+			}
+		}
+	}
+	v.oneOf("cache.backend", c.Cache.Backend, "none", "redis")
+	if strings.EqualFold(c.Cache.Backend, "redis") && c.Redis.URL == "" {
+		v.fail("redis.url is required by the redis cache backend")
+	}
+	if c.Cache.LEITTL < 0 || c.Cache.ReferenceTTL < 0 {
+		v.fail("cache.leittl and cache.referencettl must not be negative")
+	}
+	if c.Cache.LocalSize < 0 || c.Cache.LocalTTL < 0 {
+		v.fail("cache.localsize and cache.localttl must not be negative")
+	}
+	if c.BodyLimit.Public < 0 || c.BodyLimit.Auth < 0 || c.BodyLimit.Protected < 0 || c.BodyLimit.Upload < 0 {
+		v.fail("bodylimit.public, auth, protected and upload must not be negative")
+	}
+	if c.Masking.Enabled {
+		for i, rule := range c.Masking.Rules {
+			if len(rule.Fields) == 0 {
+				v.fail("masking.rules[%d] lists no fields", i)
+			}
+		}
+	}
+	v.oneOf("encryption.provider", c.Encryption.Provider, "none", "local")
+	if strings.EqualFold(c.Encryption.Provider, "local") {
+		if len(c.Encryption.Keys) == 0 {
+			v.fail("encryption.keys is required by the local encryption provider")
+		}
+		ids := map[string]bool{}
+		for i, key := range c.Encryption.Keys {
+			id, encoded, found := strings.Cut(strings.TrimSpace(key), ":")
+			kek, err := base64.StdEncoding.DecodeString(encoded)
+			switch {
+			case !found || id == "" || len(id) > 32 || strings.Contains(id, " "):
+				v.fail("encryption.keys[%d] must be <id>:<base64 key> with an ID of up to 32 characters", i)
+			case err != nil || len(kek) != 32:
+				v.fail("encryption.keys[%d] (%s) must be 32 bytes in base64, e.g. from openssl rand -base64 32", i, id)
+			case ids[id]:
+				v.fail("encryption.keys[%d] reuses the ID %s", i, id)
+			}
+			ids[id] = true
+		}
+	}
+	if c.Sentry.SampleRate < 0 || c.Sentry.SampleRate > 1 {
+		v.fail("sentry.samplerate must be between 0 and 1, got %g", c.Sentry.SampleRate)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// validOrigin reports whether s is a browser origin: scheme and host, optionally a port, nothing
+// else. The host may start with a *. wildcard label, matching any subdomain of the rest.
+func validOrigin(s string) bool {
+	if scheme, domain, ok := strings.Cut(s, "://*."); ok {
+		s = scheme + "://" + domain
+	}
+	if strings.Contains(s, "*") {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	return u.Path == "" && u.RawQuery == "" && u.Fragment == "" && u.User == nil
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// defaultConfig returns the configuration Load gives without a config file or environment
+func defaultConfig(t *testing.T) *Config {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	setDefaults()
+	var cfg Config
+	require.NoError(t, viper.Unmarshal(&cfg))
+	return &cfg
+}
+
+func TestValidate(t *testing.T) {
+	const kek = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=" // 32 bytes
+	tests := []struct {
+		name         string
+		change       func(c *Config)
+		wantProblems []string
+	}{
+		{
+			name:   "defaults",
+			change: func(*Config) {},
+		},
+		{
+			name: "every problem is reported at once",
+			change: func(c *Config) {
+				c.Server.Port = 0
+				c.Database.Port = 70000
+				c.Log.Level = "verbose"
+			},
+			wantProblems: []string{
+				"server.port must be between 1 and 65535, got 0",
+				"database.port must be between 1 and 65535, got 70000",
+				`log.level must be one of debug, info, warn, error, got "verbose"`,
+			},
+		},
+		{
+			name:         "placeholder JWT secret in release mode",
+			change:       func(c *Config) { c.Server.Mode = "release" },
+			wantProblems: []string{"jwt.secret is the built-in placeholder; set JWT_SECRET before running in release mode"},
+		},
+		{
+			name: "placeholder JWT secret replaced for release mode",
+			change: func(c *Config) {
+				c.Server.Mode = "Release"
+				c.JWT.Secret = "a-real-secret"
+			},
+		},
+		{
+			name:         "no admin role",
+			change:       func(c *Config) { c.JWT.AdminRole = " " },
+			wantProblems: []string{"jwt.adminrole is required: without it every authenticated user could use the admin routes"},
+		},
+		{
+			name:   "enumerations are case-insensitive",
+			change: func(c *Config) { c.LEI.AuditMode, c.Database.SSLMode = "DIFF", "Verify-Full" },
+		},
+		{
+			name: "durations",
+			change: func(c *Config) {
+				c.LEI.DeltaSyncInterval = "5"
+				c.LEI.StallTimeout = "30s"
+				c.LEI.ProcessingTimeout = "0s"
+				c.Scheduler.Jitter = "-1m"
+			},
+			wantProblems: []string{
+				`lei.deltasyncinterval must be a duration such as 30s or 1h, got "5"`,
+				"lei.stalltimeout must be at least 1m0s, got 30s",
+				"scheduler.jitter must be at least 1ns, got -1m",
+			},
+		},
+		{
+			name: "times of day",
+			change: func(c *Config) {
+				c.LEI.FullSyncTime = "25:00"
+				c.LEI.CleanupTime = ""
+				c.LEI.ReconcileTime = ""
+				c.Audit.PruneTime = "3pm"
+			},
+			wantProblems: []string{
+				`lei.fullsynctime must be a time of day as HH:MM, got "25:00"`,
+				`lei.cleanuptime must be a time of day as HH:MM, got ""`,
+				`audit.prunetime must be a time of day as HH:MM, got "3pm"`,
+			},
+		},
+		{
+			name: "days of the week",
+			change: func(c *Config) {
+				c.LEI.FullSyncDay = "Funday"
+				c.LEI.ELFRefreshDay = "thurs"
+				c.LEI.RARefreshDay = ""
+				c.LEI.LOUSyncDay = "SAT"
+			},
+			wantProblems: []string{`lei.fullsyncday must be a day of the week such as Monday, got "Funday"`},
+		},
+		{
+			name: "CORS origins",
+			change: func(c *Config) {
+				c.CORS.AllowedOrigins = []string{"https://app.example.com", "https://*.example.com", "http://localhost:3000", "*", "https://app.example.com/", "app.example.com", "https://*", "https://a.*.example.com"}
+			},
+			wantProblems: []string{
+				`cors.allowed_origins must hold origins such as https://app.example.com (scheme and host, no path), patterns such as https://*.example.com, or *, got "https://app.example.com/"`,
+				`cors.allowed_origins must hold origins such as https://app.example.com (scheme and host, no path), patterns such as https://*.example.com, or *, got "app.example.com"`,
+				`cors.allowed_origins must hold origins such as https://app.example.com (scheme and host, no path), patterns such as https://*.example.com, or *, got "https://*"`,
+				`cors.allowed_origins must hold origins such as https://app.example.com (scheme and host, no path), patterns such as https://*.example.com, or *, got "https://a.*.example.com"`,
+			},
+		},
+		{
+			name: "TLS with both a certificate and ACME",
+			change: func(c *Config) {
+				c.Server.TLS.Enabled = true
+				c.Server.TLS.CertFile, c.Server.TLS.KeyFile = "tls.crt", "tls.key"
+				c.Server.TLS.ACME.Domains = []string{"axiom.example.com"}
+			},
+			wantProblems: []string{"server.tls takes either certfile and keyfile or acme.domains, not both"},
+		},
+		{
+			name: "gRPC on the HTTP port",
+			change: func(c *Config) {
+				c.GRPC.Enabled = true
+				c.GRPC.Port = c.Server.Port
+			},
+			wantProblems: []string{"grpc.port must differ from server.port (8080)"},
+		},
+		{
+			name: "connection pool",
+			change: func(c *Config) {
+				c.Database.MaxOpenConns, c.Database.MaxIdleConns = 10, 20
+			},
+			wantProblems: []string{"database.maxidleconns (20) must not exceed database.maxopenconns (10)"},
+		},
+		{
+			name: "backends that need a server",
+			change: func(c *Config) {
+				c.Events.Publisher = "kafka"
+				c.Kafka.Brokers = nil
+				c.Cache.Backend = "redis"
+				c.Storage.Backend = "s3"
+			},
+			wantProblems: []string{
+				"storage.bucket is required with the s3 storage backend",
+				"kafka.brokers is required when events.publisher is kafka",
+				"redis.url is required by the redis cache backend",
+			},
+		},
+		{
+			name: "encryption keys",
+			change: func(c *Config) {
+				c.Encryption.Provider = "local"
+				c.Encryption.Keys = []string{"k1:" + kek, "k2:c2hvcnQ=", "k1:" + kek, "no-id"}
+			},
+			wantProblems: []string{
+				"encryption.keys[1] (k2) must be 32 bytes in base64, e.g. from openssl rand -base64 32",
+				"encryption.keys[2] reuses the ID k1",
+				"encryption.keys[3] must be <id>:<base64 key> with an ID of up to 32 characters",
+			},
+		},
+		{
+			name:         "negative body limit",
+			change:       func(c *Config) { c.BodyLimit.Upload = -1 },
+			wantProblems: []string{"bodylimit.public, auth, protected and upload must not be negative"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig(t)
+			tt.change(cfg)
+
+			err := cfg.Validate()
+			if tt.wantProblems == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var invalid *ValidationError
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, tt.wantProblems, invalid.Problems)
+		})
+	}
+}
+
+func TestValidationErrorListsEveryProblem(t *testing.T) {
+	one := &ValidationError{Problems: []string{"jwt.secret is required"}}
+	assert.Equal(t, "invalid configuration (1 problem):\n  - jwt.secret is required", one.Error())
+
+	two := &ValidationError{Problems: []string{"jwt.secret is required", "database.host is required"}}
+	assert.Equal(t, "invalid configuration (2 problems):\n  - jwt.secret is required\n  - database.host is required", two.Error())
+}
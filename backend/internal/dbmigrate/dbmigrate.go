@@ -0,0 +1,188 @@
+// Package dbmigrate applies the versioned schema migrations with golang-migrate, which records the
+// applied version, and whether a migration failed halfway, in schema_migrations.
+package dbmigrate
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib" // database/sql driver "pgx"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/migrations"
+)
+
+// lockTimeout is how long to wait for another process, such as a replica starting at the same
+// time, to finish applying migrations
+const lockTimeout = 5 * time.Minute
+
+// Migration is one version of the schema
+type Migration struct {
+	Version uint   `json:"version"`
+	Name    string `json:"name"`
+}
+
+// Status is where the database stands against the migrations
+type Status struct {
+	Version uint        `json:"version"` // last applied; 0 when none has been
+	Dirty   bool        `json:"dirty"`   // the last migration failed halfway
+	Latest  uint        `json:"latest"`
+	Pending []Migration `json:"pending"`
+	Unknown bool        `json:"unknown"` // the database is at a version these migrations do not have, i.e. newer
+}
+
+// Source returns the migrations in dir, or those built into the binary if dir is empty
+func Source(dir string) fs.FS {
+	if dir == "" {
+		return migrations.FS
+	}
+	return os.DirFS(dir)
+}
+
+// List returns the migrations in fsys in version order
+func List(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var list []Migration
+	for _, entry := range entries {
+		m, err := source.DefaultParse(entry.Name())
+		if err != nil || m.Direction != source.Up {
+			continue
+		}
+		list = append(list, Migration{Version: m.Version, Name: m.Identifier})
+	}
+	if len(list) == 0 {
+		return nil, errors.New("no migrations found")
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Migrator applies the migrations in a source to a database
+type Migrator struct {
+	m          *migrate.Migrate
+	migrations []Migration
+}
+
+// Open connects to the database at dsn on a connection of its own, which Close releases
+func Open(dsn string, fsys fs.FS) (*Migrator, error) {
+	list, err := List(fsys)
+	if err != nil {
+		return nil, err
+	}
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := pgx.WithInstance(db, &pgx.Config{})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect for migrations: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "pgx", driver)
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+	m.Log = logAdapter{}
+	m.LockTimeout = lockTimeout
+	return &Migrator{m: m, migrations: list}, nil
+}
+
+// Close releases the migrator's connection
+func (mg *Migrator) Close() error {
+	srcErr, dbErr := mg.m.Close()
+	if srcErr != nil {
+		return srcErr
+	}
+	return dbErr
+}
+
+// Status reports the applied version and the migrations still pending
+func (mg *Migrator) Status() (*Status, error) {
+	version, dirty, err := mg.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	return statusOf(version, dirty, mg.migrations), nil
+}
+
+// statusOf compares the applied version with migrations, which are in version order
+func statusOf(version uint, dirty bool, migrations []Migration) *Status {
+	status := &Status{Version: version, Dirty: dirty, Latest: migrations[len(migrations)-1].Version, Pending: []Migration{}}
+	status.Unknown = version > 0
+	for _, migration := range migrations {
+		if migration.Version == version {
+			status.Unknown = false
+		}
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status
+}
+
+// Check returns why a database at version, with the dirty flag of schema_migrations, cannot serve
+// the binary whose latest migration is latest: a migration failed halfway, or some are pending.
+// A newer database passes, so replicas of the previous release keep running during a rollout.
+func Check(version uint, dirty bool, latest uint) error {
+	switch {
+	case dirty:
+		return fmt.Errorf("migration %d failed halfway; fix the schema and force the version", version)
+	case version < latest:
+		return fmt.Errorf("database is at migration %d; %d is pending", version, latest)
+	}
+	return nil
+}
+
+// Up applies every pending migration, returning how many it applied
+func (mg *Migrator) Up() (int, error) {
+	before, err := mg.Status()
+	if err != nil {
+		return 0, err
+	}
+	if err := mg.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return 0, err
+	}
+	return len(before.Pending), nil
+}
+
+// Down rolls back the last steps migrations
+func (mg *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1, got %d", steps)
+	}
+	return mg.m.Steps(-steps)
+}
+
+// Force records version as applied and clears the dirty flag, after the schema has been repaired by
+// hand following a failed migration; it does not run any migration
+func (mg *Migrator) Force(version int) error {
+	return mg.m.Force(version)
+}
+
+// logAdapter writes golang-migrate's progress to the application log
+type logAdapter struct{}
+
+func (logAdapter) Printf(format string, v ...interface{}) {
+	log.Info().Str("component", "migrate").Msg(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (logAdapter) Verbose() bool {
+	return false
+}
@@ -0,0 +1,122 @@
+package dbmigrate
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/migrations"
+)
+
+func TestList(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000010_add_prices.up.sql":        {},
+		"000010_add_prices.down.sql":      {},
+		"000002_add_lei.up.sql":           {},
+		"000002_add_lei.down.sql":         {},
+		"000001_init_schema.up.sql":       {},
+		"000001_init_schema.down.sql":     {},
+		"migrations.go":                   {},
+		"README.md":                       {},
+		"000003_not_a_migration.sql":      {},
+		"000004_only_rolls_back.down.sql": {},
+	}
+
+	list, err := List(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "init_schema"},
+		{Version: 2, Name: "add_lei"},
+		{Version: 10, Name: "add_prices"},
+	}, list)
+
+	_, err = List(fstest.MapFS{"migrations.go": {}})
+	assert.Error(t, err)
+}
+
+func TestStatusOf(t *testing.T) {
+	migrations := []Migration{{Version: 1, Name: "a"}, {Version: 2, Name: "b"}, {Version: 5, Name: "c"}}
+
+	tests := []struct {
+		name        string
+		version     uint
+		dirty       bool
+		wantPending []uint
+		wantUnknown bool
+	}{
+		{name: "empty database", version: 0, wantPending: []uint{1, 2, 5}},
+		{name: "behind", version: 2, wantPending: []uint{5}},
+		{name: "dirty", version: 2, dirty: true, wantPending: []uint{5}},
+		{name: "current", version: 5, wantPending: []uint{}},
+		{name: "newer than the binary", version: 6, wantPending: []uint{}, wantUnknown: true},
+		{name: "between migrations", version: 3, wantPending: []uint{5}, wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := statusOf(tt.version, tt.dirty, migrations)
+			pending := []uint{}
+			for _, m := range status.Pending {
+				pending = append(pending, m.Version)
+			}
+			assert.Equal(t, tt.version, status.Version)
+			assert.Equal(t, tt.dirty, status.Dirty)
+			assert.Equal(t, uint(5), status.Latest)
+			assert.Equal(t, tt.wantPending, pending)
+			assert.Equal(t, tt.wantUnknown, status.Unknown)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		version uint
+		dirty   bool
+		wantErr string
+	}{
+		{name: "current", version: 5},
+		{name: "newer than the binary", version: 6},
+		{name: "pending", version: 4, wantErr: "database is at migration 4; 5 is pending"},
+		{name: "empty database", version: 0, wantErr: "database is at migration 0; 5 is pending"},
+		{name: "dirty", version: 5, dirty: true, wantErr: "migration 5 failed halfway"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.version, tt.dirty, 5)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestBuiltInMigrations checks the migrations built into the binary: versions follow each other
+// and each can be rolled back
+func TestBuiltInMigrations(t *testing.T) {
+	list, err := List(Source(""))
+	require.NoError(t, err)
+
+	for i, migration := range list {
+		assert.Equal(t, uint(i+1), migration.Version, "migration %s", migration.Name)
+		down := strings.Replace(migrationFile(t, migration), ".up.sql", ".down.sql", 1)
+		_, err := fs.Stat(migrations.FS, down)
+		assert.NoError(t, err, "%s has no down migration", migration.Name)
+	}
+}
+
+// migrationFile returns the name of a migration's up file
+func migrationFile(t *testing.T, migration Migration) string {
+	t.Helper()
+	matches, err := fs.Glob(migrations.FS, "*_"+migration.Name+".up.sql")
+	require.NoError(t, err)
+	require.Len(t, matches, 1, "migration %s", migration.Name)
+	return matches[0]
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// BICDirectoryEntry is an ISO 9362 BIC from the BIC directory
+type BICDirectoryEntry struct {
+	BIC             string    `gorm:"primaryKey;size:11" json:"bic"` // BIC11; a head office has branch code XXX
+	InstitutionName string    `gorm:"size:255;not null" json:"institution_name"`
+	BranchName      string    `gorm:"size:255" json:"branch_name,omitempty"`
+	City            string    `gorm:"size:100" json:"city,omitempty"`
+	CountryCode     string    `gorm:"size:2;not null" json:"country_code"`
+	Active          bool      `gorm:"not null;default:true" json:"active"` // false once a full import no longer lists it
+	ImportedAt      time.Time `gorm:"not null" json:"imported_at"`         // last import that listed it
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (BICDirectoryEntry) TableName() string {
+	return "bic_directory"
+}
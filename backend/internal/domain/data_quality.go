@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DQ finding statuses
+const (
+	DQFindingStatusOpen     = "OPEN"
+	DQFindingStatusResolved = "RESOLVED"
+)
+
+// DQ finding severities
+const (
+	DQSeverityError   = "ERROR"
+	DQSeverityWarning = "WARNING"
+)
+
+// DQ rule codes raised by reference data cascades
+const (
+	DQRuleInactiveCurrencyReference = "INACTIVE_CURRENCY_REFERENCE" // record references a deactivated currency
+	DQRuleInactiveCountryReference  = "INACTIVE_COUNTRY_REFERENCE"  // record references a deactivated country
+)
+
+// DQ rule codes raised by the referential integrity check
+const (
+	DQRuleOrphanedEntityReference     = "ORPHANED_ENTITY_REFERENCE"     // record references a deleted or missing entity
+	DQRuleOrphanedCurrencyReference   = "ORPHANED_CURRENCY_REFERENCE"   // record references a deleted or missing currency
+	DQRuleOrphanedCountryReference    = "ORPHANED_COUNTRY_REFERENCE"    // record references a deleted or missing country
+	DQRuleOrphanedInstrumentReference = "ORPHANED_INSTRUMENT_REFERENCE" // record references a deleted or missing instrument
+	DQRuleOrphanedAddressReference    = "ORPHANED_ADDRESS_REFERENCE"    // record references a deleted or missing address
+)
+
+// DQ rule codes raised by SSI writes
+const DQRuleOverlappingSSI = "OVERLAPPING_SSI" // SSI overlaps another for the same entity, currency, instrument and settlement type
+
+// DQ rule codes evaluated on LEI records during ingest; failing records are quarantined
+const (
+	DQRuleMissingLegalName = "MISSING_LEGAL_NAME"   // record has no legal name
+	DQRuleInvalidCountry   = "INVALID_COUNTRY_CODE" // legal or headquarters address country is not a known ISO 3166-1 code
+	DQRuleBadLEIChecksum   = "BAD_LEI_CHECKSUM"     // LEI is malformed or fails its ISO 17442 check digits
+)
+
+// DQFinding is a data quality issue raised against a single record
+// Records with open findings are flagged ReviewRequired until the findings are resolved
+type DQFinding struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RuleCode    string     `gorm:"size:100;not null;index" json:"rule_code"`
+	Severity    string     `gorm:"size:20;not null" json:"severity"`
+	TargetTable string     `gorm:"size:100;not null" json:"target_table"`
+	TargetID    uuid.UUID  `gorm:"type:uuid;not null" json:"target_id"`
+	Message     string     `gorm:"type:text;not null" json:"message"`
+	Cause       string     `gorm:"size:255" json:"cause"` // what raised the finding, e.g. "currency:HRK deactivated"
+	Status      string     `gorm:"size:20;not null;default:'OPEN';index" json:"status"`
+	ResolvedAt  *time.Time `json:"resolved_at"`
+	ResolvedBy  string     `gorm:"size:255" json:"resolved_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (DQFinding) TableName() string {
+	return "dq_findings"
+}
+
+// DependentRef identifies a record that references a piece of reference data
+type DependentRef struct {
+	Table string    `json:"table"`
+	ID    uuid.UUID `json:"id"`
+	Label string    `json:"label"` // human-readable identifier, e.g. account number
+}
+
+// IntegrityCheckResult summarises one reference (child column -> parent table) in an integrity report
+type IntegrityCheckResult struct {
+	RuleCode        string `json:"rule_code"`
+	Table           string `json:"table"`
+	Column          string `json:"column"`
+	ReferencedTable string `json:"referenced_table"`
+	Repairable      bool   `json:"repairable"` // orphans are soft-deleted when the check runs with repair
+	Orphans         int    `json:"orphans"`
+	Repaired        int    `json:"repaired"`
+	FindingsRaised  int    `json:"findings_raised"` // new OPEN findings; orphans already under an open finding are not raised again
+}
+
+// IntegrityReport is the outcome of a cross-resource referential integrity scan
+type IntegrityReport struct {
+	StartedAt      time.Time              `json:"started_at"`
+	CompletedAt    time.Time              `json:"completed_at"`
+	Repair         bool                   `json:"repair"`
+	TotalOrphans   int                    `json:"total_orphans"`
+	TotalRepaired  int                    `json:"total_repaired"`
+	FindingsRaised int                    `json:"findings_raised"`
+	Checks         []IntegrityCheckResult `json:"checks"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dead letter statuses
+const (
+	DeadLetterStatusPending  = "PENDING"  // failed permanently, awaiting operator action
+	DeadLetterStatusResolved = "RESOLVED" // retried successfully
+)
+
+// DeadLetter is a unit of async work (webhook delivery, event publish, queued job) that failed
+// permanently. The payload is kept so operators can inspect, edit and retry it.
+type DeadLetter struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Source        string     `gorm:"size:100;not null;index" json:"source"` // subsystem that produced the work, e.g. lei-refresh
+	Kind          string     `gorm:"size:100;not null" json:"kind"`         // type of work within the source, e.g. event type
+	Payload       string     `gorm:"type:jsonb;not null" json:"payload"`
+	Error         string     `gorm:"type:text" json:"error"`
+	Attempts      int        `gorm:"not null;default:1" json:"attempts"`
+	Status        string     `gorm:"size:20;not null;default:'PENDING';index" json:"status"`
+	LastFailedAt  time.Time  `json:"last_failed_at"`
+	LastRetriedAt *time.Time `json:"last_retried_at"`
+	ResolvedAt    *time.Time `json:"resolved_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (DeadLetter) TableName() string {
+	return "dead_letters"
+}
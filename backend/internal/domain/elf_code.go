@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// ISO 20275 ELF statuses
+const (
+	ELFStatusActive   = "ACTV"
+	ELFStatusInactive = "INAC"
+)
+
+// Reserved ELF codes GLEIF uses in LEI records instead of a listed code
+const (
+	ELFCodeNotListed   = "8888" // the legal form is not on the ELF list yet; the record gives it as text
+	ELFCodeNotProvided = "9999" // no legal form was provided
+)
+
+// ELFName is the name of a legal form in one language of the ELF code list
+type ELFName struct {
+	Language                    string `json:"language,omitempty"`
+	LanguageCode                string `json:"language_code,omitempty"` // ISO 639-1
+	LocalName                   string `json:"local_name"`
+	TransliteratedName          string `json:"transliterated_name,omitempty"`
+	LocalAbbreviations          string `json:"local_abbreviations,omitempty"` // semicolon separated, as listed
+	TransliteratedAbbreviations string `json:"transliterated_abbreviations,omitempty"`
+}
+
+// ELFCode is an entity legal form from the ISO 20275 ELF code list
+type ELFCode struct {
+	Code             string     `gorm:"primaryKey;size:4" json:"code"`
+	CountryCode      string     `gorm:"size:2;not null" json:"country_code"` // ISO 3166-1 alpha-2
+	Country          string     `gorm:"size:255" json:"country,omitempty"`
+	Jurisdiction     string     `gorm:"size:255" json:"jurisdiction,omitempty"`
+	SubdivisionCode  string     `gorm:"size:10" json:"subdivision_code,omitempty"` // ISO 3166-2, for sub-national legal forms
+	Name             string     `gorm:"size:500;not null" json:"name"`
+	Abbreviations    string     `gorm:"size:500" json:"abbreviations,omitempty"`
+	Names            []ELFName  `gorm:"type:jsonb;serializer:json;not null" json:"names"`
+	Status           string     `gorm:"size:4;not null" json:"status"`
+	CreatedDate      *time.Time `gorm:"type:date" json:"created_date,omitempty"`
+	ModificationDate *time.Time `gorm:"type:date" json:"modification_date,omitempty"`
+	ImportedAt       time.Time  `gorm:"not null" json:"imported_at"` // last import that listed it
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (ELFCode) TableName() string {
+	return "elf_codes"
+}
+
+// Active reports whether the legal form is in use
+func (e *ELFCode) Active() bool {
+	return e.Status != ELFStatusInactive
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons two entities are flagged as probable duplicates
+const (
+	EntityDuplicateSameLEI                = "SAME_LEI"
+	EntityDuplicateSameRegistrationNumber = "SAME_REGISTRATION_NUMBER" // equal ignoring case, spaces and punctuation
+	EntityDuplicateSimilarName            = "SIMILAR_NAME"
+)
+
+// Entity duplicate statuses
+const (
+	EntityDuplicateStatusOpen      = "OPEN"
+	EntityDuplicateStatusMerged    = "MERGED"
+	EntityDuplicateStatusDismissed = "DISMISSED" // reviewed as distinct entities; never flagged again
+)
+
+// EntityDuplicate is a pair of entities that are probably the same. The pair is stored once, with
+// EntityID the lower of the two IDs.
+type EntityDuplicate struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EntityID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_entity_duplicates_pair" json:"entity_id"`
+	Entity         *Entity    `gorm:"foreignKey:EntityID" json:"entity,omitempty"`
+	DuplicateID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_entity_duplicates_pair;index" json:"duplicate_id"`
+	Duplicate      *Entity    `gorm:"foreignKey:DuplicateID" json:"duplicate,omitempty"`
+	Reasons        string     `gorm:"size:255;not null" json:"reasons"` // comma-separated, e.g. SAME_LEI,SIMILAR_NAME
+	NameSimilarity float64    `gorm:"not null" json:"name_similarity"`  // 0-1, on normalized names
+	Status         string     `gorm:"size:20;not null;default:'OPEN';index" json:"status"`
+	DetectedAt     time.Time  `gorm:"not null" json:"detected_at"`
+	ReviewedBy     string     `gorm:"size:255" json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (EntityDuplicate) TableName() string {
+	return "entity_duplicates"
+}
+
+// EntityMerge records the merge of an entity into a surviving golden record: what was moved,
+// which empty survivor fields were filled, and the merged entity as it was before the merge
+type EntityMerge struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SurvivorID     uuid.UUID `gorm:"type:uuid;not null;index" json:"survivor_id"`
+	MergedID       uuid.UUID `gorm:"type:uuid;not null;index" json:"merged_id"`
+	MergedSnapshot string    `gorm:"type:jsonb;not null" json:"merged_snapshot"`
+	FilledFields   string    `gorm:"size:255" json:"filled_fields,omitempty"` // comma-separated survivor fields taken from the merged entity
+	Accounts       int       `gorm:"not null" json:"accounts"`                // accounts moved to the survivor
+	SSIs           int       `gorm:"column:ssis;not null" json:"ssis"`        // SSIs moved to the survivor
+	Addresses      int       `gorm:"not null" json:"addresses"`               // addresses moved to the survivor
+	Onboardings    int       `gorm:"not null" json:"onboardings"`             // onboardings moved to the survivor (0 or 1)
+	MergedBy       string    `gorm:"size:255;not null" json:"merged_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TableName overrides the table name
+func (EntityMerge) TableName() string {
+	return "entity_merges"
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// ISO 10383 MIC statuses
+const (
+	MICStatusActive  = "ACTIVE"
+	MICStatusUpdated = "UPDATED" // active, changed in the latest publication
+	MICStatusExpired = "EXPIRED"
+)
+
+// Exchange is a market or trading venue from the ISO 10383 MIC list
+type Exchange struct {
+	MIC                string     `gorm:"primaryKey;size:4" json:"mic"`
+	OperatingMIC       string     `gorm:"size:4;not null" json:"operating_mic"` // the MIC itself for an operating MIC
+	MarketType         string     `gorm:"size:4;not null" json:"market_type"`   // OPRT or SGMT
+	Name               string     `gorm:"size:255;not null" json:"name"`
+	LegalEntityName    string     `gorm:"size:255" json:"legal_entity_name,omitempty"`
+	LEI                string     `gorm:"column:lei;size:20" json:"lei,omitempty"`
+	MarketCategoryCode string     `gorm:"size:4" json:"market_category_code,omitempty"` // e.g. RMKT (regulated market), MLTF
+	Acronym            string     `gorm:"size:50" json:"acronym,omitempty"`
+	CountryCode        string     `gorm:"size:2;not null" json:"country_code"`
+	City               string     `gorm:"size:100" json:"city,omitempty"`
+	Website            string     `gorm:"size:255" json:"website,omitempty"`
+	Status             string     `gorm:"size:10;not null" json:"status"`
+	CreationDate       *time.Time `gorm:"type:date" json:"creation_date,omitempty"`
+	LastUpdateDate     *time.Time `gorm:"type:date" json:"last_update_date,omitempty"`
+	ExpiryDate         *time.Time `gorm:"type:date" json:"expiry_date,omitempty"`
+	ImportedAt         time.Time  `gorm:"not null" json:"imported_at"` // last import that listed it
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (Exchange) TableName() string {
+	return "exchanges"
+}
+
+// Active reports whether the MIC is in use
+func (e *Exchange) Active() bool {
+	return e.Status != MICStatusExpired
+}
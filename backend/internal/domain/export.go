@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Export formats
+const (
+	ExportFormatCSV    = "CSV"
+	ExportFormatJSON   = "JSON"
+	ExportFormatXLSX   = "XLSX"
+	ExportFormatNDJSON = "NDJSON" // JSON Lines: one record per line
+)
+
+// Kinds of data POST /data/export streams and scheduled exports write
+const (
+	ExportKindCountries   = "countries"
+	ExportKindCurrencies  = "currencies"
+	ExportKindEntities    = "entities"
+	ExportKindInstruments = "instruments"
+	ExportKindAccounts    = "accounts"
+	ExportKindSSIs        = "ssis"
+	ExportKindLEI         = "lei"
+)
+
+// Scheduled export destinations
+const (
+	// ExportDestinationFile writes the export under the configured export directory
+	ExportDestinationFile = "FILE"
+	// ExportDestinationSFTP uploads the export to a directory on an SFTP server
+	ExportDestinationSFTP = "SFTP"
+	// ExportDestinationS3 uploads the export to the archive bucket
+	ExportDestinationS3 = "S3"
+)
+
+// Scheduled export frequencies
+const (
+	ExportFrequencyDaily   = "DAILY"
+	ExportFrequencyWeekly  = "WEEKLY"
+	ExportFrequencyMonthly = "MONTHLY"
+)
+
+// Scheduled export run outcomes
+const (
+	ExportRunSucceeded = "SUCCEEDED"
+	ExportRunFailed    = "FAILED"
+)
+
+// LEISavedView is a named LEI query: the filters accepted by GET /lei, stored for reuse
+type LEISavedView struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name        string    `gorm:"size:255;not null;uniqueIndex" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	Search      string    `gorm:"size:255" json:"search"`     // LEI code or legal name substring
+	TextQuery   string    `gorm:"size:255" json:"q"`          // full-text query on legal name
+	Status      string    `gorm:"size:50" json:"status"`      // entity status, e.g. ACTIVE
+	Category    string    `gorm:"size:50" json:"category"`    // entity category, e.g. FUND
+	Country     string    `gorm:"size:2" json:"country"`      // legal address country
+	SortBy      string    `gorm:"size:50" json:"sort_by"`     // see GET /lei sortBy
+	SortOrder   string    `gorm:"size:4" json:"sort_order"`   // asc or desc
+	CreatedBy   string    `gorm:"size:255" json:"created_by"` // email of the creating user
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (LEISavedView) TableName() string {
+	return "lei_saved_views"
+}
+
+// ScheduledExport writes master data or LEI records matching its filters, or a saved LEI view,
+// on a recurring schedule and delivers the file to a destination
+type ScheduledExport struct {
+	ID          uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name        string            `gorm:"size:255;not null" json:"name"`
+	Kind        string            `gorm:"size:20;not null;default:lei" json:"kind"`            // see ExportKind*; lei with a saved view
+	Filters     map[string]string `gorm:"type:jsonb;serializer:json" json:"filters,omitempty"` // filters of the kind's list endpoint
+	SavedViewID *uuid.UUID        `gorm:"type:uuid;index" json:"saved_view_id,omitempty"`      // a saved LEI view, instead of filters
+	SavedView   *LEISavedView     `gorm:"foreignKey:SavedViewID" json:"saved_view,omitempty"`
+	Format      string            `gorm:"size:10;not null" json:"format"`      // CSV, XLSX, JSON or NDJSON
+	Destination string            `gorm:"size:20;not null" json:"destination"` // FILE, SFTP or S3
+	Recipients  string            `gorm:"type:text" json:"recipients"`         // comma-separated email addresses told about each run
+	Frequency   string            `gorm:"size:20;not null" json:"frequency"`   // DAILY, WEEKLY or MONTHLY
+	Active      bool              `gorm:"not null;default:true" json:"active"`
+	NextRunAt   time.Time         `gorm:"not null;index" json:"next_run_at"`
+
+	// SFTP destination; credentials and host keys are configured (export.sftp)
+	SFTPHost      string `gorm:"column:sftp_host;size:255" json:"sftp_host,omitempty"`
+	SFTPPort      int    `gorm:"column:sftp_port" json:"sftp_port,omitempty"` // default 22
+	SFTPUsername  string `gorm:"column:sftp_username;size:255" json:"sftp_username,omitempty"`
+	SFTPDirectory string `gorm:"column:sftp_directory;size:500" json:"sftp_directory,omitempty"` // must exist; default the login directory
+
+	// S3 destination: the archive bucket (archive.*)
+	S3Prefix string `gorm:"column:s3_prefix;size:500" json:"s3_prefix,omitempty"` // key prefix below archive.prefix; default exports/
+
+	// Outcome of the most recent run
+	LastRunAt     *time.Time `json:"last_run_at"`
+	LastRunStatus string     `gorm:"size:20" json:"last_run_status"` // SUCCEEDED or FAILED
+	LastRunError  string     `gorm:"type:text" json:"last_run_error,omitempty"`
+	LastRunFile   string     `gorm:"size:500" json:"last_run_file,omitempty"`
+	LastRunRows   int        `json:"last_run_rows"`
+
+	CreatedBy string    `gorm:"size:255" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (ScheduledExport) TableName() string {
+	return "scheduled_exports"
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// TableFreshness summarises the size and recency of a single table
+// RowCount is the planner's live-tuple estimate (pg_stat_user_tables), not an exact COUNT(*)
+type TableFreshness struct {
+	Table         string     `json:"table"`
+	RowCount      int64      `json:"row_count"`
+	LastUpdatedAt *time.Time `json:"last_updated_at"`
+	ChangeCounter int64      `json:"change_counter"` // inserts + updates + deletes; never decreases, statistics resets included
+}
+
+// ImportFreshness describes the most recent run of a data import job
+type ImportFreshness struct {
+	JobType       string     `json:"job_type"`
+	Status        string     `json:"status"`
+	LastRunAt     *time.Time `json:"last_run_at"`
+	LastSuccessAt *time.Time `json:"last_success_at"`
+}
+
+// DataFreshness is the payload of GET /data/freshness
+// Consumers compare ChangeSequence (or per-table values) with their last extract to decide whether to re-extract.
+// Both only increase, but can move without a visible change (an update writing the same values, a statistics reset).
+type DataFreshness struct {
+	GeneratedAt    time.Time         `json:"generated_at"`
+	CacheTTL       string            `json:"cache_ttl"`
+	ChangeSequence int64             `json:"change_sequence"`
+	Tables         []TableFreshness  `json:"tables"`
+	LastImports    []ImportFreshness `json:"last_imports"`
+}
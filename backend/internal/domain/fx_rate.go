@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// FXRate is a daily reference rate: 1 BaseCurrency buys Rate QuoteCurrency on RateDate
+type FXRate struct {
+	RateDate      time.Time `gorm:"primaryKey;type:date" json:"rate_date"`
+	BaseCurrency  string    `gorm:"primaryKey;size:3" json:"base_currency"`
+	QuoteCurrency string    `gorm:"primaryKey;size:3" json:"quote_currency"`
+	Rate          float64   `gorm:"type:decimal(28,10);not null" json:"rate"`
+	Source        string    `gorm:"size:100;not null" json:"source"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (FXRate) TableName() string {
+	return "fx_rates"
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// HolidayCalendar is the business day calendar of a market (MIC, e.g. XLON) or currency (e.g. USD)
+type HolidayCalendar struct {
+	Code        string            `gorm:"primaryKey;size:10" json:"code"`
+	Name        string            `gorm:"size:255;not null" json:"name"`
+	WeekendDays string            `gorm:"size:27;not null;default:SAT,SUN" json:"weekend_days"` // comma separated, e.g. SAT,SUN or FRI,SAT
+	Holidays    []CalendarHoliday `gorm:"foreignKey:CalendarCode;references:Code" json:"holidays,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (HolidayCalendar) TableName() string {
+	return "holiday_calendars"
+}
+
+// CalendarHoliday is a non-business day of a calendar besides its weekend days
+type CalendarHoliday struct {
+	CalendarCode string    `gorm:"primaryKey;size:10" json:"calendar_code"`
+	Date         time.Time `gorm:"primaryKey;type:date" json:"date"`
+	Name         string    `gorm:"size:255;not null" json:"name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName overrides the table name
+func (CalendarHoliday) TableName() string {
+	return "calendar_holidays"
+}
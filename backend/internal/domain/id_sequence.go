@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Business key targets an ID sequence can generate for
+const (
+	IDTargetEntity  = "ENTITY"  // entities.business_id
+	IDTargetAccount = "ACCOUNT" // accounts.account_number
+)
+
+// Check digit schemes appended to generated identifiers
+const (
+	CheckDigitNone  = "NONE"
+	CheckDigitLuhn  = "LUHN"  // one digit over the numeric part
+	CheckDigitMod97 = "MOD97" // two digits, ISO 7064 MOD 97-10 over prefix and number
+)
+
+// IDSequence generates firm-specific business identifiers: Prefix + NextValue zero-padded to
+// Padding digits + optional check digits. Values are drawn from the range NextValue..MaxValue.
+type IDSequence struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name       string    `gorm:"size:100;not null;uniqueIndex" json:"name"`
+	Target     string    `gorm:"size:20;not null;index" json:"target"` // ENTITY or ACCOUNT
+	Prefix     string    `gorm:"size:20" json:"prefix"`
+	Padding    int       `gorm:"not null;default:0" json:"padding"`
+	CheckDigit string    `gorm:"size:10;not null;default:'NONE'" json:"check_digit"` // NONE, LUHN or MOD97
+	NextValue  int64     `gorm:"not null;default:1" json:"next_value"`
+	MaxValue   int64     `gorm:"not null;default:0" json:"max_value"` // last value of the range; 0 means unbounded
+	Active     bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (IDSequence) TableName() string {
+	return "id_sequences"
+}
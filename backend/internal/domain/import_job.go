@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of master data a file import loads
+const (
+	ImportKindCountries   = "countries"
+	ImportKindCurrencies  = "currencies"
+	ImportKindEntities    = "entities"
+	ImportKindInstruments = "instruments"
+	ImportKindSSIs        = "ssis"
+)
+
+// Import file formats
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+)
+
+// Import job statuses
+const (
+	ImportStatusQueued              = "QUEUED"  // waiting for a worker
+	ImportStatusRunning             = "RUNNING" // a worker is processing the rows
+	ImportStatusCompleted           = "COMPLETED"
+	ImportStatusCompletedWithErrors = "COMPLETED_WITH_ERRORS" // some rows failed; the others were written
+	ImportStatusFailed              = "FAILED"                // the file could not be read, or the import was interrupted (see Error)
+)
+
+// Import row actions
+const (
+	ImportActionCreated   = "created"
+	ImportActionUpdated   = "updated"
+	ImportActionUnchanged = "unchanged" // the record already holds the row's values; nothing is written
+	ImportActionFailed    = "failed"
+)
+
+// ImportJob is a master data file import and the outcome of each of its rows
+type ImportJob struct {
+	ID             uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Kind           string            `gorm:"size:20;not null" json:"kind"`
+	Format         string            `gorm:"size:10;not null" json:"format"`
+	FileName       string            `gorm:"size:255;not null" json:"file_name"`
+	Status         string            `gorm:"size:30;not null" json:"status"`
+	DryRun         bool              `gorm:"not null" json:"dry_run"` // validated and compared only; the counts say what the import would do
+	TotalRows      int               `gorm:"not null" json:"total_rows"`
+	ProcessedRows  int               `gorm:"not null" json:"processed_rows"`
+	CreatedRows    int               `gorm:"not null" json:"created_rows"`
+	UpdatedRows    int               `gorm:"not null" json:"updated_rows"`
+	UnchangedRows  int               `gorm:"not null" json:"unchanged_rows"`
+	FailedRows     int               `gorm:"not null" json:"failed_rows"`
+	IgnoredColumns []string          `gorm:"type:jsonb;serializer:json" json:"ignored_columns"` // columns the kind does not have
+	Report         []ImportRowResult `gorm:"type:jsonb;serializer:json" json:"report,omitempty"`
+	Error          string            `gorm:"not null" json:"error,omitempty"` // why the file could not be read or the import stopped
+	RequestedBy    string            `gorm:"size:255;not null" json:"requested_by"`
+	Payload        []byte            `gorm:"type:bytea" json:"-"` // the uploaded file, kept until a worker has processed it, or until a failed import is retried
+	StartedAt      *time.Time        `json:"started_at"`
+	CompletedAt    *time.Time        `json:"completed_at"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// ImportRowResult is the outcome of one row of an import file
+type ImportRowResult struct {
+	Row     int      `json:"row"`               // line in a CSV file (the header is line 1), position in a JSON array (from 1)
+	Action  string   `json:"action"`            // created, updated, unchanged or failed
+	ID      string   `json:"id,omitempty"`      // the record created or updated (none for a dry-run create)
+	Key     string   `json:"key,omitempty"`     // the row's natural key or ID, to find it in the file
+	Changes []string `json:"changes,omitempty"` // fields an update changes
+	Error   string   `json:"error,omitempty"`   // why the row failed
+}
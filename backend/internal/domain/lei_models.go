@@ -41,15 +41,21 @@ type LEIRecord struct {
 	// Registration
 	RegistrationAuthority   string `gorm:"size:255" json:"registration_authority"`
 	RegistrationAuthorityID string `gorm:"size:255" json:"registration_authority_id"`
-	RegistrationNumber      string `gorm:"size:255" json:"registration_number"`
-	EntityCategory          string `gorm:"size:255" json:"entity_category"`
-	EntitySubCategory       string `gorm:"size:255" json:"entity_sub_category"`
-	EntityLegalForm         string `gorm:"size:255" json:"entity_legal_form"`
-	EntityStatus            string `gorm:"size:255" json:"entity_status"`
+	// Resolved from the GLEIF RA list in API responses
+	RegistrationAuthorityName         string `gorm:"-" json:"registration_authority_name,omitempty"`
+	RegistrationAuthorityJurisdiction string `gorm:"-" json:"registration_authority_jurisdiction,omitempty"`
+	RegistrationNumber                string `gorm:"size:255" json:"registration_number"`
+	EntityCategory                    string `gorm:"size:255" json:"entity_category"`
+	EntitySubCategory                 string `gorm:"size:255" json:"entity_sub_category"`
+	EntityLegalForm                   string `gorm:"size:255" json:"entity_legal_form"`         // ISO 20275 ELF code
+	EntityLegalFormName               string `gorm:"-" json:"entity_legal_form_name,omitempty"` // resolved from the ELF code list in API responses
+	EntityStatus                      string `gorm:"size:255" json:"entity_status"`
+	RegistrationStatus                string `gorm:"size:50" json:"registration_status"` // LEI registration: ISSUED, LAPSED, RETIRED, ...
 
 	// Associated entities
-	ManagingLOU  string `gorm:"size:255" json:"managing_lou"` // Local Operating Unit
-	SuccessorLEI string `gorm:"size:20" json:"successor_lei"`
+	ManagingLOU     string `gorm:"size:255" json:"managing_lou"`         // Local Operating Unit
+	ManagingLOUName string `gorm:"-" json:"managing_lou_name,omitempty"` // resolved from the LEI issuer list in API responses
+	SuccessorLEI    string `gorm:"size:20" json:"successor_lei"`
 
 	// Dates
 	InitialRegistrationDate time.Time `json:"initial_registration_date"`
@@ -78,6 +84,31 @@ func (LEIRecord) TableName() string {
 	return "lei_raw.lei_records"
 }
 
+// LEIFuzzyMatch is an LEI record returned by trigram similarity search
+// Similarity is the pg_trgm score (0-1) of the best matching name
+type LEIFuzzyMatch struct {
+	LEIRecord
+	Similarity float64 `json:"similarity"`
+}
+
+// LEIStatusTransition is an LEI whose registration status changed while a source file was loaded
+type LEIStatusTransition struct {
+	LEI       string    `json:"lei"`
+	LegalName string    `json:"legal_name"`
+	OldStatus string    `json:"old_status"`
+	NewStatus string    `json:"new_status"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// LEIReference is a master-data record that refers to an LEI: an entity carrying it, or an SSI
+// of such an entity
+type LEIReference struct {
+	LEI  string    `json:"lei"`
+	Kind string    `json:"kind"` // entity or ssi
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"` // entity name or SSI beneficiary name
+}
+
 // LEIRecordAudit represents the complete audit history of LEI record changes
 type LEIRecordAudit struct {
 	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -85,7 +116,7 @@ type LEIRecordAudit struct {
 	LEI         string    `gorm:"size:20;not null;index" json:"lei"`
 	Action      string    `gorm:"size:20;not null" json:"action"` // CREATE, UPDATE, DELETE
 
-	// Complete record snapshot
+	// Complete record snapshot ({} on UPDATE rows written in diff audit mode)
 	RecordSnapshot string `gorm:"type:jsonb;not null" json:"record_snapshot"`
 
 	// Change details
@@ -103,16 +134,115 @@ func (LEIRecordAudit) TableName() string {
 	return "lei_raw.lei_records_audit"
 }
 
+// LEIReportingException explains why an LEI has no direct or ultimate parent reported (GLEIF repex)
+type LEIReportingException struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	LEI                string     `gorm:"size:20;not null;index" json:"lei"`
+	ExceptionCategory  string     `gorm:"size:100;not null" json:"exception_category"` // DIRECT_ or ULTIMATE_ACCOUNTING_CONSOLIDATION_PARENT
+	ExceptionReason    string     `gorm:"size:100;not null" json:"exception_reason"`   // e.g. NATURAL_PERSONS, NON_CONSOLIDATING
+	ExceptionReference string     `gorm:"type:text" json:"exception_reference,omitempty"`
+	SourceFileID       *uuid.UUID `gorm:"type:uuid" json:"source_file_id"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (LEIReportingException) TableName() string {
+	return "lei_raw.reporting_exceptions"
+}
+
+// Quarantine sources: what kept a record out of lei_records
+const (
+	LEIQuarantineSourceDatabase = "DATABASE" // rejected by the database on import
+	LEIQuarantineSourceRules    = "RULES"    // failed data quality rules before import
+)
+
+// Quarantine review statuses
+const (
+	LEIQuarantineStatusPending   = "PENDING"   // awaiting review
+	LEIQuarantineStatusRequeued  = "REQUEUED"  // loaded after review
+	LEIQuarantineStatusDismissed = "DISMISSED" // reviewed and deliberately not loaded
+)
+
+// LEIQuarantinedRecord is a record from a source file that was kept out of lei_records: either the
+// database rejected it on import (e.g. a value too long or an invalid byte sequence), or it failed
+// data quality rules. The rest of its batch was committed.
+type LEIQuarantinedRecord struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	LEI            string     `gorm:"size:20;not null;index" json:"lei"`
+	SourceFileID   *uuid.UUID `gorm:"type:uuid;index" json:"source_file_id"`
+	Source         string     `gorm:"size:20;not null;default:'DATABASE'" json:"source"` // DATABASE or RULES
+	ErrorCode      string     `gorm:"size:10" json:"error_code"`                         // PostgreSQL SQLSTATE, e.g. 22001 (DATABASE only)
+	RuleCodes      string     `gorm:"size:255" json:"rule_codes,omitempty"`              // comma-separated failed DQ rules (RULES only)
+	ErrorMessage   string     `gorm:"type:text;not null" json:"error_message"`           // the rejection, or one reason per failed rule
+	RecordSnapshot string     `gorm:"type:text;not null" json:"record_snapshot"`         // JSON; text so malformed content can still be stored
+	Status         string     `gorm:"size:20;not null;default:'PENDING';index" json:"status"`
+	ReviewedBy     string     `gorm:"size:255" json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote     string     `gorm:"type:text" json:"review_note,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// TableName overrides the table name
+func (LEIQuarantinedRecord) TableName() string {
+	return "lei_raw.quarantined_records"
+}
+
+// LEIQualityMetric is the completeness and validity of one field across the records of a source
+// file, measured while the file was loaded
+type LEIQualityMetric struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"-"`
+	SourceFileID uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	Field        string    `gorm:"size:100;not null" json:"field"`
+	Position     int       `gorm:"not null" json:"-"` // display order of the field
+	Records      int       `gorm:"not null" json:"records"`
+	Populated    int       `gorm:"not null" json:"populated"`
+	Valid        *int      `json:"valid,omitempty"`              // nil for fields only measured for completeness
+	Completeness float64   `gorm:"not null" json:"completeness"` // populated as a percentage of records
+	Validity     *float64  `json:"validity,omitempty"`           // valid as a percentage of populated
+	CreatedAt    time.Time `json:"-"`
+}
+
+// TableName overrides the table name
+func (LEIQualityMetric) TableName() string {
+	return "lei_raw.quality_metrics"
+}
+
+// LEIReconciliationScopeTotal is the reconciliation scope covering every record
+const LEIReconciliationScopeTotal = "TOTAL"
+
+// LEIReconciliationResult compares the local LEI record count with GLEIF's for one scope (all
+// records, or one legal address country) in a reconciliation run
+type LEIReconciliationResult struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RunID            uuid.UUID `gorm:"type:uuid;not null;index" json:"run_id"`
+	Scope            string    `gorm:"size:10;not null" json:"scope"` // TOTAL or an ISO 3166-1 alpha-2 country code
+	LocalCount       int64     `gorm:"not null" json:"local_count"`
+	GLEIFCount       int64     `gorm:"column:gleif_count;not null" json:"gleif_count"`
+	Difference       int64     `gorm:"not null" json:"difference"`    // local minus GLEIF
+	DriftPercent     float64   `gorm:"not null" json:"drift_percent"` // |difference| as a percentage of the GLEIF count
+	Exceeded         bool      `gorm:"not null" json:"exceeded"`      // drift above the configured threshold
+	GLEIFPublishDate string    `gorm:"column:gleif_publish_date;size:50" json:"gleif_publish_date,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// TableName overrides the table name
+func (LEIReconciliationResult) TableName() string {
+	return "lei_raw.reconciliation_results"
+}
+
 // SourceFile represents metadata about downloaded GLEIF files
 type SourceFile struct {
 	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	FileName        string    `gorm:"size:500;not null" json:"file_name"`
-	FileType        string    `gorm:"size:20;not null" json:"file_type"` // FULL, DELTA
+	FileType        string    `gorm:"size:20;not null" json:"file_type"`                  // FULL, DELTA, REPEX
+	FileFormat      string    `gorm:"size:10;not null;default:'JSON'" json:"file_format"` // JSON or XML (LEI-CDF)
+	DeltaType       string    `gorm:"size:20" json:"delta_type,omitempty"`                // IntraDay, LastDay, LastWeek or LastMonth (DELTA files only)
 	FileURL         string    `gorm:"size:1000;not null" json:"file_url"`
 	FileSize        int64     `json:"file_size"`
 	FileHash        string    `gorm:"size:64" json:"file_hash"` // SHA-256 hash
 	DownloadedAt    time.Time `json:"downloaded_at"`
-	PublicationDate time.Time `json:"publication_date"`
+	PublicationDate time.Time `json:"publication_date"` // GLEIF publish the file belongs to; delta catch-up resumes after the latest applied one
 
 	// Processing status
 	ProcessingStatus string `gorm:"size:20;not null;default:'PENDING'" json:"processing_status"` // PENDING, IN_PROGRESS, COMPLETED, FAILED
@@ -128,7 +258,7 @@ type SourceFile struct {
 	// Retry tracking
 	RetryCount      int    `gorm:"default:0;not null" json:"retry_count"`
 	MaxRetries      int    `gorm:"default:3;not null" json:"max_retries"`
-	FailureCategory string `gorm:"size:50" json:"failure_category"` // SCHEMA_ERROR, NETWORK_ERROR, FILE_CORRUPTION, UNKNOWN
+	FailureCategory string `gorm:"size:50" json:"failure_category"` // SCHEMA_ERROR, NETWORK_ERROR, FILE_CORRUPTION, STALLED, UNKNOWN
 
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
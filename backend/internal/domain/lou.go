@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// LOU is an LEI issuer (Local Operating Unit) accredited by GLEIF to issue and manage LEIs
+type LOU struct {
+	LEI               string     `gorm:"column:lei;primaryKey;size:20" json:"lei"` // the LOU's own LEI, as in LEIRecord.ManagingLOU
+	Name              string     `gorm:"size:500;not null" json:"name"`
+	MarketingName     string     `gorm:"size:500" json:"marketing_name,omitempty"`
+	Website           string     `gorm:"size:500" json:"website,omitempty"`
+	AccreditationDate *time.Time `gorm:"type:date" json:"accreditation_date,omitempty"`
+	Accredited        bool       `gorm:"not null;default:true" json:"accredited"` // listed by GLEIF at the last sync
+	SyncedAt          time.Time  `gorm:"not null" json:"synced_at"`               // last sync that listed it
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (LOU) TableName() string {
+	return "lous"
+}
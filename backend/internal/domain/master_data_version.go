@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Master data domains with effective-dated versions
+const (
+	VersionDomainCountry    = "country"
+	VersionDomainCurrency   = "currency"
+	VersionDomainEntity     = "entity"
+	VersionDomainInstrument = "instrument"
+	VersionDomainSSI        = "ssi"
+)
+
+// MasterDataVersion is a record as it stood from ValidFrom until ValidTo. Versions are written by
+// database triggers on every change, so they cover writes from any path, including bulk imports.
+type MasterDataVersion struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Domain    string          `gorm:"size:20;not null" json:"domain"`
+	RecordID  uuid.UUID       `gorm:"type:uuid;not null" json:"record_id"`
+	Snapshot  json.RawMessage `gorm:"type:jsonb;not null" json:"snapshot" swaggertype:"object"` // the row, keyed by column name
+	ValidFrom time.Time       `gorm:"not null" json:"valid_from"`
+	ValidTo   *time.Time      `json:"valid_to"` // nil while current
+}
+
+// TableName overrides the table name
+func (MasterDataVersion) TableName() string {
+	return "master_data_versions"
+}
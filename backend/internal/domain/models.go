@@ -18,11 +18,13 @@ type BaseModel struct {
 // Country represents a country entity
 type Country struct {
 	BaseModel
-	Code       string `gorm:"uniqueIndex;size:2;not null" json:"code" validate:"required,len=2"`
-	Name       string `gorm:"not null" json:"name" validate:"required"`
-	Alpha3Code string `gorm:"size:3" json:"alpha3_code" validate:"len=3"`
-	Region     string `json:"region"`
-	Active     bool   `gorm:"default:true" json:"active"`
+	Code        string `gorm:"uniqueIndex;size:2;not null" json:"code" validate:"required,len=2"`
+	Name        string `gorm:"not null" json:"name" validate:"required"`
+	Alpha3Code  string `gorm:"size:3" json:"alpha3_code" validate:"len=3"`
+	NumericCode string `gorm:"size:3" json:"numeric_code"` // ISO 3166-1 numeric, e.g. 040
+	Region      string `json:"region"`
+	SubRegion   string `json:"sub_region"`
+	Active      bool   `gorm:"default:true" json:"active"`
 }
 
 // TableName overrides the table name
@@ -36,7 +38,10 @@ type Currency struct {
 	Code          string `gorm:"uniqueIndex;size:3;not null" json:"code" validate:"required,len=3"`
 	Name          string `gorm:"not null" json:"name" validate:"required"`
 	Symbol        string `json:"symbol"`
-	DecimalPlaces int    `gorm:"default:2" json:"decimal_places"`
+	NumericCode   string `gorm:"size:3" json:"numeric_code"` // ISO 4217 numeric, e.g. 978
+	DecimalPlaces int    `json:"decimal_places"`             // ISO 4217 minor units; 0 where not applicable
+	Fund          bool   `json:"fund"`                       // ISO 4217 fund code, e.g. CLF
+	PreciousMetal bool   `json:"precious_metal"`             // XAU, XAG, XPT, XPD
 	Active        bool   `gorm:"default:true" json:"active"`
 }
 
@@ -73,6 +78,9 @@ type Address struct {
 	AddressLine5 string `gorm:"size:70" json:"address_line_5,omitempty"`
 	AddressLine6 string `gorm:"size:70" json:"address_line_6,omitempty"`
 	AddressLine7 string `gorm:"size:70" json:"address_line_7,omitempty"`
+
+	ReviewRequired bool   `gorm:"not null;default:false" json:"review_required"` // set by reference data cascades; see dq_findings
+	ReviewReason   string `gorm:"size:255" json:"review_reason,omitempty"`
 }
 
 // TableName overrides the table name
@@ -96,9 +104,14 @@ type Entity struct {
 	BaseModel
 	Name               string          `gorm:"not null" json:"name" validate:"required"`
 	RegistrationNumber string          `gorm:"uniqueIndex" json:"registration_number"`
+	BusinessID         *string         `gorm:"size:50;uniqueIndex" json:"business_id"` // firm-specific identifier, generated on create when an ENTITY ID sequence is active
+	LEI                *string         `gorm:"size:20;index" json:"lei"`               // the entity's Legal Entity Identifier, if known
+	LegalForm          string          `gorm:"size:255" json:"legal_form,omitempty"`   // ISO 20275 ELF code
 	Type               EntityType      `gorm:"type:varchar(50)" json:"type"`
 	Addresses          []EntityAddress `gorm:"foreignKey:EntityID" json:"addresses,omitempty"`
 	Active             bool            `gorm:"default:true" json:"active"`
+	// Fields filled in automatically (e.g. from the LEI store); maintained by the enrichment, never by writes
+	AutoFilled []EntityFieldProvenance `gorm:"foreignKey:EntityID" json:"auto_filled,omitempty"`
 }
 
 // TableName overrides the table name
@@ -106,6 +119,33 @@ func (Entity) TableName() string {
 	return "entities"
 }
 
+// Sources entity fields can be auto-filled from
+const EntityFieldSourceLEI = "LEI" // the LEI record of the entity's LEI
+
+// Entity fields the LEI enrichment fills
+const (
+	EntityFieldName              = "name"
+	EntityFieldLegalForm         = "legal_form"
+	EntityFieldRegisteredAddress = "registered_address" // the REGISTERED entity address
+)
+
+// EntityFieldProvenance records that an entity field was auto-filled, from where and with which
+// value. A field whose current value no longer matches was edited since and is left alone.
+type EntityFieldProvenance struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"-"`
+	EntityID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_entity_field_provenance_field" json:"-"`
+	Field     string    `gorm:"size:50;not null;uniqueIndex:idx_entity_field_provenance_field" json:"field"`
+	Source    string    `gorm:"size:20;not null" json:"source"`  // e.g. LEI
+	SourceRef string    `gorm:"size:100" json:"source_ref"`      // e.g. the LEI the value came from
+	Value     string    `gorm:"type:text;not null" json:"value"` // value filled in; for an address, its fields joined by |
+	FilledAt  time.Time `gorm:"not null" json:"filled_at"`
+}
+
+// TableName overrides the table name
+func (EntityFieldProvenance) TableName() string {
+	return "entity_field_provenance"
+}
+
 // EntityAddress represents the many-to-many relationship between entities and addresses
 type EntityAddress struct {
 	BaseModel
@@ -139,11 +179,14 @@ type Instrument struct {
 	BaseModel
 	Name            string           `gorm:"not null" json:"name" validate:"required"`
 	Type            InstrumentType   `gorm:"type:varchar(50)" json:"type"`
+	CFI             string           `gorm:"column:cfi;size:6" json:"cfi,omitempty"` // ISO 10962 classification; sets Type when it maps to one
 	IssueCurrencyID *uuid.UUID       `gorm:"type:uuid;column:issue_currency_id" json:"issue_currency_id"`
 	IssueCurrency   *Currency        `gorm:"foreignKey:IssueCurrencyID" json:"issue_currency,omitempty"`
 	PrimaryExchange string           `gorm:"column:primary_exchange" json:"primary_exchange"`
 	Codes           []InstrumentCode `gorm:"foreignKey:InstrumentID" json:"codes,omitempty"`
 	Active          bool             `gorm:"default:true" json:"active"`
+	ReviewRequired  bool             `gorm:"not null;default:false" json:"review_required"`
+	ReviewReason    string           `gorm:"size:255" json:"review_reason,omitempty"`
 }
 
 // TableName overrides the table name
@@ -151,6 +194,27 @@ func (Instrument) TableName() string {
 	return "instruments"
 }
 
+// PriceSourceManual identifies prices loaded through the bulk import endpoint
+const PriceSourceManual = "MANUAL"
+
+// InstrumentPrice is a reference price for an instrument on a given date from a given source
+// One price per instrument, date and source; market data providers add their own source
+type InstrumentPrice struct {
+	BaseModel
+	InstrumentID uuid.UUID   `gorm:"type:uuid;not null" json:"instrument_id" validate:"required"`
+	Instrument   *Instrument `gorm:"foreignKey:InstrumentID" json:"instrument,omitempty"`
+	PriceDate    time.Time   `gorm:"type:date;not null" json:"price_date" validate:"required"`
+	Price        float64     `gorm:"type:decimal(28,10);not null" json:"price"`
+	CurrencyID   uuid.UUID   `gorm:"type:uuid;not null" json:"currency_id"`
+	Currency     *Currency   `gorm:"foreignKey:CurrencyID" json:"currency,omitempty"`
+	Source       string      `gorm:"size:100;not null" json:"source"`
+}
+
+// TableName overrides the table name
+func (InstrumentPrice) TableName() string {
+	return "instrument_prices"
+}
+
 // IdentifierLevel represents the level of instrument identifier
 type IdentifierLevel string
 
@@ -213,6 +277,8 @@ type Account struct {
 	Balance           float64     `gorm:"type:decimal(19,4);default:0" json:"balance"`
 	OpenedAt          time.Time   `json:"opened_at"`
 	Active            bool        `gorm:"default:true" json:"active"`
+	ReviewRequired    bool        `gorm:"not null;default:false" json:"review_required"`
+	ReviewReason      string      `gorm:"size:255" json:"review_reason,omitempty"`
 }
 
 // TableName overrides the table name
@@ -240,15 +306,21 @@ type SSI struct {
 	InstrumentID         *uuid.UUID     `gorm:"type:uuid" json:"instrument_id"`
 	Instrument           *Instrument    `gorm:"foreignKey:InstrumentID" json:"instrument,omitempty"`
 	BeneficiaryName      string         `gorm:"not null" json:"beneficiary_name" validate:"required"`
-	BeneficiaryAccount   string         `gorm:"not null" json:"beneficiary_account" validate:"required"`
+	BeneficiaryAccount   string         `gorm:"not null" json:"beneficiary_account" validate:"required"` // encrypted at rest by the services with encryption.provider set
 	BeneficiaryBank      string         `gorm:"not null" json:"beneficiary_bank" validate:"required"`
 	BeneficiaryBankBIC   string         `json:"beneficiary_bank_bic"`
 	IntermediaryBank     string         `json:"intermediary_bank"`
 	IntermediaryBankBIC  string         `json:"intermediary_bank_bic"`
 	SettlementType       SettlementType `gorm:"type:varchar(50)" json:"settlement_type"`
+	PlaceOfSettlement    string         `gorm:"size:11" json:"place_of_settlement,omitempty"` // PSET BIC, usually a CSD
+	CutoffTime           string         `gorm:"size:5" json:"cutoff_time,omitempty"`          // HH:MM in CutoffTimezone
+	CutoffTimezone       string         `gorm:"size:64" json:"cutoff_timezone,omitempty"`
+	DefaultRuleID        *uuid.UUID     `gorm:"type:uuid" json:"default_rule_id,omitempty"` // the SSIDefaultRule that filled fields at creation
 	ValidFrom            time.Time      `json:"valid_from"`
 	ValidTo              *time.Time     `json:"valid_to"`
 	Active               bool           `gorm:"default:true" json:"active"`
+	ReviewRequired       bool           `gorm:"not null;default:false" json:"review_required"`
+	ReviewReason         string         `gorm:"size:255" json:"review_reason,omitempty"`
 }
 
 // TableName overrides the table name
@@ -406,16 +478,24 @@ func (AccountAudit) TableName() string {
 
 // SSIAudit represents the complete audit history of SSI changes
 type SSIAudit struct {
-	ID                 uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	SSIID              uuid.UUID `gorm:"type:uuid;not null;index" json:"ssi_id"`
-	BeneficiaryAccount string    `gorm:"size:255" json:"beneficiary_account"`
-	Action             string    `gorm:"size:20;not null" json:"action"` // CREATE, UPDATE, DELETE
-	RecordSnapshot     string    `gorm:"type:jsonb;not null" json:"record_snapshot"`
-	ChangedFields      string    `gorm:"type:jsonb" json:"changed_fields"`
-	ChangedBy          string    `gorm:"size:100;not null;default:'system'" json:"changed_by"`
-	CreatedAt          time.Time `json:"created_at"`
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	SSIID          uuid.UUID `gorm:"type:uuid;not null;index" json:"ssi_id"`
+	Action         string    `gorm:"size:20;not null" json:"action"` // CREATE, UPDATE, DELETE
+	RecordSnapshot string    `gorm:"type:jsonb;not null" json:"record_snapshot"`
+	ChangedFields  string    `gorm:"type:jsonb" json:"changed_fields"`
+	ChangedBy      string    `gorm:"size:100;not null;default:'system'" json:"changed_by"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 func (SSIAudit) TableName() string {
 	return "ssis_audit"
 }
+
+// SSIConflict is a pair of active SSIs for the same entity, settlement currency, instrument and
+// settlement type whose validity windows overlap, so either could be picked for a settlement
+type SSIConflict struct {
+	SSI         *SSI       `json:"ssi"`
+	Other       *SSI       `json:"other"`
+	OverlapFrom time.Time  `json:"overlap_from"`
+	OverlapTo   *time.Time `json:"overlap_to"` // nil when both windows are open-ended
+}
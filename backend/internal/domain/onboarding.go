@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Counterparty onboarding statuses, in workflow order; APPROVED and REJECTED are final
+const (
+	OnboardingStatusDraft         = "DRAFT"
+	OnboardingStatusEnriched      = "ENRICHED"
+	OnboardingStatusSSIsValidated = "SSIS_VALIDATED"
+	OnboardingStatusApproved      = "APPROVED"
+	OnboardingStatusRejected      = "REJECTED"
+)
+
+// Counterparty onboarding actions, recorded on every attempt
+const (
+	OnboardingActionCreate       = "CREATE"
+	OnboardingActionEnrich       = "ENRICH"
+	OnboardingActionValidateSSIs = "VALIDATE_SSIS"
+	OnboardingActionApprove      = "APPROVE"
+	OnboardingActionReject       = "REJECT"
+)
+
+// Outcomes of an onboarding action
+const (
+	OnboardingOutcomeSucceeded = "SUCCEEDED"
+	OnboardingOutcomeFailed    = "FAILED" // the onboarding stayed in its status, e.g. SSIs failed validation
+)
+
+// Onboarding takes a counterparty from draft to approved. The entity, its accounts and SSIs are
+// created inactive with the draft, activated on approval and deleted on rejection.
+type Onboarding struct {
+	ID          uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EntityID    uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex" json:"entity_id"`
+	Entity      *Entity           `gorm:"foreignKey:EntityID" json:"entity,omitempty"`
+	Status      string            `gorm:"size:20;not null;index" json:"status"`
+	RequestedBy string            `gorm:"size:255;not null" json:"requested_by"`
+	ApprovedBy  string            `gorm:"size:255" json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time        `json:"approved_at,omitempty"`
+	Events      []OnboardingEvent `gorm:"foreignKey:OnboardingID" json:"events,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (Onboarding) TableName() string {
+	return "counterparty_onboardings"
+}
+
+// OnboardingEvent is the audit of one action on an onboarding, whether or not it moved it on
+type OnboardingEvent struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	OnboardingID uuid.UUID `gorm:"type:uuid;not null;index" json:"onboarding_id"`
+	Action       string    `gorm:"size:20;not null" json:"action"`
+	FromStatus   string    `gorm:"size:20" json:"from_status,omitempty"` // empty for CREATE
+	ToStatus     string    `gorm:"size:20;not null" json:"to_status"`    // equals FromStatus when the action failed
+	Outcome      string    `gorm:"size:20;not null" json:"outcome"`
+	Actor        string    `gorm:"size:255;not null" json:"actor"`
+	Note         string    `gorm:"type:text" json:"note,omitempty"`
+	Details      string    `gorm:"type:jsonb" json:"details,omitempty"` // e.g. fields filled, SSI validation failures
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName overrides the table name
+func (OnboardingEvent) TableName() string {
+	return "counterparty_onboarding_events"
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a record-change event written in the transaction of the change it describes and
+// published to the broker by the event relay
+type OutboxEvent struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	Seq         int64      `gorm:"->;uniqueIndex" json:"seq"` // relay order; assigned by the database
+	RoutingKey  string     `gorm:"size:50;not null" json:"routing_key"`
+	Payload     string     `gorm:"type:jsonb;not null" json:"payload"`
+	Attempts    int        `gorm:"not null;default:0" json:"attempts"`
+	LastError   string     `gorm:"type:text;not null;default:''" json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// TableName overrides the table name
+func (OutboxEvent) TableName() string {
+	return "event_outbox"
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// RegistrationAuthority is a business register from the GLEIF Registration Authorities (RA) list
+type RegistrationAuthority struct {
+	Code                  string    `gorm:"primaryKey;size:8" json:"code"`        // RA followed by 6 digits, e.g. RA000665
+	CountryCode           string    `gorm:"size:2" json:"country_code,omitempty"` // ISO 3166-1 alpha-2
+	Country               string    `gorm:"size:255" json:"country,omitempty"`
+	Jurisdiction          string    `gorm:"size:255" json:"jurisdiction,omitempty"` // country or region the register covers
+	RegisterName          string    `gorm:"size:500" json:"register_name,omitempty"`
+	RegisterLocalName     string    `gorm:"size:500" json:"register_local_name,omitempty"`
+	OrganizationName      string    `gorm:"size:500" json:"organization_name,omitempty"` // organization responsible for the register
+	OrganizationLocalName string    `gorm:"size:500" json:"organization_local_name,omitempty"`
+	Website               string    `gorm:"size:500" json:"website,omitempty"`
+	Name                  string    `gorm:"size:500;not null" json:"name"`
+	ImportedAt            time.Time `gorm:"not null" json:"imported_at"` // last import that listed it
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// TableName overrides the table name
+func (RegistrationAuthority) TableName() string {
+	return "registration_authorities"
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Schema drift issue kinds
+const (
+	SchemaDriftMissingTable  = "MISSING_TABLE"
+	SchemaDriftMissingColumn = "MISSING_COLUMN"
+	SchemaDriftSizeMismatch  = "SIZE_MISMATCH"
+	SchemaDriftExtraColumn   = "EXTRA_COLUMN"
+)
+
+// Schema drift severities
+// ERROR drift will break reads or writes (e.g. "value too long" on import), WARNING is a
+// mismatch that is currently harmless, INFO is expected in some cases (DB-only columns)
+const (
+	SchemaDriftSeverityError   = "ERROR"
+	SchemaDriftSeverityWarning = "WARNING"
+	SchemaDriftSeverityInfo    = "INFO"
+)
+
+// ColumnDefinition describes a column either as declared on a GORM model or as found in the database
+// Size is the character limit for text columns; 0 means unbounded (or not a text column)
+type ColumnDefinition struct {
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
+	Size     int    `json:"size"`
+}
+
+// SchemaDriftIssue is a single difference between a GORM model and the live schema
+type SchemaDriftIssue struct {
+	Table     string `json:"table"`
+	Column    string `json:"column,omitempty"`
+	Kind      string `json:"kind"`
+	Severity  string `json:"severity"`
+	ModelType string `json:"model_type,omitempty"`
+	DBType    string `json:"db_type,omitempty"`
+	ModelSize int    `json:"model_size,omitempty"`
+	DBSize    int    `json:"db_size,omitempty"`
+	Message   string `json:"message"`
+}
+
+// SchemaDriftReport is the result of comparing every GORM model with the live database
+type SchemaDriftReport struct {
+	GeneratedAt   time.Time          `json:"generated_at"`
+	TablesChecked int                `json:"tables_checked"`
+	Errors        int                `json:"errors"`
+	Warnings      int                `json:"warnings"`
+	Issues        []SchemaDriftIssue `json:"issues"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "github.com/google/uuid"
+
+// SSIDefaultRule holds the defaults for new SSIs of a market and/or settlement currency. The most
+// specific active rule matching an SSI fills the fields the SSI leaves empty.
+type SSIDefaultRule struct {
+	BaseModel
+	Market            *string        `gorm:"size:10" json:"market"`        // instrument primary exchange, e.g. XLON; nil matches any
+	CurrencyID        *uuid.UUID     `gorm:"type:uuid" json:"currency_id"` // nil matches any
+	Currency          *Currency      `gorm:"foreignKey:CurrencyID" json:"currency,omitempty"`
+	PlaceOfSettlement string         `gorm:"size:11" json:"place_of_settlement,omitempty"` // PSET BIC
+	SettlementType    SettlementType `gorm:"type:varchar(50)" json:"settlement_type,omitempty"`
+	CutoffTime        string         `gorm:"size:5" json:"cutoff_time,omitempty"` // HH:MM in CutoffTimezone
+	CutoffTimezone    string         `gorm:"size:64" json:"cutoff_timezone,omitempty"`
+	Active            bool           `gorm:"not null;default:true" json:"active"`
+}
+
+// TableName overrides the table name
+func (SSIDefaultRule) TableName() string {
+	return "ssi_default_rules"
+}
@@ -0,0 +1,76 @@
+// Package errreport sends panics and errors to Sentry, or a service speaking its protocol such as
+// GlitchTip, so failures are grouped and alerted on rather than only logged.
+//
+// Until Init is called with a DSN every function is a no-op, so callers report unconditionally.
+package errreport
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/version"
+)
+
+// Init connects to the error tracker at sentry.dsn; without a DSN nothing is reported.
+// environment is used when sentry.environment is not set.
+func Init(cfg config.SentryConfig, environment string) error {
+	if cfg.DSN == "" {
+		return nil
+	}
+	if cfg.Environment != "" {
+		environment = cfg.Environment
+	}
+	serverName, _ := os.Hostname()
+	return sentry.Init(sentry.ClientOptions{
+		Dsn:         cfg.DSN,
+		Environment: environment,
+		Release:     "axiom@" + version.Version,
+		ServerName:  serverName,
+		SampleRate:  cfg.SampleRate,
+		Debug:       cfg.Debug,
+		// Request bodies and headers may hold credentials and client data; only tags are sent
+		SendDefaultPII: false,
+	})
+}
+
+// Flush waits up to timeout for queued reports to be sent; call it before exiting
+func Flush(timeout time.Duration) {
+	sentry.Flush(timeout)
+}
+
+// Error reports err with tags describing where it happened (e.g. job_kind, source_file_id)
+func Error(err error, tags map[string]string) {
+	if err == nil {
+		return
+	}
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetTags(tags)
+		sentry.CaptureException(err)
+	})
+}
+
+// Panic reports a recovered panic with the stack it was recovered on; call it from the deferred
+// function that recovered
+func Panic(value any, tags map[string]string) {
+	message := fmt.Sprint(value)
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetTags(tags)
+		event := sentry.NewEvent()
+		event.Level = sentry.LevelFatal
+		event.Message = message
+		event.Exception = []sentry.Exception{{Type: "panic", Value: message, Stacktrace: sentry.NewStacktrace()}}
+		sentry.CaptureEvent(event)
+	})
+}
+
+// Message reports a failure that has no error value, such as a 5xx response
+func Message(message string, tags map[string]string) {
+	sentry.WithScope(func(scope *sentry.Scope) {
+		scope.SetTags(tags)
+		scope.SetLevel(sentry.LevelError)
+		sentry.CaptureMessage(message)
+	})
+}
@@ -0,0 +1,104 @@
+// Package events publishes record-change events (LEI and master data) to a message broker so
+// downstream systems such as settlement and risk can follow static-data updates as they happen.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// Domains events are raised for
+const (
+	DomainLEI        = "lei"
+	DomainCountry    = "country"
+	DomainCurrency   = "currency"
+	DomainEntity     = "entity"
+	DomainInstrument = "instrument"
+	DomainAccount    = "account"
+	DomainSSI        = "ssi"
+)
+
+// Actions
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+// Publishers selectable with events.publisher
+const (
+	PublisherNone     = "none"
+	PublisherRabbitMQ = "rabbitmq"
+	PublisherKafka    = "kafka"
+)
+
+// Event describes one committed change to a record
+type Event struct {
+	ID            uuid.UUID       `json:"id"`     // unique per event; consumers can use it to drop duplicates
+	Domain        string          `json:"domain"` // lei, entity, ssi, ...
+	Action        string          `json:"action"` // created, updated, deleted
+	Key           string          `json:"key"`    // the LEI for LEI records, otherwise the record ID
+	RecordID      uuid.UUID       `json:"record_id"`
+	ChangedFields []string        `json:"changed_fields,omitempty"` // updated LEI fields
+	SourceFileID  *uuid.UUID      `json:"source_file_id,omitempty"` // GLEIF file an LEI change came from
+	Data          json.RawMessage `json:"data,omitempty"`           // master data: the record after the change
+	Time          time.Time       `json:"time"`
+}
+
+// RoutingKey is <domain>.<action>, e.g. lei.updated
+func (e Event) RoutingKey() string {
+	return e.Domain + "." + e.Action
+}
+
+// New creates an event for a record change; data, if not nil, is marshalled as the event's payload
+func New(domain, action, key string, recordID uuid.UUID, data any) Event {
+	e := Event{
+		ID:       uuid.New(),
+		Domain:   domain,
+		Action:   action,
+		Key:      key,
+		RecordID: recordID,
+		Time:     time.Now().UTC(),
+	}
+	if data != nil {
+		if raw, err := json.Marshal(data); err == nil {
+			e.Data = raw
+		}
+	}
+	return e
+}
+
+// Publisher sends events to a broker. Publish returns once the broker has accepted every event,
+// or with an error if any was not.
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+	Close() error
+}
+
+// NewPublisher creates the publisher selected by cfg.Events.Publisher
+func NewPublisher(cfg *config.Config) (Publisher, error) {
+	switch strings.ToLower(cfg.Events.Publisher) {
+	case "", PublisherNone:
+		return Discard, nil
+	case PublisherRabbitMQ:
+		return newRabbitMQPublisher(cfg.RabbitMQ), nil
+	case PublisherKafka:
+		return newKafkaPublisher(cfg.Kafka)
+	default:
+		return nil, fmt.Errorf("unknown events.publisher %q (expected none, rabbitmq or kafka)", cfg.Events.Publisher)
+	}
+}
+
+// Discard is the publisher for deployments without a broker; it drops every event
+var Discard Publisher = nopPublisher{}
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(context.Context, ...Event) error { return nil }
+func (nopPublisher) Close() error                            { return nil }
@@ -0,0 +1,71 @@
+//go:build kafka
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// kafkaPublisher produces events to one topic per domain (<topicprefix><domain>), keyed by the
+// event key (LEI or record ID) so all changes to a record land on one partition, in order.
+// The client is idempotent and waits for all in-sync replicas to acknowledge.
+type kafkaPublisher struct {
+	client      *kgo.Client
+	topicPrefix string
+}
+
+func newKafkaPublisher(cfg config.KafkaConfig) (Publisher, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka.brokers is empty")
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Brokers...),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.ProducerBatchCompression(kgo.SnappyCompression(), kgo.NoCompression()),
+	}
+	if cfg.ClientID != "" {
+		opts = append(opts, kgo.ClientID(cfg.ClientID))
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	return &kafkaPublisher{client: client, topicPrefix: cfg.TopicPrefix}, nil
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	records := make([]*kgo.Record, len(events))
+	for i, event := range events {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+		}
+		records[i] = &kgo.Record{
+			Topic:     p.topicPrefix + event.Domain,
+			Key:       []byte(event.Key),
+			Value:     body,
+			Timestamp: event.Time,
+			Headers: []kgo.RecordHeader{
+				{Key: "event_id", Value: []byte(event.ID.String())},
+				{Key: "event_type", Value: []byte(event.RoutingKey())},
+			},
+		}
+	}
+	if err := p.client.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce change events: %w", err)
+	}
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	p.client.Close()
+	return nil
+}
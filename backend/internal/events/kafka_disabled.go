@@ -0,0 +1,15 @@
+//go:build !kafka
+
+package events
+
+import (
+	"fmt"
+
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// newKafkaPublisher is unavailable in builds without the kafka tag, which leave out the Kafka
+// client and its compression codecs
+func newKafkaPublisher(config.KafkaConfig) (Publisher, error) {
+	return nil, fmt.Errorf("events.publisher kafka requires a build with -tags kafka (the release images include it)")
+}
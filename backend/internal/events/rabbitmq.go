@@ -0,0 +1,116 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// rabbitMQPublisher publishes events to a durable topic exchange with publisher confirms. It
+// connects on first use and reconnects after the connection or channel breaks.
+type rabbitMQPublisher struct {
+	url      string
+	exchange string
+
+	mu      sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+func newRabbitMQPublisher(cfg config.RabbitMQConfig) *rabbitMQPublisher {
+	return &rabbitMQPublisher{url: cfg.URL, exchange: cfg.Exchange}
+}
+
+func (p *rabbitMQPublisher) Publish(ctx context.Context, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ch, err := p.open()
+	if err != nil {
+		return err
+	}
+
+	// Send everything, then wait for the confirms, so a batch costs one round trip rather than one each
+	confirms := make([]*amqp.DeferredConfirmation, 0, len(events))
+	for _, event := range events {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+		}
+		confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, p.exchange, event.RoutingKey(), false, false, amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    event.ID.String(),
+			Timestamp:    event.Time,
+			Type:         event.RoutingKey(),
+			Body:         body,
+		})
+		if err != nil {
+			p.reset()
+			return fmt.Errorf("failed to publish %s event: %w", event.RoutingKey(), err)
+		}
+		confirms = append(confirms, confirm)
+	}
+
+	for _, confirm := range confirms {
+		acked, err := confirm.WaitContext(ctx)
+		if err != nil {
+			p.reset() // confirms still outstanding on this channel would be misattributed
+			return fmt.Errorf("waiting for broker confirms: %w", err)
+		}
+		if !acked {
+			return fmt.Errorf("broker rejected event (delivery tag %d)", confirm.DeliveryTag)
+		}
+	}
+	return nil
+}
+
+// open returns the publishing channel, connecting and declaring the exchange if needed
+func (p *rabbitMQPublisher) open() (*amqp.Channel, error) {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return p.channel, nil
+	}
+	p.reset()
+
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open RabbitMQ channel: %w", err)
+	}
+	if err := ch.ExchangeDeclare(p.exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to declare exchange %s: %w", p.exchange, err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+	p.conn, p.channel = conn, ch
+	return ch, nil
+}
+
+// reset drops the connection so the next publish reconnects
+func (p *rabbitMQPublisher) reset() {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn, p.channel = nil, nil
+}
+
+func (p *rabbitMQPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reset()
+	return nil
+}
@@ -0,0 +1,211 @@
+// Package fieldcrypt encrypts sensitive column values at rest with envelope encryption. Each value
+// is sealed with AES-256-GCM under a data key of its own, and the data key is stored alongside it,
+// wrapped by a key encryption key (KEK) from a KeyProvider. Rotating the KEK only re-wraps data
+// keys; values whose KEK is retired stay readable as long as the provider still has it.
+//
+// Use installs the Cipher the services encrypt fields with before writing them and decrypt them
+// with after reading them. Values written before encryption was enabled have no envelope and are
+// read as they are.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// envelopePrefix starts every encrypted value, followed by <key id>:<wrapped data key>:<ciphertext>
+const envelopePrefix = "enc:v1:"
+
+// ErrNoKeys is returned reading an encrypted value without a key provider configured
+var ErrNoKeys = errors.New("value is encrypted but no encryption key provider is configured")
+
+// KeyProvider wraps and unwraps data keys with key encryption keys, e.g. keys held in config or in
+// a cloud KMS
+type KeyProvider interface {
+	// Wrap encrypts a data key with the primary KEK, returning that KEK's ID
+	Wrap(dataKey []byte) (keyID string, wrapped []byte, err error)
+	// Unwrap decrypts a data key wrapped by the KEK keyID, which need not be the primary one
+	Unwrap(keyID string, wrapped []byte) ([]byte, error)
+	// Primary returns the ID of the KEK Wrap uses
+	Primary() string
+}
+
+// Cipher encrypts and decrypts values with data keys wrapped by a KeyProvider. A nil Cipher stores
+// values in the clear.
+type Cipher struct {
+	keys KeyProvider
+}
+
+// New creates a cipher wrapping its data keys with keys
+func New(keys KeyProvider) *Cipher {
+	return &Cipher{keys: keys}
+}
+
+// Primary returns the ID of the KEK new values are encrypted under
+func (c *Cipher) Primary() string {
+	return c.keys.Primary()
+}
+
+// IsEncrypted reports whether value is an envelope rather than a plain value
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix)
+}
+
+// Encrypt seals value under a new data key wrapped with the primary KEK. Empty values stay empty, so
+// a required field left blank is still caught as blank.
+func (c *Cipher) Encrypt(value string) (string, error) {
+	if c == nil || value == "" {
+		return value, nil
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	sealed, err := seal(dataKey, []byte(value))
+	if err != nil {
+		return "", err
+	}
+	keyID, wrapped, err := c.keys.Wrap(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return envelopePrefix + keyID + ":" + encode(wrapped) + ":" + encode(sealed), nil
+}
+
+// Decrypt opens an envelope written by Encrypt; a plain value is returned as it is
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", ErrNoKeys
+	}
+
+	keyID, wrapped, sealed, err := parseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := c.keys.Unwrap(keyID, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	plain, err := open(dataKey, sealed)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plain), nil
+}
+
+// NeedsRotation reports whether value should be rewritten: it is plain, or its data key is wrapped
+// by a KEK other than the primary one
+func (c *Cipher) NeedsRotation(value string) bool {
+	if c == nil || value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	keyID, _, _ := strings.Cut(strings.TrimPrefix(value, envelopePrefix), ":")
+	return keyID != c.keys.Primary()
+}
+
+// Rotate rewrites value under the primary KEK: a plain value is encrypted, an envelope has its data
+// key re-wrapped. The ciphertext itself is kept.
+func (c *Cipher) Rotate(value string) (string, error) {
+	if !c.NeedsRotation(value) {
+		return value, nil
+	}
+	if !IsEncrypted(value) {
+		return c.Encrypt(value)
+	}
+
+	keyID, wrapped, sealed, err := parseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := c.keys.Unwrap(keyID, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	keyID, wrapped, err = c.keys.Wrap(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return envelopePrefix + keyID + ":" + encode(wrapped) + ":" + encode(sealed), nil
+}
+
+// parseEnvelope splits an envelope into its KEK ID, wrapped data key and sealed value
+func parseEnvelope(value string) (string, []byte, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(value, envelopePrefix), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", nil, nil, errors.New("malformed encrypted value")
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	return parts[0], wrapped, sealed, nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// seal encrypts plain with AES-GCM under key, returning the nonce followed by the ciphertext
+func seal(key, plain []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plain)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plain, nil), nil
+}
+
+// open decrypts the output of seal
+func open(key, sealed []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// current is the cipher Current returns; nil stores values in the clear
+var current atomic.Pointer[Cipher]
+
+// Use installs the cipher encrypted fields are written and read with; nil writes them in the clear
+// and fails reading encrypted ones
+func Use(c *Cipher) {
+	current.Store(c)
+}
+
+// Current returns the cipher installed by Use
+func Current() *Cipher {
+	return current.Load()
+}
@@ -0,0 +1,190 @@
+package fieldcrypt
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKey returns a <id>:<base64 key> setting whose key bytes are all fill
+func testKey(id string, fill byte) string {
+	return id + ":" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(fill), 32)))
+}
+
+func testCipher(t *testing.T, keys ...string) *Cipher {
+	t.Helper()
+	local, err := NewLocalKeys(keys)
+	require.NoError(t, err)
+	return New(local)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	c := testCipher(t, testKey("k1", 'a'))
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "account number", value: "GB82WEST12345698765432"},
+		{name: "unicode", value: "Zoë Müller"},
+		{name: "colons", value: "a:b:c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypted, err := c.Encrypt(tt.value)
+			require.NoError(t, err)
+			assert.True(t, IsEncrypted(encrypted))
+			assert.True(t, strings.HasPrefix(encrypted, envelopePrefix+"k1:"))
+			assert.NotContains(t, encrypted, tt.value)
+
+			decrypted, err := c.Decrypt(encrypted)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, decrypted)
+		})
+	}
+}
+
+func TestEncryptUsesFreshDataKeys(t *testing.T) {
+	c := testCipher(t, testKey("k1", 'a'))
+
+	first, err := c.Encrypt("12345678")
+	require.NoError(t, err)
+	second, err := c.Encrypt("12345678")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
+
+func TestEncryptLeavesValuesInTheClear(t *testing.T) {
+	var none *Cipher
+	encrypted, err := none.Encrypt("12345678")
+	require.NoError(t, err)
+	assert.Equal(t, "12345678", encrypted)
+
+	encrypted, err = testCipher(t, testKey("k1", 'a')).Encrypt("")
+	require.NoError(t, err)
+	assert.Equal(t, "", encrypted)
+}
+
+func TestDecrypt(t *testing.T) {
+	c := testCipher(t, testKey("k1", 'a'))
+	encrypted, err := c.Encrypt("12345678")
+	require.NoError(t, err)
+	id, rest, _ := strings.Cut(strings.TrimPrefix(encrypted, envelopePrefix), ":")
+	wrapped, sealed, _ := strings.Cut(rest, ":")
+
+	tests := []struct {
+		name    string
+		cipher  *Cipher
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "plain value", cipher: c, value: "12345678", want: "12345678"},
+		{name: "envelope", cipher: c, value: encrypted, want: "12345678"},
+		{name: "no keys", cipher: nil, value: encrypted, wantErr: ErrNoKeys.Error()},
+		{name: "malformed", cipher: c, value: envelopePrefix + "k1:only-two", wantErr: "malformed encrypted value"},
+		{name: "unknown key", cipher: c, value: envelopePrefix + "k9:" + rest, wantErr: `encryption key "k9" is not configured`},
+		{name: "wrong key", cipher: testCipher(t, testKey(id, 'b')), value: encrypted, wantErr: "failed to unwrap data key"},
+		{name: "tampered", cipher: c, value: envelopePrefix + id + ":" + wrapped + ":" + encode(tampered(t, sealed)), wantErr: "failed to decrypt value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cipher.Decrypt(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// tampered decodes a sealed value and flips a bit of its last byte
+func tampered(t *testing.T, sealed string) []byte {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(sealed)
+	require.NoError(t, err)
+	b[len(b)-1] ^= 1
+	return b
+}
+
+func TestRotate(t *testing.T) {
+	old := testCipher(t, testKey("k1", 'a'))
+	rotated := testCipher(t, testKey("k2", 'b'), testKey("k1", 'a'))
+
+	underOld, err := old.Encrypt("12345678")
+	require.NoError(t, err)
+	underNew, err := rotated.Encrypt("12345678")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		value     string
+		unchanged bool
+	}{
+		{name: "plain value is encrypted", value: "12345678"},
+		{name: "retired KEK is re-wrapped", value: underOld},
+		{name: "primary KEK is kept", value: underNew, unchanged: true},
+		{name: "empty stays empty", value: "", unchanged: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, !tt.unchanged, rotated.NeedsRotation(tt.value))
+
+			got, err := rotated.Rotate(tt.value)
+			require.NoError(t, err)
+			if tt.unchanged {
+				assert.Equal(t, tt.value, got)
+				return
+			}
+			assert.True(t, strings.HasPrefix(got, envelopePrefix+"k2:"))
+			assert.False(t, rotated.NeedsRotation(got))
+			plain, err := rotated.Decrypt(got)
+			require.NoError(t, err)
+			assert.Equal(t, "12345678", plain)
+		})
+	}
+}
+
+func TestRotateKeepsCiphertext(t *testing.T) {
+	old := testCipher(t, testKey("k1", 'a'))
+	rotated := testCipher(t, testKey("k2", 'b'), testKey("k1", 'a'))
+
+	encrypted, err := old.Encrypt("12345678")
+	require.NoError(t, err)
+	got, err := rotated.Rotate(encrypted)
+	require.NoError(t, err)
+
+	sealed := func(envelope string) string {
+		return envelope[strings.LastIndex(envelope, ":")+1:]
+	}
+	assert.Equal(t, sealed(encrypted), sealed(got))
+}
+
+func TestNewLocalKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		wantErr string
+	}{
+		{name: "valid", keys: []string{testKey("k1", 'a'), testKey("k2", 'b')}},
+		{name: "none", wantErr: "no encryption keys configured"},
+		{name: "no ID", keys: []string{base64.StdEncoding.EncodeToString(make([]byte, 32))}, wantErr: "must be <id>:<base64 key>"},
+		{name: "short key", keys: []string{"k1:" + base64.StdEncoding.EncodeToString(make([]byte, 16))}, wantErr: "must be 32 bytes"},
+		{name: "duplicate ID", keys: []string{testKey("k1", 'a'), testKey("k1", 'b')}, wantErr: `encryption key ID "k1" is used twice`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLocalKeys(tt.keys)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package fieldcrypt
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// LocalKeys is a KeyProvider holding its KEKs in configuration. The first key is the primary one;
+// the others only unwrap data keys wrapped before a rotation.
+type LocalKeys struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// NewLocalKeys parses KEKs given as <id>:<base64 of 32 random bytes>, primary first
+func NewLocalKeys(keys []string) (*LocalKeys, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no encryption keys configured")
+	}
+	local := &LocalKeys{keys: make(map[string][]byte, len(keys))}
+	for i, key := range keys {
+		id, kek, err := ParseKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %d: %w", i+1, err)
+		}
+		if _, ok := local.keys[id]; ok {
+			return nil, fmt.Errorf("encryption key ID %q is used twice", id)
+		}
+		if i == 0 {
+			local.primary = id
+		}
+		local.keys[id] = kek
+	}
+	return local, nil
+}
+
+// ParseKey splits a <id>:<base64 key> setting into the key's ID and its 32 bytes
+func ParseKey(key string) (string, []byte, error) {
+	id, encoded, found := strings.Cut(strings.TrimSpace(key), ":")
+	if !found || id == "" || len(id) > 32 || strings.ContainsAny(id, ": ") {
+		return "", nil, fmt.Errorf("must be <id>:<base64 key> with an ID of up to 32 characters and no colons")
+	}
+	kek, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(kek) != 32 {
+		return "", nil, fmt.Errorf("key %q must be 32 bytes in base64 (e.g. from openssl rand -base64 32)", id)
+	}
+	return id, kek, nil
+}
+
+// Wrap encrypts a data key with the primary KEK
+func (l *LocalKeys) Wrap(dataKey []byte) (string, []byte, error) {
+	wrapped, err := seal(l.keys[l.primary], dataKey)
+	return l.primary, wrapped, err
+}
+
+// Unwrap decrypts a data key wrapped by the KEK keyID
+func (l *LocalKeys) Unwrap(keyID string, wrapped []byte) ([]byte, error) {
+	kek, ok := l.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("encryption key %q is not configured", keyID)
+	}
+	return open(kek, wrapped)
+}
+
+// Primary returns the ID of the KEK new data keys are wrapped with
+func (l *LocalKeys) Primary() string {
+	return l.primary
+}
+
+// FromConfig creates the cipher encryption.provider selects; nil, storing values in the clear,
+// for none
+func FromConfig(cfg config.EncryptionConfig) (*Cipher, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", "none":
+		return nil, nil
+	case "local":
+		keys, err := NewLocalKeys(cfg.Keys)
+		if err != nil {
+			return nil, err
+		}
+		return New(keys), nil
+	default:
+		return nil, fmt.Errorf("unknown encryption provider %q", cfg.Provider)
+	}
+}
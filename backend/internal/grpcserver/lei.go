@@ -0,0 +1,196 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/service"
+	axiomv1 "github.com/techie2000/axiom/proto/axiom/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// batchGetLimit caps the codes of a BatchGetLEI call
+const batchGetLimit = 1000
+
+// errSearchLimit ends a search stream that has sent the records asked for
+var errSearchLimit = errors.New("search limit reached")
+
+type leiServer struct {
+	axiomv1.UnimplementedLEIServiceServer
+
+	lei     service.LEIService
+	exports service.DataExportService // streams search results
+	elf     service.ELFService
+	ra      service.RegistrationAuthorityService
+	lou     service.LOUService
+}
+
+func (s *leiServer) GetLEI(ctx context.Context, req *axiomv1.GetLEIRequest) (*axiomv1.LEIRecord, error) {
+	record, err := s.lei.GetLEIByCode(strings.ToUpper(strings.TrimSpace(req.GetLei())))
+	if err != nil {
+		return nil, callError(err, "LEI record")
+	}
+	s.describe(ctx, record)
+	return leiRecordMessage(record), nil
+}
+
+func (s *leiServer) BatchGetLEI(ctx context.Context, req *axiomv1.BatchGetLEIRequest) (*axiomv1.BatchGetLEIResponse, error) {
+	if len(req.GetLeis()) > batchGetLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d LEIs can be fetched in one call, got %d", batchGetLimit, len(req.GetLeis()))
+	}
+
+	resp := &axiomv1.BatchGetLEIResponse{}
+	records := make([]*domain.LEIRecord, 0, len(req.GetLeis()))
+	for _, lei := range req.GetLeis() {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		record, err := s.lei.GetLEIByCode(strings.ToUpper(strings.TrimSpace(lei)))
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			resp.Missing = append(resp.Missing, lei)
+		case err != nil:
+			return nil, callError(err, "LEI records")
+		default:
+			records = append(records, record)
+		}
+	}
+
+	s.describe(ctx, records...)
+	resp.Records = make([]*axiomv1.LEIRecord, len(records))
+	for i, record := range records {
+		resp.Records[i] = leiRecordMessage(record)
+	}
+	return resp, nil
+}
+
+func (s *leiServer) SearchLEI(req *axiomv1.SearchLEIRequest, stream axiomv1.LEIService_SearchLEIServer) error {
+	if req.GetLimit() < 0 {
+		return status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	filters := url.Values{}
+	for key, value := range map[string]string{
+		"search":    req.GetSearch(),
+		"q":         req.GetQuery(),
+		"status":    req.GetStatus(),
+		"category":  req.GetCategory(),
+		"country":   req.GetCountry(),
+		"legalForm": req.GetLegalForm(),
+		"lou":       req.GetManagingLou(),
+		"sortBy":    req.GetSortBy(),
+	} {
+		if value != "" {
+			filters.Set(key, value)
+		}
+	}
+	if req.GetDescending() {
+		filters.Set("sortOrder", "desc")
+	}
+
+	var sent int32
+	err := s.exports.StreamLEI(filters, func(record *domain.LEIRecord) error {
+		if req.GetLimit() > 0 && sent == req.GetLimit() {
+			return errSearchLimit
+		}
+		s.describe(stream.Context(), record)
+		if err := stream.Send(leiRecordMessage(record)); err != nil {
+			return err
+		}
+		sent++
+		return nil
+	})
+	switch {
+	case err == nil, errors.Is(err, errSearchLimit):
+		return nil
+	case stream.Context().Err() != nil:
+		return status.FromContextError(stream.Context().Err()).Err()
+	default:
+		return callError(err, "LEI records")
+	}
+}
+
+// describe resolves the codes of LEI records to names, as the REST API does
+func (s *leiServer) describe(ctx context.Context, records ...*domain.LEIRecord) {
+	s.elf.DescribeLEIRecords(ctx, records...)
+	s.ra.DescribeLEIRecords(ctx, records...)
+	s.lou.DescribeLEIRecords(ctx, records...)
+}
+
+// leiRecordMessage converts a record to its message
+func leiRecordMessage(record *domain.LEIRecord) *axiomv1.LEIRecord {
+	return &axiomv1.LEIRecord{
+		Lei:                     record.LEI,
+		LegalName:               record.LegalName,
+		TransliteratedLegalName: record.TransliteratedLegalName,
+		OtherNames:              otherNames(record.OtherNames),
+		LegalAddress: address(record.LegalAddressCity, record.LegalAddressRegion, record.LegalAddressCountry, record.LegalAddressPostalCode,
+			record.LegalAddressLine1, record.LegalAddressLine2, record.LegalAddressLine3, record.LegalAddressLine4),
+		HeadquartersAddress: address(record.HQAddressCity, record.HQAddressRegion, record.HQAddressCountry, record.HQAddressPostalCode,
+			record.HQAddressLine1, record.HQAddressLine2, record.HQAddressLine3, record.HQAddressLine4),
+		RegistrationAuthority:     record.RegistrationAuthority,
+		RegistrationAuthorityId:   record.RegistrationAuthorityID,
+		RegistrationAuthorityName: record.RegistrationAuthorityName,
+		RegistrationNumber:        record.RegistrationNumber,
+		EntityCategory:            record.EntityCategory,
+		EntitySubCategory:         record.EntitySubCategory,
+		EntityLegalForm:           record.EntityLegalForm,
+		EntityLegalFormName:       record.EntityLegalFormName,
+		EntityStatus:              record.EntityStatus,
+		RegistrationStatus:        record.RegistrationStatus,
+		ManagingLou:               record.ManagingLOU,
+		ManagingLouName:           record.ManagingLOUName,
+		SuccessorLei:              record.SuccessorLEI,
+		InitialRegistrationDate:   timestamp(record.InitialRegistrationDate),
+		LastUpdateDate:            timestamp(record.LastUpdateDate),
+		NextRenewalDate:           timestamp(record.NextRenewalDate),
+		ValidationAuthority:       record.ValidationAuthority,
+	}
+}
+
+// address converts an address, leaving out blank lines
+func address(city, region, country, postalCode string, lines ...string) *axiomv1.Address {
+	a := &axiomv1.Address{City: city, Region: region, Country: country, PostalCode: postalCode}
+	for _, line := range lines {
+		if line != "" {
+			a.Lines = append(a.Lines, line)
+		}
+	}
+	return a
+}
+
+// otherNames reads the other names of a record, stored as a JSON array
+func otherNames(stored string) []*axiomv1.OtherName {
+	if stored == "" {
+		return nil
+	}
+	var names []struct {
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Language string `json:"language"`
+	}
+	if json.Unmarshal([]byte(stored), &names) != nil {
+		return nil
+	}
+	messages := make([]*axiomv1.OtherName, len(names))
+	for i, name := range names {
+		messages[i] = &axiomv1.OtherName{Name: name.Name, Type: name.Type, Language: name.Language}
+	}
+	return messages
+}
+
+// timestamp converts a time, leaving a zero time unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,69 @@
+package grpcserver
+
+import (
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maskingStream masks the messages a stream sends
+type maskingStream struct {
+	grpc.ServerStream
+	masked map[string]bool
+}
+
+func (s *maskingStream) SendMsg(m any) error {
+	if message, ok := m.(proto.Message); ok {
+		maskMessage(message.ProtoReflect(), s.masked)
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// maskMessage masks the fields of message named in masked, at any depth, as jsonfmt.MaskFields
+// masks JSON: fields match by name (as in the REST API's JSON) or JSON name, strings keep their
+// last four characters and other values are cleared
+func maskMessage(message protoreflect.Message, masked map[string]bool) {
+	if len(masked) == 0 {
+		return
+	}
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case masked[string(field.Name())] || masked[field.JSONName()]:
+			maskField(message, field, value)
+		case field.IsMap():
+			if field.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+					maskMessage(item.Message(), masked)
+					return true
+				})
+			}
+		case field.IsList():
+			if field.Message() != nil {
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					maskMessage(list.Get(i).Message(), masked)
+				}
+			}
+		case field.Message() != nil:
+			maskMessage(value.Message(), masked)
+		}
+		return true
+	})
+}
+
+// maskField masks the value of a field named in masked
+func maskField(message protoreflect.Message, field protoreflect.FieldDescriptor, value protoreflect.Value) {
+	if field.Kind() != protoreflect.StringKind || field.IsMap() {
+		message.Clear(field)
+		return
+	}
+	if field.IsList() {
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			list.Set(i, protoreflect.ValueOfString(jsonfmt.MaskString(list.Get(i).String())))
+		}
+		return
+	}
+	message.Set(field, protoreflect.ValueOfString(jsonfmt.MaskString(value.String())))
+}
@@ -0,0 +1,71 @@
+package grpcserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	axiomv1 "github.com/techie2000/axiom/proto/axiom/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMaskMessage(t *testing.T) {
+	renewal := timestamppb.New(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		message proto.Message
+		masked  map[string]bool
+		want    proto.Message
+	}{
+		{
+			name:    "nothing masked",
+			message: &axiomv1.LEIRecord{Lei: "5493001KJTIIGC8Y1R12", RegistrationNumber: "HRB 12345"},
+			want:    &axiomv1.LEIRecord{Lei: "5493001KJTIIGC8Y1R12", RegistrationNumber: "HRB 12345"},
+		},
+		{
+			name:    "strings keep their last four characters",
+			message: &axiomv1.LEIRecord{Lei: "5493001KJTIIGC8Y1R12", RegistrationNumber: "HRB 12345", SuccessorLei: "abc"},
+			masked:  map[string]bool{"registration_number": true, "successor_lei": true},
+			want:    &axiomv1.LEIRecord{Lei: "5493001KJTIIGC8Y1R12", RegistrationNumber: "*****2345", SuccessorLei: "****"},
+		},
+		{
+			name:    "fields match by JSON name too",
+			message: &axiomv1.LEIRecord{RegistrationNumber: "HRB 12345"},
+			masked:  map[string]bool{"registrationNumber": true},
+			want:    &axiomv1.LEIRecord{RegistrationNumber: "*****2345"},
+		},
+		{
+			name:    "other values are cleared",
+			message: &axiomv1.LEIRecord{Lei: "5493001KJTIIGC8Y1R12", NextRenewalDate: renewal, LegalAddress: &axiomv1.Address{City: "Berlin"}},
+			masked:  map[string]bool{"next_renewal_date": true, "legal_address": true},
+			want:    &axiomv1.LEIRecord{Lei: "5493001KJTIIGC8Y1R12"},
+		},
+		{
+			name: "at any depth",
+			message: &axiomv1.BatchGetLEIResponse{
+				Records: []*axiomv1.LEIRecord{{
+					HeadquartersAddress: &axiomv1.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin"},
+					OtherNames:          []*axiomv1.OtherName{{Name: "Acme Holdings", Type: "TRADING_OR_OPERATING_NAME"}},
+				}},
+				Missing: []string{"529900T8BM49AURSDO55"},
+			},
+			masked: map[string]bool{"lines": true, "name": true},
+			want: &axiomv1.BatchGetLEIResponse{
+				Records: []*axiomv1.LEIRecord{{
+					HeadquartersAddress: &axiomv1.Address{Lines: []string{"**************en 1"}, City: "Berlin"},
+					OtherNames:          []*axiomv1.OtherName{{Name: "*********ings", Type: "TRADING_OR_OPERATING_NAME"}},
+				}},
+				Missing: []string{"529900T8BM49AURSDO55"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maskMessage(tt.message.ProtoReflect(), tt.masked)
+			assert.True(t, proto.Equal(tt.want, tt.message), "got %v", tt.message)
+		})
+	}
+}
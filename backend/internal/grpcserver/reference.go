@@ -0,0 +1,81 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/service"
+	axiomv1 "github.com/techie2000/axiom/proto/axiom/v1"
+)
+
+type referenceServer struct {
+	axiomv1.UnimplementedReferenceDataServiceServer
+
+	countries  service.CountryService
+	currencies service.CurrencyService
+}
+
+func (s *referenceServer) GetCountry(ctx context.Context, req *axiomv1.GetCountryRequest) (*axiomv1.Country, error) {
+	country, err := s.countries.GetByCode(req.GetCode())
+	if err != nil {
+		return nil, callError(err, "country")
+	}
+	return countryMessage(country), nil
+}
+
+func (s *referenceServer) ListCountries(ctx context.Context, req *axiomv1.ListCountriesRequest) (*axiomv1.ListCountriesResponse, error) {
+	countries, err := s.countries.GetAll(-1, 0)
+	if err != nil {
+		return nil, callError(err, "countries")
+	}
+	resp := &axiomv1.ListCountriesResponse{Countries: make([]*axiomv1.Country, len(countries))}
+	for i, country := range countries {
+		resp.Countries[i] = countryMessage(country)
+	}
+	return resp, nil
+}
+
+func (s *referenceServer) GetCurrency(ctx context.Context, req *axiomv1.GetCurrencyRequest) (*axiomv1.Currency, error) {
+	currency, err := s.currencies.GetByCode(req.GetCode())
+	if err != nil {
+		return nil, callError(err, "currency")
+	}
+	return currencyMessage(currency), nil
+}
+
+func (s *referenceServer) ListCurrencies(ctx context.Context, req *axiomv1.ListCurrenciesRequest) (*axiomv1.ListCurrenciesResponse, error) {
+	currencies, err := s.currencies.GetAll(-1, 0)
+	if err != nil {
+		return nil, callError(err, "currencies")
+	}
+	resp := &axiomv1.ListCurrenciesResponse{Currencies: make([]*axiomv1.Currency, len(currencies))}
+	for i, currency := range currencies {
+		resp.Currencies[i] = currencyMessage(currency)
+	}
+	return resp, nil
+}
+
+func countryMessage(country *domain.Country) *axiomv1.Country {
+	return &axiomv1.Country{
+		Code:        country.Code,
+		Name:        country.Name,
+		Alpha3Code:  country.Alpha3Code,
+		NumericCode: country.NumericCode,
+		Region:      country.Region,
+		SubRegion:   country.SubRegion,
+		Active:      country.Active,
+	}
+}
+
+func currencyMessage(currency *domain.Currency) *axiomv1.Currency {
+	return &axiomv1.Currency{
+		Code:          currency.Code,
+		Name:          currency.Name,
+		Symbol:        currency.Symbol,
+		NumericCode:   currency.NumericCode,
+		DecimalPlaces: int32(currency.DecimalPlaces),
+		Fund:          currency.Fund,
+		PreciousMetal: currency.PreciousMetal,
+		Active:        currency.Active,
+	}
+}
@@ -0,0 +1,186 @@
+// Package grpcserver serves the gRPC API of proto/axiom/v1: LEI and reference data lookups for
+// internal services that make too many of them for the REST API. It answers from the same services
+// as the REST handlers, and takes the same bearer tokens.
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/errreport"
+	"github.com/techie2000/axiom/internal/middleware"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/service"
+	axiomv1 "github.com/techie2000/axiom/proto/axiom/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// New creates the gRPC server with the LEI and reference data services, the standard health
+// service and, if configured, reflection. tlsConfig, if not nil, is the REST API's.
+func New(cfg *config.Config, services *service.Services, tlsConfig *tls.Config) *grpc.Server {
+	auth := &authenticator{cfg: cfg}
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryRecover, unaryLogger, auth.unary),
+		grpc.ChainStreamInterceptor(streamRecover, streamLogger, auth.stream),
+	}
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := grpc.NewServer(options...)
+	axiomv1.RegisterLEIServiceServer(server, &leiServer{
+		lei:     services.LEI,
+		exports: services.DataExport,
+		elf:     services.ELF,
+		ra:      services.RA,
+		lou:     services.LOU,
+	})
+	axiomv1.RegisterReferenceDataServiceServer(server, &referenceServer{
+		countries:  services.Country,
+		currencies: services.Currency,
+	})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	if cfg.GRPC.Reflection {
+		reflection.Register(server)
+	}
+	return server
+}
+
+// publicMethods are answered without a token: probes check health without one
+var publicMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+	healthpb.Health_Watch_FullMethodName: true,
+}
+
+// authenticator checks the bearer token of each call, as JWTAuth does for REST requests, and
+// masks the fields of the answers the token's roles may not see, as MaskFields does
+type authenticator struct {
+	cfg *config.Config
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	masked, err := a.check(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	if message, ok := resp.(proto.Message); ok && err == nil {
+		maskMessage(message.ProtoReflect(), masked)
+	}
+	return resp, err
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	masked, err := a.check(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if len(masked) > 0 {
+		ss = &maskingStream{ServerStream: ss, masked: masked}
+	}
+	return handler(srv, ss)
+}
+
+// check authenticates a call and returns the fields masked for its caller
+func (a *authenticator) check(ctx context.Context, method string) (map[string]bool, error) {
+	if publicMethods[method] || strings.HasPrefix(method, "/grpc.reflection.") {
+		return nil, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+	}
+	claims, err := middleware.ParseToken(a.cfg, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	return middleware.MaskedFieldsFor(a.cfg.Masking, middleware.ClaimRoles(claims)), nil
+}
+
+// unaryLogger logs each call once it has been answered
+func unaryLogger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(info.FullMethod, start, err)
+	return resp, err
+}
+
+func streamLogger(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(info.FullMethod, start, err)
+	return err
+}
+
+// logCall logs a call at debug level, as at thousands a second they would drown everything else;
+// calls failing on the server's side at warn
+func logCall(method string, start time.Time, err error) {
+	if publicMethods[method] {
+		return
+	}
+	code := status.Code(err)
+	event := log.Debug()
+	switch code {
+	case codes.Internal, codes.Unknown, codes.Unavailable:
+		event = log.Warn().Err(err)
+	}
+	event.Str("method", method).
+		Str("code", code.String()).
+		Dur("latency", time.Since(start)).
+		Msg("gRPC call")
+}
+
+// unaryRecover answers a panicking call with INTERNAL, reporting the panic, rather than
+// letting it take the server down
+func unaryRecover(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer recoverCall(info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+func streamRecover(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverCall(info.FullMethod, &err)
+	return handler(srv, ss)
+}
+
+func recoverCall(method string, err *error) {
+	if p := recover(); p != nil {
+		errreport.Panic(p, map[string]string{"grpc_method": method})
+		log.Error().Interface("panic", p).Str("method", method).Msg("gRPC call panicked")
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
+
+// callError maps a service error to a gRPC status; what is not the caller's doing is INTERNAL,
+// without the details
+func callError(err error, what string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, what+" not found")
+	case query.IsValidationError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		log.Error().Err(err).Msgf("gRPC: failed to read %s", what)
+		return status.Error(codes.Internal, "failed to read "+what)
+	}
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+// minStuckAge keeps an operator from resetting work that is merely between checkpoints
+const minStuckAge = time.Minute
+
+// AdminHandler serves operator diagnostics and maintenance
+type AdminHandler struct {
+	schemaDriftService service.SchemaDriftService
+	retentionService   service.AuditRetentionService
+	maintenanceService service.MaintenanceService
+	leiService         service.LEIService
+	schedulerService   service.SchedulerService
+	jobs               *service.JobRegistry
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(schemaDriftService service.SchemaDriftService, retentionService service.AuditRetentionService, maintenanceService service.MaintenanceService, leiService service.LEIService, schedulerService service.SchedulerService, jobs *service.JobRegistry) *AdminHandler {
+	return &AdminHandler{
+		schemaDriftService: schemaDriftService,
+		retentionService:   retentionService,
+		maintenanceService: maintenanceService,
+		leiService:         leiService,
+		schedulerService:   schedulerService,
+		jobs:               jobs,
+	}
+}
+
+// SchemaDrift compares GORM model definitions with the live database schema
+// @Summary Schema drift report
+// @Description Lists missing tables/columns and text size mismatches between the Go models and the database. ERROR issues (e.g. a column narrower in the database than on the model) cause "value too long" failures on import.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} domain.SchemaDriftReport
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/schema-drift [get]
+func (h *AdminHandler) SchemaDrift(c *gin.Context) {
+	report, err := h.schemaDriftService.Report()
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to build schema drift report: " + err.Error()})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, report)
+}
+
+// PruneAudit prunes audit history past the retention period now, instead of waiting for the daily run
+// @Summary Prune audit history
+// @Description Deletes lei_records_audit and audit_logs rows older than audit.retentionmonths, archiving them first when audit.archive is on. Each LEI keeps its last version from before the cutoff. Runs in the background; follow it at /admin/tasks.
+// @Tags admin
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/audit/prune [post]
+func (h *AdminHandler) PruneAudit(c *gin.Context) {
+	if !h.retentionService.Enabled() {
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrAuditRetentionDisabled.Error()})
+		return
+	}
+	job := h.jobs.Go(service.JobKindAuditPrune, requestEmail(c), "Manual audit pruning", func(*service.Job) error {
+		_, err := h.retentionService.Prune()
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Audit pruning triggered", "job_id": job.ID})
+}
+
+// CleanupFiles runs the daily LEI file cleanup now
+// @Summary Clean up LEI files
+// @Description Deletes downloaded LEI files beyond the newest lei.keepfullfiles full and lei.keepdeltafiles delta files, as the daily cleanup does. Runs in the background; follow it at /admin/tasks.
+// @Tags admin
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/maintenance/cleanup [post]
+func (h *AdminHandler) CleanupFiles(c *gin.Context) {
+	job := h.jobs.Go(service.JobKindLEICleanup, requestEmail(c), "Manual LEI file cleanup", func(*service.Job) error {
+		return h.schedulerService.RunDailyCleanup()
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "File cleanup triggered", "job_id": job.ID})
+}
+
+// ResetStuck repairs processing state left behind by a process that died mid-run
+// @Summary Reset stuck statuses
+// @Description Marks IN_PROGRESS source files that have not checkpointed for olderThan as FAILED (STALLED), and returns RUNNING processing statuses unchanged for as long to IDLE unless their file is still in progress. Files being processed by this instance are left alone. Failed files keep their checkpoint and can be re-run.
+// @Tags admin
+// @Produce json
+// @Param olderThan query string false "Age without progress after which work counts as stuck (default twice lei.stalltimeout)"
+// @Success 200 {object} service.StuckStatusReset
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/maintenance/reset-stuck [post]
+func (h *AdminHandler) ResetStuck(c *gin.Context) {
+	olderThan := h.maintenanceService.DefaultStuckAge()
+	if raw := c.Query("olderThan"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < minStuckAge {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "olderThan must be a duration of at least " + minStuckAge.String()})
+			return
+		}
+		olderThan = parsed
+	}
+
+	reset, err := h.maintenanceService.ResetStuckStatuses(c.Request.Context(), olderThan)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, reset)
+}
+
+// RerunSourceFile processes a failed source file again
+// @Summary Re-run a failed source file
+// @Description Processes a FAILED source file again, resuming after its last checkpointed LEI unless fromStart is set. Runs in the background; follow it at /admin/tasks.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Source file ID"
+// @Param fromStart query bool false "Process the whole file instead of resuming from the checkpoint"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/maintenance/source-files/{id}/rerun [post]
+func (h *AdminHandler) RerunSourceFile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid source file ID"})
+		return
+	}
+	fromStart := c.Query("fromStart") == "true"
+
+	file, err := h.maintenanceService.RerunnableSourceFile(id)
+	switch {
+	case errors.Is(err, service.ErrSourceFileNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, service.ErrSourceFileNotFailed):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resumeFrom := file.LastProcessedLEI
+	if fromStart {
+		resumeFrom = ""
+	}
+	job := h.jobs.Go(service.JobKindLEIFileResume, requestEmail(c), "Re-run LEI source file "+id.String(), func(job *service.Job) error {
+		job.SetContext("source_file_id", id.String())
+		return h.leiService.ProcessSourceFileWithResume(id, resumeFrom)
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Source file re-run triggered", "job_id": job.ID, "resume_from": resumeFrom})
+}
+
+// RecomputeStats refreshes the table statistics behind /data/freshness
+// @Summary Recompute summary statistics
+// @Description Analyzes the tables reported at /data/freshness so their row estimates are current (e.g. after a bulk load), and drops the cached freshness report. Runs in the background; follow it at /admin/tasks.
+// @Tags admin
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/maintenance/stats/recompute [post]
+func (h *AdminHandler) RecomputeStats(c *gin.Context) {
+	job := h.jobs.Go(service.JobKindStatsRecompute, requestEmail(c), "Recompute table statistics", func(job *service.Job) error {
+		_, err := h.maintenanceService.RecomputeStats(job)
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Statistics recompute triggered", "job_id": job.ID})
+}
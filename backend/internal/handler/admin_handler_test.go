@@ -0,0 +1,194 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+func TestAdminSchemaDrift(t *testing.T) {
+	h := handlertest.New(t)
+	h.SchemaDrift.On("Report").Return(&domain.SchemaDriftReport{TablesChecked: 42, Errors: 1}, nil).Once()
+	h.SchemaDrift.On("Report").Return(nil, errors.New("permission denied for information_schema")).Once()
+	h.Router.GET("/admin/schema-drift", h.Handlers.Admin.SchemaDrift)
+
+	rec := h.Do(http.MethodGet, "/admin/schema-drift", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var report domain.SchemaDriftReport
+	h.DecodeJSON(rec, &report)
+	assert.Equal(t, 42, report.TablesChecked)
+	assert.Equal(t, 1, report.Errors)
+
+	rec = h.Do(http.MethodGet, "/admin/schema-drift", nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "Failed to build schema drift report: permission denied for information_schema", body.Error)
+}
+
+func TestAdminResetStuck(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		olderThan  time.Duration
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "default age", path: "/admin/maintenance/reset-stuck", olderThan: 2 * time.Hour, wantStatus: http.StatusOK},
+		{name: "explicit age", path: "/admin/maintenance/reset-stuck?olderThan=90m", olderThan: 90 * time.Minute, wantStatus: http.StatusOK},
+		{name: "shortest age", path: "/admin/maintenance/reset-stuck?olderThan=1m", olderThan: time.Minute, wantStatus: http.StatusOK},
+		{name: "not a duration", path: "/admin/maintenance/reset-stuck?olderThan=soon", wantStatus: http.StatusBadRequest, wantError: "olderThan must be a duration of at least 1m0s"},
+		{name: "too short", path: "/admin/maintenance/reset-stuck?olderThan=59s", wantStatus: http.StatusBadRequest, wantError: "olderThan must be a duration of at least 1m0s"},
+		{name: "reset fails", path: "/admin/maintenance/reset-stuck", olderThan: 2 * time.Hour, err: errors.New("lock timeout"), wantStatus: http.StatusInternalServerError, wantError: "lock timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.Maintenance.On("DefaultStuckAge").Return(2 * time.Hour)
+			if tt.olderThan != 0 {
+				var reset *service.StuckStatusReset
+				if tt.err == nil {
+					reset = &service.StuckStatusReset{OlderThan: tt.olderThan.String(), SourceFiles: []domain.SourceFile{{FileName: "golden-copy.json.zip"}}}
+				}
+				h.Maintenance.On("ResetStuckStatuses", mock.Anything, tt.olderThan).Return(reset, tt.err)
+			}
+			h.Router.POST("/admin/maintenance/reset-stuck", h.Handlers.Admin.ResetStuck)
+
+			rec := h.Do(http.MethodPost, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var reset service.StuckStatusReset
+			h.DecodeJSON(rec, &reset)
+			assert.Equal(t, tt.olderThan.String(), reset.OlderThan)
+			assert.Len(t, reset.SourceFiles, 1)
+		})
+	}
+}
+
+func TestAdminPruneAudit(t *testing.T) {
+	t.Run("refused while retention is disabled", func(t *testing.T) {
+		h := handlertest.New(t)
+		h.Retention.On("Enabled").Return(false)
+		h.Router.POST("/admin/audit/prune", h.Handlers.Admin.PruneAudit)
+
+		rec := h.Do(http.MethodPost, "/admin/audit/prune", nil)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		var body errorBody
+		h.DecodeJSON(rec, &body)
+		assert.Equal(t, service.ErrAuditRetentionDisabled.Error(), body.Error)
+		assert.Empty(t, h.Services.Jobs.List(), "no job is started")
+	})
+
+	t.Run("prunes in a job owned by the caller", func(t *testing.T) {
+		h := handlertest.New(t)
+		asUser(h, "ops@example.com")
+		h.Retention.On("Enabled").Return(true)
+		h.Retention.On("Prune").Return(nil, errors.New("archive bucket unreachable"))
+		h.Router.POST("/admin/audit/prune", h.Handlers.Admin.PruneAudit)
+
+		rec := h.Do(http.MethodPost, "/admin/audit/prune", nil)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		var started jobStarted
+		h.DecodeJSON(rec, &started)
+
+		job := waitForJob(t, h, started.JobID)
+		assert.Equal(t, service.JobKindAuditPrune, job.Kind)
+		assert.Equal(t, "ops@example.com", job.Owner)
+		assert.Equal(t, service.JobStatusFailed, job.Status, "the job records the pruning's failure")
+		assert.Equal(t, "archive bucket unreachable", job.Error)
+	})
+}
+
+func TestAdminCleanupFiles(t *testing.T) {
+	h := handlertest.New(t)
+	h.Scheduler.On("RunDailyCleanup").Return(nil)
+	h.Router.POST("/admin/maintenance/cleanup", h.Handlers.Admin.CleanupFiles)
+
+	rec := h.Do(http.MethodPost, "/admin/maintenance/cleanup", nil)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var started jobStarted
+	h.DecodeJSON(rec, &started)
+
+	job := waitForJob(t, h, started.JobID)
+	assert.Equal(t, service.JobKindLEICleanup, job.Kind)
+	assert.Equal(t, service.JobStatusCompleted, job.Status)
+}
+
+func TestAdminRerunSourceFile(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name           string
+		path           string
+		file           *domain.SourceFile
+		err            error
+		wantStatus     int
+		wantResumeFrom string
+	}{
+		{name: "resumes after the checkpoint", path: "/admin/maintenance/source-files/" + id.String() + "/rerun", file: &domain.SourceFile{ID: id, LastProcessedLEI: "5493001KJTIIGC8Y1R12"}, wantStatus: http.StatusAccepted, wantResumeFrom: "5493001KJTIIGC8Y1R12"},
+		{name: "from the start", path: "/admin/maintenance/source-files/" + id.String() + "/rerun?fromStart=true", file: &domain.SourceFile{ID: id, LastProcessedLEI: "5493001KJTIIGC8Y1R12"}, wantStatus: http.StatusAccepted},
+		{name: "invalid ID", path: "/admin/maintenance/source-files/42/rerun", wantStatus: http.StatusBadRequest},
+		{name: "unknown file", path: "/admin/maintenance/source-files/" + id.String() + "/rerun", err: service.ErrSourceFileNotFound, wantStatus: http.StatusNotFound},
+		{name: "file has not failed", path: "/admin/maintenance/source-files/" + id.String() + "/rerun", err: fmt.Errorf("%w: file is COMPLETED", service.ErrSourceFileNotFailed), wantStatus: http.StatusConflict},
+		{name: "lookup fails", path: "/admin/maintenance/source-files/" + id.String() + "/rerun", err: errors.New("connection reset"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.file != nil || tt.err != nil {
+				h.Maintenance.On("RerunnableSourceFile", id).Return(tt.file, tt.err)
+			}
+			if tt.wantStatus == http.StatusAccepted {
+				h.LEI.On("ProcessSourceFileWithResume", id, tt.wantResumeFrom).Return(nil)
+			}
+			h.Router.POST("/admin/maintenance/source-files/:id/rerun", h.Handlers.Admin.RerunSourceFile)
+
+			rec := h.Do(http.MethodPost, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusAccepted {
+				assert.Empty(t, h.Services.Jobs.List(), "no job is started")
+				return
+			}
+			var started struct {
+				JobID      uuid.UUID `json:"job_id"`
+				ResumeFrom string    `json:"resume_from"`
+			}
+			h.DecodeJSON(rec, &started)
+			assert.Equal(t, tt.wantResumeFrom, started.ResumeFrom)
+
+			job := waitForJob(t, h, started.JobID)
+			assert.Equal(t, service.JobKindLEIFileResume, job.Kind)
+			assert.Equal(t, id.String(), job.Context["source_file_id"])
+		})
+	}
+}
+
+func TestAdminRecomputeStats(t *testing.T) {
+	h := handlertest.New(t)
+	h.Maintenance.On("RecomputeStats", mock.AnythingOfType("*service.Job")).Return(&domain.DataFreshness{}, nil)
+	h.Router.POST("/admin/maintenance/stats/recompute", h.Handlers.Admin.RecomputeStats)
+
+	rec := h.Do(http.MethodPost, "/admin/maintenance/stats/recompute", nil)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var started jobStarted
+	h.DecodeJSON(rec, &started)
+	assert.Equal(t, "Statistics recompute triggered", started.Message)
+
+	job := waitForJob(t, h, started.JobID)
+	assert.Equal(t, service.JobKindStatsRecompute, job.Kind)
+	assert.Equal(t, service.JobStatusCompleted, job.Status)
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// BICHandler serves and imports the BIC directory
+type BICHandler struct {
+	bicService service.BICService
+}
+
+// NewBICHandler creates a new BIC directory handler
+func NewBICHandler(bicService service.BICService) *BICHandler {
+	return &BICHandler{bicService: bicService}
+}
+
+// Import loads a BIC directory file
+// @Summary Import the BIC directory
+// @Description Loads a comma, semicolon or tab separated BIC directory file, sent as the multipart field "file" or as the request body. The header row must name the BIC (or BIC8 and branch code) and institution name columns; branch name, city and country code are optional. Entries are upserted by BIC and invalid rows reported. With full, BICs the file no longer lists are deactivated. Once the directory has entries, SSI BICs must be listed in it.
+// @Tags bics
+// @Accept text/csv
+// @Accept mpfd
+// @Produce json
+// @Param file formData file false "BIC directory file"
+// @Param full query bool false "The file is the complete directory: deactivate BICs it does not list"
+// @Success 200 {object} service.BICImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /bics/import [post]
+func (h *BICHandler) Import(c *gin.Context) {
+	full, _ := strconv.ParseBool(c.Query("full"))
+
+	file, err := uploadedFile(c)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	result, err := h.bicService.Import(file, full)
+	switch {
+	case errors.Is(err, service.ErrInvalidBICFile):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case isBodyTooLarge(err):
+		respondTooLarge(c, err)
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to import BIC directory"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, result)
+	}
+}
+
+// List lists BIC directory entries
+// @Summary List BICs
+// @Description BIC directory entries in BIC order
+// @Tags bics
+// @Produce json
+// @Param country query string false "ISO 3166-1 alpha-2 country code"
+// @Param search query string false "BIC prefix or part of the institution name"
+// @Param active query bool false "Only BICs still listed by the directory"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /bics [get]
+func (h *BICHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+
+	filter := repository.BICFilter{
+		Country:    strings.ToUpper(c.Query("country")),
+		Search:     strings.TrimSpace(c.Query("search")),
+		ActiveOnly: activeOnly,
+	}
+	entries, total, err := h.bicService.List(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve BICs"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  entries,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Get validates a BIC and looks it up in the BIC directory
+// @Summary Look up a BIC
+// @Description Checks the BIC against the ISO 9362 structure (400 with the reason if invalid) and returns its directory entry. A BIC8 is looked up as its head office, branch XXX.
+// @Tags bics
+// @Produce json
+// @Param bic path string true "BIC8 or BIC11"
+// @Success 200 {object} domain.BICDirectoryEntry
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /bics/{bic} [get]
+func (h *BICHandler) Get(c *gin.Context) {
+	entry, err := h.bicService.Get(c.Param("bic"))
+	switch {
+	case errors.Is(err, service.ErrInvalidBIC):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "BIC not in the BIC directory"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to look up BIC"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, entry)
+	}
+}
@@ -0,0 +1,180 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/middleware"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+const bicFile = "bic,institution_name,country_code\nDEUTDEFFXXX,Deutsche Bank,DE\n"
+
+func TestBICImportReadsTheUpload(t *testing.T) {
+	tests := []struct {
+		name     string
+		send     func(t *testing.T, h *handlertest.Harness) *httptest.ResponseRecorder
+		wantFull bool
+	}{
+		{
+			name: "multipart file, full import",
+			send: func(t *testing.T, h *handlertest.Harness) *httptest.ResponseRecorder {
+				return doUpload(t, h, "/bics/import?full=true", nil, "bics.csv", bicFile)
+			},
+			wantFull: true,
+		},
+		{
+			name: "raw body, delta import",
+			send: func(t *testing.T, h *handlertest.Harness) *httptest.ResponseRecorder {
+				return doRaw(h, http.MethodPost, "/bics/import", "text/csv", bicFile)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			var imported string
+			h.BIC.On("Import", mock.Anything, tt.wantFull).
+				Run(func(args mock.Arguments) { imported = readAll(args.Get(0).(io.Reader)) }).
+				Return(&service.BICImportResult{Read: 1, Imported: 1}, nil)
+			h.Router.POST("/bics/import", h.Handlers.BIC.Import)
+
+			rec := tt.send(t, h)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, bicFile, imported)
+			var result service.BICImportResult
+			h.DecodeJSON(rec, &result)
+			assert.Equal(t, 1, result.Imported)
+		})
+	}
+}
+
+func TestBICImportErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "invalid file", err: fmt.Errorf("%w: cannot read header: EOF", service.ErrInvalidBICFile), wantStatus: http.StatusBadRequest, wantError: "invalid BIC directory file: cannot read header: EOF"},
+		{name: "body over the limit", err: &http.MaxBytesError{Limit: 1024}, wantStatus: http.StatusRequestEntityTooLarge, wantError: "Request body exceeds the limit of 1024 bytes"},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to import BIC directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.BIC.On("Import", mock.Anything, false).Return(nil, tt.err)
+			h.Router.POST("/bics/import", h.Handlers.BIC.Import)
+
+			rec := doRaw(h, http.MethodPost, "/bics/import", "text/csv", bicFile)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestBICImportRefusesUploadsWithoutAFile(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.POST("/bics/import", h.Handlers.BIC.Import)
+
+	rec := doRaw(h, http.MethodPost, "/bics/import", "multipart/form-data; boundary=x", "--x\r\nContent-Disposition: form-data; name=\"full\"\r\n\r\ntrue\r\n--x--\r\n")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "multipart request needs a file field", body.Error)
+}
+
+func TestBICImportStopsAtTheBodyLimit(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.Use(middleware.MaxBodySize(16))
+	h.Router.POST("/bics/import", h.Handlers.BIC.Import)
+
+	// The multipart headers alone pass the limit, so the upload cannot be opened
+	rec := doUpload(t, h, "/bics/import", nil, "bics.csv", bicFile)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestBICListFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		filter repository.BICFilter
+		limit  int
+		offset int
+	}{
+		{name: "defaults", path: "/bics", limit: 50},
+		{name: "filters are normalized", path: "/bics?country=de&search=%20Deutsche%20&active=true&limit=10&offset=20", filter: repository.BICFilter{Country: "DE", Search: "Deutsche", ActiveOnly: true}, limit: 10, offset: 20},
+		{name: "limit out of range falls back to the default", path: "/bics?limit=501", limit: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.BIC.On("List", tt.filter, tt.limit, tt.offset).Return([]*domain.BICDirectoryEntry{{BIC: "DEUTDEFFXXX"}}, int64(31), nil)
+			h.Router.GET("/bics", h.Handlers.BIC.List)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var page struct {
+				Items  []domain.BICDirectoryEntry `json:"items"`
+				Total  int64                      `json:"total"`
+				Limit  int                        `json:"limit"`
+				Offset int                        `json:"offset"`
+			}
+			h.DecodeJSON(rec, &page)
+			assert.Equal(t, "DEUTDEFFXXX", page.Items[0].BIC)
+			assert.Equal(t, int64(31), page.Total)
+			assert.Equal(t, tt.limit, page.Limit)
+			assert.Equal(t, tt.offset, page.Offset)
+		})
+	}
+}
+
+func TestBICGet(t *testing.T) {
+	tests := []struct {
+		name       string
+		bic        string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "found", bic: "DEUTDEFF", wantStatus: http.StatusOK},
+		{name: "malformed", bic: "DEUT", err: fmt.Errorf("%w: DEUT", service.ErrInvalidBIC), wantStatus: http.StatusBadRequest, wantError: "invalid BIC: DEUT"},
+		{name: "not in the directory", bic: "ZZZZDEFF", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "BIC not in the BIC directory"},
+		{name: "lookup fails", bic: "DEUTDEFF", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to look up BIC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			var entry *domain.BICDirectoryEntry
+			if tt.err == nil {
+				entry = &domain.BICDirectoryEntry{BIC: "DEUTDEFFXXX", InstitutionName: "Deutsche Bank"}
+			}
+			h.BIC.On("Get", tt.bic).Return(entry, tt.err)
+			h.Router.GET("/bics/:bic", h.Handlers.BIC.Get)
+
+			rec := h.Do(http.MethodGet, "/bics/"+tt.bic, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var got domain.BICDirectoryEntry
+			h.DecodeJSON(rec, &got)
+			assert.Equal(t, "Deutsche Bank", got.InstitutionName)
+		})
+	}
+}
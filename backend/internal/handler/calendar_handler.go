@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// CalendarHandler maintains holiday calendars and computes settlement dates from them
+type CalendarHandler struct {
+	calendarService service.CalendarService
+}
+
+// NewCalendarHandler creates a new holiday calendar handler
+func NewCalendarHandler(calendarService service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// holidayRequest is a holiday to add to a calendar
+type holidayRequest struct {
+	Date string `json:"date" binding:"required"` // YYYY-MM-DD
+	Name string `json:"name" binding:"required"`
+}
+
+// List lists the holiday calendars
+// @Summary List holiday calendars
+// @Description Business day calendars by market (MIC) or currency, in code order, without their holidays
+// @Tags calendars
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /calendars [get]
+func (h *CalendarHandler) List(c *gin.Context) {
+	calendars, err := h.calendarService.List()
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve holiday calendars"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"items": calendars, "total": len(calendars)})
+}
+
+// Get returns a holiday calendar with its holidays
+// @Summary Get holiday calendar
+// @Tags calendars
+// @Produce json
+// @Param code path string true "Calendar code, e.g. XLON or USD"
+// @Param year query int false "Only the holidays of this year"
+// @Success 200 {object} domain.HolidayCalendar
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /calendars/{code} [get]
+func (h *CalendarHandler) Get(c *gin.Context) {
+	year := 0
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 9999 {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "year must be a year such as 2026"})
+			return
+		}
+		year = parsed
+	}
+
+	calendar, err := h.calendarService.Get(c.Param("code"), year)
+	h.respond(c, http.StatusOK, calendar, err, "Failed to retrieve holiday calendar")
+}
+
+// Save creates or updates a holiday calendar
+// @Summary Create or update holiday calendar
+// @Description Sets the name and weekend days (comma separated MON..SUN, default SAT,SUN) of a calendar; its holidays are left as they are
+// @Tags calendars
+// @Accept json
+// @Produce json
+// @Param code path string true "Calendar code, e.g. XLON or USD"
+// @Param calendar body domain.HolidayCalendar true "Calendar"
+// @Success 200 {object} domain.HolidayCalendar
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
+// @Router /calendars/{code} [put]
+func (h *CalendarHandler) Save(c *gin.Context) {
+	var calendar domain.HolidayCalendar
+	if err := c.ShouldBindJSON(&calendar); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	calendar.Code = c.Param("code")
+
+	err := h.calendarService.Save(&calendar)
+	h.respond(c, http.StatusOK, &calendar, err, "Failed to save holiday calendar")
+}
+
+// AddHolidays adds holidays to a calendar
+// @Summary Add holidays
+// @Description Adds holidays to a calendar; a date the calendar already has is renamed
+// @Tags calendars
+// @Accept json
+// @Produce json
+// @Param code path string true "Calendar code, e.g. XLON or USD"
+// @Param holidays body []holidayRequest true "Holidays"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /calendars/{code}/holidays [post]
+func (h *CalendarHandler) AddHolidays(c *gin.Context) {
+	var requests []holidayRequest
+	if err := c.ShouldBindJSON(&requests); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	holidays := make([]*domain.CalendarHoliday, 0, len(requests))
+	for _, request := range requests {
+		date, err := time.Parse(time.DateOnly, strings.TrimSpace(request.Date))
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid holiday date " + request.Date + ": use YYYY-MM-DD"})
+			return
+		}
+		holidays = append(holidays, &domain.CalendarHoliday{Date: date, Name: request.Name})
+	}
+
+	err := h.calendarService.AddHolidays(c.Param("code"), holidays)
+	h.respond(c, http.StatusOK, gin.H{"added": len(holidays)}, err, "Failed to add holidays")
+}
+
+// DeleteHoliday removes a holiday from a calendar
+// @Summary Delete holiday
+// @Tags calendars
+// @Param code path string true "Calendar code, e.g. XLON or USD"
+// @Param date path string true "Holiday date (YYYY-MM-DD)"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /calendars/{code}/holidays/{date} [delete]
+func (h *CalendarHandler) DeleteHoliday(c *gin.Context) {
+	date, err := time.Parse(time.DateOnly, c.Param("date"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid date: use YYYY-MM-DD"})
+		return
+	}
+
+	deleted, err := h.calendarService.DeleteHoliday(c.Param("code"), date)
+	switch {
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to delete holiday"})
+	case !deleted:
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Holiday not found"})
+	default:
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// SettlementDate computes the settlement (value) date of a trade
+// @Summary Compute settlement date
+// @Description Counts the cycle's business days on from the trade date, skipping weekends and the holidays of the market's calendar. Several markets (comma separated, e.g. XNYS,USD) settle on a day that is a business day in all of them. T+0 settles on the trade date, or the next business day if it is not one. Lists the days passed over and warns about calendars with no holidays loaded for a year involved.
+// @Tags calendars
+// @Produce json
+// @Param market query string true "Calendar code(s), comma separated"
+// @Param tradeDate query string true "Trade date (YYYY-MM-DD)"
+// @Param cycle query string false "Settlement cycle, T+0 to T+30" default(T+2)
+// @Success 200 {object} service.SettlementDateResult
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Security BearerAuth
+// @Router /calendars/settlement-date [get]
+func (h *CalendarHandler) SettlementDate(c *gin.Context) {
+	tradeDate, err := time.Parse(time.DateOnly, c.Query("tradeDate"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "tradeDate is required: use YYYY-MM-DD"})
+		return
+	}
+
+	result, err := h.calendarService.SettlementDate(strings.Split(c.Query("market"), ","), tradeDate, c.Query("cycle"))
+	switch {
+	case errors.Is(err, service.ErrInvalidCalendar), errors.Is(err, service.ErrInvalidSettlementCycle):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUnknownCalendar):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNoSettlementBusinessDay):
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to compute settlement date"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, result)
+	}
+}
+
+// respond maps holiday calendar errors to HTTP statuses
+func (h *CalendarHandler) respond(c *gin.Context, status int, body any, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Holiday calendar not found"})
+	case errors.Is(err, service.ErrInvalidCalendar):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		respondWriteError(c, err, message)
+	default:
+		jsonfmt.JSON(c, status, body)
+	}
+}
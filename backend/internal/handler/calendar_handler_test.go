@@ -0,0 +1,176 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func date(value string) time.Time {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func TestCalendarGetYear(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		year       int
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "every year", path: "/calendars/XLON", wantStatus: http.StatusOK},
+		{name: "one year", path: "/calendars/XLON?year=2026", year: 2026, wantStatus: http.StatusOK},
+		{name: "not a year", path: "/calendars/XLON?year=26th", wantStatus: http.StatusBadRequest, wantError: "year must be a year such as 2026"},
+		{name: "year out of range", path: "/calendars/XLON?year=10000", wantStatus: http.StatusBadRequest, wantError: "year must be a year such as 2026"},
+		{name: "unknown calendar", path: "/calendars/XLON", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Holiday calendar not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.wantStatus != http.StatusBadRequest {
+				var calendar *domain.HolidayCalendar
+				if tt.err == nil {
+					calendar = &domain.HolidayCalendar{Code: "XLON", Holidays: []domain.CalendarHoliday{{Date: date("2026-12-25"), Name: "Christmas Day"}}}
+				}
+				h.Calendar.On("Get", "XLON", tt.year).Return(calendar, tt.err)
+			}
+			h.Router.GET("/calendars/:code", h.Handlers.Calendar.Get)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var calendar domain.HolidayCalendar
+			h.DecodeJSON(rec, &calendar)
+			assert.Equal(t, "Christmas Day", calendar.Holidays[0].Name)
+		})
+	}
+}
+
+func TestCalendarSaveUsesThePathCode(t *testing.T) {
+	h := handlertest.New(t)
+	h.Calendar.On("Save", mock.MatchedBy(func(c *domain.HolidayCalendar) bool {
+		return c.Code == "XNYS" && c.WeekendDays == "SAT,SUN"
+	})).Return(nil).Once()
+	h.Calendar.On("Save", mock.Anything).Return(fmt.Errorf("%w: weekend day FUN", service.ErrInvalidCalendar)).Once()
+	h.Router.PUT("/calendars/:code", h.Handlers.Calendar.Save)
+
+	rec := h.Do(http.MethodPut, "/calendars/XNYS", domain.HolidayCalendar{Code: "XLON", Name: "NYSE", WeekendDays: "SAT,SUN"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var saved domain.HolidayCalendar
+	h.DecodeJSON(rec, &saved)
+	assert.Equal(t, "XNYS", saved.Code)
+
+	rec = h.Do(http.MethodPut, "/calendars/XNYS", domain.HolidayCalendar{Name: "NYSE", WeekendDays: "FUN"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "invalid holiday calendar: weekend day FUN", body.Error)
+}
+
+func TestCalendarAddHolidays(t *testing.T) {
+	h := handlertest.New(t)
+	h.Calendar.On("AddHolidays", "XLON", []*domain.CalendarHoliday{
+		{Date: date("2026-12-25"), Name: "Christmas Day"},
+		{Date: date("2026-12-28"), Name: "Boxing Day (substitute)"},
+	}).Return(nil)
+	h.Router.POST("/calendars/:code/holidays", h.Handlers.Calendar.AddHolidays)
+
+	rec := h.Do(http.MethodPost, "/calendars/XLON/holidays", []map[string]string{
+		{"date": "2026-12-25", "name": "Christmas Day"},
+		{"date": " 2026-12-28 ", "name": "Boxing Day (substitute)"},
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Added int `json:"added"`
+	}
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, 2, body.Added)
+}
+
+func TestCalendarAddHolidaysRefusesBadDates(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.POST("/calendars/:code/holidays", h.Handlers.Calendar.AddHolidays)
+
+	rec := h.Do(http.MethodPost, "/calendars/XLON/holidays", []map[string]string{
+		{"date": "2026-12-25", "name": "Christmas Day"},
+		{"date": "25/12/2026", "name": "Christmas Day"},
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "Invalid holiday date 25/12/2026: use YYYY-MM-DD", body.Error)
+
+	rec = h.Do(http.MethodPost, "/calendars/XLON/holidays", []map[string]string{{"date": "2026-12-25"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "a holiday needs a name")
+}
+
+func TestCalendarDeleteHoliday(t *testing.T) {
+	h := handlertest.New(t)
+	h.Calendar.On("DeleteHoliday", "XLON", date("2026-12-25")).Return(true, nil)
+	h.Calendar.On("DeleteHoliday", "XLON", date("2026-12-26")).Return(false, nil)
+	h.Calendar.On("DeleteHoliday", "XLON", date("2026-12-27")).Return(false, errors.New("connection refused"))
+	h.Router.DELETE("/calendars/:code/holidays/:date", h.Handlers.Calendar.DeleteHoliday)
+
+	assert.Equal(t, http.StatusNoContent, h.Do(http.MethodDelete, "/calendars/XLON/holidays/2026-12-25", nil).Code)
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodDelete, "/calendars/XLON/holidays/2026-12-26", nil).Code)
+	assert.Equal(t, http.StatusInternalServerError, h.Do(http.MethodDelete, "/calendars/XLON/holidays/2026-12-27", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodDelete, "/calendars/XLON/holidays/christmas", nil).Code)
+}
+
+func TestCalendarSettlementDate(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		markets    []string
+		cycle      string
+		err        error
+		wantStatus int
+	}{
+		{name: "markets and cycle", path: "/calendars/settlement-date?market=XLON,USD&tradeDate=2026-12-24&cycle=T%2B2", markets: []string{"XLON", "USD"}, cycle: "T+2", wantStatus: http.StatusOK},
+		{name: "invalid cycle", path: "/calendars/settlement-date?market=XLON&tradeDate=2026-12-24&cycle=T-1", markets: []string{"XLON"}, cycle: "T-1", err: service.ErrInvalidSettlementCycle, wantStatus: http.StatusBadRequest},
+		{name: "no calendar for the market", path: "/calendars/settlement-date?market=XXXX&tradeDate=2026-12-24", markets: []string{"XXXX"}, err: fmt.Errorf("%w XXXX", service.ErrUnknownCalendar), wantStatus: http.StatusNotFound},
+		{name: "never settles", path: "/calendars/settlement-date?market=XLON&tradeDate=2026-12-24", markets: []string{"XLON"}, err: service.ErrNoSettlementBusinessDay, wantStatus: http.StatusUnprocessableEntity},
+		{name: "lookup fails", path: "/calendars/settlement-date?market=XLON&tradeDate=2026-12-24", markets: []string{"XLON"}, err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+		{name: "no trade date", path: "/calendars/settlement-date?market=XLON", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.markets != nil {
+				var result *service.SettlementDateResult
+				if tt.err == nil {
+					result = &service.SettlementDateResult{Markets: tt.markets, TradeDate: "2026-12-24", Cycle: tt.cycle, SettlementDate: "2026-12-29"}
+				}
+				h.Calendar.On("SettlementDate", tt.markets, date("2026-12-24"), tt.cycle).Return(result, tt.err)
+			}
+			h.Router.GET("/calendars/settlement-date", h.Handlers.Calendar.SettlementDate)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var result service.SettlementDateResult
+				h.DecodeJSON(rec, &result)
+				assert.Equal(t, "2026-12-29", result.SettlementDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+// Sync brings the countries in line with the ISO 3166 list
+// @Summary Sync countries with ISO 3166
+// @Description Adds the missing countries of the list and updates the names, alpha-3 and numeric codes and regions of existing ones; active flags and unlisted countries are left alone. source=bundled (default) uses the dataset built into Axiom and returns the outcome; source=remote downloads referencedata.countrylisturl in the background (202 with a job ID; follow it at /admin/tasks).
+// @Tags countries
+// @Produce json
+// @Param source query string false "bundled or remote" default(bundled)
+// @Success 200 {object} service.CountrySyncResult
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/countries/sync [post]
+func (h *CountryHandler) Sync(c *gin.Context) {
+	switch source := c.DefaultQuery("source", service.CountrySourceBundled); source {
+	case service.CountrySourceBundled:
+		result, err := h.service.Sync(c.Request.Context(), source)
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to sync countries"})
+			return
+		}
+		jsonfmt.JSON(c, http.StatusOK, result)
+	case service.CountrySourceRemote:
+		if !h.service.RemoteSyncEnabled() {
+			jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrCountrySyncURLDisabled.Error()})
+			return
+		}
+		job := h.jobs.Go(service.JobKindCountrySync, requestEmail(c), "Manual ISO 3166 country sync", func(*service.Job) error {
+			_, err := h.service.Sync(context.Background(), source)
+			return err
+		})
+		jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Country sync triggered", "job_id": job.ID})
+	default:
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": service.ErrUnknownCountrySource.Error()})
+	}
+}
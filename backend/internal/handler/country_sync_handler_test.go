@@ -0,0 +1,80 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+func TestCountrySyncBundledRunsInline(t *testing.T) {
+	h := handlertest.New(t)
+	h.Country.On("Sync", mock.Anything, service.CountrySourceBundled).Return(&service.CountrySyncResult{Source: service.CountrySourceBundled, Read: 249, Created: 2, Unchanged: 247}, nil).Once()
+	h.Country.On("Sync", mock.Anything, service.CountrySourceBundled).Return(nil, errors.New("duplicate key")).Once()
+	h.Router.POST("/admin/countries/sync", h.Handlers.Country.Sync)
+
+	rec := h.Do(http.MethodPost, "/admin/countries/sync", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result service.CountrySyncResult
+	h.DecodeJSON(rec, &result)
+	assert.Equal(t, 249, result.Read)
+	assert.Equal(t, 2, result.Created)
+	assert.Empty(t, h.Services.Jobs.List(), "a bundled sync is not a background job")
+
+	rec = h.Do(http.MethodPost, "/admin/countries/sync?source=bundled", nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "Failed to sync countries", body.Error)
+}
+
+func TestCountrySyncRemoteRunsAsAJob(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "ops@example.com")
+	h.Country.On("RemoteSyncEnabled").Return(true)
+	h.Country.On("Sync", mock.Anything, service.CountrySourceRemote).Return(&service.CountrySyncResult{Source: service.CountrySourceRemote}, nil)
+	h.Router.POST("/admin/countries/sync", h.Handlers.Country.Sync)
+
+	rec := h.Do(http.MethodPost, "/admin/countries/sync?source=remote", nil)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var started jobStarted
+	h.DecodeJSON(rec, &started)
+
+	job := waitForJob(t, h, started.JobID)
+	assert.Equal(t, service.JobKindCountrySync, job.Kind)
+	assert.Equal(t, "ops@example.com", job.Owner)
+	assert.Equal(t, service.JobStatusCompleted, job.Status)
+}
+
+func TestCountrySyncRefusals(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		remoteEnabled *bool
+		wantStatus    int
+		wantError     error
+	}{
+		{name: "remote disabled", path: "/admin/countries/sync?source=remote", remoteEnabled: ptr(false), wantStatus: http.StatusConflict, wantError: service.ErrCountrySyncURLDisabled},
+		{name: "unknown source", path: "/admin/countries/sync?source=wikipedia", wantStatus: http.StatusBadRequest, wantError: service.ErrUnknownCountrySource},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.remoteEnabled != nil {
+				h.Country.On("RemoteSyncEnabled").Return(*tt.remoteEnabled)
+			}
+			h.Router.POST("/admin/countries/sync", h.Handlers.Country.Sync)
+
+			rec := h.Do(http.MethodPost, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError.Error(), body.Error)
+			assert.Empty(t, h.Services.Jobs.List())
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+// Sync brings the currencies in line with the ISO 4217 list
+// @Summary Sync currencies with ISO 4217
+// @Description Adds the missing currencies of list one and updates the names, numeric codes, minor units (decimal_places) and fund and precious metal flags of existing ones; symbols, active flags and unlisted currencies are left alone. source=bundled (default) uses the dataset built into Axiom and returns the outcome; source=remote downloads referencedata.currencylisturl in the background (202 with a job ID; follow it at /admin/tasks).
+// @Tags currencies
+// @Produce json
+// @Param source query string false "bundled or remote" default(bundled)
+// @Success 200 {object} service.CurrencySyncResult
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /currencies/sync [post]
+func (h *CurrencyHandler) Sync(c *gin.Context) {
+	switch source := c.DefaultQuery("source", service.CurrencySourceBundled); source {
+	case service.CurrencySourceBundled:
+		result, err := h.service.Sync(c.Request.Context(), source)
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to sync currencies"})
+			return
+		}
+		jsonfmt.JSON(c, http.StatusOK, result)
+	case service.CurrencySourceRemote:
+		if !h.service.RemoteSyncEnabled() {
+			jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrCurrencySyncURLDisabled.Error()})
+			return
+		}
+		job := h.jobs.Go(service.JobKindCurrencySync, requestEmail(c), "Manual ISO 4217 currency sync", func(*service.Job) error {
+			_, err := h.service.Sync(context.Background(), source)
+			return err
+		})
+		jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Currency sync triggered", "job_id": job.ID})
+	default:
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": service.ErrUnknownCurrencySource.Error()})
+	}
+}
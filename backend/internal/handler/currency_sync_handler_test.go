@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+func TestCurrencySync(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		remoteEnabled *bool
+		syncSource    string
+		wantStatus    int
+		wantError     error
+	}{
+		{name: "bundled by default", path: "/currencies/sync", syncSource: service.CurrencySourceBundled, wantStatus: http.StatusOK},
+		{name: "remote", path: "/currencies/sync?source=remote", remoteEnabled: ptr(true), syncSource: service.CurrencySourceRemote, wantStatus: http.StatusAccepted},
+		{name: "remote disabled", path: "/currencies/sync?source=remote", remoteEnabled: ptr(false), wantStatus: http.StatusConflict, wantError: service.ErrCurrencySyncURLDisabled},
+		{name: "unknown source", path: "/currencies/sync?source=ecb", wantStatus: http.StatusBadRequest, wantError: service.ErrUnknownCurrencySource},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.remoteEnabled != nil {
+				h.Currency.On("RemoteSyncEnabled").Return(*tt.remoteEnabled)
+			}
+			if tt.syncSource != "" {
+				h.Currency.On("Sync", mock.Anything, tt.syncSource).Return(&service.CurrencySyncResult{Source: tt.syncSource, Published: "2026-01-01", Read: 180}, nil)
+			}
+			h.Router.POST("/currencies/sync", h.Handlers.Currency.Sync)
+
+			rec := h.Do(http.MethodPost, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			switch tt.wantStatus {
+			case http.StatusOK:
+				var result service.CurrencySyncResult
+				h.DecodeJSON(rec, &result)
+				assert.Equal(t, "2026-01-01", result.Published)
+				assert.Equal(t, 180, result.Read)
+			case http.StatusAccepted:
+				var started jobStarted
+				h.DecodeJSON(rec, &started)
+				job := waitForJob(t, h, started.JobID)
+				assert.Equal(t, service.JobKindCurrencySync, job.Kind)
+				assert.Equal(t, service.JobStatusCompleted, job.Status)
+			default:
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError.Error(), body.Error)
+			}
+		})
+	}
+}
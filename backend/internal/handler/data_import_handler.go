@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/middleware"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// DataAcquisitionHandler imports and exports master data files and reports on data freshness
+type DataAcquisitionHandler struct {
+	freshnessService service.FreshnessService
+	importService    service.DataImportService
+	exportService    service.DataExportService
+}
+
+// NewDataAcquisitionHandler creates a new data acquisition handler
+func NewDataAcquisitionHandler(freshness service.FreshnessService, imports service.DataImportService, exports service.DataExportService) *DataAcquisitionHandler {
+	return &DataAcquisitionHandler{freshnessService: freshness, importService: imports, exportService: exports}
+}
+
+// Import queues a master data file for import
+// @Summary Import master data
+// @Description Queues a CSV (comma, semicolon or tab separated, with a header row) or JSON (array of objects) file of countries, currencies, entities, instruments or SSIs, sent as the multipart field "file" (type and format, if sent as form fields rather than in the query, must come before it). Files over bodylimit.upload are refused with 413. Columns are the JSON field names of the records (e.g. registration_number); currencies may be given by code (issue_currency, settlement_currency). Countries and currencies matching an existing code, and rows with an id, update the record; other rows create one. Empty values leave fields as they are; rows that would change nothing are reported as unchanged and not written. Every row goes through the same validation as the API; rows that fail are reported and the rest are written. With dryRun=true nothing is written: the report says which rows would create, update (with the fields that change) or fail, for sign-off before the real load. The file is checked and the job returned QUEUED; a background worker then processes the rows. Follow its progress and the outcome of each row at GET /data/jobs/{id}.
+// @Tags data
+// @Accept mpfd
+// @Produce json
+// @Param type query string true "countries, currencies, entities, instruments or ssis"
+// @Param format query string false "csv or json; defaults to the file extension, else csv"
+// @Param dryRun query bool false "Validate and compare only; write nothing" default(false)
+// @Param file formData file true "Import file"
+// @Success 202 {object} domain.ImportJob
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /data/import [post]
+func (h *DataAcquisitionHandler) Import(c *gin.Context) {
+	dryRun := false
+	if value := c.Query("dryRun"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "dryRun must be true or false"})
+			return
+		}
+		dryRun = parsed
+	}
+	upload, err := openUpload(c)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+	kind := c.Query("type")
+	if kind == "" {
+		kind = upload.fields["type"]
+	}
+	format := c.Query("format")
+	if format == "" {
+		format = upload.fields["format"]
+	}
+
+	job, err := h.importService.Import(c.Request.Context(), kind, format, upload.fileName, upload.file, requestEmail(c), dryRun)
+	switch {
+	case errors.Is(err, service.ErrUnknownImportKind), errors.Is(err, service.ErrUnknownImportFormat):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidImportFile):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error(), "job_id": job.ID})
+	case isBodyTooLarge(err):
+		respondTooLarge(c, err)
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to queue import"})
+	default:
+		jsonfmt.JSON(c, http.StatusAccepted, job)
+	}
+}
+
+// exportRequest selects the records of an export
+type exportRequest struct {
+	Type    string            `json:"type" binding:"required" example:"entities"`
+	Format  string            `json:"format" example:"csv"`
+	Filters map[string]string `json:"filters"`
+}
+
+// Export streams master data or LEI records as a file
+// @Summary Export master data
+// @Description Streams every countries, currencies, entities, instruments, accounts, ssis or lei record matching the filters as CSV (default), Excel (xlsx), JSON (an array) or ndjson (JSON Lines). Filters are those of the type's list endpoint, e.g. {"type[in]": "CORPORATE,FUND", "created_at[gte]": "2024-01-01", "sortBy": "name"} for entities, or {"country": "DE", "status": "ACTIVE"} for lei; limit and offset do not apply. CSV and JSON are sent in chunks as the records are read, so exports of any size stream; an Excel file (at most 1,048,575 rows) is sent once complete. Fields masked for the caller (masking.rules) are masked as in JSON responses; in CSV and Excel, masked values that are not text are left empty. X-Total-Count gives the rows matching when the export started; a response that ends short of it was cut off by an error.
+// @Tags data
+// @Accept json
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/json,application/x-ndjson
+// @Param request body exportRequest true "Export type, format and filters"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /data/export [post]
+func (h *DataAcquisitionHandler) Export(c *gin.Context) {
+	var req exportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filters := url.Values{}
+	for key, value := range req.Filters {
+		filters.Set(key, value)
+	}
+
+	export, err := h.exportService.Prepare(req.Type, req.Format, filters)
+	switch {
+	case errors.Is(err, service.ErrUnknownExportKind), errors.Is(err, service.ErrUnknownExportFormat),
+		errors.Is(err, service.ErrExportTooLarge), query.IsValidationError(err):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to prepare export"})
+		return
+	}
+
+	streamExport(c, h.exportService, export)
+}
+
+// streamExport sends a prepared export as a file download, with the fields masked for the caller
+// masked. Records are written as they are read, so a client reading slowly slows the read from
+// the database rather than filling memory.
+func streamExport(c *gin.Context, exports service.DataExportService, export *service.DataExport) {
+	export.Masked = middleware.MaskedFieldsFrom(c)
+	// A large export outlasts the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Ctx(c.Request.Context()).Warn().Err(err).Msg("Could not lift the write deadline for an export")
+	}
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", `attachment; filename="`+export.FileName+`"`)
+	c.Header("X-Total-Count", strconv.FormatInt(export.Rows, 10))
+	c.Status(http.StatusOK)
+
+	rows, err := exports.Write(export, c.Writer)
+	if err != nil {
+		// The status and part of the file have been sent; the client sees a short export
+		log.Ctx(c.Request.Context()).Error().Err(err).Str("type", export.Kind).Str("format", export.Format).
+			Int64("rows", rows).Int64("expected", export.Rows).Msg("Export failed")
+		return
+	}
+	log.Ctx(c.Request.Context()).Info().Str("type", export.Kind).Str("format", export.Format).Int64("rows", rows).
+		Str("requested_by", requestEmail(c)).Msg("Export completed")
+}
+
+// ListJobs lists the master data imports
+// @Summary List import jobs
+// @Description Imports, newest first, with their status, progress and row counts but without the row report
+// @Tags data
+// @Produce json
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /data/jobs [get]
+func (h *DataAcquisitionHandler) ListJobs(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	jobs, total, err := h.importService.ListJobs(limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve import jobs"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  jobs,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetJob returns a master data import with the outcome of each row
+// @Summary Get import job
+// @Description An import's status (QUEUED, RUNNING, COMPLETED, COMPLETED_WITH_ERRORS or FAILED), progress (processed_rows of total_rows) and, once finished, the outcome of each row
+// @Tags data
+// @Produce json
+// @Param id path string true "Import job ID"
+// @Success 200 {object} domain.ImportJob
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /data/jobs/{id} [get]
+func (h *DataAcquisitionHandler) GetJob(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	job, err := h.importService.GetJob(c.Param("id"))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Import job not found"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve import job"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, job)
+	}
+}
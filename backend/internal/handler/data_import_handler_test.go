@@ -0,0 +1,218 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+const countryFile = "code,name\nGB,United Kingdom\n"
+
+func TestDataImportQueuesTheUpload(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		fields     map[string]string
+		wantKind   string
+		wantFormat string
+		wantDryRun bool
+	}{
+		{name: "type and format as form fields", path: "/data/import", fields: map[string]string{"type": "countries", "format": "csv"}, wantKind: "countries", wantFormat: "csv"},
+		{name: "query parameters win", path: "/data/import?type=currencies&format=json&dryRun=true", fields: map[string]string{"type": "countries", "format": "csv"}, wantKind: "currencies", wantFormat: "json", wantDryRun: true},
+		{name: "format left to the service", path: "/data/import?type=countries", wantKind: "countries"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "ops@example.com")
+			var imported string
+			jobID := uuid.New()
+			h.DataImport.On("Import", mock.Anything, tt.wantKind, tt.wantFormat, "countries.csv", mock.Anything, "ops@example.com", tt.wantDryRun).
+				Run(func(args mock.Arguments) { imported = readAll(args.Get(4).(io.Reader)) }).
+				Return(&domain.ImportJob{ID: jobID, Kind: tt.wantKind, Status: "QUEUED", DryRun: tt.wantDryRun}, nil)
+			h.Router.POST("/data/import", h.Handlers.DataAcquisition.Import)
+
+			rec := doUpload(t, h, tt.path, tt.fields, "countries.csv", countryFile)
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+			assert.Equal(t, countryFile, imported)
+			var job domain.ImportJob
+			h.DecodeJSON(rec, &job)
+			assert.Equal(t, jobID, job.ID)
+			assert.Equal(t, tt.wantDryRun, job.DryRun)
+		})
+	}
+}
+
+func TestDataImportErrors(t *testing.T) {
+	jobID := uuid.New()
+	tests := []struct {
+		name       string
+		job        *domain.ImportJob
+		err        error
+		wantStatus int
+		wantError  string
+		wantJobID  *uuid.UUID
+	}{
+		{name: "unknown kind", err: service.ErrUnknownImportKind, wantStatus: http.StatusBadRequest, wantError: service.ErrUnknownImportKind.Error()},
+		{name: "unknown format", err: service.ErrUnknownImportFormat, wantStatus: http.StatusBadRequest, wantError: service.ErrUnknownImportFormat.Error()},
+		{name: "invalid file names the failed job", job: &domain.ImportJob{ID: jobID}, err: fmt.Errorf("%w: no header row", service.ErrInvalidImportFile), wantStatus: http.StatusBadRequest, wantError: "invalid import file: no header row", wantJobID: &jobID},
+		{name: "body over the limit", err: &http.MaxBytesError{Limit: 2048}, wantStatus: http.StatusRequestEntityTooLarge, wantError: "Request body exceeds the limit of 2048 bytes"},
+		{name: "queue failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to queue import"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.DataImport.On("Import", mock.Anything, "countries", "", "countries.csv", mock.Anything, "", false).Return(tt.job, tt.err)
+			h.Router.POST("/data/import", h.Handlers.DataAcquisition.Import)
+
+			rec := doUpload(t, h, "/data/import?type=countries", nil, "countries.csv", countryFile)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body struct {
+				Error string     `json:"error"`
+				JobID *uuid.UUID `json:"job_id"`
+			}
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+			assert.Equal(t, tt.wantJobID, body.JobID)
+		})
+	}
+}
+
+func TestDataImportRefusesBadRequests(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.POST("/data/import", h.Handlers.DataAcquisition.Import)
+
+	rec := doUpload(t, h, "/data/import?type=countries&dryRun=maybe", nil, "countries.csv", countryFile)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "dryRun must be true or false", body.Error)
+
+	rec = doRaw(h, http.MethodPost, "/data/import?type=countries", "text/csv", countryFile)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "an import must be a multipart upload")
+}
+
+func TestDataExportStreamsTheFile(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "ops@example.com")
+	masked := map[string]bool{"iban": true}
+	h.Router.Use(func(c *gin.Context) { c.Set("masked_fields", masked) })
+	export := &service.DataExport{Kind: "accounts", Format: "csv", Rows: 2, ContentType: "text/csv", FileName: "accounts.csv"}
+	h.DataExport.On("Prepare", "accounts", "csv", url.Values{"currency": {"EUR"}}).Return(export, nil)
+	h.DataExport.On("Write", mock.MatchedBy(func(e *service.DataExport) bool {
+		return e == export && e.Masked["iban"]
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		_, _ = io.WriteString(args.Get(1).(io.Writer), "name,iban\nA,\nB,\n")
+	}).Return(int64(2), nil)
+	h.Router.POST("/data/export", h.Handlers.DataAcquisition.Export)
+
+	rec := h.Do(http.MethodPost, "/data/export", map[string]interface{}{
+		"type": "accounts", "format": "csv", "filters": map[string]string{"currency": "EUR"},
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="accounts.csv"`, rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, "2", rec.Header().Get("X-Total-Count"))
+	assert.Equal(t, "name,iban\nA,\nB,\n", rec.Body.String())
+}
+
+func TestDataExportErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       interface{}
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "unknown kind", body: map[string]string{"type": "trades"}, err: service.ErrUnknownExportKind, wantStatus: http.StatusBadRequest, wantError: service.ErrUnknownExportKind.Error()},
+		{name: "too large for Excel", body: map[string]string{"type": "lei", "format": "xlsx"}, err: service.ErrExportTooLarge, wantStatus: http.StatusBadRequest, wantError: service.ErrExportTooLarge.Error()},
+		{name: "invalid filter", body: map[string]string{"type": "entities"}, err: &query.ValidationError{Message: "Unknown filter field 'colour'"}, wantStatus: http.StatusBadRequest, wantError: "Unknown filter field 'colour'"},
+		{name: "database failure", body: map[string]string{"type": "entities"}, err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to prepare export"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.DataExport.On("Prepare", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+			h.Router.POST("/data/export", h.Handlers.DataAcquisition.Export)
+
+			rec := h.Do(http.MethodPost, "/data/export", tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestDataExportNeedsAType(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.POST("/data/export", h.Handlers.DataAcquisition.Export)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPost, "/data/export", map[string]string{"format": "csv"}).Code)
+}
+
+func TestDataImportListJobs(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		limit  int
+		offset int
+	}{
+		{name: "defaults", path: "/data/import/jobs", limit: 50},
+		{name: "explicit page", path: "/data/import/jobs?limit=10&offset=30", limit: 10, offset: 30},
+		{name: "out of range values fall back", path: "/data/import/jobs?limit=0&offset=-5", limit: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.DataImport.On("ListJobs", tt.limit, tt.offset).Return([]*domain.ImportJob{{Kind: "countries"}}, int64(41), nil)
+			h.Router.GET("/data/import/jobs", h.Handlers.DataAcquisition.ListJobs)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var page struct {
+				Items  []domain.ImportJob `json:"items"`
+				Total  int64              `json:"total"`
+				Limit  int                `json:"limit"`
+				Offset int                `json:"offset"`
+			}
+			h.DecodeJSON(rec, &page)
+			assert.Equal(t, "countries", page.Items[0].Kind)
+			assert.Equal(t, int64(41), page.Total)
+			assert.Equal(t, tt.limit, page.Limit)
+			assert.Equal(t, tt.offset, page.Offset)
+		})
+	}
+}
+
+func TestDataImportGetJob(t *testing.T) {
+	h := handlertest.New(t)
+	found, missing, failing := uuid.New(), uuid.New(), uuid.New()
+	h.DataImport.On("GetJob", found.String()).Return(&domain.ImportJob{ID: found, FailedRows: 3}, nil)
+	h.DataImport.On("GetJob", missing.String()).Return(nil, gorm.ErrRecordNotFound)
+	h.DataImport.On("GetJob", failing.String()).Return(nil, errors.New("connection refused"))
+	h.Router.GET("/data/import/jobs/:id", h.Handlers.DataAcquisition.GetJob)
+
+	rec := h.Do(http.MethodGet, "/data/import/jobs/"+found.String(), nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var job domain.ImportJob
+	h.DecodeJSON(rec, &job)
+	assert.Equal(t, 3, job.FailedRows)
+
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodGet, "/data/import/jobs/"+missing.String(), nil).Code)
+	assert.Equal(t, http.StatusInternalServerError, h.Do(http.MethodGet, "/data/import/jobs/"+failing.String(), nil).Code)
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodGet, "/data/import/jobs/42", nil).Code)
+}
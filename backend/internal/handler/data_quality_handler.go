@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// DataQualityHandler serves data quality findings
+type DataQualityHandler struct {
+	dataQualityService service.DataQualityService
+}
+
+// NewDataQualityHandler creates a new data quality handler
+func NewDataQualityHandler(dataQualityService service.DataQualityService) *DataQualityHandler {
+	return &DataQualityHandler{dataQualityService: dataQualityService}
+}
+
+// ListFindings returns data quality findings, newest first
+// @Summary List data quality findings
+// @Tags data-quality
+// @Produce json
+// @Param rule_code query string false "Rule code (e.g. INACTIVE_CURRENCY_REFERENCE)"
+// @Param status query string false "OPEN or RESOLVED"
+// @Param target_table query string false "Table of the affected record (accounts, ssis, instruments, addresses)"
+// @Param target_id query string false "ID of the affected record"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /dq/findings [get]
+func (h *DataQualityHandler) ListFindings(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	filter := repository.DQFindingFilter{
+		RuleCode:    c.Query("rule_code"),
+		Status:      c.Query("status"),
+		TargetTable: c.Query("target_table"),
+		TargetID:    c.Query("target_id"),
+	}
+	items, total, err := h.dataQualityService.ListFindings(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to list findings"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"items": items, "total": total, "limit": limit, "offset": offset})
+}
+
+// ResolveFinding marks a finding resolved
+// @Summary Resolve data quality finding
+// @Description Closes the finding. When the affected record has no other open findings its review_required flag is cleared.
+// @Tags data-quality
+// @Produce json
+// @Param id path string true "Finding ID"
+// @Success 200 {object} domain.DQFinding
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /dq/findings/{id}/resolve [post]
+func (h *DataQualityHandler) ResolveFinding(c *gin.Context) {
+	resolvedBy := ""
+	if email, ok := c.Get("email"); ok && email != nil {
+		resolvedBy = fmt.Sprint(email)
+	}
+
+	finding, err := h.dataQualityService.ResolveFinding(c.Param("id"), resolvedBy)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Finding not found"})
+			return
+		}
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to resolve finding"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, finding)
+}
+
+// IntegrityCheck runs the cross-resource referential integrity scan
+// @Summary Run referential integrity check
+// @Description Scans for live records referencing deleted or missing parents (e.g. accounts of deleted entities, SSIs in deleted currencies, instrument codes without an instrument) and raises an ERROR DQ finding for each. With repair=true, orphaned instrument codes, instrument prices and entity address links are soft-deleted and their findings recorded as resolved.
+// @Tags admin
+// @Produce json
+// @Param repair query bool false "Soft-delete trivially repairable orphans" default(false)
+// @Success 200 {object} domain.IntegrityReport
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/integrity-check [post]
+func (h *DataQualityHandler) IntegrityCheck(c *gin.Context) {
+	repair, err := strconv.ParseBool(c.DefaultQuery("repair", "false"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "repair must be true or false"})
+		return
+	}
+	requestedBy := ""
+	if email, ok := c.Get("email"); ok && email != nil {
+		requestedBy = fmt.Sprint(email)
+	}
+
+	report, err := h.dataQualityService.RunIntegrityCheck(c.Request.Context(), repair, requestedBy)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Integrity check failed: " + err.Error()})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, report)
+}
@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+func TestDataQualityListFindingsFilter(t *testing.T) {
+	h := handlertest.New(t)
+	targetID := "6f1c2b1e-4d0a-4c55-9a3e-0d8f3b7a9c11"
+	h.DataQuality.On("ListFindings", repository.DQFindingFilter{
+		RuleCode:    "ORPHANED_SSI",
+		Status:      "OPEN",
+		TargetTable: "ssis",
+		TargetID:    targetID,
+	}, 20, 40).Return([]*domain.DQFinding{{RuleCode: "ORPHANED_SSI", Cause: "entity deleted"}}, int64(41), nil)
+	h.DataQuality.On("ListFindings", repository.DQFindingFilter{}, 50, 0).Return([]*domain.DQFinding{}, int64(0), nil)
+	h.Router.GET("/dq/findings", h.Handlers.DataQuality.ListFindings)
+
+	rec := h.Do(http.MethodGet, "/dq/findings?rule_code=ORPHANED_SSI&status=OPEN&target_table=ssis&target_id="+targetID+"&limit=20&offset=40", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.DQFinding `json:"items"`
+		Total int64              `json:"total"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, "entity deleted", page.Items[0].Cause)
+	assert.Equal(t, int64(41), page.Total)
+
+	assert.Equal(t, http.StatusOK, h.Do(http.MethodGet, "/dq/findings?limit=1000", nil).Code, "an out of range limit falls back to 50")
+}
+
+func TestDataQualityResolveFindingRecordsTheResolver(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "steward@example.com")
+	h.DataQuality.On("ResolveFinding", "f1", "steward@example.com").Return(&domain.DQFinding{Status: "RESOLVED", ResolvedBy: "steward@example.com"}, nil)
+	h.DataQuality.On("ResolveFinding", "f2", "steward@example.com").Return(nil, gorm.ErrRecordNotFound)
+	h.DataQuality.On("ResolveFinding", "f3", "steward@example.com").Return(nil, errors.New("connection refused"))
+	h.Router.POST("/dq/findings/:id/resolve", h.Handlers.DataQuality.ResolveFinding)
+
+	rec := h.Do(http.MethodPost, "/dq/findings/f1/resolve", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var finding domain.DQFinding
+	h.DecodeJSON(rec, &finding)
+	assert.Equal(t, "RESOLVED", finding.Status)
+	assert.Equal(t, "steward@example.com", finding.ResolvedBy)
+
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodPost, "/dq/findings/f2/resolve", nil).Code)
+	assert.Equal(t, http.StatusInternalServerError, h.Do(http.MethodPost, "/dq/findings/f3/resolve", nil).Code)
+}
+
+func TestDataQualityIntegrityCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		repair     *bool
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "report only", path: "/admin/integrity-check", repair: ptr(false), wantStatus: http.StatusOK},
+		{name: "repair", path: "/admin/integrity-check?repair=true", repair: ptr(true), wantStatus: http.StatusOK},
+		{name: "repair is not a bool", path: "/admin/integrity-check?repair=please", wantStatus: http.StatusBadRequest, wantError: "repair must be true or false"},
+		{name: "scan fails", path: "/admin/integrity-check", repair: ptr(false), err: errors.New("statement timeout"), wantStatus: http.StatusInternalServerError, wantError: "Integrity check failed: statement timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "ops@example.com")
+			if tt.repair != nil {
+				var report *domain.IntegrityReport
+				if tt.err == nil {
+					report = &domain.IntegrityReport{Repair: *tt.repair, TotalOrphans: 4}
+				}
+				h.DataQuality.On("RunIntegrityCheck", mock.Anything, *tt.repair, "ops@example.com").Return(report, tt.err)
+			}
+			h.Router.POST("/admin/integrity-check", h.Handlers.DataQuality.IntegrityCheck)
+
+			rec := h.Do(http.MethodPost, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var report domain.IntegrityReport
+			h.DecodeJSON(rec, &report)
+			assert.Equal(t, *tt.repair, report.Repair)
+			assert.Equal(t, 4, report.TotalOrphans)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// DeadLetterHandler exposes operator endpoints for permanently failed async work
+type DeadLetterHandler struct {
+	deadLetterService service.DeadLetterService
+}
+
+// NewDeadLetterHandler creates a new dead letter handler
+func NewDeadLetterHandler(deadLetterService service.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{deadLetterService: deadLetterService}
+}
+
+// UpdateDeadLetterRequest replaces a dead letter's payload before retry
+type UpdateDeadLetterRequest struct {
+	Payload json.RawMessage `json:"payload" binding:"required" swaggertype:"object"`
+}
+
+// PurgeDeadLettersRequest selects dead letters to purge; empty fields match everything
+type PurgeDeadLettersRequest struct {
+	Source    string `json:"source"`
+	Status    string `json:"status"`
+	OlderThan string `json:"older_than"` // RFC3339 timestamp
+}
+
+// List returns dead letters, newest first
+// @Summary List dead letters
+// @Tags admin
+// @Produce json
+// @Param source query string false "Source subsystem: lei-refresh, event-publish, notification or data-import"
+// @Param status query string false "PENDING or RESOLVED"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /admin/dead-letters [get]
+func (h *DeadLetterHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	filter := repository.DeadLetterFilter{Source: c.Query("source"), Status: c.Query("status")}
+	items, total, err := h.deadLetterService.List(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to list dead letters"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"items": items, "total": total, "limit": limit, "offset": offset})
+}
+
+// Get returns a single dead letter
+// @Summary Get dead letter
+// @Tags admin
+// @Produce json
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} domain.DeadLetter
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/dead-letters/{id} [get]
+func (h *DeadLetterHandler) Get(c *gin.Context) {
+	item, err := h.deadLetterService.Get(c.Param("id"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Dead letter not found"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, item)
+}
+
+// Update edits a pending dead letter's payload
+// @Summary Edit dead letter payload
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Dead letter ID"
+// @Param request body UpdateDeadLetterRequest true "Replacement payload"
+// @Success 200 {object} domain.DeadLetter
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/dead-letters/{id} [put]
+func (h *DeadLetterHandler) Update(c *gin.Context) {
+	var req UpdateDeadLetterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	item, err := h.deadLetterService.UpdatePayload(c.Param("id"), req.Payload)
+	if err != nil {
+		h.respondError(c, err, "Failed to update dead letter")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, item)
+}
+
+// Retry re-executes a pending dead letter
+// @Summary Retry dead letter
+// @Description Re-runs the work item through its source's retry handler. On failure the item stays PENDING with the new error.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} domain.DeadLetter
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/dead-letters/{id}/retry [post]
+func (h *DeadLetterHandler) Retry(c *gin.Context) {
+	item, err := h.deadLetterService.Retry(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retry dead letter")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, item)
+}
+
+// Delete purges a single dead letter
+// @Summary Delete dead letter
+// @Tags admin
+// @Param id path string true "Dead letter ID"
+// @Success 204
+// @Security BearerAuth
+// @Router /admin/dead-letters/{id} [delete]
+func (h *DeadLetterHandler) Delete(c *gin.Context) {
+	if err := h.deadLetterService.Delete(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to delete dead letter"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Purge deletes all dead letters matching the filter
+// @Summary Purge dead letters
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body PurgeDeadLettersRequest false "Filter (empty purges everything)"
+// @Success 200 {object} map[string]int64
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/dead-letters/purge [post]
+func (h *DeadLetterHandler) Purge(c *gin.Context) {
+	var req PurgeDeadLettersRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	filter := repository.DeadLetterFilter{Source: req.Source, Status: req.Status}
+	if req.OlderThan != "" {
+		olderThan, err := time.Parse(time.RFC3339, req.OlderThan)
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid older_than (expected RFC3339)"})
+			return
+		}
+		filter.OlderThan = &olderThan
+	}
+	if filter.Status != "" && filter.Status != domain.DeadLetterStatusPending && filter.Status != domain.DeadLetterStatusResolved {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid status (expected PENDING or RESOLVED)"})
+		return
+	}
+
+	purged, err := h.deadLetterService.Purge(c.Request.Context(), filter)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to purge dead letters"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"purged": purged})
+}
+
+func (h *DeadLetterHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Dead letter not found"})
+	case errors.Is(err, service.ErrDeadLetterResolved):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidDeadLetterJSON):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNoRetryHandler):
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
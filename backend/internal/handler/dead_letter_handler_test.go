@@ -0,0 +1,152 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestDeadLetterListFilter(t *testing.T) {
+	h := handlertest.New(t)
+	h.DeadLetter.On("List", repository.DeadLetterFilter{Source: service.DeadLetterSourceLEIRefresh, Status: domain.DeadLetterStatusPending}, 25, 50).
+		Return([]*domain.DeadLetter{{Source: service.DeadLetterSourceLEIRefresh, Attempts: 5}}, int64(51), nil)
+	h.Router.GET("/admin/dead-letters", h.Handlers.DeadLetter.List)
+
+	rec := h.Do(http.MethodGet, "/admin/dead-letters?source=lei-refresh&status=PENDING&limit=25&offset=50", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.DeadLetter `json:"items"`
+		Total int64               `json:"total"`
+		Limit int                 `json:"limit"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, 5, page.Items[0].Attempts)
+	assert.Equal(t, int64(51), page.Total)
+	assert.Equal(t, 25, page.Limit)
+}
+
+func TestDeadLetterUpdatePayload(t *testing.T) {
+	h := handlertest.New(t)
+	h.DeadLetter.On("UpdatePayload", "d1", json.RawMessage(`{"lei":"5493001KJTIIGC8Y1R12"}`)).
+		Return(&domain.DeadLetter{Payload: `{"lei":"5493001KJTIIGC8Y1R12"}`}, nil)
+	h.Router.PUT("/admin/dead-letters/:id", h.Handlers.DeadLetter.Update)
+
+	rec := h.Do(http.MethodPut, "/admin/dead-letters/d1", map[string]interface{}{"payload": map[string]string{"lei": "5493001KJTIIGC8Y1R12"}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var item domain.DeadLetter
+	h.DecodeJSON(rec, &item)
+	assert.Equal(t, `{"lei":"5493001KJTIIGC8Y1R12"}`, item.Payload)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPut, "/admin/dead-letters/d1", map[string]string{}).Code, "a payload is required")
+}
+
+func TestDeadLetterRetryErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "unknown", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Dead letter not found"},
+		{name: "already resolved", err: service.ErrDeadLetterResolved, wantStatus: http.StatusConflict, wantError: service.ErrDeadLetterResolved.Error()},
+		{name: "edited into invalid JSON", err: service.ErrInvalidDeadLetterJSON, wantStatus: http.StatusBadRequest, wantError: service.ErrInvalidDeadLetterJSON.Error()},
+		{name: "no retry handler", err: fmt.Errorf("%w: legacy-sync", service.ErrNoRetryHandler), wantStatus: http.StatusUnprocessableEntity, wantError: "no retry handler registered for dead letter source: legacy-sync"},
+		{name: "retry fails again", err: errors.New("GLEIF API returned 503"), wantStatus: http.StatusInternalServerError, wantError: "Failed to retry dead letter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.DeadLetter.On("Retry", mock.Anything, "d1").Return(nil, tt.err)
+			h.Router.POST("/admin/dead-letters/:id/retry", h.Handlers.DeadLetter.Retry)
+
+			rec := h.Do(http.MethodPost, "/admin/dead-letters/d1/retry", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestDeadLetterRetryReturnsTheResolvedItem(t *testing.T) {
+	h := handlertest.New(t)
+	h.DeadLetter.On("Retry", mock.Anything, "d1").Return(&domain.DeadLetter{Status: domain.DeadLetterStatusResolved, Attempts: 2}, nil)
+	h.Router.POST("/admin/dead-letters/:id/retry", h.Handlers.DeadLetter.Retry)
+
+	rec := h.Do(http.MethodPost, "/admin/dead-letters/d1/retry", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var item domain.DeadLetter
+	h.DecodeJSON(rec, &item)
+	assert.Equal(t, domain.DeadLetterStatusResolved, item.Status)
+	assert.Equal(t, 2, item.Attempts)
+}
+
+func TestDeadLetterPurgeFilter(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		body       interface{}
+		filter     *repository.DeadLetterFilter
+		wantStatus int
+		wantError  string
+	}{
+		{name: "no body purges everything", filter: &repository.DeadLetterFilter{}, wantStatus: http.StatusOK},
+		{name: "by source, status and age", body: map[string]string{"source": "event-publish", "status": "RESOLVED", "older_than": "2026-01-01T00:00:00Z"}, filter: &repository.DeadLetterFilter{Source: "event-publish", Status: "RESOLVED", OlderThan: &cutoff}, wantStatus: http.StatusOK},
+		{name: "age not RFC3339", body: map[string]string{"older_than": "2026-01-01"}, wantStatus: http.StatusBadRequest, wantError: "Invalid older_than (expected RFC3339)"},
+		{name: "unknown status", body: map[string]string{"status": "FAILED"}, wantStatus: http.StatusBadRequest, wantError: "Invalid status (expected PENDING or RESOLVED)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.filter != nil {
+				h.DeadLetter.On("Purge", mock.Anything, *tt.filter).Return(int64(7), nil)
+			}
+			h.Router.POST("/admin/dead-letters/purge", h.Handlers.DeadLetter.Purge)
+
+			rec := h.Do(http.MethodPost, "/admin/dead-letters/purge", tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var body struct {
+				Purged int64 `json:"purged"`
+			}
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, int64(7), body.Purged)
+		})
+	}
+}
+
+func TestDeadLetterGetAndDelete(t *testing.T) {
+	h := handlertest.New(t)
+	h.DeadLetter.On("Get", "d1").Return(&domain.DeadLetter{Kind: "refresh"}, nil)
+	h.DeadLetter.On("Get", "d2").Return(nil, gorm.ErrRecordNotFound)
+	h.DeadLetter.On("Delete", "d1").Return(nil)
+	h.DeadLetter.On("Delete", "d2").Return(errors.New("connection refused"))
+	h.Router.GET("/admin/dead-letters/:id", h.Handlers.DeadLetter.Get)
+	h.Router.DELETE("/admin/dead-letters/:id", h.Handlers.DeadLetter.Delete)
+
+	rec := h.Do(http.MethodGet, "/admin/dead-letters/d1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var item domain.DeadLetter
+	h.DecodeJSON(rec, &item)
+	assert.Equal(t, "refresh", item.Kind)
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodGet, "/admin/dead-letters/d2", nil).Code)
+
+	assert.Equal(t, http.StatusNoContent, h.Do(http.MethodDelete, "/admin/dead-letters/d1", nil).Code)
+	assert.Equal(t, http.StatusInternalServerError, h.Do(http.MethodDelete, "/admin/dead-letters/d2", nil).Code)
+}
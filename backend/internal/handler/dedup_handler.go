@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// DedupHandler flags probable duplicate entities and merges them
+type DedupHandler struct {
+	dedupService service.EntityDedupService
+	jobs         *service.JobRegistry
+}
+
+// NewDedupHandler creates a new entity duplicate handler
+func NewDedupHandler(dedupService service.EntityDedupService, jobs *service.JobRegistry) *DedupHandler {
+	return &DedupHandler{dedupService: dedupService, jobs: jobs}
+}
+
+// MergeEntitiesRequest names the surviving golden record and the entity merged into it
+type MergeEntitiesRequest struct {
+	SurvivorID string `json:"survivor_id" binding:"required"`
+	MergedID   string `json:"merged_id" binding:"required"`
+}
+
+// ListDuplicates lists pairs of entities flagged as probable duplicates
+// @Summary List probable duplicate entities
+// @Description Pairs flagged by the duplicate scan (reasons SAME_LEI, SAME_REGISTRATION_NUMBER, SIMILAR_NAME), most similar names first, with both entities
+// @Tags entities
+// @Produce json
+// @Param status query string false "OPEN, MERGED or DISMISSED"
+// @Param entity_id query string false "Only pairs this entity is part of"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /entities/duplicates [get]
+func (h *DedupHandler) ListDuplicates(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	filter := repository.EntityDuplicateFilter{
+		Status:   strings.ToUpper(c.Query("status")),
+		EntityID: c.Query("entity_id"),
+	}
+	if filter.EntityID != "" {
+		if _, err := uuid.Parse(filter.EntityID); err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid entity_id format"})
+			return
+		}
+	}
+
+	duplicates, total, err := h.dedupService.ListDuplicates(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve duplicate entities"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  duplicates,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ScanDuplicates flags probable duplicate entities, in the background
+// @Summary Scan entities for duplicates
+// @Description Flags pairs of entities sharing an LEI or registration number (ignoring case, spaces and punctuation) or with similar names, as a job; follow it at /admin/tasks
+// @Tags entities
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /entities/duplicates/scan [post]
+func (h *DedupHandler) ScanDuplicates(c *gin.Context) {
+	job := h.jobs.Go(service.JobKindEntityDedupScan, requestEmail(c), "Scan entities for duplicates", func(*service.Job) error {
+		_, err := h.dedupService.ScanDuplicates()
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Entity duplicate scan triggered", "job_id": job.ID})
+}
+
+// DismissDuplicate records that a flagged pair are distinct entities
+// @Summary Dismiss a duplicate pair
+// @Description Marks an OPEN pair DISMISSED; later scans do not flag it again
+// @Tags entities
+// @Produce json
+// @Param id path string true "Duplicate pair ID"
+// @Success 200 {object} domain.EntityDuplicate
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /entities/duplicates/{id}/dismiss [post]
+func (h *DedupHandler) DismissDuplicate(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	duplicate, err := h.dedupService.DismissDuplicate(c.Param("id"), requestEmail(c))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Duplicate pair not found"})
+	case errors.Is(err, service.ErrDuplicateReviewed):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to dismiss duplicate pair"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, duplicate)
+	}
+}
+
+// Merge consolidates a duplicate entity onto a surviving golden record
+// @Summary Merge entities
+// @Description Moves the merged entity's accounts, SSIs, addresses and (if the survivor has none) onboarding to the survivor, fills the survivor's empty LEI, legal form and type from it, and soft-deletes it. The merge is recorded with a snapshot of the merged entity; a flagged pair of the two becomes MERGED. Entities with an onboarding in progress cannot be merged (409).
+// @Tags entities
+// @Accept json
+// @Produce json
+// @Param request body MergeEntitiesRequest true "Survivor and merged entity"
+// @Success 200 {object} domain.EntityMerge
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Security BearerAuth
+// @Router /entities/merge [post]
+func (h *DedupHandler) Merge(c *gin.Context) {
+	var req MergeEntitiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	survivorID, err := uuid.Parse(req.SurvivorID)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid survivor_id format"})
+		return
+	}
+	mergedID, err := uuid.Parse(req.MergedID)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid merged_id format"})
+		return
+	}
+
+	merge, err := h.dedupService.Merge(c.Request.Context(), survivorID.String(), mergedID.String(), requestEmail(c))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Entity not found"})
+	case errors.Is(err, service.ErrMergeSameEntity):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrMergeLEIConflict):
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrMergeOnboardingInProgress):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to merge entities"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, merge)
+	}
+}
+
+// ListMerges lists entity merges
+// @Summary List entity merges
+// @Description Audit of merges, newest first, each with a snapshot of the merged entity and the counts of accounts, SSIs, addresses and onboardings moved
+// @Tags entities
+// @Produce json
+// @Param entity_id query string false "Only merges this entity survived or was merged in"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /entities/merges [get]
+func (h *DedupHandler) ListMerges(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	entityID := c.Query("entity_id")
+	if entityID != "" {
+		if _, err := uuid.Parse(entityID); err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid entity_id format"})
+			return
+		}
+	}
+
+	merges, total, err := h.dedupService.ListMerges(entityID, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve entity merges"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  merges,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
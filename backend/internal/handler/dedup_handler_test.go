@@ -0,0 +1,158 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestDedupListDuplicatesFilter(t *testing.T) {
+	h := handlertest.New(t)
+	entityID := uuid.New().String()
+	h.Dedup.On("ListDuplicates", repository.EntityDuplicateFilter{Status: "OPEN", EntityID: entityID}, 10, 0).
+		Return([]*domain.EntityDuplicate{{Reasons: "SAME_LEI", NameSimilarity: 0.92}}, int64(1), nil)
+	h.Router.GET("/entities/duplicates", h.Handlers.Dedup.ListDuplicates)
+
+	rec := h.Do(http.MethodGet, "/entities/duplicates?status=open&entity_id="+entityID+"&limit=10", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.EntityDuplicate `json:"items"`
+		Total int64                    `json:"total"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, "SAME_LEI", page.Items[0].Reasons)
+
+	rec = h.Do(http.MethodGet, "/entities/duplicates?entity_id=acme", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "Invalid entity_id format", body.Error)
+}
+
+func TestDedupScanRunsAsAJob(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "steward@example.com")
+	h.Dedup.On("ScanDuplicates").Return(&service.EntityDuplicateScan{Flagged: 3}, nil)
+	h.Router.POST("/entities/duplicates/scan", h.Handlers.Dedup.ScanDuplicates)
+
+	rec := h.Do(http.MethodPost, "/entities/duplicates/scan", nil)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var started jobStarted
+	h.DecodeJSON(rec, &started)
+
+	job := waitForJob(t, h, started.JobID)
+	assert.Equal(t, service.JobKindEntityDedupScan, job.Kind)
+	assert.Equal(t, "steward@example.com", job.Owner)
+	assert.Equal(t, service.JobStatusCompleted, job.Status)
+}
+
+func TestDedupDismissRecordsTheReviewer(t *testing.T) {
+	id := uuid.New().String()
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "dismissed", wantStatus: http.StatusOK},
+		{name: "unknown pair", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "already reviewed", err: service.ErrDuplicateReviewed, wantStatus: http.StatusConflict},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "steward@example.com")
+			var duplicate *domain.EntityDuplicate
+			if tt.err == nil {
+				duplicate = &domain.EntityDuplicate{Status: "DISMISSED", ReviewedBy: "steward@example.com"}
+			}
+			h.Dedup.On("DismissDuplicate", id, "steward@example.com").Return(duplicate, tt.err)
+			h.Router.POST("/entities/duplicates/:id/dismiss", h.Handlers.Dedup.DismissDuplicate)
+
+			rec := h.Do(http.MethodPost, "/entities/duplicates/"+id+"/dismiss", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.err == nil {
+				var got domain.EntityDuplicate
+				h.DecodeJSON(rec, &got)
+				assert.Equal(t, "DISMISSED", got.Status)
+			}
+		})
+	}
+}
+
+func TestDedupMerge(t *testing.T) {
+	survivor, merged := uuid.New(), uuid.New()
+	tests := []struct {
+		name       string
+		body       map[string]string
+		err        error
+		mocked     bool
+		wantStatus int
+		wantError  string
+	}{
+		{name: "merged", body: map[string]string{"survivor_id": survivor.String(), "merged_id": merged.String()}, mocked: true, wantStatus: http.StatusOK},
+		{name: "missing merged_id", body: map[string]string{"survivor_id": survivor.String()}, wantStatus: http.StatusBadRequest},
+		{name: "invalid survivor_id", body: map[string]string{"survivor_id": "acme", "merged_id": merged.String()}, wantStatus: http.StatusBadRequest, wantError: "Invalid survivor_id format"},
+		{name: "invalid merged_id", body: map[string]string{"survivor_id": survivor.String(), "merged_id": "acme"}, wantStatus: http.StatusBadRequest, wantError: "Invalid merged_id format"},
+		{name: "unknown entity", body: map[string]string{"survivor_id": survivor.String(), "merged_id": merged.String()}, mocked: true, err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Entity not found"},
+		{name: "same entity", body: map[string]string{"survivor_id": survivor.String(), "merged_id": merged.String()}, mocked: true, err: service.ErrMergeSameEntity, wantStatus: http.StatusBadRequest, wantError: service.ErrMergeSameEntity.Error()},
+		{name: "different LEIs", body: map[string]string{"survivor_id": survivor.String(), "merged_id": merged.String()}, mocked: true, err: service.ErrMergeLEIConflict, wantStatus: http.StatusUnprocessableEntity, wantError: service.ErrMergeLEIConflict.Error()},
+		{name: "onboarding in progress", body: map[string]string{"survivor_id": survivor.String(), "merged_id": merged.String()}, mocked: true, err: repository.ErrMergeOnboardingInProgress, wantStatus: http.StatusConflict, wantError: repository.ErrMergeOnboardingInProgress.Error()},
+		{name: "database failure", body: map[string]string{"survivor_id": survivor.String(), "merged_id": merged.String()}, mocked: true, err: errors.New("deadlock detected"), wantStatus: http.StatusInternalServerError, wantError: "Failed to merge entities"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "steward@example.com")
+			if tt.mocked {
+				var merge *domain.EntityMerge
+				if tt.err == nil {
+					merge = &domain.EntityMerge{SurvivorID: survivor, MergedID: merged, Accounts: 2, MergedBy: "steward@example.com"}
+				}
+				h.Dedup.On("Merge", mock.Anything, survivor.String(), merged.String(), "steward@example.com").Return(merge, tt.err)
+			}
+			h.Router.POST("/entities/merge", h.Handlers.Dedup.Merge)
+
+			rec := h.Do(http.MethodPost, "/entities/merge", tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var merge domain.EntityMerge
+				h.DecodeJSON(rec, &merge)
+				assert.Equal(t, 2, merge.Accounts)
+				assert.Equal(t, "steward@example.com", merge.MergedBy)
+				return
+			}
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+			}
+		})
+	}
+}
+
+func TestDedupListMerges(t *testing.T) {
+	h := handlertest.New(t)
+	entityID := uuid.New().String()
+	h.Dedup.On("ListMerges", entityID, 50, 0).Return([]*domain.EntityMerge{{Accounts: 1}}, int64(1), nil)
+	h.Router.GET("/entities/merges", h.Handlers.Dedup.ListMerges)
+
+	rec := h.Do(http.MethodGet, "/entities/merges?entity_id="+entityID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.EntityMerge `json:"items"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, 1, page.Items[0].Accounts)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodGet, "/entities/merges?entity_id=acme", nil).Code)
+}
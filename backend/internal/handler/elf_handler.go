@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// ELFHandler serves and imports the ISO 20275 ELF code list
+type ELFHandler struct {
+	elfService service.ELFService
+	jobs       *service.JobRegistry
+}
+
+// NewELFHandler creates a new ELF code handler
+func NewELFHandler(elfService service.ELFService, jobs *service.JobRegistry) *ELFHandler {
+	return &ELFHandler{elfService: elfService, jobs: jobs}
+}
+
+// Import loads an ELF code list file
+// @Summary Import the ELF code list
+// @Description Loads the ISO 20275 entity legal form code list as GLEIF publishes it in CSV (comma, semicolon or tab separated), sent as the multipart field "file" or as the request body. The header row must name the ELF code, country code, local name and ELF status columns. The list has a row per code and language; codes are upserted with their names in every language and invalid rows reported. LEI records are then returned with the name of their legal form.
+// @Tags elf-codes
+// @Accept text/csv
+// @Accept mpfd
+// @Produce json
+// @Param file formData file false "ELF code list file"
+// @Success 200 {object} service.ELFImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /elf-codes/import [post]
+func (h *ELFHandler) Import(c *gin.Context) {
+	file, err := uploadedFile(c)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	result, err := h.elfService.Import(file)
+	switch {
+	case errors.Is(err, service.ErrInvalidELFFile):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case isBodyTooLarge(err):
+		respondTooLarge(c, err)
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to import ELF code list"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, result)
+	}
+}
+
+// Refresh downloads and imports the ELF code list now, instead of waiting for its scheduled refresh
+// @Summary Refresh the ELF code list
+// @Description Downloads the ELF code list from lei.elflisturl and imports it. Runs in the background; follow it at /admin/tasks.
+// @Tags elf-codes
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /elf-codes/refresh [post]
+func (h *ELFHandler) Refresh(c *gin.Context) {
+	if !h.elfService.RefreshEnabled() {
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrELFRefreshDisabled.Error()})
+		return
+	}
+	job := h.jobs.Go(service.JobKindELFRefresh, requestEmail(c), "Manual ELF code list refresh", func(*service.Job) error {
+		_, err := h.elfService.Refresh()
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "ELF code list refresh triggered", "job_id": job.ID})
+}
+
+// List lists ELF codes
+// @Summary List entity legal forms
+// @Description Legal forms of the ELF code list by country, then code
+// @Tags elf-codes
+// @Produce json
+// @Param country query string false "ISO 3166-1 alpha-2 country code"
+// @Param search query string false "ELF code, or part of a name or abbreviation in any language"
+// @Param active query bool false "Only codes with status ACTV"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /elf-codes [get]
+func (h *ELFHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+
+	filter := repository.ELFFilter{
+		Country:    strings.ToUpper(c.Query("country")),
+		Search:     strings.TrimSpace(c.Query("search")),
+		ActiveOnly: activeOnly,
+	}
+	codes, total, err := h.elfService.List(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve ELF codes"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  codes,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Get looks up an ELF code
+// @Summary Look up an ELF code
+// @Description Returns the legal form of an ELF code with its names in every listed language, active or not.
+// @Tags elf-codes
+// @Produce json
+// @Param code path string true "ELF code"
+// @Success 200 {object} domain.ELFCode
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /elf-codes/{code} [get]
+func (h *ELFHandler) Get(c *gin.Context) {
+	code, err := h.elfService.Get(c.Param("code"))
+	switch {
+	case errors.Is(err, service.ErrInvalidELFCode):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "ELF code not in the ELF code list"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to look up ELF code"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, code)
+	}
+}
@@ -0,0 +1,123 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestELFImportReportsRejections(t *testing.T) {
+	h := handlertest.New(t)
+	const elfFile = "ELF Code,Country Code,Entity Legal Form name Local name\n8888,DE,Gesellschaft\n"
+	var imported string
+	h.ELF.On("Import", mock.Anything).
+		Run(func(args mock.Arguments) { imported = readAll(args.Get(0).(io.Reader)) }).
+		Return(&service.ELFImportResult{Read: 2, Imported: 1, RejectedCount: 1, Rejected: []service.ImportRejection{{Line: 3, Error: "country code XX is not ISO 3166"}}}, nil)
+	h.Router.POST("/elf-codes/import", h.Handlers.ELF.Import)
+
+	rec := doUpload(t, h, "/elf-codes/import", nil, "elf.csv", elfFile)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, elfFile, imported)
+	var result service.ELFImportResult
+	h.DecodeJSON(rec, &result)
+	assert.Equal(t, 1, result.RejectedCount)
+	assert.Equal(t, []service.ImportRejection{{Line: 3, Error: "country code XX is not ISO 3166"}}, result.Rejected)
+}
+
+func TestELFImportErrors(t *testing.T) {
+	h := handlertest.New(t)
+	h.ELF.On("Import", mock.Anything).Return(nil, fmt.Errorf("%w: no ELF Code column", service.ErrInvalidELFFile)).Once()
+	h.ELF.On("Import", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	h.Router.POST("/elf-codes/import", h.Handlers.ELF.Import)
+
+	rec := doRaw(h, http.MethodPost, "/elf-codes/import", "text/csv", "Code\n")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "invalid ELF code list file: no ELF Code column", body.Error)
+
+	rec = doRaw(h, http.MethodPost, "/elf-codes/import", "text/csv", "Code\n")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "Failed to import ELF code list", body.Error)
+}
+
+func TestELFRefresh(t *testing.T) {
+	t.Run("refused without a list URL", func(t *testing.T) {
+		h := handlertest.New(t)
+		h.ELF.On("RefreshEnabled").Return(false)
+		h.Router.POST("/elf-codes/refresh", h.Handlers.ELF.Refresh)
+
+		rec := h.Do(http.MethodPost, "/elf-codes/refresh", nil)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		var body errorBody
+		h.DecodeJSON(rec, &body)
+		assert.Equal(t, service.ErrELFRefreshDisabled.Error(), body.Error)
+		assert.Empty(t, h.Services.Jobs.List())
+	})
+
+	t.Run("a failed download fails the job", func(t *testing.T) {
+		h := handlertest.New(t)
+		h.ELF.On("RefreshEnabled").Return(true)
+		h.ELF.On("Refresh").Return(nil, errors.New("GLEIF returned 502"))
+		h.Router.POST("/elf-codes/refresh", h.Handlers.ELF.Refresh)
+
+		rec := h.Do(http.MethodPost, "/elf-codes/refresh", nil)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		var started jobStarted
+		h.DecodeJSON(rec, &started)
+
+		job := waitForJob(t, h, started.JobID)
+		assert.Equal(t, service.JobKindELFRefresh, job.Kind)
+		assert.Equal(t, service.JobStatusFailed, job.Status)
+		assert.Equal(t, "GLEIF returned 502", job.Error)
+	})
+}
+
+func TestELFListFilter(t *testing.T) {
+	h := handlertest.New(t)
+	h.ELF.On("List", repository.ELFFilter{Country: "DE", Search: "GmbH", ActiveOnly: true}, 50, 0).
+		Return([]*domain.ELFCode{{Code: "2HBR", CountryCode: "DE", Name: "Gesellschaft mit beschränkter Haftung"}}, int64(1), nil)
+	h.Router.GET("/elf-codes", h.Handlers.ELF.List)
+
+	rec := h.Do(http.MethodGet, "/elf-codes?country=de&search=+GmbH+&active=true&limit=0", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.ELFCode `json:"items"`
+		Limit int              `json:"limit"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, "2HBR", page.Items[0].Code)
+	assert.Equal(t, 50, page.Limit)
+}
+
+func TestELFGet(t *testing.T) {
+	h := handlertest.New(t)
+	h.ELF.On("Get", "2HBR").Return(&domain.ELFCode{Code: "2HBR", CountryCode: "DE"}, nil)
+	h.ELF.On("Get", "2H").Return(nil, fmt.Errorf("%w: 2H", service.ErrInvalidELFCode))
+	h.ELF.On("Get", "ZZZZ").Return(nil, gorm.ErrRecordNotFound)
+	h.Router.GET("/elf-codes/:code", h.Handlers.ELF.Get)
+
+	rec := h.Do(http.MethodGet, "/elf-codes/2HBR", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var code domain.ELFCode
+	h.DecodeJSON(rec, &code)
+	assert.Equal(t, "DE", code.CountryCode)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodGet, "/elf-codes/2H", nil).Code)
+	rec = h.Do(http.MethodGet, "/elf-codes/ZZZZ", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "ELF code not in the ELF code list", body.Error)
+}
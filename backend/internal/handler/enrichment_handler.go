@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// EnrichmentHandler fills entity master data from the LEI store
+type EnrichmentHandler struct {
+	enrichmentService service.EntityEnrichmentService
+	jobs              *service.JobRegistry
+}
+
+// NewEnrichmentHandler creates a new entity enrichment handler
+func NewEnrichmentHandler(enrichmentService service.EntityEnrichmentService, jobs *service.JobRegistry) *EnrichmentHandler {
+	return &EnrichmentHandler{enrichmentService: enrichmentService, jobs: jobs}
+}
+
+// Enrich fills an entity's name, legal form and registered address from its LEI record
+// @Summary Enrich entity from LEI
+// @Description Fills name, legal_form and the REGISTERED address from the LEI record of the entity's LEI, recording each filled field in auto_filled. Fields edited by hand since they were filled (or never filled) are left alone unless overwrite=true.
+// @Tags entities
+// @Produce json
+// @Param id path string true "Entity ID"
+// @Param overwrite query bool false "Also replace fields maintained by hand" default(false)
+// @Success 200 {object} service.EntityEnrichment
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Security BearerAuth
+// @Router /entities/{id}/enrich [post]
+func (h *EnrichmentHandler) Enrich(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	result, err := h.enrichmentService.Enrich(c.Param("id"), c.Query("overwrite") == "true")
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Entity not found"})
+	case errors.Is(err, service.ErrEntityHasNoLEI), errors.Is(err, service.ErrLEINotInStore):
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to enrich entity"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, result)
+	}
+}
+
+// EnrichAll enriches every entity that has an LEI, in the background
+// @Summary Enrich all entities from LEI
+// @Description Runs the enrichment for every entity with an LEI as a job; follow it at /admin/tasks
+// @Tags entities
+// @Produce json
+// @Param overwrite query bool false "Also replace fields maintained by hand" default(false)
+// @Success 202 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /entities/enrich [post]
+func (h *EnrichmentHandler) EnrichAll(c *gin.Context) {
+	overwrite := c.Query("overwrite") == "true"
+	job := h.jobs.Go(service.JobKindEntityEnrichment, requestEmail(c), "Enrich entities from LEI", func(*service.Job) error {
+		_, err := h.enrichmentService.EnrichAll(overwrite)
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Entity enrichment triggered", "job_id": job.ID})
+}
@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestEnrichmentEnrich(t *testing.T) {
+	id := uuid.New().String()
+	tests := []struct {
+		name          string
+		path          string
+		wantOverwrite bool
+		err           error
+		wantStatus    int
+		wantError     string
+	}{
+		{name: "keeps hand-edited fields", path: "/entities/" + id + "/enrich", wantStatus: http.StatusOK},
+		{name: "overwrites them when asked", path: "/entities/" + id + "/enrich?overwrite=true", wantOverwrite: true, wantStatus: http.StatusOK},
+		{name: "unknown entity", path: "/entities/" + id + "/enrich", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Entity not found"},
+		{name: "entity without an LEI", path: "/entities/" + id + "/enrich", err: service.ErrEntityHasNoLEI, wantStatus: http.StatusUnprocessableEntity, wantError: service.ErrEntityHasNoLEI.Error()},
+		{name: "LEI not in the store", path: "/entities/" + id + "/enrich", err: service.ErrLEINotInStore, wantStatus: http.StatusUnprocessableEntity, wantError: service.ErrLEINotInStore.Error()},
+		{name: "database failure", path: "/entities/" + id + "/enrich", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to enrich entity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			var result *service.EntityEnrichment
+			if tt.err == nil {
+				result = &service.EntityEnrichment{
+					Entity:  &domain.Entity{Name: "Acme Holdings Ltd"},
+					LEI:     "5493001KJTIIGC8Y1R12",
+					Filled:  []string{"name", "legal_form"},
+					Skipped: map[string]string{"addresses": "edited by hand"},
+				}
+			}
+			h.Enrichment.On("Enrich", id, tt.wantOverwrite).Return(result, tt.err)
+			h.Router.POST("/entities/:id/enrich", h.Handlers.Enrichment.Enrich)
+
+			rec := h.Do(http.MethodPost, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var got service.EntityEnrichment
+			h.DecodeJSON(rec, &got)
+			assert.Equal(t, []string{"name", "legal_form"}, got.Filled)
+			assert.Equal(t, "edited by hand", got.Skipped["addresses"])
+		})
+	}
+}
+
+func TestEnrichmentEnrichRefusesInvalidIDs(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.POST("/entities/:id/enrich", h.Handlers.Enrichment.Enrich)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPost, "/entities/acme/enrich", nil).Code)
+}
+
+func TestEnrichmentEnrichAllRunsAsAJob(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "steward@example.com")
+	h.Enrichment.On("EnrichAll", true).Return(&service.EntityEnrichmentSummary{Entities: 10, Enriched: 4}, nil)
+	h.Router.POST("/entities/enrich", h.Handlers.Enrichment.EnrichAll)
+
+	rec := h.Do(http.MethodPost, "/entities/enrich?overwrite=true", nil)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var started jobStarted
+	h.DecodeJSON(rec, &started)
+
+	job := waitForJob(t, h, started.JobID)
+	assert.Equal(t, service.JobKindEntityEnrichment, job.Kind)
+	assert.Equal(t, "steward@example.com", job.Owner)
+	assert.Equal(t, service.JobStatusCompleted, job.Status)
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// ExchangeHandler serves and imports the ISO 10383 MIC list
+type ExchangeHandler struct {
+	exchangeService service.ExchangeService
+	jobs            *service.JobRegistry
+}
+
+// NewExchangeHandler creates a new exchange handler
+func NewExchangeHandler(exchangeService service.ExchangeService, jobs *service.JobRegistry) *ExchangeHandler {
+	return &ExchangeHandler{exchangeService: exchangeService, jobs: jobs}
+}
+
+// Import loads a MIC list file
+// @Summary Import the MIC list
+// @Description Loads the ISO 10383 MIC list as published in CSV (comma, semicolon or tab separated), sent as the multipart field "file" or as the request body. The header row must name the MIC, operating MIC, market name, country code and status columns. Exchanges are upserted by MIC and invalid rows reported. Once the list has entries, instrument MICs must be listed in it and not expired.
+// @Tags exchanges
+// @Accept text/csv
+// @Accept mpfd
+// @Produce json
+// @Param file formData file false "MIC list file"
+// @Success 200 {object} service.MICImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /exchanges/import [post]
+func (h *ExchangeHandler) Import(c *gin.Context) {
+	file, err := uploadedFile(c)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	result, err := h.exchangeService.Import(file)
+	switch {
+	case errors.Is(err, service.ErrInvalidMICFile):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case isBodyTooLarge(err):
+		respondTooLarge(c, err)
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to import MIC list"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, result)
+	}
+}
+
+// Refresh downloads and imports the MIC list now, instead of waiting for its scheduled refresh
+// @Summary Refresh the MIC list
+// @Description Downloads the MIC list from referencedata.miclisturl and imports it. Runs in the background; follow it at /admin/tasks.
+// @Tags exchanges
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /exchanges/refresh [post]
+func (h *ExchangeHandler) Refresh(c *gin.Context) {
+	if !h.exchangeService.RefreshEnabled() {
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrMICRefreshDisabled.Error()})
+		return
+	}
+	job := h.jobs.Go(service.JobKindMICRefresh, requestEmail(c), "Manual MIC list refresh", func(*service.Job) error {
+		_, err := h.exchangeService.Refresh()
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "MIC list refresh triggered", "job_id": job.ID})
+}
+
+// List lists exchanges
+// @Summary List exchanges
+// @Description Exchanges and market segments of the MIC list in MIC order
+// @Tags exchanges
+// @Produce json
+// @Param country query string false "ISO 3166-1 alpha-2 country code"
+// @Param search query string false "MIC, acronym or part of the market name"
+// @Param active query bool false "Only MICs that have not expired"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /exchanges [get]
+func (h *ExchangeHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	activeOnly, _ := strconv.ParseBool(c.Query("active"))
+
+	filter := repository.ExchangeFilter{
+		Country:    strings.ToUpper(c.Query("country")),
+		Search:     strings.TrimSpace(c.Query("search")),
+		ActiveOnly: activeOnly,
+	}
+	exchanges, total, err := h.exchangeService.List(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve exchanges"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  exchanges,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Get looks up an exchange by MIC
+// @Summary Look up a MIC
+// @Description Checks the MIC against the ISO 10383 structure (400 with the reason if invalid) and returns its exchange, expired or not.
+// @Tags exchanges
+// @Produce json
+// @Param mic path string true "MIC"
+// @Success 200 {object} domain.Exchange
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /exchanges/{mic} [get]
+func (h *ExchangeHandler) Get(c *gin.Context) {
+	exchange, err := h.exchangeService.Get(c.Param("mic"))
+	switch {
+	case errors.Is(err, service.ErrInvalidMIC):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "MIC not in the MIC list"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to look up MIC"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, exchange)
+	}
+}
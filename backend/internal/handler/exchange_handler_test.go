@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestExchangeImport(t *testing.T) {
+	h := handlertest.New(t)
+	h.Exchange.On("Import", mock.Anything).Return(&service.MICImportResult{Read: 2, Imported: 2, Expired: 1}, nil).Once()
+	h.Exchange.On("Import", mock.Anything).Return(nil, fmt.Errorf("%w: cannot read header: EOF", service.ErrInvalidMICFile)).Once()
+	h.Router.POST("/exchanges/import", h.Handlers.Exchange.Import)
+
+	rec := doUpload(t, h, "/exchanges/import", nil, "ISO10383_MIC.csv", "MIC,OPERATING MIC\nXLON,XLON\nXOFF,XOFF\n")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result service.MICImportResult
+	h.DecodeJSON(rec, &result)
+	assert.Equal(t, 1, result.Expired)
+
+	rec = doUpload(t, h, "/exchanges/import", nil, "ISO10383_MIC.csv", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "invalid MIC list file: cannot read header: EOF", body.Error)
+}
+
+func TestExchangeRefresh(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		wantStatus int
+	}{
+		{name: "enabled", enabled: true, wantStatus: http.StatusAccepted},
+		{name: "no list URL", wantStatus: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.Exchange.On("RefreshEnabled").Return(tt.enabled)
+			if tt.enabled {
+				h.Exchange.On("Refresh").Return(&service.MICImportResult{Imported: 2700}, nil)
+			}
+			h.Router.POST("/exchanges/refresh", h.Handlers.Exchange.Refresh)
+
+			rec := h.Do(http.MethodPost, "/exchanges/refresh", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if !tt.enabled {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, service.ErrMICRefreshDisabled.Error(), body.Error)
+				assert.Empty(t, h.Services.Jobs.List())
+				return
+			}
+			var started jobStarted
+			h.DecodeJSON(rec, &started)
+			job := waitForJob(t, h, started.JobID)
+			assert.Equal(t, service.JobKindMICRefresh, job.Kind)
+			assert.Equal(t, service.JobStatusCompleted, job.Status)
+		})
+	}
+}
+
+func TestExchangeListFilter(t *testing.T) {
+	h := handlertest.New(t)
+	h.Exchange.On("List", repository.ExchangeFilter{Country: "GB", Search: "LSE", ActiveOnly: true}, 500, 0).
+		Return([]*domain.Exchange{{MIC: "XLON", OperatingMIC: "XLON", Name: "London Stock Exchange"}}, int64(1), nil)
+	h.Exchange.On("List", repository.ExchangeFilter{}, 50, 0).Return(nil, int64(0), errors.New("connection refused"))
+	h.Router.GET("/exchanges", h.Handlers.Exchange.List)
+
+	rec := h.Do(http.MethodGet, "/exchanges?country=gb&search=LSE&active=true&limit=500", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.Exchange `json:"items"`
+		Limit int               `json:"limit"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, "London Stock Exchange", page.Items[0].Name)
+	assert.Equal(t, 500, page.Limit)
+
+	rec = h.Do(http.MethodGet, "/exchanges", nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "Failed to retrieve exchanges", body.Error)
+}
+
+func TestExchangeGet(t *testing.T) {
+	h := handlertest.New(t)
+	h.Exchange.On("Get", "XLON").Return(&domain.Exchange{MIC: "XLON", MarketCategoryCode: "RMKT"}, nil)
+	h.Exchange.On("Get", "XL").Return(nil, fmt.Errorf("%w: must be 4 characters", service.ErrInvalidMIC))
+	h.Exchange.On("Get", "ZZZZ").Return(nil, gorm.ErrRecordNotFound)
+	h.Router.GET("/exchanges/:mic", h.Handlers.Exchange.Get)
+
+	rec := h.Do(http.MethodGet, "/exchanges/XLON", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var exchange domain.Exchange
+	h.DecodeJSON(rec, &exchange)
+	assert.Equal(t, "RMKT", exchange.MarketCategoryCode)
+
+	rec = h.Do(http.MethodGet, "/exchanges/XL", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "invalid MIC: must be 4 characters", body.Error)
+
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodGet, "/exchanges/ZZZZ", nil).Code)
+}
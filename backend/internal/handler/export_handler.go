@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/middleware"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// ExportHandler serves saved LEI views and scheduled exports
+type ExportHandler struct {
+	exportService service.ExportService
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(exportService service.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+// requestEmail returns the authenticated user's email, if any
+func requestEmail(c *gin.Context) string {
+	if email, ok := c.Get("email"); ok && email != nil {
+		return fmt.Sprint(email)
+	}
+	return ""
+}
+
+// refuseMasked answers 403 if a scheduled export of kind would write fields masked for the caller.
+// Its files leave the API, so they cannot be masked for whoever reads them; only callers who see
+// every field it writes may create, change or run it.
+func refuseMasked(c *gin.Context, kind string) bool {
+	masked := service.ExportedMaskedFields(kind, middleware.MaskedFieldsFrom(c))
+	if len(masked) == 0 {
+		return false
+	}
+	jsonfmt.JSON(c, http.StatusForbidden, gin.H{"error": "Export writes fields masked for you: " + strings.Join(masked, ", ")})
+	return true
+}
+
+// respondError maps export service errors to HTTP statuses
+func (h *ExportHandler) respondError(c *gin.Context, err error, notFound, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": notFound})
+	case errors.Is(err, service.ErrInvalidExport):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSavedViewExists):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// ListViews returns all saved LEI views
+// @Summary List saved LEI views
+// @Tags exports
+// @Produce json
+// @Success 200 {array} domain.LEISavedView
+// @Security BearerAuth
+// @Router /lei/views [get]
+func (h *ExportHandler) ListViews(c *gin.Context) {
+	views, err := h.exportService.ListSavedViews()
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to list saved views"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, views)
+}
+
+// GetView returns a saved LEI view
+// @Summary Get saved LEI view
+// @Tags exports
+// @Produce json
+// @Param id path string true "Saved view ID"
+// @Success 200 {object} domain.LEISavedView
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /lei/views/{id} [get]
+func (h *ExportHandler) GetView(c *gin.Context) {
+	view, err := h.exportService.GetSavedView(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Saved view not found", "Failed to retrieve saved view")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, view)
+}
+
+// CreateView saves an LEI query for reuse
+// @Summary Create saved LEI view
+// @Description Stores the filters accepted by GET /lei (search, q, status, category, country, sort_by, sort_order) under a unique name
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Param view body domain.LEISavedView true "Saved view"
+// @Success 201 {object} domain.LEISavedView
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /lei/views [post]
+func (h *ExportHandler) CreateView(c *gin.Context) {
+	var view domain.LEISavedView
+	if err := c.ShouldBindJSON(&view); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	view.ID = uuid.Nil
+	view.CreatedBy = requestEmail(c)
+
+	if err := h.exportService.CreateSavedView(&view); err != nil {
+		h.respondError(c, err, "Saved view not found", "Failed to create saved view")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusCreated, view)
+}
+
+// DeleteView removes a saved LEI view and its scheduled exports
+// @Summary Delete saved LEI view
+// @Tags exports
+// @Param id path string true "Saved view ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /lei/views/{id} [delete]
+func (h *ExportHandler) DeleteView(c *gin.Context) {
+	if err := h.exportService.DeleteSavedView(c.Param("id")); err != nil {
+		h.respondError(c, err, "Saved view not found", "Failed to delete saved view")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListSchedules returns all scheduled exports
+// @Summary List scheduled exports
+// @Tags exports
+// @Produce json
+// @Success 200 {array} domain.ScheduledExport
+// @Security BearerAuth
+// @Router /exports/schedules [get]
+func (h *ExportHandler) ListSchedules(c *gin.Context) {
+	exports, err := h.exportService.ListScheduledExports()
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to list scheduled exports"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, exports)
+}
+
+// GetSchedule returns a scheduled export with the outcome of its last run
+// @Summary Get scheduled export
+// @Tags exports
+// @Produce json
+// @Param id path string true "Scheduled export ID"
+// @Success 200 {object} domain.ScheduledExport
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /exports/schedules/{id} [get]
+func (h *ExportHandler) GetSchedule(c *gin.Context) {
+	export, err := h.exportService.GetScheduledExport(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Scheduled export not found", "Failed to retrieve scheduled export")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, export)
+}
+
+// CreateSchedule schedules a recurring export of master data, LEI records or a saved view
+// @Summary Create scheduled export
+// @Description Exports the records of kind (countries, currencies, entities, instruments, accounts, ssis or lei) matching filters, the filters of the kind's list endpoint (as for POST /data/export), or the saved LEI view saved_view_id. Runs DAILY, WEEKLY or MONTHLY, writing CSV, XLSX or JSON to the destination: FILE (under export.dir), SFTP (uploaded to sftp_directory on sftp_host:sftp_port as sftp_username, with the configured key or password) or S3 (uploaded to the archive bucket under s3_prefix). Without next_run_at the first run happens within a minute. Callers who get any field the export writes masked (masking.rules) are refused, as the file is not masked.
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Param export body domain.ScheduledExport true "Scheduled export"
+// @Success 201 {object} domain.ScheduledExport
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Security BearerAuth
+// @Router /exports/schedules [post]
+func (h *ExportHandler) CreateSchedule(c *gin.Context) {
+	var export domain.ScheduledExport
+	if err := c.ShouldBindJSON(&export); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	export.ID = uuid.Nil
+	export.CreatedBy = requestEmail(c)
+	if refuseMasked(c, export.Kind) {
+		return
+	}
+
+	if err := h.exportService.CreateScheduledExport(&export); err != nil {
+		h.respondError(c, err, "Scheduled export not found", "Failed to create scheduled export")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusCreated, export)
+}
+
+// UpdateSchedule replaces a scheduled export's definition
+// @Summary Update scheduled export
+// @Tags exports
+// @Accept json
+// @Produce json
+// @Param id path string true "Scheduled export ID"
+// @Param export body domain.ScheduledExport true "Scheduled export"
+// @Success 200 {object} domain.ScheduledExport
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /exports/schedules/{id} [put]
+func (h *ExportHandler) UpdateSchedule(c *gin.Context) {
+	exportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var export domain.ScheduledExport
+	if err := c.ShouldBindJSON(&export); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	export.ID = exportID
+	if refuseMasked(c, export.Kind) {
+		return
+	}
+	if current, err := h.exportService.GetScheduledExport(exportID.String()); err == nil && refuseMasked(c, current.Kind) {
+		return
+	}
+
+	if err := h.exportService.UpdateScheduledExport(&export); err != nil {
+		h.respondError(c, err, "Scheduled export not found", "Failed to update scheduled export")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, export)
+}
+
+// DeleteSchedule removes a scheduled export
+// @Summary Delete scheduled export
+// @Tags exports
+// @Param id path string true "Scheduled export ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /exports/schedules/{id} [delete]
+func (h *ExportHandler) DeleteSchedule(c *gin.Context) {
+	if err := h.exportService.DeleteScheduledExport(c.Param("id")); err != nil {
+		h.respondError(c, err, "Scheduled export not found", "Failed to delete scheduled export")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RunSchedule runs a scheduled export now, leaving its schedule unchanged
+// @Summary Run scheduled export now
+// @Tags exports
+// @Produce json
+// @Param id path string true "Scheduled export ID"
+// @Success 200 {object} domain.ScheduledExport
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /exports/schedules/{id}/run [post]
+func (h *ExportHandler) RunSchedule(c *gin.Context) {
+	scheduled, err := h.exportService.GetScheduledExport(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Scheduled export not found", "Failed to run scheduled export")
+		return
+	}
+	if refuseMasked(c, scheduled.Kind) {
+		return
+	}
+
+	export, err := h.exportService.RunScheduledExport(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Scheduled export not found", "Failed to run scheduled export")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, export)
+}
@@ -0,0 +1,177 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// maskFields masks fields for every request, as the masking middleware does for a caller's roles
+func maskFields(h *handlertest.Harness, fields ...string) {
+	masked := map[string]bool{}
+	for _, field := range fields {
+		masked[field] = true
+	}
+	h.Router.Use(func(c *gin.Context) { c.Set("masked_fields", masked) })
+}
+
+func TestExportCreateViewRecordsTheCreator(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "analyst@example.com")
+	h.Export.On("CreateSavedView", mock.MatchedBy(func(v *domain.LEISavedView) bool {
+		return v.ID == uuid.Nil && v.Name == "UK funds" && v.Country == "GB" && v.CreatedBy == "analyst@example.com"
+	})).Return(nil).Once()
+	h.Export.On("CreateSavedView", mock.Anything).Return(service.ErrSavedViewExists).Once()
+	h.Router.POST("/lei/views", h.Handlers.Export.CreateView)
+
+	// A client-chosen ID and creator are ignored
+	view := map[string]string{"id": uuid.New().String(), "name": "UK funds", "country": "GB", "category": "FUND", "created_by": "someone@example.com"}
+	rec := h.Do(http.MethodPost, "/lei/views", view)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var saved domain.LEISavedView
+	h.DecodeJSON(rec, &saved)
+	assert.Equal(t, "analyst@example.com", saved.CreatedBy)
+
+	rec = h.Do(http.MethodPost, "/lei/views", view)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, service.ErrSavedViewExists.Error(), body.Error)
+}
+
+func TestExportErrorStatuses(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "unknown", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Scheduled export not found"},
+		{name: "invalid definition", err: fmt.Errorf("%w: frequency must be DAILY, WEEKLY or MONTHLY", service.ErrInvalidExport), wantStatus: http.StatusBadRequest, wantError: "invalid export definition: frequency must be DAILY, WEEKLY or MONTHLY"},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to delete scheduled export"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.Export.On("DeleteScheduledExport", "e1").Return(tt.err)
+			h.Router.DELETE("/exports/schedules/:id", h.Handlers.Export.DeleteSchedule)
+
+			rec := h.Do(http.MethodDelete, "/exports/schedules/e1", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestExportCreateScheduleRefusesMaskedFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		masked     []string
+		kind       string
+		wantStatus int
+		wantError  string
+	}{
+		{name: "nothing masked", kind: "accounts", wantStatus: http.StatusCreated},
+		{name: "masked field not written by the kind", masked: []string{"account_number"}, kind: "countries", wantStatus: http.StatusCreated},
+		{name: "masked field written by the kind", masked: []string{"account_number", "balance"}, kind: "accounts", wantStatus: http.StatusForbidden, wantError: "Export writes fields masked for you: account_number, balance"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "ops@example.com")
+			maskFields(h, tt.masked...)
+			if tt.wantStatus == http.StatusCreated {
+				h.Export.On("CreateScheduledExport", mock.MatchedBy(func(e *domain.ScheduledExport) bool {
+					return e.Kind == tt.kind && e.CreatedBy == "ops@example.com"
+				})).Return(nil)
+			}
+			h.Router.POST("/exports/schedules", h.Handlers.Export.CreateSchedule)
+
+			rec := h.Do(http.MethodPost, "/exports/schedules", map[string]string{"name": "Nightly", "kind": tt.kind, "format": "CSV", "destination": "FILE", "frequency": "DAILY"})
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+			}
+		})
+	}
+}
+
+func TestExportUpdateScheduleChecksTheStoredKind(t *testing.T) {
+	id := uuid.New()
+	h := handlertest.New(t)
+	maskFields(h, "account_number")
+	// Changing an accounts export to countries would otherwise let the caller take it over
+	h.Export.On("GetScheduledExport", id.String()).Return(&domain.ScheduledExport{ID: id, Kind: "accounts"}, nil)
+	h.Router.PUT("/exports/schedules/:id", h.Handlers.Export.UpdateSchedule)
+
+	rec := h.Do(http.MethodPut, "/exports/schedules/"+id.String(), map[string]string{"name": "Nightly", "kind": "countries"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPut, "/exports/schedules/nightly", map[string]string{"kind": "countries"}).Code)
+}
+
+func TestExportUpdateScheduleUsesThePathID(t *testing.T) {
+	id := uuid.New()
+	h := handlertest.New(t)
+	h.Export.On("GetScheduledExport", id.String()).Return(&domain.ScheduledExport{ID: id, Kind: "countries"}, nil)
+	h.Export.On("UpdateScheduledExport", mock.MatchedBy(func(e *domain.ScheduledExport) bool {
+		return e.ID == id && e.Frequency == "WEEKLY"
+	})).Return(nil)
+	h.Router.PUT("/exports/schedules/:id", h.Handlers.Export.UpdateSchedule)
+
+	rec := h.Do(http.MethodPut, "/exports/schedules/"+id.String(), map[string]string{"id": uuid.New().String(), "kind": "countries", "frequency": "WEEKLY"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var saved domain.ScheduledExport
+	h.DecodeJSON(rec, &saved)
+	assert.Equal(t, id, saved.ID)
+}
+
+func TestExportRunSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		masked     []string
+		runErr     error
+		wantStatus int
+	}{
+		{name: "runs", wantStatus: http.StatusOK},
+		{name: "writes masked fields", masked: []string{"account_number"}, wantStatus: http.StatusForbidden},
+		{name: "run fails", runErr: errors.New("sftp: connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			maskFields(h, tt.masked...)
+			h.Export.On("GetScheduledExport", "e1").Return(&domain.ScheduledExport{Kind: "accounts"}, nil)
+			if tt.wantStatus != http.StatusForbidden {
+				var run *domain.ScheduledExport
+				if tt.runErr == nil {
+					run = &domain.ScheduledExport{Kind: "accounts", LastRunStatus: "SUCCEEDED"}
+				}
+				h.Export.On("RunScheduledExport", "e1").Return(run, tt.runErr)
+			}
+			h.Router.POST("/exports/schedules/:id/run", h.Handlers.Export.RunSchedule)
+
+			rec := h.Do(http.MethodPost, "/exports/schedules/e1/run", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				var export domain.ScheduledExport
+				h.DecodeJSON(rec, &export)
+				assert.Equal(t, "SUCCEEDED", export.LastRunStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+// FXHandler serves and syncs the FX reference rates
+type FXHandler struct {
+	fxService service.FXService
+	jobs      *service.JobRegistry
+}
+
+// NewFXHandler creates a new FX handler
+func NewFXHandler(fxService service.FXService, jobs *service.JobRegistry) *FXHandler {
+	return &FXHandler{fxService: fxService, jobs: jobs}
+}
+
+// Sync downloads the FX rates now, instead of waiting for the scheduled sync
+// @Summary Sync FX rates
+// @Description Downloads referencedata.fxrateurl (the latest rates) or, with history=true, referencedata.fxhistoryurl (past rates, e.g. to fill a gap) and stores the rates, replacing those already stored for the same dates. Runs in the background; follow it at /admin/tasks.
+// @Tags fx
+// @Produce json
+// @Param history query bool false "Load the history file instead of the latest rates"
+// @Success 202 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /fx/sync [post]
+func (h *FXHandler) Sync(c *gin.Context) {
+	history, _ := strconv.ParseBool(c.Query("history"))
+	switch {
+	case history && !h.fxService.SyncEnabled(true):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrFXHistoryURLDisabled.Error()})
+		return
+	case !history && !h.fxService.SyncEnabled(false):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrFXSyncURLDisabled.Error()})
+		return
+	}
+
+	description := "Manual FX rate sync"
+	if history {
+		description = "Manual FX rate history sync"
+	}
+	job := h.jobs.Go(service.JobKindFXSync, requestEmail(c), description, func(*service.Job) error {
+		_, err := h.fxService.Sync(history)
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "FX rate sync triggered", "job_id": job.ID})
+}
+
+// Rates returns the FX rates of a date
+// @Summary Get FX rates
+// @Description Rates of the latest date on or before the date asked for (today by default), looking back up to a week over weekends and holidays, as units of each currency per 1 base. A base other than the source's (EUR for the ECB) is derived as a cross rate.
+// @Tags fx
+// @Produce json
+// @Param base query string false "Base currency (ISO 4217); defaults to the source's"
+// @Param date query string false "Date (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} service.FXRateSet
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /fx/rates [get]
+func (h *FXHandler) Rates(c *gin.Context) {
+	date, ok := fxQueryDate(c, "date")
+	if !ok {
+		return
+	}
+
+	rates, err := h.fxService.Rates(c.Query("base"), date)
+	respondFX(c, rates, err, "Failed to retrieve FX rates")
+}
+
+// Convert converts an amount between currencies
+// @Summary Convert an amount
+// @Description Converts with the rates of the latest date on or before the date asked for (today by default), as for GET /fx/rates
+// @Tags fx
+// @Produce json
+// @Param amount query number true "Amount in the from currency"
+// @Param from query string true "From currency (ISO 4217)"
+// @Param to query string true "To currency (ISO 4217)"
+// @Param date query string false "Date (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} service.FXConversion
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /fx/convert [get]
+func (h *FXHandler) Convert(c *gin.Context) {
+	amount, err := strconv.ParseFloat(c.Query("amount"), 64)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "amount must be a number"})
+		return
+	}
+	date, ok := fxQueryDate(c, "date")
+	if !ok {
+		return
+	}
+
+	conversion, err := h.fxService.Convert(amount, c.Query("from"), c.Query("to"), date)
+	respondFX(c, conversion, err, "Failed to convert amount")
+}
+
+// History returns the daily rates of a currency pair
+// @Summary Get FX rate history
+// @Description Rate of quote per 1 base on each date the source has rates for, in date order; at most a year at a time
+// @Tags fx
+// @Produce json
+// @Param base query string false "Base currency (ISO 4217); defaults to the source's"
+// @Param quote query string true "Quote currency (ISO 4217)"
+// @Param from query string false "First date (YYYY-MM-DD); defaults to 30 days before to"
+// @Param to query string false "Last date (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Router /fx/history [get]
+func (h *FXHandler) History(c *gin.Context) {
+	from, ok := fxQueryDate(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := fxQueryDate(c, "to")
+	if !ok {
+		return
+	}
+
+	points, err := h.fxService.History(c.Query("base"), c.Query("quote"), from, to)
+	if err != nil {
+		respondFX(c, nil, err, "Failed to retrieve FX rate history")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"items": points, "total": len(points)})
+}
+
+// fxQueryDate reads an optional YYYY-MM-DD query parameter, answering 400 if it is malformed
+func fxQueryDate(c *gin.Context, name string) (time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, true
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": name + " must be a date (YYYY-MM-DD)"})
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// respondFX maps FX errors to HTTP statuses
+func respondFX(c *gin.Context, body any, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidFXCurrency), errors.Is(err, service.ErrInvalidFXRange):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrFXRateNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": message})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, body)
+	}
+}
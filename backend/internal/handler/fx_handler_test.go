@@ -0,0 +1,149 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+func TestFXSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		history   bool
+		enabled   bool
+		wantError string
+	}{
+		{name: "latest rates", path: "/fx/sync", enabled: true},
+		{name: "history", path: "/fx/sync?history=true", history: true, enabled: true},
+		{name: "latest rates disabled", path: "/fx/sync", wantError: service.ErrFXSyncURLDisabled.Error()},
+		{name: "history disabled", path: "/fx/sync?history=true", history: true, wantError: service.ErrFXHistoryURLDisabled.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "treasury@example.com")
+			h.FX.On("SyncEnabled", tt.history).Return(tt.enabled)
+			if tt.enabled {
+				h.FX.On("Sync", tt.history).Return(&service.FXSyncResult{Source: "ECB", Days: 1, Rates: 30}, nil)
+			}
+			h.Router.POST("/fx/sync", h.Handlers.FX.Sync)
+
+			rec := h.Do(http.MethodPost, tt.path, nil)
+			if tt.wantError != "" {
+				assert.Equal(t, http.StatusConflict, rec.Code)
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				assert.Empty(t, h.Services.Jobs.List())
+				return
+			}
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+			var started jobStarted
+			h.DecodeJSON(rec, &started)
+			job := waitForJob(t, h, started.JobID)
+			assert.Equal(t, service.JobKindFXSync, job.Kind)
+			assert.Equal(t, "treasury@example.com", job.Owner)
+			assert.Equal(t, service.JobStatusCompleted, job.Status)
+		})
+	}
+}
+
+func TestFXRates(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		base       string
+		date       time.Time
+		err        error
+		mocked     bool
+		wantStatus int
+		wantError  string
+	}{
+		{name: "today in the source's base", path: "/fx/rates", mocked: true, wantStatus: http.StatusOK},
+		{name: "cross rates on a date", path: "/fx/rates?base=USD&date=2026-03-02", base: "USD", date: date("2026-03-02"), mocked: true, wantStatus: http.StatusOK},
+		{name: "malformed date", path: "/fx/rates?date=02/03/2026", wantStatus: http.StatusBadRequest, wantError: "date must be a date (YYYY-MM-DD)"},
+		{name: "invalid currency", path: "/fx/rates?base=DOLLAR", base: "DOLLAR", err: service.ErrInvalidFXCurrency, mocked: true, wantStatus: http.StatusBadRequest, wantError: service.ErrInvalidFXCurrency.Error()},
+		{name: "no rates that week", path: "/fx/rates?date=1990-01-01", date: date("1990-01-01"), err: fmt.Errorf("%w on or in the week before 1990-01-01", service.ErrFXRateNotFound), mocked: true, wantStatus: http.StatusNotFound, wantError: "no FX rate on or in the week before 1990-01-01"},
+		{name: "database failure", path: "/fx/rates", err: errors.New("connection refused"), mocked: true, wantStatus: http.StatusInternalServerError, wantError: "Failed to retrieve FX rates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.mocked {
+				var rates *service.FXRateSet
+				if tt.err == nil {
+					rates = &service.FXRateSet{Base: "EUR", Date: "2026-02-27", Rates: map[string]float64{"USD": 1.08}}
+				}
+				h.FX.On("Rates", tt.base, tt.date).Return(rates, tt.err)
+			}
+			h.Router.GET("/fx/rates", h.Handlers.FX.Rates)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var rates service.FXRateSet
+			h.DecodeJSON(rec, &rates)
+			assert.Equal(t, 1.08, rates.Rates["USD"])
+		})
+	}
+}
+
+func TestFXConvert(t *testing.T) {
+	h := handlertest.New(t)
+	h.FX.On("Convert", 250.0, "EUR", "GBP", date("2026-03-02")).
+		Return(&service.FXConversion{Amount: 250, From: "EUR", To: "GBP", Rate: 0.86, Converted: 215}, nil)
+	h.Router.GET("/fx/convert", h.Handlers.FX.Convert)
+
+	rec := h.Do(http.MethodGet, "/fx/convert?amount=250&from=EUR&to=GBP&date=2026-03-02", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var conversion service.FXConversion
+	h.DecodeJSON(rec, &conversion)
+	assert.Equal(t, 215.0, conversion.Converted)
+
+	rec = h.Do(http.MethodGet, "/fx/convert?amount=lots&from=EUR&to=GBP", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "amount must be a number", body.Error)
+}
+
+func TestFXHistory(t *testing.T) {
+	h := handlertest.New(t)
+	h.FX.On("History", "", "USD", date("2026-01-01"), date("2026-01-31")).
+		Return([]service.FXRatePoint{{Date: "2026-01-02", Rate: 1.03}, {Date: "2026-01-05", Rate: 1.04}}, nil)
+	h.FX.On("History", "", "USD", date("2026-01-01"), date("2027-06-30")).
+		Return(nil, fmt.Errorf("%w: at most a year at a time", service.ErrInvalidFXRange))
+	h.Router.GET("/fx/history", h.Handlers.FX.History)
+
+	rec := h.Do(http.MethodGet, "/fx/history?quote=USD&from=2026-01-01&to=2026-01-31", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var history struct {
+		Items []service.FXRatePoint `json:"items"`
+		Total int                   `json:"total"`
+	}
+	h.DecodeJSON(rec, &history)
+	assert.Equal(t, 2, history.Total)
+	assert.Equal(t, 1.04, history.Items[1].Rate)
+
+	rec = h.Do(http.MethodGet, "/fx/history?quote=USD&from=2026-01-01&to=2027-06-30", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "invalid FX history range: at most a year at a time", body.Error)
+
+	rec = h.Do(http.MethodGet, "/fx/history?quote=USD&to=31-01-2026", nil)
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "to must be a date (YYYY-MM-DD)", body.Error)
+}
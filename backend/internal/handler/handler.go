@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
 	"github.com/techie2000/axiom/internal/service"
 )
 
@@ -20,21 +24,63 @@ type Handlers struct {
 	Account         *AccountHandler
 	SSI             *SSIHandler
 	LEI             *LEIHandler
+	Price           *PriceHandler
+	DeadLetter      *DeadLetterHandler
+	Admin           *AdminHandler
+	DataQuality     *DataQualityHandler
+	Export          *ExportHandler
+	IDSequence      *IDSequenceHandler
+	Job             *JobHandler
 	DataAcquisition *DataAcquisitionHandler
+	Renewal         *RenewalHandler
+	Enrichment      *EnrichmentHandler
+	Dedup           *DedupHandler
+	Onboarding      *OnboardingHandler
+	Version         *VersionHandler
+	BIC             *BICHandler
+	Exchange        *ExchangeHandler
+	ELF             *ELFHandler
+	RA              *RegistrationAuthorityHandler
+	LOU             *LOUHandler
+	Calendar        *CalendarHandler
+	FX              *FXHandler
+	Validation      *ValidationHandler
+	SSIDefaults     *SSIDefaultsHandler
 }
 
 // NewHandlers creates a new handlers instance
 func NewHandlers(services *service.Services, schedulerService service.SchedulerService) *Handlers {
 	return &Handlers{
 		Auth:            NewAuthHandler(),
-		Country:         NewCountryHandler(services.Country),
-		Currency:        NewCurrencyHandler(services.Currency),
+		Country:         NewCountryHandler(services.Country, services.Jobs),
+		Currency:        NewCurrencyHandler(services.Currency, services.Jobs),
 		Entity:          NewEntityHandler(services.Entity),
 		Instrument:      NewInstrumentHandler(services.Instrument),
 		Account:         NewAccountHandler(services.Account),
 		SSI:             NewSSIHandler(services.SSI),
-		LEI:             NewLEIHandler(services.LEI, schedulerService),
-		DataAcquisition: NewDataAcquisitionHandler(),
+		LEI:             NewLEIHandler(services.LEI, services.ELF, services.RA, services.LOU, schedulerService, services.DataExport, services.Jobs),
+		Price:           NewPriceHandler(services.Price),
+		DeadLetter:      NewDeadLetterHandler(services.DeadLetter),
+		Admin:           NewAdminHandler(services.SchemaDrift, services.Retention, services.Maintenance, services.LEI, schedulerService, services.Jobs),
+		DataQuality:     NewDataQualityHandler(services.DataQuality),
+		Export:          NewExportHandler(services.Export),
+		IDSequence:      NewIDSequenceHandler(services.IDGeneration),
+		Job:             NewJobHandler(services.Jobs),
+		DataAcquisition: NewDataAcquisitionHandler(services.Freshness, services.DataImport, services.DataExport),
+		Renewal:         NewRenewalHandler(services.Renewals, services.Jobs),
+		Enrichment:      NewEnrichmentHandler(services.Enrichment, services.Jobs),
+		Dedup:           NewDedupHandler(services.Dedup, services.Jobs),
+		Onboarding:      NewOnboardingHandler(services.Onboarding),
+		Version:         NewVersionHandler(services.Versions),
+		BIC:             NewBICHandler(services.BIC),
+		Exchange:        NewExchangeHandler(services.Exchange, services.Jobs),
+		ELF:             NewELFHandler(services.ELF, services.Jobs),
+		RA:              NewRegistrationAuthorityHandler(services.RA, services.Jobs),
+		LOU:             NewLOUHandler(services.LOU, services.Jobs),
+		Calendar:        NewCalendarHandler(services.Calendar),
+		FX:              NewFXHandler(services.FX, services.Jobs),
+		Validation:      NewValidationHandler(),
+		SSIDefaults:     NewSSIDefaultsHandler(services.SSIDefaults),
 	}
 }
 
@@ -56,7 +102,7 @@ func NewAuthHandler() *AuthHandler {
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	// TODO: Implement actual authentication
-	c.JSON(http.StatusOK, gin.H{"message": "Login endpoint - to be implemented"})
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"message": "Login endpoint - to be implemented"})
 }
 
 // Register godoc
@@ -70,16 +116,17 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	// TODO: Implement user registration
-	c.JSON(http.StatusCreated, gin.H{"message": "Register endpoint - to be implemented"})
+	jsonfmt.JSON(c, http.StatusCreated, gin.H{"message": "Register endpoint - to be implemented"})
 }
 
 // CountryHandler handles country endpoints
 type CountryHandler struct {
 	service service.CountryService
+	jobs    *service.JobRegistry
 }
 
-func NewCountryHandler(service service.CountryService) *CountryHandler {
-	return &CountryHandler{service: service}
+func NewCountryHandler(service service.CountryService, jobs *service.JobRegistry) *CountryHandler {
+	return &CountryHandler{service: service, jobs: jobs}
 }
 
 // List godoc
@@ -97,23 +144,23 @@ func (h *CountryHandler) List(c *gin.Context) {
 	// Parse and validate pagination parameters
 	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	if err != nil || limit < 1 || limit > 100 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter (must be 1-100)"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit parameter (must be 1-100)"})
 		return
 	}
 	
 	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
 	if err != nil || offset < 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter (must be >= 0)"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid offset parameter (must be >= 0)"})
 		return
 	}
 
 	countries, err := h.service.GetAll(limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch countries"})
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to fetch countries"})
 		return
 	}
 
-	c.JSON(http.StatusOK, countries)
+	jsonfmt.JSON(c, http.StatusOK, countries)
 }
 
 // Get godoc
@@ -131,11 +178,11 @@ func (h *CountryHandler) Get(c *gin.Context) {
 
 	country, err := h.service.GetByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Country not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Country not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, country)
+	jsonfmt.JSON(c, http.StatusOK, country)
 }
 
 // Create godoc
@@ -151,16 +198,16 @@ func (h *CountryHandler) Get(c *gin.Context) {
 func (h *CountryHandler) Create(c *gin.Context) {
 	var country domain.Country
 	if err := c.ShouldBindJSON(&country); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 
-	if err := h.service.Create(&country); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create country"})
+	if err := h.service.Create(c.Request.Context(), &country); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to create country"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, country)
+	jsonfmt.JSON(c, http.StatusCreated, country)
 }
 
 // Update godoc
@@ -180,13 +227,13 @@ func (h *CountryHandler) Update(c *gin.Context) {
 	// Parse UUID from path
 	countryID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
 		return
 	}
 
 	var country domain.Country
 	if err := c.ShouldBindJSON(&country); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 	
@@ -195,16 +242,16 @@ func (h *CountryHandler) Update(c *gin.Context) {
 
 	// Verify country exists
 	if _, err := h.service.GetByID(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Country not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Country not found"})
 		return
 	}
 
-	if err := h.service.Update(&country); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update country"})
+	if err := h.service.Update(c.Request.Context(), &country); err != nil {
+		respondWriteError(c, err, "Failed to update country")
 		return
 	}
 
-	c.JSON(http.StatusOK, country)
+	jsonfmt.JSON(c, http.StatusOK, country)
 }
 
 // Delete godoc
@@ -220,8 +267,8 @@ func (h *CountryHandler) Update(c *gin.Context) {
 func (h *CountryHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.service.Delete(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete country"})
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to delete country"})
 		return
 	}
 
@@ -233,10 +280,11 @@ func (h *CountryHandler) Delete(c *gin.Context) {
 
 type CurrencyHandler struct {
 	service service.CurrencyService
+	jobs    *service.JobRegistry
 }
 
-func NewCurrencyHandler(service service.CurrencyService) *CurrencyHandler {
-	return &CurrencyHandler{service: service}
+func NewCurrencyHandler(service service.CurrencyService, jobs *service.JobRegistry) *CurrencyHandler {
+	return &CurrencyHandler{service: service, jobs: jobs}
 }
 
 func (h *CurrencyHandler) List(c *gin.Context) {
@@ -244,32 +292,32 @@ func (h *CurrencyHandler) List(c *gin.Context) {
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 	currencies, err := h.service.GetAll(limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch currencies"})
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to fetch currencies"})
 		return
 	}
-	c.JSON(http.StatusOK, currencies)
+	jsonfmt.JSON(c, http.StatusOK, currencies)
 }
 
 func (h *CurrencyHandler) Get(c *gin.Context) {
 	currency, err := h.service.GetByID(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Currency not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Currency not found"})
 		return
 	}
-	c.JSON(http.StatusOK, currency)
+	jsonfmt.JSON(c, http.StatusOK, currency)
 }
 
 func (h *CurrencyHandler) Create(c *gin.Context) {
 	var currency domain.Currency
 	if err := c.ShouldBindJSON(&currency); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
-	if err := h.service.Create(&currency); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create currency"})
+	if err := h.service.Create(c.Request.Context(), &currency); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to create currency"})
 		return
 	}
-	c.JSON(http.StatusCreated, currency)
+	jsonfmt.JSON(c, http.StatusCreated, currency)
 }
 
 func (h *CurrencyHandler) Update(c *gin.Context) {
@@ -278,13 +326,13 @@ func (h *CurrencyHandler) Update(c *gin.Context) {
 	// Parse UUID from path
 	currencyID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
 		return
 	}
 
 	var currency domain.Currency
 	if err := c.ShouldBindJSON(&currency); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 	
@@ -293,20 +341,20 @@ func (h *CurrencyHandler) Update(c *gin.Context) {
 	
 	// Verify currency exists
 	if _, err := h.service.GetByID(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Currency not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Currency not found"})
 		return
 	}
 	
-	if err := h.service.Update(&currency); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update currency"})
+	if err := h.service.Update(c.Request.Context(), &currency); err != nil {
+		respondWriteError(c, err, "Failed to update currency")
 		return
 	}
-	c.JSON(http.StatusOK, currency)
+	jsonfmt.JSON(c, http.StatusOK, currency)
 }
 
 func (h *CurrencyHandler) Delete(c *gin.Context) {
-	if err := h.service.Delete(c.Param("id")); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete currency"})
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to delete currency"})
 		return
 	}
 	c.Status(http.StatusNoContent)
@@ -317,7 +365,6 @@ type EntityHandler struct{ service service.EntityService }
 type InstrumentHandler struct{ service service.InstrumentService }
 type AccountHandler struct{ service service.AccountService }
 type SSIHandler struct{ service service.SSIService }
-type DataAcquisitionHandler struct{}
 
 func NewEntityHandler(s service.EntityService) *EntityHandler { return &EntityHandler{service: s} }
 func NewInstrumentHandler(s service.InstrumentService) *InstrumentHandler {
@@ -325,40 +372,61 @@ func NewInstrumentHandler(s service.InstrumentService) *InstrumentHandler {
 }
 func NewAccountHandler(s service.AccountService) *AccountHandler { return &AccountHandler{service: s} }
 func NewSSIHandler(s service.SSIService) *SSIHandler             { return &SSIHandler{service: s} }
-func NewDataAcquisitionHandler() *DataAcquisitionHandler         { return &DataAcquisitionHandler{} }
+
+// parseListQuery parses the shared filter/sort DSL (see package query) from the request
+// Writes a 400 response and returns false if the query string is invalid
+func parseListQuery(c *gin.Context) (*query.ListQuery, bool) {
+	q, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return q, true
+}
+
+// respondListError maps filter validation errors to 400 and everything else to 500
+func respondListError(c *gin.Context, err error, message string) {
+	if query.IsValidationError(err) {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": message})
+}
 
 // Implement CRUD methods for remaining handlers
 func (h *EntityHandler) List(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	entities, err := h.service.GetAll(limit, offset)
+	q, ok := parseListQuery(c)
+	if !ok {
+		return
+	}
+	entities, err := h.service.GetAllWithFilters(q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch entities"})
+		respondListError(c, err, "Failed to fetch entities")
 		return
 	}
-	c.JSON(http.StatusOK, entities)
+	jsonfmt.JSON(c, http.StatusOK, entities)
 }
 
 func (h *EntityHandler) Get(c *gin.Context) {
 	entity, err := h.service.GetByID(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Entity not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Entity not found"})
 		return
 	}
-	c.JSON(http.StatusOK, entity)
+	jsonfmt.JSON(c, http.StatusOK, entity)
 }
 
 func (h *EntityHandler) Create(c *gin.Context) {
 	var entity domain.Entity
 	if err := c.ShouldBindJSON(&entity); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
-	if err := h.service.Create(&entity); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create entity"})
+	if err := h.service.Create(c.Request.Context(), &entity); err != nil {
+		respondWriteError(c, err, "Failed to create entity")
 		return
 	}
-	c.JSON(http.StatusCreated, entity)
+	jsonfmt.JSON(c, http.StatusCreated, entity)
 }
 
 func (h *EntityHandler) Update(c *gin.Context) {
@@ -367,13 +435,13 @@ func (h *EntityHandler) Update(c *gin.Context) {
 	// Parse UUID from path
 	entityID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
 		return
 	}
 
 	var entity domain.Entity
 	if err := c.ShouldBindJSON(&entity); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 	
@@ -382,20 +450,20 @@ func (h *EntityHandler) Update(c *gin.Context) {
 	
 	// Verify entity exists
 	if _, err := h.service.GetByID(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Entity not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Entity not found"})
 		return
 	}
 	
-	if err := h.service.Update(&entity); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update entity"})
+	if err := h.service.Update(c.Request.Context(), &entity); err != nil {
+		respondWriteError(c, err, "Failed to update entity")
 		return
 	}
-	c.JSON(http.StatusOK, entity)
+	jsonfmt.JSON(c, http.StatusOK, entity)
 }
 
 func (h *EntityHandler) Delete(c *gin.Context) {
-	if err := h.service.Delete(c.Param("id")); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete entity"})
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to delete entity"})
 		return
 	}
 	c.Status(http.StatusNoContent)
@@ -403,36 +471,38 @@ func (h *EntityHandler) Delete(c *gin.Context) {
 
 // Instrument handler methods
 func (h *InstrumentHandler) List(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	instruments, err := h.service.GetAll(limit, offset)
+	q, ok := parseListQuery(c)
+	if !ok {
+		return
+	}
+	instruments, err := h.service.GetAllWithFilters(q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch instruments"})
+		respondListError(c, err, "Failed to fetch instruments")
 		return
 	}
-	c.JSON(http.StatusOK, instruments)
+	jsonfmt.JSON(c, http.StatusOK, instruments)
 }
 
 func (h *InstrumentHandler) Get(c *gin.Context) {
 	instrument, err := h.service.GetByID(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Instrument not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Instrument not found"})
 		return
 	}
-	c.JSON(http.StatusOK, instrument)
+	jsonfmt.JSON(c, http.StatusOK, instrument)
 }
 
 func (h *InstrumentHandler) Create(c *gin.Context) {
 	var instrument domain.Instrument
 	if err := c.ShouldBindJSON(&instrument); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
-	if err := h.service.Create(&instrument); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create instrument"})
+	if err := h.service.Create(c.Request.Context(), &instrument); err != nil {
+		respondWriteError(c, err, "Failed to create instrument")
 		return
 	}
-	c.JSON(http.StatusCreated, instrument)
+	jsonfmt.JSON(c, http.StatusCreated, instrument)
 }
 
 func (h *InstrumentHandler) Update(c *gin.Context) {
@@ -441,13 +511,13 @@ func (h *InstrumentHandler) Update(c *gin.Context) {
 	// Parse UUID from path
 	instrumentID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
 		return
 	}
 
 	var instrument domain.Instrument
 	if err := c.ShouldBindJSON(&instrument); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 	
@@ -456,20 +526,20 @@ func (h *InstrumentHandler) Update(c *gin.Context) {
 	
 	// Verify instrument exists
 	if _, err := h.service.GetByID(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Instrument not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Instrument not found"})
 		return
 	}
 	
-	if err := h.service.Update(&instrument); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update instrument"})
+	if err := h.service.Update(c.Request.Context(), &instrument); err != nil {
+		respondWriteError(c, err, "Failed to update instrument")
 		return
 	}
-	c.JSON(http.StatusOK, instrument)
+	jsonfmt.JSON(c, http.StatusOK, instrument)
 }
 
 func (h *InstrumentHandler) Delete(c *gin.Context) {
-	if err := h.service.Delete(c.Param("id")); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete instrument"})
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to delete instrument"})
 		return
 	}
 	c.Status(http.StatusNoContent)
@@ -477,36 +547,38 @@ func (h *InstrumentHandler) Delete(c *gin.Context) {
 
 // Account handler methods
 func (h *AccountHandler) List(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	accounts, err := h.service.GetAll(limit, offset)
+	q, ok := parseListQuery(c)
+	if !ok {
+		return
+	}
+	accounts, err := h.service.GetAllWithFilters(q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch accounts"})
+		respondListError(c, err, "Failed to fetch accounts")
 		return
 	}
-	c.JSON(http.StatusOK, accounts)
+	jsonfmt.JSON(c, http.StatusOK, accounts)
 }
 
 func (h *AccountHandler) Get(c *gin.Context) {
 	account, err := h.service.GetByID(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Account not found"})
 		return
 	}
-	c.JSON(http.StatusOK, account)
+	jsonfmt.JSON(c, http.StatusOK, account)
 }
 
 func (h *AccountHandler) Create(c *gin.Context) {
 	var account domain.Account
 	if err := c.ShouldBindJSON(&account); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
-	if err := h.service.Create(&account); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create account"})
+	if err := h.service.Create(c.Request.Context(), &account); err != nil {
+		respondWriteError(c, err, "Failed to create account")
 		return
 	}
-	c.JSON(http.StatusCreated, account)
+	jsonfmt.JSON(c, http.StatusCreated, account)
 }
 
 func (h *AccountHandler) Update(c *gin.Context) {
@@ -515,13 +587,13 @@ func (h *AccountHandler) Update(c *gin.Context) {
 	// Parse UUID from path
 	accountID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
 		return
 	}
 
 	var account domain.Account
 	if err := c.ShouldBindJSON(&account); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 	
@@ -530,20 +602,20 @@ func (h *AccountHandler) Update(c *gin.Context) {
 	
 	// Verify account exists
 	if _, err := h.service.GetByID(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Account not found"})
 		return
 	}
 	
-	if err := h.service.Update(&account); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update account"})
+	if err := h.service.Update(c.Request.Context(), &account); err != nil {
+		respondWriteError(c, err, "Failed to update account")
 		return
 	}
-	c.JSON(http.StatusOK, account)
+	jsonfmt.JSON(c, http.StatusOK, account)
 }
 
 func (h *AccountHandler) Delete(c *gin.Context) {
-	if err := h.service.Delete(c.Param("id")); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete account"})
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to delete account"})
 		return
 	}
 	c.Status(http.StatusNoContent)
@@ -551,36 +623,38 @@ func (h *AccountHandler) Delete(c *gin.Context) {
 
 // SSI handler methods
 func (h *SSIHandler) List(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	ssis, err := h.service.GetAll(limit, offset)
+	q, ok := parseListQuery(c)
+	if !ok {
+		return
+	}
+	ssis, err := h.service.GetAllWithFilters(q)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch SSIs"})
+		respondListError(c, err, "Failed to fetch SSIs")
 		return
 	}
-	c.JSON(http.StatusOK, ssis)
+	jsonfmt.JSON(c, http.StatusOK, ssis)
 }
 
 func (h *SSIHandler) Get(c *gin.Context) {
 	ssi, err := h.service.GetByID(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "SSI not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "SSI not found"})
 		return
 	}
-	c.JSON(http.StatusOK, ssi)
+	jsonfmt.JSON(c, http.StatusOK, ssi)
 }
 
 func (h *SSIHandler) Create(c *gin.Context) {
 	var ssi domain.SSI
 	if err := c.ShouldBindJSON(&ssi); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
-	if err := h.service.Create(&ssi); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create SSI"})
+	if err := h.service.Create(c.Request.Context(), &ssi); err != nil {
+		respondWriteError(c, err, "Failed to create SSI")
 		return
 	}
-	c.JSON(http.StatusCreated, ssi)
+	jsonfmt.JSON(c, http.StatusCreated, ssi)
 }
 
 func (h *SSIHandler) Update(c *gin.Context) {
@@ -589,13 +663,13 @@ func (h *SSIHandler) Update(c *gin.Context) {
 	// Parse UUID from path
 	ssiID, err := uuid.Parse(id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
 		return
 	}
 
 	var ssi domain.SSI
 	if err := c.ShouldBindJSON(&ssi); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 	
@@ -604,38 +678,66 @@ func (h *SSIHandler) Update(c *gin.Context) {
 	
 	// Verify SSI exists
 	if _, err := h.service.GetByID(id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "SSI not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "SSI not found"})
 		return
 	}
 	
-	if err := h.service.Update(&ssi); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update SSI"})
+	if err := h.service.Update(c.Request.Context(), &ssi); err != nil {
+		respondWriteError(c, err, "Failed to update SSI")
 		return
 	}
-	c.JSON(http.StatusOK, ssi)
+	jsonfmt.JSON(c, http.StatusOK, ssi)
 }
 
 func (h *SSIHandler) Delete(c *gin.Context) {
-	if err := h.service.Delete(c.Param("id")); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete SSI"})
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to delete SSI"})
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
-// Data acquisition endpoints
-func (h *DataAcquisitionHandler) Import(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Import endpoint - to be implemented"})
-}
-
-func (h *DataAcquisitionHandler) Export(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Export endpoint - to be implemented"})
-}
-
-func (h *DataAcquisitionHandler) ListJobs(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "List jobs endpoint - to be implemented"})
-}
-
-func (h *DataAcquisitionHandler) GetJob(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Get job endpoint - to be implemented"})
+// Freshness godoc
+// @Summary Data freshness
+// @Description Per-table row counts (estimated), last update timestamps, last import runs and a change sequence. Cheap to poll: results are cached for 60 seconds.
+// @Tags data
+// @Produce json
+// @Success 200 {object} domain.DataFreshness
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /data/freshness [get]
+func (h *DataAcquisitionHandler) Freshness(c *gin.Context) {
+	freshness, err := h.freshnessService.GetFreshness()
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to compute data freshness"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, freshness)
+}
+
+// respondWriteError maps create/update errors: invalid identifiers are 400 (instruments with
+// their field errors), references to inactive reference data are 422, deactivations blocked by the
+// restrict policy and exhausted ID ranges are 409, anything else is 500
+func respondWriteError(c *gin.Context, err error, message string) {
+	var invalidInstrument *service.InstrumentValidationError
+	switch {
+	case errors.As(err, &invalidInstrument):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error(), "fields": invalidInstrument.Fields})
+	case errors.Is(err, service.ErrInactiveReference):
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidLEI):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidBIC), errors.Is(err, service.ErrInvalidIBAN):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUnknownBIC):
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDeactivationRestricted):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSSIOverlap):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrIDRangeExhausted):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": message})
+	}
 }
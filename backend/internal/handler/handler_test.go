@@ -0,0 +1,285 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// errorBody is the body of every error response
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func TestCountryListPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		limit      int
+		offset     int
+		wantStatus int
+		wantError  string
+	}{
+		{name: "defaults", path: "/countries", limit: 10, wantStatus: http.StatusOK},
+		{name: "explicit page", path: "/countries?limit=100&offset=200", limit: 100, offset: 200, wantStatus: http.StatusOK},
+		{name: "limit too large", path: "/countries?limit=101", wantStatus: http.StatusBadRequest, wantError: "Invalid limit parameter (must be 1-100)"},
+		{name: "limit not a number", path: "/countries?limit=ten", wantStatus: http.StatusBadRequest, wantError: "Invalid limit parameter (must be 1-100)"},
+		{name: "negative offset", path: "/countries?offset=-1", wantStatus: http.StatusBadRequest, wantError: "Invalid offset parameter (must be >= 0)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.wantStatus == http.StatusOK {
+				h.Country.On("GetAll", tt.limit, tt.offset).Return([]*domain.Country{{Code: "GB", Name: "United Kingdom"}}, nil)
+			}
+			h.Router.GET("/countries", h.Handlers.Country.List)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var countries []domain.Country
+			h.DecodeJSON(rec, &countries)
+			assert.Equal(t, "GB", countries[0].Code)
+		})
+	}
+}
+
+func TestCountryUpdateWritesThePathID(t *testing.T) {
+	h := handlertest.New(t)
+	id := uuid.New()
+	h.Country.On("GetByID", id.String()).Return(&domain.Country{Code: "GB"}, nil)
+	h.Country.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Country) bool {
+		return c.ID == id && c.Name == "Great Britain"
+	})).Return(nil)
+	h.Router.PUT("/countries/:id", h.Handlers.Country.Update)
+
+	// The body's ID is ignored: only the country in the path is updated
+	other := domain.Country{Code: "GB", Name: "Great Britain"}
+	other.ID = uuid.New()
+	rec := h.Do(http.MethodPut, "/countries/"+id.String(), other)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var country domain.Country
+	h.DecodeJSON(rec, &country)
+	assert.Equal(t, id, country.ID)
+}
+
+func TestCountryUpdateRefusesUnknownCountries(t *testing.T) {
+	h := handlertest.New(t)
+	id := uuid.New()
+	h.Country.On("GetByID", id.String()).Return(nil, gorm.ErrRecordNotFound)
+	h.Router.PUT("/countries/:id", h.Handlers.Country.Update)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPut, "/countries/not-a-uuid", domain.Country{Code: "GB"}).Code)
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodPut, "/countries/"+id.String(), domain.Country{Code: "GB"}).Code)
+	h.Country.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestEntityListPassesTheParsedQuery(t *testing.T) {
+	h := handlertest.New(t)
+	h.Entity.On("GetAllWithFilters", &query.ListQuery{
+		Limit:      5,
+		Offset:     10,
+		SortBy:     "name",
+		SortOrder:  "desc",
+		Conditions: []query.Condition{{Field: "created_at", Op: query.OpGte, Values: []string{"2024-01-01"}}},
+	}).Return([]*domain.Entity{{Name: "Acme"}}, nil)
+	h.Router.GET("/entities", h.Handlers.Entity.List)
+
+	rec := h.Do(http.MethodGet, "/entities?limit=5&offset=10&sortBy=name&sortOrder=DESC&created_at[gte]=2024-01-01", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var entities []domain.Entity
+	h.DecodeJSON(rec, &entities)
+	assert.Equal(t, "Acme", entities[0].Name)
+}
+
+func TestEntityListMapsErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "unparsable query", path: "/entities?name[between]=a", wantStatus: http.StatusBadRequest, wantError: "Unsupported operator 'between' on field 'name'"},
+		{name: "unknown field", path: "/entities?colour=red", err: &query.ValidationError{Message: "Unknown filter field 'colour'"}, wantStatus: http.StatusBadRequest, wantError: "Unknown filter field 'colour'"},
+		{name: "database failure", path: "/entities", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to fetch entities"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.err != nil {
+				h.Entity.On("GetAllWithFilters", mock.Anything).Return(nil, tt.err)
+			}
+			h.Router.GET("/entities", h.Handlers.Entity.List)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestEntityGet(t *testing.T) {
+	h := handlertest.New(t)
+	h.Entity.On("GetByID", "42").Return(&domain.Entity{Name: "Acme"}, nil)
+	h.Entity.On("GetByID", "43").Return(nil, gorm.ErrRecordNotFound)
+	h.Router.GET("/entities/:id", h.Handlers.Entity.Get)
+
+	rec := h.Do(http.MethodGet, "/entities/42", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var entity domain.Entity
+	h.DecodeJSON(rec, &entity)
+	assert.Equal(t, "Acme", entity.Name)
+
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodGet, "/entities/43", nil).Code)
+}
+
+func TestWriteErrorStatuses(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "inactive reference", err: fmt.Errorf("%w: currency XYZ", service.ErrInactiveReference), wantStatus: http.StatusUnprocessableEntity, wantError: "inactive reference data: currency XYZ"},
+		{name: "invalid LEI", err: service.ErrInvalidLEI, wantStatus: http.StatusBadRequest, wantError: service.ErrInvalidLEI.Error()},
+		{name: "invalid BIC", err: service.ErrInvalidBIC, wantStatus: http.StatusBadRequest, wantError: service.ErrInvalidBIC.Error()},
+		{name: "invalid IBAN", err: service.ErrInvalidIBAN, wantStatus: http.StatusBadRequest, wantError: service.ErrInvalidIBAN.Error()},
+		{name: "unknown BIC", err: service.ErrUnknownBIC, wantStatus: http.StatusUnprocessableEntity, wantError: service.ErrUnknownBIC.Error()},
+		{name: "restricted deactivation", err: service.ErrDeactivationRestricted, wantStatus: http.StatusConflict, wantError: service.ErrDeactivationRestricted.Error()},
+		{name: "overlapping SSI", err: service.ErrSSIOverlap, wantStatus: http.StatusConflict, wantError: service.ErrSSIOverlap.Error()},
+		{name: "ID range exhausted", err: repository.ErrIDRangeExhausted, wantStatus: http.StatusConflict, wantError: repository.ErrIDRangeExhausted.Error()},
+		{name: "anything else is not leaked", err: errors.New("pq: deadlock detected"), wantStatus: http.StatusInternalServerError, wantError: "Failed to create SSI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.SSI.On("Create", mock.Anything, mock.Anything).Return(tt.err)
+			h.Router.POST("/ssis", h.Handlers.SSI.Create)
+
+			rec := h.Do(http.MethodPost, "/ssis", domain.SSI{BeneficiaryAccount: "12345678"})
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestInstrumentCreateReturnsFieldErrors(t *testing.T) {
+	h := handlertest.New(t)
+	invalid := &service.InstrumentValidationError{Fields: []service.InstrumentFieldError{{Field: "cfi", Error: "must be 6 letters"}}}
+	h.Instrument.On("Create", mock.Anything, mock.MatchedBy(func(i *domain.Instrument) bool {
+		return i.CFI == "DB"
+	})).Return(invalid)
+	h.Router.POST("/instruments", h.Handlers.Instrument.Create)
+
+	rec := h.Do(http.MethodPost, "/instruments", domain.Instrument{Name: "Bond", CFI: "DB"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body struct {
+		Fields []service.InstrumentFieldError `json:"fields"`
+	}
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, invalid.Fields, body.Fields)
+}
+
+func TestDataFreshness(t *testing.T) {
+	h := handlertest.New(t)
+	h.Freshness.On("GetFreshness").Return(&domain.DataFreshness{CacheTTL: "1m0s", ChangeSequence: 17}, nil).Once()
+	h.Freshness.On("GetFreshness").Return(nil, errors.New("statistics unavailable")).Once()
+	h.Router.GET("/data/freshness", h.Handlers.DataAcquisition.Freshness)
+
+	rec := h.Do(http.MethodGet, "/data/freshness", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var freshness domain.DataFreshness
+	h.DecodeJSON(rec, &freshness)
+	assert.Equal(t, int64(17), freshness.ChangeSequence)
+
+	assert.Equal(t, http.StatusInternalServerError, h.Do(http.MethodGet, "/data/freshness", nil).Code)
+}
+
+// asUser makes every request through h's router come from email, as the auth middleware would
+func asUser(h *handlertest.Harness, email string) {
+	h.Router.Use(func(c *gin.Context) {
+		c.Set("email", email)
+	})
+}
+
+// doRaw performs a request against h's router with body sent as is
+func doRaw(h *handlertest.Harness, method, path, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+// doUpload posts content as the multipart file field of an upload, after fields
+func doUpload(t *testing.T, h *handlertest.Harness, path string, fields map[string]string, fileName, content string) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, form.WriteField(name, value))
+	}
+	file, err := form.CreateFormFile("file", fileName)
+	require.NoError(t, err)
+	_, err = file.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	return doRaw(h, http.MethodPost, path, form.FormDataContentType(), body.String())
+}
+
+// readAll reads an uploaded file, as a mocked service would
+func readAll(r io.Reader) string {
+	content, _ := io.ReadAll(r)
+	return string(content)
+}
+
+// jobStarted is the body of a 202 answering a request run as a background job
+type jobStarted struct {
+	Message string    `json:"message"`
+	JobID   uuid.UUID `json:"job_id"`
+}
+
+// waitForJob waits for the background job id to finish and returns it
+func waitForJob(t *testing.T, h *handlertest.Harness, id uuid.UUID) service.JobInfo {
+	t.Helper()
+	var job service.JobInfo
+	require.Eventually(t, func() bool {
+		var ok bool
+		job, ok = h.Services.Jobs.Get(id)
+		return ok && job.FinishedAt != nil
+	}, 5*time.Second, 5*time.Millisecond, "job %s did not finish", id)
+	return job
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
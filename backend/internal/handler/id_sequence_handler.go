@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// IDSequenceHandler serves the admin API for business ID sequences
+type IDSequenceHandler struct {
+	idService service.IDGenerationService
+}
+
+// NewIDSequenceHandler creates a new ID sequence handler
+func NewIDSequenceHandler(idService service.IDGenerationService) *IDSequenceHandler {
+	return &IDSequenceHandler{idService: idService}
+}
+
+// IDSequenceResponse is a sequence with the identifier it will generate next
+type IDSequenceResponse struct {
+	*domain.IDSequence
+	NextID string `json:"next_id"`
+}
+
+func (h *IDSequenceHandler) response(seq *domain.IDSequence) IDSequenceResponse {
+	return IDSequenceResponse{IDSequence: seq, NextID: h.idService.Preview(seq)}
+}
+
+func (h *IDSequenceHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "ID sequence not found"})
+	case errors.Is(err, service.ErrInvalidIDSequence):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// List returns all ID sequences
+// @Summary List ID sequences
+// @Tags admin
+// @Produce json
+// @Success 200 {array} IDSequenceResponse
+// @Security BearerAuth
+// @Router /admin/id-sequences [get]
+func (h *IDSequenceHandler) List(c *gin.Context) {
+	seqs, err := h.idService.ListSequences()
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to list ID sequences"})
+		return
+	}
+	items := make([]IDSequenceResponse, 0, len(seqs))
+	for _, seq := range seqs {
+		items = append(items, h.response(seq))
+	}
+	jsonfmt.JSON(c, http.StatusOK, items)
+}
+
+// Get returns an ID sequence
+// @Summary Get ID sequence
+// @Tags admin
+// @Produce json
+// @Param id path string true "ID sequence ID"
+// @Success 200 {object} IDSequenceResponse
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/id-sequences/{id} [get]
+func (h *IDSequenceHandler) Get(c *gin.Context) {
+	seq, err := h.idService.GetSequence(c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve ID sequence")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, h.response(seq))
+}
+
+// Create defines a new ID sequence
+// @Summary Create ID sequence
+// @Description Defines how business IDs are generated for a target (ENTITY business_id or ACCOUNT account_number): prefix, zero padding, check digits (NONE, LUHN, MOD97) and the range next_value..max_value. Only one sequence may be active per target.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param sequence body domain.IDSequence true "ID sequence"
+// @Success 201 {object} IDSequenceResponse
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/id-sequences [post]
+func (h *IDSequenceHandler) Create(c *gin.Context) {
+	seq := domain.IDSequence{Active: true}
+	if err := c.ShouldBindJSON(&seq); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	seq.ID = uuid.Nil
+
+	if err := h.idService.CreateSequence(&seq); err != nil {
+		h.respondError(c, err, "Failed to create ID sequence")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusCreated, h.response(&seq))
+}
+
+// Update changes an ID sequence's format or range
+// @Summary Update ID sequence
+// @Description Replaces the sequence definition. Use next_value/max_value to assign a new range; values already in use are skipped when generating.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "ID sequence ID"
+// @Param sequence body domain.IDSequence true "ID sequence"
+// @Success 200 {object} IDSequenceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/id-sequences/{id} [put]
+func (h *IDSequenceHandler) Update(c *gin.Context) {
+	seqID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	var seq domain.IDSequence
+	if err := c.ShouldBindJSON(&seq); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	seq.ID = seqID
+
+	if err := h.idService.UpdateSequence(&seq); err != nil {
+		h.respondError(c, err, "Failed to update ID sequence")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, h.response(&seq))
+}
+
+// Delete removes an ID sequence; identifiers already generated are unaffected
+// @Summary Delete ID sequence
+// @Tags admin
+// @Param id path string true "ID sequence ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/id-sequences/{id} [delete]
+func (h *IDSequenceHandler) Delete(c *gin.Context) {
+	if err := h.idService.DeleteSequence(c.Param("id")); err != nil {
+		h.respondError(c, err, "Failed to delete ID sequence")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestIDSequenceListPreviewsTheNextID(t *testing.T) {
+	h := handlertest.New(t)
+	entities := &domain.IDSequence{Name: "entities", Target: "ENTITY", Prefix: "ENT", Padding: 6, NextValue: 42}
+	h.IDGeneration.On("ListSequences").Return([]*domain.IDSequence{entities}, nil)
+	h.IDGeneration.On("Preview", entities).Return("ENT000042")
+	h.Router.GET("/admin/id-sequences", h.Handlers.IDSequence.List)
+
+	rec := h.Do(http.MethodGet, "/admin/id-sequences", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var items []handler.IDSequenceResponse
+	h.DecodeJSON(rec, &items)
+	assert.Equal(t, "entities", items[0].Name)
+	assert.Equal(t, "ENT000042", items[0].NextID)
+}
+
+func TestIDSequenceCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantActive bool
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "active unless stated", body: `{"name":"accounts","target":"ACCOUNT","check_digit":"MOD97"}`, wantActive: true, wantStatus: http.StatusCreated},
+		{name: "created inactive", body: `{"name":"accounts","target":"ACCOUNT","active":false}`, wantStatus: http.StatusCreated},
+		{name: "invalid", body: `{"name":"accounts","target":"TRADE"}`, wantActive: true, err: fmt.Errorf("%w: target must be ENTITY or ACCOUNT", service.ErrInvalidIDSequence), wantStatus: http.StatusBadRequest, wantError: "invalid ID sequence: target must be ENTITY or ACCOUNT"},
+		{name: "database failure", body: `{"name":"accounts","target":"ACCOUNT"}`, wantActive: true, err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to create ID sequence"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.IDGeneration.On("CreateSequence", mock.MatchedBy(func(seq *domain.IDSequence) bool {
+				return seq.ID == uuid.Nil && seq.Name == "accounts" && seq.Active == tt.wantActive
+			})).Return(tt.err)
+			if tt.err == nil {
+				h.IDGeneration.On("Preview", mock.Anything).Return("ACC1")
+			}
+			h.Router.POST("/admin/id-sequences", h.Handlers.IDSequence.Create)
+
+			rec := doRaw(h, http.MethodPost, "/admin/id-sequences", "application/json", tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var created handler.IDSequenceResponse
+			h.DecodeJSON(rec, &created)
+			assert.Equal(t, "ACC1", created.NextID)
+		})
+	}
+}
+
+func TestIDSequenceUpdateUsesThePathID(t *testing.T) {
+	id := uuid.New()
+	h := handlertest.New(t)
+	h.IDGeneration.On("UpdateSequence", mock.MatchedBy(func(seq *domain.IDSequence) bool {
+		return seq.ID == id && seq.NextValue == 1000 && seq.MaxValue == 1999
+	})).Return(nil)
+	h.IDGeneration.On("Preview", mock.Anything).Return("ENT1000")
+	h.Router.PUT("/admin/id-sequences/:id", h.Handlers.IDSequence.Update)
+
+	rec := h.Do(http.MethodPut, "/admin/id-sequences/"+id.String(), map[string]interface{}{"id": uuid.New(), "name": "entities", "next_value": 1000, "max_value": 1999})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var updated handler.IDSequenceResponse
+	h.DecodeJSON(rec, &updated)
+	assert.Equal(t, id, updated.ID)
+	assert.Equal(t, "ENT1000", updated.NextID)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPut, "/admin/id-sequences/entities", map[string]string{"name": "entities"}).Code)
+}
+
+func TestIDSequenceGetAndDelete(t *testing.T) {
+	h := handlertest.New(t)
+	h.IDGeneration.On("GetSequence", "s1").Return(nil, gorm.ErrRecordNotFound)
+	h.IDGeneration.On("DeleteSequence", "s1").Return(nil)
+	h.IDGeneration.On("DeleteSequence", "s2").Return(gorm.ErrRecordNotFound)
+	h.Router.GET("/admin/id-sequences/:id", h.Handlers.IDSequence.Get)
+	h.Router.DELETE("/admin/id-sequences/:id", h.Handlers.IDSequence.Delete)
+
+	rec := h.Do(http.MethodGet, "/admin/id-sequences/s1", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "ID sequence not found", body.Error)
+
+	assert.Equal(t, http.StatusNoContent, h.Do(http.MethodDelete, "/admin/id-sequences/s1", nil).Code)
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodDelete, "/admin/id-sequences/s2", nil).Code)
+}
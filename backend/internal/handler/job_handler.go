@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+const (
+	jobStreamWriteTimeout = 10 * time.Second
+	jobStreamPingInterval = 30 * time.Second
+)
+
+// jobStreamUpgrader accepts any origin: the task stream sits behind JWT auth, which browsers
+// cannot attach automatically the way they do cookies, and the LEI status stream is public
+var jobStreamUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// JobHandler serves the registry of running background operations
+type JobHandler struct {
+	jobs *service.JobRegistry
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(jobs *service.JobRegistry) *JobHandler {
+	return &JobHandler{jobs: jobs}
+}
+
+// List returns running and recently finished background operations
+// @Summary List background tasks
+// @Description Syncs, file processing, exports and cleanups running in this process, newest first. Finished tasks stay listed for an hour.
+// @Tags admin
+// @Produce json
+// @Param status query string false "RUNNING, COMPLETED or FAILED"
+// @Param kind query string false "Task kind (e.g. LEI_FULL_SYNC, LEI_FILE_PROCESSING, EXPORT)"
+// @Success 200 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /admin/tasks [get]
+func (h *JobHandler) List(c *gin.Context) {
+	status, kind := c.Query("status"), c.Query("kind")
+
+	items := make([]service.JobInfo, 0)
+	for _, job := range h.jobs.List() {
+		if (status == "" || job.Status == status) && (kind == "" || job.Kind == kind) {
+			items = append(items, job)
+		}
+	}
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"items": items, "total": len(items)})
+}
+
+// Get returns a single background operation
+// @Summary Get background task
+// @Tags admin
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {object} service.JobInfo
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/tasks/{id} [get]
+func (h *JobHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+	job, ok := h.jobs.Get(id)
+	if !ok {
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, job)
+}
+
+// Stream pushes task events over a WebSocket
+// @Summary Stream background task events
+// @Description Upgrades to a WebSocket. Sends a "snapshot" message with the current task list, then one message per started, progress or finished event ({"type": ..., "job": {...}}). Browsers may pass the JWT as the access_token query parameter.
+// @Tags admin
+// @Param access_token query string false "JWT, for clients that cannot set the Authorization header"
+// @Success 101
+// @Security BearerAuth
+// @Router /admin/tasks/stream [get]
+func (h *JobHandler) Stream(c *gin.Context) {
+	conn, err := jobStreamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade has already written the error response
+		log.Ctx(c.Request.Context()).Warn().Err(err).Msg("Task stream upgrade failed")
+		return
+	}
+	defer conn.Close()
+
+	// Subscribe before the snapshot so no event falls between the two
+	events, unsubscribe := h.jobs.Subscribe()
+	defer unsubscribe()
+
+	if err := writeJobStreamMessage(conn, gin.H{"type": "snapshot", "jobs": h.jobs.List()}); err != nil {
+		return
+	}
+
+	// The client sends nothing; reading detects disconnects and handles control frames
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(jobStreamPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeJobStreamMessage(conn, event); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(jobStreamWriteTimeout)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+func writeJobStreamMessage(conn *websocket.Conn, message interface{}) error {
+	conn.SetWriteDeadline(time.Now().Add(jobStreamWriteTimeout))
+	return conn.WriteJSON(message)
+}
@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+func TestJobListFilters(t *testing.T) {
+	h := handlertest.New(t)
+	jobs := h.Services.Jobs
+	require.NoError(t, jobs.Run(service.JobKindCountrySync, "ops@example.com", "Country sync", func(*service.Job) error { return nil }))
+	require.Error(t, jobs.Run(service.JobKindCountrySync, "ops@example.com", "Country sync", func(*service.Job) error { return errors.New("timeout") }))
+	running := jobs.Start(service.JobKindFXSync, service.JobOwnerScheduler, "FX sync")
+	t.Cleanup(func() { running.Finish(nil) })
+	h.Router.GET("/admin/tasks", h.Handlers.Job.List)
+
+	tests := []struct {
+		name      string
+		path      string
+		wantKinds []string
+	}{
+		{name: "all", path: "/admin/tasks", wantKinds: []string{service.JobKindCountrySync, service.JobKindCountrySync, service.JobKindFXSync}},
+		{name: "by status", path: "/admin/tasks?status=" + service.JobStatusRunning, wantKinds: []string{service.JobKindFXSync}},
+		{name: "by kind", path: "/admin/tasks?kind=" + service.JobKindCountrySync, wantKinds: []string{service.JobKindCountrySync, service.JobKindCountrySync}},
+		{name: "by both", path: "/admin/tasks?kind=" + service.JobKindCountrySync + "&status=" + service.JobStatusFailed, wantKinds: []string{service.JobKindCountrySync}},
+		{name: "no match", path: "/admin/tasks?kind=" + service.JobKindExport, wantKinds: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var page struct {
+				Items []service.JobInfo `json:"items"`
+				Total int               `json:"total"`
+			}
+			h.DecodeJSON(rec, &page)
+			kinds := make([]string, 0, len(page.Items))
+			for _, job := range page.Items {
+				kinds = append(kinds, job.Kind)
+			}
+			assert.ElementsMatch(t, tt.wantKinds, kinds)
+			assert.Equal(t, len(tt.wantKinds), page.Total)
+		})
+	}
+}
+
+func TestJobGet(t *testing.T) {
+	h := handlertest.New(t)
+	job := h.Services.Jobs.Start(service.JobKindExport, "ops@example.com", "Export SSIs")
+	job.SetProgress(5, 10)
+	t.Cleanup(func() { job.Finish(nil) })
+	h.Router.GET("/admin/tasks/:id", h.Handlers.Job.Get)
+
+	rec := h.Do(http.MethodGet, "/admin/tasks/"+job.ID().String(), nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var info service.JobInfo
+	h.DecodeJSON(rec, &info)
+	assert.Equal(t, job.ID(), info.ID)
+	assert.Equal(t, int64(5), info.Processed)
+	assert.Equal(t, int64(10), info.Total)
+
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodGet, "/admin/tasks/not-a-uuid", nil).Code)
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodGet, "/admin/tasks/00000000-0000-0000-0000-000000000000", nil).Code)
+}
+
+func TestJobStreamSendsSnapshotThenEvents(t *testing.T) {
+	h := handlertest.New(t)
+	jobs := h.Services.Jobs
+	existing := jobs.Start(service.JobKindLEIDeltaSync, service.JobOwnerScheduler, "Delta sync")
+	h.Router.GET("/admin/tasks/stream", h.Handlers.Job.Stream)
+	server := httptest.NewServer(h.Router)
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/admin/tasks/stream", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	var snapshot struct {
+		Type string            `json:"type"`
+		Jobs []service.JobInfo `json:"jobs"`
+	}
+	require.NoError(t, conn.ReadJSON(&snapshot))
+	assert.Equal(t, "snapshot", snapshot.Type)
+	require.Len(t, snapshot.Jobs, 1)
+	assert.Equal(t, existing.ID(), snapshot.Jobs[0].ID)
+
+	existing.Finish(nil)
+	var event service.JobEvent
+	require.NoError(t, conn.ReadJSON(&event))
+	assert.Equal(t, service.JobEventFinished, event.Type)
+	assert.Equal(t, existing.ID(), event.Job.ID)
+	assert.Equal(t, service.JobStatusCompleted, event.Job.Status)
+}
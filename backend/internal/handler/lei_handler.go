@@ -1,28 +1,61 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/breaker"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
 	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
 )
 
 // LEIHandler handles LEI-related HTTP requests
 type LEIHandler struct {
 	leiService       service.LEIService
+	elfService       service.ELFService                   // names the legal forms of returned records
+	raService        service.RegistrationAuthorityService // names the registration authorities of returned records
+	louService       service.LOUService                   // names the managing LOUs of returned records
 	schedulerService service.SchedulerService
+	exportService    service.DataExportService // streams the dataset dump
+	jobs             *service.JobRegistry
 }
 
 // NewLEIHandler creates a new LEI handler
-func NewLEIHandler(leiService service.LEIService, schedulerService service.SchedulerService) *LEIHandler {
+func NewLEIHandler(leiService service.LEIService, elfService service.ELFService, raService service.RegistrationAuthorityService, louService service.LOUService, schedulerService service.SchedulerService, exportService service.DataExportService, jobs *service.JobRegistry) *LEIHandler {
 	return &LEIHandler{
 		leiService:       leiService,
+		elfService:       elfService,
+		raService:        raService,
+		louService:       louService,
 		schedulerService: schedulerService,
+		exportService:    exportService,
+		jobs:             jobs,
 	}
 }
 
+// describe resolves the codes of LEI records (legal form, registration authority, managing LOU) to
+// names from the GLEIF reference lists
+func (h *LEIHandler) describe(ctx context.Context, records ...*domain.LEIRecord) {
+	h.elfService.DescribeLEIRecords(ctx, records...)
+	h.raService.DescribeLEIRecords(ctx, records...)
+	h.louService.DescribeLEIRecords(ctx, records...)
+}
+
 // GetDistinctCountries returns a list of all unique countries in the LEI database
 // @Summary Get distinct countries
 // @Description Get sorted list of unique countries from LEI records
@@ -34,33 +67,106 @@ func NewLEIHandler(leiService service.LEIService, schedulerService service.Sched
 func (h *LEIHandler) GetDistinctCountries(c *gin.Context) {
 	countries, err := h.leiService.GetDistinctCountries()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve countries"})
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve countries"})
 		return
 	}
-	c.JSON(http.StatusOK, countries)
+	jsonfmt.JSON(c, http.StatusOK, countries)
+}
+
+// parseAsOf reads a point in time; a bare date means the end of that day (UTC), so the record
+// includes every change made on it
+func parseAsOf(value string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), true
+	}
+	return time.Time{}, false
 }
 
 // GetLEIByCode retrieves an LEI record by LEI code
 // @Summary Get LEI record by code
-// @Description Get a specific LEI record by its LEI code
+// @Description Get a specific LEI record by its LEI code. With asOf, the record is reconstructed from its audit history as it stood at that time and returned with the audit entry it reflects.
 // @Tags LEI
 // @Accept json
 // @Produce json
 // @Param lei path string true "LEI code"
+// @Param refresh query bool false "If the LEI is not stored locally, fetch it from the GLEIF API, store it and return it; requires a token"
+// @Param asOf query string false "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
 // @Success 200 {object} domain.LEIRecord
+// @Success 200 {object} service.LEIRecordVersion "When asOf is given"
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 401 {object} map[string]string "refresh without a valid token"
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string "GLEIF API rate limit reached; see Retry-After"
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string "GLEIF unavailable after repeated failures; see Retry-After"
 // @Router /api/v1/lei/{lei} [get]
 func (h *LEIHandler) GetLEIByCode(c *gin.Context) {
 	lei := c.Param("lei")
 
+	if value := c.Query("asOf"); value != "" {
+		asOf, ok := parseAsOf(value)
+		if !ok {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "asOf must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"})
+			return
+		}
+		version, err := h.leiService.GetRecordAsOf(lei, asOf)
+		switch {
+		case errors.Is(err, service.ErrLEINotFoundAsOf):
+			jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrLEIVersionUnavailable):
+			jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+		case err != nil:
+			jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to reconstruct LEI record"})
+		default:
+			jsonfmt.JSON(c, http.StatusOK, version)
+		}
+		return
+	}
+
 	record, err := h.leiService.GetLEIByCode(lei)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "LEI record not found"})
+	if err == nil {
+		h.describe(c.Request.Context(), record)
+		jsonfmt.JSON(c, http.StatusOK, record)
+		return
+	}
+
+	if !LEIRefreshRequested(c) || !errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "LEI record not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, record)
+	// Local miss: fall back to the GLEIF single-record API
+	record, err = h.leiService.FetchLEIFromGLEIF(c.Request.Context(), lei)
+	var open *breaker.OpenError
+	var throttled *service.GLEIFThrottledError
+	switch {
+	case errors.Is(err, service.ErrInvalidLEI):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrLEINotFoundAtGLEIF):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "LEI record not found locally or at GLEIF"})
+	case errors.As(err, &open):
+		c.Header("Retry-After", strconv.Itoa(max(int(time.Until(open.Until).Seconds()), 1)))
+		jsonfmt.JSON(c, http.StatusServiceUnavailable, gin.H{"error": "GLEIF is unavailable, try again later"})
+	case errors.As(err, &throttled):
+		c.Header("Retry-After", strconv.Itoa(max(int(time.Until(throttled.Until).Seconds()), 1)))
+		jsonfmt.JSON(c, http.StatusTooManyRequests, gin.H{"error": "Too many GLEIF lookups, try again later"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusBadGateway, gin.H{"error": "Failed to fetch LEI from GLEIF"})
+	default:
+		h.describe(c.Request.Context(), record)
+		jsonfmt.JSON(c, http.StatusOK, record)
+	}
+}
+
+// LEIRefreshRequested reports whether a GetLEIByCode request asks to fetch an LEI missing locally
+// from GLEIF, which takes a token (see middleware.JWTAuthFor)
+func LEIRefreshRequested(c *gin.Context) bool {
+	refresh, _ := strconv.ParseBool(c.Query("refresh"))
+	return refresh
 }
 
 // GetLEIByID retrieves an LEI record by ID
@@ -79,11 +185,12 @@ func (h *LEIHandler) GetLEIByID(c *gin.Context) {
 
 	record, err := h.leiService.GetLEIByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "LEI record not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "LEI record not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, record)
+	h.describe(c.Request.Context(), record)
+	jsonfmt.JSON(c, http.StatusOK, record)
 }
 
 // ListLEI retrieves all LEI records with pagination, search, and filters
@@ -95,9 +202,12 @@ func (h *LEIHandler) GetLEIByID(c *gin.Context) {
 // @Param limit query int false "Limit" default(50)
 // @Param offset query int false "Offset" default(0)
 // @Param search query string false "Search term (LEI code or legal name)"
+// @Param q query string false "Full-text search on legal name (prefix match on every term, ranked by relevance unless sortBy is set)"
 // @Param status query string false "Entity status filter (e.g., ACTIVE, INACTIVE)"
 // @Param category query string false "Entity category filter (e.g., GENERAL, FUND)"
 // @Param country query string false "Country code filter (e.g., US, GB)"
+// @Param lou query string false "Managing LOU filter: the LOU's LEI, or part of its name or marketing name"
+// @Param legalForm query string false "Legal form filter: ELF code (e.g., 8Z6G), or part of the legal form's name or one of its abbreviations (e.g., GmbH)"
 // @Param sortBy query string false "Sort field (lei, legal_name, entity_status, entity_category, legal_address_country, last_update_date)"
 // @Param sortOrder query string false "Sort order (asc, desc)" default(asc)
 // @Success 200 {array} domain.LEIRecord
@@ -106,25 +216,188 @@ func (h *LEIHandler) GetLEIByID(c *gin.Context) {
 func (h *LEIHandler) ListLEI(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	search := c.Query("search")
-	status := c.Query("status")
-	category := c.Query("category")
-	country := c.Query("country")
-	sortBy := c.DefaultQuery("sortBy", "legal_name")
-	sortOrder := c.DefaultQuery("sortOrder", "asc")
-
 	// Allow up to 501 records (frontend requests itemsPerPage + 1 to detect more pages)
 	if limit > 501 {
 		limit = 501
 	}
 
-	records, err := h.leiService.GetAllLEIWithFilters(limit, offset, search, status, category, country, sortBy, sortOrder)
+	records, err := h.leiService.GetAllLEIWithFilters(limit, offset, service.LEIListFilter(c.Request.URL.Query()))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve LEI records"})
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve LEI records"})
 		return
 	}
 
-	c.JSON(http.StatusOK, records)
+	h.describe(c.Request.Context(), records...)
+
+	jsonfmt.JSON(c, http.StatusOK, records)
+}
+
+// leiDumpFilters are the GET /lei filters the NDJSON dump takes
+var leiDumpFilters = []string{"search", "q", "status", "category", "country", "legalForm", "lou", "sortBy", "sortOrder"}
+
+// ExportNDJSON streams the LEI dataset as JSON Lines
+// @Summary Dump LEI records as NDJSON
+// @Description Streams every LEI record matching the filters, or the whole dataset without any, as JSON Lines: one record per line, as stored (without the names resolved by GET /lei). Records are read from the database as the client reads the response, so a slow consumer slows the dump instead of buffering it. Takes the filters of GET /lei; limit and offset do not apply. X-Total-Count gives the records matching when the dump started; a response that ends short of it was cut off by an error.
+// @Tags LEI
+// @Produce application/x-ndjson
+// @Param search query string false "Search term (LEI code or legal name)"
+// @Param q query string false "Full-text search on legal name"
+// @Param status query string false "Entity status filter (e.g., ACTIVE, INACTIVE)"
+// @Param category query string false "Entity category filter (e.g., GENERAL, FUND)"
+// @Param country query string false "Country code filter (e.g., US, GB)"
+// @Param lou query string false "Managing LOU filter: the LOU's LEI, or part of its name or marketing name"
+// @Param legalForm query string false "Legal form filter: ELF code, or part of the legal form's name or one of its abbreviations"
+// @Param sortBy query string false "Sort field (lei, legal_name, entity_status, entity_category, legal_address_country, last_update_date)"
+// @Param sortOrder query string false "Sort order (asc, desc)" default(asc)
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/v1/lei/export.ndjson [get]
+func (h *LEIHandler) ExportNDJSON(c *gin.Context) {
+	filters := url.Values{}
+	for _, key := range leiDumpFilters {
+		if value := c.Query(key); value != "" {
+			filters.Set(key, value)
+		}
+	}
+
+	export, err := h.exportService.Prepare(domain.ExportKindLEI, domain.ExportFormatNDJSON, filters)
+	switch {
+	case query.IsValidationError(err):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to prepare LEI export"})
+		return
+	}
+	streamExport(c, h.exportService, export)
+}
+
+// Fuzzy search bounds
+const (
+	fuzzySearchDefaultLimit     = 20
+	fuzzySearchMaxLimit         = 100
+	fuzzySearchDefaultThreshold = 0.3
+)
+
+// FuzzySearchLEI finds LEI records whose legal name is similar to the given name
+// @Summary Fuzzy search LEI records by name
+// @Description Trigram similarity search on legal name (and transliterated name), tolerant of typos and abbreviations. Results are ranked by similarity score.
+// @Tags LEI
+// @Produce json
+// @Param name query string true "Entity name to match (min 3 characters)"
+// @Param threshold query number false "Minimum similarity score between 0.1 and 1.0" default(0.3)
+// @Param country query string false "Country code filter (e.g., DE, GB)"
+// @Param limit query int false "Maximum results (max 100)" default(20)
+// @Success 200 {array} domain.LEIFuzzyMatch
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/search [get]
+func (h *LEIHandler) FuzzySearchLEI(c *gin.Context) {
+	name := strings.TrimSpace(c.Query("name"))
+	if len([]rune(name)) < 3 {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "name must be at least 3 characters"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(fuzzySearchDefaultLimit)))
+	if err != nil || limit < 1 || limit > fuzzySearchMaxLimit {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit parameter (must be 1-100)"})
+		return
+	}
+
+	threshold, err := strconv.ParseFloat(c.DefaultQuery("threshold", strconv.FormatFloat(fuzzySearchDefaultThreshold, 'f', -1, 64)), 64)
+	if err != nil || threshold < 0.1 || threshold > 1 {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid threshold parameter (must be 0.1-1.0)"})
+		return
+	}
+
+	matches, err := h.leiService.FuzzySearchLEI(name, threshold, c.Query("country"), limit)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to search LEI records"})
+		return
+	}
+	for _, match := range matches {
+		h.describe(c.Request.Context(), &match.LEIRecord)
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, matches)
+}
+
+// Entity matching bounds
+const (
+	leiMatchDefaultLimit = 10
+	leiMatchMaxLimit     = 50
+)
+
+// MatchEntity proposes LEIs for an entity from its name and country
+// @Summary Match an entity to candidate LEIs
+// @Description Ranks LEI records by how well their legal (or transliterated) name matches the entity name, to speed up counterparty onboarding. Names are compared after normalization: case, punctuation and trailing legal forms (Ltd, GmbH, S.A., ...) are ignored. Each candidate's score is the mean of its trigram and whole-word similarity; among equal scores ISSUED LEIs rank first.
+// @Tags LEI
+// @Produce json
+// @Param name query string true "Entity name (min 3 characters)"
+// @Param country query string false "ISO 3166-1 alpha-2 country of the entity's legal address (e.g., DE, GB)"
+// @Param limit query int false "Maximum candidates (max 50)" default(10)
+// @Success 200 {array} service.LEIMatchCandidate
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/match [get]
+func (h *LEIHandler) MatchEntity(c *gin.Context) {
+	name := strings.TrimSpace(c.Query("name"))
+	if len([]rune(name)) < 3 {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "name must be at least 3 characters"})
+		return
+	}
+
+	country := strings.TrimSpace(c.Query("country"))
+	if country != "" && len(country) != 2 {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "country must be an ISO 3166-1 alpha-2 code"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(leiMatchDefaultLimit)))
+	if err != nil || limit < 1 || limit > leiMatchMaxLimit {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit parameter (must be 1-50)"})
+		return
+	}
+
+	candidates, err := h.leiService.MatchEntityName(name, country, limit)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to match entity"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, candidates)
+}
+
+// GetChanges lists LEIs created, updated or deleted since a point in time, in the order the changes were made
+// @Summary Get LEI changes since a timestamp or cursor
+// @Description Incremental feed for downstream caches. Start with an RFC 3339 timestamp or date, then pass next_cursor back as since; has_more means another page is ready now. Changes appear a few seconds after they commit so a feed never skips one that committed late.
+// @Tags LEI
+// @Produce json
+// @Param since query string true "RFC 3339 timestamp, date (YYYY-MM-DD) or next_cursor from a previous page"
+// @Param limit query int false "Maximum changes (max 10000)" default(1000)
+// @Success 200 {object} service.LEIChangeFeed
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/changes [get]
+func (h *LEIHandler) GetChanges(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(service.DefaultLEIChangesLimit)))
+	if err != nil || limit < 1 || limit > service.MaxLEIChangesLimit {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit parameter (must be 1-10000)"})
+		return
+	}
+
+	feed, err := h.leiService.GetChanges(c.Query("since"), limit)
+	switch {
+	case errors.Is(err, service.ErrInvalidChangesSince):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve LEI changes"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, feed)
+	}
 }
 
 // GetAuditHistory retrieves audit history for an LEI
@@ -144,11 +417,353 @@ func (h *LEIHandler) GetAuditHistory(c *gin.Context) {
 
 	audits, err := h.leiService.GetAuditHistory(lei, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve audit history"})
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve audit history"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, audits)
+}
+
+// GetAuditVersion reconstructs an LEI record as it stood after one of its audit entries
+// @Summary Get a historical LEI record version
+// @Description Rebuild the record as of an audit entry. Works in both audit modes: with lei.auditmode=diff the version is replayed from the last full snapshot and the changed fields recorded since.
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param lei path string true "LEI code"
+// @Param auditId path string true "Audit entry ID"
+// @Success 200 {object} service.LEIRecordVersion
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/{lei}/audit/{auditId}/version [get]
+func (h *LEIHandler) GetAuditVersion(c *gin.Context) {
+	version, err := h.leiService.GetRecordVersion(c.Param("lei"), c.Param("auditId"))
+	switch {
+	case errors.Is(err, service.ErrAuditEntryNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Audit entry not found for this LEI"})
+	case errors.Is(err, service.ErrLEIVersionUnavailable):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to reconstruct LEI record version"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, version)
+	}
+}
+
+// GetAuditDiff compares an LEI record as it stood after two of its audit entries
+// @Summary Diff two LEI record versions
+// @Description Field-by-field comparison of the record after the from and to audit entries, computed server-side from the audit trail. Bookkeeping fields (IDs, timestamps, source file) are left out.
+// @Tags LEI
+// @Produce json
+// @Param lei path string true "LEI code"
+// @Param from query string true "Audit entry ID of the earlier version"
+// @Param to query string true "Audit entry ID of the later version"
+// @Success 200 {object} service.LEIRecordDiff
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/{lei}/audit/diff [get]
+func (h *LEIHandler) GetAuditDiff(c *gin.Context) {
+	from, to := c.Query("from"), c.Query("to")
+	if from == "" || to == "" {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "from and to audit entry IDs are required"})
+		return
+	}
+
+	diff, err := h.leiService.DiffRecordVersions(c.Param("lei"), from, to)
+	switch {
+	case errors.Is(err, service.ErrAuditEntryNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Audit entry not found for this LEI"})
+	case errors.Is(err, service.ErrLEIVersionUnavailable):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to compare LEI record versions"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, diff)
+	}
+}
+
+// GetReportingExceptions retrieves the reporting exceptions for an LEI
+// @Summary Get LEI reporting exceptions
+// @Description Explains why an entity has no direct or ultimate accounting consolidation parent reported (GLEIF repex), e.g. NATURAL_PERSONS or NON_CONSOLIDATING. An empty list means no exception was filed.
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param lei path string true "LEI code"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/{lei}/exceptions [get]
+func (h *LEIHandler) GetReportingExceptions(c *gin.Context) {
+	lei := strings.ToUpper(c.Param("lei"))
+	if !service.IsValidLEI(lei) {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid LEI format"})
+		return
+	}
+
+	exceptions, err := h.leiService.GetReportingExceptions(lei)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve reporting exceptions"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"lei": lei, "exceptions": exceptions})
+}
+
+// RequeueQuarantinedRequest loads a quarantined record after review
+type RequeueQuarantinedRequest struct {
+	Record        json.RawMessage `json:"record" swaggertype:"object"` // corrected record; omitted loads the snapshot as is
+	OverrideRules bool            `json:"override_rules"`              // load even if the record fails data quality rules
+	Note          string          `json:"note"`
+}
+
+// DismissQuarantinedRequest records why a quarantined record is not loaded
+type DismissQuarantinedRequest struct {
+	Note string `json:"note"`
+}
+
+// ListQuarantinedRecords lists LEI records kept out of lei_records during ingestion
+// @Summary List quarantined LEI records
+// @Description Records rejected by the database (source DATABASE, with the SQLSTATE and error message) or failing data quality rules (source RULES, with the rule codes and reasons), each with a snapshot of the record
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param source_file_id query string false "Only records from this source file"
+// @Param source query string false "DATABASE or RULES"
+// @Param status query string false "PENDING, REQUEUED or DISMISSED"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/quarantine [get]
+func (h *LEIHandler) ListQuarantinedRecords(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	filter := repository.LEIQuarantineFilter{
+		SourceFileID: c.Query("source_file_id"),
+		Source:       strings.ToUpper(c.Query("source")),
+		Status:       strings.ToUpper(c.Query("status")),
+	}
+	records, total, err := h.leiService.ListQuarantinedRecords(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve quarantined records"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  records,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// RequeueQuarantinedRecord loads a quarantined record after review
+// @Summary Requeue quarantined LEI record
+// @Description Loads a PENDING quarantined record (or a corrected version of it) into lei_records. Data quality rules are evaluated again unless override_rules is set; if the record still fails, or the database rejects it, it stays PENDING.
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param id path string true "Quarantined record ID"
+// @Param request body RequeueQuarantinedRequest false "Corrected record and review note"
+// @Success 200 {object} domain.LEIQuarantinedRecord
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Router /api/v1/admin/lei/quarantine/{id}/requeue [post]
+func (h *LEIHandler) RequeueQuarantinedRecord(c *gin.Context) {
+	var req RequeueQuarantinedRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	record, err := h.leiService.RequeueQuarantinedRecord(c.Request.Context(), c.Param("id"), requestEmail(c), service.LEIQuarantineRequeue{
+		Record:        req.Record,
+		OverrideRules: req.OverrideRules,
+		Note:          req.Note,
+	})
+	if err != nil {
+		respondQuarantineError(c, err, "Failed to requeue quarantined record")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, record)
+}
+
+// DismissQuarantinedRecord marks a quarantined record as reviewed and not to be loaded
+// @Summary Dismiss quarantined LEI record
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param id path string true "Quarantined record ID"
+// @Param request body DismissQuarantinedRequest false "Review note"
+// @Success 200 {object} domain.LEIQuarantinedRecord
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/v1/lei/quarantine/{id}/dismiss [post]
+func (h *LEIHandler) DismissQuarantinedRecord(c *gin.Context) {
+	var req DismissQuarantinedRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	record, err := h.leiService.DismissQuarantinedRecord(c.Param("id"), requestEmail(c), req.Note)
+	if err != nil {
+		respondQuarantineError(c, err, "Failed to dismiss quarantined record")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, record)
+}
+
+func respondQuarantineError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Quarantined record not found"})
+	case errors.Is(err, service.ErrQuarantineReviewed):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidQuarantinedRecord):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrQuarantineRulesFailed), errors.Is(err, service.ErrQuarantineLoadFailed):
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// GetQualityTrend returns the data quality of recently loaded files
+// @Summary LEI data quality trend
+// @Description Completeness (populated as % of records) and validity (valid as % of populated) per field for the most recently published source files, newest first
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param file_type query string false "FULL or DELTA"
+// @Param limit query int false "Number of files" default(10)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/quality [get]
+func (h *LEIHandler) GetQualityTrend(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	reports, err := h.leiService.GetQualityTrend(strings.ToUpper(c.Query("file_type")), limit)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve data quality metrics"})
 		return
 	}
 
-	c.JSON(http.StatusOK, audits)
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"files": reports})
+}
+
+// GetSourceFileQuality returns the data quality of one source file's records
+// @Summary LEI source file data quality
+// @Description Completeness and validity per field across the records of a source file, measured while it was loaded
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param id path string true "Source file ID"
+// @Success 200 {object} service.LEIQualityReport
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/source-file/{id}/quality [get]
+func (h *LEIHandler) GetSourceFileQuality(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid source file ID"})
+		return
+	}
+
+	report, err := h.leiService.GetQualityMetrics(id.String())
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Source file not found"})
+	case errors.Is(err, service.ErrNoQualityMetrics):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve data quality metrics"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, report)
+	}
+}
+
+// GetReconciliation returns the latest reconciliation of local record counts against GLEIF
+// @Summary Get the latest LEI reconciliation
+// @Description Local LEI record counts compared with the golden copy record_count (TOTAL) and, if enabled, GLEIF's count per legal address country
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Success 200 {object} service.LEIReconciliationRun
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/reconciliation [get]
+func (h *LEIHandler) GetReconciliation(c *gin.Context) {
+	run, err := h.leiService.GetLatestReconciliation()
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve reconciliation"})
+		return
+	}
+	if run == nil {
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "No reconciliation has run yet"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, run)
+}
+
+// ListReconciliationDiscrepancies lists reconciliation results whose drift exceeded the threshold
+// @Summary List LEI reconciliation discrepancies
+// @Description Reconciliation results, across runs and newest first, whose drift from GLEIF exceeded lei.reconciledriftthreshold
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/reconciliation/discrepancies [get]
+func (h *LEIHandler) ListReconciliationDiscrepancies(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	results, total, err := h.leiService.ListReconciliationDiscrepancies(limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve reconciliation discrepancies"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  results,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// TriggerReconciliation manually triggers a reconciliation against GLEIF record counts
+// @Summary Trigger LEI reconciliation
+// @Description Compare local LEI record counts with GLEIF's now instead of waiting for lei.reconciletime
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Router /api/v1/lei/reconcile [post]
+func (h *LEIHandler) TriggerReconciliation(c *gin.Context) {
+	job := h.jobs.Go(service.JobKindLEIReconciliation, requestEmail(c), "Manual LEI reconciliation", func(*service.Job) error {
+		return h.schedulerService.RunReconciliation()
+	})
+
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Reconciliation triggered", "job_id": job.ID})
 }
 
 // TriggerFullSync manually triggers a full sync
@@ -157,36 +772,97 @@ func (h *LEIHandler) GetAuditHistory(c *gin.Context) {
 // @Tags LEI
 // @Accept json
 // @Produce json
-// @Success 202 {object} map[string]string
+// @Success 202 {object} map[string]interface{}
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/lei/sync/full [post]
 func (h *LEIHandler) TriggerFullSync(c *gin.Context) {
-	go func() {
-		if err := h.schedulerService.RunDailyFullSync(); err != nil {
-			// Log error but don't fail the request
-		}
-	}()
+	job := h.jobs.Go(service.JobKindLEIFullSync, requestEmail(c), "Manual full LEI sync", func(*service.Job) error {
+		return h.schedulerService.RunDailyFullSync()
+	})
 
-	c.JSON(http.StatusAccepted, gin.H{"message": "Full sync triggered"})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Full sync triggered", "job_id": job.ID})
 }
 
 // TriggerDeltaSync manually triggers a delta sync
 // @Summary Trigger delta LEI sync
-// @Description Manually trigger a delta LEI data synchronization
+// @Description Manually trigger a delta LEI data synchronization. Without type the scheduled delta type (lei.deltatype) is used.
 // @Tags LEI
 // @Accept json
 // @Produce json
-// @Success 202 {object} map[string]string
+// @Param type query string false "Delta file type (IntraDay, LastDay, LastWeek, LastMonth)"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/lei/sync/delta [post]
 func (h *LEIHandler) TriggerDeltaSync(c *gin.Context) {
-	go func() {
-		if err := h.schedulerService.RunDailyDeltaSync(); err != nil {
-			// Log error but don't fail the request
+	if deltaTypeParam := c.Query("type"); deltaTypeParam != "" {
+		deltaType, err := service.ParseDeltaType(deltaTypeParam)
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
-	}()
 
-	c.JSON(http.StatusAccepted, gin.H{"message": "Delta sync triggered"})
+		job := h.jobs.Go(service.JobKindLEIDeltaSync, requestEmail(c), "Manual "+deltaType+" delta LEI sync", func(*service.Job) error {
+			return h.schedulerService.RunDeltaSync(deltaType)
+		})
+
+		jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Delta sync triggered", "delta_type": deltaType, "job_id": job.ID})
+		return
+	}
+
+	job := h.jobs.Go(service.JobKindLEIDeltaSync, requestEmail(c), "Manual delta LEI sync", func(*service.Job) error {
+		return h.schedulerService.RunDailyDeltaSync()
+	})
+
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Delta sync triggered", "job_id": job.ID})
+}
+
+// TriggerRepexSync manually triggers a reporting exceptions sync
+// @Summary Trigger reporting exceptions sync
+// @Description Manually download and load the GLEIF reporting exceptions (repex) file. It also runs after every full sync.
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Router /api/v1/lei/sync/repex [post]
+func (h *LEIHandler) TriggerRepexSync(c *gin.Context) {
+	job := h.jobs.Go(service.JobKindLEIRepexSync, requestEmail(c), "Manual reporting exceptions sync", func(*service.Job) error {
+		return h.schedulerService.RunRepexSync()
+	})
+
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Reporting exceptions sync triggered", "job_id": job.ID})
+}
+
+// RefreshLEIsRequest is the request body for a bulk LEI refresh
+type RefreshLEIsRequest struct {
+	LEIs []string `json:"leis" binding:"required,min=1,max=500"`
+}
+
+// RefreshLEIs queues specific LEIs for immediate refresh from the GLEIF API
+// @Summary Refresh specific LEI records
+// @Description Fetch the given LEIs from the GLEIF single-record API (rate-limited, queued) and upsert them without waiting for the next delta
+// @Tags LEI
+// @Accept json
+// @Produce json
+// @Param request body RefreshLEIsRequest true "LEI codes to refresh (max 500)"
+// @Success 202 {object} service.LEIRefreshResult
+// @Failure 400 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/v1/lei/refresh [post]
+func (h *LEIHandler) RefreshLEIs(c *gin.Context) {
+	var req RefreshLEIsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Request must contain 1-500 LEIs in the 'leis' field"})
+		return
+	}
+
+	result := h.leiService.RefreshLEIs(c.Request.Context(), req.LEIs)
+	if len(result.Queued) == 0 {
+		jsonfmt.JSON(c, http.StatusBadRequest, result)
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusAccepted, result)
 }
 
 // GetProcessingStatus retrieves processing status for a job type
@@ -205,11 +881,133 @@ func (h *LEIHandler) GetProcessingStatus(c *gin.Context) {
 
 	status, err := h.leiService.GetProcessingStatus(jobType)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Processing status not found"})
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Processing status not found"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, status)
+}
+
+// progressKeepAliveInterval is how often a comment is sent on an idle progress stream so proxies keep it open
+const progressKeepAliveInterval = 20 * time.Second
+
+// StreamProcessingProgress streams a source file's processing progress as server-sent events
+// @Summary Stream file processing progress
+// @Description Server-sent events with the processing heartbeat of a source file (percent complete, rate, ETA, last LEI). A "progress" event is sent straight away and on every heartbeat; a final "done" event is sent when the file completes or fails, and the stream then ends.
+// @Tags LEI
+// @Produce text/event-stream
+// @Param id path string true "Source file ID"
+// @Success 200 {object} service.SourceFileProgress
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/source-file/{id}/progress [get]
+func (h *LEIHandler) StreamProcessingProgress(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid source file ID"})
+		return
+	}
+
+	updates, cancel, err := h.leiService.WatchProcessingProgress(c.Param("id"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Source file not found"})
+		return
+	}
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to load source file"})
 		return
 	}
+	defer cancel()
 
-	c.JSON(http.StatusOK, status)
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // stop nginx buffering the stream
+
+	keepAlive := time.NewTicker(progressKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case progress, ok := <-updates:
+			if !ok {
+				return false
+			}
+			if progress.Finished() {
+				c.SSEvent("done", progress)
+				return false
+			}
+			c.SSEvent("progress", progress)
+			return true
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+// StatusStream pushes LEI sync status over a websocket, replacing polling of /lei/status/:jobType
+// @Summary Stream LEI sync status
+// @Description WebSocket. On connect the server sends {"type":"snapshot","statuses":[...]} with the processing status of every sync job, then {"type":"processing_status","processing_status":{...}} whenever a job's status or current file changes and {"type":"batch_flushed","batch":{...}} each time a batch of a file being processed is checkpointed. Batch events for a slow client may be dropped.
+// @Tags LEI
+// @Success 101
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/ws [get]
+func (h *LEIHandler) StatusStream(c *gin.Context) {
+	// Subscribe before the snapshot so no transition falls between the two
+	events, unsubscribe := h.leiService.SubscribeStatusEvents()
+	defer unsubscribe()
+
+	statuses, err := h.leiService.ListProcessingStatuses()
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to load processing status"})
+		return
+	}
+
+	conn, err := jobStreamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade has already written the error response
+		log.Ctx(c.Request.Context()).Warn().Err(err).Msg("Status stream upgrade failed")
+		return
+	}
+	defer conn.Close()
+
+	if err := writeJobStreamMessage(conn, gin.H{"type": "snapshot", "statuses": statuses}); err != nil {
+		return
+	}
+
+	// The client sends nothing; reading detects disconnects and handles control frames
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(jobStreamPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeJobStreamMessage(conn, event); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(jobStreamWriteTimeout)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
 }
 
 // ResumeProcessing resumes processing of a source file
@@ -219,7 +1017,7 @@ func (h *LEIHandler) GetProcessingStatus(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Source file ID"
-// @Success 202 {object} map[string]string
+// @Success 202 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/lei/source-file/{id}/resume [post]
@@ -228,15 +1026,14 @@ func (h *LEIHandler) ResumeProcessing(c *gin.Context) {
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid source file ID"})
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid source file ID"})
 		return
 	}
 
-	go func() {
-		if err := h.leiService.ProcessSourceFile(id); err != nil {
-			// Log error but don't fail the request
-		}
-	}()
+	job := h.jobs.Go(service.JobKindLEIFileResume, requestEmail(c), "Resume LEI source file "+id.String(), func(job *service.Job) error {
+		job.SetContext("source_file_id", id.String())
+		return h.leiService.ProcessSourceFile(id)
+	})
 
-	c.JSON(http.StatusAccepted, gin.H{"message": "Processing resumed"})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Processing resumed", "job_id": job.ID})
 }
@@ -0,0 +1,303 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/breaker"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+const testLEI = "5493001KJTIIGC8Y1R12"
+
+// describeLEIRecords expects the records served to have their codes resolved to names
+func describeLEIRecords(h *handlertest.Harness) {
+	h.ELF.On("DescribeLEIRecords", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		for _, record := range args.Get(1).([]*domain.LEIRecord) {
+			record.EntityLegalFormName = "Private limited company"
+		}
+	})
+	h.RA.On("DescribeLEIRecords", mock.Anything, mock.Anything)
+	h.LOU.On("DescribeLEIRecords", mock.Anything, mock.Anything)
+}
+
+func TestLEIGetByCodeDescribesTheLocalRecord(t *testing.T) {
+	h := handlertest.New(t)
+	describeLEIRecords(h)
+	h.LEI.On("GetLEIByCode", testLEI).Return(&domain.LEIRecord{LEI: testLEI, EntityLegalForm: "H0PO"}, nil)
+	h.Router.GET("/lei/:lei", h.Handlers.LEI.GetLEIByCode)
+
+	rec := h.Do(http.MethodGet, "/lei/"+testLEI, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var record domain.LEIRecord
+	h.DecodeJSON(rec, &record)
+	assert.Equal(t, "Private limited company", record.EntityLegalFormName)
+}
+
+func TestLEIGetByCodeFallsBackToGLEIF(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantError      string
+		wantRetryAfter bool
+	}{
+		{name: "fetched", wantStatus: http.StatusOK},
+		{name: "invalid LEI", err: service.ErrInvalidLEI, wantStatus: http.StatusBadRequest, wantError: service.ErrInvalidLEI.Error()},
+		{name: "not at GLEIF either", err: service.ErrLEINotFoundAtGLEIF, wantStatus: http.StatusNotFound, wantError: "LEI record not found locally or at GLEIF"},
+		{name: "breaker open", err: &breaker.OpenError{Name: "gleif", Until: time.Now().Add(30 * time.Second)}, wantStatus: http.StatusServiceUnavailable, wantError: "GLEIF is unavailable, try again later", wantRetryAfter: true},
+		{name: "throttled", err: &service.GLEIFThrottledError{Until: time.Now().Add(30 * time.Second)}, wantStatus: http.StatusTooManyRequests, wantError: "Too many GLEIF lookups, try again later", wantRetryAfter: true},
+		{name: "GLEIF fails", err: errors.New("GLEIF returned 500"), wantStatus: http.StatusBadGateway, wantError: "Failed to fetch LEI from GLEIF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.LEI.On("GetLEIByCode", testLEI).Return(nil, gorm.ErrRecordNotFound)
+			var record *domain.LEIRecord
+			if tt.err == nil {
+				record = &domain.LEIRecord{LEI: testLEI}
+				describeLEIRecords(h)
+			}
+			h.LEI.On("FetchLEIFromGLEIF", mock.Anything, testLEI).Return(record, tt.err)
+			h.Router.GET("/lei/:lei", h.Handlers.LEI.GetLEIByCode)
+
+			rec := h.Do(http.MethodGet, "/lei/"+testLEI+"?refresh=true", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantRetryAfter {
+				retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+				assert.NoError(t, err)
+				assert.InDelta(t, 30, retryAfter, 1)
+			}
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+			}
+		})
+	}
+}
+
+func TestLEIGetByCodeOnlyAsksGLEIFWhenRefreshing(t *testing.T) {
+	h := handlertest.New(t)
+	h.LEI.On("GetLEIByCode", testLEI).Return(nil, gorm.ErrRecordNotFound)
+	h.Router.GET("/lei/:lei", h.Handlers.LEI.GetLEIByCode)
+
+	rec := h.Do(http.MethodGet, "/lei/"+testLEI, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "LEI record not found", body.Error)
+}
+
+func TestLEIGetByCodeAsOf(t *testing.T) {
+	endOfDay := time.Date(2025, 6, 30, 23, 59, 59, 999999999, time.UTC)
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "reconstructed", wantStatus: http.StatusOK},
+		{name: "not recorded yet", err: service.ErrLEINotFoundAsOf, wantStatus: http.StatusNotFound},
+		{name: "no snapshot", err: service.ErrLEIVersionUnavailable, wantStatus: http.StatusConflict},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			var version *service.LEIRecordVersion
+			if tt.err == nil {
+				version = &service.LEIRecordVersion{Action: "UPDATE", Record: &domain.LEIRecord{LEI: testLEI}}
+			}
+			h.LEI.On("GetRecordAsOf", testLEI, endOfDay).Return(version, tt.err)
+			h.Router.GET("/lei/:lei", h.Handlers.LEI.GetLEIByCode)
+
+			rec := h.Do(http.MethodGet, "/lei/"+testLEI+"?asOf=2025-06-30", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestLEIListCapsTheLimit(t *testing.T) {
+	h := handlertest.New(t)
+	describeLEIRecords(h)
+	h.LEI.On("GetAllLEIWithFilters", 501, 0, repository.LEIFilter{Country: "GB", LegalForm: "8Z6G", SortBy: "legal_name"}).
+		Return([]*domain.LEIRecord{{LEI: testLEI}}, nil)
+	h.Router.GET("/lei", h.Handlers.LEI.ListLEI)
+
+	rec := h.Do(http.MethodGet, "/lei?limit=5000&country=GB&legalForm=%208Z6G%20&sortBy=legal_name", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var records []domain.LEIRecord
+	h.DecodeJSON(rec, &records)
+	assert.Equal(t, "Private limited company", records[0].EntityLegalFormName)
+}
+
+func TestLEIListQuarantinedRecordsFilter(t *testing.T) {
+	h := handlertest.New(t)
+	h.LEI.On("ListQuarantinedRecords", repository.LEIQuarantineFilter{SourceFileID: "f1", Source: "RULES", Status: "PENDING"}, 50, 0).
+		Return([]*domain.LEIQuarantinedRecord{{LEI: testLEI, RuleCodes: "LEI_CHECK_DIGITS"}}, int64(1), nil)
+	h.Router.GET("/lei/quarantine", h.Handlers.LEI.ListQuarantinedRecords)
+
+	rec := h.Do(http.MethodGet, "/lei/quarantine?source_file_id=f1&source=rules&status=pending", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.LEIQuarantinedRecord `json:"items"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, "LEI_CHECK_DIGITS", page.Items[0].RuleCodes)
+}
+
+func TestLEIRequeueQuarantinedRecord(t *testing.T) {
+	corrected := json.RawMessage(`{"lei":"5493001KJTIIGC8Y1R12","legal_name":"Acme Holdings Ltd"}`)
+	tests := []struct {
+		name       string
+		body       interface{}
+		requeue    service.LEIQuarantineRequeue
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "snapshot as is", requeue: service.LEIQuarantineRequeue{}, wantStatus: http.StatusOK},
+		{name: "corrected, overriding the rules", body: map[string]interface{}{"record": corrected, "override_rules": true, "note": "Name fixed by hand"}, requeue: service.LEIQuarantineRequeue{Record: corrected, OverrideRules: true, Note: "Name fixed by hand"}, wantStatus: http.StatusOK},
+		{name: "unknown", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Quarantined record not found"},
+		{name: "already reviewed", err: service.ErrQuarantineReviewed, wantStatus: http.StatusConflict, wantError: service.ErrQuarantineReviewed.Error()},
+		{name: "correction not a record", err: service.ErrInvalidQuarantinedRecord, wantStatus: http.StatusBadRequest, wantError: service.ErrInvalidQuarantinedRecord.Error()},
+		{name: "still fails the rules", err: fmt.Errorf("%w: LEI_CHECK_DIGITS", service.ErrQuarantineRulesFailed), wantStatus: http.StatusUnprocessableEntity, wantError: "record still fails data quality rules: LEI_CHECK_DIGITS"},
+		{name: "database rejects it", err: service.ErrQuarantineLoadFailed, wantStatus: http.StatusUnprocessableEntity, wantError: service.ErrQuarantineLoadFailed.Error()},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to requeue quarantined record"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "steward@example.com")
+			var record *domain.LEIQuarantinedRecord
+			if tt.err == nil {
+				record = &domain.LEIQuarantinedRecord{Status: "REQUEUED", ReviewedBy: "steward@example.com"}
+			}
+			h.LEI.On("RequeueQuarantinedRecord", mock.Anything, "q1", "steward@example.com", tt.requeue).Return(record, tt.err)
+			h.Router.POST("/admin/lei/quarantine/:id/requeue", h.Handlers.LEI.RequeueQuarantinedRecord)
+
+			rec := h.Do(http.MethodPost, "/admin/lei/quarantine/q1/requeue", tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var got domain.LEIQuarantinedRecord
+			h.DecodeJSON(rec, &got)
+			assert.Equal(t, "REQUEUED", got.Status)
+		})
+	}
+}
+
+func TestLEIDismissQuarantinedRecord(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "steward@example.com")
+	h.LEI.On("DismissQuarantinedRecord", "q1", "steward@example.com", "Duplicate of a later record").
+		Return(&domain.LEIQuarantinedRecord{Status: "DISMISSED", ReviewNote: "Duplicate of a later record"}, nil)
+	h.Router.POST("/lei/quarantine/:id/dismiss", h.Handlers.LEI.DismissQuarantinedRecord)
+
+	rec := h.Do(http.MethodPost, "/lei/quarantine/q1/dismiss", map[string]string{"note": "Duplicate of a later record"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var record domain.LEIQuarantinedRecord
+	h.DecodeJSON(rec, &record)
+	assert.Equal(t, "DISMISSED", record.Status)
+}
+
+func TestLEISyncTriggersRunJobs(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		run           string
+		args          []interface{}
+		wantKind      string
+		wantDeltaType string
+	}{
+		{name: "full", path: "/lei/sync/full", run: "RunDailyFullSync", wantKind: service.JobKindLEIFullSync},
+		{name: "scheduled delta type", path: "/lei/sync/delta", run: "RunDailyDeltaSync", wantKind: service.JobKindLEIDeltaSync},
+		{name: "delta type given", path: "/lei/sync/delta?type=lastweek", run: "RunDeltaSync", args: []interface{}{service.DeltaTypeLastWeek}, wantKind: service.JobKindLEIDeltaSync, wantDeltaType: service.DeltaTypeLastWeek},
+		{name: "reporting exceptions", path: "/lei/sync/repex", run: "RunRepexSync", wantKind: service.JobKindLEIRepexSync},
+		{name: "reconciliation", path: "/lei/reconcile", run: "RunReconciliation", wantKind: service.JobKindLEIReconciliation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "ops@example.com")
+			h.Scheduler.On(tt.run, tt.args...).Return(nil)
+			h.Router.POST("/lei/sync/full", h.Handlers.LEI.TriggerFullSync)
+			h.Router.POST("/lei/sync/delta", h.Handlers.LEI.TriggerDeltaSync)
+			h.Router.POST("/lei/sync/repex", h.Handlers.LEI.TriggerRepexSync)
+			h.Router.POST("/lei/reconcile", h.Handlers.LEI.TriggerReconciliation)
+
+			rec := h.Do(http.MethodPost, tt.path, nil)
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+			var started struct {
+				jobStarted
+				DeltaType string `json:"delta_type"`
+			}
+			h.DecodeJSON(rec, &started)
+			assert.Equal(t, tt.wantDeltaType, started.DeltaType)
+
+			job := waitForJob(t, h, started.JobID)
+			assert.Equal(t, tt.wantKind, job.Kind)
+			assert.Equal(t, "ops@example.com", job.Owner)
+			assert.Equal(t, service.JobStatusCompleted, job.Status)
+		})
+	}
+}
+
+func TestLEIDeltaSyncRefusesUnknownTypes(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.POST("/lei/sync/delta", h.Handlers.LEI.TriggerDeltaSync)
+
+	rec := h.Do(http.MethodPost, "/lei/sync/delta?type=Yesterday", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, service.ErrInvalidDeltaType.Error(), body.Error)
+	assert.Empty(t, h.Services.Jobs.List())
+}
+
+func TestLEIRefresh(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     *service.LEIRefreshResult
+		wantStatus int
+	}{
+		{name: "queued", result: &service.LEIRefreshResult{Queued: []string{testLEI}, QueueDepth: 1}, wantStatus: http.StatusAccepted},
+		{name: "every LEI rejected", result: &service.LEIRefreshResult{Rejected: map[string]string{"ACME": "invalid LEI format or check digits"}}, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.LEI.On("RefreshLEIs", mock.Anything, []string{testLEI, "ACME"}).Return(tt.result)
+			h.Router.POST("/lei/refresh", h.Handlers.LEI.RefreshLEIs)
+
+			rec := h.Do(http.MethodPost, "/lei/refresh", map[string][]string{"leis": {testLEI, "ACME"}})
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var result service.LEIRefreshResult
+			h.DecodeJSON(rec, &result)
+			assert.Equal(t, *tt.result, result)
+		})
+	}
+
+	h := handlertest.New(t)
+	h.Router.POST("/lei/refresh", h.Handlers.LEI.RefreshLEIs)
+	rec := h.Do(http.MethodPost, "/lei/refresh", map[string][]string{"leis": {}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "Request must contain 1-500 LEIs in the 'leis' field", body.Error)
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// LOUHandler serves and syncs the LEI issuers (Local Operating Units)
+type LOUHandler struct {
+	louService service.LOUService
+	jobs       *service.JobRegistry
+}
+
+// NewLOUHandler creates a new LOU handler
+func NewLOUHandler(louService service.LOUService, jobs *service.JobRegistry) *LOUHandler {
+	return &LOUHandler{louService: louService, jobs: jobs}
+}
+
+// Sync reads GLEIF's LEI issuer list now, instead of waiting for the scheduled sync
+// @Summary Sync the LOUs
+// @Description Reads the LEI issuer list from the GLEIF API and upserts the LOUs on it; LOUs no longer listed are marked as not accredited. Runs in the background; follow it at /admin/tasks.
+// @Tags LEI
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /api/v1/lei/lous/sync [post]
+func (h *LOUHandler) Sync(c *gin.Context) {
+	job := h.jobs.Go(service.JobKindLOUSync, requestEmail(c), "Manual LEI issuer (LOU) sync", func(*service.Job) error {
+		_, err := h.louService.Sync()
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "LOU sync triggered", "job_id": job.ID})
+}
+
+// List lists LOUs
+// @Summary List LOUs
+// @Description LEI issuers (Local Operating Units) in name order, with their accreditation date and whether GLEIF still lists them
+// @Tags LEI
+// @Produce json
+// @Param search query string false "LOU LEI, or part of its name or marketing name"
+// @Param accredited query bool false "Only LOUs GLEIF still lists"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/lous [get]
+func (h *LOUHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	accreditedOnly, _ := strconv.ParseBool(c.Query("accredited"))
+
+	filter := repository.LOUFilter{
+		Search:         strings.TrimSpace(c.Query("search")),
+		AccreditedOnly: accreditedOnly,
+	}
+	lous, total, err := h.louService.List(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve LOUs"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  lous,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Get looks up an LOU by its LEI
+// @Summary Look up an LOU
+// @Description Returns the LEI issuer with the given LEI, as found in LEI records' managing_lou.
+// @Tags LEI
+// @Produce json
+// @Param lei path string true "LOU LEI"
+// @Success 200 {object} domain.LOU
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/lei/lous/{lei} [get]
+func (h *LOUHandler) Get(c *gin.Context) {
+	lou, err := h.louService.Get(c.Param("lei"))
+	switch {
+	case errors.Is(err, service.ErrInvalidLEI):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "LOU not in the LEI issuer list"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to look up LOU"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, lou)
+	}
+}
@@ -0,0 +1,84 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestLOUSyncRunsAsAJob(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "ops@example.com")
+	h.LOU.On("Sync").Return(&service.LOUSyncResult{Listed: 36, Unaccredited: 1}, nil)
+	h.Router.POST("/lei/lous/sync", h.Handlers.LOU.Sync)
+
+	rec := h.Do(http.MethodPost, "/lei/lous/sync", nil)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var started jobStarted
+	h.DecodeJSON(rec, &started)
+	assert.Equal(t, "LOU sync triggered", started.Message)
+
+	job := waitForJob(t, h, started.JobID)
+	assert.Equal(t, service.JobKindLOUSync, job.Kind)
+	assert.Equal(t, "ops@example.com", job.Owner)
+	assert.Equal(t, service.JobStatusCompleted, job.Status)
+}
+
+func TestLOUListFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		filter repository.LOUFilter
+	}{
+		{name: "everything", path: "/lei/lous"},
+		{name: "accredited only", path: "/lei/lous?accredited=true", filter: repository.LOUFilter{AccreditedOnly: true}},
+		{name: "search is trimmed", path: "/lei/lous?search=%20Bloomberg%20&accredited=1", filter: repository.LOUFilter{Search: "Bloomberg", AccreditedOnly: true}},
+		{name: "accredited must be a bool to filter", path: "/lei/lous?accredited=yes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.LOU.On("List", tt.filter, 50, 0).Return([]*domain.LOU{{LEI: "5493001KJTIIGC8Y1R12", Accredited: true}}, int64(1), nil)
+			h.Router.GET("/lei/lous", h.Handlers.LOU.List)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var page struct {
+				Items []domain.LOU `json:"items"`
+			}
+			h.DecodeJSON(rec, &page)
+			assert.Equal(t, "5493001KJTIIGC8Y1R12", page.Items[0].LEI)
+		})
+	}
+}
+
+func TestLOUGet(t *testing.T) {
+	h := handlertest.New(t)
+	h.LOU.On("Get", "5493001KJTIIGC8Y1R12").Return(&domain.LOU{LEI: "5493001KJTIIGC8Y1R12", Name: "Bloomberg Finance L.P."}, nil)
+	h.LOU.On("Get", "5493001KJTIIGC8Y1R13").Return(nil, service.ErrInvalidLEI)
+	h.LOU.On("Get", "529900T8BM49AURSDO55").Return(nil, gorm.ErrRecordNotFound)
+	h.LOU.On("Get", "213800WAVVOPS85N2205").Return(nil, errors.New("connection refused"))
+	h.Router.GET("/lei/lous/:lei", h.Handlers.LOU.Get)
+
+	rec := h.Do(http.MethodGet, "/lei/lous/5493001KJTIIGC8Y1R12", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var lou domain.LOU
+	h.DecodeJSON(rec, &lou)
+	assert.Equal(t, "Bloomberg Finance L.P.", lou.Name)
+
+	rec = h.Do(http.MethodGet, "/lei/lous/5493001KJTIIGC8Y1R13", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "the check digits are wrong")
+	rec = h.Do(http.MethodGet, "/lei/lous/529900T8BM49AURSDO55", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code, "an LEI that is not an issuer's")
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "LOU not in the LEI issuer list", body.Error)
+	assert.Equal(t, http.StatusInternalServerError, h.Do(http.MethodGet, "/lei/lous/213800WAVVOPS85N2205", nil).Code)
+}
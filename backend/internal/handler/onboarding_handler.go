@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// OnboardingHandler takes counterparties through the onboarding workflow
+type OnboardingHandler struct {
+	onboardingService service.OnboardingService
+}
+
+// NewOnboardingHandler creates a new counterparty onboarding handler
+func NewOnboardingHandler(onboardingService service.OnboardingService) *OnboardingHandler {
+	return &OnboardingHandler{onboardingService: onboardingService}
+}
+
+// EnrichOnboardingRequest lets an entity without an LEI skip enrichment, saying why
+type EnrichOnboardingRequest struct {
+	Skip bool   `json:"skip"`
+	Note string `json:"note"`
+}
+
+// OnboardingNoteRequest is the reviewer's note on an approval or rejection
+type OnboardingNoteRequest struct {
+	Note string `json:"note"`
+}
+
+// Create drafts the onboarding of a counterparty
+// @Summary Draft counterparty onboarding
+// @Description Creates the entity with its accounts and SSIs, all inactive until the onboarding is approved. If any of them cannot be created, none are kept.
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Param request body service.OnboardingDraft true "Entity, accounts and SSIs"
+// @Success 201 {object} domain.Onboarding
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Security BearerAuth
+// @Router /onboarding [post]
+func (h *OnboardingHandler) Create(c *gin.Context) {
+	var draft service.OnboardingDraft
+	if err := c.ShouldBindJSON(&draft); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	if strings.TrimSpace(draft.Entity.Name) == "" {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "entity name is required"})
+		return
+	}
+
+	onboarding, err := h.onboardingService.Create(c.Request.Context(), &draft, requestEmail(c))
+	if err != nil {
+		respondWriteError(c, err, "Failed to create onboarding")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusCreated, onboarding)
+}
+
+// List lists counterparty onboardings
+// @Summary List counterparty onboardings
+// @Description Onboardings newest first, with their entity
+// @Tags onboarding
+// @Produce json
+// @Param status query string false "DRAFT, ENRICHED, SSIS_VALIDATED, APPROVED or REJECTED"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /onboarding [get]
+func (h *OnboardingHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	onboardings, total, err := h.onboardingService.List(strings.ToUpper(c.Query("status")), limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve onboardings"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  onboardings,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Get returns an onboarding with its entity and audit trail
+// @Summary Get counterparty onboarding
+// @Description The onboarding with its entity and every action taken on it, oldest first
+// @Tags onboarding
+// @Produce json
+// @Param id path string true "Onboarding ID"
+// @Success 200 {object} domain.Onboarding
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /onboarding/{id} [get]
+func (h *OnboardingHandler) Get(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	onboarding, err := h.onboardingService.Get(c.Param("id"))
+	h.respond(c, onboarding, err, "Failed to retrieve onboarding")
+}
+
+// Enrich fills the onboarding's entity from its LEI record
+// @Summary Enrich onboarding from LEI
+// @Description DRAFT (or ENRICHED) -> ENRICHED. Fills name, legal form and registered address from the entity's LEI record. An entity without an LEI can only move on with skip set, with a note saying why.
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Param id path string true "Onboarding ID"
+// @Param request body EnrichOnboardingRequest false "Skip enrichment for an entity without an LEI"
+// @Success 200 {object} domain.Onboarding
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Security BearerAuth
+// @Router /onboarding/{id}/enrich [post]
+func (h *OnboardingHandler) Enrich(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	var req EnrichOnboardingRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	onboarding, err := h.onboardingService.Enrich(c.Request.Context(), c.Param("id"), requestEmail(c), req.Skip, req.Note)
+	h.respond(c, onboarding, err, "Failed to enrich onboarding")
+}
+
+// ValidateSSIs checks the SSIs of the onboarding's entity
+// @Summary Validate onboarding SSIs
+// @Description ENRICHED (or SSIS_VALIDATED) -> SSIS_VALIDATED. The entity needs at least one SSI, and each needs its beneficiary, a valid IBAN (if the account is one), valid BICs, an active settlement currency and a validity window that has not ended. On failure the onboarding stays where it is and the failures are returned per SSI.
+// @Tags onboarding
+// @Produce json
+// @Param id path string true "Onboarding ID"
+// @Success 200 {object} domain.Onboarding
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /onboarding/{id}/validate-ssis [post]
+func (h *OnboardingHandler) ValidateSSIs(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	onboarding, err := h.onboardingService.ValidateSSIs(c.Request.Context(), c.Param("id"), requestEmail(c))
+	var invalid *service.OnboardingSSIValidationError
+	if errors.As(err, &invalid) {
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": service.ErrOnboardingSSIsInvalid.Error(), "failures": invalid.Failures})
+		return
+	}
+	h.respond(c, onboarding, err, "Failed to validate SSIs")
+}
+
+// Approve activates the onboarded entity, accounts and SSIs
+// @Summary Approve counterparty onboarding
+// @Description SSIS_VALIDATED -> APPROVED. Activates the entity, its accounts and SSIs. The approver must not be the user who drafted the onboarding.
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Param id path string true "Onboarding ID"
+// @Param request body OnboardingNoteRequest false "Approval note"
+// @Success 200 {object} domain.Onboarding
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /onboarding/{id}/approve [post]
+func (h *OnboardingHandler) Approve(c *gin.Context) {
+	req, ok := bindOnboardingNote(c)
+	if !ok {
+		return
+	}
+
+	onboarding, err := h.onboardingService.Approve(c.Request.Context(), c.Param("id"), requestEmail(c), req.Note)
+	h.respond(c, onboarding, err, "Failed to approve onboarding")
+}
+
+// Reject ends an onboarding and deletes what it created
+// @Summary Reject counterparty onboarding
+// @Description Any open status -> REJECTED. Deletes the entity, its accounts and SSIs; the onboarding and its audit trail are kept.
+// @Tags onboarding
+// @Accept json
+// @Produce json
+// @Param id path string true "Onboarding ID"
+// @Param request body OnboardingNoteRequest false "Reason for the rejection"
+// @Success 200 {object} domain.Onboarding
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /onboarding/{id}/reject [post]
+func (h *OnboardingHandler) Reject(c *gin.Context) {
+	req, ok := bindOnboardingNote(c)
+	if !ok {
+		return
+	}
+
+	onboarding, err := h.onboardingService.Reject(c.Request.Context(), c.Param("id"), requestEmail(c), req.Note)
+	h.respond(c, onboarding, err, "Failed to reject onboarding")
+}
+
+// bindOnboardingNote checks the onboarding ID and reads the optional note; false if it responded
+func bindOnboardingNote(c *gin.Context) (OnboardingNoteRequest, bool) {
+	var req OnboardingNoteRequest
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return req, false
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return req, false
+		}
+	}
+	return req, true
+}
+
+// respond maps onboarding service errors to HTTP statuses
+func (h *OnboardingHandler) respond(c *gin.Context, onboarding any, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Onboarding not found"})
+	case errors.Is(err, service.ErrOnboardingTransition), errors.Is(err, repository.ErrOnboardingStatusChanged):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrOnboardingSelfApproval):
+		jsonfmt.JSON(c, http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrEntityHasNoLEI), errors.Is(err, service.ErrLEINotInStore):
+		jsonfmt.JSON(c, http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": message})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, onboarding)
+	}
+}
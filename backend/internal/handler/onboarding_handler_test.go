@@ -0,0 +1,166 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestOnboardingCreateRecordsTheRequester(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "sales@example.com")
+	h.Onboarding.On("Create", mock.Anything, mock.MatchedBy(func(d *service.OnboardingDraft) bool {
+		return d.Entity.Name == "Acme Holdings Ltd" && len(d.Accounts) == 1 && d.Accounts[0].AccountNumber == "ACC-1"
+	}), "sales@example.com").Return(&domain.Onboarding{Status: domain.OnboardingStatusDraft, RequestedBy: "sales@example.com"}, nil)
+	h.Router.POST("/onboarding", h.Handlers.Onboarding.Create)
+
+	rec := h.Do(http.MethodPost, "/onboarding", map[string]interface{}{
+		"entity":   map[string]string{"name": "Acme Holdings Ltd"},
+		"accounts": []map[string]string{{"account_number": "ACC-1"}},
+	})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var onboarding domain.Onboarding
+	h.DecodeJSON(rec, &onboarding)
+	assert.Equal(t, domain.OnboardingStatusDraft, onboarding.Status)
+
+	rec = h.Do(http.MethodPost, "/onboarding", map[string]interface{}{"entity": map[string]string{"name": "  "}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "entity name is required", body.Error)
+}
+
+func TestOnboardingListUppercasesTheStatus(t *testing.T) {
+	h := handlertest.New(t)
+	h.Onboarding.On("List", "SSIS_VALIDATED", 20, 0).Return([]*domain.Onboarding{{Status: domain.OnboardingStatusSSIsValidated}}, int64(1), nil)
+	h.Router.GET("/onboarding", h.Handlers.Onboarding.List)
+
+	rec := h.Do(http.MethodGet, "/onboarding?status=ssis_validated&limit=20", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.Onboarding `json:"items"`
+		Total int64               `json:"total"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, int64(1), page.Total)
+}
+
+func TestOnboardingEnrich(t *testing.T) {
+	id := uuid.New().String()
+	tests := []struct {
+		name       string
+		body       interface{}
+		skip       bool
+		note       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "from the LEI record", wantStatus: http.StatusOK},
+		{name: "skipped with a note", body: map[string]interface{}{"skip": true, "note": "Sole trader, no LEI"}, skip: true, note: "Sole trader, no LEI", wantStatus: http.StatusOK},
+		{name: "no LEI and not skipped", err: service.ErrEntityHasNoLEI, wantStatus: http.StatusUnprocessableEntity, wantError: service.ErrEntityHasNoLEI.Error()},
+		{name: "already approved", err: service.ErrOnboardingTransition, wantStatus: http.StatusConflict, wantError: service.ErrOnboardingTransition.Error()},
+		{name: "moved on concurrently", err: repository.ErrOnboardingStatusChanged, wantStatus: http.StatusConflict, wantError: repository.ErrOnboardingStatusChanged.Error()},
+		{name: "unknown onboarding", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Onboarding not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "ops@example.com")
+			var onboarding *domain.Onboarding
+			if tt.err == nil {
+				onboarding = &domain.Onboarding{Status: domain.OnboardingStatusEnriched}
+			}
+			h.Onboarding.On("Enrich", mock.Anything, id, "ops@example.com", tt.skip, tt.note).Return(onboarding, tt.err)
+			h.Router.POST("/onboarding/:id/enrich", h.Handlers.Onboarding.Enrich)
+
+			rec := h.Do(http.MethodPost, "/onboarding/"+id+"/enrich", tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+			}
+		})
+	}
+}
+
+func TestOnboardingValidateSSIsReturnsTheFailures(t *testing.T) {
+	id := uuid.New().String()
+	h := handlertest.New(t)
+	h.Onboarding.On("ValidateSSIs", mock.Anything, id, "").Return(nil, &service.OnboardingSSIValidationError{
+		Failures: []service.OnboardingSSIFailure{{SSIID: "s1", Problems: []string{"beneficiary BIC DEUT is invalid"}}},
+	})
+	h.Router.POST("/onboarding/:id/validate-ssis", h.Handlers.Onboarding.ValidateSSIs)
+
+	rec := h.Do(http.MethodPost, "/onboarding/"+id+"/validate-ssis", nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var body struct {
+		Error    string                         `json:"error"`
+		Failures []service.OnboardingSSIFailure `json:"failures"`
+	}
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, service.ErrOnboardingSSIsInvalid.Error(), body.Error)
+	assert.Equal(t, []string{"beneficiary BIC DEUT is invalid"}, body.Failures[0].Problems)
+}
+
+func TestOnboardingApproveAndReject(t *testing.T) {
+	id := uuid.New().String()
+	tests := []struct {
+		name       string
+		path       string
+		method     string
+		err        error
+		wantStatus int
+	}{
+		{name: "approved", path: "/onboarding/" + id + "/approve", method: "Approve", wantStatus: http.StatusOK},
+		{name: "approved by the requester", path: "/onboarding/" + id + "/approve", method: "Approve", err: service.ErrOnboardingSelfApproval, wantStatus: http.StatusForbidden},
+		{name: "rejected", path: "/onboarding/" + id + "/reject", method: "Reject", wantStatus: http.StatusOK},
+		{name: "reject fails", path: "/onboarding/" + id + "/reject", method: "Reject", err: errors.New("deadlock detected"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "checker@example.com")
+			var onboarding *domain.Onboarding
+			if tt.err == nil {
+				onboarding = &domain.Onboarding{Status: domain.OnboardingStatusApproved}
+			}
+			h.Onboarding.On(tt.method, mock.Anything, id, "checker@example.com", "Checked against the KYC file").Return(onboarding, tt.err)
+			h.Router.POST("/onboarding/:id/approve", h.Handlers.Onboarding.Approve)
+			h.Router.POST("/onboarding/:id/reject", h.Handlers.Onboarding.Reject)
+
+			rec := h.Do(http.MethodPost, tt.path, map[string]string{"note": "Checked against the KYC file"})
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestOnboardingRefusesInvalidIDs(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.GET("/onboarding/:id", h.Handlers.Onboarding.Get)
+	h.Router.POST("/onboarding/:id/enrich", h.Handlers.Onboarding.Enrich)
+	h.Router.POST("/onboarding/:id/validate-ssis", h.Handlers.Onboarding.ValidateSSIs)
+	h.Router.POST("/onboarding/:id/approve", h.Handlers.Onboarding.Approve)
+
+	for _, path := range []string{"/onboarding/acme", "/onboarding/acme/enrich", "/onboarding/acme/validate-ssis", "/onboarding/acme/approve"} {
+		method := http.MethodPost
+		if path == "/onboarding/acme" {
+			method = http.MethodGet
+		}
+		rec := h.Do(method, path, nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, path)
+		var body errorBody
+		h.DecodeJSON(rec, &body)
+		assert.Equal(t, "Invalid ID format", body.Error, path)
+	}
+}
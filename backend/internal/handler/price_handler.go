@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// PriceHandler handles instrument reference price endpoints
+type PriceHandler struct {
+	priceService service.PriceService
+}
+
+// NewPriceHandler creates a new price handler
+func NewPriceHandler(priceService service.PriceService) *PriceHandler {
+	return &PriceHandler{priceService: priceService}
+}
+
+// ImportPricesRequest is the payload for bulk price import
+type ImportPricesRequest struct {
+	Prices []service.PriceImportRow `json:"prices" binding:"required,min=1,max=10000"`
+}
+
+// SyncPricesRequest is the payload for a market data provider sync
+type SyncPricesRequest struct {
+	Provider string `json:"provider" binding:"required"`
+	Date     string `json:"date"` // YYYY-MM-DD, defaults to today
+}
+
+// ImportPrices bulk-loads reference prices
+// @Summary Bulk import instrument prices
+// @Description Upserts prices by (instrument, date, source). Invalid rows are reported and skipped; valid rows are stored.
+// @Tags prices
+// @Accept json
+// @Produce json
+// @Param request body ImportPricesRequest true "Prices to import"
+// @Success 200 {object} service.PriceImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /instruments/prices/import [post]
+func (h *PriceHandler) ImportPrices(c *gin.Context) {
+	var req ImportPricesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	result, err := h.priceService.ImportPrices(c.Request.Context(), req.Prices)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to import prices"})
+		return
+	}
+
+	if result.Imported == 0 {
+		jsonfmt.JSON(c, http.StatusBadRequest, result)
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, result)
+}
+
+// GetLatestPrice returns the most recent price for an instrument
+// @Summary Latest instrument price
+// @Description Most recent price on or before asOf (default today), optionally from a single source
+// @Tags prices
+// @Produce json
+// @Param id path string true "Instrument ID"
+// @Param source query string false "Price source (e.g. MANUAL or a provider name)"
+// @Param asOf query string false "Date (YYYY-MM-DD)"
+// @Success 200 {object} domain.InstrumentPrice
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /instruments/{id}/prices/latest [get]
+func (h *PriceHandler) GetLatestPrice(c *gin.Context) {
+	instrumentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid instrument ID"})
+		return
+	}
+
+	asOf := time.Now()
+	if value := c.Query("asOf"); value != "" {
+		asOf, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid asOf parameter (expected YYYY-MM-DD)"})
+			return
+		}
+	}
+
+	price, err := h.priceService.GetLatestPrice(instrumentID, c.Query("source"), asOf)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "No price found for instrument"})
+		return
+	}
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve price"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, price)
+}
+
+// ListProviders lists registered market data providers
+// @Summary List price providers
+// @Tags prices
+// @Produce json
+// @Success 200 {object} map[string][]string
+// @Security BearerAuth
+// @Router /instruments/prices/providers [get]
+func (h *PriceHandler) ListProviders(c *gin.Context) {
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"providers": h.priceService.ProviderNames()})
+}
+
+// SyncPrices pulls prices for all active instruments from a registered provider
+// @Summary Sync prices from a provider
+// @Tags prices
+// @Accept json
+// @Produce json
+// @Param request body SyncPricesRequest true "Provider and date"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Security BearerAuth
+// @Router /instruments/prices/sync [post]
+func (h *PriceHandler) SyncPrices(c *gin.Context) {
+	var req SyncPricesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	date := time.Now().Truncate(24 * time.Hour)
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid date (expected YYYY-MM-DD)"})
+			return
+		}
+		date = parsed
+	}
+
+	count, err := h.priceService.SyncFromProvider(c.Request.Context(), req.Provider, date)
+	if errors.Is(err, service.ErrPriceProviderNotFound) {
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{"provider": req.Provider, "date": date.Format("2006-01-02"), "prices": count})
+}
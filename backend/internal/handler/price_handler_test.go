@@ -0,0 +1,147 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestPriceImport(t *testing.T) {
+	rows := []service.PriceImportRow{
+		{InstrumentID: uuid.New().String(), PriceDate: "2026-03-02", Price: 101.5, Currency: "EUR"},
+		{InstrumentID: "acme", PriceDate: "2026-03-02", Price: 99, Currency: "EUR"},
+	}
+	tests := []struct {
+		name       string
+		result     *service.PriceImportResult
+		err        error
+		wantStatus int
+	}{
+		{name: "some rows imported", result: &service.PriceImportResult{Imported: 1, Rejected: []service.PriceImportRejection{{Row: 1, Error: "invalid instrument_id"}}}, wantStatus: http.StatusOK},
+		{name: "every row rejected", result: &service.PriceImportResult{Rejected: []service.PriceImportRejection{{Row: 0, Error: "unknown instrument"}, {Row: 1, Error: "invalid instrument_id"}}}, wantStatus: http.StatusBadRequest},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.Price.On("ImportPrices", mock.Anything, rows).Return(tt.result, tt.err)
+			h.Router.POST("/instruments/prices/import", h.Handlers.Price.ImportPrices)
+
+			rec := h.Do(http.MethodPost, "/instruments/prices/import", map[string]interface{}{"prices": rows})
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.result != nil {
+				var result service.PriceImportResult
+				h.DecodeJSON(rec, &result)
+				assert.Equal(t, *tt.result, result)
+			}
+		})
+	}
+}
+
+func TestPriceImportNeedsRows(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.POST("/instruments/prices/import", h.Handlers.Price.ImportPrices)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPost, "/instruments/prices/import", map[string]interface{}{"prices": []service.PriceImportRow{}}).Code)
+}
+
+func TestPriceGetLatest(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name       string
+		path       string
+		source     string
+		asOf       interface{}
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "today, any source", path: "/instruments/" + id.String() + "/prices/latest", asOf: mock.AnythingOfType("time.Time"), wantStatus: http.StatusOK},
+		{name: "as of a date from a source", path: "/instruments/" + id.String() + "/prices/latest?source=MANUAL&asOf=2026-03-02", source: "MANUAL", asOf: date("2026-03-02"), wantStatus: http.StatusOK},
+		{name: "no price", path: "/instruments/" + id.String() + "/prices/latest?asOf=2026-03-02", asOf: date("2026-03-02"), err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "No price found for instrument"},
+		{name: "malformed date", path: "/instruments/" + id.String() + "/prices/latest?asOf=yesterday", wantStatus: http.StatusBadRequest, wantError: "Invalid asOf parameter (expected YYYY-MM-DD)"},
+		{name: "malformed instrument", path: "/instruments/acme/prices/latest", wantStatus: http.StatusBadRequest, wantError: "Invalid instrument ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.asOf != nil {
+				var price *domain.InstrumentPrice
+				if tt.err == nil {
+					price = &domain.InstrumentPrice{InstrumentID: id, Price: 101.5, Source: "MANUAL"}
+				}
+				h.Price.On("GetLatestPrice", id, tt.source, tt.asOf).Return(price, tt.err)
+			}
+			h.Router.GET("/instruments/:id/prices/latest", h.Handlers.Price.GetLatestPrice)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var price domain.InstrumentPrice
+			h.DecodeJSON(rec, &price)
+			assert.Equal(t, 101.5, price.Price)
+		})
+	}
+}
+
+func TestPriceSync(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       map[string]string
+		date       interface{}
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "a given date", body: map[string]string{"provider": "acme-feed", "date": "2026-03-02"}, date: date("2026-03-02"), wantStatus: http.StatusOK},
+		{name: "today by default", body: map[string]string{"provider": "acme-feed"}, date: mock.AnythingOfType("time.Time"), wantStatus: http.StatusOK},
+		{name: "unknown provider", body: map[string]string{"provider": "acme-feed", "date": "2026-03-02"}, date: date("2026-03-02"), err: fmt.Errorf("%w: acme-feed", service.ErrPriceProviderNotFound), wantStatus: http.StatusNotFound, wantError: "price provider not registered: acme-feed"},
+		{name: "provider fails", body: map[string]string{"provider": "acme-feed", "date": "2026-03-02"}, date: date("2026-03-02"), err: errors.New("acme-feed: 503 Service Unavailable"), wantStatus: http.StatusBadGateway, wantError: "acme-feed: 503 Service Unavailable"},
+		{name: "malformed date", body: map[string]string{"provider": "acme-feed", "date": "02/03/2026"}, wantStatus: http.StatusBadRequest, wantError: "Invalid date (expected YYYY-MM-DD)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.date != nil {
+				h.Price.On("SyncFromProvider", mock.Anything, "acme-feed", tt.date).Return(12, tt.err)
+			}
+			h.Router.POST("/instruments/prices/sync", h.Handlers.Price.SyncPrices)
+
+			rec := h.Do(http.MethodPost, "/instruments/prices/sync", tt.body)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var body struct {
+				Provider string `json:"provider"`
+				Date     string `json:"date"`
+				Prices   int    `json:"prices"`
+			}
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, 12, body.Prices)
+			if tt.body["date"] != "" {
+				assert.Equal(t, tt.body["date"], body.Date)
+			} else {
+				assert.Equal(t, time.Now().Truncate(24*time.Hour).Format(time.DateOnly), body.Date)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// RegistrationAuthorityHandler serves and imports the GLEIF Registration Authorities list
+type RegistrationAuthorityHandler struct {
+	raService service.RegistrationAuthorityService
+	jobs      *service.JobRegistry
+}
+
+// NewRegistrationAuthorityHandler creates a new registration authority handler
+func NewRegistrationAuthorityHandler(raService service.RegistrationAuthorityService, jobs *service.JobRegistry) *RegistrationAuthorityHandler {
+	return &RegistrationAuthorityHandler{raService: raService, jobs: jobs}
+}
+
+// Import loads an RA list file
+// @Summary Import the RA list
+// @Description Loads the GLEIF Registration Authorities list as published in CSV (comma, semicolon or tab separated), sent as the multipart field "file" or as the request body. The header row must name the RA code column and the international or local register name column. Registers are upserted by RA code and invalid rows reported. LEI records are then returned with the name and jurisdiction of their registration authority.
+// @Tags LEI
+// @Accept text/csv
+// @Accept mpfd
+// @Produce json
+// @Param file formData file false "RA list file"
+// @Success 200 {object} service.RAImportResult
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/v1/lei/registration-authorities/import [post]
+func (h *RegistrationAuthorityHandler) Import(c *gin.Context) {
+	file, err := uploadedFile(c)
+	if err != nil {
+		respondUploadError(c, err)
+		return
+	}
+
+	result, err := h.raService.Import(file)
+	switch {
+	case errors.Is(err, service.ErrInvalidRAFile):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case isBodyTooLarge(err):
+		respondTooLarge(c, err)
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to import RA list"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, result)
+	}
+}
+
+// Refresh downloads and imports the RA list now, instead of waiting for its scheduled refresh
+// @Summary Refresh the RA list
+// @Description Downloads the RA list from lei.ralisturl and imports it. Runs in the background; follow it at /admin/tasks.
+// @Tags LEI
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /api/v1/lei/registration-authorities/refresh [post]
+func (h *RegistrationAuthorityHandler) Refresh(c *gin.Context) {
+	if !h.raService.RefreshEnabled() {
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrRARefreshDisabled.Error()})
+		return
+	}
+	job := h.jobs.Go(service.JobKindRARefresh, requestEmail(c), "Manual RA list refresh", func(*service.Job) error {
+		_, err := h.raService.Refresh()
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "RA list refresh triggered", "job_id": job.ID})
+}
+
+// List lists registration authorities
+// @Summary List registration authorities
+// @Description Business registers of the GLEIF RA list in RA code order
+// @Tags LEI
+// @Produce json
+// @Param country query string false "ISO 3166-1 alpha-2 country code"
+// @Param jurisdiction query string false "Part of the jurisdiction (country or region the register covers)"
+// @Param search query string false "RA code, or part of the register or organization name"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/registration-authorities [get]
+func (h *RegistrationAuthorityHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	filter := repository.RegistrationAuthorityFilter{
+		Country:      strings.ToUpper(c.Query("country")),
+		Jurisdiction: strings.TrimSpace(c.Query("jurisdiction")),
+		Search:       strings.TrimSpace(c.Query("search")),
+	}
+	authorities, total, err := h.raService.List(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve registration authorities"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  authorities,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Get looks up a registration authority
+// @Summary Look up a registration authority
+// @Description Returns the business register of an RA code.
+// @Tags LEI
+// @Produce json
+// @Param code path string true "RA code (e.g., RA000665)"
+// @Success 200 {object} domain.RegistrationAuthority
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/v1/lei/registration-authorities/{code} [get]
+func (h *RegistrationAuthorityHandler) Get(c *gin.Context) {
+	authority, err := h.raService.Get(c.Param("code"))
+	switch {
+	case errors.Is(err, service.ErrInvalidRACode):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "Registration authority not in the RA list"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to look up registration authority"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, authority)
+	}
+}
@@ -0,0 +1,129 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestRegistrationAuthorityImportErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "invalid file", err: fmt.Errorf("%w: missing RA Code column", service.ErrInvalidRAFile), wantStatus: http.StatusBadRequest, wantError: "invalid RA list file: missing RA Code column"},
+		{name: "body over the limit", err: &http.MaxBytesError{Limit: 4096}, wantStatus: http.StatusRequestEntityTooLarge, wantError: "Request body exceeds the limit of 4096 bytes"},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to import RA list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.RA.On("Import", mock.Anything).Return(nil, tt.err)
+			h.Router.POST("/lei/registration-authorities/import", h.Handlers.RA.Import)
+
+			rec := doUpload(t, h, "/lei/registration-authorities/import", nil, "ra.csv", "RA Code\nRA000665\n")
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestRegistrationAuthorityRefresh(t *testing.T) {
+	h := handlertest.New(t)
+	asUser(h, "ops@example.com")
+	h.RA.On("RefreshEnabled").Return(true)
+	h.RA.On("Refresh").Return(&service.RAImportResult{Imported: 1000}, nil)
+	h.Router.POST("/lei/registration-authorities/refresh", h.Handlers.RA.Refresh)
+
+	rec := h.Do(http.MethodPost, "/lei/registration-authorities/refresh", nil)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var started jobStarted
+	h.DecodeJSON(rec, &started)
+
+	job := waitForJob(t, h, started.JobID)
+	assert.Equal(t, service.JobKindRARefresh, job.Kind)
+	assert.Equal(t, "ops@example.com", job.Owner)
+	assert.Equal(t, service.JobStatusCompleted, job.Status)
+}
+
+func TestRegistrationAuthorityRefreshDisabled(t *testing.T) {
+	h := handlertest.New(t)
+	h.RA.On("RefreshEnabled").Return(false)
+	h.Router.POST("/lei/registration-authorities/refresh", h.Handlers.RA.Refresh)
+
+	rec := h.Do(http.MethodPost, "/lei/registration-authorities/refresh", nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, service.ErrRARefreshDisabled.Error(), body.Error)
+}
+
+func TestRegistrationAuthorityListFilter(t *testing.T) {
+	h := handlertest.New(t)
+	h.RA.On("List", repository.RegistrationAuthorityFilter{Country: "GB", Jurisdiction: "Scotland", Search: "Companies House"}, 100, 200).
+		Return([]*domain.RegistrationAuthority{{Code: "RA000585", Jurisdiction: "Scotland"}}, int64(201), nil)
+	h.Router.GET("/lei/registration-authorities", h.Handlers.RA.List)
+
+	rec := h.Do(http.MethodGet, "/lei/registration-authorities?country=gb&jurisdiction=Scotland+&search=+Companies+House&limit=100&offset=200", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items  []domain.RegistrationAuthority `json:"items"`
+		Total  int64                          `json:"total"`
+		Offset int                            `json:"offset"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, "RA000585", page.Items[0].Code)
+	assert.Equal(t, int64(201), page.Total)
+	assert.Equal(t, 200, page.Offset)
+}
+
+func TestRegistrationAuthorityGet(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "found", code: "RA000665", wantStatus: http.StatusOK},
+		{name: "malformed", code: "RA66", err: service.ErrInvalidRACode, wantStatus: http.StatusBadRequest, wantError: service.ErrInvalidRACode.Error()},
+		{name: "not in the list", code: "RA999999", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Registration authority not in the RA list"},
+		{name: "lookup fails", code: "RA000665", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to look up registration authority"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			var authority *domain.RegistrationAuthority
+			if tt.err == nil {
+				authority = &domain.RegistrationAuthority{Code: tt.code, RegisterName: "Handelsregister"}
+			}
+			h.RA.On("Get", tt.code).Return(authority, tt.err)
+			h.Router.GET("/lei/registration-authorities/:code", h.Handlers.RA.Get)
+
+			rec := h.Do(http.MethodGet, "/lei/registration-authorities/"+tt.code, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var got domain.RegistrationAuthority
+			h.DecodeJSON(rec, &got)
+			assert.Equal(t, "Handelsregister", got.RegisterName)
+		})
+	}
+}
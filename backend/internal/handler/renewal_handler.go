@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+// RenewalHandler serves the report of LEIs due for renewal
+type RenewalHandler struct {
+	renewalService service.RenewalReportService
+	jobs           *service.JobRegistry
+}
+
+// NewRenewalHandler creates a new renewal report handler
+func NewRenewalHandler(renewalService service.RenewalReportService, jobs *service.JobRegistry) *RenewalHandler {
+	return &RenewalHandler{renewalService: renewalService, jobs: jobs}
+}
+
+// List lists LEIs whose next renewal date falls within the given number of days
+// @Summary List LEIs due for renewal
+// @Description LEIs with a next renewal date from today up to within_days ahead, soonest first. format=csv downloads every match as CSV (ignoring limit and offset).
+// @Tags LEI
+// @Produce json
+// @Produce text/csv
+// @Param within_days query int false "Days ahead (1-366)" default(30)
+// @Param country query string false "Comma-separated legal address countries (e.g., DE,GB)"
+// @Param lou query string false "Comma-separated managing LOU LEIs"
+// @Param format query string false "json (default) or csv"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} service.RenewalsDue
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/lei/renewals [get]
+func (h *RenewalHandler) List(c *gin.Context) {
+	withinDays, err := strconv.Atoi(c.DefaultQuery("within_days", strconv.Itoa(service.DefaultRenewalWithinDays)))
+	if err != nil || withinDays < 1 || withinDays > service.MaxRenewalWithinDays {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid within_days parameter (must be 1-366)"})
+		return
+	}
+	filter := service.RenewalFilter{
+		WithinDays:   withinDays,
+		Countries:    splitQueryList(c.Query("country")),
+		ManagingLOUs: splitQueryList(c.Query("lou")),
+	}
+
+	if strings.EqualFold(c.Query("format"), "csv") {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="lei-renewals-%s.csv"`, time.Now().UTC().Format(time.DateOnly)))
+		c.Status(http.StatusOK)
+		if _, err := h.renewalService.WriteDueCSV(c.Writer, filter); err != nil {
+			// Headers are sent; all that can be done is to cut the download short
+			log.Ctx(c.Request.Context()).Error().Err(err).Msg("Failed to write renewal report CSV")
+		}
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	due, err := h.renewalService.FindDue(filter, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve LEIs due for renewal"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, due)
+}
+
+// Run sends the configured renewal report now, instead of waiting for its scheduled run
+// @Summary Send the renewal report
+// @Description Builds the report configured under renewalreport and emails it to renewalreport.emailto. Runs in the background; follow it at /admin/tasks.
+// @Tags admin
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Failure 409 {object} map[string]string
+// @Security BearerAuth
+// @Router /admin/reports/renewals/run [post]
+func (h *RenewalHandler) Run(c *gin.Context) {
+	if !h.renewalService.Enabled() {
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrRenewalReportDisabled.Error()})
+		return
+	}
+	job := h.jobs.Go(service.JobKindRenewalReport, requestEmail(c), "Manual LEI renewal report", func(*service.Job) error {
+		_, err := h.renewalService.SendReport()
+		return err
+	})
+	jsonfmt.JSON(c, http.StatusAccepted, gin.H{"message": "Renewal report triggered", "job_id": job.ID})
+}
+
+// splitQueryList splits a comma-separated query value, dropping empty items
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
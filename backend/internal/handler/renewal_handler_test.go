@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+func TestRenewalList(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		filter     *service.RenewalFilter
+		limit      int
+		offset     int
+		wantStatus int
+	}{
+		{name: "defaults", path: "/lei/renewals", filter: &service.RenewalFilter{WithinDays: 30}, limit: 50, wantStatus: http.StatusOK},
+		{name: "lists are split and trimmed", path: "/lei/renewals?within_days=90&country=DE,%20GB,&lou=5299000J2N45DDNE4Y28&limit=10&offset=10", filter: &service.RenewalFilter{WithinDays: 90, Countries: []string{"DE", "GB"}, ManagingLOUs: []string{"5299000J2N45DDNE4Y28"}}, limit: 10, offset: 10, wantStatus: http.StatusOK},
+		{name: "zero days", path: "/lei/renewals?within_days=0", wantStatus: http.StatusBadRequest},
+		{name: "over a year", path: "/lei/renewals?within_days=367", wantStatus: http.StatusBadRequest},
+		{name: "not a number", path: "/lei/renewals?within_days=month", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.filter != nil {
+				h.Renewals.On("FindDue", *tt.filter, tt.limit, tt.offset).
+					Return(&service.RenewalsDue{Total: 1, Records: []*domain.LEIRecord{{LEI: "5493001KJTIIGC8Y1R12"}}}, nil)
+			}
+			h.Router.GET("/lei/renewals", h.Handlers.Renewal.List)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusBadRequest {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, "Invalid within_days parameter (must be 1-366)", body.Error)
+				return
+			}
+			var due service.RenewalsDue
+			h.DecodeJSON(rec, &due)
+			assert.Equal(t, "5493001KJTIIGC8Y1R12", due.Records[0].LEI)
+		})
+	}
+}
+
+func TestRenewalListDownloadsCSV(t *testing.T) {
+	h := handlertest.New(t)
+	h.Renewals.On("WriteDueCSV", mock.Anything, service.RenewalFilter{WithinDays: 14, Countries: []string{"FR"}}).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(0).(io.Writer), "lei,next_renewal_date\n969500UP76J52A9OXU27,2026-10-20\n")
+		}).Return(1, nil)
+	h.Router.GET("/lei/renewals", h.Handlers.Renewal.List)
+
+	rec := h.Do(http.MethodGet, "/lei/renewals?within_days=14&country=FR&format=CSV&limit=1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Disposition"), `attachment; filename="lei-renewals-`))
+	assert.Equal(t, "lei,next_renewal_date\n969500UP76J52A9OXU27,2026-10-20\n", rec.Body.String())
+}
+
+func TestRenewalRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		err        error
+		wantStatus string
+	}{
+		{name: "sent", enabled: true, wantStatus: service.JobStatusCompleted},
+		{name: "mail fails", enabled: true, err: errors.New("smtp: connection refused"), wantStatus: service.JobStatusFailed},
+		{name: "disabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			asUser(h, "ops@example.com")
+			h.Renewals.On("Enabled").Return(tt.enabled)
+			if tt.enabled {
+				h.Renewals.On("SendReport").Return(&service.RenewalReportResult{}, tt.err)
+			}
+			h.Router.POST("/admin/reports/renewals/run", h.Handlers.Renewal.Run)
+
+			rec := h.Do(http.MethodPost, "/admin/reports/renewals/run", nil)
+			if !tt.enabled {
+				assert.Equal(t, http.StatusConflict, rec.Code)
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, service.ErrRenewalReportDisabled.Error(), body.Error)
+				return
+			}
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+			var started jobStarted
+			h.DecodeJSON(rec, &started)
+			job := waitForJob(t, h, started.JobID)
+			assert.Equal(t, service.JobKindRenewalReport, job.Kind)
+			assert.Equal(t, "ops@example.com", job.Owner)
+			assert.Equal(t, tt.wantStatus, job.Status)
+		})
+	}
+}
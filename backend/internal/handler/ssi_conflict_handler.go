@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+)
+
+// Conflicts reports overlapping SSIs
+// @Summary List conflicting SSIs
+// @Description Pairs of active SSIs for the same entity, settlement currency, instrument and settlement type whose validity windows overlap, with the overlap. Such pairs make the SSI for a settlement ambiguous; they predate the overlap check, were saved under the flag policy or were activated together by an onboarding approval.
+// @Tags ssis
+// @Produce json
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/conflicts [get]
+func (h *SSIHandler) Conflicts(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	conflicts, total, err := h.service.ListConflicts(limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve SSI conflicts"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  conflicts,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
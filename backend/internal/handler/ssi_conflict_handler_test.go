@@ -0,0 +1,29 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+)
+
+func TestSSIConflicts(t *testing.T) {
+	h := handlertest.New(t)
+	overlap := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.SSI.On("ListConflicts", 20, 40).Return([]*domain.SSIConflict{{SSI: &domain.SSI{}, Other: &domain.SSI{}, OverlapFrom: overlap}}, int64(41), nil)
+	h.Router.GET("/ssis/conflicts", h.Handlers.SSI.Conflicts)
+
+	rec := h.Do(http.MethodGet, "/ssis/conflicts?limit=20&offset=40", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.SSIConflict `json:"items"`
+		Total int64                `json:"total"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, overlap, page.Items[0].OverlapFrom)
+	assert.Nil(t, page.Items[0].OverlapTo, "both windows open-ended")
+	assert.Equal(t, int64(41), page.Total)
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// SSIDefaultsHandler maintains the SSI defaults by market and currency
+type SSIDefaultsHandler struct {
+	defaultsService service.SSIDefaultsService
+}
+
+// NewSSIDefaultsHandler creates a new SSI defaults handler
+func NewSSIDefaultsHandler(defaultsService service.SSIDefaultsService) *SSIDefaultsHandler {
+	return &SSIDefaultsHandler{defaultsService: defaultsService}
+}
+
+// List lists the SSI default rules
+// @Summary List SSI default rules
+// @Description Default place of settlement, settlement type and cutoff by market (instrument primary exchange) and/or settlement currency, by market then currency
+// @Tags ssis
+// @Produce json
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/defaults [get]
+func (h *SSIDefaultsHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rules, total, err := h.defaultsService.List(limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve SSI default rules"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  rules,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// Get returns an SSI default rule
+// @Summary Get SSI default rule
+// @Tags ssis
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Success 200 {object} domain.SSIDefaultRule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/defaults/{id} [get]
+func (h *SSIDefaultsHandler) Get(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	rule, err := h.defaultsService.Get(c.Param("id"))
+	h.respond(c, http.StatusOK, rule, err, "Failed to retrieve SSI default rule")
+}
+
+// Create adds an SSI default rule
+// @Summary Create SSI default rule
+// @Description A rule is scoped to a market, a settlement currency or both, one rule per scope, and sets at least one of place_of_settlement (a BIC), settlement_type and cutoff_time (HH:MM, in cutoff_timezone, UTC if unset)
+// @Tags ssis
+// @Accept json
+// @Produce json
+// @Param rule body domain.SSIDefaultRule true "Rule"
+// @Success 201 {object} domain.SSIDefaultRule
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/defaults [post]
+func (h *SSIDefaultsHandler) Create(c *gin.Context) {
+	var rule domain.SSIDefaultRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	rule.ID = uuid.Nil
+
+	err := h.defaultsService.Create(&rule)
+	h.respond(c, http.StatusCreated, &rule, err, "Failed to create SSI default rule")
+}
+
+// Update replaces an SSI default rule
+// @Summary Update SSI default rule
+// @Description Existing SSIs keep the defaults they were created with
+// @Tags ssis
+// @Accept json
+// @Produce json
+// @Param id path string true "Rule ID"
+// @Param rule body domain.SSIDefaultRule true "Rule"
+// @Success 200 {object} domain.SSIDefaultRule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/defaults/{id} [put]
+func (h *SSIDefaultsHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+	var rule domain.SSIDefaultRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	rule.ID = id
+
+	err = h.defaultsService.Update(&rule)
+	h.respond(c, http.StatusOK, &rule, err, "Failed to update SSI default rule")
+}
+
+// Delete removes an SSI default rule
+// @Summary Delete SSI default rule
+// @Description Existing SSIs keep the defaults they were created with
+// @Tags ssis
+// @Param id path string true "Rule ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/defaults/{id} [delete]
+func (h *SSIDefaultsHandler) Delete(c *gin.Context) {
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if err := h.defaultsService.Delete(c.Param("id")); err != nil {
+		h.respond(c, 0, nil, err, "Failed to delete SSI default rule")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Preview shows the defaults creating an SSI would fill in
+// @Summary Preview SSI defaults
+// @Description Finds the most specific active rule for the SSI's instrument market and settlement currency (market and currency, then market, then currency) and returns the fields it would fill, those the SSI leaves empty, and the SSI as it would be created. Nothing is saved.
+// @Tags ssis
+// @Accept json
+// @Produce json
+// @Param ssi body domain.SSI true "SSI to create"
+// @Success 200 {object} service.SSIDefaultsPreview
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/defaults/preview [post]
+func (h *SSIDefaultsHandler) Preview(c *gin.Context) {
+	var ssi domain.SSI
+	if err := c.ShouldBindJSON(&ssi); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	preview, err := h.defaultsService.Preview(&ssi)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to preview SSI defaults"})
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, preview)
+}
+
+// respond maps SSI default rule errors to HTTP statuses
+func (h *SSIDefaultsHandler) respond(c *gin.Context, status int, body any, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "SSI default rule not found"})
+	case errors.Is(err, service.ErrInvalidSSIDefaultRule):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSSIDefaultRuleExists):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		respondWriteError(c, err, message)
+	default:
+		jsonfmt.JSON(c, status, body)
+	}
+}
@@ -0,0 +1,131 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestSSIDefaultsCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "created", wantStatus: http.StatusCreated},
+		{name: "invalid", err: fmt.Errorf("%w: cutoff_time must be HH:MM", service.ErrInvalidSSIDefaultRule), wantStatus: http.StatusBadRequest, wantError: "invalid SSI default rule: cutoff_time must be HH:MM"},
+		{name: "scope taken", err: service.ErrSSIDefaultRuleExists, wantStatus: http.StatusConflict, wantError: service.ErrSSIDefaultRuleExists.Error()},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to create SSI default rule"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.SSIDefaults.On("Create", mock.MatchedBy(func(r *domain.SSIDefaultRule) bool {
+				return r.ID == uuid.Nil && r.Market != nil && *r.Market == "XLON" && r.PlaceOfSettlement == "CRSTGB22"
+			})).Return(tt.err)
+			h.Router.POST("/ssis/defaults", h.Handlers.SSIDefaults.Create)
+
+			rec := h.Do(http.MethodPost, "/ssis/defaults", map[string]string{"id": uuid.New().String(), "market": "XLON", "place_of_settlement": "CRSTGB22", "cutoff_time": "16:30"})
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var rule domain.SSIDefaultRule
+			h.DecodeJSON(rec, &rule)
+			assert.Equal(t, "16:30", rule.CutoffTime)
+		})
+	}
+}
+
+func TestSSIDefaultsUpdateUsesThePathID(t *testing.T) {
+	id := uuid.New()
+	h := handlertest.New(t)
+	h.SSIDefaults.On("Update", mock.MatchedBy(func(r *domain.SSIDefaultRule) bool {
+		return r.ID == id && r.SettlementType == "DVP"
+	})).Return(nil).Once()
+	h.SSIDefaults.On("Update", mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+	h.Router.PUT("/ssis/defaults/:id", h.Handlers.SSIDefaults.Update)
+
+	rec := h.Do(http.MethodPut, "/ssis/defaults/"+id.String(), map[string]string{"id": uuid.New().String(), "settlement_type": "DVP"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var rule domain.SSIDefaultRule
+	h.DecodeJSON(rec, &rule)
+	assert.Equal(t, id, rule.ID)
+
+	rec = h.Do(http.MethodPut, "/ssis/defaults/"+id.String(), map[string]string{"settlement_type": "DVP"})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "SSI default rule not found", body.Error)
+}
+
+func TestSSIDefaultsRefuseInvalidIDs(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.GET("/ssis/defaults/:id", h.Handlers.SSIDefaults.Get)
+	h.Router.PUT("/ssis/defaults/:id", h.Handlers.SSIDefaults.Update)
+	h.Router.DELETE("/ssis/defaults/:id", h.Handlers.SSIDefaults.Delete)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodGet, "/ssis/defaults/xlon", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPut, "/ssis/defaults/xlon", map[string]string{}).Code)
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodDelete, "/ssis/defaults/xlon", nil).Code)
+}
+
+func TestSSIDefaultsDelete(t *testing.T) {
+	kept, missing := uuid.New().String(), uuid.New().String()
+	h := handlertest.New(t)
+	h.SSIDefaults.On("Delete", kept).Return(nil)
+	h.SSIDefaults.On("Delete", missing).Return(gorm.ErrRecordNotFound)
+	h.Router.DELETE("/ssis/defaults/:id", h.Handlers.SSIDefaults.Delete)
+
+	assert.Equal(t, http.StatusNoContent, h.Do(http.MethodDelete, "/ssis/defaults/"+kept, nil).Code)
+	assert.Equal(t, http.StatusNotFound, h.Do(http.MethodDelete, "/ssis/defaults/"+missing, nil).Code)
+}
+
+func TestSSIDefaultsPreview(t *testing.T) {
+	h := handlertest.New(t)
+	market := "XLON"
+	h.SSIDefaults.On("Preview", mock.MatchedBy(func(s *domain.SSI) bool {
+		return s.BeneficiaryName == "Acme Holdings Ltd"
+	})).Return(&service.SSIDefaultsPreview{
+		Rule:    &domain.SSIDefaultRule{Market: &market},
+		Applied: map[string]string{"place_of_settlement": "CRSTGB22"},
+		SSI:     &domain.SSI{BeneficiaryName: "Acme Holdings Ltd", PlaceOfSettlement: "CRSTGB22"},
+	}, nil)
+	h.Router.POST("/ssis/defaults/preview", h.Handlers.SSIDefaults.Preview)
+
+	rec := h.Do(http.MethodPost, "/ssis/defaults/preview", map[string]string{"beneficiary_name": "Acme Holdings Ltd"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var preview service.SSIDefaultsPreview
+	h.DecodeJSON(rec, &preview)
+	assert.Equal(t, "CRSTGB22", preview.Applied["place_of_settlement"])
+	assert.Equal(t, "CRSTGB22", preview.SSI.PlaceOfSettlement)
+}
+
+func TestSSIDefaultsList(t *testing.T) {
+	h := handlertest.New(t)
+	h.SSIDefaults.On("List", 50, 0).Return([]*domain.SSIDefaultRule{{CutoffTime: "16:30"}}, int64(1), nil)
+	h.Router.GET("/ssis/defaults", h.Handlers.SSIDefaults.List)
+
+	rec := h.Do(http.MethodGet, "/ssis/defaults?limit=1000&offset=-1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var page struct {
+		Items []domain.SSIDefaultRule `json:"items"`
+		Limit int                     `json:"limit"`
+	}
+	h.DecodeJSON(rec, &page)
+	assert.Equal(t, "16:30", page.Items[0].CutoffTime)
+	assert.Equal(t, 50, page.Limit)
+}
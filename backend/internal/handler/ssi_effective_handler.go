@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+// Effective resolves the SSI that applies to a settlement
+// @Summary Resolve the SSI in effect for a settlement
+// @Description The active SSI of the entity in the settlement currency whose validity window (from valid_from up to but excluding valid_to) contains asOf. With instrument, an SSI for that instrument wins over one for any instrument; without it, only SSIs for any instrument are considered. When several SSIs apply equally, 409 lists them. With knownAt, the SSIs are taken as they were recorded at that time, reproducing what the lookup returned then.
+// @Tags ssis
+// @Produce json
+// @Param entity query string true "Entity ID"
+// @Param currency query string true "Settlement currency ISO 4217 code"
+// @Param asOf query string false "Settlement date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp; defaults to now"
+// @Param knownAt query string false "Resolve from the SSIs as recorded at this time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Param instrument query string false "Instrument ID"
+// @Param settlement_type query string false "DVP, FOP, RVP or DAP"
+// @Success 200 {object} domain.SSI
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Security BearerAuth
+// @Router /ssis/effective [get]
+func (h *SSIHandler) Effective(c *gin.Context) {
+	entityID, err := uuid.Parse(c.Query("entity"))
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "entity must be an entity ID"})
+		return
+	}
+	lookup := service.SSILookup{
+		EntityID:       entityID,
+		Currency:       c.Query("currency"),
+		SettlementType: c.Query("settlement_type"),
+		AsOf:           time.Now().UTC(),
+	}
+	if len(lookup.Currency) != 3 {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "currency must be an ISO 4217 code"})
+		return
+	}
+	if value := c.Query("asOf"); value != "" {
+		asOf, ok := parseAsOf(value)
+		if !ok {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "asOf must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"})
+			return
+		}
+		lookup.AsOf = asOf
+	}
+	if value := c.Query("knownAt"); value != "" {
+		knownAt, ok := parseAsOf(value)
+		if !ok {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "knownAt must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"})
+			return
+		}
+		lookup.KnownAt = &knownAt
+	}
+	if value := c.Query("instrument"); value != "" {
+		instrumentID, err := uuid.Parse(value)
+		if err != nil {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "instrument must be an instrument ID"})
+			return
+		}
+		lookup.InstrumentID = &instrumentID
+	}
+
+	ssi, err := h.service.ResolveEffective(lookup)
+	var ambiguous *service.AmbiguousSSIError
+	switch {
+	case errors.As(err, &ambiguous):
+		jsonfmt.JSON(c, http.StatusConflict, gin.H{"error": service.ErrAmbiguousSSI.Error(), "candidates": ambiguous.Candidates})
+	case errors.Is(err, service.ErrUnknownCurrency):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNoEffectiveSSI):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": err.Error()})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to resolve SSI"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, ssi)
+	}
+}
@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+func TestSSIEffectiveParsesTheLookup(t *testing.T) {
+	entity, instrument := uuid.New(), uuid.New()
+	endOfDay := time.Date(2026, 3, 2, 23, 59, 59, 999999999, time.UTC)
+	knownAt := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query string
+		want  service.SSILookup
+	}{
+		{
+			name:  "a date means the end of that day",
+			query: "entity=" + entity.String() + "&currency=EUR&asOf=2026-03-02",
+			want:  service.SSILookup{EntityID: entity, Currency: "EUR", AsOf: endOfDay},
+		},
+		{
+			name:  "instrument, settlement type and knownAt",
+			query: "entity=" + entity.String() + "&currency=EUR&asOf=2026-03-02&knownAt=2026-02-01T09:00:00Z&instrument=" + instrument.String() + "&settlement_type=DVP",
+			want:  service.SSILookup{EntityID: entity, Currency: "EUR", AsOf: endOfDay, KnownAt: &knownAt, InstrumentID: &instrument, SettlementType: "DVP"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.SSI.On("ResolveEffective", tt.want).Return(&domain.SSI{BeneficiaryName: "Acme Holdings Ltd"}, nil)
+			h.Router.GET("/ssis/effective", h.Handlers.SSI.Effective)
+
+			rec := h.Do(http.MethodGet, "/ssis/effective?"+tt.query, nil)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var ssi domain.SSI
+			h.DecodeJSON(rec, &ssi)
+			assert.Equal(t, "Acme Holdings Ltd", ssi.BeneficiaryName)
+		})
+	}
+}
+
+func TestSSIEffectiveDefaultsToNow(t *testing.T) {
+	h := handlertest.New(t)
+	before := time.Now().UTC()
+	h.SSI.On("ResolveEffective", mock.MatchedBy(func(l service.SSILookup) bool {
+		return !l.AsOf.Before(before) && l.AsOf.Location() == time.UTC && l.KnownAt == nil
+	})).Return(&domain.SSI{}, nil)
+	h.Router.GET("/ssis/effective", h.Handlers.SSI.Effective)
+
+	assert.Equal(t, http.StatusOK, h.Do(http.MethodGet, "/ssis/effective?entity="+uuid.New().String()+"&currency=EUR", nil).Code)
+}
+
+func TestSSIEffectiveRefusesBadParameters(t *testing.T) {
+	entity := uuid.New().String()
+	tests := []struct {
+		name      string
+		query     string
+		wantError string
+	}{
+		{name: "no entity", query: "currency=EUR", wantError: "entity must be an entity ID"},
+		{name: "currency not a code", query: "entity=" + entity + "&currency=EURO", wantError: "currency must be an ISO 4217 code"},
+		{name: "malformed asOf", query: "entity=" + entity + "&currency=EUR&asOf=tomorrow", wantError: "asOf must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"},
+		{name: "malformed knownAt", query: "entity=" + entity + "&currency=EUR&knownAt=02/03/2026", wantError: "knownAt must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"},
+		{name: "malformed instrument", query: "entity=" + entity + "&currency=EUR&instrument=VOD.L", wantError: "instrument must be an instrument ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.Router.GET("/ssis/effective", h.Handlers.SSI.Effective)
+
+			rec := h.Do(http.MethodGet, "/ssis/effective?"+tt.query, nil)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var body errorBody
+			h.DecodeJSON(rec, &body)
+			assert.Equal(t, tt.wantError, body.Error)
+		})
+	}
+}
+
+func TestSSIEffectiveErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantCandidates int
+	}{
+		{name: "ambiguous", err: &service.AmbiguousSSIError{Candidates: []*domain.SSI{{}, {}}}, wantStatus: http.StatusConflict, wantCandidates: 2},
+		{name: "unknown currency", err: service.ErrUnknownCurrency, wantStatus: http.StatusBadRequest},
+		{name: "nothing in effect", err: service.ErrNoEffectiveSSI, wantStatus: http.StatusNotFound},
+		{name: "database failure", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.SSI.On("ResolveEffective", mock.Anything).Return(nil, tt.err)
+			h.Router.GET("/ssis/effective", h.Handlers.SSI.Effective)
+
+			rec := h.Do(http.MethodGet, "/ssis/effective?entity="+uuid.New().String()+"&currency=EUR", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body struct {
+				Candidates []domain.SSI `json:"candidates"`
+			}
+			h.DecodeJSON(rec, &body)
+			assert.Len(t, body.Candidates, tt.wantCandidates)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/middleware"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// Export renders an SSI as ISO 20022 XML
+// @Summary Export an SSI as ISO 20022
+// @Description Renders the SSI as an ISO 20022 message fragment to paste into a settlement message: pacs.008 gives the creditor agent, creditor (with the entity's address and LEI), creditor account and any intermediary agent of a CdtTrfTxInf; sese.023 gives the RcvgSttlmPties chain from the intermediary or beneficiary bank down to the beneficiary and its safekeeping account. Without message, an SSI with a settlement type is exported as sese.023 and any other as pacs.008. XML is not masked, so callers who get any field of the SSI, its entity or the entity's addresses masked (masking.rules) are refused.
+// @Tags ssis
+// @Produce xml
+// @Param id path string true "SSI ID"
+// @Param message query string false "pacs.008 or sese.023"
+// @Success 200 {string} string "XML fragment"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/{id}/export [post]
+func (h *SSIHandler) Export(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if masked := service.SSIExportMaskedFields(middleware.MaskedFieldsFrom(c)); len(masked) > 0 {
+		jsonfmt.JSON(c, http.StatusForbidden, gin.H{"error": "SSI exports carry fields masked for you: " + strings.Join(masked, ", ")})
+		return
+	}
+
+	export, err := h.service.Export(id, c.Query("message"))
+	switch {
+	case errors.Is(err, service.ErrUnsupportedSSIExport):
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": "SSI not found"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to export SSI"})
+	default:
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="ssi-%s-%s.xml"`, id, export.Message))
+		c.Data(http.StatusOK, "application/xml; charset=utf-8", export.Document)
+	}
+}
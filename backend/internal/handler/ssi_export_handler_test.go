@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+func TestSSIExport(t *testing.T) {
+	id := uuid.New().String()
+	const document = `<?xml version="1.0" encoding="UTF-8"?><CdtTrfTxInf/>`
+	tests := []struct {
+		name       string
+		query      string
+		message    string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "chosen by the SSI", wantStatus: http.StatusOK},
+		{name: "pacs.008 asked for", query: "?message=pacs.008", message: "pacs.008", wantStatus: http.StatusOK},
+		{name: "unsupported message", query: "?message=mt540", message: "mt540", err: fmt.Errorf("%w %q: must be pacs.008 or sese.023", service.ErrUnsupportedSSIExport, "mt540"), wantStatus: http.StatusBadRequest, wantError: `unsupported SSI export message "mt540": must be pacs.008 or sese.023`},
+		{name: "unknown SSI", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "SSI not found"},
+		{name: "render fails", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to export SSI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			var export *service.SSIExport
+			if tt.err == nil {
+				export = &service.SSIExport{Message: "pacs.008", Document: []byte(document)}
+			}
+			h.SSI.On("Export", id, tt.message).Return(export, tt.err)
+			h.Router.POST("/ssis/:id/export", h.Handlers.SSI.Export)
+
+			rec := h.Do(http.MethodPost, "/ssis/"+id+"/export"+tt.query, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			assert.Equal(t, "application/xml; charset=utf-8", rec.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="ssi-`+id+`-pacs.008.xml"`, rec.Header().Get("Content-Disposition"))
+			assert.Equal(t, document, rec.Body.String())
+		})
+	}
+}
+
+func TestSSIExportRefusesMaskedFields(t *testing.T) {
+	h := handlertest.New(t)
+	maskFields(h, "beneficiary_account", "balance")
+	h.Router.POST("/ssis/:id/export", h.Handlers.SSI.Export)
+
+	rec := h.Do(http.MethodPost, "/ssis/"+uuid.New().String()+"/export", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var body errorBody
+	h.DecodeJSON(rec, &body)
+	assert.Equal(t, "SSI exports carry fields masked for you: beneficiary_account", body.Error)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodPost, "/ssis/acme/export", nil).Code)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+)
+
+// maxUploadFieldSize caps the form fields sent along with an upload
+const maxUploadFieldSize = 4096
+
+// errNoUploadFile is returned for a multipart request without a file field
+var errNoUploadFile = errors.New("multipart request needs a file field")
+
+// upload is the file field of a multipart request
+type upload struct {
+	fileName string
+	file     io.Reader         // Read as it arrives, rather than spooled to memory or disk first
+	fields   map[string]string // Form fields sent before the file
+}
+
+// uploadedFile returns the file of an import request: the multipart field "file", or else the body
+func uploadedFile(c *gin.Context) (io.Reader, error) {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		upload, err := openUpload(c)
+		if err != nil {
+			return nil, err
+		}
+		return upload.file, nil
+	}
+	liftDeadlines(c)
+	return c.Request.Body, nil
+}
+
+// openUpload streams the multipart field "file" of the request. Form fields must come before the
+// file to be read; those after it are not.
+func openUpload(c *gin.Context) (*upload, error) {
+	liftDeadlines(c)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, errNoUploadFile
+	}
+	fields := map[string]string{}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errNoUploadFile
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return &upload{fileName: part.FileName(), file: part, fields: fields}, nil
+		}
+		if part.FormName() == "" || part.FileName() != "" {
+			continue
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(value) > maxUploadFieldSize {
+			return nil, fmt.Errorf("form field %s is over %d bytes", part.FormName(), maxUploadFieldSize)
+		}
+		fields[part.FormName()] = string(value)
+	}
+}
+
+// liftDeadlines lifts the server's read and write timeouts for an upload, which may take longer
+// than they allow to arrive; its size is bounded by the body limit instead
+func liftDeadlines(c *gin.Context) {
+	controller := http.NewResponseController(c.Writer)
+	if err := controller.SetReadDeadline(time.Time{}); err != nil {
+		log.Ctx(c.Request.Context()).Warn().Err(err).Msg("Could not lift the read deadline for an upload")
+	}
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		log.Ctx(c.Request.Context()).Warn().Err(err).Msg("Could not lift the write deadline for an upload")
+	}
+}
+
+// respondUploadError answers a request whose upload could not be opened: 413 for a body over its
+// size limit, 400 otherwise
+func respondUploadError(c *gin.Context, err error) {
+	if isBodyTooLarge(err) {
+		respondTooLarge(c, err)
+		return
+	}
+	jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+// isBodyTooLarge reports whether err is the request body passing its size limit (see
+// middleware.MaxBodySize)
+func isBodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
+
+// respondTooLarge answers 413 for a request body that passed its size limit
+func respondTooLarge(c *gin.Context, err error) {
+	message := "Request body is too large"
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		message = fmt.Sprintf("Request body exceeds the limit of %d bytes", tooLarge.Limit)
+	}
+	jsonfmt.JSON(c, http.StatusRequestEntityTooLarge, gin.H{"error": message})
+}
@@ -0,0 +1,172 @@
+package v2
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// Country is a country as v2 returns it
+type Country struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Alpha3Code  string    `json:"alpha3_code"`
+	NumericCode string    `json:"numeric_code"`
+	Region      string    `json:"region"`
+	SubRegion   string    `json:"sub_region"`
+	Active      bool      `json:"active"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func countryDTO(country *domain.Country) Country {
+	return Country{
+		ID:          country.ID,
+		Code:        country.Code,
+		Name:        country.Name,
+		Alpha3Code:  country.Alpha3Code,
+		NumericCode: country.NumericCode,
+		Region:      country.Region,
+		SubRegion:   country.SubRegion,
+		Active:      country.Active,
+		UpdatedAt:   country.UpdatedAt,
+	}
+}
+
+// Currency is a currency as v2 returns it
+type Currency struct {
+	ID            uuid.UUID `json:"id"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	Symbol        string    `json:"symbol"`
+	NumericCode   string    `json:"numeric_code"`
+	DecimalPlaces int       `json:"decimal_places"`
+	Fund          bool      `json:"fund"`
+	PreciousMetal bool      `json:"precious_metal"`
+	Active        bool      `json:"active"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func currencyDTO(currency *domain.Currency) Currency {
+	return Currency{
+		ID:            currency.ID,
+		Code:          currency.Code,
+		Name:          currency.Name,
+		Symbol:        currency.Symbol,
+		NumericCode:   currency.NumericCode,
+		DecimalPlaces: currency.DecimalPlaces,
+		Fund:          currency.Fund,
+		PreciousMetal: currency.PreciousMetal,
+		Active:        currency.Active,
+		UpdatedAt:     currency.UpdatedAt,
+	}
+}
+
+// LEIRecord is an LEI record as v2 returns it: addresses and codes with their names are nested
+// rather than flattened into columns, and the bookkeeping of the import (source file, changed
+// fields) is left out
+type LEIRecord struct {
+	LEI                     string      `json:"lei"`
+	LegalName               string      `json:"legal_name"`
+	TransliteratedLegalName string      `json:"transliterated_legal_name,omitempty"`
+	OtherNames              []OtherName `json:"other_names"`
+
+	LegalAddress        Address `json:"legal_address"`
+	HeadquartersAddress Address `json:"headquarters_address"`
+
+	RegistrationAuthority         Coded  `json:"registration_authority"`
+	RegistrationAuthorityEntityID string `json:"registration_authority_entity_id,omitempty"`
+	RegistrationNumber            string `json:"registration_number,omitempty"`
+
+	EntityCategory     string `json:"entity_category"`
+	EntitySubCategory  string `json:"entity_sub_category,omitempty"`
+	LegalForm          Coded  `json:"legal_form"`
+	EntityStatus       string `json:"entity_status"`
+	RegistrationStatus string `json:"registration_status"`
+	ManagingLOU        Coded  `json:"managing_lou"`
+	SuccessorLEI       string `json:"successor_lei,omitempty"`
+
+	InitialRegistrationDate *time.Time `json:"initial_registration_date"`
+	LastUpdateDate          *time.Time `json:"last_update_date"`
+	NextRenewalDate         *time.Time `json:"next_renewal_date"`
+	ValidationAuthority     string     `json:"validation_authority,omitempty"`
+}
+
+// OtherName is another name of an LEI's entity, e.g. a trading name or a translation
+type OtherName struct {
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// Address is a postal address; lines holds the non-blank address lines in order
+type Address struct {
+	Lines      []string `json:"lines"`
+	City       string   `json:"city"`
+	Region     string   `json:"region,omitempty"`
+	Country    string   `json:"country"`
+	PostalCode string   `json:"postal_code,omitempty"`
+}
+
+// Coded is a code with the name it resolves to, where the code list has it
+type Coded struct {
+	Code string `json:"code"`
+	Name string `json:"name,omitempty"`
+}
+
+func leiRecordDTO(record *domain.LEIRecord) LEIRecord {
+	return LEIRecord{
+		LEI:                     record.LEI,
+		LegalName:               record.LegalName,
+		TransliteratedLegalName: record.TransliteratedLegalName,
+		OtherNames:              otherNames(record.OtherNames),
+		LegalAddress: address(record.LegalAddressCity, record.LegalAddressRegion, record.LegalAddressCountry, record.LegalAddressPostalCode,
+			record.LegalAddressLine1, record.LegalAddressLine2, record.LegalAddressLine3, record.LegalAddressLine4),
+		HeadquartersAddress: address(record.HQAddressCity, record.HQAddressRegion, record.HQAddressCountry, record.HQAddressPostalCode,
+			record.HQAddressLine1, record.HQAddressLine2, record.HQAddressLine3, record.HQAddressLine4),
+		RegistrationAuthority:         Coded{Code: record.RegistrationAuthority, Name: record.RegistrationAuthorityName},
+		RegistrationAuthorityEntityID: record.RegistrationAuthorityID,
+		RegistrationNumber:            record.RegistrationNumber,
+		EntityCategory:                record.EntityCategory,
+		EntitySubCategory:             record.EntitySubCategory,
+		LegalForm:                     Coded{Code: record.EntityLegalForm, Name: record.EntityLegalFormName},
+		EntityStatus:                  record.EntityStatus,
+		RegistrationStatus:            record.RegistrationStatus,
+		ManagingLOU:                   Coded{Code: record.ManagingLOU, Name: record.ManagingLOUName},
+		SuccessorLEI:                  record.SuccessorLEI,
+		InitialRegistrationDate:       date(record.InitialRegistrationDate),
+		LastUpdateDate:                date(record.LastUpdateDate),
+		NextRenewalDate:               date(record.NextRenewalDate),
+		ValidationAuthority:           record.ValidationAuthority,
+	}
+}
+
+// address builds an address, leaving out blank lines
+func address(city, region, country, postalCode string, lines ...string) Address {
+	a := Address{Lines: []string{}, City: city, Region: region, Country: country, PostalCode: postalCode}
+	for _, line := range lines {
+		if line != "" {
+			a.Lines = append(a.Lines, line)
+		}
+	}
+	return a
+}
+
+// otherNames reads the other names of a record, stored as a JSON array
+func otherNames(stored string) []OtherName {
+	var names []OtherName
+	if stored == "" || json.Unmarshal([]byte(stored), &names) != nil || names == nil {
+		return []OtherName{}
+	}
+	return names
+}
+
+// date is nil for a date the record does not have, so it is null rather than year 1
+func date(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
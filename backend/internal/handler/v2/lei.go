@@ -0,0 +1,100 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// LEIHandler serves the v2 LEI lookups
+type LEIHandler struct {
+	leiService service.LEIService
+	elfService service.ELFService
+	raService  service.RegistrationAuthorityService
+	louService service.LOUService
+}
+
+// NewLEIHandler creates a new v2 LEI handler
+func NewLEIHandler(leiService service.LEIService, elfService service.ELFService, raService service.RegistrationAuthorityService, louService service.LOUService) *LEIHandler {
+	return &LEIHandler{
+		leiService: leiService,
+		elfService: elfService,
+		raService:  raService,
+		louService: louService,
+	}
+}
+
+// describe resolves the legal form, registration authority and managing LOU codes of records to names
+func (h *LEIHandler) describe(ctx context.Context, records ...*domain.LEIRecord) {
+	h.elfService.DescribeLEIRecords(ctx, records...)
+	h.raService.DescribeLEIRecords(ctx, records...)
+	h.louService.DescribeLEIRecords(ctx, records...)
+}
+
+// List godoc
+// @Summary List LEI records (v2)
+// @Description Get a page of LEI records matching the filters of GET /api/v1/lei, in a pagination envelope
+// @Tags LEI
+// @Produce json
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Items to skip" default(0)
+// @Param search query string false "Search term (LEI code or legal name)"
+// @Param q query string false "Full-text search on legal name"
+// @Param status query string false "Entity status filter (e.g., ACTIVE, INACTIVE)"
+// @Param category query string false "Entity category filter (e.g., GENERAL, FUND)"
+// @Param country query string false "Country code filter (e.g., US, GB)"
+// @Param legalForm query string false "Legal form filter: ELF code, or part of the legal form's name or one of its abbreviations"
+// @Param lou query string false "Managing LOU filter: the LOU's LEI, or part of its name or marketing name"
+// @Param sortBy query string false "Sort field (lei, legal_name, entity_status, entity_category, legal_address_country, last_update_date)"
+// @Param sortOrder query string false "Sort order (asc, desc)" default(asc)
+// @Success 200 {object} v2.Page[v2.LEIRecord]
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v2/lei [get]
+func (h *LEIHandler) List(c *gin.Context) {
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+	records, err := h.leiService.GetAllLEIWithFilters(limit+1, offset, service.LEIListFilter(c.Request.URL.Query()))
+	switch {
+	case query.IsValidationError(err):
+		abort(c, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	case err != nil:
+		abort(c, http.StatusInternalServerError, "", "Failed to retrieve LEI records")
+		return
+	}
+
+	h.describe(c.Request.Context(), records...)
+	jsonfmt.JSON(c, http.StatusOK, newPage(records, limit, offset, leiRecordDTO))
+}
+
+// Get godoc
+// @Summary Get LEI record by code (v2)
+// @Description Get a stored LEI record by its LEI code
+// @Tags LEI
+// @Produce json
+// @Param lei path string true "LEI code"
+// @Success 200 {object} v2.LEIRecord
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v2/lei/{lei} [get]
+func (h *LEIHandler) Get(c *gin.Context) {
+	record, err := h.leiService.GetLEIByCode(strings.ToUpper(c.Param("lei")))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		abort(c, http.StatusNotFound, "", "LEI record not found")
+	case err != nil:
+		abort(c, http.StatusInternalServerError, "", "Failed to retrieve LEI record")
+	default:
+		h.describe(c.Request.Context(), record)
+		jsonfmt.JSON(c, http.StatusOK, leiRecordDTO(record))
+	}
+}
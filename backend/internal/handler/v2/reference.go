@@ -0,0 +1,120 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// CountryHandler serves the v2 country routes
+type CountryHandler struct {
+	service service.CountryService
+}
+
+// NewCountryHandler creates a new v2 country handler
+func NewCountryHandler(service service.CountryService) *CountryHandler {
+	return &CountryHandler{service: service}
+}
+
+// List godoc
+// @Summary List countries (v2)
+// @Description Get a page of countries, in a pagination envelope
+// @Tags countries
+// @Produce json
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Items to skip" default(0)
+// @Success 200 {object} v2.Page[v2.Country]
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v2/countries [get]
+func (h *CountryHandler) List(c *gin.Context) {
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+	countries, err := h.service.GetAll(limit+1, offset)
+	if err != nil {
+		abort(c, http.StatusInternalServerError, "", "Failed to fetch countries")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, newPage(countries, limit, offset, countryDTO))
+}
+
+// Get godoc
+// @Summary Get country by code (v2)
+// @Description Get a country by its ISO 3166-1 alpha-2 code
+// @Tags countries
+// @Produce json
+// @Param code path string true "Country code, e.g. GB"
+// @Success 200 {object} v2.Country
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v2/countries/{code} [get]
+func (h *CountryHandler) Get(c *gin.Context) {
+	country, err := h.service.GetByCode(strings.ToUpper(c.Param("code")))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		abort(c, http.StatusNotFound, "", "Country not found")
+	case err != nil:
+		abort(c, http.StatusInternalServerError, "", "Failed to fetch country")
+	default:
+		jsonfmt.JSON(c, http.StatusOK, countryDTO(country))
+	}
+}
+
+// CurrencyHandler serves the v2 currency routes
+type CurrencyHandler struct {
+	service service.CurrencyService
+}
+
+// NewCurrencyHandler creates a new v2 currency handler
+func NewCurrencyHandler(service service.CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{service: service}
+}
+
+// List godoc
+// @Summary List currencies (v2)
+// @Description Get a page of currencies, in a pagination envelope
+// @Tags currencies
+// @Produce json
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Items to skip" default(0)
+// @Success 200 {object} v2.Page[v2.Currency]
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v2/currencies [get]
+func (h *CurrencyHandler) List(c *gin.Context) {
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+	currencies, err := h.service.GetAll(limit+1, offset)
+	if err != nil {
+		abort(c, http.StatusInternalServerError, "", "Failed to fetch currencies")
+		return
+	}
+	jsonfmt.JSON(c, http.StatusOK, newPage(currencies, limit, offset, currencyDTO))
+}
+
+// Get godoc
+// @Summary Get currency by code (v2)
+// @Description Get a currency by its ISO 4217 code
+// @Tags currencies
+// @Produce json
+// @Param code path string true "Currency code, e.g. EUR"
+// @Success 200 {object} v2.Currency
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v2/currencies/{code} [get]
+func (h *CurrencyHandler) Get(c *gin.Context) {
+	currency, err := h.service.GetByCode(strings.ToUpper(c.Param("code")))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		abort(c, http.StatusNotFound, "", "Currency not found")
+	case err != nil:
+		abort(c, http.StatusInternalServerError, "", "Failed to fetch currency")
+	default:
+		jsonfmt.JSON(c, http.StatusOK, currencyDTO(currency))
+	}
+}
@@ -0,0 +1,100 @@
+// Package v2 serves /api/v2: the routes whose response shapes break with v1, alongside it.
+//
+// v2 answers with its own DTOs rather than the GORM models, so the schema can change without the
+// API changing with it, and the other way round. Lists come in a pagination envelope:
+//
+//	{"data": [...], "pagination": {"limit": 20, "offset": 0, "has_more": true, "next_offset": 20}}
+//
+// and errors as an object with a machine-readable code (see middleware.APIVersion):
+//
+//	{"error": {"code": "not_found", "message": "Country not found", "request_id": "..."}}
+//
+// Handlers write errors as v1 does, {"error": "message"} with an optional "code", and RequestID
+// reshapes them, so middleware shared with v1 answers in the v2 format too. A route moves to v2
+// when its response shape has to change; the others stay on v1 only.
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+// Pagination bounds of v2 lists
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// Handlers holds the v2 handler groups
+type Handlers struct {
+	Country  *CountryHandler
+	Currency *CurrencyHandler
+	LEI      *LEIHandler
+}
+
+// NewHandlers creates the v2 handlers over the same services as v1
+func NewHandlers(services *service.Services) *Handlers {
+	return &Handlers{
+		Country:  NewCountryHandler(services.Country),
+		Currency: NewCurrencyHandler(services.Currency),
+		LEI:      NewLEIHandler(services.LEI, services.ELF, services.RA, services.LOU),
+	}
+}
+
+// Page is a page of a list
+type Page[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// Pagination says where a page is in its list, and where the next one starts
+type Pagination struct {
+	Limit      int  `json:"limit"`
+	Offset     int  `json:"offset"`
+	HasMore    bool `json:"has_more"`
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+// pageParams reads limit and offset, answering 400 if they are out of range. Lists fetch one item
+// more than limit, to tell whether there is a next page without counting.
+func pageParams(c *gin.Context) (limit, offset int, ok bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+	if err != nil || limit < 1 || limit > maxLimit {
+		abort(c, http.StatusBadRequest, "invalid_parameter", "limit must be 1-"+strconv.Itoa(maxLimit))
+		return 0, 0, false
+	}
+	offset, err = strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		abort(c, http.StatusBadRequest, "invalid_parameter", "offset must be 0 or more")
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
+
+// newPage makes the page of up to limit+1 items fetched at offset, converting them with dto
+func newPage[M, T any](items []M, limit, offset int, dto func(M) T) Page[T] {
+	page := Page[T]{
+		Data:       make([]T, 0, min(len(items), limit)),
+		Pagination: Pagination{Limit: limit, Offset: offset, HasMore: len(items) > limit},
+	}
+	for _, item := range items[:min(len(items), limit)] {
+		page.Data = append(page.Data, dto(item))
+	}
+	if page.Pagination.HasMore {
+		next := offset + limit
+		page.Pagination.NextOffset = &next
+	}
+	return page
+}
+
+// abort answers an error; code names it for clients, or "" to name it by the status
+func abort(c *gin.Context, status int, code, message string) {
+	body := gin.H{"error": message}
+	if code != "" {
+		body["code"] = code
+	}
+	c.AbortWithStatusJSON(status, body)
+}
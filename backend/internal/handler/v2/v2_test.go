@@ -0,0 +1,56 @@
+package v2_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	v2 "github.com/techie2000/axiom/internal/handler/v2"
+)
+
+func TestCountryListPagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		limit    int
+		offset   int
+		fetched  int
+		wantData int
+		wantNext *int
+	}{
+		{name: "last page", path: "/countries?limit=2&offset=4", limit: 2, offset: 4, fetched: 1, wantData: 1},
+		{name: "more to come", path: "/countries?limit=2", limit: 2, fetched: 3, wantData: 2, wantNext: ptr(2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			countries := make([]*domain.Country, tt.fetched)
+			for i := range countries {
+				countries[i] = &domain.Country{Code: "C" + string(rune('A'+i))}
+			}
+			h.Country.On("GetAll", tt.limit+1, tt.offset).Return(countries, nil)
+			h.Router.GET("/countries", v2.NewHandlers(h.Services).Country.List)
+
+			rec := h.Do(http.MethodGet, tt.path, nil)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var page v2.Page[v2.Country]
+			h.DecodeJSON(rec, &page)
+			assert.Len(t, page.Data, tt.wantData)
+			assert.Equal(t, tt.wantNext != nil, page.Pagination.HasMore)
+			assert.Equal(t, tt.wantNext, page.Pagination.NextOffset)
+		})
+	}
+}
+
+func TestCountryListRejectsLimit(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.GET("/countries", v2.NewHandlers(h.Services).Country.List)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodGet, "/countries?limit=0", nil).Code)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+// ValidationHandler checks identifiers as they are typed, before anything is saved
+type ValidationHandler struct{}
+
+// NewValidationHandler creates a new identifier validation handler
+func NewValidationHandler() *ValidationHandler {
+	return &ValidationHandler{}
+}
+
+// IBAN validates an IBAN
+// @Summary Validate an IBAN
+// @Description Checks an IBAN, with or without spaces, against its country's IBAN length and the ISO 7064 mod-97 check digits. An invalid IBAN is still a 200, with valid false and the reason in error; a valid one is broken into country code, check digits and BBAN and formatted in groups of four.
+// @Tags validation
+// @Produce json
+// @Param iban path string true "IBAN"
+// @Success 200 {object} service.IBANCheck
+// @Security BearerAuth
+// @Router /validate/iban/{iban} [get]
+func (h *ValidationHandler) IBAN(c *gin.Context) {
+	jsonfmt.JSON(c, http.StatusOK, service.CheckIBAN(c.Param("iban")))
+}
@@ -0,0 +1,45 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"github.com/techie2000/axiom/internal/service"
+)
+
+func TestValidationIBAN(t *testing.T) {
+	tests := []struct {
+		name        string
+		iban        string
+		want        service.IBANCheck
+		wantInvalid bool
+	}{
+		{
+			name: "valid, printed with spaces",
+			iban: "GB82%20WEST%201234%205698%207654%2032",
+			want: service.IBANCheck{IBAN: "GB82WEST12345698765432", Valid: true, CountryCode: "GB", CheckDigits: "82", BBAN: "WEST12345698765432", Formatted: "GB82 WEST 1234 5698 7654 32"},
+		},
+		{name: "wrong check digits", iban: "GB83WEST12345698765432", wantInvalid: true},
+		{name: "wrong length for the country", iban: "GB82WEST123456987654", wantInvalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			h.Router.GET("/validate/iban/:iban", h.Handlers.Validation.IBAN)
+
+			// An invalid IBAN is an answer, not a bad request
+			rec := h.Do(http.MethodGet, "/validate/iban/"+tt.iban, nil)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var check service.IBANCheck
+			h.DecodeJSON(rec, &check)
+			if tt.wantInvalid {
+				assert.False(t, check.Valid)
+				assert.NotEmpty(t, check.Error)
+				return
+			}
+			assert.Equal(t, tt.want, check)
+		})
+	}
+}
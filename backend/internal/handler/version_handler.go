@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/service"
+	"gorm.io/gorm"
+)
+
+// VersionHandler serves the effective-dated versions of countries, currencies, entities, instruments and SSIs
+type VersionHandler struct {
+	versionService service.MasterDataVersionService
+}
+
+// NewVersionHandler creates a new master data version handler
+func NewVersionHandler(versionService service.MasterDataVersionService) *VersionHandler {
+	return &VersionHandler{versionService: versionService}
+}
+
+// CountryVersions returns the versions of a country
+// @Summary Get country versions
+// @Description Every version of a country, oldest first, each valid from valid_from until valid_to (null while current). With asOf, only the version in effect then.
+// @Tags countries
+// @Produce json
+// @Param id path string true "Country ID"
+// @Param asOf query string false "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Success 200 {array} domain.MasterDataVersion
+// @Success 200 {object} domain.MasterDataVersion "When asOf is given"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /countries/{id}/versions [get]
+func (h *VersionHandler) CountryVersions(c *gin.Context) {
+	h.versions(c, domain.VersionDomainCountry, "Country")
+}
+
+// CountriesAsOf lists countries as they stood at a point in time
+// @Summary List countries as of a point in time
+// @Description The version of each country in effect at asOf, in ID order; countries created later or deleted by then are left out
+// @Tags countries
+// @Produce json
+// @Param asOf query string true "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Param limit query int false "Limit" default(100)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /countries/as-of [get]
+func (h *VersionHandler) CountriesAsOf(c *gin.Context) {
+	h.listAsOf(c, domain.VersionDomainCountry)
+}
+
+// CurrencyVersions returns the versions of a currency
+// @Summary Get currency versions
+// @Description Every version of a currency, oldest first, each valid from valid_from until valid_to (null while current). With asOf, only the version in effect then.
+// @Tags currencies
+// @Produce json
+// @Param id path string true "Currency ID"
+// @Param asOf query string false "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Success 200 {array} domain.MasterDataVersion
+// @Success 200 {object} domain.MasterDataVersion "When asOf is given"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /currencies/{id}/versions [get]
+func (h *VersionHandler) CurrencyVersions(c *gin.Context) {
+	h.versions(c, domain.VersionDomainCurrency, "Currency")
+}
+
+// CurrenciesAsOf lists currencies as they stood at a point in time
+// @Summary List currencies as of a point in time
+// @Description The version of each currency in effect at asOf, in ID order; currencies created later or deleted by then are left out
+// @Tags currencies
+// @Produce json
+// @Param asOf query string true "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Param limit query int false "Limit" default(100)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /currencies/as-of [get]
+func (h *VersionHandler) CurrenciesAsOf(c *gin.Context) {
+	h.listAsOf(c, domain.VersionDomainCurrency)
+}
+
+// EntityVersions returns the versions of an entity
+// @Summary Get entity versions
+// @Description Every version of an entity's own fields (not its addresses), oldest first, each valid from valid_from until valid_to (null while current). With asOf, only the version in effect then.
+// @Tags entities
+// @Produce json
+// @Param id path string true "Entity ID"
+// @Param asOf query string false "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Success 200 {array} domain.MasterDataVersion
+// @Success 200 {object} domain.MasterDataVersion "When asOf is given"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /entities/{id}/versions [get]
+func (h *VersionHandler) EntityVersions(c *gin.Context) {
+	h.versions(c, domain.VersionDomainEntity, "Entity")
+}
+
+// EntitiesAsOf lists entities as they stood at a point in time
+// @Summary List entities as of a point in time
+// @Description The version of each entity in effect at asOf, in ID order; entities created later or deleted (or merged away) by then are left out
+// @Tags entities
+// @Produce json
+// @Param asOf query string true "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Param limit query int false "Limit" default(100)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /entities/as-of [get]
+func (h *VersionHandler) EntitiesAsOf(c *gin.Context) {
+	h.listAsOf(c, domain.VersionDomainEntity)
+}
+
+// InstrumentVersions returns the versions of an instrument
+// @Summary Get instrument versions
+// @Description Every version of an instrument, oldest first, each valid from valid_from until valid_to (null while current). With asOf, only the version in effect then.
+// @Tags instruments
+// @Produce json
+// @Param id path string true "Instrument ID"
+// @Param asOf query string false "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Success 200 {array} domain.MasterDataVersion
+// @Success 200 {object} domain.MasterDataVersion "When asOf is given"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /instruments/{id}/versions [get]
+func (h *VersionHandler) InstrumentVersions(c *gin.Context) {
+	h.versions(c, domain.VersionDomainInstrument, "Instrument")
+}
+
+// InstrumentsAsOf lists instruments as they stood at a point in time
+// @Summary List instruments as of a point in time
+// @Description The version of each instrument in effect at asOf, in ID order; instruments created later or deleted by then are left out
+// @Tags instruments
+// @Produce json
+// @Param asOf query string true "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Param limit query int false "Limit" default(100)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Security BearerAuth
+// @Router /instruments/as-of [get]
+func (h *VersionHandler) InstrumentsAsOf(c *gin.Context) {
+	h.listAsOf(c, domain.VersionDomainInstrument)
+}
+
+// SSIVersions returns the versions of an SSI
+// @Summary Get SSI versions
+// @Description Every recorded version of an SSI, oldest first, each valid from valid_from until valid_to (null while current). These are when the SSI was known in each state, not its own validity window, which is part of each snapshot. With asOf, only the version recorded then.
+// @Tags ssis
+// @Produce json
+// @Param id path string true "SSI ID"
+// @Param asOf query string false "Point in time: a date (YYYY-MM-DD, meaning the end of that day UTC) or an RFC 3339 timestamp"
+// @Success 200 {array} domain.MasterDataVersion
+// @Success 200 {object} domain.MasterDataVersion "When asOf is given"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Security BearerAuth
+// @Router /ssis/{id}/versions [get]
+func (h *VersionHandler) SSIVersions(c *gin.Context) {
+	h.versions(c, domain.VersionDomainSSI, "SSI")
+}
+
+// versions serves a record's version history, or with asOf its version in effect then
+func (h *VersionHandler) versions(c *gin.Context, domainName, label string) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	if value := c.Query("asOf"); value != "" {
+		asOf, ok := parseAsOf(value)
+		if !ok {
+			jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "asOf must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"})
+			return
+		}
+		version, err := h.versionService.AsOf(domainName, id, asOf)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": label + " not in effect at " + value})
+		case err != nil:
+			jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve " + domainName + " version"})
+		default:
+			jsonfmt.JSON(c, http.StatusOK, version)
+		}
+		return
+	}
+
+	versions, err := h.versionService.History(domainName, id)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		jsonfmt.JSON(c, http.StatusNotFound, gin.H{"error": label + " not found"})
+	case err != nil:
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve " + domainName + " versions"})
+	default:
+		jsonfmt.JSON(c, http.StatusOK, versions)
+	}
+}
+
+// listAsOf serves a domain's records as they stood at the required asOf
+func (h *VersionHandler) listAsOf(c *gin.Context, domainName string) {
+	asOf, ok := parseAsOf(c.Query("asOf"))
+	if !ok {
+		jsonfmt.JSON(c, http.StatusBadRequest, gin.H{"error": "asOf is required: a date (YYYY-MM-DD) or an RFC 3339 timestamp"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 1000 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	versions, total, err := h.versionService.ListAsOf(domainName, asOf, limit, offset)
+	if err != nil {
+		jsonfmt.JSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to retrieve " + domainName + " versions"})
+		return
+	}
+
+	jsonfmt.JSON(c, http.StatusOK, gin.H{
+		"items":  versions,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+		"as_of":  asOf,
+	})
+}
@@ -0,0 +1,152 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/handler/handlertest"
+	"gorm.io/gorm"
+)
+
+func TestVersionHistory(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name       string
+		route      func(h *handlertest.Harness) gin.HandlerFunc
+		path       string
+		domain     string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "country", route: func(h *handlertest.Harness) gin.HandlerFunc { return h.Handlers.Version.CountryVersions }, path: "/countries/", domain: domain.VersionDomainCountry, wantStatus: http.StatusOK},
+		{name: "SSI", route: func(h *handlertest.Harness) gin.HandlerFunc { return h.Handlers.Version.SSIVersions }, path: "/ssis/", domain: domain.VersionDomainSSI, wantStatus: http.StatusOK},
+		{name: "unknown entity", route: func(h *handlertest.Harness) gin.HandlerFunc { return h.Handlers.Version.EntityVersions }, path: "/entities/", domain: domain.VersionDomainEntity, err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Entity not found"},
+		{name: "database failure", route: func(h *handlertest.Harness) gin.HandlerFunc { return h.Handlers.Version.InstrumentVersions }, path: "/instruments/", domain: domain.VersionDomainInstrument, err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantError: "Failed to retrieve instrument versions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			var versions []*domain.MasterDataVersion
+			if tt.err == nil {
+				versions = []*domain.MasterDataVersion{{Domain: tt.domain, RecordID: id}, {Domain: tt.domain, RecordID: id}}
+			}
+			h.Versions.On("History", tt.domain, id.String()).Return(versions, tt.err)
+			h.Router.GET(tt.path+":id/versions", tt.route(h))
+
+			rec := h.Do(http.MethodGet, tt.path+id.String()+"/versions", nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var got []domain.MasterDataVersion
+			h.DecodeJSON(rec, &got)
+			assert.Len(t, got, 2)
+		})
+	}
+}
+
+func TestVersionAsOf(t *testing.T) {
+	id := uuid.New()
+	endOfDay := time.Date(2025, 12, 31, 23, 59, 59, 999999999, time.UTC)
+	tests := []struct {
+		name       string
+		query      string
+		mocked     bool
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{name: "in effect", query: "?asOf=2025-12-31", mocked: true, wantStatus: http.StatusOK},
+		{name: "not in effect", query: "?asOf=2025-12-31", mocked: true, err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound, wantError: "Currency not in effect at 2025-12-31"},
+		{name: "malformed asOf", query: "?asOf=last-year", wantStatus: http.StatusBadRequest, wantError: "asOf must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.mocked {
+				var version *domain.MasterDataVersion
+				if tt.err == nil {
+					version = &domain.MasterDataVersion{Domain: domain.VersionDomainCurrency, RecordID: id, Snapshot: []byte(`{"code":"HRK"}`)}
+				}
+				h.Versions.On("AsOf", domain.VersionDomainCurrency, id.String(), endOfDay).Return(version, tt.err)
+			}
+			h.Router.GET("/currencies/:id/versions", h.Handlers.Version.CurrencyVersions)
+
+			rec := h.Do(http.MethodGet, "/currencies/"+id.String()+"/versions"+tt.query, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, tt.wantError, body.Error)
+				return
+			}
+			var version domain.MasterDataVersion
+			h.DecodeJSON(rec, &version)
+			assert.JSONEq(t, `{"code":"HRK"}`, string(version.Snapshot))
+		})
+	}
+}
+
+func TestVersionRefusesInvalidIDs(t *testing.T) {
+	h := handlertest.New(t)
+	h.Router.GET("/countries/:id/versions", h.Handlers.Version.CountryVersions)
+
+	assert.Equal(t, http.StatusBadRequest, h.Do(http.MethodGet, "/countries/GB/versions", nil).Code)
+}
+
+func TestVersionListAsOf(t *testing.T) {
+	asOf := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		query      string
+		limit      int
+		offset     int
+		wantStatus int
+	}{
+		{name: "defaults", query: "?asOf=2025-01-01T12:00:00Z", limit: 100, wantStatus: http.StatusOK},
+		{name: "explicit page", query: "?asOf=2025-01-01T12:00:00Z&limit=1000&offset=200", limit: 1000, offset: 200, wantStatus: http.StatusOK},
+		{name: "out of range values fall back", query: "?asOf=2025-01-01T12:00:00Z&limit=1001&offset=-1", limit: 100, wantStatus: http.StatusOK},
+		{name: "asOf is required", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handlertest.New(t)
+			if tt.wantStatus == http.StatusOK {
+				h.Versions.On("ListAsOf", domain.VersionDomainEntity, asOf, tt.limit, tt.offset).
+					Return([]*domain.MasterDataVersion{{Domain: domain.VersionDomainEntity}}, int64(250), nil)
+			}
+			h.Router.GET("/entities/as-of", h.Handlers.Version.EntitiesAsOf)
+
+			rec := h.Do(http.MethodGet, "/entities/as-of"+tt.query, nil)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				var body errorBody
+				h.DecodeJSON(rec, &body)
+				assert.Equal(t, "asOf is required: a date (YYYY-MM-DD) or an RFC 3339 timestamp", body.Error)
+				return
+			}
+			var page struct {
+				Items  []domain.MasterDataVersion `json:"items"`
+				Total  int64                      `json:"total"`
+				Limit  int                        `json:"limit"`
+				Offset int                        `json:"offset"`
+				AsOf   time.Time                  `json:"as_of"`
+			}
+			h.DecodeJSON(rec, &page)
+			assert.Equal(t, int64(250), page.Total)
+			assert.Equal(t, tt.limit, page.Limit)
+			assert.Equal(t, tt.offset, page.Offset)
+			assert.True(t, asOf.Equal(page.AsOf))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package jsonfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+)
+
+// maskKeep is how many trailing characters of a masked string stay readable, so that masked
+// account numbers can still be told apart
+const maskKeep = 4
+
+// MaskFields masks the values of the given object fields, at any depth: strings keep their last
+// four characters (shorter ones are masked whole), other values become null. Key order and every
+// other value are left untouched.
+func MaskFields(body []byte, fields map[string]bool) []byte {
+	if len(fields) == 0 {
+		return body
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(body))
+
+	for i := 0; i < len(body); {
+		if body[i] != '"' {
+			out.WriteByte(body[i])
+			i++
+			continue
+		}
+
+		end := stringEnd(body, i)
+		if end >= len(body) {
+			out.Write(body[i:])
+			break
+		}
+		literal := body[i : end+1]
+		i = end + 1
+		out.Write(literal)
+
+		var key string
+		if !isObjectKey(body, i) || json.Unmarshal(literal, &key) != nil || !fields[key] {
+			continue
+		}
+
+		// Copy up to the value, then replace it
+		for i < len(body) && body[i] != ':' {
+			out.WriteByte(body[i])
+			i++
+		}
+		for i < len(body) && (body[i] == ':' || isSpace(body[i])) {
+			out.WriteByte(body[i])
+			i++
+		}
+		if i >= len(body) {
+			break
+		}
+		valueEnd := valueEnd(body, i)
+		if body[i] == '"' {
+			var value string
+			if json.Unmarshal(body[i:valueEnd], &value) == nil {
+				masked, _ := json.Marshal(MaskString(value))
+				out.Write(masked)
+				i = valueEnd
+				continue
+			}
+		}
+		out.WriteString("null")
+		i = valueEnd
+	}
+
+	return out.Bytes()
+}
+
+// MaskString masks a string as MaskFields does: all but its last four characters become '*'
+func MaskString(value string) string {
+	n := utf8.RuneCountInString(value)
+	if n <= maskKeep {
+		return "****"
+	}
+	runes := []rune(value)
+	return strings.Repeat("*", n-maskKeep) + string(runes[n-maskKeep:])
+}
+
+// stringEnd returns the index of the quote closing the string literal opening at start
+func stringEnd(body []byte, start int) int {
+	end := start + 1
+	for end < len(body) {
+		if body[end] == '\\' {
+			end += 2
+			continue
+		}
+		if body[end] == '"' {
+			break
+		}
+		end++
+	}
+	return end
+}
+
+// valueEnd returns the index just past the JSON value starting at start
+func valueEnd(body []byte, start int) int {
+	switch body[start] {
+	case '"':
+		return min(stringEnd(body, start)+1, len(body))
+	case '{', '[':
+		depth := 0
+		for i := start; i < len(body); i++ {
+			switch body[i] {
+			case '"':
+				i = stringEnd(body, i)
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return len(body)
+	default:
+		i := start
+		for i < len(body) && body[i] != ',' && body[i] != '}' && body[i] != ']' && !isSpace(body[i]) {
+			i++
+		}
+		return i
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// isObjectKey reports whether the next non-whitespace byte at or after pos is ':'
+func isObjectKey(body []byte, pos int) bool {
+	for ; pos < len(body); pos++ {
+		switch body[pos] {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case ':':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package jsonfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		fields map[string]bool
+		want   string
+	}{
+		{
+			name:   "no fields",
+			body:   `{"iban":"GB82WEST12345698765432"}`,
+			fields: nil,
+			want:   `{"iban":"GB82WEST12345698765432"}`,
+		},
+		{
+			name:   "keeps the last four characters",
+			body:   `{"iban":"GB82WEST12345698765432","name":"Acme"}`,
+			fields: map[string]bool{"iban": true},
+			want:   `{"iban":"******************5432","name":"Acme"}`,
+		},
+		{
+			name:   "short strings masked whole",
+			body:   `{"pin":"1234"}`,
+			fields: map[string]bool{"pin": true},
+			want:   `{"pin":"****"}`,
+		},
+		{
+			name:   "other values become null",
+			body:   `{"balance":1250.5,"limits":{"daily":100},"tags":["a","b"],"ok":true}`,
+			fields: map[string]bool{"balance": true, "limits": true, "tags": true},
+			want:   `{"balance":null,"limits":null,"tags":null,"ok":true}`,
+		},
+		{
+			name:   "any depth",
+			body:   `[{"account":{"number":"12345678"}},{"number":"87654321"}]`,
+			fields: map[string]bool{"number": true},
+			want:   `[{"account":{"number":"****5678"}},{"number":"****4321"}]`,
+		},
+		{
+			name:   "string values matching a field name untouched",
+			body:   `{"field":"iban","iban" : "DE89370400440532013000"}`,
+			fields: map[string]bool{"iban": true},
+			want:   `{"field":"iban","iban" : "******************3000"}`,
+		},
+		{
+			name:   "escapes and multibyte characters",
+			body:   `{"holder":"Zoë \"Z\" Müller"}`,
+			fields: map[string]bool{"holder": true},
+			want:   `{"holder":"**********ller"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(MaskFields([]byte(tt.body), tt.fields)))
+		})
+	}
+}
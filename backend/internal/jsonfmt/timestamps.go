@@ -0,0 +1,163 @@
+// Package jsonfmt formats JSON API responses.
+//
+// Handlers render responses with JSON, which encodes them with the encoder configured for the
+// request's Options, so every time.Time (and *time.Time) value is rendered in the requested
+// format and timezone wherever it appears, while strings, including the user's data, are never
+// touched. MaskFields masks the values of sensitive fields.
+package jsonfmt
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/gin-gonic/gin"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// TimestampFormat selects how timestamps are rendered
+type TimestampFormat string
+
+const (
+	// RFC3339 renders timestamps as RFC3339 strings (with fractional seconds when present)
+	// Zero timestamps are rendered as 0001-01-01T00:00:00Z
+	RFC3339 TimestampFormat = "rfc3339"
+	// EpochMillis renders timestamps as integer milliseconds since the Unix epoch
+	// Zero timestamps (0001-01-01T00:00:00Z) are rendered as null
+	EpochMillis TimestampFormat = "epoch_millis"
+)
+
+// ParseFormat validates a format name (case-insensitive); empty means RFC3339
+func ParseFormat(value string) (TimestampFormat, error) {
+	switch TimestampFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case "", RFC3339:
+		return RFC3339, nil
+	case EpochMillis:
+		return EpochMillis, nil
+	default:
+		return "", fmt.Errorf("unsupported timestamp format %q (supported: %s, %s)", value, RFC3339, EpochMillis)
+	}
+}
+
+// Options describes how to render timestamps
+type Options struct {
+	Format   TimestampFormat
+	Location *time.Location // timezone for RFC3339 output; nil means UTC
+}
+
+// optionsKey is the gin context key SetOptions stores the request's Options under
+const optionsKey = "timestamp_options"
+
+// SetOptions sets how JSON renders timestamps for the rest of the request
+func SetOptions(c *gin.Context, opts Options) {
+	c.Set(optionsKey, opts)
+}
+
+// OptionsFrom returns the Options set for the request; RFC3339 in UTC if none were set
+func OptionsFrom(c *gin.Context) Options {
+	value, _ := c.Get(optionsKey)
+	opts, _ := value.(Options)
+	return opts
+}
+
+// JSON renders obj as the JSON response body, like gin's c.JSON, with the encoder for the
+// request's Options
+func JSON(c *gin.Context, code int, obj any) {
+	c.Render(code, jsonRender{api: Encoder(OptionsFrom(c)), data: obj})
+}
+
+// encoders caches an encoder per format and timezone, as each keeps its own type cache
+var encoders sync.Map
+
+// Encoder returns the JSON encoder rendering timestamps as opts says. It otherwise behaves like
+// encoding/json: struct tags, omitempty, json.Marshaler and map key order are honoured.
+func Encoder(opts Options) jsoniter.API {
+	if opts.Format == "" {
+		opts.Format = RFC3339
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	key := string(opts.Format) + "|" + opts.Location.String()
+	if api, ok := encoders.Load(key); ok {
+		return api.(jsoniter.API)
+	}
+	api := jsoniter.Config{EscapeHTML: true, SortMapKeys: true, ValidateJsonRawMessage: true}.Froze()
+	api.RegisterExtension(&timestampExtension{opts: opts})
+	actual, _ := encoders.LoadOrStore(key, api)
+	return actual.(jsoniter.API)
+}
+
+var (
+	timeType    = reflect2.TypeOf(time.Time{})
+	timePtrType = reflect2.TypeOf(&time.Time{})
+)
+
+// timestampExtension replaces time.Time's own MarshalJSON with timestampEncoder. *time.Time needs
+// its own encoder, as jsoniter would otherwise use the MarshalJSON it has too.
+type timestampExtension struct {
+	jsoniter.DummyExtension
+	opts Options
+}
+
+func (e *timestampExtension) CreateEncoder(typ reflect2.Type) jsoniter.ValEncoder {
+	switch typ {
+	case timeType:
+		return timestampEncoder(e.opts)
+	case timePtrType:
+		return &jsoniter.OptionalEncoder{ValueEncoder: timestampEncoder(e.opts)}
+	}
+	return nil
+}
+
+// timestampEncoder renders time.Time values as Options says
+type timestampEncoder Options
+
+func (e timestampEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	t := *(*time.Time)(ptr)
+	switch e.Format {
+	case EpochMillis:
+		if t.IsZero() {
+			stream.WriteNil()
+		} else {
+			stream.WriteInt64(t.UnixMilli())
+		}
+	default:
+		if !t.IsZero() {
+			t = t.In(e.Location)
+		}
+		stream.WriteString(t.Format(time.RFC3339Nano))
+	}
+}
+
+// IsEmpty is false like for any struct: encoding/json never omits a time.Time
+func (e timestampEncoder) IsEmpty(unsafe.Pointer) bool {
+	return false
+}
+
+// jsonRender is gin's render.JSON with a configured encoder
+type jsonRender struct {
+	api  jsoniter.API
+	data any
+}
+
+func (r jsonRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	body, err := r.api.Marshal(r.data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+func (r jsonRender) WriteContentType(w http.ResponseWriter) {
+	if header := w.Header(); len(header["Content-Type"]) == 0 {
+		header["Content-Type"] = []string{"application/json; charset=utf-8"}
+	}
+}
@@ -0,0 +1,139 @@
+package jsonfmt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	Name      string     `json:"name"`
+	Note      string     `json:"note,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Raw       json.RawMessage
+}
+
+func TestEncoder(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	paris := time.FixedZone("CET", 3600)
+	noon := time.Date(2026, 3, 1, 12, 0, 0, 0, paris)
+	deleted := time.Date(2026, 7, 1, 16, 0, 0, 500_000_000, time.UTC)
+
+	tests := []struct {
+		name  string
+		value any
+		opts  Options
+		want  string
+	}{
+		{
+			name:  "RFC3339 in UTC by default",
+			value: record{Name: "Acme", CreatedAt: noon, Raw: json.RawMessage(`{}`)},
+			want:  `{"name":"Acme","created_at":"2026-03-01T11:00:00Z","Raw":{}}`,
+		},
+		{
+			name:  "RFC3339 in a time zone, pointers too",
+			value: record{CreatedAt: noon, DeletedAt: &deleted, Raw: json.RawMessage(`{}`)},
+			opts:  Options{Format: RFC3339, Location: newYork},
+			want:  `{"name":"","created_at":"2026-03-01T06:00:00-05:00","deleted_at":"2026-07-01T12:00:00.5-04:00","Raw":{}}`,
+		},
+		{
+			name:  "epoch millis",
+			value: record{CreatedAt: time.Unix(1, 500_000_000), DeletedAt: &deleted, Raw: json.RawMessage(`{}`)},
+			opts:  Options{Format: EpochMillis},
+			want:  `{"name":"","created_at":1500,"deleted_at":1782921600500,"Raw":{}}`,
+		},
+		{
+			name:  "zero timestamps",
+			value: map[string]any{"rfc": time.Time{}},
+			want:  `{"rfc":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:  "zero timestamps in epoch millis are null",
+			value: map[string]any{"epoch": time.Time{}},
+			opts:  Options{Format: EpochMillis},
+			want:  `{"epoch":null}`,
+		},
+		{
+			name:  "strings that look like timestamps are the user's data",
+			value: map[string]any{"to": "2026-03-01T12:00:00+01:00", "note": "2026-03-01T12:00:00Z"},
+			opts:  Options{Format: EpochMillis},
+			want:  `{"note":"2026-03-01T12:00:00Z","to":"2026-03-01T12:00:00+01:00"}`,
+		},
+		{
+			name:  "timestamps in maps and slices",
+			value: map[string]any{"b": []time.Time{noon}, "a": map[string]time.Time{"x": noon}},
+			opts:  Options{Format: EpochMillis},
+			want:  `{"a":{"x":1772362800000},"b":[1772362800000]}`,
+		},
+		{
+			name:  "html escaped like encoding/json",
+			value: map[string]string{"q": "<a&b>"},
+			want:  `{"q":"\u003ca\u0026b\u003e"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := Encoder(tt.opts).Marshal(tt.value)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(body))
+			assert.Equal(t, tt.want, string(body), "key order must match encoding/json")
+		})
+	}
+}
+
+func TestEncoderIsCached(t *testing.T) {
+	assert.Same(t, Encoder(Options{}), Encoder(Options{Format: RFC3339, Location: time.UTC}))
+	assert.NotSame(t, Encoder(Options{}), Encoder(Options{Format: EpochMillis}))
+}
+
+func TestJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		SetOptions(c, Options{Format: EpochMillis})
+		JSON(c, http.StatusCreated, gin.H{"created_at": time.UnixMilli(42)})
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `{"created_at":42}`, rec.Body.String())
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    TimestampFormat
+		wantErr bool
+	}{
+		{value: "", want: RFC3339},
+		{value: "rfc3339", want: RFC3339},
+		{value: " EPOCH_MILLIS ", want: EpochMillis},
+		{value: "unix", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseFormat(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
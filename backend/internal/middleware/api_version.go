@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersionHeader names the API version that answered a request, e.g. v2
+const APIVersionHeader = "API-Version"
+
+// apiVersionPattern matches the version prefix of the versioned route groups, /api/v1/...
+var apiVersionPattern = regexp.MustCompile(`^/api/(v[0-9]+)/`)
+
+// APIVersion tags each request to a versioned route group with its version, returned in the
+// API-Version header. It goes by the matched route, so register it globally ahead of middleware
+// that may answer the request: RequestID shapes JSON error responses by the version, for the
+// answers of middleware as much as of handlers.
+func APIVersion() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if match := apiVersionPattern.FindStringSubmatch(c.FullPath()); match != nil {
+			c.Set("api_version", match[1])
+			c.Header(APIVersionHeader, match[1])
+		}
+		c.Next()
+	}
+}
+
+// APIVersionFrom returns the version APIVersion tagged the request with, or "" outside the
+// versioned routes
+func APIVersionFrom(c *gin.Context) string {
+	return c.GetString("api_version")
+}
+
+// errorCodes name the statuses of v2 error responses that do not name their own "code"
+var errorCodes = map[int]string{
+	http.StatusBadRequest:            "invalid_request",
+	http.StatusUnauthorized:          "unauthenticated",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusConflict:              "conflict",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnprocessableEntity:   "unprocessable",
+	http.StatusTooManyRequests:       "rate_limited",
+	http.StatusBadGateway:            "upstream_error",
+	http.StatusServiceUnavailable:    "unavailable",
+}
+
+// errorCode names status for a v2 error response
+func errorCode(status int) string {
+	if code, ok := errorCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return "internal"
+	}
+	return "error"
+}
+
+// versionedError shapes a JSON error response, {"error": "message", ...}, for the API version:
+// v1 and unversioned routes get request_id added, v2 gets an error object of its code, message
+// and request ID, with the other fields as its details:
+//
+//	{"error": {"code": "not_found", "message": "Country not found", "request_id": "..."}}
+//
+// Any other body is returned as it is.
+func versionedError(body []byte, status int, id, version string) []byte {
+	if version == "" || version == "v1" {
+		return withRequestID(body, id)
+	}
+
+	var envelope map[string]json.RawMessage
+	if json.Unmarshal(bytes.TrimSpace(body), &envelope) != nil {
+		return body
+	}
+	var message string
+	if json.Unmarshal(envelope["error"], &message) != nil {
+		return body
+	}
+
+	object := struct {
+		Code      string                     `json:"code"`
+		Message   string                     `json:"message"`
+		RequestID string                     `json:"request_id"`
+		Details   map[string]json.RawMessage `json:"details,omitempty"`
+	}{Code: errorCode(status), Message: message, RequestID: id}
+	if code := envelope["code"]; code != nil && json.Unmarshal(code, &object.Code) == nil {
+		delete(envelope, "code")
+	}
+	delete(envelope, "error")
+	delete(envelope, "request_id")
+	if len(envelope) > 0 {
+		object.Details = envelope
+	}
+
+	out, err := json.Marshal(gin.H{"error": object})
+	if err != nil {
+		return body
+	}
+	return out
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBodySize refuses requests whose body is over limit bytes with 413. A request declaring a
+// larger Content-Length is refused before its body is read; otherwise reading stops with an
+// *http.MaxBytesError once the limit is passed, so a handler never works on a truncated body. A
+// limit of 0 or less leaves requests unlimited.
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	if limit <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Request body exceeds the limit of %d bytes", limit)})
+			return
+		}
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       int64
+		body        string
+		chunked     bool // sent without a Content-Length, so only reading can find it too large
+		wantStatus  int
+		wantHandled bool
+		wantRead    int
+	}{
+		{name: "under the limit", limit: 16, body: "0123456789", wantStatus: http.StatusOK, wantHandled: true, wantRead: 10},
+		{name: "exactly the limit", limit: 10, body: "0123456789", wantStatus: http.StatusOK, wantHandled: true, wantRead: 10},
+		{name: "declared over the limit is refused unread", limit: 9, body: "0123456789", wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "streamed over the limit stops the read", limit: 9, body: "0123456789", chunked: true, wantStatus: http.StatusRequestEntityTooLarge, wantHandled: true},
+		{name: "streamed under the limit", limit: 16, body: "0123456789", chunked: true, wantStatus: http.StatusOK, wantHandled: true, wantRead: 10},
+		{name: "no body", limit: 9, wantStatus: http.StatusOK, wantHandled: true},
+		{name: "no limit", limit: 0, body: strings.Repeat("x", 1<<20), wantStatus: http.StatusOK, wantHandled: true, wantRead: 1 << 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled, read := false, 0
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(MaxBodySize(tt.limit))
+			router.POST("/", func(c *gin.Context) {
+				handled = true
+				body, err := io.ReadAll(c.Request.Body)
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					c.Status(http.StatusRequestEntityTooLarge)
+					return
+				}
+				read = len(body)
+				c.Status(http.StatusOK)
+			})
+
+			var body io.Reader = http.NoBody
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			if tt.chunked {
+				req.ContentLength = -1
+				req.Body = io.NopCloser(strings.NewReader(tt.body))
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantHandled, handled)
+			assert.Equal(t, tt.wantRead, read)
+			if !tt.wantHandled {
+				assert.JSONEq(t, `{"error":"Request body exceeds the limit of 9 bytes"}`, rec.Body.String())
+			}
+		})
+	}
+}
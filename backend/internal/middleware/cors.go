@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// originPattern is an allowed origin with a *. wildcard label, such as https://*.example.com,
+// which matches any subdomain of the domain (but not the domain itself)
+type originPattern struct {
+	prefix string // Scheme and ://
+	suffix string // . and the domain, plus any port
+}
+
+func (p originPattern) matches(origin string) bool {
+	if len(origin) <= len(p.prefix)+len(p.suffix) || !strings.HasPrefix(origin, p.prefix) || !strings.HasSuffix(origin, p.suffix) {
+		return false
+	}
+	subdomain := origin[len(p.prefix) : len(origin)-len(p.suffix)]
+	return !strings.ContainsAny(subdomain, "/:@")
+}
+
+// originMatcher decides whether a request's Origin is allowed
+type originMatcher struct {
+	any      bool
+	exact    map[string]bool
+	patterns []originPattern
+}
+
+func newOriginMatcher(allowed []string) originMatcher {
+	m := originMatcher{exact: map[string]bool{}}
+	for _, origin := range allowed {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		if origin == "*" {
+			m.any = true
+		} else if scheme, domain, ok := strings.Cut(origin, "://*."); ok {
+			m.patterns = append(m.patterns, originPattern{prefix: scheme + "://", suffix: "." + domain})
+		} else {
+			m.exact[origin] = true
+		}
+	}
+	return m
+}
+
+// allows reports whether origin may read responses; a request without an Origin is allowed only
+// when any origin is
+func (m originMatcher) allows(origin string) bool {
+	if m.any {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if m.exact[origin] {
+		return true
+	}
+	for _, p := range m.patterns {
+		if p.matches(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS answers preflight requests and adds the CORS headers to responses for the origins in
+// cors.allowed_origins: exact origins, *. wildcard subdomain patterns, or * for any. Preflights
+// from other origins are refused with 403; their other requests are served without CORS headers,
+// so the browser keeps the response from the page.
+func CORS(cfg *config.Config) gin.HandlerFunc {
+	origins := newOriginMatcher(cfg.CORS.AllowedOrigins)
+	allowMethods := strings.Join(cfg.CORS.AllowedMethods, ",")
+	allowHeaders := strings.Join(cfg.CORS.AllowedHeaders, ",")
+	exposeHeaders := strings.Join(cfg.CORS.ExposedHeaders, ",")
+	maxAge := ""
+	if seconds := int(cfg.CORS.MaxAge.Seconds()); seconds > 0 {
+		maxAge = strconv.Itoa(seconds)
+	}
+
+	return func(c *gin.Context) {
+		origin := c.Request.Header.Get("Origin")
+		allowed := origins.allows(origin)
+		header := c.Writer.Header()
+
+		// The response depends on the origin, so caches must not share it between origins
+		header.Add("Vary", "Origin")
+		if allowed {
+			if origin != "" {
+				header.Set("Access-Control-Allow-Origin", origin)
+			} else {
+				header.Set("Access-Control-Allow-Origin", "*")
+			}
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			if !allowed {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			header.Set("Access-Control-Allow-Methods", allowMethods)
+			header.Set("Access-Control-Allow-Headers", allowHeaders)
+			if maxAge != "" {
+				header.Set("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if allowed && exposeHeaders != "" {
+			header.Set("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+func TestOriginMatcher(t *testing.T) {
+	allowed := []string{"https://app.example.com", " HTTPS://*.Example.org ", "http://*.localhost:5173"}
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{origin: "https://app.example.com", want: true},
+		{origin: "https://APP.example.com", want: true},
+		{origin: "https://api.example.com"},
+		{origin: "http://app.example.com"},
+		{origin: "https://app.example.com:8443"},
+		{origin: "https://portal.example.org", want: true},
+		{origin: "https://eu.portal.example.org", want: true},
+		{origin: "https://example.org"},
+		{origin: "https://.example.org"},
+		{origin: "https://evil-example.org"},
+		{origin: "https://portal.example.org.evil.com"},
+		{origin: "http://portal.example.org"},
+		{origin: "https://portal.example.org:8443"},
+		{origin: "https://evil.com/.example.org"},
+		{origin: "https://user@portal.example.org"},
+		{origin: "http://web.localhost:5173", want: true},
+		{origin: "http://web.localhost"},
+		{origin: "http://web.localhost:3000"},
+		{origin: ""},
+	}
+	matcher := newOriginMatcher(allowed)
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			assert.Equal(t, tt.want, matcher.allows(tt.origin))
+		})
+	}
+
+	anyOrigin := newOriginMatcher([]string{"https://app.example.com", "*"})
+	assert.True(t, anyOrigin.allows("https://anything.test"))
+	assert.True(t, anyOrigin.allows(""), "a request without an Origin is allowed when any origin is")
+}
+
+func TestCORS(t *testing.T) {
+	cfg := &config.Config{CORS: config.CORSConfig{
+		AllowedOrigins: []string{"https://*.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		ExposedHeaders: []string{"X-Total-Count", "X-Request-ID"},
+		MaxAge:         10 * time.Minute,
+	}}
+	tests := []struct {
+		name             string
+		method           string
+		origin           string
+		wantStatus       int
+		wantAllowOrigin  string
+		wantAllowMethods string
+		wantMaxAge       string
+		wantExpose       string
+	}{
+		{name: "preflight from a matching origin", method: http.MethodOptions, origin: "https://app.example.com", wantStatus: http.StatusNoContent, wantAllowOrigin: "https://app.example.com", wantAllowMethods: "GET,POST", wantMaxAge: "600"},
+		{name: "preflight from another origin", method: http.MethodOptions, origin: "https://evil.test", wantStatus: http.StatusForbidden},
+		{name: "request from a matching origin", method: http.MethodGet, origin: "https://app.example.com", wantStatus: http.StatusOK, wantAllowOrigin: "https://app.example.com", wantExpose: "X-Total-Count,X-Request-ID"},
+		{name: "request from another origin is served without CORS headers", method: http.MethodGet, origin: "https://evil.test", wantStatus: http.StatusOK},
+		{name: "request without an origin", method: http.MethodGet, wantStatus: http.StatusOK},
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CORS(cfg))
+	router.GET("/lei", func(c *gin.Context) { c.Status(http.StatusOK) })
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/lei", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+			assert.Equal(t, tt.wantAllowOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.wantAllowMethods, rec.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, tt.wantMaxAge, rec.Header().Get("Access-Control-Max-Age"))
+			assert.Equal(t, tt.wantExpose, rec.Header().Get("Access-Control-Expose-Headers"))
+			if tt.wantAllowOrigin != "" {
+				assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+			}
+		})
+	}
+}
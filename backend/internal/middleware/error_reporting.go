@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/errreport"
+)
+
+// ErrorReporting reports panics and 5xx responses to the error tracker, tagged with the route and
+// request ID. It must run inside gin.Recovery, which it passes panics on to, and inside RequestID,
+// whose buffered error response gives the report its message.
+func ErrorReporting() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if p := recover(); p != nil {
+				errreport.Panic(p, requestTags(c))
+				panic(p)
+			}
+		}()
+
+		c.Next()
+
+		if status := c.Writer.Status(); status >= http.StatusInternalServerError {
+			message := fmt.Sprintf("%s %s answered %d", c.Request.Method, requestRoute(c), status)
+			if reason := responseError(c); reason != "" {
+				message += ": " + reason
+			}
+			errreport.Message(message, requestTags(c))
+		}
+	}
+}
+
+// requestTags describes a request to the error tracker
+func requestTags(c *gin.Context) map[string]string {
+	tags := map[string]string{
+		"request_id": RequestIDFrom(c),
+		"method":     c.Request.Method,
+		"route":      requestRoute(c),
+	}
+	if userID, ok := c.Get("user_id"); ok && userID != nil {
+		tags["user_id"] = fmt.Sprint(userID)
+	}
+	return tags
+}
+
+// requestRoute is the route pattern (/api/v1/lei/:lei), so reports of one route group together
+func requestRoute(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return c.Request.URL.Path
+}
+
+// responseError is the error message of the JSON error response RequestID is holding, if any
+func responseError(c *gin.Context) string {
+	writer, ok := c.Writer.(*errorEnvelopeWriter)
+	if !ok || !writer.buffering {
+		return ""
+	}
+	var envelope struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(writer.buf.Bytes(), &envelope) != nil {
+		return ""
+	}
+	return envelope.Error
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+)
+
+// MaskFields masks the fields of masking.rules in JSON responses for callers whose token (checked
+// by JWTAuth, which must run first) carries none of the roles a rule lets see them. Responses to
+// callers allowed to see everything are not buffered. File downloads are not masked here: the
+// handlers writing them take the fields from MaskedFieldsFrom.
+func MaskFields(cfg config.MaskingConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		masked := MaskedFieldsFor(cfg, c.GetStringSlice("roles"))
+		if len(masked) == 0 {
+			c.Next()
+			return
+		}
+		c.Set("masked_fields", masked)
+
+		writer := &jsonBufferWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if writer.buffering {
+			_, _ = writer.ResponseWriter.Write(jsonfmt.MaskFields(writer.buf.Bytes(), masked))
+		}
+	}
+}
+
+// MaskedFieldsFrom returns the fields MaskFields masks for the caller, or nil if none
+func MaskedFieldsFrom(c *gin.Context) map[string]bool {
+	masked, _ := c.Get("masked_fields")
+	fields, _ := masked.(map[string]bool)
+	return fields
+}
+
+// MaskedFieldsFor returns the fields masked for a caller holding roles, or nil if none; shared with
+// the gRPC server, which masks its messages alike
+func MaskedFieldsFor(cfg config.MaskingConfig, roles []string) map[string]bool {
+	if !cfg.Enabled {
+		return nil
+	}
+	return maskedFields(cfg.Rules, roles)
+}
+
+// maskedFields returns the fields of the rules granting none of roles
+func maskedFields(rules []config.MaskingRule, roles []string) map[string]bool {
+	held := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		held[role] = true
+	}
+	masked := map[string]bool{}
+	for _, rule := range rules {
+		granted := false
+		for _, role := range rule.Roles {
+			granted = granted || held[role]
+		}
+		if !granted {
+			for _, field := range rule.Fields {
+				masked[field] = true
+			}
+		}
+	}
+	return masked
+}
+
+// jsonBufferWriter buffers JSON bodies so they can be masked once complete; other content types
+// (file downloads, event streams) and JSON sent as an attachment, which is streamed, pass
+// straight through
+type jsonBufferWriter struct {
+	gin.ResponseWriter
+	buf       bytes.Buffer
+	buffering bool
+	decided   bool
+}
+
+func (w *jsonBufferWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	w.buffering = strings.Contains(w.Header().Get("Content-Type"), "application/json") &&
+		!strings.HasPrefix(w.Header().Get("Content-Disposition"), "attachment")
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *jsonBufferWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *jsonBufferWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.buf.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *jsonBufferWriter) WriteString(s string) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.buf.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Flush is a no-op while buffering; streaming responses are not JSON and pass through
+func (w *jsonBufferWriter) Flush() {
+	w.decide()
+	if !w.buffering {
+		w.ResponseWriter.Flush()
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
 	"github.com/techie2000/axiom/internal/config"
 )
 
@@ -14,6 +16,10 @@ import (
 func JWTAuth(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+		// Browsers cannot set headers on a WebSocket handshake, so upgrades may pass the token as a query parameter
+		if authHeader == "" && strings.EqualFold(c.GetHeader("Upgrade"), "websocket") && c.Query("access_token") != "" {
+			authHeader = "Bearer " + c.Query("access_token")
+		}
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
 			c.Abort()
@@ -28,93 +34,126 @@ func JWTAuth(cfg *config.Config) gin.HandlerFunc {
 			return
 		}
 
-		tokenString := parts[1]
-
-		// Parse and validate token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Validate the signing algorithm to prevent algorithm confusion attacks
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(cfg.JWT.Secret), nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := ParseToken(cfg, parts[1])
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			c.Abort()
 			return
 		}
 
-		// Extract claims
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			c.Set("user_id", claims["user_id"])
-			c.Set("email", claims["email"])
+		c.Set("user_id", claims["user_id"])
+		c.Set("email", claims["email"])
+		c.Set("roles", ClaimRoles(claims))
+		// Tokens issued to an API client name the key they were issued for
+		if keyID, ok := claims["api_key_id"].(string); ok && keyID != "" {
+			c.Set("api_key_id", keyID)
 		}
 
 		c.Next()
 	}
 }
 
-// CORS middleware
-func CORS(cfg *config.Config) gin.HandlerFunc {
+// JWTAuthFor runs JWTAuth on the requests to an open route for which need is true, e.g. those that
+// make calls out of the service; the others pass without a token
+func JWTAuthFor(cfg *config.Config, need func(c *gin.Context) bool) gin.HandlerFunc {
+	auth := JWTAuth(cfg)
 	return func(c *gin.Context) {
-		origin := c.Request.Header.Get("Origin")
-
-		// DEBUG: Log CORS configuration and request
-		fmt.Printf("[CORS DEBUG] Origin: %s, Allowed Origins: %v\n", origin, cfg.CORS.AllowedOrigins)
-
-		// Check if the origin is in the allowed list
-		allowed := false
-		for _, allowedOrigin := range cfg.CORS.AllowedOrigins {
-			fmt.Printf("[CORS DEBUG] Checking: %s == %s\n", allowedOrigin, origin)
-			if allowedOrigin == "*" || allowedOrigin == origin {
-				allowed = true
-				fmt.Printf("[CORS DEBUG] MATCH FOUND!\n")
-				break
-			}
+		if need(c) {
+			auth(c)
+			return
 		}
+		c.Next()
+	}
+}
 
-		fmt.Printf("[ CORS DEBUG] Allowed: %v\n", allowed)
+// ParseToken validates a JWT signed with jwt.secret and returns its claims; shared with the gRPC
+// server, which takes the same tokens
+func ParseToken(cfg *config.Config, tokenString string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		// Validate the signing algorithm to prevent algorithm confusion attacks
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(cfg.JWT.Secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
 
-		// Set CORS headers if origin is allowed
-		if allowed {
-			if origin != "" {
-				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-				fmt.Printf("[CORS DEBUG] Set Access-Control-Allow-Origin: %s\n", origin)
-			} else if len(cfg.CORS.AllowedOrigins) > 0 && cfg.CORS.AllowedOrigins[0] == "*" {
-				c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-				fmt.Printf("[CORS DEBUG] Set Access-Control-Allow-Origin: *\n")
+// ClaimRoles reads a token's roles from its "roles" claim (a list, or a single name) and its
+// "role" claim
+func ClaimRoles(claims jwt.MapClaims) []string {
+	var roles []string
+	for _, key := range []string{"roles", "role"} {
+		switch value := claims[key].(type) {
+		case string:
+			if value != "" {
+				roles = append(roles, value)
+			}
+		case []interface{}:
+			for _, item := range value {
+				if role, ok := item.(string); ok && role != "" {
+					roles = append(roles, role)
+				}
 			}
-			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-			c.Writer.Header().Set("Vary", "Origin")
-			c.Writer.Header().Set("Access-Control-Allow-Methods", strings.Join(cfg.CORS.AllowedMethods, ","))
-			c.Writer.Header().Set("Access-Control-Allow-Headers", strings.Join(cfg.CORS.AllowedHeaders, ","))
 		}
+	}
+	return roles
+}
 
-		if c.Request.Method == "OPTIONS" {
-			if allowed {
-				c.AbortWithStatus(http.StatusNoContent)
-			} else {
-				c.AbortWithStatus(http.StatusForbidden)
-			}
+// RequireRole lets through only requests whose token (checked by JWTAuth, which must run first)
+// carries role, answering others with 403. An empty role lets every authenticated user through.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if role == "" {
+			c.Next()
 			return
 		}
-
-		c.Next()
+		roles, _ := c.Get("roles")
+		if list, ok := roles.([]string); ok {
+			for _, r := range list {
+				if r == role {
+					c.Next()
+					return
+				}
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This operation requires the " + role + " role"})
 	}
 }
 
-// Logger middleware
+// Logger logs each request once it has been answered, through the request's logger so the line
+// carries its request_id (RequestID must run first). Health checks and probes are not logged.
 func Logger() gin.HandlerFunc {
-	return gin.LoggerWithConfig(gin.LoggerConfig{
-		SkipPaths: []string{"/health"},
-	})
-}
-
-// RateLimit middleware (simplified version)
-func RateLimit() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement proper rate limiting with Redis or in-memory store
+		if path := c.Request.URL.Path; path == "/ready" || path == "/health" || strings.HasPrefix(path, "/health/") {
+			c.Next()
+			return
+		}
+		start := time.Now()
+		path := c.Request.URL.Path
+
 		c.Next()
+
+		status := c.Writer.Status()
+		event := log.Ctx(c.Request.Context()).Info()
+		if status >= http.StatusInternalServerError {
+			event = log.Ctx(c.Request.Context()).Error()
+		}
+		if len(c.Errors) > 0 {
+			event = event.Str("errors", c.Errors.String())
+		}
+		event.Str("method", c.Request.Method).
+			Str("path", path).
+			Int("status", status).
+			Dur("latency", time.Since(start)).
+			Str("client_ip", c.ClientIP()).
+			Msg("Request")
 	}
 }
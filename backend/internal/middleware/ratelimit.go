@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/ratelimit"
+)
+
+// Rate limit response headers
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"     // Requests a full bucket allows
+	RateLimitRemainingHeader = "X-RateLimit-Remaining" // Requests left before the limit applies
+)
+
+// rateLimitWarnInterval spaces out the warnings while the store is failing
+const rateLimitWarnInterval = time.Minute
+
+// RateLimit limits the requests of a route group to its rule in rules, counting each API key's or
+// user's requests (once JWTAuth has identified them) or else each client IP's in a bucket of their
+// own. A refused request is answered with 429 and Retry-After. With no store, or a rule without a
+// rate, requests are not limited; if the store fails they are let through, so an outage of Redis
+// does not take the API down with it. The rule is looked up per request, so a reload applies at
+// once.
+func RateLimit(store ratelimit.Store, rules *ratelimit.Rules, group string) gin.HandlerFunc {
+	if store == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	var lastWarned atomic.Int64
+
+	return func(c *gin.Context) {
+		rule := rules.Rule(group)
+		if rule.Rate <= 0 || rule.Burst < 1 {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(c.Request.Context(), group+":"+rateLimitKey(c), rule)
+		if err != nil {
+			if now := time.Now().UnixNano(); now-lastWarned.Load() >= int64(rateLimitWarnInterval) {
+				lastWarned.Store(now)
+				log.Warn().Err(err).Str("group", group).Msg("Rate limit store unavailable; requests are not limited")
+			}
+			c.Next()
+			return
+		}
+
+		c.Header(RateLimitLimitHeader, strconv.Itoa(rule.Burst))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		if !result.Allowed {
+			seconds := int(math.Ceil(result.RetryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(max(seconds, 1)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitKey identifies whose bucket a request is counted in: an API client's keys have a bucket
+// each, apart from the user they were issued to
+func rateLimitKey(c *gin.Context) string {
+	if keyID := c.GetString("api_key_id"); keyID != "" {
+		return "key:" + keyID
+	}
+	if userID, ok := c.Get("user_id"); ok && userID != nil {
+		return fmt.Sprintf("user:%v", userID)
+	}
+	return "ip:" + c.ClientIP()
+}
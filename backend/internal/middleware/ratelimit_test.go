@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/ratelimit"
+)
+
+// failingStore is a store whose backend is down
+type failingStore struct{ calls int }
+
+func (s *failingStore) Take(context.Context, string, config.RateLimitRule) (ratelimit.Result, error) {
+	s.calls++
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+// rateLimitedRouter serves GET / behind RateLimit for the protected group, identifying the caller
+// from the X-User and X-Key test headers as JWTAuth would from a token
+func rateLimitedRouter(store ratelimit.Store, rule config.RateLimitRule) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-User"); user != "" {
+			c.Set("user_id", user)
+		}
+		if key := c.GetHeader("X-Key"); key != "" {
+			c.Set("api_key_id", key)
+		}
+	})
+	rules := ratelimit.NewRules(config.RateLimitConfig{Protected: rule})
+	router.Use(RateLimit(store, rules, ratelimit.GroupProtected))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	return router
+}
+
+func get(router *gin.Engine, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	router := rateLimitedRouter(ratelimit.NewMemoryStore(), config.RateLimitRule{Rate: 0.25, Burst: 2})
+
+	first := get(router, nil)
+	assert.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, "2", first.Header().Get(RateLimitLimitHeader))
+	assert.Equal(t, "1", first.Header().Get(RateLimitRemainingHeader))
+	assert.Empty(t, first.Header().Get("Retry-After"))
+
+	second := get(router, nil)
+	assert.Equal(t, http.StatusNoContent, second.Code)
+	assert.Equal(t, "0", second.Header().Get(RateLimitRemainingHeader))
+
+	refused := get(router, nil)
+	assert.Equal(t, http.StatusTooManyRequests, refused.Code)
+	assert.Equal(t, "2", refused.Header().Get(RateLimitLimitHeader))
+	assert.Equal(t, "0", refused.Header().Get(RateLimitRemainingHeader))
+	assert.Equal(t, "4", refused.Header().Get("Retry-After"), "seconds until a token is back, rounded up")
+	assert.JSONEq(t, `{"error":"Rate limit exceeded"}`, refused.Body.String())
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		first    map[string]string
+		second   map[string]string
+		separate bool
+	}{
+		{name: "same IP", separate: false},
+		{name: "two IPs", second: map[string]string{"X-Forwarded-For": "10.0.0.2"}, separate: true},
+		{name: "users behind one IP", first: map[string]string{"X-User": "1"}, second: map[string]string{"X-User": "2"}, separate: true},
+		{name: "a user from two IPs", first: map[string]string{"X-User": "1"}, second: map[string]string{"X-User": "1", "X-Forwarded-For": "10.0.0.2"}, separate: false},
+		{name: "a user's API key", first: map[string]string{"X-User": "1"}, second: map[string]string{"X-User": "1", "X-Key": "k1"}, separate: true},
+		{name: "two API keys of a user", first: map[string]string{"X-User": "1", "X-Key": "k1"}, second: map[string]string{"X-User": "1", "X-Key": "k2"}, separate: true},
+		{name: "an API key with another user", first: map[string]string{"X-User": "1", "X-Key": "k1"}, second: map[string]string{"X-User": "2", "X-Key": "k1"}, separate: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := rateLimitedRouter(ratelimit.NewMemoryStore(), config.RateLimitRule{Rate: 1, Burst: 1})
+
+			assert.Equal(t, http.StatusNoContent, get(router, tt.first).Code)
+			want := http.StatusTooManyRequests
+			if tt.separate {
+				want = http.StatusNoContent
+			}
+			assert.Equal(t, want, get(router, tt.second).Code)
+		})
+	}
+}
+
+func TestRateLimitFailsOpen(t *testing.T) {
+	store := &failingStore{}
+	router := rateLimitedRouter(store, config.RateLimitRule{Rate: 1, Burst: 1})
+
+	for range 3 {
+		rec := get(router, nil)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get(RateLimitLimitHeader))
+	}
+	assert.Equal(t, 3, store.calls)
+}
+
+func TestRateLimitUnlimited(t *testing.T) {
+	store := &failingStore{}
+
+	for _, rule := range []config.RateLimitRule{{}, {Rate: 1}, {Burst: 1}} {
+		rec := get(rateLimitedRouter(store, rule), nil)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+	assert.Zero(t, store.calls, "a rule without a rate takes no token")
+
+	rec := get(rateLimitedRouter(nil, config.RateLimitRule{Rate: 1, Burst: 1}), nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// RequestIDHeader carries the ID a request is logged under, in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern accepts the IDs proxies and clients commonly send (UUIDs, trace IDs); anything
+// else is replaced, so a caller cannot inject text into the logs
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID gives every request an ID: the caller's X-Request-ID if it sent a usable one, else a
+// new UUID. The ID is returned in X-Request-ID and added as request_id to JSON error responses
+// (shaped for the API version, see APIVersion), so a user reporting an error can quote it. The
+// request's context carries a logger that adds request_id to every line; log through
+// log.Ctx(c.Request.Context()) to use it. Handlers pass the context on to the services, which log
+// with log.Ctx(ctx), and to the repositories, whose queries GORM logs with it.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+		c.Set("request_id", id)
+		c.Header(RequestIDHeader, id)
+
+		logger := log.Logger.With().Str("request_id", id).Logger()
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context()))
+
+		writer := &errorEnvelopeWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if writer.buffering {
+			_, _ = writer.ResponseWriter.Write(versionedError(writer.buf.Bytes(), writer.Status(), id, APIVersionFrom(c)))
+		}
+	}
+}
+
+// RequestIDFrom returns the ID RequestID gave the request, or "" outside it
+func RequestIDFrom(c *gin.Context) string {
+	return c.GetString("request_id")
+}
+
+// withRequestID adds request_id to a JSON error object, keeping its other fields in order; any
+// other body is returned as it is
+func withRequestID(body []byte, id string) []byte {
+	var envelope map[string]json.RawMessage
+	if json.Unmarshal(body, &envelope) != nil || envelope["error"] == nil || envelope["request_id"] != nil {
+		return body
+	}
+	trimmed := bytes.TrimRight(body, " \t\r\n")
+	value, _ := json.Marshal(id)
+	out := make([]byte, 0, len(trimmed)+len(value)+16)
+	out = append(out, trimmed[:len(trimmed)-1]...)
+	out = append(out, `,"request_id":`...)
+	out = append(out, value...)
+	return append(out, '}')
+}
+
+// errorEnvelopeWriter buffers JSON error responses so the request ID can be added to them;
+// successful responses and other content types pass straight through
+type errorEnvelopeWriter struct {
+	gin.ResponseWriter
+	buf       bytes.Buffer
+	buffering bool
+	decided   bool
+}
+
+func (w *errorEnvelopeWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	w.buffering = w.Status() >= http.StatusBadRequest &&
+		strings.Contains(w.Header().Get("Content-Type"), "application/json")
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *errorEnvelopeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *errorEnvelopeWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.buf.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorEnvelopeWriter) WriteString(s string) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.buf.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Flush is a no-op while buffering; error bodies are small and sent once complete
+func (w *errorEnvelopeWriter) Flush() {
+	w.decide()
+	if !w.buffering {
+		w.ResponseWriter.Flush()
+	}
+}
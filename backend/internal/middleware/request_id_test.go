@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/service"
+	"github.com/techie2000/axiom/pkg/logger"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// purgedDeadLetters is a dead letter repository purging a fixed number of items
+type purgedDeadLetters struct {
+	repository.DeadLetterRepository
+}
+
+func (purgedDeadLetters) Purge(ctx context.Context, filter repository.DeadLetterFilter) (int64, error) {
+	return 3, nil
+}
+
+// captureLogs sends log.Logger's output to the returned buffer until the test ends
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = previous })
+	return &buf
+}
+
+// logLines decodes the JSON lines logged to buf
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, raw := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &line), "%s", raw)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestRequestIDReachesServiceLogs(t *testing.T) {
+	buf := captureLogs(t)
+	deadLetters := service.NewDeadLetterService(purgedDeadLetters{})
+	queries := logger.NewGORM(gormlogger.Error)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	router.DELETE("/dead-letters", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if _, err := deadLetters.Purge(ctx, repository.DeadLetterFilter{Source: service.DeadLetterSourceLEIRefresh}); err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		// A repository's query, as GORM logs it when it fails
+		queries.Trace(ctx, time.Now(), func() (string, int64) { return "DELETE FROM dead_letters", 0 }, errors.New("deadlock detected"))
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/dead-letters", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	lines := logLines(t, buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Dead letters purged", lines[0]["message"])
+	assert.Equal(t, service.DeadLetterSourceLEIRefresh, lines[0]["source"])
+	assert.Equal(t, "req-42", lines[0]["request_id"], "the service logs with the request's logger")
+	assert.Equal(t, "Database query failed", lines[1]["message"])
+	assert.Equal(t, "DELETE FROM dead_letters", lines[1]["sql"])
+	assert.Equal(t, "req-42", lines[1]["request_id"], "GORM logs with the query's context")
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+)
+
+// Request headers that override the configured timestamp rendering
+const (
+	TimestampFormatHeader = "X-Timestamp-Format" // rfc3339 (default) or epoch_millis
+	TimezoneHeader        = "X-Timezone"         // IANA zone for rfc3339 output, e.g. Europe/London
+)
+
+// TimestampFormat picks how jsonfmt.JSON renders the timestamps of the request's response:
+// RFC3339 in UTC by default, configurable globally (server.timestampformat, server.timezone)
+// and per request via the X-Timestamp-Format and X-Timezone headers
+func TimestampFormat(cfg *config.Config) gin.HandlerFunc {
+	defaultFormat, err := jsonfmt.ParseFormat(cfg.Server.TimestampFormat)
+	if err != nil {
+		defaultFormat = jsonfmt.RFC3339
+	}
+	defaultLocation, err := time.LoadLocation(cfg.Server.Timezone)
+	if err != nil || cfg.Server.Timezone == "" {
+		defaultLocation = time.UTC
+	}
+
+	return func(c *gin.Context) {
+		opts := jsonfmt.Options{Format: defaultFormat, Location: defaultLocation}
+
+		if value := c.GetHeader(TimestampFormatHeader); value != "" {
+			format, err := jsonfmt.ParseFormat(value)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			opts.Format = format
+		}
+
+		if value := c.GetHeader(TimezoneHeader); value != "" {
+			loc, err := time.LoadLocation(value)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid " + TimezoneHeader + " header: unknown timezone " + value})
+				return
+			}
+			opts.Location = loc
+		}
+
+		jsonfmt.SetOptions(c, opts)
+		c.Header(TimestampFormatHeader, string(opts.Format))
+		c.Next()
+	}
+}
@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// ErrEmailDisabled is returned by a Mailer when notifications.email is not enabled
+var ErrEmailDisabled = errors.New("email is not enabled (notifications.email.enabled)")
+
+// Attachment is a file sent with an email
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Mailer sends email, such as scheduled reports, through the notifications SMTP relay to any
+// recipients. Unlike Notify, Send blocks until the relay accepted the message.
+type Mailer interface {
+	Send(ctx context.Context, to []string, subject, body string, attachments ...Attachment) error
+}
+
+// NewMailer creates a mailer for the notifications SMTP relay
+func NewMailer(cfg config.EmailNotifierConfig) Mailer {
+	return newEmailSender(cfg)
+}
+
+// emailSender sends notifications through an SMTP relay, using STARTTLS when the server offers it
+type emailSender struct {
+	enabled bool
+	addr    string
+	auth    smtp.Auth // nil for relays that accept mail without authentication
+	from    string
+	to      []string
+}
+
+func newEmailSender(cfg config.EmailNotifierConfig) *emailSender {
+	s := &emailSender{
+		enabled: cfg.Enabled,
+		addr:    net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		from:    cfg.From,
+		to:      cfg.To,
+	}
+	if cfg.Username != "" {
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.SMTPHost)
+	}
+	return s
+}
+
+func (s *emailSender) send(ctx context.Context, n Notification) error {
+	return s.deliver(ctx, s.to, n.subject(), n.text(), n.Time)
+}
+
+func (s *emailSender) Send(ctx context.Context, to []string, subject, body string, attachments ...Attachment) error {
+	if !s.enabled {
+		return ErrEmailDisabled
+	}
+	return s.deliver(ctx, to, subject, body, time.Now(), attachments...)
+}
+
+func (s *emailSender) deliver(ctx context.Context, to []string, subject, body string, date time.Time, attachments ...Attachment) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	body = strings.ReplaceAll(body, "\n", "\r\n") + "\r\n"
+	if len(attachments) == 0 {
+		msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+		msg.WriteString(body)
+	} else if err := writeMultipart(&msg, body, attachments); err != nil {
+		return err
+	}
+
+	// net/smtp has no context support; run it aside so the timeout still bounds the caller
+	done := make(chan error, 1)
+	go func() { done <- smtp.SendMail(s.addr, s.auth, s.from, to, msg.Bytes()) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp %s: %w", s.addr, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("smtp %s: %w", s.addr, ctx.Err())
+	}
+}
+
+// writeMultipart writes a multipart/mixed body: the text, then each attachment base64-encoded
+func writeMultipart(msg *bytes.Buffer, body string, attachments []Attachment) error {
+	mw := multipart.NewWriter(msg)
+	fmt.Fprintf(msg, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mw.Boundary())
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=UTF-8"}})
+	if err != nil {
+		return err
+	}
+	part.Write([]byte(body))
+
+	for _, a := range attachments {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {a.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		})
+		if err != nil {
+			return err
+		}
+		encoded := base64.StdEncoding.EncodeToString(a.Data)
+		for len(encoded) > 76 {
+			fmt.Fprintf(part, "%s\r\n", encoded[:76])
+			encoded = encoded[76:]
+		}
+		fmt.Fprintf(part, "%s\r\n", encoded)
+	}
+	return mw.Close()
+}
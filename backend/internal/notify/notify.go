@@ -0,0 +1,157 @@
+// Package notify sends operational alerts (failed syncs, files out of retries, stalled processing)
+// to email, Slack and generic webhooks. Which channels receive an alert depends on its severity,
+// as configured in notifications.routes.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// Severities, in increasing order of urgency
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Channels alerts can be routed to
+const (
+	ChannelEmail   = "email"
+	ChannelSlack   = "slack"
+	ChannelWebhook = "webhook"
+)
+
+// sendTimeout bounds a single delivery so a slow channel never piles up goroutines
+const sendTimeout = 30 * time.Second
+
+// Notification is an alert about something an operator should look at
+type Notification struct {
+	Event    string            `json:"event"` // e.g. sync_failed, retries_exhausted, file_stalled
+	Severity string            `json:"severity"`
+	Title    string            `json:"title"`
+	Message  string            `json:"message"`
+	Fields   map[string]string `json:"fields,omitempty"` // context such as the job type or source file
+	Time     time.Time         `json:"time"`
+}
+
+// Notifier delivers notifications. Notify returns immediately; delivery happens in the background
+// and failures are logged and handed to the notifier's FailureFunc, so alerting never holds up the
+// work being reported on. Resend delivers a notification again over one channel, waiting for it.
+type Notifier interface {
+	Notify(n Notification)
+	Resend(ctx context.Context, channel string, n Notification) error
+}
+
+// FailureFunc is told about a notification a channel could not deliver
+type FailureFunc func(channel string, n Notification, err error)
+
+// sender delivers a notification over one channel
+type sender interface {
+	send(ctx context.Context, n Notification) error
+}
+
+type dispatcher struct {
+	senders map[string]sender
+	routes  map[string][]string // severity -> channels
+	failed  FailureFunc
+}
+
+// New creates a notifier for the enabled channels; failed, if not nil, is told about each failed
+// delivery. Routes naming a channel that is not enabled are ignored, so with nothing enabled
+// notifications are only logged.
+func New(cfg config.NotificationsConfig, failed FailureFunc) Notifier {
+	d := &dispatcher{senders: make(map[string]sender), routes: make(map[string][]string), failed: failed}
+	if cfg.Email.Enabled {
+		d.senders[ChannelEmail] = newEmailSender(cfg.Email)
+	}
+	if cfg.Slack.Enabled {
+		d.senders[ChannelSlack] = newSlackSender(cfg.Slack)
+	}
+	if cfg.Webhook.Enabled {
+		d.senders[ChannelWebhook] = newWebhookSender(cfg.Webhook)
+	}
+
+	for severity, channels := range cfg.Routes {
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		for _, channel := range channels {
+			channel = strings.ToLower(strings.TrimSpace(channel))
+			if _, ok := d.senders[channel]; !ok {
+				log.Debug().Str("severity", severity).Str("channel", channel).Msg("Notification route to a disabled channel ignored")
+				continue
+			}
+			d.routes[severity] = append(d.routes[severity], channel)
+		}
+	}
+
+	enabled := make([]string, 0, len(d.senders))
+	for channel := range d.senders {
+		enabled = append(enabled, channel)
+	}
+	sort.Strings(enabled)
+	log.Info().Strs("channels", enabled).Msg("Notifications configured")
+	return d
+}
+
+func (d *dispatcher) Notify(n Notification) {
+	if n.Time.IsZero() {
+		n.Time = time.Now().UTC()
+	}
+	log.Warn().
+		Str("event", n.Event).
+		Str("severity", n.Severity).
+		Strs("channels", d.routes[n.Severity]).
+		Msg("NOTIFY: " + n.Title)
+
+	for _, channel := range d.routes[n.Severity] {
+		go func(channel string, s sender) {
+			ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+			defer cancel()
+			if err := s.send(ctx, n); err != nil {
+				log.Error().Err(err).Str("channel", channel).Str("event", n.Event).Msg("Failed to send notification")
+				if d.failed != nil {
+					d.failed(channel, n, err)
+				}
+			}
+		}(channel, d.senders[channel])
+	}
+}
+
+func (d *dispatcher) Resend(ctx context.Context, channel string, n Notification) error {
+	s, ok := d.senders[channel]
+	if !ok {
+		return fmt.Errorf("notification channel %q is not enabled", channel)
+	}
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	return s.send(ctx, n)
+}
+
+// text renders a notification as plain text: the message followed by its fields, one per line
+func (n Notification) text() string {
+	var b strings.Builder
+	b.WriteString(n.Message)
+	keys := make([]string, 0, len(n.Fields))
+	for key := range n.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		b.WriteString("\n")
+	}
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\n%s: %s", key, n.Fields[key])
+	}
+	return b.String()
+}
+
+// subject is the one-line summary used as email subject and Slack heading
+func (n Notification) subject() string {
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(n.Severity), n.Title)
+}
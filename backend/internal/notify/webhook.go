@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// slackSender posts notifications to a Slack incoming webhook
+type slackSender struct {
+	url    string
+	client *http.Client
+}
+
+func newSlackSender(cfg config.SlackNotifierConfig) *slackSender {
+	return &slackSender{url: cfg.WebhookURL, client: &http.Client{}}
+}
+
+func (s *slackSender) send(ctx context.Context, n Notification) error {
+	payload := map[string]string{"text": fmt.Sprintf("*%s*\n%s", n.subject(), n.text())}
+	return postJSON(ctx, s.client, s.url, payload, nil)
+}
+
+// webhookSender posts notifications as JSON to an arbitrary endpoint (PagerDuty/Opsgenie bridges,
+// chat bots, ...)
+type webhookSender struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func newWebhookSender(cfg config.WebhookNotifierConfig) *webhookSender {
+	return &webhookSender{url: cfg.URL, headers: cfg.Headers, client: &http.Client{}}
+}
+
+func (s *webhookSender) send(ctx context.Context, n Notification) error {
+	return postJSON(ctx, s.client, s.url, n, s.headers)
+}
+
+// postJSON POSTs body as JSON and treats any non-2xx response as a failure
+func postJSON(ctx context.Context, client *http.Client, url string, body any, headers map[string]string) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, bytes.TrimSpace(snippet))
+	}
+	return nil
+}
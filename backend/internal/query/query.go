@@ -0,0 +1,307 @@
+// Package query implements the filter/sort query-parameter DSL shared by list endpoints.
+//
+// Syntax (all parameters optional):
+//
+//	?name=Acme                    equality (shorthand for name[eq]=Acme)
+//	?name[like]=acme              case-insensitive substring match
+//	?cfi[prefix]=ES               starts with (case-sensitive), e.g. a code prefix
+//	?type[in]=EQUITY,BOND         membership in a comma-separated list
+//	?created_at[gte]=2024-01-01   range operators (gt, gte, lt, lte) on dates and numbers
+//	?active[ne]=false             inequality
+//	?sortBy=name&sortOrder=desc   sorting on any filterable field
+//	?limit=50&offset=100          pagination
+//
+// Each repository declares the fields it allows (FieldSet), so unknown fields and
+// operators are rejected rather than interpolated into SQL. A parameter written without an
+// operator that is not a field (e.g. a "_" cache-buster) is ignored rather than rejected.
+//
+// A date without a time stands for the whole day: created_at[lte]=2024-01-31 includes all of
+// January 31st, created_at[gt]=2024-01-31 starts on February 1st and created_at=2024-01-31
+// matches any time that day.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Pagination defaults shared by list endpoints
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// Operator is a comparison operator in a filter condition
+type Operator string
+
+const (
+	OpEq     Operator = "eq"
+	OpNe     Operator = "ne"
+	OpLike   Operator = "like"
+	OpPrefix Operator = "prefix"
+	OpIn     Operator = "in"
+	OpGt     Operator = "gt"
+	OpGte    Operator = "gte"
+	OpLt     Operator = "lt"
+	OpLte    Operator = "lte"
+)
+
+var validOperators = map[Operator]bool{
+	OpEq: true, OpNe: true, OpLike: true, OpPrefix: true, OpIn: true,
+	OpGt: true, OpGte: true, OpLt: true, OpLte: true,
+}
+
+// reservedParams are query parameters that are not field filters
+var reservedParams = map[string]bool{
+	"limit": true, "offset": true, "sortBy": true, "sortOrder": true,
+}
+
+// FieldType determines how filter values are parsed and which operators apply
+type FieldType int
+
+const (
+	String FieldType = iota
+	Number
+	Bool
+	Date
+	UUID
+)
+
+// Field describes a filterable/sortable column
+type Field struct {
+	Column string
+	Type   FieldType
+}
+
+// FieldSet maps public field names to columns; anything not listed is rejected
+type FieldSet map[string]Field
+
+// Condition is a single parsed filter, e.g. created_at[gte]=2024-01-01
+type Condition struct {
+	Field     string
+	Op        Operator
+	Values    []string
+	Shorthand bool // written as field=value, without an operator
+}
+
+// ListQuery is the parsed form of a list endpoint's query string
+type ListQuery struct {
+	Limit      int
+	Offset     int
+	SortBy     string
+	SortOrder  string // asc or desc
+	Conditions []Condition
+}
+
+// ValidationError reports an invalid filter, sort field or pagination value
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// IsValidationError reports whether err (or any error it wraps) is a ValidationError
+func IsValidationError(err error) bool {
+	var ve *ValidationError
+	return errors.As(err, &ve)
+}
+
+func invalid(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+// Parse builds a ListQuery from URL query parameters
+// Field names are validated later against the repository's FieldSet in Apply
+func Parse(values url.Values) (*ListQuery, error) {
+	q := &ListQuery{
+		Limit:     DefaultLimit,
+		Offset:    0,
+		SortOrder: "asc",
+	}
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return nil, invalid("Invalid limit parameter (must be 1-%d)", MaxLimit)
+		}
+		q.Limit = limit
+	}
+
+	if raw := values.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return nil, invalid("Invalid offset parameter (must be >= 0)")
+		}
+		q.Offset = offset
+	}
+
+	q.SortBy = values.Get("sortBy")
+	if order := strings.ToLower(values.Get("sortOrder")); order != "" {
+		if order != "asc" && order != "desc" {
+			return nil, invalid("Invalid sortOrder parameter (must be asc or desc)")
+		}
+		q.SortOrder = order
+	}
+
+	for key, vals := range values {
+		if reservedParams[key] || len(vals) == 0 {
+			continue
+		}
+
+		field, op, shorthand := key, OpEq, true
+		if open := strings.Index(key, "["); open > 0 && strings.HasSuffix(key, "]") {
+			field = key[:open]
+			op = Operator(strings.ToLower(key[open+1 : len(key)-1]))
+			shorthand = false
+		}
+		if !validOperators[op] {
+			return nil, invalid("Unsupported operator '%s' on field '%s'", op, field)
+		}
+
+		for _, v := range vals {
+			cond := Condition{Field: field, Op: op, Shorthand: shorthand}
+			if op == OpIn {
+				for _, item := range strings.Split(v, ",") {
+					if item = strings.TrimSpace(item); item != "" {
+						cond.Values = append(cond.Values, item)
+					}
+				}
+				if len(cond.Values) == 0 {
+					return nil, invalid("Empty value list for '%s[in]'", field)
+				}
+			} else {
+				cond.Values = []string{v}
+			}
+			q.Conditions = append(q.Conditions, cond)
+		}
+	}
+
+	return q, nil
+}
+
+// Apply adds the query's filters, sorting and pagination to a GORM query
+// defaultSort is used when no (valid) sortBy is requested
+func Apply(db *gorm.DB, q *ListQuery, fields FieldSet, defaultSort string) (*gorm.DB, error) {
+	for _, cond := range q.Conditions {
+		field, ok := fields[cond.Field]
+		if !ok {
+			// Parameters like a "_" cache-buster are not meant as filters
+			if cond.Shorthand {
+				continue
+			}
+			return nil, invalid("Unknown filter field '%s'", cond.Field)
+		}
+
+		args := make([]interface{}, 0, len(cond.Values))
+		for _, raw := range cond.Values {
+			value, err := parseValue(field.Type, raw)
+			if err != nil {
+				return nil, invalid("Invalid value '%s' for field '%s': %v", raw, cond.Field, err)
+			}
+			args = append(args, value)
+		}
+
+		// A date without a time is the whole day, up to the start of the next
+		if field.Type == Date && len(cond.Values) == 1 && isDateOnly(cond.Values[0]) {
+			day := args[0].(time.Time)
+			next := day.AddDate(0, 0, 1)
+			switch cond.Op {
+			case OpEq:
+				db = db.Where(field.Column+" >= ? AND "+field.Column+" < ?", day, next)
+				continue
+			case OpNe:
+				db = db.Where("("+field.Column+" < ? OR "+field.Column+" >= ?)", day, next)
+				continue
+			case OpLte:
+				db = db.Where(field.Column+" < ?", next)
+				continue
+			case OpGt:
+				db = db.Where(field.Column+" >= ?", next)
+				continue
+			}
+		}
+
+		switch cond.Op {
+		case OpEq:
+			db = db.Where(field.Column+" = ?", args[0])
+		case OpNe:
+			db = db.Where(field.Column+" <> ?", args[0])
+		case OpLike:
+			if field.Type != String {
+				return nil, invalid("Operator 'like' is only supported on text fields ('%s')", cond.Field)
+			}
+			db = db.Where(field.Column+" ILIKE ?", "%"+likeEscaper.Replace(cond.Values[0])+"%")
+		case OpPrefix:
+			if field.Type != String {
+				return nil, invalid("Operator 'prefix' is only supported on text fields ('%s')", cond.Field)
+			}
+			db = db.Where(field.Column+" LIKE ?", likeEscaper.Replace(cond.Values[0])+"%")
+		case OpIn:
+			db = db.Where(field.Column+" IN ?", args)
+		case OpGt, OpGte, OpLt, OpLte:
+			if field.Type == Bool || field.Type == UUID {
+				return nil, invalid("Range operators are not supported on field '%s'", cond.Field)
+			}
+			db = db.Where(field.Column+" "+rangeSQL[cond.Op]+" ?", args[0])
+		}
+	}
+
+	sortColumn := defaultSort
+	if q.SortBy != "" {
+		field, ok := fields[q.SortBy]
+		if !ok {
+			return nil, invalid("Unknown sort field '%s'", q.SortBy)
+		}
+		sortColumn = field.Column
+	}
+	if sortColumn != "" {
+		db = db.Order(sortColumn + " " + q.SortOrder)
+	}
+
+	return db.Limit(q.Limit).Offset(q.Offset), nil
+}
+
+// likeEscaper escapes LIKE wildcards so a substring or prefix matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+var rangeSQL = map[Operator]string{
+	OpGt: ">", OpGte: ">=", OpLt: "<", OpLte: "<=",
+}
+
+// isDateOnly reports whether a date value has no time of day (YYYY-MM-DD)
+func isDateOnly(raw string) bool {
+	_, err := time.Parse(time.DateOnly, raw)
+	return err == nil
+}
+
+// parseValue converts a raw query value to the Go type matching the field
+func parseValue(fieldType FieldType, raw string) (interface{}, error) {
+	switch fieldType {
+	case Number:
+		return strconv.ParseFloat(raw, 64)
+	case Bool:
+		return strconv.ParseBool(raw)
+	case Date:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected YYYY-MM-DD or RFC3339")
+		}
+		return t, nil
+	case UUID:
+		return uuid.Parse(raw)
+	default:
+		return raw, nil
+	}
+}
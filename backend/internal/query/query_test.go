@@ -0,0 +1,205 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var testFields = FieldSet{
+	"name":       {Column: "name", Type: String},
+	"score":      {Column: "score", Type: Number},
+	"active":     {Column: "active", Type: Bool},
+	"created_at": {Column: "created_at", Type: Date},
+	"id":         {Column: "id", Type: UUID},
+}
+
+// dryRunDB builds statements without a database
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return db
+}
+
+func TestApply(t *testing.T) {
+	id := uuid.MustParse("6f1c1a52-95a4-4b0e-8a40-8c8b1c7b0c11")
+	tests := []struct {
+		name     string
+		query    ListQuery
+		wantSQL  string
+		wantVars []interface{}
+		wantErr  string
+	}{
+		{
+			name:     "default sort and pagination",
+			query:    ListQuery{Limit: 10, SortOrder: "asc"},
+			wantSQL:  `SELECT * FROM "rows" ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{},
+		},
+		{
+			name:     "equality and inequality",
+			query:    ListQuery{Limit: 5, Offset: 10, SortOrder: "asc", Conditions: []Condition{{Field: "name", Op: OpEq, Values: []string{"Acme"}}, {Field: "active", Op: OpNe, Values: []string{"false"}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE name = $1 AND active <> $2 ORDER BY name asc LIMIT 5 OFFSET 10`,
+			wantVars: []interface{}{"Acme", false},
+		},
+		{
+			name:     "like escapes wildcards",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "name", Op: OpLike, Values: []string{"50%_off"}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE name ILIKE $1 ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{`%50\%\_off%`},
+		},
+		{
+			name:     "prefix",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "name", Op: OpPrefix, Values: []string{"ES"}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE name LIKE $1 ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{"ES%"},
+		},
+		{
+			name:     "in",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "id", Op: OpIn, Values: []string{id.String()}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE id IN ($1) ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{id},
+		},
+		{
+			name:     "date and number ranges",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "created_at", Op: OpGte, Values: []string{"2024-01-01"}}, {Field: "score", Op: OpLt, Values: []string{"0.5"}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE created_at >= $1 AND score < $2 ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 0.5},
+		},
+		{
+			name:     "date-only lte includes the whole day",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "created_at", Op: OpLte, Values: []string{"2024-01-31"}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE created_at < $1 ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "date-only gt starts the next day",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "created_at", Op: OpGt, Values: []string{"2024-01-31"}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE created_at >= $1 ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "date-only equality matches the day",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "created_at", Op: OpEq, Values: []string{"2024-01-31"}, Shorthand: true}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE created_at >= $1 AND created_at < $2 ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "date-only inequality excludes the day",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "created_at", Op: OpNe, Values: []string{"2024-01-31"}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE (created_at < $1 OR created_at >= $2) ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "timestamp lte is exact",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "created_at", Op: OpLte, Values: []string{"2024-01-31T12:00:00Z"}}}},
+			wantSQL:  `SELECT * FROM "rows" WHERE created_at <= $1 ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "parameters without an operator that are not fields are ignored",
+			query:    ListQuery{Limit: 10, SortOrder: "asc", Conditions: []Condition{{Field: "_", Op: OpEq, Values: []string{"1718000000"}, Shorthand: true}, {Field: "format", Op: OpEq, Values: []string{"csv"}, Shorthand: true}}},
+			wantSQL:  `SELECT * FROM "rows" ORDER BY name asc LIMIT 10`,
+			wantVars: []interface{}{},
+		},
+		{
+			name:     "requested sort",
+			query:    ListQuery{Limit: 10, SortBy: "created_at", SortOrder: "desc"},
+			wantSQL:  `SELECT * FROM "rows" ORDER BY created_at desc LIMIT 10`,
+			wantVars: []interface{}{},
+		},
+		{
+			name:    "unknown field with an operator",
+			query:   ListQuery{Limit: 10, Conditions: []Condition{{Field: "colour", Op: OpEq, Values: []string{"red"}}}},
+			wantErr: "Unknown filter field 'colour'",
+		},
+		{
+			name:    "unparsable value",
+			query:   ListQuery{Limit: 10, Conditions: []Condition{{Field: "score", Op: OpEq, Values: []string{"high"}}}},
+			wantErr: "Invalid value 'high' for field 'score'",
+		},
+		{
+			name:    "like on a number",
+			query:   ListQuery{Limit: 10, Conditions: []Condition{{Field: "score", Op: OpLike, Values: []string{"1"}}}},
+			wantErr: "Operator 'like' is only supported on text fields ('score')",
+		},
+		{
+			name:    "range on a bool",
+			query:   ListQuery{Limit: 10, Conditions: []Condition{{Field: "active", Op: OpGt, Values: []string{"true"}}}},
+			wantErr: "Range operators are not supported on field 'active'",
+		},
+		{
+			name:    "unknown sort field",
+			query:   ListQuery{Limit: 10, SortBy: "colour", SortOrder: "asc"},
+			wantErr: "Unknown sort field 'colour'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := Apply(dryRunDB(t).Table("rows"), &tt.query, testFields, "name")
+			if tt.wantErr != "" {
+				assert.True(t, IsValidationError(err))
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var rows []map[string]interface{}
+			stmt := db.Find(&rows).Statement
+			assert.Equal(t, tt.wantSQL, stmt.SQL.String())
+			assert.Equal(t, tt.wantVars, stmt.Vars)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    *ListQuery
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			raw:  "",
+			want: &ListQuery{Limit: DefaultLimit, SortOrder: "asc"},
+		},
+		{
+			name: "operators and pagination",
+			raw:  "type[in]=EQUITY,%20BOND,&limit=50&offset=100&sortBy=name&sortOrder=DESC",
+			want: &ListQuery{Limit: 50, Offset: 100, SortBy: "name", SortOrder: "desc", Conditions: []Condition{{Field: "type", Op: OpIn, Values: []string{"EQUITY", "BOND"}}}},
+		},
+		{
+			name: "shorthand equality",
+			raw:  "name=Acme",
+			want: &ListQuery{Limit: DefaultLimit, SortOrder: "asc", Conditions: []Condition{{Field: "name", Op: OpEq, Values: []string{"Acme"}, Shorthand: true}}},
+		},
+		{name: "limit too large", raw: "limit=101", wantErr: "Invalid limit parameter"},
+		{name: "negative offset", raw: "offset=-1", wantErr: "Invalid offset parameter"},
+		{name: "bad sort order", raw: "sortOrder=up", wantErr: "Invalid sortOrder parameter"},
+		{name: "unknown operator", raw: "name[regex]=a", wantErr: "Unsupported operator 'regex' on field 'name'"},
+		{name: "empty in list", raw: "type[in]=,", wantErr: "Empty value list for 'type[in]'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.raw)
+			require.NoError(t, err)
+
+			got, err := Parse(values)
+			if tt.wantErr != "" {
+				assert.True(t, IsValidationError(err))
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
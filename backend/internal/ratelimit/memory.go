@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// memorySweepInterval is how often buckets that have refilled are dropped
+const memorySweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Duration // Time an empty bucket takes to refill
+}
+
+// MemoryStore keeps buckets in this process, so each replica limits on its own
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, lastSweep: time.Now(), now: time.Now}
+}
+
+// Take takes a token from the bucket under key
+func (s *MemoryStore) Take(_ context.Context, key string, rule config.RateLimitRule) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.full = time.Duration(float64(rule.Burst) / rule.Rate * float64(time.Second))
+
+	tokens, result := take(refill(b.tokens, now.Sub(b.updated), rule), rule)
+	b.tokens, b.updated = tokens, now
+	return result, nil
+}
+
+// sweep drops the buckets that have refilled since they were last used; they would be created
+// full again. Called with the lock held.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= b.full {
+			delete(s.buckets, key)
+		}
+	}
+}
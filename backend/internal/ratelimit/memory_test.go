@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+func TestMemoryStore(t *testing.T) {
+	rule := config.RateLimitRule{Rate: 2, Burst: 3}
+
+	tests := []struct {
+		name  string
+		steps []time.Duration // Time since the previous take
+		want  []Result
+	}{
+		{
+			name:  "a burst is spent at once",
+			steps: []time.Duration{0, 0, 0, 0},
+			want: []Result{
+				{Allowed: true, Remaining: 2},
+				{Allowed: true, Remaining: 1},
+				{Allowed: true, Remaining: 0},
+				{RetryAfter: 500 * time.Millisecond},
+			},
+		},
+		{
+			name:  "refills at rate",
+			steps: []time.Duration{0, 0, 0, 250 * time.Millisecond, 250 * time.Millisecond},
+			want: []Result{
+				{Allowed: true, Remaining: 2},
+				{Allowed: true, Remaining: 1},
+				{Allowed: true, Remaining: 0},
+				{RetryAfter: 250 * time.Millisecond},
+				{Allowed: true, Remaining: 0},
+			},
+		},
+		{
+			name:  "never holds more than a burst",
+			steps: []time.Duration{0, time.Hour},
+			want: []Result{
+				{Allowed: true, Remaining: 2},
+				{Allowed: true, Remaining: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			store := NewMemoryStore()
+			store.now = func() time.Time { return now }
+
+			for i, step := range tt.steps {
+				now = now.Add(step)
+				result, err := store.Take(context.Background(), "user:1", rule)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want[i], result, "take %d", i)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreKeys(t *testing.T) {
+	store := NewMemoryStore()
+	rule := config.RateLimitRule{Rate: 1, Burst: 1}
+
+	first, _ := store.Take(context.Background(), "user:1", rule)
+	again, _ := store.Take(context.Background(), "user:1", rule)
+	other, _ := store.Take(context.Background(), "user:2", rule)
+
+	assert.True(t, first.Allowed)
+	assert.False(t, again.Allowed)
+	assert.True(t, other.Allowed, "each key has a bucket of its own")
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	store.lastSweep = now
+
+	fast := config.RateLimitRule{Rate: 10, Burst: 10}  // Full again after a second
+	slow := config.RateLimitRule{Rate: 0.01, Burst: 5} // After 500 seconds
+	_, _ = store.Take(context.Background(), "fast", fast)
+	_, _ = store.Take(context.Background(), "slow", slow)
+
+	now = now.Add(memorySweepInterval)
+	_, _ = store.Take(context.Background(), "other", fast)
+
+	assert.NotContains(t, store.buckets, "fast")
+	assert.Contains(t, store.buckets, "slow")
+	assert.Contains(t, store.buckets, "other")
+}
@@ -0,0 +1,106 @@
+// Package ratelimit counts requests in token buckets. A bucket holds up to Burst tokens and is
+// refilled at Rate tokens per second; each request takes one, and a request finding the bucket
+// empty is refused until a token is back.
+//
+// Buckets are kept in memory, per replica, or in Redis, where every replica shares them.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// Store backends
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Route groups, each limited by a rule of its own
+const (
+	GroupPublic    = "public"
+	GroupAuth      = "auth"
+	GroupProtected = "protected"
+)
+
+// Rules holds the rule of each route group. Set replaces them while requests are being served, so
+// a config reload applies to the next request.
+type Rules struct {
+	cfg atomic.Pointer[config.RateLimitConfig]
+}
+
+// NewRules holds the rules of cfg
+func NewRules(cfg config.RateLimitConfig) *Rules {
+	r := &Rules{}
+	r.Set(cfg)
+	return r
+}
+
+// Set replaces the rules with those of cfg
+func (r *Rules) Set(cfg config.RateLimitConfig) {
+	r.cfg.Store(&cfg)
+}
+
+// Rule returns the rule of group; an unknown group has none, leaving it unlimited
+func (r *Rules) Rule(group string) config.RateLimitRule {
+	cfg := r.cfg.Load()
+	switch group {
+	case GroupPublic:
+		return cfg.Public
+	case GroupAuth:
+		return cfg.Auth
+	case GroupProtected:
+		return cfg.Protected
+	default:
+		return config.RateLimitRule{}
+	}
+}
+
+// Result is the outcome of taking a token
+type Result struct {
+	Allowed    bool
+	Remaining  int           // Tokens left in the bucket
+	RetryAfter time.Duration // Until a token is back, when refused
+}
+
+// Store keeps token buckets by key
+type Store interface {
+	// Take takes a token from the bucket under key, creating it full if it does not exist
+	Take(ctx context.Context, key string, rule config.RateLimitRule) (Result, error)
+}
+
+// NewStore creates the configured store
+func NewStore(cfg config.RateLimitConfig, redisCfg config.RedisConfig) (Store, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case "", BackendMemory:
+		return NewMemoryStore(), nil
+	case BackendRedis:
+		return newRedisStore(redisCfg)
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q (expected memory or redis)", cfg.Backend)
+	}
+}
+
+// refill returns the tokens in a bucket that held tokens elapsed ago
+func refill(tokens float64, elapsed time.Duration, rule config.RateLimitRule) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(rule.Burst), tokens+elapsed.Seconds()*rule.Rate)
+}
+
+// take takes a token from a bucket holding tokens and returns what it holds afterwards
+func take(tokens float64, rule config.RateLimitRule) (float64, Result) {
+	if tokens >= 1 {
+		tokens--
+		return tokens, Result{Allowed: true, Remaining: int(tokens)}
+	}
+	wait := time.Duration((1 - tokens) / rule.Rate * float64(time.Second))
+	return tokens, Result{RetryAfter: wait}
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// redisKeyPrefix namespaces the buckets in a Redis database shared with other data
+const redisKeyPrefix = "axiom:ratelimit:"
+
+// takeScript refills and takes from a bucket in one step, so concurrent requests on different
+// replicas cannot take the same token. The bucket is a hash of its tokens and the time they were
+// counted, by the Redis clock so replicas' clocks need not agree, and expires once it would be
+// full again.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1]) or burst
+local updated = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - updated) / 1000 * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, math.floor(tokens), wait}
+`)
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// newRedisStore connects to the Redis server at redis.url
+func newRedisStore(cfg config.RedisConfig) (*redisStore, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("the redis rate limit backend needs redis.url")
+	}
+	options, err := redis.ParseURL(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis.url: %w", err)
+	}
+	return &redisStore{client: redis.NewClient(options)}, nil
+}
+
+func (s *redisStore) Take(ctx context.Context, key string, rule config.RateLimitRule) (Result, error) {
+	reply, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key},
+		strconv.FormatFloat(rule.Rate, 'f', -1, 64), rule.Burst).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("rate limit bucket %s: %w", key, err)
+	}
+	if len(reply) != 3 {
+		return Result{}, fmt.Errorf("rate limit bucket %s: unexpected reply %v", key, reply)
+	}
+	return Result{
+		Allowed:    reply[0] == 1,
+		Remaining:  int(reply[1]),
+		RetryAfter: time.Duration(reply[2]) * time.Millisecond,
+	}, nil
+}
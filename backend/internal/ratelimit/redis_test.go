@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// newTestRedisStore returns a store on an in-process Redis server
+func newTestRedisStore(t *testing.T) (*redisStore, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store, err := newRedisStore(config.RedisConfig{URL: "redis://" + server.Addr()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.client.Close() })
+	return store, server
+}
+
+func TestRedisStore(t *testing.T) {
+	store, server := newTestRedisStore(t)
+	rule := config.RateLimitRule{Rate: 2, Burst: 3}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	server.SetTime(now)
+
+	take := func() Result {
+		t.Helper()
+		result, err := store.Take(context.Background(), "protected:user:1", rule)
+		require.NoError(t, err)
+		return result
+	}
+
+	assert.Equal(t, Result{Allowed: true, Remaining: 2}, take())
+	assert.Equal(t, Result{Allowed: true, Remaining: 1}, take())
+	assert.Equal(t, Result{Allowed: true, Remaining: 0}, take())
+	assert.Equal(t, Result{RetryAfter: 500 * time.Millisecond}, take())
+
+	// The bucket is refilled by the Redis clock
+	server.SetTime(now.Add(500 * time.Millisecond))
+	assert.Equal(t, Result{Allowed: true, Remaining: 0}, take())
+
+	key := redisKeyPrefix + "protected:user:1"
+	assert.True(t, server.Exists(key))
+	assert.Equal(t, 2500*time.Millisecond, server.TTL(key), "expires once it would be full again")
+
+	other, err := store.Take(context.Background(), "protected:user:2", rule)
+	require.NoError(t, err)
+	assert.True(t, other.Allowed, "each key has a bucket of its own")
+}
+
+func TestRedisStoreUnavailable(t *testing.T) {
+	store, server := newTestRedisStore(t)
+	server.Close()
+
+	_, err := store.Take(context.Background(), "public:ip:10.0.0.1", config.RateLimitRule{Rate: 1, Burst: 1})
+	assert.ErrorContains(t, err, "rate limit bucket public:ip:10.0.0.1")
+}
+
+func TestNewStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		url     string
+		wantErr string
+	}{
+		{name: "memory by default"},
+		{name: "memory", backend: " Memory "},
+		{name: "redis", backend: "redis", url: "redis://localhost:6379/0"},
+		{name: "redis without a url", backend: "redis", wantErr: "needs redis.url"},
+		{name: "redis with a bad url", backend: "redis", url: "http://localhost", wantErr: "invalid redis.url"},
+		{name: "unknown", backend: "memcached", wantErr: "unknown rate limit backend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := NewStore(config.RateLimitConfig{Backend: tt.backend}, config.RedisConfig{URL: tt.url})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, store)
+		})
+	}
+}
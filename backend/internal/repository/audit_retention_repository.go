@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// AuditRetentionRepository finds and deletes audit rows past the retention cutoff
+type AuditRetentionRepository interface {
+	// Oldest first; each LEI keeps its last full snapshot before the cutoff and everything after it
+	FindPrunableLEIAudits(cutoff time.Time, limit int) ([]*domain.LEIRecordAudit, error)
+	DeleteLEIAudits(ids []uuid.UUID) (int64, error)
+	FindPrunableAuditLogs(cutoff time.Time, limit int) ([]*domain.AuditLog, error)
+	DeleteAuditLogs(ids []uuid.UUID) (int64, error)
+}
+
+type auditRetentionRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRetentionRepository creates a new audit retention repository instance
+func NewAuditRetentionRepository(db *gorm.DB) AuditRetentionRepository {
+	return &auditRetentionRepository{db: db}
+}
+
+// FindPrunableLEIAudits returns LEI audit rows older than cutoff that are safe to delete. The newest
+// row with a full snapshot before the cutoff is kept for each LEI, along with everything after it,
+// so the version in force at the cutoff (and every later one, in diff audit mode) can still be
+// reconstructed.
+func (r *auditRetentionRepository) FindPrunableLEIAudits(cutoff time.Time, limit int) ([]*domain.LEIRecordAudit, error) {
+	var audits []*domain.LEIRecordAudit
+	err := r.db.
+		Where("created_at < ?", cutoff).
+		Where(`created_at < (
+			SELECT MAX(base.created_at) FROM lei_raw.lei_records_audit base
+			WHERE base.lei = lei_records_audit.lei
+			AND base.created_at < ?
+			AND base.record_snapshot <> ?::jsonb
+		)`, cutoff, LEIAuditDiffSnapshot).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&audits).Error
+	if err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+// DeleteLEIAudits hard-deletes LEI audit rows
+func (r *auditRetentionRepository) DeleteLEIAudits(ids []uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("id IN ?", ids).Delete(&domain.LEIRecordAudit{})
+	return result.RowsAffected, result.Error
+}
+
+// FindPrunableAuditLogs returns audit log rows older than cutoff, including soft-deleted ones
+func (r *auditRetentionRepository) FindPrunableAuditLogs(cutoff time.Time, limit int) ([]*domain.AuditLog, error) {
+	var logs []*domain.AuditLog
+	if err := r.db.Unscoped().Where("created_at < ?", cutoff).Order("created_at ASC").Limit(limit).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// DeleteAuditLogs hard-deletes audit log rows
+func (r *auditRetentionRepository) DeleteAuditLogs(ids []uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Unscoped().Where("id IN ?", ids).Delete(&domain.AuditLog{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BICFilter narrows BIC directory listings; zero values match everything
+type BICFilter struct {
+	Country    string // ISO 3166-1 alpha-2 code
+	Search     string // BIC prefix or part of the institution name
+	ActiveOnly bool
+}
+
+// BICRepository stores the BIC directory
+type BICRepository interface {
+	Upsert(entries []*domain.BICDirectoryEntry) error
+	DeactivateNotImportedSince(importedAt time.Time) (int64, error)
+	FindByBIC(bic string) (*domain.BICDirectoryEntry, error)
+	FindAll(filter BICFilter, limit, offset int) ([]*domain.BICDirectoryEntry, int64, error)
+	HasEntries() (bool, error)
+}
+
+type bicRepository struct {
+	db *gorm.DB
+}
+
+// NewBICRepository creates a new BIC directory repository
+func NewBICRepository(db *gorm.DB) BICRepository {
+	return &bicRepository{db: db}
+}
+
+// Upsert inserts or refreshes directory entries by BIC
+func (r *bicRepository) Upsert(entries []*domain.BICDirectoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bic"}},
+		DoUpdates: clause.AssignmentColumns([]string{"institution_name", "branch_name", "city", "country_code", "active", "imported_at", "updated_at"}),
+	}).CreateInBatches(entries, 1000).Error
+}
+
+// DeactivateNotImportedSince deactivates the entries the import at importedAt did not list
+func (r *bicRepository) DeactivateNotImportedSince(importedAt time.Time) (int64, error) {
+	result := r.db.Model(&domain.BICDirectoryEntry{}).
+		Where("active AND imported_at < ?", importedAt).
+		Update("active", false)
+	return result.RowsAffected, result.Error
+}
+
+// FindByBIC returns the directory entry of a BIC11
+func (r *bicRepository) FindByBIC(bic string) (*domain.BICDirectoryEntry, error) {
+	var entry domain.BICDirectoryEntry
+	if err := r.db.First(&entry, "bic = ?", bic).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindAll lists directory entries in BIC order
+func (r *bicRepository) FindAll(filter BICFilter, limit, offset int) ([]*domain.BICDirectoryEntry, int64, error) {
+	query := r.db.Model(&domain.BICDirectoryEntry{})
+	if filter.Country != "" {
+		query = query.Where("country_code = ?", filter.Country)
+	}
+	if filter.Search != "" {
+		query = query.Where("bic LIKE ? OR LOWER(institution_name) LIKE ?",
+			strings.ToUpper(filter.Search)+"%", "%"+strings.ToLower(filter.Search)+"%")
+	}
+	if filter.ActiveOnly {
+		query = query.Where("active")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []*domain.BICDirectoryEntry
+	if err := query.Order("bic").Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// HasEntries reports whether a directory has been imported
+func (r *bicRepository) HasEntries() (bool, error) {
+	var found []string
+	if err := r.db.Model(&domain.BICDirectoryEntry{}).Limit(1).Pluck("bic", &found).Error; err != nil {
+		return false, err
+	}
+	return len(found) > 0, nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/cache"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// CacheTTL is how long cached lookups live, per kind; 0 caches nothing of that kind
+type CacheTTL struct {
+	LEI       time.Duration // LEI records by code, distinct LEI field values
+	Reference time.Duration // Country and currency lists and lookups
+}
+
+// cachedLEIRepository caches LEI records by code and distinct values in front of an LEI repository.
+// Every write through it drops the entries of the records written; writes of unknown records (a
+// delete by ID, a full-file merge) drop them all.
+type cachedLEIRepository struct {
+	LEIRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func newCachedLEIRepository(repo LEIRepository, c cache.Cache, ttl time.Duration) LEIRepository {
+	return &cachedLEIRepository{LEIRepository: repo, cache: c, ttl: ttl}
+}
+
+func (r *cachedLEIRepository) FindLEIByLEI(lei string) (*domain.LEIRecord, error) {
+	return cache.Fetch(r.cache, cache.GroupLEI, lei, r.ttl, func() (*domain.LEIRecord, error) {
+		return r.LEIRepository.FindLEIByLEI(lei)
+	})
+}
+
+func (r *cachedLEIRepository) GetDistinctCountries() ([]string, error) {
+	return cache.Fetch(r.cache, cache.GroupLEIFacets, "countries", r.ttl, r.LEIRepository.GetDistinctCountries)
+}
+
+// forget drops the cached records and the distinct values, which they may have changed
+func (r *cachedLEIRepository) forget(records ...*domain.LEIRecord) {
+	leis := make([]string, len(records))
+	for i, record := range records {
+		leis[i] = record.LEI
+	}
+	r.cache.Delete(context.Background(), cache.GroupLEI, leis...)
+	r.cache.Invalidate(context.Background(), cache.GroupLEIFacets)
+}
+
+// forgetAll drops every cached record and the distinct values
+func (r *cachedLEIRepository) forgetAll() {
+	r.cache.Invalidate(context.Background(), cache.GroupLEI)
+	r.cache.Invalidate(context.Background(), cache.GroupLEIFacets)
+}
+
+func (r *cachedLEIRepository) CreateLEIRecord(record *domain.LEIRecord) error {
+	err := r.LEIRepository.CreateLEIRecord(record)
+	r.forget(record)
+	return err
+}
+
+func (r *cachedLEIRepository) UpdateLEIRecord(record *domain.LEIRecord) error {
+	err := r.LEIRepository.UpdateLEIRecord(record)
+	r.forget(record)
+	return err
+}
+
+func (r *cachedLEIRepository) UpsertLEIRecord(ctx context.Context, record *domain.LEIRecord) (bool, error) {
+	updated, err := r.LEIRepository.UpsertLEIRecord(ctx, record)
+	r.forget(record)
+	return updated, err
+}
+
+func (r *cachedLEIRepository) BatchUpsertLEIRecords(ctx context.Context, records []*domain.LEIRecord) (int, int, error) {
+	created, updated, err := r.LEIRepository.BatchUpsertLEIRecords(ctx, records)
+	r.forget(records...)
+	return created, updated, err
+}
+
+func (r *cachedLEIRepository) BatchUpsertLEIRecordsIsolating(ctx context.Context, records []*domain.LEIRecord) (int, int, []*domain.LEIQuarantinedRecord, error) {
+	created, updated, quarantined, err := r.LEIRepository.BatchUpsertLEIRecordsIsolating(ctx, records)
+	r.forget(records...)
+	return created, updated, quarantined, err
+}
+
+func (r *cachedLEIRepository) DeleteLEI(id string) error {
+	err := r.LEIRepository.DeleteLEI(id)
+	r.forgetAll()
+	return err
+}
+
+func (r *cachedLEIRepository) MergeStagedLEIRecords(sourceFileID uuid.UUID) (int, int, error) {
+	created, updated, err := r.LEIRepository.MergeStagedLEIRecords(sourceFileID)
+	r.forgetAll()
+	return created, updated, err
+}
+
+// cachedCountryRepository caches country lists and lookups; any write drops them all
+type cachedCountryRepository struct {
+	CountryRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func newCachedCountryRepository(repo CountryRepository, c cache.Cache, ttl time.Duration) CountryRepository {
+	return &cachedCountryRepository{CountryRepository: repo, cache: c, ttl: ttl}
+}
+
+func (r *cachedCountryRepository) FindByID(id string) (*domain.Country, error) {
+	return cache.Fetch(r.cache, cache.GroupCountries, "id:"+id, r.ttl, func() (*domain.Country, error) {
+		return r.CountryRepository.FindByID(id)
+	})
+}
+
+func (r *cachedCountryRepository) FindByCode(code string) (*domain.Country, error) {
+	return cache.Fetch(r.cache, cache.GroupCountries, "code:"+code, r.ttl, func() (*domain.Country, error) {
+		return r.CountryRepository.FindByCode(code)
+	})
+}
+
+func (r *cachedCountryRepository) FindAll(limit, offset int) ([]*domain.Country, error) {
+	return cache.Fetch(r.cache, cache.GroupCountries, listKey(limit, offset), r.ttl, func() ([]*domain.Country, error) {
+		return r.CountryRepository.FindAll(limit, offset)
+	})
+}
+
+func (r *cachedCountryRepository) Create(ctx context.Context, country *domain.Country) error {
+	defer r.cache.Invalidate(context.WithoutCancel(ctx), cache.GroupCountries)
+	return r.CountryRepository.Create(ctx, country)
+}
+
+func (r *cachedCountryRepository) Update(ctx context.Context, country *domain.Country) error {
+	defer r.cache.Invalidate(context.WithoutCancel(ctx), cache.GroupCountries)
+	return r.CountryRepository.Update(ctx, country)
+}
+
+func (r *cachedCountryRepository) Delete(ctx context.Context, id string) error {
+	defer r.cache.Invalidate(context.WithoutCancel(ctx), cache.GroupCountries)
+	return r.CountryRepository.Delete(ctx, id)
+}
+
+// cachedCurrencyRepository caches currency lists and lookups; any write drops them all
+type cachedCurrencyRepository struct {
+	CurrencyRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func newCachedCurrencyRepository(repo CurrencyRepository, c cache.Cache, ttl time.Duration) CurrencyRepository {
+	return &cachedCurrencyRepository{CurrencyRepository: repo, cache: c, ttl: ttl}
+}
+
+func (r *cachedCurrencyRepository) FindByID(id string) (*domain.Currency, error) {
+	return cache.Fetch(r.cache, cache.GroupCurrencies, "id:"+id, r.ttl, func() (*domain.Currency, error) {
+		return r.CurrencyRepository.FindByID(id)
+	})
+}
+
+func (r *cachedCurrencyRepository) FindByCode(code string) (*domain.Currency, error) {
+	return cache.Fetch(r.cache, cache.GroupCurrencies, "code:"+code, r.ttl, func() (*domain.Currency, error) {
+		return r.CurrencyRepository.FindByCode(code)
+	})
+}
+
+func (r *cachedCurrencyRepository) FindAll(limit, offset int) ([]*domain.Currency, error) {
+	return cache.Fetch(r.cache, cache.GroupCurrencies, listKey(limit, offset), r.ttl, func() ([]*domain.Currency, error) {
+		return r.CurrencyRepository.FindAll(limit, offset)
+	})
+}
+
+func (r *cachedCurrencyRepository) Create(ctx context.Context, currency *domain.Currency) error {
+	defer r.cache.Invalidate(context.WithoutCancel(ctx), cache.GroupCurrencies)
+	return r.CurrencyRepository.Create(ctx, currency)
+}
+
+func (r *cachedCurrencyRepository) Update(ctx context.Context, currency *domain.Currency) error {
+	defer r.cache.Invalidate(context.WithoutCancel(ctx), cache.GroupCurrencies)
+	return r.CurrencyRepository.Update(ctx, currency)
+}
+
+func (r *cachedCurrencyRepository) Delete(ctx context.Context, id string) error {
+	defer r.cache.Invalidate(context.WithoutCancel(ctx), cache.GroupCurrencies)
+	return r.CurrencyRepository.Delete(ctx, id)
+}
+
+func listKey(limit, offset int) string {
+	return "list:" + strconv.Itoa(limit) + ":" + strconv.Itoa(offset)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CalendarRepository stores holiday calendars and their holidays
+type CalendarRepository interface {
+	Save(calendar *domain.HolidayCalendar) error
+	FindByCode(code string) (*domain.HolidayCalendar, error)
+	FindByCodes(codes []string) ([]*domain.HolidayCalendar, error)
+	FindAll() ([]*domain.HolidayCalendar, error)
+	FindHolidays(codes []string, from, to time.Time) ([]*domain.CalendarHoliday, error)
+	UpsertHolidays(holidays []*domain.CalendarHoliday) error
+	DeleteHoliday(code string, date time.Time) (bool, error)
+}
+
+type calendarRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarRepository creates a new holiday calendar repository
+func NewCalendarRepository(db *gorm.DB) CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+// Save creates a calendar or updates its name and weekend days
+func (r *calendarRepository) Save(calendar *domain.HolidayCalendar) error {
+	return r.db.Omit("Holidays").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "weekend_days", "updated_at"}),
+	}).Create(calendar).Error
+}
+
+// FindByCode returns a calendar without its holidays
+func (r *calendarRepository) FindByCode(code string) (*domain.HolidayCalendar, error) {
+	var calendar domain.HolidayCalendar
+	if err := r.db.First(&calendar, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+// FindByCodes returns the calendars of codes that exist, without their holidays
+func (r *calendarRepository) FindByCodes(codes []string) ([]*domain.HolidayCalendar, error) {
+	var calendars []*domain.HolidayCalendar
+	if err := r.db.Where("code IN ?", codes).Find(&calendars).Error; err != nil {
+		return nil, err
+	}
+	return calendars, nil
+}
+
+// FindAll lists the calendars in code order, without their holidays
+func (r *calendarRepository) FindAll() ([]*domain.HolidayCalendar, error) {
+	var calendars []*domain.HolidayCalendar
+	if err := r.db.Order("code").Find(&calendars).Error; err != nil {
+		return nil, err
+	}
+	return calendars, nil
+}
+
+// FindHolidays returns the holidays of the calendars from one date to another, both included, in
+// date order
+func (r *calendarRepository) FindHolidays(codes []string, from, to time.Time) ([]*domain.CalendarHoliday, error) {
+	var holidays []*domain.CalendarHoliday
+	if err := r.db.Where("calendar_code IN ? AND date BETWEEN ? AND ?", codes, from, to).
+		Order("date, calendar_code").Find(&holidays).Error; err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// UpsertHolidays adds holidays, renaming those already on the calendar
+func (r *calendarRepository) UpsertHolidays(holidays []*domain.CalendarHoliday) error {
+	if len(holidays) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "calendar_code"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}).Create(holidays).Error
+}
+
+// DeleteHoliday removes a holiday and reports whether there was one
+func (r *calendarRepository) DeleteHoliday(code string, date time.Time) (bool, error) {
+	result := r.db.Where("calendar_code = ? AND date = ?", code, date).Delete(&domain.CalendarHoliday{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
+	"gorm.io/gorm"
+)
+
+// changeEventBatchSize is how many outbox rows are inserted per statement
+const changeEventBatchSize = 500
+
+// changeEvents writes record-change events to the outbox in the transaction of the change they
+// describe, so an event exists exactly when its change committed. The event relay publishes them.
+type changeEvents struct {
+	enabled bool // false when no publisher is configured, so the outbox does not grow unread
+}
+
+// record writes events to the outbox using tx
+func (c changeEvents) record(tx *gorm.DB, evts ...events.Event) error {
+	if !c.enabled || len(evts) == 0 {
+		return nil
+	}
+	rows := make([]domain.OutboxEvent, len(evts))
+	for i, event := range evts {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event: %w", event.RoutingKey(), err)
+		}
+		rows[i] = domain.OutboxEvent{
+			ID:         event.ID,
+			RoutingKey: event.RoutingKey(),
+			Payload:    string(payload),
+			CreatedAt:  event.Time,
+		}
+	}
+	if err := tx.CreateInBatches(rows, changeEventBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to write change events to the outbox: %w", err)
+	}
+	return nil
+}
+
+// recordChange writes the change of a master-data record, with the record as payload
+func (c changeEvents) recordChange(tx *gorm.DB, domainName, action string, id uuid.UUID, record any) error {
+	if !c.enabled {
+		return nil
+	}
+	return c.record(tx, events.New(domainName, action, id.String(), id, record))
+}
+
+// recordDelete writes the deletion of a master-data record by ID
+func (c changeEvents) recordDelete(tx *gorm.DB, domainName, id string) error {
+	recordID, _ := uuid.Parse(id)
+	return c.record(tx, events.New(domainName, events.ActionDeleted, id, recordID, nil))
+}
+
+// leiChangeEvent describes an LEI record change; changes holds the updated fields, keyed by name
+func leiChangeEvent(action string, recordID uuid.UUID, lei string, changes map[string]domain.LEIChangeDetection, sourceFileID *uuid.UUID) events.Event {
+	event := events.New(events.DomainLEI, action, lei, recordID, nil)
+	event.SourceFileID = sourceFileID
+	for field := range changes {
+		event.ChangedFields = append(event.ChangedFields, field)
+	}
+	sort.Strings(event.ChangedFields)
+	return event
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/query"
+	"gorm.io/gorm"
+)
+
+// exportTable is a kind of master data an export reads, with the filters of its list endpoint
+type exportTable struct {
+	newRecord   func() any
+	fields      query.FieldSet
+	defaultSort string
+}
+
+// countryFilterFields and currencyFilterFields are the filters of country and currency exports
+var (
+	countryFilterFields = query.FieldSet{
+		"code":        {Column: "code", Type: query.String},
+		"name":        {Column: "name", Type: query.String},
+		"alpha3_code": {Column: "alpha3_code", Type: query.String},
+		"region":      {Column: "region", Type: query.String},
+		"sub_region":  {Column: "sub_region", Type: query.String},
+		"active":      {Column: "active", Type: query.Bool},
+		"created_at":  {Column: "created_at", Type: query.Date},
+		"updated_at":  {Column: "updated_at", Type: query.Date},
+	}
+	currencyFilterFields = query.FieldSet{
+		"code":           {Column: "code", Type: query.String},
+		"name":           {Column: "name", Type: query.String},
+		"decimal_places": {Column: "decimal_places", Type: query.Number},
+		"fund":           {Column: "fund", Type: query.Bool},
+		"active":         {Column: "active", Type: query.Bool},
+		"created_at":     {Column: "created_at", Type: query.Date},
+		"updated_at":     {Column: "updated_at", Type: query.Date},
+	}
+)
+
+var exportTables = map[string]exportTable{
+	domain.ExportKindCountries:   {func() any { return &domain.Country{} }, countryFilterFields, "code"},
+	domain.ExportKindCurrencies:  {func() any { return &domain.Currency{} }, currencyFilterFields, "code"},
+	domain.ExportKindEntities:    {func() any { return &domain.Entity{} }, entityFilterFields, "created_at"},
+	domain.ExportKindInstruments: {func() any { return &domain.Instrument{} }, instrumentFilterFields, "created_at"},
+	domain.ExportKindAccounts:    {func() any { return &domain.Account{} }, accountFilterFields, "created_at"},
+	domain.ExportKindSSIs:        {func() any { return &domain.SSI{} }, ssiFilterFields, "created_at"},
+}
+
+// DataExportRepository reads whole filtered tables for exports, a record at a time, so the size
+// of an export does not depend on memory
+type DataExportRepository interface {
+	// Count returns the records of a kind matching the list filters of q; invalid filters are a
+	// query.ValidationError
+	Count(kind string, q *query.ListQuery) (int64, error)
+	// Stream calls fn with each record of a kind matching the list filters of q, in q's order,
+	// as it is read; pagination in q is ignored
+	Stream(kind string, q *query.ListQuery, fn func(record any) error) error
+	CountLEI(filter LEIFilter) (int64, error)
+	StreamLEI(filter LEIFilter, fn func(record *domain.LEIRecord) error) error
+}
+
+type dataExportRepository struct {
+	reads *ReadReplicas
+}
+
+// NewDataExportRepository creates a new data export repository; exports read from reads
+func NewDataExportRepository(reads *ReadReplicas) DataExportRepository {
+	return &dataExportRepository{reads: reads}
+}
+
+// filtered applies the list filters and sort of q to a kind's table, without pagination
+func (r *dataExportRepository) filtered(kind string, q *query.ListQuery) (exportTable, *gorm.DB, error) {
+	table, ok := exportTables[kind]
+	if !ok {
+		return exportTable{}, nil, fmt.Errorf("unknown export kind %q", kind)
+	}
+	all := *q
+	all.Limit, all.Offset = -1, 0
+	db, err := query.Apply(r.reads.DB().Model(table.newRecord()), &all, table.fields, table.defaultSort)
+	return table, db, err
+}
+
+func (r *dataExportRepository) Count(kind string, q *query.ListQuery) (int64, error) {
+	_, db, err := r.filtered(kind, q)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	err = db.Count(&count).Error
+	return count, err
+}
+
+func (r *dataExportRepository) Stream(kind string, q *query.ListQuery, fn func(record any) error) error {
+	table, db, err := r.filtered(kind, q)
+	if err != nil {
+		return err
+	}
+	return streamRows(db, table.newRecord, fn)
+}
+
+func (r *dataExportRepository) CountLEI(filter LEIFilter) (int64, error) {
+	var count int64
+	err := applyLEIFilters(r.reads.DB().Model(&domain.LEIRecord{}), filter).Count(&count).Error
+	return count, err
+}
+
+func (r *dataExportRepository) StreamLEI(filter LEIFilter, fn func(record *domain.LEIRecord) error) error {
+	db := applyLEIFilters(r.reads.DB().Model(&domain.LEIRecord{}), filter)
+	return streamRows(db, func() any { return &domain.LEIRecord{} }, func(record any) error {
+		return fn(record.(*domain.LEIRecord))
+	})
+}
+
+// streamRows runs a query and scans its rows one at a time into new records
+func streamRows(db *gorm.DB, newRecord func() any, fn func(record any) error) error {
+	rows, err := db.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		record := newRecord()
+		if err := db.ScanRows(rows, record); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
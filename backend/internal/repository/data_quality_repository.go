@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// reviewableTables lists the tables that carry review_required/review_reason, and whether
+// they also have an active flag that a deactivation cascade may clear
+// Only these names are ever interpolated into SQL
+var reviewableTables = map[string]bool{
+	"addresses":   false,
+	"instruments": true,
+	"accounts":    true,
+	"ssis":        true,
+}
+
+// DQFindingFilter narrows finding listings; zero values match everything
+type DQFindingFilter struct {
+	RuleCode    string
+	Status      string
+	TargetTable string
+	TargetID    string
+}
+
+// DQFindingRepository persists data quality findings
+type DQFindingRepository interface {
+	FindByID(id string) (*domain.DQFinding, error)
+	FindAll(filter DQFindingFilter, limit, offset int) ([]*domain.DQFinding, error)
+	Count(filter DQFindingFilter) (int64, error)
+	Resolve(finding *domain.DQFinding, resolvedBy string) error
+}
+
+type dqFindingRepository struct {
+	db *gorm.DB
+}
+
+// NewDQFindingRepository creates a new DQ finding repository instance
+func NewDQFindingRepository(db *gorm.DB) DQFindingRepository {
+	return &dqFindingRepository{db: db}
+}
+
+func (r *dqFindingRepository) FindByID(id string) (*domain.DQFinding, error) {
+	var finding domain.DQFinding
+	if err := r.db.First(&finding, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
+
+func (r *dqFindingRepository) FindAll(filter DQFindingFilter, limit, offset int) ([]*domain.DQFinding, error) {
+	var findings []*domain.DQFinding
+	if err := r.applyFilter(r.db, filter).Order("created_at DESC").Limit(limit).Offset(offset).Find(&findings).Error; err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+func (r *dqFindingRepository) Count(filter DQFindingFilter) (int64, error) {
+	var count int64
+	if err := r.applyFilter(r.db.Model(&domain.DQFinding{}), filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Resolve marks a finding resolved and, if it was the target's last open finding,
+// clears the target's review flag
+func (r *dqFindingRepository) Resolve(finding *domain.DQFinding, resolvedBy string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		finding.Status = domain.DQFindingStatusResolved
+		finding.ResolvedAt = &now
+		finding.ResolvedBy = resolvedBy
+		if err := tx.Save(finding).Error; err != nil {
+			return err
+		}
+
+		if _, ok := reviewableTables[finding.TargetTable]; !ok {
+			return nil
+		}
+		var open int64
+		if err := tx.Model(&domain.DQFinding{}).
+			Where("target_table = ? AND target_id = ? AND status = ?", finding.TargetTable, finding.TargetID, domain.DQFindingStatusOpen).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return nil
+		}
+		return tx.Table(finding.TargetTable).
+			Where("id = ?", finding.TargetID).
+			Updates(map[string]interface{}{"review_required": false, "review_reason": nil}).Error
+	})
+}
+
+func (r *dqFindingRepository) applyFilter(db *gorm.DB, filter DQFindingFilter) *gorm.DB {
+	if filter.RuleCode != "" {
+		db = db.Where("rule_code = ?", filter.RuleCode)
+	}
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+	if filter.TargetTable != "" {
+		db = db.Where("target_table = ?", filter.TargetTable)
+	}
+	if filter.TargetID != "" {
+		db = db.Where("target_id = ?", filter.TargetID)
+	}
+	return db
+}
+
+// ReferenceCascadeRepository finds and updates records that depend on a country or currency
+type ReferenceCascadeRepository interface {
+	FindCurrencyDependents(currencyID uuid.UUID) ([]domain.DependentRef, error)
+	FindCountryDependents(countryID uuid.UUID) ([]domain.DependentRef, error)
+	SaveWithCascade(ctx context.Context, parent interface{}, dependents []domain.DependentRef, reason string, deactivate bool, findings []*domain.DQFinding) error
+}
+
+type referenceCascadeRepository struct {
+	db *gorm.DB
+}
+
+// NewReferenceCascadeRepository creates a new reference cascade repository instance
+func NewReferenceCascadeRepository(db *gorm.DB) ReferenceCascadeRepository {
+	return &referenceCascadeRepository{db: db}
+}
+
+// FindCurrencyDependents returns active accounts, SSIs and instruments denominated in the currency
+func (r *referenceCascadeRepository) FindCurrencyDependents(currencyID uuid.UUID) ([]domain.DependentRef, error) {
+	var refs []domain.DependentRef
+
+	queries := []struct {
+		table string
+		db    *gorm.DB
+	}{
+		{"accounts", r.db.Model(&domain.Account{}).Select("id, account_number AS label").Where("account_currency_id = ? AND active", currencyID)},
+		{"ssis", r.db.Model(&domain.SSI{}).Select("id, beneficiary_name || ' / ' || beneficiary_bank AS label").Where("settlement_currency_id = ? AND active", currencyID)},
+		{"instruments", r.db.Model(&domain.Instrument{}).Select("id, name AS label").Where("issue_currency_id = ? AND active", currencyID)},
+	}
+	for _, q := range queries {
+		found, err := scanDependents(q.db, q.table)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, found...)
+	}
+	return refs, nil
+}
+
+// FindCountryDependents returns addresses located in the country
+func (r *referenceCascadeRepository) FindCountryDependents(countryID uuid.UUID) ([]domain.DependentRef, error) {
+	return scanDependents(
+		r.db.Model(&domain.Address{}).
+			Select("id, CONCAT_WS(', ', NULLIF(street_name, ''), NULLIF(town_name, ''), NULLIF(postal_code, '')) AS label").
+			Where("country_id = ?", countryID),
+		"addresses",
+	)
+}
+
+func scanDependents(db *gorm.DB, table string) ([]domain.DependentRef, error) {
+	var rows []struct {
+		ID    uuid.UUID
+		Label string
+	}
+	if err := db.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	refs := make([]domain.DependentRef, 0, len(rows))
+	for _, row := range rows {
+		refs = append(refs, domain.DependentRef{Table: table, ID: row.ID, Label: row.Label})
+	}
+	return refs, nil
+}
+
+// SaveWithCascade saves the deactivated parent, flags (and optionally deactivates) its dependents
+// and records the findings in one transaction
+func (r *referenceCascadeRepository) SaveWithCascade(ctx context.Context, parent interface{}, dependents []domain.DependentRef, reason string, deactivate bool, findings []*domain.DQFinding) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(parent).Error; err != nil {
+			return err
+		}
+
+		byTable := make(map[string][]uuid.UUID)
+		for _, dep := range dependents {
+			byTable[dep.Table] = append(byTable[dep.Table], dep.ID)
+		}
+		for table, ids := range byTable {
+			hasActive, ok := reviewableTables[table]
+			if !ok {
+				continue
+			}
+			updates := map[string]interface{}{"review_required": true, "review_reason": reason}
+			if deactivate && hasActive {
+				updates["active"] = false
+			}
+			if err := tx.Table(table).Where("id IN ?", ids).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(findings) > 0 {
+			if err := tx.CreateInBatches(findings, 500).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// DeadLetterFilter narrows dead letter listing and purging; zero values match everything
+type DeadLetterFilter struct {
+	Source    string
+	Status    string
+	OlderThan *time.Time
+}
+
+// DeadLetterRepository persists permanently failed async work
+type DeadLetterRepository interface {
+	Create(item *domain.DeadLetter) error
+	FindByID(id string) (*domain.DeadLetter, error)
+	FindAll(filter DeadLetterFilter, limit, offset int) ([]*domain.DeadLetter, error)
+	Count(filter DeadLetterFilter) (int64, error)
+	Update(item *domain.DeadLetter) error
+	Delete(id string) error
+	Purge(ctx context.Context, filter DeadLetterFilter) (int64, error)
+}
+
+type deadLetterRepository struct {
+	db *gorm.DB
+}
+
+// NewDeadLetterRepository creates a new dead letter repository instance
+func NewDeadLetterRepository(db *gorm.DB) DeadLetterRepository {
+	return &deadLetterRepository{db: db}
+}
+
+func (r *deadLetterRepository) Create(item *domain.DeadLetter) error {
+	return r.db.Create(item).Error
+}
+
+func (r *deadLetterRepository) FindByID(id string) (*domain.DeadLetter, error) {
+	var item domain.DeadLetter
+	if err := r.db.First(&item, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *deadLetterRepository) FindAll(filter DeadLetterFilter, limit, offset int) ([]*domain.DeadLetter, error) {
+	var items []*domain.DeadLetter
+	if err := r.applyFilter(r.db, filter).Order("created_at DESC").Limit(limit).Offset(offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *deadLetterRepository) Count(filter DeadLetterFilter) (int64, error) {
+	var count int64
+	if err := r.applyFilter(r.db.Model(&domain.DeadLetter{}), filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *deadLetterRepository) Update(item *domain.DeadLetter) error {
+	return r.db.Save(item).Error
+}
+
+func (r *deadLetterRepository) Delete(id string) error {
+	return r.db.Delete(&domain.DeadLetter{}, "id = ?", id).Error
+}
+
+// Purge hard-deletes all items matching the filter and returns how many were removed
+func (r *deadLetterRepository) Purge(ctx context.Context, filter DeadLetterFilter) (int64, error) {
+	// Session allows a delete without conditions when the filter is empty (purge everything)
+	result := r.applyFilter(r.db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}), filter).Delete(&domain.DeadLetter{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *deadLetterRepository) applyFilter(db *gorm.DB, filter DeadLetterFilter) *gorm.DB {
+	if filter.Source != "" {
+		db = db.Where("source = ?", filter.Source)
+	}
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+	if filter.OlderThan != nil {
+		db = db.Where("created_at < ?", *filter.OlderThan)
+	}
+	return db
+}
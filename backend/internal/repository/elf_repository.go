@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ELFFilter narrows ELF code listings; zero values match everything
+type ELFFilter struct {
+	Country    string // ISO 3166-1 alpha-2 code
+	Search     string // code or part of a name or abbreviation
+	ActiveOnly bool   // leave out inactive codes
+}
+
+// ELFRepository stores the ISO 20275 ELF code list
+type ELFRepository interface {
+	Upsert(codes []*domain.ELFCode) error
+	FindByCode(code string) (*domain.ELFCode, error)
+	FindAll(filter ELFFilter, limit, offset int) ([]*domain.ELFCode, int64, error)
+	Names() (map[string]string, error)
+}
+
+type elfRepository struct {
+	db *gorm.DB
+}
+
+// NewELFRepository creates a new ELF code repository
+func NewELFRepository(db *gorm.DB) ELFRepository {
+	return &elfRepository{db: db}
+}
+
+// Upsert inserts or refreshes ELF codes by code
+func (r *elfRepository) Upsert(codes []*domain.ELFCode) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"country_code", "country", "jurisdiction", "subdivision_code", "name",
+			"abbreviations", "names", "status", "created_date", "modification_date", "imported_at", "updated_at"}),
+	}).CreateInBatches(codes, 1000).Error
+}
+
+// FindByCode returns an ELF code
+func (r *elfRepository) FindByCode(code string) (*domain.ELFCode, error) {
+	var elf domain.ELFCode
+	if err := r.db.First(&elf, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &elf, nil
+}
+
+// FindAll lists ELF codes in country, then code order
+func (r *elfRepository) FindAll(filter ELFFilter, limit, offset int) ([]*domain.ELFCode, int64, error) {
+	query := r.db.Model(&domain.ELFCode{})
+	if filter.Country != "" {
+		query = query.Where("country_code = ?", filter.Country)
+	}
+	if filter.Search != "" {
+		query = query.Where("code = ? OR name ILIKE ? OR abbreviations ILIKE ? OR names::text ILIKE ?",
+			strings.ToUpper(filter.Search), "%"+filter.Search+"%", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+	if filter.ActiveOnly {
+		query = query.Where("status <> ?", domain.ELFStatusInactive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var codes []*domain.ELFCode
+	if err := query.Order("country_code, code").Limit(limit).Offset(offset).Find(&codes).Error; err != nil {
+		return nil, 0, err
+	}
+	return codes, total, nil
+}
+
+// Names returns the display name of every ELF code
+func (r *elfRepository) Names() (map[string]string, error) {
+	var rows []struct {
+		Code string
+		Name string
+	}
+	if err := r.db.Model(&domain.ELFCode{}).Select("code, name").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(rows))
+	for _, row := range rows {
+		names[row.Code] = row.Name
+	}
+	return names, nil
+}
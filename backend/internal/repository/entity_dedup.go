@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrMergeOnboardingInProgress is returned when either entity of a merge has an onboarding that is
+// neither approved nor rejected; it has to be finished first
+var ErrMergeOnboardingInProgress = errors.New("an entity to merge has an onboarding in progress")
+
+// entityRegistrationKeySQL compares registration numbers ignoring case, spaces and punctuation
+const entityRegistrationKeySQL = "regexp_replace(upper(coalesce(registration_number, '')), '[^A-Z0-9]', '', 'g')"
+
+// EntityDuplicateCandidate is a pair of live entities that share an LEI or registration number, or
+// whose names are trigram-similar; EntityID is the lower of the two IDs
+type EntityDuplicateCandidate struct {
+	EntityID               uuid.UUID
+	DuplicateID            uuid.UUID
+	EntityName             string
+	DuplicateName          string
+	SameLEI                bool
+	SameRegistrationNumber bool
+}
+
+// EntityDuplicateFilter narrows duplicate listing; zero values match everything
+type EntityDuplicateFilter struct {
+	Status   string // OPEN, MERGED or DISMISSED
+	EntityID string // pairs either side of which is this entity
+}
+
+// OrderedEntityPair returns two entity IDs in the order a duplicate pair is stored in, the order
+// Postgres compares UUIDs in
+func OrderedEntityPair(a, b uuid.UUID) (uuid.UUID, uuid.UUID) {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		return b, a
+	}
+	return a, b
+}
+
+// FindDuplicateCandidates returns every pair of live entities with the same LEI, the same
+// registration number, or names at least nameThreshold similar by pg_trgm
+func (r *entityRepository) FindDuplicateCandidates(nameThreshold float64) ([]*EntityDuplicateCandidate, error) {
+	var candidates []*EntityDuplicateCandidate
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The % operator compares against pg_trgm.similarity_threshold; scope it to this transaction
+		if err := tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true)", fmt.Sprintf("%.2f", nameThreshold)).Error; err != nil {
+			return err
+		}
+
+		return tx.Raw(`
+			WITH live AS (
+				SELECT id, name, coalesce(lei, '') AS lei, ` + entityRegistrationKeySQL + ` AS registration_key
+				FROM entities WHERE deleted_at IS NULL
+			), pairs AS (
+				SELECT a.id AS entity_id, b.id AS duplicate_id
+				FROM live a JOIN live b ON a.id < b.id AND a.lei = b.lei
+				WHERE a.lei != ''
+				UNION
+				SELECT a.id, b.id
+				FROM live a JOIN live b ON a.id < b.id AND a.registration_key = b.registration_key
+				WHERE a.registration_key != ''
+				UNION
+				SELECT a.id, b.id
+				FROM entities a JOIN entities b ON a.id < b.id AND a.name % b.name
+				WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL
+			)
+			SELECT p.entity_id, p.duplicate_id, a.name AS entity_name, b.name AS duplicate_name,
+				(a.lei != '' AND a.lei = b.lei) AS same_lei,
+				(a.registration_key != '' AND a.registration_key = b.registration_key) AS same_registration_number
+			FROM pairs p
+			JOIN live a ON a.id = p.entity_id
+			JOIN live b ON b.id = p.duplicate_id
+			ORDER BY p.entity_id, p.duplicate_id`).Scan(&candidates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// SaveDuplicateScan stores the pairs flagged by a scan that started at scannedAt: new pairs are
+// created OPEN and open pairs refreshed, while reviewed pairs are left as they are. Open pairs the
+// scan no longer flagged, e.g. because one side was edited, are removed.
+// Returns the number of pairs flagged for the first time.
+func (r *entityRepository) SaveDuplicateScan(found []*domain.EntityDuplicate, scannedAt time.Time) (int, error) {
+	var created int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var before, after int64
+		if err := tx.Model(&domain.EntityDuplicate{}).Count(&before).Error; err != nil {
+			return err
+		}
+		if len(found) > 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "entity_id"}, {Name: "duplicate_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"reasons", "name_similarity", "detected_at"}),
+				Where:     clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "entity_duplicates.status", Value: domain.EntityDuplicateStatusOpen}}},
+			}).CreateInBatches(found, 500).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&domain.EntityDuplicate{}).Count(&after).Error; err != nil {
+			return err
+		}
+		created = after - before
+
+		return tx.Where("status = ? AND detected_at < ?", domain.EntityDuplicateStatusOpen, scannedAt).
+			Delete(&domain.EntityDuplicate{}).Error
+	})
+	return int(created), err
+}
+
+// FindDuplicates lists duplicate pairs matching the filter, most similar first, with both entities
+// (including merged, since deleted, ones)
+func (r *entityRepository) FindDuplicates(filter EntityDuplicateFilter, limit, offset int) ([]*domain.EntityDuplicate, int64, error) {
+	query := r.db.Model(&domain.EntityDuplicate{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ? OR duplicate_id = ?", filter.EntityID, filter.EntityID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var duplicates []*domain.EntityDuplicate
+	err := query.
+		Preload("Entity", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Duplicate", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("name_similarity DESC, detected_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&duplicates).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return duplicates, total, nil
+}
+
+// FindDuplicateByID returns a duplicate pair by ID
+func (r *entityRepository) FindDuplicateByID(id string) (*domain.EntityDuplicate, error) {
+	var duplicate domain.EntityDuplicate
+	if err := r.db.First(&duplicate, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &duplicate, nil
+}
+
+// UpdateDuplicate saves a reviewed duplicate pair
+func (r *entityRepository) UpdateDuplicate(duplicate *domain.EntityDuplicate) error {
+	return r.db.Omit(clause.Associations).Save(duplicate).Error
+}
+
+// MergeEntities merges an entity into the survivor in one transaction: the merged entity's
+// accounts, SSIs and addresses move to the survivor, the survivor is saved with the fields filled
+// from the merged entity, the merged entity is soft-deleted, and merge is recorded with the counts
+// of what was moved. An address both entities link to stays linked once; moved addresses are no
+// longer primary if the survivor already has a primary address. An entity has one onboarding at
+// most, so the merged entity's finished onboarding moves only if the survivor has none; otherwise
+// it stays with the merged entity as history.
+// Returns gorm.ErrRecordNotFound if the merged entity was deleted in the meantime, and
+// ErrMergeOnboardingInProgress if either entity is being onboarded.
+func (r *entityRepository) MergeEntities(ctx context.Context, survivor *domain.Entity, mergedID uuid.UUID, merge *domain.EntityMerge) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locked, so an onboarding cannot start or move on while the merge decides about it
+		var onboardings []*domain.Onboarding
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("entity_id IN ?", []uuid.UUID{survivor.ID, mergedID}).Find(&onboardings).Error
+		if err != nil {
+			return err
+		}
+		var mergedOnboarding *domain.Onboarding
+		survivorOnboarded := false
+		for _, onboarding := range onboardings {
+			if onboarding.Status != domain.OnboardingStatusApproved && onboarding.Status != domain.OnboardingStatusRejected {
+				return fmt.Errorf("%w: onboarding %s is %s", ErrMergeOnboardingInProgress, onboarding.ID, onboarding.Status)
+			}
+			if onboarding.EntityID == survivor.ID {
+				survivorOnboarded = true
+			} else {
+				mergedOnboarding = onboarding
+			}
+		}
+
+		result := tx.Delete(&domain.Entity{}, "id = ?", mergedID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var accounts []*domain.Account
+		if err := tx.Where("entity_id = ?", mergedID).Find(&accounts).Error; err != nil {
+			return err
+		}
+		var ssis []*domain.SSI
+		if err := tx.Where("entity_id = ?", mergedID).Find(&ssis).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.Account{}).Where("entity_id = ?", mergedID).Update("entity_id", survivor.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.SSI{}).Where("entity_id = ?", mergedID).Update("entity_id", survivor.ID).Error; err != nil {
+			return err
+		}
+
+		if mergedOnboarding != nil && !survivorOnboarded {
+			err := tx.Model(mergedOnboarding).Updates(map[string]any{"entity_id": survivor.ID, "updated_at": time.Now()}).Error
+			if err != nil {
+				return err
+			}
+			merge.Onboardings = 1
+		}
+
+		var survivorHasPrimary bool
+		err = tx.Raw("SELECT EXISTS (SELECT 1 FROM entity_addresses WHERE entity_id = ? AND is_primary AND deleted_at IS NULL)", survivor.ID).
+			Scan(&survivorHasPrimary).Error
+		if err != nil {
+			return err
+		}
+		// The unique (entity_id, address_id) constraint covers soft-deleted links too
+		moved := tx.Exec(`
+			UPDATE entity_addresses SET entity_id = ?, is_primary = is_primary AND NOT ?, updated_at = ?
+			WHERE entity_id = ? AND deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM entity_addresses s WHERE s.entity_id = ? AND s.address_id = entity_addresses.address_id)`,
+			survivor.ID, survivorHasPrimary, time.Now(), mergedID, survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		if err := tx.Where("entity_id = ?", mergedID).Delete(&domain.EntityAddress{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("entity_id = ?", mergedID).Delete(&domain.EntityFieldProvenance{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Save(survivor).Error; err != nil {
+			return err
+		}
+
+		// The pair, if it was flagged, is resolved by the merge; other open pairs of the merged
+		// entity are stale and come back against the survivor on the next scan if still relevant
+		low, high := OrderedEntityPair(survivor.ID, mergedID)
+		now := time.Now()
+		err = tx.Model(&domain.EntityDuplicate{}).
+			Where("entity_id = ? AND duplicate_id = ?", low, high).
+			Updates(map[string]any{"status": domain.EntityDuplicateStatusMerged, "reviewed_by": merge.MergedBy, "reviewed_at": now}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("status = ? AND (entity_id = ? OR duplicate_id = ?)", domain.EntityDuplicateStatusOpen, mergedID, mergedID).
+			Delete(&domain.EntityDuplicate{}).Error
+		if err != nil {
+			return err
+		}
+
+		merge.Accounts = len(accounts)
+		merge.SSIs = len(ssis)
+		merge.Addresses = int(moved.RowsAffected)
+		if err := tx.Create(merge).Error; err != nil {
+			return err
+		}
+
+		if err := r.changes.recordChange(tx, events.DomainEntity, events.ActionUpdated, survivor.ID, survivor); err != nil {
+			return err
+		}
+		if err := r.changes.recordDelete(tx, events.DomainEntity, mergedID.String()); err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			account.EntityID = &survivor.ID
+			if err := r.changes.recordChange(tx, events.DomainAccount, events.ActionUpdated, account.ID, account); err != nil {
+				return err
+			}
+		}
+		for _, ssi := range ssis {
+			ssi.EntityID = &survivor.ID
+			if err := r.changes.recordChange(tx, events.DomainSSI, events.ActionUpdated, ssi.ID, ssi); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindMerges lists merges, newest first; with entityID only those it survived or was merged in
+func (r *entityRepository) FindMerges(entityID string, limit, offset int) ([]*domain.EntityMerge, int64, error) {
+	query := r.db.Model(&domain.EntityMerge{})
+	if entityID != "" {
+		query = query.Where("survivor_id = ? OR merged_id = ?", entityID, entityID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var merges []*domain.EntityMerge
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&merges).Error; err != nil {
+		return nil, 0, err
+	}
+	return merges, total, nil
+}
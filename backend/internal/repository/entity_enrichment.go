@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FindIDsWithLEI returns the IDs of entities that have an LEI, oldest first
+func (r *entityRepository) FindIDsWithLEI() ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&domain.Entity{}).
+		Where("lei IS NOT NULL AND lei != ''").
+		Order("created_at").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// SaveEnrichment saves an enriched entity in one transaction: the entity's own columns, its
+// registered address (created or updated, if given) and the provenance of the filled fields
+func (r *entityRepository) SaveEnrichment(entity *domain.Entity, registered *domain.EntityAddress, provenance []*domain.EntityFieldProvenance) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if registered != nil {
+			if err := tx.Save(registered.Address).Error; err != nil {
+				return err
+			}
+			registered.AddressID = registered.Address.ID
+			if err := tx.Omit(clause.Associations).Save(registered).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit(clause.Associations).Save(entity).Error; err != nil {
+			return err
+		}
+		if len(provenance) > 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "entity_id"}, {Name: "field"}},
+				DoUpdates: clause.AssignmentColumns([]string{"source", "source_ref", "value", "filled_at"}),
+			}).Create(&provenance).Error
+			if err != nil {
+				return err
+			}
+		}
+		return r.changes.recordChange(tx, events.DomainEntity, events.ActionUpdated, entity.ID, entity)
+	})
+}
+
+// DeleteFieldProvenance forgets that fields were auto-filled, e.g. because they were edited since
+func (r *entityRepository) DeleteFieldProvenance(entityID uuid.UUID, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return r.db.Where("entity_id = ? AND field IN ?", entityID, fields).Delete(&domain.EntityFieldProvenance{}).Error
+}
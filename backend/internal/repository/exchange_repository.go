@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExchangeFilter narrows exchange listings; zero values match everything
+type ExchangeFilter struct {
+	Country    string // ISO 3166-1 alpha-2 code
+	Search     string // MIC, acronym or part of the name
+	ActiveOnly bool   // leave out expired MICs
+}
+
+// ExchangeRepository stores the ISO 10383 MIC list
+type ExchangeRepository interface {
+	Upsert(exchanges []*domain.Exchange) error
+	FindByMIC(mic string) (*domain.Exchange, error)
+	FindAll(filter ExchangeFilter, limit, offset int) ([]*domain.Exchange, int64, error)
+	HasEntries() (bool, error)
+}
+
+type exchangeRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeRepository creates a new exchange repository
+func NewExchangeRepository(db *gorm.DB) ExchangeRepository {
+	return &exchangeRepository{db: db}
+}
+
+// Upsert inserts or refreshes exchanges by MIC
+func (r *exchangeRepository) Upsert(exchanges []*domain.Exchange) error {
+	if len(exchanges) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "mic"}},
+		DoUpdates: clause.AssignmentColumns([]string{"operating_mic", "market_type", "name", "legal_entity_name", "lei",
+			"market_category_code", "acronym", "country_code", "city", "website", "status", "creation_date",
+			"last_update_date", "expiry_date", "imported_at", "updated_at"}),
+	}).CreateInBatches(exchanges, 1000).Error
+}
+
+// FindByMIC returns the exchange of a MIC
+func (r *exchangeRepository) FindByMIC(mic string) (*domain.Exchange, error) {
+	var exchange domain.Exchange
+	if err := r.db.First(&exchange, "mic = ?", mic).Error; err != nil {
+		return nil, err
+	}
+	return &exchange, nil
+}
+
+// FindAll lists exchanges in MIC order
+func (r *exchangeRepository) FindAll(filter ExchangeFilter, limit, offset int) ([]*domain.Exchange, int64, error) {
+	query := r.db.Model(&domain.Exchange{})
+	if filter.Country != "" {
+		query = query.Where("country_code = ?", filter.Country)
+	}
+	if filter.Search != "" {
+		query = query.Where("mic = ? OR UPPER(acronym) = ? OR LOWER(name) LIKE ?",
+			strings.ToUpper(filter.Search), strings.ToUpper(filter.Search), "%"+strings.ToLower(filter.Search)+"%")
+	}
+	if filter.ActiveOnly {
+		query = query.Where("status <> ?", domain.MICStatusExpired)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var exchanges []*domain.Exchange
+	if err := query.Order("mic").Limit(limit).Offset(offset).Find(&exchanges).Error; err != nil {
+		return nil, 0, err
+	}
+	return exchanges, total, nil
+}
+
+// HasEntries reports whether a MIC list has been imported
+func (r *exchangeRepository) HasEntries() (bool, error) {
+	var found []string
+	if err := r.db.Model(&domain.Exchange{}).Limit(1).Pluck("mic", &found).Error; err != nil {
+		return false, err
+	}
+	return len(found) > 0, nil
+}
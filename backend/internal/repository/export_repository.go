@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// SavedViewRepository persists saved LEI views
+type SavedViewRepository interface {
+	Create(view *domain.LEISavedView) error
+	FindByID(id string) (*domain.LEISavedView, error)
+	FindAll() ([]*domain.LEISavedView, error)
+	Update(view *domain.LEISavedView) error
+	Delete(id string) error
+}
+
+type savedViewRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedViewRepository creates a new saved view repository instance
+func NewSavedViewRepository(db *gorm.DB) SavedViewRepository {
+	return &savedViewRepository{db: db}
+}
+
+func (r *savedViewRepository) Create(view *domain.LEISavedView) error {
+	return r.db.Create(view).Error
+}
+
+func (r *savedViewRepository) FindByID(id string) (*domain.LEISavedView, error) {
+	var view domain.LEISavedView
+	if err := r.db.First(&view, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (r *savedViewRepository) FindAll() ([]*domain.LEISavedView, error) {
+	var views []*domain.LEISavedView
+	if err := r.db.Order("name ASC").Find(&views).Error; err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+func (r *savedViewRepository) Update(view *domain.LEISavedView) error {
+	return r.db.Save(view).Error
+}
+
+// Delete removes a view; its scheduled exports are removed by the foreign key cascade
+func (r *savedViewRepository) Delete(id string) error {
+	return r.db.Delete(&domain.LEISavedView{}, "id = ?", id).Error
+}
+
+// ScheduledExportRepository persists scheduled exports
+type ScheduledExportRepository interface {
+	Create(export *domain.ScheduledExport) error
+	FindByID(id string) (*domain.ScheduledExport, error)
+	FindAll() ([]*domain.ScheduledExport, error)
+	FindDue(now time.Time) ([]*domain.ScheduledExport, error)
+	Update(export *domain.ScheduledExport) error
+	Delete(id string) error
+}
+
+type scheduledExportRepository struct {
+	db *gorm.DB
+}
+
+// NewScheduledExportRepository creates a new scheduled export repository instance
+func NewScheduledExportRepository(db *gorm.DB) ScheduledExportRepository {
+	return &scheduledExportRepository{db: db}
+}
+
+func (r *scheduledExportRepository) Create(export *domain.ScheduledExport) error {
+	return r.db.Create(export).Error
+}
+
+func (r *scheduledExportRepository) FindByID(id string) (*domain.ScheduledExport, error) {
+	var export domain.ScheduledExport
+	if err := r.db.Preload("SavedView").First(&export, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *scheduledExportRepository) FindAll() ([]*domain.ScheduledExport, error) {
+	var exports []*domain.ScheduledExport
+	if err := r.db.Preload("SavedView").Order("name ASC").Find(&exports).Error; err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// FindDue returns active exports whose next run is at or before now, oldest first
+func (r *scheduledExportRepository) FindDue(now time.Time) ([]*domain.ScheduledExport, error) {
+	var exports []*domain.ScheduledExport
+	if err := r.db.Preload("SavedView").
+		Where("active = ? AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC").
+		Find(&exports).Error; err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *scheduledExportRepository) Update(export *domain.ScheduledExport) error {
+	return r.db.Omit("SavedView").Save(export).Error
+}
+
+func (r *scheduledExportRepository) Delete(id string) error {
+	return r.db.Delete(&domain.ScheduledExport{}, "id = ?", id).Error
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// FreshnessTables lists the tables reported by the freshness endpoint (schema-qualified where needed)
+// Only these names are ever interpolated into SQL
+var FreshnessTables = []string{
+	"countries",
+	"currencies",
+	"entities",
+	"instruments",
+	"accounts",
+	"ssis",
+	"lei_raw.lei_records",
+}
+
+// FreshnessRepository reads cheap table statistics for data consumers
+type FreshnessRepository interface {
+	GetTableFreshness(table string) (*domain.TableFreshness, error)
+	GetLastImports() ([]domain.ImportFreshness, error)
+}
+
+type freshnessRepository struct {
+	db *gorm.DB
+}
+
+// NewFreshnessRepository creates a new freshness repository instance
+func NewFreshnessRepository(db *gorm.DB) FreshnessRepository {
+	return &freshnessRepository{db: db}
+}
+
+// GetTableFreshness returns estimated row count and change counter from pg_stat_user_tables,
+// plus MAX(updated_at) (served from an index on the large tables). The statistics counter restarts
+// at zero on a reset, crash or failover, so the change counter reported adds the offset kept in
+// change_counter_offsets: a reading below the last one moves the offset past it.
+func (r *freshnessRepository) GetTableFreshness(table string) (*domain.TableFreshness, error) {
+	if !isFreshnessTable(table) {
+		return nil, fmt.Errorf("table %s is not reported by the freshness endpoint", table)
+	}
+
+	schema, relname := "public", table
+	if idx := strings.Index(table, "."); idx > 0 {
+		schema, relname = table[:idx], table[idx+1:]
+	}
+
+	result := &domain.TableFreshness{Table: table}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the offset before reading the counter, so replicas reading concurrently see the
+		// counter rise rather than mistake an earlier reading for a reset
+		if err := tx.Exec("INSERT INTO change_counter_offsets (table_name) VALUES (?) ON CONFLICT (table_name) DO NOTHING", table).Error; err != nil {
+			return err
+		}
+		var offset struct {
+			Base     int64
+			LastSeen int64
+		}
+		if err := tx.Raw("SELECT base, last_seen FROM change_counter_offsets WHERE table_name = ? FOR UPDATE", table).Scan(&offset).Error; err != nil {
+			return err
+		}
+
+		var stats struct {
+			LiveTuples    int64
+			ChangeCounter int64
+		}
+		err := tx.Raw(`
+			SELECT n_live_tup AS live_tuples, n_tup_ins + n_tup_upd + n_tup_del AS change_counter
+			FROM pg_stat_user_tables
+			WHERE schemaname = ? AND relname = ?`, schema, relname).Scan(&stats).Error
+		if err != nil {
+			return err
+		}
+
+		// Changes made between the last reading and the reset are lost with the statistics: one more
+		// makes sure the counter moves on
+		if stats.ChangeCounter < offset.LastSeen {
+			offset.Base += offset.LastSeen + 1
+		}
+		if err := tx.Exec("UPDATE change_counter_offsets SET base = ?, last_seen = ?, updated_at = NOW() WHERE table_name = ?",
+			offset.Base, stats.ChangeCounter, table).Error; err != nil {
+			return err
+		}
+
+		result.RowCount = stats.LiveTuples
+		result.ChangeCounter = offset.Base + stats.ChangeCounter
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statistics for %s: %w", table, err)
+	}
+
+	var lastUpdated *time.Time
+	if err := r.db.Raw("SELECT MAX(updated_at) FROM " + table).Scan(&lastUpdated).Error; err != nil {
+		return nil, fmt.Errorf("failed to read last update for %s: %w", table, err)
+	}
+	result.LastUpdatedAt = lastUpdated
+
+	return result, nil
+}
+
+// GetLastImports returns the status of each LEI import job
+func (r *freshnessRepository) GetLastImports() ([]domain.ImportFreshness, error) {
+	var statuses []domain.FileProcessingStatus
+	if err := r.db.Order("job_type").Find(&statuses).Error; err != nil {
+		return nil, err
+	}
+
+	imports := make([]domain.ImportFreshness, 0, len(statuses))
+	for _, s := range statuses {
+		imports = append(imports, domain.ImportFreshness{
+			JobType:       s.JobType,
+			Status:        s.Status,
+			LastRunAt:     s.LastRunAt,
+			LastSuccessAt: s.LastSuccessAt,
+		})
+	}
+	return imports, nil
+}
+
+func isFreshnessTable(table string) bool {
+	for _, t := range FreshnessTables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// fxRateBatchSize is the number of rates written per insert
+const fxRateBatchSize = 500
+
+// FXRateRepository stores daily FX reference rates
+type FXRateRepository interface {
+	Upsert(rates []*domain.FXRate) error
+	LatestDate(base string, onOrBefore time.Time) (*time.Time, error)
+	FindByDate(base string, date time.Time) ([]*domain.FXRate, error)
+	FindHistory(base string, quotes []string, from, to time.Time) ([]*domain.FXRate, error)
+}
+
+type fxRateRepository struct {
+	db *gorm.DB
+}
+
+// NewFXRateRepository creates a new FX rate repository
+func NewFXRateRepository(db *gorm.DB) FXRateRepository {
+	return &fxRateRepository{db: db}
+}
+
+// Upsert adds rates, replacing the rate and source of those already stored for their date and pair
+func (r *fxRateRepository) Upsert(rates []*domain.FXRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "rate_date"}, {Name: "base_currency"}, {Name: "quote_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "source", "updated_at"}),
+	}).CreateInBatches(rates, fxRateBatchSize).Error
+}
+
+// LatestDate returns the newest date with rates against a base currency on or before a date, or
+// nil if there is none
+func (r *fxRateRepository) LatestDate(base string, onOrBefore time.Time) (*time.Time, error) {
+	var latest *time.Time
+	err := r.db.Model(&domain.FXRate{}).
+		Where("base_currency = ? AND rate_date <= ?", base, onOrBefore).
+		Select("MAX(rate_date)").Scan(&latest).Error
+	if err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
+// FindByDate returns the rates against a base currency on a date, by quote currency
+func (r *fxRateRepository) FindByDate(base string, date time.Time) ([]*domain.FXRate, error) {
+	var rates []*domain.FXRate
+	if err := r.db.Where("base_currency = ? AND rate_date = ?", base, date).
+		Order("quote_currency").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// FindHistory returns the rates of quote currencies against a base currency from one date to
+// another, both included, in date order
+func (r *fxRateRepository) FindHistory(base string, quotes []string, from, to time.Time) ([]*domain.FXRate, error) {
+	var rates []*domain.FXRate
+	if err := r.db.Where("base_currency = ? AND quote_currency IN ? AND rate_date BETWEEN ? AND ?", base, quotes, from, to).
+		Order("rate_date, quote_currency").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ErrIDRangeExhausted is returned when a sequence has allocated every value in its range
+var ErrIDRangeExhausted = errors.New("ID sequence range exhausted")
+
+// businessKeyColumns maps ID sequence targets to the table and column holding the business key
+// Only these names are ever interpolated into SQL
+var businessKeyColumns = map[string][2]string{
+	domain.IDTargetEntity:  {"entities", "business_id"},
+	domain.IDTargetAccount: {"accounts", "account_number"},
+}
+
+// IDSequenceRepository persists ID sequences and allocates values from them
+type IDSequenceRepository interface {
+	Create(seq *domain.IDSequence) error
+	FindByID(id string) (*domain.IDSequence, error)
+	FindAll() ([]*domain.IDSequence, error)
+	// FindActiveByTarget returns the active sequence for a target, or nil if none is configured
+	FindActiveByTarget(target string) (*domain.IDSequence, error)
+	Update(seq *domain.IDSequence) error
+	Delete(id string) error
+	// Allocate atomically reserves the sequence's next value
+	Allocate(id string) (int64, error)
+	// BusinessKeyExists reports whether a business key is already used by a record of the target
+	BusinessKeyExists(target, value string) (bool, error)
+}
+
+type idSequenceRepository struct {
+	db *gorm.DB
+}
+
+// NewIDSequenceRepository creates a new ID sequence repository instance
+func NewIDSequenceRepository(db *gorm.DB) IDSequenceRepository {
+	return &idSequenceRepository{db: db}
+}
+
+func (r *idSequenceRepository) Create(seq *domain.IDSequence) error {
+	return r.db.Create(seq).Error
+}
+
+func (r *idSequenceRepository) FindByID(id string) (*domain.IDSequence, error) {
+	var seq domain.IDSequence
+	if err := r.db.First(&seq, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &seq, nil
+}
+
+func (r *idSequenceRepository) FindAll() ([]*domain.IDSequence, error) {
+	var seqs []*domain.IDSequence
+	if err := r.db.Order("target ASC, name ASC").Find(&seqs).Error; err != nil {
+		return nil, err
+	}
+	return seqs, nil
+}
+
+func (r *idSequenceRepository) FindActiveByTarget(target string) (*domain.IDSequence, error) {
+	var seq domain.IDSequence
+	err := r.db.Where("target = ? AND active = ?", target, true).First(&seq).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &seq, nil
+}
+
+func (r *idSequenceRepository) Update(seq *domain.IDSequence) error {
+	return r.db.Save(seq).Error
+}
+
+func (r *idSequenceRepository) Delete(id string) error {
+	return r.db.Delete(&domain.IDSequence{}, "id = ?", id).Error
+}
+
+// Allocate increments next_value in a single statement so concurrent creates never share a value
+func (r *idSequenceRepository) Allocate(id string) (int64, error) {
+	var allocated []int64
+	err := r.db.Raw(`UPDATE id_sequences
+		SET next_value = next_value + 1, updated_at = NOW()
+		WHERE id = ? AND (max_value = 0 OR next_value <= max_value)
+		RETURNING next_value - 1`, id).Scan(&allocated).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(allocated) == 0 {
+		return 0, ErrIDRangeExhausted
+	}
+	return allocated[0], nil
+}
+
+func (r *idSequenceRepository) BusinessKeyExists(target, value string) (bool, error) {
+	column, ok := businessKeyColumns[target]
+	if !ok {
+		return false, fmt.Errorf("unknown ID sequence target %q", target)
+	}
+	var count int64
+	if err := r.db.Table(column[0]).Where(column[1]+" = ?", value).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ImportJobRepository stores master data file imports and queues them for the import workers
+type ImportJobRepository interface {
+	Create(job *domain.ImportJob) error
+	Update(job *domain.ImportJob) error
+	UpdateProgress(job *domain.ImportJob) error
+	ClaimNext() (*domain.ImportJob, error)
+	FailStale(before time.Time, message string) ([]*domain.ImportJob, error)
+	Requeue(id string) (bool, error)
+	FindByID(id string) (*domain.ImportJob, error)
+	FindAll(limit, offset int) ([]*domain.ImportJob, int64, error)
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobRepository creates a new import job repository
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) Create(job *domain.ImportJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *importJobRepository) Update(job *domain.ImportJob) error {
+	return r.db.Save(job).Error
+}
+
+// UpdateProgress saves the row counts of a running import
+func (r *importJobRepository) UpdateProgress(job *domain.ImportJob) error {
+	return r.db.Model(job).Updates(map[string]interface{}{
+		"processed_rows": job.ProcessedRows,
+		"created_rows":   job.CreatedRows,
+		"updated_rows":   job.UpdatedRows,
+		"unchanged_rows": job.UnchangedRows,
+		"failed_rows":    job.FailedRows,
+	}).Error
+}
+
+// ClaimNext marks the oldest queued import RUNNING and returns it with its file, or nil when none
+// is queued. Queued jobs locked by another replica's worker are skipped.
+func (r *importJobRepository) ClaimNext() (*domain.ImportJob, error) {
+	var ids []string
+	err := r.db.Raw(`
+		UPDATE import_jobs SET status = ?, started_at = ?
+		WHERE id = (
+			SELECT id FROM import_jobs WHERE status = ?
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id`, domain.ImportStatusRunning, time.Now().UTC(), domain.ImportStatusQueued).Scan(&ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	var job domain.ImportJob
+	if err := r.db.First(&job, "id = ?", ids[0]).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FailStale marks FAILED the imports still RUNNING without progress since before, whose worker
+// has gone away, and returns them (without their files, which are kept for a retry)
+func (r *importJobRepository) FailStale(before time.Time, message string) ([]*domain.ImportJob, error) {
+	var jobs []*domain.ImportJob
+	err := r.db.Raw(`
+		UPDATE import_jobs SET status = ?, error = ?, completed_at = ?, updated_at = ?
+		WHERE status = ? AND updated_at < ?
+		RETURNING id, kind, file_name, error`,
+		domain.ImportStatusFailed, message, time.Now().UTC(), time.Now().UTC(), domain.ImportStatusRunning, before).Scan(&jobs).Error
+	return jobs, err
+}
+
+// Requeue queues a FAILED import whose file is still stored again, clearing its outcome; false if
+// the import is not FAILED or no longer has its file
+func (r *importJobRepository) Requeue(id string) (bool, error) {
+	result := r.db.Model(&domain.ImportJob{}).
+		Where("id = ? AND status = ? AND payload IS NOT NULL", id, domain.ImportStatusFailed).
+		Updates(map[string]interface{}{
+			"status":         domain.ImportStatusQueued,
+			"error":          "",
+			"processed_rows": 0,
+			"created_rows":   0,
+			"updated_rows":   0,
+			"unchanged_rows": 0,
+			"failed_rows":    0,
+			"report":         "[]",
+			"started_at":     nil,
+			"completed_at":   nil,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// FindByID returns an import job with its row report
+func (r *importJobRepository) FindByID(id string) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	if err := r.db.Omit("payload").First(&job, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindAll lists import jobs, newest first, without their row reports
+func (r *importJobRepository) FindAll(limit, offset int) ([]*domain.ImportJob, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.ImportJob{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var jobs []*domain.ImportJob
+	if err := r.db.Omit("report", "payload").Order("created_at DESC").Limit(limit).Offset(offset).Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, total, nil
+}
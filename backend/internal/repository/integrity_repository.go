@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ReferenceCheck describes a foreign key column whose parent may have been soft-deleted
+// (or removed outright) without the child following
+type ReferenceCheck struct {
+	RuleCode        string
+	Table           string
+	Column          string
+	ReferencedTable string
+	Label           string // SQL expression over the child row (alias c) used in finding messages
+	Repairable      bool   // child rows are meaningless without the parent and may be soft-deleted
+}
+
+// ReferenceChecks lists every reference scanned by the integrity check
+// Only these names are ever interpolated into SQL
+var ReferenceChecks = []ReferenceCheck{
+	{domain.DQRuleOrphanedEntityReference, "accounts", "entity_id", "entities", "c.account_number", false},
+	{domain.DQRuleOrphanedEntityReference, "ssis", "entity_id", "entities", "c.beneficiary_name || ' / ' || c.beneficiary_bank", false},
+	{domain.DQRuleOrphanedEntityReference, "entity_addresses", "entity_id", "entities", "COALESCE(c.address_type, '') || ' ' || c.address_id::text", true},
+	{domain.DQRuleOrphanedAddressReference, "entity_addresses", "address_id", "addresses", "COALESCE(c.address_type, '') || ' ' || c.entity_id::text", true},
+	{domain.DQRuleOrphanedCurrencyReference, "accounts", "account_currency_id", "currencies", "c.account_number", false},
+	{domain.DQRuleOrphanedCurrencyReference, "ssis", "settlement_currency_id", "currencies", "c.beneficiary_name || ' / ' || c.beneficiary_bank", false},
+	{domain.DQRuleOrphanedCurrencyReference, "instruments", "issue_currency_id", "currencies", "c.name", false},
+	{domain.DQRuleOrphanedCurrencyReference, "instrument_prices", "currency_id", "currencies", "c.source || ' ' || c.price_date::text", false},
+	{domain.DQRuleOrphanedCountryReference, "addresses", "country_id", "countries", "CONCAT_WS(', ', NULLIF(c.street_name, ''), NULLIF(c.town_name, ''), NULLIF(c.postal_code, ''))", false},
+	{domain.DQRuleOrphanedInstrumentReference, "ssis", "instrument_id", "instruments", "c.beneficiary_name || ' / ' || c.beneficiary_bank", false},
+	{domain.DQRuleOrphanedInstrumentReference, "instrument_codes", "instrument_id", "instruments", "c.code_type || ' ' || c.code_value", true},
+	{domain.DQRuleOrphanedInstrumentReference, "instrument_prices", "instrument_id", "instruments", "c.source || ' ' || c.price_date::text", true},
+}
+
+// IntegrityRepository finds and repairs live records whose references point at deleted rows
+type IntegrityRepository interface {
+	FindOrphans(check ReferenceCheck) ([]domain.DependentRef, error)
+	SoftDelete(ctx context.Context, table string, ids []uuid.UUID) error
+	RecordFindings(ctx context.Context, findings []*domain.DQFinding) (int, error)
+}
+
+type integrityRepository struct {
+	db *gorm.DB
+}
+
+// NewIntegrityRepository creates a new integrity repository instance
+func NewIntegrityRepository(db *gorm.DB) IntegrityRepository {
+	return &integrityRepository{db: db}
+}
+
+// FindOrphans returns live rows of check.Table whose check.Column is set but whose parent
+// is missing or soft-deleted
+func (r *integrityRepository) FindOrphans(check ReferenceCheck) ([]domain.DependentRef, error) {
+	if !isReferenceCheck(check) {
+		return nil, fmt.Errorf("unknown reference check %s.%s", check.Table, check.Column)
+	}
+	sql := fmt.Sprintf(`SELECT c.id, COALESCE(%s, '') AS label
+		FROM %s c LEFT JOIN %s p ON p.id = c.%s
+		WHERE c.deleted_at IS NULL AND c.%s IS NOT NULL AND (p.id IS NULL OR p.deleted_at IS NOT NULL)
+		ORDER BY c.created_at`,
+		check.Label, check.Table, check.ReferencedTable, check.Column, check.Column)
+	return scanDependents(r.db.Raw(sql), check.Table)
+}
+
+// SoftDelete marks the rows deleted, as GORM's Delete would for the matching model
+func (r *integrityRepository) SoftDelete(ctx context.Context, table string, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if !isRepairableTable(table) {
+		return fmt.Errorf("table %s is not repairable", table)
+	}
+	return r.db.WithContext(ctx).Table(table).Where("id IN ? AND deleted_at IS NULL", ids).Update("deleted_at", time.Now()).Error
+}
+
+// RecordFindings inserts the findings and flags open findings' targets for review. A target that
+// already has an open finding for the same rule is not raised again; a resolved (repaired) finding
+// closes it instead. Returns the number inserted
+func (r *integrityRepository) RecordFindings(ctx context.Context, findings []*domain.DQFinding) (int, error) {
+	inserted := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, finding := range findings {
+			var open int64
+			if err := tx.Model(&domain.DQFinding{}).
+				Where("rule_code = ? AND target_table = ? AND target_id = ? AND status = ?",
+					finding.RuleCode, finding.TargetTable, finding.TargetID, domain.DQFindingStatusOpen).
+				Count(&open).Error; err != nil {
+				return err
+			}
+			if open > 0 {
+				if finding.Status != domain.DQFindingStatusResolved {
+					continue
+				}
+				// Repaired since an earlier scan: close the finding that scan raised
+				if err := tx.Model(&domain.DQFinding{}).
+					Where("rule_code = ? AND target_table = ? AND target_id = ? AND status = ?",
+						finding.RuleCode, finding.TargetTable, finding.TargetID, domain.DQFindingStatusOpen).
+					Updates(map[string]interface{}{
+						"status":      domain.DQFindingStatusResolved,
+						"resolved_at": finding.ResolvedAt,
+						"resolved_by": finding.ResolvedBy,
+					}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Create(finding).Error; err != nil {
+				return err
+			}
+			inserted++
+
+			if finding.Status != domain.DQFindingStatusOpen {
+				continue
+			}
+			if _, ok := reviewableTables[finding.TargetTable]; !ok {
+				continue
+			}
+			if err := tx.Table(finding.TargetTable).
+				Where("id = ?", finding.TargetID).
+				Updates(map[string]interface{}{"review_required": true, "review_reason": finding.Cause}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return inserted, err
+}
+
+func isReferenceCheck(check ReferenceCheck) bool {
+	for _, known := range ReferenceChecks {
+		if known == check {
+			return true
+		}
+	}
+	return false
+}
+
+func isRepairableTable(table string) bool {
+	for _, known := range ReferenceChecks {
+		if known.Repairable && known.Table == table {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// LeaderLock is a Postgres session-level advisory lock used to elect one replica to run scheduled
+// jobs. The lock is held on a connection taken out of the pool for as long as it is held, so it is
+// released by the server as soon as that session ends, e.g. when the holding process dies.
+type LeaderLock interface {
+	// TryAcquire takes the lock if no other session holds it, without waiting
+	TryAcquire(ctx context.Context) (bool, error)
+	// Check returns an error if the lock is no longer held, e.g. because its connection broke
+	Check(ctx context.Context) error
+	// Release gives the lock up; a no-op if it is not held
+	Release()
+}
+
+type leaderLock struct {
+	db  *gorm.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn // the session holding the lock, nil when not held
+}
+
+// NewLeaderLock creates a leader lock on the advisory lock key; every replica must use the same key
+func NewLeaderLock(db *gorm.DB, key int64) LeaderLock {
+	return &leaderLock{db: db, key: key}
+}
+
+func (l *leaderLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn != nil {
+		return true, nil
+	}
+
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get connection for leader lock: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		discardConn(conn)
+		return false, fmt.Errorf("failed to try leader lock: %w", err)
+	}
+	if !acquired {
+		conn.Close() // the session holds nothing, so it can go back to the pool
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+func (l *leaderLock) Check(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return fmt.Errorf("leader lock not held")
+	}
+
+	// A bigint advisory key is stored as classid (high 32 bits) and objid (low 32 bits), objsubid 1
+	var held bool
+	err := l.conn.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_locks
+			WHERE locktype = 'advisory' AND pid = pg_backend_pid() AND granted AND objsubid = 1
+			AND ((classid::bigint << 32) | objid::bigint) = $1
+		)`, l.key).Scan(&held)
+	if err != nil {
+		return fmt.Errorf("failed to check leader lock: %w", err)
+	}
+	if !held {
+		return fmt.Errorf("leader lock no longer held by this session")
+	}
+	return nil
+}
+
+func (l *leaderLock) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return
+	}
+	// Ending the session releases the lock without relying on an unlock round trip succeeding
+	discardConn(l.conn)
+	l.conn = nil
+}
+
+// discardConn closes a connection's session instead of returning it to the pool
+func discardConn(conn *sql.Conn) {
+	conn.Raw(func(any) error { return driver.ErrBadConn })
+	conn.Close()
+}
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm/schema"
+)
+
+// Full files are loaded in two steps instead of thousands of multi-row upserts: every batch is
+// COPYed into lei_raw.lei_records_staging, and once the whole file has been read a single
+// INSERT ... ON CONFLICT merges the staged rows into lei_records and writes their audit rows.
+
+// leiStagingTable is the COPY target for full-file bulk loads (migration 000021)
+const leiStagingTable = "lei_raw.lei_records_staging"
+
+// leiBulkLoadColumns describes how LEIRecord maps onto the staging and merge SQL
+type leiBulkLoadColumns struct {
+	fields   []*schema.Field // Loaded from the file: copied into staging and merged
+	compared []*schema.Field // Loaded fields compared for the audit trail (as detectChanges)
+	snapshot []*schema.Field // Fields in an audit record snapshot (as recordToJSON)
+}
+
+// leiBulkLoadManagedColumns are set by the merge rather than loaded from the file
+var leiBulkLoadManagedColumns = map[string]bool{
+	"id": true, "created_at": true, "updated_at": true, "deleted_at": true,
+	"created_by": true, "updated_by": true, "changed_fields": true,
+}
+
+var leiBulkLoadSchema = sync.OnceValues(func() (*leiBulkLoadColumns, error) {
+	s, err := schema.Parse(&domain.LEIRecord{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse LEI record schema: %w", err)
+	}
+	cols := &leiBulkLoadColumns{}
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue // associations such as SourceFile
+		}
+		if jsonName := jsonFieldName(field); jsonName != "-" {
+			cols.snapshot = append(cols.snapshot, field)
+		}
+		if leiBulkLoadManagedColumns[field.DBName] {
+			continue
+		}
+		cols.fields = append(cols.fields, field)
+		if field.DBName != "source_file_id" {
+			cols.compared = append(cols.compared, field)
+		}
+	}
+	return cols, nil
+})
+
+// jsonFieldName returns the key a field is marshalled under
+func jsonFieldName(field *schema.Field) string {
+	name, _, _ := strings.Cut(field.StructField.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// jsonValueSQL renders a column as the JSON value encoding/json produces for its field, so audit
+// rows written by the merge replay like those written from Go: timestamps carry a time zone and
+// jsonb columns are strings holding the JSON text
+func jsonValueSQL(field *schema.Field, alias string) string {
+	column := alias + "." + field.DBName
+	switch {
+	case field.FieldType == reflect.TypeOf(time.Time{}):
+		return "to_jsonb(" + column + " AT TIME ZONE 'UTC')"
+	case field.DataType == "jsonb":
+		return "to_jsonb(" + column + "::text)"
+	default:
+		return column
+	}
+}
+
+func (c *leiBulkLoadColumns) names() []string {
+	names := make([]string, len(c.fields))
+	for i, field := range c.fields {
+		names[i] = field.DBName
+	}
+	return names
+}
+
+// PrepareLEIStaging readies the staging table for a full file. A fresh load empties it; a resumed
+// load keeps the rows already staged for the file and returns how many there are (0 after a crash,
+// since the table is unlogged).
+func (r *leiRepository) PrepareLEIStaging(sourceFileID uuid.UUID, resume bool) (int64, error) {
+	if !resume {
+		if err := r.ClearLEIStaging(); err != nil {
+			return 0, err
+		}
+		return 0, nil
+	}
+
+	// Rows left behind by another file's abandoned load must not be merged with this one
+	if err := r.db.Exec("DELETE FROM "+leiStagingTable+" WHERE source_file_id IS DISTINCT FROM ?", sourceFileID).Error; err != nil {
+		return 0, fmt.Errorf("failed to clear stale staged records: %w", err)
+	}
+	var staged int64
+	if err := r.db.Table(leiStagingTable).Where("source_file_id = ?", sourceFileID).Count(&staged).Error; err != nil {
+		return 0, fmt.Errorf("failed to count staged records: %w", err)
+	}
+	return staged, nil
+}
+
+// ClearLEIStaging empties the staging table
+func (r *leiRepository) ClearLEIStaging() error {
+	if err := r.db.Exec("TRUNCATE " + leiStagingTable).Error; err != nil {
+		return fmt.Errorf("failed to clear LEI staging table: %w", err)
+	}
+	return nil
+}
+
+// StageLEIRecords COPYs records into the staging table. COPY is all or nothing: a record the
+// database rejects fails the whole call and nothing from it is staged.
+func (r *leiRepository) StageLEIRecords(records []*domain.LEIRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	cols, err := leiBulkLoadSchema()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	rows := make([][]any, len(records))
+	for i, record := range records {
+		value := reflect.ValueOf(record)
+		row := make([]any, len(cols.fields))
+		for j, field := range cols.fields {
+			row[j], _ = field.ValueOf(ctx, value)
+		}
+		rows[i] = row
+	}
+
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection for COPY: %w", err)
+	}
+	defer conn.Close()
+
+	var copied int64
+	err = conn.Raw(func(driverConn any) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("COPY requires the pgx driver, got %T", driverConn)
+		}
+		copied, err = pgxConn.Conn().CopyFrom(ctx, pgx.Identifier{"lei_raw", "lei_records_staging"}, cols.names(), pgx.CopyFromRows(rows))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to COPY %d records into staging: %w", len(records), err)
+	}
+
+	log.Debug().
+		Int64("records", copied).
+		Str("first_lei", records[0].LEI).
+		Str("last_lei", records[len(records)-1].LEI).
+		Msg("Staged LEI records")
+	return nil
+}
+
+// mergeChangeEventsSQL is the CTE that writes a change event to the outbox per audit row of a
+// merge, built like leiChangeEvent builds them; empty when change events are off
+func (r *leiRepository) mergeChangeEventsSQL() string {
+	if !r.changes.enabled {
+		return ""
+	}
+	return `,
+		outboxed AS (
+			INSERT INTO event_outbox (id, routing_key, payload, created_at)
+			SELECT e.id, 'lei.' || e.action,
+				jsonb_strip_nulls(jsonb_build_object(
+					'id', e.id, 'domain', 'lei', 'action', e.action, 'key', a.lei,
+					'record_id', a.lei_record_id,
+					'changed_fields', (SELECT jsonb_agg(k ORDER BY k) FROM jsonb_object_keys(a.changed_fields) k),
+					'source_file_id', a.source_file_id, 'time', NOW()
+				)),
+				NOW()
+			FROM audited a
+			CROSS JOIN LATERAL (
+				SELECT gen_random_uuid() AS id,
+					CASE WHEN a.action = 'CREATE' THEN 'created' ELSE 'updated' END AS action
+			) e
+		)`
+}
+
+// MergeStagedLEIRecords upserts a file's staged records into lei_records with one INSERT ... ON
+// CONFLICT and writes the same audit trail and change events as BatchUpsertLEIRecords (CREATE for
+// new records, UPDATE only when a field changed), all in a single statement. Records identical to
+// their stored version are not rewritten. If an LEI was staged more than once, its last staged
+// version wins.
+// Returns (created, updated, error).
+func (r *leiRepository) MergeStagedLEIRecords(sourceFileID uuid.UUID) (int, int, error) {
+	cols, err := leiBulkLoadSchema()
+	if err != nil {
+		return 0, 0, err
+	}
+	names := cols.names()
+	columnList := strings.Join(names, ", ")
+
+	updates := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != "lei" {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", name, name))
+		}
+	}
+
+	// Audit JSON is built in SQL, keyed like the Go path: snapshots by JSON name, changes by field name
+	snapshot := make([]string, len(cols.snapshot))
+	for i, field := range cols.snapshot {
+		snapshot[i] = fmt.Sprintf("jsonb_build_object('%s', %s)", jsonFieldName(field), jsonValueSQL(field, "u"))
+	}
+	createSnapshot := strings.Join(snapshot, " || ")
+	updateSnapshot := createSnapshot
+	if r.auditMode == LEIAuditModeDiff {
+		updateSnapshot = "'" + LEIAuditDiffSnapshot + "'::jsonb"
+	}
+	changes := make([]string, len(cols.compared))
+	storedValues := make([]string, len(cols.compared))
+	loadedValues := make([]string, len(cols.compared))
+	for i, field := range cols.compared {
+		storedValues[i] = "existing." + field.DBName
+		loadedValues[i] = "EXCLUDED." + field.DBName
+		changes[i] = fmt.Sprintf(
+			"CASE WHEN p.%[2]s IS DISTINCT FROM u.%[2]s THEN jsonb_build_object('%[1]s', "+
+				"jsonb_build_object('field_name', '%[1]s', 'old_value', %[3]s, 'new_value', %[4]s)) ELSE '{}'::jsonb END",
+			field.Name, field.DBName, jsonValueSQL(field, "p"), jsonValueSQL(field, "u"))
+	}
+
+	stmt := fmt.Sprintf(`
+		WITH staged AS (
+			SELECT DISTINCT ON (lei) %[1]s
+			FROM %[2]s
+			WHERE source_file_id = @source_file_id
+			ORDER BY lei, staged_seq DESC
+		),
+		previous AS (
+			SELECT r.* FROM lei_raw.lei_records r
+			JOIN staged s ON s.lei = r.lei
+			WHERE r.deleted_at IS NULL
+		),
+		upserted AS (
+			INSERT INTO lei_raw.lei_records AS existing (
+				%[1]s,
+				created_at, updated_at, created_by, updated_by, changed_fields, search_vector
+			)
+			SELECT %[1]s,
+				NOW(), NOW(), 'system', 'system', '{}', %[3]s
+			FROM staged
+			ON CONFLICT (lei) DO UPDATE SET
+				%[4]s,
+				search_vector = EXCLUDED.search_vector,
+				updated_at = NOW(),
+				updated_by = 'system'
+			WHERE ROW(%[7]s) IS DISTINCT FROM ROW(%[8]s)
+			RETURNING *
+		),
+		audited AS (
+			INSERT INTO lei_raw.lei_records_audit (
+				lei_record_id, lei, action, record_snapshot, changed_fields, source_file_id, changed_by, created_at
+			)
+			SELECT u.id, u.lei,
+				CASE WHEN p.id IS NULL THEN 'CREATE' ELSE 'UPDATE' END,
+				CASE WHEN p.id IS NULL THEN %[5]s ELSE %[9]s END,
+				CASE WHEN p.id IS NULL THEN '{}'::jsonb ELSE c.changes END,
+				u.source_file_id, 'system', NOW()
+			FROM upserted u
+			LEFT JOIN previous p ON p.lei = u.lei
+			CROSS JOIN LATERAL (SELECT %[6]s AS changes) c
+			WHERE p.id IS NULL OR c.changes <> '{}'::jsonb
+			RETURNING lei_record_id, lei, action, changed_fields, source_file_id
+		)%[10]s
+		SELECT
+			COUNT(*) FILTER (WHERE action = 'CREATE') AS created,
+			COUNT(*) FILTER (WHERE action = 'UPDATE') AS updated
+		FROM audited`,
+		columnList, leiStagingTable, leiSearchVectorSQL,
+		strings.Join(updates, ",\n\t\t\t\t"),
+		createSnapshot,
+		strings.Join(changes, "\n\t\t\t\t|| "),
+		strings.Join(storedValues, ", "),
+		strings.Join(loadedValues, ", "),
+		updateSnapshot,
+		r.mergeChangeEventsSQL())
+
+	started := time.Now()
+	var counts struct {
+		Created int
+		Updated int
+	}
+	if err := r.db.Raw(stmt, sql.Named("source_file_id", sourceFileID)).Scan(&counts).Error; err != nil {
+		log.Error().
+			Err(err).
+			Str("source_file_id", sourceFileID.String()).
+			Msg("CRITICAL: Merge of staged LEI records failed")
+		return 0, 0, fmt.Errorf("failed to merge staged records: %w", err)
+	}
+
+	log.Info().
+		Int("created", counts.Created).
+		Int("updated", counts.Updated).
+		Dur("duration", time.Since(started)).
+		Msg("Merged staged LEI records with full audit trail")
+
+	return counts.Created, counts.Updated, nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// leiChangeFeedColumns are the audit columns the changes feed needs; snapshots are left out
+const leiChangeFeedColumns = "id, lei_record_id, lei, action, changed_fields, source_file_id, changed_by, created_at"
+
+// FindAuditEntriesAfter returns up to limit audit entries positioned after (afterTime, afterID),
+// oldest first, without their snapshots.
+//
+// Entries are stamped when written but only become visible when their transaction commits, so an
+// entry could otherwise appear behind a position a reader has already passed. Only entries older
+// than the oldest open write transaction (less a margin for clock skew) are returned; those can
+// no longer be joined by anything earlier.
+func (r *leiRepository) FindAuditEntriesAfter(afterTime time.Time, afterID uuid.UUID, limit int) ([]*domain.LEIRecordAudit, error) {
+	var audits []*domain.LEIRecordAudit
+	err := r.db.
+		Select(leiChangeFeedColumns).
+		Where("(created_at > ? OR (created_at = ? AND id > ?))", afterTime, afterTime, afterID).
+		Where(`created_at < (
+			SELECT LEAST(NOW(), COALESCE(MIN(xact_start), NOW()))::timestamp - INTERVAL '5 seconds'
+			FROM pg_stat_activity
+			WHERE backend_xid IS NOT NULL AND pid <> pg_backend_pid()
+		)`).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&audits).Error
+	if err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LEIExceptionRepository persists GLEIF reporting exceptions (repex)
+type LEIExceptionRepository interface {
+	BatchUpsert(exceptions []*domain.LEIReportingException) error
+	DeleteNotInSourceFile(sourceFileID uuid.UUID) (int64, error)
+	FindByLEI(lei string) ([]*domain.LEIReportingException, error)
+}
+
+type leiExceptionRepository struct {
+	db *gorm.DB
+}
+
+// NewLEIExceptionRepository creates a new reporting exception repository instance
+func NewLEIExceptionRepository(db *gorm.DB) LEIExceptionRepository {
+	return &leiExceptionRepository{db: db}
+}
+
+// BatchUpsert inserts exceptions, or re-stamps existing ones with the new source file
+func (r *leiExceptionRepository) BatchUpsert(exceptions []*domain.LEIReportingException) error {
+	if len(exceptions) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "lei"}, {Name: "exception_category"}, {Name: "exception_reason"}},
+		DoUpdates: clause.AssignmentColumns([]string{"exception_reference", "source_file_id", "updated_at"}),
+	}).CreateInBatches(exceptions, 1000).Error
+}
+
+// DeleteNotInSourceFile removes exceptions that were not (re)loaded from the given full snapshot
+func (r *leiExceptionRepository) DeleteNotInSourceFile(sourceFileID uuid.UUID) (int64, error) {
+	result := r.db.Where("source_file_id IS DISTINCT FROM ?", sourceFileID).Delete(&domain.LEIReportingException{})
+	return result.RowsAffected, result.Error
+}
+
+// FindByLEI returns the reporting exceptions for an LEI, direct parent first
+func (r *leiExceptionRepository) FindByLEI(lei string) ([]*domain.LEIReportingException, error) {
+	var exceptions []*domain.LEIReportingException
+	if err := r.db.Where("lei = ?", lei).Order("exception_category, exception_reason").Find(&exceptions).Error; err != nil {
+		return nil, err
+	}
+	return exceptions, nil
+}
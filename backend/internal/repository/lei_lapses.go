@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// FindRegistrationStatusTransitions returns the LEIs whose registration status changed to one of
+// statuses while the source file was loaded, read from its UPDATE audit rows. Changes from an empty
+// status (records loaded before the status was stored) are not transitions.
+func (r *leiRepository) FindRegistrationStatusTransitions(sourceFileID uuid.UUID, statuses []string) ([]*domain.LEIStatusTransition, error) {
+	var transitions []*domain.LEIStatusTransition
+	err := r.db.Raw(`
+		SELECT a.lei,
+			COALESCE(l.legal_name, '') AS legal_name,
+			a.changed_fields->'RegistrationStatus'->>'old_value' AS old_status,
+			a.changed_fields->'RegistrationStatus'->>'new_value' AS new_status,
+			a.created_at AS changed_at
+		FROM lei_raw.lei_records_audit a
+		LEFT JOIN lei_raw.lei_records l ON l.id = a.lei_record_id
+		WHERE a.source_file_id = ?
+			AND a.action = 'UPDATE'
+			AND a.changed_fields->'RegistrationStatus'->>'new_value' IN ?
+			AND COALESCE(a.changed_fields->'RegistrationStatus'->>'old_value', '') NOT IN ?
+		ORDER BY a.lei`,
+		sourceFileID, statuses, append([]string{""}, statuses...)).
+		Scan(&transitions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// FindLEIReferences returns the entities carrying any of the LEIs and the SSIs of those entities
+func (r *leiRepository) FindLEIReferences(leis []string) ([]*domain.LEIReference, error) {
+	if len(leis) == 0 {
+		return nil, nil
+	}
+	var references []*domain.LEIReference
+	err := r.db.Raw(`
+		SELECT e.lei, 'entity' AS kind, e.id, e.name
+		FROM entities e
+		WHERE e.lei IN ? AND e.deleted_at IS NULL
+		UNION ALL
+		SELECT e.lei, 'ssi' AS kind, s.id, s.beneficiary_name AS name
+		FROM ssis s
+		JOIN entities e ON e.id = s.entity_id
+		WHERE e.lei IN ? AND e.deleted_at IS NULL AND s.deleted_at IS NULL
+		ORDER BY lei, kind, name`,
+		leis, leis).
+		Scan(&references).Error
+	if err != nil {
+		return nil, err
+	}
+	return references, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ReplaceQualityMetrics stores the quality metrics of a source file, replacing any from an earlier
+// attempt at loading it
+func (r *leiRepository) ReplaceQualityMetrics(sourceFileID uuid.UUID, metrics []*domain.LEIQualityMetric) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source_file_id = ?", sourceFileID).Delete(&domain.LEIQualityMetric{}).Error; err != nil {
+			return err
+		}
+		if len(metrics) == 0 {
+			return nil
+		}
+		return tx.Create(&metrics).Error
+	})
+}
+
+// FindQualityMetrics returns the quality metrics of the given source files, in field order
+func (r *leiRepository) FindQualityMetrics(sourceFileIDs []uuid.UUID) ([]*domain.LEIQualityMetric, error) {
+	var metrics []*domain.LEIQualityMetric
+	if len(sourceFileIDs) == 0 {
+		return metrics, nil
+	}
+	err := r.db.Where("source_file_id IN ?", sourceFileIDs).Order("position").Find(&metrics).Error
+	return metrics, err
+}
+
+// FindQualityMeasuredFiles returns the most recently published source files that have quality
+// metrics, optionally of one file type (FULL, DELTA)
+func (r *leiRepository) FindQualityMeasuredFiles(fileType string, limit int) ([]*domain.SourceFile, error) {
+	query := r.db.Where("id IN (SELECT source_file_id FROM lei_raw.quality_metrics)")
+	if fileType != "" {
+		query = query.Where("file_type = ?", fileType)
+	}
+	var files []*domain.SourceFile
+	err := query.Order("publication_date DESC, downloaded_at DESC").Limit(limit).Find(&files).Error
+	return files, err
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// batchIsolation tracks a savepoint bisection run inside one transaction
+type batchIsolation struct {
+	ctx context.Context
+	// Run in the batch's transaction
+	savePoint  func(name string) error
+	rollbackTo func(name string) error
+	upsert     func(records []*domain.LEIRecord) (created, updated int, err error)
+
+	savepoints  int
+	created     int
+	updated     int
+	quarantined []*domain.LEIQuarantinedRecord
+}
+
+// BatchUpsertLEIRecordsIsolating upserts records like BatchUpsertLEIRecords, but when a chunk is
+// rejected it rolls back to a savepoint and bisects it until the offending records are isolated.
+// Those are written to the quarantine table; everything else is committed.
+// Errors that are not caused by record content (connection loss, SQL errors) abort the whole batch.
+// Returns (created_count, updated_count, quarantined, error)
+func (r *leiRepository) BatchUpsertLEIRecordsIsolating(ctx context.Context, records []*domain.LEIRecord) (int, int, []*domain.LEIQuarantinedRecord, error) {
+	if len(records) == 0 {
+		return 0, 0, nil, nil
+	}
+	r = r.withDB(r.db.WithContext(ctx))
+
+	existingMap, err := r.prepareBatchUpsert(records)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return 0, 0, nil, tx.Error
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	iso := &batchIsolation{
+		ctx:        ctx,
+		savePoint:  func(name string) error { return tx.SavePoint(name).Error },
+		rollbackTo: func(name string) error { return tx.RollbackTo(name).Error },
+		upsert: func(records []*domain.LEIRecord) (int, int, error) {
+			return r.upsertChunks(tx, records, existingMap)
+		},
+	}
+	if err := r.upsertIsolating(iso, records); err != nil {
+		tx.Rollback()
+		return 0, 0, nil, err
+	}
+
+	if len(iso.quarantined) > 0 {
+		if err := tx.Create(&iso.quarantined).Error; err != nil {
+			tx.Rollback()
+			return 0, 0, nil, fmt.Errorf("failed to store quarantined records: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, 0, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int("created", iso.created).
+		Int("updated", iso.updated).
+		Int("quarantined", len(iso.quarantined)).
+		Int("savepoints", iso.savepoints).
+		Msg("Batch upsert with record isolation completed")
+
+	return iso.created, iso.updated, iso.quarantined, nil
+}
+
+// upsertIsolating upserts records under a savepoint; on a record-level rejection it rolls back to
+// the savepoint and retries each half, quarantining single records that still fail
+func (r *leiRepository) upsertIsolating(iso *batchIsolation, records []*domain.LEIRecord) error {
+	iso.savepoints++
+	savepoint := fmt.Sprintf("lei_bisect_%d", iso.savepoints)
+	if err := iso.savePoint(savepoint); err != nil {
+		return err
+	}
+
+	created, updated, err := iso.upsert(records)
+	if err == nil {
+		iso.created += created
+		iso.updated += updated
+		return nil
+	}
+
+	if rbErr := iso.rollbackTo(savepoint); rbErr != nil {
+		return fmt.Errorf("failed to roll back to savepoint: %w (after: %v)", rbErr, err)
+	}
+	if !isRecordLevelError(err) {
+		return err
+	}
+
+	if len(records) == 1 {
+		record := records[0]
+		code := ""
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			code = pgErr.Code
+		}
+		log.Ctx(iso.ctx).Warn().
+			Err(err).
+			Str("lei", record.LEI).
+			Str("sqlstate", code).
+			Msg("Quarantining LEI record rejected by the database")
+		iso.quarantined = append(iso.quarantined, &domain.LEIQuarantinedRecord{
+			LEI:            truncateLEI(record.LEI),
+			SourceFileID:   record.SourceFileID,
+			Source:         domain.LEIQuarantineSourceDatabase,
+			ErrorCode:      code,
+			ErrorMessage:   err.Error(),
+			RecordSnapshot: r.recordToJSON(record),
+		})
+		return nil
+	}
+
+	mid := len(records) / 2
+	if err := r.upsertIsolating(iso, records[:mid]); err != nil {
+		return err
+	}
+	return r.upsertIsolating(iso, records[mid:])
+}
+
+// upsertChunks runs upsertChunk over records in chunks of 100
+func (r *leiRepository) upsertChunks(tx *gorm.DB, records []*domain.LEIRecord, existingMap map[string]*domain.LEIRecord) (int, int, error) {
+	createdCount, updatedCount := 0, 0
+	for i := 0; i < len(records); i += 100 {
+		end := i + 100
+		if end > len(records) {
+			end = len(records)
+		}
+		created, updated, err := r.upsertChunk(tx, records[i:end], existingMap)
+		if err != nil {
+			return 0, 0, err
+		}
+		createdCount += created
+		updatedCount += updated
+	}
+	return createdCount, updatedCount, nil
+}
+
+// isRecordLevelError reports whether err was caused by the content of the rows being written:
+// cardinality violations (21xxx, e.g. the same LEI twice in one statement), data exceptions
+// (22xxx, e.g. value too long or invalid byte sequence) and integrity constraint violations (23xxx)
+func isRecordLevelError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || len(pgErr.Code) < 2 {
+		return false
+	}
+	switch pgErr.Code[:2] {
+	case "21", "22", "23":
+		return true
+	}
+	return false
+}
+
+// truncateLEI keeps an over-long LEI (itself a likely cause of rejection) within the quarantine column
+func truncateLEI(lei string) string {
+	if len(lei) > 20 {
+		return lei[:20]
+	}
+	return lei
+}
+
+// LEIQuarantineFilter narrows quarantine listing; zero values match everything
+type LEIQuarantineFilter struct {
+	SourceFileID string
+	Source       string // DATABASE or RULES
+	Status       string // PENDING, REQUEUED or DISMISSED
+}
+
+// CreateQuarantinedRecords stores records kept out of lei_records, e.g. for failing data quality rules
+func (r *leiRepository) CreateQuarantinedRecords(records []*domain.LEIQuarantinedRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return r.db.Create(&records).Error
+}
+
+// FindQuarantinedRecords lists quarantined records matching the filter, newest first
+func (r *leiRepository) FindQuarantinedRecords(filter LEIQuarantineFilter, limit, offset int) ([]*domain.LEIQuarantinedRecord, int64, error) {
+	query := r.db.Model(&domain.LEIQuarantinedRecord{})
+	if filter.SourceFileID != "" {
+		query = query.Where("source_file_id = ?", filter.SourceFileID)
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []*domain.LEIQuarantinedRecord
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+	return records, total, nil
+}
+
+// FindQuarantinedRecordByID returns a quarantined record; gorm.ErrRecordNotFound if there is none
+func (r *leiRepository) FindQuarantinedRecordByID(id string) (*domain.LEIQuarantinedRecord, error) {
+	var record domain.LEIQuarantinedRecord
+	if err := r.db.Where("id = ?", id).First(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// UpdateQuarantinedRecord saves the review of a quarantined record
+func (r *leiRepository) UpdateQuarantinedRecord(record *domain.LEIQuarantinedRecord) error {
+	return r.db.Save(record).Error
+}
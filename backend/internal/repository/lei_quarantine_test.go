@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// bisectedTx stands in for the batch's transaction. An upsert writes its records in order until it
+// reaches one in rejects, then fails with that record's error; rolling back to a savepoint undoes
+// everything written since.
+type bisectedTx struct {
+	rejects    map[string]error
+	rollbackOK bool
+
+	written    []string
+	savepoints map[string]int // written records when each savepoint was taken
+}
+
+func (tx *bisectedTx) isolation() *batchIsolation {
+	tx.savepoints = map[string]int{}
+	return &batchIsolation{
+		ctx: context.Background(),
+		savePoint: func(name string) error {
+			tx.savepoints[name] = len(tx.written)
+			return nil
+		},
+		rollbackTo: func(name string) error {
+			if !tx.rollbackOK {
+				return errors.New("current transaction is aborted")
+			}
+			tx.written = tx.written[:tx.savepoints[name]]
+			return nil
+		},
+		upsert: func(records []*domain.LEIRecord) (int, int, error) {
+			for _, record := range records {
+				if err := tx.rejects[record.LEI]; err != nil {
+					return 0, 0, err
+				}
+				tx.written = append(tx.written, record.LEI)
+			}
+			return len(records), 0, nil
+		},
+	}
+}
+
+func bisectionLEI(i int) string {
+	return fmt.Sprintf("BISECT%014d", i)
+}
+
+func bisectionBatch(n int) []*domain.LEIRecord {
+	records := make([]*domain.LEIRecord, n)
+	for i := range records {
+		records[i] = &domain.LEIRecord{LEI: bisectionLEI(i)}
+	}
+	return records
+}
+
+func TestUpsertIsolating(t *testing.T) {
+	tooLong := &pgconn.PgError{Code: "22001", Message: "value too long for type character varying(500)"}
+	badUTF8 := &pgconn.PgError{Code: "22021", Message: "invalid byte sequence for encoding \"UTF8\""}
+	tests := []struct {
+		name            string
+		rejects         map[string]error
+		rollbackFails   bool
+		wantWritten     int
+		wantQuarantined map[string]string // LEI to SQLSTATE
+		wantSavepoints  int
+		wantErr         bool
+	}{
+		{
+			name:           "clean batch needs one savepoint",
+			wantWritten:    8,
+			wantSavepoints: 1,
+		},
+		{
+			name:            "one poison record is isolated",
+			rejects:         map[string]error{bisectionLEI(5): tooLong},
+			wantWritten:     7,
+			wantQuarantined: map[string]string{bisectionLEI(5): "22001"},
+			wantSavepoints:  7,
+		},
+		{
+			name:            "poison records in both halves are isolated",
+			rejects:         map[string]error{bisectionLEI(0): tooLong, bisectionLEI(7): badUTF8},
+			wantWritten:     6,
+			wantQuarantined: map[string]string{bisectionLEI(0): "22001", bisectionLEI(7): "22021"},
+			wantSavepoints:  11,
+		},
+		{
+			name:            "every record poisoned",
+			rejects:         map[string]error{bisectionLEI(0): tooLong, bisectionLEI(1): tooLong, bisectionLEI(2): tooLong, bisectionLEI(3): tooLong, bisectionLEI(4): tooLong, bisectionLEI(5): tooLong, bisectionLEI(6): tooLong, bisectionLEI(7): tooLong},
+			wantQuarantined: map[string]string{bisectionLEI(0): "22001", bisectionLEI(1): "22001", bisectionLEI(2): "22001", bisectionLEI(3): "22001", bisectionLEI(4): "22001", bisectionLEI(5): "22001", bisectionLEI(6): "22001", bisectionLEI(7): "22001"},
+			wantSavepoints:  15,
+		},
+		{
+			name:            "duplicate LEI in one statement is record-level",
+			rejects:         map[string]error{bisectionLEI(3): &pgconn.PgError{Code: "21000", Message: "ON CONFLICT DO UPDATE command cannot affect row a second time"}},
+			wantWritten:     7,
+			wantSavepoints:  7,
+			wantQuarantined: map[string]string{bisectionLEI(3): "21000"},
+		},
+		{
+			name:           "connection loss aborts the batch",
+			rejects:        map[string]error{bisectionLEI(5): errors.New("unexpected EOF")},
+			wantSavepoints: 1,
+			wantErr:        true,
+		},
+		{
+			name:           "SQL error aborts the batch",
+			rejects:        map[string]error{bisectionLEI(5): &pgconn.PgError{Code: "42703", Message: "column \"lei_status\" does not exist"}},
+			wantSavepoints: 1,
+			wantErr:        true,
+		},
+		{
+			name:           "failed rollback aborts the batch",
+			rejects:        map[string]error{bisectionLEI(5): tooLong},
+			rollbackFails:  true,
+			wantSavepoints: 1,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &bisectedTx{rejects: tt.rejects, rollbackOK: !tt.rollbackFails}
+			iso := tx.isolation()
+
+			err := (&leiRepository{}).upsertIsolating(iso, bisectionBatch(8))
+			assert.Equal(t, tt.wantSavepoints, iso.savepoints)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// Only the records that went through are left written, each once
+			assert.Len(t, tx.written, tt.wantWritten)
+			assert.Equal(t, tt.wantWritten, iso.created)
+			for _, lei := range tx.written {
+				assert.NotContains(t, tt.rejects, lei)
+			}
+			quarantined := map[string]string{}
+			for _, record := range iso.quarantined {
+				assert.Equal(t, domain.LEIQuarantineSourceDatabase, record.Source)
+				assert.Contains(t, record.RecordSnapshot, record.LEI)
+				quarantined[record.LEI] = record.ErrorCode
+			}
+			if tt.wantQuarantined == nil {
+				assert.Empty(t, quarantined)
+			} else {
+				assert.Equal(t, tt.wantQuarantined, quarantined)
+			}
+		})
+	}
+}
+
+func TestIsRecordLevelError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "cardinality violation", err: &pgconn.PgError{Code: "21000"}, want: true},
+		{name: "value too long", err: &pgconn.PgError{Code: "22001"}, want: true},
+		{name: "not null violation", err: &pgconn.PgError{Code: "23502"}, want: true},
+		{name: "wrapped", err: fmt.Errorf("upsert failed: %w", &pgconn.PgError{Code: "23505"}), want: true},
+		{name: "undefined column", err: &pgconn.PgError{Code: "42703"}},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}},
+		{name: "no SQLSTATE", err: &pgconn.PgError{}},
+		{name: "network", err: errors.New("connection reset by peer")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRecordLevelError(tt.err))
+		})
+	}
+}
+
+func TestTruncateLEI(t *testing.T) {
+	assert.Equal(t, "5493001KJTIIGC8Y1R12", truncateLEI("5493001KJTIIGC8Y1R12"))
+	assert.Equal(t, "5493001KJTIIGC8Y1R12", truncateLEI("5493001KJTIIGC8Y1R12-EXTRA"))
+	assert.Equal(t, "", truncateLEI(""))
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// CountLEIRecordsByCountry returns the number of LEI records per legal address country
+func (r *leiRepository) CountLEIRecordsByCountry() (map[string]int64, error) {
+	var rows []struct {
+		Country string
+		Count   int64
+	}
+	err := r.db.Model(&domain.LEIRecord{}).
+		Select("legal_address_country AS country, COUNT(*) AS count").
+		Where("legal_address_country IS NOT NULL AND legal_address_country != ''").
+		Group("legal_address_country").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Country] = row.Count
+	}
+	return counts, nil
+}
+
+// CreateReconciliationResults stores the results of a reconciliation run
+func (r *leiRepository) CreateReconciliationResults(results []*domain.LEIReconciliationResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(results, 500).Error
+}
+
+// FindLatestReconciliation returns the results of the most recent reconciliation run, TOTAL first
+func (r *leiRepository) FindLatestReconciliation() ([]*domain.LEIReconciliationResult, error) {
+	var results []*domain.LEIReconciliationResult
+	err := r.db.
+		Where("run_id = (SELECT run_id FROM lei_raw.reconciliation_results ORDER BY created_at DESC LIMIT 1)").
+		Order(reconciliationScopeOrder).
+		Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindReconciliationDiscrepancies returns results whose drift exceeded the threshold, newest first
+func (r *leiRepository) FindReconciliationDiscrepancies(limit, offset int) ([]*domain.LEIReconciliationResult, int64, error) {
+	query := r.db.Model(&domain.LEIReconciliationResult{}).Where("exceeded")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var results []*domain.LEIReconciliationResult
+	if err := query.Order("created_at DESC").Order(reconciliationScopeOrder).Limit(limit).Offset(offset).Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// reconciliationScopeOrder orders the TOTAL result before the countries, which follow alphabetically
+const reconciliationScopeOrder = "scope <> '" + domain.LEIReconciliationScopeTotal + "', scope"
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// FindRenewalsDue returns LEI records whose next renewal date falls in [from, to], soonest first,
+// optionally limited to legal address countries and managing LOUs, with the total number matching
+func (r *leiRepository) FindRenewalsDue(from, to time.Time, countries, managingLOUs []string, limit, offset int) ([]*domain.LEIRecord, int64, error) {
+	query := r.reads.DB().Model(&domain.LEIRecord{}).Where("next_renewal_date BETWEEN ? AND ?", from, to)
+	if len(countries) > 0 {
+		query = query.Where("legal_address_country IN ?", countries)
+	}
+	if len(managingLOUs) > 0 {
+		query = query.Where("managing_lou IN ?", managingLOUs)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []*domain.LEIRecord
+	if err := query.Order("next_renewal_date ASC, lei ASC").Limit(limit).Offset(offset).Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+	return records, total, nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -10,7 +12,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // LEIRepository interface
@@ -20,19 +24,34 @@ type LEIRepository interface {
 	FindLEIByLEI(lei string) (*domain.LEIRecord, error)
 	FindLEIByID(id string) (*domain.LEIRecord, error)
 	FindAllLEI(limit, offset int) ([]*domain.LEIRecord, error)
-	FindAllLEIWithFilters(limit, offset int, search, status, category, country, sortBy, sortOrder string) ([]*domain.LEIRecord, error)
+	FindAllLEIWithFilters(limit, offset int, filter LEIFilter) ([]*domain.LEIRecord, error)
+	FuzzySearchLEI(name string, threshold float64, country string, limit int) ([]*domain.LEIFuzzyMatch, error)
 	CountLEIRecords() (int64, error)
 	GetDistinctCountries() ([]string, error)
+	FindRenewalsDue(from, to time.Time, countries, managingLOUs []string, limit, offset int) ([]*domain.LEIRecord, int64, error)
 	UpdateLEIRecord(record *domain.LEIRecord) error
-	UpsertLEIRecord(record *domain.LEIRecord) (bool, error)              // Returns true if updated, false if created
-	BatchUpsertLEIRecords(records []*domain.LEIRecord) (int, int, error) // Returns (created, updated, error)
+	UpsertLEIRecord(ctx context.Context, record *domain.LEIRecord) (bool, error)              // Returns true if updated, false if created
+	BatchUpsertLEIRecords(ctx context.Context, records []*domain.LEIRecord) (int, int, error) // Returns (created, updated, error)
+	// Like BatchUpsertLEIRecords, but isolates and quarantines rejected records instead of failing the batch
+	BatchUpsertLEIRecordsIsolating(ctx context.Context, records []*domain.LEIRecord) (int, int, []*domain.LEIQuarantinedRecord, error)
+	CreateQuarantinedRecords(records []*domain.LEIQuarantinedRecord) error
+	FindQuarantinedRecords(filter LEIQuarantineFilter, limit, offset int) ([]*domain.LEIQuarantinedRecord, int64, error)
+	FindQuarantinedRecordByID(id string) (*domain.LEIQuarantinedRecord, error)
+	UpdateQuarantinedRecord(record *domain.LEIQuarantinedRecord) error
 	DeleteLEI(id string) error
 
+	// Full-file bulk load: batches are COPYed into a staging table, then merged in one statement
+	PrepareLEIStaging(sourceFileID uuid.UUID, resume bool) (int64, error) // Returns records already staged for a resume
+	StageLEIRecords(records []*domain.LEIRecord) error
+	MergeStagedLEIRecords(sourceFileID uuid.UUID) (int, int, error) // Returns (created, updated, error)
+	ClearLEIStaging() error
+
 	// Source File operations
 	CreateSourceFile(file *domain.SourceFile) error
 	FindSourceFileByID(id string) (*domain.SourceFile, error)
 	FindSourceFileByHash(hash string) (*domain.SourceFile, error)
 	FindLatestSourceFile(fileType string) (*domain.SourceFile, error)
+	FindLastAppliedPublicationDate() (*time.Time, error)
 	UpdateSourceFile(file *domain.SourceFile) error
 	FindPendingSourceFiles() ([]*domain.SourceFile, error)
 	FindRetryableFailedFiles() ([]*domain.SourceFile, error)
@@ -41,19 +60,57 @@ type LEIRepository interface {
 	// File Processing Status operations
 	FindProcessingStatus(jobType string) (*domain.FileProcessingStatus, error)
 	UpdateProcessingStatus(status *domain.FileProcessingStatus) error
+	ReleaseProcessingStatus(sourceFileID uuid.UUID, errorMessage string) (int64, error)
 
 	// Audit operations
 	CreateAuditRecord(audit *domain.LEIRecordAudit) error
 	FindAuditHistoryByLEI(lei string, limit int) ([]*domain.LEIRecordAudit, error)
+	FindAuditTrailByLEI(lei string) ([]*domain.LEIRecordAudit, error) // Oldest first, for replaying versions
+	FindAuditEntriesAfter(afterTime time.Time, afterID uuid.UUID, limit int) ([]*domain.LEIRecordAudit, error)
+
+	// Lapse detection: registration status transitions in a file and the master data referring to the LEIs
+	FindRegistrationStatusTransitions(sourceFileID uuid.UUID, statuses []string) ([]*domain.LEIStatusTransition, error)
+	FindLEIReferences(leis []string) ([]*domain.LEIReference, error)
+
+	// Data quality metrics per source file
+	ReplaceQualityMetrics(sourceFileID uuid.UUID, metrics []*domain.LEIQualityMetric) error
+	FindQualityMetrics(sourceFileIDs []uuid.UUID) ([]*domain.LEIQualityMetric, error)
+	FindQualityMeasuredFiles(fileType string, limit int) ([]*domain.SourceFile, error)
+
+	// Reconciliation against GLEIF record counts
+	CountLEIRecordsByCountry() (map[string]int64, error)
+	CreateReconciliationResults(results []*domain.LEIReconciliationResult) error
+	FindLatestReconciliation() ([]*domain.LEIReconciliationResult, error)
+	FindReconciliationDiscrepancies(limit, offset int) ([]*domain.LEIReconciliationResult, int64, error)
 }
 
+// LEI audit modes: what UPDATE audit rows store besides their changed fields
+const (
+	LEIAuditModeSnapshot = "snapshot" // Full record snapshot on every audit row
+	LEIAuditModeDiff     = "diff"     // Snapshots on CREATE and DELETE only; UPDATE rows store just the changed fields
+)
+
+// LEIAuditDiffSnapshot is the record snapshot of an UPDATE audit row written in diff mode
+const LEIAuditDiffSnapshot = "{}"
+
 type leiRepository struct {
-	db *gorm.DB
+	db        *gorm.DB
+	reads     *ReadReplicas // lists and searches
+	auditMode string
+	changes   changeEvents
 }
 
-// NewLEIRepository creates a new LEI repository instance
-func NewLEIRepository(db *gorm.DB) LEIRepository {
-	return &leiRepository{db: db}
+// NewLEIRepository creates a new LEI repository instance; with outbox, every created, updated
+// or deleted record also writes a change event to the outbox. Lists and searches read from
+// reads.
+func NewLEIRepository(db *gorm.DB, reads *ReadReplicas, auditMode string, outbox bool) LEIRepository {
+	switch auditMode {
+	case LEIAuditModeSnapshot, LEIAuditModeDiff:
+	default:
+		log.Warn().Str("value", auditMode).Msg("Invalid lei.auditmode (expected snapshot or diff), using snapshot")
+		auditMode = LEIAuditModeSnapshot
+	}
+	return &leiRepository{db: db, reads: reads, auditMode: auditMode, changes: changeEvents{enabled: outbox}}
 }
 
 // CreateLEIRecord creates a new LEI record
@@ -82,43 +139,90 @@ func (r *leiRepository) FindLEIByID(id string) (*domain.LEIRecord, error) {
 // FindAllLEI retrieves all LEI records with pagination
 func (r *leiRepository) FindAllLEI(limit, offset int) ([]*domain.LEIRecord, error) {
 	var records []*domain.LEIRecord
-	if err := r.db.Limit(limit).Offset(offset).Preload("SourceFile").Find(&records).Error; err != nil {
+	if err := r.reads.DB().Limit(limit).Offset(offset).Preload("SourceFile").Find(&records).Error; err != nil {
 		return nil, err
 	}
 	return records, nil
 }
 
+// LEIFilter holds the search, filters and sort order of the LEI list
+type LEIFilter struct {
+	Search      string // LEI code or part of the legal name
+	TextQuery   string // full-text search on the legal name
+	Status      string // entity status; NULL for records without one
+	Category    string
+	Country     string // legal address country
+	LegalForm   string // ELF code, or part of the legal form's name or one of its abbreviations
+	ManagingLOU string // LOU LEI, or part of the LOU's name or marketing name
+	SortBy      string
+	SortOrder   string
+}
+
 // FindAllLEIWithFilters retrieves LEI records with search and filters
-func (r *leiRepository) FindAllLEIWithFilters(limit, offset int, search, status, category, country, sortBy, sortOrder string) ([]*domain.LEIRecord, error) {
+// TextQuery uses the full-text index (search_vector) and, unless SortBy is given, orders by relevance
+func (r *leiRepository) FindAllLEIWithFilters(limit, offset int, filter LEIFilter) ([]*domain.LEIRecord, error) {
 	var records []*domain.LEIRecord
-	query := r.db.Limit(limit).Offset(offset).Preload("SourceFile")
+	query := applyLEIFilters(r.reads.DB().Limit(limit).Offset(offset).Preload("SourceFile"), filter)
+	if err := query.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
 
+// applyLEIFilters adds the LEI list's search, filters and sort order to a query
+func applyLEIFilters(query *gorm.DB, filter LEIFilter) *gorm.DB {
 	// Apply search filter (LEI code or legal name)
-	if search != "" {
-		query = query.Where("lei ILIKE ? OR legal_name ILIKE ?", "%"+search+"%", "%"+search+"%")
+	if filter.Search != "" {
+		query = query.Where("lei ILIKE ? OR legal_name ILIKE ?", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+
+	// Apply full-text search (prefix match on every term of the legal name)
+	tsQuery := buildPrefixTSQuery(filter.TextQuery)
+	if tsQuery != "" {
+		query = query.Where("search_vector @@ to_tsquery('simple', ?)", tsQuery)
 	}
 
 	// Apply status filter
-	if status != "" {
-		if status == "NULL" {
+	if filter.Status != "" {
+		if filter.Status == "NULL" {
 			// Filter for records where entity_status IS NULL or empty string
 			query = query.Where("entity_status IS NULL OR entity_status = ''")
 		} else {
-			query = query.Where("entity_status = ?", status)
+			query = query.Where("entity_status = ?", filter.Status)
 		}
 	}
 
 	// Apply category filter
-	if category != "" {
-		query = query.Where("entity_category = ?", category)
+	if filter.Category != "" {
+		query = query.Where("entity_category = ?", filter.Category)
 	}
 
 	// Apply country filter
-	if country != "" {
-		query = query.Where("legal_address_country = ?", country)
+	if filter.Country != "" {
+		query = query.Where("legal_address_country = ?", filter.Country)
+	}
+
+	// Apply legal form filter (ELF code, or part of the legal form's name or one of its abbreviations)
+	if filter.LegalForm != "" {
+		query = query.Where("entity_legal_form = ? OR entity_legal_form IN (SELECT code FROM elf_codes WHERE name ILIKE ? OR ? ILIKE ANY (regexp_split_to_array(abbreviations, '\\s*;\\s*')))",
+			strings.ToUpper(filter.LegalForm), "%"+filter.LegalForm+"%", filter.LegalForm)
+	}
+
+	// Apply managing LOU filter (LOU LEI, or part of the LOU's name or marketing name)
+	if filter.ManagingLOU != "" {
+		query = query.Where("managing_lou = ? OR managing_lou IN (SELECT lei FROM lous WHERE name ILIKE ? OR marketing_name ILIKE ?)",
+			strings.ToUpper(filter.ManagingLOU), "%"+filter.ManagingLOU+"%", "%"+filter.ManagingLOU+"%")
+	}
+
+	// Full-text searches without an explicit sort are ranked by relevance
+	if tsQuery != "" && filter.SortBy == "" {
+		return query.Clauses(clause.OrderBy{
+			Expression: clause.Expr{SQL: "ts_rank(search_vector, to_tsquery('simple', ?)) DESC, legal_name ASC", Vars: []interface{}{tsQuery}},
+		})
 	}
 
 	// Apply sorting (default to legal_name ascending)
+	sortBy, sortOrder := filter.SortBy, filter.SortOrder
 	if sortBy == "" {
 		sortBy = "legal_name"
 	}
@@ -137,16 +241,10 @@ func (r *leiRepository) FindAllLEIWithFilters(limit, offset int, search, status,
 	}
 
 	if validSortFields[sortBy] {
-		query = query.Order(sortBy + " " + sortOrder)
-	} else {
-		// Default to legal_name if invalid sort field
-		query = query.Order("legal_name " + sortOrder)
-	}
-
-	if err := query.Find(&records).Error; err != nil {
-		return nil, err
+		return query.Order(sortBy + " " + sortOrder)
 	}
-	return records, nil
+	// Default to legal_name if invalid sort field
+	return query.Order("legal_name " + sortOrder)
 }
 
 // CountLEIRecords returns the total count of LEI records
@@ -161,7 +259,7 @@ func (r *leiRepository) CountLEIRecords() (int64, error) {
 // GetDistinctCountries returns a sorted list of unique countries from the LEI database
 func (r *leiRepository) GetDistinctCountries() ([]string, error) {
 	var countries []string
-	err := r.db.Model(&domain.LEIRecord{}).
+	err := r.reads.DB().Model(&domain.LEIRecord{}).
 		Distinct("legal_address_country").
 		Where("legal_address_country IS NOT NULL AND legal_address_country != ''").
 		Order("legal_address_country ASC").
@@ -179,16 +277,39 @@ func (r *leiRepository) UpdateLEIRecord(record *domain.LEIRecord) error {
 
 // UpsertLEIRecord creates or updates an LEI record with change detection
 // Returns true if updated, false if created
-func (r *leiRepository) UpsertLEIRecord(record *domain.LEIRecord) (bool, error) {
+func (r *leiRepository) UpsertLEIRecord(ctx context.Context, record *domain.LEIRecord) (updated bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updated, err = r.withDB(tx).upsertLEIRecord(record)
+		return err
+	})
+	return updated, err
+}
+
+// withDB returns a copy of the repository that runs its statements on db, e.g. a transaction
+func (r *leiRepository) withDB(db *gorm.DB) *leiRepository {
+	return &leiRepository{db: db, reads: r.reads, auditMode: r.auditMode, changes: r.changes}
+}
+
+// upsertLEIRecord is UpsertLEIRecord without its transaction
+func (r *leiRepository) upsertLEIRecord(record *domain.LEIRecord) (bool, error) {
 	existing, err := r.FindLEIByLEI(record.LEI)
 
+	// Attribute the change to the caller-provided source (e.g. gleif-api), defaulting to system
+	changedBy := record.UpdatedBy
+	if changedBy == "" {
+		changedBy = "system"
+	}
+
 	// If not found, create new record
 	if err == gorm.ErrRecordNotFound {
-		record.CreatedBy = "system"
-		record.UpdatedBy = "system"
+		record.CreatedBy = changedBy
+		record.UpdatedBy = changedBy
 		if err := r.CreateLEIRecord(record); err != nil {
 			return false, err
 		}
+		if err := updateSearchVector(r.db, record.ID); err != nil {
+			return false, fmt.Errorf("failed to update search vector: %w", err)
+		}
 
 		// Create audit record for creation
 		auditRecord := &domain.LEIRecordAudit{
@@ -198,11 +319,14 @@ func (r *leiRepository) UpsertLEIRecord(record *domain.LEIRecord) (bool, error)
 			RecordSnapshot: r.recordToJSON(record),
 			ChangedFields:  "{}",
 			SourceFileID:   record.SourceFileID,
-			ChangedBy:      "system",
+			ChangedBy:      changedBy,
 		}
 		if err := r.CreateAuditRecord(auditRecord); err != nil {
 			return false, fmt.Errorf("failed to create audit record: %w", err)
 		}
+		if err := r.changes.record(r.db, leiChangeEvent(events.ActionCreated, record.ID, record.LEI, nil, record.SourceFileID)); err != nil {
+			return false, err
+		}
 
 		return false, nil
 	}
@@ -229,38 +353,101 @@ func (r *leiRepository) UpsertLEIRecord(record *domain.LEIRecord) (bool, error)
 	record.ID = existing.ID
 	record.CreatedAt = existing.CreatedAt
 	record.CreatedBy = existing.CreatedBy
-	record.UpdatedBy = "system"
+	record.UpdatedBy = changedBy
 	record.ChangedFields = string(changesJSON)
 
 	if err := r.UpdateLEIRecord(record); err != nil {
 		return false, err
 	}
+	if err := updateSearchVector(r.db, record.ID); err != nil {
+		return false, fmt.Errorf("failed to update search vector: %w", err)
+	}
 
 	// Create audit record for update
 	auditRecord := &domain.LEIRecordAudit{
 		LEIRecordID:    record.ID,
 		LEI:            record.LEI,
 		Action:         "UPDATE",
-		RecordSnapshot: r.recordToJSON(record),
+		RecordSnapshot: r.updateSnapshot(record),
 		ChangedFields:  string(changesJSON),
 		SourceFileID:   record.SourceFileID,
-		ChangedBy:      "system",
+		ChangedBy:      changedBy,
 	}
 	if err := r.CreateAuditRecord(auditRecord); err != nil {
 		return false, fmt.Errorf("failed to create audit record: %w", err)
 	}
+	if err := r.changes.record(r.db, leiChangeEvent(events.ActionUpdated, record.ID, record.LEI, changes, record.SourceFileID)); err != nil {
+		return false, err
+	}
 
 	return true, nil
 }
 
+// batchUpsertArgsPerRecord is the number of placeholders per row in the batch upsert statement
+const batchUpsertArgsPerRecord = 43
+
 // BatchUpsertLEIRecords performs batch upsert of LEI records with full audit trail
 // Returns (created_count, updated_count, error)
 // CRITICAL: Every record operation is audited for data provenance compliance
-func (r *leiRepository) BatchUpsertLEIRecords(records []*domain.LEIRecord) (int, int, error) {
+func (r *leiRepository) BatchUpsertLEIRecords(ctx context.Context, records []*domain.LEIRecord) (int, int, error) {
 	if len(records) == 0 {
 		return 0, 0, nil
 	}
+	r = r.withDB(r.db.WithContext(ctx))
 
+	existingMap, err := r.prepareBatchUpsert(records)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Use transaction for atomicity: record + audit must succeed together
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return 0, 0, tx.Error
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	createdCount := 0
+	updatedCount := 0
+
+	// Process in batches of 100 for optimal performance
+	batchSize := 100
+	for i := 0; i < len(records); i += batchSize {
+		end := i + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+
+		created, updated, err := r.upsertChunk(tx, records[i:end], existingMap)
+		if err != nil {
+			tx.Rollback()
+			return 0, 0, fmt.Errorf("failed to batch upsert records %d-%d: %w", i, end, err)
+		}
+		createdCount += created
+		updatedCount += updated
+	}
+
+	// Commit transaction: all records + audits persisted together
+	if err := tx.Commit().Error; err != nil {
+		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int("created", createdCount).
+		Int("updated", updatedCount).
+		Int("total", len(records)).
+		Msg("Batch upsert with full audit trail completed successfully")
+
+	return createdCount, updatedCount, nil
+}
+
+// prepareBatchUpsert fills in audit defaults and loads the current version of each record
+// (keyed by LEI) for change detection
+func (r *leiRepository) prepareBatchUpsert(records []*domain.LEIRecord) (map[string]*domain.LEIRecord, error) {
 	// Set created_by and updated_by for all records
 	now := time.Now()
 	leiCodes := make([]string, len(records))
@@ -285,7 +472,7 @@ func (r *leiRepository) BatchUpsertLEIRecords(records []*domain.LEIRecord) (int,
 	if err := r.db.Model(&domain.LEIRecord{}).
 		Where("lei IN ?", leiCodes).
 		Find(&existingRecords).Error; err != nil {
-		return 0, 0, fmt.Errorf("failed to query existing records: %w", err)
+		return nil, fmt.Errorf("failed to query existing records: %w", err)
 	}
 
 	// Build map of existing records for fast lookup and change detection
@@ -294,271 +481,273 @@ func (r *leiRepository) BatchUpsertLEIRecords(records []*domain.LEIRecord) (int,
 		existingMap[existingRecords[i].LEI] = &existingRecords[i]
 	}
 
-	// Use transaction for atomicity: record + audit must succeed together
-	tx := r.db.Begin()
-	if tx.Error != nil {
-		return 0, 0, tx.Error
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	createdCount := 0
-	updatedCount := 0
+	return existingMap, nil
+}
 
-	// Process in batches of 100 for optimal performance
-	batchSize := 100
-	for i := 0; i < len(records); i += batchSize {
-		end := i + batchSize
-		if end > len(records) {
-			end = len(records)
-		}
-		batch := records[i:end]
-
-		// Build SQL with RETURNING to get affected record IDs
-		valueStrings := make([]string, 0, len(batch))
-		valueArgs := make([]interface{}, 0, len(batch)*20)
-
-		// Generate all values in Go, use placeholders for everything
-		now := time.Now()
-		emptyChangedFields := "{}"
-
-		for _, record := range batch {
-			// Use placeholders for ALL fields (41 total)
-			valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
-
-			// Generate ID and timestamps in Go
-			newID := uuid.New()
-
-			valueArgs = append(valueArgs,
-				newID,                          // id
-				record.LEI,                     // lei
-				record.LegalName,               // legal_name
-				record.TransliteratedLegalName, // transliterated_legal_name
-				record.OtherNames,              // other_names
-				record.LegalAddressLine1,       // legal_address_line_1
-				record.LegalAddressLine2,       // legal_address_line_2
-				record.LegalAddressLine3,       // legal_address_line_3
-				record.LegalAddressLine4,       // legal_address_line_4
-				record.LegalAddressCity,        // legal_address_city
-				record.LegalAddressRegion,      // legal_address_region
-				record.LegalAddressCountry,     // legal_address_country
-				record.LegalAddressPostalCode,  // legal_address_postal_code
-				record.HQAddressLine1,          // hq_address_line_1
-				record.HQAddressLine2,          // hq_address_line_2
-				record.HQAddressLine3,          // hq_address_line_3
-				record.HQAddressLine4,          // hq_address_line_4
-				record.HQAddressCity,           // hq_address_city
-				record.HQAddressRegion,         // hq_address_region
-				record.HQAddressCountry,        // hq_address_country
-				record.HQAddressPostalCode,     // hq_address_postal_code
-				record.RegistrationAuthority,   // registration_authority
-				record.RegistrationAuthorityID, // registration_authority_id
-				record.RegistrationNumber,      // registration_number
-				record.EntityCategory,          // entity_category
-				record.EntitySubCategory,       // entity_sub_category
-				record.EntityLegalForm,         // entity_legal_form
-				record.EntityStatus,            // entity_status
-				record.SuccessorLEI,            // successor_lei
-				record.ValidationAuthority,     // validation_authority
-				record.InitialRegistrationDate, // initial_registration_date
-				record.LastUpdateDate,          // last_update_date
-				record.NextRenewalDate,         // next_renewal_date
-				record.ManagingLOU,             // managing_lou
-				record.ValidationSources,       // validation_sources
-				record.SourceFileID,            // source_file_id
-				now,                            // created_at
-				now,                            // updated_at
-				"system",                       // created_by
-				"system",                       // updated_by
-				emptyChangedFields,             // changed_fields
-			)
+// upsertChunk upserts up to 100 records and writes their audit rows and change events inside tx
+// Records identical to their stored version are skipped: no write and no audit row
+// The caller owns the transaction and rolls back (fully or to a savepoint) on error
+func (r *leiRepository) upsertChunk(tx *gorm.DB, chunk []*domain.LEIRecord, existingMap map[string]*domain.LEIRecord) (created, updated int, err error) {
+	// Diff against the stored versions first so unchanged records never reach the database
+	batch := make([]*domain.LEIRecord, 0, len(chunk))
+	changesByLEI := make(map[string]map[string]domain.LEIChangeDetection)
+	for _, record := range chunk {
+		if existingRecord, wasExisting := existingMap[record.LEI]; wasExisting {
+			changes := r.detectChanges(existingRecord, record)
+			if len(changes) == 0 {
+				continue
+			}
+			changesByLEI[record.LEI] = changes
 		}
+		batch = append(batch, record)
+	}
+	if len(batch) == 0 {
+		log.Ctx(tx.Statement.Context).Debug().Int("records", len(chunk)).Msg("Batch upsert skipped, no records changed")
+		return 0, 0, nil
+	}
 
-		// Execute upsert with RETURNING to get IDs
-		stmt := fmt.Sprintf(`
-			INSERT INTO lei_raw.lei_records (
-				id, lei, legal_name, transliterated_legal_name, other_names,
-				legal_address_line_1, legal_address_line_2, legal_address_line_3, legal_address_line_4,
-				legal_address_city, legal_address_region, legal_address_country, legal_address_postal_code,
-				hq_address_line_1, hq_address_line_2, hq_address_line_3, hq_address_line_4,
-				hq_address_city, hq_address_region, hq_address_country, hq_address_postal_code,
-				registration_authority, registration_authority_id, registration_number,
-				entity_category, entity_sub_category, entity_legal_form,
-				entity_status, successor_lei, validation_authority,
-				initial_registration_date, last_update_date, next_renewal_date,
-				managing_lou, validation_sources,
-				source_file_id,
-				created_at, updated_at, created_by, updated_by, changed_fields
-			) VALUES %s
-			ON CONFLICT (lei) DO UPDATE SET
-				legal_name = EXCLUDED.legal_name,
-				transliterated_legal_name = EXCLUDED.transliterated_legal_name,
-				other_names = EXCLUDED.other_names,
-				entity_status = EXCLUDED.entity_status,
-				legal_address_line_1 = EXCLUDED.legal_address_line_1,
-				legal_address_line_2 = EXCLUDED.legal_address_line_2,
-				legal_address_line_3 = EXCLUDED.legal_address_line_3,
-				legal_address_line_4 = EXCLUDED.legal_address_line_4,
-				legal_address_city = EXCLUDED.legal_address_city,
-				legal_address_region = EXCLUDED.legal_address_region,
-				legal_address_country = EXCLUDED.legal_address_country,
-				legal_address_postal_code = EXCLUDED.legal_address_postal_code,
-				hq_address_line_1 = EXCLUDED.hq_address_line_1,
-				hq_address_line_2 = EXCLUDED.hq_address_line_2,
-				hq_address_line_3 = EXCLUDED.hq_address_line_3,
-				hq_address_line_4 = EXCLUDED.hq_address_line_4,
-				hq_address_city = EXCLUDED.hq_address_city,
-				hq_address_region = EXCLUDED.hq_address_region,
-				hq_address_country = EXCLUDED.hq_address_country,
-				hq_address_postal_code = EXCLUDED.hq_address_postal_code,
-				registration_authority = EXCLUDED.registration_authority,
-				registration_authority_id = EXCLUDED.registration_authority_id,
-				registration_number = EXCLUDED.registration_number,
-				entity_category = EXCLUDED.entity_category,
-				entity_sub_category = EXCLUDED.entity_sub_category,
-				entity_legal_form = EXCLUDED.entity_legal_form,
-				successor_lei = EXCLUDED.successor_lei,
-				validation_authority = EXCLUDED.validation_authority,
-				initial_registration_date = EXCLUDED.initial_registration_date,
-				last_update_date = EXCLUDED.last_update_date,
-				next_renewal_date = EXCLUDED.next_renewal_date,
-				managing_lou = EXCLUDED.managing_lou,
-				validation_sources = EXCLUDED.validation_sources,
-				source_file_id = EXCLUDED.source_file_id,
-				updated_at = NOW(),
-				updated_by = 'system'
-	`, strings.Join(valueStrings, ","))
-
-		// Execute batch upsert using Exec (better placeholder handling than Raw)
-		result := tx.Exec(stmt, valueArgs...)
-		if result.Error != nil {
-			// Calculate debug info
-			stmtPreview := stmt
-			if len(stmt) > 2000 {
-				stmtPreview = stmt[:2000]
-			}
+	// Build SQL with RETURNING to get affected record IDs
+	valueStrings := make([]string, 0, len(batch))
+	valueArgs := make([]interface{}, 0, len(batch)*20)
 
-			log.Error().
-				Err(result.Error).
-				Int("batch_start", i).
-				Int("batch_end", end).
-				Int("value_args_count", len(valueArgs)).
-				Int("expected_per_record", 41).
-				Int("records_in_batch", len(batch)).
-				Str("stmt_preview", stmtPreview).
-				Msg("CRITICAL: Batch upsert failed")
-			return 0, 0, fmt.Errorf("failed to batch upsert records %d-%d: %w", i, end, result.Error)
+	// Generate all values in Go, use placeholders for everything
+	now := time.Now()
+	emptyChangedFields := "{}"
+
+	for _, record := range batch {
+		// Use placeholders for ALL fields (43 total; the last feeds search_vector)
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, to_tsvector('simple', ?))")
+
+		// Generate ID and timestamps in Go
+		newID := uuid.New()
+
+		valueArgs = append(valueArgs,
+			newID,                          // id
+			record.LEI,                     // lei
+			record.LegalName,               // legal_name
+			record.TransliteratedLegalName, // transliterated_legal_name
+			record.OtherNames,              // other_names
+			record.LegalAddressLine1,       // legal_address_line_1
+			record.LegalAddressLine2,       // legal_address_line_2
+			record.LegalAddressLine3,       // legal_address_line_3
+			record.LegalAddressLine4,       // legal_address_line_4
+			record.LegalAddressCity,        // legal_address_city
+			record.LegalAddressRegion,      // legal_address_region
+			record.LegalAddressCountry,     // legal_address_country
+			record.LegalAddressPostalCode,  // legal_address_postal_code
+			record.HQAddressLine1,          // hq_address_line_1
+			record.HQAddressLine2,          // hq_address_line_2
+			record.HQAddressLine3,          // hq_address_line_3
+			record.HQAddressLine4,          // hq_address_line_4
+			record.HQAddressCity,           // hq_address_city
+			record.HQAddressRegion,         // hq_address_region
+			record.HQAddressCountry,        // hq_address_country
+			record.HQAddressPostalCode,     // hq_address_postal_code
+			record.RegistrationAuthority,   // registration_authority
+			record.RegistrationAuthorityID, // registration_authority_id
+			record.RegistrationNumber,      // registration_number
+			record.EntityCategory,          // entity_category
+			record.EntitySubCategory,       // entity_sub_category
+			record.EntityLegalForm,         // entity_legal_form
+			record.EntityStatus,            // entity_status
+			record.RegistrationStatus,      // registration_status
+			record.SuccessorLEI,            // successor_lei
+			record.ValidationAuthority,     // validation_authority
+			record.InitialRegistrationDate, // initial_registration_date
+			record.LastUpdateDate,          // last_update_date
+			record.NextRenewalDate,         // next_renewal_date
+			record.ManagingLOU,             // managing_lou
+			record.ValidationSources,       // validation_sources
+			record.SourceFileID,            // source_file_id
+			now,                            // created_at
+			now,                            // updated_at
+			"system",                       // created_by
+			"system",                       // updated_by
+			emptyChangedFields,             // changed_fields
+			leiSearchText(record.LegalName, record.TransliteratedLegalName), // search_vector
+		)
+	}
+
+	// Execute upsert with RETURNING to get IDs
+	stmt := fmt.Sprintf(`
+		INSERT INTO lei_raw.lei_records (
+			id, lei, legal_name, transliterated_legal_name, other_names,
+			legal_address_line_1, legal_address_line_2, legal_address_line_3, legal_address_line_4,
+			legal_address_city, legal_address_region, legal_address_country, legal_address_postal_code,
+			hq_address_line_1, hq_address_line_2, hq_address_line_3, hq_address_line_4,
+			hq_address_city, hq_address_region, hq_address_country, hq_address_postal_code,
+			registration_authority, registration_authority_id, registration_number,
+			entity_category, entity_sub_category, entity_legal_form,
+			entity_status, registration_status, successor_lei, validation_authority,
+			initial_registration_date, last_update_date, next_renewal_date,
+			managing_lou, validation_sources,
+			source_file_id,
+			created_at, updated_at, created_by, updated_by, changed_fields,
+			search_vector
+		) VALUES %s
+		ON CONFLICT (lei) DO UPDATE SET
+			legal_name = EXCLUDED.legal_name,
+			transliterated_legal_name = EXCLUDED.transliterated_legal_name,
+			other_names = EXCLUDED.other_names,
+			entity_status = EXCLUDED.entity_status,
+			registration_status = EXCLUDED.registration_status,
+			legal_address_line_1 = EXCLUDED.legal_address_line_1,
+			legal_address_line_2 = EXCLUDED.legal_address_line_2,
+			legal_address_line_3 = EXCLUDED.legal_address_line_3,
+			legal_address_line_4 = EXCLUDED.legal_address_line_4,
+			legal_address_city = EXCLUDED.legal_address_city,
+			legal_address_region = EXCLUDED.legal_address_region,
+			legal_address_country = EXCLUDED.legal_address_country,
+			legal_address_postal_code = EXCLUDED.legal_address_postal_code,
+			hq_address_line_1 = EXCLUDED.hq_address_line_1,
+			hq_address_line_2 = EXCLUDED.hq_address_line_2,
+			hq_address_line_3 = EXCLUDED.hq_address_line_3,
+			hq_address_line_4 = EXCLUDED.hq_address_line_4,
+			hq_address_city = EXCLUDED.hq_address_city,
+			hq_address_region = EXCLUDED.hq_address_region,
+			hq_address_country = EXCLUDED.hq_address_country,
+			hq_address_postal_code = EXCLUDED.hq_address_postal_code,
+			registration_authority = EXCLUDED.registration_authority,
+			registration_authority_id = EXCLUDED.registration_authority_id,
+			registration_number = EXCLUDED.registration_number,
+			entity_category = EXCLUDED.entity_category,
+			entity_sub_category = EXCLUDED.entity_sub_category,
+			entity_legal_form = EXCLUDED.entity_legal_form,
+			successor_lei = EXCLUDED.successor_lei,
+			validation_authority = EXCLUDED.validation_authority,
+			initial_registration_date = EXCLUDED.initial_registration_date,
+			last_update_date = EXCLUDED.last_update_date,
+			next_renewal_date = EXCLUDED.next_renewal_date,
+			managing_lou = EXCLUDED.managing_lou,
+			validation_sources = EXCLUDED.validation_sources,
+			source_file_id = EXCLUDED.source_file_id,
+			search_vector = EXCLUDED.search_vector,
+			updated_at = NOW(),
+			updated_by = 'system'
+`, strings.Join(valueStrings, ","))
+
+	// Execute batch upsert using Exec (better placeholder handling than Raw)
+	result := tx.Exec(stmt, valueArgs...)
+	if result.Error != nil {
+		// Calculate debug info
+		stmtPreview := stmt
+		if len(stmt) > 2000 {
+			stmtPreview = stmt[:2000]
 		}
 
-		// Get IDs from valueArgs we just inserted (first value of each record)
-		leiToID := make(map[string]uuid.UUID)
-		for idx, record := range batch {
-			// ID is at position: idx * 41 (since we have 41 values per record)
-			idPos := idx * 41
-			insertedID := valueArgs[idPos].(uuid.UUID)
-			leiToID[record.LEI] = insertedID
+		log.Ctx(tx.Statement.Context).Error().
+			Err(result.Error).
+			Str("first_lei", batch[0].LEI).
+			Str("last_lei", batch[len(batch)-1].LEI).
+			Int("value_args_count", len(valueArgs)).
+			Int("expected_per_record", batchUpsertArgsPerRecord).
+			Int("records_in_batch", len(batch)).
+			Str("stmt_preview", stmtPreview).
+			Msg("CRITICAL: Batch upsert failed")
+		return 0, 0, result.Error
+	}
+
+	// Get IDs: new records got the ID from valueArgs (first value of each record),
+	// updated records keep their existing ID
+	leiToID := make(map[string]uuid.UUID)
+	for idx, record := range batch {
+		if existingRecord, wasExisting := existingMap[record.LEI]; wasExisting {
+			leiToID[record.LEI] = existingRecord.ID
+			continue
+		}
+		// ID is at position: idx * batchUpsertArgsPerRecord
+		idPos := idx * batchUpsertArgsPerRecord
+		insertedID := valueArgs[idPos].(uuid.UUID)
+		leiToID[record.LEI] = insertedID
+	}
+
+	// Build audit records for this batch
+	auditRecords := make([]domain.LEIRecordAudit, 0, len(batch))
+	changed := make([]events.Event, 0, len(batch))
+	for _, record := range batch {
+		recordID, exists := leiToID[record.LEI]
+		if !exists {
+			return 0, 0, fmt.Errorf("failed to get ID for LEI %s after upsert", record.LEI)
 		}
 
-		// Build audit records for this batch
-		auditRecords := make([]domain.LEIRecordAudit, 0, len(batch))
-		for _, record := range batch {
-			recordID, exists := leiToID[record.LEI]
-			if !exists {
-				tx.Rollback()
-				return 0, 0, fmt.Errorf("failed to get ID for LEI %s after upsert", record.LEI)
-			}
+		// Check if this record existed before
+		changes, wasExisting := changesByLEI[record.LEI]
+
+		if !wasExisting {
+			// New record - always create audit entry
+			created++
+			auditRecords = append(auditRecords, domain.LEIRecordAudit{
+				LEIRecordID:    recordID,
+				LEI:            record.LEI,
+				Action:         "CREATE",
+				RecordSnapshot: r.recordToJSON(record),
+				ChangedFields:  "{}",
+				SourceFileID:   record.SourceFileID,
+				ChangedBy:      "system",
+			})
+			changed = append(changed, leiChangeEvent(events.ActionCreated, recordID, record.LEI, nil, record.SourceFileID))
+		} else {
+			// Existing record - unchanged ones were filtered out above
+			updated++
 
-			// Check if this record existed before
-			existingRecord, wasExisting := existingMap[record.LEI]
-
-			if !wasExisting {
-				// New record - always create audit entry
-				createdCount++
-				auditRecords = append(auditRecords, domain.LEIRecordAudit{
-					LEIRecordID:    recordID,
-					LEI:            record.LEI,
-					Action:         "CREATE",
-					RecordSnapshot: r.recordToJSON(record),
-					ChangedFields:  "{}",
-					SourceFileID:   record.SourceFileID,
-					ChangedBy:      "system",
-				})
-			} else {
-				// Existing record - detect changes
-				changes := r.detectChanges(existingRecord, record)
-
-				// Only create audit record if something actually changed
-				if len(changes) > 0 {
-					updatedCount++
-
-					// Convert changes to JSON
-					changesJSON, err := json.Marshal(changes)
-					if err != nil {
-						tx.Rollback()
-						return 0, 0, fmt.Errorf("failed to marshal changes: %w", err)
-					}
-
-					auditRecords = append(auditRecords, domain.LEIRecordAudit{
-						LEIRecordID:    recordID,
-						LEI:            record.LEI,
-						Action:         "UPDATE",
-						RecordSnapshot: r.recordToJSON(record),
-						ChangedFields:  string(changesJSON),
-						SourceFileID:   record.SourceFileID,
-						ChangedBy:      "system",
-					})
-				}
-				// If no changes, don't create audit record or increment updatedCount
+			// Convert changes to JSON
+			changesJSON, err := json.Marshal(changes)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to marshal changes: %w", err)
 			}
-		}
 
-		// Batch insert audit records (100 at a time)
-		auditBatchSize := 100
-		for j := 0; j < len(auditRecords); j += auditBatchSize {
-			auditEnd := j + auditBatchSize
-			if auditEnd > len(auditRecords) {
-				auditEnd = len(auditRecords)
-			}
-			auditBatch := auditRecords[j:auditEnd]
-
-			if err := tx.Create(&auditBatch).Error; err != nil {
-				tx.Rollback()
-				log.Error().
-					Err(err).
-					Int("audit_batch_start", j).
-					Int("audit_batch_end", auditEnd).
-					Msg("CRITICAL: Audit record creation failed")
-				return 0, 0, fmt.Errorf("failed to create audit records: %w", err)
-			}
+			auditRecords = append(auditRecords, domain.LEIRecordAudit{
+				LEIRecordID:    recordID,
+				LEI:            record.LEI,
+				Action:         "UPDATE",
+				RecordSnapshot: r.updateSnapshot(record),
+				ChangedFields:  string(changesJSON),
+				SourceFileID:   record.SourceFileID,
+				ChangedBy:      "system",
+			})
+			changed = append(changed, leiChangeEvent(events.ActionUpdated, recordID, record.LEI, changes, record.SourceFileID))
 		}
-
-		log.Debug().
-			Int("batch_start", i).
-			Int("batch_end", end).
-			Int("records", len(batch)).
-			Int("audits", len(auditRecords)).
-			Msg("Batch upsert with audit trail completed")
 	}
 
-	// Commit transaction: all records + audits persisted together
-	if err := tx.Commit().Error; err != nil {
-		return 0, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	// Batch insert audit records (100 at a time)
+	auditBatchSize := 100
+	for j := 0; j < len(auditRecords); j += auditBatchSize {
+		auditEnd := j + auditBatchSize
+		if auditEnd > len(auditRecords) {
+			auditEnd = len(auditRecords)
+		}
+		auditBatch := auditRecords[j:auditEnd]
+
+		if err := tx.Create(&auditBatch).Error; err != nil {
+			log.Ctx(tx.Statement.Context).Error().
+				Err(err).
+				Int("audit_batch_start", j).
+				Int("audit_batch_end", auditEnd).
+				Msg("CRITICAL: Audit record creation failed")
+			return 0, 0, fmt.Errorf("failed to create audit records: %w", err)
+		}
+	}
+	if err := r.changes.record(tx, changed...); err != nil {
+		return 0, 0, err
 	}
 
-	log.Info().
-		Int("created", createdCount).
-		Int("updated", updatedCount).
-		Int("total", len(records)).
-		Msg("Batch upsert with full audit trail completed successfully")
+	log.Ctx(tx.Statement.Context).Debug().
+		Int("records", len(batch)).
+		Int("audits", len(auditRecords)).
+		Msg("Batch upsert with audit trail completed")
 
-	return createdCount, updatedCount, nil
+	return created, updated, nil
 }
 
 // DeleteLEI soft deletes an LEI record
 func (r *leiRepository) DeleteLEI(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.withDB(tx).deleteLEI(id)
+	})
+}
+
+// deleteLEI is DeleteLEI without its transaction
+func (r *leiRepository) deleteLEI(id string) error {
 	// Get the record before deleting for audit
 	record, err := r.FindLEIByID(id)
 	if err != nil {
@@ -579,7 +768,10 @@ func (r *leiRepository) DeleteLEI(id string) error {
 		ChangedFields:  "{}",
 		ChangedBy:      "system",
 	}
-	return r.CreateAuditRecord(auditRecord)
+	if err := r.CreateAuditRecord(auditRecord); err != nil {
+		return err
+	}
+	return r.changes.record(r.db, leiChangeEvent(events.ActionDeleted, record.ID, record.LEI, nil, record.SourceFileID))
 }
 
 // CreateSourceFile creates a new source file record
@@ -617,6 +809,22 @@ func (r *leiRepository) FindLatestSourceFile(fileType string) (*domain.SourceFil
 	return &file, nil
 }
 
+// FindLastAppliedPublicationDate returns the publication date of the newest successfully processed
+// full or delta file, or nil when none has been applied
+func (r *leiRepository) FindLastAppliedPublicationDate() (*time.Time, error) {
+	var latest sql.NullTime
+	if err := r.db.Model(&domain.SourceFile{}).
+		Select("MAX(publication_date)").
+		Where("file_type IN ? AND processing_status = ?", []string{"FULL", "DELTA"}, "COMPLETED").
+		Row().Scan(&latest); err != nil {
+		return nil, err
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
 // UpdateSourceFile updates a source file record
 func (r *leiRepository) UpdateSourceFile(file *domain.SourceFile) error {
 	return r.db.Save(file).Error
@@ -637,7 +845,7 @@ func (r *leiRepository) FindPendingSourceFiles() ([]*domain.SourceFile, error) {
 func (r *leiRepository) FindRetryableFailedFiles() ([]*domain.SourceFile, error) {
 	var files []*domain.SourceFile
 	if err := r.db.Where("processing_status = ? AND retry_count < max_retries", "FAILED").
-		Where("failure_category IN ? OR failure_category IS NULL", []string{"SCHEMA_ERROR", "NETWORK_ERROR", "UNKNOWN"}).
+		Where("failure_category IN ? OR failure_category IS NULL", []string{"SCHEMA_ERROR", "NETWORK_ERROR", "STALLED", "UNKNOWN"}).
 		Order("publication_date ASC").
 		Find(&files).Error; err != nil {
 		return nil, err
@@ -670,6 +878,18 @@ func (r *leiRepository) UpdateProcessingStatus(status *domain.FileProcessingStat
 	return r.db.Save(status).Error
 }
 
+// ReleaseProcessingStatus marks RUNNING jobs working on a source file as FAILED and detaches the file
+func (r *leiRepository) ReleaseProcessingStatus(sourceFileID uuid.UUID, errorMessage string) (int64, error) {
+	result := r.db.Model(&domain.FileProcessingStatus{}).
+		Where("current_source_file_id = ? AND status = ?", sourceFileID, "RUNNING").
+		Updates(map[string]interface{}{
+			"status":                 "FAILED",
+			"error_message":          errorMessage,
+			"current_source_file_id": nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
 // CreateAuditRecord creates a new audit record
 func (r *leiRepository) CreateAuditRecord(audit *domain.LEIRecordAudit) error {
 	return r.db.Create(audit).Error
@@ -688,6 +908,15 @@ func (r *leiRepository) FindAuditHistoryByLEI(lei string, limit int) ([]*domain.
 	return audits, nil
 }
 
+// FindAuditTrailByLEI retrieves the full audit trail for an LEI, oldest first
+func (r *leiRepository) FindAuditTrailByLEI(lei string) ([]*domain.LEIRecordAudit, error) {
+	var audits []*domain.LEIRecordAudit
+	if err := r.db.Where("lei = ?", lei).Order("created_at ASC").Order("id ASC").Find(&audits).Error; err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
 // detectChanges compares two LEI records and returns a map of changed fields
 func (r *leiRepository) detectChanges(old, new *domain.LEIRecord) map[string]domain.LEIChangeDetection {
 	changes := make(map[string]domain.LEIChangeDetection)
@@ -703,7 +932,9 @@ func (r *leiRepository) detectChanges(old, new *domain.LEIRecord) map[string]dom
 		// Skip internal fields and timestamps
 		if fieldName == "ID" || fieldName == "CreatedAt" || fieldName == "UpdatedAt" ||
 			fieldName == "DeletedAt" || fieldName == "CreatedBy" || fieldName == "UpdatedBy" ||
-			fieldName == "ChangedFields" || fieldName == "SourceFile" || fieldName == "SourceFileID" {
+			fieldName == "ChangedFields" || fieldName == "SourceFile" || fieldName == "SourceFileID" ||
+			fieldName == "EntityLegalFormName" || fieldName == "RegistrationAuthorityName" ||
+			fieldName == "RegistrationAuthorityJurisdiction" || fieldName == "ManagingLOUName" {
 			continue
 		}
 
@@ -711,16 +942,7 @@ func (r *leiRepository) detectChanges(old, new *domain.LEIRecord) map[string]dom
 		newFieldVal := newVal.Field(i).Interface()
 
 		// Compare values
-		if !reflect.DeepEqual(oldFieldVal, newFieldVal) {
-			// Special handling for time.Time zero values
-			if field.Type == reflect.TypeOf(time.Time{}) {
-				oldTime := oldFieldVal.(time.Time)
-				newTime := newFieldVal.(time.Time)
-				if oldTime.IsZero() && newTime.IsZero() {
-					continue
-				}
-			}
-
+		if !fieldValuesEqual(field, oldFieldVal, newFieldVal) {
 			changes[fieldName] = domain.LEIChangeDetection{
 				FieldName: fieldName,
 				OldValue:  oldFieldVal,
@@ -732,6 +954,41 @@ func (r *leiRepository) detectChanges(old, new *domain.LEIRecord) map[string]dom
 	return changes
 }
 
+// fieldValuesEqual compares a field of a stored record with a freshly parsed one. Values read back
+// from the database differ in form from parsed ones, so a plain DeepEqual would flag every record:
+// timestamp columns drop the time zone and jsonb columns are re-serialized (key order, spacing).
+func fieldValuesEqual(field reflect.StructField, old, new interface{}) bool {
+	if oldTime, ok := old.(time.Time); ok {
+		newTime := new.(time.Time)
+		return wallClock(oldTime).Equal(wallClock(newTime))
+	}
+	if strings.Contains(field.Tag.Get("gorm"), "type:jsonb") {
+		oldJSON, newJSON := old.(string), new.(string)
+		if oldJSON == newJSON {
+			return true
+		}
+		var oldDoc, newDoc interface{}
+		if json.Unmarshal([]byte(oldJSON), &oldDoc) != nil || json.Unmarshal([]byte(newJSON), &newDoc) != nil {
+			return false
+		}
+		return reflect.DeepEqual(oldDoc, newDoc)
+	}
+	return reflect.DeepEqual(old, new)
+}
+
+// wallClock drops the time zone the way a TIMESTAMP column does
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// updateSnapshot returns the record snapshot stored on an UPDATE audit row for the audit mode
+func (r *leiRepository) updateSnapshot(record *domain.LEIRecord) string {
+	if r.auditMode == LEIAuditModeDiff {
+		return LEIAuditDiffSnapshot
+	}
+	return r.recordToJSON(record)
+}
+
 // recordToJSON converts an LEI record to JSON string
 func (r *leiRepository) recordToJSON(record *domain.LEIRecord) string {
 	jsonBytes, err := json.Marshal(record)
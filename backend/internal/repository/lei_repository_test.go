@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// storedLEIRecord is a record as read back from lei_records: timestamps without a time zone and
+// jsonb re-serialized by Postgres
+func storedLEIRecord() *domain.LEIRecord {
+	registered := time.Date(2014, 3, 12, 9, 30, 0, 0, time.UTC)
+	return &domain.LEIRecord{
+		ID:                      uuid.New(),
+		LEI:                     "5493001KJTIIGC8Y1R12",
+		LegalName:               "Bloomberg Finance L.P.",
+		OtherNames:              `[{"name": "BFLP", "type": "TRADING_OR_OPERATING_NAME"}]`,
+		LegalAddressCountry:     "US",
+		RegistrationStatus:      "ISSUED",
+		InitialRegistrationDate: registered,
+		LastUpdateDate:          registered.AddDate(9, 0, 0),
+		ValidationSources:       `{"authority": "RA000665", "level": "FULLY_CORROBORATED"}`,
+		CreatedBy:               "system",
+		CreatedAt:               time.Now().Add(-time.Hour),
+	}
+}
+
+// parsedLEIRecord is the same record freshly parsed from a GLEIF file
+func parsedLEIRecord() *domain.LEIRecord {
+	record := storedLEIRecord()
+	zone := time.FixedZone("", 0)
+	record.ID = uuid.Nil
+	record.InitialRegistrationDate = record.InitialRegistrationDate.In(zone)
+	record.LastUpdateDate = record.LastUpdateDate.In(zone)
+	record.OtherNames = `[{"type":"TRADING_OR_OPERATING_NAME","name":"BFLP"}]`
+	record.ValidationSources = `{"level":"FULLY_CORROBORATED","authority":"RA000665"}`
+	record.CreatedAt = time.Time{}
+	return record
+}
+
+func TestDetectChanges(t *testing.T) {
+	sourceFileID := uuid.New()
+	tests := []struct {
+		name   string
+		change func(record *domain.LEIRecord)
+		want   []string
+	}{
+		{
+			name:   "same record read back from the database",
+			change: func(*domain.LEIRecord) {},
+		},
+		{
+			name:   "new legal name",
+			change: func(r *domain.LEIRecord) { r.LegalName = "Bloomberg Finance LP" },
+			want:   []string{"LegalName"},
+		},
+		{
+			name: "lapsed with a new last update date",
+			change: func(r *domain.LEIRecord) {
+				r.RegistrationStatus = "LAPSED"
+				r.LastUpdateDate = r.LastUpdateDate.AddDate(1, 0, 0)
+			},
+			want: []string{"LastUpdateDate", "RegistrationStatus"},
+		},
+		{
+			name: "other names added",
+			change: func(r *domain.LEIRecord) {
+				r.OtherNames = `[{"name":"BFLP","type":"TRADING_OR_OPERATING_NAME"},{"name":"Bloomberg"}]`
+			},
+			want: []string{"OtherNames"},
+		},
+		{
+			name:   "validation sources that are not JSON",
+			change: func(r *domain.LEIRecord) { r.ValidationSources = "FULLY_CORROBORATED" },
+			want:   []string{"ValidationSources"},
+		},
+		{
+			name: "provenance and names resolved for responses are ignored",
+			change: func(r *domain.LEIRecord) {
+				r.SourceFileID = &sourceFileID
+				r.UpdatedBy = "steward@example.com"
+				r.ChangedFields = `{"LegalName":{}}`
+				r.EntityLegalFormName = "Limited Partnership"
+				r.ManagingLOUName = "Bloomberg Finance L.P."
+			},
+		},
+	}
+	repo := &leiRepository{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := parsedLEIRecord()
+			tt.change(parsed)
+
+			changes := repo.detectChanges(storedLEIRecord(), parsed)
+			var got []string
+			for field, change := range changes {
+				assert.Equal(t, field, change.FieldName)
+				got = append(got, field)
+			}
+			sort.Strings(got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// leiSearchVectorSQL builds the full-text search vector for an LEI record from its names
+// The 'simple' configuration is used because legal names are multilingual and must not be stemmed
+const leiSearchVectorSQL = "to_tsvector('simple', coalesce(legal_name, '') || ' ' || coalesce(transliterated_legal_name, ''))"
+
+// leiSearchText returns the text indexed for a record; must match leiSearchVectorSQL
+func leiSearchText(legalName, transliteratedLegalName string) string {
+	return legalName + " " + transliteratedLegalName
+}
+
+// updateSearchVector recomputes search_vector for the given record IDs
+// Used by single-record writes that go through GORM rather than the batch upsert SQL
+func updateSearchVector(db *gorm.DB, ids ...uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Exec("UPDATE lei_raw.lei_records SET search_vector = "+leiSearchVectorSQL+" WHERE id IN ?", ids).Error
+}
+
+// buildPrefixTSQuery converts free text into a to_tsquery expression where every term
+// must match as a prefix, e.g. "deutsche ban" -> "deutsche:* & ban:*"
+// Punctuation is stripped so user input can never produce tsquery syntax errors
+func buildPrefixTSQuery(text string) string {
+	terms := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// FuzzySearchLEI ranks LEI records by trigram similarity of legal_name (or transliterated name) to name
+// Only records scoring at least threshold are returned; the % operator lets Postgres use the trigram indexes
+func (r *leiRepository) FuzzySearchLEI(name string, threshold float64, country string, limit int) ([]*domain.LEIFuzzyMatch, error) {
+	var matches []*domain.LEIFuzzyMatch
+
+	err := r.reads.DB().Transaction(func(tx *gorm.DB) error {
+		// The % operator compares against pg_trgm.similarity_threshold; scope it to this transaction
+		if err := tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true)", fmt.Sprintf("%.2f", threshold)).Error; err != nil {
+			return err
+		}
+
+		query := tx.Table(domain.LEIRecord{}.TableName()).
+			Select("*, GREATEST(similarity(legal_name, ?), similarity(coalesce(transliterated_legal_name, ''), ?)) AS similarity", name, name).
+			Where("deleted_at IS NULL").
+			Where("(legal_name % ? OR transliterated_legal_name % ?)", name, name)
+
+		if country != "" {
+			query = query.Where("legal_address_country = ?", country)
+		}
+
+		return query.Order("similarity DESC, legal_name ASC").Limit(limit).Scan(&matches).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LOUFilter narrows LOU listings; zero values match everything
+type LOUFilter struct {
+	Search         string // LEI or part of the name or marketing name
+	AccreditedOnly bool   // leave out LOUs no longer on GLEIF's list
+}
+
+// LOURepository stores GLEIF's LEI issuer list
+type LOURepository interface {
+	Upsert(lous []*domain.LOU) error
+	MarkUnlisted(listed []string) (int64, error)
+	FindByLEI(lei string) (*domain.LOU, error)
+	FindAll(filter LOUFilter, limit, offset int) ([]*domain.LOU, int64, error)
+	Names() (map[string]string, error)
+}
+
+type louRepository struct {
+	db *gorm.DB
+}
+
+// NewLOURepository creates a new LOU repository
+func NewLOURepository(db *gorm.DB) LOURepository {
+	return &louRepository{db: db}
+}
+
+// Upsert inserts or refreshes LOUs by LEI, marking them accredited
+func (r *louRepository) Upsert(lous []*domain.LOU) error {
+	if len(lous) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "lei"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "marketing_name", "website", "accreditation_date", "accredited", "synced_at", "updated_at"}),
+	}).Create(lous).Error
+}
+
+// MarkUnlisted marks the accredited LOUs not in listed as no longer accredited and returns how many
+// there were
+func (r *louRepository) MarkUnlisted(listed []string) (int64, error) {
+	query := r.db.Model(&domain.LOU{}).Where("accredited")
+	if len(listed) > 0 {
+		query = query.Where("lei NOT IN ?", listed)
+	}
+	result := query.Update("accredited", false)
+	return result.RowsAffected, result.Error
+}
+
+// FindByLEI returns the LOU with an LEI
+func (r *louRepository) FindByLEI(lei string) (*domain.LOU, error) {
+	var lou domain.LOU
+	if err := r.db.First(&lou, "lei = ?", lei).Error; err != nil {
+		return nil, err
+	}
+	return &lou, nil
+}
+
+// FindAll lists LOUs in name order
+func (r *louRepository) FindAll(filter LOUFilter, limit, offset int) ([]*domain.LOU, int64, error) {
+	query := r.db.Model(&domain.LOU{})
+	if filter.Search != "" {
+		query = query.Where("lei = ? OR name ILIKE ? OR marketing_name ILIKE ?",
+			strings.ToUpper(filter.Search), "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+	if filter.AccreditedOnly {
+		query = query.Where("accredited")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var lous []*domain.LOU
+	if err := query.Order("name").Limit(limit).Offset(offset).Find(&lous).Error; err != nil {
+		return nil, 0, err
+	}
+	return lous, total, nil
+}
+
+// Names returns the name of every LOU by LEI
+func (r *louRepository) Names() (map[string]string, error) {
+	var rows []struct {
+		LEI  string `gorm:"column:lei"`
+		Name string
+	}
+	if err := r.db.Model(&domain.LOU{}).Select("lei, name").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(rows))
+	for _, row := range rows {
+		names[row.LEI] = row.Name
+	}
+	return names, nil
+}
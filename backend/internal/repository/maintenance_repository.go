@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaintenanceRepository repairs state left behind by interrupted work and refreshes planner
+// statistics, for the admin maintenance endpoints
+type MaintenanceRepository interface {
+	ResetStalledSourceFiles(ctx context.Context, before time.Time, skip []uuid.UUID, category, reason string) ([]domain.SourceFile, error)
+	ResetStalledProcessingStatuses(ctx context.Context, before time.Time, reason string) ([]domain.FileProcessingStatus, error)
+	AnalyzeTable(table string) error
+}
+
+type maintenanceRepository struct {
+	db *gorm.DB
+}
+
+// NewMaintenanceRepository creates a new maintenance repository instance
+func NewMaintenanceRepository(db *gorm.DB) MaintenanceRepository {
+	return &maintenanceRepository{db: db}
+}
+
+// ResetStalledSourceFiles marks IN_PROGRESS source files that have not checkpointed since before
+// as FAILED, leaving out those in skip (files this process is still working on)
+func (r *maintenanceRepository) ResetStalledSourceFiles(ctx context.Context, before time.Time, skip []uuid.UUID, category, reason string) ([]domain.SourceFile, error) {
+	var files []domain.SourceFile
+	query := r.db.WithContext(ctx).Model(&files).Clauses(clause.Returning{}).
+		Where("processing_status = ? AND updated_at < ?", "IN_PROGRESS", before)
+	if len(skip) > 0 {
+		query = query.Where("id NOT IN ?", skip)
+	}
+	err := query.Updates(map[string]interface{}{
+		"processing_status": "FAILED",
+		"failure_category":  category,
+		"processing_error":  reason,
+	}).Error
+	return files, err
+}
+
+// ResetStalledProcessingStatuses returns RUNNING processing statuses unchanged since before to IDLE,
+// unless the file they point at is still IN_PROGRESS
+func (r *maintenanceRepository) ResetStalledProcessingStatuses(ctx context.Context, before time.Time, reason string) ([]domain.FileProcessingStatus, error) {
+	var statuses []domain.FileProcessingStatus
+	err := r.db.WithContext(ctx).Model(&statuses).Clauses(clause.Returning{}).
+		Where("status = ? AND updated_at < ?", "RUNNING", before).
+		Where("current_source_file_id IS NULL OR current_source_file_id NOT IN (?)",
+			r.db.Model(&domain.SourceFile{}).Select("id").Where("processing_status = ?", "IN_PROGRESS")).
+		Updates(map[string]interface{}{
+			"status":                 "IDLE",
+			"current_source_file_id": nil,
+			"error_message":          reason,
+		}).Error
+	return statuses, err
+}
+
+// AnalyzeTable refreshes the planner statistics of one of FreshnessTables, which are also the row
+// estimates the freshness endpoint reports
+func (r *maintenanceRepository) AnalyzeTable(table string) error {
+	if !isFreshnessTable(table) {
+		return fmt.Errorf("table %s is not reported by the freshness endpoint", table)
+	}
+	return r.db.Exec("ANALYZE " + table).Error
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// MasterDataVersionRepository reads the effective-dated versions of master data records. The
+// versions themselves are written by database triggers, never through this repository.
+type MasterDataVersionRepository interface {
+	FindVersions(domainName, recordID string) ([]*domain.MasterDataVersion, error)
+	FindVersionAsOf(domainName, recordID string, asOf time.Time) (*domain.MasterDataVersion, error)
+	FindAllAsOf(domainName string, asOf time.Time, limit, offset int) ([]*domain.MasterDataVersion, int64, error)
+}
+
+type masterDataVersionRepository struct {
+	db *gorm.DB
+}
+
+// NewMasterDataVersionRepository creates a new master data version repository
+func NewMasterDataVersionRepository(db *gorm.DB) MasterDataVersionRepository {
+	return &masterDataVersionRepository{db: db}
+}
+
+// FindVersions returns every version of a record, oldest first
+func (r *masterDataVersionRepository) FindVersions(domainName, recordID string) ([]*domain.MasterDataVersion, error) {
+	var versions []*domain.MasterDataVersion
+	err := r.db.Where("domain = ? AND record_id = ?", domainName, recordID).
+		Order("valid_from").
+		Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// FindVersionAsOf returns the version of a record in effect at asOf
+func (r *masterDataVersionRepository) FindVersionAsOf(domainName, recordID string, asOf time.Time) (*domain.MasterDataVersion, error) {
+	var version domain.MasterDataVersion
+	err := r.effectiveAt(asOf).
+		Where("domain = ? AND record_id = ?", domainName, recordID).
+		First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// FindAllAsOf returns the versions of a domain's records in effect at asOf, i.e. the records as
+// they stood then, in record ID order
+func (r *masterDataVersionRepository) FindAllAsOf(domainName string, asOf time.Time, limit, offset int) ([]*domain.MasterDataVersion, int64, error) {
+	query := r.effectiveAt(asOf).Where("domain = ?", domainName)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var versions []*domain.MasterDataVersion
+	if err := query.Order("record_id").Limit(limit).Offset(offset).Find(&versions).Error; err != nil {
+		return nil, 0, err
+	}
+	return versions, total, nil
+}
+
+// effectiveAt scopes a query to the versions whose validity window contains asOf
+func (r *masterDataVersionRepository) effectiveAt(asOf time.Time) *gorm.DB {
+	return r.db.Model(&domain.MasterDataVersion{}).
+		Where("valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)", asOf, asOf)
+}
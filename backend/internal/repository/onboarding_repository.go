@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
+	"gorm.io/gorm"
+)
+
+// ErrOnboardingStatusChanged is returned when an onboarding moved on between being read and a
+// transition being saved, e.g. when two reviewers act on it at once
+var ErrOnboardingStatusChanged = errors.New("onboarding status changed concurrently")
+
+// OnboardingRepository stores counterparty onboardings and their audit trail
+type OnboardingRepository interface {
+	Create(ctx context.Context, onboarding *domain.Onboarding, event *domain.OnboardingEvent) error
+	FindByID(id string) (*domain.Onboarding, error)
+	FindAll(status string, limit, offset int) ([]*domain.Onboarding, int64, error)
+	FindEntitySSIs(entityID uuid.UUID) ([]*domain.SSI, error)
+	RecordEvent(ctx context.Context, event *domain.OnboardingEvent) error
+	Transition(ctx context.Context, onboarding *domain.Onboarding, from string, event *domain.OnboardingEvent) error
+	Approve(ctx context.Context, onboarding *domain.Onboarding, from string, event *domain.OnboardingEvent) error
+	Reject(ctx context.Context, onboarding *domain.Onboarding, from string, event *domain.OnboardingEvent) error
+	DiscardRecords(ctx context.Context, entityID uuid.UUID) error
+}
+
+type onboardingRepository struct {
+	db      *gorm.DB
+	changes changeEvents
+}
+
+// NewOnboardingRepository creates a new counterparty onboarding repository
+func NewOnboardingRepository(db *gorm.DB, outbox bool) OnboardingRepository {
+	return &onboardingRepository{db: db, changes: changeEvents{enabled: outbox}}
+}
+
+// Create stores a draft onboarding and deactivates its entity, accounts and SSIs until approval
+func (r *onboardingRepository) Create(ctx context.Context, onboarding *domain.Onboarding, event *domain.OnboardingEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Entity", "Events").Create(onboarding).Error; err != nil {
+			return err
+		}
+		event.OnboardingID = onboarding.ID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		return r.setRecordsActive(tx, onboarding.EntityID, false)
+	})
+}
+
+// FindByID returns an onboarding with its entity (even once deleted by a rejection) and audit trail
+func (r *onboardingRepository) FindByID(id string) (*domain.Onboarding, error) {
+	var onboarding domain.Onboarding
+	err := r.db.
+		Preload("Entity", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		First(&onboarding, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &onboarding, nil
+}
+
+// FindAll lists onboardings, optionally in one status, newest first
+func (r *onboardingRepository) FindAll(status string, limit, offset int) ([]*domain.Onboarding, int64, error) {
+	query := r.db.Model(&domain.Onboarding{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var onboardings []*domain.Onboarding
+	err := query.
+		Preload("Entity", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&onboardings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return onboardings, total, nil
+}
+
+// FindEntitySSIs returns the SSIs of an entity with their settlement currency
+func (r *onboardingRepository) FindEntitySSIs(entityID uuid.UUID) ([]*domain.SSI, error) {
+	var ssis []*domain.SSI
+	if err := r.db.Preload("SettlementCurrency").Where("entity_id = ?", entityID).Order("created_at").Find(&ssis).Error; err != nil {
+		return nil, err
+	}
+	return ssis, nil
+}
+
+// RecordEvent audits an action that did not change the onboarding's status
+func (r *onboardingRepository) RecordEvent(ctx context.Context, event *domain.OnboardingEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// Transition moves an onboarding on from status from and audits it
+func (r *onboardingRepository) Transition(ctx context.Context, onboarding *domain.Onboarding, from string, event *domain.OnboardingEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.transition(tx, onboarding, from, event)
+	})
+}
+
+// Approve moves an onboarding to APPROVED and activates its entity, accounts and SSIs
+func (r *onboardingRepository) Approve(ctx context.Context, onboarding *domain.Onboarding, from string, event *domain.OnboardingEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.transition(tx, onboarding, from, event); err != nil {
+			return err
+		}
+		return r.setRecordsActive(tx, onboarding.EntityID, true)
+	})
+}
+
+// Reject moves an onboarding to REJECTED and deletes its entity, accounts and SSIs
+func (r *onboardingRepository) Reject(ctx context.Context, onboarding *domain.Onboarding, from string, event *domain.OnboardingEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.transition(tx, onboarding, from, event); err != nil {
+			return err
+		}
+		return r.discardRecords(tx, onboarding.EntityID)
+	})
+}
+
+// DiscardRecords deletes an entity with its accounts and SSIs, e.g. a draft that failed to be created
+func (r *onboardingRepository) DiscardRecords(ctx context.Context, entityID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.discardRecords(tx, entityID)
+	})
+}
+
+// transition saves the onboarding's new status, provided it is still in from, and the event
+func (r *onboardingRepository) transition(tx *gorm.DB, onboarding *domain.Onboarding, from string, event *domain.OnboardingEvent) error {
+	result := tx.Model(&domain.Onboarding{}).
+		Where("id = ? AND status = ?", onboarding.ID, from).
+		Updates(map[string]any{
+			"status":      onboarding.Status,
+			"approved_by": onboarding.ApprovedBy,
+			"approved_at": onboarding.ApprovedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOnboardingStatusChanged
+	}
+	event.OnboardingID = onboarding.ID
+	return tx.Create(event).Error
+}
+
+// setRecordsActive sets the active flag of an entity and its accounts and SSIs. Updated
+// explicitly because creating a record with active false stores the column default, true.
+func (r *onboardingRepository) setRecordsActive(tx *gorm.DB, entityID uuid.UUID, active bool) error {
+	var entity domain.Entity
+	if err := tx.First(&entity, "id = ?", entityID).Error; err != nil {
+		return err
+	}
+	var accounts []*domain.Account
+	if err := tx.Where("entity_id = ?", entityID).Find(&accounts).Error; err != nil {
+		return err
+	}
+	var ssis []*domain.SSI
+	if err := tx.Where("entity_id = ?", entityID).Find(&ssis).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&domain.Entity{}).Where("id = ?", entityID).Update("active", active).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&domain.Account{}).Where("entity_id = ?", entityID).Update("active", active).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&domain.SSI{}).Where("entity_id = ?", entityID).Update("active", active).Error; err != nil {
+		return err
+	}
+
+	entity.Active = active
+	if err := r.changes.recordChange(tx, events.DomainEntity, events.ActionUpdated, entity.ID, &entity); err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		account.Active = active
+		if err := r.changes.recordChange(tx, events.DomainAccount, events.ActionUpdated, account.ID, account); err != nil {
+			return err
+		}
+	}
+	for _, ssi := range ssis {
+		ssi.Active = active
+		if err := r.changes.recordChange(tx, events.DomainSSI, events.ActionUpdated, ssi.ID, ssi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discardRecords soft-deletes an entity and its accounts and SSIs
+func (r *onboardingRepository) discardRecords(tx *gorm.DB, entityID uuid.UUID) error {
+	var accountIDs, ssiIDs []uuid.UUID
+	if err := tx.Model(&domain.Account{}).Where("entity_id = ?", entityID).Pluck("id", &accountIDs).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&domain.SSI{}).Where("entity_id = ?", entityID).Pluck("id", &ssiIDs).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("entity_id = ?", entityID).Delete(&domain.SSI{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("entity_id = ?", entityID).Delete(&domain.Account{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&domain.Entity{}, "id = ?", entityID).Error; err != nil {
+		return err
+	}
+
+	for _, id := range ssiIDs {
+		if err := r.changes.recordDelete(tx, events.DomainSSI, id.String()); err != nil {
+			return err
+		}
+	}
+	for _, id := range accountIDs {
+		if err := r.changes.recordDelete(tx, events.DomainAccount, id.String()); err != nil {
+			return err
+		}
+	}
+	return r.changes.recordDelete(tx, events.DomainEntity, entityID.String())
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// OutboxRelayLockKey is the advisory lock key of the LeaderLock that lets one replica relay at a
+// time
+const OutboxRelayLockKey int64 = 4827150331
+
+// OutboxRepository reads and maintains the change-event outbox
+type OutboxRepository interface {
+	// FindPending returns up to limit undelivered events, oldest first
+	FindPending(limit int) ([]*domain.OutboxEvent, error)
+	// MarkPublished stamps events as delivered
+	MarkPublished(events []*domain.OutboxEvent) error
+	// MarkFailed counts a failed attempt to publish events and records its error
+	MarkFailed(events []*domain.OutboxEvent, cause error) error
+	// MoveToDeadLetters stores item and removes event from the outbox in one transaction, so the
+	// event is never both dead-lettered and pending
+	MoveToDeadLetters(event *domain.OutboxEvent, item *domain.DeadLetter) error
+	CountPending() (int64, error)
+	DeleteDelivered(before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) FindPending(limit int) ([]*domain.OutboxEvent, error) {
+	var pending []*domain.OutboxEvent
+	err := r.db.
+		Where("published_at IS NULL").
+		Order("seq ASC").
+		Limit(limit).
+		Find(&pending).Error
+	return pending, err
+}
+
+func (r *outboxRepository) MarkPublished(events []*domain.OutboxEvent) error {
+	return r.db.Model(&domain.OutboxEvent{}).Where("id IN ?", eventIDs(events)).Update("published_at", time.Now()).Error
+}
+
+func (r *outboxRepository) MarkFailed(events []*domain.OutboxEvent, cause error) error {
+	return r.db.Model(&domain.OutboxEvent{}).Where("id IN ?", eventIDs(events)).Updates(map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": cause.Error(),
+	}).Error
+}
+
+func (r *outboxRepository) MoveToDeadLetters(event *domain.OutboxEvent, item *domain.DeadLetter) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		return tx.Delete(event).Error
+	})
+}
+
+func eventIDs(events []*domain.OutboxEvent) []uuid.UUID {
+	ids := make([]uuid.UUID, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+func (r *outboxRepository) CountPending() (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.OutboxEvent{}).Where("published_at IS NULL").Count(&count).Error
+	return count, err
+}
+
+// DeleteDelivered removes events delivered before the cutoff
+func (r *outboxRepository) DeleteDelivered(before time.Time) (int64, error) {
+	result := r.db.Where("published_at < ?", before).Delete(&domain.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
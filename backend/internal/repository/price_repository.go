@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InstrumentPriceRepository stores reference prices
+type InstrumentPriceRepository interface {
+	BulkUpsert(ctx context.Context, prices []*domain.InstrumentPrice) error
+	FindLatest(instrumentID uuid.UUID, source string, asOf time.Time) (*domain.InstrumentPrice, error)
+}
+
+type instrumentPriceRepository struct {
+	db *gorm.DB
+}
+
+// NewInstrumentPriceRepository creates a new instrument price repository instance
+func NewInstrumentPriceRepository(db *gorm.DB) InstrumentPriceRepository {
+	return &instrumentPriceRepository{db: db}
+}
+
+// BulkUpsert inserts prices, replacing the price for an existing (instrument, date, source). A
+// price repeated in prices replaces the earlier one, as it would in a later call; Postgres cannot
+// upsert the same row twice in one statement. All prices are stored, or none.
+func (r *instrumentPriceRepository) BulkUpsert(ctx context.Context, prices []*domain.InstrumentPrice) error {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	type priceKey struct {
+		instrumentID uuid.UUID
+		date         string
+		source       string
+	}
+	latest := make(map[priceKey]int, len(prices))
+	unique := make([]*domain.InstrumentPrice, 0, len(prices))
+	for _, price := range prices {
+		key := priceKey{price.InstrumentID, price.PriceDate.Format("2006-01-02"), price.Source}
+		if i, ok := latest[key]; ok {
+			unique[i] = price
+			continue
+		}
+		latest[key] = len(unique)
+		unique = append(unique, price)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "instrument_id"}, {Name: "price_date"}, {Name: "source"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+			DoUpdates:   clause.AssignmentColumns([]string{"price", "currency_id", "updated_at"}),
+		}).CreateInBatches(unique, 500).Error
+	})
+}
+
+// FindLatest returns the most recent price on or before asOf, optionally restricted to one source
+func (r *instrumentPriceRepository) FindLatest(instrumentID uuid.UUID, source string, asOf time.Time) (*domain.InstrumentPrice, error) {
+	var price domain.InstrumentPrice
+	query := r.db.Preload("Currency").
+		Where("instrument_id = ? AND price_date <= ?", instrumentID, asOf)
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	if err := query.Order("price_date DESC, updated_at DESC").First(&price).Error; err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// ReadReplicas routes the heavy read queries (LEI lists, searches and counts, and exports) to read
+// replicas in turn. Writes, sync jobs and reads that must see their own writes stay on the primary,
+// as do heavy reads while no replica is reachable.
+type ReadReplicas struct {
+	primary  *gorm.DB
+	replicas []*readReplica
+	next     atomic.Uint64
+}
+
+type readReplica struct {
+	name    string
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// NewReadReplicas creates a router over replicas, keyed by a name to log them by (their host, not
+// their DSN, which may hold a password); with none, every read goes to primary. Replicas are
+// taken to be reachable until Check finds otherwise.
+func NewReadReplicas(primary *gorm.DB, replicas map[string]*gorm.DB) *ReadReplicas {
+	r := &ReadReplicas{primary: primary}
+	for name, db := range replicas {
+		replica := &readReplica{name: name, db: db}
+		replica.healthy.Store(true)
+		r.replicas = append(r.replicas, replica)
+	}
+	return r
+}
+
+// DB returns the database for a heavy read: the next reachable replica, else the primary
+func (r *ReadReplicas) DB() *gorm.DB {
+	n := len(r.replicas)
+	if n == 0 {
+		return r.primary
+	}
+	start := r.next.Add(1)
+	for i := 0; i < n; i++ {
+		replica := r.replicas[(start+uint64(i))%uint64(n)]
+		if replica.healthy.Load() {
+			return replica.db
+		}
+	}
+	return r.primary
+}
+
+// Count returns the number of replicas and how many of them are in rotation
+func (r *ReadReplicas) Count() (total, healthy int) {
+	for _, replica := range r.replicas {
+		if replica.healthy.Load() {
+			healthy++
+		}
+	}
+	return len(r.replicas), healthy
+}
+
+// Check pings every replica, taking those that do not answer out of rotation and returning those
+// that do
+func (r *ReadReplicas) Check(ctx context.Context) {
+	for _, replica := range r.replicas {
+		err := ping(ctx, replica.db)
+		wasHealthy := replica.healthy.Swap(err == nil)
+		switch {
+		case err != nil && wasHealthy:
+			log.Warn().Err(err).Str("replica", replica.name).Msg("Read replica unreachable, reading from the others or the primary")
+		case err == nil && !wasHealthy:
+			log.Info().Str("replica", replica.name).Msg("Read replica reachable again")
+		}
+	}
+}
+
+// Close closes the replicas' connection pools
+func (r *ReadReplicas) Close() error {
+	var firstErr error
+	for _, replica := range r.replicas {
+		sqlDB, err := replica.db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RegistrationAuthorityFilter narrows registration authority listings; zero values match everything
+type RegistrationAuthorityFilter struct {
+	Country      string // ISO 3166-1 alpha-2 code
+	Jurisdiction string // part of the jurisdiction
+	Search       string // RA code or part of a register or organization name
+}
+
+// RegistrationAuthoritySummary is what LEI responses show of a registration authority
+type RegistrationAuthoritySummary struct {
+	Code         string
+	Name         string
+	Jurisdiction string
+}
+
+// RegistrationAuthorityRepository stores the GLEIF RA list
+type RegistrationAuthorityRepository interface {
+	Upsert(authorities []*domain.RegistrationAuthority) error
+	FindByCode(code string) (*domain.RegistrationAuthority, error)
+	FindAll(filter RegistrationAuthorityFilter, limit, offset int) ([]*domain.RegistrationAuthority, int64, error)
+	Summaries() (map[string]RegistrationAuthoritySummary, error)
+}
+
+type registrationAuthorityRepository struct {
+	db *gorm.DB
+}
+
+// NewRegistrationAuthorityRepository creates a new registration authority repository
+func NewRegistrationAuthorityRepository(db *gorm.DB) RegistrationAuthorityRepository {
+	return &registrationAuthorityRepository{db: db}
+}
+
+// Upsert inserts or refreshes registration authorities by code
+func (r *registrationAuthorityRepository) Upsert(authorities []*domain.RegistrationAuthority) error {
+	if len(authorities) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"country_code", "country", "jurisdiction", "register_name",
+			"register_local_name", "organization_name", "organization_local_name", "website", "name", "imported_at", "updated_at"}),
+	}).CreateInBatches(authorities, 1000).Error
+}
+
+// FindByCode returns a registration authority
+func (r *registrationAuthorityRepository) FindByCode(code string) (*domain.RegistrationAuthority, error) {
+	var authority domain.RegistrationAuthority
+	if err := r.db.First(&authority, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &authority, nil
+}
+
+// FindAll lists registration authorities in code order
+func (r *registrationAuthorityRepository) FindAll(filter RegistrationAuthorityFilter, limit, offset int) ([]*domain.RegistrationAuthority, int64, error) {
+	query := r.db.Model(&domain.RegistrationAuthority{})
+	if filter.Country != "" {
+		query = query.Where("country_code = ?", filter.Country)
+	}
+	if filter.Jurisdiction != "" {
+		query = query.Where("jurisdiction ILIKE ?", "%"+filter.Jurisdiction+"%")
+	}
+	if filter.Search != "" {
+		pattern := "%" + filter.Search + "%"
+		query = query.Where("code = ? OR register_name ILIKE ? OR register_local_name ILIKE ? OR organization_name ILIKE ? OR organization_local_name ILIKE ?",
+			strings.ToUpper(filter.Search), pattern, pattern, pattern, pattern)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var authorities []*domain.RegistrationAuthority
+	if err := query.Order("code").Limit(limit).Offset(offset).Find(&authorities).Error; err != nil {
+		return nil, 0, err
+	}
+	return authorities, total, nil
+}
+
+// Summaries returns the name and jurisdiction of every registration authority by code
+func (r *registrationAuthorityRepository) Summaries() (map[string]RegistrationAuthoritySummary, error) {
+	var rows []RegistrationAuthoritySummary
+	if err := r.db.Model(&domain.RegistrationAuthority{}).Select("code, name, COALESCE(NULLIF(jurisdiction, ''), country) AS jurisdiction").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	summaries := make(map[string]RegistrationAuthoritySummary, len(rows))
+	for _, row := range rows {
+		summaries[row.Code] = row
+	}
+	return summaries, nil
+}
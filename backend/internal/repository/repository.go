@@ -1,53 +1,140 @@
 package repository
 
 import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/cache"
 	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
+	"github.com/techie2000/axiom/internal/fieldcrypt"
+	"github.com/techie2000/axiom/internal/query"
 	"gorm.io/gorm"
 )
 
 // Repositories holds all repository interfaces
 type Repositories struct {
-	Country    CountryRepository
-	Currency   CurrencyRepository
-	Entity     EntityRepository
-	Instrument InstrumentRepository
-	Account    AccountRepository
-	SSI        SSIRepository
-	LEI        LEIRepository
+	Country      CountryRepository
+	Currency     CurrencyRepository
+	Entity       EntityRepository
+	Instrument   InstrumentRepository
+	Account      AccountRepository
+	SSI          SSIRepository
+	LEI          LEIRepository
+	Freshness    FreshnessRepository
+	Maintenance  MaintenanceRepository
+	Price        InstrumentPriceRepository
+	DeadLetter   DeadLetterRepository
+	Schema       SchemaRepository
+	DQFinding    DQFindingRepository
+	Cascade      ReferenceCascadeRepository
+	LEIException LEIExceptionRepository
+	SavedView    SavedViewRepository
+	Export       ScheduledExportRepository
+	IDSequence   IDSequenceRepository
+	Integrity    IntegrityRepository
+	Retention    AuditRetentionRepository
+	Outbox       OutboxRepository
+	Onboarding   OnboardingRepository
+	Versions     MasterDataVersionRepository
+	BIC          BICRepository
+	SSIDefaults  SSIDefaultRuleRepository
+	Exchange     ExchangeRepository
+	ELF          ELFRepository
+	RA           RegistrationAuthorityRepository
+	LOU          LOURepository
+	Calendar     CalendarRepository
+	FXRate       FXRateRepository
+	ImportJob    ImportJobRepository
+	DataExport   DataExportRepository
+}
+
+// Options configures repository behaviour
+type Options struct {
+	LEIAuditMode string        // LEIAuditModeSnapshot or LEIAuditModeDiff
+	ChangeEvents bool          // write LEI and master-data change events to the outbox
+	ReadReplicas *ReadReplicas // LEI lists, searches and exports; nil reads them from the primary
+	Cache        cache.Cache   // LEI lookups and reference lists; nil caches nothing
+	CacheTTL     CacheTTL
 }
 
 // NewRepositories creates a new repositories instance
-func NewRepositories(db *gorm.DB) *Repositories {
-	return &Repositories{
-		Country:    NewCountryRepository(db),
-		Currency:   NewCurrencyRepository(db),
-		Entity:     NewEntityRepository(db),
-		Instrument: NewInstrumentRepository(db),
-		Account:    NewAccountRepository(db),
-		SSI:        NewSSIRepository(db),
-		LEI:        NewLEIRepository(db),
+func NewRepositories(db *gorm.DB, opts Options) *Repositories {
+	reads := opts.ReadReplicas
+	if reads == nil {
+		reads = NewReadReplicas(db, nil)
+	}
+	repos := &Repositories{
+		Country:      NewCountryRepository(db, opts.ChangeEvents),
+		Currency:     NewCurrencyRepository(db, opts.ChangeEvents),
+		Entity:       NewEntityRepository(db, opts.ChangeEvents),
+		Instrument:   NewInstrumentRepository(db, opts.ChangeEvents),
+		Account:      NewAccountRepository(db, opts.ChangeEvents),
+		SSI:          NewSSIRepository(db, opts.ChangeEvents),
+		LEI:          NewLEIRepository(db, reads, opts.LEIAuditMode, opts.ChangeEvents),
+		Freshness:    NewFreshnessRepository(db),
+		Maintenance:  NewMaintenanceRepository(db),
+		Price:        NewInstrumentPriceRepository(db),
+		DeadLetter:   NewDeadLetterRepository(db),
+		Schema:       NewSchemaRepository(db),
+		DQFinding:    NewDQFindingRepository(db),
+		Cascade:      NewReferenceCascadeRepository(db),
+		LEIException: NewLEIExceptionRepository(db),
+		SavedView:    NewSavedViewRepository(db),
+		Export:       NewScheduledExportRepository(db),
+		IDSequence:   NewIDSequenceRepository(db),
+		Integrity:    NewIntegrityRepository(db),
+		Retention:    NewAuditRetentionRepository(db),
+		Outbox:       NewOutboxRepository(db),
+		Onboarding:   NewOnboardingRepository(db, opts.ChangeEvents),
+		Versions:     NewMasterDataVersionRepository(db),
+		BIC:          NewBICRepository(db),
+		SSIDefaults:  NewSSIDefaultRuleRepository(db),
+		Exchange:     NewExchangeRepository(db),
+		ELF:          NewELFRepository(db),
+		RA:           NewRegistrationAuthorityRepository(db),
+		LOU:          NewLOURepository(db),
+		Calendar:     NewCalendarRepository(db),
+		FXRate:       NewFXRateRepository(db),
+		ImportJob:    NewImportJobRepository(db),
+		DataExport:   NewDataExportRepository(reads),
+	}
+	if opts.Cache != nil {
+		repos.LEI = newCachedLEIRepository(repos.LEI, opts.Cache, opts.CacheTTL.LEI)
+		repos.Country = newCachedCountryRepository(repos.Country, opts.Cache, opts.CacheTTL.Reference)
+		repos.Currency = newCachedCurrencyRepository(repos.Currency, opts.Cache, opts.CacheTTL.Reference)
 	}
+	return repos
 }
 
 // CountryRepository interface
 type CountryRepository interface {
-	Create(country *domain.Country) error
+	Create(ctx context.Context, country *domain.Country) error
 	FindByID(id string) (*domain.Country, error)
+	FindByCode(code string) (*domain.Country, error)
 	FindAll(limit, offset int) ([]*domain.Country, error)
-	Update(country *domain.Country) error
-	Delete(id string) error
+	FindAllIncludingDeleted() ([]*domain.Country, error)
+	Update(ctx context.Context, country *domain.Country) error
+	Delete(ctx context.Context, id string) error
 }
 
 type countryRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	changes changeEvents
 }
 
-func NewCountryRepository(db *gorm.DB) CountryRepository {
-	return &countryRepository{db: db}
+func NewCountryRepository(db *gorm.DB, outbox bool) CountryRepository {
+	return &countryRepository{db: db, changes: changeEvents{enabled: outbox}}
 }
 
-func (r *countryRepository) Create(country *domain.Country) error {
-	return r.db.Create(country).Error
+func (r *countryRepository) Create(ctx context.Context, country *domain.Country) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(country).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainCountry, events.ActionCreated, country.ID, country)
+	})
 }
 
 func (r *countryRepository) FindByID(id string) (*domain.Country, error) {
@@ -58,6 +145,14 @@ func (r *countryRepository) FindByID(id string) (*domain.Country, error) {
 	return &country, nil
 }
 
+func (r *countryRepository) FindByCode(code string) (*domain.Country, error) {
+	var country domain.Country
+	if err := r.db.First(&country, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &country, nil
+}
+
 func (r *countryRepository) FindAll(limit, offset int) ([]*domain.Country, error) {
 	var countries []*domain.Country
 	if err := r.db.Limit(limit).Offset(offset).Find(&countries).Error; err != nil {
@@ -66,33 +161,61 @@ func (r *countryRepository) FindAll(limit, offset int) ([]*domain.Country, error
 	return countries, nil
 }
 
-func (r *countryRepository) Update(country *domain.Country) error {
-	return r.db.Save(country).Error
+// FindAllIncludingDeleted returns every country, soft-deleted ones too, in code order
+func (r *countryRepository) FindAllIncludingDeleted() ([]*domain.Country, error) {
+	var countries []*domain.Country
+	if err := r.db.Unscoped().Order("code").Find(&countries).Error; err != nil {
+		return nil, err
+	}
+	return countries, nil
 }
 
-func (r *countryRepository) Delete(id string) error {
-	return r.db.Delete(&domain.Country{}, "id = ?", id).Error
+func (r *countryRepository) Update(ctx context.Context, country *domain.Country) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(country).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainCountry, events.ActionUpdated, country.ID, country)
+	})
+}
+
+func (r *countryRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Country{}, "id = ?", id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.changes.recordDelete(tx, events.DomainCountry, id)
+	})
 }
 
 // CurrencyRepository interface
 type CurrencyRepository interface {
-	Create(currency *domain.Currency) error
+	Create(ctx context.Context, currency *domain.Currency) error
 	FindByID(id string) (*domain.Currency, error)
+	FindByCode(code string) (*domain.Currency, error)
 	FindAll(limit, offset int) ([]*domain.Currency, error)
-	Update(currency *domain.Currency) error
-	Delete(id string) error
+	FindAllIncludingDeleted() ([]*domain.Currency, error)
+	Update(ctx context.Context, currency *domain.Currency) error
+	Delete(ctx context.Context, id string) error
 }
 
 type currencyRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	changes changeEvents
 }
 
-func NewCurrencyRepository(db *gorm.DB) CurrencyRepository {
-	return &currencyRepository{db: db}
+func NewCurrencyRepository(db *gorm.DB, outbox bool) CurrencyRepository {
+	return &currencyRepository{db: db, changes: changeEvents{enabled: outbox}}
 }
 
-func (r *currencyRepository) Create(currency *domain.Currency) error {
-	return r.db.Create(currency).Error
+func (r *currencyRepository) Create(ctx context.Context, currency *domain.Currency) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(currency).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainCurrency, events.ActionCreated, currency.ID, currency)
+	})
 }
 
 func (r *currencyRepository) FindByID(id string) (*domain.Currency, error) {
@@ -103,6 +226,14 @@ func (r *currencyRepository) FindByID(id string) (*domain.Currency, error) {
 	return &currency, nil
 }
 
+func (r *currencyRepository) FindByCode(code string) (*domain.Currency, error) {
+	var currency domain.Currency
+	if err := r.db.First(&currency, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &currency, nil
+}
+
 func (r *currencyRepository) FindAll(limit, offset int) ([]*domain.Currency, error) {
 	var currencies []*domain.Currency
 	if err := r.db.Limit(limit).Offset(offset).Find(&currencies).Error; err != nil {
@@ -111,40 +242,81 @@ func (r *currencyRepository) FindAll(limit, offset int) ([]*domain.Currency, err
 	return currencies, nil
 }
 
-func (r *currencyRepository) Update(currency *domain.Currency) error {
-	return r.db.Save(currency).Error
+// FindAllIncludingDeleted returns every currency, soft-deleted ones too, in code order
+func (r *currencyRepository) FindAllIncludingDeleted() ([]*domain.Currency, error) {
+	var currencies []*domain.Currency
+	if err := r.db.Unscoped().Order("code").Find(&currencies).Error; err != nil {
+		return nil, err
+	}
+	return currencies, nil
+}
+
+func (r *currencyRepository) Update(ctx context.Context, currency *domain.Currency) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(currency).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainCurrency, events.ActionUpdated, currency.ID, currency)
+	})
 }
 
-func (r *currencyRepository) Delete(id string) error {
-	return r.db.Delete(&domain.Currency{}, "id = ?", id).Error
+func (r *currencyRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Currency{}, "id = ?", id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.changes.recordDelete(tx, events.DomainCurrency, id)
+	})
 }
 
 // Additional repository implementations for Entity, Instrument, Account, SSI
 // (Following same pattern as above)
 
 type EntityRepository interface {
-	Create(entity *domain.Entity) error
+	Create(ctx context.Context, entity *domain.Entity) error
 	FindByID(id string) (*domain.Entity, error)
 	FindAll(limit, offset int) ([]*domain.Entity, error)
-	Update(entity *domain.Entity) error
-	Delete(id string) error
+	FindAllWithFilters(q *query.ListQuery) ([]*domain.Entity, error)
+	Update(ctx context.Context, entity *domain.Entity) error
+	Delete(ctx context.Context, id string) error
+
+	// Enrichment from the LEI store
+	FindIDsWithLEI() ([]uuid.UUID, error)
+	SaveEnrichment(entity *domain.Entity, registered *domain.EntityAddress, provenance []*domain.EntityFieldProvenance) error
+	DeleteFieldProvenance(entityID uuid.UUID, fields []string) error
+
+	// Duplicate detection and merge
+	FindDuplicateCandidates(nameThreshold float64) ([]*EntityDuplicateCandidate, error)
+	SaveDuplicateScan(found []*domain.EntityDuplicate, scannedAt time.Time) (int, error)
+	FindDuplicates(filter EntityDuplicateFilter, limit, offset int) ([]*domain.EntityDuplicate, int64, error)
+	FindDuplicateByID(id string) (*domain.EntityDuplicate, error)
+	UpdateDuplicate(duplicate *domain.EntityDuplicate) error
+	MergeEntities(ctx context.Context, survivor *domain.Entity, mergedID uuid.UUID, merge *domain.EntityMerge) error
+	FindMerges(entityID string, limit, offset int) ([]*domain.EntityMerge, int64, error)
 }
 
 type entityRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	changes changeEvents
 }
 
-func NewEntityRepository(db *gorm.DB) EntityRepository {
-	return &entityRepository{db: db}
+func NewEntityRepository(db *gorm.DB, outbox bool) EntityRepository {
+	return &entityRepository{db: db, changes: changeEvents{enabled: outbox}}
 }
 
-func (r *entityRepository) Create(entity *domain.Entity) error {
-	return r.db.Create(entity).Error
+func (r *entityRepository) Create(ctx context.Context, entity *domain.Entity) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entity).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainEntity, events.ActionCreated, entity.ID, entity)
+	})
 }
 
 func (r *entityRepository) FindByID(id string) (*domain.Entity, error) {
 	var entity domain.Entity
-	if err := r.db.Preload("Addresses").Preload("Addresses.Address").Preload("Addresses.Address.Country").First(&entity, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("Addresses").Preload("Addresses.Address").Preload("Addresses.Address.Country").Preload("AutoFilled").First(&entity, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &entity, nil
@@ -158,33 +330,73 @@ func (r *entityRepository) FindAll(limit, offset int) ([]*domain.Entity, error)
 	return entities, nil
 }
 
-func (r *entityRepository) Update(entity *domain.Entity) error {
-	return r.db.Save(entity).Error
+// entityFilterFields lists the fields accepted by the list endpoint's filter DSL
+var entityFilterFields = query.FieldSet{
+	"name":                {Column: "name", Type: query.String},
+	"registration_number": {Column: "registration_number", Type: query.String},
+	"type":                {Column: "type", Type: query.String},
+	"active":              {Column: "active", Type: query.Bool},
+	"created_at":          {Column: "created_at", Type: query.Date},
+	"updated_at":          {Column: "updated_at", Type: query.Date},
 }
 
-func (r *entityRepository) Delete(id string) error {
-	return r.db.Delete(&domain.Entity{}, "id = ?", id).Error
+func (r *entityRepository) FindAllWithFilters(q *query.ListQuery) ([]*domain.Entity, error) {
+	var entities []*domain.Entity
+	db, err := query.Apply(r.db.Preload("Addresses").Preload("Addresses.Address"), q, entityFilterFields, "created_at")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *entityRepository) Update(ctx context.Context, entity *domain.Entity) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(entity).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainEntity, events.ActionUpdated, entity.ID, entity)
+	})
+}
+
+func (r *entityRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Entity{}, "id = ?", id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.changes.recordDelete(tx, events.DomainEntity, id)
+	})
 }
 
 // InstrumentRepository interface
 type InstrumentRepository interface {
-	Create(instrument *domain.Instrument) error
+	Create(ctx context.Context, instrument *domain.Instrument) error
 	FindByID(id string) (*domain.Instrument, error)
 	FindAll(limit, offset int) ([]*domain.Instrument, error)
-	Update(instrument *domain.Instrument) error
-	Delete(id string) error
+	FindAllWithFilters(q *query.ListQuery) ([]*domain.Instrument, error)
+	Update(ctx context.Context, instrument *domain.Instrument) error
+	Delete(ctx context.Context, id string) error
 }
 
 type instrumentRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	changes changeEvents
 }
 
-func NewInstrumentRepository(db *gorm.DB) InstrumentRepository {
-	return &instrumentRepository{db: db}
+func NewInstrumentRepository(db *gorm.DB, outbox bool) InstrumentRepository {
+	return &instrumentRepository{db: db, changes: changeEvents{enabled: outbox}}
 }
 
-func (r *instrumentRepository) Create(instrument *domain.Instrument) error {
-	return r.db.Create(instrument).Error
+func (r *instrumentRepository) Create(ctx context.Context, instrument *domain.Instrument) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(instrument).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainInstrument, events.ActionCreated, instrument.ID, instrument)
+	})
 }
 
 func (r *instrumentRepository) FindByID(id string) (*domain.Instrument, error) {
@@ -203,33 +415,75 @@ func (r *instrumentRepository) FindAll(limit, offset int) ([]*domain.Instrument,
 	return instruments, nil
 }
 
-func (r *instrumentRepository) Update(instrument *domain.Instrument) error {
-	return r.db.Save(instrument).Error
+// instrumentFilterFields lists the fields accepted by the list endpoint's filter DSL
+var instrumentFilterFields = query.FieldSet{
+	"name":              {Column: "name", Type: query.String},
+	"type":              {Column: "type", Type: query.String},
+	"cfi":               {Column: "cfi", Type: query.String},
+	"issue_currency_id": {Column: "issue_currency_id", Type: query.UUID},
+	"primary_exchange":  {Column: "primary_exchange", Type: query.String},
+	"active":            {Column: "active", Type: query.Bool},
+	"created_at":        {Column: "created_at", Type: query.Date},
+	"updated_at":        {Column: "updated_at", Type: query.Date},
 }
 
-func (r *instrumentRepository) Delete(id string) error {
-	return r.db.Delete(&domain.Instrument{}, "id = ?", id).Error
+func (r *instrumentRepository) FindAllWithFilters(q *query.ListQuery) ([]*domain.Instrument, error) {
+	var instruments []*domain.Instrument
+	db, err := query.Apply(r.db.Preload("IssueCurrency").Preload("Codes"), q, instrumentFilterFields, "created_at")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Find(&instruments).Error; err != nil {
+		return nil, err
+	}
+	return instruments, nil
+}
+
+func (r *instrumentRepository) Update(ctx context.Context, instrument *domain.Instrument) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(instrument).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainInstrument, events.ActionUpdated, instrument.ID, instrument)
+	})
+}
+
+func (r *instrumentRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Instrument{}, "id = ?", id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.changes.recordDelete(tx, events.DomainInstrument, id)
+	})
 }
 
 // AccountRepository interface
 type AccountRepository interface {
-	Create(account *domain.Account) error
+	Create(ctx context.Context, account *domain.Account) error
 	FindByID(id string) (*domain.Account, error)
 	FindAll(limit, offset int) ([]*domain.Account, error)
-	Update(account *domain.Account) error
-	Delete(id string) error
+	FindAllWithFilters(q *query.ListQuery) ([]*domain.Account, error)
+	Update(ctx context.Context, account *domain.Account) error
+	Delete(ctx context.Context, id string) error
 }
 
 type accountRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	changes changeEvents
 }
 
-func NewAccountRepository(db *gorm.DB) AccountRepository {
-	return &accountRepository{db: db}
+func NewAccountRepository(db *gorm.DB, outbox bool) AccountRepository {
+	return &accountRepository{db: db, changes: changeEvents{enabled: outbox}}
 }
 
-func (r *accountRepository) Create(account *domain.Account) error {
-	return r.db.Create(account).Error
+func (r *accountRepository) Create(ctx context.Context, account *domain.Account) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainAccount, events.ActionCreated, account.ID, account)
+	})
 }
 
 func (r *accountRepository) FindByID(id string) (*domain.Account, error) {
@@ -248,33 +502,81 @@ func (r *accountRepository) FindAll(limit, offset int) ([]*domain.Account, error
 	return accounts, nil
 }
 
-func (r *accountRepository) Update(account *domain.Account) error {
-	return r.db.Save(account).Error
+// accountFilterFields lists the fields accepted by the list endpoint's filter DSL
+var accountFilterFields = query.FieldSet{
+	"account_number":      {Column: "account_number", Type: query.String},
+	"entity_id":           {Column: "entity_id", Type: query.UUID},
+	"account_currency_id": {Column: "account_currency_id", Type: query.UUID},
+	"type":                {Column: "type", Type: query.String},
+	"balance":             {Column: "balance", Type: query.Number},
+	"opened_at":           {Column: "opened_at", Type: query.Date},
+	"active":              {Column: "active", Type: query.Bool},
+	"created_at":          {Column: "created_at", Type: query.Date},
+	"updated_at":          {Column: "updated_at", Type: query.Date},
+}
+
+func (r *accountRepository) FindAllWithFilters(q *query.ListQuery) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	db, err := query.Apply(r.db.Preload("Entity").Preload("AccountCurrency"), q, accountFilterFields, "created_at")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *accountRepository) Update(ctx context.Context, account *domain.Account) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(account).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainAccount, events.ActionUpdated, account.ID, account)
+	})
 }
 
-func (r *accountRepository) Delete(id string) error {
-	return r.db.Delete(&domain.Account{}, "id = ?", id).Error
+func (r *accountRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Account{}, "id = ?", id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.changes.recordDelete(tx, events.DomainAccount, id)
+	})
 }
 
 // SSIRepository interface
 type SSIRepository interface {
-	Create(ssi *domain.SSI) error
+	Create(ctx context.Context, ssi *domain.SSI) error
 	FindByID(id string) (*domain.SSI, error)
 	FindAll(limit, offset int) ([]*domain.SSI, error)
-	Update(ssi *domain.SSI) error
-	Delete(id string) error
+	FindAllWithFilters(q *query.ListQuery) ([]*domain.SSI, error)
+	FindEffective(filter SSIEffectiveFilter, asOf time.Time) ([]*domain.SSI, error)
+	FindOverlapping(ssi *domain.SSI) ([]*domain.SSI, error)
+	FindConflicts(limit, offset int) ([]*domain.SSIConflict, int64, error)
+	FindForExport(id string) (*domain.SSI, error)
+	Update(ctx context.Context, ssi *domain.SSI) error
+	Delete(ctx context.Context, id string) error
+	ReencryptAccounts(c *fieldcrypt.Cipher) (SSIReencryption, error)
 }
 
 type ssiRepository struct {
-	db *gorm.DB
+	db      *gorm.DB
+	changes changeEvents
 }
 
-func NewSSIRepository(db *gorm.DB) SSIRepository {
-	return &ssiRepository{db: db}
+func NewSSIRepository(db *gorm.DB, outbox bool) SSIRepository {
+	return &ssiRepository{db: db, changes: changeEvents{enabled: outbox}}
 }
 
-func (r *ssiRepository) Create(ssi *domain.SSI) error {
-	return r.db.Create(ssi).Error
+func (r *ssiRepository) Create(ctx context.Context, ssi *domain.SSI) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(ssi).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainSSI, events.ActionCreated, ssi.ID, ssi)
+	})
 }
 
 func (r *ssiRepository) FindByID(id string) (*domain.SSI, error) {
@@ -293,10 +595,49 @@ func (r *ssiRepository) FindAll(limit, offset int) ([]*domain.SSI, error) {
 	return ssis, nil
 }
 
-func (r *ssiRepository) Update(ssi *domain.SSI) error {
-	return r.db.Save(ssi).Error
+// ssiFilterFields lists the fields accepted by the list endpoint's filter DSL
+var ssiFilterFields = query.FieldSet{
+	"entity_id":              {Column: "entity_id", Type: query.UUID},
+	"settlement_currency_id": {Column: "settlement_currency_id", Type: query.UUID},
+	"instrument_id":          {Column: "instrument_id", Type: query.UUID},
+	"beneficiary_name":       {Column: "beneficiary_name", Type: query.String},
+	"beneficiary_bank":       {Column: "beneficiary_bank", Type: query.String},
+	"beneficiary_bank_bic":   {Column: "beneficiary_bank_bic", Type: query.String},
+	"settlement_type":        {Column: "settlement_type", Type: query.String},
+	"valid_from":             {Column: "valid_from", Type: query.Date},
+	"valid_to":               {Column: "valid_to", Type: query.Date},
+	"active":                 {Column: "active", Type: query.Bool},
+	"created_at":             {Column: "created_at", Type: query.Date},
+	"updated_at":             {Column: "updated_at", Type: query.Date},
+}
+
+func (r *ssiRepository) FindAllWithFilters(q *query.ListQuery) ([]*domain.SSI, error) {
+	var ssis []*domain.SSI
+	db, err := query.Apply(r.db.Preload("Entity").Preload("SettlementCurrency").Preload("Instrument"), q, ssiFilterFields, "created_at")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Find(&ssis).Error; err != nil {
+		return nil, err
+	}
+	return ssis, nil
+}
+
+func (r *ssiRepository) Update(ctx context.Context, ssi *domain.SSI) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(ssi).Error; err != nil {
+			return err
+		}
+		return r.changes.recordChange(tx, events.DomainSSI, events.ActionUpdated, ssi.ID, ssi)
+	})
 }
 
-func (r *ssiRepository) Delete(id string) error {
-	return r.db.Delete(&domain.SSI{}, "id = ?", id).Error
+func (r *ssiRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.SSI{}, "id = ?", id)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return r.changes.recordDelete(tx, events.DomainSSI, id)
+	})
 }
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SchemaRepository reads column definitions from GORM models and from the live database
+type SchemaRepository interface {
+	ModelColumns(model interface{}) (table string, columns []domain.ColumnDefinition, err error)
+	TableColumns(table string) (columns []domain.ColumnDefinition, exists bool, err error)
+}
+
+type schemaRepository struct {
+	db    *gorm.DB
+	cache *sync.Map
+}
+
+// NewSchemaRepository creates a new schema repository instance
+func NewSchemaRepository(db *gorm.DB) SchemaRepository {
+	return &schemaRepository{db: db, cache: &sync.Map{}}
+}
+
+// ModelColumns parses a GORM model and returns its table name and persisted columns
+// Relations and fields excluded from migration (gorm:"-" / "-:migration") are skipped
+func (r *schemaRepository) ModelColumns(model interface{}) (string, []domain.ColumnDefinition, error) {
+	s, err := schema.Parse(model, r.cache, r.db.NamingStrategy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns := make([]domain.ColumnDefinition, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		if field.DBName == "" || field.IgnoreMigration {
+			continue
+		}
+		col := domain.ColumnDefinition{Name: field.DBName, DataType: string(field.DataType)}
+		if field.DataType == schema.String {
+			col.Size = field.Size
+		}
+		columns = append(columns, col)
+	}
+	return s.Table, columns, nil
+}
+
+// TableColumns returns the live column definitions for a (optionally schema-qualified) table
+func (r *schemaRepository) TableColumns(table string) ([]domain.ColumnDefinition, bool, error) {
+	schemaName, relname := "public", table
+	if idx := strings.Index(table, "."); idx > 0 {
+		schemaName, relname = table[:idx], table[idx+1:]
+	}
+
+	var rows []struct {
+		ColumnName             string
+		DataType               string
+		CharacterMaximumLength *int
+	}
+	err := r.db.Raw(`
+		SELECT column_name, data_type, character_maximum_length
+		FROM information_schema.columns
+		WHERE table_schema = ? AND table_name = ?
+		ORDER BY ordinal_position`, schemaName, relname).Scan(&rows).Error
+	if err != nil {
+		return nil, false, err
+	}
+	if len(rows) == 0 {
+		return nil, false, nil
+	}
+
+	columns := make([]domain.ColumnDefinition, 0, len(rows))
+	for _, row := range rows {
+		col := domain.ColumnDefinition{Name: row.ColumnName, DataType: row.DataType}
+		if row.CharacterMaximumLength != nil {
+			col.Size = *row.CharacterMaximumLength
+		}
+		columns = append(columns, col)
+	}
+	return columns, true, nil
+}
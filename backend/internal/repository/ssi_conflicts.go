@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// ssiConflictsQuery pairs each active SSI (a) with the later-ID active SSIs (b) it conflicts
+// with: same entity, settlement currency, instrument and settlement type, and overlapping
+// validity windows, each from valid_from up to but excluding valid_to
+const ssiConflictsQuery = `
+	SELECT a.id AS ssi_id, b.id AS other_id,
+		GREATEST(a.valid_from, b.valid_from) AS overlap_from,
+		NULLIF(LEAST(COALESCE(a.valid_to, 'infinity'), COALESCE(b.valid_to, 'infinity')), 'infinity') AS overlap_to
+	FROM ssis a
+	JOIN ssis b ON b.id > a.id
+		AND b.deleted_at IS NULL AND b.active
+		AND b.entity_id = a.entity_id
+		AND b.settlement_currency_id = a.settlement_currency_id
+		AND b.instrument_id IS NOT DISTINCT FROM a.instrument_id
+		AND COALESCE(b.settlement_type, '') = COALESCE(a.settlement_type, '')
+		AND b.valid_from < COALESCE(a.valid_to, 'infinity')
+		AND a.valid_from < COALESCE(b.valid_to, 'infinity')
+	WHERE a.deleted_at IS NULL AND a.active`
+
+// FindOverlapping returns the other active SSIs that would conflict with ssi: same entity,
+// settlement currency, instrument and settlement type, with an overlapping validity window
+func (r *ssiRepository) FindOverlapping(ssi *domain.SSI) ([]*domain.SSI, error) {
+	if ssi.EntityID == nil || ssi.SettlementCurrencyID == nil {
+		return nil, nil
+	}
+	query := r.db.
+		Where("id <> ? AND active = ? AND entity_id = ? AND settlement_currency_id = ?", ssi.ID, true, *ssi.EntityID, *ssi.SettlementCurrencyID).
+		Where("COALESCE(settlement_type, '') = ?", string(ssi.SettlementType)).
+		Where("valid_from < COALESCE(?, 'infinity'::timestamp) AND COALESCE(valid_to, 'infinity') > ?", ssi.ValidTo, ssi.ValidFrom)
+	if ssi.InstrumentID != nil {
+		query = query.Where("instrument_id = ?", *ssi.InstrumentID)
+	} else {
+		query = query.Where("instrument_id IS NULL")
+	}
+
+	var ssis []*domain.SSI
+	if err := query.Order("valid_from").Find(&ssis).Error; err != nil {
+		return nil, err
+	}
+	return ssis, nil
+}
+
+// FindConflicts returns the pairs of active SSIs that conflict with each other, by entity and
+// then overlap start, with both SSIs
+func (r *ssiRepository) FindConflicts(limit, offset int) ([]*domain.SSIConflict, int64, error) {
+	var total int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM (" + ssiConflictsQuery + ") conflicts").Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		SSIID       uuid.UUID  `gorm:"column:ssi_id"`
+		OtherID     uuid.UUID  `gorm:"column:other_id"`
+		OverlapFrom time.Time  `gorm:"column:overlap_from"`
+		OverlapTo   *time.Time `gorm:"column:overlap_to"`
+	}
+	err := r.db.Raw(ssiConflictsQuery+" ORDER BY a.entity_id, overlap_from, a.id, b.id LIMIT ? OFFSET ?", limit, offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uuid.UUID, 0, 2*len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.SSIID, row.OtherID)
+	}
+	var ssis []*domain.SSI
+	if len(ids) > 0 {
+		if err := r.db.Preload("Entity").Preload("SettlementCurrency").Preload("Instrument").Where("id IN ?", ids).Find(&ssis).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+	byID := make(map[uuid.UUID]*domain.SSI, len(ssis))
+	for _, ssi := range ssis {
+		byID[ssi.ID] = ssi
+	}
+
+	conflicts := make([]*domain.SSIConflict, 0, len(rows))
+	for _, row := range rows {
+		conflicts = append(conflicts, &domain.SSIConflict{
+			SSI:         byID[row.SSIID],
+			Other:       byID[row.OtherID],
+			OverlapFrom: row.OverlapFrom,
+			OverlapTo:   row.OverlapTo,
+		})
+	}
+	return conflicts, total, nil
+}
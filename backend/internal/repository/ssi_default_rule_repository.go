@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// SSIDefaultRuleRepository stores the SSI defaults by market and currency
+type SSIDefaultRuleRepository interface {
+	Create(rule *domain.SSIDefaultRule) error
+	FindByID(id string) (*domain.SSIDefaultRule, error)
+	FindAll(limit, offset int) ([]*domain.SSIDefaultRule, int64, error)
+	FindBest(instrumentID, currencyID *uuid.UUID) (*domain.SSIDefaultRule, error)
+	Update(rule *domain.SSIDefaultRule) error
+	Delete(id string) error
+}
+
+type ssiDefaultRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewSSIDefaultRuleRepository creates a new SSI default rule repository
+func NewSSIDefaultRuleRepository(db *gorm.DB) SSIDefaultRuleRepository {
+	return &ssiDefaultRuleRepository{db: db}
+}
+
+func (r *ssiDefaultRuleRepository) Create(rule *domain.SSIDefaultRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *ssiDefaultRuleRepository) FindByID(id string) (*domain.SSIDefaultRule, error) {
+	var rule domain.SSIDefaultRule
+	if err := r.db.Preload("Currency").First(&rule, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// FindAll lists rules by market, then currency, the catch-alls of each first
+func (r *ssiDefaultRuleRepository) FindAll(limit, offset int) ([]*domain.SSIDefaultRule, int64, error) {
+	var total int64
+	if err := r.db.Model(&domain.SSIDefaultRule{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rules []*domain.SSIDefaultRule
+	if err := r.db.Preload("Currency").
+		Joins("LEFT JOIN currencies ON currencies.id = ssi_default_rules.currency_id").
+		Order("ssi_default_rules.market NULLS FIRST, currencies.code NULLS FIRST").
+		Limit(limit).Offset(offset).Find(&rules).Error; err != nil {
+		return nil, 0, err
+	}
+	return rules, total, nil
+}
+
+// FindBest returns the most specific active rule for an SSI's instrument and settlement currency:
+// market and currency, then market only, then currency only. Nil if no rule matches.
+func (r *ssiDefaultRuleRepository) FindBest(instrumentID, currencyID *uuid.UUID) (*domain.SSIDefaultRule, error) {
+	query := r.db.Preload("Currency").Where("active")
+	if currencyID != nil {
+		query = query.Where("currency_id IS NULL OR currency_id = ?", *currencyID)
+	} else {
+		query = query.Where("currency_id IS NULL")
+	}
+	if instrumentID != nil {
+		query = query.Where("market IS NULL OR market = (SELECT UPPER(primary_exchange) FROM instruments WHERE id = ?)", *instrumentID)
+	} else {
+		query = query.Where("market IS NULL")
+	}
+
+	var rule domain.SSIDefaultRule
+	err := query.Order("market IS NULL, currency_id IS NULL").First(&rule).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *ssiDefaultRuleRepository) Update(rule *domain.SSIDefaultRule) error {
+	return r.db.Save(rule).Error
+}
+
+func (r *ssiDefaultRuleRepository) Delete(id string) error {
+	result := r.db.Delete(&domain.SSIDefaultRule{}, "id = ?", id)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// SSIEffectiveFilter narrows the SSIs that could apply to a settlement
+type SSIEffectiveFilter struct {
+	EntityID       uuid.UUID
+	CurrencyID     uuid.UUID
+	InstrumentID   *uuid.UUID // nil: only SSIs for any instrument; set: those and SSIs for this instrument
+	SettlementType string     // empty: any
+	KnownAt        *time.Time // nil: the SSIs as they are now; set: as they were recorded at that time
+}
+
+// FindEffective returns the active SSIs matching the filter whose validity window, from valid_from
+// up to but excluding valid_to, contains asOf. Instrument-specific SSIs come first, then the
+// most recently started.
+func (r *ssiRepository) FindEffective(filter SSIEffectiveFilter, asOf time.Time) ([]*domain.SSI, error) {
+	query := r.db
+	if filter.KnownAt != nil {
+		query = r.ssisKnownAt(*filter.KnownAt)
+	}
+	query = query.Preload("Entity").Preload("SettlementCurrency").Preload("Instrument").
+		Where("entity_id = ? AND settlement_currency_id = ? AND active = ?", filter.EntityID, filter.CurrencyID, true).
+		Where("valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)", asOf, asOf)
+	if filter.InstrumentID != nil {
+		query = query.Where("instrument_id = ? OR instrument_id IS NULL", *filter.InstrumentID)
+	} else {
+		query = query.Where("instrument_id IS NULL")
+	}
+	if filter.SettlementType != "" {
+		query = query.Where("settlement_type = ?", filter.SettlementType)
+	}
+
+	var ssis []*domain.SSI
+	if err := query.Order("instrument_id IS NULL, valid_from DESC").Find(&ssis).Error; err != nil {
+		return nil, err
+	}
+	return ssis, nil
+}
+
+// ssisKnownAt queries the SSIs as they stood at knownAt, rebuilt from their versions in place of
+// the ssis table
+func (r *ssiRepository) ssisKnownAt(knownAt time.Time) *gorm.DB {
+	versions := r.db.Raw(`
+		SELECT s.* FROM master_data_versions v
+		CROSS JOIN LATERAL JSONB_POPULATE_RECORD(NULL::ssis, v.snapshot) s
+		WHERE v.domain = ? AND v.valid_from <= ? AND (v.valid_to IS NULL OR v.valid_to > ?)`,
+		domain.VersionDomainSSI, knownAt, knownAt)
+	return r.db.Table("(?) AS ssis", versions)
+}
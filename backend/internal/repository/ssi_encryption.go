@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/fieldcrypt"
+	"gorm.io/gorm"
+)
+
+// reencryptBatchSize is how many rows each re-encryption transaction rewrites
+const reencryptBatchSize = 500
+
+// SSIReencryption counts the beneficiary accounts rewritten under the primary key
+type SSIReencryption struct {
+	SSIs     int64 `json:"ssis"`
+	Versions int64 `json:"versions"`
+}
+
+// storedAccount is a beneficiary account as stored
+type storedAccount struct {
+	ID      string
+	Account string
+}
+
+// ReencryptAccounts rewrites the beneficiary accounts, of SSIs (soft-deleted ones included) and
+// of their versions, that are in the clear or have data keys wrapped by a retired KEK. Only the
+// data keys are re-wrapped; the records get no new version, as their values are unchanged. An SSI
+// changed meanwhile is left as its change wrote it, which is under the primary KEK already.
+func (r *ssiRepository) ReencryptAccounts(c *fieldcrypt.Cipher) (SSIReencryption, error) {
+	var result SSIReencryption
+	var err error
+
+	result.SSIs, err = r.reencrypt(c,
+		`SELECT id::TEXT AS id, beneficiary_account AS account FROM ssis WHERE id > ? ORDER BY id LIMIT ?`,
+		`UPDATE ssis SET beneficiary_account = ? WHERE id = ? AND beneficiary_account = ?`)
+	if err != nil {
+		return result, err
+	}
+
+	result.Versions, err = r.reencrypt(c,
+		`SELECT id::TEXT AS id, snapshot->>'beneficiary_account' AS account FROM master_data_versions
+		WHERE domain = '`+domain.VersionDomainSSI+`' AND id > ? ORDER BY id LIMIT ?`,
+		`UPDATE master_data_versions SET snapshot = JSONB_SET(snapshot, '{beneficiary_account}', TO_JSONB(?::TEXT))
+		WHERE id = ? AND snapshot->>'beneficiary_account' = ?`)
+	return result, err
+}
+
+// reencrypt pages through the accounts selectSQL returns, rewriting those needing it with
+// updateSQL (taking the new value, the ID and the old value) a batch per transaction
+func (r *ssiRepository) reencrypt(c *fieldcrypt.Cipher, selectSQL, updateSQL string) (int64, error) {
+	var rewritten int64
+	after := "00000000-0000-0000-0000-000000000000"
+	for {
+		var batch []storedAccount
+		if err := r.db.Raw(selectSQL, after, reencryptBatchSize).Scan(&batch).Error; err != nil {
+			return rewritten, err
+		}
+		if len(batch) == 0 {
+			return rewritten, nil
+		}
+		after = batch[len(batch)-1].ID
+
+		var batchRewritten int64
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SET LOCAL axiom.reencrypting = 'on'").Error; err != nil {
+				return err
+			}
+			for _, row := range batch {
+				if !c.NeedsRotation(row.Account) {
+					continue
+				}
+				account, err := c.Rotate(row.Account)
+				if err != nil {
+					return fmt.Errorf("%s: %w", row.ID, err)
+				}
+				update := tx.Exec(updateSQL, account, row.ID, row.Account)
+				if update.Error != nil {
+					return update.Error
+				}
+				batchRewritten += update.RowsAffected
+			}
+			return nil
+		})
+		if err != nil {
+			return rewritten, err
+		}
+		rewritten += batchRewritten
+	}
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "github.com/techie2000/axiom/internal/domain"
+
+// FindForExport returns an SSI with what a settlement message needs: its currency and its entity
+// with the entity's addresses and their countries
+func (r *ssiRepository) FindForExport(id string) (*domain.SSI, error) {
+	var ssi domain.SSI
+	if err := r.db.Preload("SettlementCurrency").Preload("Instrument").
+		Preload("Entity").Preload("Entity.Addresses").Preload("Entity.Addresses.Address").Preload("Entity.Addresses.Address.Country").
+		First(&ssi, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &ssi, nil
+}
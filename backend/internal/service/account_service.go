@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// AccountService manages accounts
+type AccountService interface {
+	Create(ctx context.Context, account *domain.Account) error
+	GetByID(id string) (*domain.Account, error)
+	GetAll(limit, offset int) ([]*domain.Account, error)
+	GetAllWithFilters(q *query.ListQuery) ([]*domain.Account, error)
+	Update(ctx context.Context, account *domain.Account) error
+	Delete(ctx context.Context, id string) error
+}
+
+type accountService struct {
+	repo       repository.AccountRepository
+	currencies repository.CurrencyRepository
+	ids        BusinessIDGenerator
+}
+
+func NewAccountService(repo repository.AccountRepository, currencies repository.CurrencyRepository, ids BusinessIDGenerator) AccountService {
+	return &accountService{repo: repo, currencies: currencies, ids: ids}
+}
+
+// Create stores the account, generating its account number from the active ACCOUNT sequence when none is supplied
+func (s *accountService) Create(ctx context.Context, account *domain.Account) error {
+	if err := requireActiveCurrency(s.currencies, account.AccountCurrencyID, nil); err != nil {
+		return err
+	}
+	if account.AccountNumber == "" {
+		accountNumber, err := s.ids.Generate(ctx, domain.IDTargetAccount)
+		if err != nil {
+			return err
+		}
+		account.AccountNumber = accountNumber
+	}
+	return s.repo.Create(ctx, account)
+}
+
+func (s *accountService) GetByID(id string) (*domain.Account, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *accountService) GetAll(limit, offset int) ([]*domain.Account, error) {
+	return s.repo.FindAll(limit, offset)
+}
+
+func (s *accountService) GetAllWithFilters(q *query.ListQuery) ([]*domain.Account, error) {
+	return s.repo.FindAllWithFilters(q)
+}
+
+// Update saves the account; the review flag is owned by the DQ workflow and is carried over unchanged
+func (s *accountService) Update(ctx context.Context, account *domain.Account) error {
+	existing, err := s.repo.FindByID(account.ID.String())
+	if err != nil {
+		return err
+	}
+	if err := requireActiveCurrency(s.currencies, account.AccountCurrencyID, existing.AccountCurrencyID); err != nil {
+		return err
+	}
+	account.ReviewRequired = existing.ReviewRequired
+	account.ReviewReason = existing.ReviewReason
+	return s.repo.Update(ctx, account)
+}
+
+func (s *accountService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// ErrAuditRetentionDisabled is returned when pruning is requested without a retention period
+var ErrAuditRetentionDisabled = errors.New("audit retention is disabled (audit.retentionmonths is 0)")
+
+// auditPruneBatchSize is how many audit rows are archived and deleted at a time
+const auditPruneBatchSize = 5000
+
+// AuditRetentionService prunes audit history older than the configured retention period
+type AuditRetentionService interface {
+	// Enabled reports whether a retention period is configured
+	Enabled() bool
+	// Prune deletes audit rows past the retention period, archiving them first if configured
+	Prune() (*AuditPruneResult, error)
+}
+
+// AuditPruneResult summarizes a pruning run
+type AuditPruneResult struct {
+	Cutoff time.Time               `json:"cutoff"`
+	Tables []AuditPruneTableResult `json:"tables"`
+}
+
+// AuditPruneTableResult is the outcome of pruning one audit table
+type AuditPruneTableResult struct {
+	Table       string `json:"table"`
+	Pruned      int64  `json:"pruned"`
+	ArchiveFile string `json:"archive_file,omitempty"` // Empty when archiving is off or nothing was pruned
+}
+
+type auditRetentionService struct {
+	repo            repository.AuditRetentionRepository
+	retentionMonths int
+	archive         bool
+	archiveDir      string
+}
+
+// NewAuditRetentionService creates a new audit retention service
+func NewAuditRetentionService(repo repository.AuditRetentionRepository, cfg config.AuditConfig) AuditRetentionService {
+	months := cfg.RetentionMonths
+	if months < 0 {
+		log.Warn().Int("value", months).Msg("Invalid audit.retentionmonths, keeping audit history forever")
+		months = 0
+	}
+	return &auditRetentionService{
+		repo:            repo,
+		retentionMonths: months,
+		archive:         cfg.Archive,
+		archiveDir:      cfg.ArchiveDir,
+	}
+}
+
+func (s *auditRetentionService) Enabled() bool {
+	return s.retentionMonths > 0
+}
+
+func (s *auditRetentionService) Prune() (*AuditPruneResult, error) {
+	if !s.Enabled() {
+		return nil, ErrAuditRetentionDisabled
+	}
+	now := time.Now().UTC()
+	result := &AuditPruneResult{Cutoff: now.AddDate(0, -s.retentionMonths, 0)}
+
+	log.Info().
+		Time("cutoff", result.Cutoff).
+		Bool("archive", s.archive).
+		Msg("Starting audit pruning")
+
+	leiAudits, err := pruneAuditTable(s, "lei_records_audit", result.Cutoff, now,
+		s.repo.FindPrunableLEIAudits, func(a *domain.LEIRecordAudit) uuid.UUID { return a.ID }, s.repo.DeleteLEIAudits)
+	result.Tables = append(result.Tables, leiAudits)
+	if err != nil {
+		return result, err
+	}
+
+	auditLogs, err := pruneAuditTable(s, "audit_logs", result.Cutoff, now,
+		s.repo.FindPrunableAuditLogs, func(l *domain.AuditLog) uuid.UUID { return l.ID }, s.repo.DeleteAuditLogs)
+	result.Tables = append(result.Tables, auditLogs)
+	if err != nil {
+		return result, err
+	}
+
+	log.Info().
+		Int64("lei_records_audit", leiAudits.Pruned).
+		Int64("audit_logs", auditLogs.Pruned).
+		Msg("Audit pruning completed")
+	return result, nil
+}
+
+// pruneAuditTable archives and deletes one table's prunable rows in batches. Each batch is flushed
+// to the archive file before it is deleted, so a failed run never loses rows (at worst a row is
+// archived again by the next run).
+func pruneAuditTable[T any](s *auditRetentionService, table string, cutoff, runAt time.Time,
+	find func(time.Time, int) ([]T, error), id func(T) uuid.UUID, remove func([]uuid.UUID) (int64, error),
+) (result AuditPruneTableResult, err error) {
+	result.Table = table
+
+	var archive *auditArchive
+	defer func() {
+		if archive == nil {
+			return
+		}
+		if closeErr := archive.close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close %s archive: %w", table, closeErr)
+		}
+	}()
+
+	for {
+		rows, err := find(cutoff, auditPruneBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to find prunable %s rows: %w", table, err)
+		}
+		if len(rows) == 0 {
+			return result, nil
+		}
+
+		if s.archive {
+			if archive == nil {
+				if archive, err = createAuditArchive(s.archiveDir, table, runAt); err != nil {
+					return result, err
+				}
+				result.ArchiveFile = archive.path
+			}
+			for _, row := range rows {
+				if err := archive.enc.Encode(row); err != nil {
+					return result, fmt.Errorf("failed to archive %s rows: %w", table, err)
+				}
+			}
+			if err := archive.sync(); err != nil {
+				return result, fmt.Errorf("failed to archive %s rows: %w", table, err)
+			}
+		}
+
+		ids := make([]uuid.UUID, len(rows))
+		for i, row := range rows {
+			ids[i] = id(row)
+		}
+		deleted, err := remove(ids)
+		if err != nil {
+			return result, fmt.Errorf("failed to delete %s rows: %w", table, err)
+		}
+		result.Pruned += deleted
+		log.Debug().Str("table", table).Int64("deleted", deleted).Int64("total", result.Pruned).Msg("Pruned audit batch")
+		if deleted == 0 {
+			return result, nil // rows vanished under us; stop rather than loop
+		}
+	}
+}
+
+// auditArchive is a gzipped JSON lines file of pruned audit rows
+type auditArchive struct {
+	path string
+	file *os.File
+	gz   *gzip.Writer
+	enc  *json.Encoder
+}
+
+func createAuditArchive(dir, table string, runAt time.Time) (*auditArchive, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create audit archive directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.jsonl.gz", table, runAt.Format("20060102T150405Z")))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit archive: %w", err)
+	}
+	gz := gzip.NewWriter(file)
+	return &auditArchive{path: path, file: file, gz: gz, enc: json.NewEncoder(gz)}, nil
+}
+
+// sync makes everything written so far durable
+func (a *auditArchive) sync() error {
+	if err := a.gz.Flush(); err != nil {
+		return err
+	}
+	return a.file.Sync()
+}
+
+func (a *auditArchive) close() error {
+	if err := a.gz.Close(); err != nil {
+		a.file.Close()
+		return err
+	}
+	return a.file.Close()
+}
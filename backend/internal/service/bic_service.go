@@ -0,0 +1,300 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Errors validating a BIC
+var (
+	ErrInvalidBIC     = errors.New("invalid BIC")
+	ErrUnknownBIC     = errors.New("BIC not in the BIC directory")
+	ErrInvalidBICFile = errors.New("invalid BIC directory file")
+)
+
+// importMaxRejections bounds the rejected rows reported by an import; the rest are only counted
+const importMaxRejections = 100
+
+// NormalizeBIC upper-cases a BIC and strips its spaces
+func NormalizeBIC(bic string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(bic), " ", ""))
+}
+
+// ValidateBIC checks a normalized BIC against the ISO 9362 structure: a 4-character institution
+// code, a 2-letter country code, a 2-character location code and an optional 3-character branch
+// code. The error wraps ErrInvalidBIC and says what is wrong.
+func ValidateBIC(bic string) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidBIC, bic, reason)
+	}
+	if len(bic) != 8 && len(bic) != 11 {
+		return invalid("must be 8 or 11 characters")
+	}
+	for i, r := range bic {
+		letter := r >= 'A' && r <= 'Z'
+		digit := r >= '0' && r <= '9'
+		switch {
+		case i >= 4 && i < 6 && !letter:
+			return invalid("country code must be two letters")
+		case !letter && !digit:
+			return invalid("must only contain letters and digits")
+		}
+	}
+	// O as the second location character was replaced by 0 (test and training BICs)
+	if bic[7] == 'O' {
+		return invalid("second character of the location code must not be O")
+	}
+	if len(bic) == 11 && bic[8] == 'X' && bic[8:] != "XXX" {
+		return invalid("branch code starting with X must be XXX")
+	}
+	return nil
+}
+
+// bic11 returns the 11-character form of a valid BIC; a BIC8 is the head office, branch XXX
+func bic11(bic string) string {
+	if len(bic) == 8 {
+		return bic + "XXX"
+	}
+	return bic
+}
+
+// ImportRejection explains why a row of an imported file was not imported
+type ImportRejection struct {
+	Line  int    `json:"line"` // 1-based line in the file, the header being line 1
+	Error string `json:"error"`
+}
+
+// BICImportResult summarises a BIC directory import
+type BICImportResult struct {
+	Read          int               `json:"read"`
+	Imported      int               `json:"imported"`
+	Deactivated   int64             `json:"deactivated"` // BICs a full import no longer lists
+	RejectedCount int               `json:"rejected_count"`
+	Rejected      []ImportRejection `json:"rejected"` // the first rejections
+}
+
+// BICService maintains the BIC directory
+type BICService interface {
+	Import(file io.Reader, full bool) (*BICImportResult, error)
+	Get(bic string) (*domain.BICDirectoryEntry, error)
+	List(filter repository.BICFilter, limit, offset int) ([]*domain.BICDirectoryEntry, int64, error)
+}
+
+type bicService struct {
+	repo repository.BICRepository
+}
+
+// NewBICService creates a new BIC directory service
+func NewBICService(repo repository.BICRepository) BICService {
+	return &bicService{repo: repo}
+}
+
+// bicColumns maps directory file headers, upper-cased without spaces or punctuation, to fields.
+// Covers SWIFT BIC directory exports and simple CSVs.
+var bicColumns = map[string]string{
+	"BIC":               "bic",
+	"BIC11":             "bic",
+	"BICCODE":           "bic",
+	"SWIFTBIC":          "bic",
+	"SWIFTCODE":         "bic",
+	"BIC8":              "bic8",
+	"BRANCHCODE":        "branch_code",
+	"INSTITUTIONNAME":   "institution_name",
+	"INSTITUTION":       "institution_name",
+	"BANKNAME":          "institution_name",
+	"NAME":              "institution_name",
+	"BRANCHINFORMATION": "branch_name",
+	"BRANCHNAME":        "branch_name",
+	"BRANCH":            "branch_name",
+	"CITY":              "city",
+	"CITYHEADING":       "city",
+	"TOWN":              "city",
+	"COUNTRYCODE":       "country_code",
+	"ISOCOUNTRYCODE":    "country_code",
+	"COUNTRY":           "country_code", // only compared when it holds a 2-letter code
+}
+
+// Import loads a BIC directory file: comma, semicolon or tab separated, with a header row naming
+// at least the BIC (or BIC8 and branch code) and institution name columns. Entries are upserted
+// by BIC; invalid rows are reported and skipped. A full import also deactivates the BICs the file
+// no longer lists, provided it imported any.
+func (s *bicService) Import(file io.Reader, full bool) (*BICImportResult, error) {
+	reader := bufio.NewReader(file)
+	header, err := reader.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	firstLine, _, _ := strings.Cut(string(header), "\n")
+
+	records := csv.NewReader(reader)
+	records.Comma = bicFileDelimiter(firstLine)
+	records.FieldsPerRecord = -1
+	records.LazyQuotes = true
+
+	columns, err := records.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", ErrInvalidBICFile, err)
+	}
+	index := map[string]int{}
+	for i, name := range columns {
+		key := strings.Map(func(r rune) rune {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, strings.ToUpper(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := bicColumns[key]; ok {
+			if _, seen := index[field]; !seen {
+				index[field] = i
+			}
+		}
+	}
+	_, hasBIC := index["bic"]
+	_, hasBIC8 := index["bic8"]
+	if _, ok := index["institution_name"]; !ok || (!hasBIC && !hasBIC8) {
+		return nil, fmt.Errorf("%w: header must name the BIC and institution name columns", ErrInvalidBICFile)
+	}
+
+	importedAt := time.Now().UTC()
+	result := &BICImportResult{Rejected: make([]ImportRejection, 0)}
+	entries := make(map[string]*domain.BICDirectoryEntry)
+	order := make([]string, 0)
+
+	for {
+		record, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line := 0
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.Line
+		} else if err == nil {
+			line, _ = records.FieldPos(0)
+		}
+		reject := func(format string, args ...any) {
+			result.RejectedCount++
+			if len(result.Rejected) < importMaxRejections {
+				result.Rejected = append(result.Rejected, ImportRejection{Line: line, Error: fmt.Sprintf(format, args...)})
+			}
+		}
+		if err != nil {
+			result.Read++
+			reject("%v", err)
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		result.Read++
+
+		bic := NormalizeBIC(field("bic"))
+		if bic == "" {
+			bic = NormalizeBIC(field("bic8") + field("branch_code"))
+		}
+		if err := ValidateBIC(bic); err != nil {
+			reject("%v", err)
+			continue
+		}
+		entry := &domain.BICDirectoryEntry{
+			BIC:             bic11(bic),
+			InstitutionName: field("institution_name"),
+			BranchName:      field("branch_name"),
+			City:            field("city"),
+			CountryCode:     bic[4:6],
+			Active:          true,
+			ImportedAt:      importedAt,
+		}
+		if entry.InstitutionName == "" {
+			reject("institution name is required")
+			continue
+		}
+		if country := strings.ToUpper(field("country_code")); len(country) == 2 && country != entry.CountryCode {
+			reject("country code %s does not match BIC %s", country, bic)
+			continue
+		}
+
+		// A BIC listed twice keeps its last row
+		if _, seen := entries[entry.BIC]; !seen {
+			order = append(order, entry.BIC)
+		}
+		entries[entry.BIC] = entry
+	}
+
+	batch := make([]*domain.BICDirectoryEntry, 0, len(order))
+	for _, bic := range order {
+		batch = append(batch, entries[bic])
+	}
+	if err := s.repo.Upsert(batch); err != nil {
+		return nil, err
+	}
+	result.Imported = len(batch)
+
+	if full && result.Imported > 0 {
+		if result.Deactivated, err = s.repo.DeactivateNotImportedSince(importedAt); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Get returns the directory entry of a BIC, given as BIC8 or BIC11
+func (s *bicService) Get(bic string) (*domain.BICDirectoryEntry, error) {
+	bic = NormalizeBIC(bic)
+	if err := ValidateBIC(bic); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByBIC(bic11(bic))
+}
+
+// List lists directory entries
+func (s *bicService) List(filter repository.BICFilter, limit, offset int) ([]*domain.BICDirectoryEntry, int64, error) {
+	return s.repo.FindAll(filter, limit, offset)
+}
+
+// bicFileDelimiter guesses a directory file's delimiter from its header line
+func bicFileDelimiter(header string) rune {
+	switch {
+	case strings.Contains(header, "\t"):
+		return '\t'
+	case strings.Count(header, ";") > strings.Count(header, ","):
+		return ';'
+	default:
+		return ','
+	}
+}
+
+// requireKnownBIC validates a normalized BIC's structure and, once a BIC directory has been
+// imported, that it is listed and active. An unchanged BIC (equal to previous) is accepted as is.
+func requireKnownBIC(bics repository.BICRepository, bic, previous string) error {
+	if bic == "" || bic == previous {
+		return nil
+	}
+	if err := ValidateBIC(bic); err != nil {
+		return err
+	}
+	loaded, err := bics.HasEntries()
+	if err != nil || !loaded {
+		return err
+	}
+	entry, err := bics.FindByBIC(bic11(bic))
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !entry.Active) {
+		return fmt.Errorf("%w: %s", ErrUnknownBIC, bic)
+	}
+	return err
+}
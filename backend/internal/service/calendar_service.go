@@ -0,0 +1,331 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Errors maintaining holiday calendars and computing settlement dates
+var (
+	ErrInvalidCalendar         = errors.New("invalid holiday calendar")
+	ErrUnknownCalendar         = errors.New("no holiday calendar for market")
+	ErrInvalidSettlementCycle  = errors.New("settlement cycle must be T, T+0 or T+n with n up to 30")
+	ErrNoSettlementBusinessDay = errors.New("no business day within a year of the trade date")
+)
+
+// calendarCodePattern is a calendar code: a MIC (e.g. XLON), a currency (e.g. USD) or another
+// upper case market code
+var calendarCodePattern = regexp.MustCompile(`^[A-Z0-9]{2,10}$`)
+
+// settlementCyclePattern is a settlement cycle: T, T+0 or T+n
+var settlementCyclePattern = regexp.MustCompile(`^T(\+(\d{1,2}))?$`)
+
+// maxSettlementCycle is the longest settlement cycle, in business days
+const maxSettlementCycle = 30
+
+// settlementSearchDays bounds how far past the trade date a settlement date is looked for
+const settlementSearchDays = 366
+
+// weekdayCodes are the codes of weekend days, e.g. SAT,SUN
+var weekdayCodes = map[string]time.Weekday{
+	"MON": time.Monday, "TUE": time.Tuesday, "WED": time.Wednesday, "THU": time.Thursday,
+	"FRI": time.Friday, "SAT": time.Saturday, "SUN": time.Sunday,
+}
+
+// SettlementDateResult is the settlement (value) date of a trade
+type SettlementDateResult struct {
+	Markets        []string        `json:"markets"`
+	TradeDate      string          `json:"trade_date"`
+	Cycle          string          `json:"cycle"`
+	SettlementDate string          `json:"settlement_date"`
+	Skipped        []NonSettlement `json:"skipped"`            // non-business days passed over, in date order
+	Warnings       []string        `json:"warnings,omitempty"` // e.g. a calendar with no holidays loaded for a year
+}
+
+// NonSettlement is a day a trade cannot settle on, and why
+type NonSettlement struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"` // "weekend", or the calendar and holiday name, e.g. "XLON: Christmas Day"
+}
+
+// CalendarService maintains holiday calendars and computes settlement dates from them
+type CalendarService interface {
+	List() ([]*domain.HolidayCalendar, error)
+	Get(code string, year int) (*domain.HolidayCalendar, error)
+	Save(calendar *domain.HolidayCalendar) error
+	AddHolidays(code string, holidays []*domain.CalendarHoliday) error
+	DeleteHoliday(code string, date time.Time) (bool, error)
+	SettlementDate(markets []string, tradeDate time.Time, cycle string) (*SettlementDateResult, error)
+}
+
+type calendarService struct {
+	repo repository.CalendarRepository
+}
+
+// NewCalendarService creates a new holiday calendar service
+func NewCalendarService(repo repository.CalendarRepository) CalendarService {
+	return &calendarService{repo: repo}
+}
+
+// List lists the calendars, without their holidays
+func (s *calendarService) List() ([]*domain.HolidayCalendar, error) {
+	return s.repo.FindAll()
+}
+
+// Get returns a calendar with its holidays of a year, or all of them if year is 0
+func (s *calendarService) Get(code string, year int) (*domain.HolidayCalendar, error) {
+	calendar, err := s.repo.FindByCode(normalizeCalendarCode(code))
+	if err != nil {
+		return nil, err
+	}
+	from, to := time.Time{}, time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if year != 0 {
+		from = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to = time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	holidays, err := s.repo.FindHolidays([]string{calendar.Code}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	calendar.Holidays = make([]domain.CalendarHoliday, 0, len(holidays))
+	for _, holiday := range holidays {
+		calendar.Holidays = append(calendar.Holidays, *holiday)
+	}
+	return calendar, nil
+}
+
+// Save creates a calendar or updates its name and weekend days; weekend days default to SAT,SUN
+func (s *calendarService) Save(calendar *domain.HolidayCalendar) error {
+	calendar.Code = normalizeCalendarCode(calendar.Code)
+	calendar.Name = strings.TrimSpace(calendar.Name)
+	if !calendarCodePattern.MatchString(calendar.Code) {
+		return fmt.Errorf("%w: code %q must be 2 to 10 upper case letters or digits", ErrInvalidCalendar, calendar.Code)
+	}
+	if calendar.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCalendar)
+	}
+	if strings.TrimSpace(calendar.WeekendDays) == "" {
+		calendar.WeekendDays = "SAT,SUN"
+	}
+	weekend, err := parseWeekendDays(calendar.WeekendDays)
+	if err != nil {
+		return err
+	}
+	codes := make([]string, 0, len(weekend))
+	for code, day := range weekdayCodes {
+		if weekend[day] {
+			codes = append(codes, code)
+		}
+	}
+	slices.SortFunc(codes, func(a, b string) int { return int(isoWeekday(weekdayCodes[a]) - isoWeekday(weekdayCodes[b])) })
+	calendar.WeekendDays = strings.Join(codes, ",")
+	calendar.Holidays = nil
+	return s.repo.Save(calendar)
+}
+
+// AddHolidays adds holidays to a calendar, renaming any it already has
+func (s *calendarService) AddHolidays(code string, holidays []*domain.CalendarHoliday) error {
+	calendar, err := s.repo.FindByCode(normalizeCalendarCode(code))
+	if err != nil {
+		return err
+	}
+	for _, holiday := range holidays {
+		holiday.CalendarCode = calendar.Code
+		holiday.Name = strings.TrimSpace(holiday.Name)
+		holiday.Date = truncateToDate(holiday.Date)
+		if holiday.Date.IsZero() {
+			return fmt.Errorf("%w: holiday date is required", ErrInvalidCalendar)
+		}
+		if holiday.Name == "" {
+			return fmt.Errorf("%w: holiday %s needs a name", ErrInvalidCalendar, holiday.Date.Format(time.DateOnly))
+		}
+	}
+	return s.repo.UpsertHolidays(holidays)
+}
+
+// DeleteHoliday removes a holiday from a calendar and reports whether it had one
+func (s *calendarService) DeleteHoliday(code string, date time.Time) (bool, error) {
+	return s.repo.DeleteHoliday(normalizeCalendarCode(code), truncateToDate(date))
+}
+
+// SettlementDate counts the cycle's business days on from the trade date. With more than one
+// market, a business day is one that is a business day in every market. T+0 settles on the trade
+// date if it is a business day, or on the next one.
+func (s *calendarService) SettlementDate(markets []string, tradeDate time.Time, cycle string) (*SettlementDateResult, error) {
+	days, cycle, err := parseSettlementCycle(cycle)
+	if err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, len(markets))
+	for _, market := range markets {
+		if code := normalizeCalendarCode(market); code != "" && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("%w: market is required", ErrInvalidCalendar)
+	}
+
+	calendars, err := s.repo.FindByCodes(codes)
+	if err != nil {
+		return nil, err
+	}
+	weekend := make(map[time.Weekday]bool)
+	found := make(map[string]bool, len(calendars))
+	for _, calendar := range calendars {
+		calendarWeekend, err := parseWeekendDays(calendar.WeekendDays)
+		if err != nil {
+			return nil, err
+		}
+		for day := range calendarWeekend {
+			weekend[day] = true
+		}
+		found[calendar.Code] = true
+	}
+	for _, code := range codes {
+		if !found[code] {
+			return nil, fmt.Errorf("%w %s", ErrUnknownCalendar, code)
+		}
+	}
+
+	tradeDate = truncateToDate(tradeDate)
+	last := tradeDate.AddDate(0, 0, settlementSearchDays)
+	holidays, err := s.repo.FindHolidays(codes, tradeDate, last)
+	if err != nil {
+		return nil, err
+	}
+	closed := make(map[string][]string) // date -> "CODE: name"
+	for _, holiday := range holidays {
+		date := holiday.Date.Format(time.DateOnly)
+		closed[date] = append(closed[date], holiday.CalendarCode+": "+holiday.Name)
+	}
+
+	result := &SettlementDateResult{
+		Markets:   codes,
+		TradeDate: tradeDate.Format(time.DateOnly),
+		Cycle:     cycle,
+		Skipped:   []NonSettlement{},
+	}
+	// isBusinessDay records why a day is not one as it goes
+	isBusinessDay := func(day time.Time) bool {
+		date := day.Format(time.DateOnly)
+		switch {
+		case weekend[day.Weekday()]:
+			result.Skipped = append(result.Skipped, NonSettlement{Date: date, Reason: "weekend"})
+		case len(closed[date]) > 0:
+			result.Skipped = append(result.Skipped, NonSettlement{Date: date, Reason: strings.Join(closed[date], "; ")})
+		default:
+			return true
+		}
+		return false
+	}
+
+	day, counted := tradeDate, 0
+	if days > 0 {
+		day = day.AddDate(0, 0, 1)
+	}
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !isBusinessDay(day) {
+			continue
+		}
+		if counted++; counted >= max(days, 1) {
+			result.SettlementDate = day.Format(time.DateOnly)
+			break
+		}
+	}
+	if result.SettlementDate == "" {
+		return nil, ErrNoSettlementBusinessDay
+	}
+	result.Warnings = s.missingHolidayYears(codes, holidays, tradeDate, day)
+	return result, nil
+}
+
+// missingHolidayYears warns about calendars with no holidays at all in a year the trade and
+// settlement dates span, which usually means the year has not been loaded yet
+func (s *calendarService) missingHolidayYears(codes []string, holidays []*domain.CalendarHoliday, from, to time.Time) []string {
+	var warnings []string
+	for year := from.Year(); year <= to.Year(); year++ {
+		for _, code := range codes {
+			loaded := slices.ContainsFunc(holidays, func(h *domain.CalendarHoliday) bool {
+				return h.CalendarCode == code && h.Date.Year() == year
+			})
+			if loaded {
+				continue
+			}
+			// Holidays before the trade date fall outside the settlement window, so look at the whole year
+			yearHolidays, err := s.repo.FindHolidays([]string{code}, time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC))
+			if err == nil && len(yearHolidays) == 0 {
+				warnings = append(warnings, fmt.Sprintf("calendar %s has no holidays for %d", code, year))
+			}
+		}
+	}
+	return warnings
+}
+
+// parseSettlementCycle returns the business days of a cycle such as T+2, and the cycle in canonical form
+func parseSettlementCycle(cycle string) (int, string, error) {
+	cycle = strings.ToUpper(strings.ReplaceAll(cycle, " ", ""))
+	if cycle == "" {
+		cycle = "T+2"
+	}
+	match := settlementCyclePattern.FindStringSubmatch(cycle)
+	if match == nil {
+		return 0, "", ErrInvalidSettlementCycle
+	}
+	days := 0
+	if match[2] != "" {
+		days, _ = strconv.Atoi(match[2])
+	}
+	if days > maxSettlementCycle {
+		return 0, "", ErrInvalidSettlementCycle
+	}
+	return days, fmt.Sprintf("T+%d", days), nil
+}
+
+// parseWeekendDays reads comma separated weekday codes, e.g. SAT,SUN
+func parseWeekendDays(value string) (map[time.Weekday]bool, error) {
+	weekend := make(map[time.Weekday]bool)
+	for _, code := range strings.Split(value, ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		day, ok := weekdayCodes[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: weekend day %q must be one of MON, TUE, WED, THU, FRI, SAT, SUN", ErrInvalidCalendar, code)
+		}
+		weekend[day] = true
+	}
+	if len(weekend) == len(weekdayCodes) {
+		return nil, fmt.Errorf("%w: a calendar needs at least one business weekday", ErrInvalidCalendar)
+	}
+	return weekend, nil
+}
+
+// isoWeekday numbers weekdays from Monday (1) to Sunday (7)
+func isoWeekday(day time.Weekday) int {
+	if day == time.Sunday {
+		return 7
+	}
+	return int(day)
+}
+
+func normalizeCalendarCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// truncateToDate drops the time of day, keeping the calendar date
+func truncateToDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
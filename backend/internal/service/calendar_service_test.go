@@ -0,0 +1,184 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// memoryCalendars is a CalendarRepository over calendars and holidays held in memory
+type memoryCalendars struct {
+	repository.CalendarRepository
+	calendars []*domain.HolidayCalendar
+	holidays  []*domain.CalendarHoliday
+}
+
+func (m *memoryCalendars) FindByCodes(codes []string) ([]*domain.HolidayCalendar, error) {
+	var found []*domain.HolidayCalendar
+	for _, calendar := range m.calendars {
+		if slices.Contains(codes, calendar.Code) {
+			found = append(found, calendar)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryCalendars) FindByCode(code string) (*domain.HolidayCalendar, error) {
+	found, _ := m.FindByCodes([]string{code})
+	if len(found) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return found[0], nil
+}
+
+func (m *memoryCalendars) FindHolidays(codes []string, from, to time.Time) ([]*domain.CalendarHoliday, error) {
+	var found []*domain.CalendarHoliday
+	for _, holiday := range m.holidays {
+		if slices.Contains(codes, holiday.CalendarCode) && !holiday.Date.Before(from) && !holiday.Date.After(to) {
+			found = append(found, holiday)
+		}
+	}
+	return found, nil
+}
+
+func mustDate(value string) time.Time {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestSettlementDate(t *testing.T) {
+	repo := &memoryCalendars{
+		calendars: []*domain.HolidayCalendar{
+			{Code: "XLON", Name: "London Stock Exchange", WeekendDays: "SAT,SUN"},
+			{Code: "XTAE", Name: "Tel Aviv Stock Exchange", WeekendDays: "FRI,SAT"},
+		},
+		holidays: []*domain.CalendarHoliday{
+			{CalendarCode: "XLON", Date: mustDate("2026-01-01"), Name: "New Year's Day"},
+			{CalendarCode: "XLON", Date: mustDate("2026-12-25"), Name: "Christmas Day"},
+			{CalendarCode: "XLON", Date: mustDate("2026-12-28"), Name: "Boxing Day (substitute)"},
+			{CalendarCode: "XTAE", Date: mustDate("2026-03-03"), Name: "Purim"},
+		},
+	}
+	svc := NewCalendarService(repo)
+
+	tests := []struct {
+		name        string
+		markets     []string
+		tradeDate   string
+		cycle       string
+		want        string
+		wantCycle   string
+		wantSkipped []NonSettlement
+		wantWarning string
+		wantErr     error
+	}{
+		{
+			name:      "T+2 over Christmas",
+			markets:   []string{"XLON"},
+			tradeDate: "2026-12-24",
+			cycle:     "T+2",
+			want:      "2026-12-30",
+			wantCycle: "T+2",
+			wantSkipped: []NonSettlement{
+				{Date: "2026-12-25", Reason: "XLON: Christmas Day"},
+				{Date: "2026-12-26", Reason: "weekend"},
+				{Date: "2026-12-27", Reason: "weekend"},
+				{Date: "2026-12-28", Reason: "XLON: Boxing Day (substitute)"},
+			},
+		},
+		{
+			name:        "default cycle",
+			markets:     []string{"xlon"},
+			tradeDate:   "2026-06-01",
+			want:        "2026-06-03",
+			wantCycle:   "T+2",
+			wantSkipped: []NonSettlement{},
+		},
+		{
+			name:        "T+0 on a business day",
+			markets:     []string{"XLON"},
+			tradeDate:   "2026-12-24",
+			cycle:       "t",
+			want:        "2026-12-24",
+			wantCycle:   "T+0",
+			wantSkipped: []NonSettlement{},
+		},
+		{
+			name:      "T+0 on a weekend rolls to the next business day",
+			markets:   []string{"XLON"},
+			tradeDate: "2026-12-26",
+			cycle:     "T+0",
+			want:      "2026-12-29",
+			wantCycle: "T+0",
+			wantSkipped: []NonSettlement{
+				{Date: "2026-12-26", Reason: "weekend"},
+				{Date: "2026-12-27", Reason: "weekend"},
+				{Date: "2026-12-28", Reason: "XLON: Boxing Day (substitute)"},
+			},
+		},
+		{
+			name:      "business day in every market",
+			markets:   []string{"XLON", "XTAE", "XLON"},
+			tradeDate: "2026-03-02",
+			cycle:     "T+1",
+			want:      "2026-03-04",
+			wantCycle: "T+1",
+			wantSkipped: []NonSettlement{
+				{Date: "2026-03-03", Reason: "XTAE: Purim"},
+			},
+		},
+		{
+			name:      "weekends of every market",
+			markets:   []string{"XLON", "XTAE"},
+			tradeDate: "2026-03-05",
+			cycle:     "T+1",
+			want:      "2026-03-09",
+			wantCycle: "T+1",
+			wantSkipped: []NonSettlement{
+				{Date: "2026-03-06", Reason: "weekend"},
+				{Date: "2026-03-07", Reason: "weekend"},
+				{Date: "2026-03-08", Reason: "weekend"},
+			},
+		},
+		{
+			name:        "warns about a year without holidays",
+			markets:     []string{"XLON"},
+			tradeDate:   "2026-12-30",
+			cycle:       "T+2",
+			want:        "2027-01-01",
+			wantCycle:   "T+2",
+			wantSkipped: []NonSettlement{},
+			wantWarning: "calendar XLON has no holidays for 2027",
+		},
+		{name: "unknown market", markets: []string{"XLON", "XNYS"}, tradeDate: "2026-06-01", wantErr: ErrUnknownCalendar},
+		{name: "no market", markets: []string{" "}, tradeDate: "2026-06-01", wantErr: ErrInvalidCalendar},
+		{name: "cycle too long", markets: []string{"XLON"}, tradeDate: "2026-06-01", cycle: "T+31", wantErr: ErrInvalidSettlementCycle},
+		{name: "cycle backwards", markets: []string{"XLON"}, tradeDate: "2026-06-01", cycle: "T-1", wantErr: ErrInvalidSettlementCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.SettlementDate(tt.markets, mustDate(tt.tradeDate), tt.cycle)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.SettlementDate)
+			assert.Equal(t, tt.wantCycle, result.Cycle)
+			assert.Equal(t, tt.wantSkipped, result.Skipped)
+			if tt.wantWarning != "" {
+				assert.Contains(t, result.Warnings, tt.wantWarning)
+			}
+		})
+	}
+}
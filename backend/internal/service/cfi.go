@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// ErrInvalidCFI is returned for a CFI code that fails the ISO 10962 structure
+var ErrInvalidCFI = errors.New("invalid CFI code")
+
+// cfiGroups lists the groups of each ISO 10962:2019 category
+var cfiGroups = map[byte]string{
+	'E': "SPCFLDYM",     // equities
+	'C': "IHBESFPM",     // collective investment vehicles
+	'D': "BCWTSEGANDMY", // debt instruments
+	'R': "ASPWFDM",      // entitlements (rights)
+	'O': "CPM",          // listed options
+	'F': "FC",           // futures
+	'S': "RTECFM",       // swaps
+	'H': "RTECFM",       // non-listed and complex listed options
+	'I': "FT",           // spot
+	'J': "EFCRT",        // forwards
+	'K': "RTECFYM",      // strategies
+	'L': "LRS",          // financing
+	'T': "CTRIBDM",      // referential instruments
+	'M': "CM",           // others
+}
+
+// NormalizeCFI upper-cases a CFI code and strips its spaces
+func NormalizeCFI(cfi string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(cfi), " ", ""))
+}
+
+// ValidateCFI checks a normalized CFI code: six letters, a known category and a group of that
+// category. The four attribute letters depend on the group and are only checked to be letters (X
+// where not applicable). The error wraps ErrInvalidCFI and says what is wrong.
+func ValidateCFI(cfi string) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidCFI, cfi, reason)
+	}
+	if len(cfi) != 6 {
+		return invalid("must be 6 letters")
+	}
+	for _, r := range cfi {
+		if r < 'A' || r > 'Z' {
+			return invalid("must only contain letters")
+		}
+	}
+	groups, ok := cfiGroups[cfi[0]]
+	if !ok {
+		return invalid(fmt.Sprintf("unknown category %c", cfi[0]))
+	}
+	if !strings.ContainsRune(groups, rune(cfi[1])) {
+		return invalid(fmt.Sprintf("unknown group %c for category %c", cfi[1], cfi[0]))
+	}
+	return nil
+}
+
+// InstrumentTypeForCFI derives the coarse instrument type of a valid CFI code; empty for
+// categories with no matching type (financing and others)
+func InstrumentTypeForCFI(cfi string) domain.InstrumentType {
+	switch cfi[0] {
+	case 'E':
+		return domain.InstrumentTypeEquity
+	case 'C':
+		return domain.InstrumentTypeFund
+	case 'D':
+		return domain.InstrumentTypeBond
+	case 'R', 'O', 'F', 'S', 'H', 'J', 'K':
+		return domain.InstrumentTypeDerivative
+	case 'I', 'T':
+		// Spot and referential instruments: currencies and commodities
+		switch cfi[1] {
+		case 'F', 'C':
+			return domain.InstrumentTypeForex
+		case 'T':
+			return domain.InstrumentTypeCommodity
+		}
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/techie2000/axiom/internal/cache"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CountryService manages country reference data
+type CountryService interface {
+	Create(ctx context.Context, country *domain.Country) error
+	GetByID(id string) (*domain.Country, error)
+	GetByCode(code string) (*domain.Country, error)
+	GetAll(limit, offset int) ([]*domain.Country, error)
+	Update(ctx context.Context, country *domain.Country) error
+	Delete(ctx context.Context, id string) error
+	Seed(ctx context.Context) (*CountrySyncResult, error)
+	Sync(ctx context.Context, source string) (*CountrySyncResult, error)
+	RemoteSyncEnabled() bool
+}
+
+type countryService struct {
+	repo    repository.CountryRepository
+	cascade *referenceCascade
+	listURL string
+	client  *http.Client
+	lookups *cache.LRU[string, *domain.Country] // GetByCode results, nil for codes not found
+}
+
+// NewCountryService creates a country service; deactivating a country applies deactivationPolicy to its dependents,
+// and a remote sync downloads the ISO 3166 country list from listURL. lookups, if not nil,
+// caches GetByCode for the per-record lookups of syncs and imports.
+func NewCountryService(repo repository.CountryRepository, cascadeRepo repository.ReferenceCascadeRepository, deactivationPolicy, listURL string, lookups *cache.LRU[string, *domain.Country]) CountryService {
+	return &countryService{
+		repo:    repo,
+		cascade: newReferenceCascade(cascadeRepo, deactivationPolicy),
+		listURL: strings.TrimSpace(listURL),
+		client:  &http.Client{Timeout: countryDownloadTimeout},
+		lookups: lookups,
+	}
+}
+
+func (s *countryService) Create(ctx context.Context, country *domain.Country) error {
+	defer s.lookups.Purge()
+	return s.repo.Create(ctx, country)
+}
+
+func (s *countryService) GetByID(id string) (*domain.Country, error) {
+	return s.repo.FindByID(id)
+}
+
+// GetByCode returns the country with a code, from the lookup cache if it holds it; a code not
+// found is cached as such
+func (s *countryService) GetByCode(code string) (*domain.Country, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	country, ok := s.lookups.Get(code)
+	if !ok {
+		var err error
+		country, err = s.repo.FindByCode(code)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		s.lookups.Add(code, country)
+	}
+	if country == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *country // a copy, as the cached country is shared
+	return &found, nil
+}
+
+func (s *countryService) GetAll(limit, offset int) ([]*domain.Country, error) {
+	return s.repo.FindAll(limit, offset)
+}
+
+func (s *countryService) Update(ctx context.Context, country *domain.Country) error {
+	defer s.lookups.Purge()
+	existing, err := s.repo.FindByID(country.ID.String())
+	if err != nil {
+		return err
+	}
+	if !existing.Active || country.Active {
+		return s.repo.Update(ctx, country)
+	}
+
+	dependents, err := s.cascade.repo.FindCountryDependents(country.ID)
+	if err != nil {
+		return err
+	}
+	return s.cascade.deactivate(ctx, country, "country", existing.Code, domain.DQRuleInactiveCountryReference, dependents)
+}
+
+func (s *countryService) Delete(ctx context.Context, id string) error {
+	defer s.lookups.Purge()
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// Sources of a country sync
+const (
+	CountrySourceBundled = "bundled" // the ISO 3166 dataset built into Axiom
+	CountrySourceRemote  = "remote"  // referencedata.countrylisturl
+)
+
+// Errors syncing countries
+var (
+	ErrInvalidCountryFile     = errors.New("invalid country list file")
+	ErrUnknownCountrySource   = errors.New("country sync source must be bundled or remote")
+	ErrCountrySyncURLDisabled = errors.New("remote country sync is disabled (referencedata.countrylisturl is not set)")
+)
+
+// countryDownloadTimeout bounds the download of the country list
+const countryDownloadTimeout = 2 * time.Minute
+
+// bundledCountries is the ISO 3166-1 list with UN M49 regions, as of the Axiom release
+//
+//go:embed data/iso3166_countries.csv
+var bundledCountries []byte
+
+// CountrySyncResult summarises a country sync
+type CountrySyncResult struct {
+	Source        string            `json:"source"`
+	Read          int               `json:"read"`
+	Created       int               `json:"created"`
+	Updated       int               `json:"updated"`
+	Unchanged     int               `json:"unchanged"`
+	Skipped       int               `json:"skipped"` // soft-deleted countries, left deleted
+	RejectedCount int               `json:"rejected_count"`
+	Rejected      []ImportRejection `json:"rejected"` // the first rejections
+}
+
+// countryColumns maps country list headers, upper-cased without spaces or punctuation, to fields
+var countryColumns = map[string]string{
+	"NAME":        "name",
+	"ALPHA2":      "alpha2",
+	"ALPHA3":      "alpha3",
+	"COUNTRYCODE": "numeric",
+	"NUMERIC":     "numeric",
+	"NUMERICCODE": "numeric",
+	"REGION":      "region",
+	"SUBREGION":   "sub_region",
+}
+
+// Seed adds the countries of the bundled dataset that are missing; existing countries are left
+// as they are
+func (s *countryService) Seed(ctx context.Context) (*CountrySyncResult, error) {
+	return s.sync(ctx, CountrySourceBundled, bytes.NewReader(bundledCountries), false)
+}
+
+// RemoteSyncEnabled reports whether a country list URL is configured
+func (s *countryService) RemoteSyncEnabled() bool {
+	return s.listURL != ""
+}
+
+// Sync brings the countries in line with a source: missing countries are added, and the name,
+// alpha-3 and numeric codes and regions of existing ones updated. Countries the source does not list
+// are left alone, as are active flags: withdrawing a country is a deactivation, with its cascade.
+func (s *countryService) Sync(ctx context.Context, source string) (*CountrySyncResult, error) {
+	switch source {
+	case "", CountrySourceBundled:
+		return s.sync(ctx, CountrySourceBundled, bytes.NewReader(bundledCountries), true)
+	case CountrySourceRemote:
+		if !s.RemoteSyncEnabled() {
+			return nil, ErrCountrySyncURLDisabled
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.listURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download country list: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to download country list: %s returned %s", s.listURL, resp.Status)
+		}
+		return s.sync(ctx, CountrySourceRemote, resp.Body, true)
+	default:
+		return nil, ErrUnknownCountrySource
+	}
+}
+
+func (s *countryService) sync(ctx context.Context, source string, file io.Reader, update bool) (*CountrySyncResult, error) {
+	defer s.lookups.Purge()
+	listed, err := parseCountryList(file)
+	if err != nil {
+		return nil, err
+	}
+	result := &CountrySyncResult{Source: source, Read: listed.read, RejectedCount: len(listed.rejected), Rejected: listed.rejected}
+	if len(result.Rejected) > importMaxRejections {
+		result.Rejected = result.Rejected[:importMaxRejections]
+	}
+
+	existing, err := s.repo.FindAllIncludingDeleted()
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]*domain.Country, len(existing))
+	for _, country := range existing {
+		byCode[country.Code] = country
+	}
+
+	for _, entry := range listed.countries {
+		current, ok := byCode[entry.Code]
+		switch {
+		case !ok:
+			entry.Active = true
+			if err := s.repo.Create(ctx, entry); err != nil {
+				return nil, fmt.Errorf("failed to create country %s: %w", entry.Code, err)
+			}
+			result.Created++
+		case current.DeletedAt.Valid:
+			result.Skipped++
+		case !update || (current.Name == entry.Name && current.Alpha3Code == entry.Alpha3Code &&
+			current.NumericCode == entry.NumericCode && current.Region == entry.Region && current.SubRegion == entry.SubRegion):
+			result.Unchanged++
+		default:
+			current.Name, current.Alpha3Code, current.NumericCode = entry.Name, entry.Alpha3Code, entry.NumericCode
+			current.Region, current.SubRegion = entry.Region, entry.SubRegion
+			if err := s.repo.Update(ctx, current); err != nil {
+				return nil, fmt.Errorf("failed to update country %s: %w", entry.Code, err)
+			}
+			result.Updated++
+		}
+	}
+	return result, nil
+}
+
+// countryList is a parsed country list
+type countryList struct {
+	countries []*domain.Country
+	read      int
+	rejected  []ImportRejection
+}
+
+// parseCountryList reads a comma, semicolon or tab separated country list. The header must name
+// the name, alpha-2 and alpha-3 columns; numeric code, region and sub-region are optional.
+func parseCountryList(file io.Reader) (*countryList, error) {
+	reader := bufio.NewReader(file)
+	header, err := reader.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	firstLine, _, _ := strings.Cut(string(header), "\n")
+
+	records := csv.NewReader(reader)
+	records.Comma = bicFileDelimiter(firstLine)
+	records.FieldsPerRecord = -1
+
+	columns, err := records.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", ErrInvalidCountryFile, err)
+	}
+	index := map[string]int{}
+	for i, name := range columns {
+		key := strings.Map(func(r rune) rune {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, strings.ToUpper(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := countryColumns[key]; ok {
+			if _, seen := index[field]; !seen {
+				index[field] = i
+			}
+		}
+	}
+	for _, required := range []string{"name", "alpha2", "alpha3"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("%w: header must name the name, alpha-2 and alpha-3 columns", ErrInvalidCountryFile)
+		}
+	}
+
+	list := &countryList{}
+	seen := make(map[string]bool)
+	for {
+		record, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line := 0
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.Line
+		} else if err == nil {
+			line, _ = records.FieldPos(0)
+		}
+		reject := func(format string, args ...any) {
+			list.rejected = append(list.rejected, ImportRejection{Line: line, Error: fmt.Sprintf(format, args...)})
+		}
+		if err != nil {
+			list.read++
+			reject("%v", err)
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		list.read++
+
+		country := &domain.Country{
+			Code:        strings.ToUpper(field("alpha2")),
+			Name:        field("name"),
+			Alpha3Code:  strings.ToUpper(field("alpha3")),
+			NumericCode: field("numeric"),
+			Region:      field("region"),
+			SubRegion:   field("sub_region"),
+		}
+		if n := len(country.NumericCode); n > 0 && n < 3 {
+			country.NumericCode = strings.Repeat("0", 3-n) + country.NumericCode
+		}
+		switch {
+		case !isLetters(country.Code, 2):
+			reject("alpha-2 code %q must be 2 letters", country.Code)
+		case !isLetters(country.Alpha3Code, 3):
+			reject("%s: alpha-3 code %q must be 3 letters", country.Code, country.Alpha3Code)
+		case country.NumericCode != "" && !isDigits(country.NumericCode, 3):
+			reject("%s: numeric code %q must be 3 digits", country.Code, country.NumericCode)
+		case country.Name == "":
+			reject("%s: name is required", country.Code)
+		case seen[country.Code]:
+			reject("%s: listed twice", country.Code)
+		default:
+			seen[country.Code] = true
+			list.countries = append(list.countries, country)
+		}
+	}
+	return list, nil
+}
+
+// isLetters reports whether s is n upper-case letters
+func isLetters(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits reports whether s is n digits
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/techie2000/axiom/internal/cache"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CurrencyService manages currency reference data
+type CurrencyService interface {
+	Create(ctx context.Context, currency *domain.Currency) error
+	GetByID(id string) (*domain.Currency, error)
+	GetByCode(code string) (*domain.Currency, error)
+	GetAll(limit, offset int) ([]*domain.Currency, error)
+	Update(ctx context.Context, currency *domain.Currency) error
+	Delete(ctx context.Context, id string) error
+	Seed(ctx context.Context) (*CurrencySyncResult, error)
+	Sync(ctx context.Context, source string) (*CurrencySyncResult, error)
+	RemoteSyncEnabled() bool
+}
+
+type currencyService struct {
+	repo    repository.CurrencyRepository
+	cascade *referenceCascade
+	listURL string
+	client  *http.Client
+	lookups *cache.LRU[string, *domain.Currency] // GetByCode results, nil for codes not found
+}
+
+// NewCurrencyService creates a currency service; deactivating a currency applies deactivationPolicy to its dependents,
+// and a remote sync downloads ISO 4217 list one from listURL. lookups, if not nil,
+// caches GetByCode for the per-record lookups of syncs and imports.
+func NewCurrencyService(repo repository.CurrencyRepository, cascadeRepo repository.ReferenceCascadeRepository, deactivationPolicy, listURL string, lookups *cache.LRU[string, *domain.Currency]) CurrencyService {
+	return &currencyService{
+		repo:    repo,
+		cascade: newReferenceCascade(cascadeRepo, deactivationPolicy),
+		listURL: strings.TrimSpace(listURL),
+		client:  &http.Client{Timeout: currencyDownloadTimeout},
+		lookups: lookups,
+	}
+}
+
+// Create saves a new currency; an ISO 4217 code takes its numeric code, minor units and flags from
+// the standard rather than the request
+func (s *currencyService) Create(ctx context.Context, currency *domain.Currency) error {
+	defer s.lookups.Purge()
+	applyISOCurrency(currency)
+	return s.repo.Create(ctx, currency)
+}
+
+func (s *currencyService) GetByID(id string) (*domain.Currency, error) {
+	return s.repo.FindByID(id)
+}
+
+// GetByCode returns the currency with a code, from the lookup cache if it holds it; a code not
+// found is cached as such
+func (s *currencyService) GetByCode(code string) (*domain.Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	currency, ok := s.lookups.Get(code)
+	if !ok {
+		var err error
+		currency, err = s.repo.FindByCode(code)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		s.lookups.Add(code, currency)
+	}
+	if currency == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *currency // a copy, as the cached currency is shared
+	return &found, nil
+}
+
+func (s *currencyService) GetAll(limit, offset int) ([]*domain.Currency, error) {
+	return s.repo.FindAll(limit, offset)
+}
+
+// Update saves the currency; the ISO 4217 fields are owned by the currency sync and carried over,
+// as are the minor units of a currency the sync maintains
+func (s *currencyService) Update(ctx context.Context, currency *domain.Currency) error {
+	defer s.lookups.Purge()
+	existing, err := s.repo.FindByID(currency.ID.String())
+	if err != nil {
+		return err
+	}
+	currency.NumericCode, currency.Fund, currency.PreciousMetal = existing.NumericCode, existing.Fund, existing.PreciousMetal
+	if existing.NumericCode != "" {
+		currency.DecimalPlaces = existing.DecimalPlaces
+	}
+	if !existing.Active || currency.Active {
+		return s.repo.Update(ctx, currency)
+	}
+
+	dependents, err := s.cascade.repo.FindCurrencyDependents(currency.ID)
+	if err != nil {
+		return err
+	}
+	return s.cascade.deactivate(ctx, currency, "currency", existing.Code, domain.DQRuleInactiveCurrencyReference, dependents)
+}
+
+func (s *currencyService) Delete(ctx context.Context, id string) error {
+	defer s.lookups.Purge()
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// Sources of a currency sync
+const (
+	CurrencySourceBundled = "bundled" // the ISO 4217 dataset built into Axiom
+	CurrencySourceRemote  = "remote"  // referencedata.currencylisturl
+)
+
+// Errors syncing currencies
+var (
+	ErrInvalidCurrencyFile     = errors.New("invalid ISO 4217 list file")
+	ErrUnknownCurrencySource   = errors.New("currency sync source must be bundled or remote")
+	ErrCurrencySyncURLDisabled = errors.New("remote currency sync is disabled (referencedata.currencylisturl is not set)")
+)
+
+// currencyDownloadTimeout bounds the download of the ISO 4217 list
+const currencyDownloadTimeout = 2 * time.Minute
+
+// defaultDecimalPlaces applies to currencies ISO 4217 does not list, when none is given
+const defaultDecimalPlaces = 2
+
+// preciousMetalCodes are the ISO 4217 codes of precious metals
+var preciousMetalCodes = map[string]bool{"XAU": true, "XAG": true, "XPT": true, "XPD": true}
+
+// bundledCurrencies is ISO 4217 list one, one entry per code, as of the Axiom release
+//
+//go:embed data/iso4217_list_one.xml
+var bundledCurrencies []byte
+
+// isoCurrencies is the bundled list by code, for filling in currencies created through the API
+var isoCurrencies = func() map[string]*domain.Currency {
+	list, err := parseCurrencyList(bytes.NewReader(bundledCurrencies))
+	if err != nil {
+		panic(fmt.Sprintf("bundled ISO 4217 list: %v", err))
+	}
+	byCode := make(map[string]*domain.Currency, len(list.currencies))
+	for _, currency := range list.currencies {
+		byCode[currency.Code] = currency
+	}
+	return byCode
+}()
+
+// CurrencySyncResult summarises a currency sync
+type CurrencySyncResult struct {
+	Source        string            `json:"source"`
+	Published     string            `json:"published,omitempty"` // publication date of the list, if it says
+	Read          int               `json:"read"`
+	Created       int               `json:"created"`
+	Updated       int               `json:"updated"`
+	Unchanged     int               `json:"unchanged"`
+	Skipped       int               `json:"skipped"` // soft-deleted currencies, left deleted
+	RejectedCount int               `json:"rejected_count"`
+	Rejected      []ImportRejection `json:"rejected"` // the first rejections
+}
+
+// Seed adds the currencies of the bundled dataset that are missing; existing currencies are left
+// as they are
+func (s *currencyService) Seed(ctx context.Context) (*CurrencySyncResult, error) {
+	return s.sync(ctx, CurrencySourceBundled, bytes.NewReader(bundledCurrencies), false)
+}
+
+// RemoteSyncEnabled reports whether an ISO 4217 list URL is configured
+func (s *currencyService) RemoteSyncEnabled() bool {
+	return s.listURL != ""
+}
+
+// Sync brings the currencies in line with a source: missing currencies are added, and the name,
+// numeric code, minor units and fund and precious metal flags of existing ones updated. Currencies
+// the source does not list are left alone, as are symbols and active flags: withdrawing a currency
+// is a deactivation, with its cascade.
+func (s *currencyService) Sync(ctx context.Context, source string) (*CurrencySyncResult, error) {
+	switch source {
+	case "", CurrencySourceBundled:
+		return s.sync(ctx, CurrencySourceBundled, bytes.NewReader(bundledCurrencies), true)
+	case CurrencySourceRemote:
+		if !s.RemoteSyncEnabled() {
+			return nil, ErrCurrencySyncURLDisabled
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.listURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download ISO 4217 list: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to download ISO 4217 list: %s returned %s", s.listURL, resp.Status)
+		}
+		return s.sync(ctx, CurrencySourceRemote, resp.Body, true)
+	default:
+		return nil, ErrUnknownCurrencySource
+	}
+}
+
+func (s *currencyService) sync(ctx context.Context, source string, file io.Reader, update bool) (*CurrencySyncResult, error) {
+	defer s.lookups.Purge()
+	listed, err := parseCurrencyList(file)
+	if err != nil {
+		return nil, err
+	}
+	result := &CurrencySyncResult{
+		Source:        source,
+		Published:     listed.published,
+		Read:          listed.read,
+		RejectedCount: len(listed.rejected),
+		Rejected:      listed.rejected,
+	}
+	if len(result.Rejected) > importMaxRejections {
+		result.Rejected = result.Rejected[:importMaxRejections]
+	}
+
+	existing, err := s.repo.FindAllIncludingDeleted()
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]*domain.Currency, len(existing))
+	for _, currency := range existing {
+		byCode[currency.Code] = currency
+	}
+
+	for _, entry := range listed.currencies {
+		current, ok := byCode[entry.Code]
+		switch {
+		case !ok:
+			entry.Active = true
+			if err := s.repo.Create(ctx, entry); err != nil {
+				return nil, fmt.Errorf("failed to create currency %s: %w", entry.Code, err)
+			}
+			result.Created++
+		case current.DeletedAt.Valid:
+			result.Skipped++
+		case !update || (current.Name == entry.Name && current.NumericCode == entry.NumericCode &&
+			current.DecimalPlaces == entry.DecimalPlaces && current.Fund == entry.Fund && current.PreciousMetal == entry.PreciousMetal):
+			result.Unchanged++
+		default:
+			current.Name, current.NumericCode, current.DecimalPlaces = entry.Name, entry.NumericCode, entry.DecimalPlaces
+			current.Fund, current.PreciousMetal = entry.Fund, entry.PreciousMetal
+			if err := s.repo.Update(ctx, current); err != nil {
+				return nil, fmt.Errorf("failed to update currency %s: %w", entry.Code, err)
+			}
+			result.Updated++
+		}
+	}
+	return result, nil
+}
+
+// iso4217Document is ISO 4217 list one as the maintenance agency publishes it
+type iso4217Document struct {
+	XMLName   xml.Name `xml:"ISO_4217"`
+	Published string   `xml:"Pblshd,attr"`
+	Entries   []struct {
+		Country string `xml:"CtryNm"`
+		Name    struct {
+			Value  string `xml:",chardata"`
+			IsFund bool   `xml:"IsFund,attr"`
+		} `xml:"CcyNm"`
+		Code       string `xml:"Ccy"`
+		Number     string `xml:"CcyNbr"`
+		MinorUnits string `xml:"CcyMnrUnts"`
+	} `xml:"CcyTbl>CcyNtry"`
+}
+
+// currencyList is a parsed ISO 4217 list
+type currencyList struct {
+	currencies []*domain.Currency
+	published  string
+	read       int
+	rejected   []ImportRejection
+}
+
+// parseCurrencyList reads ISO 4217 list one (XML). The list has an entry per country using a
+// currency; a code keeps its first entry, and entries without a code (e.g. Antarctica) are skipped.
+// Minor units of N.A. (e.g. gold, SDR) become 0 decimal places. Rejections are numbered by entry.
+func parseCurrencyList(file io.Reader) (*currencyList, error) {
+	var doc iso4217Document
+	if err := xml.NewDecoder(file).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCurrencyFile, err)
+	}
+
+	list := &currencyList{published: doc.Published}
+	seen := make(map[string]bool)
+	for i, entry := range doc.Entries {
+		code := strings.ToUpper(strings.TrimSpace(entry.Code))
+		if code == "" || seen[code] {
+			continue
+		}
+		list.read++
+		reject := func(format string, args ...any) {
+			list.rejected = append(list.rejected, ImportRejection{Line: i + 1, Error: fmt.Sprintf(format, args...)})
+		}
+
+		currency := &domain.Currency{
+			Code:          code,
+			Name:          strings.Join(strings.Fields(entry.Name.Value), " "),
+			NumericCode:   strings.TrimSpace(entry.Number),
+			Fund:          entry.Name.IsFund,
+			PreciousMetal: preciousMetalCodes[code],
+		}
+		if n := len(currency.NumericCode); n > 0 && n < 3 {
+			currency.NumericCode = strings.Repeat("0", 3-n) + currency.NumericCode
+		}
+		minorUnits := strings.TrimSpace(entry.MinorUnits)
+		switch {
+		case !isLetters(code, 3):
+			reject("code %q must be 3 letters", code)
+			continue
+		case currency.NumericCode != "" && !isDigits(currency.NumericCode, 3):
+			reject("%s: numeric code %q must be 3 digits", code, currency.NumericCode)
+			continue
+		case currency.Name == "":
+			reject("%s: name is required", code)
+			continue
+		case minorUnits == "" || strings.EqualFold(minorUnits, "N.A."):
+			currency.DecimalPlaces = 0
+		default:
+			places, err := strconv.Atoi(minorUnits)
+			if err != nil || places < 0 || places > 6 {
+				reject("%s: minor units %q must be a number from 0 to 6 or N.A.", code, minorUnits)
+				continue
+			}
+			currency.DecimalPlaces = places
+		}
+		seen[code] = true
+		list.currencies = append(list.currencies, currency)
+	}
+	return list, nil
+}
+
+// applyISOCurrency fills the ISO 4217 fields of a currency being created: a code the bundled list
+// has takes its numeric code, minor units and flags; any other code gets defaultDecimalPlaces if
+// it has none
+func applyISOCurrency(currency *domain.Currency) {
+	currency.Code = strings.ToUpper(strings.TrimSpace(currency.Code))
+	iso, ok := isoCurrencies[currency.Code]
+	if !ok {
+		if currency.DecimalPlaces == 0 {
+			currency.DecimalPlaces = defaultDecimalPlaces
+		}
+		return
+	}
+	currency.NumericCode, currency.DecimalPlaces = iso.NumericCode, iso.DecimalPlaces
+	currency.Fund, currency.PreciousMetal = iso.Fund, iso.PreciousMetal
+}
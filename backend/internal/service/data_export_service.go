@@ -0,0 +1,527 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/jsonfmt"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/xuri/excelize/v2"
+)
+
+// Errors exporting master data
+var (
+	ErrUnknownExportKind   = errors.New("export type must be countries, currencies, entities, instruments, accounts, ssis or lei")
+	ErrUnknownExportFormat = errors.New("export format must be csv, xlsx, json or ndjson")
+	ErrExportTooLarge      = errors.New("too many rows for an Excel sheet; narrow the filters or export as csv or json")
+)
+
+const (
+	dataExportFlushRows = 1000      // rows written between flushes of the response
+	dataExportXLSXRows  = 1_048_575 // rows an Excel sheet holds below its header
+)
+
+// dataExportTypes are the records each export kind writes
+var dataExportTypes = map[string]reflect.Type{
+	domain.ExportKindCountries:   reflect.TypeOf(domain.Country{}),
+	domain.ExportKindCurrencies:  reflect.TypeOf(domain.Currency{}),
+	domain.ExportKindEntities:    reflect.TypeOf(domain.Entity{}),
+	domain.ExportKindInstruments: reflect.TypeOf(domain.Instrument{}),
+	domain.ExportKindAccounts:    reflect.TypeOf(domain.Account{}),
+	domain.ExportKindSSIs:        reflect.TypeOf(domain.SSI{}),
+	domain.ExportKindLEI:         reflect.TypeOf(domain.LEIRecord{}),
+}
+
+// dataExportContentTypes are the media types of the export formats
+var dataExportContentTypes = map[string]string{
+	domain.ExportFormatCSV:    "text/csv; charset=utf-8",
+	domain.ExportFormatJSON:   "application/json",
+	domain.ExportFormatNDJSON: "application/x-ndjson",
+	domain.ExportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// leiExportFilters are the filters of an LEI export, named as on GET /lei
+var leiExportFilters = map[string]bool{
+	"search": true, "q": true, "status": true, "category": true, "country": true,
+	"legalForm": true, "lou": true, "sortBy": true, "sortOrder": true,
+}
+
+// DataExport is a checked export, ready to be written
+type DataExport struct {
+	Kind        string
+	Format      string // ExportFormatCSV, ExportFormatXLSX, ExportFormatJSON or ExportFormatNDJSON
+	Rows        int64  // records matching the filters when the export was prepared
+	ContentType string
+	FileName    string
+	Masked      map[string]bool // fields masked as jsonfmt.MaskFields masks them; in CSV and Excel, other values are left empty
+
+	query *query.ListQuery
+	lei   repository.LEIFilter
+}
+
+// DataExportService writes whole filtered tables of master data or LEI records as CSV, Excel or
+// JSON (an array or JSON Lines), reading and writing one record at a time so that exports of any
+// size stream
+type DataExportService interface {
+	// Prepare checks the kind, format and filters of an export and counts its rows. Filters are
+	// those of the kind's list endpoint; invalid ones are a query.ValidationError.
+	Prepare(kind, format string, filters url.Values) (*DataExport, error)
+	// Write streams the export to w, flushing w as it goes if it can be flushed, and returns
+	// the rows written
+	Write(export *DataExport, w io.Writer) (int64, error)
+	// StreamLEI calls fn with each LEI record matching the filters of GET /lei, as it is read,
+	// until fn returns an error; invalid filters are a query.ValidationError
+	StreamLEI(filters url.Values, fn func(record *domain.LEIRecord) error) error
+}
+
+type dataExportService struct {
+	exports repository.DataExportRepository
+}
+
+// NewDataExportService creates a new data export service
+func NewDataExportService(exports repository.DataExportRepository) DataExportService {
+	return &dataExportService{exports: exports}
+}
+
+func (s *dataExportService) Prepare(kind, format string, filters url.Values) (*DataExport, error) {
+	if _, ok := dataExportTypes[kind]; !ok {
+		return nil, ErrUnknownExportKind
+	}
+	format = strings.ToUpper(format)
+	if format == "" {
+		format = domain.ExportFormatCSV
+	}
+	contentType, ok := dataExportContentTypes[format]
+	if !ok {
+		return nil, ErrUnknownExportFormat
+	}
+
+	export := &DataExport{
+		Kind:        kind,
+		Format:      format,
+		ContentType: contentType,
+		FileName:    fmt.Sprintf("%s_%s.%s", kind, time.Now().UTC().Format("20060102T150405Z"), strings.ToLower(format)),
+	}
+
+	var err error
+	if kind == domain.ExportKindLEI {
+		if export.lei, err = leiExportFilter(filters); err != nil {
+			return nil, err
+		}
+		export.Rows, err = s.exports.CountLEI(export.lei)
+	} else {
+		// An export has every matching row; pagination does not apply
+		values := url.Values{}
+		for key, vals := range filters {
+			if key != "limit" && key != "offset" {
+				values[key] = vals
+			}
+		}
+		if export.query, err = query.Parse(values); err != nil {
+			return nil, err
+		}
+		export.Rows, err = s.exports.Count(kind, export.query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if format == domain.ExportFormatXLSX && export.Rows > dataExportXLSXRows {
+		return nil, ErrExportTooLarge
+	}
+	return export, nil
+}
+
+func (s *dataExportService) StreamLEI(filters url.Values, fn func(record *domain.LEIRecord) error) error {
+	filter, err := leiExportFilter(filters)
+	if err != nil {
+		return err
+	}
+	return s.exports.StreamLEI(filter, fn)
+}
+
+// leiExportFilter reads the filters of an LEI export, with the defaults of GET /lei: name order
+// unless a full-text query ranks by relevance
+func leiExportFilter(filters url.Values) (repository.LEIFilter, error) {
+	for key := range filters {
+		if !leiExportFilters[key] {
+			return repository.LEIFilter{}, &query.ValidationError{Message: fmt.Sprintf("Unknown filter field '%s'", key)}
+		}
+	}
+	return LEIListFilter(filters), nil
+}
+
+func (s *dataExportService) Write(export *DataExport, w io.Writer) (int64, error) {
+	columns := maskColumns(dataExportColumns(dataExportTypes[export.Kind]), export.Masked)
+	var out exportEncoder
+	switch export.Format {
+	case domain.ExportFormatCSV:
+		out = &csvExportEncoder{csv: csv.NewWriter(w), w: w, columns: columns}
+	case domain.ExportFormatJSON:
+		out = newJSONExportEncoder(w, false, export.Masked)
+	case domain.ExportFormatNDJSON:
+		out = newJSONExportEncoder(w, true, export.Masked)
+	case domain.ExportFormatXLSX:
+		out = &xlsxExportEncoder{w: w, columns: columns}
+	default:
+		return 0, ErrUnknownExportFormat
+	}
+	defer out.close()
+	if err := out.begin(); err != nil {
+		return 0, err
+	}
+
+	var rows int64
+	write := func(record any) error {
+		if ssi, ok := record.(*domain.SSI); ok {
+			if err := decryptAccounts(ssi); err != nil {
+				return err
+			}
+		}
+		if err := out.write(reflect.ValueOf(record).Elem()); err != nil {
+			return err
+		}
+		rows++
+		if rows%dataExportFlushRows == 0 {
+			return out.flush()
+		}
+		return nil
+	}
+
+	var err error
+	if export.Kind == domain.ExportKindLEI {
+		err = s.exports.StreamLEI(export.lei, func(record *domain.LEIRecord) error { return write(record) })
+	} else {
+		err = s.exports.Stream(export.Kind, export.query, write)
+	}
+	if err != nil {
+		return rows, err
+	}
+	return rows, out.end()
+}
+
+// dataExportColumn is a field written as a CSV or Excel column
+type dataExportColumn struct {
+	name   string // the field's JSON name
+	index  []int
+	masked bool
+}
+
+// maskColumns marks the columns of the masked fields
+func maskColumns(columns []dataExportColumn, masked map[string]bool) []dataExportColumn {
+	for i := range columns {
+		columns[i].masked = masked[columns[i].name]
+	}
+	return columns
+}
+
+// ExportedMaskedFields returns the fields of masked that an export of kind writes, sorted; an
+// empty kind is an LEI export
+func ExportedMaskedFields(kind string, masked map[string]bool) []string {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = domain.ExportKindLEI
+	}
+	t, ok := dataExportTypes[kind]
+	if !ok {
+		return nil
+	}
+	return maskedColumnNames(masked, t)
+}
+
+// maskedColumnNames returns the fields of masked among the columns of the record types, sorted
+func maskedColumnNames(masked map[string]bool, types ...reflect.Type) []string {
+	found := map[string]bool{}
+	for _, t := range types {
+		for _, column := range dataExportColumns(t) {
+			if masked[column.name] {
+				found[column.name] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// dataExportColumns are the scalar fields of a record type, by JSON name, in declaration order.
+// Associations, lists and fields that are not stored (gorm:"-") are left out.
+func dataExportColumns(t reflect.Type) []dataExportColumn {
+	var columns []dataExportColumn
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous || !field.IsExported() || strings.HasPrefix(field.Tag.Get("gorm"), "-") {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Interface:
+			continue
+		case reflect.Struct:
+			if fieldType != timeType {
+				continue
+			}
+		}
+		columns = append(columns, dataExportColumn{name: name, index: field.Index})
+	}
+	return columns
+}
+
+// dataExportCell is a column's value for a CSV or Excel cell, masked if the column is
+func dataExportCell(record reflect.Value, column dataExportColumn) any {
+	value := dataExportValue(record.FieldByIndex(column.index))
+	if !column.masked {
+		return value
+	}
+	if text, ok := value.(string); ok && text != "" {
+		return jsonfmt.MaskString(text)
+	}
+	return ""
+}
+
+// dataExportValue is a field's value for a CSV or Excel cell: numbers and booleans as they are,
+// times as RFC3339 in UTC, and anything else as text. Nil and zero times are empty.
+func dataExportValue(v reflect.Value) any {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	case uuidType:
+		return v.Interface().(uuid.UUID).String()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// exportEncoder writes the records of an export in one format
+type exportEncoder interface {
+	begin() error
+	write(record reflect.Value) error
+	flush() error
+	end() error
+	close() // releases what the encoder holds, whether or not it ended
+}
+
+// flushWriter flushes w if it buffers, e.g. an HTTP response sent in chunks
+func flushWriter(w io.Writer) {
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+}
+
+type csvExportEncoder struct {
+	csv     *csv.Writer
+	w       io.Writer
+	columns []dataExportColumn
+	row     []string
+}
+
+func (e *csvExportEncoder) begin() error {
+	header := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		header[i] = column.name
+	}
+	e.row = make([]string, len(e.columns))
+	return e.csv.Write(header)
+}
+
+func (e *csvExportEncoder) write(record reflect.Value) error {
+	for i, column := range e.columns {
+		switch value := dataExportCell(record, column).(type) {
+		case string:
+			e.row[i] = value
+		case float64:
+			e.row[i] = strconv.FormatFloat(value, 'f', -1, 64)
+		default:
+			e.row[i] = fmt.Sprint(value)
+		}
+	}
+	return e.csv.Write(e.row)
+}
+
+func (e *csvExportEncoder) flush() error {
+	e.csv.Flush()
+	flushWriter(e.w)
+	return e.csv.Error()
+}
+
+func (e *csvExportEncoder) end() error {
+	return e.flush()
+}
+
+func (e *csvExportEncoder) close() {}
+
+// jsonExportEncoder writes the records as the API returns them, one per line: as an array, or
+// as JSON Lines with nothing between them
+type jsonExportEncoder struct {
+	json    *json.Encoder
+	w       io.Writer
+	lines   bool
+	masked  map[string]bool
+	buf     bytes.Buffer // a record being masked
+	written bool
+}
+
+// newJSONExportEncoder creates a JSON encoder; records with masked fields are encoded into a
+// buffer to mask them before writing
+func newJSONExportEncoder(w io.Writer, lines bool, masked map[string]bool) *jsonExportEncoder {
+	e := &jsonExportEncoder{w: w, lines: lines, masked: masked}
+	if len(masked) > 0 {
+		e.json = json.NewEncoder(&e.buf)
+	} else {
+		e.json = json.NewEncoder(w)
+	}
+	return e
+}
+
+func (e *jsonExportEncoder) begin() error {
+	if e.lines {
+		return nil
+	}
+	_, err := io.WriteString(e.w, "[\n")
+	return err
+}
+
+func (e *jsonExportEncoder) write(record reflect.Value) error {
+	if e.written && !e.lines {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.written = true
+	if len(e.masked) == 0 {
+		return e.json.Encode(record.Addr().Interface())
+	}
+	e.buf.Reset()
+	if err := e.json.Encode(record.Addr().Interface()); err != nil {
+		return err
+	}
+	_, err := e.w.Write(jsonfmt.MaskFields(e.buf.Bytes(), e.masked))
+	return err
+}
+
+func (e *jsonExportEncoder) flush() error {
+	flushWriter(e.w)
+	return nil
+}
+
+func (e *jsonExportEncoder) end() error {
+	var err error
+	if !e.lines {
+		_, err = io.WriteString(e.w, "]\n")
+	}
+	flushWriter(e.w)
+	return err
+}
+
+func (e *jsonExportEncoder) close() {}
+
+// xlsxExportEncoder writes a single sheet. Excel files are zip archives, so nothing is sent
+// until the last row; the stream writer keeps rows on disk rather than in memory meanwhile.
+type xlsxExportEncoder struct {
+	w       io.Writer
+	columns []dataExportColumn
+	file    *excelize.File
+	sheet   *excelize.StreamWriter
+	row     int
+}
+
+func (e *xlsxExportEncoder) begin() error {
+	e.file = excelize.NewFile()
+	sheet, err := e.file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return err
+	}
+	e.sheet = sheet
+
+	header := make([]any, len(e.columns))
+	for i, column := range e.columns {
+		header[i] = column.name
+	}
+	return e.setRow(header)
+}
+
+func (e *xlsxExportEncoder) setRow(values []any) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	return e.sheet.SetRow(cell, values)
+}
+
+func (e *xlsxExportEncoder) write(record reflect.Value) error {
+	values := make([]any, len(e.columns))
+	for i, column := range e.columns {
+		values[i] = dataExportCell(record, column)
+	}
+	return e.setRow(values)
+}
+
+func (e *xlsxExportEncoder) flush() error {
+	return nil
+}
+
+func (e *xlsxExportEncoder) end() error {
+	if err := e.sheet.Flush(); err != nil {
+		return err
+	}
+	if err := e.file.Write(e.w); err != nil {
+		return err
+	}
+	flushWriter(e.w)
+	return nil
+}
+
+func (e *xlsxExportEncoder) close() {
+	if e.file != nil {
+		e.file.Close()
+	}
+}
@@ -0,0 +1,533 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// importKind describes the columns of one kind of master data and how a row is written
+type importKind struct {
+	columns []string
+	key     func(fields map[string]string) string
+	apply   func(s *dataImportService, run *importRun, fields map[string]string) (id, action string, changes []string, err error)
+}
+
+// importKinds are the kinds of master data a file import loads, by name
+var importKinds = map[string]importKind{
+	domain.ImportKindCountries: {
+		columns: []string{"code", "name", "alpha3_code", "numeric_code", "region", "sub_region", "active"},
+		key:     func(fields map[string]string) string { return strings.ToUpper(fields["code"]) },
+		apply:   (*dataImportService).importCountry,
+	},
+	domain.ImportKindCurrencies: {
+		columns: []string{"code", "name", "symbol", "decimal_places", "active"},
+		key:     func(fields map[string]string) string { return strings.ToUpper(fields["code"]) },
+		apply:   (*dataImportService).importCurrency,
+	},
+	domain.ImportKindEntities: {
+		columns: []string{"id", "name", "registration_number", "business_id", "lei", "legal_form", "type", "active"},
+		key:     importKeyOr("name"),
+		apply:   (*dataImportService).importEntity,
+	},
+	domain.ImportKindInstruments: {
+		columns: []string{"id", "name", "type", "cfi", "isin", "issue_currency", "issue_currency_id", "primary_exchange", "active"},
+		key:     importKeyOr("name"),
+		apply:   (*dataImportService).importInstrument,
+	},
+	domain.ImportKindSSIs: {
+		columns: []string{"id", "entity_id", "settlement_currency", "settlement_currency_id", "instrument_id",
+			"beneficiary_name", "beneficiary_account", "beneficiary_bank", "beneficiary_bank_bic",
+			"intermediary_bank", "intermediary_bank_bic", "settlement_type", "place_of_settlement",
+			"cutoff_time", "cutoff_timezone", "valid_from", "valid_to", "active"},
+		key:   importKeyOr("beneficiary_account"),
+		apply: (*dataImportService).importSSI,
+	},
+}
+
+// importKeyOr keys rows by id, or by another column for rows creating a record
+func importKeyOr(column string) func(fields map[string]string) string {
+	return func(fields map[string]string) string {
+		if fields["id"] != "" {
+			return fields["id"]
+		}
+		return fields[column]
+	}
+}
+
+// checkColumns returns the columns of a file the kind does not have; a file with none of its
+// columns is rejected
+func (k importKind) checkColumns(columns []string) ([]string, error) {
+	ignored := []string{}
+	known := 0
+	for _, column := range columns {
+		switch {
+		case slices.Contains(k.columns, column):
+			known++
+		case column != "" && !slices.Contains(ignored, column):
+			ignored = append(ignored, column)
+		}
+	}
+	if known == 0 {
+		return nil, fmt.Errorf("%w: no known columns; expected some of %s", ErrInvalidImportFile, strings.Join(k.columns, ", "))
+	}
+	slices.Sort(ignored)
+	return ignored, nil
+}
+
+// importRun is the state of one import: the context its records are written with, whether it is
+// a dry run, the codes a dry run would create, and codes resolved to IDs
+type importRun struct {
+	ctx          context.Context
+	dryRun       bool
+	planned      map[string]bool // kind:code of records an earlier row of a dry run would create
+	currencyRepo repository.CurrencyRepository
+	currencies   map[string]uuid.UUID
+}
+
+func newImportRun(ctx context.Context, dryRun bool, currencyRepo repository.CurrencyRepository) *importRun {
+	return &importRun{
+		ctx:          ctx,
+		dryRun:       dryRun,
+		planned:      make(map[string]bool),
+		currencyRepo: currencyRepo,
+		currencies:   make(map[string]uuid.UUID),
+	}
+}
+
+// currencyID returns the ID of the currency with an ISO 4217 code
+func (r *importRun) currencyID(code string) (uuid.UUID, error) {
+	code = strings.ToUpper(code)
+	if id, ok := r.currencies[code]; ok {
+		return id, nil
+	}
+	currency, err := r.currencyRepo.FindByCode(code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, fmt.Errorf("unknown currency %s", code)
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	r.currencies[code] = currency.ID
+	return currency.ID, nil
+}
+
+// plan records that a dry run would create the record with a code, and reports whether an earlier
+// row already would; that row's record is then updated rather than created
+func (r *importRun) plan(kind, code string) bool {
+	key := kind + ":" + code
+	if r.planned[key] {
+		return true
+	}
+	r.planned[key] = true
+	return false
+}
+
+// saveImported writes the record a row creates or updates. An update is compared with before, the
+// record as stored, and not written when no field changes. A dry run validates the record instead
+// of writing it (validate may be nil when saving checks nothing beyond the row's values).
+func saveImported[T any](run *importRun, action string, before []byte, record *T, validate func(*T) error, create, update func(context.Context, *T) error) (string, []string, error) {
+	var changes []string
+	if action == domain.ImportActionUpdated {
+		var err error
+		if changes, err = changedFields(before, record); err != nil {
+			return "", nil, err
+		}
+		if len(changes) == 0 {
+			return domain.ImportActionUnchanged, nil, nil
+		}
+	}
+	switch {
+	case run.dryRun && validate != nil:
+		return action, changes, validate(record)
+	case run.dryRun:
+		return action, changes, nil
+	case action == domain.ImportActionCreated:
+		return action, nil, create(run.ctx, record)
+	default:
+		return action, changes, update(run.ctx, record)
+	}
+}
+
+// importSnapshot is a record as stored, for changedFields
+func importSnapshot(record any) []byte {
+	snapshot, _ := json.Marshal(record)
+	return snapshot
+}
+
+// changedFields lists the JSON fields of record that differ from a snapshot of it, leaving out
+// the timestamps maintained on save
+func changedFields(before []byte, record any) ([]string, error) {
+	after, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	var old, current map[string]json.RawMessage
+	if err := json.Unmarshal(before, &old); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &current); err != nil {
+		return nil, err
+	}
+	var changes []string
+	for name, value := range current {
+		if previous, ok := old[name]; !ok || !bytes.Equal(previous, value) {
+			changes = append(changes, name)
+		}
+	}
+	for name := range old {
+		if _, ok := current[name]; !ok {
+			changes = append(changes, name)
+		}
+	}
+	changes = slices.DeleteFunc(changes, func(name string) bool { return name == "created_at" || name == "updated_at" })
+	slices.Sort(changes)
+	return changes, nil
+}
+
+// importValues applies the values of a row to a record, collecting the errors; empty values
+// leave fields as they are
+type importValues struct {
+	fields map[string]string
+	errs   []string
+}
+
+func (v *importValues) fail(format string, args ...any) {
+	v.errs = append(v.errs, fmt.Sprintf(format, args...))
+}
+
+// require reports the columns a new record needs that the row leaves empty
+func (v *importValues) require(names ...string) {
+	for _, name := range names {
+		if v.fields[name] == "" {
+			v.fail("%s is required", name)
+		}
+	}
+}
+
+func (v *importValues) text(name string, target *string) {
+	if value := v.fields[name]; value != "" {
+		*target = value
+	}
+}
+
+func (v *importValues) upper(name string, target *string) {
+	if value := v.fields[name]; value != "" {
+		*target = strings.ToUpper(value)
+	}
+}
+
+func (v *importValues) textPtr(name string, target **string) {
+	if value := v.fields[name]; value != "" {
+		*target = &value
+	}
+}
+
+func (v *importValues) boolean(name string, target *bool) {
+	value := v.fields[name]
+	if value == "" {
+		return
+	}
+	switch strings.ToLower(value) {
+	case "y", "yes":
+		*target = true
+	case "n", "no":
+		*target = false
+	default:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			v.fail("%s %q must be true or false", name, value)
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (v *importValues) integer(name string, target *int) {
+	if value := v.fields[name]; value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			v.fail("%s %q must be a whole number", name, value)
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (v *importValues) date(name string, target *time.Time) {
+	if value := v.fields[name]; value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			v.fail("%s %q must be a date (YYYY-MM-DD)", name, value)
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (v *importValues) datePtr(name string, target **time.Time) {
+	var parsed time.Time
+	v.date(name, &parsed)
+	if !parsed.IsZero() {
+		*target = &parsed
+	}
+}
+
+func (v *importValues) uuidPtr(name string, target **uuid.UUID) {
+	if value := v.fields[name]; value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			v.fail("%s %q is not a UUID", name, value)
+			return
+		}
+		*target = &parsed
+	}
+}
+
+// currency sets a currency ID from a code column or an ID column
+func (v *importValues) currency(run *importRun, codeName, idName string, target **uuid.UUID) {
+	v.uuidPtr(idName, target)
+	if code := v.fields[codeName]; code != "" {
+		id, err := run.currencyID(code)
+		if err != nil {
+			v.fail("%s: %v", codeName, err)
+			return
+		}
+		*target = &id
+	}
+}
+
+func (v *importValues) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(v.errs, "; "))
+}
+
+// existingByID loads the record a row's id column names, or returns nil for a row without one
+func existingByID[T any](fields map[string]string, what string, find func(id string) (*T, error)) (*T, error) {
+	id := fields["id"]
+	if id == "" {
+		return nil, nil
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("id %q is not a UUID", id)
+	}
+	record, err := find(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("no %s with id %s", what, id)
+	}
+	return record, err
+}
+
+// importCountry creates or updates a country, matched by ISO 3166 alpha-2 code
+func (s *dataImportService) importCountry(run *importRun, fields map[string]string) (string, string, []string, error) {
+	code := strings.ToUpper(fields["code"])
+	if code == "" {
+		return "", "", nil, errors.New("code is required")
+	}
+	values := &importValues{fields: fields}
+	country, err := s.countryRepo.FindByCode(code)
+	action := domain.ImportActionUpdated
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound) && run.dryRun && run.plan(domain.ImportKindCountries, code):
+		country = &domain.Country{Code: code, Active: true}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		country, action = &domain.Country{Code: code, Active: true}, domain.ImportActionCreated
+		values.require("name")
+	case err != nil:
+		return "", "", nil, err
+	}
+	before := importSnapshot(country)
+	values.text("name", &country.Name)
+	values.upper("alpha3_code", &country.Alpha3Code)
+	values.text("numeric_code", &country.NumericCode)
+	values.text("region", &country.Region)
+	values.text("sub_region", &country.SubRegion)
+	values.boolean("active", &country.Active)
+	if err := values.err(); err != nil {
+		return "", "", nil, err
+	}
+
+	action, changes, err := saveImported(run, action, before, country, nil, s.countries.Create, s.countries.Update)
+	return importedID(country.ID), action, changes, err
+}
+
+// importCurrency creates or updates a currency, matched by ISO 4217 code
+func (s *dataImportService) importCurrency(run *importRun, fields map[string]string) (string, string, []string, error) {
+	code := strings.ToUpper(fields["code"])
+	if code == "" {
+		return "", "", nil, errors.New("code is required")
+	}
+	values := &importValues{fields: fields}
+	currency, err := s.currencyRepo.FindByCode(code)
+	action := domain.ImportActionUpdated
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound) && run.dryRun && run.plan(domain.ImportKindCurrencies, code):
+		currency = &domain.Currency{Code: code, Active: true}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		currency, action = &domain.Currency{Code: code, Active: true}, domain.ImportActionCreated
+		values.require("name")
+	case err != nil:
+		return "", "", nil, err
+	}
+	before := importSnapshot(currency)
+	values.text("name", &currency.Name)
+	values.text("symbol", &currency.Symbol)
+	values.integer("decimal_places", &currency.DecimalPlaces)
+	values.boolean("active", &currency.Active)
+	if err := values.err(); err != nil {
+		return "", "", nil, err
+	}
+
+	action, changes, err := saveImported(run, action, before, currency, nil, s.currencies.Create, s.currencies.Update)
+	return importedID(currency.ID), action, changes, err
+}
+
+// importEntity creates an entity, or updates the one its id names
+func (s *dataImportService) importEntity(run *importRun, fields map[string]string) (string, string, []string, error) {
+	entity, err := existingByID(fields, "entity", s.entities.GetByID)
+	if err != nil {
+		return "", "", nil, err
+	}
+	values := &importValues{fields: fields}
+	action := domain.ImportActionUpdated
+	if entity == nil {
+		entity, action = &domain.Entity{Active: true}, domain.ImportActionCreated
+		values.require("name")
+		values.textPtr("business_id", &entity.BusinessID)
+	} else if fields["business_id"] != "" && (entity.BusinessID == nil || *entity.BusinessID != fields["business_id"]) {
+		values.fail("business_id cannot be changed by an import")
+	}
+	// Addresses and provenance are not part of an import; leave them as they are
+	entity.Addresses, entity.AutoFilled = nil, nil
+	before := importSnapshot(entity)
+	values.text("name", &entity.Name)
+	values.text("registration_number", &entity.RegistrationNumber)
+	var lei string
+	values.upper("lei", &lei)
+	if lei != "" {
+		entity.LEI = &lei
+	}
+	values.upper("legal_form", &entity.LegalForm)
+	var entityType string
+	values.upper("type", &entityType)
+	if entityType != "" {
+		entity.Type = domain.EntityType(entityType)
+	}
+	values.boolean("active", &entity.Active)
+	if err := values.err(); err != nil {
+		return "", "", nil, err
+	}
+
+	action, changes, err := saveImported(run, action, before, entity, s.entities.Validate, s.entities.Create, s.entities.Update)
+	return importedID(entity.ID), action, changes, err
+}
+
+// importInstrument creates an instrument, with its ISIN if given, or updates the one its id names
+func (s *dataImportService) importInstrument(run *importRun, fields map[string]string) (string, string, []string, error) {
+	instrument, err := existingByID(fields, "instrument", s.instruments.GetByID)
+	if err != nil {
+		return "", "", nil, err
+	}
+	values := &importValues{fields: fields}
+	action := domain.ImportActionUpdated
+	isin := strings.ToUpper(fields["isin"])
+	if instrument == nil {
+		instrument, action = &domain.Instrument{Active: true}, domain.ImportActionCreated
+		values.require("name")
+		if isin != "" {
+			instrument.Codes = []domain.InstrumentCode{{CodeType: domain.CodeTypeISIN, CodeValue: isin, IdentifierLevel: domain.IdentifierLevelInternational}}
+		}
+	} else {
+		if isin != "" && !slices.ContainsFunc(instrument.Codes, func(code domain.InstrumentCode) bool {
+			return code.CodeType == domain.CodeTypeISIN && code.CodeValue == isin
+		}) {
+			values.fail("isin can only be set when the instrument is created")
+		}
+		// Codes are maintained through the instrument API; leave them as they are
+		instrument.Codes, instrument.IssueCurrency = nil, nil
+	}
+	before := importSnapshot(instrument)
+	values.text("name", &instrument.Name)
+	var instrumentType string
+	values.upper("type", &instrumentType)
+	if instrumentType != "" {
+		instrument.Type = domain.InstrumentType(instrumentType)
+	}
+	values.upper("cfi", &instrument.CFI)
+	values.currency(run, "issue_currency", "issue_currency_id", &instrument.IssueCurrencyID)
+	values.upper("primary_exchange", &instrument.PrimaryExchange)
+	values.boolean("active", &instrument.Active)
+	if err := values.err(); err != nil {
+		return "", "", nil, err
+	}
+
+	action, changes, err := saveImported(run, action, before, instrument, s.instruments.Validate, s.instruments.Create, s.instruments.Update)
+	return importedID(instrument.ID), action, changes, err
+}
+
+// importSSI creates an SSI, valid from the day of the import unless the row says, or updates the
+// one its id names
+func (s *dataImportService) importSSI(run *importRun, fields map[string]string) (string, string, []string, error) {
+	ssi, err := existingByID(fields, "SSI", s.ssis.GetByID)
+	if err != nil {
+		return "", "", nil, err
+	}
+	values := &importValues{fields: fields}
+	action := domain.ImportActionUpdated
+	if ssi == nil {
+		ssi, action = &domain.SSI{Active: true, ValidFrom: truncateToDate(time.Now().UTC())}, domain.ImportActionCreated
+		values.require("entity_id", "beneficiary_name", "beneficiary_account", "beneficiary_bank")
+		if fields["settlement_currency"] == "" && fields["settlement_currency_id"] == "" {
+			values.fail("settlement_currency is required")
+		}
+	}
+	ssi.Entity, ssi.SettlementCurrency, ssi.Instrument = nil, nil, nil
+	before := importSnapshot(ssi)
+	values.uuidPtr("entity_id", &ssi.EntityID)
+	values.currency(run, "settlement_currency", "settlement_currency_id", &ssi.SettlementCurrencyID)
+	values.uuidPtr("instrument_id", &ssi.InstrumentID)
+	values.text("beneficiary_name", &ssi.BeneficiaryName)
+	values.text("beneficiary_account", &ssi.BeneficiaryAccount)
+	values.text("beneficiary_bank", &ssi.BeneficiaryBank)
+	values.upper("beneficiary_bank_bic", &ssi.BeneficiaryBankBIC)
+	values.text("intermediary_bank", &ssi.IntermediaryBank)
+	values.upper("intermediary_bank_bic", &ssi.IntermediaryBankBIC)
+	var settlementType string
+	values.upper("settlement_type", &settlementType)
+	if settlementType != "" {
+		ssi.SettlementType = domain.SettlementType(settlementType)
+	}
+	values.upper("place_of_settlement", &ssi.PlaceOfSettlement)
+	values.text("cutoff_time", &ssi.CutoffTime)
+	values.text("cutoff_timezone", &ssi.CutoffTimezone)
+	values.date("valid_from", &ssi.ValidFrom)
+	values.datePtr("valid_to", &ssi.ValidTo)
+	values.boolean("active", &ssi.Active)
+	if err := values.err(); err != nil {
+		return "", "", nil, err
+	}
+
+	action, changes, err := saveImported(run, action, before, ssi, s.ssis.Validate, s.ssis.Create, s.ssis.Update)
+	return importedID(ssi.ID), action, changes, err
+}
+
+// importedID is the ID reported for a row's record; a record a dry run would create has none
+func importedID(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
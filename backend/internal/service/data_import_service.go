@@ -0,0 +1,521 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Errors importing master data files
+var (
+	ErrInvalidImportFile   = errors.New("invalid import file")
+	ErrUnknownImportKind   = errors.New("import type must be countries, currencies, entities, instruments or ssis")
+	ErrUnknownImportFormat = errors.New("import format must be csv or json")
+	ErrImportNotRetryable  = errors.New("import is not FAILED or no longer has its file")
+)
+
+const (
+	dataImportMaxRows          = 10000           // bounds the rows of an import file
+	dataImportProgressInterval = 2 * time.Second // how often a running import saves its progress
+	dataImportStaleAfter       = 5 * time.Minute // a running import without progress for this long has lost its worker
+)
+
+// importRow is a row of an import file: its number and its values by column, all as text
+type importRow struct {
+	number int
+	fields map[string]string
+}
+
+// DataImportService loads master data files, row by row, through the same services as the API.
+// Imports are queued and processed in the background by workers on any replica.
+type DataImportService interface {
+	Import(ctx context.Context, kind, format, fileName string, file io.Reader, requestedBy string, dryRun bool) (*domain.ImportJob, error)
+	GetJob(id string) (*domain.ImportJob, error)
+	ListJobs(limit, offset int) ([]*domain.ImportJob, int64, error)
+	StartWorkers()
+	StopWorkers(ctx context.Context) error
+	RetryDeadLetteredImport(ctx context.Context, kind string, payload json.RawMessage) error
+}
+
+// dataImportPayload identifies a failed import in the dead letters
+type dataImportPayload struct {
+	ImportJobID string `json:"import_job_id"`
+}
+
+type dataImportService struct {
+	jobs         repository.ImportJobRepository
+	countries    CountryService
+	countryRepo  repository.CountryRepository
+	currencies   CurrencyService
+	currencyRepo repository.CurrencyRepository
+	entities     EntityService
+	instruments  InstrumentService
+	ssis         SSIService
+	registry     *JobRegistry
+	deadLetters  DeadLetterRecorder
+
+	workers      int
+	pollInterval time.Duration
+	wake         chan struct{}
+	stop         chan struct{}
+	stopOnce     sync.Once
+	running      sync.WaitGroup
+}
+
+// NewDataImportService creates a new data import service
+func NewDataImportService(jobs repository.ImportJobRepository, countries CountryService, countryRepo repository.CountryRepository, currencies CurrencyService, currencyRepo repository.CurrencyRepository, entities EntityService, instruments InstrumentService, ssis SSIService, registry *JobRegistry, deadLetters DeadLetterRecorder, cfg config.DataImportConfig) DataImportService {
+	workers := cfg.Workers
+	if workers < 0 {
+		log.Warn().Int("value", workers).Msg("Invalid dataimport.workers, using 0")
+		workers = 0
+	}
+	pollInterval, err := time.ParseDuration(cfg.PollInterval)
+	if err != nil || pollInterval <= 0 {
+		log.Warn().Str("value", cfg.PollInterval).Msg("Invalid dataimport.pollinterval, using 5s")
+		pollInterval = 5 * time.Second
+	}
+	return &dataImportService{
+		jobs:         jobs,
+		countries:    countries,
+		countryRepo:  countryRepo,
+		currencies:   currencies,
+		currencyRepo: currencyRepo,
+		entities:     entities,
+		instruments:  instruments,
+		ssis:         ssis,
+		registry:     registry,
+		deadLetters:  deadLetters,
+		workers:      workers,
+		pollInterval: pollInterval,
+		wake:         make(chan struct{}, 1),
+		stop:         make(chan struct{}),
+	}
+}
+
+// ImportFormatOf returns the format of an import file: format if given, else from the file
+// name's extension, else CSV
+func ImportFormatOf(format, fileName string) string {
+	if format = strings.ToLower(strings.TrimSpace(format)); format != "" {
+		return format
+	}
+	if strings.EqualFold(filepath.Ext(fileName), ".json") {
+		return domain.ImportFormatJSON
+	}
+	return domain.ImportFormatCSV
+}
+
+// Import checks that a CSV or JSON file of one kind of master data can be read and queues it,
+// returning the QUEUED job; a worker then writes each row. Rows matching an existing record (by
+// code for countries and currencies, by id otherwise) update it, other rows create one. Empty
+// values leave a field as it is, and an update that changes nothing is not written. A row that
+// fails is reported and the others are still written. A dry run validates every row and compares
+// it with the stored record but writes nothing, reporting what the import would do. A file that
+// cannot be read is recorded as FAILED and returned with an error wrapping ErrInvalidImportFile.
+func (s *dataImportService) Import(ctx context.Context, kind, format, fileName string, file io.Reader, requestedBy string, dryRun bool) (*domain.ImportJob, error) {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	spec, ok := importKinds[kind]
+	if !ok {
+		return nil, ErrUnknownImportKind
+	}
+	format = ImportFormatOf(format, fileName)
+	if format != domain.ImportFormatCSV && format != domain.ImportFormatJSON {
+		return nil, ErrUnknownImportFormat
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	job := &domain.ImportJob{
+		Kind:           kind,
+		Format:         format,
+		FileName:       filepath.Base(fileName),
+		Status:         domain.ImportStatusQueued,
+		DryRun:         dryRun,
+		IgnoredColumns: []string{},
+		Report:         []domain.ImportRowResult{},
+		RequestedBy:    requestedBy,
+	}
+	if job.FileName == "." {
+		job.FileName = ""
+	}
+
+	rows, columns, err := readImportFile(format, data)
+	if err == nil {
+		job.IgnoredColumns, err = spec.checkColumns(columns)
+	}
+	if err != nil {
+		return s.fail(ctx, job, err)
+	}
+
+	job.TotalRows = len(rows)
+	job.Payload = data
+	if err := s.jobs.Create(job); err != nil {
+		return nil, err
+	}
+	job.Payload = nil
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	log.Ctx(ctx).Info().
+		Str("job_id", job.ID.String()).
+		Str("kind", kind).
+		Bool("dry_run", dryRun).
+		Int("rows", job.TotalRows).
+		Msg("Data import queued")
+	return job, nil
+}
+
+// fail records an import whose file could not be read
+func (s *dataImportService) fail(ctx context.Context, job *domain.ImportJob, cause error) (*domain.ImportJob, error) {
+	job.Status = domain.ImportStatusFailed
+	job.Error = cause.Error()
+	now := time.Now().UTC()
+	job.StartedAt, job.CompletedAt = &now, &now
+	if err := s.jobs.Create(job); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to record failed data import")
+	}
+	return job, cause
+}
+
+func (s *dataImportService) GetJob(id string) (*domain.ImportJob, error) {
+	return s.jobs.FindByID(id)
+}
+
+func (s *dataImportService) ListJobs(limit, offset int) ([]*domain.ImportJob, int64, error) {
+	return s.jobs.FindAll(limit, offset)
+}
+
+// StartWorkers starts the workers that process queued imports
+func (s *dataImportService) StartWorkers() {
+	if s.workers == 0 {
+		log.Info().Msg("Data import workers disabled; imports queued here are processed by other replicas")
+		return
+	}
+	log.Info().Int("workers", s.workers).Dur("poll_interval", s.pollInterval).Msg("Starting data import workers")
+	for range s.workers {
+		s.running.Add(1)
+		go s.work()
+	}
+}
+
+// StopWorkers stops the workers, waiting up to the context deadline. An import in progress stops
+// after its current row and is recorded as FAILED, with the rows written so far in its report.
+func (s *dataImportService) StopWorkers(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("data import workers did not stop: %w", ctx.Err())
+	}
+}
+
+func (s *dataImportService) stopping() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// work processes queued imports until the workers are stopped
+func (s *dataImportService) work() {
+	defer s.running.Done()
+	for !s.stopping() {
+		if failed, err := s.jobs.FailStale(time.Now().Add(-dataImportStaleAfter), "interrupted: the worker processing it stopped; rows before processed_rows may have been written"); err != nil {
+			log.Error().Err(err).Msg("Failed to check for interrupted data imports")
+		} else if len(failed) > 0 {
+			log.Warn().Int("jobs", len(failed)).Msg("Marked interrupted data imports as failed")
+			for _, job := range failed {
+				s.deadLetter(job, errors.New(job.Error))
+			}
+		}
+
+		job, err := s.jobs.ClaimNext()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to take a queued data import")
+		} else if job != nil {
+			s.process(job)
+			continue
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// process writes the rows of a claimed import, saving its progress as it goes, and records the
+// outcome; the job is also listed with the other background jobs while it runs
+func (s *dataImportService) process(job *domain.ImportJob) {
+	description := fmt.Sprintf("Import %s from %s", job.Kind, job.FileName)
+	if job.DryRun {
+		description = fmt.Sprintf("Dry-run import of %s from %s", job.Kind, job.FileName)
+	}
+	err := s.registry.Run(JobKindDataImport, job.RequestedBy, description, func(tracker *Job) error {
+		tracker.SetContext("import_job_id", job.ID.String())
+		tracker.SetStage("importing")
+		return s.importRows(job, tracker)
+	})
+
+	// A failed import keeps its file, so it can be retried from the dead letters
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	switch {
+	case err != nil:
+		job.Status, job.Error = domain.ImportStatusFailed, err.Error()
+	case job.FailedRows > 0:
+		job.Status, job.Payload = domain.ImportStatusCompletedWithErrors, nil
+	default:
+		job.Status, job.Payload = domain.ImportStatusCompleted, nil
+	}
+	if err := s.jobs.Update(job); err != nil {
+		log.Error().Err(err).Str("job_id", job.ID.String()).Msg("Failed to record data import outcome")
+		return
+	}
+	if job.Status == domain.ImportStatusFailed {
+		s.deadLetter(job, err)
+	}
+	log.Info().
+		Str("job_id", job.ID.String()).
+		Str("kind", job.Kind).
+		Bool("dry_run", job.DryRun).
+		Str("status", job.Status).
+		Int("created", job.CreatedRows).
+		Int("updated", job.UpdatedRows).
+		Int("unchanged", job.UnchangedRows).
+		Int("failed", job.FailedRows).
+		Msg("Data import finished")
+}
+
+// deadLetter records a queued import that failed, with its kind; rows before the failure may
+// have been written
+func (s *dataImportService) deadLetter(job *domain.ImportJob, cause error) {
+	if s.deadLetters == nil {
+		return
+	}
+	s.deadLetters.Record(context.Background(), DeadLetterSourceDataImport, job.Kind, dataImportPayload{ImportJobID: job.ID.String()}, cause)
+}
+
+// RetryDeadLetteredImport is the dead letter retry handler for failed imports: it queues the
+// import again, from its first row. Rows written before it failed are matched again, so only rows
+// creating records without an id are written twice.
+func (s *dataImportService) RetryDeadLetteredImport(ctx context.Context, kind string, payload json.RawMessage) error {
+	var p dataImportPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("invalid data-import payload: %w", err)
+	}
+	requeued, err := s.jobs.Requeue(p.ImportJobID)
+	if err != nil {
+		return err
+	}
+	if !requeued {
+		return ErrImportNotRetryable
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	log.Info().Str("job_id", p.ImportJobID).Str("kind", kind).Msg("Data import queued again from the dead letters")
+	return nil
+}
+
+// importRows applies each row of a job's file, adding its outcome to the report
+func (s *dataImportService) importRows(job *domain.ImportJob, tracker *Job) error {
+	spec, ok := importKinds[job.Kind]
+	if !ok {
+		return ErrUnknownImportKind
+	}
+	rows, _, err := readImportFile(job.Format, job.Payload)
+	if err != nil {
+		return err
+	}
+
+	job.TotalRows = len(rows)
+	// Records are written with a logger naming the job, so what the services log can be traced to it
+	ctx := log.With().Str("import_job_id", job.ID.String()).Logger().WithContext(context.Background())
+	run := newImportRun(ctx, job.DryRun, s.currencyRepo)
+	lastSaved := time.Now()
+	for _, row := range rows {
+		if s.stopping() {
+			return fmt.Errorf("interrupted by shutdown after %d of %d rows; the report lists the rows processed", job.ProcessedRows, job.TotalRows)
+		}
+		job.Report = append(job.Report, s.importRow(spec, run, job, row))
+		job.ProcessedRows++
+		tracker.SetProgress(int64(job.ProcessedRows), int64(job.TotalRows))
+		if time.Since(lastSaved) >= dataImportProgressInterval {
+			lastSaved = time.Now()
+			if err := s.jobs.UpdateProgress(job); err != nil {
+				log.Warn().Err(err).Str("job_id", job.ID.String()).Msg("Failed to save data import progress")
+			}
+		}
+	}
+	return nil
+}
+
+// importRow applies one row and counts its outcome
+func (s *dataImportService) importRow(spec importKind, run *importRun, job *domain.ImportJob, row importRow) domain.ImportRowResult {
+	result := domain.ImportRowResult{Row: row.number, Key: spec.key(row.fields)}
+	id, action, changes, err := spec.apply(s, run, row.fields)
+	if err != nil {
+		result.Action, result.Error = domain.ImportActionFailed, err.Error()
+		job.FailedRows++
+		return result
+	}
+	result.Action, result.ID, result.Changes = action, id, changes
+	switch action {
+	case domain.ImportActionCreated:
+		job.CreatedRows++
+	case domain.ImportActionUnchanged:
+		job.UnchangedRows++
+	default:
+		job.UpdatedRows++
+	}
+	return result
+}
+
+// readImportFile reads the rows and columns of an import file
+func readImportFile(format string, data []byte) ([]importRow, []string, error) {
+	if format == domain.ImportFormatJSON {
+		return readImportJSON(bytes.NewReader(data))
+	}
+	return readImportCSV(bytes.NewReader(data))
+}
+
+// normalizeImportColumn turns a column name into the snake_case field name it stands for, e.g.
+// "Registration Number" into registration_number
+func normalizeImportColumn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_':
+			return r
+		case r == ' ' || r == '-' || r == '.':
+			return '_'
+		}
+		return -1
+	}, name)
+}
+
+// readImportCSV reads a comma, semicolon or tab separated file with a header row
+func readImportCSV(file io.Reader) ([]importRow, []string, error) {
+	reader := bufio.NewReader(file)
+	header, err := reader.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, nil, err
+	}
+	firstLine, _, _ := strings.Cut(string(header), "\n")
+
+	records := csv.NewReader(reader)
+	records.Comma = bicFileDelimiter(firstLine)
+	records.FieldsPerRecord = -1
+
+	names, err := records.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: cannot read header: %v", ErrInvalidImportFile, err)
+	}
+	columns := make([]string, len(names))
+	for i, name := range names {
+		columns[i] = normalizeImportColumn(name)
+	}
+
+	var rows []importRow
+	for {
+		record, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		line, _ := records.FieldPos(0)
+		row := importRow{number: line, fields: make(map[string]string, len(columns))}
+		blank := true
+		for i, value := range record {
+			if i < len(columns) && columns[i] != "" {
+				row.fields[columns[i]] = strings.TrimSpace(value)
+				blank = blank && row.fields[columns[i]] == ""
+			}
+		}
+		if blank {
+			continue
+		}
+		if len(rows) == dataImportMaxRows {
+			return nil, nil, fmt.Errorf("%w: more than %d rows", ErrInvalidImportFile, dataImportMaxRows)
+		}
+		rows = append(rows, row)
+	}
+	return rows, columns, nil
+}
+
+// readImportJSON reads a JSON array of objects whose values are strings, numbers, booleans or null
+func readImportJSON(file io.Reader) ([]importRow, []string, error) {
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, nil, fmt.Errorf("%w: must be a JSON array of objects", ErrInvalidImportFile)
+	}
+
+	var rows []importRow
+	var columns []string
+	for number := 1; decoder.More(); number++ {
+		if len(rows) == dataImportMaxRows {
+			return nil, nil, fmt.Errorf("%w: more than %d rows", ErrInvalidImportFile, dataImportMaxRows)
+		}
+		var object map[string]any
+		if err := decoder.Decode(&object); err != nil {
+			return nil, nil, fmt.Errorf("%w: row %d: %v", ErrInvalidImportFile, number, err)
+		}
+		row := importRow{number: number, fields: make(map[string]string, len(object))}
+		for name, value := range object {
+			column := normalizeImportColumn(name)
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+			switch v := value.(type) {
+			case nil:
+				row.fields[column] = ""
+			case string:
+				row.fields[column] = strings.TrimSpace(v)
+			case json.Number:
+				row.fields[column] = v.String()
+			case bool:
+				row.fields[column] = strconv.FormatBool(v)
+			default:
+				return nil, nil, fmt.Errorf("%w: row %d: %s must be a string, number, boolean or null", ErrInvalidImportFile, number, name)
+			}
+		}
+		rows = append(rows, row)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	return rows, columns, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// DataQualityService exposes data quality findings for review
+type DataQualityService interface {
+	ListFindings(filter repository.DQFindingFilter, limit, offset int) ([]*domain.DQFinding, int64, error)
+	ResolveFinding(id, resolvedBy string) (*domain.DQFinding, error)
+	RunIntegrityCheck(ctx context.Context, repair bool, requestedBy string) (*domain.IntegrityReport, error)
+}
+
+// integrityCheckActor is recorded as the resolver of findings closed by an automatic repair
+const integrityCheckActor = "integrity-check"
+
+type dataQualityService struct {
+	findings  repository.DQFindingRepository
+	integrity repository.IntegrityRepository
+}
+
+// NewDataQualityService creates a new data quality service
+func NewDataQualityService(findings repository.DQFindingRepository, integrity repository.IntegrityRepository) DataQualityService {
+	return &dataQualityService{findings: findings, integrity: integrity}
+}
+
+func (s *dataQualityService) ListFindings(filter repository.DQFindingFilter, limit, offset int) ([]*domain.DQFinding, int64, error) {
+	items, err := s.findings.FindAll(filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.findings.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// ResolveFinding closes a finding; the target's review flag is cleared once none of its findings remain open
+// Resolving an already resolved finding is a no-op
+func (s *dataQualityService) ResolveFinding(id, resolvedBy string) (*domain.DQFinding, error) {
+	finding, err := s.findings.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if finding.Status == domain.DQFindingStatusResolved {
+		return finding, nil
+	}
+	if err := s.findings.Resolve(finding, resolvedBy); err != nil {
+		return nil, err
+	}
+	return finding, nil
+}
+
+// RunIntegrityCheck scans for live records referencing deleted or missing parents and raises an
+// ERROR finding for each. With repair, orphans that are meaningless without their parent (codes,
+// prices and address links) are soft-deleted and their findings recorded as resolved instead
+func (s *dataQualityService) RunIntegrityCheck(ctx context.Context, repair bool, requestedBy string) (*domain.IntegrityReport, error) {
+	report := &domain.IntegrityReport{StartedAt: time.Now(), Repair: repair}
+	resolvedBy := integrityCheckActor
+	if requestedBy != "" {
+		resolvedBy = fmt.Sprintf("%s (%s)", integrityCheckActor, requestedBy)
+	}
+
+	for _, check := range repository.ReferenceChecks {
+		orphans, err := s.integrity.FindOrphans(check)
+		if err != nil {
+			return nil, fmt.Errorf("checking %s.%s: %w", check.Table, check.Column, err)
+		}
+		result := domain.IntegrityCheckResult{
+			RuleCode:        check.RuleCode,
+			Table:           check.Table,
+			Column:          check.Column,
+			ReferencedTable: check.ReferencedTable,
+			Repairable:      check.Repairable,
+			Orphans:         len(orphans),
+		}
+
+		if len(orphans) > 0 {
+			fix := repair && check.Repairable
+			if fix {
+				ids := make([]uuid.UUID, 0, len(orphans))
+				for _, orphan := range orphans {
+					ids = append(ids, orphan.ID)
+				}
+				if err := s.integrity.SoftDelete(ctx, check.Table, ids); err != nil {
+					return nil, fmt.Errorf("repairing %s.%s: %w", check.Table, check.Column, err)
+				}
+				result.Repaired = len(orphans)
+			}
+
+			cause := fmt.Sprintf("%s.%s references deleted %s", check.Table, check.Column, check.ReferencedTable)
+			findings := make([]*domain.DQFinding, 0, len(orphans))
+			for _, orphan := range orphans {
+				finding := &domain.DQFinding{
+					RuleCode:    check.RuleCode,
+					Severity:    domain.DQSeverityError,
+					TargetTable: check.Table,
+					TargetID:    orphan.ID,
+					Message:     fmt.Sprintf("%s %s references a %s row that is deleted or missing", singular(check.Table), orphan.Label, singular(check.ReferencedTable)),
+					Cause:       cause,
+					Status:      domain.DQFindingStatusOpen,
+				}
+				if fix {
+					now := time.Now()
+					finding.Message += "; soft-deleted by integrity check"
+					finding.Status = domain.DQFindingStatusResolved
+					finding.ResolvedAt = &now
+					finding.ResolvedBy = resolvedBy
+				}
+				findings = append(findings, finding)
+			}
+			raised, err := s.integrity.RecordFindings(ctx, findings)
+			if err != nil {
+				return nil, fmt.Errorf("recording findings for %s.%s: %w", check.Table, check.Column, err)
+			}
+			result.FindingsRaised = raised
+		}
+
+		report.Checks = append(report.Checks, result)
+		report.TotalOrphans += result.Orphans
+		report.TotalRepaired += result.Repaired
+		report.FindingsRaised += result.FindingsRaised
+	}
+
+	report.CompletedAt = time.Now()
+	log.Ctx(ctx).Info().
+		Bool("repair", repair).
+		Int("orphans", report.TotalOrphans).
+		Int("repaired", report.TotalRepaired).
+		Int("findings_raised", report.FindingsRaised).
+		Msg("Referential integrity check complete")
+	return report, nil
+}
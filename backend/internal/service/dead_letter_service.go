@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/notify"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Dead letter sources (one per async subsystem)
+const (
+	DeadLetterSourceLEIRefresh   = "lei-refresh"
+	DeadLetterSourceEventPublish = "event-publish"
+	DeadLetterSourceNotification = "notification"
+	DeadLetterSourceDataImport   = "data-import"
+)
+
+// Errors returned by dead letter operations
+var (
+	ErrNoRetryHandler        = errors.New("no retry handler registered for dead letter source")
+	ErrDeadLetterResolved    = errors.New("dead letter already resolved")
+	ErrInvalidDeadLetterJSON = errors.New("payload must be valid JSON")
+)
+
+// DeadLetterRetryFunc re-executes a dead-lettered work item from its (possibly edited) payload
+type DeadLetterRetryFunc func(ctx context.Context, kind string, payload json.RawMessage) error
+
+// DeadLetterRecorder is the narrow interface async subsystems use to dead-letter failed work
+type DeadLetterRecorder interface {
+	Record(ctx context.Context, source, kind string, payload interface{}, cause error)
+}
+
+// DeadLetterService collects permanently failed async work and lets operators inspect, edit, retry and purge it
+type DeadLetterService interface {
+	DeadLetterRecorder
+	RegisterRetryHandler(source string, fn DeadLetterRetryFunc)
+	List(filter repository.DeadLetterFilter, limit, offset int) ([]*domain.DeadLetter, int64, error)
+	Get(id string) (*domain.DeadLetter, error)
+	UpdatePayload(id string, payload json.RawMessage) (*domain.DeadLetter, error)
+	Retry(ctx context.Context, id string) (*domain.DeadLetter, error)
+	Delete(id string) error
+	Purge(ctx context.Context, filter repository.DeadLetterFilter) (int64, error)
+}
+
+type deadLetterService struct {
+	repo repository.DeadLetterRepository
+
+	mu       sync.RWMutex
+	handlers map[string]DeadLetterRetryFunc
+}
+
+// NewDeadLetterService creates a new dead letter service
+func NewDeadLetterService(repo repository.DeadLetterRepository) DeadLetterService {
+	return &deadLetterService{
+		repo:     repo,
+		handlers: make(map[string]DeadLetterRetryFunc),
+	}
+}
+
+// Record stores a failed work item; it never fails the caller (errors are logged)
+func (s *deadLetterService) Record(ctx context.Context, source, kind string, payload interface{}, cause error) {
+	item := newDeadLetter(source, kind, payload, cause)
+	if err := s.repo.Create(item); err != nil {
+		log.Ctx(ctx).Error().Err(err).
+			Str("source", source).
+			Str("kind", kind).
+			Str("payload", item.Payload).
+			Msg("Failed to store dead letter")
+		return
+	}
+
+	log.Ctx(ctx).Warn().
+		Str("dead_letter_id", item.ID.String()).
+		Str("source", source).
+		Str("kind", kind).
+		Str("error", item.Error).
+		Msg("Work item dead-lettered")
+}
+
+// newDeadLetter builds the pending dead letter of a failed work item
+func newDeadLetter(source, kind string, payload interface{}, cause error) *domain.DeadLetter {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Str("source", source).Str("kind", kind).Msg("Failed to encode dead letter payload")
+		payloadJSON = []byte("null")
+	}
+
+	item := &domain.DeadLetter{
+		Source:       source,
+		Kind:         kind,
+		Payload:      string(payloadJSON),
+		Attempts:     1,
+		Status:       domain.DeadLetterStatusPending,
+		LastFailedAt: time.Now(),
+	}
+	if cause != nil {
+		item.Error = cause.Error()
+	}
+	return item
+}
+
+// RegisterRetryHandler sets how items from a source are re-executed
+func (s *deadLetterService) RegisterRetryHandler(source string, fn DeadLetterRetryFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[source] = fn
+}
+
+func (s *deadLetterService) List(filter repository.DeadLetterFilter, limit, offset int) ([]*domain.DeadLetter, int64, error) {
+	items, err := s.repo.FindAll(filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (s *deadLetterService) Get(id string) (*domain.DeadLetter, error) {
+	return s.repo.FindByID(id)
+}
+
+// UpdatePayload replaces the payload of a pending item (e.g. to fix bad data before retrying)
+func (s *deadLetterService) UpdatePayload(id string, payload json.RawMessage) (*domain.DeadLetter, error) {
+	if !json.Valid(payload) {
+		return nil, ErrInvalidDeadLetterJSON
+	}
+
+	item, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status == domain.DeadLetterStatusResolved {
+		return nil, ErrDeadLetterResolved
+	}
+
+	item.Payload = string(payload)
+	if err := s.repo.Update(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Retry re-executes a pending item through its source's retry handler
+// On success the item is marked RESOLVED; on failure attempts and error are updated and it stays PENDING
+func (s *deadLetterService) Retry(ctx context.Context, id string) (*domain.DeadLetter, error) {
+	item, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status == domain.DeadLetterStatusResolved {
+		return nil, ErrDeadLetterResolved
+	}
+
+	s.mu.RLock()
+	handler, ok := s.handlers[item.Source]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoRetryHandler, item.Source)
+	}
+
+	now := time.Now()
+	item.LastRetriedAt = &now
+
+	if retryErr := handler(ctx, item.Kind, json.RawMessage(item.Payload)); retryErr != nil {
+		item.Attempts++
+		item.Error = retryErr.Error()
+		item.LastFailedAt = now
+		log.Ctx(ctx).Warn().Err(retryErr).Str("dead_letter_id", id).Str("source", item.Source).Msg("Dead letter retry failed")
+	} else {
+		item.Status = domain.DeadLetterStatusResolved
+		item.ResolvedAt = &now
+		log.Ctx(ctx).Info().Str("dead_letter_id", id).Str("source", item.Source).Msg("Dead letter retried successfully")
+	}
+
+	if err := s.repo.Update(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *deadLetterService) Delete(id string) error {
+	return s.repo.Delete(id)
+}
+
+func (s *deadLetterService) Purge(ctx context.Context, filter repository.DeadLetterFilter) (int64, error) {
+	purged, err := s.repo.Purge(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	log.Ctx(ctx).Info().
+		Str("source", filter.Source).
+		Str("status", filter.Status).
+		Int64("purged", purged).
+		Msg("Dead letters purged")
+	return purged, nil
+}
+
+// notificationFailed dead-letters the notifications a channel could not deliver, with the channel
+// as the kind
+func notificationFailed(deadLetters DeadLetterRecorder) notify.FailureFunc {
+	return func(channel string, n notify.Notification, err error) {
+		deadLetters.Record(context.Background(), DeadLetterSourceNotification, channel, n, err)
+	}
+}
+
+// resendNotification is the dead letter retry handler for notifications: it sends the
+// notification again over the channel it failed on
+func resendNotification(notifier notify.Notifier) DeadLetterRetryFunc {
+	return func(ctx context.Context, channel string, payload json.RawMessage) error {
+		var n notify.Notification
+		if err := json.Unmarshal(payload, &n); err != nil {
+			return fmt.Errorf("invalid notification payload: %w", err)
+		}
+		return notifier.Resend(ctx, channel, n)
+	}
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Errors loading the ELF code list
+var (
+	ErrInvalidELFCode     = errors.New("invalid ELF code")
+	ErrInvalidELFFile     = errors.New("invalid ELF code list file")
+	ErrELFRefreshDisabled = errors.New("ELF code list refresh is disabled (lei.elflisturl is not set)")
+)
+
+// elfReservedNames describes the codes GLEIF reserves for legal forms without a listed code
+var elfReservedNames = map[string]string{
+	domain.ELFCodeNotListed:   "Legal form not on the ELF code list",
+	domain.ELFCodeNotProvided: "Legal form not provided",
+}
+
+// codeListCacheTTL bounds how long names from GLEIF reference lists (ELF, RA, LOU) are cached, so
+// an import through another replica shows up
+const codeListCacheTTL = 10 * time.Minute
+
+// NormalizeELFCode upper-cases an ELF code and strips its spaces
+func NormalizeELFCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ELFImportResult summarises an ELF code list import
+type ELFImportResult struct {
+	Read          int               `json:"read"`     // rows, one per code and language
+	Imported      int               `json:"imported"` // codes
+	Inactive      int               `json:"inactive"` // imported codes with status INAC
+	RejectedCount int               `json:"rejected_count"`
+	Rejected      []ImportRejection `json:"rejected"` // the first rejections
+}
+
+// ELFService maintains the ISO 20275 entity legal forms of the GLEIF ELF code list
+type ELFService interface {
+	Import(file io.Reader) (*ELFImportResult, error)
+	Refresh() (*ELFImportResult, error)
+	RefreshEnabled() bool
+	Get(code string) (*domain.ELFCode, error)
+	List(filter repository.ELFFilter, limit, offset int) ([]*domain.ELFCode, int64, error)
+	DescribeLEIRecords(ctx context.Context, records ...*domain.LEIRecord)
+}
+
+type elfService struct {
+	repo    repository.ELFRepository
+	listURL string
+	client  *gleifClient
+
+	mu       sync.RWMutex
+	names    map[string]string // code -> display name
+	loadedAt time.Time
+}
+
+// NewELFService creates a new ELF code service; Refresh downloads the code list from listURL
+// through the GLEIF HTTP client settings
+func NewELFService(repo repository.ELFRepository, listURL string, settings GLEIFHTTPSettings) ELFService {
+	return &elfService{repo: repo, listURL: strings.TrimSpace(listURL), client: newGLEIFClient(settings)}
+}
+
+// elfColumns maps ELF code list headers, upper-cased without spaces or punctuation, to fields by
+// prefix: GLEIF's headers carry their format (e.g. "Date created YYYY-MM-DD (ISO 8601)"). The first
+// matching prefix wins, so longer prefixes come first.
+var elfColumns = []struct{ prefix, field string }{
+	{"ELFCODE", "code"},
+	{"COUNTRYOFFORMATION", "country"},
+	{"COUNTRYCODE", "country_code"},
+	{"COUNTRYSUBDIVISIONCODE", "subdivision_code"},
+	{"JURISDICTIONOFFORMATION", "jurisdiction"},
+	{"ENTITYLEGALFORMNAMELOCALNAME", "local_name"},
+	{"ENTITYLEGALFORMNAMETRANSLITERATEDNAME", "transliterated_name"},
+	{"LANGUAGECODE", "language_code"},
+	{"LANGUAGE", "language"},
+	{"ABBREVIATIONSLOCAL", "local_abbreviations"},
+	{"ABBREVIATIONSTRANSLITERATED", "transliterated_abbreviations"},
+	{"DATECREATED", "created_date"},
+	{"ELFSTATUS", "status"},
+	{"MODIFICATIONDATE", "modification_date"},
+}
+
+// Import loads the ELF code list as GLEIF publishes it in CSV (comma, semicolon or tab separated):
+// a row per code and language. Codes are upserted with their names in every listed language;
+// invalid rows are reported and skipped. Withdrawn codes stay in the list with status INAC, so
+// nothing is deleted.
+func (s *elfService) Import(file io.Reader) (*ELFImportResult, error) {
+	reader := bufio.NewReader(file)
+	header, err := reader.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	firstLine, _, _ := strings.Cut(string(header), "\n")
+
+	records := csv.NewReader(reader)
+	records.Comma = bicFileDelimiter(firstLine)
+	records.FieldsPerRecord = -1
+	records.LazyQuotes = true
+
+	columns, err := records.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", ErrInvalidELFFile, err)
+	}
+	index := map[string]int{}
+	for i, name := range columns {
+		key := strings.Map(func(r rune) rune {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, strings.ToUpper(strings.TrimPrefix(name, "\ufeff")))
+		for _, column := range elfColumns {
+			if strings.HasPrefix(key, column.prefix) {
+				if _, seen := index[column.field]; !seen {
+					index[column.field] = i
+				}
+				break
+			}
+		}
+	}
+	for _, required := range []string{"code", "country_code", "local_name", "status"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("%w: header must name the ELF code, country code, local name and ELF status columns", ErrInvalidELFFile)
+		}
+	}
+
+	importedAt := time.Now().UTC()
+	result := &ELFImportResult{Rejected: make([]ImportRejection, 0)}
+	codes := make(map[string]*domain.ELFCode)
+	order := make([]string, 0)
+
+	for {
+		record, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line := 0
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.Line
+		} else if err == nil {
+			line, _ = records.FieldPos(0)
+		}
+		reject := func(format string, args ...any) {
+			result.RejectedCount++
+			if len(result.Rejected) < importMaxRejections {
+				result.Rejected = append(result.Rejected, ImportRejection{Line: line, Error: fmt.Sprintf(format, args...)})
+			}
+		}
+		if err != nil {
+			result.Read++
+			reject("%v", err)
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		result.Read++
+
+		code := NormalizeELFCode(field("code"))
+		name := domain.ELFName{
+			Language:                    field("language"),
+			LanguageCode:                strings.ToLower(field("language_code")),
+			LocalName:                   field("local_name"),
+			TransliteratedName:          field("transliterated_name"),
+			LocalAbbreviations:          field("local_abbreviations"),
+			TransliteratedAbbreviations: field("transliterated_abbreviations"),
+		}
+		status := strings.ToUpper(field("status"))
+		countryCode := strings.ToUpper(field("country_code"))
+		switch {
+		case !elfCodePattern.MatchString(code):
+			reject("%v %q: must be 4 letters or digits", ErrInvalidELFCode, code)
+			continue
+		case name.LocalName == "":
+			reject("ELF %s: local name is required", code)
+			continue
+		case !isLetters(countryCode, 2):
+			reject("ELF %s: country code must be 2 letters", code)
+			continue
+		case status != domain.ELFStatusActive && status != domain.ELFStatusInactive:
+			reject("ELF %s: status must be ACTV or INAC", code)
+			continue
+		}
+		createdDate, err := parseMICDate(field("created_date"))
+		if err != nil {
+			reject("ELF %s: created_date: %v", code, err)
+			continue
+		}
+		modificationDate, err := parseMICDate(field("modification_date"))
+		if err != nil {
+			reject("ELF %s: modification_date: %v", code, err)
+			continue
+		}
+
+		// Further rows of a code add its names in other languages
+		if elf, seen := codes[code]; seen {
+			elf.Names = append(elf.Names, name)
+			continue
+		}
+		elf := &domain.ELFCode{
+			Code:             code,
+			CountryCode:      countryCode,
+			Country:          field("country"),
+			Jurisdiction:     field("jurisdiction"),
+			SubdivisionCode:  strings.ToUpper(field("subdivision_code")),
+			Name:             name.LocalName,
+			Abbreviations:    name.LocalAbbreviations,
+			Names:            []domain.ELFName{name},
+			Status:           status,
+			CreatedDate:      createdDate,
+			ModificationDate: modificationDate,
+			ImportedAt:       importedAt,
+		}
+		// Names in other scripts (e.g. Cyrillic, Greek) are shown transliterated
+		if !isLatinScript(name.LocalName) && name.TransliteratedName != "" {
+			elf.Name = name.TransliteratedName
+			if name.TransliteratedAbbreviations != "" {
+				elf.Abbreviations = name.TransliteratedAbbreviations
+			}
+		}
+		codes[code] = elf
+		order = append(order, code)
+	}
+
+	batch := make([]*domain.ELFCode, 0, len(order))
+	for _, code := range order {
+		batch = append(batch, codes[code])
+		if !codes[code].Active() {
+			result.Inactive++
+		}
+	}
+	if err := s.repo.Upsert(batch); err != nil {
+		return nil, err
+	}
+	result.Imported = len(batch)
+
+	s.mu.Lock()
+	s.names = nil
+	s.mu.Unlock()
+	return result, nil
+}
+
+// isLatinScript reports whether the letters of s are all in the Latin script
+func isLatinScript(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// RefreshEnabled reports whether an ELF code list URL is configured
+func (s *elfService) RefreshEnabled() bool {
+	return s.listURL != ""
+}
+
+// Refresh downloads the ELF code list from the configured URL and imports it
+func (s *elfService) Refresh() (*ELFImportResult, error) {
+	if !s.RefreshEnabled() {
+		return nil, ErrELFRefreshDisabled
+	}
+	req, err := http.NewRequest(http.MethodGet, s.listURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download ELF code list: %w", err)
+	}
+	resp, err := s.client.stream(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download ELF code list: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download ELF code list: %s returned %s", s.listURL, resp.Status)
+	}
+	return s.Import(resp.Body)
+}
+
+// Get returns an ELF code
+func (s *elfService) Get(code string) (*domain.ELFCode, error) {
+	code = NormalizeELFCode(code)
+	if !elfCodePattern.MatchString(code) {
+		return nil, fmt.Errorf("%w %q: must be 4 letters or digits", ErrInvalidELFCode, code)
+	}
+	return s.repo.FindByCode(code)
+}
+
+// List lists ELF codes
+func (s *elfService) List(filter repository.ELFFilter, limit, offset int) ([]*domain.ELFCode, int64, error) {
+	return s.repo.FindAll(filter, limit, offset)
+}
+
+// DescribeLEIRecords fills in the name of each record's legal form. Codes the list does not have
+// are left without one; if the names cannot be loaded, records are returned as they are.
+func (s *elfService) DescribeLEIRecords(ctx context.Context, records ...*domain.LEIRecord) {
+	names := s.cachedNames(ctx)
+	for _, record := range records {
+		if record == nil || record.EntityLegalForm == "" {
+			continue
+		}
+		if name, ok := elfReservedNames[record.EntityLegalForm]; ok {
+			record.EntityLegalFormName = name
+		} else {
+			record.EntityLegalFormName = names[record.EntityLegalForm]
+		}
+	}
+}
+
+// cachedNames returns the ELF display names, reloading them after an import or once
+// codeListCacheTTL has passed
+func (s *elfService) cachedNames(ctx context.Context) map[string]string {
+	s.mu.RLock()
+	names, loadedAt := s.names, s.loadedAt
+	s.mu.RUnlock()
+	if names != nil && time.Since(loadedAt) < codeListCacheTTL {
+		return names
+	}
+
+	loaded, err := s.repo.Names()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to load ELF code names")
+		return names
+	}
+	s.mu.Lock()
+	s.names, s.loadedAt = loaded, time.Now()
+	s.mu.Unlock()
+	return loaded
+}
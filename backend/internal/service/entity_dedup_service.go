@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Errors returned by entity duplicate review and merge
+var (
+	ErrDuplicateReviewed = errors.New("duplicate pair has already been reviewed")
+	ErrMergeSameEntity   = errors.New("an entity cannot be merged into itself")
+	ErrMergeLEIConflict  = errors.New("entities have different LEIs, so are different legal entities")
+)
+
+// Duplicate detection thresholds
+const (
+	// entityDuplicateCandidateThreshold is the pg_trgm similarity of raw names candidate pairs are
+	// fetched at; legal forms and punctuation lower it, so it is well below the flagging threshold
+	entityDuplicateCandidateThreshold = 0.3
+	// entityDuplicateNameThreshold is the normalized name similarity a pair is flagged at on its
+	// name alone
+	entityDuplicateNameThreshold = 0.8
+)
+
+// EntityDuplicateScan is the outcome of a duplicate scan
+type EntityDuplicateScan struct {
+	ScannedAt  time.Time `json:"scanned_at"`
+	Candidates int       `json:"candidates"` // pairs sharing an identifier or with trigram-similar names
+	Flagged    int       `json:"flagged"`    // pairs flagged as probable duplicates
+	New        int       `json:"new"`        // flagged for the first time
+}
+
+// EntityDedupService flags probable duplicate entities and merges duplicates into a surviving
+// golden record
+type EntityDedupService interface {
+	ScanDuplicates() (*EntityDuplicateScan, error)
+	ListDuplicates(filter repository.EntityDuplicateFilter, limit, offset int) ([]*domain.EntityDuplicate, int64, error)
+	DismissDuplicate(id, reviewer string) (*domain.EntityDuplicate, error)
+	Merge(ctx context.Context, survivorID, mergedID, mergedBy string) (*domain.EntityMerge, error)
+	ListMerges(entityID string, limit, offset int) ([]*domain.EntityMerge, int64, error)
+}
+
+type entityDedupService struct {
+	entities repository.EntityRepository
+}
+
+// NewEntityDedupService creates a new entity duplicate detection service
+func NewEntityDedupService(entities repository.EntityRepository) EntityDedupService {
+	return &entityDedupService{entities: entities}
+}
+
+// ScanDuplicates flags pairs of live entities that share an LEI or registration number, or whose
+// normalized names are at least entityDuplicateNameThreshold similar. Pairs already dismissed or
+// merged stay as reviewed.
+func (s *entityDedupService) ScanDuplicates() (*EntityDuplicateScan, error) {
+	scan := &EntityDuplicateScan{ScannedAt: time.Now()}
+	candidates, err := s.entities.FindDuplicateCandidates(entityDuplicateCandidateThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate candidates: %w", err)
+	}
+	scan.Candidates = len(candidates)
+
+	var found []*domain.EntityDuplicate
+	for _, candidate := range candidates {
+		similarity, _, _ := nameSimilarity(normalizeEntityName(candidate.EntityName), normalizeEntityName(candidate.DuplicateName))
+		var reasons []string
+		if candidate.SameLEI {
+			reasons = append(reasons, domain.EntityDuplicateSameLEI)
+		}
+		if candidate.SameRegistrationNumber {
+			reasons = append(reasons, domain.EntityDuplicateSameRegistrationNumber)
+		}
+		if similarity >= entityDuplicateNameThreshold {
+			reasons = append(reasons, domain.EntityDuplicateSimilarName)
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+		found = append(found, &domain.EntityDuplicate{
+			EntityID:       candidate.EntityID,
+			DuplicateID:    candidate.DuplicateID,
+			Reasons:        strings.Join(reasons, ","),
+			NameSimilarity: roundScore(similarity),
+			Status:         domain.EntityDuplicateStatusOpen,
+			DetectedAt:     scan.ScannedAt,
+		})
+	}
+	scan.Flagged = len(found)
+
+	scan.New, err = s.entities.SaveDuplicateScan(found, scan.ScannedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save duplicate scan: %w", err)
+	}
+
+	log.Info().
+		Int("candidates", scan.Candidates).
+		Int("flagged", scan.Flagged).
+		Int("new", scan.New).
+		Msg("Entity duplicate scan completed")
+	return scan, nil
+}
+
+func (s *entityDedupService) ListDuplicates(filter repository.EntityDuplicateFilter, limit, offset int) ([]*domain.EntityDuplicate, int64, error) {
+	return s.entities.FindDuplicates(filter, limit, offset)
+}
+
+// DismissDuplicate records that an OPEN pair are distinct entities; the pair is not flagged again
+func (s *entityDedupService) DismissDuplicate(id, reviewer string) (*domain.EntityDuplicate, error) {
+	duplicate, err := s.entities.FindDuplicateByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate.Status != domain.EntityDuplicateStatusOpen {
+		return nil, fmt.Errorf("%w (%s)", ErrDuplicateReviewed, duplicate.Status)
+	}
+
+	now := time.Now()
+	duplicate.Status = domain.EntityDuplicateStatusDismissed
+	duplicate.ReviewedBy = reviewer
+	duplicate.ReviewedAt = &now
+	if err := s.entities.UpdateDuplicate(duplicate); err != nil {
+		return nil, err
+	}
+	return duplicate, nil
+}
+
+// Merge consolidates an entity onto the surviving golden record. The survivor keeps its own values;
+// its empty LEI, legal form and type are taken from the merged entity. Accounts, SSIs, addresses
+// and a finished onboarding move to the survivor and the merged entity is soft-deleted, with a
+// snapshot of it kept in the merge record. Entities still being onboarded are not merged.
+func (s *entityDedupService) Merge(ctx context.Context, survivorID, mergedID, mergedBy string) (*domain.EntityMerge, error) {
+	if survivorID == mergedID {
+		return nil, ErrMergeSameEntity
+	}
+	survivor, err := s.entities.FindByID(survivorID)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := s.entities.FindByID(mergedID)
+	if err != nil {
+		return nil, err
+	}
+	if survivor.LEI != nil && merged.LEI != nil && *survivor.LEI != *merged.LEI {
+		return nil, fmt.Errorf("%w: %s, %s", ErrMergeLEIConflict, *survivor.LEI, *merged.LEI)
+	}
+
+	snapshot, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot merged entity: %w", err)
+	}
+
+	var filled []string
+	if survivor.LEI == nil && merged.LEI != nil {
+		survivor.LEI = merged.LEI
+		filled = append(filled, "lei")
+	}
+	if survivor.LegalForm == "" && merged.LegalForm != "" {
+		survivor.LegalForm = merged.LegalForm
+		filled = append(filled, domain.EntityFieldLegalForm)
+	}
+	if survivor.Type == "" && merged.Type != "" {
+		survivor.Type = merged.Type
+		filled = append(filled, "type")
+	}
+
+	merge := &domain.EntityMerge{
+		SurvivorID:     survivor.ID,
+		MergedID:       merged.ID,
+		MergedSnapshot: string(snapshot),
+		FilledFields:   strings.Join(filled, ","),
+		MergedBy:       mergedBy,
+	}
+	if err := s.entities.MergeEntities(ctx, survivor, merged.ID, merge); err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("survivor_id", survivorID).
+		Str("merged_id", mergedID).
+		Int("accounts", merge.Accounts).
+		Int("ssis", merge.SSIs).
+		Int("addresses", merge.Addresses).
+		Str("merged_by", mergedBy).
+		Msg("Entities merged")
+	return merge, nil
+}
+
+func (s *entityDedupService) ListMerges(entityID string, limit, offset int) ([]*domain.EntityMerge, int64, error) {
+	return s.entities.FindMerges(entityID, limit, offset)
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Errors returned by entity enrichment
+var (
+	ErrEntityHasNoLEI = errors.New("entity has no LEI")
+	ErrLEINotInStore  = errors.New("LEI not in the LEI store")
+)
+
+// EntityEnrichment is the outcome of enriching one entity from its LEI record
+type EntityEnrichment struct {
+	Entity  *domain.Entity    `json:"entity"`
+	LEI     string            `json:"lei"`
+	Filled  []string          `json:"filled"`            // fields set from the LEI record
+	Skipped map[string]string `json:"skipped,omitempty"` // field -> why it was left alone
+}
+
+// EntityEnrichmentSummary is the outcome of enriching every entity that has an LEI
+type EntityEnrichmentSummary struct {
+	Entities  int `json:"entities"`  // entities with an LEI
+	Enriched  int `json:"enriched"`  // entities with at least one field filled
+	Unchanged int `json:"unchanged"` // entities already up to date or maintained by hand
+	NotFound  int `json:"not_found"` // entities whose LEI is not in the LEI store
+	Failed    int `json:"failed"`
+}
+
+// EntityEnrichmentService fills entity master data (name, legal form, registered address) from
+// the LEI store. Every filled field's provenance is kept; a field edited by hand since it was
+// filled is left alone unless overwrite is set.
+type EntityEnrichmentService interface {
+	Enrich(entityID string, overwrite bool) (*EntityEnrichment, error)
+	EnrichAll(overwrite bool) (*EntityEnrichmentSummary, error)
+}
+
+type entityEnrichmentService struct {
+	entities  repository.EntityRepository
+	leis      repository.LEIRepository
+	countries CountryService
+}
+
+// NewEntityEnrichmentService creates a new entity enrichment service
+func NewEntityEnrichmentService(entities repository.EntityRepository, leis repository.LEIRepository, countries CountryService) EntityEnrichmentService {
+	return &entityEnrichmentService{entities: entities, leis: leis, countries: countries}
+}
+
+// Enrich fills an entity's fields from the LEI record of its LEI
+func (s *entityEnrichmentService) Enrich(entityID string, overwrite bool) (*EntityEnrichment, error) {
+	entity, err := s.entities.FindByID(entityID)
+	if err != nil {
+		return nil, err
+	}
+	if entity.LEI == nil || *entity.LEI == "" {
+		return nil, ErrEntityHasNoLEI
+	}
+	record, err := s.leis.FindLEIByLEI(*entity.LEI)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrLEINotInStore, *entity.LEI)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EntityEnrichment{Entity: entity, LEI: record.LEI, Filled: []string{}, Skipped: make(map[string]string)}
+	provenance := make(map[string]*domain.EntityFieldProvenance, len(entity.AutoFilled))
+	for i := range entity.AutoFilled {
+		provenance[entity.AutoFilled[i].Field] = &entity.AutoFilled[i]
+	}
+	now := time.Now()
+	var filled []*domain.EntityFieldProvenance
+	var edited []string
+	fill := func(field, current, proposed string) bool {
+		switch {
+		case proposed == "":
+			result.Skipped[field] = "not in the LEI record"
+			return false
+		case current == proposed:
+			if p := provenance[field]; p != nil && p.SourceRef == record.LEI {
+				return false // already filled from this record
+			}
+		case current != "" && !overwrite:
+			if p := provenance[field]; p == nil || p.Value != current {
+				result.Skipped[field] = "maintained by hand"
+				if p != nil {
+					edited = append(edited, field)
+				}
+				return false
+			}
+		}
+		filled = append(filled, &domain.EntityFieldProvenance{
+			EntityID:  entity.ID,
+			Field:     field,
+			Source:    domain.EntityFieldSourceLEI,
+			SourceRef: record.LEI,
+			Value:     proposed,
+			FilledAt:  now,
+		})
+		if current != proposed {
+			result.Filled = append(result.Filled, field)
+		}
+		return current != proposed
+	}
+
+	if fill(domain.EntityFieldName, entity.Name, record.LegalName) {
+		entity.Name = record.LegalName
+	}
+	if fill(domain.EntityFieldLegalForm, entity.LegalForm, record.EntityLegalForm) {
+		entity.LegalForm = record.EntityLegalForm
+	}
+
+	registered := registeredEntityAddress(entity)
+	address, err := s.legalAddress(record)
+	if err != nil {
+		return nil, err
+	}
+	current := ""
+	if registered != nil && registered.Address != nil {
+		current = addressFingerprint(registered.Address)
+	}
+	proposed := ""
+	if address != nil {
+		proposed = addressFingerprint(address)
+	}
+	var saveAddress *domain.EntityAddress
+	if fill(domain.EntityFieldRegisteredAddress, current, proposed) {
+		if registered == nil {
+			registered = &domain.EntityAddress{EntityID: entity.ID, AddressType: "REGISTERED", IsPrimary: !hasPrimaryAddress(entity)}
+		}
+		if registered.Address != nil {
+			address.BaseModel = registered.Address.BaseModel // update the registered address in place
+		}
+		registered.Address = address
+		saveAddress = registered
+	}
+
+	if err := s.entities.DeleteFieldProvenance(entity.ID, edited); err != nil {
+		return nil, err
+	}
+	if len(filled) > 0 {
+		if err := s.entities.SaveEnrichment(entity, saveAddress, filled); err != nil {
+			return nil, err
+		}
+		if entity, err = s.entities.FindByID(entityID); err != nil {
+			return nil, err
+		}
+		result.Entity = entity
+	}
+	return result, nil
+}
+
+// EnrichAll enriches every entity that has an LEI
+func (s *entityEnrichmentService) EnrichAll(overwrite bool) (*EntityEnrichmentSummary, error) {
+	ids, err := s.entities.FindIDsWithLEI()
+	if err != nil {
+		return nil, err
+	}
+	summary := &EntityEnrichmentSummary{Entities: len(ids)}
+	for _, id := range ids {
+		result, err := s.Enrich(id.String(), overwrite)
+		switch {
+		case errors.Is(err, ErrLEINotInStore):
+			summary.NotFound++
+		case err != nil:
+			summary.Failed++
+			log.Warn().Err(err).Str("entity_id", id.String()).Msg("Failed to enrich entity from LEI")
+		case len(result.Filled) > 0:
+			summary.Enriched++
+		default:
+			summary.Unchanged++
+		}
+	}
+	log.Info().
+		Int("entities", summary.Entities).
+		Int("enriched", summary.Enriched).
+		Int("not_found", summary.NotFound).
+		Int("failed", summary.Failed).
+		Msg("Entity enrichment from LEI completed")
+	return summary, nil
+}
+
+// legalAddress maps an LEI record's legal address onto an address, truncating to the ISO 20022
+// field lengths; nil if the record has no legal address
+func (s *entityEnrichmentService) legalAddress(record *domain.LEIRecord) (*domain.Address, error) {
+	if record.LegalAddressLine1 == "" && record.LegalAddressCity == "" {
+		return nil, nil
+	}
+	address := &domain.Address{
+		AddressType:        "ADDR",
+		AddressLine1:       truncate(record.LegalAddressLine1, 70),
+		AddressLine2:       truncate(record.LegalAddressLine2, 70),
+		AddressLine3:       truncate(record.LegalAddressLine3, 70),
+		AddressLine4:       truncate(record.LegalAddressLine4, 70),
+		TownName:           truncate(record.LegalAddressCity, 35),
+		CountrySubDivision: truncate(record.LegalAddressRegion, 35),
+		PostalCode:         truncate(record.LegalAddressPostalCode, 16),
+	}
+	if record.LegalAddressCountry != "" {
+		country, err := s.countries.GetByCode(record.LegalAddressCountry)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if country != nil {
+			address.CountryID = &country.ID
+			address.Country = country
+		}
+	}
+	return address, nil
+}
+
+// addressFingerprint joins the address fields enrichment fills, to tell whether an address still
+// holds what was filled in
+func addressFingerprint(address *domain.Address) string {
+	country := ""
+	if address.Country != nil {
+		country = address.Country.Code
+	}
+	return strings.Join([]string{
+		address.AddressLine1, address.AddressLine2, address.AddressLine3, address.AddressLine4,
+		address.TownName, address.CountrySubDivision, address.PostalCode, country,
+	}, "|")
+}
+
+// registeredEntityAddress returns the entity's REGISTERED address link, if any
+func registeredEntityAddress(entity *domain.Entity) *domain.EntityAddress {
+	for i := range entity.Addresses {
+		if strings.EqualFold(entity.Addresses[i].AddressType, "REGISTERED") {
+			return &entity.Addresses[i]
+		}
+	}
+	return nil
+}
+
+func hasPrimaryAddress(entity *domain.Entity) bool {
+	for _, ea := range entity.Addresses {
+		if ea.IsPrimary {
+			return true
+		}
+	}
+	return false
+}
+
+// editedAutoFilledFields returns the auto-filled fields an update changes, so their provenance
+// can be dropped; the registered address is checked by the next enrichment instead
+func editedAutoFilledFields(existing, updated *domain.Entity) []string {
+	var edited []string
+	for _, p := range existing.AutoFilled {
+		switch {
+		case p.Field == domain.EntityFieldName && updated.Name != p.Value,
+			p.Field == domain.EntityFieldLegalForm && updated.LegalForm != p.Value:
+			edited = append(edited, p.Field)
+		}
+	}
+	return edited
+}
+
+// truncate cuts s to at most n characters
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
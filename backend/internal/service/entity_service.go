@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// EntityService manages legal entities
+type EntityService interface {
+	Create(ctx context.Context, entity *domain.Entity) error
+	GetByID(id string) (*domain.Entity, error)
+	GetAll(limit, offset int) ([]*domain.Entity, error)
+	GetAllWithFilters(q *query.ListQuery) ([]*domain.Entity, error)
+	Update(ctx context.Context, entity *domain.Entity) error
+	Validate(entity *domain.Entity) error
+	Delete(ctx context.Context, id string) error
+}
+
+type entityService struct {
+	repo      repository.EntityRepository
+	countries repository.CountryRepository
+	ids       BusinessIDGenerator
+}
+
+func NewEntityService(repo repository.EntityRepository, countries repository.CountryRepository, ids BusinessIDGenerator) EntityService {
+	return &entityService{repo: repo, countries: countries, ids: ids}
+}
+
+// Create stores the entity, assigning a business ID from the active ENTITY sequence when none is supplied
+func (s *entityService) Create(ctx context.Context, entity *domain.Entity) error {
+	entity.AutoFilled = nil // provenance is only written by enrichment
+	if err := normalizeEntityLEI(entity); err != nil {
+		return err
+	}
+	if err := s.requireActiveCountries(entity, nil); err != nil {
+		return err
+	}
+	if entity.BusinessID == nil || *entity.BusinessID == "" {
+		businessID, err := s.ids.Generate(ctx, domain.IDTargetEntity)
+		if err != nil {
+			return err
+		}
+		entity.BusinessID = nil
+		if businessID != "" {
+			entity.BusinessID = &businessID
+		}
+	}
+	return s.repo.Create(ctx, entity)
+}
+
+func (s *entityService) GetByID(id string) (*domain.Entity, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *entityService) GetAll(limit, offset int) ([]*domain.Entity, error) {
+	return s.repo.FindAll(limit, offset)
+}
+
+func (s *entityService) GetAllWithFilters(q *query.ListQuery) ([]*domain.Entity, error) {
+	return s.repo.FindAllWithFilters(q)
+}
+
+func (s *entityService) Update(ctx context.Context, entity *domain.Entity) error {
+	existing, err := s.checkUpdate(entity)
+	if err != nil {
+		return err
+	}
+	// Auto-filled fields edited here are maintained by hand from now on
+	entity.AutoFilled = nil
+	if err := s.repo.Update(ctx, entity); err != nil {
+		return err
+	}
+	return s.repo.DeleteFieldProvenance(entity.ID, editedAutoFilledFields(existing, entity))
+}
+
+// Validate runs the checks of Create, or of Update for an entity with an ID, without saving
+func (s *entityService) Validate(entity *domain.Entity) error {
+	if entity.ID != uuid.Nil {
+		_, err := s.checkUpdate(entity)
+		return err
+	}
+	if err := normalizeEntityLEI(entity); err != nil {
+		return err
+	}
+	return s.requireActiveCountries(entity, nil)
+}
+
+// checkUpdate checks an entity update against the stored entity, carrying over the business ID
+// and LEI it omits, and returns the stored entity
+func (s *entityService) checkUpdate(entity *domain.Entity) (*domain.Entity, error) {
+	existing, err := s.repo.FindByID(entity.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	// Countries the entity already has addresses in stay allowed even if since deactivated
+	allowed := make(map[uuid.UUID]bool)
+	for _, ea := range existing.Addresses {
+		if ea.Address != nil && ea.Address.CountryID != nil {
+			allowed[*ea.Address.CountryID] = true
+		}
+	}
+	if err := s.requireActiveCountries(entity, allowed); err != nil {
+		return nil, err
+	}
+	// An update that omits the business ID keeps the one already assigned
+	if entity.BusinessID == nil {
+		entity.BusinessID = existing.BusinessID
+	}
+	// Likewise for the LEI; an empty LEI removes it
+	if entity.LEI == nil {
+		entity.LEI = existing.LEI
+	}
+	if err := normalizeEntityLEI(entity); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (s *entityService) requireActiveCountries(entity *domain.Entity, allowed map[uuid.UUID]bool) error {
+	for _, ea := range entity.Addresses {
+		if ea.Address == nil {
+			continue
+		}
+		if err := requireActiveCountry(s.countries, ea.Address.CountryID, allowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeEntityLEI upper-cases the entity's LEI and checks its format and check digits
+func normalizeEntityLEI(entity *domain.Entity) error {
+	if entity.LEI == nil {
+		return nil
+	}
+	lei := strings.ToUpper(strings.TrimSpace(*entity.LEI))
+	if lei == "" {
+		entity.LEI = nil
+		return nil
+	}
+	if !IsValidLEI(lei) {
+		return fmt.Errorf("%w: %s", ErrInvalidLEI, lei)
+	}
+	entity.LEI = &lei
+	return nil
+}
+
+func (s *entityService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+const (
+	eventRelayPublishTimeout = 30 * time.Second
+	eventRelayLockTimeout    = 10 * time.Second
+	eventRelayMaxBackoff     = time.Minute // longest wait between attempts while the broker is failing
+	eventRelayPruneInterval  = time.Hour   // how often delivered events past retention are deleted
+	defaultOutboxRetention   = 7 * 24 * time.Hour
+)
+
+// EventRelay publishes change events from the outbox to the broker, oldest first. Every replica may
+// run one; the replica holding the relay lock publishes while the others stand by. Delivery is at
+// least once: an event is published again if marking it delivered fails. An event the broker keeps
+// rejecting while the ones after it go through is moved to the dead letters.
+type EventRelay interface {
+	Start()
+	Stop(ctx context.Context) error
+}
+
+type eventRelay struct {
+	outbox      repository.OutboxRepository
+	lock        repository.LeaderLock
+	publisher   events.Publisher
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	retention   time.Duration
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewEventRelay creates the outbox relay for the configured publisher, relaying while it holds
+// lock, and registers how its dead-lettered events are retried
+func NewEventRelay(outbox repository.OutboxRepository, lock repository.LeaderLock, publisher events.Publisher, deadLetters DeadLetterService, cfg config.EventsConfig) EventRelay {
+	interval, err := time.ParseDuration(cfg.RelayInterval)
+	if err != nil || interval <= 0 {
+		log.Warn().Str("value", cfg.RelayInterval).Msg("Invalid events.relayinterval, using 1s")
+		interval = time.Second
+	}
+	batchSize := cfg.RelayBatchSize
+	if batchSize < 1 {
+		log.Warn().Int("value", batchSize).Msg("Invalid events.relaybatchsize, using 500")
+		batchSize = 500
+	}
+	maxAttempts := cfg.RelayMaxAttempts
+	if maxAttempts < 1 {
+		log.Warn().Int("value", maxAttempts).Msg("Invalid events.relaymaxattempts, using 10")
+		maxAttempts = 10
+	}
+	retention, err := time.ParseDuration(cfg.OutboxRetention)
+	if err != nil || retention <= 0 {
+		log.Warn().Str("value", cfg.OutboxRetention).Msg("Invalid events.outboxretention, using 168h")
+		retention = defaultOutboxRetention
+	}
+	r := &eventRelay{
+		outbox:      outbox,
+		lock:        lock,
+		publisher:   publisher,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		retention:   retention,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	deadLetters.RegisterRetryHandler(DeadLetterSourceEventPublish, r.RetryDeadLetteredEvent)
+	return r
+}
+
+// Start runs the relay in the background; a no-op when events are discarded
+func (r *eventRelay) Start() {
+	if r.publisher == events.Discard {
+		close(r.done)
+		return
+	}
+	log.Info().Dur("interval", r.interval).Int("batch_size", r.batchSize).Msg("Starting change event relay")
+	go r.run()
+}
+
+// Stop waits for the batch in flight, if any, up to the context deadline
+func (r *eventRelay) Stop(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("event relay did not stop: %w", ctx.Err())
+	}
+}
+
+func (r *eventRelay) run() {
+	defer close(r.done)
+	defer r.lock.Release()
+	failures := 0
+	var lastPrune time.Time
+	for {
+		wait := r.interval
+		relayed, err := r.relay()
+		switch {
+		case err != nil:
+			failures++
+			wait = min(r.interval<<min(failures, 16), eventRelayMaxBackoff)
+			log.Error().Err(err).Int("consecutive_failures", failures).Dur("retry_in", wait).Msg("Failed to relay change events")
+		case relayed == r.batchSize:
+			failures = 0
+			wait = 0 // more are probably waiting
+		default:
+			failures = 0
+		}
+
+		if time.Since(lastPrune) >= eventRelayPruneInterval {
+			lastPrune = time.Now()
+			if deleted, err := r.outbox.DeleteDelivered(time.Now().Add(-r.retention)); err != nil {
+				log.Error().Err(err).Msg("Failed to prune delivered change events")
+			} else if deleted > 0 {
+				log.Info().Int64("deleted", deleted).Msg("Pruned delivered change events")
+			}
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// relay publishes the oldest pending events if this replica holds the relay lock, and returns how
+// many were delivered. No transaction is open while the broker is called.
+func (r *eventRelay) relay() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventRelayLockTimeout)
+	defer cancel()
+	held, err := r.lock.TryAcquire(ctx)
+	if err != nil || !held {
+		return 0, err
+	}
+	if err := r.lock.Check(ctx); err != nil {
+		r.lock.Release()
+		return 0, err
+	}
+
+	pending, err := r.outbox.FindPending(r.batchSize)
+	if err != nil || len(pending) == 0 {
+		return 0, err
+	}
+	head := pending[0]
+	if head.Attempts < r.maxAttempts {
+		return r.publishBatch(pending)
+	}
+
+	// An oldest event failing over and over may be one the broker will never take. Published
+	// alone, it fails again; if the events after it then go through, the broker is fine and the
+	// event is moved to the dead letters. Otherwise the broker is failing and it stays.
+	relayed, headErr := r.publishBatch(pending[:1])
+	if headErr == nil || len(pending) == 1 {
+		return relayed, headErr
+	}
+	if relayed, err = r.publishBatch(pending[1:]); err != nil {
+		return relayed, err
+	}
+	return relayed, r.deadLetter(head, headErr)
+}
+
+// publishBatch publishes rows and marks them delivered. A failed publish is recorded on the rows,
+// so their attempts accumulate, and returned.
+func (r *eventRelay) publishBatch(rows []*domain.OutboxEvent) (int, error) {
+	if err := r.publish(rows); err != nil {
+		if markErr := r.outbox.MarkFailed(rows, err); markErr != nil {
+			return 0, markErr
+		}
+		return 0, err
+	}
+	if err := r.outbox.MarkPublished(rows); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// publish sends a batch of outbox rows to the broker as the events they hold
+func (r *eventRelay) publish(rows []*domain.OutboxEvent) error {
+	batch := make([]events.Event, len(rows))
+	for i, row := range rows {
+		if err := json.Unmarshal([]byte(row.Payload), &batch[i]); err != nil {
+			return fmt.Errorf("outbox event %s has an invalid payload: %w", row.ID, err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), eventRelayPublishTimeout)
+	defer cancel()
+	return r.publisher.Publish(ctx, batch...)
+}
+
+// deadLetter moves an event the broker rejected events.relaymaxattempts times to the dead letters,
+// its routing key as the kind and the event as the payload
+func (r *eventRelay) deadLetter(row *domain.OutboxEvent, cause error) error {
+	item := newDeadLetter(DeadLetterSourceEventPublish, row.RoutingKey, json.RawMessage(row.Payload), cause)
+	if err := r.outbox.MoveToDeadLetters(row, item); err != nil {
+		return fmt.Errorf("failed to dead-letter change event %s: %w", row.ID, err)
+	}
+	log.Error().Err(cause).Str("event_id", row.ID.String()).Str("dead_letter_id", item.ID.String()).
+		Str("routing_key", row.RoutingKey).Int("attempts", row.Attempts+1).
+		Msg("Change event rejected by the broker; moved to the dead letters")
+	return nil
+}
+
+// RetryDeadLetteredEvent publishes a dead-lettered event again, straight to the broker; it is not
+// ordered with the events relayed since
+func (r *eventRelay) RetryDeadLetteredEvent(ctx context.Context, _ string, payload json.RawMessage) error {
+	var event events.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("invalid change event: %w", err)
+	}
+	return r.publisher.Publish(ctx, event)
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// memoryOutbox is an OutboxRepository over events held in memory
+type memoryOutbox struct {
+	repository.OutboxRepository
+	events      []*domain.OutboxEvent
+	deadLetters []*domain.DeadLetter
+}
+
+func (m *memoryOutbox) add(keys ...string) {
+	for _, key := range keys {
+		event := events.Event{ID: uuid.New(), Domain: "lei", Action: "updated", Key: key}
+		payload, _ := json.Marshal(event)
+		m.events = append(m.events, &domain.OutboxEvent{ID: event.ID, Seq: int64(len(m.events) + 1), RoutingKey: event.RoutingKey(), Payload: string(payload)})
+	}
+}
+
+func (m *memoryOutbox) find(id uuid.UUID) *domain.OutboxEvent {
+	for _, event := range m.events {
+		if event.ID == id {
+			return event
+		}
+	}
+	return nil
+}
+
+func (m *memoryOutbox) FindPending(limit int) ([]*domain.OutboxEvent, error) {
+	var pending []*domain.OutboxEvent
+	for _, event := range m.events {
+		if event.PublishedAt == nil && len(pending) < limit {
+			copied := *event
+			pending = append(pending, &copied)
+		}
+	}
+	return pending, nil
+}
+
+func (m *memoryOutbox) MarkPublished(rows []*domain.OutboxEvent) error {
+	now := time.Now()
+	for _, row := range rows {
+		m.find(row.ID).PublishedAt = &now
+	}
+	return nil
+}
+
+func (m *memoryOutbox) MarkFailed(rows []*domain.OutboxEvent, cause error) error {
+	for _, row := range rows {
+		event := m.find(row.ID)
+		event.Attempts++
+		event.LastError = cause.Error()
+	}
+	return nil
+}
+
+func (m *memoryOutbox) MoveToDeadLetters(row *domain.OutboxEvent, item *domain.DeadLetter) error {
+	m.deadLetters = append(m.deadLetters, item)
+	for i, event := range m.events {
+		if event.ID == row.ID {
+			m.events = append(m.events[:i], m.events[i+1:]...)
+		}
+	}
+	return nil
+}
+
+// pending returns the keys of the undelivered events and their attempts
+func (m *memoryOutbox) pending() map[string]int {
+	pending := map[string]int{}
+	for _, event := range m.events {
+		if event.PublishedAt == nil {
+			var e events.Event
+			_ = json.Unmarshal([]byte(event.Payload), &e)
+			pending[e.Key] = event.Attempts
+		}
+	}
+	return pending
+}
+
+// testLock is a LeaderLock another replica may hold, or this one may lose
+type testLock struct {
+	heldElsewhere bool
+	lost          bool
+	held          bool
+}
+
+func (l *testLock) TryAcquire(context.Context) (bool, error) {
+	l.held = l.held || !l.heldElsewhere
+	return l.held, nil
+}
+
+func (l *testLock) Check(context.Context) error {
+	if l.lost {
+		return errors.New("leader lock no longer held by this session")
+	}
+	return nil
+}
+
+func (l *testLock) Release() { l.held = false }
+
+// testBroker rejects the events with a key in reject, or every event while down
+type testBroker struct {
+	reject    map[string]bool
+	down      bool
+	published [][]string // keys of each accepted batch
+}
+
+func (b *testBroker) Publish(_ context.Context, batch ...events.Event) error {
+	if b.down {
+		return errors.New("connection refused")
+	}
+	keys := make([]string, len(batch))
+	for i, event := range batch {
+		if b.reject[event.Key] {
+			return fmt.Errorf("event %s rejected", event.Key)
+		}
+		keys[i] = event.Key
+	}
+	b.published = append(b.published, keys)
+	return nil
+}
+
+func (b *testBroker) Close() error { return nil }
+
+func newTestRelay(outbox *memoryOutbox, lock *testLock, broker *testBroker) *eventRelay {
+	return &eventRelay{outbox: outbox, lock: lock, publisher: broker, batchSize: 3, maxAttempts: 2}
+}
+
+func TestEventRelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		events        []string
+		attempts      int // Failed attempts of the oldest event so far
+		reject        map[string]bool
+		down          bool
+		wantRelayed   int
+		wantErr       bool
+		wantPublished [][]string
+		wantPending   map[string]int
+		wantDead      []string
+	}{
+		{
+			name:          "publishes the oldest batch",
+			events:        []string{"a", "b", "c", "d"},
+			wantRelayed:   3,
+			wantPublished: [][]string{{"a", "b", "c"}},
+			wantPending:   map[string]int{"d": 0},
+		},
+		{
+			name:        "nothing pending",
+			wantPending: map[string]int{},
+		},
+		{
+			name:        "a failed publish is counted on the batch",
+			events:      []string{"a", "b"},
+			down:        true,
+			wantErr:     true,
+			wantPending: map[string]int{"a": 1, "b": 1},
+		},
+		{
+			name:        "an event rejected fewer than maxAttempts times holds the batch up",
+			events:      []string{"a", "b"},
+			attempts:    1,
+			reject:      map[string]bool{"a": true},
+			wantErr:     true,
+			wantPending: map[string]int{"a": 2, "b": 1},
+		},
+		{
+			name:          "an event failing maxAttempts times is published alone",
+			events:        []string{"a", "b"},
+			attempts:      2,
+			wantRelayed:   1,
+			wantPublished: [][]string{{"a"}},
+			wantPending:   map[string]int{"b": 0},
+		},
+		{
+			name:          "an event rejected while the rest go through is dead-lettered",
+			events:        []string{"a", "b", "c"},
+			attempts:      2,
+			reject:        map[string]bool{"a": true},
+			wantRelayed:   2,
+			wantPublished: [][]string{{"b", "c"}},
+			wantPending:   map[string]int{},
+			wantDead:      []string{"a"},
+		},
+		{
+			name:        "an event failing alone stays while the broker is down",
+			events:      []string{"a", "b"},
+			attempts:    2,
+			down:        true,
+			wantErr:     true,
+			wantPending: map[string]int{"a": 3, "b": 1},
+		},
+		{
+			name:        "an event failing alone with none after it stays",
+			events:      []string{"a"},
+			attempts:    2,
+			reject:      map[string]bool{"a": true},
+			wantErr:     true,
+			wantPending: map[string]int{"a": 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &memoryOutbox{}
+			outbox.add(tt.events...)
+			if len(outbox.events) > 0 {
+				outbox.events[0].Attempts = tt.attempts
+			}
+			broker := &testBroker{reject: tt.reject, down: tt.down}
+
+			relayed, err := newTestRelay(outbox, &testLock{}, broker).relay()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRelayed, relayed)
+			assert.Equal(t, tt.wantPublished, broker.published)
+			assert.Equal(t, tt.wantPending, outbox.pending())
+
+			var dead []string
+			for _, item := range outbox.deadLetters {
+				var event events.Event
+				require.NoError(t, json.Unmarshal([]byte(item.Payload), &event))
+				assert.Equal(t, DeadLetterSourceEventPublish, item.Source)
+				assert.Equal(t, "lei.updated", item.Kind)
+				assert.Equal(t, "event a rejected", item.Error)
+				dead = append(dead, event.Key)
+			}
+			assert.Equal(t, tt.wantDead, dead)
+		})
+	}
+}
+
+func TestEventRelayLock(t *testing.T) {
+	outbox := &memoryOutbox{}
+	outbox.add("a")
+	broker := &testBroker{}
+
+	lock := &testLock{heldElsewhere: true}
+	relayed, err := newTestRelay(outbox, lock, broker).relay()
+	assert.NoError(t, err)
+	assert.Zero(t, relayed)
+	assert.Empty(t, broker.published, "another replica is relaying")
+
+	lock = &testLock{lost: true}
+	relayed, err = newTestRelay(outbox, lock, broker).relay()
+	assert.Error(t, err)
+	assert.Zero(t, relayed)
+	assert.Empty(t, broker.published)
+	assert.False(t, lock.held, "a lock no longer held is released")
+
+	lock = &testLock{}
+	relayed, err = newTestRelay(outbox, lock, broker).relay()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, relayed)
+	assert.True(t, lock.held, "the lock is kept between rounds")
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Errors validating a MIC and loading the MIC list
+var (
+	ErrInvalidMIC         = errors.New("invalid MIC")
+	ErrUnknownMIC         = errors.New("MIC not in the MIC list")
+	ErrInvalidMICFile     = errors.New("invalid MIC list file")
+	ErrMICRefreshDisabled = errors.New("MIC refresh is disabled (referencedata.miclisturl is not set)")
+)
+
+// micDownloadTimeout bounds the download of the MIC list
+const micDownloadTimeout = 5 * time.Minute
+
+// NormalizeMIC upper-cases a MIC and strips its spaces
+func NormalizeMIC(mic string) string {
+	return strings.ToUpper(strings.TrimSpace(mic))
+}
+
+// ValidateMIC checks a normalized MIC against the ISO 10383 structure: four letters or digits.
+// The error wraps ErrInvalidMIC and says what is wrong.
+func ValidateMIC(mic string) error {
+	if len(mic) != 4 {
+		return fmt.Errorf("%w %q: must be 4 characters", ErrInvalidMIC, mic)
+	}
+	for _, r := range mic {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return fmt.Errorf("%w %q: must only contain letters and digits", ErrInvalidMIC, mic)
+		}
+	}
+	return nil
+}
+
+// MICImportResult summarises a MIC list import
+type MICImportResult struct {
+	Read          int               `json:"read"`
+	Imported      int               `json:"imported"`
+	Expired       int               `json:"expired"` // imported MICs with status EXPIRED
+	RejectedCount int               `json:"rejected_count"`
+	Rejected      []ImportRejection `json:"rejected"` // the first rejections
+}
+
+// ExchangeService maintains the exchanges of the ISO 10383 MIC list
+type ExchangeService interface {
+	Import(file io.Reader) (*MICImportResult, error)
+	Refresh() (*MICImportResult, error)
+	RefreshEnabled() bool
+	Get(mic string) (*domain.Exchange, error)
+	List(filter repository.ExchangeFilter, limit, offset int) ([]*domain.Exchange, int64, error)
+}
+
+type exchangeService struct {
+	repo    repository.ExchangeRepository
+	listURL string
+	client  *http.Client
+}
+
+// NewExchangeService creates a new exchange service; Refresh downloads the MIC list from listURL
+func NewExchangeService(repo repository.ExchangeRepository, listURL string) ExchangeService {
+	return &exchangeService{repo: repo, listURL: strings.TrimSpace(listURL), client: &http.Client{Timeout: micDownloadTimeout}}
+}
+
+// micColumns maps MIC list headers, upper-cased without spaces or punctuation, to fields
+var micColumns = map[string]string{
+	"MIC":                              "mic",
+	"OPERATINGMIC":                     "operating_mic",
+	"OPRTSGMT":                         "market_type",
+	"MARKETNAMEINSTITUTIONDESCRIPTION": "name",
+	"NAMEINSTITUTIONDESCRIPTION":       "name",
+	"LEGALENTITYNAME":                  "legal_entity_name",
+	"LEI":                              "lei",
+	"MARKETCATEGORYCODE":               "market_category_code",
+	"ACRONYM":                          "acronym",
+	"ISOCOUNTRYCODEISO3166":            "country_code",
+	"ISOCOUNTRYCODE":                   "country_code",
+	"CITY":                             "city",
+	"WEBSITE":                          "website",
+	"STATUS":                           "status",
+	"CREATIONDATE":                     "creation_date",
+	"LASTUPDATEDATE":                   "last_update_date",
+	"EXPIRYDATE":                       "expiry_date",
+}
+
+// Import loads the ISO 10383 MIC list, as published in CSV (comma, semicolon or tab separated).
+// Exchanges are upserted by MIC; invalid rows are reported and skipped. Withdrawn MICs stay in the
+// published list with status EXPIRED, so nothing is deleted.
+func (s *exchangeService) Import(file io.Reader) (*MICImportResult, error) {
+	reader := bufio.NewReader(file)
+	header, err := reader.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	firstLine, _, _ := strings.Cut(string(header), "\n")
+
+	records := csv.NewReader(reader)
+	records.Comma = bicFileDelimiter(firstLine)
+	records.FieldsPerRecord = -1
+	records.LazyQuotes = true
+
+	columns, err := records.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", ErrInvalidMICFile, err)
+	}
+	index := map[string]int{}
+	for i, name := range columns {
+		key := strings.Map(func(r rune) rune {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, strings.ToUpper(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := micColumns[key]; ok {
+			if _, seen := index[field]; !seen {
+				index[field] = i
+			}
+		}
+	}
+	for _, required := range []string{"mic", "operating_mic", "name", "country_code", "status"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("%w: header must name the MIC, operating MIC, name, country code and status columns", ErrInvalidMICFile)
+		}
+	}
+
+	importedAt := time.Now().UTC()
+	result := &MICImportResult{Rejected: make([]ImportRejection, 0)}
+	exchanges := make(map[string]*domain.Exchange)
+	order := make([]string, 0)
+
+	for {
+		record, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line := 0
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.Line
+		} else if err == nil {
+			line, _ = records.FieldPos(0)
+		}
+		reject := func(format string, args ...any) {
+			result.RejectedCount++
+			if len(result.Rejected) < importMaxRejections {
+				result.Rejected = append(result.Rejected, ImportRejection{Line: line, Error: fmt.Sprintf(format, args...)})
+			}
+		}
+		if err != nil {
+			result.Read++
+			reject("%v", err)
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		result.Read++
+
+		exchange := &domain.Exchange{
+			MIC:                NormalizeMIC(field("mic")),
+			OperatingMIC:       NormalizeMIC(field("operating_mic")),
+			MarketType:         strings.ToUpper(field("market_type")),
+			Name:               field("name"),
+			LegalEntityName:    field("legal_entity_name"),
+			LEI:                strings.ToUpper(field("lei")),
+			MarketCategoryCode: strings.ToUpper(field("market_category_code")),
+			Acronym:            field("acronym"),
+			CountryCode:        strings.ToUpper(field("country_code")),
+			City:               field("city"),
+			Website:            field("website"),
+			Status:             strings.ToUpper(field("status")),
+			ImportedAt:         importedAt,
+		}
+		if err := ValidateMIC(exchange.MIC); err != nil {
+			reject("%v", err)
+			continue
+		}
+		if err := ValidateMIC(exchange.OperatingMIC); err != nil {
+			reject("operating MIC: %v", err)
+			continue
+		}
+		if exchange.MarketType == "" {
+			exchange.MarketType = "SGMT"
+			if exchange.MIC == exchange.OperatingMIC {
+				exchange.MarketType = "OPRT"
+			}
+		}
+		switch {
+		case exchange.Name == "":
+			reject("MIC %s: name is required", exchange.MIC)
+			continue
+		case len(exchange.CountryCode) != 2:
+			reject("MIC %s: country code must be 2 letters", exchange.MIC)
+			continue
+		case exchange.Status != domain.MICStatusActive && exchange.Status != domain.MICStatusUpdated && exchange.Status != domain.MICStatusExpired:
+			reject("MIC %s: status must be ACTIVE, UPDATED or EXPIRED", exchange.MIC)
+			continue
+		}
+		var dateErr error
+		for _, date := range []struct {
+			column string
+			target **time.Time
+		}{
+			{"creation_date", &exchange.CreationDate},
+			{"last_update_date", &exchange.LastUpdateDate},
+			{"expiry_date", &exchange.ExpiryDate},
+		} {
+			if *date.target, dateErr = parseMICDate(field(date.column)); dateErr != nil {
+				dateErr = fmt.Errorf("MIC %s: %s: %w", exchange.MIC, date.column, dateErr)
+				break
+			}
+		}
+		if dateErr != nil {
+			reject("%v", dateErr)
+			continue
+		}
+
+		// A MIC listed twice keeps its last row
+		if _, seen := exchanges[exchange.MIC]; !seen {
+			order = append(order, exchange.MIC)
+		}
+		exchanges[exchange.MIC] = exchange
+	}
+
+	batch := make([]*domain.Exchange, 0, len(order))
+	for _, mic := range order {
+		batch = append(batch, exchanges[mic])
+		if !exchanges[mic].Active() {
+			result.Expired++
+		}
+	}
+	if err := s.repo.Upsert(batch); err != nil {
+		return nil, err
+	}
+	result.Imported = len(batch)
+	return result, nil
+}
+
+// parseMICDate parses a MIC list date: YYYYMMDD as published, or YYYY-MM-DD; empty is nil
+func parseMICDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{"20060102", time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q", value)
+}
+
+// RefreshEnabled reports whether a MIC list URL is configured
+func (s *exchangeService) RefreshEnabled() bool {
+	return s.listURL != ""
+}
+
+// Refresh downloads the MIC list from the configured URL and imports it
+func (s *exchangeService) Refresh() (*MICImportResult, error) {
+	if !s.RefreshEnabled() {
+		return nil, ErrMICRefreshDisabled
+	}
+	resp, err := s.client.Get(s.listURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download MIC list: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download MIC list: %s returned %s", s.listURL, resp.Status)
+	}
+	return s.Import(resp.Body)
+}
+
+// Get returns the exchange of a MIC
+func (s *exchangeService) Get(mic string) (*domain.Exchange, error) {
+	mic = NormalizeMIC(mic)
+	if err := ValidateMIC(mic); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByMIC(mic)
+}
+
+// List lists exchanges
+func (s *exchangeService) List(filter repository.ExchangeFilter, limit, offset int) ([]*domain.Exchange, int64, error) {
+	return s.repo.FindAll(filter, limit, offset)
+}
+
+// requireKnownMIC validates a normalized MIC's structure and, once the MIC list has been imported,
+// that it is listed and not expired. An unchanged MIC (equal to previous) is accepted as is.
+func requireKnownMIC(exchanges repository.ExchangeRepository, mic, previous string) error {
+	if mic == "" || mic == previous {
+		return nil
+	}
+	if err := ValidateMIC(mic); err != nil {
+		return err
+	}
+	loaded, err := exchanges.HasEntries()
+	if err != nil || !loaded {
+		return err
+	}
+	exchange, err := exchanges.FindByMIC(mic)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %s", ErrUnknownMIC, mic)
+	}
+	if err == nil && !exchange.Active() {
+		return fmt.Errorf("%w: %s expired", ErrUnknownMIC, mic)
+	}
+	return err
+}
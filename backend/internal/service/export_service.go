@@ -0,0 +1,480 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/storage"
+)
+
+var (
+	// ErrInvalidExport is returned when a saved view or scheduled export fails validation
+	ErrInvalidExport = errors.New("invalid export definition")
+	// ErrSavedViewExists is returned when a saved view name is already taken
+	ErrSavedViewExists = errors.New("a saved view with this name already exists")
+)
+
+const (
+	// exportBufferSize is how much of an export is buffered before it is written to its destination
+	exportBufferSize = 64 * 1024
+	// defaultExportS3Prefix is where S3 exports go in the archive bucket unless they say otherwise
+	defaultExportS3Prefix = "exports"
+)
+
+// exportCSVColumns are the LEI fields written to CSV renewal reports, in order
+var exportCSVColumns = []string{
+	"lei", "legal_name", "entity_status", "entity_category", "entity_legal_form",
+	"legal_address_city", "legal_address_country", "hq_address_country",
+	"managing_lou", "next_renewal_date", "last_update_date",
+}
+
+var exportFileNameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// ExportService manages saved LEI views and recurring exports of master data and LEI records
+type ExportService interface {
+	CreateSavedView(view *domain.LEISavedView) error
+	GetSavedView(id string) (*domain.LEISavedView, error)
+	ListSavedViews() ([]*domain.LEISavedView, error)
+	DeleteSavedView(id string) error
+
+	CreateScheduledExport(export *domain.ScheduledExport) error
+	GetScheduledExport(id string) (*domain.ScheduledExport, error)
+	ListScheduledExports() ([]*domain.ScheduledExport, error)
+	UpdateScheduledExport(export *domain.ScheduledExport) error
+	DeleteScheduledExport(id string) error
+
+	// RunScheduledExport runs an export immediately without moving its schedule
+	RunScheduledExport(id string) (*domain.ScheduledExport, error)
+	// RunDueExports runs every active export whose next run has passed (called by the scheduler)
+	RunDueExports() error
+}
+
+type exportService struct {
+	views       repository.SavedViewRepository
+	exports     repository.ScheduledExportRepository
+	dataExports DataExportService
+	cfg         config.ExportConfig // export.dir for FILE exports, export.sftp for SFTP ones
+	archive     storage.Archive     // S3 exports; nil when no archive bucket is configured
+	jobs        *JobRegistry
+}
+
+// NewExportService creates a new export service
+func NewExportService(views repository.SavedViewRepository, exports repository.ScheduledExportRepository, dataExports DataExportService, cfg config.ExportConfig, archive storage.Archive, jobs *JobRegistry) ExportService {
+	return &exportService{views: views, exports: exports, dataExports: dataExports, cfg: cfg, archive: archive, jobs: jobs}
+}
+
+func (s *exportService) CreateSavedView(view *domain.LEISavedView) error {
+	if err := validateSavedView(view); err != nil {
+		return err
+	}
+	if err := s.views.Create(view); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrSavedViewExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *exportService) GetSavedView(id string) (*domain.LEISavedView, error) {
+	return s.views.FindByID(id)
+}
+
+func (s *exportService) ListSavedViews() ([]*domain.LEISavedView, error) {
+	return s.views.FindAll()
+}
+
+// DeleteSavedView removes a view together with its scheduled exports
+func (s *exportService) DeleteSavedView(id string) error {
+	if _, err := s.views.FindByID(id); err != nil {
+		return err
+	}
+	return s.views.Delete(id)
+}
+
+// CreateScheduledExport validates and stores an export; without an explicit next_run_at
+// the first run happens on the scheduler's next pass
+func (s *exportService) CreateScheduledExport(export *domain.ScheduledExport) error {
+	if err := s.validateScheduledExport(export); err != nil {
+		return err
+	}
+	if export.NextRunAt.IsZero() {
+		export.NextRunAt = time.Now()
+	}
+	return s.exports.Create(export)
+}
+
+func (s *exportService) GetScheduledExport(id string) (*domain.ScheduledExport, error) {
+	return s.exports.FindByID(id)
+}
+
+func (s *exportService) ListScheduledExports() ([]*domain.ScheduledExport, error) {
+	return s.exports.FindAll()
+}
+
+// UpdateScheduledExport replaces an export's definition; run history is preserved
+func (s *exportService) UpdateScheduledExport(export *domain.ScheduledExport) error {
+	existing, err := s.exports.FindByID(export.ID.String())
+	if err != nil {
+		return err
+	}
+	if err := s.validateScheduledExport(export); err != nil {
+		return err
+	}
+	if export.NextRunAt.IsZero() {
+		export.NextRunAt = existing.NextRunAt
+	}
+	export.LastRunAt = existing.LastRunAt
+	export.LastRunStatus = existing.LastRunStatus
+	export.LastRunError = existing.LastRunError
+	export.LastRunFile = existing.LastRunFile
+	export.LastRunRows = existing.LastRunRows
+	export.CreatedBy = existing.CreatedBy
+	export.CreatedAt = existing.CreatedAt
+	return s.exports.Update(export)
+}
+
+func (s *exportService) DeleteScheduledExport(id string) error {
+	if _, err := s.exports.FindByID(id); err != nil {
+		return err
+	}
+	return s.exports.Delete(id)
+}
+
+func (s *exportService) RunScheduledExport(id string) (*domain.ScheduledExport, error) {
+	export, err := s.exports.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	s.run(export)
+	if err := s.exports.Update(export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (s *exportService) RunDueExports() error {
+	now := time.Now()
+	due, err := s.exports.FindDue(now)
+	if err != nil {
+		return fmt.Errorf("failed to find due exports: %w", err)
+	}
+
+	for _, export := range due {
+		s.run(export)
+		export.NextRunAt = nextExportRun(export.NextRunAt, export.Frequency, now)
+		if err := s.exports.Update(export); err != nil {
+			log.Error().Err(err).Str("export_id", export.ID.String()).Msg("Failed to record scheduled export run")
+		}
+	}
+	return nil
+}
+
+// run executes one export and records the outcome on it (the caller persists it)
+// The run is registered as a job owned by the export's creator
+func (s *exportService) run(export *domain.ScheduledExport) {
+	owner := export.CreatedBy
+	if owner == "" {
+		owner = JobOwnerScheduler
+	}
+	s.jobs.Run(JobKindExport, owner, fmt.Sprintf("Export %s", export.Name), func(job *Job) error {
+		job.SetContext("export_id", export.ID.String())
+		return s.runExport(export, job)
+	})
+}
+
+func (s *exportService) runExport(export *domain.ScheduledExport, job *Job) error {
+	started := time.Now()
+	export.LastRunAt = &started
+
+	path, rows, err := s.writeExport(export, job)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("export_id", export.ID.String()).
+			Str("export_name", export.Name).
+			Msg("Scheduled export failed")
+		export.LastRunStatus = domain.ExportRunFailed
+		export.LastRunError = err.Error()
+		return err
+	}
+
+	export.LastRunStatus = domain.ExportRunSucceeded
+	export.LastRunError = ""
+	export.LastRunFile = path
+	export.LastRunRows = rows
+
+	log.Info().
+		Str("export_id", export.ID.String()).
+		Str("export_name", export.Name).
+		Str("file", path).
+		Int("rows", rows).
+		Str("recipients", export.Recipients).
+		Dur("duration", time.Since(started)).
+		Msg("Scheduled export completed")
+	return nil
+}
+
+// writeExport writes the export's records to its destination under a new name, which only
+// appears once the file is complete
+func (s *exportService) writeExport(export *domain.ScheduledExport, job *Job) (string, int, error) {
+	prepared, err := s.prepareExport(export)
+	if err != nil {
+		return "", 0, err
+	}
+	job.SetProgress(0, prepared.Rows)
+
+	name := strings.Trim(exportFileNameUnsafe.ReplaceAllString(export.Name, "_"), "_")
+	name = fmt.Sprintf("%s_%s.%s", name, time.Now().UTC().Format("20060102T150405Z"), strings.ToLower(export.Format))
+
+	var rows int64
+	write := func(w io.Writer) error {
+		buffered := bufio.NewWriterSize(w, exportBufferSize)
+		if rows, err = s.dataExports.Write(prepared, buffered); err != nil {
+			return err
+		}
+		return buffered.Flush()
+	}
+
+	var location string
+	switch export.Destination {
+	case domain.ExportDestinationSFTP:
+		location, err = s.uploadSFTP(export, name, write)
+	case domain.ExportDestinationS3:
+		location, err = s.uploadS3(export, name, write)
+	default:
+		location, err = s.writeFile(name, write)
+	}
+	job.SetProgress(rows, prepared.Rows)
+	return location, int(rows), err
+}
+
+// prepareExport checks the export's kind, format and filters, or those of its saved view
+func (s *exportService) prepareExport(export *domain.ScheduledExport) (*DataExport, error) {
+	filters := url.Values{}
+	if export.SavedViewID != nil {
+		view := export.SavedView
+		if view == nil {
+			var err error
+			if view, err = s.views.FindByID(export.SavedViewID.String()); err != nil {
+				return nil, fmt.Errorf("failed to load saved view: %w", err)
+			}
+		}
+		filters = savedViewFilters(view)
+	} else {
+		for key, value := range export.Filters {
+			filters.Set(key, value)
+		}
+	}
+	return s.dataExports.Prepare(export.Kind, export.Format, filters)
+}
+
+// savedViewFilters are a saved view's filters as GET /lei takes them
+func savedViewFilters(view *domain.LEISavedView) url.Values {
+	filters := url.Values{}
+	for key, value := range map[string]string{
+		"search": view.Search, "q": view.TextQuery, "status": view.Status, "category": view.Category,
+		"country": view.Country, "sortBy": view.SortBy, "sortOrder": view.SortOrder,
+	} {
+		if value != "" {
+			filters.Set(key, value)
+		}
+	}
+	return filters
+}
+
+// uploadS3 streams an export into the archive bucket, where it appears once the upload completes
+func (s *exportService) uploadS3(export *domain.ScheduledExport, name string, write func(io.Writer) error) (string, error) {
+	reader, writer := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		err := write(writer)
+		writer.CloseWithError(err) // a failed export fails the upload
+		written <- err
+	}()
+
+	location, err := s.archive.Put(context.Background(), path.Join(export.S3Prefix, name), reader)
+	reader.Close() // a failed upload stops the export
+	if writeErr := <-written; writeErr != nil && !errors.Is(writeErr, io.ErrClosedPipe) {
+		return "", writeErr
+	}
+	return location, err
+}
+
+// writeFile writes an export under the export directory as name.partial and renames it once
+// complete
+func (s *exportService) writeFile(name string, write func(io.Writer) error) (string, error) {
+	if err := os.MkdirAll(s.cfg.Dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	path := filepath.Join(s.cfg.Dir, name)
+	tmpPath := path + ".partial"
+
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to finalise export file: %w", err)
+	}
+	return path, nil
+}
+
+// exportCSVRow renders a record in exportCSVColumns order
+func exportCSVRow(r *domain.LEIRecord) []string {
+	date := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+	return []string{
+		r.LEI, r.LegalName, r.EntityStatus, r.EntityCategory, r.EntityLegalForm,
+		r.LegalAddressCity, r.LegalAddressCountry, r.HQAddressCountry,
+		r.ManagingLOU, date(r.NextRenewalDate), date(r.LastUpdateDate),
+	}
+}
+
+// nextExportRun advances a schedule by its frequency until it is in the future,
+// so exports missed while the service was down run once rather than once per missed period
+func nextExportRun(from time.Time, frequency string, now time.Time) time.Time {
+	next := from
+	for !next.After(now) {
+		switch frequency {
+		case domain.ExportFrequencyDaily:
+			next = next.AddDate(0, 0, 1)
+		case domain.ExportFrequencyMonthly:
+			next = next.AddDate(0, 1, 0)
+		default:
+			next = next.AddDate(0, 0, 7)
+		}
+	}
+	return next
+}
+
+func validateSavedView(view *domain.LEISavedView) error {
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidExport)
+	}
+	view.Country = strings.ToUpper(view.Country)
+	if view.Country != "" && len(view.Country) != 2 {
+		return fmt.Errorf("%w: country must be an ISO 3166-1 alpha-2 code", ErrInvalidExport)
+	}
+	view.SortOrder = strings.ToLower(view.SortOrder)
+	if view.SortOrder != "" && view.SortOrder != "asc" && view.SortOrder != "desc" {
+		return fmt.Errorf("%w: sort_order must be asc or desc", ErrInvalidExport)
+	}
+	return nil
+}
+
+func (s *exportService) validateScheduledExport(export *domain.ScheduledExport) error {
+	export.Name = strings.TrimSpace(export.Name)
+	if export.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidExport)
+	}
+
+	export.Kind = strings.ToLower(strings.TrimSpace(export.Kind))
+	if export.Kind == "" {
+		export.Kind = domain.ExportKindLEI
+	}
+	export.Format = strings.ToUpper(export.Format)
+	if export.Format == "" {
+		export.Format = domain.ExportFormatCSV
+	}
+	if export.SavedViewID != nil {
+		if export.Kind != domain.ExportKindLEI || len(export.Filters) > 0 {
+			return fmt.Errorf("%w: a saved view is an lei export and takes no other filters", ErrInvalidExport)
+		}
+		if _, err := s.views.FindByID(export.SavedViewID.String()); err != nil {
+			return fmt.Errorf("%w: saved view %s not found", ErrInvalidExport, export.SavedViewID)
+		}
+		if _, ok := dataExportContentTypes[export.Format]; !ok {
+			return fmt.Errorf("%w: format must be CSV, XLSX, JSON or NDJSON", ErrInvalidExport)
+		}
+	} else if _, err := s.prepareExport(export); err != nil {
+		switch {
+		case errors.Is(err, ErrUnknownExportKind), errors.Is(err, ErrExportTooLarge), query.IsValidationError(err):
+			return fmt.Errorf("%w: %v", ErrInvalidExport, err)
+		case errors.Is(err, ErrUnknownExportFormat):
+			return fmt.Errorf("%w: format must be CSV, XLSX, JSON or NDJSON", ErrInvalidExport)
+		}
+		return err
+	}
+
+	export.Destination = strings.ToUpper(export.Destination)
+	switch export.Destination {
+	case "", domain.ExportDestinationFile:
+		export.Destination = domain.ExportDestinationFile
+	case domain.ExportDestinationSFTP:
+		if err := validateSFTPDestination(export); err != nil {
+			return err
+		}
+	case domain.ExportDestinationS3:
+		if s.archive == nil {
+			return fmt.Errorf("%w: S3 exports need an archive bucket (archive.bucket)", ErrInvalidExport)
+		}
+		export.S3Prefix = strings.Trim(strings.TrimSpace(export.S3Prefix), "/")
+		if export.S3Prefix == "" {
+			export.S3Prefix = defaultExportS3Prefix
+		}
+	default:
+		return fmt.Errorf("%w: destination must be FILE, SFTP or S3", ErrInvalidExport)
+	}
+	// Settings of other destinations are not kept
+	if export.Destination != domain.ExportDestinationSFTP {
+		export.SFTPHost, export.SFTPPort, export.SFTPUsername, export.SFTPDirectory = "", 0, "", ""
+	}
+	if export.Destination != domain.ExportDestinationS3 {
+		export.S3Prefix = ""
+	}
+
+	export.Frequency = strings.ToUpper(export.Frequency)
+	switch export.Frequency {
+	case domain.ExportFrequencyDaily, domain.ExportFrequencyWeekly, domain.ExportFrequencyMonthly:
+	default:
+		return fmt.Errorf("%w: frequency must be DAILY, WEEKLY or MONTHLY", ErrInvalidExport)
+	}
+
+	var recipients []string
+	for _, r := range strings.Split(export.Recipients, ",") {
+		if r = strings.TrimSpace(r); r == "" {
+			continue
+		}
+		if !strings.Contains(r, "@") {
+			return fmt.Errorf("%w: invalid recipient %q", ErrInvalidExport, r)
+		}
+		recipients = append(recipients, r)
+	}
+	export.Recipients = strings.Join(recipients, ",")
+	export.SavedView = nil
+	return nil
+}
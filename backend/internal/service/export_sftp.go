@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// defaultSFTPTimeout bounds connecting and logging in to an SFTP server when export.sftp.timeout
+// is unset or invalid
+const defaultSFTPTimeout = 30 * time.Second
+
+// validateSFTPDestination checks an SFTP export's server and directory
+func validateSFTPDestination(export *domain.ScheduledExport) error {
+	export.SFTPHost = strings.TrimSpace(export.SFTPHost)
+	export.SFTPUsername = strings.TrimSpace(export.SFTPUsername)
+	export.SFTPDirectory = strings.TrimSpace(export.SFTPDirectory)
+	if export.SFTPHost == "" || export.SFTPUsername == "" {
+		return fmt.Errorf("%w: an SFTP export needs sftp_host and sftp_username", ErrInvalidExport)
+	}
+	if export.SFTPPort == 0 {
+		export.SFTPPort = 22
+	}
+	if export.SFTPPort < 1 || export.SFTPPort > 65535 {
+		return fmt.Errorf("%w: sftp_port must be between 1 and 65535", ErrInvalidExport)
+	}
+	return nil
+}
+
+// uploadSFTP writes an export to its SFTP directory as name.partial and renames it once complete,
+// so the receiving side never picks up part of a file
+func (s *exportService) uploadSFTP(export *domain.ScheduledExport, name string, write func(io.Writer) error) (string, error) {
+	address := net.JoinHostPort(export.SFTPHost, strconv.Itoa(export.SFTPPort))
+	conn, err := dialSFTP(s.cfg.SFTP, address, export.SFTPUsername)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		return "", fmt.Errorf("failed to start SFTP session with %s: %w", address, err)
+	}
+	defer client.Close()
+
+	remotePath := path.Join(export.SFTPDirectory, name)
+	tmpPath := remotePath + ".partial"
+	file, err := client.Create(tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s on %s: %w", tmpPath, address, err)
+	}
+
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		client.Remove(tmpPath)
+		return "", err
+	}
+
+	if err := client.Rename(tmpPath, remotePath); err != nil {
+		client.Remove(tmpPath)
+		return "", fmt.Errorf("failed to finalise %s on %s: %w", remotePath, address, err)
+	}
+	return fmt.Sprintf("sftp://%s@%s/%s", export.SFTPUsername, address, strings.TrimPrefix(remotePath, "/")), nil
+}
+
+// dialSFTP connects and logs in to an SFTP server with the configured key or password, checking
+// its host key against the configured known hosts
+func dialSFTP(cfg config.ExportSFTPConfig, address, user string) (*ssh.Client, error) {
+	var auth []ssh.AuthMethod
+	if cfg.PrivateKeyFile != "" {
+		key, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read export.sftp.privatekeyfile: %w", err)
+		}
+		var signer ssh.Signer
+		if cfg.PrivateKeyPassphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(cfg.PrivateKeyPassphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse export.sftp.privatekeyfile: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		auth = append(auth, ssh.Password(cfg.Password))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New("SFTP exports need export.sftp.privatekeyfile or export.sftp.password")
+	}
+
+	var hostKeys ssh.HostKeyCallback
+	switch {
+	case cfg.InsecureIgnoreHostKey:
+		hostKeys = ssh.InsecureIgnoreHostKey()
+	case cfg.KnownHostsFile == "":
+		return nil, errors.New("SFTP exports need export.sftp.knownhostsfile to check the server's host key")
+	default:
+		var err error
+		if hostKeys, err = knownhosts.New(cfg.KnownHostsFile); err != nil {
+			return nil, fmt.Errorf("failed to read export.sftp.knownhostsfile: %w", err)
+		}
+	}
+
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil || timeout <= 0 {
+		timeout = defaultSFTPTimeout
+	}
+
+	conn, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostKeys,
+		Timeout:         timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SFTP server %s: %w", address, err)
+	}
+	return conn, nil
+}
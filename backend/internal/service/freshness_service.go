@@ -0,0 +1,73 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// FreshnessCacheTTL bounds how often freshness statistics are recomputed, however often consumers poll
+const FreshnessCacheTTL = 60 * time.Second
+
+// FreshnessService reports row counts and recency so ETL consumers can decide whether to re-extract
+type FreshnessService interface {
+	GetFreshness() (*domain.DataFreshness, error)
+	Invalidate()
+}
+
+type freshnessService struct {
+	repo repository.FreshnessRepository
+
+	mu       sync.Mutex
+	cached   *domain.DataFreshness
+	cachedAt time.Time
+}
+
+// NewFreshnessService creates a new freshness service
+func NewFreshnessService(repo repository.FreshnessRepository) FreshnessService {
+	return &freshnessService{repo: repo}
+}
+
+// GetFreshness returns the cached report, recomputing it if older than FreshnessCacheTTL
+func (s *freshnessService) GetFreshness() (*domain.DataFreshness, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && time.Since(s.cachedAt) < FreshnessCacheTTL {
+		return s.cached, nil
+	}
+
+	report := &domain.DataFreshness{
+		GeneratedAt: time.Now().UTC(),
+		CacheTTL:    FreshnessCacheTTL.String(),
+		Tables:      make([]domain.TableFreshness, 0, len(repository.FreshnessTables)),
+	}
+
+	for _, table := range repository.FreshnessTables {
+		tf, err := s.repo.GetTableFreshness(table)
+		if err != nil {
+			return nil, err
+		}
+		report.Tables = append(report.Tables, *tf)
+		report.ChangeSequence += tf.ChangeCounter
+	}
+
+	imports, err := s.repo.GetLastImports()
+	if err != nil {
+		return nil, err
+	}
+	report.LastImports = imports
+
+	s.cached = report
+	s.cachedAt = time.Now()
+	return report, nil
+}
+
+// Invalidate drops the cached report, so the next request sees statistics that were just recomputed
+func (s *freshnessService) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached = nil
+}
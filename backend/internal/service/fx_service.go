@@ -0,0 +1,390 @@
+package service
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Errors syncing and reading FX rates
+var (
+	ErrInvalidFXFile        = errors.New("invalid FX rate file")
+	ErrFXSyncURLDisabled    = errors.New("FX sync is disabled (referencedata.fxrateurl is not set)")
+	ErrFXHistoryURLDisabled = errors.New("FX history sync is disabled (referencedata.fxhistoryurl is not set)")
+	ErrInvalidFXCurrency    = errors.New("currency must be a 3 letter ISO 4217 code")
+	ErrFXRateNotFound       = errors.New("no FX rate")
+	ErrInvalidFXRange       = errors.New("invalid FX history range")
+)
+
+// fxDownloadTimeout bounds the download of an FX rate file
+const fxDownloadTimeout = 2 * time.Minute
+
+// fxMaxRateAge is how far back a rate is looked for when the date asked for has none, e.g. a
+// weekend or a holiday of the source
+const fxMaxRateAge = 7 * 24 * time.Hour
+
+// fxMaxHistoryDays bounds the date range of a rate history
+const fxMaxHistoryDays = 366
+
+// FXSyncResult summarises an FX rate sync
+type FXSyncResult struct {
+	Source        string            `json:"source"`
+	Days          int               `json:"days"`  // dates with rates in the file
+	Rates         int               `json:"rates"` // rates stored
+	From          string            `json:"from,omitempty"`
+	To            string            `json:"to,omitempty"`
+	RejectedCount int               `json:"rejected_count"`
+	Rejected      []ImportRejection `json:"rejected"` // the first rejections
+}
+
+// FXRateSet is the rates of one date against a base currency
+type FXRateSet struct {
+	Base   string             `json:"base"`
+	Date   string             `json:"date"`            // date of the rates, on or before the date asked for
+	AsOf   string             `json:"as_of"`           // date asked for
+	Source string             `json:"source"`          // publisher of the rates
+	Rates  map[string]float64 `json:"rates"`           // quote currency -> units per 1 base
+	Cross  bool               `json:"cross,omitempty"` // derived through the source's base currency
+}
+
+// FXConversion is an amount converted between currencies
+type FXConversion struct {
+	Amount    float64 `json:"amount"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Rate      float64 `json:"rate"` // units of To per 1 From
+	Converted float64 `json:"converted"`
+	RateDate  string  `json:"rate_date"`
+	AsOf      string  `json:"as_of"`
+	Source    string  `json:"source"`
+}
+
+// FXRatePoint is the rate of a currency pair on a date
+type FXRatePoint struct {
+	Date string  `json:"date"`
+	Rate float64 `json:"rate"`
+}
+
+// FXService loads daily FX reference rates and converts between currencies with them
+type FXService interface {
+	SyncEnabled(history bool) bool
+	Sync(history bool) (*FXSyncResult, error)
+	Rates(base string, asOf time.Time) (*FXRateSet, error)
+	Convert(amount float64, from, to string, asOf time.Time) (*FXConversion, error)
+	History(base, quote string, from, to time.Time) ([]FXRatePoint, error)
+}
+
+type fxService struct {
+	repo       repository.FXRateRepository
+	source     string
+	base       string // currency the source quotes against
+	rateURL    string
+	historyURL string
+	client     *http.Client
+}
+
+// NewFXService creates a new FX service reading the source set in the reference data config
+func NewFXService(repo repository.FXRateRepository, cfg config.ReferenceDataConfig) FXService {
+	source := strings.TrimSpace(cfg.FXSource)
+	if source == "" {
+		source = "ECB"
+	}
+	base := strings.ToUpper(strings.TrimSpace(cfg.FXBaseCurrency))
+	if base == "" {
+		base = "EUR"
+	}
+	return &fxService{
+		repo:       repo,
+		source:     source,
+		base:       base,
+		rateURL:    strings.TrimSpace(cfg.FXRateURL),
+		historyURL: strings.TrimSpace(cfg.FXHistoryURL),
+		client:     &http.Client{Timeout: fxDownloadTimeout},
+	}
+}
+
+// SyncEnabled reports whether the FX rate URL, or the history URL, is configured
+func (s *fxService) SyncEnabled(history bool) bool {
+	if history {
+		return s.historyURL != ""
+	}
+	return s.rateURL != ""
+}
+
+// Sync downloads the FX rate file, or the history file, and stores its rates. Rates already
+// stored for a date are replaced, so a sync can be rerun.
+func (s *fxService) Sync(history bool) (*FXSyncResult, error) {
+	url := s.rateURL
+	if history {
+		if url = s.historyURL; url == "" {
+			return nil, ErrFXHistoryURLDisabled
+		}
+	} else if url == "" {
+		return nil, ErrFXSyncURLDisabled
+	}
+
+	resp, err := s.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download FX rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download FX rates: %s returned %s", url, resp.Status)
+	}
+	return s.sync(resp.Body)
+}
+
+func (s *fxService) sync(file io.Reader) (*FXSyncResult, error) {
+	rates, days, rejected, err := parseEurofxref(file, s.base, s.source)
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w: no rates", ErrInvalidFXFile)
+	}
+	if err := s.repo.Upsert(rates); err != nil {
+		return nil, err
+	}
+
+	result := &FXSyncResult{
+		Source:        s.source,
+		Days:          len(days),
+		Rates:         len(rates),
+		From:          slices.Min(days),
+		To:            slices.Max(days),
+		RejectedCount: len(rejected),
+		Rejected:      rejected,
+	}
+	if len(result.Rejected) > importMaxRejections {
+		result.Rejected = result.Rejected[:importMaxRejections]
+	}
+	return result, nil
+}
+
+// eurofxrefDocument is the ECB euro foreign exchange reference rates layout: a Cube per date,
+// holding a Cube per currency
+type eurofxrefDocument struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// parseEurofxref reads rates in the ECB eurofxref XML layout, returning them with the dates they
+// cover (YYYY-MM-DD) and the rates rejected, numbered by date entry
+func parseEurofxref(file io.Reader, base, source string) ([]*domain.FXRate, []string, []ImportRejection, error) {
+	var doc eurofxrefDocument
+	if err := xml.NewDecoder(file).Decode(&doc); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v", ErrInvalidFXFile, err)
+	}
+
+	var rates []*domain.FXRate
+	var days []string
+	var rejected []ImportRejection
+	for i, day := range doc.Days {
+		reject := func(format string, args ...any) {
+			rejected = append(rejected, ImportRejection{Line: i + 1, Error: fmt.Sprintf(format, args...)})
+		}
+		date, err := time.Parse(time.DateOnly, strings.TrimSpace(day.Time))
+		if err != nil {
+			reject("date %q must be YYYY-MM-DD", day.Time)
+			continue
+		}
+		days = append(days, date.Format(time.DateOnly))
+		for _, entry := range day.Rates {
+			quote := strings.ToUpper(strings.TrimSpace(entry.Currency))
+			rate, err := strconv.ParseFloat(strings.TrimSpace(entry.Rate), 64)
+			switch {
+			case !isLetters(quote, 3) || quote == base:
+				reject("%s: currency %q must be a 3 letter code other than %s", day.Time, entry.Currency, base)
+			case err != nil || rate <= 0 || math.IsInf(rate, 0):
+				reject("%s %s: rate %q must be a positive number", day.Time, quote, entry.Rate)
+			default:
+				rates = append(rates, &domain.FXRate{RateDate: date, BaseCurrency: base, QuoteCurrency: quote, Rate: rate, Source: source})
+			}
+		}
+	}
+	return rates, days, rejected, nil
+}
+
+// Rates returns the rates of the latest date on or before asOf (today if zero), against base. A
+// base other than the source's is derived as a cross rate through it.
+func (s *fxService) Rates(base string, asOf time.Time) (*FXRateSet, error) {
+	base, err := normalizeFXCurrency(base, s.base)
+	if err != nil {
+		return nil, err
+	}
+	asOf = fxAsOf(asOf)
+	date, byQuote, err := s.ratesOn(asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &FXRateSet{
+		Base:   base,
+		Date:   date.Format(time.DateOnly),
+		AsOf:   asOf.Format(time.DateOnly),
+		Source: s.source,
+		Rates:  make(map[string]float64, len(byQuote)),
+		Cross:  base != s.base,
+	}
+	baseRate, ok := byQuote[base]
+	if !ok {
+		return nil, fmt.Errorf("%w for %s on %s", ErrFXRateNotFound, base, set.Date)
+	}
+	for quote, rate := range byQuote {
+		if quote != base {
+			set.Rates[quote] = roundRate(rate / baseRate)
+		}
+	}
+	return set, nil
+}
+
+// Convert converts an amount with the rates of the latest date on or before asOf (today if zero)
+func (s *fxService) Convert(amount float64, from, to string, asOf time.Time) (*FXConversion, error) {
+	from, err := normalizeFXCurrency(from, "")
+	if err != nil {
+		return nil, err
+	}
+	to, err = normalizeFXCurrency(to, "")
+	if err != nil {
+		return nil, err
+	}
+	asOf = fxAsOf(asOf)
+	date, byQuote, err := s.ratesOn(asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range []string{from, to} {
+		if _, ok := byQuote[code]; !ok {
+			return nil, fmt.Errorf("%w for %s on %s", ErrFXRateNotFound, code, date.Format(time.DateOnly))
+		}
+	}
+	rate := roundRate(byQuote[to] / byQuote[from])
+	return &FXConversion{
+		Amount:    amount,
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		Converted: amount * byQuote[to] / byQuote[from],
+		RateDate:  date.Format(time.DateOnly),
+		AsOf:      asOf.Format(time.DateOnly),
+		Source:    s.source,
+	}, nil
+}
+
+// History returns the daily rates of quote against base from one date to another, on the dates
+// the source has rates for both
+func (s *fxService) History(base, quote string, from, to time.Time) ([]FXRatePoint, error) {
+	base, err := normalizeFXCurrency(base, s.base)
+	if err != nil {
+		return nil, err
+	}
+	quote, err = normalizeFXCurrency(quote, "")
+	if err != nil {
+		return nil, err
+	}
+	to = fxAsOf(to)
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	from = truncateToDate(from)
+	if to.Before(from) || to.Sub(from) > fxMaxHistoryDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: from must be on or before to, at most %d days apart", ErrInvalidFXRange, fxMaxHistoryDays)
+	}
+
+	quotes := make([]string, 0, 2)
+	for _, code := range []string{base, quote} {
+		if code != s.base {
+			quotes = append(quotes, code)
+		}
+	}
+	points := make([]FXRatePoint, 0)
+	if len(quotes) == 0 {
+		return points, nil
+	}
+	rates, err := s.repo.FindHistory(s.base, quotes, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]map[string]float64)
+	var dates []string
+	for _, rate := range rates {
+		date := rate.RateDate.Format(time.DateOnly)
+		if byDate[date] == nil {
+			byDate[date] = map[string]float64{s.base: 1}
+			dates = append(dates, date)
+		}
+		byDate[date][rate.QuoteCurrency] = rate.Rate
+	}
+	for _, date := range dates {
+		baseRate, okBase := byDate[date][base]
+		quoteRate, okQuote := byDate[date][quote]
+		if okBase && okQuote {
+			points = append(points, FXRatePoint{Date: date, Rate: roundRate(quoteRate / baseRate)})
+		}
+	}
+	return points, nil
+}
+
+// ratesOn returns the rates of the latest date on or before asOf, within fxMaxRateAge, by quote
+// currency, with the source's base currency at 1
+func (s *fxService) ratesOn(asOf time.Time) (time.Time, map[string]float64, error) {
+	latest, err := s.repo.LatestDate(s.base, asOf)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if latest == nil || asOf.Sub(truncateToDate(*latest)) > fxMaxRateAge {
+		return time.Time{}, nil, fmt.Errorf("%w on or in the week before %s", ErrFXRateNotFound, asOf.Format(time.DateOnly))
+	}
+	rates, err := s.repo.FindByDate(s.base, *latest)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	byQuote := map[string]float64{s.base: 1}
+	for _, rate := range rates {
+		byQuote[rate.QuoteCurrency] = rate.Rate
+	}
+	return truncateToDate(*latest), byQuote, nil
+}
+
+// normalizeFXCurrency upper-cases a currency code, using fallback if it is empty
+func normalizeFXCurrency(code, fallback string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		code = fallback
+	}
+	if !isLetters(code, 3) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidFXCurrency, code)
+	}
+	return code, nil
+}
+
+// fxAsOf returns the date rates are wanted for: asOf, or today if it is zero
+func fxAsOf(asOf time.Time) time.Time {
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	return truncateToDate(asOf)
+}
+
+// roundRate rounds a derived rate to the 10 decimal places fx_rates stores
+func roundRate(rate float64) float64 {
+	return math.Round(rate*1e10) / 1e10
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// GLEIF single-record API configuration
+const (
+	// Default base URL for the GLEIF LEI records API (JSON:API format, one record per call; lei.apibaseurl)
+	GLEIFAPIBaseURL = "https://api.gleif.org/api/v1"
+
+	// GLEIF allows 60 requests per minute per client; stay at one request per second
+	GLEIFAPIRequestInterval = 1 * time.Second
+
+	// ChangedByGLEIFAPI marks records and audit entries sourced from the single-record API
+	ChangedByGLEIFAPI = "gleif-api"
+)
+
+// leiCodePattern matches the ISO 17442 structure: 18 alphanumeric characters plus 2 check digits
+var leiCodePattern = regexp.MustCompile(`^[A-Z0-9]{18}[0-9]{2}$`)
+
+// IsValidLEI validates LEI structure and the ISO 7064 MOD 97-10 check digits
+func IsValidLEI(lei string) bool {
+	if !leiCodePattern.MatchString(lei) {
+		return false
+	}
+
+	remainder := 0
+	for _, ch := range lei {
+		var digits string
+		if ch >= 'A' && ch <= 'Z' {
+			digits = fmt.Sprintf("%d", ch-'A'+10)
+		} else {
+			digits = string(ch)
+		}
+		for _, d := range digits {
+			remainder = (remainder*10 + int(d-'0')) % 97
+		}
+	}
+	return remainder == 1
+}
+
+// GLEIFAPIRecordResponse represents the response from GET /lei-records/{lei}
+// NOTE: This is the SINGLE RECORD API FORMAT, which differs from the bulk file
+// format (LEIJSONRecord). Values are plain JSON fields rather than {"$": value} objects.
+type GLEIFAPIRecordResponse struct {
+	Data GLEIFAPIRecord `json:"data"`
+}
+
+type GLEIFAPIRecord struct {
+	Type       string              `json:"type"`
+	ID         string              `json:"id"`
+	Attributes GLEIFAPIRecordAttrs `json:"attributes"`
+}
+
+type GLEIFAPIRecordAttrs struct {
+	LEI          string               `json:"lei"`
+	Entity       GLEIFAPIEntity       `json:"entity"`
+	Registration GLEIFAPIRegistration `json:"registration"`
+}
+
+type GLEIFAPIEntity struct {
+	LegalName                GLEIFAPIName      `json:"legalName"`
+	OtherNames               []GLEIFAPIName    `json:"otherNames"`
+	TransliteratedOtherNames []GLEIFAPIName    `json:"transliteratedOtherNames"`
+	LegalAddress             GLEIFAPIAddress   `json:"legalAddress"`
+	HeadquartersAddress      GLEIFAPIAddress   `json:"headquartersAddress"`
+	RegisteredAt             GLEIFAPIReference `json:"registeredAt"`
+	RegisteredAs             string            `json:"registeredAs"`
+	Jurisdiction             string            `json:"jurisdiction"`
+	Category                 string            `json:"category"`
+	SubCategory              string            `json:"subCategory"`
+	LegalForm                GLEIFAPIReference `json:"legalForm"`
+	Status                   string            `json:"status"`
+	SuccessorEntity          GLEIFAPISuccessor `json:"successorEntity"`
+}
+
+type GLEIFAPIName struct {
+	Name     string `json:"name"`
+	Language string `json:"language"`
+	Type     string `json:"type"`
+}
+
+type GLEIFAPIAddress struct {
+	Language     string   `json:"language"`
+	AddressLines []string `json:"addressLines"`
+	City         string   `json:"city"`
+	Region       string   `json:"region"`
+	Country      string   `json:"country"`
+	PostalCode   string   `json:"postalCode"`
+}
+
+type GLEIFAPIReference struct {
+	ID    string `json:"id"`
+	Other string `json:"other"`
+}
+
+type GLEIFAPISuccessor struct {
+	LEI  string `json:"lei"`
+	Name string `json:"name"`
+}
+
+type GLEIFAPIRegistration struct {
+	InitialRegistrationDate string            `json:"initialRegistrationDate"`
+	LastUpdateDate          string            `json:"lastUpdateDate"`
+	Status                  string            `json:"status"`
+	NextRenewalDate         string            `json:"nextRenewalDate"`
+	ManagingLOU             string            `json:"managingLou"`
+	CorroborationLevel      string            `json:"corroborationLevel"`
+	ValidatedAt             GLEIFAPIReference `json:"validatedAt"`
+	ValidatedAs             string            `json:"validatedAs"`
+}
+
+// Errors returned by on-demand GLEIF lookups
+var (
+	ErrInvalidLEI         = errors.New("invalid LEI format or check digits")
+	ErrLEINotFoundAtGLEIF = errors.New("LEI not found at GLEIF")
+)
+
+// gleifAPIThrottle spaces GLEIF API calls across all callers (refresh queue and on-demand fetches)
+type gleifAPIThrottle struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the next API call is allowed
+func (t *gleifAPIThrottle) wait() {
+	t.mu.Lock()
+	now := time.Now()
+	slot := t.next
+	if slot.Before(now) {
+		slot = now
+	}
+	t.next = slot.Add(GLEIFAPIRequestInterval)
+	t.mu.Unlock()
+
+	time.Sleep(time.Until(slot))
+}
+
+// reserve takes the next API call if it is allowed now, without waiting; otherwise it returns
+// when it will be
+func (t *gleifAPIThrottle) reserve() (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if t.next.After(now) {
+		return t.next, false
+	}
+	t.next = now.Add(GLEIFAPIRequestInterval)
+	return now, true
+}
+
+// GLEIFThrottledError is returned by an on-demand GLEIF fetch when the API's rate limit is used
+// up; callers should try again at Until rather than wait for it
+type GLEIFThrottledError struct {
+	Until time.Time
+}
+
+func (e *GLEIFThrottledError) Error() string {
+	return fmt.Sprintf("GLEIF API rate limit reached, next call allowed at %s", e.Until.Format(time.RFC3339))
+}
+
+// FetchLEIFromGLEIF fetches a single LEI from the GLEIF API, upserts it with gleif-api provenance
+// and returns the stored record. It does not wait for the API's rate limit: while it is used up,
+// a *GLEIFThrottledError is returned.
+func (s *leiService) FetchLEIFromGLEIF(ctx context.Context, lei string) (*domain.LEIRecord, error) {
+	lei = strings.ToUpper(strings.TrimSpace(lei))
+	if !IsValidLEI(lei) {
+		return nil, ErrInvalidLEI
+	}
+	if until, ok := s.apiThrottle.reserve(); !ok {
+		return nil, &GLEIFThrottledError{Until: until}
+	}
+	return s.storeLEIFromGLEIF(ctx, lei)
+}
+
+// storeLEIFromGLEIF fetches lei, its API call already allowed by the throttle, and upserts it
+func (s *leiService) storeLEIFromGLEIF(ctx context.Context, lei string) (*domain.LEIRecord, error) {
+	record, err := s.fetchLEIFromAPI(ctx, lei)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.UpsertLEIRecord(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to store LEI %s fetched from GLEIF: %w", lei, err)
+	}
+
+	log.Ctx(ctx).Info().Str("lei", lei).Msg("LEI fetched on demand from GLEIF API")
+
+	return s.repo.FindLEIByLEI(lei)
+}
+
+// fetchLEIFromAPI retrieves a single LEI record from the GLEIF API and maps it to the domain model;
+// callers take their turn from apiThrottle first
+func (s *leiService) fetchLEIFromAPI(ctx context.Context, lei string) (*domain.LEIRecord, error) {
+	url := fmt.Sprintf("%s/lei-records/%s", s.gleif.settings.APIURL, lei)
+
+	body, err := s.gleif.get(ctx, url)
+	var status *GLEIFStatusError
+	if errors.As(err, &status) && status.StatusCode == http.StatusNotFound {
+		return nil, ErrLEINotFoundAtGLEIF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch LEI %s from GLEIF: %w", lei, err)
+	}
+
+	var apiResp GLEIFAPIRecordResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to decode GLEIF record response: %w", err)
+	}
+	if apiResp.Data.Attributes.LEI == "" {
+		return nil, ErrLEINotFoundAtGLEIF
+	}
+
+	return apiRecordToDomainRecord(&apiResp.Data.Attributes), nil
+}
+
+// apiRecordToDomainRecord converts a GLEIF API record to a domain.LEIRecord
+// Field mapping mirrors jsonToDomainRecord so bulk and API updates don't produce spurious changes
+func apiRecordToDomainRecord(attrs *GLEIFAPIRecordAttrs) *domain.LEIRecord {
+	entity := attrs.Entity
+	record := &domain.LEIRecord{
+		LEI:                    attrs.LEI,
+		LegalName:              entity.LegalName.Name,
+		LegalAddressCity:       entity.LegalAddress.City,
+		LegalAddressRegion:     entity.LegalAddress.Region,
+		LegalAddressCountry:    entity.LegalAddress.Country,
+		LegalAddressPostalCode: entity.LegalAddress.PostalCode,
+		RegistrationAuthority:  entity.RegisteredAt.ID,
+		RegistrationNumber:     entity.RegisteredAs,
+		EntityCategory:         entity.Category,
+		EntityLegalForm:        entity.LegalForm.ID,
+		EntityStatus:           entity.Status,
+		RegistrationStatus:     attrs.Registration.Status,
+		ManagingLOU:            attrs.Registration.ManagingLOU,
+		CreatedBy:              ChangedByGLEIFAPI,
+		UpdatedBy:              ChangedByGLEIFAPI,
+		// Initialize JSONB fields with valid JSON
+		OtherNames:        "[]",
+		ValidationSources: "{}",
+		ChangedFields:     "{}",
+	}
+
+	for _, name := range entity.TransliteratedOtherNames {
+		if name.Type == "AUTO_ASCII_TRANSLITERATED_LEGAL_NAME" {
+			record.TransliteratedLegalName = name.Name
+			break
+		}
+	}
+
+	if len(entity.OtherNames) > 0 {
+		otherNames := make([]map[string]string, 0, len(entity.OtherNames))
+		for _, name := range entity.OtherNames {
+			otherNames = append(otherNames, map[string]string{
+				"name":     name.Name,
+				"type":     name.Type,
+				"language": name.Language,
+			})
+		}
+		if otherNamesJSON, err := json.Marshal(otherNames); err == nil {
+			record.OtherNames = string(otherNamesJSON)
+		}
+	}
+
+	// The API returns all address lines in one array; the first is the bulk FirstAddressLine
+	legalLines := entity.LegalAddress.AddressLines
+	assignAddressLines(legalLines, &record.LegalAddressLine1, &record.LegalAddressLine2, &record.LegalAddressLine3, &record.LegalAddressLine4)
+
+	hqLines := entity.HeadquartersAddress.AddressLines
+	if len(hqLines) > 0 && strings.TrimSpace(hqLines[0]) != "" {
+		assignAddressLines(hqLines, &record.HQAddressLine1, &record.HQAddressLine2, &record.HQAddressLine3, &record.HQAddressLine4)
+		record.HQAddressCity = entity.HeadquartersAddress.City
+		record.HQAddressRegion = entity.HeadquartersAddress.Region
+		record.HQAddressCountry = entity.HeadquartersAddress.Country
+		record.HQAddressPostalCode = entity.HeadquartersAddress.PostalCode
+	}
+
+	record.InitialRegistrationDate = parseGLEIFDate(attrs.Registration.InitialRegistrationDate)
+	record.LastUpdateDate = parseGLEIFDate(attrs.Registration.LastUpdateDate)
+	record.NextRenewalDate = parseGLEIFDate(attrs.Registration.NextRenewalDate)
+
+	return record
+}
+
+// assignAddressLines copies up to four address lines into the given destinations
+func assignAddressLines(lines []string, dest ...*string) {
+	for i := 0; i < len(lines) && i < len(dest); i++ {
+		*dest[i] = lines[i]
+	}
+}
+
+// parseGLEIFDate parses the date formats used by GLEIF, returning the zero time if unparseable
+func parseGLEIFDate(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
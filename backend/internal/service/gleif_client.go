@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/breaker"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// GLEIFHTTPSettings configures where and how GLEIF is called, for discovery, single-record
+// API calls and bulk file downloads
+type GLEIFHTTPSettings struct {
+	GoldenCopyURL string // Golden copy base URL (GLEIF or an internal mirror), without trailing slash
+	APIURL        string // Single-record API base URL, without trailing slash
+
+	Timeout     time.Duration  // Whole-request limit for API calls; downloads abort after this long without data
+	MaxAttempts int            // Attempts per request, including the first
+	BaseDelay   time.Duration  // Backoff before the first retry; doubled for each further retry
+	MaxDelay    time.Duration  // Upper bound for a single backoff
+	Proxy       *url.URL       // Egress proxy; nil uses the HTTPS_PROXY/NO_PROXY environment
+	RootCAs     *x509.CertPool // Trusted roots; nil uses the system pool
+
+	Breaker *breaker.Breaker // Stops calls after repeated upstream failures; nil never does
+}
+
+// gleifHTTPSettings parses the GLEIF HTTP settings, falling back to defaults for invalid values
+func gleifHTTPSettings(cfg config.LEIConfig) GLEIFHTTPSettings {
+	settings := GLEIFHTTPSettings{
+		GoldenCopyURL: parseBaseURLSetting("lei.goldencopybaseurl", cfg.GoldenCopyBaseURL, GLEIFBaseURL),
+		APIURL:        parseBaseURLSetting("lei.apibaseurl", cfg.APIBaseURL, GLEIFAPIBaseURL),
+		Timeout:       parseHTTPSetting("lei.httptimeout", cfg.HTTPTimeout, 30*time.Second),
+		MaxAttempts:   cfg.HTTPMaxAttempts,
+		BaseDelay:     parseHTTPSetting("lei.httpretrybasedelay", cfg.HTTPRetryBaseDelay, 2*time.Second),
+		MaxDelay:      parseHTTPSetting("lei.httpretrymaxdelay", cfg.HTTPRetryMaxDelay, 1*time.Minute),
+	}
+	if settings.MaxAttempts < 1 {
+		log.Warn().Int("value", cfg.HTTPMaxAttempts).Msg("Invalid lei.httpmaxattempts, using 5")
+		settings.MaxAttempts = 5
+	}
+	if settings.MaxDelay < settings.BaseDelay {
+		settings.MaxDelay = settings.BaseDelay
+	}
+	settings.Breaker = breaker.New("GLEIF", cfg.HTTPBreakerThreshold,
+		parseHTTPSetting("lei.httpbreakercooldown", cfg.HTTPBreakerCooldown, time.Minute))
+
+	if cfg.HTTPProxy != "" {
+		proxy, err := url.Parse(cfg.HTTPProxy)
+		if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+			log.Error().Msg("Invalid lei.httpproxy (expected e.g. http://proxy:8080), using the proxy environment variables")
+		} else {
+			settings.Proxy = proxy
+			log.Info().Str("proxy", proxy.Redacted()).Msg("GLEIF requests use the configured proxy")
+		}
+	}
+	if cfg.CABundle != "" {
+		pool, err := loadCABundle(cfg.CABundle)
+		if err != nil {
+			log.Error().Err(err).Str("file", cfg.CABundle).Msg("Failed to load lei.cabundle, trusting system roots only")
+		} else {
+			settings.RootCAs = pool
+			log.Info().Str("file", cfg.CABundle).Msg("GLEIF requests trust the configured CA bundle")
+		}
+	}
+	return settings
+}
+
+func parseBaseURLSetting(key, value, fallback string) string {
+	value = strings.TrimRight(strings.TrimSpace(value), "/")
+	if value == "" {
+		return fallback
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		log.Warn().
+			Str("key", key).
+			Str("value", value).
+			Str("default", fallback).
+			Msg("Invalid GLEIF base URL, using default")
+		return fallback
+	}
+	if value != fallback {
+		log.Info().Str("key", key).Str("url", value).Msg("Using GLEIF mirror")
+	}
+	return value
+}
+
+// loadCABundle returns the system roots plus the certificates in a PEM file
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+func parseHTTPSetting(key, value string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Warn().
+			Str("key", key).
+			Str("value", value).
+			Dur("default", fallback).
+			Msg("Invalid GLEIF HTTP setting, using default")
+		return fallback
+	}
+	return d
+}
+
+// GLEIFStatusError is returned when GLEIF answers with an unexpected HTTP status
+type GLEIFStatusError struct {
+	StatusCode int
+}
+
+func (e *GLEIFStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d", e.StatusCode)
+}
+
+// retryable reports whether the status is worth retrying (rate limiting and server errors)
+func (e *GLEIFStatusError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// upstreamFailure returns err if it says GLEIF is failing (a network error, 429 or 5xx) rather
+// than answering a request it cannot serve, else nil
+func upstreamFailure(err error) error {
+	var status *GLEIFStatusError
+	if errors.As(err, &status) && !status.retryable() {
+		return nil
+	}
+	return err
+}
+
+// gleifClient makes GLEIF HTTP calls with timeouts and retries transient failures with
+// exponential backoff and jitter. Every attempt goes through the breaker, so retries stop as soon
+// as it opens.
+type gleifClient struct {
+	client   *http.Client
+	settings GLEIFHTTPSettings
+}
+
+func newGLEIFClient(settings GLEIFHTTPSettings) *gleifClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = settings.Timeout
+	if settings.Proxy != nil {
+		transport.Proxy = http.ProxyURL(settings.Proxy)
+	}
+	if settings.RootCAs != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: settings.RootCAs, MinVersion: tls.VersionTLS12}
+	}
+
+	// No client-wide timeout: it would also bound the body of a multi-GB download.
+	// API calls get a per-attempt deadline and downloads an idle timeout instead.
+	return &gleifClient{
+		client:   &http.Client{Transport: transport},
+		settings: settings,
+	}
+}
+
+// get fetches url and returns the body of a 200 response. Network errors, 429 and 5xx are
+// retried; other statuses fail immediately with a *GLEIFStatusError, and calls while the breaker
+// is open with a *breaker.OpenError.
+func (c *gleifClient) get(ctx context.Context, url string) ([]byte, error) {
+	var lastErr error
+	for attempt := 1; attempt <= c.settings.MaxAttempts; attempt++ {
+		if err := c.settings.Breaker.Allow(); err != nil {
+			return nil, err
+		}
+		body, retryAfter, err := c.getOnce(ctx, url)
+		c.settings.Breaker.Record(upstreamFailure(err))
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		var status *GLEIFStatusError
+		if errors.As(err, &status) && !status.retryable() {
+			return nil, err
+		}
+		if attempt == c.settings.MaxAttempts {
+			break
+		}
+		delay := max(c.backoff(attempt), min(retryAfter, c.settings.MaxDelay))
+		log.Ctx(ctx).Warn().
+			Err(err).
+			Str("url", url).
+			Int("attempt", attempt).
+			Dur("retry_in", delay).
+			Msg("GLEIF request failed, retrying")
+		time.Sleep(delay)
+	}
+	return nil, fmt.Errorf("after %d attempts: %w", c.settings.MaxAttempts, lastErr)
+}
+
+// getOnce makes a single attempt, returning any Retry-After the server asked for
+func (c *gleifClient) getOnce(ctx context.Context, url string) ([]byte, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.settings.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // lets the connection be reused
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After")), &GLEIFStatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, 0, nil
+}
+
+// stream sends req and returns the response with its body guarded by the idle timeout: the
+// transfer is aborted if no data arrives for settings.Timeout. The caller closes the body.
+// While the breaker is open it fails with a *breaker.OpenError without sending.
+func (c *gleifClient) stream(req *http.Request) (*http.Response, error) {
+	if err := c.settings.Breaker.Allow(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancelCause(req.Context())
+	idle := time.AfterFunc(c.settings.Timeout, func() {
+		cancel(fmt.Errorf("no data received for %s", c.settings.Timeout))
+	})
+
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		c.settings.Breaker.Record(err)
+		idle.Stop()
+		cancel(nil)
+		return nil, err
+	}
+	if status := (&GLEIFStatusError{StatusCode: resp.StatusCode}); status.retryable() {
+		c.settings.Breaker.Record(status)
+	} else {
+		c.settings.Breaker.Record(nil)
+	}
+	resp.Body = &idleTimeoutBody{ReadCloser: resp.Body, ctx: ctx, timer: idle, timeout: c.settings.Timeout, cancel: cancel}
+	return resp, nil
+}
+
+// mirrored rewrites a file URL published by GLEIF to the configured golden copy mirror, so a
+// mirror can serve GLEIF's publishes responses unchanged. Other URLs are returned as is.
+func (c *gleifClient) mirrored(fileURL string) string {
+	if c.settings.GoldenCopyURL == GLEIFBaseURL {
+		return fileURL
+	}
+	if rest, ok := strings.CutPrefix(fileURL, GLEIFBaseURL+"/"); ok {
+		return c.settings.GoldenCopyURL + "/" + rest
+	}
+	return fileURL
+}
+
+// backoff returns the delay before retry number attempt (1-based): exponential from BaseDelay,
+// capped at MaxDelay, with the upper half jittered so clients retrying together spread out
+func (c *gleifClient) backoff(attempt int) time.Duration {
+	delay := c.settings.BaseDelay << min(attempt-1, 30)
+	if delay <= 0 || delay > c.settings.MaxDelay {
+		delay = c.settings.MaxDelay
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter reads a Retry-After header in seconds or HTTP-date form (0 if absent)
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+// idleTimeoutBody pushes back the idle deadline every time data is read
+type idleTimeoutBody struct {
+	io.ReadCloser
+	ctx     context.Context
+	timer   *time.Timer
+	timeout time.Duration
+	cancel  context.CancelCauseFunc
+}
+
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.timer.Reset(b.timeout)
+	}
+	if err != nil && err != io.EOF && context.Cause(b.ctx) != nil {
+		err = context.Cause(b.ctx) // report the stall rather than "context canceled"
+	}
+	return n, err
+}
+
+func (b *idleTimeoutBody) Close() error {
+	b.timer.Stop()
+	b.cancel(nil)
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/breaker"
+)
+
+// scriptedGLEIF answers each request with the next status in its script, repeating the last one.
+// A zero status stalls past the client's timeout.
+type scriptedGLEIF struct {
+	statuses   []int
+	retryAfter string
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *scriptedGLEIF) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	status := s.statuses[min(s.calls, len(s.statuses)-1)]
+	s.calls++
+	s.mu.Unlock()
+
+	switch status {
+	case 0:
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		return
+	case http.StatusOK:
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	default:
+		if s.retryAfter != "" {
+			w.Header().Set("Retry-After", s.retryAfter)
+		}
+		w.WriteHeader(status)
+	}
+}
+
+func (s *scriptedGLEIF) attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func TestGLEIFClientGet(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		retryAfter   string
+		wantAttempts int
+		wantStatus   int // of the *GLEIFStatusError returned; 0 for success or a network error
+		wantErr      bool
+	}{
+		{name: "first attempt succeeds", statuses: []int{200}, wantAttempts: 1},
+		{name: "server errors are retried", statuses: []int{503, 502, 200}, wantAttempts: 3},
+		{name: "rate limiting is retried", statuses: []int{429, 200}, retryAfter: "120", wantAttempts: 2},
+		{name: "a stalled request times out and is retried", statuses: []int{0, 200}, wantAttempts: 2},
+		{name: "not found fails at once", statuses: []int{404}, wantAttempts: 1, wantStatus: 404, wantErr: true},
+		{name: "bad request fails at once", statuses: []int{503, 400}, wantAttempts: 2, wantStatus: 400, wantErr: true},
+		{name: "gives up after the last attempt", statuses: []int{500}, wantAttempts: 3, wantStatus: 500, wantErr: true},
+		{name: "gives up on a server that keeps stalling", statuses: []int{0}, wantAttempts: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &scriptedGLEIF{statuses: tt.statuses, retryAfter: tt.retryAfter}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			// Retry-After is honoured only up to MaxDelay, so the 120s above costs a millisecond
+			client := newGLEIFClient(GLEIFHTTPSettings{Timeout: 50 * time.Millisecond, MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+			body, err := client.get(context.Background(), ts.URL+"/api/v1/lei-records")
+			assert.Equal(t, tt.wantAttempts, server.attempts())
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.JSONEq(t, `{"data":[]}`, string(body))
+				return
+			}
+			require.Error(t, err)
+			var status *GLEIFStatusError
+			if tt.wantStatus != 0 {
+				require.ErrorAs(t, err, &status)
+				assert.Equal(t, tt.wantStatus, status.StatusCode)
+			} else {
+				assert.False(t, errors.As(err, &status), "a network error has no status")
+			}
+		})
+	}
+}
+
+func TestGLEIFClientStopsAtAnOpenBreaker(t *testing.T) {
+	server := &scriptedGLEIF{statuses: []int{503}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	client := newGLEIFClient(GLEIFHTTPSettings{
+		Timeout: time.Second, MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond,
+		Breaker: breaker.New("GLEIF", 2, time.Minute),
+	})
+
+	_, err := client.get(context.Background(), ts.URL+"/api/v1/lei-records")
+	require.ErrorIs(t, err, breaker.ErrOpen)
+	assert.Equal(t, 2, server.attempts(), "retries stop once the breaker opens")
+
+	// Not found is GLEIF answering, so it does not count towards opening the breaker
+	notFound := &scriptedGLEIF{statuses: []int{404}}
+	ts404 := httptest.NewServer(notFound)
+	defer ts404.Close()
+	client = newGLEIFClient(GLEIFHTTPSettings{
+		Timeout: time.Second, MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond,
+		Breaker: breaker.New("GLEIF", 2, time.Minute),
+	})
+	for range 3 {
+		_, err = client.get(context.Background(), ts404.URL+"/api/v1/lei-records/UNKNOWN")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, breaker.ErrOpen)
+	}
+	assert.Equal(t, breaker.StateClosed, client.settings.Breaker.Status().State)
+}
+
+func TestGLEIFClientBackoff(t *testing.T) {
+	client := &gleifClient{settings: GLEIFHTTPSettings{BaseDelay: 2 * time.Second, MaxDelay: time.Minute}}
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{attempt: 1, ceiling: 2 * time.Second},
+		{attempt: 2, ceiling: 4 * time.Second},
+		{attempt: 5, ceiling: 32 * time.Second},
+		{attempt: 6, ceiling: time.Minute},
+		{attempt: 100, ceiling: time.Minute},
+	}
+	for _, tt := range tests {
+		// Jitter keeps each delay within the upper half of the exponential step
+		for range 50 {
+			delay := client.backoff(tt.attempt)
+			assert.GreaterOrEqual(t, delay, tt.ceiling/2, "attempt %d", tt.attempt)
+			assert.LessOrEqual(t, delay, tt.ceiling, "attempt %d", tt.attempt)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "absent", value: "", want: 0},
+		{name: "seconds", value: "30", want: 30 * time.Second},
+		{name: "zero seconds", value: "0", want: 0},
+		{name: "date in the past", value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+		{name: "garbage", value: "soon", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.value))
+		})
+	}
+
+	future := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Minute), float64(future), float64(2*time.Second), "an HTTP-date is the time until then")
+}
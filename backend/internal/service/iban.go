@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidIBAN is returned for an account number that is an IBAN but not a valid one
+var ErrInvalidIBAN = errors.New("invalid IBAN")
+
+// ibanLengths is the IBAN length of each country in the ISO 13616 IBAN registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// IBANCheck is the outcome of validating an IBAN
+type IBANCheck struct {
+	IBAN        string `json:"iban"` // normalized: upper case, no spaces
+	Valid       bool   `json:"valid"`
+	Error       string `json:"error,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	CheckDigits string `json:"check_digits,omitempty"`
+	BBAN        string `json:"bban,omitempty"`      // the domestic account number
+	Formatted   string `json:"formatted,omitempty"` // in groups of four, as printed
+}
+
+// NormalizeIBAN upper-cases an IBAN and strips the spaces and hyphens of its printed form
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(iban)))
+}
+
+// ValidateIBAN checks a normalized IBAN: a registry country, that country's length, alphanumeric
+// account number and ISO 7064 mod-97 check digits. The error wraps ErrInvalidIBAN and says what
+// is wrong.
+func ValidateIBAN(iban string) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidIBAN, iban, reason)
+	}
+	if len(iban) < 4 {
+		return invalid("too short")
+	}
+	length, ok := ibanLengths[iban[:2]]
+	if !ok {
+		return invalid(fmt.Sprintf("country %s does not use IBANs", iban[:2]))
+	}
+	if len(iban) != length {
+		return invalid(fmt.Sprintf("%s IBANs have %d characters, not %d", iban[:2], length, len(iban)))
+	}
+	if iban[2] < '0' || iban[2] > '9' || iban[3] < '0' || iban[3] > '9' {
+		return invalid("check digits must be digits")
+	}
+	if check := iban[2:4]; check == "00" || check == "01" || check == "99" {
+		return invalid("check digits must be 02 to 98")
+	}
+
+	// Country code and check digits move to the end; letters count as 10 (A) to 35 (Z)
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return invalid("must only contain letters and digits")
+		}
+	}
+	if remainder != 1 {
+		return invalid("check digits do not match")
+	}
+	return nil
+}
+
+// CheckIBAN validates an IBAN as entered and breaks a valid one into its parts
+func CheckIBAN(iban string) *IBANCheck {
+	check := &IBANCheck{IBAN: NormalizeIBAN(iban)}
+	if err := ValidateIBAN(check.IBAN); err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	check.Valid = true
+	check.CountryCode = check.IBAN[:2]
+	check.CheckDigits = check.IBAN[2:4]
+	check.BBAN = check.IBAN[4:]
+
+	groups := make([]string, 0, len(check.IBAN)/4+1)
+	for i := 0; i < len(check.IBAN); i += 4 {
+		groups = append(groups, check.IBAN[i:min(i+4, len(check.IBAN))])
+	}
+	check.Formatted = strings.Join(groups, " ")
+	return check
+}
+
+// looksLikeIBAN reports whether a normalized account number is meant as an IBAN: a registry
+// country code followed by two digits. Other account numbers (e.g. US or domestic ones) are not
+// validated as IBANs.
+func looksLikeIBAN(account string) bool {
+	if len(account) < 4 {
+		return false
+	}
+	_, ok := ibanLengths[account[:2]]
+	return ok && account[2] >= '0' && account[2] <= '9' && account[3] >= '0' && account[3] <= '9'
+}
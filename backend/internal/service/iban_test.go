@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIBAN(t *testing.T) {
+	tests := []struct {
+		name   string
+		iban   string
+		reason string
+	}{
+		{name: "GB", iban: "GB82WEST12345698765432"},
+		{name: "DE", iban: "DE89370400440532013000"},
+		{name: "NO, shortest", iban: "NO9386011117947"},
+		{name: "too short", iban: "GB8", reason: "too short"},
+		{name: "not an IBAN country", iban: "US12345678901234", reason: "country US does not use IBANs"},
+		{name: "wrong length", iban: "GB82WEST1234569876543", reason: "GB IBANs have 22 characters, not 21"},
+		{name: "letter check digits", iban: "GBX2WEST12345698765432", reason: "check digits must be digits"},
+		{name: "reserved check digits", iban: "GB00WEST12345698765432", reason: "check digits must be 02 to 98"},
+		{name: "punctuation", iban: "GB82WEST1234569876543.", reason: "must only contain letters and digits"},
+		{name: "check digits do not match", iban: "GB83WEST12345698765432", reason: "check digits do not match"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIBAN(tt.iban)
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, ErrInvalidIBAN))
+			assert.ErrorContains(t, err, tt.reason)
+		})
+	}
+}
+
+func TestCheckIBAN(t *testing.T) {
+	check := CheckIBAN("gb82 west-1234 5698 7654 32")
+	assert.Equal(t, &IBANCheck{
+		IBAN:        "GB82WEST12345698765432",
+		Valid:       true,
+		CountryCode: "GB",
+		CheckDigits: "82",
+		BBAN:        "WEST12345698765432",
+		Formatted:   "GB82 WEST 1234 5698 7654 32",
+	}, check)
+
+	check = CheckIBAN("GB83WEST12345698765432")
+	assert.False(t, check.Valid)
+	assert.NotEmpty(t, check.Error)
+	assert.Empty(t, check.Formatted)
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// ErrInvalidIDSequence is returned when an ID sequence definition fails validation
+var ErrInvalidIDSequence = errors.New("invalid ID sequence")
+
+// maxIDGenerationAttempts bounds how many allocated values are skipped because they are already in use
+// (e.g. entered by hand before the sequence existed) before generation gives up
+const maxIDGenerationAttempts = 100
+
+// BusinessIDGenerator assigns business identifiers to new records
+type BusinessIDGenerator interface {
+	// Generate returns the next unused identifier for target, or "" when no sequence is active for it
+	Generate(ctx context.Context, target string) (string, error)
+}
+
+// IDGenerationService manages ID sequences and generates business identifiers from them
+type IDGenerationService interface {
+	BusinessIDGenerator
+	ListSequences() ([]*domain.IDSequence, error)
+	GetSequence(id string) (*domain.IDSequence, error)
+	CreateSequence(seq *domain.IDSequence) error
+	UpdateSequence(seq *domain.IDSequence) error
+	DeleteSequence(id string) error
+	// Preview formats the value the sequence would allocate next, without allocating it
+	Preview(seq *domain.IDSequence) string
+}
+
+type idGenerationService struct {
+	repo repository.IDSequenceRepository
+}
+
+// NewIDGenerationService creates a new ID generation service
+func NewIDGenerationService(repo repository.IDSequenceRepository) IDGenerationService {
+	return &idGenerationService{repo: repo}
+}
+
+func (s *idGenerationService) ListSequences() ([]*domain.IDSequence, error) {
+	return s.repo.FindAll()
+}
+
+func (s *idGenerationService) GetSequence(id string) (*domain.IDSequence, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *idGenerationService) CreateSequence(seq *domain.IDSequence) error {
+	if seq.NextValue == 0 {
+		seq.NextValue = 1
+	}
+	if err := s.validate(seq); err != nil {
+		return err
+	}
+	return s.repo.Create(seq)
+}
+
+// UpdateSequence changes a sequence's format or range. Moving next_value backwards is allowed
+// (e.g. to reuse a released range); values already in use are skipped at generation time.
+func (s *idGenerationService) UpdateSequence(seq *domain.IDSequence) error {
+	existing, err := s.repo.FindByID(seq.ID.String())
+	if err != nil {
+		return err
+	}
+	if seq.NextValue == 0 {
+		seq.NextValue = existing.NextValue
+	}
+	if err := s.validate(seq); err != nil {
+		return err
+	}
+	seq.CreatedAt = existing.CreatedAt
+	return s.repo.Update(seq)
+}
+
+func (s *idGenerationService) DeleteSequence(id string) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *idGenerationService) Preview(seq *domain.IDSequence) string {
+	return formatBusinessID(seq, seq.NextValue)
+}
+
+// Generate allocates values from the target's active sequence until one is not already in use
+func (s *idGenerationService) Generate(ctx context.Context, target string) (string, error) {
+	seq, err := s.repo.FindActiveByTarget(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to load ID sequence: %w", err)
+	}
+	if seq == nil {
+		return "", nil
+	}
+
+	for attempt := 0; attempt < maxIDGenerationAttempts; attempt++ {
+		value, err := s.repo.Allocate(seq.ID.String())
+		if err != nil {
+			return "", fmt.Errorf("failed to allocate from ID sequence %s: %w", seq.Name, err)
+		}
+		id := formatBusinessID(seq, value)
+
+		taken, err := s.repo.BusinessKeyExists(target, id)
+		if err != nil {
+			return "", fmt.Errorf("failed to check business key uniqueness: %w", err)
+		}
+		if !taken {
+			return id, nil
+		}
+		log.Ctx(ctx).Warn().Str("sequence", seq.Name).Str("business_id", id).Msg("Generated business ID already in use, skipping")
+	}
+	return "", fmt.Errorf("ID sequence %s: no unused value in %d attempts", seq.Name, maxIDGenerationAttempts)
+}
+
+func (s *idGenerationService) validate(seq *domain.IDSequence) error {
+	seq.Name = strings.TrimSpace(seq.Name)
+	seq.Target = strings.ToUpper(seq.Target)
+	seq.Prefix = strings.ToUpper(strings.TrimSpace(seq.Prefix))
+	seq.CheckDigit = strings.ToUpper(seq.CheckDigit)
+	if seq.CheckDigit == "" {
+		seq.CheckDigit = domain.CheckDigitNone
+	}
+
+	switch {
+	case seq.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidIDSequence)
+	case seq.Target != domain.IDTargetEntity && seq.Target != domain.IDTargetAccount:
+		return fmt.Errorf("%w: target must be ENTITY or ACCOUNT", ErrInvalidIDSequence)
+	case seq.CheckDigit != domain.CheckDigitNone && seq.CheckDigit != domain.CheckDigitLuhn && seq.CheckDigit != domain.CheckDigitMod97:
+		return fmt.Errorf("%w: check_digit must be NONE, LUHN or MOD97", ErrInvalidIDSequence)
+	case seq.Padding < 0 || seq.Padding > 20:
+		return fmt.Errorf("%w: padding must be between 0 and 20", ErrInvalidIDSequence)
+	case seq.NextValue < 1:
+		return fmt.Errorf("%w: next_value must be positive", ErrInvalidIDSequence)
+	case seq.MaxValue != 0 && seq.MaxValue < seq.NextValue-1:
+		return fmt.Errorf("%w: max_value is below the range already allocated", ErrInvalidIDSequence)
+	}
+	for _, r := range seq.Prefix {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return fmt.Errorf("%w: prefix may only contain letters, digits, '-' and '_'", ErrInvalidIDSequence)
+		}
+	}
+
+	if seq.Active {
+		active, err := s.repo.FindActiveByTarget(seq.Target)
+		if err != nil {
+			return err
+		}
+		if active != nil && active.ID != seq.ID {
+			return fmt.Errorf("%w: sequence %s is already active for %s", ErrInvalidIDSequence, active.Name, seq.Target)
+		}
+	}
+	return nil
+}
+
+// formatBusinessID renders prefix + zero-padded value + check digits
+func formatBusinessID(seq *domain.IDSequence, value int64) string {
+	number := fmt.Sprintf("%0*d", seq.Padding, value)
+	switch seq.CheckDigit {
+	case domain.CheckDigitLuhn:
+		return seq.Prefix + number + strconv.Itoa(luhnCheckDigit(number))
+	case domain.CheckDigitMod97:
+		return seq.Prefix + number + fmt.Sprintf("%02d", mod97CheckDigits(seq.Prefix+number))
+	}
+	return seq.Prefix + number
+}
+
+// luhnCheckDigit computes the Luhn (mod 10) check digit for a string of digits
+func luhnCheckDigit(digits string) int {
+	sum := 0
+	double := true // the check digit will occupy the rightmost position
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+// mod97CheckDigits computes ISO 7064 MOD 97-10 check digits; letters count as 10 (A) to 35 (Z)
+// and separators are ignored
+func mod97CheckDigits(s string) int {
+	remainder := 0
+	add := func(n int) {
+		if n >= 10 {
+			remainder = (remainder*100 + n) % 97
+		} else {
+			remainder = (remainder*10 + n) % 97
+		}
+	}
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			add(int(r - '0'))
+		case r >= 'A' && r <= 'Z':
+			add(int(r-'A') + 10)
+		}
+	}
+	// Append two zero digits, then the check makes the whole string ≡ 1 (mod 97)
+	remainder = remainder * 100 % 97
+	return 98 - remainder
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// InstrumentService manages financial instruments
+type InstrumentService interface {
+	Create(ctx context.Context, instrument *domain.Instrument) error
+	GetByID(id string) (*domain.Instrument, error)
+	GetAll(limit, offset int) ([]*domain.Instrument, error)
+	GetAllWithFilters(q *query.ListQuery) ([]*domain.Instrument, error)
+	Update(ctx context.Context, instrument *domain.Instrument) error
+	Validate(instrument *domain.Instrument) error
+	Delete(ctx context.Context, id string) error
+}
+
+type instrumentService struct {
+	repo       repository.InstrumentRepository
+	currencies repository.CurrencyRepository
+	exchanges  repository.ExchangeRepository
+}
+
+func NewInstrumentService(repo repository.InstrumentRepository, currencies repository.CurrencyRepository, exchanges repository.ExchangeRepository) InstrumentService {
+	return &instrumentService{repo: repo, currencies: currencies, exchanges: exchanges}
+}
+
+// Create saves a new instrument with its codes; its CFI code and codes with a standard structure
+// (e.g. ISIN) must be valid, its MICs must be in the MIC list, and a CFI code sets the instrument
+// type
+func (s *instrumentService) Create(ctx context.Context, instrument *domain.Instrument) error {
+	if err := s.check(instrument, nil); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, instrument)
+}
+
+func (s *instrumentService) GetByID(id string) (*domain.Instrument, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *instrumentService) GetAll(limit, offset int) ([]*domain.Instrument, error) {
+	return s.repo.FindAll(limit, offset)
+}
+
+func (s *instrumentService) GetAllWithFilters(q *query.ListQuery) ([]*domain.Instrument, error) {
+	return s.repo.FindAllWithFilters(q)
+}
+
+// Update saves the instrument; a new or changed CFI code, codes and MICs are validated as on create, and the review flag
+// is owned by the DQ workflow and is carried over unchanged
+func (s *instrumentService) Update(ctx context.Context, instrument *domain.Instrument) error {
+	existing, err := s.repo.FindByID(instrument.ID.String())
+	if err != nil {
+		return err
+	}
+	if err := s.check(instrument, existing); err != nil {
+		return err
+	}
+	instrument.ReviewRequired = existing.ReviewRequired
+	instrument.ReviewReason = existing.ReviewReason
+	return s.repo.Update(ctx, instrument)
+}
+
+// Validate runs the checks of Create, or of Update for an instrument with an ID, without saving
+func (s *instrumentService) Validate(instrument *domain.Instrument) error {
+	var existing *domain.Instrument
+	if instrument.ID != uuid.Nil {
+		var err error
+		if existing, err = s.repo.FindByID(instrument.ID.String()); err != nil {
+			return err
+		}
+	}
+	return s.check(instrument, existing)
+}
+
+// check validates a new instrument, or an update of existing: its issue currency must be active
+// unless unchanged, and its CFI code, codes and MICs valid
+func (s *instrumentService) check(instrument, existing *domain.Instrument) error {
+	var previousCurrency *uuid.UUID
+	if existing != nil {
+		previousCurrency = existing.IssueCurrencyID
+	}
+	if err := requireActiveCurrency(s.currencies, instrument.IssueCurrencyID, previousCurrency); err != nil {
+		return err
+	}
+	return validateInstrument(s.exchanges, instrument, existing)
+}
+
+func (s *instrumentService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
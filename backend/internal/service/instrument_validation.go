@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// ErrInvalidInstrument is wrapped by InstrumentValidationError
+var ErrInvalidInstrument = errors.New("invalid instrument")
+
+// InstrumentFieldError says what is wrong with one field of an instrument
+type InstrumentFieldError struct {
+	Field string `json:"field"` // e.g. cfi or codes[0].code_value
+	Error string `json:"error"`
+}
+
+// InstrumentValidationError carries every invalid field of an instrument write
+type InstrumentValidationError struct {
+	Fields []InstrumentFieldError
+}
+
+func (e *InstrumentValidationError) Error() string {
+	return fmt.Sprintf("%s: %d field error(s)", ErrInvalidInstrument, len(e.Fields))
+}
+
+func (e *InstrumentValidationError) Unwrap() error {
+	return ErrInvalidInstrument
+}
+
+// validateInstrument normalizes an instrument's identifiers and validates them: the CFI code, which
+// then sets the instrument type, the codes that have a standard structure and the MICs of the
+// primary exchange and codes, which must be in the MIC list. Values unchanged from existing are not
+// re-checked. Returns an *InstrumentValidationError listing every failure, or nil; other errors
+// (e.g. a failed MIC lookup) are returned as is.
+func validateInstrument(exchanges repository.ExchangeRepository, instrument, existing *domain.Instrument) error {
+	var fields []InstrumentFieldError
+
+	instrument.CFI = NormalizeCFI(instrument.CFI)
+	if instrument.CFI != "" {
+		if existing == nil || instrument.CFI != existing.CFI {
+			if err := ValidateCFI(instrument.CFI); err != nil {
+				fields = append(fields, InstrumentFieldError{Field: "cfi", Error: err.Error()})
+			}
+		}
+		if len(fields) == 0 {
+			if derived := InstrumentTypeForCFI(instrument.CFI); derived != "" {
+				instrument.Type = derived
+			}
+		}
+	}
+
+	instrument.PrimaryExchange = NormalizeMIC(instrument.PrimaryExchange)
+	var previousExchange string
+	var existingCodes []domain.InstrumentCode
+	if existing != nil {
+		previousExchange = existing.PrimaryExchange
+		existingCodes = existing.Codes
+	}
+	if err := requireKnownMIC(exchanges, instrument.PrimaryExchange, previousExchange); err != nil {
+		if !errors.Is(err, ErrInvalidMIC) && !errors.Is(err, ErrUnknownMIC) {
+			return err
+		}
+		fields = append(fields, InstrumentFieldError{Field: "primary_exchange", Error: err.Error()})
+	}
+	fields = append(fields, checkInstrumentCodes(instrument.Codes, existingCodes)...)
+	micFields, err := checkInstrumentCodeMICs(exchanges, instrument.Codes, existingCodes)
+	if err != nil {
+		return err
+	}
+	fields = append(fields, micFields...)
+
+	if len(fields) > 0 {
+		return &InstrumentValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// checkInstrumentCodes normalizes an instrument's codes and validates those that have a standard
+// structure. Codes unchanged from existing (same ID, type and value) are not re-checked.
+func checkInstrumentCodes(codes []domain.InstrumentCode, existing []domain.InstrumentCode) []InstrumentFieldError {
+	previous := make(map[uuid.UUID]domain.InstrumentCode, len(existing))
+	for _, code := range existing {
+		previous[code.ID] = code
+	}
+
+	var fields []InstrumentFieldError
+	for i := range codes {
+		code := &codes[i]
+		code.CodeType = domain.CodeType(strings.ToUpper(strings.TrimSpace(string(code.CodeType))))
+		if code.CodeType == domain.CodeTypeISIN {
+			code.CodeValue = NormalizeISIN(code.CodeValue)
+		}
+		if old, ok := previous[code.ID]; ok && code.ID != uuid.Nil && old.CodeType == code.CodeType && old.CodeValue == code.CodeValue {
+			continue
+		}
+
+		var err error
+		switch code.CodeType {
+		case domain.CodeTypeISIN:
+			err = ValidateISIN(code.CodeValue)
+		}
+		if err != nil {
+			fields = append(fields, InstrumentFieldError{Field: fmt.Sprintf("codes[%d].code_value", i), Error: err.Error()})
+		}
+	}
+	return fields
+}
+
+// checkInstrumentCodeMICs normalizes the MICs of an instrument's codes and checks them against the
+// MIC list. A code's MIC unchanged from existing (same ID) is not re-checked.
+func checkInstrumentCodeMICs(exchanges repository.ExchangeRepository, codes []domain.InstrumentCode, existing []domain.InstrumentCode) ([]InstrumentFieldError, error) {
+	previous := make(map[uuid.UUID]string, len(existing))
+	for _, code := range existing {
+		previous[code.ID] = code.MarketIdentifierCode
+	}
+
+	var fields []InstrumentFieldError
+	for i := range codes {
+		code := &codes[i]
+		code.MarketIdentifierCode = NormalizeMIC(code.MarketIdentifierCode)
+		var old string
+		if code.ID != uuid.Nil {
+			old = previous[code.ID]
+		}
+		err := requireKnownMIC(exchanges, code.MarketIdentifierCode, old)
+		if errors.Is(err, ErrInvalidMIC) || errors.Is(err, ErrUnknownMIC) {
+			fields = append(fields, InstrumentFieldError{Field: fmt.Sprintf("codes[%d].market_identifier_code", i), Error: err.Error()})
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
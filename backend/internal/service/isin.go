@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidISIN is returned for an ISIN that fails the ISO 6166 structure or check digit
+var ErrInvalidISIN = errors.New("invalid ISIN")
+
+// NormalizeISIN upper-cases an ISIN and strips its spaces
+func NormalizeISIN(isin string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(isin), " ", ""))
+}
+
+// ValidateISIN checks a normalized ISIN: a 2-letter country prefix, a 9-character alphanumeric
+// national security identifier and a Luhn check digit. The error wraps ErrInvalidISIN and says
+// what is wrong.
+func ValidateISIN(isin string) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidISIN, isin, reason)
+	}
+	if len(isin) != 12 {
+		return invalid("must be 12 characters")
+	}
+	for i, r := range isin {
+		letter := r >= 'A' && r <= 'Z'
+		digit := r >= '0' && r <= '9'
+		switch {
+		case i < 2 && !letter:
+			return invalid("must start with a 2-letter country code")
+		case i == 11 && !digit:
+			return invalid("check digit must be a digit")
+		case !letter && !digit:
+			return invalid("must only contain letters and digits")
+		}
+	}
+	if check := isinCheckDigit(isin[:11]); check != isin[11] {
+		return invalid(fmt.Sprintf("check digit should be %c", check))
+	}
+	return nil
+}
+
+// isinCheckDigit computes the check digit of the first 11 characters of an ISIN: letters are
+// expanded to two digits (A=10 ... Z=35) and the Luhn algorithm is run over the digits
+func isinCheckDigit(body string) byte {
+	digits := make([]int, 0, 2*len(body))
+	for _, r := range body {
+		if r >= 'A' && r <= 'Z' {
+			value := int(r-'A') + 10
+			digits = append(digits, value/10, value%10)
+		} else {
+			digits = append(digits, int(r-'0'))
+		}
+	}
+	// Doubling starts from the rightmost digit, the check digit not yet being there
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
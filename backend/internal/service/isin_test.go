@@ -0,0 +1,40 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateISIN(t *testing.T) {
+	tests := []struct {
+		name   string
+		isin   string
+		reason string
+	}{
+		{name: "Apple", isin: "US0378331005"},
+		{name: "BAE Systems", isin: "GB0002634946"},
+		{name: "letters in the body", isin: "AU0000XVGZA3"},
+		{name: "too short", isin: "US037833100", reason: "must be 12 characters"},
+		{name: "numeric country", isin: "120378331005", reason: "must start with a 2-letter country code"},
+		{name: "letter check digit", isin: "US037833100X", reason: "check digit must be a digit"},
+		{name: "punctuation", isin: "US03783-1005", reason: "must only contain letters and digits"},
+		{name: "wrong check digit", isin: "US0378331006", reason: "check digit should be 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateISIN(tt.isin)
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, ErrInvalidISIN))
+			assert.ErrorContains(t, err, tt.reason)
+		})
+	}
+}
+
+func TestNormalizeISIN(t *testing.T) {
+	assert.Equal(t, "US0378331005", NormalizeISIN(" us03 7833 1005 "))
+}
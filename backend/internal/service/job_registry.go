@@ -0,0 +1,372 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/errreport"
+)
+
+// Job statuses
+const (
+	JobStatusRunning   = "RUNNING"
+	JobStatusCompleted = "COMPLETED"
+	JobStatusFailed    = "FAILED"
+)
+
+// Job kinds
+const (
+	JobKindLEIFullSync       = "LEI_FULL_SYNC"
+	JobKindLEIDeltaSync      = "LEI_DELTA_SYNC"
+	JobKindLEIRepexSync      = "LEI_REPEX_SYNC"
+	JobKindLEIFileProcessing = "LEI_FILE_PROCESSING"
+	JobKindLEIFileResume     = "LEI_FILE_RESUME"
+	JobKindLEICleanup        = "LEI_CLEANUP"
+	JobKindExport            = "EXPORT"
+	JobKindAuditPrune        = "AUDIT_PRUNE"
+	JobKindRenewalReport     = "LEI_RENEWAL_REPORT"
+	JobKindLEIReconciliation = "LEI_RECONCILIATION"
+	JobKindEntityEnrichment  = "ENTITY_LEI_ENRICHMENT"
+	JobKindEntityDedupScan   = "ENTITY_DUPLICATE_SCAN"
+	JobKindMICRefresh        = "MIC_REFRESH"
+	JobKindCountrySync       = "COUNTRY_SYNC"
+	JobKindCurrencySync      = "CURRENCY_SYNC"
+	JobKindELFRefresh        = "ELF_REFRESH"
+	JobKindRARefresh         = "RA_REFRESH"
+	JobKindLOUSync           = "LOU_SYNC"
+	JobKindFXSync            = "FX_SYNC"
+	JobKindDataImport        = "DATA_IMPORT"
+)
+
+// Job owners for work not started by a user
+const (
+	JobOwnerScheduler = "scheduler"
+	JobOwnerSystem    = "system"
+)
+
+// Job event types sent to subscribers
+const (
+	JobEventStarted  = "started"
+	JobEventProgress = "progress"
+	JobEventFinished = "finished"
+)
+
+const (
+	jobRetention         = 1 * time.Hour // finished jobs stay listed this long
+	jobMaxFinished       = 200           // and at most this many of them
+	jobSubscriberBuffer  = 64
+	jobProgressMinPeriod = 1 * time.Second // progress events per job are throttled to one per period
+)
+
+// JobInfo is a snapshot of a background operation
+type JobInfo struct {
+	ID          uuid.UUID         `json:"id"`
+	Kind        string            `json:"kind"`
+	Description string            `json:"description"`
+	Owner       string            `json:"owner"` // email of the requesting user, or scheduler/system
+	Status      string            `json:"status"`
+	Stage       string            `json:"stage,omitempty"` // current step, e.g. "downloading"
+	Processed   int64             `json:"processed"`
+	Total       int64             `json:"total"` // 0 when unknown
+	Error       string            `json:"error,omitempty"`
+	Context     map[string]string `json:"context,omitempty"` // IDs of what the job works on, e.g. source_file_id
+	StartedAt   time.Time         `json:"started_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	FinishedAt  *time.Time        `json:"finished_at,omitempty"`
+}
+
+// JobEvent is a change to a job, delivered to registry subscribers
+type JobEvent struct {
+	Type string  `json:"type"`
+	Job  JobInfo `json:"job"`
+}
+
+// Job is a handle on a registered background operation, used by the code doing the work
+// to report progress. All methods are safe for concurrent use and no-ops on a nil Job.
+type Job struct {
+	registry      *JobRegistry
+	info          JobInfo
+	lastPublished time.Time
+}
+
+// JobRegistry tracks the background operations running in this process (syncs, file processing,
+// exports, cleanups) so operators can see what is running, for whom and how far it has got.
+// Finished jobs remain listed for a while so their outcome can be read.
+type JobRegistry struct {
+	mu          sync.RWMutex
+	jobs        map[uuid.UUID]*Job
+	subscribers map[chan JobEvent]struct{}
+}
+
+// NewJobRegistry creates an empty job registry
+func NewJobRegistry() *JobRegistry {
+	return &JobRegistry{
+		jobs:        make(map[uuid.UUID]*Job),
+		subscribers: make(map[chan JobEvent]struct{}),
+	}
+}
+
+// Start registers a running job
+func (r *JobRegistry) Start(kind, owner, description string) *Job {
+	now := time.Now()
+	job := &Job{
+		registry: r,
+		info: JobInfo{
+			ID:          uuid.New(),
+			Kind:        kind,
+			Description: description,
+			Owner:       owner,
+			Status:      JobStatusRunning,
+			StartedAt:   now,
+			UpdatedAt:   now,
+		},
+	}
+
+	r.mu.Lock()
+	r.pruneLocked(now)
+	r.jobs[job.info.ID] = job
+	r.publishLocked(JobEvent{Type: JobEventStarted, Job: job.info})
+	r.mu.Unlock()
+
+	log.Debug().Str("job_id", job.info.ID.String()).Str("kind", kind).Str("owner", owner).Msg("Job started")
+	return job
+}
+
+// Run registers a job, runs fn synchronously and records its outcome
+func (r *JobRegistry) Run(kind, owner, description string, fn func(job *Job) error) error {
+	job := r.Start(kind, owner, description)
+	err := runJob(job, fn)
+	job.Finish(err)
+	return err
+}
+
+// Go registers a job and runs fn in a new goroutine, returning the job immediately
+// Failures are logged and recorded on the job
+func (r *JobRegistry) Go(kind, owner, description string, fn func(job *Job) error) JobInfo {
+	job := r.Start(kind, owner, description)
+	go func() {
+		err := runJob(job, fn)
+		if err != nil {
+			log.Error().Err(err).Str("job_id", job.ID().String()).Str("kind", kind).Msg("Background job failed")
+		}
+		job.Finish(err)
+	}()
+	return job.Info()
+}
+
+// jobPanic is a panic recovered from a job; it has been reported with its stack already
+type jobPanic struct {
+	value any
+}
+
+func (p *jobPanic) Error() string {
+	return fmt.Sprintf("panic: %v", p.value)
+}
+
+// runJob converts a panic in fn into an error so the job is never left RUNNING
+func runJob(job *Job, fn func(job *Job) error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Error().Interface("panic", p).Str("job_id", job.ID().String()).Msg("PANIC in background job")
+			errreport.Panic(p, job.reportTags())
+			err = &jobPanic{value: p}
+		}
+	}()
+	return fn(job)
+}
+
+// List returns running jobs and recently finished ones, newest first
+func (r *JobRegistry) List() []JobInfo {
+	r.mu.Lock()
+	r.pruneLocked(time.Now())
+	items := make([]JobInfo, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		items = append(items, job.info)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(items, func(i, j int) bool { return items[i].StartedAt.After(items[j].StartedAt) })
+	return items
+}
+
+// Get returns a job by ID
+func (r *JobRegistry) Get(id uuid.UUID) (JobInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return JobInfo{}, false
+	}
+	return job.info, true
+}
+
+// Subscribe returns a channel of job events and a function that cancels the subscription
+// Events are dropped for subscribers that fall behind rather than blocking the jobs
+func (r *JobRegistry) Subscribe() (<-chan JobEvent, func()) {
+	ch := make(chan JobEvent, jobSubscriberBuffer)
+	r.mu.Lock()
+	r.subscribers[ch] = struct{}{}
+	r.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			r.mu.Lock()
+			delete(r.subscribers, ch)
+			r.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (r *JobRegistry) publishLocked(event JobEvent) {
+	for ch := range r.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// pruneLocked drops finished jobs past their retention, and the oldest beyond jobMaxFinished
+func (r *JobRegistry) pruneLocked(now time.Time) {
+	var finished []*Job
+	for id, job := range r.jobs {
+		if job.info.FinishedAt == nil {
+			continue
+		}
+		if now.Sub(*job.info.FinishedAt) > jobRetention {
+			delete(r.jobs, id)
+			continue
+		}
+		finished = append(finished, job)
+	}
+	if len(finished) <= jobMaxFinished {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].info.FinishedAt.Before(*finished[j].info.FinishedAt) })
+	for _, job := range finished[:len(finished)-jobMaxFinished] {
+		delete(r.jobs, job.info.ID)
+	}
+}
+
+// ID returns the job's identifier
+func (j *Job) ID() uuid.UUID {
+	if j == nil {
+		return uuid.Nil
+	}
+	return j.info.ID
+}
+
+// Info returns a snapshot of the job
+func (j *Job) Info() JobInfo {
+	if j == nil {
+		return JobInfo{}
+	}
+	j.registry.mu.RLock()
+	defer j.registry.mu.RUnlock()
+	return j.info
+}
+
+// SetStage records the step the job is on
+func (j *Job) SetStage(stage string) {
+	j.update(true, func(info *JobInfo) { info.Stage = stage })
+}
+
+// SetContext records an ID of what the job works on, such as source_file_id
+func (j *Job) SetContext(key, value string) {
+	j.update(true, func(info *JobInfo) {
+		// Snapshots already taken share the old map, so it is replaced rather than changed
+		context := make(map[string]string, len(info.Context)+1)
+		for k, v := range info.Context {
+			context[k] = v
+		}
+		context[key] = value
+		info.Context = context
+	})
+}
+
+// SetProgress records how many items have been processed out of total (0 if unknown)
+func (j *Job) SetProgress(processed, total int64) {
+	j.update(false, func(info *JobInfo) {
+		info.Processed = processed
+		info.Total = total
+	})
+}
+
+// Finish records the job's outcome; later calls are ignored
+func (j *Job) Finish(err error) {
+	if j == nil {
+		return
+	}
+	r := j.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if j.info.FinishedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	j.info.UpdatedAt = now
+	j.info.FinishedAt = &now
+	j.info.Status = JobStatusCompleted
+	if err != nil {
+		j.info.Status = JobStatusFailed
+		j.info.Error = err.Error()
+		// Panics were reported where they were recovered; an interruption by shutdown is not a fault
+		var panicked *jobPanic
+		if !errors.As(err, &panicked) && !errors.Is(err, ErrProcessingInterrupted) {
+			errreport.Error(err, j.reportTagsLocked())
+		}
+	}
+	r.publishLocked(JobEvent{Type: JobEventFinished, Job: j.info})
+}
+
+// reportTags describes the job to the error tracker
+func (j *Job) reportTags() map[string]string {
+	j.registry.mu.RLock()
+	defer j.registry.mu.RUnlock()
+	return j.reportTagsLocked()
+}
+
+func (j *Job) reportTagsLocked() map[string]string {
+	tags := map[string]string{
+		"job_id":    j.info.ID.String(),
+		"job_kind":  j.info.Kind,
+		"job_owner": j.info.Owner,
+	}
+	if j.info.Stage != "" {
+		tags["job_stage"] = j.info.Stage
+	}
+	for key, value := range j.info.Context {
+		tags[key] = value
+	}
+	return tags
+}
+
+// update applies change to a running job and notifies subscribers; progress-only changes
+// (force false) are published at most once per jobProgressMinPeriod
+func (j *Job) update(force bool, change func(info *JobInfo)) {
+	if j == nil {
+		return
+	}
+	r := j.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if j.info.FinishedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	change(&j.info)
+	j.info.UpdatedAt = now
+	if force || now.Sub(j.lastPublished) >= jobProgressMinPeriod {
+		j.lastPublished = now
+		r.publishLocked(JobEvent{Type: JobEventProgress, Job: j.info})
+	}
+}
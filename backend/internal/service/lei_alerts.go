@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/notify"
+)
+
+// Notification events raised by LEI processing
+const (
+	NotifyEventSyncFailed       = "sync_failed"       // a sync job's status went to FAILED
+	NotifyEventRetriesExhausted = "retries_exhausted" // a source file failed with no automatic retries left
+	NotifyEventFileStalled      = "file_stalled"      // the watchdog aborted a file stuck IN_PROGRESS
+	NotifyEventLEILapsed        = "lei_lapsed"        // LEIs used by master data lapsed or were retired
+)
+
+// lapsedRegistrationStatuses are the registration statuses that make an LEI unusable for reporting
+var lapsedRegistrationStatuses = []string{"LAPSED", "RETIRED"}
+
+// lapsedAlertMaxLEIs caps the LEIs listed in one lei_lapsed alert
+const lapsedAlertMaxLEIs = 50
+
+// notifySyncFailed alerts that a sync job failed
+func (s *leiService) notifySyncFailed(status *domain.FileProcessingStatus) {
+	fields := map[string]string{"job_type": status.JobType}
+	if status.CurrentSourceFileID != nil {
+		fields["source_file_id"] = status.CurrentSourceFileID.String()
+	}
+	s.notifier.Notify(notify.Notification{
+		Event:    NotifyEventSyncFailed,
+		Severity: notify.SeverityWarning,
+		Title:    fmt.Sprintf("LEI %s sync failed", status.JobType),
+		Message:  status.ErrorMessage,
+		Fields:   fields,
+	})
+}
+
+// notifyIfRetriesExhausted alerts when a failed source file will not be retried automatically again
+func (s *leiService) notifyIfRetriesExhausted(file *domain.SourceFile) {
+	if file.RetryCount < file.MaxRetries {
+		return
+	}
+	s.notifier.Notify(notify.Notification{
+		Event:    NotifyEventRetriesExhausted,
+		Severity: notify.SeverityCritical,
+		Title:    fmt.Sprintf("LEI source file %s failed after %d retries", file.FileName, file.RetryCount),
+		Message:  file.ProcessingError + "\n\nThe file will not be retried automatically; resume it with POST /api/v1/lei/source-file/" + file.ID.String() + "/resume once the cause is fixed.",
+		Fields: map[string]string{
+			"source_file_id":     file.ID.String(),
+			"file_type":          file.FileType,
+			"failure_category":   file.FailureCategory,
+			"retry_count":        strconv.Itoa(file.RetryCount),
+			"last_processed_lei": file.LastProcessedLEI,
+		},
+	})
+}
+
+// notifyFileStalled alerts that a file stopped making progress and was aborted
+func (s *leiService) notifyFileStalled(sourceFileID uuid.UUID, cause error) {
+	s.notifier.Notify(notify.Notification{
+		Event:    NotifyEventFileStalled,
+		Severity: notify.SeverityCritical,
+		Title:    "LEI file processing stalled and was aborted",
+		Message:  cause.Error() + "\n\nCheck for database locks or hung connections.",
+		Fields:   map[string]string{"source_file_id": sourceFileID.String()},
+	})
+}
+
+// alertLapsedLEIs alerts when LEIs that entities (and through them SSIs) refer to lapsed or were
+// retired while a source file was loaded. LEIs nothing refers to are not reported.
+func (s *leiService) alertLapsedLEIs(file *domain.SourceFile) {
+	transitions, err := s.repo.FindRegistrationStatusTransitions(file.ID, lapsedRegistrationStatuses)
+	if err != nil {
+		log.Error().Err(err).Str("source_file_id", file.ID.String()).Msg("Failed to check for lapsed LEIs")
+		return
+	}
+	if len(transitions) == 0 {
+		return
+	}
+	leis := make([]string, len(transitions))
+	for i, t := range transitions {
+		leis[i] = t.LEI
+	}
+	references, err := s.repo.FindLEIReferences(leis)
+	if err != nil {
+		log.Error().Err(err).Str("source_file_id", file.ID.String()).Msg("Failed to find master data referring to lapsed LEIs")
+		return
+	}
+	byLEI := make(map[string][]*domain.LEIReference)
+	for _, ref := range references {
+		byLEI[ref.LEI] = append(byLEI[ref.LEI], ref)
+	}
+
+	var lines []string
+	for _, t := range transitions {
+		refs := byLEI[t.LEI]
+		if len(refs) == 0 {
+			continue
+		}
+		if len(lines) == lapsedAlertMaxLEIs {
+			lines = append(lines, "... and more; see the source file's audit entries")
+			break
+		}
+		var entities []string
+		ssis := 0
+		for _, ref := range refs {
+			if ref.Kind == "entity" {
+				entities = append(entities, ref.Name)
+			} else {
+				ssis++
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s (%s): %s -> %s; entities: %s; SSIs: %d",
+			t.LEI, t.LegalName, t.OldStatus, t.NewStatus, strings.Join(entities, ", "), ssis))
+	}
+	log.Info().
+		Str("source_file_id", file.ID.String()).
+		Int("transitions", len(transitions)).
+		Int("referenced", len(byLEI)).
+		Msg("Lapsed/retired LEIs detected")
+	if len(byLEI) == 0 {
+		return
+	}
+
+	s.notifier.Notify(notify.Notification{
+		Event:    NotifyEventLEILapsed,
+		Severity: notify.SeverityWarning,
+		Title:    fmt.Sprintf("%d LEIs used by master data lapsed or were retired", len(byLEI)),
+		Message:  strings.Join(lines, "\n"),
+		Fields: map[string]string{
+			"source_file_id": file.ID.String(),
+			"file_type":      file.FileType,
+		},
+	})
+}
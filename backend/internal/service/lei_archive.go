@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/storage"
+)
+
+// leiArchivePrefix is where LEI files are kept in the archive bucket
+const leiArchivePrefix = "gleif"
+
+// leiArchive is the archive LEI files go to before cleanup removes them, if archive.leifiles is set
+func leiArchive(cfg config.ArchiveConfig, archive storage.Archive) storage.Archive {
+	if !cfg.LEIFiles {
+		return nil
+	}
+	return archive
+}
+
+// archiveFile uploads a stored LEI file to the archive
+func (s *leiService) archiveFile(ctx context.Context, key string) error {
+	localPath, release, err := s.store.Fetch(ctx, key, s.dataDir)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	location, err := s.archive.Put(ctx, path.Join(leiArchivePrefix, key), file)
+	if err != nil {
+		return err
+	}
+	log.Info().Str("file", key).Str("archive", location).Msg("Archived LEI file")
+	return nil
+}
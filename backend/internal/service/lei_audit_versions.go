@@ -0,0 +1,157 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+var (
+	// ErrAuditEntryNotFound is returned when an audit entry does not exist for the LEI
+	ErrAuditEntryNotFound = errors.New("audit entry not found")
+	// ErrLEIVersionUnavailable is returned when the audit trail has no full snapshot to replay from
+	ErrLEIVersionUnavailable = errors.New("audit trail has no snapshot to reconstruct this version from")
+	// ErrLEINotFoundAsOf is returned when an LEI had not been recorded yet, or had been deleted, at the requested time
+	ErrLEINotFoundAsOf = errors.New("LEI record did not exist at the requested time")
+)
+
+// LEIRecordVersion is an LEI record as it stood right after one of its audit entries
+type LEIRecordVersion struct {
+	AuditID   uuid.UUID         `json:"audit_id"`
+	Action    string            `json:"action"`
+	ChangedAt time.Time         `json:"changed_at"`
+	ChangedBy string            `json:"changed_by"`
+	Record    *domain.LEIRecord `json:"record,omitempty"`
+}
+
+// GetRecordVersion reconstructs an LEI record as of one of its audit entries. Entries written in
+// diff audit mode only hold the changed fields, so the version is replayed from the latest full
+// snapshot at or before the entry, applying each later entry's changes in order.
+func (s *leiService) GetRecordVersion(lei, auditID string) (*LEIRecordVersion, error) {
+	target, err := uuid.Parse(auditID)
+	if err != nil {
+		return nil, ErrAuditEntryNotFound
+	}
+	audits, err := s.repo.FindAuditTrailByLEI(lei)
+	if err != nil {
+		return nil, err
+	}
+
+	var state map[string]json.RawMessage
+	for _, audit := range audits {
+		if state, err = applyAuditEntry(state, audit); err != nil {
+			return nil, fmt.Errorf("audit entry %s: %w", audit.ID, err)
+		}
+		if audit.ID != target {
+			continue
+		}
+		return newRecordVersion(state, audit)
+	}
+	return nil, ErrAuditEntryNotFound
+}
+
+// GetRecordAsOf reconstructs an LEI record as it stood at asOf, by replaying its audit trail up to
+// and including the last entry at or before that time
+func (s *leiService) GetRecordAsOf(lei string, asOf time.Time) (*LEIRecordVersion, error) {
+	audits, err := s.repo.FindAuditTrailByLEI(lei)
+	if err != nil {
+		return nil, err
+	}
+
+	var state map[string]json.RawMessage
+	var last *domain.LEIRecordAudit
+	for _, audit := range audits {
+		if audit.CreatedAt.After(asOf) {
+			break
+		}
+		if state, err = applyAuditEntry(state, audit); err != nil {
+			return nil, fmt.Errorf("audit entry %s: %w", audit.ID, err)
+		}
+		last = audit
+	}
+	if last == nil || last.Action == "DELETE" {
+		return nil, ErrLEINotFoundAsOf
+	}
+	return newRecordVersion(state, last)
+}
+
+// newRecordVersion decodes a replayed record as the version written by audit
+func newRecordVersion(state map[string]json.RawMessage, audit *domain.LEIRecordAudit) (*LEIRecordVersion, error) {
+	if state == nil {
+		return nil, ErrLEIVersionUnavailable
+	}
+
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	var record domain.LEIRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode reconstructed record: %w", err)
+	}
+	return &LEIRecordVersion{
+		AuditID:   audit.ID,
+		Action:    audit.Action,
+		ChangedAt: audit.CreatedAt,
+		ChangedBy: audit.ChangedBy,
+		Record:    &record,
+	}, nil
+}
+
+// applyAuditEntry moves a replayed record (JSON fields keyed like domain.LEIRecord) forward by one
+// audit entry: a full snapshot replaces it, a diff entry overwrites the changed fields. A diff with
+// nothing to apply to leaves the state nil.
+func applyAuditEntry(state map[string]json.RawMessage, audit *domain.LEIRecordAudit) (map[string]json.RawMessage, error) {
+	snapshot := strings.TrimSpace(audit.RecordSnapshot)
+	if snapshot != "" && snapshot != repository.LEIAuditDiffSnapshot {
+		var full map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(snapshot), &full); err != nil {
+			return nil, fmt.Errorf("invalid record snapshot: %w", err)
+		}
+		if len(full) > 0 {
+			return full, nil
+		}
+	}
+	if state == nil {
+		return nil, nil
+	}
+
+	var changes map[string]struct {
+		NewValue json.RawMessage `json:"new_value"`
+	}
+	if audit.ChangedFields != "" {
+		if err := json.Unmarshal([]byte(audit.ChangedFields), &changes); err != nil {
+			return nil, fmt.Errorf("invalid changed fields: %w", err)
+		}
+	}
+	keys := leiRecordJSONKeys()
+	for fieldName, change := range changes {
+		if key, ok := keys[fieldName]; ok {
+			state[key] = change.NewValue
+		}
+	}
+	return state, nil
+}
+
+// leiRecordJSONKeys maps LEIRecord field names (as used in ChangedFields) to their JSON keys
+var leiRecordJSONKeys = sync.OnceValue(func() map[string]string {
+	keys := make(map[string]string)
+	recordType := reflect.TypeOf(domain.LEIRecord{})
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		keys[field.Name] = name
+	}
+	return keys
+})
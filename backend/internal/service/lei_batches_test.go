@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// memoryLEIs is an LEIRepository writing LEI records to memory. before, if set, runs ahead of each
+// batch write, outside the lock, and may fail it.
+type memoryLEIs struct {
+	repository.LEIRepository
+	before func(records []*domain.LEIRecord) error
+
+	mu          sync.Mutex
+	names       map[string]string // legal name of each LEI as last written
+	batches     int               // batches written
+	checkpoints []string          // LastProcessedLEI of each source file update
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (m *memoryLEIs) BatchUpsertLEIRecords(_ context.Context, records []*domain.LEIRecord) (int, int, error) {
+	current := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for peak := m.maxInFlight.Load(); current > peak && !m.maxInFlight.CompareAndSwap(peak, current); peak = m.maxInFlight.Load() {
+	}
+
+	if m.before != nil {
+		if err := m.before(records); err != nil {
+			return 0, 0, err
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, record := range records {
+		m.names[record.LEI] = record.LegalName
+	}
+	m.batches++
+	return 0, len(records), nil
+}
+
+func (m *memoryLEIs) BatchUpsertLEIRecordsIsolating(ctx context.Context, records []*domain.LEIRecord) (int, int, []*domain.LEIQuarantinedRecord, error) {
+	created, updated, err := m.BatchUpsertLEIRecords(ctx, records)
+	return created, updated, nil, err
+}
+
+func (m *memoryLEIs) UpdateSourceFile(file *domain.SourceFile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpoints = append(m.checkpoints, file.LastProcessedLEI)
+	return nil
+}
+
+func (m *memoryLEIs) ReplaceQualityMetrics(uuid.UUID, []*domain.LEIQualityMetric) error {
+	return nil
+}
+
+// noCountries is a CountryRepository with an empty countries table
+type noCountries struct {
+	repository.CountryRepository
+}
+
+func (noCountries) FindAll(limit, offset int) ([]*domain.Country, error) {
+	return nil, nil
+}
+
+// leiRecordSlice is a leiRecordStream over records in memory
+type leiRecordSlice struct {
+	records []LEIJSONRecord
+	next    int
+}
+
+func (s *leiRecordSlice) More() bool { return s.next < len(s.records) }
+
+func (s *leiRecordSlice) Decode(record *LEIJSONRecord) error {
+	*record = s.records[s.next]
+	s.next++
+	return nil
+}
+
+func (s *leiRecordSlice) Err() error { return nil }
+
+// testLEI is the LEI of the i-th record of a test file
+func testLEI(i int) string {
+	return fmt.Sprintf("TEST%016d", i)
+}
+
+// testLEIFile returns a stream of n records, named after their position in the file
+func testLEIFile(n int) *leiRecordSlice {
+	stream := &leiRecordSlice{}
+	for i := 0; i < n; i++ {
+		var record LEIJSONRecord
+		record.LEI.Value = testLEI(i)
+		record.Entity.LegalName.Value = fmt.Sprintf("record %d", i)
+		stream.records = append(stream.records, record)
+	}
+	return stream
+}
+
+// processTestFile processes stream with workers and returns the error and the source file
+func processTestFile(t *testing.T, repo *memoryLEIs, stream leiRecordStream, workers int, watchdog *processingWatchdog) (*domain.SourceFile, error) {
+	t.Helper()
+	if repo.names == nil {
+		repo.names = map[string]string{}
+	}
+	if watchdog == nil {
+		watchdog = startProcessingWatchdog(context.Background(), uuid.Nil, LEIProcessingLimits{})
+	}
+	t.Cleanup(func() { watchdog.cancel(nil) })
+
+	svc := &leiService{
+		repo:        repo,
+		countryRepo: noCountries{},
+		limits:      LEIProcessingLimits{Workers: workers},
+		progress:    newSourceFileProgressHub(),
+		events:      newLEIStatusHub(),
+	}
+	sourceFile := &domain.SourceFile{ID: uuid.New(), FileType: "DELTA"}
+	err := svc.processRecordsArray(stream, sourceFile, "", watchdog, nil)
+	return sourceFile, err
+}
+
+// slowFirstBatch holds the file's first batch up, so the batches after it land before it
+func slowFirstBatch(records []*domain.LEIRecord) error {
+	if records[0].LEI == testLEI(0) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	return nil
+}
+
+func TestProcessRecordsArrayPool(t *testing.T) {
+	repo := &memoryLEIs{before: func([]*domain.LEIRecord) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}}
+
+	sourceFile, err := processTestFile(t, repo, testLEIFile(7500), 3, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 8, repo.batches)
+	assert.Len(t, repo.names, 7500)
+	assert.Equal(t, 7500, sourceFile.TotalRecords)
+	assert.Equal(t, 7500, sourceFile.ProcessedRecords)
+	assert.Equal(t, testLEI(7499), sourceFile.LastProcessedLEI)
+	assert.Greater(t, repo.maxInFlight.Load(), int32(1), "batches are written concurrently")
+	assert.LessOrEqual(t, repo.maxInFlight.Load(), int32(3), "by at most limits.Workers workers")
+}
+
+func TestProcessRecordsArrayCheckpointsInFileOrder(t *testing.T) {
+	repo := &memoryLEIs{before: slowFirstBatch}
+
+	sourceFile, err := processTestFile(t, repo, testLEIFile(3000), 4, nil)
+	require.NoError(t, err)
+
+	// The final update repeats the last checkpoint
+	assert.Equal(t, []string{testLEI(999), testLEI(1999), testLEI(2999), testLEI(2999)}, repo.checkpoints)
+	assert.Equal(t, 3000, sourceFile.ProcessedRecords)
+}
+
+func TestProcessRecordsArrayWritesAnLEIInFileOrder(t *testing.T) {
+	repo := &memoryLEIs{before: slowFirstBatch}
+	stream := testLEIFile(3000)
+	// The third batch lists a record of the first again
+	stream.records[2500].LEI.Value = testLEI(10)
+	stream.records[2500].Entity.LegalName.Value = "listed again"
+
+	_, err := processTestFile(t, repo, stream, 4, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "listed again", repo.names[testLEI(10)], "the later listing is written last")
+	assert.Equal(t, "record 1999", repo.names[testLEI(1999)])
+}
+
+func TestProcessRecordsArrayStopsAtAFailedBatch(t *testing.T) {
+	repo := &memoryLEIs{before: func(records []*domain.LEIRecord) error {
+		if records[0].LEI == testLEI(1000) {
+			return errors.New("connection reset")
+		}
+		return slowFirstBatch(records)
+	}}
+
+	sourceFile, err := processTestFile(t, repo, testLEIFile(5000), 4, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "batch upsert failed")
+
+	assert.Equal(t, []string{testLEI(999)}, repo.checkpoints, "nothing after the failed batch is checkpointed")
+	assert.Equal(t, testLEI(999), sourceFile.LastProcessedLEI)
+	assert.Equal(t, 1000, sourceFile.ProcessedRecords)
+}
+
+func TestProcessRecordsArrayInterrupted(t *testing.T) {
+	ctx, interrupt := context.WithCancelCause(context.Background())
+	defer interrupt(nil)
+	watchdog := startProcessingWatchdog(ctx, uuid.Nil, LEIProcessingLimits{})
+	repo := &memoryLEIs{before: func(records []*domain.LEIRecord) error {
+		if records[0].LEI == testLEI(2000) {
+			interrupt(ErrProcessingInterrupted)
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}}
+
+	sourceFile, err := processTestFile(t, repo, testLEIFile(20000), 2, watchdog)
+	require.ErrorIs(t, err, ErrProcessingInterrupted)
+
+	assert.Less(t, repo.batches, 20, "no more batches are written once interrupted")
+	require.NotEmpty(t, repo.checkpoints)
+	for i, checkpoint := range repo.checkpoints {
+		assert.Equal(t, testLEI((i+1)*1000-1), checkpoint, "checkpoints advance a whole batch at a time")
+	}
+	assert.Equal(t, len(repo.checkpoints)*1000, sourceFile.ProcessedRecords)
+	assert.Equal(t, repo.checkpoints[len(repo.checkpoints)-1], sourceFile.LastProcessedLEI)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// bulkLoads reports whether a source file is loaded through the COPY staging table rather than
+// batch upserts; only full files are, since deltas are small enough for upserts
+func (s *leiService) bulkLoads(sourceFile *domain.SourceFile) bool {
+	return s.limits.BulkLoad && sourceFile.FileType == "FULL"
+}
+
+// prepareBulkLoad readies the staging table and returns the LEI to resume from. A resume whose
+// staged records were lost (the staging table is unlogged, so a database crash empties it)
+// starts the file over.
+func (s *leiService) prepareBulkLoad(sourceFile *domain.SourceFile, resumeFromLEI string) (string, error) {
+	staged, err := s.repo.PrepareLEIStaging(sourceFile.ID, resumeFromLEI != "")
+	if err != nil {
+		return "", err
+	}
+	if resumeFromLEI != "" && staged == 0 {
+		log.Warn().
+			Str("source_file_id", sourceFile.ID.String()).
+			Str("resume_from", resumeFromLEI).
+			Msg("Staged records for this file were lost, restarting the bulk load from the beginning")
+		return "", nil
+	}
+	log.Info().
+		Str("source_file_id", sourceFile.ID.String()).
+		Int64("already_staged", staged).
+		Msg("Loading full file through COPY staging")
+	return resumeFromLEI, nil
+}
+
+// stageRecordBatch COPYs a batch into the staging table. If the database rejects the batch, it is
+// upserted directly instead so only the rejected records are quarantined.
+func (s *leiService) stageRecordBatch(batch *leiUpsertBatch) (result *leiUpsertResult) {
+	result = &leiUpsertResult{batch: batch}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Interface("panic", r).Int("batch", batch.seq).Msg("PANIC staging LEI batch")
+			result.err = fmt.Errorf("panic during processing: %v", r)
+		}
+	}()
+
+	err := s.repo.StageLEIRecords(batch.records)
+	if err == nil {
+		return result
+	}
+
+	log.Warn().
+		Err(err).
+		Int("batch", batch.seq).
+		Int("batch_size", len(batch.records)).
+		Str("first_lei", batch.records[0].LEI).
+		Msg("COPY into staging failed, upserting the batch directly with record isolation")
+	created, updated, quarantined, err := s.repo.BatchUpsertLEIRecordsIsolating(context.Background(), batch.records)
+	result.created, result.updated, result.quarantined, result.err = created, updated, len(quarantined), err
+	return result
+}
+
+// mergeStagedRecords merges the staged file into lei_records and empties the staging table
+func (s *leiService) mergeStagedRecords(sourceFile *domain.SourceFile, watchdog *processingWatchdog, job *Job) error {
+	job.SetStage("merging")
+	log.Info().Str("source_file_id", sourceFile.ID.String()).Msg("Merging staged LEI records")
+	progress := progressFromSourceFile(sourceFile)
+	progress.Stage = "merging"
+	s.progress.publish(progress)
+
+	// The merge is a single long statement without checkpoints, so keep the stall detector from
+	// firing while it runs; the whole-file processing deadline still applies
+	merging := make(chan struct{})
+	defer close(merging)
+	go func() {
+		ticker := time.NewTicker(watchdogCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				watchdog.touch()
+			case <-merging:
+				return
+			}
+		}
+	}()
+
+	created, updated, err := s.repo.MergeStagedLEIRecords(sourceFile.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ClearLEIStaging(); err != nil {
+		log.Error().Err(err).Msg("Failed to empty LEI staging table after merge")
+	}
+
+	log.Info().
+		Str("source_file_id", sourceFile.ID.String()).
+		Int("created", created).
+		Int("updated", updated).
+		Msg("Full file merged")
+	return nil
+}
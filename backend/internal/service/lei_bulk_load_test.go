@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// memoryStaging adds the COPY staging table to memoryLEIs. rejectCOPY, if set, fails the COPY of
+// batches it returns true for; mergeErr fails the merge.
+type memoryStaging struct {
+	*memoryLEIs
+	rejectCOPY func(records []*domain.LEIRecord) bool
+	mergeErr   error
+
+	staged        []*domain.LEIRecord
+	stagedBatches int // COPYs that succeeded
+	merges        int
+}
+
+func (m *memoryStaging) PrepareLEIStaging(_ uuid.UUID, resume bool) (int64, error) {
+	if !resume {
+		return 0, m.ClearLEIStaging()
+	}
+	return int64(len(m.staged)), nil
+}
+
+func (m *memoryStaging) ClearLEIStaging() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.staged = nil
+	return nil
+}
+
+func (m *memoryStaging) StageLEIRecords(records []*domain.LEIRecord) error {
+	if m.rejectCOPY != nil && m.rejectCOPY(records) {
+		return errors.New(`ERROR: value too long for type character varying(20) (SQLSTATE 22001)`)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.staged = append(m.staged, records...)
+	m.stagedBatches++
+	return nil
+}
+
+func (m *memoryStaging) MergeStagedLEIRecords(uuid.UUID) (int, int, error) {
+	if m.mergeErr != nil {
+		return 0, 0, m.mergeErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, record := range m.staged {
+		m.names[record.LEI] = record.LegalName
+	}
+	m.merges++
+	return len(m.staged), 0, nil
+}
+
+func TestProcessRecordsArrayBulkLoad(t *testing.T) {
+	tests := []struct {
+		name                string
+		fileType            string
+		bulkLoad            bool
+		resumeFrom          string
+		alreadyStaged       int // records of the file staged by an earlier run
+		rejectCOPY          func(records []*domain.LEIRecord) bool
+		mergeErr            error
+		wantStagedBatches   int
+		wantUpsertedBatches int
+		wantMerges          int
+		wantLoaded          int // records in lei_records afterwards
+		wantStagingLeft     int
+		wantErr             bool
+	}{
+		{
+			name:              "full file is staged and merged once",
+			fileType:          "FULL",
+			bulkLoad:          true,
+			wantStagedBatches: 3,
+			wantMerges:        1,
+			wantLoaded:        3000,
+		},
+		{
+			name:                "delta file is upserted",
+			fileType:            "DELTA",
+			bulkLoad:            true,
+			wantUpsertedBatches: 3,
+			wantLoaded:          3000,
+		},
+		{
+			name:                "full file is upserted with bulk loading off",
+			fileType:            "FULL",
+			wantUpsertedBatches: 3,
+			wantLoaded:          3000,
+		},
+		{
+			name:                "batch the COPY rejects is upserted directly",
+			fileType:            "FULL",
+			bulkLoad:            true,
+			rejectCOPY:          func(records []*domain.LEIRecord) bool { return records[0].LEI == testLEI(1000) },
+			wantStagedBatches:   2,
+			wantUpsertedBatches: 1,
+			wantMerges:          1,
+			wantLoaded:          3000,
+		},
+		{
+			name:              "resume keeps the records already staged",
+			fileType:          "FULL",
+			bulkLoad:          true,
+			resumeFrom:        testLEI(999),
+			alreadyStaged:     1000,
+			wantStagedBatches: 2,
+			wantMerges:        1,
+			wantLoaded:        3000,
+		},
+		{
+			name:              "resume whose staged records were lost starts over",
+			fileType:          "FULL",
+			bulkLoad:          true,
+			resumeFrom:        testLEI(999),
+			wantStagedBatches: 3,
+			wantMerges:        1,
+			wantLoaded:        3000,
+		},
+		{
+			name:              "failed merge leaves the staged records",
+			fileType:          "FULL",
+			bulkLoad:          true,
+			mergeErr:          errors.New("deadlock detected"),
+			wantStagedBatches: 3,
+			wantStagingLeft:   3000,
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryStaging{
+				memoryLEIs: &memoryLEIs{names: map[string]string{}},
+				rejectCOPY: tt.rejectCOPY,
+				mergeErr:   tt.mergeErr,
+			}
+			stream := testLEIFile(3000)
+			for i := 0; i < tt.alreadyStaged; i++ {
+				repo.staged = append(repo.staged, &domain.LEIRecord{LEI: testLEI(i), LegalName: stream.records[i].Entity.LegalName.Value})
+			}
+
+			watchdog := startProcessingWatchdog(context.Background(), uuid.Nil, LEIProcessingLimits{})
+			defer watchdog.cancel(nil)
+			svc := &leiService{
+				repo:        repo,
+				countryRepo: noCountries{},
+				limits:      LEIProcessingLimits{Workers: 2, BulkLoad: tt.bulkLoad},
+				progress:    newSourceFileProgressHub(),
+				events:      newLEIStatusHub(),
+			}
+			sourceFile := &domain.SourceFile{ID: uuid.New(), FileType: tt.fileType}
+			err := svc.processRecordsArray(stream, sourceFile, tt.resumeFrom, watchdog, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantStagedBatches, repo.stagedBatches)
+			assert.Equal(t, tt.wantUpsertedBatches, repo.batches)
+			assert.Equal(t, tt.wantMerges, repo.merges)
+			assert.Len(t, repo.names, tt.wantLoaded)
+			assert.Len(t, repo.staged, tt.wantStagingLeft)
+		})
+	}
+}
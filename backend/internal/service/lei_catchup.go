@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// GLEIFPublishesHistoryPath lists past LEI2 golden copy publishes, newest first (paginated);
+// relative to the golden copy base URL
+const GLEIFPublishesHistoryPath = "/api/v2/golden-copies/publishes/lei2"
+
+// gleifHistoryPageSize is how many publishes are requested per history page; GLEIF publishes
+// three times a day, so one page covers about a month
+const gleifHistoryPageSize = 100
+
+// GLEIFPublishesPage is one page of the publishes history endpoint
+type GLEIFPublishesPage struct {
+	Data []GLEIFFileFormats `json:"data"`
+	Meta struct {
+		Pagination struct {
+			CurrentPage int `json:"current_page"`
+			LastPage    int `json:"last_page"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+// PendingDelta is the IntraDay delta of a publish that has not been applied yet
+// An IntraDay delta holds the changes since the previous publish, so applying them in
+// publication order brings the database forward without gaps
+type PendingDelta struct {
+	PublishedAt time.Time `json:"published_at"`
+	URL         string    `json:"url"`
+	Format      string    `json:"format"`
+}
+
+// DeltaCatchUpPlan is what the scheduled delta sync needs to apply to become current
+type DeltaCatchUpPlan struct {
+	LastApplied      *time.Time     `json:"last_applied"`
+	Deltas           []PendingDelta `json:"deltas"` // oldest first
+	FullSyncRequired bool           `json:"full_sync_required"`
+	Reason           string         `json:"reason,omitempty"` // why a full sync is required
+}
+
+// parseGLEIFPublishDate accepts the publish date formats seen in GLEIF responses
+// (RFC 3339 and "2006-01-02 15:04:05", which is UTC)
+func parseGLEIFPublishDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02 15:04:05", value, time.UTC)
+}
+
+// PlanDeltaCatchUp works out which publishes have been missed since the last applied full or
+// delta file. More than maxDeltas missed publishes (or a gap older than the history GLEIF still
+// serves) requires a full sync instead
+func (s *leiService) PlanDeltaCatchUp(maxDeltas int) (*DeltaCatchUpPlan, error) {
+	lastApplied, err := s.repo.FindLastAppliedPublicationDate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find last applied publication: %w", err)
+	}
+	plan := &DeltaCatchUpPlan{LastApplied: lastApplied}
+	if lastApplied == nil {
+		plan.FullSyncRequired = true
+		plan.Reason = "no full or delta file has been applied yet"
+		return plan, nil
+	}
+
+	var missed []GLEIFFileFormats
+	reached := false
+	for page := 1; !reached; page++ {
+		history, err := s.fetchPublishesPage(page)
+		if err != nil {
+			return nil, err
+		}
+		for _, publish := range history.Data {
+			publishedAt, err := parseGLEIFPublishDate(publish.PublishDate)
+			if err != nil {
+				return nil, fmt.Errorf("unrecognised publish date %q: %w", publish.PublishDate, err)
+			}
+			if !publishedAt.After(*lastApplied) {
+				reached = true
+				break
+			}
+			missed = append(missed, publish)
+		}
+
+		if len(missed) > maxDeltas {
+			plan.FullSyncRequired = true
+			plan.Reason = fmt.Sprintf("more than %d publishes missed since %s", maxDeltas, lastApplied.Format(time.RFC3339))
+			return plan, nil
+		}
+		if !reached && (len(history.Data) == 0 || page >= history.Meta.Pagination.LastPage) {
+			plan.FullSyncRequired = true
+			plan.Reason = fmt.Sprintf("GLEIF publish history does not reach back to %s", lastApplied.Format(time.RFC3339))
+			return plan, nil
+		}
+	}
+
+	for _, publish := range missed {
+		publishedAt, _ := parseGLEIFPublishDate(publish.PublishDate)
+		url, format := selectFileURL(publish.DeltaFiles.IntraDay, s.fileFormat)
+		if url == "" {
+			plan.FullSyncRequired = true
+			plan.Reason = fmt.Sprintf("publish %s has no IntraDay delta file", publish.PublishDate)
+			plan.Deltas = nil
+			return plan, nil
+		}
+		plan.Deltas = append(plan.Deltas, PendingDelta{PublishedAt: publishedAt, URL: url, Format: format})
+	}
+	sort.Slice(plan.Deltas, func(i, j int) bool { return plan.Deltas[i].PublishedAt.Before(plan.Deltas[j].PublishedAt) })
+
+	log.Info().
+		Time("last_applied", *lastApplied).
+		Int("missed_publishes", len(plan.Deltas)).
+		Msg("Planned delta catch-up")
+	return plan, nil
+}
+
+// DownloadPendingDelta downloads the IntraDay delta of a missed publish
+func (s *leiService) DownloadPendingDelta(delta PendingDelta) (*domain.SourceFile, error) {
+	return s.downloadFile(delta.URL, "DELTA", delta.Format, delta.PublishedAt.Format(time.RFC3339), DeltaTypeIntraDay)
+}
+
+func (s *leiService) fetchPublishesPage(page int) (*GLEIFPublishesPage, error) {
+	url := fmt.Sprintf("%s%s?page=%d&per_page=%d", s.gleif.settings.GoldenCopyURL, GLEIFPublishesHistoryPath, page, gleifHistoryPageSize)
+	body, err := s.gleif.get(context.Background(), url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch publish history: %w", err)
+	}
+
+	var history GLEIFPublishesPage
+	if err := json.Unmarshal(body, &history); err != nil {
+		return nil, fmt.Errorf("failed to decode publish history: %w", err)
+	}
+	return &history, nil
+}
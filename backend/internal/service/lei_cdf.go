@@ -0,0 +1,224 @@
+package service
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// Bulk file formats; recorded per source file so it is always parsed the way it was downloaded
+const (
+	SourceFileFormatJSON = "JSON"
+	SourceFileFormatXML  = "XML" // LEI-CDF 3.1 XML
+)
+
+// normalizeFileFormat maps the configured bulk file format to a source file format, defaulting to JSON
+func normalizeFileFormat(format string) string {
+	if strings.EqualFold(strings.TrimSpace(format), "xml") {
+		return SourceFileFormatXML
+	}
+	return SourceFileFormatJSON
+}
+
+// selectFileURL picks the preferred format from a GLEIF file group, falling back to the other
+// format when the preferred one has not been published (yet)
+func selectFileURL(group GLEIFFileGroup, preferred string) (url, format string) {
+	if preferred == SourceFileFormatXML {
+		if group.XML.URL != "" {
+			return group.XML.URL, SourceFileFormatXML
+		}
+		return group.JSON.URL, SourceFileFormatJSON
+	}
+	if group.JSON.URL == "" && group.XML.URL != "" {
+		return group.XML.URL, SourceFileFormatXML
+	}
+	return group.JSON.URL, SourceFileFormatJSON
+}
+
+// CDF (LEI Common Data File) XML elements. Elements are matched by local name, so both the
+// lei: namespace prefix and unprefixed documents are accepted.
+
+type cdfRecord struct {
+	LEI          string          `xml:"LEI"`
+	Entity       cdfEntity       `xml:"Entity"`
+	Registration cdfRegistration `xml:"Registration"`
+}
+
+type cdfEntity struct {
+	LegalName                      cdfName      `xml:"LegalName"`
+	OtherEntityNames               []cdfName    `xml:"OtherEntityNames>OtherEntityName"`
+	TransliteratedOtherEntityNames []cdfName    `xml:"TransliteratedOtherEntityNames>TransliteratedOtherEntityName"`
+	LegalAddress                   cdfAddress   `xml:"LegalAddress"`
+	HeadquartersAddress            cdfAddress   `xml:"HeadquartersAddress"`
+	RegistrationAuthorityID        string       `xml:"RegistrationAuthority>RegistrationAuthorityID"`
+	RegistrationAuthorityEntityID  string       `xml:"RegistrationAuthority>RegistrationAuthorityEntityID"`
+	LegalJurisdiction              string       `xml:"LegalJurisdiction"`
+	EntityCategory                 string       `xml:"EntityCategory"`
+	LegalForm                      cdfLegalForm `xml:"LegalForm"`
+	EntityStatus                   string       `xml:"EntityStatus"`
+}
+
+type cdfName struct {
+	Value    string `xml:",chardata"`
+	Type     string `xml:"type,attr"`
+	Language string `xml:"lang,attr"`
+}
+
+type cdfAddress struct {
+	FirstAddressLine      string   `xml:"FirstAddressLine"`
+	AdditionalAddressLine []string `xml:"AdditionalAddressLine"`
+	City                  string   `xml:"City"`
+	Region                string   `xml:"Region"`
+	Country               string   `xml:"Country"`
+	PostalCode            string   `xml:"PostalCode"`
+	Language              string   `xml:"lang,attr"`
+}
+
+type cdfLegalForm struct {
+	EntityLegalFormCode string `xml:"EntityLegalFormCode"`
+	OtherLegalForm      string `xml:"OtherLegalForm"`
+}
+
+type cdfRegistration struct {
+	InitialRegistrationDate     string `xml:"InitialRegistrationDate"`
+	LastUpdateDate              string `xml:"LastUpdateDate"`
+	RegistrationStatus          string `xml:"RegistrationStatus"`
+	NextRenewalDate             string `xml:"NextRenewalDate"`
+	ManagingLOU                 string `xml:"ManagingLOU"`
+	ValidationSources           string `xml:"ValidationSources"`
+	ValidationAuthorityID       string `xml:"ValidationAuthority>ValidationAuthorityID"`
+	ValidationAuthorityEntityID string `xml:"ValidationAuthority>ValidationAuthorityEntityID"`
+}
+
+// toJSONRecord maps a CDF record onto the JSON bulk structure so both formats share one conversion
+func (c *cdfRecord) toJSONRecord(record *LEIJSONRecord) {
+	value := func(v string) LEIValueField { return LEIValueField{Value: strings.TrimSpace(v)} }
+	names := func(in []cdfName) LEIOtherEntityNames {
+		out := LEIOtherEntityNames{}
+		for _, n := range in {
+			out.OtherEntityName = append(out.OtherEntityName, LEIOtherName{Value: strings.TrimSpace(n.Value), Type: n.Type, Language: n.Language})
+		}
+		return out
+	}
+	address := func(a cdfAddress) LEIAddress {
+		out := LEIAddress{
+			FirstAddressLine: value(a.FirstAddressLine),
+			City:             value(a.City),
+			Region:           value(a.Region),
+			Country:          value(a.Country),
+			PostalCode:       value(a.PostalCode),
+			Language:         a.Language,
+		}
+		for _, line := range a.AdditionalAddressLine {
+			out.AdditionalAddressLine = append(out.AdditionalAddressLine, value(line))
+		}
+		return out
+	}
+
+	*record = LEIJSONRecord{
+		LEI: value(c.LEI),
+		Entity: LEIEntity{
+			LegalName:                      LEILegalName{Value: strings.TrimSpace(c.Entity.LegalName.Value), Language: c.Entity.LegalName.Language},
+			OtherEntityNames:               names(c.Entity.OtherEntityNames),
+			TransliteratedOtherEntityNames: names(c.Entity.TransliteratedOtherEntityNames),
+			LegalAddress:                   address(c.Entity.LegalAddress),
+			HeadquartersAddress:            address(c.Entity.HeadquartersAddress),
+			RegistrationAuthority: LEIRegistrationAuthority{
+				RegistrationAuthorityID:       value(c.Entity.RegistrationAuthorityID),
+				RegistrationAuthorityEntityID: value(c.Entity.RegistrationAuthorityEntityID),
+			},
+			LegalJurisdiction: value(c.Entity.LegalJurisdiction),
+			EntityCategory:    value(c.Entity.EntityCategory),
+			LegalForm: LEILegalForm{
+				EntityLegalFormCode: value(c.Entity.LegalForm.EntityLegalFormCode),
+				OtherLegalForm:      value(c.Entity.LegalForm.OtherLegalForm),
+			},
+			EntityStatus: value(c.Entity.EntityStatus),
+		},
+		Registration: LEIRegistration{
+			InitialRegistrationDate: value(c.Registration.InitialRegistrationDate),
+			LastUpdateDate:          value(c.Registration.LastUpdateDate),
+			RegistrationStatus:      value(c.Registration.RegistrationStatus),
+			NextRenewalDate:         value(c.Registration.NextRenewalDate),
+			ManagingLOU:             value(c.Registration.ManagingLOU),
+			ValidationSources:       value(c.Registration.ValidationSources),
+			ValidationAuthority: LEIValidationAuthority{
+				ValidationAuthorityID:       value(c.Registration.ValidationAuthorityID),
+				ValidationAuthorityEntityID: value(c.Registration.ValidationAuthorityEntityID),
+			},
+		},
+	}
+}
+
+// cdfRecordStream streams LEIRecord elements from a CDF XML file without loading the document
+type cdfRecordStream struct {
+	decoder *xml.Decoder
+	next    *xml.StartElement // start of the record Decode will read
+	err     error
+}
+
+func newCDFRecordStream(r io.Reader) *cdfRecordStream {
+	return &cdfRecordStream{decoder: xml.NewDecoder(r)}
+}
+
+// More advances to the next LEIRecord start element
+func (c *cdfRecordStream) More() bool {
+	if c.next != nil {
+		return true
+	}
+	if c.err != nil {
+		return false
+	}
+	for {
+		token, err := c.decoder.Token()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				c.err = fmt.Errorf("invalid XML: %w", err)
+			}
+			return false
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "LEIRecord" {
+			c.next = &start
+			return true
+		}
+	}
+}
+
+// Decode reads the record located by More; a record that does not match the schema is
+// skipped, but malformed XML ends the stream
+func (c *cdfRecordStream) Decode(record *LEIJSONRecord) error {
+	if c.next == nil && !c.More() {
+		return io.EOF
+	}
+	start := c.next
+	c.next = nil
+
+	var cdf cdfRecord
+	if err := c.decoder.DecodeElement(&cdf, start); err != nil {
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+			c.err = fmt.Errorf("invalid XML: %w", err)
+		}
+		return err
+	}
+	cdf.toJSONRecord(record)
+	return nil
+}
+
+func (c *cdfRecordStream) Err() error {
+	return c.err
+}
+
+// processCDFFile processes an LEI-CDF XML bulk data file
+func (s *leiService) processCDFFile(file io.Reader, sourceFile *domain.SourceFile, resumeFromLEI string, watchdog *processingWatchdog, job *Job) error {
+	log.Info().
+		Str("source_file_id", sourceFile.ID.String()).
+		Msg("Streaming LEI records from CDF XML file")
+
+	return s.processRecordsArray(newCDFRecordStream(file), sourceFile, resumeFromLEI, watchdog, job)
+}
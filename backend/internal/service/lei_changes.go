@@ -0,0 +1,140 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/events"
+)
+
+// ErrInvalidChangesSince is returned when since is neither a timestamp nor a cursor from the feed
+var ErrInvalidChangesSince = errors.New("since must be an RFC 3339 timestamp, a date (YYYY-MM-DD) or a cursor returned by this endpoint")
+
+// Changes feed page size limits
+const (
+	DefaultLEIChangesLimit = 1000
+	MaxLEIChangesLimit     = 10000
+)
+
+// LEIChange is one created, updated or deleted LEI in the changes feed
+type LEIChange struct {
+	LEI           string     `json:"lei"`
+	Action        string     `json:"action"` // created, updated or deleted
+	ChangedAt     time.Time  `json:"changed_at"`
+	ChangedFields []string   `json:"changed_fields,omitempty"` // updated fields
+	ChangedBy     string     `json:"changed_by"`
+	SourceFileID  *uuid.UUID `json:"source_file_id,omitempty"`
+	Cursor        string     `json:"cursor"` // pass as since to continue after this change
+}
+
+// LEIChangeFeed is a page of the changes feed
+type LEIChangeFeed struct {
+	Changes    []LEIChange `json:"changes"`
+	NextCursor string      `json:"next_cursor"` // since for the next request; unchanged when there was nothing new
+	HasMore    bool        `json:"has_more"`    // another page is ready now
+}
+
+// leiChangeActions maps audit actions to feed actions
+var leiChangeActions = map[string]string{
+	"CREATE": events.ActionCreated,
+	"UPDATE": events.ActionUpdated,
+	"DELETE": events.ActionDeleted,
+}
+
+// GetChanges returns LEI changes after since (a timestamp, or a cursor from a previous page) in the
+// order they were made, so downstream caches can sync incrementally
+func (s *leiService) GetChanges(since string, limit int) (*LEIChangeFeed, error) {
+	afterTime, afterID, err := parseChangesSince(since)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 {
+		limit = DefaultLEIChangesLimit
+	}
+	limit = min(limit, MaxLEIChangesLimit)
+
+	audits, err := s.repo.FindAuditEntriesAfter(afterTime, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	feed := &LEIChangeFeed{Changes: make([]LEIChange, 0, min(len(audits), limit)), NextCursor: since}
+	if len(audits) > limit {
+		feed.HasMore = true
+		audits = audits[:limit]
+	}
+	for _, audit := range audits {
+		feed.Changes = append(feed.Changes, leiChangeFromAudit(audit))
+	}
+	if len(feed.Changes) > 0 {
+		feed.NextCursor = feed.Changes[len(feed.Changes)-1].Cursor
+	}
+	return feed, nil
+}
+
+func leiChangeFromAudit(audit *domain.LEIRecordAudit) LEIChange {
+	change := LEIChange{
+		LEI:          audit.LEI,
+		Action:       leiChangeActions[audit.Action],
+		ChangedAt:    audit.CreatedAt,
+		ChangedBy:    audit.ChangedBy,
+		SourceFileID: audit.SourceFileID,
+		Cursor:       encodeChangesCursor(audit.CreatedAt, audit.ID),
+	}
+	if change.Action == "" {
+		change.Action = strings.ToLower(audit.Action)
+	}
+	if audit.Action == "UPDATE" && audit.ChangedFields != "" {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal([]byte(audit.ChangedFields), &fields) == nil {
+			for field := range fields {
+				change.ChangedFields = append(change.ChangedFields, field)
+			}
+			sort.Strings(change.ChangedFields)
+		}
+	}
+	return change
+}
+
+// encodeChangesCursor encodes a feed position: the audit entry's timestamp and ID
+func encodeChangesCursor(at time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(at.UTC().Format(time.RFC3339Nano) + "|" + id.String()))
+}
+
+// parseChangesSince reads since as a cursor, an RFC 3339 timestamp or a date. A timestamp
+// includes changes made at exactly that time; a cursor continues after its change.
+func parseChangesSince(since string) (time.Time, uuid.UUID, error) {
+	since = strings.TrimSpace(since)
+	if since == "" {
+		return time.Time{}, uuid.Nil, ErrInvalidChangesSince
+	}
+	if t, err := time.Parse(time.RFC3339Nano, since); err == nil {
+		return t.UTC(), uuid.Nil, nil
+	}
+	if t, err := time.Parse(time.DateOnly, since); err == nil {
+		return t, uuid.Nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(since)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidChangesSince
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, ErrInvalidChangesSince
+	}
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidChangesSince
+	}
+	afterID, err := uuid.Parse(id)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidChangesSince
+	}
+	return t, afterID, nil
+}
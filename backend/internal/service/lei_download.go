@@ -0,0 +1,285 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/breaker"
+)
+
+const (
+	// partialDownloadDir (under the LEI data directory) holds downloads that have not completed yet
+	partialDownloadDir = "partial"
+	// partialDownloadMaxAge is how long an untouched partial download is kept for resuming
+	partialDownloadMaxAge = 48 * time.Hour
+)
+
+// partialDownloadMeta is stored next to a partial download so that a resume can ask the server,
+// via If-Range, to send the rest only if the remote file has not changed since
+type partialDownloadMeta struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	TotalSize    int64  `json:"total_size"` // -1 when the server did not say
+}
+
+// permanentDownloadError marks failures that retrying will not fix (e.g. a malformed URL)
+type permanentDownloadError struct{ err error }
+
+func (e permanentDownloadError) Error() string { return e.err.Error() }
+func (e permanentDownloadError) Unwrap() error { return e.err }
+
+// downloadResumable downloads url to destPath. Data is written to a partial file that survives
+// network failures and restarts; later attempts continue it with a Range request instead of
+// starting from zero. Returns the size and SHA-256 of the completed file.
+func (c *gleifClient) downloadResumable(url, partialDir, destPath string) (int64, string, error) {
+	if err := os.MkdirAll(partialDir, 0755); err != nil {
+		return 0, "", fmt.Errorf("failed to create partial download directory: %w", err)
+	}
+	key := sha256.Sum256([]byte(url))
+	partialPath := filepath.Join(partialDir, hex.EncodeToString(key[:8])+".part")
+	metaPath := partialPath + ".json"
+
+	var err error
+	for attempt := 1; attempt <= c.settings.MaxAttempts; attempt++ {
+		if err = c.fetchIntoPartial(url, partialPath, metaPath); err == nil {
+			break
+		}
+		var permanent permanentDownloadError
+		var status *GLEIFStatusError
+		if errors.As(err, &permanent) || (errors.As(err, &status) && !status.retryable()) || errors.Is(err, breaker.ErrOpen) {
+			return 0, "", err
+		}
+		if attempt == c.settings.MaxAttempts {
+			return 0, "", fmt.Errorf("download failed after %d attempts: %w", attempt, err)
+		}
+		delay := c.backoff(attempt)
+		log.Warn().
+			Err(err).
+			Str("url", url).
+			Int("attempt", attempt).
+			Dur("retry_in", delay).
+			Msg("Download interrupted, will resume")
+		time.Sleep(delay)
+	}
+
+	// The hash cannot be carried across resumed attempts, so it is computed over the assembled file
+	size, hash, err := hashFile(partialPath)
+	if err != nil {
+		return 0, "", err
+	}
+	if meta, _ := loadPartialMeta(metaPath); meta != nil && meta.TotalSize >= 0 && size != meta.TotalSize {
+		os.Remove(partialPath)
+		os.Remove(metaPath)
+		return 0, "", fmt.Errorf("downloaded file is %d bytes, server reported %d", size, meta.TotalSize)
+	}
+
+	if err := os.Rename(partialPath, destPath); err != nil {
+		return 0, "", fmt.Errorf("failed to finalise download: %w", err)
+	}
+	os.Remove(metaPath)
+	return size, hash, nil
+}
+
+// fetchIntoPartial makes one attempt to complete the partial file
+func (c *gleifClient) fetchIntoPartial(url, partialPath, metaPath string) error {
+	meta, err := loadPartialMeta(metaPath)
+	if err != nil || meta == nil || meta.URL != url {
+		meta = nil
+	}
+
+	var offset int64
+	if meta != nil {
+		if info, err := os.Stat(partialPath); err == nil {
+			offset = info.Size()
+		}
+	}
+	if meta != nil && meta.TotalSize >= 0 && offset == meta.TotalSize {
+		return nil // completed by an earlier attempt that failed before finalising
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return permanentDownloadError{err}
+	}
+	validator := ""
+	if meta != nil {
+		validator = meta.ETag
+		if validator == "" {
+			validator = meta.LastModified
+		}
+	}
+	// Without a validator there is no way to know the remote file is unchanged, so start over
+	if offset > 0 && validator != "" {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", validator)
+	} else {
+		offset = 0
+	}
+
+	resp, err := c.stream(req)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out *os.File
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start, total, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil || meta == nil || start != offset {
+			os.Remove(partialPath)
+			return fmt.Errorf("unexpected Content-Range %q for resume at %d", resp.Header.Get("Content-Range"), offset)
+		}
+		if total >= 0 && meta.TotalSize < 0 {
+			meta.TotalSize = total
+			if err := savePartialMeta(metaPath, meta); err != nil {
+				return err
+			}
+		}
+		if out, err = os.OpenFile(partialPath, os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+			return fmt.Errorf("failed to open partial download: %w", err)
+		}
+		log.Info().Str("url", url).Int64("resume_from", offset).Msg("Resuming partial download")
+
+	case http.StatusOK:
+		// Fresh download, or the remote file changed since the partial was started
+		meta = &partialDownloadMeta{
+			URL:          url,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			TotalSize:    resp.ContentLength,
+		}
+		if err := savePartialMeta(metaPath, meta); err != nil {
+			return err
+		}
+		if out, err = os.Create(partialPath); err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+
+	case http.StatusRequestedRangeNotSatisfiable:
+		os.Remove(partialPath)
+		os.Remove(metaPath)
+		return fmt.Errorf("server rejected resume at byte %d, restarting download", offset)
+
+	default:
+		return fmt.Errorf("failed to download file: %w", &GLEIFStatusError{StatusCode: resp.StatusCode})
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+
+	if meta.TotalSize >= 0 {
+		info, err := out.Stat()
+		if err != nil {
+			return err
+		}
+		if info.Size() < meta.TotalSize {
+			return fmt.Errorf("download ended early at %d of %d bytes", info.Size(), meta.TotalSize)
+		}
+	}
+	return nil
+}
+
+// parseContentRange parses "bytes start-end/total"; total is -1 when given as "*"
+func parseContentRange(value string) (start, total int64, err error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(value), "bytes ")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	rangePart, totalPart, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	startPart, _, ok := strings.Cut(rangePart, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	if start, err = strconv.ParseInt(startPart, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	if totalPart == "*" {
+		return start, -1, nil
+	}
+	if total, err = strconv.ParseInt(totalPart, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	return start, total, nil
+}
+
+func loadPartialMeta(path string) (*partialDownloadMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var meta partialDownloadMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func savePartialMeta(path string, meta *partialDownloadMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to record partial download: %w", err)
+	}
+	return nil
+}
+
+// hashFile returns the size and SHA-256 of a file
+func hashFile(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to open downloaded file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to hash downloaded file: %w", err)
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// removeStalePartialDownloads deletes partial downloads nobody has resumed for partialDownloadMaxAge
+func removeStalePartialDownloads(partialDir string) {
+	entries, err := os.ReadDir(partialDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < partialDownloadMaxAge {
+			continue
+		}
+		if err := os.Remove(filepath.Join(partialDir, entry.Name())); err != nil {
+			log.Warn().Err(err).Str("file", entry.Name()).Msg("Failed to remove stale partial download")
+		} else {
+			log.Info().Str("file", entry.Name()).Msg("Removed stale partial download")
+		}
+	}
+}
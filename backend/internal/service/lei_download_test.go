@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goldenCopyServer serves one file with an ETag, so Range and If-Range behave as GLEIF's do, and
+// records the Range header of every request. The first interrupted requests are cut off after
+// half the body.
+type goldenCopyServer struct {
+	content     []byte
+	etag        string
+	interrupted int
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (s *goldenCopyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	interrupt := len(s.ranges) <= s.interrupted
+	s.mu.Unlock()
+
+	w.Header().Set("ETag", s.etag)
+	if interrupt {
+		w.Header().Set("Content-Length", strconv.Itoa(len(s.content)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(s.content[:len(s.content)/2])
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	http.ServeContent(w, r, "golden-copy.zip", time.Time{}, bytes.NewReader(s.content))
+}
+
+func (s *goldenCopyServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ranges...)
+}
+
+func TestDownloadResumable(t *testing.T) {
+	remote := []byte(strings.Repeat("5493001KJTIIGC8Y1R12,ISSUED\n", 400))
+	half := len(remote) / 2
+	tests := []struct {
+		name        string
+		etag        string
+		interrupted int
+		partial     []byte               // left by an earlier attempt
+		meta        *partialDownloadMeta // URL filled in with the server's
+		wantRanges  []string
+	}{
+		{
+			name:       "fresh download",
+			etag:       `"v1"`,
+			wantRanges: []string{""},
+		},
+		{
+			name:        "interrupted download resumes where it stopped",
+			etag:        `"v1"`,
+			interrupted: 1,
+			wantRanges:  []string{"", "bytes=" + strconv.Itoa(half) + "-"},
+		},
+		{
+			name:       "partial from an earlier run resumes",
+			etag:       `"v1"`,
+			partial:    remote[:1000],
+			meta:       &partialDownloadMeta{ETag: `"v1"`, TotalSize: int64(len(remote))},
+			wantRanges: []string{"bytes=1000-"},
+		},
+		{
+			name:       "partial of a file that has since changed starts over",
+			etag:       `"v2"`,
+			partial:    []byte(strings.Repeat("OLD", 300)),
+			meta:       &partialDownloadMeta{ETag: `"v1"`, TotalSize: 5000},
+			wantRanges: []string{"bytes=900-"},
+		},
+		{
+			name:       "partial without a validator starts over",
+			etag:       `"v1"`,
+			partial:    remote[:1000],
+			meta:       &partialDownloadMeta{TotalSize: int64(len(remote))},
+			wantRanges: []string{""},
+		},
+		{
+			name:       "partial the server cannot resume is discarded",
+			etag:       `"v1"`,
+			partial:    bytes.Repeat([]byte("x"), len(remote)+10),
+			meta:       &partialDownloadMeta{ETag: `"v1"`, TotalSize: -1},
+			wantRanges: []string{"bytes=" + strconv.Itoa(len(remote)+10) + "-", ""},
+		},
+		{
+			name:       "partial completed before a crash is not fetched again",
+			etag:       `"v1"`,
+			partial:    remote,
+			meta:       &partialDownloadMeta{ETag: `"v1"`, TotalSize: int64(len(remote))},
+			wantRanges: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &goldenCopyServer{content: remote, etag: tt.etag, interrupted: tt.interrupted}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+			url := ts.URL + "/golden-copy.zip"
+
+			dir := t.TempDir()
+			partialDir := filepath.Join(dir, partialDownloadDir)
+			require.NoError(t, os.MkdirAll(partialDir, 0755))
+			key := sha256.Sum256([]byte(url))
+			partialPath := filepath.Join(partialDir, hex.EncodeToString(key[:8])+".part")
+			if tt.partial != nil {
+				require.NoError(t, os.WriteFile(partialPath, tt.partial, 0644))
+				tt.meta.URL = url
+				require.NoError(t, savePartialMeta(partialPath+".json", tt.meta))
+			}
+
+			client := newGLEIFClient(GLEIFHTTPSettings{Timeout: 5 * time.Second, MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+			destPath := filepath.Join(dir, "golden-copy.zip")
+			size, hash, err := client.downloadResumable(url, partialDir, destPath)
+			require.NoError(t, err)
+
+			sum := sha256.Sum256(remote)
+			assert.Equal(t, int64(len(remote)), size)
+			assert.Equal(t, hex.EncodeToString(sum[:]), hash)
+			downloaded, err := os.ReadFile(destPath)
+			require.NoError(t, err)
+			assert.Equal(t, remote, downloaded)
+			assert.Equal(t, tt.wantRanges, server.requests())
+
+			// Nothing is left to resume once the file is complete
+			leftovers, err := os.ReadDir(partialDir)
+			require.NoError(t, err)
+			assert.Empty(t, leftovers)
+		})
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantStart int64
+		wantTotal int64
+		wantErr   bool
+	}{
+		{value: "bytes 1000-4999/5000", wantStart: 1000, wantTotal: 5000},
+		{value: "bytes 0-99/*", wantStart: 0, wantTotal: -1},
+		{value: " bytes 10-19/20 ", wantStart: 10, wantTotal: 20},
+		{value: "items 0-9/10", wantErr: true},
+		{value: "bytes 0-9", wantErr: true},
+		{value: "bytes 10/20", wantErr: true},
+		{value: "bytes x-9/10", wantErr: true},
+		{value: "bytes 0-9/ten", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			start, total, err := parseContentRange(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantTotal, total)
+		})
+	}
+}
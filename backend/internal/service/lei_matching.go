@@ -0,0 +1,201 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// Entity-to-LEI matching bounds
+const (
+	// leiMatchCandidateThreshold is the trigram similarity candidates are fetched at; deliberately
+	// low because the database compares raw legal names, including legal forms, to the core name
+	leiMatchCandidateThreshold = 0.2
+	// leiMatchMinScore is the lowest combined score a candidate is returned with
+	leiMatchMinScore = 0.3
+	// leiMatchCandidatePool is how many candidates are re-scored per requested match, at most leiMatchMaxCandidates
+	leiMatchCandidatePool = 5
+	leiMatchMaxCandidates = 250
+)
+
+// legalFormTokens are legal form abbreviations and words stripped from the end of names before
+// scoring, so "Acme Holdings Ltd" and "ACME HOLDINGS LIMITED" compare as equal
+var legalFormTokens = map[string]bool{
+	"ltd": true, "limited": true, "plc": true, "public": true, "llc": true, "llp": true, "lp": true,
+	"inc": true, "incorporated": true, "corp": true, "corporation": true, "co": true, "company": true,
+	"gmbh": true, "mbh": true, "ag": true, "kg": true, "kgaa": true, "ug": true, "se": true, "eg": true,
+	"sa": true, "sas": true, "sarl": true, "sca": true, "srl": true, "spa": true, "sl": true,
+	"nv": true, "bv": true, "oy": true, "oyj": true, "ab": true, "publ": true, "as": true, "asa": true, "aps": true,
+	"pte": true, "pty": true, "bhd": true, "sdn": true, "kk": true, "and": true,
+	"aktiengesellschaft": true, "societe": true, "anonyme": true,
+}
+
+// LEIMatchCandidate is an LEI record proposed as the identity of an entity, with how well its
+// name matches the entity name
+type LEIMatchCandidate struct {
+	LEI                string  `json:"lei"`
+	LegalName          string  `json:"legal_name"`
+	MatchedName        string  `json:"matched_name"` // the legal or transliterated name that scored best
+	Country            string  `json:"country"`
+	City               string  `json:"city"`
+	LegalForm          string  `json:"legal_form"`
+	EntityStatus       string  `json:"entity_status"`
+	RegistrationStatus string  `json:"registration_status"`
+	Score              float64 `json:"score"`         // mean of trigram and token score; 1 for an exact normalized match
+	TrigramScore       float64 `json:"trigram_score"` // trigram similarity of the normalized names
+	TokenScore         float64 `json:"token_score"`   // Dice coefficient of the normalized name words
+}
+
+// MatchEntityName ranks LEI records as candidates for an entity by name similarity, best first.
+// Candidates are fetched from the trigram index, then re-scored on normalized names: case,
+// punctuation and trailing legal forms are ignored and whole words are compared as well as trigrams.
+func (s *leiService) MatchEntityName(name, country string, limit int) ([]*LEIMatchCandidate, error) {
+	tokens := normalizeEntityName(name)
+	core := strings.Join(tokens, " ")
+
+	pool := limit * leiMatchCandidatePool
+	if pool > leiMatchMaxCandidates {
+		pool = leiMatchMaxCandidates
+	}
+	matches, err := s.repo.FuzzySearchLEI(core, leiMatchCandidateThreshold, strings.ToUpper(strings.TrimSpace(country)), pool)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*LEIMatchCandidate, 0, len(matches))
+	for _, match := range matches {
+		candidate := scoreLEIMatch(tokens, &match.LEIRecord)
+		if candidate.Score >= leiMatchMinScore {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	// Among equal scores, an LEI that is still ISSUED is the more useful identity to onboard
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if issuedA, issuedB := a.RegistrationStatus == "ISSUED", b.RegistrationStatus == "ISSUED"; issuedA != issuedB {
+			return issuedA
+		}
+		return a.LegalName < b.LegalName
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// scoreLEIMatch scores a record against the normalized entity name, using whichever of its legal
+// and transliterated names matches best
+func scoreLEIMatch(tokens []string, record *domain.LEIRecord) *LEIMatchCandidate {
+	candidate := &LEIMatchCandidate{
+		LEI:                record.LEI,
+		LegalName:          record.LegalName,
+		Country:            record.LegalAddressCountry,
+		City:               record.LegalAddressCity,
+		LegalForm:          record.EntityLegalForm,
+		EntityStatus:       record.EntityStatus,
+		RegistrationStatus: record.RegistrationStatus,
+	}
+
+	for _, recordName := range []string{record.LegalName, record.TransliteratedLegalName} {
+		if recordName == "" {
+			continue
+		}
+		score, trigram, token := nameSimilarity(tokens, normalizeEntityName(recordName))
+		if score > candidate.Score || candidate.MatchedName == "" {
+			candidate.MatchedName = recordName
+			candidate.Score = roundScore(score)
+			candidate.TrigramScore = roundScore(trigram)
+			candidate.TokenScore = roundScore(token)
+		}
+	}
+	return candidate
+}
+
+// nameSimilarity scores two normalized names: the mean of their trigram similarity and the Dice
+// coefficient of their words, or 1 when they are equal
+func nameSimilarity(a, b []string) (score, trigram, token float64) {
+	trigram = trigramSimilarity(strings.Join(a, " "), strings.Join(b, " "))
+	token = tokenDice(a, b)
+	if trigram == 1 {
+		return 1, trigram, token
+	}
+	return (trigram + token) / 2, trigram, token
+}
+
+// normalizeEntityName lowercases a name and splits it into words, dropping punctuation and
+// trailing legal forms. "&" reads as "and"; dots and apostrophes join letters, so "S.A." is "sa".
+// At least one word is always kept, so a name that is only a legal form still matches itself.
+func normalizeEntityName(name string) []string {
+	name = strings.ToLower(strings.ReplaceAll(name, "&", " and "))
+	name = strings.NewReplacer(".", "", "'", "", "’", "").Replace(name)
+	tokens := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	end := len(tokens)
+	for end > 1 && legalFormTokens[tokens[end-1]] {
+		end--
+	}
+	if len(tokens) > 0 && tokens[0] == "the" && end > 1 {
+		return tokens[1:end]
+	}
+	return tokens[:end]
+}
+
+// trigramSimilarity scores two strings the way pg_trgm's similarity() does: the share of
+// trigrams, taken per word padded with two leading and one trailing space, the strings have in common
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// tokenDice is the Dice coefficient of two word lists: twice the words in common over the words in both
+func tokenDice(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	words := make(map[string]int, len(b))
+	for _, word := range b {
+		words[word]++
+	}
+	shared := 0
+	for _, word := range a {
+		if words[word] > 0 {
+			words[word]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
+
+// roundScore rounds a score to three decimals for stable JSON output
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
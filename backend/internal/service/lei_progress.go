@@ -0,0 +1,187 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+const (
+	progressSubscriberBuffer = 16
+	progressRetention        = 5 * time.Minute // how long a finished file's last progress is kept for late subscribers
+)
+
+// SourceFileProgress is a source file's processing progress, as reported by the heartbeat
+type SourceFileProgress struct {
+	SourceFileID     uuid.UUID `json:"source_file_id"`
+	Status           string    `json:"status"`          // PENDING, IN_PROGRESS, COMPLETED or FAILED
+	Stage            string    `json:"stage,omitempty"` // processing or merging while IN_PROGRESS
+	TotalRecords     int       `json:"total_records"`   // records scanned so far
+	ProcessedRecords int       `json:"processed_records"`
+	FailedRecords    int       `json:"failed_records"`
+	PercentComplete  float64   `json:"percent_complete"`
+	RecordsPerSecond float64   `json:"records_per_sec"`
+	ETASeconds       float64   `json:"eta_seconds"`
+	LastLEI          string    `json:"last_lei,omitempty"`
+	Error            string    `json:"error,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Finished reports whether processing has ended
+func (p SourceFileProgress) Finished() bool {
+	return p.Status == "COMPLETED" || p.Status == "FAILED"
+}
+
+// progressFromSourceFile is the progress recorded on a source file at its last checkpoint
+func progressFromSourceFile(file *domain.SourceFile) SourceFileProgress {
+	progress := SourceFileProgress{
+		SourceFileID:     file.ID,
+		Status:           file.ProcessingStatus,
+		TotalRecords:     file.TotalRecords,
+		ProcessedRecords: file.ProcessedRecords,
+		FailedRecords:    file.FailedRecords,
+		LastLEI:          file.LastProcessedLEI,
+		Error:            file.ProcessingError,
+		UpdatedAt:        file.UpdatedAt,
+	}
+	if file.TotalRecords > 0 {
+		progress.PercentComplete = float64(file.ProcessedRecords) / float64(file.TotalRecords) * 100
+	}
+	return progress
+}
+
+// sourceFileProgressHub fans out the progress of files processed in this process to subscribers
+type sourceFileProgressHub struct {
+	mu          sync.Mutex
+	latest      map[uuid.UUID]SourceFileProgress
+	subscribers map[uuid.UUID]map[chan SourceFileProgress]struct{}
+}
+
+func newSourceFileProgressHub() *sourceFileProgressHub {
+	return &sourceFileProgressHub{
+		latest:      make(map[uuid.UUID]SourceFileProgress),
+		subscribers: make(map[uuid.UUID]map[chan SourceFileProgress]struct{}),
+	}
+}
+
+// publish records a file's progress and sends it to its subscribers. Progress is dropped for
+// subscribers that fall behind, except a finished file's final progress, which is always delivered
+// before their channels are closed.
+func (h *sourceFileProgressHub) publish(progress SourceFileProgress) {
+	progress.UpdatedAt = time.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pruneLocked(progress.UpdatedAt)
+	h.latest[progress.SourceFileID] = progress
+
+	for ch := range h.subscribers[progress.SourceFileID] {
+		if !progress.Finished() {
+			select {
+			case ch <- progress:
+			default:
+			}
+			continue
+		}
+		select {
+		case ch <- progress:
+		default:
+			// Make room by dropping the oldest update (unless the subscriber just did)
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- progress
+		}
+		close(ch)
+	}
+	if progress.Finished() {
+		delete(h.subscribers, progress.SourceFileID)
+	}
+}
+
+// finish publishes a file's final progress once processSourceFile has returned
+func (h *sourceFileProgressHub) finish(sourceFileID uuid.UUID, err error) {
+	h.mu.Lock()
+	progress, ok := h.latest[sourceFileID]
+	h.mu.Unlock()
+	if !ok {
+		progress = SourceFileProgress{SourceFileID: sourceFileID}
+	}
+
+	progress.Stage = ""
+	progress.RecordsPerSecond = 0
+	progress.ETASeconds = 0
+	if err != nil {
+		progress.Status = "FAILED"
+		progress.Error = err.Error()
+	} else {
+		progress.Status = "COMPLETED"
+		progress.PercentComplete = 100
+	}
+	h.publish(progress)
+}
+
+// subscribe returns a channel of a file's progress, starting with its latest progress (fallback if
+// this process has none), and a function that cancels the subscription. The channel is closed once
+// the file has finished.
+func (h *sourceFileProgressHub) subscribe(sourceFileID uuid.UUID, fallback SourceFileProgress) (<-chan SourceFileProgress, func()) {
+	ch := make(chan SourceFileProgress, progressSubscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pruneLocked(time.Now())
+
+	current, ok := h.latest[sourceFileID]
+	if !ok {
+		current = fallback
+	}
+	ch <- current
+	if current.Finished() {
+		close(ch)
+		return ch, func() {}
+	}
+
+	if h.subscribers[sourceFileID] == nil {
+		h.subscribers[sourceFileID] = make(map[chan SourceFileProgress]struct{})
+	}
+	h.subscribers[sourceFileID][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		// Not subscribed any more if the file finished and the channel was already closed
+		if _, ok := h.subscribers[sourceFileID][ch]; !ok {
+			return
+		}
+		delete(h.subscribers[sourceFileID], ch)
+		if len(h.subscribers[sourceFileID]) == 0 {
+			delete(h.subscribers, sourceFileID)
+		}
+		close(ch)
+	}
+}
+
+// pruneLocked forgets finished files once late subscribers have had time to see the outcome
+func (h *sourceFileProgressHub) pruneLocked(now time.Time) {
+	for id, progress := range h.latest {
+		if progress.Finished() && now.Sub(progress.UpdatedAt) > progressRetention {
+			delete(h.latest, id)
+		}
+	}
+}
+
+// WatchProcessingProgress streams a source file's processing progress. The channel receives the
+// current progress straight away, then each heartbeat, and is closed after the final progress of a
+// finished file. Only files processed by this instance report heartbeats; for any other file in
+// progress the channel holds its last checkpoint until the subscription is cancelled.
+func (s *leiService) WatchProcessingProgress(sourceFileID string) (<-chan SourceFileProgress, func(), error) {
+	file, err := s.repo.FindSourceFileByID(sourceFileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	updates, cancel := s.progress.subscribe(file.ID, progressFromSourceFile(file))
+	return updates, cancel, nil
+}
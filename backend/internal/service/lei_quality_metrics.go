@@ -0,0 +1,230 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// ErrNoQualityMetrics is returned for a source file that was not measured, e.g. one still loading
+var ErrNoQualityMetrics = errors.New("no quality metrics for this source file")
+
+// Code sets the validity of enumerated fields is checked against (GLEIF LEI-CDF 3.1)
+var (
+	leiRegistrationStatuses = map[string]bool{
+		"PENDING_VALIDATION": true, "ISSUED": true, "DUPLICATE": true, "LAPSED": true, "MERGED": true, "RETIRED": true,
+		"ANNULLED": true, "CANCELLED": true, "TRANSFERRED": true, "PENDING_TRANSFER": true, "PENDING_ARCHIVAL": true,
+	}
+	leiEntityStatuses   = map[string]bool{"ACTIVE": true, "INACTIVE": true}
+	leiEntityCategories = map[string]bool{
+		"BRANCH": true, "FUND": true, "SOLE_PROPRIETOR": true, "GENERAL": true,
+		"RESIDENT_GOVERNMENT_ENTITY": true, "INTERNATIONAL_ORGANIZATION": true,
+	}
+	registrationAuthorityIDPattern = regexp.MustCompile(`^RA\d{6}$`)
+	elfCodePattern                 = regexp.MustCompile(`^[A-Z0-9]{4}$`)
+)
+
+// leiQualityField is a field of LEI records measured for completeness and, where it has a
+// validity check, validity
+type leiQualityField struct {
+	name      string
+	populated func(r *domain.LEIRecord) bool
+	valid     func(r *domain.LEIRecord, countries map[string]bool) bool // nil: completeness only
+	countries bool                                                      // valid needs the countries reference table
+}
+
+// nonEmpty counts a string field as populated when it is not empty
+func nonEmpty(value func(r *domain.LEIRecord) string) func(r *domain.LEIRecord) bool {
+	return func(r *domain.LEIRecord) bool { return value(r) != "" }
+}
+
+// leiQualityFields are the measured fields, in reporting order
+var leiQualityFields = []leiQualityField{
+	{name: "lei", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.LEI }),
+		valid: func(r *domain.LEIRecord, _ map[string]bool) bool { return IsValidLEI(r.LEI) }},
+	{name: "legal_name", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.LegalName })},
+	{name: "legal_address_line_1", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.LegalAddressLine1 })},
+	{name: "legal_address_city", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.LegalAddressCity })},
+	{name: "legal_address_postal_code", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.LegalAddressPostalCode })},
+	{name: "legal_address_country", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.LegalAddressCountry }),
+		valid: func(r *domain.LEIRecord, countries map[string]bool) bool { return countries[r.LegalAddressCountry] }, countries: true},
+	{name: "hq_address_line_1", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.HQAddressLine1 })},
+	{name: "hq_address_city", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.HQAddressCity })},
+	{name: "hq_address_country", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.HQAddressCountry }),
+		valid: func(r *domain.LEIRecord, countries map[string]bool) bool { return countries[r.HQAddressCountry] }, countries: true},
+	{name: "registration_authority_id", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.RegistrationAuthorityID }),
+		valid: func(r *domain.LEIRecord, _ map[string]bool) bool {
+			return registrationAuthorityIDPattern.MatchString(r.RegistrationAuthorityID)
+		}},
+	{name: "registration_number", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.RegistrationNumber })},
+	{name: "entity_category", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.EntityCategory }),
+		valid: func(r *domain.LEIRecord, _ map[string]bool) bool { return leiEntityCategories[r.EntityCategory] }},
+	{name: "entity_legal_form", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.EntityLegalForm }),
+		valid: func(r *domain.LEIRecord, _ map[string]bool) bool {
+			return elfCodePattern.MatchString(r.EntityLegalForm)
+		}},
+	{name: "entity_status", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.EntityStatus }),
+		valid: func(r *domain.LEIRecord, _ map[string]bool) bool { return leiEntityStatuses[r.EntityStatus] }},
+	{name: "registration_status", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.RegistrationStatus }),
+		valid: func(r *domain.LEIRecord, _ map[string]bool) bool {
+			return leiRegistrationStatuses[r.RegistrationStatus]
+		}},
+	{name: "managing_lou", populated: nonEmpty(func(r *domain.LEIRecord) string { return r.ManagingLOU }),
+		valid: func(r *domain.LEIRecord, _ map[string]bool) bool { return IsValidLEI(r.ManagingLOU) }},
+	{name: "initial_registration_date", populated: func(r *domain.LEIRecord) bool { return !r.InitialRegistrationDate.IsZero() }},
+	{name: "next_renewal_date", populated: func(r *domain.LEIRecord) bool { return !r.NextRenewalDate.IsZero() },
+		valid: func(r *domain.LEIRecord, _ map[string]bool) bool {
+			return r.NextRenewalDate.After(r.InitialRegistrationDate)
+		}},
+}
+
+// leiQualityMetrics accumulates field completeness and validity over the records of a file
+type leiQualityMetrics struct {
+	countries map[string]bool // nil when the countries reference table is unavailable
+	records   int
+	populated []int
+	valid     []int
+}
+
+// newQualityMetrics starts measuring a file. Without the countries reference table the country
+// fields are only measured for completeness.
+func (s *leiService) newQualityMetrics() *leiQualityMetrics {
+	countries, err := s.countryCodes()
+	if err != nil {
+		log.Warn().Err(err).Msg("Countries reference table unavailable, country validity is not measured")
+	}
+	return &leiQualityMetrics{
+		countries: countries,
+		populated: make([]int, len(leiQualityFields)),
+		valid:     make([]int, len(leiQualityFields)),
+	}
+}
+
+// add measures a record
+func (m *leiQualityMetrics) add(record *domain.LEIRecord) {
+	m.records++
+	for i, field := range leiQualityFields {
+		if !field.populated(record) {
+			continue
+		}
+		m.populated[i]++
+		if field.valid != nil && field.valid(record, m.countries) {
+			m.valid[i]++
+		}
+	}
+}
+
+// results returns a metric per field
+func (m *leiQualityMetrics) results(sourceFileID uuid.UUID) []*domain.LEIQualityMetric {
+	metrics := make([]*domain.LEIQualityMetric, len(leiQualityFields))
+	for i, field := range leiQualityFields {
+		metric := &domain.LEIQualityMetric{
+			SourceFileID: sourceFileID,
+			Field:        field.name,
+			Position:     i,
+			Records:      m.records,
+			Populated:    m.populated[i],
+			Completeness: percentage(m.populated[i], m.records),
+		}
+		if field.valid != nil && (!field.countries || m.countries != nil) {
+			valid := m.valid[i]
+			validity := percentage(valid, m.populated[i])
+			metric.Valid, metric.Validity = &valid, &validity
+		}
+		metrics[i] = metric
+	}
+	return metrics
+}
+
+// percentage returns part of whole as a percentage with two decimals; 0 when whole is 0
+func percentage(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*10000) / 100
+}
+
+// saveQualityMetrics stores the metrics of a processed file. Failing to is logged, not fatal: the
+// records themselves have landed.
+func (s *leiService) saveQualityMetrics(sourceFile *domain.SourceFile, metrics *leiQualityMetrics) {
+	if metrics.records == 0 {
+		return
+	}
+	if err := s.repo.ReplaceQualityMetrics(sourceFile.ID, metrics.results(sourceFile.ID)); err != nil {
+		log.Error().Err(err).Str("source_file_id", sourceFile.ID.String()).Msg("Failed to store data quality metrics")
+	}
+}
+
+// LEIQualityReport is the data quality of the records in one source file
+type LEIQualityReport struct {
+	SourceFileID    uuid.UUID                  `json:"source_file_id"`
+	FileName        string                     `json:"file_name"`
+	FileType        string                     `json:"file_type"`
+	PublicationDate time.Time                  `json:"publication_date"`
+	Records         int                        `json:"records"` // records measured
+	Fields          []*domain.LEIQualityMetric `json:"fields"`
+}
+
+// GetQualityMetrics returns the data quality of a source file's records
+func (s *leiService) GetQualityMetrics(sourceFileID string) (*LEIQualityReport, error) {
+	file, err := s.repo.FindSourceFileByID(sourceFileID)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.qualityReports([]*domain.SourceFile{file})
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, ErrNoQualityMetrics
+	}
+	return reports[0], nil
+}
+
+// GetQualityTrend returns the data quality of the most recently published files, newest first,
+// optionally of one file type
+func (s *leiService) GetQualityTrend(fileType string, limit int) ([]*LEIQualityReport, error) {
+	files, err := s.repo.FindQualityMeasuredFiles(fileType, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.qualityReports(files)
+}
+
+// qualityReports builds the reports of the files that have metrics, in the order given
+func (s *leiService) qualityReports(files []*domain.SourceFile) ([]*LEIQualityReport, error) {
+	ids := make([]uuid.UUID, len(files))
+	for i, file := range files {
+		ids[i] = file.ID
+	}
+	metrics, err := s.repo.FindQualityMetrics(ids)
+	if err != nil {
+		return nil, err
+	}
+	byFile := make(map[uuid.UUID][]*domain.LEIQualityMetric, len(files))
+	for _, metric := range metrics {
+		byFile[metric.SourceFileID] = append(byFile[metric.SourceFileID], metric)
+	}
+
+	reports := make([]*LEIQualityReport, 0, len(files))
+	for _, file := range files {
+		fields := byFile[file.ID]
+		if len(fields) == 0 {
+			continue
+		}
+		reports = append(reports, &LEIQualityReport{
+			SourceFileID:    file.ID,
+			FileName:        file.FileName,
+			FileType:        file.FileType,
+			PublicationDate: file.PublicationDate,
+			Records:         fields[0].Records,
+			Fields:          fields,
+		})
+	}
+	return reports, nil
+}
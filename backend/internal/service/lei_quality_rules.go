@@ -0,0 +1,168 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// leiQualityRuleCodes are the data quality rules that can be enabled in lei.qualityrules, in
+// evaluation order
+var leiQualityRuleCodes = []string{
+	domain.DQRuleBadLEIChecksum,
+	domain.DQRuleMissingLegalName,
+	domain.DQRuleInvalidCountry,
+}
+
+// leiQualityRuleNames validates lei.qualityrules, dropping unknown rule codes
+func leiQualityRuleNames(cfg config.LEIConfig) []string {
+	known := make(map[string]bool, len(leiQualityRuleCodes))
+	for _, code := range leiQualityRuleCodes {
+		known[code] = true
+	}
+	var rules []string
+	for _, code := range cfg.QualityRules {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !known[code] {
+			log.Warn().Str("rule", code).Strs("known", leiQualityRuleCodes).Msg("Unknown rule in lei.qualityrules, ignoring")
+			continue
+		}
+		rules = append(rules, code)
+	}
+	return rules
+}
+
+// leiRuleFailure is a data quality rule a record failed, with the reason
+type leiRuleFailure struct {
+	code   string
+	reason string
+}
+
+// leiQualityRules evaluates the enabled data quality rules on records during ingest
+type leiQualityRules struct {
+	enabled   map[string]bool
+	countries map[string]bool // country codes of the countries reference table
+}
+
+// loadQualityRules prepares the configured rules for a file. The country rule needs the countries
+// reference table; if it is empty or cannot be read the rule is skipped rather than quarantining
+// every record.
+func (s *leiService) loadQualityRules() *leiQualityRules {
+	rules := &leiQualityRules{enabled: make(map[string]bool, len(s.qualityRules))}
+	for _, code := range s.qualityRules {
+		rules.enabled[code] = true
+	}
+	if !rules.enabled[domain.DQRuleInvalidCountry] {
+		return rules
+	}
+
+	countries, err := s.countryCodes()
+	if err != nil {
+		log.Warn().Err(err).Msg("Countries reference table unavailable, skipping the INVALID_COUNTRY_CODE rule")
+		delete(rules.enabled, domain.DQRuleInvalidCountry)
+		return rules
+	}
+	rules.countries = countries
+	return rules
+}
+
+// countryCodes returns the codes in the countries reference table, active or not
+func (s *leiService) countryCodes() (map[string]bool, error) {
+	countries, err := s.countryRepo.FindAll(1000, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(countries) == 0 {
+		return nil, errors.New("countries reference table is empty")
+	}
+	codes := make(map[string]bool, len(countries))
+	for _, country := range countries {
+		codes[country.Code] = true
+	}
+	return codes, nil
+}
+
+// evaluate returns the enabled rules the record fails; none means it can be loaded
+func (q *leiQualityRules) evaluate(record *domain.LEIRecord) []leiRuleFailure {
+	var failures []leiRuleFailure
+	for _, code := range leiQualityRuleCodes {
+		if !q.enabled[code] {
+			continue
+		}
+		if reason := q.check(code, record); reason != "" {
+			failures = append(failures, leiRuleFailure{code: code, reason: reason})
+		}
+	}
+	return failures
+}
+
+// check applies one rule, returning why the record fails it or "" if it passes
+func (q *leiQualityRules) check(code string, record *domain.LEIRecord) string {
+	switch code {
+	case domain.DQRuleBadLEIChecksum:
+		if !IsValidLEI(record.LEI) {
+			return fmt.Sprintf("LEI %q is malformed or fails its check digits", record.LEI)
+		}
+	case domain.DQRuleMissingLegalName:
+		if strings.TrimSpace(record.LegalName) == "" {
+			return "legal name is missing"
+		}
+	case domain.DQRuleInvalidCountry:
+		if !q.countries[record.LegalAddressCountry] {
+			return fmt.Sprintf("legal address country %q is not a known country code", record.LegalAddressCountry)
+		}
+		if record.HQAddressCountry != "" && !q.countries[record.HQAddressCountry] {
+			return fmt.Sprintf("headquarters address country %q is not a known country code", record.HQAddressCountry)
+		}
+	}
+	return ""
+}
+
+// quarantineRecord builds the quarantine entry for a record that failed rules
+func quarantineRecord(record *domain.LEIRecord, failures []leiRuleFailure) *domain.LEIQuarantinedRecord {
+	codes := make([]string, len(failures))
+	reasons := make([]string, len(failures))
+	for i, failure := range failures {
+		codes[i] = failure.code
+		reasons[i] = failure.reason
+	}
+	snapshot, err := json.Marshal(record)
+	if err != nil {
+		snapshot = []byte("{}")
+	}
+	lei := record.LEI
+	if len(lei) > 20 {
+		lei = lei[:20]
+	}
+	return &domain.LEIQuarantinedRecord{
+		LEI:            lei,
+		SourceFileID:   record.SourceFileID,
+		Source:         domain.LEIQuarantineSourceRules,
+		RuleCodes:      strings.Join(codes, ","),
+		ErrorMessage:   strings.Join(reasons, "; "),
+		RecordSnapshot: string(snapshot),
+		Status:         domain.LEIQuarantineStatusPending,
+	}
+}
+
+// loadScreenedBatch quarantines the batch's records that failed rules and loads the rest
+func (s *leiService) loadScreenedBatch(batch *leiUpsertBatch, load func(*leiUpsertBatch) *leiUpsertResult) *leiUpsertResult {
+	result := &leiUpsertResult{batch: batch}
+	if len(batch.records) > 0 {
+		result = load(batch)
+	}
+	if result.err != nil || len(batch.rejected) == 0 {
+		return result
+	}
+	if err := s.repo.CreateQuarantinedRecords(batch.rejected); err != nil {
+		result.err = fmt.Errorf("failed to store quarantined records: %w", err)
+		return result
+	}
+	result.quarantined += len(batch.rejected)
+	return result
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// Errors returned when reviewing quarantined records
+var (
+	ErrQuarantineReviewed       = errors.New("quarantined record already reviewed")
+	ErrInvalidQuarantinedRecord = errors.New("record is not a valid LEI record JSON object")
+	ErrQuarantineRulesFailed    = errors.New("record still fails data quality rules")
+	ErrQuarantineLoadFailed     = errors.New("record rejected by the database")
+)
+
+// LEIQuarantineRequeue is an operator's decision to load a quarantined record after review
+type LEIQuarantineRequeue struct {
+	Record        json.RawMessage // corrected record replacing the snapshot; empty loads the snapshot as is
+	OverrideRules bool            // load even if the record still fails data quality rules
+	Note          string
+}
+
+// RequeueQuarantinedRecord loads a pending quarantined record into lei_records, re-evaluating the
+// data quality rules unless overridden. On success it is marked REQUEUED; on failure it stays
+// PENDING and the error says why.
+func (s *leiService) RequeueQuarantinedRecord(ctx context.Context, id, reviewer string, req LEIQuarantineRequeue) (*domain.LEIQuarantinedRecord, error) {
+	quarantined, err := s.pendingQuarantinedRecord(id)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := quarantined.RecordSnapshot
+	if len(req.Record) > 0 {
+		snapshot = string(req.Record)
+	}
+	var record domain.LEIRecord
+	if err := json.Unmarshal([]byte(snapshot), &record); err != nil || record.LEI == "" {
+		return nil, ErrInvalidQuarantinedRecord
+	}
+	record.ID = uuid.Nil // the snapshot may carry the ID of a record that was never stored
+	record.SourceFileID = quarantined.SourceFileID
+	record.UpdatedBy = reviewer
+
+	if !req.OverrideRules {
+		if failures := s.loadQualityRules().evaluate(&record); len(failures) > 0 {
+			reasons := make([]string, len(failures))
+			for i, failure := range failures {
+				reasons[i] = failure.reason
+			}
+			return nil, fmt.Errorf("%w: %s", ErrQuarantineRulesFailed, strings.Join(reasons, "; "))
+		}
+	}
+
+	if _, err := s.repo.UpsertLEIRecord(ctx, &record); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrQuarantineLoadFailed, err)
+	}
+
+	quarantined.RecordSnapshot = snapshot
+	s.markReviewed(quarantined, domain.LEIQuarantineStatusRequeued, reviewer, req.Note)
+	if err := s.repo.UpdateQuarantinedRecord(quarantined); err != nil {
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Str("quarantine_id", id).
+		Str("lei", record.LEI).
+		Bool("override_rules", req.OverrideRules).
+		Str("reviewer", reviewer).
+		Msg("Quarantined LEI record requeued")
+	return quarantined, nil
+}
+
+// DismissQuarantinedRecord marks a pending quarantined record as reviewed and not to be loaded
+func (s *leiService) DismissQuarantinedRecord(id, reviewer, note string) (*domain.LEIQuarantinedRecord, error) {
+	quarantined, err := s.pendingQuarantinedRecord(id)
+	if err != nil {
+		return nil, err
+	}
+	s.markReviewed(quarantined, domain.LEIQuarantineStatusDismissed, reviewer, note)
+	if err := s.repo.UpdateQuarantinedRecord(quarantined); err != nil {
+		return nil, err
+	}
+	return quarantined, nil
+}
+
+// pendingQuarantinedRecord returns a quarantined record that has not been reviewed yet
+func (s *leiService) pendingQuarantinedRecord(id string) (*domain.LEIQuarantinedRecord, error) {
+	quarantined, err := s.repo.FindQuarantinedRecordByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if quarantined.Status != domain.LEIQuarantineStatusPending {
+		return nil, fmt.Errorf("%w (%s)", ErrQuarantineReviewed, quarantined.Status)
+	}
+	return quarantined, nil
+}
+
+func (s *leiService) markReviewed(quarantined *domain.LEIQuarantinedRecord, status, reviewer, note string) {
+	now := time.Now()
+	quarantined.Status = status
+	quarantined.ReviewedBy = reviewer
+	quarantined.ReviewedAt = &now
+	quarantined.ReviewNote = note
+}
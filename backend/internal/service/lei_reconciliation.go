@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/notify"
+)
+
+// NotifyEventReconciliationDrift is raised when local record counts drift from GLEIF's beyond the threshold
+const NotifyEventReconciliationDrift = "reconciliation_drift"
+
+// LEIReconcileSettings configures the reconciliation of local record counts against GLEIF
+type LEIReconcileSettings struct {
+	DriftThreshold float64 // Percentage difference above which a count is a discrepancy
+	ByCountry      bool    // Compare each legal address country with the GLEIF API as well as the total
+}
+
+// leiReconcileSettings parses the reconciliation settings, falling back to defaults for invalid values
+func leiReconcileSettings(cfg config.LEIConfig) LEIReconcileSettings {
+	threshold := cfg.ReconcileDriftThreshold
+	if threshold < 0 || math.IsNaN(threshold) {
+		log.Warn().Float64("value", threshold).Float64("default", 0.5).Msg("Invalid lei.reconciledriftthreshold, using default")
+		threshold = 0.5
+	}
+	return LEIReconcileSettings{DriftThreshold: threshold, ByCountry: cfg.ReconcileByCountry}
+}
+
+// LEIReconciliationRun is the outcome of one reconciliation: a result per scope, TOTAL first
+type LEIReconciliationRun struct {
+	RunID          uuid.UUID                         `json:"run_id"`
+	RunAt          time.Time                         `json:"run_at"`
+	DriftThreshold float64                           `json:"drift_threshold"`
+	Exceeded       int                               `json:"exceeded"` // scopes over the threshold
+	Results        []*domain.LEIReconciliationResult `json:"results"`
+}
+
+// gleifRecordCountResponse is the part of a GLEIF API list response holding the match count
+type gleifRecordCountResponse struct {
+	Meta struct {
+		Pagination struct {
+			Total int64 `json:"total"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+// ReconcileWithGLEIF compares the local record count with the record_count of the latest golden
+// copy and, if configured, each legal address country's count with the GLEIF API. Results are
+// stored; scopes drifting beyond the threshold raise one alert.
+func (s *leiService) ReconcileWithGLEIF() (*LEIReconciliationRun, error) {
+	run := &LEIReconciliationRun{RunID: uuid.New(), RunAt: time.Now().UTC(), DriftThreshold: s.reconcile.DriftThreshold}
+
+	publishes, err := s.getLatestFileURLs()
+	if err != nil {
+		return nil, err
+	}
+	gleifTotal := int64(publishes.Data.LEI2.FullFile.JSON.RecordCount)
+	if gleifTotal == 0 {
+		return nil, fmt.Errorf("latest GLEIF publish has no full file record_count")
+	}
+	publishDate := publishes.Data.LEI2.PublishDate
+
+	localTotal, err := s.repo.CountLEIRecords()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count local LEI records: %w", err)
+	}
+	run.add(domain.LEIReconciliationScopeTotal, localTotal, gleifTotal, publishDate)
+
+	if s.reconcile.ByCountry {
+		localCounts, err := s.repo.CountLEIRecordsByCountry()
+		if err != nil {
+			return nil, fmt.Errorf("failed to count local LEI records by country: %w", err)
+		}
+		countries := make([]string, 0, len(localCounts))
+		for country := range localCounts {
+			countries = append(countries, country)
+		}
+		sort.Strings(countries)
+		for _, country := range countries {
+			gleifCount, err := s.countGLEIFRecords(country)
+			if err != nil {
+				return nil, err
+			}
+			run.add(country, localCounts[country], gleifCount, "")
+		}
+	}
+
+	for _, result := range run.Results {
+		result.Exceeded = result.DriftPercent > run.DriftThreshold
+		if result.Exceeded {
+			run.Exceeded++
+		}
+	}
+	if err := s.repo.CreateReconciliationResults(run.Results); err != nil {
+		return nil, fmt.Errorf("failed to store reconciliation results: %w", err)
+	}
+
+	log.Info().
+		Str("run_id", run.RunID.String()).
+		Int64("local_total", localTotal).
+		Int64("gleif_total", gleifTotal).
+		Int("scopes", len(run.Results)).
+		Int("exceeded", run.Exceeded).
+		Msg("LEI reconciliation completed")
+	if run.Exceeded > 0 {
+		s.notifyReconciliationDrift(run)
+	}
+	return run, nil
+}
+
+// add records the comparison of one scope
+func (run *LEIReconciliationRun) add(scope string, local, gleif int64, publishDate string) {
+	result := &domain.LEIReconciliationResult{
+		RunID:            run.RunID,
+		Scope:            scope,
+		LocalCount:       local,
+		GLEIFCount:       gleif,
+		Difference:       local - gleif,
+		GLEIFPublishDate: publishDate,
+		CreatedAt:        run.RunAt,
+	}
+	switch {
+	case gleif > 0:
+		result.DriftPercent = math.Round(math.Abs(float64(result.Difference))/float64(gleif)*100*10000) / 10000
+	case local > 0:
+		result.DriftPercent = 100 // records GLEIF no longer has for the country at all
+	}
+	run.Results = append(run.Results, result)
+}
+
+// countGLEIFRecords asks the GLEIF API how many LEI records have a legal address in the country
+func (s *leiService) countGLEIFRecords(country string) (int64, error) {
+	query := url.Values{}
+	query.Set("filter[entity.legalAddress.country]", country)
+	query.Set("page[size]", "1")
+	endpoint := s.gleif.settings.APIURL + "/lei-records?" + query.Encode()
+
+	s.apiThrottle.wait()
+	body, err := s.gleif.get(context.Background(), endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count GLEIF records for %s: %w", country, err)
+	}
+	var resp gleifRecordCountResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, fmt.Errorf("failed to decode GLEIF record count for %s: %w", country, err)
+	}
+	return resp.Meta.Pagination.Total, nil
+}
+
+// notifyReconciliationDrift alerts with the scopes that drifted beyond the threshold
+func (s *leiService) notifyReconciliationDrift(run *LEIReconciliationRun) {
+	var lines []string
+	for _, result := range run.Results {
+		if result.Exceeded {
+			lines = append(lines, fmt.Sprintf("%s: local %d, GLEIF %d (%+d, %.2f%%)",
+				result.Scope, result.LocalCount, result.GLEIFCount, result.Difference, result.DriftPercent))
+		}
+	}
+	s.notifier.Notify(notify.Notification{
+		Event:    NotifyEventReconciliationDrift,
+		Severity: notify.SeverityWarning,
+		Title:    fmt.Sprintf("LEI record counts drift from GLEIF in %d scopes", run.Exceeded),
+		Message:  strings.Join(lines, "\n") + "\n\nA full sync usually closes the gap; persistent drift points at failed or skipped files.",
+		Fields: map[string]string{
+			"run_id":          run.RunID.String(),
+			"drift_threshold": strconv.FormatFloat(run.DriftThreshold, 'f', -1, 64) + "%",
+		},
+	})
+}
+
+// GetLatestReconciliation returns the most recent reconciliation run, or nil if none has run
+func (s *leiService) GetLatestReconciliation() (*LEIReconciliationRun, error) {
+	results, err := s.repo.FindLatestReconciliation()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	run := &LEIReconciliationRun{RunID: results[0].RunID, RunAt: results[0].CreatedAt, DriftThreshold: s.reconcile.DriftThreshold, Results: results}
+	for _, result := range results {
+		if result.Exceeded {
+			run.Exceeded++
+		}
+	}
+	return run, nil
+}
+
+// ListReconciliationDiscrepancies returns stored results that exceeded the drift threshold, newest first
+func (s *leiService) ListReconciliationDiscrepancies(limit, offset int) ([]*domain.LEIReconciliationResult, int64, error) {
+	return s.repo.FindReconciliationDiscrepancies(limit, offset)
+}
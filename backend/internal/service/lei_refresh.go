@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LEIRefreshQueueSize bounds the number of LEIs waiting for an on-demand refresh
+const LEIRefreshQueueSize = 1000
+
+// LEIRefreshResult describes the outcome of a refresh request
+type LEIRefreshResult struct {
+	Queued     []string          `json:"queued"`
+	Rejected   map[string]string `json:"rejected"` // LEI -> reason
+	QueueDepth int               `json:"queue_depth"`
+}
+
+// leiRefreshItem is an LEI waiting for a refresh, with the context of the request that queued it
+type leiRefreshItem struct {
+	ctx context.Context
+	lei string
+}
+
+// leiRefreshQueue serializes on-demand GLEIF API calls so bulk refreshes respect the API rate limit
+type leiRefreshQueue struct {
+	items    chan leiRefreshItem
+	pending  sync.Map // LEIs currently queued, to avoid duplicate fetches
+	once     sync.Once
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{} // closed when the worker has exited
+}
+
+func newLEIRefreshQueue() *leiRefreshQueue {
+	return &leiRefreshQueue{
+		items: make(chan leiRefreshItem, LEIRefreshQueueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// RefreshLEIs queues the given LEIs for immediate refresh from the GLEIF single-record API
+// Records are upserted (with audit) as soon as they are fetched, without waiting for the next delta.
+// The refreshes log with ctx's logger but are not cancelled with it.
+func (s *leiService) RefreshLEIs(ctx context.Context, leis []string) *LEIRefreshResult {
+	// Start the worker on first use
+	s.refreshQueue.once.Do(func() {
+		go s.refreshWorker()
+	})
+
+	itemCtx := context.WithoutCancel(ctx)
+	result := &LEIRefreshResult{
+		Queued:   make([]string, 0, len(leis)),
+		Rejected: make(map[string]string),
+	}
+
+	for _, raw := range leis {
+		lei := strings.ToUpper(strings.TrimSpace(raw))
+		if !IsValidLEI(lei) {
+			result.Rejected[raw] = "invalid LEI format or check digits"
+			continue
+		}
+		if _, alreadyQueued := s.refreshQueue.pending.LoadOrStore(lei, struct{}{}); alreadyQueued {
+			result.Rejected[lei] = "refresh already queued"
+			continue
+		}
+
+		select {
+		case s.refreshQueue.items <- leiRefreshItem{ctx: itemCtx, lei: lei}:
+			result.Queued = append(result.Queued, lei)
+		default:
+			s.refreshQueue.pending.Delete(lei)
+			result.Rejected[lei] = "refresh queue full"
+		}
+	}
+
+	result.QueueDepth = len(s.refreshQueue.items)
+
+	log.Ctx(ctx).Info().
+		Int("queued", len(result.Queued)).
+		Int("rejected", len(result.Rejected)).
+		Int("queue_depth", result.QueueDepth).
+		Msg("LEI refresh request accepted")
+
+	return result
+}
+
+// refreshWorker drains the refresh queue at the GLEIF API rate limit
+func (s *leiService) refreshWorker() {
+	defer close(s.refreshQueue.done)
+
+	// Calls are spaced by the shared GLEIF API throttle, waited for in refreshSingleLEI
+	for {
+		select {
+		case <-s.refreshQueue.stop:
+			return
+		case item := <-s.refreshQueue.items:
+			s.refreshSingleLEI(item.ctx, item.lei)
+			s.refreshQueue.pending.Delete(item.lei)
+		}
+	}
+}
+
+// StopRefreshWorker stops the refresh worker, waiting for an in-flight fetch to finish
+// LEIs still queued are dropped; they will be picked up by the next delta sync
+func (s *leiService) StopRefreshWorker(ctx context.Context) error {
+	// If the worker was never started there is nothing to wait for
+	s.refreshQueue.once.Do(func() {
+		close(s.refreshQueue.done)
+	})
+	s.refreshQueue.stopOnce.Do(func() {
+		close(s.refreshQueue.stop)
+	})
+
+	select {
+	case <-s.refreshQueue.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// leiRefreshPayload is the dead letter payload for a failed refresh
+type leiRefreshPayload struct {
+	LEI string `json:"lei"`
+}
+
+// refreshSingleLEI fetches one LEI from GLEIF and upserts it
+// Failures are dead-lettered so operators can retry them
+func (s *leiService) refreshSingleLEI(ctx context.Context, lei string) {
+	s.apiThrottle.wait()
+	record, err := s.fetchLEIFromAPI(ctx, lei)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("lei", lei).Msg("Failed to refresh LEI from GLEIF API")
+		s.deadLetter(ctx, "fetch", lei, err)
+		return
+	}
+
+	updated, err := s.repo.UpsertLEIRecord(ctx, record)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("lei", lei).Msg("Failed to upsert refreshed LEI record")
+		s.deadLetter(ctx, "upsert", lei, err)
+		return
+	}
+
+	log.Ctx(ctx).Info().
+		Str("lei", lei).
+		Bool("updated", updated).
+		Msg("LEI refreshed from GLEIF API")
+}
+
+func (s *leiService) deadLetter(ctx context.Context, kind, lei string, cause error) {
+	if s.deadLetters == nil {
+		return
+	}
+	s.deadLetters.Record(ctx, DeadLetterSourceLEIRefresh, kind, leiRefreshPayload{LEI: lei}, cause)
+}
+
+// RetryDeadLetteredRefresh is the dead letter retry handler for failed LEI refreshes
+func (s *leiService) RetryDeadLetteredRefresh(ctx context.Context, kind string, payload json.RawMessage) error {
+	var p leiRefreshPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("invalid lei-refresh payload: %w", err)
+	}
+	lei := strings.ToUpper(strings.TrimSpace(p.LEI))
+	if !IsValidLEI(lei) {
+		return ErrInvalidLEI
+	}
+	s.apiThrottle.wait()
+	_, err := s.storeLEIFromGLEIF(ctx, lei)
+	return err
+}
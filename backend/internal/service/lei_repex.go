@@ -0,0 +1,220 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// SourceFileTypeRepex marks a source file as a GLEIF reporting exceptions (repex) full snapshot
+const SourceFileTypeRepex = "REPEX"
+
+// repexBatchSize is the number of exceptions written per upsert
+const repexBatchSize = 1000
+
+// RepexJSONRecord is one entry of the repex golden copy file
+// GLEIF JSON format: {"exceptions": [ {"LEI": {"$": ...}, "ExceptionCategory": {"$": ...}, "ExceptionReason": [{"$": ...}], ...} ]}
+type RepexJSONRecord struct {
+	LEI                LEIValueField  `json:"LEI"`
+	ExceptionCategory  LEIValueField  `json:"ExceptionCategory"`
+	ExceptionReason    LEIValueFields `json:"ExceptionReason"`
+	ExceptionReference LEIValueFields `json:"ExceptionReference"`
+}
+
+// LEIValueFields decodes either a single {"$": value} object or an array of them
+type LEIValueFields []LEIValueField
+
+// UnmarshalJSON accepts both the object and array encodings used across GLEIF files
+func (f *LEIValueFields) UnmarshalJSON(data []byte) error {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "null" {
+		*f = nil
+		return nil
+	}
+	if strings.HasPrefix(trimmed, "[") {
+		var values []LEIValueField
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		*f = values
+		return nil
+	}
+	var value LEIValueField
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*f = LEIValueFields{value}
+	return nil
+}
+
+// DownloadRepexFile downloads the full reporting exceptions file from GLEIF
+func (s *leiService) DownloadRepexFile() (*domain.SourceFile, error) {
+	publishes, err := s.getLatestFileURLs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest file URLs: %w", err)
+	}
+
+	url := publishes.Data.Repex.FullFile.JSON.URL
+	if url == "" {
+		return nil, fmt.Errorf("GLEIF publishes response has no repex full file URL")
+	}
+	publishedAt := publishes.Data.Repex.PublishDate
+	return s.downloadFile(url, SourceFileTypeRepex, SourceFileFormatJSON, publishedAt, "")
+}
+
+// GetReportingExceptions returns why an LEI has no direct/ultimate parent reported
+func (s *leiService) GetReportingExceptions(lei string) ([]*domain.LEIReportingException, error) {
+	return s.exceptionRepo.FindByLEI(lei)
+}
+
+// processRepexFile loads a repex snapshot and removes exceptions no longer published
+// The file is small enough (a few hundred thousand entries) to reload in full, so there is no resume
+func (s *leiService) processRepexFile(file io.Reader, sourceFile *domain.SourceFile, watchdog *processingWatchdog, job *Job) error {
+	decoder := json.NewDecoder(file)
+	if err := seekJSONArray(decoder, "exceptions"); err != nil {
+		return err
+	}
+
+	var total, loaded, failed int
+	batch := make([]*domain.LEIReportingException, 0, repexBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.exceptionRepo.BatchUpsert(batch); err != nil {
+			return fmt.Errorf("repex batch upsert failed: %w", err)
+		}
+		loaded += len(batch)
+		batch = batch[:0]
+		watchdog.touch()
+		if err := watchdog.err(); err != nil {
+			return err
+		}
+
+		sourceFile.TotalRecords = total
+		sourceFile.ProcessedRecords = loaded
+		sourceFile.FailedRecords = failed
+		if err := s.repo.UpdateSourceFile(sourceFile); err != nil {
+			log.Error().Err(err).Msg("Failed to update source file progress")
+		}
+		job.SetProgress(int64(loaded), 0)
+		return nil
+	}
+
+	for decoder.More() {
+		if err := watchdog.err(); err != nil {
+			return err
+		}
+		total++
+		var record RepexJSONRecord
+		if err := decoder.Decode(&record); err != nil {
+			log.Error().Err(err).Int("record_number", total).Msg("Failed to decode repex JSON record")
+			failed++
+			continue
+		}
+
+		batch = append(batch, repexToDomain(&record, sourceFile.ID)...)
+		if len(batch) >= repexBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	removed, err := s.exceptionRepo.DeleteNotInSourceFile(sourceFile.ID)
+	if err != nil {
+		return fmt.Errorf("failed to remove withdrawn reporting exceptions: %w", err)
+	}
+
+	log.Info().
+		Str("source_file_id", sourceFile.ID.String()).
+		Int("records", total).
+		Int("exceptions_loaded", loaded).
+		Int("failed", failed).
+		Int64("withdrawn", removed).
+		Msg("Reporting exceptions file processed")
+	return nil
+}
+
+// repexToDomain converts a repex entry to one row per exception reason
+func repexToDomain(record *RepexJSONRecord, sourceFileID uuid.UUID) []*domain.LEIReportingException {
+	lei := strings.TrimSpace(record.LEI.Value)
+	category := strings.TrimSpace(record.ExceptionCategory.Value)
+	if lei == "" || category == "" {
+		return nil
+	}
+
+	references := make([]string, 0, len(record.ExceptionReference))
+	for _, ref := range record.ExceptionReference {
+		if v := strings.TrimSpace(ref.Value); v != "" {
+			references = append(references, v)
+		}
+	}
+	reference := strings.Join(references, "; ")
+
+	reasons := record.ExceptionReason
+	if len(reasons) == 0 {
+		reasons = LEIValueFields{{Value: "UNSPECIFIED"}}
+	}
+
+	exceptions := make([]*domain.LEIReportingException, 0, len(reasons))
+	seen := make(map[string]bool, len(reasons))
+	for _, reason := range reasons {
+		code := strings.TrimSpace(reason.Value)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		id := sourceFileID
+		exceptions = append(exceptions, &domain.LEIReportingException{
+			LEI:                lei,
+			ExceptionCategory:  category,
+			ExceptionReason:    code,
+			ExceptionReference: reference,
+			SourceFileID:       &id,
+		})
+	}
+	return exceptions
+}
+
+// seekJSONArray advances the decoder past the opening '[' of the top-level array stored under key
+func seekJSONArray(decoder *json.Decoder, key string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read opening brace: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected '{', got %v", token)
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read token: %w", err)
+		}
+		if name, ok := token.(string); ok && name == key {
+			token, err := decoder.Token()
+			if err != nil {
+				return fmt.Errorf("failed to read array opening: %w", err)
+			}
+			if delim, ok := token.(json.Delim); !ok || delim != '[' {
+				return fmt.Errorf("expected '[', got %v", token)
+			}
+			return nil
+		}
+
+		var skipValue json.RawMessage
+		if err := decoder.Decode(&skipValue); err != nil {
+			return fmt.Errorf("failed to skip value: %w", err)
+		}
+	}
+	return fmt.Errorf("%s array not found in JSON file", key)
+}
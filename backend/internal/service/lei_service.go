@@ -2,35 +2,42 @@ package service
 
 import (
 	"archive/zip"
-	"crypto/sha256"
-	"encoding/hex"
+	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/notify"
 	"github.com/techie2000/axiom/internal/repository"
+	"github.com/techie2000/axiom/internal/storage"
 )
 
 // GLEIF API endpoints and data directory configuration
 const (
-	// Base URL for GLEIF golden copy API
+	// Default base URL for GLEIF golden copy API (lei.goldencopybaseurl)
 	GLEIFBaseURL = "https://goldencopy.gleif.org"
 
-	// Discovery endpoint to get latest file URLs
+	// Discovery endpoint to get latest file URLs, relative to the golden copy base URL
 	// Returns bulk file metadata - format differs from single LEI API queries
-	GLEIFLatestPublishesURL = "https://goldencopy.gleif.org/api/v2/golden-copies/publishes/latest"
+	GLEIFLatestPublishesPath = "/api/v2/golden-copies/publishes/latest"
 
 	// Data directory for downloaded files (relative to working directory)
 	DefaultDataDirectory = "./data/lei"
+
+	// Read buffer between the ZIP decompressor and the JSON/XML decoder
+	zipStreamBufferSize = 1 << 20
 )
 
 // GLEIFPublishesResponse represents the response from the GLEIF latest publishes endpoint
@@ -39,7 +46,8 @@ type GLEIFPublishesResponse struct {
 }
 
 type GLEIFPublishesData struct {
-	LEI2 GLEIFFileFormats `json:"lei2"`
+	LEI2  GLEIFFileFormats `json:"lei2"`
+	Repex GLEIFFileFormats `json:"repex"` // reporting exceptions
 }
 
 type GLEIFFileFormats struct {
@@ -51,6 +59,7 @@ type GLEIFFileFormats struct {
 
 type GLEIFFileGroup struct {
 	JSON GLEIFJSONFileInfo `json:"json"`
+	XML  GLEIFJSONFileInfo `json:"xml"` // LEI-CDF; same metadata as the JSON file
 }
 
 type GLEIFDeltaFileGroups struct {
@@ -60,6 +69,40 @@ type GLEIFDeltaFileGroups struct {
 	LastMonth GLEIFFileGroup `json:"LastMonth"`
 }
 
+// GLEIF delta file flavors: changes published in the last 8 hours, day, week or month
+const (
+	DeltaTypeIntraDay  = "IntraDay"
+	DeltaTypeLastDay   = "LastDay"
+	DeltaTypeLastWeek  = "LastWeek"
+	DeltaTypeLastMonth = "LastMonth"
+)
+
+// ErrInvalidDeltaType is returned for a delta type other than IntraDay, LastDay, LastWeek or LastMonth
+var ErrInvalidDeltaType = errors.New("delta type must be IntraDay, LastDay, LastWeek or LastMonth")
+
+// ParseDeltaType normalizes a delta type case-insensitively (e.g. "lastday" -> LastDay)
+func ParseDeltaType(deltaType string) (string, error) {
+	for _, t := range []string{DeltaTypeIntraDay, DeltaTypeLastDay, DeltaTypeLastWeek, DeltaTypeLastMonth} {
+		if strings.EqualFold(strings.TrimSpace(deltaType), t) {
+			return t, nil
+		}
+	}
+	return "", ErrInvalidDeltaType
+}
+
+// group returns the file group for a (parsed) delta type
+func (g GLEIFDeltaFileGroups) group(deltaType string) GLEIFFileGroup {
+	switch deltaType {
+	case DeltaTypeIntraDay:
+		return g.IntraDay
+	case DeltaTypeLastDay:
+		return g.LastDay
+	case DeltaTypeLastMonth:
+		return g.LastMonth
+	}
+	return g.LastWeek
+}
+
 type GLEIFJSONFileInfo struct {
 	URL         string `json:"url"`
 	Size        int64  `json:"size"`
@@ -72,7 +115,10 @@ type GLEIFJSONFileInfo struct {
 type LEIService interface {
 	// File download and management
 	DownloadFullFile() (*domain.SourceFile, error)
-	DownloadDeltaFile() (*domain.SourceFile, error)
+	DownloadDeltaFile(deltaType string) (*domain.SourceFile, error)
+	DownloadRepexFile() (*domain.SourceFile, error)
+	PlanDeltaCatchUp(maxDeltas int) (*DeltaCatchUpPlan, error)
+	DownloadPendingDelta(delta PendingDelta) (*domain.SourceFile, error)
 
 	// File processing
 	ProcessSourceFile(sourceFileID uuid.UUID) error
@@ -81,21 +127,52 @@ type LEIService interface {
 	FindRetryableFailedFiles() ([]*domain.SourceFile, error)
 	ResetFailedFileForRetry(fileID uuid.UUID) error
 	UpdateSourceFile(file *domain.SourceFile) error
+	StopProcessing(ctx context.Context) error
 
 	// Record management
 	CreateLEIRecord(record *domain.LEIRecord) error
 	GetLEIByCode(lei string) (*domain.LEIRecord, error)
+	FetchLEIFromGLEIF(ctx context.Context, lei string) (*domain.LEIRecord, error)
 	GetLEIByID(id string) (*domain.LEIRecord, error)
 	GetAllLEI(limit, offset int) ([]*domain.LEIRecord, error)
-	GetAllLEIWithFilters(limit, offset int, search, status, category, country, sortBy, sortOrder string) ([]*domain.LEIRecord, error)
+	GetAllLEIWithFilters(limit, offset int, filter repository.LEIFilter) ([]*domain.LEIRecord, error)
+	FuzzySearchLEI(name string, threshold float64, country string, limit int) ([]*domain.LEIFuzzyMatch, error)
+	MatchEntityName(name, country string, limit int) ([]*LEIMatchCandidate, error)
 	CountLEIRecords() (int64, error)
 	GetDistinctCountries() ([]domain.Country, error)
 	UpdateLEIRecord(record *domain.LEIRecord) error
+	RefreshLEIs(ctx context.Context, leis []string) *LEIRefreshResult
+	StopRefreshWorker(ctx context.Context) error
+	RetryDeadLetteredRefresh(ctx context.Context, kind string, payload json.RawMessage) error
 
 	// Audit and history
 	GetAuditHistory(lei string, limit int) ([]*domain.LEIRecordAudit, error)
+	GetRecordVersion(lei, auditID string) (*LEIRecordVersion, error)
+	GetRecordAsOf(lei string, asOf time.Time) (*LEIRecordVersion, error)
+	DiffRecordVersions(lei, fromAuditID, toAuditID string) (*LEIRecordDiff, error)
+	GetChanges(since string, limit int) (*LEIChangeFeed, error)
+
+	// Data quality of loaded files
+	GetQualityMetrics(sourceFileID string) (*LEIQualityReport, error)
+	GetQualityTrend(fileType string, limit int) ([]*LEIQualityReport, error)
+
+	// Reconciliation of local record counts against GLEIF
+	ReconcileWithGLEIF() (*LEIReconciliationRun, error)
+	GetLatestReconciliation() (*LEIReconciliationRun, error)
+	ListReconciliationDiscrepancies(limit, offset int) ([]*domain.LEIReconciliationResult, int64, error)
+
+	// Level 2 reporting exceptions
+	GetReportingExceptions(lei string) ([]*domain.LEIReportingException, error)
+
+	// Records rejected by the database or data quality rules during ingestion, and their review
+	ListQuarantinedRecords(filter repository.LEIQuarantineFilter, limit, offset int) ([]*domain.LEIQuarantinedRecord, int64, error)
+	RequeueQuarantinedRecord(ctx context.Context, id, reviewer string, req LEIQuarantineRequeue) (*domain.LEIQuarantinedRecord, error)
+	DismissQuarantinedRecord(id, reviewer, note string) (*domain.LEIQuarantinedRecord, error)
 
 	// Processing status
+	WatchProcessingProgress(sourceFileID string) (<-chan SourceFileProgress, func(), error)
+	ListProcessingStatuses() ([]*domain.FileProcessingStatus, error)
+	SubscribeStatusEvents() (<-chan LEIStatusEvent, func())
 	GetProcessingStatus(jobType string) (*domain.FileProcessingStatus, error)
 	UpdateProcessingStatus(status *domain.FileProcessingStatus) error
 
@@ -106,37 +183,80 @@ type LEIService interface {
 type leiService struct {
 	repo        repository.LEIRepository
 	countryRepo repository.CountryRepository
-	dataDir     string // Directory to store downloaded files
+	// Reporting exceptions loaded from repex files
+	exceptionRepo repository.LEIExceptionRepository
+	dataDir       string // Working directory for downloads in progress (and fetched files with remote storage)
+	// Where downloaded files are kept (dataDir itself for local storage)
+	store storage.Storage
+	// Where files are archived before cleanup removes them; nil removes them outright
+	archive storage.Archive
+	// Queue for on-demand refreshes from the GLEIF single-record API
+	refreshQueue *leiRefreshQueue
+	// Spaces GLEIF single-record API calls to respect the rate limit
+	apiThrottle gleifAPIThrottle
+	// Receives refreshes that failed permanently (may be nil)
+	deadLetters DeadLetterRecorder
+	// Deadline and stall limits enforced by the processing watchdog
+	limits LEIProcessingLimits
+	// Preferred bulk file format (JSON or XML) for full and delta downloads
+	fileFormat string
+	// Registers file processing so its progress is visible at /admin/tasks
+	jobs *JobRegistry
+	// Heartbeats of the files being processed, for progress streams
+	progress *sourceFileProgressHub
+	// Status transitions and batch progress, for status websocket clients
+	events *leiStatusHub
+	// Alerts operators to failed syncs and files that need attention
+	notifier notify.Notifier
+	// Timeouts and retries for discovery, API calls and downloads
+	gleif *gleifClient
+	// Drift threshold and scope of the reconciliation against GLEIF record counts
+	reconcile LEIReconcileSettings
+	// Data quality rules (DQ rule codes) records must pass during ingest
+	qualityRules []string
+	// Cancelled by StopProcessing, interrupting the files being processed
+	shutdown       context.Context
+	stopProcessing context.CancelCauseFunc
+	// Files being processed; processingMu orders new files against StopProcessing
+	processing   sync.WaitGroup
+	processingMu sync.Mutex
 }
 
 // NewLEIService creates a new LEI service
-func NewLEIService(repo repository.LEIRepository, countryRepo repository.CountryRepository, dataDir string) LEIService {
+func NewLEIService(repo repository.LEIRepository, countryRepo repository.CountryRepository, exceptionRepo repository.LEIExceptionRepository, dataDir string, store storage.Storage, archive storage.Archive, deadLetters DeadLetterRecorder, limits LEIProcessingLimits, fileFormat string, jobs *JobRegistry, notifier notify.Notifier, httpSettings GLEIFHTTPSettings, reconcile LEIReconcileSettings, qualityRules []string) LEIService {
+	shutdown, stopProcessing := context.WithCancelCause(context.Background())
 	return &leiService{
-		repo:        repo,
-		countryRepo: countryRepo,
-		dataDir:     dataDir,
+		repo:           repo,
+		countryRepo:    countryRepo,
+		exceptionRepo:  exceptionRepo,
+		dataDir:        dataDir,
+		store:          store,
+		archive:        archive,
+		refreshQueue:   newLEIRefreshQueue(),
+		deadLetters:    deadLetters,
+		limits:         limits,
+		fileFormat:     normalizeFileFormat(fileFormat),
+		jobs:           jobs,
+		progress:       newSourceFileProgressHub(),
+		events:         newLEIStatusHub(),
+		notifier:       notifier,
+		gleif:          newGLEIFClient(httpSettings),
+		reconcile:      reconcile,
+		qualityRules:   qualityRules,
+		shutdown:       shutdown,
+		stopProcessing: stopProcessing,
 	}
 }
 
 // getLatestFileURLs fetches the latest file URLs from GLEIF API
 func (s *leiService) getLatestFileURLs() (*GLEIFPublishesResponse, error) {
-	log.Info().Str("url", GLEIFLatestPublishesURL).Msg("Fetching latest file URLs from GLEIF")
+	url := s.gleif.settings.GoldenCopyURL + GLEIFLatestPublishesPath
+	log.Info().Str("url", url).Msg("Fetching latest file URLs from GLEIF")
 
-	resp, err := http.Get(GLEIFLatestPublishesURL)
+	body, err := s.gleif.get(context.Background(), url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch latest publishes: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch latest publishes: HTTP %d", resp.StatusCode)
-	}
-
-	// Read the response body for debugging
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
 
 	var publishesResp GLEIFPublishesResponse
 	if err := json.Unmarshal(body, &publishesResp); err != nil {
@@ -167,67 +287,54 @@ func (s *leiService) DownloadFullFile() (*domain.SourceFile, error) {
 		return nil, fmt.Errorf("failed to get latest file URLs: %w", err)
 	}
 
-	url := publishes.Data.LEI2.FullFile.JSON.URL
+	url, format := selectFileURL(publishes.Data.LEI2.FullFile, s.fileFormat)
 	publishedAt := publishes.Data.LEI2.PublishDate
-	return s.downloadFile(url, "FULL", publishedAt)
+	return s.downloadFile(url, "FULL", format, publishedAt, "")
 }
 
-// DownloadDeltaFile downloads the delta LEI data file from GLEIF
-func (s *leiService) DownloadDeltaFile() (*domain.SourceFile, error) {
+// DownloadDeltaFile downloads the delta LEI data file of the given type (IntraDay, LastDay, LastWeek, LastMonth) from GLEIF
+func (s *leiService) DownloadDeltaFile(deltaType string) (*domain.SourceFile, error) {
+	deltaType, err := ParseDeltaType(deltaType)
+	if err != nil {
+		return nil, err
+	}
+
 	publishes, err := s.getLatestFileURLs()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest file URLs: %w", err)
 	}
 
-	url := publishes.Data.LEI2.DeltaFiles.LastWeek.JSON.URL
+	url, format := selectFileURL(publishes.Data.LEI2.DeltaFiles.group(deltaType), s.fileFormat)
+	if url == "" {
+		return nil, fmt.Errorf("GLEIF publishes response has no %s delta file URL", deltaType)
+	}
+	log.Info().Str("delta_type", deltaType).Msg("Selected delta file")
 	publishedAt := publishes.Data.LEI2.PublishDate
-	return s.downloadFile(url, "DELTA", publishedAt)
+	return s.downloadFile(url, "DELTA", format, publishedAt, deltaType)
 }
 
 // downloadFile downloads a file from GLEIF and creates a SourceFile record
-func (s *leiService) downloadFile(url, fileType, publishedAt string) (*domain.SourceFile, error) {
-	log.Info().Str("url", url).Str("type", fileType).Msg("Starting file download from GLEIF")
+// deltaType records which delta flavor a DELTA file is (empty for other file types)
+func (s *leiService) downloadFile(url, fileType, fileFormat, publishedAt, deltaType string) (*domain.SourceFile, error) {
+	url = s.gleif.mirrored(url)
+	log.Info().Str("url", url).Str("type", fileType).Str("format", fileFormat).Msg("Starting file download from GLEIF")
 
 	// Create data directory if it doesn't exist
 	if err := os.MkdirAll(s.dataDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
-	// Download file
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to download file: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to download file: HTTP %d", resp.StatusCode)
-	}
-
 	// Generate filename with timestamp
 	timestamp := time.Now().Format("20060102-150405")
-	fileName := fmt.Sprintf("lei-%s-%s.json.zip", fileType, timestamp)
+	fileName := fmt.Sprintf("lei-%s-%s.%s.zip", fileType, timestamp, strings.ToLower(fileFormat))
 	filePath := filepath.Join(s.dataDir, fileName)
 
-	// Create file
-	out, err := os.Create(filePath)
+	// Download file; an interrupted download resumes where it stopped
+	fileSize, fileHash, err := s.gleif.downloadResumable(url, filepath.Join(s.dataDir, partialDownloadDir), filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create file: %w", err)
-	}
-	defer out.Close()
-
-	// Calculate hash while downloading
-	hash := sha256.New()
-	multiWriter := io.MultiWriter(out, hash)
-
-	// Copy data
-	fileSize, err := io.Copy(multiWriter, resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to save file: %w", err)
+		return nil, err
 	}
 
-	fileHash := hex.EncodeToString(hash.Sum(nil))
-
 	log.Info().
 		Str("file", fileName).
 		Int64("size", fileSize).
@@ -251,15 +358,15 @@ func (s *leiService) downloadFile(url, fileType, publishedAt string) (*domain.So
 		return nil, fmt.Errorf("duplicate file already processed: %s", existingFile.FileName)
 	}
 
+	if err := s.store.Store(context.Background(), fileName, filePath); err != nil {
+		os.Remove(filePath)
+		return nil, fmt.Errorf("failed to store downloaded file: %w", err)
+	}
+
 	// Parse publication date
-	var publicationDate time.Time
-	if publishedAt != "" {
-		if t, err := time.Parse(time.RFC3339, publishedAt); err == nil {
-			publicationDate = t
-		} else {
-			publicationDate = time.Now()
-		}
-	} else {
+	publicationDate, err := parseGLEIFPublishDate(publishedAt)
+	if err != nil {
+		log.Warn().Err(err).Str("published_at", publishedAt).Msg("Unrecognised GLEIF publish date, using download time")
 		publicationDate = time.Now()
 	}
 
@@ -267,6 +374,8 @@ func (s *leiService) downloadFile(url, fileType, publishedAt string) (*domain.So
 	sourceFile := &domain.SourceFile{
 		FileName:         fileName,
 		FileType:         fileType,
+		FileFormat:       fileFormat,
+		DeltaType:        deltaType,
 		FileURL:          url,
 		FileSize:         fileSize,
 		FileHash:         fileHash,
@@ -289,6 +398,22 @@ func (s *leiService) ProcessSourceFile(sourceFileID uuid.UUID) error {
 
 // ProcessSourceFileWithResume processes a source file, optionally resuming from a specific LEI
 func (s *leiService) ProcessSourceFileWithResume(sourceFileID uuid.UUID, resumeFromLEI string) error {
+	if !s.beginProcessing() {
+		return ErrProcessingInterrupted
+	}
+	defer s.processing.Done()
+
+	description := fmt.Sprintf("Process LEI source file %s", sourceFileID)
+	return s.jobs.Run(JobKindLEIFileProcessing, JobOwnerSystem, description, func(job *Job) error {
+		job.SetContext("source_file_id", sourceFileID.String())
+		err := s.processSourceFile(sourceFileID, resumeFromLEI, job)
+		s.progress.finish(sourceFileID, err)
+		return err
+	})
+}
+
+// processSourceFile streams a source file out of its ZIP and loads it, reporting progress on job
+func (s *leiService) processSourceFile(sourceFileID uuid.UUID, resumeFromLEI string, job *Job) error {
 	log.Info().Str("source_file_id", sourceFileID.String()).Str("resume_from", resumeFromLEI).Msg("Starting file processing")
 
 	// Get source file
@@ -307,45 +432,78 @@ func (s *leiService) ProcessSourceFileWithResume(sourceFileID uuid.UUID, resumeF
 	if err := s.repo.UpdateSourceFile(sourceFile); err != nil {
 		return fmt.Errorf("failed to update source file status: %w", err)
 	}
+	s.progress.publish(progressFromSourceFile(sourceFile))
 
-	// Extract and process file
+	// Stream the data file straight out of the ZIP; nothing is extracted to disk
 	filePath := filepath.Join(s.dataDir, sourceFile.FileName)
+	fileFormat := normalizeFileFormat(sourceFile.FileFormat)
 
-	// Check if already extracted (from previous run)
-	jsonPath := filePath + ".extracted.json"
-	if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
-		// Extracted file doesn't exist, try to extract from zip
-		log.Info().
-			Str("source_file_id", sourceFileID.String()).
-			Str("file_path", filePath).
-			Msg("Extracted file not found, starting extraction from ZIP")
-
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			sourceFile.ProcessingStatus = "FAILED"
-			sourceFile.ProcessingError = fmt.Sprintf("source file not found: %s", filePath)
-			sourceFile.FailureCategory = "FILE_MISSING"
-			s.repo.UpdateSourceFile(sourceFile)
-			return fmt.Errorf("source file not found: %s", filePath)
-		}
+	job.SetStage("fetching")
+	zipPath, release, err := s.store.Fetch(context.Background(), sourceFile.FileName, s.dataDir)
+	if errors.Is(err, storage.ErrNotFound) {
+		sourceFile.ProcessingStatus = "FAILED"
+		sourceFile.ProcessingError = fmt.Sprintf("source file not found: %s", filePath)
+		sourceFile.FailureCategory = "FILE_MISSING"
+		s.repo.UpdateSourceFile(sourceFile)
+		return fmt.Errorf("source file not found: %s", filePath)
+	}
+	if err != nil {
+		sourceFile.ProcessingStatus = "FAILED"
+		sourceFile.ProcessingError = err.Error()
+		sourceFile.FailureCategory = "FILE_MISSING"
+		s.repo.UpdateSourceFile(sourceFile)
+		return fmt.Errorf("failed to fetch source file from %s storage: %w", s.store.Backend(), err)
+	}
+	defer release()
 
-		// Unzip file
-		var extractErr error
-		jsonPath, extractErr = s.extractZipFile(filePath)
-		if extractErr != nil {
-			sourceFile.ProcessingStatus = "FAILED"
-			sourceFile.ProcessingError = extractErr.Error()
-			sourceFile.FailureCategory = "FILE_CORRUPTION"
-			s.repo.UpdateSourceFile(sourceFile)
-			return fmt.Errorf("failed to extract file: %w", extractErr)
+	data, err := openZipDataFile(zipPath, fileFormat)
+	if err != nil {
+		sourceFile.ProcessingStatus = "FAILED"
+		sourceFile.ProcessingError = err.Error()
+		sourceFile.FailureCategory = "FILE_CORRUPTION"
+		s.repo.UpdateSourceFile(sourceFile)
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer data.Close()
+
+	// Parse and process JSON under the watchdog; a stalled file is abandoned so the job is released
+	// even if the processing goroutine is stuck in a blocked database call
+	job.SetStage("processing")
+	watchdog := startProcessingWatchdog(s.shutdown, sourceFileID, s.limits)
+	defer watchdog.stop()
+	process := func() error { return s.processJSONFile(data, sourceFile, resumeFromLEI, watchdog, job) }
+	if sourceFile.FileType == SourceFileTypeRepex {
+		process = func() error { return s.processRepexFile(data, sourceFile, watchdog, job) }
+	} else if fileFormat == SourceFileFormatXML {
+		process = func() error { return s.processCDFFile(data, sourceFile, resumeFromLEI, watchdog, job) }
+	}
+	done := make(chan error, 1)
+	go func() { done <- process() }()
+
+	var processErr error
+	stalled := false
+	select {
+	case processErr = <-done:
+		stalled = processErr != nil && watchdog.err() != nil
+	case <-watchdog.done():
+		stalled = true
+		if errors.Is(watchdog.err(), ErrProcessingInterrupted) {
+			// Shutting down: the processing goroutine stops at its next checkpoint
+			processErr = <-done
+			stalled = processErr != nil
+		}
+	}
+	if stalled {
+		cause := watchdog.err()
+		if errors.Is(cause, ErrProcessingInterrupted) {
+			s.interruptSourceFile(sourceFileID, cause)
+			return cause
 		}
-		log.Info().Str("json_path", jsonPath).Msg("File extracted successfully")
-	} else {
-		log.Info().Str("json_path", jsonPath).Msg("Using previously extracted file")
+		s.abortStalledFile(sourceFileID, cause)
+		return fmt.Errorf("failed to process JSON file: %w", cause)
 	}
-	defer os.Remove(jsonPath) // Clean up extracted JSON
 
-	// Parse and process JSON
-	if err := s.processJSONFile(jsonPath, sourceFile, resumeFromLEI); err != nil {
+	if err := processErr; err != nil {
 		sourceFile.ProcessingStatus = "FAILED"
 		sourceFile.ProcessingError = err.Error()
 
@@ -357,7 +515,8 @@ func (s *leiService) ProcessSourceFileWithResume(sourceFileID uuid.UUID, resumeF
 			sourceFile.FailureCategory = "SCHEMA_ERROR"
 		} else if strings.Contains(errorMsg, "connection") || strings.Contains(errorMsg, "timeout") {
 			sourceFile.FailureCategory = "NETWORK_ERROR"
-		} else if strings.Contains(errorMsg, "invalid JSON") || strings.Contains(errorMsg, "unexpected EOF") {
+		} else if strings.Contains(errorMsg, "invalid JSON") || strings.Contains(errorMsg, "invalid XML") || strings.Contains(errorMsg, "unexpected EOF") ||
+			errors.Is(err, zip.ErrChecksum) || errors.Is(err, zip.ErrFormat) {
 			sourceFile.FailureCategory = "FILE_CORRUPTION"
 		} else {
 			// Defensive: ensure category is always set for FAILED status
@@ -377,6 +536,7 @@ func (s *leiService) ProcessSourceFileWithResume(sourceFileID uuid.UUID, resumeF
 			Msg("File processing failed with categorized error")
 
 		s.repo.UpdateSourceFile(sourceFile)
+		s.notifyIfRetriesExhausted(sourceFile)
 		return fmt.Errorf("failed to process JSON file: %w", err)
 	}
 
@@ -397,62 +557,65 @@ func (s *leiService) ProcessSourceFileWithResume(sourceFileID uuid.UUID, resumeF
 		Int("failed", sourceFile.FailedRecords).
 		Msg("File processing completed")
 
+	if sourceFile.FileType != SourceFileTypeRepex {
+		s.alertLapsedLEIs(sourceFile)
+	}
+
 	return nil
 }
 
-// extractZipFile extracts the JSON file from a ZIP archive
-func (s *leiService) extractZipFile(zipPath string) (string, error) {
-	r, err := zip.OpenReader(zipPath)
-	if err != nil {
-		return "", err
-	}
-	defer r.Close()
-
-	// Find the JSON file in the ZIP
-	for _, f := range r.File {
-		if filepath.Ext(f.Name) == ".json" || filepath.Ext(f.Name) == ".jsonl" {
-			rc, err := f.Open()
-			if err != nil {
-				return "", err
-			}
-			defer rc.Close()
+// zipDataFile streams the JSON or XML data file inside a ZIP archive
+type zipDataFile struct {
+	io.Reader
+	rc io.ReadCloser
+	zr *zip.ReadCloser
+}
 
-			// Create output file
-			jsonPath := zipPath + ".extracted.json"
-			outFile, err := os.Create(jsonPath)
-			if err != nil {
-				return "", err
-			}
-			defer outFile.Close()
+func (f *zipDataFile) Close() error {
+	f.rc.Close()
+	return f.zr.Close()
+}
 
-			// Log extraction start
-			uncompressedSize := f.UncompressedSize64
-			log.Info().
-				Str("file", f.Name).
-				Uint64("size_bytes", uncompressedSize).
-				Float64("size_mb", float64(uncompressedSize)/(1024*1024)).
-				Msg("Starting file extraction from ZIP")
-
-			// Copy content with progress tracking
-			startTime := time.Now()
-			written, err := io.Copy(outFile, rc)
-			if err != nil {
-				return "", err
-			}
-			elapsed := time.Since(startTime).Seconds()
+// openZipDataFile opens the JSON or XML (per fileFormat) data file of a ZIP archive for
+// streaming, so the ~15GB uncompressed golden copy never has to be written to disk.
+// A corrupted archive surfaces as zip.ErrChecksum when the end of the entry is read.
+func openZipDataFile(zipPath, fileFormat string) (*zipDataFile, error) {
+	extensions := map[string]bool{".json": true, ".jsonl": true}
+	if fileFormat == SourceFileFormatXML {
+		extensions = map[string]bool{".xml": true}
+	}
 
-			log.Info().
-				Int64("bytes_written", written).
-				Float64("mb_written", float64(written)/(1024*1024)).
-				Float64("duration_seconds", elapsed).
-				Float64("mb_per_second", float64(written)/(1024*1024)/elapsed).
-				Msg("File extraction completed")
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, err
+	}
 
-			return jsonPath, nil
+	// Find the data file in the ZIP
+	for _, f := range zr.File {
+		if !extensions[strings.ToLower(filepath.Ext(f.Name))] {
+			continue
 		}
+		rc, err := f.Open()
+		if err != nil {
+			zr.Close()
+			return nil, err
+		}
+
+		log.Info().
+			Str("file", f.Name).
+			Uint64("size_bytes", f.UncompressedSize64).
+			Float64("size_mb", float64(f.UncompressedSize64)/(1024*1024)).
+			Msg("Streaming data file from ZIP")
+
+		return &zipDataFile{
+			Reader: bufio.NewReaderSize(rc, zipStreamBufferSize),
+			rc:     rc,
+			zr:     zr,
+		}, nil
 	}
 
-	return "", fmt.Errorf("no JSON file found in ZIP archive")
+	zr.Close()
+	return nil, fmt.Errorf("no %s file found in ZIP archive", fileFormat)
 }
 
 // FindPendingSourceFiles finds all source files that are pending or in-progress
@@ -475,29 +638,13 @@ func (s *leiService) UpdateSourceFile(file *domain.SourceFile) error {
 	return s.repo.UpdateSourceFile(file)
 }
 
-// processJSONFile parses and processes the LEI JSON file
+// processJSONFile parses and processes an LEI JSON data file
 // GLEIF JSON format: {"records": [ {...}, {...}, ... ]}
-func (s *leiService) processJSONFile(jsonPath string, sourceFile *domain.SourceFile, resumeFromLEI string) error {
-	// Get file size for progress tracking
-	fileInfo, err := os.Stat(jsonPath)
-	if err != nil {
-		return err
-	}
-	fileSize := fileInfo.Size()
-
+func (s *leiService) processJSONFile(file io.Reader, sourceFile *domain.SourceFile, resumeFromLEI string, watchdog *processingWatchdog, job *Job) error {
 	log.Info().
-		Str("file", jsonPath).
-		Int64("size_bytes", fileSize).
-		Float64("size_mb", float64(fileSize)/(1024*1024)).
 		Str("source_file_id", sourceFile.ID.String()).
 		Msg("Starting JSON file parsing")
 
-	file, err := os.Open(jsonPath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
 	// Create a JSON decoder
 	decoder := json.NewDecoder(file)
 
@@ -526,7 +673,11 @@ func (s *leiService) processJSONFile(jsonPath string, sourceFile *domain.SourceF
 				Str("source_file_id", sourceFile.ID.String()).
 				Msg("Found records array, starting record processing")
 			// Found the records array, start processing
-			return s.processRecordsArray(decoder, sourceFile, resumeFromLEI)
+			stream, err := newJSONRecordStream(decoder)
+			if err != nil {
+				return err
+			}
+			return s.processRecordsArray(stream, sourceFile, resumeFromLEI, watchdog, job)
 		}
 
 		// Skip the value for non-records keys
@@ -539,8 +690,139 @@ func (s *leiService) processJSONFile(jsonPath string, sourceFile *domain.SourceF
 	return fmt.Errorf("records array not found in JSON file")
 }
 
-// processRecordsArray processes the records array from the JSON decoder using batch processing
-func (s *leiService) processRecordsArray(decoder *json.Decoder, sourceFile *domain.SourceFile, resumeFromLEI string) (retErr error) {
+// leiRecordStream yields LEI records one at a time from a bulk file, whatever its format
+type leiRecordStream interface {
+	// More reports whether another record follows
+	More() bool
+	// Decode reads the next record; a decode error affects only that record
+	Decode(record *LEIJSONRecord) error
+	// Err returns the error that ended the stream early, if any
+	Err() error
+}
+
+// jsonRecordStream reads records from the "records" array of a JSON bulk file
+type jsonRecordStream struct {
+	decoder *json.Decoder
+}
+
+// newJSONRecordStream consumes the opening bracket of the records array
+func newJSONRecordStream(decoder *json.Decoder) (*jsonRecordStream, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read array opening: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("expected '[', got %v", token)
+	}
+	return &jsonRecordStream{decoder: decoder}, nil
+}
+
+func (j *jsonRecordStream) More() bool                         { return j.decoder.More() }
+func (j *jsonRecordStream) Decode(record *LEIJSONRecord) error { return j.decoder.Decode(record) }
+func (j *jsonRecordStream) Err() error                         { return nil }
+
+// leiUpsertBatch is a batch of decoded records handed to an upsert worker
+type leiUpsertBatch struct {
+	seq            int // position in the file; batches are checkpointed in this order
+	records        []*domain.LEIRecord
+	rejected       []*domain.LEIQuarantinedRecord // records that failed data quality rules
+	firstLEI       string                         // first and last LEI read into the batch, loaded or rejected
+	lastLEI        string
+	scanned        int // records counted up to and including this batch
+	decodeFailures int // records that failed to decode up to this batch
+	// Set by leiWriteOrder: the earlier batches sharing an LEI with this one, which must land
+	// first, and the channel closed once this one has
+	after  []<-chan struct{}
+	landed chan struct{}
+}
+
+// leis returns the LEIs of the batch, loaded or rejected
+func (b *leiUpsertBatch) leis() []string {
+	leis := make([]string, 0, len(b.records)+len(b.rejected))
+	for _, record := range b.records {
+		leis = append(leis, record.LEI)
+	}
+	for _, record := range b.rejected {
+		leis = append(leis, record.LEI)
+	}
+	return leis
+}
+
+// leiWriteOrder keeps the writes to an LEI in file order while batches are loaded concurrently.
+// The decoder claims each batch as it hands it over, in file order, and a worker waits for the
+// earlier batches still in flight that share an LEI with its batch before loading it, so a record
+// listed twice in a file is left as its later listing says.
+type leiWriteOrder struct {
+	mu      sync.Mutex
+	holders map[string]*leiUpsertBatch // the last batch claimed with each LEI, until it lands
+}
+
+func newLEIWriteOrder() *leiWriteOrder {
+	return &leiWriteOrder{holders: make(map[string]*leiUpsertBatch)}
+}
+
+// claim records the batch as the last holder of its LEIs, after their previous holders. Waiting for
+// those is enough: each waits in turn for the holders before it.
+func (o *leiWriteOrder) claim(batch *leiUpsertBatch) {
+	batch.landed = make(chan struct{})
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	waiting := make(map[*leiUpsertBatch]bool)
+	for _, lei := range batch.leis() {
+		if holder, ok := o.holders[lei]; ok && holder != batch && !waiting[holder] {
+			waiting[holder] = true
+			batch.after = append(batch.after, holder.landed)
+		}
+		o.holders[lei] = batch
+	}
+}
+
+// wait blocks until the batches claimed before batch with its LEIs have landed; false once stop is
+// closed, when batch is not to be loaded
+func (o *leiWriteOrder) wait(batch *leiUpsertBatch, stop <-chan struct{}) bool {
+	for _, landed := range batch.after {
+		select {
+		case <-landed:
+		case <-stop:
+			return false
+		}
+	}
+	select {
+	case <-stop:
+		return false
+	default:
+		return true
+	}
+}
+
+// release marks the batch landed, loaded or given up, so the batches waiting for it can go
+func (o *leiWriteOrder) release(batch *leiUpsertBatch) {
+	o.mu.Lock()
+	for _, lei := range batch.leis() {
+		if o.holders[lei] == batch {
+			delete(o.holders, lei)
+		}
+	}
+	o.mu.Unlock()
+	close(batch.landed)
+}
+
+// leiUpsertResult is a worker's outcome for one batch
+type leiUpsertResult struct {
+	batch       *leiUpsertBatch
+	created     int
+	updated     int
+	quarantined int
+	err         error
+}
+
+// processRecordsArray processes the records of a bulk file using batch processing
+// One goroutine decodes records into batches, limits.Workers goroutines upsert batches concurrently,
+// and this goroutine checkpoints them in file order: LastProcessedLEI only advances past a batch once
+// every batch before it has landed, so a resume never skips records. Batches that landed after the
+// checkpoint are upserted again on resume, which is harmless. Batches sharing an LEI are loaded one
+// after the other, in file order (see leiWriteOrder).
+func (s *leiService) processRecordsArray(stream leiRecordStream, sourceFile *domain.SourceFile, resumeFromLEI string, watchdog *processingWatchdog, job *Job) (retErr error) {
 	// Panic recovery to catch any unhandled errors
 	defer func() {
 		if r := recover(); r != nil {
@@ -549,24 +831,21 @@ func (s *leiService) processRecordsArray(decoder *json.Decoder, sourceFile *doma
 		}
 	}()
 
-	// Read the opening bracket of the records array
-	token, err := decoder.Token()
-	if err != nil {
-		return fmt.Errorf("failed to read array opening: %w", err)
-	}
-	if delim, ok := token.(json.Delim); !ok || delim != '[' {
-		return fmt.Errorf("expected '[', got %v", token)
+	// Full files are COPYed into a staging table and merged once every batch has landed
+	bulkLoad := s.bulkLoads(sourceFile)
+	if bulkLoad {
+		var err error
+		if resumeFromLEI, err = s.prepareBulkLoad(sourceFile, resumeFromLEI); err != nil {
+			return err
+		}
 	}
 
 	// Start counters based on whether we're resuming or starting fresh
-	var totalRecords int
 	var processedRecords int
-	var failedRecords int
-	var shouldProcess bool = (resumeFromLEI == "")
 	var lastProcessedLEI string
 
 	// Track checkpoint value separately from session progress
-	var checkpointProcessed int = 0
+	var checkpointProcessed, startingTotal, startingFailed int
 
 	// Only load existing progress if resuming an interrupted file
 	// If starting fresh, reset all counters to avoid accumulation on reprocessing
@@ -574,15 +853,12 @@ func (s *leiService) processRecordsArray(decoder *json.Decoder, sourceFile *doma
 		// Resuming: initialize totalRecords at checkpoint to account for skipped records
 		// processedRecords tracks only NEW records processed in this session
 		checkpointProcessed = sourceFile.ProcessedRecords
-		totalRecords = sourceFile.ProcessedRecords // Start counting from checkpoint
-		processedRecords = 0                       // Track only new records in this session
-		failedRecords = sourceFile.FailedRecords
-	} else {
-		// Starting fresh: reset all counters
-		totalRecords = 0
-		processedRecords = 0
-		failedRecords = 0
+		startingTotal = sourceFile.ProcessedRecords // Start counting from checkpoint
+		startingFailed = sourceFile.FailedRecords
 	}
+	totalRecords := startingTotal // records scanned so far, as of the last checkpointed batch
+	failedRecords := startingFailed
+	workers := max(s.limits.Workers, 1)
 
 	log.Info().
 		Int("starting_total", totalRecords).
@@ -591,135 +867,309 @@ func (s *leiService) processRecordsArray(decoder *json.Decoder, sourceFile *doma
 		Int("starting_failed", failedRecords).
 		Str("resume_from", resumeFromLEI).
 		Bool("is_resume", resumeFromLEI != "").
+		Int("workers", workers).
+		Bool("bulk_load", bulkLoad).
 		Msg("Starting array processing with counters")
 
-	// Start heartbeat ticker for progress monitoring (every 15 seconds)
+	// Heartbeat ticker for progress monitoring (every 15 seconds)
 	heartbeatTicker := time.NewTicker(15 * time.Second)
 	defer heartbeatTicker.Stop()
 	lastHeartbeatTime := time.Now()
 	lastHeartbeatProcessed := processedRecords
 
-	// Goroutine for periodic heartbeat logging
+	logHeartbeat := func() {
+		elapsed := time.Since(lastHeartbeatTime).Seconds()
+		recordsSinceLastHeartbeat := processedRecords - lastHeartbeatProcessed
+		rate := float64(recordsSinceLastHeartbeat) / elapsed
+
+		cumulativeProcessed := checkpointProcessed + processedRecords
+		remainingRecords := totalRecords - cumulativeProcessed
+		etaSeconds := 0.0
+		if rate > 0 {
+			etaSeconds = float64(remainingRecords) / rate
+		}
+
+		percentComplete := 0.0
+		if totalRecords > 0 {
+			percentComplete = (float64(cumulativeProcessed) / float64(totalRecords)) * 100
+		}
+
+		log.Info().
+			Int("total_records", totalRecords).
+			Int("checkpoint_processed", checkpointProcessed).
+			Int("session_processed", processedRecords).
+			Int("cumulative_processed", cumulativeProcessed).
+			Int("failed_records", failedRecords).
+			Float64("percent_complete", percentComplete).
+			Float64("records_per_sec", rate).
+			Float64("eta_seconds", etaSeconds).
+			Str("last_lei", lastProcessedLEI).
+			Int("workers", workers).
+			Msg("HEARTBEAT: LEI import in progress")
+
+		s.progress.publish(SourceFileProgress{
+			SourceFileID:     sourceFile.ID,
+			Status:           "IN_PROGRESS",
+			Stage:            "processing",
+			TotalRecords:     totalRecords,
+			ProcessedRecords: cumulativeProcessed,
+			FailedRecords:    failedRecords,
+			PercentComplete:  percentComplete,
+			RecordsPerSecond: rate,
+			ETASeconds:       etaSeconds,
+			LastLEI:          lastProcessedLEI,
+		})
+
+		lastHeartbeatTime = time.Now()
+		lastHeartbeatProcessed = processedRecords
+	}
+
+	work := make(chan *leiUpsertBatch, workers)
+	results := make(chan *leiUpsertResult, workers)
+	// stop tells the decoder and workers to give up once a batch has failed
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	stopAll := func() { stopOnce.Do(func() { close(stop) }) }
+	defer stopAll()
+
+	// Decoder: reads the stream into batches, setting aside records that fail data quality rules
+	rules := s.loadQualityRules()
+	metrics := s.newQualityMetrics()
+	order := newLEIWriteOrder()
+	var scanned int // written by the decoder; read once work is closed
+	var decodeErr error
 	go func() {
-		for range heartbeatTicker.C {
-			elapsed := time.Since(lastHeartbeatTime).Seconds()
-			recordsSinceLastHeartbeat := processedRecords - lastHeartbeatProcessed
-			rate := float64(recordsSinceLastHeartbeat) / elapsed
-
-			cumulativeProcessed := checkpointProcessed + processedRecords
-			remainingRecords := totalRecords - cumulativeProcessed
-			etaSeconds := 0.0
-			if rate > 0 {
-				etaSeconds = float64(remainingRecords) / rate
+		defer close(work)
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error().Interface("panic", r).Str("source_file_id", sourceFile.ID.String()).Msg("PANIC decoding LEI records")
+				decodeErr = fmt.Errorf("panic during processing: %v", r)
 			}
+		}()
+		scanned, decodeErr = s.decodeRecordBatches(stream, sourceFile, resumeFromLEI, startingTotal, rules, metrics, watchdog, order, work, stop)
+	}()
 
-			percentComplete := 0.0
-			if totalRecords > 0 {
-				percentComplete = (float64(cumulativeProcessed) / float64(totalRecords)) * 100
+	// Upsert workers (staging workers for a bulk load)
+	load := s.upsertRecordBatch
+	if bulkLoad {
+		load = s.stageRecordBatch
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range work {
+				if !order.wait(batch, stop) {
+					order.release(batch)
+					continue // drain without upserting
+				}
+				result := s.loadScreenedBatch(batch, load)
+				order.release(batch)
+				results <- result
 			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 
-			log.Info().
-				Int("total_records", totalRecords).
-				Int("checkpoint_processed", checkpointProcessed).
-				Int("session_processed", processedRecords).
-				Int("cumulative_processed", cumulativeProcessed).
-				Int("failed_records", failedRecords).
-				Float64("percent_complete", percentComplete).
-				Float64("records_per_sec", rate).
-				Float64("eta_seconds", etaSeconds).
-				Str("last_lei", lastProcessedLEI).
-				Msg("HEARTBEAT: LEI import in progress")
-
-			lastHeartbeatTime = time.Now()
-			lastHeartbeatProcessed = processedRecords
+	// commit checkpoints a batch whose predecessors have all landed
+	quarantinedRecords := 0
+	commit := func(result *leiUpsertResult) error {
+		batch := result.batch
+		quarantinedRecords += result.quarantined
+		failedRecords = startingFailed + batch.decodeFailures + quarantinedRecords
+		totalRecords = batch.scanned
+
+		if result.err != nil {
+			log.Error().
+				Err(result.err).
+				Int("batch_size", len(batch.records)).
+				Str("first_lei", batch.firstLEI).
+				Str("last_lei", batch.lastLEI).
+				Msg("CRITICAL: Failed to batch upsert LEI records")
+			failedRecords += len(batch.records)
+			// Return error to stop processing
+			return fmt.Errorf("batch upsert failed: %w", result.err)
 		}
-	}()
 
-	const batchSize = 1000
-	batch := make([]*domain.LEIRecord, 0, batchSize)
+		// Track records processed in this session (use batch size, not DB results)
+		processedRecords += len(batch.records)
+		lastProcessedLEI = batch.lastLEI
+		watchdog.touch()
 
-	// flushBatch processes accumulated records using batch upsert
-	flushBatch := func() error {
-		if len(batch) == 0 {
-			return nil
+		// Once the watchdog has aborted a stalled file, its status belongs to the abort handler
+		if err := watchdog.err(); errors.Is(err, ErrProcessingStalled) {
+			return err
 		}
 
-		// Calculate progress for flush message
+		// Update source file with cumulative progress
 		cumulativeProcessed := checkpointProcessed + processedRecords
-		flushPercent := 0.0
+		sourceFile.TotalRecords = totalRecords
+		sourceFile.ProcessedRecords = cumulativeProcessed
+		sourceFile.FailedRecords = failedRecords
+		sourceFile.LastProcessedLEI = lastProcessedLEI
+		if err := s.repo.UpdateSourceFile(sourceFile); err != nil {
+			log.Error().Err(err).Msg("Failed to update source file progress")
+		}
+		job.SetProgress(int64(cumulativeProcessed), 0) // the record count is only known once the file has been read
+		s.events.publish(LEIStatusEvent{Type: LEIEventBatchFlushed, Batch: &LEIBatchProgress{
+			SourceFileID:     sourceFile.ID,
+			Batch:            batch.seq,
+			Records:          len(batch.records),
+			Created:          result.created,
+			Updated:          result.updated,
+			Quarantined:      result.quarantined,
+			TotalRecords:     totalRecords,
+			ProcessedRecords: cumulativeProcessed,
+			FailedRecords:    failedRecords,
+			LastLEI:          lastProcessedLEI,
+		}})
+
+		// Calculate progress percentage
+		percentComplete := 0.0
 		if totalRecords > 0 {
-			flushPercent = (float64(cumulativeProcessed) / float64(totalRecords)) * 100
+			percentComplete = (float64(cumulativeProcessed) / float64(totalRecords)) * 100
 		}
 
 		log.Info().
-			Int("batch_size", len(batch)).
-			Int("checkpoint_processed", checkpointProcessed).
-			Int("session_processed", processedRecords).
+			Int("total_scanned", totalRecords).
 			Int("cumulative_processed", cumulativeProcessed).
-			Int("total_records", totalRecords).
-			Float64("percent_complete", flushPercent).
+			Int("session_processed", processedRecords).
+			Int("created", result.created).
+			Int("updated", result.updated).
+			Int("failed", failedRecords).
+			Float64("percent_complete", percentComplete).
 			Str("last_lei", lastProcessedLEI).
-			Msg("Flushing batch to database")
+			Msg("Batch processing progress")
 
-		created, updated, err := s.repo.BatchUpsertLEIRecords(batch)
-		if err != nil {
-			log.Error().
-				Err(err).
-				Int("batch_size", len(batch)).
-				Str("first_lei", batch[0].LEI).
-				Str("last_lei", batch[len(batch)-1].LEI).
-				Msg("CRITICAL: Failed to batch upsert LEI records")
-			failedRecords += len(batch)
-			// Return error to stop processing
-			return fmt.Errorf("batch upsert failed: %w", err)
-		} else {
-			// Track records processed in this session (use batch size, not DB results)
-			processedRecords += len(batch)
-
-			// Update source file with cumulative progress
-			cumulativeProcessed = checkpointProcessed + processedRecords
-			sourceFile.TotalRecords = totalRecords
-			sourceFile.ProcessedRecords = cumulativeProcessed
-			sourceFile.FailedRecords = failedRecords
-			sourceFile.LastProcessedLEI = lastProcessedLEI
-			if err := s.repo.UpdateSourceFile(sourceFile); err != nil {
-				log.Error().Err(err).Msg("Failed to update source file progress")
-			}
+		// On shutdown, stop at the checkpoint just saved
+		return watchdog.err()
+	}
 
-			// Calculate progress percentage
-			percentComplete := 0.0
-			if totalRecords > 0 {
-				percentComplete = (float64(cumulativeProcessed) / float64(totalRecords)) * 100
+	// Checkpoint results in file order as they arrive
+	var commitErr error
+	pending := make(map[int]*leiUpsertResult)
+	next := 0
+	for results != nil {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				results = nil
+				break
 			}
+			pending[result.batch.seq] = result
+			for ready, found := pending[next]; found; ready, found = pending[next] {
+				delete(pending, next)
+				next++
+				if commitErr != nil {
+					continue // nothing after a failed batch is checkpointed
+				}
+				if err := commit(ready); err != nil {
+					commitErr = err
+					stopAll()
+				}
+			}
+		case <-heartbeatTicker.C:
+			logHeartbeat()
+		}
+	}
+	if commitErr != nil {
+		return commitErr
+	}
 
-			log.Info().
-				Int("total_scanned", totalRecords).
-				Int("cumulative_processed", cumulativeProcessed).
-				Int("session_processed", processedRecords).
-				Int("created", created).
-				Int("updated", updated).
-				Int("failed", failedRecords).
-				Float64("percent_complete", percentComplete).
-				Str("last_lei", lastProcessedLEI).
-				Msg("Batch processing progress")
+	// A truncated or malformed file fails after the records read so far are checkpointed
+	if decodeErr != nil {
+		return decodeErr
+	}
+	totalRecords = scanned
+
+	if err := watchdog.err(); err != nil {
+		return err
+	}
+
+	if bulkLoad {
+		if err := s.mergeStagedRecords(sourceFile, watchdog, job); err != nil {
+			return err
+		}
+		// Once merged the file is loaded, so shutting down now need not interrupt it
+		if err := watchdog.err(); errors.Is(err, ErrProcessingStalled) {
+			return err
 		}
+	}
 
-		// Clear batch for next iteration
-		batch = make([]*domain.LEIRecord, 0, batchSize)
-		return nil
+	// Final update
+	cumulativeProcessed := checkpointProcessed + processedRecords
+	sourceFile.TotalRecords = totalRecords
+	sourceFile.ProcessedRecords = cumulativeProcessed
+	sourceFile.FailedRecords = failedRecords
+	if err := s.repo.UpdateSourceFile(sourceFile); err != nil {
+		log.Error().Err(err).Msg("Failed to update final source file status")
+	}
+
+	log.Info().
+		Int("total_records", totalRecords).
+		Int("checkpoint_processed", checkpointProcessed).
+		Int("session_processed", processedRecords).
+		Int("cumulative_processed", cumulativeProcessed).
+		Int("total_failed", failedRecords).
+		Msg("File processing completed")
+
+	s.saveQualityMetrics(sourceFile, metrics)
+
+	return nil
+}
+
+// decodeRecordBatches reads records from the stream, skipping up to resumeFromLEI, and sends them
+// to work in batches, each claimed in order. It returns the number of records counted (starting
+// from startingTotal) and the error that ended the stream early, if any. It gives up when stop is
+// closed.
+func (s *leiService) decodeRecordBatches(stream leiRecordStream, sourceFile *domain.SourceFile, resumeFromLEI string, startingTotal int, rules *leiQualityRules, metrics *leiQualityMetrics, watchdog *processingWatchdog, order *leiWriteOrder, work chan<- *leiUpsertBatch, stop <-chan struct{}) (int, error) {
+	const batchSize = 1000
+	// Scanning past already-processed records to the checkpoint counts as progress for the watchdog
+	const resumeScanProgressInterval = 10000
+
+	shouldProcess := resumeFromLEI == ""
+	totalRecords := startingTotal
+	decodeFailures := 0
+	seq := 0
+	batch := make([]*domain.LEIRecord, 0, batchSize)
+	var rejected []*domain.LEIQuarantinedRecord
+	var firstLEI, lastLEI string
+
+	send := func() bool {
+		if len(batch) == 0 && len(rejected) == 0 {
+			return true
+		}
+		next := &leiUpsertBatch{seq: seq, records: batch, rejected: rejected, firstLEI: firstLEI, lastLEI: lastLEI, scanned: totalRecords, decodeFailures: decodeFailures}
+		order.claim(next)
+		select {
+		case work <- next:
+			seq++
+			batch = make([]*domain.LEIRecord, 0, batchSize)
+			rejected = nil
+			return true
+		case <-stop:
+			return false
+		}
 	}
 
 	// Process each record in the array
 	recordCount := 0
-	for decoder.More() {
+	for stream.More() {
+		if err := watchdog.err(); err != nil {
+			return totalRecords, err
+		}
 		recordCount++
 		var jsonRecord LEIJSONRecord
-		if err := decoder.Decode(&jsonRecord); err != nil {
+		if err := stream.Decode(&jsonRecord); err != nil {
 			log.Error().
 				Err(err).
 				Int("record_number", recordCount).
-				Msg("Failed to decode LEI JSON record")
-			failedRecords++
+				Msg("Failed to decode LEI record")
+			decodeFailures++
 			continue
 		}
 
@@ -737,6 +1187,9 @@ func (s *leiService) processRecordsArray(decoder *json.Decoder, sourceFile *doma
 			} else {
 				// Scanning to find resume point - skip record
 				// Don't increment totalRecords during skip phase (already counted in checkpoint)
+				if recordCount%resumeScanProgressInterval == 0 {
+					watchdog.touch()
+				}
 				continue
 			}
 		}
@@ -744,44 +1197,67 @@ func (s *leiService) processRecordsArray(decoder *json.Decoder, sourceFile *doma
 		// Count records only after we start processing (or if not resuming)
 		totalRecords++
 
-		// Convert JSON record to domain model
+		// Convert JSON record to domain model and add to batch, or set it aside for quarantine
 		record := s.jsonToDomainRecord(&jsonRecord, sourceFile.ID)
-		lastProcessedLEI = record.LEI
-
-		// Add to batch
-		batch = append(batch, record)
+		if len(batch) == 0 && len(rejected) == 0 {
+			firstLEI = record.LEI
+		}
+		lastLEI = record.LEI
+		metrics.add(record)
+		if failures := rules.evaluate(record); len(failures) > 0 {
+			quarantined := quarantineRecord(record, failures)
+			log.Warn().
+				Str("lei", record.LEI).
+				Str("rules", quarantined.RuleCodes).
+				Msg("Quarantining LEI record that failed data quality rules")
+			rejected = append(rejected, quarantined)
+		} else {
+			batch = append(batch, record)
+		}
 
-		// Flush batch when it reaches batch size
-		if len(batch) >= batchSize {
-			if err := flushBatch(); err != nil {
-				return err
-			}
+		// Hand the batch to a worker when it reaches batch size
+		if len(batch)+len(rejected) >= batchSize && !send() {
+			return totalRecords, nil
 		}
 	}
 
-	// Flush any remaining records in the batch
-	if err := flushBatch(); err != nil {
-		return err
-	}
+	// Hand over any remaining records
+	send()
 
-	// Final update
-	cumulativeProcessed := checkpointProcessed + processedRecords
-	sourceFile.TotalRecords = totalRecords
-	sourceFile.ProcessedRecords = cumulativeProcessed
-	sourceFile.FailedRecords = failedRecords
-	if err := s.repo.UpdateSourceFile(sourceFile); err != nil {
-		log.Error().Err(err).Msg("Failed to update final source file status")
-	}
+	return totalRecords, stream.Err()
+}
+
+// upsertRecordBatch writes a batch of records, isolating rejected records if the batch fails
+func (s *leiService) upsertRecordBatch(batch *leiUpsertBatch) (result *leiUpsertResult) {
+	result = &leiUpsertResult{batch: batch}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Interface("panic", r).Int("batch", batch.seq).Msg("PANIC upserting LEI batch")
+			result.err = fmt.Errorf("panic during processing: %v", r)
+		}
+	}()
 
 	log.Info().
-		Int("total_records", totalRecords).
-		Int("checkpoint_processed", checkpointProcessed).
-		Int("session_processed", processedRecords).
-		Int("cumulative_processed", cumulativeProcessed).
-		Int("total_failed", failedRecords).
-		Msg("File processing completed")
+		Int("batch", batch.seq).
+		Int("batch_size", len(batch.records)).
+		Str("first_lei", batch.records[0].LEI).
+		Str("last_lei", batch.records[len(batch.records)-1].LEI).
+		Msg("Flushing batch to database")
 
-	return nil
+	created, updated, err := s.repo.BatchUpsertLEIRecords(context.Background(), batch.records)
+	if err != nil {
+		// A single poison record fails the whole batch; retry with savepoint bisection so only
+		// the rejected records are quarantined and the rest of the batch still lands
+		log.Warn().
+			Err(err).
+			Int("batch_size", len(batch.records)).
+			Msg("Batch upsert failed, retrying with record isolation")
+		var quarantined []*domain.LEIQuarantinedRecord
+		created, updated, quarantined, err = s.repo.BatchUpsertLEIRecordsIsolating(context.Background(), batch.records)
+		result.quarantined = len(quarantined)
+	}
+	result.created, result.updated, result.err = created, updated, err
+	return result
 }
 
 // extractLEI extracts the LEI string from a JSON record (handles nested $ structure)
@@ -882,6 +1358,7 @@ func (s *leiService) jsonToDomainRecord(jsonRecord *LEIJSONRecord, sourceFileID
 		EntityCategory:         jsonRecord.Entity.EntityCategory.Value,
 		EntityLegalForm:        jsonRecord.Entity.LegalForm.EntityLegalFormCode.Value,
 		EntityStatus:           jsonRecord.Entity.EntityStatus.Value,
+		RegistrationStatus:     jsonRecord.Registration.RegistrationStatus.Value,
 		ManagingLOU:            jsonRecord.Registration.ManagingLOU.Value,
 		SourceFileID:           &sourceFileID,
 		// Initialize JSONB fields with valid JSON
@@ -994,9 +1471,30 @@ func (s *leiService) GetAllLEI(limit, offset int) ([]*domain.LEIRecord, error) {
 	return s.repo.FindAllLEI(limit, offset)
 }
 
+// FuzzySearchLEI ranks LEI records by trigram similarity to the given name
+func (s *leiService) FuzzySearchLEI(name string, threshold float64, country string, limit int) ([]*domain.LEIFuzzyMatch, error) {
+	return s.repo.FuzzySearchLEI(strings.TrimSpace(name), threshold, strings.ToUpper(country), limit)
+}
+
 // GetAllLEIWithFilters retrieves LEI records with search and filters
-func (s *leiService) GetAllLEIWithFilters(limit, offset int, search, status, category, country, sortBy, sortOrder string) ([]*domain.LEIRecord, error) {
-	return s.repo.FindAllLEIWithFilters(limit, offset, search, status, category, country, sortBy, sortOrder)
+func (s *leiService) GetAllLEIWithFilters(limit, offset int, filter repository.LEIFilter) ([]*domain.LEIRecord, error) {
+	return s.repo.FindAllLEIWithFilters(limit, offset, filter)
+}
+
+// LEIListFilter reads the search, filters and sort order of GET /lei from its query parameters.
+// Without a sort, records are in name order, or ranked by relevance for a full-text query.
+func LEIListFilter(values url.Values) repository.LEIFilter {
+	return repository.LEIFilter{
+		Search:      values.Get("search"),
+		TextQuery:   values.Get("q"),
+		Status:      values.Get("status"),
+		Category:    values.Get("category"),
+		Country:     values.Get("country"),
+		LegalForm:   strings.TrimSpace(values.Get("legalForm")),
+		ManagingLOU: strings.TrimSpace(values.Get("lou")),
+		SortBy:      values.Get("sortBy"),
+		SortOrder:   values.Get("sortOrder"),
+	}
 }
 
 // CountLEIRecords returns the total count of LEI records
@@ -1033,110 +1531,101 @@ func (s *leiService) GetAuditHistory(lei string, limit int) ([]*domain.LEIRecord
 	return s.repo.FindAuditHistoryByLEI(lei, limit)
 }
 
+// ListQuarantinedRecords lists records kept out of lei_records, by the database or data quality rules
+func (s *leiService) ListQuarantinedRecords(filter repository.LEIQuarantineFilter, limit, offset int) ([]*domain.LEIQuarantinedRecord, int64, error) {
+	return s.repo.FindQuarantinedRecords(filter, limit, offset)
+}
+
 // GetProcessingStatus retrieves processing status for a job type
 func (s *leiService) GetProcessingStatus(jobType string) (*domain.FileProcessingStatus, error) {
 	return s.repo.FindProcessingStatus(jobType)
 }
 
-// UpdateProcessingStatus updates processing status
+// UpdateProcessingStatus updates processing status, notifying status subscribers of transitions
 func (s *leiService) UpdateProcessingStatus(status *domain.FileProcessingStatus) error {
-	return s.repo.UpdateProcessingStatus(status)
+	if err := s.repo.UpdateProcessingStatus(status); err != nil {
+		return err
+	}
+	s.publishStatusTransition(status)
+	return nil
 }
 
 // CleanupOldFiles removes old LEI files to free disk space
-// Keeps the most recent N full files and N delta files
+// Keeps the most recent N full files and N delta files; with an archive, older files are
+// archived before they are removed
 func (s *leiService) CleanupOldFiles(keepFullFiles, keepDeltaFiles int) error {
 	log.Info().
 		Int("keep_full", keepFullFiles).
 		Int("keep_delta", keepDeltaFiles).
 		Msg("Starting LEI file cleanup")
 
-	// Read all files in data directory
-	files, err := os.ReadDir(s.dataDir)
+	removeStalePartialDownloads(filepath.Join(s.dataDir, partialDownloadDir))
+
+	// List all files in storage
+	ctx := context.Background()
+	files, err := s.store.List(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to read data directory: %w", err)
+		return fmt.Errorf("failed to list stored files: %w", err)
 	}
 
 	// Separate files by type
-	var fullFiles, deltaFiles []os.DirEntry
+	var fullFiles, deltaFiles []storage.Object
 	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-		name := file.Name()
-		if strings.Contains(name, "FULL") {
+		if strings.Contains(file.Key, "FULL") {
 			fullFiles = append(fullFiles, file)
-		} else if strings.Contains(name, "DELTA") {
+		} else if strings.Contains(file.Key, "DELTA") {
 			deltaFiles = append(deltaFiles, file)
 		}
 	}
 
 	// Sort by modification time (newest first)
-	sortByModTimeDesc := func(files []os.DirEntry) {
+	sortByModTimeDesc := func(files []storage.Object) {
 		sort.Slice(files, func(i, j int) bool {
-			infoI, _ := files[i].Info()
-			infoJ, _ := files[j].Info()
-			return infoI.ModTime().After(infoJ.ModTime())
+			return files[i].ModTime.After(files[j].ModTime)
 		})
 	}
 
 	sortByModTimeDesc(fullFiles)
 	sortByModTimeDesc(deltaFiles)
 
-	// Remove old full files
-	removedCount := 0
+	// Remove old files beyond the number to keep
 	var totalSize int64
-
-	for i, file := range fullFiles {
-		if i < keepFullFiles {
-			continue // Keep recent files
-		}
-		filePath := filepath.Join(s.dataDir, file.Name())
-		info, err := file.Info()
-		if err != nil {
-			log.Warn().Err(err).Str("file", file.Name()).Msg("Failed to get file info")
-			continue
-		}
-		if err := os.Remove(filePath); err != nil {
-			log.Warn().Err(err).Str("file", file.Name()).Msg("Failed to remove old file")
-		} else {
-			log.Info().
-				Str("file", file.Name()).
-				Int64("size_mb", info.Size()/1024/1024).
-				Msg("Removed old full file")
-			removedCount++
-			totalSize += info.Size()
-		}
-	}
-
-	// Remove old delta files
-	for i, file := range deltaFiles {
-		if i < keepDeltaFiles {
-			continue // Keep recent files
-		}
-		filePath := filepath.Join(s.dataDir, file.Name())
-		info, err := file.Info()
-		if err != nil {
-			log.Warn().Err(err).Str("file", file.Name()).Msg("Failed to get file info")
-			continue
-		}
-		if err := os.Remove(filePath); err != nil {
-			log.Warn().Err(err).Str("file", file.Name()).Msg("Failed to remove old file")
-		} else {
+	removeOld := func(files []storage.Object, keep int, label string) int {
+		removed := 0
+		for i, file := range files {
+			if i < keep {
+				continue // Keep recent files
+			}
+			if s.archive != nil {
+				// A file that could not be archived is kept for the next cleanup
+				if err := s.archiveFile(ctx, file.Key); err != nil {
+					log.Warn().Err(err).Str("file", file.Key).Msg("Failed to archive old file")
+					continue
+				}
+			}
+			if err := s.store.Delete(ctx, file.Key); err != nil {
+				log.Warn().Err(err).Str("file", file.Key).Msg("Failed to remove old file")
+				continue
+			}
 			log.Info().
-				Str("file", file.Name()).
-				Int64("size_mb", info.Size()/1024/1024).
-				Msg("Removed old delta file")
-			removedCount++
-			totalSize += info.Size()
+				Str("file", file.Key).
+				Str("storage", s.store.Backend()).
+				Int64("size_mb", file.Size/1024/1024).
+				Msgf("Removed old %s file", label)
+			removed++
+			totalSize += file.Size
 		}
+		return removed
 	}
+	removedFull := removeOld(fullFiles, keepFullFiles, "full")
+	removedDelta := removeOld(deltaFiles, keepDeltaFiles, "delta")
+	removedCount := removedFull + removedDelta
 
 	log.Info().
 		Int("removed_count", removedCount).
 		Int64("freed_mb", totalSize/1024/1024).
-		Int("full_remaining", len(fullFiles)-removedCount).
-		Int("delta_remaining", len(deltaFiles)).
+		Int("full_remaining", len(fullFiles)-removedFull).
+		Int("delta_remaining", len(deltaFiles)-removedDelta).
 		Msg("Cleanup completed successfully")
 
 	return nil
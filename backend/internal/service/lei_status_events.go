@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"gorm.io/gorm"
+)
+
+// LEI status event types sent to subscribers
+const (
+	LEIEventProcessingStatus = "processing_status" // a sync job's FileProcessingStatus changed
+	LEIEventBatchFlushed     = "batch_flushed"     // a batch of a source file was checkpointed
+)
+
+const leiStatusSubscriberBuffer = 256
+
+// processingStatusJobTypes are the FileProcessingStatus rows maintained by the scheduler
+var processingStatusJobTypes = []string{"DAILY_FULL", "DAILY_DELTA", "REPEX"}
+
+// LEIStatusEvent is a sync status change or processing progress update
+type LEIStatusEvent struct {
+	Type             string                       `json:"type"`
+	ProcessingStatus *domain.FileProcessingStatus `json:"processing_status,omitempty"` // processing_status events
+	Batch            *LEIBatchProgress            `json:"batch,omitempty"`             // batch_flushed events
+	Time             time.Time                    `json:"time"`
+}
+
+// LEIBatchProgress is a source file's progress after one of its batches was checkpointed
+type LEIBatchProgress struct {
+	SourceFileID     uuid.UUID `json:"source_file_id"`
+	Batch            int       `json:"batch"` // position of the batch in the file, from 0
+	Records          int       `json:"records"`
+	Created          int       `json:"created"` // 0 for full files, whose records are merged at the end
+	Updated          int       `json:"updated"`
+	Quarantined      int       `json:"quarantined"`
+	TotalRecords     int       `json:"total_records"` // records scanned so far
+	ProcessedRecords int       `json:"processed_records"`
+	FailedRecords    int       `json:"failed_records"`
+	LastLEI          string    `json:"last_lei"`
+}
+
+// leiStatusHub fans out LEI status events to subscribers, dropping events for subscribers that
+// fall behind rather than blocking file processing
+type leiStatusHub struct {
+	mu          sync.Mutex
+	subscribers map[chan LEIStatusEvent]struct{}
+	// Last status published per job type, so only transitions are sent
+	statuses map[string]string
+}
+
+func newLEIStatusHub() *leiStatusHub {
+	return &leiStatusHub{
+		subscribers: make(map[chan LEIStatusEvent]struct{}),
+		statuses:    make(map[string]string),
+	}
+}
+
+func (h *leiStatusHub) publish(event LEIStatusEvent) {
+	event.Time = time.Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// transition reports whether status differs from the last one published for its job type, and
+// records it as published
+func (h *leiStatusHub) transition(status *domain.FileProcessingStatus) bool {
+	key := status.Status
+	if status.CurrentSourceFileID != nil {
+		key += "/" + status.CurrentSourceFileID.String()
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.statuses[status.JobType] == key {
+		return false
+	}
+	h.statuses[status.JobType] = key
+	return true
+}
+
+func (h *leiStatusHub) subscribe() (<-chan LEIStatusEvent, func()) {
+	ch := make(chan LEIStatusEvent, leiStatusSubscriberBuffer)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers, ch)
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// SubscribeStatusEvents returns a channel of processing status transitions and batch progress, and
+// a function that cancels the subscription
+func (s *leiService) SubscribeStatusEvents() (<-chan LEIStatusEvent, func()) {
+	return s.events.subscribe()
+}
+
+// ListProcessingStatuses returns the processing status of every sync job that has run
+func (s *leiService) ListProcessingStatuses() ([]*domain.FileProcessingStatus, error) {
+	statuses := make([]*domain.FileProcessingStatus, 0, len(processingStatusJobTypes))
+	for _, jobType := range processingStatusJobTypes {
+		status, err := s.repo.FindProcessingStatus(jobType)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// publishStatusTransition sends a job's status to subscribers if it has changed since the last one
+// sent, and alerts operators when it has become FAILED. The stored status is read back so its
+// current source file is included.
+func (s *leiService) publishStatusTransition(status *domain.FileProcessingStatus) {
+	if !s.events.transition(status) {
+		return
+	}
+	if stored, err := s.repo.FindProcessingStatus(status.JobType); err == nil {
+		status = stored
+	}
+	s.events.publish(LEIStatusEvent{Type: LEIEventProcessingStatus, ProcessingStatus: status})
+	if status.Status == "FAILED" {
+		s.notifySyncFailed(status)
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// FailureCategoryStalled marks source files aborted by the processing watchdog
+const FailureCategoryStalled = "STALLED"
+
+// ErrProcessingStalled is returned when a source file stops making checkpoint progress
+// or runs past its processing deadline
+var ErrProcessingStalled = errors.New("source file processing stalled")
+
+// ErrProcessingInterrupted is returned when the service shuts down while a source file is being
+// processed; the file keeps its checkpoint and resumes on the next start
+var ErrProcessingInterrupted = errors.New("source file processing interrupted by shutdown")
+
+// watchdogCheckInterval is how often the watchdog compares progress against the limits
+const watchdogCheckInterval = 15 * time.Second
+
+// LEIProcessingLimits bounds how long a single source file may be processed (zero disables a limit)
+// and how its batches are written
+type LEIProcessingLimits struct {
+	Timeout      time.Duration // Deadline for processing a whole file
+	StallTimeout time.Duration // Maximum time without checkpoint progress
+	Workers      int           // Concurrent batch upsert workers per file
+	BulkLoad     bool          // Load full files with COPY into a staging table and a single merge
+}
+
+// leiProcessingLimits parses the LEI processing limits, falling back to defaults for invalid values
+func leiProcessingLimits(cfg config.LEIConfig) LEIProcessingLimits {
+	return LEIProcessingLimits{
+		Timeout:      parseProcessingLimit("lei.processingtimeout", cfg.ProcessingTimeout, 8*time.Hour),
+		StallTimeout: parseProcessingLimit("lei.stalltimeout", cfg.StallTimeout, 15*time.Minute),
+		Workers:      parseProcessingWorkers(cfg.ProcessingWorkers),
+		BulkLoad:     cfg.FullFileBulkLoad,
+	}
+}
+
+// parseProcessingWorkers bounds the upsert worker count; each worker holds a database connection
+func parseProcessingWorkers(workers int) int {
+	const maxWorkers = 32
+	if workers < 1 || workers > maxWorkers {
+		log.Warn().
+			Int("value", workers).
+			Int("default", 4).
+			Msgf("Invalid lei.processingworkers (must be 1-%d), using default", maxWorkers)
+		return 4
+	}
+	return workers
+}
+
+func parseProcessingLimit(key, value string, fallback time.Duration) time.Duration {
+	if value == "0" {
+		return 0
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < time.Minute {
+		log.Warn().
+			Str("key", key).
+			Str("value", value).
+			Dur("default", fallback).
+			Msg("Invalid LEI processing limit, using default")
+		return fallback
+	}
+	return d
+}
+
+// processingWatchdog aborts file processing that stops advancing or overruns its deadline, or is
+// interrupted by shutdown. The processing goroutine calls touch() at every checkpoint and checks
+// err() between records.
+type processingWatchdog struct {
+	ctx          context.Context
+	cancel       context.CancelCauseFunc
+	lastProgress atomic.Int64 // UnixNano of the last checkpoint
+}
+
+// startProcessingWatchdog starts watching one source file against limits; cancelling parent
+// with ErrProcessingInterrupted interrupts it
+func startProcessingWatchdog(parent context.Context, sourceFileID uuid.UUID, limits LEIProcessingLimits) *processingWatchdog {
+	ctx, cancel := context.WithCancelCause(parent)
+	w := &processingWatchdog{ctx: ctx, cancel: cancel}
+	w.touch()
+
+	if limits.Timeout <= 0 && limits.StallTimeout <= 0 {
+		return w
+	}
+
+	started := time.Now()
+	go func() {
+		ticker := time.NewTicker(watchdogCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				idle := now.Sub(time.Unix(0, w.lastProgress.Load()))
+				switch {
+				case limits.Timeout > 0 && now.Sub(started) > limits.Timeout:
+					cancel(fmt.Errorf("%w: exceeded processing deadline of %s", ErrProcessingStalled, limits.Timeout))
+				case limits.StallTimeout > 0 && idle > limits.StallTimeout:
+					cancel(fmt.Errorf("%w: no checkpoint progress for %s", ErrProcessingStalled, idle.Round(time.Second)))
+				default:
+					continue
+				}
+				log.Warn().
+					Str("source_file_id", sourceFileID.String()).
+					Dur("elapsed", now.Sub(started)).
+					Dur("idle", idle).
+					Msg("Processing watchdog fired")
+				return
+			}
+		}
+	}()
+	return w
+}
+
+// touch records checkpoint progress
+func (w *processingWatchdog) touch() {
+	w.lastProgress.Store(time.Now().UnixNano())
+}
+
+// err returns the abort reason once the watchdog has fired
+func (w *processingWatchdog) err() error {
+	if w.ctx.Err() == nil {
+		return nil
+	}
+	if cause := context.Cause(w.ctx); errors.Is(cause, ErrProcessingStalled) || errors.Is(cause, ErrProcessingInterrupted) {
+		return cause
+	}
+	return nil
+}
+
+// done returns a channel closed when the watchdog fires or is stopped
+func (w *processingWatchdog) done() <-chan struct{} {
+	return w.ctx.Done()
+}
+
+// stop releases the watchdog goroutine
+func (w *processingWatchdog) stop() {
+	w.cancel(context.Canceled)
+}
+
+// abortStalledFile marks a stalled source file FAILED, releases the job that was processing it and alerts operators.
+// The source file is re-read because the (possibly still blocked) processing goroutine owns the in-memory copy.
+func (s *leiService) abortStalledFile(sourceFileID uuid.UUID, cause error) {
+	log.Error().
+		Err(cause).
+		Str("source_file_id", sourceFileID.String()).
+		Str("alert", "LEI_PROCESSING_STALLED").
+		Msg("ALERT: LEI file processing stalled and was aborted; check for database locks or hung connections")
+
+	sourceFile, err := s.repo.FindSourceFileByID(sourceFileID.String())
+	if err != nil {
+		log.Error().Err(err).Str("source_file_id", sourceFileID.String()).Msg("Failed to load stalled source file")
+	} else {
+		sourceFile.ProcessingStatus = "FAILED"
+		sourceFile.ProcessingError = cause.Error()
+		sourceFile.FailureCategory = FailureCategoryStalled
+		if err := s.repo.UpdateSourceFile(sourceFile); err != nil {
+			log.Error().Err(err).Str("source_file_id", sourceFileID.String()).Msg("Failed to mark stalled source file as FAILED")
+		}
+		s.notifyIfRetriesExhausted(sourceFile)
+	}
+	s.notifyFileStalled(sourceFileID, cause)
+
+	released, err := s.repo.ReleaseProcessingStatus(sourceFileID, cause.Error())
+	if err != nil {
+		log.Error().Err(err).Str("source_file_id", sourceFileID.String()).Msg("Failed to release job status for stalled source file")
+	} else if released > 0 {
+		log.Info().Int64("jobs_released", released).Str("source_file_id", sourceFileID.String()).Msg("Released job status for stalled source file")
+		if statuses, err := s.ListProcessingStatuses(); err == nil {
+			for _, status := range statuses {
+				s.publishStatusTransition(status)
+			}
+		}
+	}
+}
+
+// interruptSourceFile records on a file interrupted by shutdown that it stopped at its checkpoint.
+// It stays IN_PROGRESS, so the scheduler resumes it from there on the next start.
+func (s *leiService) interruptSourceFile(sourceFileID uuid.UUID, cause error) {
+	sourceFile, err := s.repo.FindSourceFileByID(sourceFileID.String())
+	if err != nil {
+		log.Error().Err(err).Str("source_file_id", sourceFileID.String()).Msg("Failed to load interrupted source file")
+		return
+	}
+	sourceFile.ProcessingError = fmt.Sprintf("%s after %d records; resumes from the last checkpoint", cause, sourceFile.ProcessedRecords)
+	if err := s.repo.UpdateSourceFile(sourceFile); err != nil {
+		log.Error().Err(err).Str("source_file_id", sourceFileID.String()).Msg("Failed to record source file interruption")
+	}
+	log.Warn().
+		Str("source_file_id", sourceFileID.String()).
+		Int("processed", sourceFile.ProcessedRecords).
+		Str("last_lei", sourceFile.LastProcessedLEI).
+		Msg("Source file processing interrupted by shutdown at its last checkpoint")
+}
+
+// beginProcessing registers a file about to be processed, unless the service is shutting down
+func (s *leiService) beginProcessing() bool {
+	s.processingMu.Lock()
+	defer s.processingMu.Unlock()
+	if s.shutdown.Err() != nil {
+		return false
+	}
+	s.processing.Add(1)
+	return true
+}
+
+// StopProcessing interrupts the source files being processed and waits, up to ctx's deadline, for
+// them to stop at their next checkpoint. Files are then refused with ErrProcessingInterrupted.
+func (s *leiService) StopProcessing(ctx context.Context) error {
+	s.processingMu.Lock()
+	s.stopProcessing(ErrProcessingInterrupted)
+	s.processingMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.processing.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("source files still processing at shutdown deadline: %w", ctx.Err())
+	}
+}
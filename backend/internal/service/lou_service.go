@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// ErrEmptyLOUList is returned when GLEIF lists no LEI issuers, so the sync leaves the LOUs alone
+// rather than mark every one unaccredited
+var ErrEmptyLOUList = errors.New("GLEIF returned an empty LEI issuer list")
+
+// louPageSize is the page size of LEI issuer list requests
+const louPageSize = 200
+
+// LOUSyncResult summarises a sync of the LEI issuer list
+type LOUSyncResult struct {
+	Listed       int   `json:"listed"`       // LOUs on GLEIF's list
+	Unaccredited int64 `json:"unaccredited"` // LOUs that dropped off the list in this sync
+}
+
+// LOUService maintains the LEI issuers (Local Operating Units) of GLEIF's LEI issuer list
+type LOUService interface {
+	Sync() (*LOUSyncResult, error)
+	Get(lei string) (*domain.LOU, error)
+	List(filter repository.LOUFilter, limit, offset int) ([]*domain.LOU, int64, error)
+	DescribeLEIRecords(ctx context.Context, records ...*domain.LEIRecord)
+}
+
+type louService struct {
+	repo   repository.LOURepository
+	client *gleifClient
+
+	mu       sync.RWMutex
+	names    map[string]string // LOU LEI -> name
+	loadedAt time.Time
+}
+
+// NewLOUService creates a new LOU service; Sync reads the LEI issuer list from the GLEIF API
+func NewLOUService(repo repository.LOURepository, settings GLEIFHTTPSettings) LOUService {
+	return &louService{repo: repo, client: newGLEIFClient(settings)}
+}
+
+// gleifLEIIssuersResponse is a page of GET /lei-issuers
+type gleifLEIIssuersResponse struct {
+	Meta struct {
+		Pagination struct {
+			CurrentPage int `json:"currentPage"`
+			LastPage    int `json:"lastPage"`
+		} `json:"pagination"`
+	} `json:"meta"`
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			LEI               string `json:"lei"`
+			Name              string `json:"name"`
+			MarketingName     string `json:"marketingName"`
+			Website           string `json:"website"`
+			AccreditationDate string `json:"accreditationDate"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// Sync reads GLEIF's LEI issuer list and upserts the LOUs on it. LOUs no longer listed are kept,
+// since LEI records may still name them, but marked as no longer accredited.
+func (s *louService) Sync() (*LOUSyncResult, error) {
+	syncedAt := time.Now().UTC()
+	var lous []*domain.LOU
+	listed := make([]string, 0)
+	for page := 1; ; page++ {
+		if page > 1 {
+			time.Sleep(GLEIFAPIRequestInterval)
+		}
+		query := url.Values{}
+		query.Set("page[size]", strconv.Itoa(louPageSize))
+		query.Set("page[number]", strconv.Itoa(page))
+		body, err := s.client.get(context.Background(), s.client.settings.APIURL+"/lei-issuers?"+query.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch LEI issuer list: %w", err)
+		}
+		var resp gleifLEIIssuersResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to decode LEI issuer list: %w", err)
+		}
+
+		for _, issuer := range resp.Data {
+			lou := &domain.LOU{
+				LEI:           strings.ToUpper(strings.TrimSpace(issuer.Attributes.LEI)),
+				Name:          strings.TrimSpace(issuer.Attributes.Name),
+				MarketingName: strings.TrimSpace(issuer.Attributes.MarketingName),
+				Website:       strings.TrimSpace(issuer.Attributes.Website),
+				Accredited:    true,
+				SyncedAt:      syncedAt,
+			}
+			if lou.LEI == "" {
+				lou.LEI = strings.ToUpper(strings.TrimSpace(issuer.ID))
+			}
+			if !IsValidLEI(lou.LEI) || lou.Name == "" {
+				log.Warn().Str("lei", lou.LEI).Str("name", lou.Name).Msg("Skipping LEI issuer without a valid LEI or name")
+				continue
+			}
+			if value := issuer.Attributes.AccreditationDate; value != "" {
+				if date, err := time.Parse(time.DateOnly, value[:min(len(value), len(time.DateOnly))]); err == nil {
+					lou.AccreditationDate = &date
+				}
+			}
+			lous = append(lous, lou)
+			listed = append(listed, lou.LEI)
+		}
+		if page >= resp.Meta.Pagination.LastPage || len(resp.Data) == 0 {
+			break
+		}
+	}
+	if len(lous) == 0 {
+		return nil, ErrEmptyLOUList
+	}
+
+	if err := s.repo.Upsert(lous); err != nil {
+		return nil, err
+	}
+	unaccredited, err := s.repo.MarkUnlisted(listed)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.names = nil
+	s.mu.Unlock()
+	return &LOUSyncResult{Listed: len(lous), Unaccredited: unaccredited}, nil
+}
+
+// Get returns the LOU with an LEI
+func (s *louService) Get(lei string) (*domain.LOU, error) {
+	lei = strings.ToUpper(strings.TrimSpace(lei))
+	if !IsValidLEI(lei) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLEI, lei)
+	}
+	return s.repo.FindByLEI(lei)
+}
+
+// List lists LOUs
+func (s *louService) List(filter repository.LOUFilter, limit, offset int) ([]*domain.LOU, int64, error) {
+	return s.repo.FindAll(filter, limit, offset)
+}
+
+// DescribeLEIRecords fills in the name of each record's managing LOU. LOUs not synced yet are left
+// without one; if the names cannot be loaded, records are returned as they are.
+func (s *louService) DescribeLEIRecords(ctx context.Context, records ...*domain.LEIRecord) {
+	names := s.cachedNames(ctx)
+	for _, record := range records {
+		if record == nil || record.ManagingLOU == "" {
+			continue
+		}
+		record.ManagingLOUName = names[record.ManagingLOU]
+	}
+}
+
+// cachedNames returns the LOU names, reloading them after a sync or once codeListCacheTTL has passed
+func (s *louService) cachedNames(ctx context.Context) map[string]string {
+	s.mu.RLock()
+	names, loadedAt := s.names, s.loadedAt
+	s.mu.RUnlock()
+	if names != nil && time.Since(loadedAt) < codeListCacheTTL {
+		return names
+	}
+
+	loaded, err := s.repo.Names()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to load LOU names")
+		return names
+	}
+	s.mu.Lock()
+	s.names, s.loadedAt = loaded, time.Now()
+	s.mu.Unlock()
+	return loaded
+}
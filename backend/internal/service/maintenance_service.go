@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// JobKindStatsRecompute is the job kind of a manual statistics refresh
+const JobKindStatsRecompute = "STATS_RECOMPUTE"
+
+// ErrSourceFileNotFound is returned when a source file ID matches no file
+var ErrSourceFileNotFound = errors.New("source file not found")
+
+// ErrSourceFileNotFailed is returned when a re-run is requested for a file that has not failed
+var ErrSourceFileNotFailed = errors.New("only FAILED source files can be re-run")
+
+// StuckStatusReset lists what ResetStuckStatuses repaired
+type StuckStatusReset struct {
+	OlderThan          string                        `json:"older_than"`
+	SourceFiles        []domain.SourceFile           `json:"source_files"`        // IN_PROGRESS files marked FAILED (STALLED)
+	ProcessingStatuses []domain.FileProcessingStatus `json:"processing_statuses"` // RUNNING statuses returned to IDLE
+}
+
+// MaintenanceService repairs what interrupted runs leave behind, for operators at /admin/maintenance
+type MaintenanceService interface {
+	DefaultStuckAge() time.Duration
+	ResetStuckStatuses(ctx context.Context, olderThan time.Duration) (*StuckStatusReset, error)
+	RerunnableSourceFile(id uuid.UUID) (*domain.SourceFile, error)
+	RecomputeStats(job *Job) (*domain.DataFreshness, error)
+}
+
+type maintenanceService struct {
+	repo      repository.MaintenanceRepository
+	leiRepo   repository.LEIRepository
+	freshness FreshnessService
+	jobs      *JobRegistry
+	stuckAge  time.Duration
+}
+
+// NewMaintenanceService creates a new maintenance service; work counts as stuck once it has not
+// progressed for stuckAge
+func NewMaintenanceService(repo repository.MaintenanceRepository, leiRepo repository.LEIRepository, freshness FreshnessService, jobs *JobRegistry, stuckAge time.Duration) MaintenanceService {
+	return &maintenanceService{repo: repo, leiRepo: leiRepo, freshness: freshness, jobs: jobs, stuckAge: stuckAge}
+}
+
+// stuckStatusAge is twice the stall timeout: the watchdog of a live process fails a stalled file
+// well before then, so what is still IN_PROGRESS was left by a process that is gone
+func stuckStatusAge(limits LEIProcessingLimits) time.Duration {
+	if limits.StallTimeout <= 0 {
+		return time.Hour
+	}
+	return 2 * limits.StallTimeout
+}
+
+func (s *maintenanceService) DefaultStuckAge() time.Duration {
+	return s.stuckAge
+}
+
+// ResetStuckStatuses fails IN_PROGRESS source files and idles RUNNING processing statuses that have
+// not changed for olderThan, as the scheduler does for its own statuses at startup. Files a job in
+// this process is still working on are left alone; a failed file can then be retried or re-run.
+func (s *maintenanceService) ResetStuckStatuses(ctx context.Context, olderThan time.Duration) (*StuckStatusReset, error) {
+	before := time.Now().Add(-olderThan)
+	reason := fmt.Sprintf("Reset by an operator: no progress for over %s", olderThan)
+
+	files, err := s.repo.ResetStalledSourceFiles(ctx, before, s.activeSourceFiles(), FailureCategoryStalled, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset stuck source files: %w", err)
+	}
+	statuses, err := s.repo.ResetStalledProcessingStatuses(ctx, before, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset stuck processing statuses: %w", err)
+	}
+
+	for _, file := range files {
+		log.Ctx(ctx).Warn().Str("source_file_id", file.ID.String()).Str("last_lei", file.LastProcessedLEI).Msg("Reset stuck source file to FAILED")
+	}
+	for _, status := range statuses {
+		log.Ctx(ctx).Warn().Str("job_type", status.JobType).Msg("Reset stuck processing status to IDLE")
+	}
+	return &StuckStatusReset{OlderThan: olderThan.String(), SourceFiles: files, ProcessingStatuses: statuses}, nil
+}
+
+// activeSourceFiles lists the source files running jobs of this process are working on
+func (s *maintenanceService) activeSourceFiles() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, job := range s.jobs.List() {
+		if job.Status != JobStatusRunning {
+			continue
+		}
+		if id, err := uuid.Parse(job.Context["source_file_id"]); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// RerunnableSourceFile returns a source file if it may be re-run, i.e. it has FAILED
+func (s *maintenanceService) RerunnableSourceFile(id uuid.UUID) (*domain.SourceFile, error) {
+	file, err := s.leiRepo.FindSourceFileByID(id.String())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSourceFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if file.ProcessingStatus != "FAILED" {
+		return nil, fmt.Errorf("%w: file is %s", ErrSourceFileNotFailed, file.ProcessingStatus)
+	}
+	return file, nil
+}
+
+// RecomputeStats analyzes the tables the freshness endpoint reports, refreshing its row estimates
+// (and the query planner's), and returns the recomputed report
+func (s *maintenanceService) RecomputeStats(job *Job) (*domain.DataFreshness, error) {
+	total := int64(len(repository.FreshnessTables))
+	for i, table := range repository.FreshnessTables {
+		job.SetStage("analyzing " + table)
+		if err := s.repo.AnalyzeTable(table); err != nil {
+			return nil, fmt.Errorf("failed to analyze %s: %w", table, err)
+		}
+		job.SetProgress(int64(i+1), total)
+	}
+	s.freshness.Invalidate()
+	return s.freshness.GetFreshness()
+}
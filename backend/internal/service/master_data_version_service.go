@@ -0,0 +1,97 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/fieldcrypt"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// MasterDataVersionService answers what countries, currencies, entities, instruments and SSIs
+// looked like at a point in time, so historical reports use the reference data in effect on e.g.
+// trade date
+type MasterDataVersionService interface {
+	History(domainName, recordID string) ([]*domain.MasterDataVersion, error)
+	AsOf(domainName, recordID string, asOf time.Time) (*domain.MasterDataVersion, error)
+	ListAsOf(domainName string, asOf time.Time, limit, offset int) ([]*domain.MasterDataVersion, int64, error)
+}
+
+type masterDataVersionService struct {
+	repo repository.MasterDataVersionRepository
+}
+
+// NewMasterDataVersionService creates a new master data version service
+func NewMasterDataVersionService(repo repository.MasterDataVersionRepository) MasterDataVersionService {
+	return &masterDataVersionService{repo: repo}
+}
+
+// History returns every version of a record, oldest first; gorm.ErrRecordNotFound if it has none
+func (s *masterDataVersionService) History(domainName, recordID string) ([]*domain.MasterDataVersion, error) {
+	versions, err := s.repo.FindVersions(domainName, recordID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err := decryptVersions(versions...); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// AsOf returns the version of a record in effect at asOf; gorm.ErrRecordNotFound if the record
+// did not exist then (not yet created, or already deleted)
+func (s *masterDataVersionService) AsOf(domainName, recordID string, asOf time.Time) (*domain.MasterDataVersion, error) {
+	version, err := s.repo.FindVersionAsOf(domainName, recordID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if err := decryptVersions(version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// ListAsOf returns the records of a domain as they stood at asOf
+func (s *masterDataVersionService) ListAsOf(domainName string, asOf time.Time, limit, offset int) ([]*domain.MasterDataVersion, int64, error) {
+	versions, total, err := s.repo.FindAllAsOf(domainName, asOf, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := decryptVersions(versions...); err != nil {
+		return nil, 0, err
+	}
+	return versions, total, nil
+}
+
+// decryptVersions decrypts the beneficiary accounts of SSI snapshots, which the versioning trigger
+// copies from the table as stored
+func decryptVersions(versions ...*domain.MasterDataVersion) error {
+	for _, version := range versions {
+		if version.Domain != domain.VersionDomainSSI {
+			continue
+		}
+		var snapshot map[string]json.RawMessage
+		if err := json.Unmarshal(version.Snapshot, &snapshot); err != nil {
+			return fmt.Errorf("SSI version %s: %w", version.ID, err)
+		}
+		var account string
+		if json.Unmarshal(snapshot["beneficiary_account"], &account) != nil || !fieldcrypt.IsEncrypted(account) {
+			continue
+		}
+		account, err := fieldcrypt.Current().Decrypt(account)
+		if err != nil {
+			return fmt.Errorf("SSI version %s: %w", version.ID, err)
+		}
+		snapshot["beneficiary_account"], _ = json.Marshal(account)
+		if version.Snapshot, err = json.Marshal(snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
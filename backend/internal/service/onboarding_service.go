@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Errors returned by the onboarding workflow
+var (
+	ErrOnboardingTransition   = errors.New("action not allowed in the onboarding's status")
+	ErrOnboardingSelfApproval = errors.New("an onboarding cannot be approved by the user who requested it")
+	ErrOnboardingSSIsInvalid  = errors.New("SSIs failed validation")
+)
+
+// onboardingTransitions is the onboarding state machine: per action, the statuses it may be taken
+// in and the status it moves the onboarding to. Enrichment and SSI validation can be repeated
+// until the next step is taken.
+var onboardingTransitions = map[string]struct {
+	from []string
+	to   string
+}{
+	domain.OnboardingActionEnrich: {
+		from: []string{domain.OnboardingStatusDraft, domain.OnboardingStatusEnriched},
+		to:   domain.OnboardingStatusEnriched,
+	},
+	domain.OnboardingActionValidateSSIs: {
+		from: []string{domain.OnboardingStatusEnriched, domain.OnboardingStatusSSIsValidated},
+		to:   domain.OnboardingStatusSSIsValidated,
+	},
+	domain.OnboardingActionApprove: {
+		from: []string{domain.OnboardingStatusSSIsValidated},
+		to:   domain.OnboardingStatusApproved,
+	},
+	domain.OnboardingActionReject: {
+		from: []string{domain.OnboardingStatusDraft, domain.OnboardingStatusEnriched, domain.OnboardingStatusSSIsValidated},
+		to:   domain.OnboardingStatusRejected,
+	},
+}
+
+// OnboardingDraft is a counterparty to onboard: the entity with its accounts and SSIs
+type OnboardingDraft struct {
+	Entity   domain.Entity    `json:"entity"`
+	Accounts []domain.Account `json:"accounts"`
+	SSIs     []domain.SSI     `json:"ssis"`
+}
+
+// OnboardingSSIFailure lists why an SSI failed validation
+type OnboardingSSIFailure struct {
+	SSIID    string   `json:"ssi_id,omitempty"` // empty when the entity has no SSIs at all
+	Problems []string `json:"problems"`
+}
+
+// OnboardingSSIValidationError carries the SSI failures of a VALIDATE_SSIS attempt
+type OnboardingSSIValidationError struct {
+	Failures []OnboardingSSIFailure
+}
+
+func (e *OnboardingSSIValidationError) Error() string {
+	return fmt.Sprintf("%s: %d failure(s)", ErrOnboardingSSIsInvalid, len(e.Failures))
+}
+
+func (e *OnboardingSSIValidationError) Unwrap() error {
+	return ErrOnboardingSSIsInvalid
+}
+
+// OnboardingService takes counterparties through onboarding: draft, enrich from LEI, validate
+// SSIs, approve. Every action, including failed attempts, is audited on the onboarding.
+type OnboardingService interface {
+	Create(ctx context.Context, draft *OnboardingDraft, requestedBy string) (*domain.Onboarding, error)
+	Get(id string) (*domain.Onboarding, error)
+	List(status string, limit, offset int) ([]*domain.Onboarding, int64, error)
+	Enrich(ctx context.Context, id, actor string, skip bool, note string) (*domain.Onboarding, error)
+	ValidateSSIs(ctx context.Context, id, actor string) (*domain.Onboarding, error)
+	Approve(ctx context.Context, id, actor, note string) (*domain.Onboarding, error)
+	Reject(ctx context.Context, id, actor, note string) (*domain.Onboarding, error)
+}
+
+type onboardingService struct {
+	repo       repository.OnboardingRepository
+	entities   EntityService
+	accounts   AccountService
+	ssis       SSIService
+	enrichment EntityEnrichmentService
+}
+
+// NewOnboardingService creates a new counterparty onboarding service
+func NewOnboardingService(repo repository.OnboardingRepository, entities EntityService, accounts AccountService, ssis SSIService, enrichment EntityEnrichmentService) OnboardingService {
+	return &onboardingService{repo: repo, entities: entities, accounts: accounts, ssis: ssis, enrichment: enrichment}
+}
+
+// Create stores the entity, accounts and SSIs of a draft, inactive until approval. If any of them
+// cannot be created, those already created are deleted again.
+func (s *onboardingService) Create(ctx context.Context, draft *OnboardingDraft, requestedBy string) (*domain.Onboarding, error) {
+	entity := &draft.Entity
+	if err := s.entities.Create(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	onboarding := &domain.Onboarding{EntityID: entity.ID, Status: domain.OnboardingStatusDraft, RequestedBy: requestedBy}
+	err := func() error {
+		for i := range draft.Accounts {
+			draft.Accounts[i].EntityID = &entity.ID
+			if err := s.accounts.Create(ctx, &draft.Accounts[i]); err != nil {
+				return fmt.Errorf("account %d: %w", i+1, err)
+			}
+		}
+		for i := range draft.SSIs {
+			draft.SSIs[i].EntityID = &entity.ID
+			if err := s.ssis.Create(ctx, &draft.SSIs[i]); err != nil {
+				return fmt.Errorf("SSI %d: %w", i+1, err)
+			}
+		}
+		return s.repo.Create(ctx, onboarding, &domain.OnboardingEvent{
+			Action:   domain.OnboardingActionCreate,
+			ToStatus: domain.OnboardingStatusDraft,
+			Outcome:  domain.OnboardingOutcomeSucceeded,
+			Actor:    requestedBy,
+			Details:  onboardingDetails(map[string]int{"accounts": len(draft.Accounts), "ssis": len(draft.SSIs)}),
+		})
+	}()
+	if err != nil {
+		if discardErr := s.repo.DiscardRecords(ctx, entity.ID); discardErr != nil {
+			log.Ctx(ctx).Error().Err(discardErr).Str("entity_id", entity.ID.String()).Msg("Failed to discard records of a failed onboarding draft")
+		}
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().Str("onboarding_id", onboarding.ID.String()).Str("entity_id", entity.ID.String()).Str("requested_by", requestedBy).Msg("Counterparty onboarding drafted")
+	return s.repo.FindByID(onboarding.ID.String())
+}
+
+func (s *onboardingService) Get(id string) (*domain.Onboarding, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *onboardingService) List(status string, limit, offset int) ([]*domain.Onboarding, int64, error) {
+	return s.repo.FindAll(status, limit, offset)
+}
+
+// Enrich fills the entity from its LEI record. An entity without an LEI, e.g. an individual, can
+// only move on with skip, and the note says why.
+func (s *onboardingService) Enrich(ctx context.Context, id, actor string, skip bool, note string) (*domain.Onboarding, error) {
+	onboarding, from, err := s.begin(id, domain.OnboardingActionEnrich)
+	if err != nil {
+		return nil, err
+	}
+
+	var details string
+	result, err := s.enrichment.Enrich(onboarding.EntityID.String(), false)
+	switch {
+	case errors.Is(err, ErrEntityHasNoLEI) && skip:
+		details = onboardingDetails(map[string]string{"skipped": err.Error()})
+	case err != nil:
+		s.recordFailure(ctx, onboarding, domain.OnboardingActionEnrich, actor, note, onboardingDetails(map[string]string{"error": err.Error()}))
+		return nil, err
+	default:
+		details = onboardingDetails(map[string]any{"lei": result.LEI, "filled": result.Filled, "skipped": result.Skipped})
+	}
+
+	return s.complete(ctx, onboarding, from, domain.OnboardingActionEnrich, actor, note, details)
+}
+
+// ValidateSSIs checks every SSI of the entity; the onboarding moves on only if there is at least
+// one and all pass. Failures are returned as an *OnboardingSSIValidationError.
+func (s *onboardingService) ValidateSSIs(ctx context.Context, id, actor string) (*domain.Onboarding, error) {
+	onboarding, from, err := s.begin(id, domain.OnboardingActionValidateSSIs)
+	if err != nil {
+		return nil, err
+	}
+
+	ssis, err := s.repo.FindEntitySSIs(onboarding.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := decryptAccounts(ssis...); err != nil {
+		return nil, err
+	}
+	var failures []OnboardingSSIFailure
+	if len(ssis) == 0 {
+		failures = append(failures, OnboardingSSIFailure{Problems: []string{"entity has no SSIs"}})
+	}
+	now := time.Now()
+	for _, ssi := range ssis {
+		if problems := validateOnboardingSSI(ssi, now); len(problems) > 0 {
+			failures = append(failures, OnboardingSSIFailure{SSIID: ssi.ID.String(), Problems: problems})
+		}
+	}
+	if len(failures) > 0 {
+		s.recordFailure(ctx, onboarding, domain.OnboardingActionValidateSSIs, actor, "", onboardingDetails(map[string]any{"failures": failures}))
+		return nil, &OnboardingSSIValidationError{Failures: failures}
+	}
+
+	return s.complete(ctx, onboarding, from, domain.OnboardingActionValidateSSIs, actor, "", onboardingDetails(map[string]int{"ssis": len(ssis)}))
+}
+
+// validateOnboardingSSI returns what is wrong with an SSI, if anything
+func validateOnboardingSSI(ssi *domain.SSI, now time.Time) []string {
+	var problems []string
+	if ssi.BeneficiaryName == "" || ssi.BeneficiaryAccount == "" || ssi.BeneficiaryBank == "" {
+		problems = append(problems, "beneficiary name, account and bank are required")
+	}
+	if account := NormalizeIBAN(ssi.BeneficiaryAccount); looksLikeIBAN(account) {
+		if err := ValidateIBAN(account); err != nil {
+			problems = append(problems, "beneficiary account: "+err.Error())
+		}
+	}
+	if ssi.BeneficiaryBankBIC != "" {
+		if err := ValidateBIC(ssi.BeneficiaryBankBIC); err != nil {
+			problems = append(problems, "beneficiary bank: "+err.Error())
+		}
+	}
+	if ssi.IntermediaryBankBIC != "" {
+		if err := ValidateBIC(ssi.IntermediaryBankBIC); err != nil {
+			problems = append(problems, "intermediary bank: "+err.Error())
+		}
+	}
+	switch {
+	case ssi.SettlementCurrency == nil:
+		problems = append(problems, "settlement currency is required")
+	case !ssi.SettlementCurrency.Active:
+		problems = append(problems, fmt.Sprintf("settlement currency %s is inactive", ssi.SettlementCurrency.Code))
+	}
+	if ssi.ValidTo != nil {
+		if !ssi.ValidTo.After(ssi.ValidFrom) {
+			problems = append(problems, "valid_to must be after valid_from")
+		} else if ssi.ValidTo.Before(now) {
+			problems = append(problems, "SSI has expired")
+		}
+	}
+	return problems
+}
+
+// Approve activates the entity, accounts and SSIs. The approver must not be the requester.
+func (s *onboardingService) Approve(ctx context.Context, id, actor, note string) (*domain.Onboarding, error) {
+	onboarding, from, err := s.begin(id, domain.OnboardingActionApprove)
+	if err != nil {
+		return nil, err
+	}
+	if actor == "" || actor == onboarding.RequestedBy {
+		s.recordFailure(ctx, onboarding, domain.OnboardingActionApprove, actor, note, onboardingDetails(map[string]string{"error": ErrOnboardingSelfApproval.Error()}))
+		return nil, ErrOnboardingSelfApproval
+	}
+
+	now := time.Now()
+	onboarding.Status = domain.OnboardingStatusApproved
+	onboarding.ApprovedBy = actor
+	onboarding.ApprovedAt = &now
+	if err := s.repo.Approve(ctx, onboarding, from, s.event(onboarding, from, domain.OnboardingActionApprove, actor, note, "")); err != nil {
+		return nil, err
+	}
+	log.Ctx(ctx).Info().Str("onboarding_id", id).Str("entity_id", onboarding.EntityID.String()).Str("approved_by", actor).Msg("Counterparty onboarding approved")
+	return s.repo.FindByID(id)
+}
+
+// Reject ends the onboarding and deletes the entity, accounts and SSIs it created
+func (s *onboardingService) Reject(ctx context.Context, id, actor, note string) (*domain.Onboarding, error) {
+	onboarding, from, err := s.begin(id, domain.OnboardingActionReject)
+	if err != nil {
+		return nil, err
+	}
+
+	onboarding.Status = domain.OnboardingStatusRejected
+	if err := s.repo.Reject(ctx, onboarding, from, s.event(onboarding, from, domain.OnboardingActionReject, actor, note, "")); err != nil {
+		return nil, err
+	}
+	log.Ctx(ctx).Info().Str("onboarding_id", id).Str("entity_id", onboarding.EntityID.String()).Str("rejected_by", actor).Msg("Counterparty onboarding rejected")
+	return s.repo.FindByID(id)
+}
+
+// begin loads an onboarding and checks the action is allowed in its status, which it returns
+func (s *onboardingService) begin(id, action string) (*domain.Onboarding, string, error) {
+	onboarding, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, status := range onboardingTransitions[action].from {
+		if onboarding.Status == status {
+			return onboarding, status, nil
+		}
+	}
+	return nil, "", fmt.Errorf("%w: cannot %s an onboarding in status %s", ErrOnboardingTransition, action, onboarding.Status)
+}
+
+// complete moves the onboarding on after a successful action and returns it reloaded
+func (s *onboardingService) complete(ctx context.Context, onboarding *domain.Onboarding, from, action, actor, note, details string) (*domain.Onboarding, error) {
+	onboarding.Status = onboardingTransitions[action].to
+	if err := s.repo.Transition(ctx, onboarding, from, s.event(onboarding, from, action, actor, note, details)); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(onboarding.ID.String())
+}
+
+// event builds the audit event of a successful action; the onboarding already has its new status
+func (s *onboardingService) event(onboarding *domain.Onboarding, from, action, actor, note, details string) *domain.OnboardingEvent {
+	if details == "" {
+		details = "{}" // details is jsonb, which has no empty string
+	}
+	return &domain.OnboardingEvent{
+		OnboardingID: onboarding.ID,
+		Action:       action,
+		FromStatus:   from,
+		ToStatus:     onboarding.Status,
+		Outcome:      domain.OnboardingOutcomeSucceeded,
+		Actor:        actor,
+		Note:         note,
+		Details:      details,
+	}
+}
+
+// recordFailure audits an attempt that left the onboarding in its status
+func (s *onboardingService) recordFailure(ctx context.Context, onboarding *domain.Onboarding, action, actor, note, details string) {
+	err := s.repo.RecordEvent(ctx, &domain.OnboardingEvent{
+		OnboardingID: onboarding.ID,
+		Action:       action,
+		FromStatus:   onboarding.Status,
+		ToStatus:     onboarding.Status,
+		Outcome:      domain.OnboardingOutcomeFailed,
+		Actor:        actor,
+		Note:         note,
+		Details:      details,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("onboarding_id", onboarding.ID.String()).Str("action", action).Msg("Failed to audit onboarding action")
+	}
+}
+
+// onboardingDetails renders the details of an audit event as JSON
+func onboardingDetails(details any) string {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
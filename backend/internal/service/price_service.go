@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// PriceProvider is the hook for market data feeds
+// Prices returned are stored with Source = Name(), so each feed coexists with manual prices
+type PriceProvider interface {
+	Name() string
+	FetchPrices(ctx context.Context, instruments []*domain.Instrument, date time.Time) ([]*domain.InstrumentPrice, error)
+}
+
+// PriceImportRow is one price in a bulk import request
+type PriceImportRow struct {
+	InstrumentID string  `json:"instrument_id"`
+	PriceDate    string  `json:"price_date"` // YYYY-MM-DD
+	Price        float64 `json:"price"`
+	Currency     string  `json:"currency"` // ISO 4217 code
+	Source       string  `json:"source"`   // defaults to MANUAL
+}
+
+// PriceImportRejection explains why a row was not imported
+type PriceImportRejection struct {
+	Row   int    `json:"row"` // zero-based index in the request
+	Error string `json:"error"`
+}
+
+// PriceImportResult summarises a bulk import
+type PriceImportResult struct {
+	Imported int                    `json:"imported"`
+	Rejected []PriceImportRejection `json:"rejected"`
+}
+
+// ErrPriceProviderNotFound is returned when syncing from an unregistered provider
+var ErrPriceProviderNotFound = errors.New("price provider not registered")
+
+// PriceService manages instrument reference prices
+type PriceService interface {
+	ImportPrices(ctx context.Context, rows []PriceImportRow) (*PriceImportResult, error)
+	GetLatestPrice(instrumentID uuid.UUID, source string, asOf time.Time) (*domain.InstrumentPrice, error)
+	RegisterProvider(provider PriceProvider)
+	ProviderNames() []string
+	SyncFromProvider(ctx context.Context, name string, date time.Time) (int, error)
+}
+
+type priceService struct {
+	repo           repository.InstrumentPriceRepository
+	instrumentRepo repository.InstrumentRepository
+	currencyRepo   repository.CurrencyRepository
+
+	mu        sync.RWMutex
+	providers map[string]PriceProvider
+}
+
+// NewPriceService creates a new price service
+func NewPriceService(repo repository.InstrumentPriceRepository, instrumentRepo repository.InstrumentRepository, currencyRepo repository.CurrencyRepository) PriceService {
+	return &priceService{
+		repo:           repo,
+		instrumentRepo: instrumentRepo,
+		currencyRepo:   currencyRepo,
+		providers:      make(map[string]PriceProvider),
+	}
+}
+
+// ImportPrices validates and upserts prices; invalid rows are reported, valid rows are stored, in one
+// transaction. Of rows repeating an (instrument, date, source), the last one is kept.
+func (s *priceService) ImportPrices(ctx context.Context, rows []PriceImportRow) (*PriceImportResult, error) {
+	result := &PriceImportResult{Rejected: make([]PriceImportRejection, 0)}
+	prices := make([]*domain.InstrumentPrice, 0, len(rows))
+
+	// Lookups are cached for the duration of the import
+	instruments := make(map[uuid.UUID]bool)
+	currencies := make(map[string]uuid.UUID)
+
+	for i, row := range rows {
+		reject := func(format string, args ...interface{}) {
+			result.Rejected = append(result.Rejected, PriceImportRejection{Row: i, Error: fmt.Sprintf(format, args...)})
+		}
+
+		instrumentID, err := uuid.Parse(row.InstrumentID)
+		if err != nil {
+			reject("invalid instrument_id %q", row.InstrumentID)
+			continue
+		}
+		priceDate, err := time.Parse("2006-01-02", row.PriceDate)
+		if err != nil {
+			reject("invalid price_date %q (expected YYYY-MM-DD)", row.PriceDate)
+			continue
+		}
+		if row.Price <= 0 {
+			reject("price must be positive")
+			continue
+		}
+
+		exists, checked := instruments[instrumentID]
+		if !checked {
+			_, err := s.instrumentRepo.FindByID(instrumentID.String())
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to look up instrument %s: %w", instrumentID, err)
+			}
+			exists = err == nil
+			instruments[instrumentID] = exists
+		}
+		if !exists {
+			reject("instrument %s not found", instrumentID)
+			continue
+		}
+
+		code := strings.ToUpper(strings.TrimSpace(row.Currency))
+		currencyID, ok := currencies[code]
+		if !ok {
+			currency, err := s.currencyRepo.FindByCode(code)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to look up currency %s: %w", code, err)
+			}
+			if err == nil {
+				currencyID = currency.ID
+				currencies[code] = currencyID
+			}
+		}
+		if currencyID == uuid.Nil {
+			reject("unknown currency %q", row.Currency)
+			continue
+		}
+
+		source := strings.TrimSpace(row.Source)
+		if source == "" {
+			source = domain.PriceSourceManual
+		}
+
+		prices = append(prices, &domain.InstrumentPrice{
+			InstrumentID: instrumentID,
+			PriceDate:    priceDate,
+			Price:        row.Price,
+			CurrencyID:   currencyID,
+			Source:       source,
+		})
+	}
+
+	if err := s.repo.BulkUpsert(ctx, prices); err != nil {
+		return nil, fmt.Errorf("failed to store prices: %w", err)
+	}
+	result.Imported = len(prices)
+
+	log.Ctx(ctx).Info().
+		Int("imported", result.Imported).
+		Int("rejected", len(result.Rejected)).
+		Msg("Instrument prices imported")
+
+	return result, nil
+}
+
+// GetLatestPrice returns the most recent price on or before asOf (any source if source is empty)
+func (s *priceService) GetLatestPrice(instrumentID uuid.UUID, source string, asOf time.Time) (*domain.InstrumentPrice, error) {
+	return s.repo.FindLatest(instrumentID, source, asOf)
+}
+
+// RegisterProvider makes a market data feed available to SyncFromProvider
+func (s *priceService) RegisterProvider(provider PriceProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.providers[provider.Name()] = provider
+	log.Info().Str("provider", provider.Name()).Msg("Price provider registered")
+}
+
+// ProviderNames lists registered providers
+func (s *priceService) ProviderNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SyncFromProvider fetches prices for all active instruments for date and stores them
+func (s *priceService) SyncFromProvider(ctx context.Context, name string, date time.Time) (int, error) {
+	s.mu.RLock()
+	provider, ok := s.providers[name]
+	s.mu.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrPriceProviderNotFound, name)
+	}
+
+	var active []*domain.Instrument
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		page, err := s.instrumentRepo.FindAll(pageSize, offset)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list instruments: %w", err)
+		}
+		for _, instrument := range page {
+			if instrument.Active {
+				active = append(active, instrument)
+			}
+		}
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	prices, err := provider.FetchPrices(ctx, active, date)
+	if err != nil {
+		return 0, fmt.Errorf("provider %s failed: %w", name, err)
+	}
+	for _, price := range prices {
+		price.Source = provider.Name()
+	}
+
+	if err := s.repo.BulkUpsert(ctx, prices); err != nil {
+		return 0, fmt.Errorf("failed to store prices from %s: %w", name, err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("provider", name).
+		Time("date", date).
+		Int("prices", len(prices)).
+		Msg("Prices synced from provider")
+
+	return len(prices), nil
+}
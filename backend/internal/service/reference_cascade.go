@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Deactivation cascade policies, applied when a country or currency is deactivated
+const (
+	DeactivationPolicyFlag       = "flag"       // flag dependents for review and raise DQ findings (default)
+	DeactivationPolicyDeactivate = "deactivate" // as flag, and also deactivate dependents
+	DeactivationPolicyRestrict   = "restrict"   // refuse deactivation while active dependents exist
+)
+
+// ErrInactiveReference is returned when a create/update would newly reference an inactive country or currency
+var ErrInactiveReference = errors.New("inactive reference data")
+
+// ErrDeactivationRestricted is returned under the restrict policy when dependents still exist
+var ErrDeactivationRestricted = errors.New("deactivation blocked by active dependents")
+
+// IsValidDeactivationPolicy reports whether policy is one of the DeactivationPolicy constants
+func IsValidDeactivationPolicy(policy string) bool {
+	switch policy {
+	case DeactivationPolicyFlag, DeactivationPolicyDeactivate, DeactivationPolicyRestrict:
+		return true
+	}
+	return false
+}
+
+// referenceCascade applies the deactivation policy to the dependents of a country or currency
+type referenceCascade struct {
+	repo   repository.ReferenceCascadeRepository
+	policy string
+}
+
+func newReferenceCascade(repo repository.ReferenceCascadeRepository, policy string) *referenceCascade {
+	if !IsValidDeactivationPolicy(policy) {
+		log.Warn().Str("policy", policy).Msg("Unknown deactivation policy, using flag")
+		policy = DeactivationPolicyFlag
+	}
+	return &referenceCascade{repo: repo, policy: policy}
+}
+
+// deactivate saves parent (already marked inactive) and cascades to dependents
+// kind/code describe the parent for messages, e.g. "currency"/"HRK"
+func (c *referenceCascade) deactivate(ctx context.Context, parent interface{}, kind, code, ruleCode string, dependents []domain.DependentRef) error {
+	if c.policy == DeactivationPolicyRestrict && len(dependents) > 0 {
+		return fmt.Errorf("%w: %s %s is referenced by %d active record(s)", ErrDeactivationRestricted, kind, code, len(dependents))
+	}
+
+	cause := fmt.Sprintf("%s %s deactivated", kind, code)
+	findings := make([]*domain.DQFinding, 0, len(dependents))
+	for _, dep := range dependents {
+		findings = append(findings, &domain.DQFinding{
+			RuleCode:    ruleCode,
+			Severity:    domain.DQSeverityWarning,
+			TargetTable: dep.Table,
+			TargetID:    dep.ID,
+			Message:     fmt.Sprintf("%s %s references %s %s, which has been deactivated", singular(dep.Table), dep.Label, kind, code),
+			Cause:       cause,
+			Status:      domain.DQFindingStatusOpen,
+		})
+	}
+
+	if err := c.repo.SaveWithCascade(ctx, parent, dependents, cause, c.policy == DeactivationPolicyDeactivate, findings); err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("kind", kind).
+		Str("code", code).
+		Str("policy", c.policy).
+		Int("dependents", len(dependents)).
+		Msg("Reference data deactivated; dependents flagged for review")
+	return nil
+}
+
+func singular(table string) string {
+	switch table {
+	case "addresses":
+		return "address"
+	case "entity_addresses":
+		return "entity address"
+	case "entities":
+		return "entity"
+	case "currencies":
+		return "currency"
+	case "countries":
+		return "country"
+	case "instrument_codes":
+		return "instrument code"
+	case "instrument_prices":
+		return "instrument price"
+	case "ssis":
+		return "SSI"
+	default:
+		return table[:len(table)-1]
+	}
+}
+
+// requireActiveCurrency rejects a reference to an inactive currency unless it is unchanged from previous
+func requireActiveCurrency(currencies repository.CurrencyRepository, id, previous *uuid.UUID) error {
+	if id == nil || (previous != nil && *id == *previous) {
+		return nil
+	}
+	currency, err := currencies.FindByID(id.String())
+	if err != nil {
+		return err
+	}
+	if !currency.Active {
+		return fmt.Errorf("%w: currency %s is inactive", ErrInactiveReference, currency.Code)
+	}
+	return nil
+}
+
+// requireActiveCountry rejects a reference to an inactive country unless allowed (already referenced)
+func requireActiveCountry(countries repository.CountryRepository, id *uuid.UUID, allowed map[uuid.UUID]bool) error {
+	if id == nil || allowed[*id] {
+		return nil
+	}
+	country, err := countries.FindByID(id.String())
+	if err != nil {
+		return err
+	}
+	if !country.Active {
+		return fmt.Errorf("%w: country %s is inactive", ErrInactiveReference, country.Code)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// Errors loading the RA list
+var (
+	ErrInvalidRACode     = errors.New("invalid registration authority code")
+	ErrInvalidRAFile     = errors.New("invalid RA list file")
+	ErrRARefreshDisabled = errors.New("RA list refresh is disabled (lei.ralisturl is not set)")
+)
+
+// NormalizeRACode upper-cases an RA code and strips its spaces
+func NormalizeRACode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// RAImportResult summarises an RA list import
+type RAImportResult struct {
+	Read          int               `json:"read"`
+	Imported      int               `json:"imported"`
+	RejectedCount int               `json:"rejected_count"`
+	Rejected      []ImportRejection `json:"rejected"` // the first rejections
+}
+
+// RegistrationAuthorityService maintains the business registers of the GLEIF RA list
+type RegistrationAuthorityService interface {
+	Import(file io.Reader) (*RAImportResult, error)
+	Refresh() (*RAImportResult, error)
+	RefreshEnabled() bool
+	Get(code string) (*domain.RegistrationAuthority, error)
+	List(filter repository.RegistrationAuthorityFilter, limit, offset int) ([]*domain.RegistrationAuthority, int64, error)
+	DescribeLEIRecords(ctx context.Context, records ...*domain.LEIRecord)
+}
+
+type registrationAuthorityService struct {
+	repo    repository.RegistrationAuthorityRepository
+	listURL string
+	client  *gleifClient
+
+	mu        sync.RWMutex
+	summaries map[string]repository.RegistrationAuthoritySummary
+	loadedAt  time.Time
+}
+
+// NewRegistrationAuthorityService creates a new registration authority service; Refresh downloads
+// the RA list from listURL through the GLEIF HTTP client settings
+func NewRegistrationAuthorityService(repo repository.RegistrationAuthorityRepository, listURL string, settings GLEIFHTTPSettings) RegistrationAuthorityService {
+	return &registrationAuthorityService{repo: repo, listURL: strings.TrimSpace(listURL), client: newGLEIFClient(settings)}
+}
+
+// raColumns maps RA list headers, upper-cased without spaces or punctuation, to fields by prefix.
+// The first matching prefix wins, so longer prefixes come first.
+var raColumns = []struct{ prefix, field string }{
+	{"RALISTCODE", "code"},
+	{"RACODE", "code"},
+	{"COUNTRYCODE", "country_code"},
+	{"COUNTRY", "country"},
+	{"JURISDICTION", "jurisdiction"},
+	{"INTERNATIONALNAMEOFREGISTER", "register_name"},
+	{"LOCALNAMEOFREGISTER", "register_local_name"},
+	{"INTERNATIONALNAMEOFORGANI", "organization_name"},
+	{"LOCALNAMEOFORGANI", "organization_local_name"},
+	{"WEBSITE", "website"},
+}
+
+// Import loads the RA list as GLEIF publishes it in CSV (comma, semicolon or tab separated).
+// Registers are upserted by RA code; invalid rows are reported and skipped. Nothing is deleted:
+// LEI records may still name a register GLEIF has since dropped.
+func (s *registrationAuthorityService) Import(file io.Reader) (*RAImportResult, error) {
+	reader := bufio.NewReader(file)
+	header, err := reader.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	firstLine, _, _ := strings.Cut(string(header), "\n")
+
+	records := csv.NewReader(reader)
+	records.Comma = bicFileDelimiter(firstLine)
+	records.FieldsPerRecord = -1
+	records.LazyQuotes = true
+
+	columns, err := records.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", ErrInvalidRAFile, err)
+	}
+	index := map[string]int{}
+	for i, name := range columns {
+		key := strings.Map(func(r rune) rune {
+			if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, strings.ToUpper(strings.TrimPrefix(name, "\ufeff")))
+		for _, column := range raColumns {
+			if strings.HasPrefix(key, column.prefix) {
+				if _, seen := index[column.field]; !seen {
+					index[column.field] = i
+				}
+				break
+			}
+		}
+	}
+	if _, ok := index["code"]; !ok {
+		return nil, fmt.Errorf("%w: header must name the RA code column", ErrInvalidRAFile)
+	}
+	_, hasRegister := index["register_name"]
+	_, hasLocalRegister := index["register_local_name"]
+	if !hasRegister && !hasLocalRegister {
+		return nil, fmt.Errorf("%w: header must name the international or local register name column", ErrInvalidRAFile)
+	}
+
+	importedAt := time.Now().UTC()
+	result := &RAImportResult{Rejected: make([]ImportRejection, 0)}
+	authorities := make(map[string]*domain.RegistrationAuthority)
+	order := make([]string, 0)
+
+	for {
+		record, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line := 0
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			line = parseErr.Line
+		} else if err == nil {
+			line, _ = records.FieldPos(0)
+		}
+		reject := func(format string, args ...any) {
+			result.RejectedCount++
+			if len(result.Rejected) < importMaxRejections {
+				result.Rejected = append(result.Rejected, ImportRejection{Line: line, Error: fmt.Sprintf(format, args...)})
+			}
+		}
+		if err != nil {
+			result.Read++
+			reject("%v", err)
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		result.Read++
+
+		authority := &domain.RegistrationAuthority{
+			Code:                  NormalizeRACode(field("code")),
+			CountryCode:           strings.ToUpper(field("country_code")),
+			Country:               field("country"),
+			Jurisdiction:          field("jurisdiction"),
+			RegisterName:          field("register_name"),
+			RegisterLocalName:     field("register_local_name"),
+			OrganizationName:      field("organization_name"),
+			OrganizationLocalName: field("organization_local_name"),
+			Website:               field("website"),
+			ImportedAt:            importedAt,
+		}
+		for _, name := range []string{authority.RegisterName, authority.RegisterLocalName, authority.OrganizationName, authority.OrganizationLocalName} {
+			if name != "" {
+				authority.Name = name
+				break
+			}
+		}
+		switch {
+		case !registrationAuthorityIDPattern.MatchString(authority.Code):
+			reject("%v %q: must be RA followed by 6 digits", ErrInvalidRACode, authority.Code)
+			continue
+		case authority.Name == "":
+			reject("RA %s: a register or organization name is required", authority.Code)
+			continue
+		case authority.CountryCode != "" && !isLetters(authority.CountryCode, 2):
+			reject("RA %s: country code must be 2 letters", authority.Code)
+			continue
+		}
+
+		// A code listed twice keeps its last row
+		if _, seen := authorities[authority.Code]; !seen {
+			order = append(order, authority.Code)
+		}
+		authorities[authority.Code] = authority
+	}
+
+	batch := make([]*domain.RegistrationAuthority, 0, len(order))
+	for _, code := range order {
+		batch = append(batch, authorities[code])
+	}
+	if err := s.repo.Upsert(batch); err != nil {
+		return nil, err
+	}
+	result.Imported = len(batch)
+
+	s.mu.Lock()
+	s.summaries = nil
+	s.mu.Unlock()
+	return result, nil
+}
+
+// RefreshEnabled reports whether an RA list URL is configured
+func (s *registrationAuthorityService) RefreshEnabled() bool {
+	return s.listURL != ""
+}
+
+// Refresh downloads the RA list from the configured URL and imports it
+func (s *registrationAuthorityService) Refresh() (*RAImportResult, error) {
+	if !s.RefreshEnabled() {
+		return nil, ErrRARefreshDisabled
+	}
+	req, err := http.NewRequest(http.MethodGet, s.listURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download RA list: %w", err)
+	}
+	resp, err := s.client.stream(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download RA list: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download RA list: %s returned %s", s.listURL, resp.Status)
+	}
+	return s.Import(resp.Body)
+}
+
+// Get returns a registration authority
+func (s *registrationAuthorityService) Get(code string) (*domain.RegistrationAuthority, error) {
+	code = NormalizeRACode(code)
+	if !registrationAuthorityIDPattern.MatchString(code) {
+		return nil, fmt.Errorf("%w %q: must be RA followed by 6 digits", ErrInvalidRACode, code)
+	}
+	return s.repo.FindByCode(code)
+}
+
+// List lists registration authorities
+func (s *registrationAuthorityService) List(filter repository.RegistrationAuthorityFilter, limit, offset int) ([]*domain.RegistrationAuthority, int64, error) {
+	return s.repo.FindAll(filter, limit, offset)
+}
+
+// DescribeLEIRecords fills in the name and jurisdiction of each record's registration authority.
+// Codes the list does not have (e.g. RA888888, "other") are left without them; if the list cannot
+// be loaded, records are returned as they are.
+func (s *registrationAuthorityService) DescribeLEIRecords(ctx context.Context, records ...*domain.LEIRecord) {
+	summaries := s.cachedSummaries(ctx)
+	for _, record := range records {
+		if record == nil || record.RegistrationAuthority == "" {
+			continue
+		}
+		summary := summaries[record.RegistrationAuthority]
+		record.RegistrationAuthorityName, record.RegistrationAuthorityJurisdiction = summary.Name, summary.Jurisdiction
+	}
+}
+
+// cachedSummaries returns the RA names and jurisdictions, reloading them after an import or once
+// codeListCacheTTL has passed
+func (s *registrationAuthorityService) cachedSummaries(ctx context.Context) map[string]repository.RegistrationAuthoritySummary {
+	s.mu.RLock()
+	summaries, loadedAt := s.summaries, s.loadedAt
+	s.mu.RUnlock()
+	if summaries != nil && time.Since(loadedAt) < codeListCacheTTL {
+		return summaries
+	}
+
+	loaded, err := s.repo.Summaries()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to load registration authority names")
+		return summaries
+	}
+	s.mu.Lock()
+	s.summaries, s.loadedAt = loaded, time.Now()
+	s.mu.Unlock()
+	return loaded
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/notify"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// ErrRenewalReportDisabled is returned when the scheduled report is run while renewalreport.enabled is off
+var ErrRenewalReportDisabled = errors.New("renewal report is disabled (renewalreport.enabled is false)")
+
+// Renewal report limits
+const (
+	DefaultRenewalWithinDays = 30
+	MaxRenewalWithinDays     = 366
+	renewalReportPageSize    = 1000
+	renewalReportSendTimeout = 2 * time.Minute
+)
+
+// RenewalFilter selects the LEIs due for renewal
+type RenewalFilter struct {
+	WithinDays   int      `json:"within_days"`   // next renewal date from today to this many days ahead
+	Countries    []string `json:"countries"`     // legal address countries; empty: all
+	ManagingLOUs []string `json:"managing_lous"` // managing LOU LEIs; empty: all
+}
+
+// RenewalsDue is a page of LEIs due for renewal
+type RenewalsDue struct {
+	From    time.Time           `json:"from"`
+	To      time.Time           `json:"to"`
+	Total   int64               `json:"total"`
+	Records []*domain.LEIRecord `json:"records"`
+}
+
+// RenewalReportResult summarizes a run of the scheduled report
+type RenewalReportResult struct {
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	Records    int       `json:"records"`
+	Recipients []string  `json:"recipients,omitempty"` // empty when the report was not emailed
+}
+
+// RenewalReportService reports LEIs whose next renewal date is coming up, on request and on a schedule
+type RenewalReportService interface {
+	// Enabled reports whether the scheduled report is configured
+	Enabled() bool
+	// FindDue returns a page of LEIs due for renewal, soonest first
+	FindDue(filter RenewalFilter, limit, offset int) (*RenewalsDue, error)
+	// WriteDueCSV writes every LEI due for renewal as CSV, returning the number of records
+	WriteDueCSV(w io.Writer, filter RenewalFilter) (int, error)
+	// SendReport runs the configured report, emailing it as CSV when recipients are configured
+	SendReport() (*RenewalReportResult, error)
+}
+
+type renewalReportService struct {
+	repo    repository.LEIRepository
+	mailer  notify.Mailer
+	enabled bool
+	filter  RenewalFilter
+	emailTo []string
+}
+
+// NewRenewalReportService creates a new renewal report service
+func NewRenewalReportService(repo repository.LEIRepository, mailer notify.Mailer, cfg config.RenewalReportConfig) RenewalReportService {
+	filter := RenewalFilter{
+		WithinDays:   cfg.WithinDays,
+		Countries:    normalizeCodes(cfg.Countries),
+		ManagingLOUs: normalizeCodes(cfg.ManagingLOUs),
+	}
+	if filter.WithinDays < 1 || filter.WithinDays > MaxRenewalWithinDays {
+		log.Warn().Int("value", cfg.WithinDays).Int("default", DefaultRenewalWithinDays).Msg("Invalid renewalreport.withindays, using default")
+		filter.WithinDays = DefaultRenewalWithinDays
+	}
+	return &renewalReportService{
+		repo:    repo,
+		mailer:  mailer,
+		enabled: cfg.Enabled,
+		filter:  filter,
+		emailTo: cfg.EmailTo,
+	}
+}
+
+func (s *renewalReportService) Enabled() bool {
+	return s.enabled
+}
+
+func (s *renewalReportService) FindDue(filter RenewalFilter, limit, offset int) (*RenewalsDue, error) {
+	from, to := renewalWindow(filter.WithinDays)
+	records, total, err := s.repo.FindRenewalsDue(from, to, normalizeCodes(filter.Countries), normalizeCodes(filter.ManagingLOUs), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &RenewalsDue{From: from, To: to, Total: total, Records: records}, nil
+}
+
+func (s *renewalReportService) WriteDueCSV(w io.Writer, filter RenewalFilter) (int, error) {
+	from, to := renewalWindow(filter.WithinDays)
+	countries, lous := normalizeCodes(filter.Countries), normalizeCodes(filter.ManagingLOUs)
+
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(exportCSVColumns); err != nil {
+		return 0, err
+	}
+	rows := 0
+	for offset := 0; ; offset += renewalReportPageSize {
+		records, _, err := s.repo.FindRenewalsDue(from, to, countries, lous, renewalReportPageSize, offset)
+		if err != nil {
+			return rows, fmt.Errorf("failed to query renewals due: %w", err)
+		}
+		for _, record := range records {
+			if err := csvWriter.Write(exportCSVRow(record)); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+		if len(records) < renewalReportPageSize {
+			break
+		}
+	}
+	csvWriter.Flush()
+	return rows, csvWriter.Error()
+}
+
+func (s *renewalReportService) SendReport() (*RenewalReportResult, error) {
+	if !s.enabled {
+		return nil, ErrRenewalReportDisabled
+	}
+
+	var buf bytes.Buffer
+	rows, err := s.WriteDueCSV(&buf, s.filter)
+	if err != nil {
+		return nil, err
+	}
+	from, to := renewalWindow(s.filter.WithinDays)
+	result := &RenewalReportResult{From: from, To: to, Records: rows}
+	log.Info().Int("records", rows).Int("within_days", s.filter.WithinDays).Msg("Renewal report built")
+	if len(s.emailTo) == 0 {
+		return result, nil
+	}
+
+	subject := fmt.Sprintf("LEI renewals due by %s: %d", to.Format(time.DateOnly), rows)
+	body := fmt.Sprintf("%d LEIs have a next renewal date between %s and %s.\n\nCountries: %s\nManaging LOUs: %s\n\nThe full list is attached as CSV.",
+		rows, from.Format(time.DateOnly), to.Format(time.DateOnly), listOrAll(s.filter.Countries), listOrAll(s.filter.ManagingLOUs))
+	attachment := notify.Attachment{
+		Filename:    fmt.Sprintf("lei-renewals-%s.csv", from.Format(time.DateOnly)),
+		ContentType: "text/csv; charset=UTF-8",
+		Data:        buf.Bytes(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), renewalReportSendTimeout)
+	defer cancel()
+	if err := s.mailer.Send(ctx, s.emailTo, subject, body, attachment); err != nil {
+		return result, fmt.Errorf("failed to email renewal report: %w", err)
+	}
+	result.Recipients = s.emailTo
+	log.Info().Strs("recipients", s.emailTo).Msg("Renewal report emailed")
+	return result, nil
+}
+
+// renewalWindow returns the start of today (UTC) and the end of the day withinDays ahead
+func renewalWindow(withinDays int) (time.Time, time.Time) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	return from, from.AddDate(0, 0, withinDays+1).Add(-time.Nanosecond)
+}
+
+// normalizeCodes upper-cases and trims filter codes, dropping empty ones
+func normalizeCodes(codes []string) []string {
+	var out []string
+	for _, code := range codes {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" {
+			out = append(out, code)
+		}
+	}
+	return out
+}
+
+func listOrAll(values []string) string {
+	if len(values) == 0 {
+		return "all"
+	}
+	return strings.Join(values, ", ")
+}
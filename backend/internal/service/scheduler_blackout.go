@@ -0,0 +1,155 @@
+package service
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// blackoutWindow is a recurring period in which scheduled syncs must not run, such as an EOD batch.
+// A window may cross midnight; it then belongs to the day it starts on.
+type blackoutWindow struct {
+	spec  string
+	days  map[time.Weekday]bool // nil applies every day
+	start int                   // minutes after midnight
+	end   int
+}
+
+// parseBlackoutWindow parses "HH:MM-HH:MM", optionally prefixed by the days it applies to as a
+// list or range (e.g., "Mon-Fri 17:30-19:00", "Sat,Sun 00:00-06:00")
+func parseBlackoutWindow(spec string) (blackoutWindow, error) {
+	w := blackoutWindow{spec: strings.TrimSpace(spec)}
+	times := w.spec
+	if days, rest, found := strings.Cut(w.spec, " "); found {
+		w.days = make(map[time.Weekday]bool)
+		for _, day := range strings.Split(days, ",") {
+			first, last, isRange := strings.Cut(day, "-")
+			from, to := parseWeekday(first), parseWeekday(last)
+			if !isRange {
+				to = from
+			}
+			if from < 0 || to < 0 {
+				return w, fmt.Errorf("invalid day: %s", day)
+			}
+			for d := from; ; d = (d + 1) % 7 {
+				w.days[d] = true
+				if d == to {
+					break
+				}
+			}
+		}
+		times = strings.TrimSpace(rest)
+	}
+
+	from, to, found := strings.Cut(times, "-")
+	if !found {
+		return w, fmt.Errorf("invalid format, expected HH:MM-HH:MM")
+	}
+	startHour, startMinute, err := parseTimeOfDay(from)
+	if err != nil {
+		return w, err
+	}
+	endHour, endMinute, err := parseTimeOfDay(to)
+	if err != nil {
+		return w, err
+	}
+	w.start = startHour*60 + startMinute
+	w.end = endHour*60 + endMinute
+	if w.start == w.end {
+		return w, fmt.Errorf("window is empty")
+	}
+	return w, nil
+}
+
+// endAfter returns when the window covering t ends, if one does
+func (w blackoutWindow) endAfter(t time.Time) (time.Time, bool) {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	// The occurrence starting today, or the one that started yesterday and crosses midnight
+	for _, dayStart := range []time.Time{midnight, midnight.AddDate(0, 0, -1)} {
+		if w.days != nil && !w.days[dayStart.Weekday()] {
+			continue
+		}
+		start := dayStart.Add(time.Duration(w.start) * time.Minute)
+		end := dayStart.Add(time.Duration(w.end) * time.Minute)
+		if w.end < w.start {
+			end = end.AddDate(0, 0, 1)
+		}
+		if !t.Before(start) && t.Before(end) {
+			return end, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseBlackoutWindows parses the configured windows, skipping (and logging) invalid ones
+func parseBlackoutWindows(specs []string) []blackoutWindow {
+	var windows []blackoutWindow
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		w, err := parseBlackoutWindow(spec)
+		if err != nil {
+			log.Warn().Str("value", spec).Err(err).Msg("Invalid scheduler blackout window, ignoring it")
+			continue
+		}
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+// blackoutEnd returns when the blackout covering t ends, following windows that overlap or
+// adjoin, or false if t is outside every window
+func (s *schedulerService) blackoutEnd(t time.Time) (time.Time, bool) {
+	sch, _ := s.schedule()
+	end, blocked := t, false
+	for extended := true; extended; {
+		extended = false
+		for _, w := range sch.blackouts {
+			if until, ok := w.endAfter(end); ok {
+				end, blocked, extended = until, true, true
+			}
+		}
+	}
+	return end, blocked
+}
+
+// waitJitter sleeps for a random part of the configured jitter so environments sharing a schedule
+// don't call GLEIF at the same instant. Returns false if stop was closed first.
+func (s *schedulerService) waitJitter(stop <-chan struct{}) bool {
+	sch, _ := s.schedule()
+	if sch.jitter <= 0 {
+		return true
+	}
+	delay := rand.N(sch.jitter)
+	log.Debug().Dur("delay", delay).Msg("Delaying scheduled sync by jitter")
+	select {
+	case <-time.After(delay):
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// waitOutBlackout waits until no blackout window is in force. Returns false if stop was closed first.
+func (s *schedulerService) waitOutBlackout(stop <-chan struct{}, job string) bool {
+	for {
+		now := time.Now()
+		end, blocked := s.blackoutEnd(now)
+		if !blocked {
+			return true
+		}
+		log.Info().
+			Str("job", job).
+			Time("until", end).
+			Msg("In scheduler blackout window, postponing")
+		select {
+		case <-time.After(end.Sub(now)):
+		case <-stop:
+			return false
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// leaderEvents records, in order, the terms the election loop starts and stops and the lock releases
+type leaderEvents struct {
+	mu     sync.Mutex
+	events []string
+	terms  int
+}
+
+func (e *leaderEvents) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *leaderEvents) snapshot() ([]string, int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.events...), e.terms
+}
+
+// start stands in for the schedule: one loop that runs until its term ends, taking a moment to
+// finish its job in flight
+func (e *leaderEvents) start(stop <-chan struct{}, wg *sync.WaitGroup) {
+	e.mu.Lock()
+	e.terms++
+	term := e.terms
+	e.events = append(e.events, fmt.Sprintf("start %d", term))
+	e.mu.Unlock()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-stop
+		time.Sleep(5 * time.Millisecond)
+		e.add(fmt.Sprintf("stop %d", term))
+	}()
+}
+
+// scriptedLock is a LeaderLock answering TryAcquire and Check from scripts, repeating the last answer
+type scriptedLock struct {
+	events   *leaderEvents
+	mu       sync.Mutex
+	acquires []error // nil acquires, errLockTaken is held by another replica, anything else fails
+	checks   []error
+	tries    int
+	held     bool
+}
+
+var errLockTaken = errors.New("held by another replica")
+
+func nextAnswer(script []error) ([]error, error) {
+	if len(script) == 0 {
+		return script, nil
+	}
+	if len(script) == 1 {
+		return script, script[0]
+	}
+	return script[1:], script[0]
+}
+
+func (l *scriptedLock) TryAcquire(context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tries++
+	var err error
+	l.acquires, err = nextAnswer(l.acquires)
+	switch {
+	case errors.Is(err, errLockTaken):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	l.held = true
+	return true, nil
+}
+
+func (l *scriptedLock) Check(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var err error
+	l.checks, err = nextAnswer(l.checks)
+	return err
+}
+
+func (l *scriptedLock) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held = false
+	l.events.add("release")
+}
+
+func (l *scriptedLock) attempts() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tries
+}
+
+func TestLeaderElectionLoop(t *testing.T) {
+	lost := errors.New("leader lock no longer held by this session")
+	tests := []struct {
+		name      string
+		acquires  []error
+		checks    []error
+		until     func(lock *scriptedLock, terms int) bool
+		wantTerms int
+		wantLog   []string
+	}{
+		{
+			name:     "stands by while another replica leads",
+			acquires: []error{errLockTaken},
+			until:    func(lock *scriptedLock, _ int) bool { return lock.attempts() >= 3 },
+		},
+		{
+			name:      "takes over once the lock is free",
+			acquires:  []error{errLockTaken, errLockTaken, nil},
+			until:     func(_ *scriptedLock, terms int) bool { return terms == 1 },
+			wantTerms: 1,
+			wantLog:   []string{"start 1", "stop 1", "release"},
+		},
+		{
+			name:      "keeps contending after a failed attempt",
+			acquires:  []error{errors.New("connection refused"), nil},
+			until:     func(_ *scriptedLock, terms int) bool { return terms == 1 },
+			wantTerms: 1,
+			wantLog:   []string{"start 1", "stop 1", "release"},
+		},
+		{
+			name:      "steps down when the lock is lost and leads again once it is retaken",
+			acquires:  []error{nil, errLockTaken, nil},
+			checks:    []error{lost, nil},
+			until:     func(_ *scriptedLock, terms int) bool { return terms == 2 },
+			wantTerms: 2,
+			wantLog:   []string{"start 1", "stop 1", "release", "start 2", "stop 2", "release"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &leaderEvents{}
+			lock := &scriptedLock{events: events, acquires: tt.acquires, checks: tt.checks}
+			s := &schedulerService{
+				leaderLock: lock,
+				stopChan:   make(chan struct{}),
+				sched:      schedule{leaderInterval: time.Millisecond},
+			}
+			s.wg.Add(1)
+			go s.leaderElectionLoop(events.start)
+
+			require.Eventually(t, func() bool {
+				_, terms := events.snapshot()
+				return tt.until(lock, terms)
+			}, 5*time.Second, time.Millisecond)
+			close(s.stopChan)
+			s.wg.Wait()
+
+			// Stopping waits for the term's loops before the lock is given up
+			log, terms := events.snapshot()
+			assert.Equal(t, tt.wantTerms, terms)
+			assert.Equal(t, tt.wantLog, log)
+			assert.False(t, lock.held)
+		})
+	}
+}
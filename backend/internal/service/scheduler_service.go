@@ -1,57 +1,184 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/techie2000/axiom/internal/config"
 	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
 )
 
 // SchedulerService handles scheduled jobs for LEI data acquisition
 type SchedulerService interface {
 	Start() error
 	Stop()
+	Wait(ctx context.Context) error
+	Reload(cfg *config.Config)
 	RunDailyFullSync() error
 	RunDailyDeltaSync() error
+	RunDeltaSync(deltaType string) error
+	RunRepexSync() error
 	RunDailyCleanup() error
+	RunAuditPrune() error
+	RunRenewalReport() error
+	RunReconciliation() error
+	RunMICRefresh() error
+	RunCountrySync() error
+	RunCurrencySync() error
+	RunELFRefresh() error
+	RunRARefresh() error
+	RunLOUSync() error
+	RunFXSync() error
 }
 
 type schedulerService struct {
-	leiService LEIService
-	stopChan   chan struct{}
-	running    bool
-	// Parsed schedule configuration
-	deltaSyncInterval time.Duration
-	fullSyncDay       time.Weekday
-	fullSyncHour      int
-	fullSyncMinute    int
-	cleanupHour       int
-	cleanupMinute     int
-	keepFullFiles     int
-	keepDeltaFiles    int
-}
-
-// NewSchedulerService creates a new scheduler service
-func NewSchedulerService(leiService LEIService, cfg *config.Config) SchedulerService {
+	leiService    LEIService
+	exportService ExportService
+	retention     AuditRetentionService
+	renewals      RenewalReportService
+	exchanges     ExchangeService
+	countries     CountryService
+	currencies    CurrencyService
+	elfCodes      ELFService
+	registrars    RegistrationAuthorityService
+	lous          LOUService
+	fx            FXService
+	jobs          *JobRegistry // scheduled runs are registered here so they show up at /admin/tasks
+	stopChan      chan struct{}
+	running       bool
+	wg            sync.WaitGroup // tracks the schedule loops so shutdown can wait for in-flight jobs
+	// Replicas run the schedule only while holding this lock; nil runs it unconditionally
+	leaderLock repository.LeaderLock
+	// Parsed schedule configuration, replaced by Reload. mu guards it and reloaded, which Reload
+	// closes so the loops waiting for their next run work it out again.
+	mu       sync.RWMutex
+	sched    schedule
+	reloaded chan struct{}
+}
+
+// schedule is the parsed schedule configuration
+type schedule struct {
+	deltaSyncInterval   time.Duration
+	deltaType           string // GLEIF delta flavor used by the scheduled delta sync
+	maxCatchUpDeltas    int    // missed publishes applied in order before falling back to a full sync
+	fullSyncDay         time.Weekday
+	fullSyncHour        int
+	fullSyncMinute      int
+	cleanupHour         int
+	cleanupMinute       int
+	auditPruneHour      int
+	auditPruneMinute    int
+	renewalDay          time.Weekday // -1 runs the renewal report daily
+	renewalHour         int
+	renewalMinute       int
+	reconcileEnabled    bool // false when lei.reconciletime is empty
+	reconcileHour       int
+	reconcileMinute     int
+	micRefreshEnabled   bool         // false when referencedata.micrefreshtime or miclisturl is empty
+	micRefreshDay       time.Weekday // -1 refreshes the MIC list daily
+	micRefreshHour      int
+	micRefreshMinute    int
+	countrySyncEnabled  bool         // false when referencedata.countrysynctime or countrylisturl is empty
+	countrySyncDay      time.Weekday // -1 syncs the countries daily
+	countrySyncHour     int
+	countrySyncMinute   int
+	currencySyncEnabled bool         // false when referencedata.currencysynctime or currencylisturl is empty
+	currencySyncDay     time.Weekday // -1 syncs the currencies daily
+	currencySyncHour    int
+	currencySyncMinute  int
+	elfRefreshEnabled   bool         // false when lei.elfrefreshtime or elflisturl is empty
+	elfRefreshDay       time.Weekday // -1 refreshes the ELF code list daily
+	elfRefreshHour      int
+	elfRefreshMinute    int
+	raRefreshEnabled    bool         // false when lei.rarefreshtime or ralisturl is empty
+	raRefreshDay        time.Weekday // -1 refreshes the RA list daily
+	raRefreshHour       int
+	raRefreshMinute     int
+	louSyncEnabled      bool         // false when lei.lousynctime is empty
+	louSyncDay          time.Weekday // -1 syncs the LOUs daily
+	louSyncHour         int
+	louSyncMinute       int
+	fxSyncEnabled       bool         // false when referencedata.fxsynctime or fxrateurl is empty
+	fxSyncDay           time.Weekday // -1 syncs the FX rates daily
+	fxSyncHour          int
+	fxSyncMinute        int
+	keepFullFiles       int
+	keepDeltaFiles      int
+	leaderInterval      time.Duration    // between takeover attempts (standby) and lock checks (leader)
+	blackouts           []blackoutWindow // no syncs start inside these
+	jitter              time.Duration    // scheduled syncs start up to this much later than due
+}
+
+// NewSchedulerService creates a new scheduler service. With a leader lock, the schedule only runs
+// while this replica holds it; with nil, it always runs.
+func NewSchedulerService(leiService LEIService, exportService ExportService, retention AuditRetentionService, renewals RenewalReportService, exchanges ExchangeService, countries CountryService, currencies CurrencyService, elfCodes ELFService, registrars RegistrationAuthorityService, lous LOUService, fx FXService, jobs *JobRegistry, leaderLock repository.LeaderLock, cfg *config.Config) SchedulerService {
 	s := &schedulerService{
-		leiService: leiService,
-		stopChan:   make(chan struct{}),
-		running:    false,
+		leiService:    leiService,
+		exportService: exportService,
+		retention:     retention,
+		renewals:      renewals,
+		exchanges:     exchanges,
+		countries:     countries,
+		currencies:    currencies,
+		elfCodes:      elfCodes,
+		registrars:    registrars,
+		lous:          lous,
+		fx:            fx,
+		jobs:          jobs,
+		leaderLock:    leaderLock,
+		stopChan:      make(chan struct{}),
+		running:       false,
+		reloaded:      make(chan struct{}),
 	}
 
 	// Parse and validate schedule configuration
-	s.parseScheduleConfig(cfg)
+	s.sched.parse(cfg)
 
 	return s
 }
 
-// parseScheduleConfig parses and validates schedule configuration
+// Reload replaces the schedule with that of cfg. Loops waiting for their next run work it out
+// again; a job already running finishes under the schedule it started with.
+func (s *schedulerService) Reload(cfg *config.Config) {
+	var sched schedule
+	sched.parse(cfg)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sched = sched
+	close(s.reloaded)
+	s.reloaded = make(chan struct{})
+	log.Info().Msg("Scheduler schedule reloaded")
+}
+
+// schedule returns the current schedule and a channel the next Reload closes
+func (s *schedulerService) schedule() (schedule, <-chan struct{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sched, s.reloaded
+}
+
+// waitForReload waits for the next Reload, for a loop whose job is disabled. Returns false if stop
+// was closed first.
+func waitForReload(stop <-chan struct{}, reloaded <-chan struct{}) bool {
+	select {
+	case <-reloaded:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// parse parses and validates schedule configuration
 // Falls back to defaults if values are invalid
-func (s *schedulerService) parseScheduleConfig(cfg *config.Config) {
+func (s *schedule) parse(cfg *config.Config) {
 	// Parse delta sync interval (e.g., "1h", "30m")
 	interval, err := time.ParseDuration(cfg.LEI.DeltaSyncInterval)
 	if err != nil || interval < 1*time.Minute {
@@ -67,6 +194,33 @@ func (s *schedulerService) parseScheduleConfig(cfg *config.Config) {
 			Msg("Delta sync interval configured")
 	}
 
+	// Parse delta type (IntraDay, LastDay, LastWeek, LastMonth)
+	deltaType, err := ParseDeltaType(cfg.LEI.DeltaType)
+	if err != nil {
+		log.Warn().
+			Str("value", cfg.LEI.DeltaType).
+			Str("default", DeltaTypeLastWeek).
+			Msg("Invalid delta type, using default")
+		s.deltaType = DeltaTypeLastWeek
+	} else {
+		s.deltaType = deltaType
+		log.Info().
+			Str("delta_type", deltaType).
+			Msg("Delta type configured")
+	}
+
+	// Delta catch-up limit (0 disables catch-up)
+	if cfg.LEI.MaxCatchUpDeltas < 0 {
+		log.Warn().
+			Int("value", cfg.LEI.MaxCatchUpDeltas).
+			Int("default", 21).
+			Msg("Invalid max catch-up deltas, using default")
+		s.maxCatchUpDeltas = 21
+	} else {
+		s.maxCatchUpDeltas = cfg.LEI.MaxCatchUpDeltas
+		log.Info().Int("max_catch_up_deltas", s.maxCatchUpDeltas).Msg("Delta catch-up configured")
+	}
+
 	// Parse full sync day (e.g., "Sunday", "Monday")
 	s.fullSyncDay = parseWeekday(cfg.LEI.FullSyncDay)
 	if s.fullSyncDay < 0 {
@@ -119,6 +273,265 @@ func (s *schedulerService) parseScheduleConfig(cfg *config.Config) {
 			Msg("Cleanup time configured")
 	}
 
+	// Parse audit pruning time (e.g., "03:30")
+	hour, minute, err = parseTimeOfDay(cfg.Audit.PruneTime)
+	if err != nil {
+		log.Warn().
+			Str("value", cfg.Audit.PruneTime).
+			Str("default", "03:30").
+			Err(err).
+			Msg("Invalid audit prune time, using default")
+		s.auditPruneHour = 3
+		s.auditPruneMinute = 30
+	} else {
+		s.auditPruneHour = hour
+		s.auditPruneMinute = minute
+	}
+
+	// Parse renewal report schedule (e.g., "Monday" at "07:00"; no day runs it daily)
+	s.renewalDay = -1
+	if strings.TrimSpace(cfg.RenewalReport.Day) != "" {
+		if s.renewalDay = parseWeekday(cfg.RenewalReport.Day); s.renewalDay < 0 {
+			log.Warn().
+				Str("value", cfg.RenewalReport.Day).
+				Str("default", "Monday").
+				Msg("Invalid renewal report day, using default")
+			s.renewalDay = time.Monday
+		}
+	}
+	hour, minute, err = parseTimeOfDay(cfg.RenewalReport.Time)
+	if err != nil {
+		log.Warn().
+			Str("value", cfg.RenewalReport.Time).
+			Str("default", "07:00").
+			Err(err).
+			Msg("Invalid renewal report time, using default")
+		s.renewalHour = 7
+		s.renewalMinute = 0
+	} else {
+		s.renewalHour = hour
+		s.renewalMinute = minute
+	}
+
+	// Parse MIC list refresh schedule (e.g., "Monday" at "05:00"; no day refreshes daily, no time
+	// or no list URL disables it)
+	s.micRefreshDay = -1
+	if strings.TrimSpace(cfg.ReferenceData.MICRefreshTime) != "" && strings.TrimSpace(cfg.ReferenceData.MICListURL) != "" {
+		s.micRefreshEnabled = true
+		if strings.TrimSpace(cfg.ReferenceData.MICRefreshDay) != "" {
+			if s.micRefreshDay = parseWeekday(cfg.ReferenceData.MICRefreshDay); s.micRefreshDay < 0 {
+				log.Warn().
+					Str("value", cfg.ReferenceData.MICRefreshDay).
+					Str("default", "Monday").
+					Msg("Invalid MIC refresh day, using default")
+				s.micRefreshDay = time.Monday
+			}
+		}
+		hour, minute, err = parseTimeOfDay(cfg.ReferenceData.MICRefreshTime)
+		if err != nil {
+			log.Warn().
+				Str("value", cfg.ReferenceData.MICRefreshTime).
+				Str("default", "05:00").
+				Err(err).
+				Msg("Invalid MIC refresh time, using default")
+			s.micRefreshHour = 5
+			s.micRefreshMinute = 0
+		} else {
+			s.micRefreshHour = hour
+			s.micRefreshMinute = minute
+		}
+	}
+
+	// Parse country sync schedule (e.g., "Monday" at "05:30"; no day syncs daily, no time or no list
+	// URL disables it)
+	s.countrySyncDay = -1
+	if strings.TrimSpace(cfg.ReferenceData.CountrySyncTime) != "" && strings.TrimSpace(cfg.ReferenceData.CountryListURL) != "" {
+		s.countrySyncEnabled = true
+		if strings.TrimSpace(cfg.ReferenceData.CountrySyncDay) != "" {
+			if s.countrySyncDay = parseWeekday(cfg.ReferenceData.CountrySyncDay); s.countrySyncDay < 0 {
+				log.Warn().
+					Str("value", cfg.ReferenceData.CountrySyncDay).
+					Str("default", "Monday").
+					Msg("Invalid country sync day, using default")
+				s.countrySyncDay = time.Monday
+			}
+		}
+		hour, minute, err = parseTimeOfDay(cfg.ReferenceData.CountrySyncTime)
+		if err != nil {
+			log.Warn().
+				Str("value", cfg.ReferenceData.CountrySyncTime).
+				Str("default", "05:30").
+				Err(err).
+				Msg("Invalid country sync time, using default")
+			s.countrySyncHour = 5
+			s.countrySyncMinute = 30
+		} else {
+			s.countrySyncHour = hour
+			s.countrySyncMinute = minute
+		}
+	}
+
+	// Parse currency sync schedule (e.g., "Monday" at "05:45"; no day syncs daily, no time or no
+	// list URL disables it)
+	s.currencySyncDay = -1
+	if strings.TrimSpace(cfg.ReferenceData.CurrencySyncTime) != "" && strings.TrimSpace(cfg.ReferenceData.CurrencyListURL) != "" {
+		s.currencySyncEnabled = true
+		if strings.TrimSpace(cfg.ReferenceData.CurrencySyncDay) != "" {
+			if s.currencySyncDay = parseWeekday(cfg.ReferenceData.CurrencySyncDay); s.currencySyncDay < 0 {
+				log.Warn().
+					Str("value", cfg.ReferenceData.CurrencySyncDay).
+					Str("default", "Monday").
+					Msg("Invalid currency sync day, using default")
+				s.currencySyncDay = time.Monday
+			}
+		}
+		hour, minute, err = parseTimeOfDay(cfg.ReferenceData.CurrencySyncTime)
+		if err != nil {
+			log.Warn().
+				Str("value", cfg.ReferenceData.CurrencySyncTime).
+				Str("default", "05:45").
+				Err(err).
+				Msg("Invalid currency sync time, using default")
+			s.currencySyncHour = 5
+			s.currencySyncMinute = 45
+		} else {
+			s.currencySyncHour = hour
+			s.currencySyncMinute = minute
+		}
+	}
+
+	// Parse ELF code list refresh schedule (e.g., "Monday" at "05:15"; no day refreshes daily, no
+	// time or no list URL disables it)
+	s.elfRefreshDay = -1
+	if strings.TrimSpace(cfg.LEI.ELFRefreshTime) != "" && strings.TrimSpace(cfg.LEI.ELFListURL) != "" {
+		s.elfRefreshEnabled = true
+		if strings.TrimSpace(cfg.LEI.ELFRefreshDay) != "" {
+			if s.elfRefreshDay = parseWeekday(cfg.LEI.ELFRefreshDay); s.elfRefreshDay < 0 {
+				log.Warn().
+					Str("value", cfg.LEI.ELFRefreshDay).
+					Str("default", "Monday").
+					Msg("Invalid ELF refresh day, using default")
+				s.elfRefreshDay = time.Monday
+			}
+		}
+		hour, minute, err = parseTimeOfDay(cfg.LEI.ELFRefreshTime)
+		if err != nil {
+			log.Warn().
+				Str("value", cfg.LEI.ELFRefreshTime).
+				Str("default", "05:15").
+				Err(err).
+				Msg("Invalid ELF refresh time, using default")
+			s.elfRefreshHour = 5
+			s.elfRefreshMinute = 15
+		} else {
+			s.elfRefreshHour = hour
+			s.elfRefreshMinute = minute
+		}
+	}
+
+	// Parse RA list refresh schedule (e.g., "Monday" at "05:20"; no day refreshes daily, no time or
+	// no list URL disables it)
+	s.raRefreshDay = -1
+	if strings.TrimSpace(cfg.LEI.RARefreshTime) != "" && strings.TrimSpace(cfg.LEI.RAListURL) != "" {
+		s.raRefreshEnabled = true
+		if strings.TrimSpace(cfg.LEI.RARefreshDay) != "" {
+			if s.raRefreshDay = parseWeekday(cfg.LEI.RARefreshDay); s.raRefreshDay < 0 {
+				log.Warn().
+					Str("value", cfg.LEI.RARefreshDay).
+					Str("default", "Monday").
+					Msg("Invalid RA refresh day, using default")
+				s.raRefreshDay = time.Monday
+			}
+		}
+		hour, minute, err = parseTimeOfDay(cfg.LEI.RARefreshTime)
+		if err != nil {
+			log.Warn().
+				Str("value", cfg.LEI.RARefreshTime).
+				Str("default", "05:20").
+				Err(err).
+				Msg("Invalid RA refresh time, using default")
+			s.raRefreshHour = 5
+			s.raRefreshMinute = 20
+		} else {
+			s.raRefreshHour = hour
+			s.raRefreshMinute = minute
+		}
+	}
+
+	// Parse LOU sync schedule (e.g., "Monday" at "05:25"; no day syncs daily, no time disables it)
+	s.louSyncDay = -1
+	if strings.TrimSpace(cfg.LEI.LOUSyncTime) != "" {
+		s.louSyncEnabled = true
+		if strings.TrimSpace(cfg.LEI.LOUSyncDay) != "" {
+			if s.louSyncDay = parseWeekday(cfg.LEI.LOUSyncDay); s.louSyncDay < 0 {
+				log.Warn().
+					Str("value", cfg.LEI.LOUSyncDay).
+					Str("default", "Monday").
+					Msg("Invalid LOU sync day, using default")
+				s.louSyncDay = time.Monday
+			}
+		}
+		hour, minute, err = parseTimeOfDay(cfg.LEI.LOUSyncTime)
+		if err != nil {
+			log.Warn().
+				Str("value", cfg.LEI.LOUSyncTime).
+				Str("default", "05:25").
+				Err(err).
+				Msg("Invalid LOU sync time, using default")
+			s.louSyncHour = 5
+			s.louSyncMinute = 25
+		} else {
+			s.louSyncHour = hour
+			s.louSyncMinute = minute
+		}
+	}
+
+	// Parse FX rate sync schedule (e.g., "16:30"; no day syncs daily, no time or no rate URL
+	// disables it)
+	s.fxSyncDay = -1
+	if strings.TrimSpace(cfg.ReferenceData.FXSyncTime) != "" && strings.TrimSpace(cfg.ReferenceData.FXRateURL) != "" {
+		s.fxSyncEnabled = true
+		if strings.TrimSpace(cfg.ReferenceData.FXSyncDay) != "" {
+			if s.fxSyncDay = parseWeekday(cfg.ReferenceData.FXSyncDay); s.fxSyncDay < 0 {
+				log.Warn().
+					Str("value", cfg.ReferenceData.FXSyncDay).
+					Str("default", "daily").
+					Msg("Invalid FX sync day, using default")
+			}
+		}
+		hour, minute, err = parseTimeOfDay(cfg.ReferenceData.FXSyncTime)
+		if err != nil {
+			log.Warn().
+				Str("value", cfg.ReferenceData.FXSyncTime).
+				Str("default", "16:30").
+				Err(err).
+				Msg("Invalid FX sync time, using default")
+			s.fxSyncHour = 16
+			s.fxSyncMinute = 30
+		} else {
+			s.fxSyncHour = hour
+			s.fxSyncMinute = minute
+		}
+	}
+
+	// Parse reconciliation time (e.g., "04:00"; empty disables the reconciliation)
+	if strings.TrimSpace(cfg.LEI.ReconcileTime) != "" {
+		s.reconcileEnabled = true
+		hour, minute, err = parseTimeOfDay(cfg.LEI.ReconcileTime)
+		if err != nil {
+			log.Warn().
+				Str("value", cfg.LEI.ReconcileTime).
+				Str("default", "04:00").
+				Err(err).
+				Msg("Invalid reconciliation time, using default")
+			s.reconcileHour = 4
+			s.reconcileMinute = 0
+		} else {
+			s.reconcileHour = hour
+			s.reconcileMinute = minute
+		}
+	}
+
 	// Parse retention settings
 	if cfg.LEI.KeepFullFiles < 1 {
 		log.Warn().
@@ -141,6 +554,33 @@ func (s *schedulerService) parseScheduleConfig(cfg *config.Config) {
 		s.keepDeltaFiles = cfg.LEI.KeepDeltaFiles
 		log.Info().Int("count", s.keepDeltaFiles).Msg("Delta file retention configured")
 	}
+
+	// Parse leader election check interval (e.g., "15s")
+	interval, err = time.ParseDuration(cfg.Scheduler.LeaderCheckInterval)
+	if err != nil || interval < 1*time.Second {
+		log.Warn().
+			Str("value", cfg.Scheduler.LeaderCheckInterval).
+			Str("default", "15s").
+			Msg("Invalid leader check interval, using default")
+		s.leaderInterval = 15 * time.Second
+	} else {
+		s.leaderInterval = interval
+	}
+
+	// Parse blackout windows (e.g., "Mon-Fri 17:30-19:00") and sync jitter (e.g., "5m")
+	s.blackouts = parseBlackoutWindows(cfg.Scheduler.BlackoutWindows)
+	for _, w := range s.blackouts {
+		log.Info().Str("window", w.spec).Msg("Scheduler blackout window configured")
+	}
+	jitter, err := time.ParseDuration(cfg.Scheduler.Jitter)
+	if err != nil || jitter < 0 {
+		log.Warn().
+			Str("value", cfg.Scheduler.Jitter).
+			Str("default", "0s").
+			Msg("Invalid scheduler jitter, using default")
+	} else {
+		s.jitter = jitter
+	}
 }
 
 // parseWeekday parses a weekday string (e.g., "Sunday", "Monday")
@@ -197,22 +637,126 @@ func (s *schedulerService) Start() error {
 	s.running = true
 	log.Info().Msg("Starting LEI scheduler service")
 
-	// CRITICAL: Reset any stuck RUNNING statuses from previous crashes/restarts
+	if s.leaderLock == nil {
+		s.startSchedule(s.stopChan, &s.wg)
+		return nil
+	}
+
+	// Only the leader runs the schedule; the others stand by to take over
+	s.wg.Add(1)
+	go s.leaderElectionLoop(s.startSchedule)
+
+	return nil
+}
+
+// startSchedule starts the schedule loops, which run until stop is closed, tracking them on wg
+func (s *schedulerService) startSchedule(stop <-chan struct{}, wg *sync.WaitGroup) {
+	// CRITICAL: Reset any stuck RUNNING statuses from previous crashes/restarts (or a failed leader)
 	s.cleanupStuckJobStatuses()
 
 	// CRITICAL: Initialize next_run_at for jobs that don't have it set
 	s.initializeNextRunTimes()
 
+	run := func(loop func(stop <-chan struct{})) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loop(stop)
+		}()
+	}
+
 	// Start goroutine for daily delta sync (runs every hour to check for updates)
-	go s.dailyDeltaSyncLoop()
+	run(s.dailyDeltaSyncLoop)
 
 	// Start goroutine for weekly full sync (runs every Sunday at 2 AM)
-	go s.weeklyFullSyncLoop()
+	run(s.weeklyFullSyncLoop)
 
 	// Start goroutine for daily cleanup (runs daily at 3 AM)
-	go s.dailyCleanupLoop()
+	run(s.dailyCleanupLoop)
 
-	return nil
+	// Start goroutine for scheduled exports of saved views (checks every minute)
+	run(s.scheduledExportLoop)
+
+	// Start goroutine for daily audit pruning (only prunes when a retention period is set)
+	run(s.auditPruneLoop)
+
+	// Start goroutine for the renewal report (only when enabled)
+	run(s.renewalReportLoop)
+
+	// Start goroutine for the daily reconciliation against GLEIF record counts (only when enabled)
+	run(s.reconciliationLoop)
+
+	// Start goroutine for the MIC list refresh (only when enabled)
+	run(s.micRefreshLoop)
+
+	// Start goroutine for the country sync from the remote list (only when enabled)
+	run(s.countrySyncLoop)
+
+	// Start goroutine for the currency sync from the remote list (only when enabled)
+	run(s.currencySyncLoop)
+
+	// Start goroutine for the ELF code list refresh (only when enabled)
+	run(s.elfRefreshLoop)
+
+	// Start goroutine for the RA list refresh (only when enabled)
+	run(s.raRefreshLoop)
+
+	// Start goroutine for the LOU sync (only when enabled)
+	run(s.louSyncLoop)
+
+	// Start goroutine for the FX rate sync (only when enabled)
+	run(s.fxSyncLoop)
+}
+
+// leaderElectionLoop competes for the leader lock and runs the schedule (started by start) while
+// holding it. A standby retries every leaderInterval, so it takes over that long after the leader's
+// database session ends. The leader checks its lock just as often; if the lock is lost (e.g. its
+// connection broke) it stops the schedule, waits for the job in flight and goes back to standing by.
+func (s *schedulerService) leaderElectionLoop(start func(stop <-chan struct{}, wg *sync.WaitGroup)) {
+	sch, _ := s.schedule()
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(sch.leaderInterval)
+	defer ticker.Stop()
+
+	var term chan struct{} // open while leading; closing it stops the schedule
+	var termLoops sync.WaitGroup
+	stepDown := func() {
+		close(term)
+		termLoops.Wait()
+		term = nil
+		s.leaderLock.Release()
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), sch.leaderInterval)
+		if term == nil {
+			acquired, err := s.leaderLock.TryAcquire(ctx)
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to contend for scheduler leadership")
+			} else if acquired {
+				log.Info().Msg("Acquired scheduler leadership, starting the schedule on this replica")
+				term = make(chan struct{})
+				start(term, &termLoops)
+			} else {
+				log.Debug().Msg("Another replica is running the schedule, standing by")
+			}
+		} else if err := s.leaderLock.Check(ctx); err != nil {
+			log.Error().Err(err).Msg("Lost scheduler leadership, stopping the schedule on this replica")
+			stepDown()
+		}
+		cancel()
+
+		select {
+		case <-ticker.C:
+		case <-s.stopChan:
+			if term != nil {
+				log.Info().Msg("Stopping schedule and releasing scheduler leadership")
+				stepDown()
+			}
+			return
+		}
+	}
 }
 
 // Stop stops the scheduler
@@ -226,6 +770,38 @@ func (s *schedulerService) Stop() {
 	close(s.stopChan)
 }
 
+// Wait blocks until the schedule loops have exited (including any job they are running)
+// or ctx is done, whichever comes first
+func (s *schedulerService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler jobs still running at shutdown deadline: %w", ctx.Err())
+	}
+}
+
+// recordRunFailure records on status that its run failed with err. A run interrupted by shutdown
+// is returned to IDLE instead: its file resumes from the checkpoint on the next start.
+func (s *schedulerService) recordRunFailure(status *domain.FileProcessingStatus, err error) {
+	status.Status = "FAILED"
+	status.ErrorMessage = err.Error()
+	switch {
+	case errors.Is(err, ErrProcessingInterrupted):
+		status.Status = "IDLE"
+		status.CurrentSourceFileID = nil
+	case errors.Is(err, ErrProcessingStalled):
+		status.CurrentSourceFileID = nil // Released by the processing watchdog
+	}
+	s.leiService.UpdateProcessingStatus(status)
+}
+
 // cleanupStuckJobStatuses resets any jobs stuck in RUNNING status
 // This handles crash recovery and ensures clean startup
 func (s *schedulerService) cleanupStuckJobStatuses() {
@@ -267,6 +843,7 @@ func (s *schedulerService) cleanupStuckJobStatuses() {
 // initializeNextRunTimes ensures all jobs have next_run_at set
 // This handles cases where jobs completed but next_run_at wasn't saved
 func (s *schedulerService) initializeNextRunTimes() {
+	sch, _ := s.schedule()
 	log.Info().Msg("Initializing next_run_at for jobs if missing")
 
 	// Initialize DAILY_FULL
@@ -293,7 +870,7 @@ func (s *schedulerService) initializeNextRunTimes() {
 			Str("job_type", "DAILY_DELTA").
 			Str("status", deltaStatus.Status).
 			Msg("Setting next_run_at for DAILY_DELTA job")
-		deltaStatus.NextRunAt = calculateNextRun(s.deltaSyncInterval)
+		deltaStatus.NextRunAt = calculateNextRun(sch.deltaSyncInterval)
 		if err := s.leiService.UpdateProcessingStatus(deltaStatus); err != nil {
 			log.Error().Err(err).Msg("Failed to update DAILY_DELTA next_run_at")
 		} else {
@@ -305,7 +882,7 @@ func (s *schedulerService) initializeNextRunTimes() {
 		// DAILY_DELTA job doesn't exist - create it
 		log.Info().Msg("DAILY_DELTA job status doesn't exist, creating...")
 		now := time.Now()
-		nextRun := calculateNextRun(s.deltaSyncInterval)
+		nextRun := calculateNextRun(sch.deltaSyncInterval)
 		newStatus := &domain.FileProcessingStatus{
 			JobType:   "DAILY_DELTA",
 			Status:    "IDLE",
@@ -325,11 +902,23 @@ func (s *schedulerService) initializeNextRunTimes() {
 	log.Info().Msg("Next_run_at initialization completed")
 }
 
+// runScheduled runs fn as a job owned by the scheduler
+func (s *schedulerService) runScheduled(kind, description string, fn func() error) error {
+	return s.jobs.Run(kind, JobOwnerScheduler, description, func(*Job) error { return fn() })
+}
+
 // dailyDeltaSyncLoop runs delta sync at configured interval
-func (s *schedulerService) dailyDeltaSyncLoop() {
-	ticker := time.NewTicker(s.deltaSyncInterval)
+func (s *schedulerService) dailyDeltaSyncLoop(stop <-chan struct{}) {
+	sch, reloaded := s.schedule()
+	ticker := time.NewTicker(sch.deltaSyncInterval)
 	defer ticker.Stop()
 
+	// The startup retry, resume and initial sync below are syncs too
+	if !s.waitOutBlackout(stop, "startup sync") {
+		log.Info().Msg("Stopping delta sync loop")
+		return
+	}
+
 	// First, check for FAILED files that should be retried
 	failedFiles, err := s.leiService.FindRetryableFailedFiles()
 	if err != nil {
@@ -387,6 +976,8 @@ func (s *schedulerService) dailyDeltaSyncLoop() {
 				jobType := "DAILY_FULL"
 				if file.FileType == "DELTA" {
 					jobType = "DAILY_DELTA"
+				} else if file.FileType == SourceFileTypeRepex {
+					jobType = "REPEX"
 				}
 
 				// Update job status to RUNNING when resuming file processing
@@ -422,9 +1013,10 @@ func (s *schedulerService) dailyDeltaSyncLoop() {
 					log.Error().Err(err).Str("file_id", file.ID.String()).Msg("Failed to process pending file")
 					// Update job status to FAILED
 					if jobStatus, getErr := s.leiService.GetProcessingStatus(jobType); getErr == nil {
-						jobStatus.Status = "FAILED"
-						jobStatus.ErrorMessage = err.Error()
-						s.leiService.UpdateProcessingStatus(jobStatus)
+						s.recordRunFailure(jobStatus, err)
+					}
+					if errors.Is(err, ErrProcessingInterrupted) {
+						return // shutting down; the remaining files resume on the next start
 					}
 				} else {
 					// Update job status to COMPLETED on success
@@ -447,12 +1039,12 @@ func (s *schedulerService) dailyDeltaSyncLoop() {
 			log.Error().Err(err).Msg("Failed to count LEI records")
 		} else if count == 0 {
 			log.Info().Msg("Database is empty, running initial full sync instead of delta")
-			if err := s.RunDailyFullSync(); err != nil {
+			if err := s.runScheduled(JobKindLEIFullSync, "Initial full LEI sync", s.RunDailyFullSync); err != nil {
 				log.Error().Err(err).Msg("Failed to run initial full sync")
 			}
 		} else {
 			log.Info().Int64("existing_records", count).Msg("Database has existing records, running delta sync")
-			if err := s.RunDailyDeltaSync(); err != nil {
+			if err := s.runScheduled(JobKindLEIDeltaSync, "Initial delta LEI sync", s.RunDailyDeltaSync); err != nil {
 				log.Error().Err(err).Msg("Failed to run initial delta sync")
 			}
 		}
@@ -461,10 +1053,24 @@ func (s *schedulerService) dailyDeltaSyncLoop() {
 	for {
 		select {
 		case <-ticker.C:
-			if err := s.RunDailyDeltaSync(); err != nil {
+			if !s.waitJitter(stop) {
+				log.Info().Msg("Stopping delta sync loop")
+				return
+			}
+			// A delta due in a blackout window is skipped; the next one catches up
+			if end, blocked := s.blackoutEnd(time.Now()); blocked {
+				log.Info().Time("until", end).Msg("In scheduler blackout window, skipping delta sync")
+				continue
+			}
+			if err := s.runScheduled(JobKindLEIDeltaSync, "Scheduled delta LEI sync", s.RunDailyDeltaSync); err != nil {
 				log.Error().Err(err).Msg("Failed to run scheduled delta sync")
 			}
-		case <-s.stopChan:
+		case <-reloaded:
+			interval := sch.deltaSyncInterval
+			if sch, reloaded = s.schedule(); sch.deltaSyncInterval != interval {
+				ticker.Reset(sch.deltaSyncInterval)
+			}
+		case <-stop:
 			log.Info().Msg("Stopping delta sync loop")
 			return
 		}
@@ -472,15 +1078,17 @@ func (s *schedulerService) dailyDeltaSyncLoop() {
 }
 
 // weeklyFullSyncLoop runs full sync on configured day and time
-func (s *schedulerService) weeklyFullSyncLoop() {
+func (s *schedulerService) weeklyFullSyncLoop(stop <-chan struct{}) {
 	for {
+		sch, reloaded := s.schedule()
+
 		// Calculate next run at configured day/time
 		now := time.Now()
-		nextRun := time.Date(now.Year(), now.Month(), now.Day(), s.fullSyncHour, s.fullSyncMinute, 0, 0, now.Location())
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.fullSyncHour, sch.fullSyncMinute, 0, 0, now.Location())
 
 		// Add days until configured weekday
-		daysUntilTarget := (int(s.fullSyncDay) - int(now.Weekday()) + 7) % 7
-		if daysUntilTarget == 0 && (now.Hour() > s.fullSyncHour || (now.Hour() == s.fullSyncHour && now.Minute() >= s.fullSyncMinute)) {
+		daysUntilTarget := (int(sch.fullSyncDay) - int(now.Weekday()) + 7) % 7
+		if daysUntilTarget == 0 && (now.Hour() > sch.fullSyncHour || (now.Hour() == sch.fullSyncHour && now.Minute() >= sch.fullSyncMinute)) {
 			daysUntilTarget = 7 // Next week if we've already passed the time today
 		}
 		nextRun = nextRun.AddDate(0, 0, daysUntilTarget)
@@ -498,20 +1106,78 @@ func (s *schedulerService) weeklyFullSyncLoop() {
 
 		select {
 		case <-time.After(duration):
-			if err := s.RunDailyFullSync(); err != nil {
+			// A full sync due in a blackout window runs when it ends
+			if !s.waitJitter(stop) || !s.waitOutBlackout(stop, "full sync") {
+				log.Info().Msg("Stopping full sync loop")
+				return
+			}
+			if err := s.runScheduled(JobKindLEIFullSync, "Scheduled full LEI sync", s.RunDailyFullSync); err != nil {
 				log.Error().Err(err).Msg("Failed to run scheduled full sync")
 			}
-		case <-s.stopChan:
+		case <-reloaded:
+		case <-stop:
 			log.Info().Msg("Stopping full sync loop")
 			return
 		}
 	}
 }
 
-// RunDailyDeltaSync downloads and processes delta file
+// RunDailyDeltaSync brings the database up to date with the latest GLEIF publish.
+// Publishes missed while the service was down are applied in publication order; when too
+// many were missed a full sync runs instead. Without catch-up (or if the publish history
+// cannot be read) only the latest delta of the configured type is applied.
 func (s *schedulerService) RunDailyDeltaSync() error {
-	log.Info().Msg("Starting daily delta sync")
+	sch, _ := s.schedule()
+	if sch.maxCatchUpDeltas <= 0 {
+		return s.RunDeltaSync(sch.deltaType)
+	}
+
+	plan, err := s.leiService.PlanDeltaCatchUp(sch.maxCatchUpDeltas)
+	if err != nil {
+		log.Warn().Err(err).Str("delta_type", sch.deltaType).Msg("Could not plan delta catch-up, applying latest delta only")
+		return s.RunDeltaSync(sch.deltaType)
+	}
+	if plan.FullSyncRequired {
+		log.Warn().Str("reason", plan.Reason).Msg("Delta catch-up not possible, running full sync")
+		return s.RunDailyFullSync()
+	}
+
+	log.Info().Int("missed_publishes", len(plan.Deltas)).Msg("Starting daily delta sync")
+	return s.runDeltaJob(func(status *domain.FileProcessingStatus) error {
+		for _, delta := range plan.Deltas {
+			select {
+			case <-s.stopChan:
+				return fmt.Errorf("scheduler stopped during delta catch-up; resumes from publish %s", delta.PublishedAt.Format(time.RFC3339))
+			default:
+			}
+
+			log.Info().Time("published_at", delta.PublishedAt).Msg("Applying missed delta")
+			err := s.applyDelta(status, func() (*domain.SourceFile, error) {
+				return s.leiService.DownloadPendingDelta(delta)
+			})
+			if err != nil {
+				// Later deltas must not be applied over a gap; the next run starts again from this publish
+				return fmt.Errorf("delta published %s: %w", delta.PublishedAt.Format(time.RFC3339), err)
+			}
+		}
+		return nil
+	})
+}
+
+// RunDeltaSync downloads and processes the latest delta file of the given type
+func (s *schedulerService) RunDeltaSync(deltaType string) error {
+	log.Info().Str("delta_type", deltaType).Msg("Starting delta sync")
+	return s.runDeltaJob(func(status *domain.FileProcessingStatus) error {
+		return s.applyDelta(status, func() (*domain.SourceFile, error) {
+			return s.leiService.DownloadDeltaFile(deltaType)
+		})
+	})
+}
 
+// runDeltaJob runs delta work under the DAILY_DELTA processing status, which guards against
+// overlapping delta and full syncs
+func (s *schedulerService) runDeltaJob(work func(status *domain.FileProcessingStatus) error) error {
+	sch, _ := s.schedule()
 	// Update processing status
 	status, err := s.leiService.GetProcessingStatus("DAILY_DELTA")
 	if err != nil {
@@ -544,43 +1210,15 @@ func (s *schedulerService) RunDailyDeltaSync() error {
 		log.Error().Err(err).Msg("Failed to update processing status")
 	}
 
-	// Download delta file
-	sourceFile, err := s.leiService.DownloadDeltaFile()
-	if err != nil {
-		// Check if this is a duplicate file (already processed)
-		if strings.Contains(err.Error(), "duplicate file already processed") {
-			// This is success - no new data to process
-			log.Info().Msg("No new delta file available (duplicate hash detected)")
-			status.Status = "COMPLETED"
-			status.LastSuccessAt = &now
-			status.NextRunAt = calculateNextRun(s.deltaSyncInterval)
-			status.ErrorMessage = ""
-			s.leiService.UpdateProcessingStatus(status)
-			return nil
-		}
-		// Real error
-		status.Status = "FAILED"
-		status.ErrorMessage = err.Error()
-		s.leiService.UpdateProcessingStatus(status)
-		return err
-	}
-
-	// Update status with current file
-	status.CurrentSourceFileID = &sourceFile.ID
-	s.leiService.UpdateProcessingStatus(status)
-
-	// Process file
-	if err := s.leiService.ProcessSourceFile(sourceFile.ID); err != nil {
-		status.Status = "FAILED"
-		status.ErrorMessage = err.Error()
-		s.leiService.UpdateProcessingStatus(status)
+	if err := work(status); err != nil {
+		s.recordRunFailure(status, err)
 		return err
 	}
 
 	// Update status
 	status.Status = "COMPLETED"
 	status.LastSuccessAt = &now
-	status.NextRunAt = calculateNextRun(s.deltaSyncInterval)
+	status.NextRunAt = calculateNextRun(sch.deltaSyncInterval)
 	status.ErrorMessage = ""
 	if err := s.leiService.UpdateProcessingStatus(status); err != nil {
 		log.Error().Err(err).Msg("Failed to update processing status")
@@ -590,6 +1228,26 @@ func (s *schedulerService) RunDailyDeltaSync() error {
 	return nil
 }
 
+// applyDelta downloads one delta file and processes it
+// A file identical to one already processed is skipped: there is no new data in it
+func (s *schedulerService) applyDelta(status *domain.FileProcessingStatus, download func() (*domain.SourceFile, error)) error {
+	sourceFile, err := download()
+	if err != nil {
+		// Check if this is a duplicate file (already processed)
+		if strings.Contains(err.Error(), "duplicate file already processed") {
+			log.Info().Msg("No new delta file available (duplicate hash detected)")
+			return nil
+		}
+		return err
+	}
+
+	// Update status with current file
+	status.CurrentSourceFileID = &sourceFile.ID
+	s.leiService.UpdateProcessingStatus(status)
+
+	return s.leiService.ProcessSourceFile(sourceFile.ID)
+}
+
 // RunDailyFullSync downloads and processes full file
 func (s *schedulerService) RunDailyFullSync() error {
 	log.Info().Msg("Starting daily full sync")
@@ -659,9 +1317,7 @@ func (s *schedulerService) RunDailyFullSync() error {
 	}
 
 	if err := s.leiService.ProcessSourceFileWithResume(sourceFile.ID, resumeLEI); err != nil {
-		status.Status = "FAILED"
-		status.ErrorMessage = err.Error()
-		s.leiService.UpdateProcessingStatus(status)
+		s.recordRunFailure(status, err)
 		return err
 	}
 
@@ -675,39 +1331,106 @@ func (s *schedulerService) RunDailyFullSync() error {
 	}
 
 	log.Info().Msg("Daily full sync completed successfully")
-	return nil
-}
 
-// calculateNextRun calculates the next run time based on interval
-func calculateNextRun(interval time.Duration) *time.Time {
-	next := time.Now().Add(interval)
-	return &next
+	// Reporting exceptions are published alongside the full file; a failure here doesn't fail the LEI sync
+	if err := s.RunRepexSync(); err != nil {
+		log.Error().Err(err).Msg("Reporting exceptions sync failed")
+	}
+	return nil
 }
 
-// calculateNextWeeklyRun calculates next Sunday at 2 AM
-func calculateNextWeeklyRun() *time.Time {
-	now := time.Now()
-	nextRun := time.Date(now.Year(), now.Month(), now.Day(), 2, 0, 0, 0, now.Location())
+// RunRepexSync downloads and loads the reporting exceptions (repex) full file
+func (s *schedulerService) RunRepexSync() error {
+	log.Info().Msg("Starting reporting exceptions sync")
 
-	daysUntilSunday := (7 - int(now.Weekday())) % 7
-	if daysUntilSunday == 0 && now.Hour() >= 2 {
-		daysUntilSunday = 7
+	status, err := s.leiService.GetProcessingStatus("REPEX")
+	if err != nil {
+		status = &domain.FileProcessingStatus{
+			JobType: "REPEX",
+			Status:  "IDLE",
+		}
 	}
-	nextRun = nextRun.AddDate(0, 0, daysUntilSunday)
 
-	if nextRun.Before(now) {
-		nextRun = nextRun.AddDate(0, 0, 7)
+	if status.Status == "RUNNING" {
+		log.Warn().Msg("Reporting exceptions sync already running, skipping")
+		return nil
 	}
 
-	return &nextRun
-}
+	status.Status = "RUNNING"
+	now := time.Now()
+	status.LastRunAt = &now
+	if err := s.leiService.UpdateProcessingStatus(status); err != nil {
+		log.Error().Err(err).Msg("Failed to update processing status")
+	}
 
-// dailyCleanupLoop runs cleanup at configured time daily
-func (s *schedulerService) dailyCleanupLoop() {
+	sourceFile, err := s.leiService.DownloadRepexFile()
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate file already processed") {
+			log.Info().Msg("No new reporting exceptions file available (duplicate hash detected)")
+			status.Status = "COMPLETED"
+			status.LastSuccessAt = &now
+			status.ErrorMessage = ""
+			s.leiService.UpdateProcessingStatus(status)
+			return nil
+		}
+		status.Status = "FAILED"
+		status.ErrorMessage = err.Error()
+		s.leiService.UpdateProcessingStatus(status)
+		return err
+	}
+
+	status.CurrentSourceFileID = &sourceFile.ID
+	s.leiService.UpdateProcessingStatus(status)
+
+	if err := s.leiService.ProcessSourceFile(sourceFile.ID); err != nil {
+		s.recordRunFailure(status, err)
+		return err
+	}
+
+	status.Status = "COMPLETED"
+	status.LastSuccessAt = &now
+	status.ErrorMessage = ""
+	status.CurrentSourceFileID = nil
+	if err := s.leiService.UpdateProcessingStatus(status); err != nil {
+		log.Error().Err(err).Msg("Failed to update processing status")
+	}
+
+	log.Info().Msg("Reporting exceptions sync completed successfully")
+	return nil
+}
+
+// calculateNextRun calculates the next run time based on interval
+func calculateNextRun(interval time.Duration) *time.Time {
+	next := time.Now().Add(interval)
+	return &next
+}
+
+// calculateNextWeeklyRun calculates next Sunday at 2 AM
+func calculateNextWeeklyRun() *time.Time {
+	now := time.Now()
+	nextRun := time.Date(now.Year(), now.Month(), now.Day(), 2, 0, 0, 0, now.Location())
+
+	daysUntilSunday := (7 - int(now.Weekday())) % 7
+	if daysUntilSunday == 0 && now.Hour() >= 2 {
+		daysUntilSunday = 7
+	}
+	nextRun = nextRun.AddDate(0, 0, daysUntilSunday)
+
+	if nextRun.Before(now) {
+		nextRun = nextRun.AddDate(0, 0, 7)
+	}
+
+	return &nextRun
+}
+
+// dailyCleanupLoop runs cleanup at configured time daily
+func (s *schedulerService) dailyCleanupLoop(stop <-chan struct{}) {
 	for {
+		sch, reloaded := s.schedule()
+
 		// Calculate next run at configured time
 		now := time.Now()
-		nextRun := time.Date(now.Year(), now.Month(), now.Day(), s.cleanupHour, s.cleanupMinute, 0, 0, now.Location())
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.cleanupHour, sch.cleanupMinute, 0, 0, now.Location())
 
 		// If we've passed the configured time today, schedule for tomorrow
 		if nextRun.Before(now) {
@@ -722,21 +1445,378 @@ func (s *schedulerService) dailyCleanupLoop() {
 
 		select {
 		case <-time.After(duration):
-			if err := s.RunDailyCleanup(); err != nil {
+			if err := s.runScheduled(JobKindLEICleanup, "Scheduled LEI file cleanup", s.RunDailyCleanup); err != nil {
 				log.Error().Err(err).Msg("Failed to run scheduled cleanup")
 			}
-		case <-s.stopChan:
+		case <-reloaded:
+		case <-stop:
 			log.Info().Msg("Stopping cleanup loop")
 			return
 		}
 	}
 }
 
+// auditPruneLoop prunes audit history past the retention period daily at the configured time
+func (s *schedulerService) auditPruneLoop(stop <-chan struct{}) {
+	if !s.retention.Enabled() {
+		log.Info().Msg("Audit retention disabled, audit history is kept forever")
+		return
+	}
+
+	for {
+		sch, reloaded := s.schedule()
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.auditPruneHour, sch.auditPruneMinute, 0, 0, now.Location())
+		if nextRun.Before(now) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next audit pruning")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindAuditPrune, "Scheduled audit pruning", s.RunAuditPrune); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled audit pruning")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping audit pruning loop")
+			return
+		}
+	}
+}
+
+// renewalReportLoop sends the renewal report on the configured day (or daily) at the configured time
+func (s *schedulerService) renewalReportLoop(stop <-chan struct{}) {
+	if !s.renewals.Enabled() {
+		return
+	}
+
+	for {
+		sch, reloaded := s.schedule()
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.renewalHour, sch.renewalMinute, 0, 0, now.Location())
+		for nextRun.Before(now) || (sch.renewalDay >= 0 && nextRun.Weekday() != sch.renewalDay) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next renewal report")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindRenewalReport, "Scheduled LEI renewal report", s.RunRenewalReport); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled renewal report")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping renewal report loop")
+			return
+		}
+	}
+}
+
+// reconciliationLoop compares local record counts with GLEIF's daily at the configured time
+func (s *schedulerService) reconciliationLoop(stop <-chan struct{}) {
+	for {
+		sch, reloaded := s.schedule()
+		if !sch.reconcileEnabled {
+			if !waitForReload(stop, reloaded) {
+				return
+			}
+			continue
+		}
+
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.reconcileHour, sch.reconcileMinute, 0, 0, now.Location())
+		if nextRun.Before(now) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next LEI reconciliation")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindLEIReconciliation, "Scheduled LEI reconciliation", s.RunReconciliation); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled LEI reconciliation")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping LEI reconciliation loop")
+			return
+		}
+	}
+}
+
+// micRefreshLoop refreshes the ISO 10383 MIC list on the configured day (or daily) at the configured time
+func (s *schedulerService) micRefreshLoop(stop <-chan struct{}) {
+	for {
+		sch, reloaded := s.schedule()
+		if !sch.micRefreshEnabled {
+			if !waitForReload(stop, reloaded) {
+				return
+			}
+			continue
+		}
+
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.micRefreshHour, sch.micRefreshMinute, 0, 0, now.Location())
+		for nextRun.Before(now) || (sch.micRefreshDay >= 0 && nextRun.Weekday() != sch.micRefreshDay) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next MIC list refresh")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindMICRefresh, "Scheduled MIC list refresh", s.RunMICRefresh); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled MIC list refresh")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping MIC list refresh loop")
+			return
+		}
+	}
+}
+
+// countrySyncLoop syncs the countries from the remote ISO 3166 list on the configured day (or daily)
+// at the configured time
+func (s *schedulerService) countrySyncLoop(stop <-chan struct{}) {
+	for {
+		sch, reloaded := s.schedule()
+		if !sch.countrySyncEnabled {
+			if !waitForReload(stop, reloaded) {
+				return
+			}
+			continue
+		}
+
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.countrySyncHour, sch.countrySyncMinute, 0, 0, now.Location())
+		for nextRun.Before(now) || (sch.countrySyncDay >= 0 && nextRun.Weekday() != sch.countrySyncDay) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next country sync")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindCountrySync, "Scheduled ISO 3166 country sync", s.RunCountrySync); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled country sync")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping country sync loop")
+			return
+		}
+	}
+}
+
+// currencySyncLoop syncs the currencies from the remote ISO 4217 list on the configured day (or
+// daily) at the configured time
+func (s *schedulerService) currencySyncLoop(stop <-chan struct{}) {
+	for {
+		sch, reloaded := s.schedule()
+		if !sch.currencySyncEnabled {
+			if !waitForReload(stop, reloaded) {
+				return
+			}
+			continue
+		}
+
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.currencySyncHour, sch.currencySyncMinute, 0, 0, now.Location())
+		for nextRun.Before(now) || (sch.currencySyncDay >= 0 && nextRun.Weekday() != sch.currencySyncDay) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next currency sync")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindCurrencySync, "Scheduled ISO 4217 currency sync", s.RunCurrencySync); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled currency sync")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping currency sync loop")
+			return
+		}
+	}
+}
+
+// elfRefreshLoop refreshes the ELF code list on the configured day (or daily) at the configured time
+func (s *schedulerService) elfRefreshLoop(stop <-chan struct{}) {
+	for {
+		sch, reloaded := s.schedule()
+		if !sch.elfRefreshEnabled {
+			if !waitForReload(stop, reloaded) {
+				return
+			}
+			continue
+		}
+
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.elfRefreshHour, sch.elfRefreshMinute, 0, 0, now.Location())
+		for nextRun.Before(now) || (sch.elfRefreshDay >= 0 && nextRun.Weekday() != sch.elfRefreshDay) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next ELF code list refresh")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindELFRefresh, "Scheduled ELF code list refresh", s.RunELFRefresh); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled ELF code list refresh")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping ELF refresh loop")
+			return
+		}
+	}
+}
+
+// raRefreshLoop refreshes the RA list on the configured day (or daily) at the configured time
+func (s *schedulerService) raRefreshLoop(stop <-chan struct{}) {
+	for {
+		sch, reloaded := s.schedule()
+		if !sch.raRefreshEnabled {
+			if !waitForReload(stop, reloaded) {
+				return
+			}
+			continue
+		}
+
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.raRefreshHour, sch.raRefreshMinute, 0, 0, now.Location())
+		for nextRun.Before(now) || (sch.raRefreshDay >= 0 && nextRun.Weekday() != sch.raRefreshDay) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next RA list refresh")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindRARefresh, "Scheduled RA list refresh", s.RunRARefresh); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled RA list refresh")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping RA refresh loop")
+			return
+		}
+	}
+}
+
+// louSyncLoop syncs the LEI issuer list on the configured day (or daily) at the configured time
+func (s *schedulerService) louSyncLoop(stop <-chan struct{}) {
+	for {
+		sch, reloaded := s.schedule()
+		if !sch.louSyncEnabled {
+			if !waitForReload(stop, reloaded) {
+				return
+			}
+			continue
+		}
+
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.louSyncHour, sch.louSyncMinute, 0, 0, now.Location())
+		for nextRun.Before(now) || (sch.louSyncDay >= 0 && nextRun.Weekday() != sch.louSyncDay) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next LOU sync")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindLOUSync, "Scheduled LEI issuer (LOU) sync", s.RunLOUSync); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled LOU sync")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping LOU sync loop")
+			return
+		}
+	}
+}
+
+// fxSyncLoop syncs the FX reference rates on the configured day (or daily) at the configured time
+func (s *schedulerService) fxSyncLoop(stop <-chan struct{}) {
+	for {
+		sch, reloaded := s.schedule()
+		if !sch.fxSyncEnabled {
+			if !waitForReload(stop, reloaded) {
+				return
+			}
+			continue
+		}
+
+		now := time.Now()
+		nextRun := time.Date(now.Year(), now.Month(), now.Day(), sch.fxSyncHour, sch.fxSyncMinute, 0, 0, now.Location())
+		for nextRun.Before(now) || (sch.fxSyncDay >= 0 && nextRun.Weekday() != sch.fxSyncDay) {
+			nextRun = nextRun.AddDate(0, 0, 1)
+		}
+
+		log.Info().
+			Time("next_run", nextRun).
+			Msg("Scheduled next FX rate sync")
+
+		select {
+		case <-time.After(nextRun.Sub(now)):
+			if err := s.runScheduled(JobKindFXSync, "Scheduled FX rate sync", s.RunFXSync); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled FX rate sync")
+			}
+		case <-reloaded:
+		case <-stop:
+			log.Info().Msg("Stopping FX rate sync loop")
+			return
+		}
+	}
+}
+
+// scheduledExportLoop runs saved-view exports as they fall due
+func (s *schedulerService) scheduledExportLoop(stop <-chan struct{}) {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.exportService.RunDueExports(); err != nil {
+				log.Error().Err(err).Msg("Failed to run scheduled exports")
+			}
+		case <-stop:
+			log.Info().Msg("Stopping scheduled export loop")
+			return
+		}
+	}
+}
+
 // RunDailyCleanup removes old LEI files to free disk space
 func (s *schedulerService) RunDailyCleanup() error {
+	sch, _ := s.schedule()
 	log.Info().Msg("Starting daily file cleanup")
 
-	if err := s.leiService.CleanupOldFiles(s.keepFullFiles, s.keepDeltaFiles); err != nil {
+	if err := s.leiService.CleanupOldFiles(sch.keepFullFiles, sch.keepDeltaFiles); err != nil {
 		log.Error().Err(err).Msg("Failed to cleanup old files")
 		return err
 	}
@@ -744,3 +1824,132 @@ func (s *schedulerService) RunDailyCleanup() error {
 	log.Info().Msg("Daily cleanup completed successfully")
 	return nil
 }
+
+// RunAuditPrune deletes (and archives, if configured) audit rows past the retention period
+func (s *schedulerService) RunAuditPrune() error {
+	_, err := s.retention.Prune()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to prune audit history")
+	}
+	return err
+}
+
+// RunRenewalReport builds the renewal report and emails it to the configured recipients
+func (s *schedulerService) RunRenewalReport() error {
+	_, err := s.renewals.SendReport()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to send renewal report")
+	}
+	return err
+}
+
+// RunReconciliation compares local LEI record counts with GLEIF's and records the discrepancies
+func (s *schedulerService) RunReconciliation() error {
+	_, err := s.leiService.ReconcileWithGLEIF()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to reconcile LEI record counts")
+	}
+	return err
+}
+
+// RunMICRefresh downloads the ISO 10383 MIC list and updates the exchanges
+func (s *schedulerService) RunMICRefresh() error {
+	result, err := s.exchanges.Refresh()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to refresh MIC list")
+		return err
+	}
+	log.Info().
+		Int("imported", result.Imported).
+		Int("rejected", result.RejectedCount).
+		Msg("MIC list refreshed")
+	return nil
+}
+
+// RunCountrySync syncs the countries from the remote ISO 3166 country list
+func (s *schedulerService) RunCountrySync() error {
+	result, err := s.countries.Sync(context.Background(), CountrySourceRemote)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sync countries")
+		return err
+	}
+	log.Info().
+		Int("created", result.Created).
+		Int("updated", result.Updated).
+		Int("rejected", result.RejectedCount).
+		Msg("Countries synced")
+	return nil
+}
+
+// RunCurrencySync syncs the currencies from the remote ISO 4217 list
+func (s *schedulerService) RunCurrencySync() error {
+	result, err := s.currencies.Sync(context.Background(), CurrencySourceRemote)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sync currencies")
+		return err
+	}
+	log.Info().
+		Int("created", result.Created).
+		Int("updated", result.Updated).
+		Int("rejected", result.RejectedCount).
+		Msg("Currencies synced")
+	return nil
+}
+
+// RunELFRefresh downloads the ISO 20275 ELF code list and updates the legal forms
+func (s *schedulerService) RunELFRefresh() error {
+	result, err := s.elfCodes.Refresh()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to refresh ELF code list")
+		return err
+	}
+	log.Info().
+		Int("imported", result.Imported).
+		Int("inactive", result.Inactive).
+		Int("rejected", result.RejectedCount).
+		Msg("ELF code list refreshed")
+	return nil
+}
+
+// RunRARefresh downloads the GLEIF RA list and updates the registration authorities
+func (s *schedulerService) RunRARefresh() error {
+	result, err := s.registrars.Refresh()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to refresh RA list")
+		return err
+	}
+	log.Info().
+		Int("imported", result.Imported).
+		Int("rejected", result.RejectedCount).
+		Msg("RA list refreshed")
+	return nil
+}
+
+// RunLOUSync syncs the LOUs from GLEIF's LEI issuer list
+func (s *schedulerService) RunLOUSync() error {
+	result, err := s.lous.Sync()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sync LOUs")
+		return err
+	}
+	log.Info().
+		Int("listed", result.Listed).
+		Int64("unaccredited", result.Unaccredited).
+		Msg("LOUs synced")
+	return nil
+}
+
+// RunFXSync downloads the latest FX reference rates
+func (s *schedulerService) RunFXSync() error {
+	result, err := s.fx.Sync(false)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sync FX rates")
+		return err
+	}
+	log.Info().
+		Int("rates", result.Rates).
+		Str("to", result.To).
+		Int("rejected", result.RejectedCount).
+		Msg("FX rates synced")
+	return nil
+}
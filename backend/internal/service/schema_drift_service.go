@@ -0,0 +1,195 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// schemaDriftModels lists every GORM model backed by a table managed through migrations. A test
+// checks it against the tables the migrations create, so a new table cannot be left out.
+var schemaDriftModels = []interface{}{
+	&domain.Country{},
+	&domain.Currency{},
+	&domain.Address{},
+	&domain.Entity{},
+	&domain.EntityAddress{},
+	&domain.EntityFieldProvenance{},
+	&domain.Instrument{},
+	&domain.InstrumentCode{},
+	&domain.InstrumentPrice{},
+	&domain.Account{},
+	&domain.SSI{},
+	&domain.AuditLog{},
+	&domain.CountryAudit{},
+	&domain.CurrencyAudit{},
+	&domain.AddressAudit{},
+	&domain.EntityAudit{},
+	&domain.EntityAddressAudit{},
+	&domain.InstrumentAudit{},
+	&domain.InstrumentCodeAudit{},
+	&domain.AccountAudit{},
+	&domain.SSIAudit{},
+	&domain.LEIRecord{},
+	&domain.LEIRecordAudit{},
+	&domain.LEIReportingException{},
+	&domain.LEIQuarantinedRecord{},
+	&domain.LEIQualityMetric{},
+	&domain.LEIReconciliationResult{},
+	&domain.SourceFile{},
+	&domain.FileProcessingStatus{},
+	&domain.DeadLetter{},
+	&domain.DQFinding{},
+	&domain.LEISavedView{},
+	&domain.ScheduledExport{},
+	&domain.IDSequence{},
+	&domain.OutboxEvent{},
+	&domain.EntityDuplicate{},
+	&domain.EntityMerge{},
+	&domain.Onboarding{},
+	&domain.OnboardingEvent{},
+	&domain.MasterDataVersion{},
+	&domain.BICDirectoryEntry{},
+	&domain.SSIDefaultRule{},
+	&domain.Exchange{},
+	&domain.ELFCode{},
+	&domain.RegistrationAuthority{},
+	&domain.LOU{},
+	&domain.HolidayCalendar{},
+	&domain.CalendarHoliday{},
+	&domain.FXRate{},
+	&domain.ImportJob{},
+}
+
+// SchemaDriftService compares GORM model definitions with the live database schema
+type SchemaDriftService interface {
+	Report() (*domain.SchemaDriftReport, error)
+}
+
+type schemaDriftService struct {
+	repo repository.SchemaRepository
+}
+
+// NewSchemaDriftService creates a new schema drift service
+func NewSchemaDriftService(repo repository.SchemaRepository) SchemaDriftService {
+	return &schemaDriftService{repo: repo}
+}
+
+// Report checks every model for missing tables/columns and text size mismatches
+// A column that is narrower in the database than on the model is an ERROR: writes of
+// values the model accepts fail with "value too long for type character varying(n)"
+func (s *schemaDriftService) Report() (*domain.SchemaDriftReport, error) {
+	report := &domain.SchemaDriftReport{
+		GeneratedAt: time.Now().UTC(),
+		Issues:      []domain.SchemaDriftIssue{},
+	}
+
+	for _, model := range schemaDriftModels {
+		table, modelColumns, err := s.repo.ModelColumns(model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		dbColumns, exists, err := s.repo.TableColumns(table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		report.TablesChecked++
+
+		if !exists {
+			addDriftIssue(report, domain.SchemaDriftIssue{
+				Table:    table,
+				Kind:     domain.SchemaDriftMissingTable,
+				Severity: domain.SchemaDriftSeverityError,
+				Message:  fmt.Sprintf("table %s does not exist (model %T)", table, model),
+			})
+			continue
+		}
+
+		for _, issue := range compareColumns(table, modelColumns, dbColumns) {
+			addDriftIssue(report, issue)
+		}
+	}
+
+	return report, nil
+}
+
+func addDriftIssue(report *domain.SchemaDriftReport, issue domain.SchemaDriftIssue) {
+	switch issue.Severity {
+	case domain.SchemaDriftSeverityError:
+		report.Errors++
+	case domain.SchemaDriftSeverityWarning:
+		report.Warnings++
+	}
+	report.Issues = append(report.Issues, issue)
+}
+
+// compareColumns diffs a model's columns against the live table's columns
+func compareColumns(table string, modelColumns, dbColumns []domain.ColumnDefinition) []domain.SchemaDriftIssue {
+	var issues []domain.SchemaDriftIssue
+
+	live := make(map[string]domain.ColumnDefinition, len(dbColumns))
+	for _, col := range dbColumns {
+		live[col.Name] = col
+	}
+	declared := make(map[string]bool, len(modelColumns))
+
+	for _, mc := range modelColumns {
+		declared[mc.Name] = true
+		dc, ok := live[mc.Name]
+		if !ok {
+			issues = append(issues, domain.SchemaDriftIssue{
+				Table:     table,
+				Column:    mc.Name,
+				Kind:      domain.SchemaDriftMissingColumn,
+				Severity:  domain.SchemaDriftSeverityError,
+				ModelType: mc.DataType,
+				ModelSize: mc.Size,
+				Message:   fmt.Sprintf("column %s.%s is declared on the model but missing from the database", table, mc.Name),
+			})
+			continue
+		}
+
+		if mc.Size == 0 || mc.Size == dc.Size {
+			continue
+		}
+		issue := domain.SchemaDriftIssue{
+			Table:     table,
+			Column:    mc.Name,
+			Kind:      domain.SchemaDriftSizeMismatch,
+			Severity:  domain.SchemaDriftSeverityWarning,
+			ModelType: mc.DataType,
+			DBType:    dc.DataType,
+			ModelSize: mc.Size,
+			DBSize:    dc.Size,
+		}
+		switch {
+		case dc.Size == 0:
+			issue.Message = fmt.Sprintf("%s.%s is size %d on the model but unbounded in the database", table, mc.Name, mc.Size)
+		case dc.Size < mc.Size:
+			issue.Severity = domain.SchemaDriftSeverityError
+			issue.Message = fmt.Sprintf("%s.%s is size %d on the model but only %d in the database; longer values will fail with 'value too long'", table, mc.Name, mc.Size, dc.Size)
+		default:
+			issue.Message = fmt.Sprintf("%s.%s is size %d on the model but %d in the database", table, mc.Name, mc.Size, dc.Size)
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, dc := range dbColumns {
+		if declared[dc.Name] {
+			continue
+		}
+		issues = append(issues, domain.SchemaDriftIssue{
+			Table:    table,
+			Column:   dc.Name,
+			Kind:     domain.SchemaDriftExtraColumn,
+			Severity: domain.SchemaDriftSeverityInfo,
+			DBType:   dc.DataType,
+			DBSize:   dc.Size,
+			Message:  fmt.Sprintf("column %s.%s exists in the database but not on the model", table, dc.Name),
+		})
+	}
+
+	return issues
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/migrations"
+	"gorm.io/gorm/schema"
+)
+
+// tablesWithoutModel are tables the migrations create that no GORM model maps, and why
+var tablesWithoutModel = map[string]string{
+	"change_counter_offsets": "bookkeeping of the freshness repository, read and written with raw SQL",
+}
+
+var (
+	createTablePattern = regexp.MustCompile(`(?i)create\s+table\s+(?:if\s+not\s+exists\s+)?([a-z_][a-z0-9_.]*)`)
+	dropTablePattern   = regexp.MustCompile(`(?i)drop\s+table\s+(?:if\s+exists\s+)?([a-z_][a-z0-9_.]*)`)
+)
+
+// migratedTables returns the tables the up migrations leave behind, applied in version order
+func migratedTables(t *testing.T) map[string]bool {
+	t.Helper()
+	names, err := fs.Glob(migrations.FS, "*.up.sql")
+	require.NoError(t, err)
+	sort.Strings(names)
+
+	tables := make(map[string]bool)
+	for _, name := range names {
+		sql, err := fs.ReadFile(migrations.FS, name)
+		require.NoError(t, err)
+		for _, match := range createTablePattern.FindAllStringSubmatch(string(sql), -1) {
+			tables[strings.ToLower(match[1])] = true
+		}
+		for _, match := range dropTablePattern.FindAllStringSubmatch(string(sql), -1) {
+			delete(tables, strings.ToLower(match[1]))
+		}
+	}
+	return tables
+}
+
+// TestSchemaDriftModelsCoverMigrations fails when a migration creates a table without its model
+// being added to schemaDriftModels, or a listed model has no table
+func TestSchemaDriftModelsCoverMigrations(t *testing.T) {
+	modelTables := make(map[string]bool, len(schemaDriftModels))
+	cache := &sync.Map{}
+	for _, model := range schemaDriftModels {
+		s, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		require.NoError(t, err, "model %T", model)
+		modelTables[s.Table] = true
+	}
+
+	for table := range migratedTables(t) {
+		if _, ok := tablesWithoutModel[table]; ok {
+			continue
+		}
+		assert.True(t, modelTables[table], "table %s is created by a migration but its model is not in schemaDriftModels", table)
+	}
+	migrated := migratedTables(t)
+	for table := range modelTables {
+		assert.True(t, migrated[table], "model table %s is in schemaDriftModels but no migration creates it", table)
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// SSI overlap policies, applied when a create/update would leave two active SSIs for the same
+// entity, settlement currency, instrument and settlement type with overlapping validity windows
+const (
+	SSIOverlapPolicyReject = "reject" // refuse the write (default)
+	SSIOverlapPolicyFlag   = "flag"   // save it, flag the SSI for review and raise a DQ finding
+)
+
+// ErrSSIOverlap is returned under the reject policy when an SSI would overlap another
+var ErrSSIOverlap = errors.New("SSI overlaps another SSI")
+
+// IsValidSSIOverlapPolicy reports whether policy is one of the SSIOverlapPolicy constants
+func IsValidSSIOverlapPolicy(policy string) bool {
+	return policy == SSIOverlapPolicyReject || policy == SSIOverlapPolicyFlag
+}
+
+// ListConflicts reports the pairs of active SSIs that overlap, e.g. written before the overlap
+// check existed, under the flag policy, or activated by an onboarding approval
+func (s *ssiService) ListConflicts(limit, offset int) ([]*domain.SSIConflict, int64, error) {
+	conflicts, total, err := s.repo.FindConflicts(limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, conflict := range conflicts {
+		if err := decryptAccounts(conflict.SSI, conflict.Other); err != nil {
+			return nil, 0, err
+		}
+	}
+	return conflicts, total, nil
+}
+
+// checkOverlap returns the active SSIs ssi would overlap; under the reject policy, any are an
+// ErrSSIOverlap. An inactive SSI conflicts with nothing.
+func (s *ssiService) checkOverlap(ssi *domain.SSI, active bool) ([]*domain.SSI, error) {
+	if !active {
+		return nil, nil
+	}
+	overlapping, err := s.repo.FindOverlapping(ssi)
+	if err != nil || len(overlapping) == 0 {
+		return nil, err
+	}
+	if err := decryptAccounts(overlapping...); err != nil {
+		return nil, err
+	}
+	if s.overlapPolicy == SSIOverlapPolicyReject {
+		return nil, fmt.Errorf("%w: %s", ErrSSIOverlap, overlapDescription(overlapping))
+	}
+	return overlapping, nil
+}
+
+// flagOverlap raises a DQ finding on a saved SSI that overlaps others, flagging it for review.
+// The SSI is already saved, so a failure is logged rather than returned.
+func (s *ssiService) flagOverlap(ctx context.Context, ssi *domain.SSI, overlapping []*domain.SSI) {
+	if len(overlapping) == 0 {
+		return
+	}
+	cause := "overlaps " + overlapDescription(overlapping)
+	finding := &domain.DQFinding{
+		RuleCode:    domain.DQRuleOverlappingSSI,
+		Severity:    domain.DQSeverityWarning,
+		TargetTable: "ssis",
+		TargetID:    ssi.ID,
+		Message:     fmt.Sprintf("SSI %s %s; either could be picked for a settlement", ssi.ID, cause),
+		Cause:       cause,
+		Status:      domain.DQFindingStatusOpen,
+	}
+	if _, err := s.findings.RecordFindings(ctx, []*domain.DQFinding{finding}); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("ssi_id", ssi.ID.String()).Msg("Failed to flag overlapping SSI")
+		return
+	}
+	ssi.ReviewRequired = true
+	ssi.ReviewReason = cause
+}
+
+// overlapDescription names overlapping SSIs for messages, e.g. "SSI 1b2c…, 3d4e…"
+func overlapDescription(overlapping []*domain.SSI) string {
+	ids := make([]string, len(overlapping))
+	for i, other := range overlapping {
+		ids[i] = other.ID.String()
+	}
+	return "SSI " + strings.Join(ids, ", ")
+}
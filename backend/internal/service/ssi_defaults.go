@@ -0,0 +1,191 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidSSIDefaultRule is returned when an SSI default rule fails validation
+	ErrInvalidSSIDefaultRule = errors.New("invalid SSI default rule")
+	// ErrSSIDefaultRuleExists is returned when another rule already covers the market and currency
+	ErrSSIDefaultRuleExists = errors.New("an SSI default rule for this market and currency already exists")
+)
+
+// SSI fields a default rule can fill
+const (
+	SSIDefaultFieldSettlementType    = "settlement_type"
+	SSIDefaultFieldPlaceOfSettlement = "place_of_settlement"
+	SSIDefaultFieldCutoffTime        = "cutoff_time"
+	SSIDefaultFieldCutoffTimezone    = "cutoff_timezone"
+)
+
+// SSIDefaultsPreview shows what the defaults would fill into an SSI
+type SSIDefaultsPreview struct {
+	Rule    *domain.SSIDefaultRule `json:"rule"`    // nil if no rule matches
+	Applied map[string]string      `json:"applied"` // field -> value filled
+	SSI     *domain.SSI            `json:"ssi"`     // the SSI as it would be created
+}
+
+// SSIDefaultsService maintains the SSI defaults by market and currency
+type SSIDefaultsService interface {
+	Create(rule *domain.SSIDefaultRule) error
+	Get(id string) (*domain.SSIDefaultRule, error)
+	List(limit, offset int) ([]*domain.SSIDefaultRule, int64, error)
+	Update(rule *domain.SSIDefaultRule) error
+	Delete(id string) error
+	Preview(ssi *domain.SSI) (*SSIDefaultsPreview, error)
+}
+
+type ssiDefaultsService struct {
+	repo       repository.SSIDefaultRuleRepository
+	currencies repository.CurrencyRepository
+}
+
+// NewSSIDefaultsService creates a new SSI defaults service
+func NewSSIDefaultsService(repo repository.SSIDefaultRuleRepository, currencies repository.CurrencyRepository) SSIDefaultsService {
+	return &ssiDefaultsService{repo: repo, currencies: currencies}
+}
+
+func (s *ssiDefaultsService) Create(rule *domain.SSIDefaultRule) error {
+	if err := s.validate(rule, nil); err != nil {
+		return err
+	}
+	return ssiDefaultRuleWriteError(s.repo.Create(rule))
+}
+
+func (s *ssiDefaultsService) Get(id string) (*domain.SSIDefaultRule, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *ssiDefaultsService) List(limit, offset int) ([]*domain.SSIDefaultRule, int64, error) {
+	return s.repo.FindAll(limit, offset)
+}
+
+func (s *ssiDefaultsService) Update(rule *domain.SSIDefaultRule) error {
+	existing, err := s.repo.FindByID(rule.ID.String())
+	if err != nil {
+		return err
+	}
+	if err := s.validate(rule, existing); err != nil {
+		return err
+	}
+	rule.CreatedAt = existing.CreatedAt
+	return ssiDefaultRuleWriteError(s.repo.Update(rule))
+}
+
+func (s *ssiDefaultsService) Delete(id string) error {
+	return s.repo.Delete(id)
+}
+
+// Preview applies the defaults to a copy of an SSI, as creating it would
+func (s *ssiDefaultsService) Preview(ssi *domain.SSI) (*SSIDefaultsPreview, error) {
+	preview := *ssi
+	rule, applied, err := applySSIDefaults(s.repo, &preview)
+	if err != nil {
+		return nil, err
+	}
+	return &SSIDefaultsPreview{Rule: rule, Applied: applied, SSI: &preview}, nil
+}
+
+// validate normalizes a rule and checks it: it must be scoped to a market or a currency (an
+// active one, unless unchanged) and set at least one valid default
+func (s *ssiDefaultsService) validate(rule, existing *domain.SSIDefaultRule) error {
+	if rule.Market != nil {
+		market := strings.ToUpper(strings.TrimSpace(*rule.Market))
+		rule.Market = &market
+		if market == "" {
+			rule.Market = nil
+		}
+	}
+	if rule.Market == nil && rule.CurrencyID == nil {
+		return fmt.Errorf("%w: market or currency_id is required", ErrInvalidSSIDefaultRule)
+	}
+	var previousCurrency *uuid.UUID
+	if existing != nil {
+		previousCurrency = existing.CurrencyID
+	}
+	if err := requireActiveCurrency(s.currencies, rule.CurrencyID, previousCurrency); errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: unknown currency_id", ErrInvalidSSIDefaultRule)
+	} else if err != nil {
+		return err
+	}
+
+	rule.PlaceOfSettlement = NormalizeBIC(rule.PlaceOfSettlement)
+	if rule.PlaceOfSettlement != "" {
+		if err := ValidateBIC(rule.PlaceOfSettlement); err != nil {
+			return fmt.Errorf("%w: place_of_settlement: %v", ErrInvalidSSIDefaultRule, err)
+		}
+	}
+	switch rule.SettlementType {
+	case "", domain.SettlementTypeDVP, domain.SettlementTypeFOP, domain.SettlementTypeRVP, domain.SettlementTypeDAP:
+	default:
+		return fmt.Errorf("%w: settlement_type must be DVP, FOP, RVP or DAP", ErrInvalidSSIDefaultRule)
+	}
+	if rule.CutoffTime != "" {
+		if _, err := time.Parse("15:04", rule.CutoffTime); err != nil {
+			return fmt.Errorf("%w: cutoff_time must be HH:MM", ErrInvalidSSIDefaultRule)
+		}
+		if rule.CutoffTimezone == "" {
+			rule.CutoffTimezone = "UTC"
+		}
+	}
+	if rule.CutoffTimezone != "" {
+		if rule.CutoffTime == "" {
+			return fmt.Errorf("%w: cutoff_timezone needs a cutoff_time", ErrInvalidSSIDefaultRule)
+		}
+		if _, err := time.LoadLocation(rule.CutoffTimezone); err != nil {
+			return fmt.Errorf("%w: unknown cutoff_timezone %q", ErrInvalidSSIDefaultRule, rule.CutoffTimezone)
+		}
+	}
+	if rule.PlaceOfSettlement == "" && rule.SettlementType == "" && rule.CutoffTime == "" {
+		return fmt.Errorf("%w: set at least one of place_of_settlement, settlement_type and cutoff_time", ErrInvalidSSIDefaultRule)
+	}
+	return nil
+}
+
+func ssiDefaultRuleWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrSSIDefaultRuleExists
+	}
+	return err
+}
+
+// applySSIDefaults fills the empty default fields of an SSI from the most specific active rule for
+// its instrument's market and its settlement currency, and records the rule on the SSI if it
+// filled anything. Returns the rule (nil if none matches) and the fields it filled.
+func applySSIDefaults(rules repository.SSIDefaultRuleRepository, ssi *domain.SSI) (*domain.SSIDefaultRule, map[string]string, error) {
+	applied := make(map[string]string)
+	rule, err := rules.FindBest(ssi.InstrumentID, ssi.SettlementCurrencyID)
+	if err != nil || rule == nil {
+		return nil, applied, err
+	}
+
+	if ssi.SettlementType == "" && rule.SettlementType != "" {
+		ssi.SettlementType = rule.SettlementType
+		applied[SSIDefaultFieldSettlementType] = string(rule.SettlementType)
+	}
+	if ssi.PlaceOfSettlement == "" && rule.PlaceOfSettlement != "" {
+		ssi.PlaceOfSettlement = rule.PlaceOfSettlement
+		applied[SSIDefaultFieldPlaceOfSettlement] = rule.PlaceOfSettlement
+	}
+	// The cutoff time and its timezone go together
+	if ssi.CutoffTime == "" && rule.CutoffTime != "" {
+		ssi.CutoffTime, ssi.CutoffTimezone = rule.CutoffTime, rule.CutoffTimezone
+		applied[SSIDefaultFieldCutoffTime] = rule.CutoffTime
+		applied[SSIDefaultFieldCutoffTimezone] = rule.CutoffTimezone
+	}
+	if len(applied) > 0 {
+		ssi.DefaultRuleID = &rule.ID
+	}
+	return rule, applied, nil
+}
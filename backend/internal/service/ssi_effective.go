@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Errors resolving the SSI in effect for a settlement
+var (
+	ErrUnknownCurrency = errors.New("unknown currency")
+	ErrNoEffectiveSSI  = errors.New("no SSI in effect")
+	ErrAmbiguousSSI    = errors.New("more than one SSI in effect")
+)
+
+// SSILookup describes a settlement to find the standing instruction for
+type SSILookup struct {
+	EntityID       uuid.UUID
+	Currency       string     // ISO 4217 code of the settlement currency
+	InstrumentID   *uuid.UUID // optional; an SSI for this instrument wins over one for any instrument
+	SettlementType string     // optional, e.g. DVP
+	AsOf           time.Time  // the settlement date or time
+	KnownAt        *time.Time // optional; resolve from the SSIs as they were recorded then
+}
+
+// AmbiguousSSIError lists the SSIs equally in effect for a settlement
+type AmbiguousSSIError struct {
+	Candidates []*domain.SSI
+}
+
+func (e *AmbiguousSSIError) Error() string {
+	return fmt.Sprintf("%s: %d candidates", ErrAmbiguousSSI, len(e.Candidates))
+}
+
+func (e *AmbiguousSSIError) Unwrap() error {
+	return ErrAmbiguousSSI
+}
+
+// ResolveEffective returns the SSI that applies to a settlement: the active SSI of the entity in
+// the settlement currency whose validity window contains AsOf, preferring one specific to the
+// instrument over one for any instrument. More than one such SSI is an *AmbiguousSSIError. With
+// KnownAt, the SSIs are taken as they were recorded then, reproducing an earlier resolution.
+func (s *ssiService) ResolveEffective(lookup SSILookup) (*domain.SSI, error) {
+	code := strings.ToUpper(lookup.Currency)
+	currency, err := s.currencies.FindByCode(code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.repo.FindEffective(repository.SSIEffectiveFilter{
+		EntityID:       lookup.EntityID,
+		CurrencyID:     currency.ID,
+		InstrumentID:   lookup.InstrumentID,
+		SettlementType: strings.ToUpper(lookup.SettlementType),
+		KnownAt:        lookup.KnownAt,
+	}, lookup.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	if err := decryptAccounts(candidates...); err != nil {
+		return nil, err
+	}
+
+	// Instrument-specific SSIs are listed first; when there are any, they replace the generic ones
+	if len(candidates) > 0 && candidates[0].InstrumentID != nil {
+		specific := 0
+		for specific < len(candidates) && candidates[specific].InstrumentID != nil {
+			specific++
+		}
+		candidates = candidates[:specific]
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, ErrNoEffectiveSSI
+	case 1:
+		return candidates[0], nil
+	default:
+		return nil, &AmbiguousSSIError{Candidates: candidates}
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/fieldcrypt"
+)
+
+// SSI beneficiary accounts are encrypted at rest with the cipher fieldcrypt.Use installs. The
+// services encrypt them just before an SSI is written and decrypt every SSI they read, so the
+// repositories only see accounts as stored and callers only in the clear.
+
+// encryptAccount encrypts the beneficiary account of an SSI about to be written; restore puts the
+// plain account back once the write is done
+func encryptAccount(ssi *domain.SSI) (restore func(), err error) {
+	plain := ssi.BeneficiaryAccount
+	stored, err := fieldcrypt.Current().Encrypt(plain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt beneficiary account: %w", err)
+	}
+	ssi.BeneficiaryAccount = stored
+	return func() { ssi.BeneficiaryAccount = plain }, nil
+}
+
+// decryptAccounts decrypts the beneficiary accounts of SSIs as read; accounts stored before
+// encryption was enabled are left as they are
+func decryptAccounts(ssis ...*domain.SSI) error {
+	for _, ssi := range ssis {
+		if ssi == nil {
+			continue
+		}
+		account, err := fieldcrypt.Current().Decrypt(ssi.BeneficiaryAccount)
+		if err != nil {
+			return fmt.Errorf("SSI %s beneficiary account: %w", ssi.ID, err)
+		}
+		ssi.BeneficiaryAccount = account
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/fieldcrypt"
+	"github.com/techie2000/axiom/internal/repository"
+	"gorm.io/gorm"
+)
+
+// memorySSIs is an SSIRepository over SSIs held in memory as stored
+type memorySSIs struct {
+	repository.SSIRepository
+	stored map[uuid.UUID]domain.SSI
+}
+
+func (m *memorySSIs) Create(_ context.Context, ssi *domain.SSI) error {
+	ssi.ID = uuid.New()
+	m.stored[ssi.ID] = *ssi
+	return nil
+}
+
+func (m *memorySSIs) Update(_ context.Context, ssi *domain.SSI) error {
+	m.stored[ssi.ID] = *ssi
+	return nil
+}
+
+func (m *memorySSIs) FindByID(id string) (*domain.SSI, error) {
+	ssi, ok := m.stored[uuid.MustParse(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &ssi, nil
+}
+
+func (m *memorySSIs) FindAll(limit, offset int) ([]*domain.SSI, error) {
+	var ssis []*domain.SSI
+	for _, ssi := range m.stored {
+		ssis = append(ssis, &ssi)
+	}
+	return ssis, nil
+}
+
+func (m *memorySSIs) FindConflicts(limit, offset int) ([]*domain.SSIConflict, int64, error) {
+	var ssis []*domain.SSI
+	for _, ssi := range m.stored {
+		ssis = append(ssis, &ssi)
+	}
+	return []*domain.SSIConflict{{SSI: ssis[0], Other: ssis[1]}}, 1, nil
+}
+
+// useTestCipher installs a cipher with a single local key until the test ends
+func useTestCipher(t *testing.T) {
+	t.Helper()
+	keys, err := fieldcrypt.NewLocalKeys([]string{"k1:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))})
+	require.NoError(t, err)
+	fieldcrypt.Use(fieldcrypt.New(keys))
+	t.Cleanup(func() { fieldcrypt.Use(nil) })
+}
+
+func TestSSIAccountEncryption(t *testing.T) {
+	useTestCipher(t)
+	repo := &memorySSIs{stored: map[uuid.UUID]domain.SSI{}}
+	svc := &ssiService{repo: repo}
+
+	ssi := &domain.SSI{BeneficiaryName: "Acme", BeneficiaryAccount: "GB82WEST12345698765432"}
+	require.NoError(t, svc.save(context.Background(), ssi, repo.Create))
+
+	assert.Equal(t, "GB82WEST12345698765432", ssi.BeneficiaryAccount, "the caller keeps the plain account")
+	stored := repo.stored[ssi.ID].BeneficiaryAccount
+	assert.True(t, fieldcrypt.IsEncrypted(stored), "stored as %q", stored)
+	assert.NotContains(t, stored, "12345698765432")
+
+	got, err := svc.GetByID(ssi.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "GB82WEST12345698765432", got.BeneficiaryAccount)
+
+	// Accounts stored before encryption was enabled are read as they are
+	plain := &domain.SSI{BeneficiaryName: "Legacy", BeneficiaryAccount: "12345678"}
+	require.NoError(t, repo.Create(context.Background(), plain))
+
+	all, err := svc.GetAll(-1, 0)
+	require.NoError(t, err)
+	accounts := []string{all[0].BeneficiaryAccount, all[1].BeneficiaryAccount}
+	assert.ElementsMatch(t, []string{"GB82WEST12345698765432", "12345678"}, accounts)
+
+	conflicts, _, err := svc.ListConflicts(10, 0)
+	require.NoError(t, err)
+	accounts = []string{conflicts[0].SSI.BeneficiaryAccount, conflicts[0].Other.BeneficiaryAccount}
+	assert.ElementsMatch(t, []string{"GB82WEST12345698765432", "12345678"}, accounts)
+}
+
+func TestSSIAccountDecryptionWithoutKeys(t *testing.T) {
+	useTestCipher(t)
+	repo := &memorySSIs{stored: map[uuid.UUID]domain.SSI{}}
+	svc := &ssiService{repo: repo}
+	ssi := &domain.SSI{BeneficiaryAccount: "GB82WEST12345698765432"}
+	require.NoError(t, svc.save(context.Background(), ssi, repo.Create))
+
+	fieldcrypt.Use(nil)
+	_, err := svc.GetByID(ssi.ID.String())
+	assert.ErrorIs(t, err, fieldcrypt.ErrNoKeys)
+}
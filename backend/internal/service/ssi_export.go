@@ -0,0 +1,339 @@
+package service
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/techie2000/axiom/internal/domain"
+)
+
+// ISO 20022 messages an SSI can be exported as
+const (
+	SSIExportPacs008 = "pacs.008" // creditor side of a customer credit transfer, for cash
+	SSIExportSese023 = "sese.023" // receiving settlement parties of a securities settlement instruction
+)
+
+// ssiExportNamespaces are the message versions exported
+var ssiExportNamespaces = map[string]string{
+	SSIExportPacs008: "urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08",
+	SSIExportSese023: "urn:iso:std:iso:20022:tech:xsd:sese.023.001.09",
+}
+
+// ErrUnsupportedSSIExport is returned for an export to a message that is not supported
+var ErrUnsupportedSSIExport = errors.New("unsupported SSI export message")
+
+// SSIExport is an SSI rendered as an ISO 20022 message fragment
+type SSIExport struct {
+	Message  string // SSIExportPacs008 or SSIExportSese023
+	Document []byte // the XML, with its declaration
+}
+
+// SSIExportMaskedFields returns the fields of masked that the ISO 20022 export of an SSI carries,
+// sorted: the SSI's own and those of its entity and the entity's addresses. XML is not masked, so
+// the export is not for callers who get any of them masked.
+func SSIExportMaskedFields(masked map[string]bool) []string {
+	return maskedColumnNames(masked, reflect.TypeOf(domain.SSI{}), reflect.TypeOf(domain.Entity{}), reflect.TypeOf(domain.Address{}))
+}
+
+// Export renders an SSI as an ISO 20022 fragment. An empty message picks sese.023 for an SSI with
+// a settlement type and pacs.008 otherwise.
+func (s *ssiService) Export(id, message string) (*SSIExport, error) {
+	message = strings.ToLower(strings.TrimSpace(message))
+	if _, ok := ssiExportNamespaces[message]; !ok && message != "" {
+		return nil, fmt.Errorf("%w %q: must be %s or %s", ErrUnsupportedSSIExport, message, SSIExportPacs008, SSIExportSese023)
+	}
+
+	ssi, err := s.repo.FindForExport(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := decryptAccounts(ssi); err != nil {
+		return nil, err
+	}
+	if message == "" {
+		message = SSIExportPacs008
+		if ssi.SettlementType != "" {
+			message = SSIExportSese023
+		}
+	}
+
+	var fragment any
+	if message == SSIExportSese023 {
+		fragment = seseReceivingParties(ssi)
+	} else {
+		fragment = pacsCreditorTransaction(ssi)
+	}
+	body, err := xml.MarshalIndent(fragment, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &SSIExport{Message: message, Document: append([]byte(xml.Header), body...)}, nil
+}
+
+// isoAddressTypeCodes are the ISO 20022 AddressType2Code values
+var isoAddressTypeCodes = map[string]bool{"ADDR": true, "PBOX": true, "HOME": true, "BIZZ": true, "MLTO": true, "DLVY": true}
+
+// isoPostalAddress24 is the full ISO 20022 structured address, as used by pacs.008
+type isoPostalAddress24 struct {
+	AdrTp       *isoAddressType `xml:"AdrTp,omitempty"`
+	Dept        string          `xml:"Dept,omitempty"`
+	SubDept     string          `xml:"SubDept,omitempty"`
+	StrtNm      string          `xml:"StrtNm,omitempty"`
+	BldgNb      string          `xml:"BldgNb,omitempty"`
+	BldgNm      string          `xml:"BldgNm,omitempty"`
+	Flr         string          `xml:"Flr,omitempty"`
+	PstBx       string          `xml:"PstBx,omitempty"`
+	Room        string          `xml:"Room,omitempty"`
+	PstCd       string          `xml:"PstCd,omitempty"`
+	TwnNm       string          `xml:"TwnNm,omitempty"`
+	TwnLctnNm   string          `xml:"TwnLctnNm,omitempty"`
+	DstrctNm    string          `xml:"DstrctNm,omitempty"`
+	CtrySubDvsn string          `xml:"CtrySubDvsn,omitempty"`
+	Ctry        string          `xml:"Ctry,omitempty"`
+	AdrLine     []string        `xml:"AdrLine,omitempty"`
+}
+
+type isoAddressType struct {
+	Cd string `xml:"Cd"`
+}
+
+// isoPostalAddress1 is the older, shorter address of the securities messages; the country is required
+type isoPostalAddress1 struct {
+	AdrTp       string   `xml:"AdrTp,omitempty"`
+	AdrLine     []string `xml:"AdrLine,omitempty"` // at most 5
+	StrtNm      string   `xml:"StrtNm,omitempty"`
+	BldgNb      string   `xml:"BldgNb,omitempty"`
+	PstCd       string   `xml:"PstCd,omitempty"`
+	TwnNm       string   `xml:"TwnNm,omitempty"`
+	CtrySubDvsn string   `xml:"CtrySubDvsn,omitempty"`
+	Ctry        string   `xml:"Ctry"`
+}
+
+// exportAddress picks the address an entity is known by: its primary address, else its registered
+// address, else any
+func exportAddress(entity *domain.Entity) *domain.Address {
+	if entity == nil {
+		return nil
+	}
+	var chosen *domain.Address
+	for _, link := range entity.Addresses {
+		switch {
+		case link.Address == nil:
+		case link.IsPrimary:
+			return link.Address
+		case chosen == nil || link.AddressType == "REGISTERED":
+			chosen = link.Address
+		}
+	}
+	return chosen
+}
+
+// addressLines returns an address's non-empty unstructured lines
+func addressLines(address *domain.Address) []string {
+	var lines []string
+	for _, line := range []string{address.AddressLine1, address.AddressLine2, address.AddressLine3, address.AddressLine4,
+		address.AddressLine5, address.AddressLine6, address.AddressLine7} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func addressCountry(address *domain.Address) string {
+	if address.Country == nil {
+		return ""
+	}
+	return address.Country.Code
+}
+
+func postalAddress24(address *domain.Address) *isoPostalAddress24 {
+	if address == nil {
+		return nil
+	}
+	adr := &isoPostalAddress24{
+		Dept:        address.Department,
+		SubDept:     address.SubDepartment,
+		StrtNm:      address.StreetName,
+		BldgNb:      address.BuildingNumber,
+		BldgNm:      address.BuildingName,
+		Flr:         address.Floor,
+		PstBx:       address.PostBox,
+		Room:        address.Room,
+		PstCd:       address.PostalCode,
+		TwnNm:       address.TownName,
+		TwnLctnNm:   address.TownLocationName,
+		DstrctNm:    address.DistrictName,
+		CtrySubDvsn: address.CountrySubDivision,
+		Ctry:        addressCountry(address),
+		AdrLine:     addressLines(address),
+	}
+	if isoAddressTypeCodes[address.AddressType] {
+		adr.AdrTp = &isoAddressType{Cd: address.AddressType}
+	}
+	return adr
+}
+
+func postalAddress1(address *domain.Address) *isoPostalAddress1 {
+	if address == nil || addressCountry(address) == "" {
+		return nil
+	}
+	adr := &isoPostalAddress1{
+		AdrLine:     addressLines(address),
+		StrtNm:      address.StreetName,
+		BldgNb:      address.BuildingNumber,
+		PstCd:       address.PostalCode,
+		TwnNm:       address.TownName,
+		CtrySubDvsn: address.CountrySubDivision,
+		Ctry:        addressCountry(address),
+	}
+	if isoAddressTypeCodes[address.AddressType] {
+		adr.AdrTp = address.AddressType
+	}
+	if len(adr.AdrLine) > 5 {
+		adr.AdrLine = adr.AdrLine[:5]
+	}
+	return adr
+}
+
+func entityLEI(entity *domain.Entity) string {
+	if entity == nil || entity.LEI == nil {
+		return ""
+	}
+	return *entity.LEI
+}
+
+// pacs.008 CdtTrfTxInf, with only the creditor-side elements an SSI provides
+
+type pacsCreditorFragment struct {
+	XMLName    xml.Name    `xml:"CdtTrfTxInf"`
+	Xmlns      string      `xml:"xmlns,attr"`
+	IntrmyAgt1 *pacsAgent  `xml:"IntrmyAgt1,omitempty"`
+	CdtrAgt    pacsAgent   `xml:"CdtrAgt"`
+	Cdtr       pacsParty   `xml:"Cdtr"`
+	CdtrAcct   pacsAccount `xml:"CdtrAcct"`
+}
+
+type pacsAgent struct {
+	FinInstnId struct {
+		BICFI string `xml:"BICFI,omitempty"`
+		Nm    string `xml:"Nm,omitempty"`
+	} `xml:"FinInstnId"`
+}
+
+type pacsParty struct {
+	Nm      string              `xml:"Nm"`
+	PstlAdr *isoPostalAddress24 `xml:"PstlAdr,omitempty"`
+	Id      *pacsPartyID        `xml:"Id,omitempty"`
+}
+
+type pacsPartyID struct {
+	OrgId struct {
+		LEI string `xml:"LEI"`
+	} `xml:"OrgId"`
+}
+
+type pacsAccount struct {
+	Id struct {
+		IBAN string   `xml:"IBAN,omitempty"`
+		Othr *otherID `xml:"Othr,omitempty"`
+	} `xml:"Id"`
+	Ccy string `xml:"Ccy,omitempty"`
+}
+
+type otherID struct {
+	Id string `xml:"Id"`
+}
+
+func newPacsAgent(bic, name string) *pacsAgent {
+	agent := &pacsAgent{}
+	agent.FinInstnId.BICFI = bic
+	agent.FinInstnId.Nm = name
+	return agent
+}
+
+func pacsCreditorTransaction(ssi *domain.SSI) *pacsCreditorFragment {
+	fragment := &pacsCreditorFragment{
+		Xmlns:   ssiExportNamespaces[SSIExportPacs008],
+		CdtrAgt: *newPacsAgent(ssi.BeneficiaryBankBIC, ssi.BeneficiaryBank),
+		Cdtr: pacsParty{
+			Nm:      ssi.BeneficiaryName,
+			PstlAdr: postalAddress24(exportAddress(ssi.Entity)),
+		},
+	}
+	if ssi.IntermediaryBank != "" || ssi.IntermediaryBankBIC != "" {
+		fragment.IntrmyAgt1 = newPacsAgent(ssi.IntermediaryBankBIC, ssi.IntermediaryBank)
+	}
+	if lei := entityLEI(ssi.Entity); lei != "" {
+		fragment.Cdtr.Id = &pacsPartyID{}
+		fragment.Cdtr.Id.OrgId.LEI = lei
+	}
+	if account := NormalizeIBAN(ssi.BeneficiaryAccount); looksLikeIBAN(account) {
+		fragment.CdtrAcct.Id.IBAN = account
+	} else {
+		fragment.CdtrAcct.Id.Othr = &otherID{Id: ssi.BeneficiaryAccount}
+	}
+	if ssi.SettlementCurrency != nil {
+		fragment.CdtrAcct.Ccy = ssi.SettlementCurrency.Code
+	}
+	return fragment
+}
+
+// sese.023 RcvgSttlmPties: the place of settlement, then the chain from the party closest to it
+// down to the beneficiary
+
+type seseReceivingPartiesFragment struct {
+	XMLName xml.Name   `xml:"RcvgSttlmPties"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	Dpstry  *seseParty `xml:"Dpstry,omitempty"` // the place of settlement
+	Pty1    *seseParty `xml:"Pty1,omitempty"`
+	Pty2    *seseParty `xml:"Pty2,omitempty"`
+	Pty3    *seseParty `xml:"Pty3,omitempty"`
+}
+
+type seseParty struct {
+	Id struct {
+		AnyBIC   string          `xml:"AnyBIC,omitempty"`
+		NmAndAdr *seseNameAndAdr `xml:"NmAndAdr,omitempty"`
+	} `xml:"Id"`
+	LEI       string   `xml:"LEI,omitempty"`
+	SfkpgAcct *otherID `xml:"SfkpgAcct,omitempty"`
+}
+
+type seseNameAndAdr struct {
+	Nm  string             `xml:"Nm"`
+	Adr *isoPostalAddress1 `xml:"Adr,omitempty"`
+}
+
+// newSeseParty identifies a party by BIC when it has one, else by name (and address)
+func newSeseParty(bic, name string, address *domain.Address) *seseParty {
+	party := &seseParty{}
+	if bic != "" {
+		party.Id.AnyBIC = bic
+	} else {
+		party.Id.NmAndAdr = &seseNameAndAdr{Nm: name, Adr: postalAddress1(address)}
+	}
+	return party
+}
+
+func seseReceivingParties(ssi *domain.SSI) *seseReceivingPartiesFragment {
+	beneficiary := newSeseParty("", ssi.BeneficiaryName, exportAddress(ssi.Entity))
+	beneficiary.LEI = entityLEI(ssi.Entity)
+	beneficiary.SfkpgAcct = &otherID{Id: ssi.BeneficiaryAccount}
+	bank := newSeseParty(ssi.BeneficiaryBankBIC, ssi.BeneficiaryBank, nil)
+
+	fragment := &seseReceivingPartiesFragment{Xmlns: ssiExportNamespaces[SSIExportSese023]}
+	if ssi.PlaceOfSettlement != "" {
+		fragment.Dpstry = newSeseParty(ssi.PlaceOfSettlement, "", nil)
+	}
+	if ssi.IntermediaryBank != "" || ssi.IntermediaryBankBIC != "" {
+		fragment.Pty1 = newSeseParty(ssi.IntermediaryBankBIC, ssi.IntermediaryBank, nil)
+		fragment.Pty2, fragment.Pty3 = bank, beneficiary
+	} else {
+		fragment.Pty1, fragment.Pty2 = bank, beneficiary
+	}
+	return fragment
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/domain"
+	"github.com/techie2000/axiom/internal/query"
+	"github.com/techie2000/axiom/internal/repository"
+)
+
+// SSIService manages standing settlement instructions
+type SSIService interface {
+	Create(ctx context.Context, ssi *domain.SSI) error
+	GetByID(id string) (*domain.SSI, error)
+	GetAll(limit, offset int) ([]*domain.SSI, error)
+	GetAllWithFilters(q *query.ListQuery) ([]*domain.SSI, error)
+	ResolveEffective(lookup SSILookup) (*domain.SSI, error)
+	ListConflicts(limit, offset int) ([]*domain.SSIConflict, int64, error)
+	Export(id, message string) (*SSIExport, error)
+	Update(ctx context.Context, ssi *domain.SSI) error
+	Validate(ssi *domain.SSI) error
+	Delete(ctx context.Context, id string) error
+}
+
+type ssiService struct {
+	repo          repository.SSIRepository
+	currencies    repository.CurrencyRepository
+	findings      repository.IntegrityRepository
+	bics          repository.BICRepository
+	defaults      repository.SSIDefaultRuleRepository
+	overlapPolicy string
+}
+
+// NewSSIService creates an SSI service; new SSIs are filled from the default rules, and a write
+// that would overlap another SSI is handled per overlapPolicy
+func NewSSIService(repo repository.SSIRepository, currencies repository.CurrencyRepository, findings repository.IntegrityRepository, bics repository.BICRepository, defaults repository.SSIDefaultRuleRepository, overlapPolicy string) SSIService {
+	if !IsValidSSIOverlapPolicy(overlapPolicy) {
+		log.Warn().Str("policy", overlapPolicy).Msg("Unknown SSI overlap policy, using reject")
+		overlapPolicy = SSIOverlapPolicyReject
+	}
+	return &ssiService{repo: repo, currencies: currencies, findings: findings, bics: bics, defaults: defaults, overlapPolicy: overlapPolicy}
+}
+
+// Create saves a new SSI, filling the fields it leaves empty from the matching default rule; new
+// SSIs are always stored active, so they are checked for overlaps
+func (s *ssiService) Create(ctx context.Context, ssi *domain.SSI) error {
+	overlapping, err := s.checkCreate(ssi)
+	if err != nil {
+		return err
+	}
+	if err := s.save(ctx, ssi, s.repo.Create); err != nil {
+		return err
+	}
+	s.flagOverlap(ctx, ssi, overlapping)
+	return nil
+}
+
+func (s *ssiService) GetByID(id string) (*domain.SSI, error) {
+	ssi, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return ssi, decryptAccounts(ssi)
+}
+
+func (s *ssiService) GetAll(limit, offset int) ([]*domain.SSI, error) {
+	ssis, err := s.repo.FindAll(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return ssis, decryptAccounts(ssis...)
+}
+
+func (s *ssiService) GetAllWithFilters(q *query.ListQuery) ([]*domain.SSI, error) {
+	ssis, err := s.repo.FindAllWithFilters(q)
+	if err != nil {
+		return nil, err
+	}
+	return ssis, decryptAccounts(ssis...)
+}
+
+// Update saves the ssi; the review flag is owned by the DQ workflow and is carried over unchanged
+func (s *ssiService) Update(ctx context.Context, ssi *domain.SSI) error {
+	overlapping, err := s.checkUpdate(ssi)
+	if err != nil {
+		return err
+	}
+	if err := s.save(ctx, ssi, s.repo.Update); err != nil {
+		return err
+	}
+	s.flagOverlap(ctx, ssi, overlapping)
+	return nil
+}
+
+// save writes an SSI with write, its beneficiary account encrypted
+func (s *ssiService) save(ctx context.Context, ssi *domain.SSI, write func(ctx context.Context, ssi *domain.SSI) error) error {
+	restore, err := encryptAccount(ssi)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	return write(ctx, ssi)
+}
+
+// Validate runs the checks of Create, filling in defaults, or of Update for an SSI with an ID,
+// without saving. An overlap is an error only under the reject policy.
+func (s *ssiService) Validate(ssi *domain.SSI) error {
+	var err error
+	if ssi.ID == uuid.Nil {
+		_, err = s.checkCreate(ssi)
+	} else {
+		_, err = s.checkUpdate(ssi)
+	}
+	return err
+}
+
+// checkCreate validates a new SSI, filling in defaults, and returns the active SSIs it overlaps
+func (s *ssiService) checkCreate(ssi *domain.SSI) ([]*domain.SSI, error) {
+	if err := requireActiveCurrency(s.currencies, ssi.SettlementCurrencyID, nil); err != nil {
+		return nil, err
+	}
+	if _, _, err := applySSIDefaults(s.defaults, ssi); err != nil {
+		return nil, err
+	}
+	if err := s.checkBankDetails(ssi, nil); err != nil {
+		return nil, err
+	}
+	return s.checkOverlap(ssi, true)
+}
+
+// checkUpdate validates an SSI update against the stored SSI, carrying over the fields owned by
+// the DQ workflow and defaults, and returns the active SSIs it overlaps
+func (s *ssiService) checkUpdate(ssi *domain.SSI) ([]*domain.SSI, error) {
+	existing, err := s.GetByID(ssi.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireActiveCurrency(s.currencies, ssi.SettlementCurrencyID, existing.SettlementCurrencyID); err != nil {
+		return nil, err
+	}
+	if err := s.checkBankDetails(ssi, existing); err != nil {
+		return nil, err
+	}
+	ssi.ReviewRequired = existing.ReviewRequired
+	ssi.ReviewReason = existing.ReviewReason
+	ssi.DefaultRuleID = existing.DefaultRuleID
+	return s.checkOverlap(ssi, ssi.Active)
+}
+
+func (s *ssiService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// checkBankDetails normalizes the SSI's place of settlement, beneficiary account, if an IBAN, and
+// its BICs, and checks them: the place of settlement against ISO 9362, an IBAN against its
+// country's length and check digits, bank BICs against ISO 9362 and, once imported, the BIC
+// directory. Values unchanged from existing are not re-checked.
+func (s *ssiService) checkBankDetails(ssi, existing *domain.SSI) error {
+	ssi.PlaceOfSettlement = NormalizeBIC(ssi.PlaceOfSettlement)
+	if ssi.PlaceOfSettlement != "" && (existing == nil || ssi.PlaceOfSettlement != existing.PlaceOfSettlement) {
+		if err := ValidateBIC(ssi.PlaceOfSettlement); err != nil {
+			return fmt.Errorf("place of settlement: %w", err)
+		}
+	}
+	if account := NormalizeIBAN(ssi.BeneficiaryAccount); looksLikeIBAN(account) {
+		ssi.BeneficiaryAccount = account
+		if existing == nil || account != NormalizeIBAN(existing.BeneficiaryAccount) {
+			if err := ValidateIBAN(account); err != nil {
+				return fmt.Errorf("beneficiary account: %w", err)
+			}
+		}
+	}
+
+	ssi.BeneficiaryBankBIC = NormalizeBIC(ssi.BeneficiaryBankBIC)
+	ssi.IntermediaryBankBIC = NormalizeBIC(ssi.IntermediaryBankBIC)
+	var previousBeneficiary, previousIntermediary string
+	if existing != nil {
+		previousBeneficiary, previousIntermediary = existing.BeneficiaryBankBIC, existing.IntermediaryBankBIC
+	}
+	if err := requireKnownBIC(s.bics, ssi.BeneficiaryBankBIC, previousBeneficiary); err != nil {
+		return fmt.Errorf("beneficiary bank: %w", err)
+	}
+	if err := requireKnownBIC(s.bics, ssi.IntermediaryBankBIC, previousIntermediary); err != nil {
+		return fmt.Errorf("intermediary bank: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// Package sftp uploads files over SFTP (version 3 of the SSH file transfer protocol, which every
+// common server speaks). It implements only what delivering files needs: writing a file, and
+// renaming and removing one.
+package sftp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Packet types (draft-ietf-secsh-filexfer-02)
+const (
+	fxpInit    = 1
+	fxpVersion = 2
+	fxpOpen    = 3
+	fxpClose   = 4
+	fxpWrite   = 6
+	fxpRemove  = 13
+	fxpRename  = 18
+	fxpStatus  = 101
+	fxpHandle  = 102
+)
+
+// Open flags
+const (
+	fxfWrite = 0x02
+	fxfCreat = 0x08
+	fxfTrunc = 0x10
+)
+
+const (
+	protocolVersion = 3
+	fxOK            = 0
+	// chunkSize is the data of one write request; servers must accept packets of 34000 bytes
+	chunkSize = 32 * 1024
+	// maxInFlight bounds the write requests sent before their replies are read, so uploads are
+	// not limited to one chunk per round trip
+	maxInFlight = 16
+	// maxPacket bounds the replies read; they are status or handle packets
+	maxPacket = 256 * 1024
+)
+
+// StatusError is a request the server refused
+type StatusError struct {
+	Code    uint32
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("sftp: server returned status %d", e.Code)
+	}
+	return fmt.Sprintf("sftp: %s (status %d)", e.Message, e.Code)
+}
+
+// Client is an SFTP session on an SSH connection. It is not safe for concurrent use.
+type Client struct {
+	session *ssh.Session
+	in      io.WriteCloser
+	out     io.Reader
+	nextID  uint32
+}
+
+// NewClient starts the sftp subsystem on conn
+func NewClient(conn *ssh.Client) (*Client, error) {
+	session, err := conn.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	in, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	out, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	if err := session.RequestSubsystem("sftp"); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("sftp: subsystem refused: %w", err)
+	}
+
+	c := &Client{session: session, in: in, out: out}
+	if err := c.send(fxpInit, binary.BigEndian.AppendUint32(nil, protocolVersion)); err != nil {
+		c.Close()
+		return nil, err
+	}
+	typ, _, err := c.receive()
+	if err == nil && typ != fxpVersion {
+		err = fmt.Errorf("sftp: unexpected packet %d instead of version", typ)
+	}
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close ends the session; the SSH connection stays open
+func (c *Client) Close() error {
+	c.in.Close()
+	return c.session.Close()
+}
+
+// Create opens path for writing, creating it or truncating it. Writes are sent as they are made
+// and must be followed by Close, which reports any write the server refused.
+func (c *Client) Create(path string) (io.WriteCloser, error) {
+	id, payload := c.request()
+	payload = appendString(payload, path)
+	payload = binary.BigEndian.AppendUint32(payload, fxfWrite|fxfCreat|fxfTrunc)
+	payload = binary.BigEndian.AppendUint32(payload, 0) // no attributes
+	if err := c.send(fxpOpen, payload); err != nil {
+		return nil, err
+	}
+	typ, reply, err := c.reply(id)
+	if err != nil {
+		return nil, err
+	}
+	if typ == fxpStatus {
+		return nil, statusError(reply)
+	}
+	if typ != fxpHandle {
+		return nil, fmt.Errorf("sftp: unexpected packet %d instead of a handle", typ)
+	}
+	handle, _, ok := readString(reply)
+	if !ok {
+		return nil, errors.New("sftp: malformed handle")
+	}
+	return &file{client: c, handle: handle, pending: map[uint32]bool{}}, nil
+}
+
+// Rename renames oldPath to newPath; most servers refuse if newPath exists
+func (c *Client) Rename(oldPath, newPath string) error {
+	id, payload := c.request()
+	payload = appendString(appendString(payload, oldPath), newPath)
+	return c.call(id, fxpRename, payload)
+}
+
+// Remove deletes path
+func (c *Client) Remove(path string) error {
+	id, payload := c.request()
+	return c.call(id, fxpRemove, appendString(payload, path))
+}
+
+// request starts the payload of a new request with its ID
+func (c *Client) request() (uint32, []byte) {
+	c.nextID++
+	return c.nextID, binary.BigEndian.AppendUint32(nil, c.nextID)
+}
+
+// call sends a request answered by a status
+func (c *Client) call(id uint32, typ byte, payload []byte) error {
+	if err := c.send(typ, payload); err != nil {
+		return err
+	}
+	return c.status(id)
+}
+
+// status reads the status answering request id
+func (c *Client) status(id uint32) error {
+	typ, reply, err := c.reply(id)
+	if err != nil {
+		return err
+	}
+	if typ != fxpStatus {
+		return fmt.Errorf("sftp: unexpected packet %d instead of a status", typ)
+	}
+	return statusError(reply)
+}
+
+// reply reads the next packet, which must answer request id, and returns it after the ID
+func (c *Client) reply(id uint32) (byte, []byte, error) {
+	typ, data, err := c.receive()
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 4 || binary.BigEndian.Uint32(data) != id {
+		return 0, nil, errors.New("sftp: reply to an unexpected request")
+	}
+	return typ, data[4:], nil
+}
+
+func (c *Client) send(typ byte, payload []byte) error {
+	packet := binary.BigEndian.AppendUint32(make([]byte, 0, 5+len(payload)), uint32(1+len(payload)))
+	packet = append(append(packet, typ), payload...)
+	_, err := c.in.Write(packet)
+	return err
+}
+
+func (c *Client) receive() (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(c.out, header[:]); err != nil {
+		return 0, nil, fmt.Errorf("sftp: connection lost: %w", err)
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	if length < 1 || length > maxPacket {
+		return 0, nil, fmt.Errorf("sftp: invalid packet length %d", length)
+	}
+	data := make([]byte, length-1)
+	if _, err := io.ReadFull(c.out, data); err != nil {
+		return 0, nil, fmt.Errorf("sftp: connection lost: %w", err)
+	}
+	return header[4], data, nil
+}
+
+// file is an open remote file being written
+type file struct {
+	client  *Client
+	handle  string
+	offset  uint64
+	pending map[uint32]bool // write requests awaiting a status
+	err     error           // the first refused write
+}
+
+func (f *file) Write(data []byte) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	written := 0
+	for len(data) > 0 {
+		n := min(len(data), chunkSize)
+		if len(f.pending) == maxInFlight {
+			if err := f.settle(); err != nil {
+				return written, err
+			}
+		}
+		id, payload := f.client.request()
+		payload = appendString(payload, f.handle)
+		payload = binary.BigEndian.AppendUint64(payload, f.offset)
+		payload = appendString(payload, string(data[:n]))
+		if err := f.client.send(fxpWrite, payload); err != nil {
+			f.err = err
+			return written, err
+		}
+		f.pending[id] = true
+		f.offset += uint64(n)
+		written += n
+		data = data[n:]
+	}
+	return written, nil
+}
+
+// settle reads the status of one outstanding write
+func (f *file) settle() error {
+	typ, data, err := f.client.receive()
+	if err == nil && (typ != fxpStatus || len(data) < 4 || !f.pending[binary.BigEndian.Uint32(data)]) {
+		err = errors.New("sftp: unexpected reply to a write")
+	}
+	if err == nil {
+		delete(f.pending, binary.BigEndian.Uint32(data))
+		err = statusError(data[4:])
+	}
+	if err != nil && f.err == nil {
+		f.err = err
+	}
+	return err
+}
+
+// Close waits for the outstanding writes and closes the handle
+func (f *file) Close() error {
+	for len(f.pending) > 0 {
+		// Replies to refused writes are read too, so the session stays usable
+		var status *StatusError
+		if err := f.settle(); err != nil && !errors.As(err, &status) {
+			return err
+		}
+	}
+	id, payload := f.client.request()
+	err := f.client.call(id, fxpClose, appendString(payload, f.handle))
+	if f.err != nil {
+		return f.err
+	}
+	return err
+}
+
+// statusError decodes a status payload (after its ID); OK is nil
+func statusError(data []byte) error {
+	if len(data) < 4 {
+		return errors.New("sftp: malformed status")
+	}
+	code := binary.BigEndian.Uint32(data)
+	if code == fxOK {
+		return nil
+	}
+	message, _, _ := readString(data[4:])
+	return &StatusError{Code: code, Message: message}
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(binary.BigEndian.AppendUint32(b, uint32(len(s))), s...)
+}
+
+func readString(b []byte) (string, []byte, bool) {
+	if len(b) < 4 {
+		return "", nil, false
+	}
+	n := binary.BigEndian.Uint32(b)
+	if uint64(len(b)-4) < uint64(n) {
+		return "", nil, false
+	}
+	return string(b[4 : 4+n]), b[4+n:], true
+}
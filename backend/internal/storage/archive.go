@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// archivePartSize is the size of the parts a large file is uploaded in. S3 allows 10,000 parts,
+// so files up to about 80 GB can be archived.
+const archivePartSize = 8 * 1024 * 1024
+
+// Archive keeps files in an S3 bucket. The bucket's lifecycle rules, not the application, decide
+// when archived files move to colder storage or expire.
+type Archive interface {
+	// Put uploads everything read from r under key, below the archive prefix, and returns the
+	// object's s3:// URL. Large files are uploaded in parts as they are read; the object only
+	// appears once the upload is complete.
+	Put(ctx context.Context, key string, r io.Reader) (string, error)
+}
+
+type s3Archive struct {
+	client       *s3.Client
+	bucket       string
+	prefix       string
+	storageClass types.StorageClass
+}
+
+// NewArchive creates the configured archive, or returns nil when archive.bucket is not set
+func NewArchive(cfg config.ArchiveConfig) (Archive, error) {
+	if cfg.Bucket == "" {
+		return nil, nil
+	}
+	client, err := newS3Client(config.StorageConfig{
+		Region:          cfg.Region,
+		Endpoint:        cfg.Endpoint,
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+		UsePathStyle:    cfg.UsePathStyle,
+	}, BackendS3)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Trim(cfg.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &s3Archive{client: client, bucket: cfg.Bucket, prefix: prefix, storageClass: types.StorageClass(cfg.StorageClass)}, nil
+}
+
+func (a *s3Archive) Put(ctx context.Context, key string, r io.Reader) (string, error) {
+	key = a.prefix + key
+	location := fmt.Sprintf("s3://%s/%s", a.bucket, key)
+
+	part := make([]byte, archivePartSize)
+	n, err := io.ReadFull(r, part)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// Small enough for a single request
+		_, err = a.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:        aws.String(a.bucket),
+			Key:           aws.String(key),
+			Body:          bytes.NewReader(part[:n]),
+			ContentLength: aws.Int64(int64(n)),
+			StorageClass:  a.storageClass,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to upload %s: %w", location, err)
+		}
+		return location, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	upload, err := a.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:       aws.String(a.bucket),
+		Key:          aws.String(key),
+		StorageClass: a.storageClass,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start upload of %s: %w", location, err)
+	}
+	abort := func(cause error) (string, error) {
+		// Parts of an aborted upload are discarded rather than left to accrue storage charges
+		a.client.AbortMultipartUpload(context.Background(), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(a.bucket),
+			Key:      aws.String(key),
+			UploadId: upload.UploadId,
+		})
+		return "", cause
+	}
+
+	var parts []types.CompletedPart
+	for number := int32(1); ; number++ {
+		out, err := a.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(a.bucket),
+			Key:           aws.String(key),
+			UploadId:      upload.UploadId,
+			PartNumber:    aws.Int32(number),
+			Body:          bytes.NewReader(part[:n]),
+			ContentLength: aws.Int64(int64(n)),
+		})
+		if err != nil {
+			return abort(fmt.Errorf("failed to upload part %d of %s: %w", number, location, err))
+		}
+		parts = append(parts, types.CompletedPart{ETag: out.ETag, PartNumber: aws.Int32(number)})
+
+		n, err = io.ReadFull(r, part)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return abort(err)
+		}
+	}
+
+	_, err = a.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(a.bucket),
+		Key:             aws.String(key),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return abort(fmt.Errorf("failed to complete upload of %s: %w", location, err))
+	}
+	return location, nil
+}
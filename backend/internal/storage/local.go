@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// localStorage keeps files in a directory
+type localStorage struct {
+	dir string
+}
+
+// NewLocal creates storage backed by a local directory
+func NewLocal(dir string) Storage {
+	return &localStorage{dir: dir}
+}
+
+func (s *localStorage) Backend() string { return BackendLocal }
+
+func (s *localStorage) Store(ctx context.Context, key, path string) error {
+	dest := filepath.Join(s.dir, key)
+	if filepath.Clean(path) == filepath.Clean(dest) {
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	if err := os.Rename(path, dest); err != nil {
+		return fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *localStorage) Fetch(ctx context.Context, key, workDir string) (string, func(), error) {
+	path := filepath.Join(s.dir, key)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return "", func() {}, ErrNotFound
+		}
+		return "", func() {}, err
+	}
+	return path, func() {}, nil
+}
+
+func (s *localStorage) List(ctx context.Context) ([]Object, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read storage directory: %w", err)
+	}
+	objects := make([]Object, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // removed since ReadDir
+		}
+		objects = append(objects, Object{Key: entry.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return objects, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(filepath.Join(s.dir, key)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// gcsEndpoint is Google Cloud Storage's S3-compatible XML API; it is used with HMAC keys
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// s3Storage keeps files in an S3 bucket (or a GCS bucket through its S3-compatible API)
+type s3Storage struct {
+	backend string
+	client  *s3.Client
+	bucket  string
+	prefix  string // key prefix ("folder") within the bucket, ending in "/" when set
+}
+
+func newS3(cfg config.StorageConfig, backend string) (Storage, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("storage.bucket is required for the %s backend", backend)
+	}
+	client, err := newS3Client(cfg, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Trim(cfg.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &s3Storage{backend: backend, client: client, bucket: cfg.Bucket, prefix: prefix}, nil
+}
+
+// newS3Client creates a client for an S3 bucket, or a GCS bucket through its S3-compatible API
+func newS3Client(cfg config.StorageConfig, backend string) (*s3.Client, error) {
+	region, endpoint := cfg.Region, cfg.Endpoint
+	if backend == BackendGCS {
+		if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+			return nil, errors.New("the gcs backend requires an HMAC key (storage.accesskeyid and storage.secretaccesskey)")
+		}
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+		if region == "" {
+			region = "auto"
+		}
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{}
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	// Without static keys the default chain applies (environment, shared config, IAM role)
+	if cfg.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, "")))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s configuration: %w", backend, err)
+	}
+
+	return s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = cfg.UsePathStyle
+	}), nil
+}
+
+func (s *s3Storage) Backend() string { return s.backend }
+
+func (s *s3Storage) Store(ctx context.Context, key, localPath string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(s.prefix + key),
+		Body:          file,
+		ContentLength: aws.Int64(info.Size()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to %s: %w", key, s.backend, err)
+	}
+	file.Close()
+	return os.Remove(localPath)
+}
+
+func (s *s3Storage) Fetch(ctx context.Context, key, workDir string) (string, func(), error) {
+	noop := func() {}
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return "", noop, ErrNotFound
+		}
+		return "", noop, fmt.Errorf("failed to download %s from %s: %w", key, s.backend, err)
+	}
+	defer out.Body.Close()
+
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		return "", noop, err
+	}
+	localPath := filepath.Join(workDir, key)
+	file, err := os.Create(localPath)
+	if err != nil {
+		return "", noop, err
+	}
+	release := func() { os.Remove(localPath) }
+	if _, err := io.Copy(file, out.Body); err != nil {
+		file.Close()
+		release()
+		return "", noop, fmt.Errorf("failed to download %s from %s: %w", key, s.backend, err)
+	}
+	if err := file.Close(); err != nil {
+		release()
+		return "", noop, err
+	}
+	return localPath, release, nil
+}
+
+func (s *s3Storage) List(ctx context.Context) ([]Object, error) {
+	var objects []Object
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s bucket: %w", s.backend, err)
+		}
+		for _, item := range page.Contents {
+			key := strings.TrimPrefix(aws.ToString(item.Key), s.prefix)
+			if key == "" || strings.Contains(key, "/") {
+				continue // nested "folders" are not ours
+			}
+			objects = append(objects, Object{Key: key, Size: aws.ToInt64(item.Size), ModTime: aws.ToTime(item.LastModified)})
+		}
+	}
+	return objects, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s from %s: %w", key, s.backend, err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NotFound" || apiErr.ErrorCode() == "NoSuchKey")
+}
@@ -0,0 +1,66 @@
+// Package storage stores downloaded LEI files on local disk or in an object store, so
+// containerized deployments don't need a large persistent volume.
+//
+// Files are written and read through local paths: downloads land on local disk first (where
+// they can be resumed) and are then stored, and processing fetches a stored file back to local
+// disk because reading a ZIP needs random access.
+//
+// An Archive is a separate S3 bucket that scheduled exports are delivered to and old LEI files
+// are archived in.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/techie2000/axiom/internal/config"
+)
+
+// Storage backends
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+	BackendGCS   = "gcs"
+)
+
+// ErrNotFound is returned when a key is not in storage
+var ErrNotFound = errors.New("file not found in storage")
+
+// Object describes a stored file
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage holds files by key (a plain file name, no directories)
+type Storage interface {
+	// Backend returns the backend name (local, s3 or gcs)
+	Backend() string
+	// Store moves the local file at path into storage under key; the local file is consumed
+	Store(ctx context.Context, key, path string) error
+	// Fetch makes a stored file available on local disk, downloading it into workDir if needed.
+	// The returned release function removes any temporary copy and must always be called.
+	Fetch(ctx context.Context, key, workDir string) (path string, release func(), err error)
+	// List returns all stored files
+	List(ctx context.Context) ([]Object, error)
+	// Delete removes a stored file
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the configured storage backend; localDir is the root of the local backend
+func New(cfg config.StorageConfig, localDir string) (Storage, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Backend)) {
+	case "", BackendLocal:
+		return NewLocal(localDir), nil
+	case BackendS3:
+		return newS3(cfg, BackendS3)
+	case BackendGCS:
+		return newS3(cfg, BackendGCS)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (expected local, s3 or gcs)", cfg.Backend)
+	}
+}
@@ -0,0 +1,139 @@
+// Package tlscert provides the certificates the API serves HTTPS with when it terminates TLS
+// itself: a certificate and key read from files, reloaded when they change on disk, or
+// certificates obtained and renewed from an ACME CA such as Let's Encrypt.
+package tlscert
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/techie2000/axiom/internal/config"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// reloadCheckInterval is how often a handshake checks the certificate files for changes
+const reloadCheckInterval = 10 * time.Second
+
+// NewConfig returns the server TLS configuration for cfg
+func NewConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	if len(cfg.ACME.Domains) > 0 {
+		return newACMEConfig(cfg.ACME)
+	}
+
+	reloader, err := NewReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}, nil
+}
+
+// newACMEConfig obtains certificates for the configured domains on their first handshake and
+// renews them before they expire
+func newACMEConfig(cfg config.ACMEConfig) (*tls.Config, error) {
+	if err := os.MkdirAll(cfg.CacheDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create ACME cache directory: %w", err)
+	}
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.Domains...),
+		Cache:      autocert.DirCache(cfg.CacheDir),
+		Email:      cfg.Email,
+	}
+	if cfg.DirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: cfg.DirectoryURL}
+	}
+	log.Info().Strs("domains", cfg.Domains).Msg("Serving HTTPS with ACME certificates")
+
+	tlsConfig := manager.TLSConfig() // answers TLS-ALPN-01 challenges
+	tlsConfig.MinVersion = tls.VersionTLS12
+	return tlsConfig, nil
+}
+
+// Reloader serves a certificate from files, loading it again when either file changes so a
+// renewed certificate is picked up without a restart. If the new files cannot be loaded (say the
+// key was written but not yet the certificate), the previous certificate is kept.
+type Reloader struct {
+	certFile string
+	keyFile  string
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	lastChecked time.Time
+}
+
+// NewReloader loads the certificate in certFile and keyFile
+func NewReloader(certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile}
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(certModTime, keyModTime); err != nil {
+		return nil, err
+	}
+	r.lastChecked = time.Now()
+	return r, nil
+}
+
+// GetCertificate returns the current certificate, for tls.Config.GetCertificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := time.Now(); now.Sub(r.lastChecked) >= reloadCheckInterval {
+		r.lastChecked = now
+		r.reloadIfChanged()
+	}
+	return r.cert, nil
+}
+
+// reloadIfChanged loads the certificate again if a file has changed since it was loaded. Called
+// with the lock held.
+func (r *Reloader) reloadIfChanged() {
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check TLS certificate files, keeping the current certificate")
+		return
+	}
+	if certModTime.Equal(r.certModTime) && keyModTime.Equal(r.keyModTime) {
+		return
+	}
+	if err := r.load(certModTime, keyModTime); err != nil {
+		log.Warn().Err(err).Msg("Failed to reload TLS certificate, keeping the current certificate")
+		return
+	}
+	log.Info().Str("cert_file", r.certFile).Msg("Reloaded TLS certificate")
+}
+
+// load reads the certificate and key, recording the modification times they were read at
+func (r *Reloader) load(certModTime, keyModTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	r.cert, r.certModTime, r.keyModTime = &cert, certModTime, keyModTime
+	return nil
+}
+
+// modTimes returns when the certificate and key files were last modified
+func (r *Reloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
@@ -0,0 +1,6 @@
+-- Rollback full-text search over LEI legal names
+
+DROP INDEX IF EXISTS lei_raw.idx_lei_records_search_vector;
+
+ALTER TABLE lei_raw.lei_records
+DROP COLUMN IF EXISTS search_vector;
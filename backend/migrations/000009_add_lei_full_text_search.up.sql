@@ -0,0 +1,15 @@
+-- Add full-text search over LEI legal names
+-- ILIKE '%term%' cannot use a B-tree index and forces a sequential scan of the full LEI table.
+-- search_vector is maintained by the application during upsert (see leiSearchVectorSQL),
+-- using the 'simple' configuration because legal names are multilingual and should not be stemmed.
+
+ALTER TABLE lei_raw.lei_records
+ADD COLUMN search_vector TSVECTOR;
+
+-- Backfill existing records
+UPDATE lei_raw.lei_records
+SET search_vector = to_tsvector('simple', coalesce(legal_name, '') || ' ' || coalesce(transliterated_legal_name, ''));
+
+CREATE INDEX idx_lei_records_search_vector ON lei_raw.lei_records USING GIN (search_vector);
+
+COMMENT ON COLUMN lei_raw.lei_records.search_vector IS 'Full-text search vector over legal_name and transliterated_legal_name (simple config). Maintained on upsert; queried via ?q= on /api/v1/lei';
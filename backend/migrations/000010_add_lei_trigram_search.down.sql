@@ -0,0 +1,5 @@
+-- Rollback trigram indexes for fuzzy LEI legal name matching
+-- NOTE: the pg_trgm extension is left installed as other objects may depend on it
+
+DROP INDEX IF EXISTS lei_raw.idx_lei_records_transliterated_legal_name_trgm;
+DROP INDEX IF EXISTS lei_raw.idx_lei_records_legal_name_trgm;
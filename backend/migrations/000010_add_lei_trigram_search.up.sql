@@ -0,0 +1,11 @@
+-- Add trigram (pg_trgm) indexes for fuzzy LEI legal name matching
+-- Lets users find "Deutsche Bank AG" from "Deutsche Bk AG" despite typos or abbreviations.
+-- Queries use the % similarity operator so these GIN indexes are used (see FuzzySearchLEI).
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_lei_records_legal_name_trgm
+ON lei_raw.lei_records USING GIN (legal_name gin_trgm_ops);
+
+CREATE INDEX idx_lei_records_transliterated_legal_name_trgm
+ON lei_raw.lei_records USING GIN (transliterated_legal_name gin_trgm_ops);
@@ -0,0 +1,7 @@
+-- Rollback updated_at indexes
+
+DROP INDEX IF EXISTS idx_ssis_updated_at;
+DROP INDEX IF EXISTS idx_accounts_updated_at;
+DROP INDEX IF EXISTS idx_instruments_updated_at;
+DROP INDEX IF EXISTS idx_entities_updated_at;
+DROP INDEX IF EXISTS lei_raw.idx_lei_records_updated_at;
@@ -0,0 +1,8 @@
+-- Index updated_at so MAX(updated_at) (used by GET /data/freshness) is an index lookup
+-- rather than a sequential scan of the full LEI table
+
+CREATE INDEX IF NOT EXISTS idx_lei_records_updated_at ON lei_raw.lei_records (updated_at);
+CREATE INDEX IF NOT EXISTS idx_entities_updated_at ON entities (updated_at);
+CREATE INDEX IF NOT EXISTS idx_instruments_updated_at ON instruments (updated_at);
+CREATE INDEX IF NOT EXISTS idx_accounts_updated_at ON accounts (updated_at);
+CREATE INDEX IF NOT EXISTS idx_ssis_updated_at ON ssis (updated_at);
@@ -0,0 +1,3 @@
+-- Rollback instrument reference prices
+
+DROP TABLE IF EXISTS instrument_prices;
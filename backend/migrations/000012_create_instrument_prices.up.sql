@@ -0,0 +1,30 @@
+-- Reference prices per instrument, date and source
+-- Minimal model for valuation: market data providers plug in via the PriceProvider interface
+-- and write rows with their own source, so new feeds need no schema change.
+
+CREATE TABLE IF NOT EXISTS instrument_prices (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    instrument_id UUID NOT NULL REFERENCES instruments (id),
+    price_date DATE NOT NULL,
+    price NUMERIC(28, 10) NOT NULL,
+    currency_id UUID NOT NULL REFERENCES currencies (id),
+    source VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_instrument_prices_instrument_date_source
+ON instrument_prices (instrument_id, price_date, source)
+WHERE deleted_at IS NULL;
+
+-- Latest-price lookup: newest date first per instrument
+CREATE INDEX idx_instrument_prices_instrument_date ON instrument_prices (instrument_id, price_date DESC);
+CREATE INDEX idx_instrument_prices_deleted_at ON instrument_prices (deleted_at);
+
+COMMENT ON TABLE instrument_prices IS
+'Reference prices per instrument, date and source. Loaded via bulk import (source MANUAL) or market data providers.';
+COMMENT ON COLUMN instrument_prices.price_date IS 'Business date the price applies to';
+COMMENT ON COLUMN instrument_prices.price IS 'Price in the quoted currency';
+COMMENT ON COLUMN instrument_prices.currency_id IS 'Currency the price is quoted in (FK to currencies)';
+COMMENT ON COLUMN instrument_prices.source IS 'Origin of the price, e.g. MANUAL or the market data provider name';
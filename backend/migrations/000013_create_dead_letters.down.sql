@@ -0,0 +1,3 @@
+-- Rollback dead-letter store
+
+DROP TABLE IF EXISTS dead_letters;
@@ -0,0 +1,29 @@
+-- Dead-letter store for async work that failed permanently
+-- (webhook deliveries, event publishing, queued jobs). Items stay here until an operator
+-- retries (optionally after editing the payload) or purges them.
+
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    source VARCHAR(100) NOT NULL,
+    kind VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    last_failed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_retried_at TIMESTAMP,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_dead_letters_source ON dead_letters (source);
+CREATE INDEX idx_dead_letters_status ON dead_letters (status);
+CREATE INDEX idx_dead_letters_created_at ON dead_letters (created_at);
+
+COMMENT ON TABLE dead_letters IS
+'Permanently failed async work items kept for inspection, edit-and-retry and purge via /api/v1/admin/dead-letters';
+COMMENT ON COLUMN dead_letters.source IS 'Subsystem that produced the work (e.g. lei-refresh, webhook, event-publisher)';
+COMMENT ON COLUMN dead_letters.kind IS 'Type of work within the source (e.g. event type or job name)';
+COMMENT ON COLUMN dead_letters.payload IS 'Work item payload as JSON; editable before retry';
+COMMENT ON COLUMN dead_letters.status IS 'PENDING (awaiting action) or RESOLVED (retried successfully)';
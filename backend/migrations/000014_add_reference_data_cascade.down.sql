@@ -0,0 +1,8 @@
+-- Rollback reference data deactivation cascade
+
+ALTER TABLE ssis DROP COLUMN IF EXISTS review_reason, DROP COLUMN IF EXISTS review_required;
+ALTER TABLE accounts DROP COLUMN IF EXISTS review_reason, DROP COLUMN IF EXISTS review_required;
+ALTER TABLE instruments DROP COLUMN IF EXISTS review_reason, DROP COLUMN IF EXISTS review_required;
+ALTER TABLE addresses DROP COLUMN IF EXISTS review_reason, DROP COLUMN IF EXISTS review_required;
+
+DROP TABLE IF EXISTS dq_findings;
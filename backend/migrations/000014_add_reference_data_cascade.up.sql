@@ -0,0 +1,43 @@
+-- Reference data deactivation cascade
+-- When a country or currency is deactivated (e.g. HRK after Croatia adopted the euro), records
+-- that reference it are flagged for review and a data quality finding is raised for each.
+
+CREATE TABLE IF NOT EXISTS dq_findings (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    rule_code VARCHAR(100) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    target_table VARCHAR(100) NOT NULL,
+    target_id UUID NOT NULL,
+    message TEXT NOT NULL,
+    cause VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    resolved_at TIMESTAMP,
+    resolved_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_dq_findings_rule_code ON dq_findings (rule_code);
+CREATE INDEX idx_dq_findings_status ON dq_findings (status);
+CREATE INDEX idx_dq_findings_target ON dq_findings (target_table, target_id);
+
+COMMENT ON TABLE dq_findings IS
+'Data quality findings raised against individual records (e.g. references to deactivated countries/currencies)';
+COMMENT ON COLUMN dq_findings.target_table IS 'Table of the record the finding is about';
+COMMENT ON COLUMN dq_findings.cause IS 'What raised the finding, e.g. "currency HRK deactivated"';
+COMMENT ON COLUMN dq_findings.status IS 'OPEN or RESOLVED';
+
+ALTER TABLE addresses
+    ADD COLUMN IF NOT EXISTS review_required BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS review_reason VARCHAR(255);
+ALTER TABLE instruments
+    ADD COLUMN IF NOT EXISTS review_required BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS review_reason VARCHAR(255);
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS review_required BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS review_reason VARCHAR(255);
+ALTER TABLE ssis
+    ADD COLUMN IF NOT EXISTS review_required BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS review_reason VARCHAR(255);
+
+COMMENT ON COLUMN accounts.review_required IS 'Set when referenced reference data (e.g. account currency) was deactivated; cleared when its findings are resolved';
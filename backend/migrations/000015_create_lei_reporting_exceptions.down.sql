@@ -0,0 +1,4 @@
+-- Rollback GLEIF reporting exceptions
+
+DELETE FROM lei_raw.file_processing_status WHERE job_type = 'REPEX';
+DROP TABLE IF EXISTS lei_raw.reporting_exceptions;
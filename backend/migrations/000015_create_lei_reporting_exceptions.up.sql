@@ -0,0 +1,33 @@
+-- GLEIF reporting exceptions (repex)
+-- Explains why an entity reports no direct/ultimate accounting consolidation parent
+-- (e.g. NATURAL_PERSONS, NON_CONSOLIDATING, NO_KNOWN_PERSON). Loaded from the repex golden copy
+-- file, which is a full snapshot: rows not present in the latest file are removed.
+
+CREATE TABLE IF NOT EXISTS lei_raw.reporting_exceptions (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    lei VARCHAR(20) NOT NULL,
+    exception_category VARCHAR(100) NOT NULL,
+    exception_reason VARCHAR(100) NOT NULL,
+    exception_reference TEXT,
+    source_file_id UUID REFERENCES lei_raw.source_files (id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_reporting_exceptions UNIQUE (lei, exception_category, exception_reason)
+);
+
+CREATE INDEX idx_reporting_exceptions_lei ON lei_raw.reporting_exceptions (lei);
+CREATE INDEX idx_reporting_exceptions_source_file_id ON lei_raw.reporting_exceptions (source_file_id);
+
+CREATE TRIGGER update_reporting_exceptions_updated_at BEFORE UPDATE ON lei_raw.reporting_exceptions
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+INSERT INTO lei_raw.file_processing_status (job_type, status, created_at, updated_at)
+VALUES ('REPEX', 'IDLE', NOW(), NOW())
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE lei_raw.reporting_exceptions IS
+'GLEIF Level 2 reporting exceptions: why an LEI has no parent relationship reported. Served at /api/v1/lei/{lei}/exceptions.';
+COMMENT ON COLUMN lei_raw.reporting_exceptions.exception_category IS 'DIRECT_ACCOUNTING_CONSOLIDATION_PARENT or ULTIMATE_ACCOUNTING_CONSOLIDATION_PARENT';
+COMMENT ON COLUMN lei_raw.reporting_exceptions.exception_reason IS 'GLEIF reason code, e.g. NATURAL_PERSONS, NON_CONSOLIDATING, NO_KNOWN_PERSON, NON_PUBLIC, BINDING_LEGAL_COMMITMENTS';
+COMMENT ON COLUMN lei_raw.reporting_exceptions.exception_reference IS 'Optional parent identifiers supplied with the exception, separated by "; "';
+COMMENT ON COLUMN lei_raw.source_files.file_type IS 'File type: FULL (complete snapshot of all LEI records), DELTA (last week changes only) or REPEX (full snapshot of reporting exceptions)';
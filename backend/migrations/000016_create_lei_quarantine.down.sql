@@ -0,0 +1,3 @@
+-- Rollback LEI import quarantine
+
+DROP TABLE IF EXISTS lei_raw.quarantined_records;
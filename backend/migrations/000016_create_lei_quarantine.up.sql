@@ -0,0 +1,21 @@
+-- Quarantine for LEI records rejected during batch import
+-- When a batch upsert fails, the batch is retried with savepoint bisection: the offending
+-- record(s) are isolated and stored here, and the rest of the batch is committed.
+
+CREATE TABLE IF NOT EXISTS lei_raw.quarantined_records (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    lei VARCHAR(20) NOT NULL,
+    source_file_id UUID REFERENCES lei_raw.source_files (id),
+    error_code VARCHAR(10),
+    error_message TEXT NOT NULL,
+    record_snapshot TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quarantined_records_lei ON lei_raw.quarantined_records (lei);
+CREATE INDEX idx_quarantined_records_source_file_id ON lei_raw.quarantined_records (source_file_id);
+
+COMMENT ON TABLE lei_raw.quarantined_records IS
+'LEI records rejected by the database during import, isolated by savepoint bisection so the rest of their batch could be committed';
+COMMENT ON COLUMN lei_raw.quarantined_records.error_code IS 'PostgreSQL SQLSTATE of the rejection (22xxx data exception, 23xxx constraint violation)';
+COMMENT ON COLUMN lei_raw.quarantined_records.record_snapshot IS 'The rejected record as JSON. TEXT rather than JSONB so records containing NUL escapes can still be kept.';
@@ -0,0 +1,4 @@
+-- Rollback saved LEI views and scheduled exports
+
+DROP TABLE IF EXISTS scheduled_exports;
+DROP TABLE IF EXISTS lei_saved_views;
@@ -0,0 +1,49 @@
+-- Saved LEI views and recurring exports of them
+-- A saved view stores the filters accepted by GET /api/v1/lei; a scheduled export runs a view
+-- daily, weekly or monthly and writes the result as CSV or JSON.
+
+CREATE TABLE IF NOT EXISTS lei_saved_views (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    search VARCHAR(255),
+    text_query VARCHAR(255),
+    status VARCHAR(50),
+    category VARCHAR(50),
+    country VARCHAR(2),
+    sort_by VARCHAR(50),
+    sort_order VARCHAR(4),
+    created_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_lei_saved_views_name ON lei_saved_views (name);
+
+CREATE TABLE IF NOT EXISTS scheduled_exports (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    name VARCHAR(255) NOT NULL,
+    saved_view_id UUID NOT NULL REFERENCES lei_saved_views (id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL,
+    destination VARCHAR(20) NOT NULL,
+    recipients TEXT,
+    frequency VARCHAR(20) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    last_run_status VARCHAR(20),
+    last_run_error TEXT,
+    last_run_file VARCHAR(500),
+    last_run_rows INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_scheduled_exports_saved_view_id ON scheduled_exports (saved_view_id);
+CREATE INDEX idx_scheduled_exports_next_run_at ON scheduled_exports (next_run_at);
+
+COMMENT ON TABLE lei_saved_views IS 'Named LEI queries (GET /api/v1/lei filters) reusable by scheduled exports';
+COMMENT ON TABLE scheduled_exports IS 'Recurring exports of saved LEI views, run by the scheduler when next_run_at passes';
+COMMENT ON COLUMN scheduled_exports.destination IS 'FILE (written under export.dir)';
+COMMENT ON COLUMN scheduled_exports.recipients IS 'Comma-separated email addresses told about each run';
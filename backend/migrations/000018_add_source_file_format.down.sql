@@ -0,0 +1,3 @@
+-- Rollback source file format
+
+ALTER TABLE lei_raw.source_files DROP COLUMN IF EXISTS file_format;
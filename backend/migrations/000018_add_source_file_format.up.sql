@@ -0,0 +1,7 @@
+-- Record the bulk file format of each source file so it is parsed the way it was downloaded
+-- (JSON golden copy or LEI-CDF XML)
+
+ALTER TABLE lei_raw.source_files
+    ADD COLUMN IF NOT EXISTS file_format VARCHAR(10) NOT NULL DEFAULT 'JSON';
+
+COMMENT ON COLUMN lei_raw.source_files.file_format IS 'JSON or XML (LEI-CDF 3.1); selects the parser used for the file';
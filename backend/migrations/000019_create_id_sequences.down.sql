@@ -0,0 +1,6 @@
+-- Rollback business ID sequences
+
+DROP INDEX IF EXISTS idx_entities_business_id;
+ALTER TABLE entities DROP COLUMN IF EXISTS business_id;
+
+DROP TABLE IF EXISTS id_sequences;
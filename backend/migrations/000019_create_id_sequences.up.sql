@@ -0,0 +1,31 @@
+-- Firm-specific business identifiers
+-- ID sequences generate entity business IDs and account numbers on create:
+-- prefix + zero-padded value + optional check digits, drawn from next_value..max_value.
+
+CREATE TABLE IF NOT EXISTS id_sequences (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    name VARCHAR(100) NOT NULL,
+    target VARCHAR(20) NOT NULL,
+    prefix VARCHAR(20),
+    padding INTEGER NOT NULL DEFAULT 0,
+    check_digit VARCHAR(10) NOT NULL DEFAULT 'NONE',
+    next_value BIGINT NOT NULL DEFAULT 1,
+    max_value BIGINT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_id_sequences_name ON id_sequences (name);
+CREATE INDEX idx_id_sequences_target ON id_sequences (target);
+-- At most one active sequence per target
+CREATE UNIQUE INDEX idx_id_sequences_active_target ON id_sequences (target) WHERE active;
+
+ALTER TABLE entities ADD COLUMN IF NOT EXISTS business_id VARCHAR(50);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_entities_business_id ON entities (business_id);
+
+COMMENT ON TABLE id_sequences IS 'Business identifier generators, managed via /api/v1/admin/id-sequences';
+COMMENT ON COLUMN id_sequences.target IS 'ENTITY (entities.business_id) or ACCOUNT (accounts.account_number)';
+COMMENT ON COLUMN id_sequences.check_digit IS 'NONE, LUHN (1 digit over the number) or MOD97 (2 digits, ISO 7064 over prefix and number)';
+COMMENT ON COLUMN id_sequences.max_value IS 'Last value of the allocated range; 0 means unbounded';
+COMMENT ON COLUMN entities.business_id IS 'Firm-specific entity identifier, generated on create when an ENTITY sequence is active';
@@ -0,0 +1,4 @@
+-- Rollback source file delta type
+
+DROP INDEX IF EXISTS lei_raw.idx_source_files_applied_publication;
+ALTER TABLE lei_raw.source_files DROP COLUMN IF EXISTS delta_type;
@@ -0,0 +1,11 @@
+-- Record which delta flavor each DELTA source file is, and index publication dates so the
+-- scheduled delta sync can find the last applied publish and catch up on missed ones in order
+
+ALTER TABLE lei_raw.source_files
+    ADD COLUMN IF NOT EXISTS delta_type VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_source_files_applied_publication
+    ON lei_raw.source_files (file_type, processing_status, publication_date DESC);
+
+COMMENT ON COLUMN lei_raw.source_files.delta_type IS 'IntraDay, LastDay, LastWeek or LastMonth for DELTA files; catch-up applies IntraDay deltas of missed publishes in publication order';
+COMMENT ON COLUMN lei_raw.source_files.publication_date IS 'GLEIF publish the file belongs to; the newest COMPLETED FULL/DELTA publication date is where delta catch-up resumes';
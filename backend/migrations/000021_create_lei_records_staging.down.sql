@@ -0,0 +1,3 @@
+-- Rollback full-file bulk load staging
+
+DROP TABLE IF EXISTS lei_raw.lei_records_staging;
@@ -0,0 +1,18 @@
+-- Staging table for full-file bulk loads: batches are COPYed in and merged into lei_records with a
+-- single INSERT ... ON CONFLICT once the whole file has been read (see MergeStagedLEIRecords).
+-- UNLOGGED because the contents are disposable: a crash empties the table and the load restarts.
+-- Unique and check constraints are deliberately not copied; duplicates are resolved at merge time.
+-- New lei_records columns that the loader writes must be added here as well.
+
+CREATE UNLOGGED TABLE IF NOT EXISTS lei_raw.lei_records_staging (
+    LIKE lei_raw.lei_records INCLUDING DEFAULTS
+);
+
+ALTER TABLE lei_raw.lei_records_staging
+    ADD COLUMN IF NOT EXISTS staged_seq BIGINT GENERATED ALWAYS AS IDENTITY;
+
+CREATE INDEX IF NOT EXISTS idx_lei_records_staging_source_lei
+    ON lei_raw.lei_records_staging (source_file_id, lei, staged_seq DESC);
+
+COMMENT ON TABLE lei_raw.lei_records_staging IS 'Full-file bulk load staging (COPY target); emptied at the start of each full file and after its merge';
+COMMENT ON COLUMN lei_raw.lei_records_staging.staged_seq IS 'Load order; when an LEI is staged more than once (e.g. re-staged after a resume) the latest row wins';
@@ -0,0 +1,26 @@
+-- Transactional outbox for record-change events
+-- Each change to an LEI or master-data record writes its event here in the same transaction as the
+-- change; the event relay publishes undelivered rows in seq order and stamps published_at.
+
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id UUID PRIMARY KEY,
+    seq BIGINT GENERATED ALWAYS AS IDENTITY,
+    routing_key VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_outbox_seq ON event_outbox (seq);
+-- The relay only ever scans undelivered rows
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox (seq) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_event_outbox_published_at ON event_outbox (published_at) WHERE published_at IS NOT NULL;
+
+COMMENT ON TABLE event_outbox IS 'Record-change events awaiting publication by the event relay, and recently delivered ones';
+COMMENT ON COLUMN event_outbox.id IS 'Event ID, also the message ID consumers deduplicate on';
+COMMENT ON COLUMN event_outbox.routing_key IS '<domain>.<action>, e.g. lei.updated';
+COMMENT ON COLUMN event_outbox.payload IS 'The event as published';
+COMMENT ON COLUMN event_outbox.attempts IS 'Failed publish attempts; last_error holds the most recent failure';
+COMMENT ON COLUMN event_outbox.published_at IS 'When the broker accepted the event; NULL while pending. Delivered rows are pruned after events.outboxretention';
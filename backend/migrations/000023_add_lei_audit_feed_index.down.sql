@@ -0,0 +1,3 @@
+-- Rollback LEI changes feed index
+
+DROP INDEX IF EXISTS lei_raw.idx_lei_records_audit_created_at_id;
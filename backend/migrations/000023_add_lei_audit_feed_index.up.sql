@@ -0,0 +1,5 @@
+-- Keyset index for the LEI changes feed (GET /api/v1/lei/changes), which pages through audit
+-- entries in (created_at, id) order
+
+CREATE INDEX IF NOT EXISTS idx_lei_records_audit_created_at_id
+    ON lei_raw.lei_records_audit (created_at, id);
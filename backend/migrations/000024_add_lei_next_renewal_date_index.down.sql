@@ -0,0 +1 @@
+DROP INDEX IF EXISTS lei_raw.idx_lei_records_next_renewal_date;
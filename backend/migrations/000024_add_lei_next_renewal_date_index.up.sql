@@ -0,0 +1,4 @@
+-- Supports the renewal report, which selects LEIs by an upcoming next_renewal_date range
+CREATE INDEX IF NOT EXISTS idx_lei_records_next_renewal_date
+    ON lei_raw.lei_records (next_renewal_date)
+    WHERE deleted_at IS NULL;
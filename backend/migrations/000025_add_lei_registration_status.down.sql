@@ -0,0 +1,7 @@
+-- Rollback registration status and entity LEI
+
+DROP INDEX IF EXISTS idx_entities_lei;
+ALTER TABLE entities DROP COLUMN IF EXISTS lei;
+
+ALTER TABLE lei_raw.lei_records_staging DROP COLUMN IF EXISTS registration_status;
+ALTER TABLE lei_raw.lei_records DROP COLUMN IF EXISTS registration_status;
@@ -0,0 +1,11 @@
+-- GLEIF registration status (ISSUED, LAPSED, RETIRED, ...) on LEI records, so lapsing and
+-- retirement can be detected, and the LEI of entities, so lapses can be traced to master data
+
+ALTER TABLE lei_raw.lei_records ADD COLUMN IF NOT EXISTS registration_status VARCHAR(50);
+ALTER TABLE lei_raw.lei_records_staging ADD COLUMN IF NOT EXISTS registration_status VARCHAR(50);
+
+ALTER TABLE entities ADD COLUMN IF NOT EXISTS lei VARCHAR(20);
+CREATE INDEX IF NOT EXISTS idx_entities_lei ON entities (lei);
+
+COMMENT ON COLUMN lei_raw.lei_records.registration_status IS 'GLEIF Registration.RegistrationStatus, e.g. ISSUED, LAPSED, RETIRED; NULL until the record is next loaded';
+COMMENT ON COLUMN entities.lei IS 'Legal Entity Identifier of the entity; lapsed or retired LEIs referenced here raise lei_lapsed alerts';
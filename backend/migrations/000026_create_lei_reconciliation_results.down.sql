@@ -0,0 +1 @@
+DROP TABLE IF EXISTS lei_raw.reconciliation_results;
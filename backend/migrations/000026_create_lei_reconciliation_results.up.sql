@@ -0,0 +1,24 @@
+-- Reconciliation of local LEI record counts against GLEIF: one row per scope (TOTAL or a legal
+-- address country) per run, so drift can be tracked over time
+
+CREATE TABLE IF NOT EXISTS lei_raw.reconciliation_results (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    run_id UUID NOT NULL,
+    scope VARCHAR(10) NOT NULL,
+    local_count BIGINT NOT NULL,
+    gleif_count BIGINT NOT NULL,
+    difference BIGINT NOT NULL,
+    drift_percent DOUBLE PRECISION NOT NULL,
+    exceeded BOOLEAN NOT NULL,
+    gleif_publish_date VARCHAR(50),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reconciliation_results_run_id ON lei_raw.reconciliation_results (run_id);
+CREATE INDEX idx_reconciliation_results_created_at ON lei_raw.reconciliation_results (created_at DESC);
+CREATE INDEX idx_reconciliation_results_exceeded ON lei_raw.reconciliation_results (created_at DESC) WHERE exceeded;
+
+COMMENT ON TABLE lei_raw.reconciliation_results IS 'Local vs GLEIF LEI record counts per reconciliation run; see GET /api/v1/lei/reconciliation';
+COMMENT ON COLUMN lei_raw.reconciliation_results.scope IS 'TOTAL (compared with the golden copy record_count) or a legal address country (compared with the GLEIF API total)';
+COMMENT ON COLUMN lei_raw.reconciliation_results.difference IS 'Local count minus GLEIF count';
+COMMENT ON COLUMN lei_raw.reconciliation_results.exceeded IS 'drift_percent above lei.reconciledriftthreshold; raised a reconciliation_drift alert';
@@ -0,0 +1,9 @@
+-- Rollback quarantine rules and review
+
+DROP INDEX IF EXISTS lei_raw.idx_quarantined_records_status;
+ALTER TABLE lei_raw.quarantined_records DROP COLUMN IF EXISTS review_note;
+ALTER TABLE lei_raw.quarantined_records DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE lei_raw.quarantined_records DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE lei_raw.quarantined_records DROP COLUMN IF EXISTS status;
+ALTER TABLE lei_raw.quarantined_records DROP COLUMN IF EXISTS rule_codes;
+ALTER TABLE lei_raw.quarantined_records DROP COLUMN IF EXISTS source;
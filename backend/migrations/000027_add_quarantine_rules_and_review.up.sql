@@ -0,0 +1,15 @@
+-- Data quality rules during LEI ingest: records failing configured rules are quarantined alongside
+-- those the database rejected, and quarantined records can be reviewed and requeued or dismissed
+
+ALTER TABLE lei_raw.quarantined_records ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'DATABASE';
+ALTER TABLE lei_raw.quarantined_records ADD COLUMN IF NOT EXISTS rule_codes VARCHAR(255);
+ALTER TABLE lei_raw.quarantined_records ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'PENDING';
+ALTER TABLE lei_raw.quarantined_records ADD COLUMN IF NOT EXISTS reviewed_by VARCHAR(255);
+ALTER TABLE lei_raw.quarantined_records ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+ALTER TABLE lei_raw.quarantined_records ADD COLUMN IF NOT EXISTS review_note TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_quarantined_records_status ON lei_raw.quarantined_records (status);
+
+COMMENT ON COLUMN lei_raw.quarantined_records.source IS 'DATABASE: rejected by the database on import; RULES: failed data quality rules (lei.qualityrules)';
+COMMENT ON COLUMN lei_raw.quarantined_records.rule_codes IS 'Comma-separated DQ rule codes the record failed, e.g. MISSING_LEGAL_NAME,INVALID_COUNTRY_CODE';
+COMMENT ON COLUMN lei_raw.quarantined_records.status IS 'PENDING awaiting review, REQUEUED loaded after review, DISMISSED deliberately not loaded';
@@ -0,0 +1,3 @@
+-- Rollback LEI data quality metrics
+
+DROP TABLE IF EXISTS lei_raw.quality_metrics;
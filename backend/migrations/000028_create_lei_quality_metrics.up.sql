@@ -0,0 +1,21 @@
+-- Data quality metrics per LEI source file: completeness and validity of each field across the
+-- records of the file, measured while it is loaded, so quality can be tracked across syncs
+
+CREATE TABLE IF NOT EXISTS lei_raw.quality_metrics (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    source_file_id UUID NOT NULL REFERENCES lei_raw.source_files (id) ON DELETE CASCADE,
+    field VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL,
+    records INTEGER NOT NULL,
+    populated INTEGER NOT NULL,
+    valid INTEGER,
+    completeness DOUBLE PRECISION NOT NULL,
+    validity DOUBLE PRECISION,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_quality_metrics_source_file_id ON lei_raw.quality_metrics (source_file_id);
+
+COMMENT ON TABLE lei_raw.quality_metrics IS 'Completeness and validity of LEI record fields per source file';
+COMMENT ON COLUMN lei_raw.quality_metrics.records IS 'Records of the file measured; after a resume only those read since the checkpoint';
+COMMENT ON COLUMN lei_raw.quality_metrics.valid IS 'Populated values passing the field''s validity check; NULL for fields only measured for completeness';
@@ -0,0 +1,4 @@
+-- Rollback entity LEI enrichment
+
+DROP TABLE IF EXISTS entity_field_provenance;
+ALTER TABLE entities DROP COLUMN IF EXISTS legal_form;
@@ -0,0 +1,20 @@
+-- Enrichment of entities from the LEI store: the entity's legal form, and the provenance of
+-- fields filled in automatically so later runs can tell them from fields maintained by hand
+
+ALTER TABLE entities ADD COLUMN IF NOT EXISTS legal_form VARCHAR(255);
+
+CREATE TABLE IF NOT EXISTS entity_field_provenance (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    entity_id UUID NOT NULL REFERENCES entities (id) ON DELETE CASCADE,
+    field VARCHAR(50) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    source_ref VARCHAR(100),
+    value TEXT NOT NULL,
+    filled_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_entity_field_provenance_field ON entity_field_provenance (entity_id, field);
+
+COMMENT ON COLUMN entities.legal_form IS 'ISO 20275 Entity Legal Form (ELF) code';
+COMMENT ON TABLE entity_field_provenance IS 'Entity fields filled in automatically (name, legal_form, registered_address), with the source and the value filled in';
+COMMENT ON COLUMN entity_field_provenance.value IS 'The value filled in; a field whose current value differs was edited since and is not overwritten by enrichment';
@@ -0,0 +1,5 @@
+-- Rollback entity duplicate detection and merge
+
+DROP INDEX IF EXISTS idx_entities_name_trgm;
+DROP TABLE IF EXISTS entity_merges;
+DROP TABLE IF EXISTS entity_duplicates;
@@ -0,0 +1,43 @@
+-- Duplicate detection for entities: probable duplicate pairs flagged by the scan, and an audit of
+-- merges that consolidated a duplicate onto a surviving golden record
+
+CREATE TABLE IF NOT EXISTS entity_duplicates (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    entity_id UUID NOT NULL REFERENCES entities (id),
+    duplicate_id UUID NOT NULL REFERENCES entities (id),
+    reasons VARCHAR(255) NOT NULL,
+    name_similarity DOUBLE PRECISION NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    detected_at TIMESTAMP NOT NULL,
+    reviewed_by VARCHAR(255),
+    reviewed_at TIMESTAMP,
+    CHECK (entity_id < duplicate_id)
+);
+
+CREATE UNIQUE INDEX idx_entity_duplicates_pair ON entity_duplicates (entity_id, duplicate_id);
+CREATE INDEX idx_entity_duplicates_duplicate_id ON entity_duplicates (duplicate_id);
+CREATE INDEX idx_entity_duplicates_status ON entity_duplicates (status);
+
+CREATE TABLE IF NOT EXISTS entity_merges (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    survivor_id UUID NOT NULL REFERENCES entities (id),
+    merged_id UUID NOT NULL REFERENCES entities (id),
+    merged_snapshot JSONB NOT NULL,
+    filled_fields VARCHAR(255),
+    accounts INTEGER NOT NULL,
+    ssis INTEGER NOT NULL,
+    addresses INTEGER NOT NULL,
+    merged_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_entity_merges_survivor_id ON entity_merges (survivor_id);
+CREATE INDEX idx_entity_merges_merged_id ON entity_merges (merged_id);
+
+-- Similar-name candidates are found with the pg_trgm % operator (extension from 000010)
+CREATE INDEX IF NOT EXISTS idx_entities_name_trgm ON entities USING gin (name gin_trgm_ops);
+
+COMMENT ON TABLE entity_duplicates IS 'Pairs of entities flagged as probable duplicates; entity_id is the lower ID of the pair';
+COMMENT ON COLUMN entity_duplicates.reasons IS 'Comma-separated: SAME_LEI, SAME_REGISTRATION_NUMBER (ignoring case, spaces and punctuation), SIMILAR_NAME';
+COMMENT ON COLUMN entity_duplicates.status IS 'OPEN awaiting review, MERGED consolidated by a merge, DISMISSED reviewed as distinct entities';
+COMMENT ON TABLE entity_merges IS 'Audit of entity merges: the merged (soft-deleted) entity as it was, and the accounts, SSIs and addresses moved to the survivor';
@@ -0,0 +1,4 @@
+-- Rollback counterparty onboarding workflow
+
+DROP TABLE IF EXISTS counterparty_onboarding_events;
+DROP TABLE IF EXISTS counterparty_onboardings;
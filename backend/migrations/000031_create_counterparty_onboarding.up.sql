@@ -0,0 +1,35 @@
+-- Counterparty onboarding workflow: draft -> enriched from LEI -> SSIs validated -> approved (or
+-- rejected), with every action audited
+
+CREATE TABLE IF NOT EXISTS counterparty_onboardings (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    entity_id UUID NOT NULL REFERENCES entities (id),
+    status VARCHAR(20) NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    approved_by VARCHAR(255),
+    approved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_counterparty_onboardings_entity_id ON counterparty_onboardings (entity_id);
+CREATE INDEX idx_counterparty_onboardings_status ON counterparty_onboardings (status);
+
+CREATE TABLE IF NOT EXISTS counterparty_onboarding_events (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    onboarding_id UUID NOT NULL REFERENCES counterparty_onboardings (id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    outcome VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    note TEXT,
+    details JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_counterparty_onboarding_events_onboarding_id ON counterparty_onboarding_events (onboarding_id);
+
+COMMENT ON TABLE counterparty_onboardings IS 'Counterparty onboarding workflow; the entity, its accounts and SSIs stay inactive until APPROVED and are deleted if REJECTED';
+COMMENT ON COLUMN counterparty_onboardings.status IS 'DRAFT, ENRICHED, SSIS_VALIDATED, APPROVED or REJECTED';
+COMMENT ON TABLE counterparty_onboarding_events IS 'Audit of every onboarding action (CREATE, ENRICH, VALIDATE_SSIS, APPROVE, REJECT), including failed attempts';
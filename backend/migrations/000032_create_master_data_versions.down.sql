@@ -0,0 +1,8 @@
+-- Rollback effective-dated master data versions
+
+DROP TRIGGER IF EXISTS version_instruments ON instruments;
+DROP TRIGGER IF EXISTS version_entities ON entities;
+DROP TRIGGER IF EXISTS version_currencies ON currencies;
+DROP TRIGGER IF EXISTS version_countries ON countries;
+DROP FUNCTION IF EXISTS RECORD_MASTER_DATA_VERSION();
+DROP TABLE IF EXISTS master_data_versions;
@@ -0,0 +1,78 @@
+-- Effective-dated versions of countries, currencies, entities and instruments. Each change to a
+-- row closes its current version and opens a new one, so reports can read the reference data
+-- that was in effect at a point in time (e.g. trade date).
+
+CREATE TABLE IF NOT EXISTS master_data_versions (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    domain VARCHAR(20) NOT NULL,
+    record_id UUID NOT NULL,
+    snapshot JSONB NOT NULL,
+    valid_from TIMESTAMP NOT NULL,
+    valid_to TIMESTAMP,
+    CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE INDEX idx_master_data_versions_record ON master_data_versions (domain, record_id, valid_from);
+CREATE INDEX idx_master_data_versions_window ON master_data_versions (domain, valid_from, valid_to);
+CREATE UNIQUE INDEX idx_master_data_versions_current ON master_data_versions (domain, record_id) WHERE valid_to IS NULL;
+
+-- Versions the row a trigger fired for; TG_ARGV[0] is the domain. Versions are stamped with the
+-- transaction time, so a record changed twice in one transaction keeps only its final version.
+CREATE OR REPLACE FUNCTION RECORD_MASTER_DATA_VERSION()
+RETURNS TRIGGER AS $$
+DECLARE
+    changed_at TIMESTAMP := NOW();
+    version JSONB;
+BEGIN
+    IF TG_OP = 'UPDATE' AND (TO_JSONB(NEW) - 'updated_at') = (TO_JSONB(OLD) - 'updated_at') THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP <> 'DELETE' THEN
+        IF NEW.deleted_at IS NULL THEN
+            version := TO_JSONB(NEW) - 'deleted_at';
+        END IF;
+    END IF;
+
+    IF TG_OP <> 'INSERT' THEN
+        DELETE FROM master_data_versions
+        WHERE domain = TG_ARGV[0] AND record_id = OLD.id AND valid_to IS NULL AND valid_from = changed_at;
+        UPDATE master_data_versions SET valid_to = changed_at
+        WHERE domain = TG_ARGV[0] AND record_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    -- A soft-deleted record has no version from the deletion on
+    IF version IS NOT NULL THEN
+        INSERT INTO master_data_versions (domain, record_id, snapshot, valid_from)
+        VALUES (TG_ARGV[0], NEW.id, version, changed_at);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER version_countries AFTER INSERT OR UPDATE OR DELETE ON countries
+FOR EACH ROW EXECUTE FUNCTION RECORD_MASTER_DATA_VERSION('country');
+
+CREATE TRIGGER version_currencies AFTER INSERT OR UPDATE OR DELETE ON currencies
+FOR EACH ROW EXECUTE FUNCTION RECORD_MASTER_DATA_VERSION('currency');
+
+CREATE TRIGGER version_entities AFTER INSERT OR UPDATE OR DELETE ON entities
+FOR EACH ROW EXECUTE FUNCTION RECORD_MASTER_DATA_VERSION('entity');
+
+CREATE TRIGGER version_instruments AFTER INSERT OR UPDATE OR DELETE ON instruments
+FOR EACH ROW EXECUTE FUNCTION RECORD_MASTER_DATA_VERSION('instrument');
+
+-- Existing records get a first version from their creation; earlier changes were not kept
+INSERT INTO master_data_versions (domain, record_id, snapshot, valid_from)
+SELECT 'country', id, TO_JSONB(c) - 'deleted_at', created_at FROM countries c WHERE deleted_at IS NULL;
+INSERT INTO master_data_versions (domain, record_id, snapshot, valid_from)
+SELECT 'currency', id, TO_JSONB(c) - 'deleted_at', created_at FROM currencies c WHERE deleted_at IS NULL;
+INSERT INTO master_data_versions (domain, record_id, snapshot, valid_from)
+SELECT 'entity', id, TO_JSONB(e) - 'deleted_at', created_at FROM entities e WHERE deleted_at IS NULL;
+INSERT INTO master_data_versions (domain, record_id, snapshot, valid_from)
+SELECT 'instrument', id, TO_JSONB(i) - 'deleted_at', created_at FROM instruments i WHERE deleted_at IS NULL;
+
+COMMENT ON TABLE master_data_versions IS 'Effective-dated versions of countries, currencies, entities and instruments, maintained by triggers';
+COMMENT ON COLUMN master_data_versions.domain IS 'country, currency, entity or instrument';
+COMMENT ON COLUMN master_data_versions.snapshot IS 'The row as it stood from valid_from, keyed by column name';
+COMMENT ON COLUMN master_data_versions.valid_to IS 'When the version was superseded or the record deleted; NULL for the current version';
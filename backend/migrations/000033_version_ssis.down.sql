@@ -0,0 +1,6 @@
+-- Rollback SSI versions
+
+DROP TRIGGER IF EXISTS version_ssis ON ssis;
+DELETE FROM master_data_versions WHERE domain = 'ssi';
+
+COMMENT ON COLUMN master_data_versions.domain IS 'country, currency, entity or instrument';
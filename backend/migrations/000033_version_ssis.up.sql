@@ -0,0 +1,10 @@
+-- Version SSIs like the rest of master data, so the SSI in effect for a settlement date can also be
+-- resolved as it was known at an earlier time (their own valid_from/valid_to being the other axis)
+
+CREATE TRIGGER version_ssis AFTER INSERT OR UPDATE OR DELETE ON ssis
+FOR EACH ROW EXECUTE FUNCTION RECORD_MASTER_DATA_VERSION('ssi');
+
+INSERT INTO master_data_versions (domain, record_id, snapshot, valid_from)
+SELECT 'ssi', id, TO_JSONB(s) - 'deleted_at', created_at FROM ssis s WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN master_data_versions.domain IS 'country, currency, entity, instrument or ssi';
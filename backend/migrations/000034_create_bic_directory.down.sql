@@ -0,0 +1,3 @@
+-- Rollback BIC directory
+
+DROP TABLE IF EXISTS bic_directory;
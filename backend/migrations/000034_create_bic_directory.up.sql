@@ -0,0 +1,23 @@
+-- ISO 9362 BIC directory, imported from a directory file; SSI BICs are checked against it once loaded
+
+CREATE TABLE IF NOT EXISTS bic_directory (
+    bic VARCHAR(11) PRIMARY KEY,
+    institution_name VARCHAR(255) NOT NULL,
+    branch_name VARCHAR(255),
+    city VARCHAR(100),
+    country_code VARCHAR(2) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    imported_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_bic_directory_country_code ON bic_directory (country_code);
+CREATE INDEX idx_bic_directory_institution_name ON bic_directory (LOWER(institution_name));
+
+CREATE TRIGGER update_bic_directory_updated_at BEFORE UPDATE ON bic_directory
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+COMMENT ON TABLE bic_directory IS 'ISO 9362 BIC directory; SSI BICs must be listed (and active) once it has entries';
+COMMENT ON COLUMN bic_directory.bic IS '11-character BIC; head offices are stored with branch code XXX';
+COMMENT ON COLUMN bic_directory.active IS 'False once a full import no longer lists the BIC';
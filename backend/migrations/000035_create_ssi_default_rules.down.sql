@@ -0,0 +1,9 @@
+-- Rollback SSI default rules
+
+ALTER TABLE ssis
+    DROP COLUMN IF EXISTS default_rule_id,
+    DROP COLUMN IF EXISTS cutoff_timezone,
+    DROP COLUMN IF EXISTS cutoff_time,
+    DROP COLUMN IF EXISTS place_of_settlement;
+
+DROP TABLE IF EXISTS ssi_default_rules;
@@ -0,0 +1,37 @@
+-- SSI defaults by market and currency: place of settlement, cutoff and settlement type, filled
+-- into new SSIs that leave them out
+
+CREATE TABLE IF NOT EXISTS ssi_default_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    market VARCHAR(10),
+    currency_id UUID REFERENCES currencies(id),
+    place_of_settlement VARCHAR(11),
+    settlement_type VARCHAR(50),
+    cutoff_time VARCHAR(5),
+    cutoff_timezone VARCHAR(64),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT ssi_default_rules_scope CHECK (market IS NOT NULL OR currency_id IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX idx_ssi_default_rules_scope ON ssi_default_rules
+    (COALESCE(market, ''), COALESCE(currency_id, '00000000-0000-0000-0000-000000000000'::UUID))
+    WHERE deleted_at IS NULL;
+CREATE INDEX idx_ssi_default_rules_deleted_at ON ssi_default_rules (deleted_at);
+
+CREATE TRIGGER update_ssi_default_rules_updated_at BEFORE UPDATE ON ssi_default_rules
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+ALTER TABLE ssis
+    ADD COLUMN IF NOT EXISTS place_of_settlement VARCHAR(11),
+    ADD COLUMN IF NOT EXISTS cutoff_time VARCHAR(5),
+    ADD COLUMN IF NOT EXISTS cutoff_timezone VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS default_rule_id UUID REFERENCES ssi_default_rules(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE ssi_default_rules IS 'SSI defaults by market (instrument primary exchange) and/or settlement currency; the most specific active rule fills a new SSI';
+COMMENT ON COLUMN ssi_default_rules.market IS 'Primary exchange of the SSI instrument, e.g. XLON; null matches any';
+COMMENT ON COLUMN ssi_default_rules.place_of_settlement IS 'BIC of the place of settlement (PSET), usually a CSD';
+COMMENT ON COLUMN ssi_default_rules.cutoff_time IS 'Instruction cutoff, HH:MM in cutoff_timezone';
+COMMENT ON COLUMN ssis.default_rule_id IS 'The SSI default rule that filled fields of this SSI at creation, if any';
@@ -0,0 +1,4 @@
+-- Rollback instrument CFI codes
+
+DROP INDEX IF EXISTS idx_instruments_cfi;
+ALTER TABLE instruments DROP COLUMN IF EXISTS cfi;
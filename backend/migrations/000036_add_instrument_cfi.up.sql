@@ -0,0 +1,7 @@
+-- ISO 10962 CFI code of instruments; the list endpoint filters on its prefix (cfi[prefix]=ES)
+
+ALTER TABLE instruments ADD COLUMN IF NOT EXISTS cfi VARCHAR(6);
+
+CREATE INDEX IF NOT EXISTS idx_instruments_cfi ON instruments (cfi VARCHAR_PATTERN_OPS);
+
+COMMENT ON COLUMN instruments.cfi IS 'ISO 10962 Classification of Financial Instruments code; sets type when it maps to one';
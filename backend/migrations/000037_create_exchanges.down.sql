@@ -0,0 +1,3 @@
+-- Rollback exchanges
+
+DROP TABLE IF EXISTS exchanges;
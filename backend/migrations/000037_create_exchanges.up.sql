@@ -0,0 +1,33 @@
+-- Exchanges and trading venues from the ISO 10383 MIC list; instrument primary exchanges and
+-- instrument code MICs are checked against it once loaded
+
+CREATE TABLE IF NOT EXISTS exchanges (
+    mic VARCHAR(4) PRIMARY KEY,
+    operating_mic VARCHAR(4) NOT NULL,
+    market_type VARCHAR(4) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    legal_entity_name VARCHAR(255),
+    lei VARCHAR(20),
+    market_category_code VARCHAR(4),
+    acronym VARCHAR(50),
+    country_code VARCHAR(2) NOT NULL,
+    city VARCHAR(100),
+    website VARCHAR(255),
+    status VARCHAR(10) NOT NULL,
+    creation_date DATE,
+    last_update_date DATE,
+    expiry_date DATE,
+    imported_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_exchanges_country_code ON exchanges (country_code);
+CREATE INDEX idx_exchanges_operating_mic ON exchanges (operating_mic);
+
+CREATE TRIGGER update_exchanges_updated_at BEFORE UPDATE ON exchanges
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+COMMENT ON TABLE exchanges IS 'ISO 10383 market identifier codes (MICs); instrument MICs must be listed and not expired once it has entries';
+COMMENT ON COLUMN exchanges.market_type IS 'OPRT (operating MIC, the venue operator) or SGMT (segment MIC, a segment of an operating MIC)';
+COMMENT ON COLUMN exchanges.status IS 'ACTIVE, UPDATED or EXPIRED, as published';
@@ -0,0 +1,4 @@
+-- Rollback ISO 3166-1 numeric codes and sub-regions of countries
+
+ALTER TABLE countries DROP COLUMN IF EXISTS sub_region;
+ALTER TABLE countries DROP COLUMN IF EXISTS numeric_code;
@@ -0,0 +1,7 @@
+-- ISO 3166-1 numeric codes and UN M49 sub-regions of countries, filled by the country sync
+
+ALTER TABLE countries ADD COLUMN IF NOT EXISTS numeric_code VARCHAR(3);
+ALTER TABLE countries ADD COLUMN IF NOT EXISTS sub_region VARCHAR(255);
+
+COMMENT ON COLUMN countries.numeric_code IS 'ISO 3166-1 numeric code, zero-padded (e.g. 040 for Austria)';
+COMMENT ON COLUMN countries.sub_region IS 'UN M49 sub-region (e.g. Western Europe)';
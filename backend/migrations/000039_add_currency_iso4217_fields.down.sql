@@ -0,0 +1,5 @@
+-- Rollback ISO 4217 numeric codes and fund/precious metal flags of currencies
+
+ALTER TABLE currencies DROP COLUMN IF EXISTS precious_metal;
+ALTER TABLE currencies DROP COLUMN IF EXISTS fund;
+ALTER TABLE currencies DROP COLUMN IF EXISTS numeric_code;
@@ -0,0 +1,10 @@
+-- ISO 4217 numeric codes of currencies, and flags for fund and precious metal codes, filled by the
+-- currency sync
+
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS numeric_code VARCHAR(3);
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS fund BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE currencies ADD COLUMN IF NOT EXISTS precious_metal BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN currencies.numeric_code IS 'ISO 4217 numeric code, zero-padded (e.g. 008 for ALL)';
+COMMENT ON COLUMN currencies.fund IS 'ISO 4217 fund code (e.g. BOV, CLF, USN) rather than a currency';
+COMMENT ON COLUMN currencies.precious_metal IS 'ISO 4217 precious metal code (XAU, XAG, XPT, XPD)';
@@ -0,0 +1,3 @@
+-- Rollback elf_codes
+
+DROP TABLE IF EXISTS elf_codes;
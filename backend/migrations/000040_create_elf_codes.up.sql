@@ -0,0 +1,29 @@
+-- ISO 20275 entity legal forms (ELF) from the GLEIF code list; LEI records carry the ELF code of
+-- their legal form, which the API resolves to its name
+
+CREATE TABLE IF NOT EXISTS elf_codes (
+    code VARCHAR(4) PRIMARY KEY,
+    country_code VARCHAR(2) NOT NULL,
+    country VARCHAR(255),
+    jurisdiction VARCHAR(255),
+    subdivision_code VARCHAR(10),
+    name VARCHAR(500) NOT NULL,
+    abbreviations VARCHAR(500),
+    names JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(4) NOT NULL,
+    created_date DATE,
+    modification_date DATE,
+    imported_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_elf_codes_country_code ON elf_codes (country_code);
+
+CREATE TRIGGER update_elf_codes_updated_at BEFORE UPDATE ON elf_codes
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+COMMENT ON TABLE elf_codes IS 'ISO 20275 entity legal forms from the GLEIF ELF code list, one row per code';
+COMMENT ON COLUMN elf_codes.name IS 'Display name: the local name of the first listed language, transliterated if not in Latin script';
+COMMENT ON COLUMN elf_codes.names IS 'Local and transliterated names and abbreviations per language, as listed';
+COMMENT ON COLUMN elf_codes.status IS 'ACTV or INAC, as published';
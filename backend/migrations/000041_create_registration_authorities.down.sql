@@ -0,0 +1,3 @@
+-- Rollback registration_authorities
+
+DROP TABLE IF EXISTS registration_authorities;
@@ -0,0 +1,27 @@
+-- Business registers from the GLEIF Registration Authorities (RA) list; LEI records carry the RA
+-- code of the register their entity is registered in, which the API resolves to its name
+
+CREATE TABLE IF NOT EXISTS registration_authorities (
+    code VARCHAR(8) PRIMARY KEY,
+    country_code VARCHAR(2),
+    country VARCHAR(255),
+    jurisdiction VARCHAR(255),
+    register_name VARCHAR(500),
+    register_local_name VARCHAR(500),
+    organization_name VARCHAR(500),
+    organization_local_name VARCHAR(500),
+    website VARCHAR(500),
+    name VARCHAR(500) NOT NULL,
+    imported_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_registration_authorities_country_code ON registration_authorities (country_code);
+
+CREATE TRIGGER update_registration_authorities_updated_at BEFORE UPDATE ON registration_authorities
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+COMMENT ON TABLE registration_authorities IS 'Business registers of the GLEIF RA list, one row per RA code';
+COMMENT ON COLUMN registration_authorities.name IS 'Display name: the international name of the register, else its local name, else the responsible organization';
+COMMENT ON COLUMN registration_authorities.jurisdiction IS 'Country or region the register covers, as listed';
@@ -0,0 +1,3 @@
+-- Rollback lous
+
+DROP TABLE IF EXISTS lous;
@@ -0,0 +1,21 @@
+-- LEI issuers (Local Operating Units) from GLEIF's LEI issuer list; LEI records carry the LEI of
+-- their managing LOU, which the API resolves to its name
+
+CREATE TABLE IF NOT EXISTS lous (
+    lei VARCHAR(20) PRIMARY KEY,
+    name VARCHAR(500) NOT NULL,
+    marketing_name VARCHAR(500),
+    website VARCHAR(500),
+    accreditation_date DATE,
+    accredited BOOLEAN NOT NULL DEFAULT TRUE,
+    synced_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_lous_updated_at BEFORE UPDATE ON lous
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+COMMENT ON TABLE lous IS 'LEI issuers (Local Operating Units) from the GLEIF LEI issuer list, one row per LOU LEI';
+COMMENT ON COLUMN lous.accredited IS 'Listed by GLEIF at the last sync; false once an LOU drops off the list (e.g. accreditation withdrawn)';
+COMMENT ON COLUMN lous.synced_at IS 'Last sync that listed the LOU';
@@ -0,0 +1,4 @@
+-- Rollback holiday calendars
+
+DROP TABLE IF EXISTS calendar_holidays;
+DROP TABLE IF EXISTS holiday_calendars;
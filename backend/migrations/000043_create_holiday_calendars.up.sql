@@ -0,0 +1,25 @@
+-- Holiday calendars by market (MIC, e.g. XLON) or currency (e.g. USD), for working out settlement
+-- dates: a day is a business day unless it is a weekend day or a holiday of the calendar
+
+CREATE TABLE IF NOT EXISTS holiday_calendars (
+    code VARCHAR(10) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    weekend_days VARCHAR(27) NOT NULL DEFAULT 'SAT,SUN',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_holiday_calendars_updated_at BEFORE UPDATE ON holiday_calendars
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+CREATE TABLE IF NOT EXISTS calendar_holidays (
+    calendar_code VARCHAR(10) NOT NULL REFERENCES holiday_calendars (code) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (calendar_code, date)
+);
+
+COMMENT ON TABLE holiday_calendars IS 'Business day calendars by market (MIC) or currency, used to compute settlement dates';
+COMMENT ON COLUMN holiday_calendars.weekend_days IS 'Comma separated non-business weekdays (MON..SUN), e.g. SAT,SUN or FRI,SAT';
+COMMENT ON TABLE calendar_holidays IS 'Non-business days of a calendar besides its weekend days';
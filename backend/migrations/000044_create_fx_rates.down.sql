@@ -0,0 +1,3 @@
+-- Rollback FX rates
+
+DROP TABLE IF EXISTS fx_rates;
@@ -0,0 +1,23 @@
+-- Daily FX reference rates, one per date and currency pair, as published by the configured source
+-- (by default the ECB euro foreign exchange reference rates). Cross rates are derived on read.
+
+CREATE TABLE IF NOT EXISTS fx_rates (
+    rate_date DATE NOT NULL,
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate NUMERIC(28, 10) NOT NULL CHECK (rate > 0),
+    source VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (rate_date, base_currency, quote_currency)
+);
+
+-- Latest-rate lookup: newest date first per pair
+CREATE INDEX idx_fx_rates_pair_date ON fx_rates (base_currency, quote_currency, rate_date DESC);
+
+CREATE TRIGGER update_fx_rates_updated_at BEFORE UPDATE ON fx_rates
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+COMMENT ON TABLE fx_rates IS 'Daily FX reference rates: 1 base_currency = rate quote_currency on rate_date';
+COMMENT ON COLUMN fx_rates.base_currency IS 'ISO 4217 code of the source''s base currency, e.g. EUR for the ECB';
+COMMENT ON COLUMN fx_rates.source IS 'Publisher of the rate, e.g. ECB';
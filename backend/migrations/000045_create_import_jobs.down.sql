@@ -0,0 +1,3 @@
+-- Rollback import jobs
+
+DROP TABLE IF EXISTS import_jobs;
@@ -0,0 +1,33 @@
+-- File imports of master data (countries, currencies, entities, instruments, SSIs) through
+-- POST /api/v1/data/import, with the outcome of every row
+
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+    kind VARCHAR(20) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(30) NOT NULL,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    updated_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    ignored_columns JSONB NOT NULL DEFAULT '[]',
+    report JSONB NOT NULL DEFAULT '[]',
+    error TEXT NOT NULL DEFAULT '',
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Job list: newest first
+CREATE INDEX idx_import_jobs_started_at ON import_jobs (started_at DESC);
+
+CREATE TRIGGER update_import_jobs_updated_at BEFORE UPDATE ON import_jobs
+FOR EACH ROW EXECUTE FUNCTION UPDATE_UPDATED_AT_COLUMN();
+
+COMMENT ON TABLE import_jobs IS 'Master data file imports and their per-row outcome';
+COMMENT ON COLUMN import_jobs.kind IS 'What the file holds: countries, currencies, entities, instruments or ssis';
+COMMENT ON COLUMN import_jobs.status IS 'COMPLETED, COMPLETED_WITH_ERRORS (some rows failed) or FAILED (the file could not be read)';
+COMMENT ON COLUMN import_jobs.report IS 'Outcome of each row: row number, action (created, updated or failed), record ID and error';
@@ -0,0 +1,6 @@
+-- Rollback dry-run imports and unchanged row counts
+
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS unchanged_rows;
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS dry_run;
+
+COMMENT ON COLUMN import_jobs.report IS 'Outcome of each row: row number, action (created, updated or failed), record ID and error';
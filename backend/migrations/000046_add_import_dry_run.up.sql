@@ -0,0 +1,9 @@
+-- Dry-run imports (validated and compared with the stored records, nothing written) and rows that
+-- would not change anything
+
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS dry_run BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS unchanged_rows INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN import_jobs.dry_run IS 'Rows were validated and compared but nothing was written; the counts and report say what the import would do';
+COMMENT ON COLUMN import_jobs.unchanged_rows IS 'Rows matching a record whose values they already hold; these are not written';
+COMMENT ON COLUMN import_jobs.report IS 'Outcome of each row: row number, action (created, updated, unchanged or failed), record ID, changed fields and error';
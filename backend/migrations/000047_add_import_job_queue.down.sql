@@ -0,0 +1,12 @@
+-- Rollback the background import queue
+
+DROP INDEX IF EXISTS idx_import_jobs_created_at;
+CREATE INDEX IF NOT EXISTS idx_import_jobs_started_at ON import_jobs (started_at DESC);
+DROP INDEX IF EXISTS idx_import_jobs_queued;
+
+UPDATE import_jobs SET started_at = created_at WHERE started_at IS NULL;
+ALTER TABLE import_jobs ALTER COLUMN started_at SET NOT NULL;
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS processed_rows;
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS payload;
+
+COMMENT ON COLUMN import_jobs.status IS 'COMPLETED, COMPLETED_WITH_ERRORS (some rows failed) or FAILED (the file could not be read)';
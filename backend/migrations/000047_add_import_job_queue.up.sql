@@ -0,0 +1,16 @@
+-- Imports run in the background: the uploaded file waits in the job row until a worker picks it
+-- up, and the worker records its progress
+
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS payload BYTEA;
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS processed_rows INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE import_jobs ALTER COLUMN started_at DROP NOT NULL;
+
+-- Workers take the oldest queued job; the job list is newest first
+CREATE INDEX IF NOT EXISTS idx_import_jobs_queued ON import_jobs (created_at) WHERE status = 'QUEUED';
+DROP INDEX IF EXISTS idx_import_jobs_started_at;
+CREATE INDEX IF NOT EXISTS idx_import_jobs_created_at ON import_jobs (created_at DESC);
+
+COMMENT ON COLUMN import_jobs.status IS 'QUEUED, RUNNING, COMPLETED, COMPLETED_WITH_ERRORS (some rows failed) or FAILED (the file could not be read, or the import was interrupted)';
+COMMENT ON COLUMN import_jobs.payload IS 'The uploaded file, kept until a worker has processed it';
+COMMENT ON COLUMN import_jobs.processed_rows IS 'Rows processed so far; saved every few seconds while the import runs';
+COMMENT ON COLUMN import_jobs.started_at IS 'When a worker picked the job up; empty while queued';
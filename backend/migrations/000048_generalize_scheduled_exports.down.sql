@@ -0,0 +1,13 @@
+DELETE FROM scheduled_exports WHERE saved_view_id IS NULL;
+
+ALTER TABLE scheduled_exports DROP COLUMN IF EXISTS sftp_directory;
+ALTER TABLE scheduled_exports DROP COLUMN IF EXISTS sftp_username;
+ALTER TABLE scheduled_exports DROP COLUMN IF EXISTS sftp_port;
+ALTER TABLE scheduled_exports DROP COLUMN IF EXISTS sftp_host;
+
+ALTER TABLE scheduled_exports ALTER COLUMN saved_view_id SET NOT NULL;
+ALTER TABLE scheduled_exports DROP COLUMN IF EXISTS filters;
+ALTER TABLE scheduled_exports DROP COLUMN IF EXISTS kind;
+
+COMMENT ON TABLE scheduled_exports IS 'Recurring exports of saved LEI views, run by the scheduler when next_run_at passes';
+COMMENT ON COLUMN scheduled_exports.destination IS 'FILE (written under export.dir)';
@@ -0,0 +1,19 @@
+-- Scheduled exports of any master data kind with list-endpoint filters, and SFTP delivery
+-- An export either runs a saved LEI view or has its own kind and filters; SFTP exports are
+-- uploaded to a server instead of written under export.dir.
+
+ALTER TABLE scheduled_exports ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'lei';
+ALTER TABLE scheduled_exports ADD COLUMN IF NOT EXISTS filters JSONB;
+ALTER TABLE scheduled_exports ALTER COLUMN saved_view_id DROP NOT NULL;
+
+ALTER TABLE scheduled_exports ADD COLUMN IF NOT EXISTS sftp_host VARCHAR(255);
+ALTER TABLE scheduled_exports ADD COLUMN IF NOT EXISTS sftp_port INTEGER;
+ALTER TABLE scheduled_exports ADD COLUMN IF NOT EXISTS sftp_username VARCHAR(255);
+ALTER TABLE scheduled_exports ADD COLUMN IF NOT EXISTS sftp_directory VARCHAR(500);
+
+COMMENT ON TABLE scheduled_exports IS 'Recurring exports of master data or LEI records, run by the scheduler when next_run_at passes';
+COMMENT ON COLUMN scheduled_exports.kind IS 'countries, currencies, entities, instruments, accounts, ssis or lei';
+COMMENT ON COLUMN scheduled_exports.filters IS 'Filters of the kind''s list endpoint, by query parameter name; unused with a saved view';
+COMMENT ON COLUMN scheduled_exports.saved_view_id IS 'Saved LEI view the export runs, instead of its own filters';
+COMMENT ON COLUMN scheduled_exports.destination IS 'FILE (written under export.dir) or SFTP (uploaded to sftp_host)';
+COMMENT ON COLUMN scheduled_exports.sftp_directory IS 'Remote directory SFTP exports are uploaded to; it must exist';
@@ -0,0 +1,5 @@
+DELETE FROM scheduled_exports WHERE destination = 'S3';
+
+ALTER TABLE scheduled_exports DROP COLUMN IF EXISTS s3_prefix;
+
+COMMENT ON COLUMN scheduled_exports.destination IS 'FILE (written under export.dir) or SFTP (uploaded to sftp_host)';
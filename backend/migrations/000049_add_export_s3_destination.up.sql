@@ -0,0 +1,6 @@
+-- S3 delivery of scheduled exports, into the configured archive bucket
+
+ALTER TABLE scheduled_exports ADD COLUMN IF NOT EXISTS s3_prefix VARCHAR(500);
+
+COMMENT ON COLUMN scheduled_exports.destination IS 'FILE (written under export.dir), SFTP (uploaded to sftp_host) or S3 (uploaded to the archive bucket)';
+COMMENT ON COLUMN scheduled_exports.s3_prefix IS 'Key prefix of S3 exports within the archive bucket, below archive.prefix';
@@ -0,0 +1,44 @@
+-- Application-level encryption of SSI beneficiary accounts. Encrypted values are longer than the
+-- accounts they hold, so the column loses its length limit. Key rotation rewrites the stored values
+-- with axiom.reencrypting set, which the versioning trigger skips: the records did not change.
+
+ALTER TABLE ssis ALTER COLUMN beneficiary_account TYPE TEXT;
+
+COMMENT ON COLUMN ssis.beneficiary_account IS 'Plain, or enc:v1:<key id>:<wrapped data key>:<ciphertext> when encryption.provider is set';
+
+CREATE OR REPLACE FUNCTION RECORD_MASTER_DATA_VERSION()
+RETURNS TRIGGER AS $$
+DECLARE
+    changed_at TIMESTAMP := NOW();
+    version JSONB;
+BEGIN
+    IF TG_OP = 'UPDATE' AND (TO_JSONB(NEW) - 'updated_at') = (TO_JSONB(OLD) - 'updated_at') THEN
+        RETURN NULL;
+    END IF;
+
+    -- Re-encrypting under a new key changes how a value is stored, not the value
+    IF TG_OP = 'UPDATE' AND CURRENT_SETTING('axiom.reencrypting', TRUE) = 'on' THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP <> 'DELETE' THEN
+        IF NEW.deleted_at IS NULL THEN
+            version := TO_JSONB(NEW) - 'deleted_at';
+        END IF;
+    END IF;
+
+    IF TG_OP <> 'INSERT' THEN
+        DELETE FROM master_data_versions
+        WHERE domain = TG_ARGV[0] AND record_id = OLD.id AND valid_to IS NULL AND valid_from = changed_at;
+        UPDATE master_data_versions SET valid_to = changed_at
+        WHERE domain = TG_ARGV[0] AND record_id = OLD.id AND valid_to IS NULL;
+    END IF;
+
+    -- A soft-deleted record has no version from the deletion on
+    IF version IS NOT NULL THEN
+        INSERT INTO master_data_versions (domain, record_id, snapshot, valid_from)
+        VALUES (TG_ARGV[0], NEW.id, version, changed_at);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,13 @@
+-- High-water marks of the pg_stat_user_tables change counters behind GET /data/freshness. The
+-- counters restart at zero on pg_stat_reset(), after a crash and on a failover; the offset carries
+-- the count from before, so the change counters reported never go backwards.
+
+CREATE TABLE IF NOT EXISTS change_counter_offsets (
+    table_name VARCHAR(100) PRIMARY KEY,
+    base BIGINT NOT NULL DEFAULT 0,
+    last_seen BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN change_counter_offsets.base IS 'Added to the statistics counter: the counts seen before each reset, plus one per reset';
+COMMENT ON COLUMN change_counter_offsets.last_seen IS 'Statistics counter last read; a lower reading means the statistics were reset';
@@ -0,0 +1 @@
+ALTER TABLE entity_merges DROP COLUMN IF EXISTS onboardings;
@@ -0,0 +1,5 @@
+-- Entity merges move the merged entity's onboarding to the survivor when it has none
+
+ALTER TABLE entity_merges ADD COLUMN IF NOT EXISTS onboardings INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN entity_merges.onboardings IS 'Onboardings moved to the survivor: 0 or 1, as an entity has one at most';
@@ -0,0 +1 @@
+ALTER TABLE ssis_audit ADD COLUMN IF NOT EXISTS beneficiary_account VARCHAR(255);
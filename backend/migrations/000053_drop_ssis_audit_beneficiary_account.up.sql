@@ -0,0 +1,4 @@
+-- SSI beneficiary accounts are encrypted at rest (000050), but the SSI audit table kept a copy in
+-- a plain column. Nothing writes it; the audit snapshot holds the account as stored in ssis.
+
+ALTER TABLE ssis_audit DROP COLUMN IF EXISTS beneficiary_account;
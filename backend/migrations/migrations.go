@@ -0,0 +1,11 @@
+// Package migrations holds the versioned SQL migrations of the database schema, including the
+// lei_raw schema and every index. They are built into the binary, which applies them with
+// "migrate up" and, until the database has all of them, reports not ready.
+package migrations
+
+import "embed"
+
+// FS holds the migrations as <version>_<name>.up.sql and <version>_<name>.down.sql
+//
+//go:embed *.sql
+var FS embed.FS
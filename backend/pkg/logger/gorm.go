@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// SlowQueryThreshold is how long a query runs before it is logged as slow
+const SlowQueryThreshold = 200 * time.Millisecond
+
+// GORM logs GORM's messages and queries with the logger of the query's context, so the queries
+// run for a request carry its request ID. "record not found" errors are not logged: they are
+// expected, e.g. when an upsert looks for the row it is about to create.
+type GORM struct {
+	level gormlogger.LogLevel
+}
+
+// NewGORM returns a GORM logger logging at level: errors at Error, slow queries too at Warn, and
+// every query at Info
+func NewGORM(level gormlogger.LogLevel) *GORM {
+	return &GORM{level: level}
+}
+
+// LogMode returns a copy of the logger logging at level
+func (l *GORM) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &GORM{level: level}
+}
+
+func (l *GORM) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		log.Ctx(ctx).Info().Msgf(msg, data...)
+	}
+}
+
+func (l *GORM) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		log.Ctx(ctx).Warn().Msgf(msg, data...)
+	}
+}
+
+func (l *GORM) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error && !strings.Contains(msg, "record not found") {
+		log.Ctx(ctx).Error().Msgf(msg, data...)
+	}
+}
+
+// Trace logs a query that failed, was slow, or, at Info, any query
+func (l *GORM) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gormlogger.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		sql, rows := fc()
+		log.Ctx(ctx).Error().Err(err).Dur("elapsed", elapsed).Int64("rows", rows).Str("sql", sql).Msg("Database query failed")
+	case elapsed > SlowQueryThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		log.Ctx(ctx).Warn().Dur("elapsed", elapsed).Int64("rows", rows).Str("sql", sql).Msg("Slow database query")
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		log.Ctx(ctx).Info().Dur("elapsed", elapsed).Int64("rows", rows).Str("sql", sql).Msg("Database query")
+	}
+}
@@ -12,7 +12,23 @@ var logger zerolog.Logger
 // Init initializes the logger
 func Init(level string) {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
+	SetLevel(level)
 
+	// The level is applied globally rather than to the logger, so SetLevel also reaches the loggers
+	// derived from it (such as each request's)
+	logger = zerolog.New(os.Stdout).
+		With().
+		Timestamp().
+		Caller().
+		Logger()
+
+	log.Logger = logger
+	// log.Ctx on a context without a request logger (background jobs, tests) logs as log.Logger
+	zerolog.DefaultContextLogger = &log.Logger
+}
+
+// SetLevel changes the level of every logger at runtime: debug, warn, error, or info for anything else
+func SetLevel(level string) {
 	logLevel := zerolog.InfoLevel
 	switch level {
 	case "debug":
@@ -22,15 +38,7 @@ func Init(level string) {
 	case "error":
 		logLevel = zerolog.ErrorLevel
 	}
-
-	logger = zerolog.New(os.Stdout).
-		Level(logLevel).
-		With().
-		Timestamp().
-		Caller().
-		Logger()
-
-	log.Logger = logger
+	zerolog.SetGlobalLevel(logLevel)
 }
 
 // Debug returns a debug level event
@@ -0,0 +1,959 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: axiom/v1/lei.proto
+
+// LEI lookups for internal services that make too many of them for the REST API, where encoding
+// JSON and a request per record dominate. The records are those of GET /api/v1/lei, with the
+// codes of legal forms, registration authorities and managing LOUs resolved to names.
+
+package axiomv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetLEIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lei string `protobuf:"bytes,1,opt,name=lei,proto3" json:"lei,omitempty"`
+}
+
+func (x *GetLEIRequest) Reset() {
+	*x = GetLEIRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_axiom_v1_lei_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLEIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLEIRequest) ProtoMessage() {}
+
+func (x *GetLEIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_axiom_v1_lei_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLEIRequest.ProtoReflect.Descriptor instead.
+func (*GetLEIRequest) Descriptor() ([]byte, []int) {
+	return file_axiom_v1_lei_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetLEIRequest) GetLei() string {
+	if x != nil {
+		return x.Lei
+	}
+	return ""
+}
+
+type BatchGetLEIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Leis []string `protobuf:"bytes,1,rep,name=leis,proto3" json:"leis,omitempty"`
+}
+
+func (x *BatchGetLEIRequest) Reset() {
+	*x = BatchGetLEIRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_axiom_v1_lei_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGetLEIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetLEIRequest) ProtoMessage() {}
+
+func (x *BatchGetLEIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_axiom_v1_lei_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetLEIRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetLEIRequest) Descriptor() ([]byte, []int) {
+	return file_axiom_v1_lei_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatchGetLEIRequest) GetLeis() []string {
+	if x != nil {
+		return x.Leis
+	}
+	return nil
+}
+
+type BatchGetLEIResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*LEIRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"` // in the order requested
+	Missing []string     `protobuf:"bytes,2,rep,name=missing,proto3" json:"missing,omitempty"` // codes not held
+}
+
+func (x *BatchGetLEIResponse) Reset() {
+	*x = BatchGetLEIResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_axiom_v1_lei_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchGetLEIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetLEIResponse) ProtoMessage() {}
+
+func (x *BatchGetLEIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_axiom_v1_lei_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetLEIResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetLEIResponse) Descriptor() ([]byte, []int) {
+	return file_axiom_v1_lei_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchGetLEIResponse) GetRecords() []*LEIRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *BatchGetLEIResponse) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+// SearchLEIRequest takes the filters of GET /api/v1/lei
+type SearchLEIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Search      string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`                              // LEI code or part of the legal name
+	Query       string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`                                // full-text search on the legal name, ranked by relevance unless sorted
+	Status      string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                              // entity status, e.g. ACTIVE
+	Category    string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`                          // entity category, e.g. FUND
+	Country     string `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`                            // legal address country, e.g. GB
+	LegalForm   string `protobuf:"bytes,6,opt,name=legal_form,json=legalForm,proto3" json:"legal_form,omitempty"`       // ELF code, or part of the legal form's name or an abbreviation
+	ManagingLou string `protobuf:"bytes,7,opt,name=managing_lou,json=managingLou,proto3" json:"managing_lou,omitempty"` // the LOU's LEI, or part of its name
+	SortBy      string `protobuf:"bytes,8,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                // lei, legal_name (default), entity_status, entity_category, legal_address_country, last_update_date
+	Descending  bool   `protobuf:"varint,9,opt,name=descending,proto3" json:"descending,omitempty"`
+	Limit       int32  `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"` // records to stream at most; 0 streams every match
+}
+
+func (x *SearchLEIRequest) Reset() {
+	*x = SearchLEIRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_axiom_v1_lei_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchLEIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLEIRequest) ProtoMessage() {}
+
+func (x *SearchLEIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_axiom_v1_lei_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchLEIRequest.ProtoReflect.Descriptor instead.
+func (*SearchLEIRequest) Descriptor() ([]byte, []int) {
+	return file_axiom_v1_lei_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchLEIRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *SearchLEIRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchLEIRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SearchLEIRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SearchLEIRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SearchLEIRequest) GetLegalForm() string {
+	if x != nil {
+		return x.LegalForm
+	}
+	return ""
+}
+
+func (x *SearchLEIRequest) GetManagingLou() string {
+	if x != nil {
+		return x.ManagingLou
+	}
+	return ""
+}
+
+func (x *SearchLEIRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *SearchLEIRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *SearchLEIRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LEIRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lei                       string                 `protobuf:"bytes,1,opt,name=lei,proto3" json:"lei,omitempty"`
+	LegalName                 string                 `protobuf:"bytes,2,opt,name=legal_name,json=legalName,proto3" json:"legal_name,omitempty"`
+	TransliteratedLegalName   string                 `protobuf:"bytes,3,opt,name=transliterated_legal_name,json=transliteratedLegalName,proto3" json:"transliterated_legal_name,omitempty"`
+	OtherNames                []*OtherName           `protobuf:"bytes,4,rep,name=other_names,json=otherNames,proto3" json:"other_names,omitempty"`
+	LegalAddress              *Address               `protobuf:"bytes,5,opt,name=legal_address,json=legalAddress,proto3" json:"legal_address,omitempty"`
+	HeadquartersAddress       *Address               `protobuf:"bytes,6,opt,name=headquarters_address,json=headquartersAddress,proto3" json:"headquarters_address,omitempty"`
+	RegistrationAuthority     string                 `protobuf:"bytes,7,opt,name=registration_authority,json=registrationAuthority,proto3" json:"registration_authority,omitempty"` // GLEIF RA code
+	RegistrationAuthorityId   string                 `protobuf:"bytes,8,opt,name=registration_authority_id,json=registrationAuthorityId,proto3" json:"registration_authority_id,omitempty"`
+	RegistrationAuthorityName string                 `protobuf:"bytes,9,opt,name=registration_authority_name,json=registrationAuthorityName,proto3" json:"registration_authority_name,omitempty"`
+	RegistrationNumber        string                 `protobuf:"bytes,10,opt,name=registration_number,json=registrationNumber,proto3" json:"registration_number,omitempty"`
+	EntityCategory            string                 `protobuf:"bytes,11,opt,name=entity_category,json=entityCategory,proto3" json:"entity_category,omitempty"`
+	EntitySubCategory         string                 `protobuf:"bytes,12,opt,name=entity_sub_category,json=entitySubCategory,proto3" json:"entity_sub_category,omitempty"`
+	EntityLegalForm           string                 `protobuf:"bytes,13,opt,name=entity_legal_form,json=entityLegalForm,proto3" json:"entity_legal_form,omitempty"` // ISO 20275 ELF code
+	EntityLegalFormName       string                 `protobuf:"bytes,14,opt,name=entity_legal_form_name,json=entityLegalFormName,proto3" json:"entity_legal_form_name,omitempty"`
+	EntityStatus              string                 `protobuf:"bytes,15,opt,name=entity_status,json=entityStatus,proto3" json:"entity_status,omitempty"`
+	RegistrationStatus        string                 `protobuf:"bytes,16,opt,name=registration_status,json=registrationStatus,proto3" json:"registration_status,omitempty"` // ISSUED, LAPSED, RETIRED, ...
+	ManagingLou               string                 `protobuf:"bytes,17,opt,name=managing_lou,json=managingLou,proto3" json:"managing_lou,omitempty"`
+	ManagingLouName           string                 `protobuf:"bytes,18,opt,name=managing_lou_name,json=managingLouName,proto3" json:"managing_lou_name,omitempty"`
+	SuccessorLei              string                 `protobuf:"bytes,19,opt,name=successor_lei,json=successorLei,proto3" json:"successor_lei,omitempty"`
+	InitialRegistrationDate   *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=initial_registration_date,json=initialRegistrationDate,proto3" json:"initial_registration_date,omitempty"`
+	LastUpdateDate            *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=last_update_date,json=lastUpdateDate,proto3" json:"last_update_date,omitempty"`
+	NextRenewalDate           *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=next_renewal_date,json=nextRenewalDate,proto3" json:"next_renewal_date,omitempty"`
+	ValidationAuthority       string                 `protobuf:"bytes,23,opt,name=validation_authority,json=validationAuthority,proto3" json:"validation_authority,omitempty"`
+}
+
+func (x *LEIRecord) Reset() {
+	*x = LEIRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_axiom_v1_lei_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LEIRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LEIRecord) ProtoMessage() {}
+
+func (x *LEIRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_axiom_v1_lei_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LEIRecord.ProtoReflect.Descriptor instead.
+func (*LEIRecord) Descriptor() ([]byte, []int) {
+	return file_axiom_v1_lei_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LEIRecord) GetLei() string {
+	if x != nil {
+		return x.Lei
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetLegalName() string {
+	if x != nil {
+		return x.LegalName
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetTransliteratedLegalName() string {
+	if x != nil {
+		return x.TransliteratedLegalName
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetOtherNames() []*OtherName {
+	if x != nil {
+		return x.OtherNames
+	}
+	return nil
+}
+
+func (x *LEIRecord) GetLegalAddress() *Address {
+	if x != nil {
+		return x.LegalAddress
+	}
+	return nil
+}
+
+func (x *LEIRecord) GetHeadquartersAddress() *Address {
+	if x != nil {
+		return x.HeadquartersAddress
+	}
+	return nil
+}
+
+func (x *LEIRecord) GetRegistrationAuthority() string {
+	if x != nil {
+		return x.RegistrationAuthority
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetRegistrationAuthorityId() string {
+	if x != nil {
+		return x.RegistrationAuthorityId
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetRegistrationAuthorityName() string {
+	if x != nil {
+		return x.RegistrationAuthorityName
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetRegistrationNumber() string {
+	if x != nil {
+		return x.RegistrationNumber
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetEntityCategory() string {
+	if x != nil {
+		return x.EntityCategory
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetEntitySubCategory() string {
+	if x != nil {
+		return x.EntitySubCategory
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetEntityLegalForm() string {
+	if x != nil {
+		return x.EntityLegalForm
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetEntityLegalFormName() string {
+	if x != nil {
+		return x.EntityLegalFormName
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetEntityStatus() string {
+	if x != nil {
+		return x.EntityStatus
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetRegistrationStatus() string {
+	if x != nil {
+		return x.RegistrationStatus
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetManagingLou() string {
+	if x != nil {
+		return x.ManagingLou
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetManagingLouName() string {
+	if x != nil {
+		return x.ManagingLouName
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetSuccessorLei() string {
+	if x != nil {
+		return x.SuccessorLei
+	}
+	return ""
+}
+
+func (x *LEIRecord) GetInitialRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InitialRegistrationDate
+	}
+	return nil
+}
+
+func (x *LEIRecord) GetLastUpdateDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdateDate
+	}
+	return nil
+}
+
+func (x *LEIRecord) GetNextRenewalDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRenewalDate
+	}
+	return nil
+}
+
+func (x *LEIRecord) GetValidationAuthority() string {
+	if x != nil {
+		return x.ValidationAuthority
+	}
+	return ""
+}
+
+type OtherName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type     string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // e.g. PREVIOUS_LEGAL_NAME, TRADING_OR_OPERATING_NAME
+	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *OtherName) Reset() {
+	*x = OtherName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_axiom_v1_lei_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OtherName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OtherName) ProtoMessage() {}
+
+func (x *OtherName) ProtoReflect() protoreflect.Message {
+	mi := &file_axiom_v1_lei_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OtherName.ProtoReflect.Descriptor instead.
+func (*OtherName) Descriptor() ([]byte, []int) {
+	return file_axiom_v1_lei_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OtherName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OtherName) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OtherName) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type Address struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lines      []string `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	City       string   `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Region     string   `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	Country    string   `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"` // ISO 3166-1 alpha-2
+	PostalCode string   `protobuf:"bytes,5,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_axiom_v1_lei_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_axiom_v1_lei_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_axiom_v1_lei_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Address) GetLines() []string {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+var File_axiom_v1_lei_proto protoreflect.FileDescriptor
+
+var file_axiom_v1_lei_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x65, 0x69, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x21, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6c, 0x65, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c,
+	0x65, 0x69, 0x22, 0x28, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x4c, 0x45,
+	0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x69, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x65, 0x69, 0x73, 0x22, 0x5e, 0x0a, 0x13,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x45, 0x49, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x22, 0x9f, 0x02, 0x0a,
+	0x10, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c, 0x45, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67,
+	0x6f, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x6f, 0x75, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x75, 0x12,
+	0x17, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65,
+	0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xad,
+	0x09, 0x0a, 0x09, 0x4c, 0x45, 0x49, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x6c, 0x65, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x65, 0x69, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a,
+	0x19, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x17, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x4c, 0x65, 0x67, 0x61, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x0b, 0x6f, 0x74, 0x68,
+	0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x52, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12,
+	0x36, 0x0a, 0x0d, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x0c, 0x6c, 0x65, 0x67, 0x61, 0x6c,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x44, 0x0a, 0x14, 0x68, 0x65, 0x61, 0x64, 0x71,
+	0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x13, 0x68, 0x65, 0x61, 0x64, 0x71, 0x75,
+	0x61, 0x72, 0x74, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x35, 0x0a,
+	0x16, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x74, 0x79, 0x12, 0x3a, 0x0a, 0x19, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x49, 0x64,
+	0x12, 0x3e, 0x0a, 0x1b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x63, 0x61, 0x74, 0x65,
+	0x67, 0x6f, 0x72, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72,
+	0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53,
+	0x75, 0x62, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x2a, 0x0a, 0x11, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x5f, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x65, 0x67,
+	0x61, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x12, 0x33, 0x0a, 0x16, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x5f, 0x6c, 0x65, 0x67, 0x61, 0x6c, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x4c, 0x65,
+	0x67, 0x61, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x5f, 0x6c, 0x6f,
+	0x75, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x69, 0x6e,
+	0x67, 0x4c, 0x6f, 0x75, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x69, 0x6e, 0x67,
+	0x5f, 0x6c, 0x6f, 0x75, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x75, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x5f, 0x6c, 0x65,
+	0x69, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x6f, 0x72, 0x4c, 0x65, 0x69, 0x12, 0x56, 0x0a, 0x19, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x17, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x44, 0x0a,
+	0x10, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x46, 0x0a, 0x11, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x65, 0x6e, 0x65,
+	0x77, 0x61, 0x6c, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x6e, 0x65, 0x78, 0x74,
+	0x52, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x4f,
+	0x0a, 0x09, 0x4f, 0x74, 0x68, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22,
+	0x86, 0x01, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x65,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61,
+	0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f,
+	0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x32, 0xd0, 0x01, 0x0a, 0x0a, 0x4c, 0x45, 0x49,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4c, 0x45,
+	0x49, 0x12, 0x17, 0x2e, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x4c, 0x45, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x61, 0x78, 0x69,
+	0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x45, 0x49, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x4a, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x4c, 0x45, 0x49, 0x12, 0x1c,
+	0x2e, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47,
+	0x65, 0x74, 0x4c, 0x45, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61,
+	0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74,
+	0x4c, 0x45, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x4c, 0x45, 0x49, 0x12, 0x1a, 0x2e, 0x61, 0x78, 0x69, 0x6f, 0x6d,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4c, 0x45, 0x49, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x45, 0x49, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x30, 0x01, 0x42, 0x34, 0x5a, 0x32, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x65, 0x63, 0x68, 0x69, 0x65,
+	0x32, 0x30, 0x30, 0x30, 0x2f, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x78, 0x69, 0x6f, 0x6d, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_axiom_v1_lei_proto_rawDescOnce sync.Once
+	file_axiom_v1_lei_proto_rawDescData = file_axiom_v1_lei_proto_rawDesc
+)
+
+func file_axiom_v1_lei_proto_rawDescGZIP() []byte {
+	file_axiom_v1_lei_proto_rawDescOnce.Do(func() {
+		file_axiom_v1_lei_proto_rawDescData = protoimpl.X.CompressGZIP(file_axiom_v1_lei_proto_rawDescData)
+	})
+	return file_axiom_v1_lei_proto_rawDescData
+}
+
+var file_axiom_v1_lei_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_axiom_v1_lei_proto_goTypes = []interface{}{
+	(*GetLEIRequest)(nil),         // 0: axiom.v1.GetLEIRequest
+	(*BatchGetLEIRequest)(nil),    // 1: axiom.v1.BatchGetLEIRequest
+	(*BatchGetLEIResponse)(nil),   // 2: axiom.v1.BatchGetLEIResponse
+	(*SearchLEIRequest)(nil),      // 3: axiom.v1.SearchLEIRequest
+	(*LEIRecord)(nil),             // 4: axiom.v1.LEIRecord
+	(*OtherName)(nil),             // 5: axiom.v1.OtherName
+	(*Address)(nil),               // 6: axiom.v1.Address
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_axiom_v1_lei_proto_depIdxs = []int32{
+	4,  // 0: axiom.v1.BatchGetLEIResponse.records:type_name -> axiom.v1.LEIRecord
+	5,  // 1: axiom.v1.LEIRecord.other_names:type_name -> axiom.v1.OtherName
+	6,  // 2: axiom.v1.LEIRecord.legal_address:type_name -> axiom.v1.Address
+	6,  // 3: axiom.v1.LEIRecord.headquarters_address:type_name -> axiom.v1.Address
+	7,  // 4: axiom.v1.LEIRecord.initial_registration_date:type_name -> google.protobuf.Timestamp
+	7,  // 5: axiom.v1.LEIRecord.last_update_date:type_name -> google.protobuf.Timestamp
+	7,  // 6: axiom.v1.LEIRecord.next_renewal_date:type_name -> google.protobuf.Timestamp
+	0,  // 7: axiom.v1.LEIService.GetLEI:input_type -> axiom.v1.GetLEIRequest
+	1,  // 8: axiom.v1.LEIService.BatchGetLEI:input_type -> axiom.v1.BatchGetLEIRequest
+	3,  // 9: axiom.v1.LEIService.SearchLEI:input_type -> axiom.v1.SearchLEIRequest
+	4,  // 10: axiom.v1.LEIService.GetLEI:output_type -> axiom.v1.LEIRecord
+	2,  // 11: axiom.v1.LEIService.BatchGetLEI:output_type -> axiom.v1.BatchGetLEIResponse
+	4,  // 12: axiom.v1.LEIService.SearchLEI:output_type -> axiom.v1.LEIRecord
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_axiom_v1_lei_proto_init() }
+func file_axiom_v1_lei_proto_init() {
+	if File_axiom_v1_lei_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_axiom_v1_lei_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLEIRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_axiom_v1_lei_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGetLEIRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_axiom_v1_lei_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchGetLEIResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_axiom_v1_lei_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchLEIRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_axiom_v1_lei_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LEIRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_axiom_v1_lei_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OtherName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_axiom_v1_lei_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_axiom_v1_lei_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_axiom_v1_lei_proto_goTypes,
+		DependencyIndexes: file_axiom_v1_lei_proto_depIdxs,
+		MessageInfos:      file_axiom_v1_lei_proto_msgTypes,
+	}.Build()
+	File_axiom_v1_lei_proto = out.File
+	file_axiom_v1_lei_proto_rawDesc = nil
+	file_axiom_v1_lei_proto_goTypes = nil
+	file_axiom_v1_lei_proto_depIdxs = nil
+}
@@ -0,0 +1,225 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: axiom/v1/lei.proto
+
+// LEI lookups for internal services that make too many of them for the REST API, where encoding
+// JSON and a request per record dominate. The records are those of GET /api/v1/lei, with the
+// codes of legal forms, registration authorities and managing LOUs resolved to names.
+
+package axiomv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	LEIService_GetLEI_FullMethodName      = "/axiom.v1.LEIService/GetLEI"
+	LEIService_BatchGetLEI_FullMethodName = "/axiom.v1.LEIService/BatchGetLEI"
+	LEIService_SearchLEI_FullMethodName   = "/axiom.v1.LEIService/SearchLEI"
+)
+
+// LEIServiceClient is the client API for LEIService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LEIServiceClient interface {
+	// GetLEI returns the record of an LEI code; NOT_FOUND if it is not held
+	GetLEI(ctx context.Context, in *GetLEIRequest, opts ...grpc.CallOption) (*LEIRecord, error)
+	// BatchGetLEI returns the records of up to 1000 LEI codes in one call; codes not held are
+	// listed in missing rather than failing the call
+	BatchGetLEI(ctx context.Context, in *BatchGetLEIRequest, opts ...grpc.CallOption) (*BatchGetLEIResponse, error)
+	// SearchLEI streams every record matching the filters, as they are read from the database;
+	// without filters, the whole dataset
+	SearchLEI(ctx context.Context, in *SearchLEIRequest, opts ...grpc.CallOption) (LEIService_SearchLEIClient, error)
+}
+
+type lEIServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLEIServiceClient(cc grpc.ClientConnInterface) LEIServiceClient {
+	return &lEIServiceClient{cc}
+}
+
+func (c *lEIServiceClient) GetLEI(ctx context.Context, in *GetLEIRequest, opts ...grpc.CallOption) (*LEIRecord, error) {
+	out := new(LEIRecord)
+	err := c.cc.Invoke(ctx, LEIService_GetLEI_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lEIServiceClient) BatchGetLEI(ctx context.Context, in *BatchGetLEIRequest, opts ...grpc.CallOption) (*BatchGetLEIResponse, error) {
+	out := new(BatchGetLEIResponse)
+	err := c.cc.Invoke(ctx, LEIService_BatchGetLEI_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lEIServiceClient) SearchLEI(ctx context.Context, in *SearchLEIRequest, opts ...grpc.CallOption) (LEIService_SearchLEIClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LEIService_ServiceDesc.Streams[0], LEIService_SearchLEI_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lEIServiceSearchLEIClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LEIService_SearchLEIClient interface {
+	Recv() (*LEIRecord, error)
+	grpc.ClientStream
+}
+
+type lEIServiceSearchLEIClient struct {
+	grpc.ClientStream
+}
+
+func (x *lEIServiceSearchLEIClient) Recv() (*LEIRecord, error) {
+	m := new(LEIRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LEIServiceServer is the server API for LEIService service.
+// All implementations must embed UnimplementedLEIServiceServer
+// for forward compatibility
+type LEIServiceServer interface {
+	// GetLEI returns the record of an LEI code; NOT_FOUND if it is not held
+	GetLEI(context.Context, *GetLEIRequest) (*LEIRecord, error)
+	// BatchGetLEI returns the records of up to 1000 LEI codes in one call; codes not held are
+	// listed in missing rather than failing the call
+	BatchGetLEI(context.Context, *BatchGetLEIRequest) (*BatchGetLEIResponse, error)
+	// SearchLEI streams every record matching the filters, as they are read from the database;
+	// without filters, the whole dataset
+	SearchLEI(*SearchLEIRequest, LEIService_SearchLEIServer) error
+	mustEmbedUnimplementedLEIServiceServer()
+}
+
+// UnimplementedLEIServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLEIServiceServer struct {
+}
+
+func (UnimplementedLEIServiceServer) GetLEI(context.Context, *GetLEIRequest) (*LEIRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLEI not implemented")
+}
+func (UnimplementedLEIServiceServer) BatchGetLEI(context.Context, *BatchGetLEIRequest) (*BatchGetLEIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetLEI not implemented")
+}
+func (UnimplementedLEIServiceServer) SearchLEI(*SearchLEIRequest, LEIService_SearchLEIServer) error {
+	return status.Errorf(codes.Unimplemented, "method SearchLEI not implemented")
+}
+func (UnimplementedLEIServiceServer) mustEmbedUnimplementedLEIServiceServer() {}
+
+// UnsafeLEIServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LEIServiceServer will
+// result in compilation errors.
+type UnsafeLEIServiceServer interface {
+	mustEmbedUnimplementedLEIServiceServer()
+}
+
+func RegisterLEIServiceServer(s grpc.ServiceRegistrar, srv LEIServiceServer) {
+	s.RegisterService(&LEIService_ServiceDesc, srv)
+}
+
+func _LEIService_GetLEI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLEIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LEIServiceServer).GetLEI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LEIService_GetLEI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LEIServiceServer).GetLEI(ctx, req.(*GetLEIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LEIService_BatchGetLEI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetLEIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LEIServiceServer).BatchGetLEI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LEIService_BatchGetLEI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LEIServiceServer).BatchGetLEI(ctx, req.(*BatchGetLEIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LEIService_SearchLEI_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchLEIRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LEIServiceServer).SearchLEI(m, &lEIServiceSearchLEIServer{stream})
+}
+
+type LEIService_SearchLEIServer interface {
+	Send(*LEIRecord) error
+	grpc.ServerStream
+}
+
+type lEIServiceSearchLEIServer struct {
+	grpc.ServerStream
+}
+
+func (x *lEIServiceSearchLEIServer) Send(m *LEIRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// LEIService_ServiceDesc is the grpc.ServiceDesc for LEIService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LEIService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "axiom.v1.LEIService",
+	HandlerType: (*LEIServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLEI",
+			Handler:    _LEIService_GetLEI_Handler,
+		},
+		{
+			MethodName: "BatchGetLEI",
+			Handler:    _LEIService_BatchGetLEI_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchLEI",
+			Handler:       _LEIService_SearchLEI_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "axiom/v1/lei.proto",
+}
@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: axiom/v1/reference.proto
+
+// Reference data lookups for internal services: countries and currencies by ISO code.
+
+package axiomv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ReferenceDataService_GetCountry_FullMethodName     = "/axiom.v1.ReferenceDataService/GetCountry"
+	ReferenceDataService_ListCountries_FullMethodName  = "/axiom.v1.ReferenceDataService/ListCountries"
+	ReferenceDataService_GetCurrency_FullMethodName    = "/axiom.v1.ReferenceDataService/GetCurrency"
+	ReferenceDataService_ListCurrencies_FullMethodName = "/axiom.v1.ReferenceDataService/ListCurrencies"
+)
+
+// ReferenceDataServiceClient is the client API for ReferenceDataService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReferenceDataServiceClient interface {
+	// GetCountry returns a country by its ISO 3166-1 alpha-2 code; NOT_FOUND if there is none
+	GetCountry(ctx context.Context, in *GetCountryRequest, opts ...grpc.CallOption) (*Country, error)
+	// ListCountries returns every country
+	ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (*ListCountriesResponse, error)
+	// GetCurrency returns a currency by its ISO 4217 code; NOT_FOUND if there is none
+	GetCurrency(ctx context.Context, in *GetCurrencyRequest, opts ...grpc.CallOption) (*Currency, error)
+	// ListCurrencies returns every currency
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+}
+
+type referenceDataServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReferenceDataServiceClient(cc grpc.ClientConnInterface) ReferenceDataServiceClient {
+	return &referenceDataServiceClient{cc}
+}
+
+func (c *referenceDataServiceClient) GetCountry(ctx context.Context, in *GetCountryRequest, opts ...grpc.CallOption) (*Country, error) {
+	out := new(Country)
+	err := c.cc.Invoke(ctx, ReferenceDataService_GetCountry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referenceDataServiceClient) ListCountries(ctx context.Context, in *ListCountriesRequest, opts ...grpc.CallOption) (*ListCountriesResponse, error) {
+	out := new(ListCountriesResponse)
+	err := c.cc.Invoke(ctx, ReferenceDataService_ListCountries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referenceDataServiceClient) GetCurrency(ctx context.Context, in *GetCurrencyRequest, opts ...grpc.CallOption) (*Currency, error) {
+	out := new(Currency)
+	err := c.cc.Invoke(ctx, ReferenceDataService_GetCurrency_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *referenceDataServiceClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, ReferenceDataService_ListCurrencies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReferenceDataServiceServer is the server API for ReferenceDataService service.
+// All implementations must embed UnimplementedReferenceDataServiceServer
+// for forward compatibility
+type ReferenceDataServiceServer interface {
+	// GetCountry returns a country by its ISO 3166-1 alpha-2 code; NOT_FOUND if there is none
+	GetCountry(context.Context, *GetCountryRequest) (*Country, error)
+	// ListCountries returns every country
+	ListCountries(context.Context, *ListCountriesRequest) (*ListCountriesResponse, error)
+	// GetCurrency returns a currency by its ISO 4217 code; NOT_FOUND if there is none
+	GetCurrency(context.Context, *GetCurrencyRequest) (*Currency, error)
+	// ListCurrencies returns every currency
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
+	mustEmbedUnimplementedReferenceDataServiceServer()
+}
+
+// UnimplementedReferenceDataServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReferenceDataServiceServer struct {
+}
+
+func (UnimplementedReferenceDataServiceServer) GetCountry(context.Context, *GetCountryRequest) (*Country, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCountry not implemented")
+}
+func (UnimplementedReferenceDataServiceServer) ListCountries(context.Context, *ListCountriesRequest) (*ListCountriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCountries not implemented")
+}
+func (UnimplementedReferenceDataServiceServer) GetCurrency(context.Context, *GetCurrencyRequest) (*Currency, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrency not implemented")
+}
+func (UnimplementedReferenceDataServiceServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedReferenceDataServiceServer) mustEmbedUnimplementedReferenceDataServiceServer() {}
+
+// UnsafeReferenceDataServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReferenceDataServiceServer will
+// result in compilation errors.
+type UnsafeReferenceDataServiceServer interface {
+	mustEmbedUnimplementedReferenceDataServiceServer()
+}
+
+func RegisterReferenceDataServiceServer(s grpc.ServiceRegistrar, srv ReferenceDataServiceServer) {
+	s.RegisterService(&ReferenceDataService_ServiceDesc, srv)
+}
+
+func _ReferenceDataService_GetCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferenceDataServiceServer).GetCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferenceDataService_GetCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferenceDataServiceServer).GetCountry(ctx, req.(*GetCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferenceDataService_ListCountries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCountriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferenceDataServiceServer).ListCountries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferenceDataService_ListCountries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferenceDataServiceServer).ListCountries(ctx, req.(*ListCountriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferenceDataService_GetCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferenceDataServiceServer).GetCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferenceDataService_GetCurrency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferenceDataServiceServer).GetCurrency(ctx, req.(*GetCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReferenceDataService_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReferenceDataServiceServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReferenceDataService_ListCurrencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReferenceDataServiceServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReferenceDataService_ServiceDesc is the grpc.ServiceDesc for ReferenceDataService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReferenceDataService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "axiom.v1.ReferenceDataService",
+	HandlerType: (*ReferenceDataServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCountry",
+			Handler:    _ReferenceDataService_GetCountry_Handler,
+		},
+		{
+			MethodName: "ListCountries",
+			Handler:    _ReferenceDataService_ListCountries_Handler,
+		},
+		{
+			MethodName: "GetCurrency",
+			Handler:    _ReferenceDataService_GetCurrency_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _ReferenceDataService_ListCurrencies_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "axiom/v1/reference.proto",
+}
@@ -2,6 +2,7 @@
 
 import { useEffect, useState } from 'react'
 import Link from 'next/link'
+import { applyLEIStatusMessage, useLEIStatusStream } from './useLEIStatusStream'
 
 interface LEIStatus {
   status: string
@@ -10,21 +11,29 @@ interface LEIStatus {
   processed_records?: number
   failed_records?: number
   error_message?: string
+  current_source_file_id?: string | null
   current_source_file?: {
     total_records?: number
-  }
+    processed_records?: number
+  } | null
 }
 
 export default function LEIStatusCard() {
   const [fullStatus, setFullStatus] = useState<LEIStatus | null>(null)
   const [deltaStatus, setDeltaStatus] = useState<LEIStatus | null>(null)
   const [loading, setLoading] = useState(true)
+  const API_URL = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080'
+
+  // Status transitions and processing progress are pushed over the websocket
+  useLEIStatusStream<LEIStatus>(API_URL, (message) => {
+    setFullStatus((prev) => applyLEIStatusMessage(prev, 'DAILY_FULL', message))
+    setDeltaStatus((prev) => applyLEIStatusMessage(prev, 'DAILY_DELTA', message))
+    setLoading(false)
+  })
 
   useEffect(() => {
     const fetchStatus = async () => {
       try {
-        const API_URL = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080'
-        
         const [fullRes, deltaRes] = await Promise.all([
           fetch(`${API_URL}/api/v1/lei/status/DAILY_FULL`, { cache: 'no-store' }),
           fetch(`${API_URL}/api/v1/lei/status/DAILY_DELTA`, { cache: 'no-store' })
@@ -46,10 +55,7 @@ export default function LEIStatusCard() {
     }
 
     fetchStatus()
-    const interval = setInterval(fetchStatus, 5000) // Refresh every 5 seconds
-
-    return () => clearInterval(interval)
-  }, [])
+  }, [API_URL])
 
   const getHealthIndicator = (status: LEIStatus | null) => {
     if (!status) return { color: 'bg-gray-400', label: 'Unknown', icon: '❓' }
@@ -74,8 +80,9 @@ export default function LEIStatusCard() {
   }
 
   const getProgress = (status: LEIStatus | null) => {
-    if (!status?.total_records || !status?.processed_records) return 0
-    return Math.min(100, (status.processed_records / status.total_records) * 100)
+    const file = status?.current_source_file
+    if (!file?.total_records || !file?.processed_records) return 0
+    return Math.min(100, (file.processed_records / file.total_records) * 100)
   }
 
   const getOverallStatus = () => {
@@ -127,7 +134,7 @@ export default function LEIStatusCard() {
                 <span className="font-semibold text-gray-900 dark:text-white">{formatNumber(totalRecords)}</span>
               </div>
               
-              {fullStatus?.status === 'RUNNING' && fullStatus.total_records && (
+              {fullStatus?.status === 'RUNNING' && !!fullStatus.current_source_file?.total_records && (
                 <div className="space-y-1">
                   <div className="flex justify-between text-xs text-gray-600 dark:text-gray-400">
                     <span>Processing Full Sync</span>
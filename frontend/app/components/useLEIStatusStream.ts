@@ -0,0 +1,97 @@
+'use client'
+
+import { useEffect, useRef } from 'react'
+
+// Progress of a batch checkpointed while a source file is processed (batch_flushed events)
+export interface LEIBatchProgress {
+  source_file_id: string
+  batch: number
+  records: number
+  total_records: number
+  processed_records: number
+  failed_records: number
+  last_lei: string
+}
+
+// Messages sent by GET /api/v1/ws
+export type LEIStatusMessage<T> =
+  | { type: 'snapshot'; statuses: T[] }
+  | { type: 'processing_status'; processing_status: T }
+  | { type: 'batch_flushed'; batch: LEIBatchProgress }
+
+interface StreamedStatus {
+  job_type: string
+  current_source_file_id?: string | null
+  current_source_file?: {
+    total_records?: number
+    processed_records?: number
+    failed_records?: number
+    last_processed_lei?: string
+  } | null
+}
+
+// applyLEIStatusMessage returns the status of one job type after a stream message
+export function applyLEIStatusMessage<T extends StreamedStatus>(status: T | null, jobType: string, message: LEIStatusMessage<T>): T | null {
+  switch (message.type) {
+    case 'snapshot':
+      return message.statuses.find((s) => s.job_type === jobType) ?? status
+    case 'processing_status':
+      return message.processing_status.job_type === jobType ? message.processing_status : status
+    case 'batch_flushed': {
+      const batch = message.batch
+      if (!status || status.current_source_file_id !== batch.source_file_id) return status
+      return {
+        ...status,
+        current_source_file: {
+          ...status.current_source_file,
+          total_records: batch.total_records,
+          processed_records: batch.processed_records,
+          failed_records: batch.failed_records,
+          last_processed_lei: batch.last_lei,
+        },
+      }
+    }
+    default:
+      return status
+  }
+}
+
+// useLEIStatusStream delivers LEI sync status messages from the backend websocket while enabled,
+// reconnecting after a dropped connection (each connection starts with a fresh snapshot)
+export function useLEIStatusStream<T extends StreamedStatus>(
+  apiUrl: string,
+  onMessage: (message: LEIStatusMessage<T>) => void,
+  enabled = true,
+) {
+  const handler = useRef(onMessage)
+  handler.current = onMessage
+
+  useEffect(() => {
+    if (!enabled || typeof window === 'undefined') return
+
+    let socket: WebSocket | null = null
+    let retry: ReturnType<typeof setTimeout> | undefined
+    let stopped = false
+
+    const connect = () => {
+      socket = new WebSocket(`${apiUrl.replace(/^http/, 'ws')}/api/v1/ws`)
+      socket.onmessage = (event) => {
+        try {
+          handler.current(JSON.parse(event.data))
+        } catch (error) {
+          console.error('Invalid LEI status message:', error)
+        }
+      }
+      socket.onclose = () => {
+        if (!stopped) retry = setTimeout(connect, 5000) // Reconnect after 5 seconds
+      }
+    }
+
+    connect()
+    return () => {
+      stopped = true
+      clearTimeout(retry)
+      socket?.close()
+    }
+  }, [apiUrl, enabled])
+}
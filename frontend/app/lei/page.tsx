@@ -3,6 +3,7 @@
 import { useEffect, useState } from 'react'
 import Link from 'next/link'
 import ThemeToggle from '../components/ThemeToggle'
+import { applyLEIStatusMessage, useLEIStatusStream } from '../components/useLEIStatusStream'
 
 interface SourceFile {
   id: string
@@ -87,12 +88,13 @@ export default function LEIStatusPage() {
     }
   }, [])
 
-  useEffect(() => {
-    if (!autoRefresh) return
-
-    const interval = setInterval(fetchStatus, 5000) // Refresh every 5 seconds
-    return () => clearInterval(interval)
-  }, [autoRefresh])
+  // Live updates: status transitions and batch progress are pushed over the websocket
+  useLEIStatusStream<ProcessingStatus>(API_BASE_URL, (message) => {
+    setFullStatus((prev) => applyLEIStatusMessage(prev, 'DAILY_FULL', message))
+    setDeltaStatus((prev) => applyLEIStatusMessage(prev, 'DAILY_DELTA', message))
+    setLoading(false)
+    setError(null)
+  }, autoRefresh)
 
   const formatDate = (dateString: string | null) => {
     if (!dateString || dateString.startsWith('0001-')) return 'Never'
@@ -307,7 +309,7 @@ export default function LEIStatusPage() {
                 onChange={(e) => setAutoRefresh(e.target.checked)}
                 className="w-4 h-4"
               />
-              <span className="text-sm opacity-70">Auto-refresh (5s)</span>
+              <span className="text-sm opacity-70">Live updates</span>
             </label>
           </div>
         </div>